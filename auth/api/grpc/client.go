@@ -10,6 +10,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/go-kit/kit/endpoint"
@@ -37,11 +38,17 @@ type AuthServiceClient interface {
 }
 
 type authGrpcClient struct {
-	issue     endpoint.Endpoint
-	refresh   endpoint.Endpoint
-	identify  endpoint.Endpoint
-	authorize endpoint.Endpoint
-	timeout   time.Duration
+	issue         endpoint.Endpoint
+	refresh       endpoint.Endpoint
+	identify      endpoint.Endpoint
+	revokeTokens  endpoint.Endpoint
+	applyMappings endpoint.Endpoint
+	addMember     endpoint.Endpoint
+	checkQuota    endpoint.Endpoint
+	reserveQuota  endpoint.Endpoint
+	releaseQuota  endpoint.Endpoint
+	authorize     endpoint.Endpoint
+	timeout       time.Duration
 }
 
 // NewAuthClient returns new auth gRPC client instance.
@@ -71,6 +78,54 @@ func NewAuthClient(conn *grpc.ClientConn, timeout time.Duration) AuthServiceClie
 			decodeIdentifyResponse,
 			magistrala.IdentityRes{},
 		).Endpoint(),
+		revokeTokens: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"RevokeTokens",
+			encodeRevokeTokensRequest,
+			decodeRevokeTokensResponse,
+			magistrala.RevokeTokensRes{},
+		).Endpoint(),
+		applyMappings: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"ApplyClaimMappings",
+			encodeApplyClaimMappingsRequest,
+			decodeApplyClaimMappingsResponse,
+			magistrala.ApplyClaimMappingsRes{},
+		).Endpoint(),
+		addMember: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"AddDomainMember",
+			encodeAddDomainMemberRequest,
+			decodeAddDomainMemberResponse,
+			magistrala.AddDomainMemberRes{},
+		).Endpoint(),
+		checkQuota: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"CheckQuota",
+			encodeCheckQuotaRequest,
+			decodeCheckQuotaResponse,
+			magistrala.CheckQuotaRes{},
+		).Endpoint(),
+		reserveQuota: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"ReserveQuota",
+			encodeReserveQuotaRequest,
+			decodeReserveQuotaResponse,
+			magistrala.ReserveQuotaRes{},
+		).Endpoint(),
+		releaseQuota: kitgrpc.NewClient(
+			conn,
+			authnSvcName,
+			"ReleaseQuota",
+			encodeReleaseQuotaRequest,
+			decodeReleaseQuotaResponse,
+			magistrala.ReleaseQuotaRes{},
+		).Endpoint(),
 		authorize: kitgrpc.NewClient(
 			conn,
 			authzSvcName,
@@ -91,6 +146,8 @@ func (client authGrpcClient) Issue(ctx context.Context, req *magistrala.IssueReq
 		userID:   req.GetUserId(),
 		domainID: req.GetDomainId(),
 		keyType:  auth.KeyType(req.GetType()),
+		mfa:      req.GetMfa(),
+		token:    req.GetToken(),
 	})
 	if err != nil {
 		return &magistrala.Token{}, decodeError(err)
@@ -104,6 +161,8 @@ func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, er
 		UserId:   req.userID,
 		DomainId: &req.domainID,
 		Type:     uint32(req.keyType),
+		Mfa:      req.mfa,
+		Token:    req.token,
 	}, nil
 }
 
@@ -140,7 +199,7 @@ func (client authGrpcClient) Identify(ctx context.Context, token *magistrala.Ide
 		return &magistrala.IdentityRes{}, decodeError(err)
 	}
 	ir := res.(identityRes)
-	return &magistrala.IdentityRes{Id: ir.id, UserId: ir.userID, DomainId: ir.domainID}, nil
+	return &magistrala.IdentityRes{Id: ir.id, UserId: ir.userID, DomainId: ir.domainID, Type: ir.keyType}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -150,13 +209,151 @@ func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*magistrala.IdentityRes)
-	return identityRes{id: res.GetId(), userID: res.GetUserId(), domainID: res.GetDomainId()}, nil
+	return identityRes{id: res.GetId(), userID: res.GetUserId(), domainID: res.GetDomainId(), keyType: res.GetType()}, nil
+}
+
+func (client authGrpcClient) RevokeTokens(ctx context.Context, req *magistrala.RevokeTokensReq, _ ...grpc.CallOption) (*magistrala.RevokeTokensRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.revokeTokens(ctx, revokeTokensReq{userID: req.GetUserId()})
+	if err != nil {
+		return &magistrala.RevokeTokensRes{}, decodeError(err)
+	}
+	rr := res.(revokeTokensRes)
+	return &magistrala.RevokeTokensRes{Revoked: rr.revoked}, nil
+}
+
+func encodeRevokeTokensRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(revokeTokensReq)
+	return &magistrala.RevokeTokensReq{UserId: req.userID}, nil
+}
+
+func decodeRevokeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.RevokeTokensRes)
+	return revokeTokensRes{revoked: res.GetRevoked()}, nil
+}
+
+func (client authGrpcClient) ApplyClaimMappings(ctx context.Context, req *magistrala.ApplyClaimMappingsReq, _ ...grpc.CallOption) (*magistrala.ApplyClaimMappingsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.applyMappings(ctx, applyClaimMappingsReq{userID: req.GetUserId(), provider: req.GetProvider(), groups: req.GetGroups()})
+	if err != nil {
+		return &magistrala.ApplyClaimMappingsRes{}, decodeError(err)
+	}
+	ar := res.(applyClaimMappingsRes)
+	return &magistrala.ApplyClaimMappingsRes{Applied: ar.applied}, nil
+}
+
+func encodeApplyClaimMappingsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(applyClaimMappingsReq)
+	return &magistrala.ApplyClaimMappingsReq{UserId: req.userID, Provider: req.provider, Groups: req.groups}, nil
+}
+
+func decodeApplyClaimMappingsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ApplyClaimMappingsRes)
+	return applyClaimMappingsRes{applied: res.GetApplied()}, nil
+}
+
+func (client authGrpcClient) AddDomainMember(ctx context.Context, req *magistrala.AddDomainMemberReq, _ ...grpc.CallOption) (*magistrala.AddDomainMemberRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.addMember(ctx, addDomainMemberReq{domainID: req.GetDomainId(), userID: req.GetUserId(), relation: req.GetRelation()})
+	if err != nil {
+		return &magistrala.AddDomainMemberRes{}, decodeError(err)
+	}
+	ar := res.(addDomainMemberRes)
+	return &magistrala.AddDomainMemberRes{Added: ar.added}, nil
+}
+
+func encodeAddDomainMemberRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(addDomainMemberReq)
+	return &magistrala.AddDomainMemberReq{DomainId: req.domainID, UserId: req.userID, Relation: req.relation}, nil
+}
+
+func decodeAddDomainMemberResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.AddDomainMemberRes)
+	return addDomainMemberRes{added: res.GetAdded()}, nil
+}
+
+func (client authGrpcClient) CheckQuota(ctx context.Context, req *magistrala.CheckQuotaReq, _ ...grpc.CallOption) (*magistrala.CheckQuotaRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.checkQuota(ctx, checkQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), count: req.GetCount()})
+	if err != nil {
+		return &magistrala.CheckQuotaRes{}, decodeError(err)
+	}
+	cr := res.(checkQuotaRes)
+	return &magistrala.CheckQuotaRes{Allowed: cr.allowed, Limited: cr.limited}, nil
+}
+
+func encodeCheckQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(checkQuotaReq)
+	return &magistrala.CheckQuotaReq{DomainId: req.domainID, Resource: req.resource, Count: req.count}, nil
+}
+
+func decodeCheckQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.CheckQuotaRes)
+	return checkQuotaRes{allowed: res.GetAllowed(), limited: res.GetLimited()}, nil
+}
+
+func (client authGrpcClient) ReserveQuota(ctx context.Context, req *magistrala.ReserveQuotaReq, _ ...grpc.CallOption) (*magistrala.ReserveQuotaRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.reserveQuota(ctx, reserveQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), ids: req.GetIds(), existing: req.GetExisting()})
+	if err != nil {
+		return &magistrala.ReserveQuotaRes{}, decodeError(err)
+	}
+	rr := res.(reserveQuotaRes)
+	return &magistrala.ReserveQuotaRes{ReservedIds: rr.reservedIDs}, nil
+}
+
+func encodeReserveQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(reserveQuotaReq)
+	return &magistrala.ReserveQuotaReq{DomainId: req.domainID, Resource: req.resource, Ids: req.ids, Existing: req.existing}, nil
+}
+
+func decodeReserveQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ReserveQuotaRes)
+	return reserveQuotaRes{reservedIDs: res.GetReservedIds()}, nil
+}
+
+func (client authGrpcClient) ReleaseQuota(ctx context.Context, req *magistrala.ReleaseQuotaReq, _ ...grpc.CallOption) (*magistrala.ReleaseQuotaRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.releaseQuota(ctx, releaseQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), ids: req.GetIds()})
+	if err != nil {
+		return &magistrala.ReleaseQuotaRes{}, decodeError(err)
+	}
+	rr := res.(releaseQuotaRes)
+	return &magistrala.ReleaseQuotaRes{Released: rr.released}, nil
+}
+
+func encodeReleaseQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(releaseQuotaReq)
+	return &magistrala.ReleaseQuotaReq{DomainId: req.domainID, Resource: req.resource, Ids: req.ids}, nil
+}
+
+func decodeReleaseQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ReleaseQuotaRes)
+	return releaseQuotaRes{released: res.GetReleased()}, nil
 }
 
 func (client authGrpcClient) Authorize(ctx context.Context, req *magistrala.AuthorizeReq, _ ...grpc.CallOption) (r *magistrala.AuthorizeRes, err error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
 
+	// The IP address of the request the client serves is used if the
+	// caller doesn't set it explicitly.
+	sourceIP := req.GetSourceIp()
+	if sourceIP == "" {
+		sourceIP = apiutil.SourceIP(ctx)
+	}
 	res, err := client.authorize(ctx, authReq{
 		Domain:      req.GetDomain(),
 		SubjectType: req.GetSubjectType(),
@@ -166,6 +363,7 @@ func (client authGrpcClient) Authorize(ctx context.Context, req *magistrala.Auth
 		Permission:  req.GetPermission(),
 		ObjectType:  req.GetObjectType(),
 		Object:      req.GetObject(),
+		SourceIP:    sourceIP,
 	})
 	if err != nil {
 		return &magistrala.AuthorizeRes{}, decodeError(err)
@@ -191,6 +389,7 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		Permission:  req.Permission,
 		ObjectType:  req.ObjectType,
 		Object:      req.Object,
+		SourceIp:    req.SourceIP,
 	}, nil
 }
 
@@ -319,15 +518,17 @@ func (client policyGrpcClient) AddPolicy(ctx context.Context, in *magistrala.Add
 	defer cancel()
 
 	res, err := client.addPolicy(ctx, policyReq{
-		Domain:      in.GetDomain(),
-		SubjectType: in.GetSubjectType(),
-		SubjectKind: in.GetSubjectKind(),
-		Subject:     in.GetSubject(),
-		Relation:    in.GetRelation(),
-		Permission:  in.GetPermission(),
-		ObjectType:  in.GetObjectType(),
-		ObjectKind:  in.GetObjectKind(),
-		Object:      in.GetObject(),
+		Domain:       in.GetDomain(),
+		SubjectType:  in.GetSubjectType(),
+		SubjectKind:  in.GetSubjectKind(),
+		Subject:      in.GetSubject(),
+		Relation:     in.GetRelation(),
+		Permission:   in.GetPermission(),
+		ObjectType:   in.GetObjectType(),
+		ObjectKind:   in.GetObjectKind(),
+		Object:       in.GetObject(),
+		ExpiresAt:    in.GetExpiresAt(),
+		AllowedCIDRs: in.GetAllowedCidrs(),
 	})
 	if err != nil {
 		return &magistrala.AddPolicyRes{}, decodeError(err)
@@ -345,15 +546,17 @@ func decodeAddPolicyResponse(_ context.Context, grpcRes interface{}) (interface{
 func encodeAddPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(policyReq)
 	return &magistrala.AddPolicyReq{
-		Domain:      req.Domain,
-		SubjectType: req.SubjectType,
-		SubjectKind: req.SubjectKind,
-		Subject:     req.Subject,
-		Relation:    req.Relation,
-		Permission:  req.Permission,
-		ObjectType:  req.ObjectType,
-		ObjectKind:  req.ObjectKind,
-		Object:      req.Object,
+		Domain:       req.Domain,
+		SubjectType:  req.SubjectType,
+		SubjectKind:  req.SubjectKind,
+		Subject:      req.Subject,
+		Relation:     req.Relation,
+		Permission:   req.Permission,
+		ObjectType:   req.ObjectType,
+		ObjectKind:   req.ObjectKind,
+		Object:       req.Object,
+		ExpiresAt:    req.ExpiresAt,
+		AllowedCidrs: req.AllowedCIDRs,
 	}, nil
 }
 
@@ -364,15 +567,17 @@ func (client policyGrpcClient) AddPolicies(ctx context.Context, in *magistrala.A
 	if in.GetAddPoliciesReq() != nil {
 		for _, mgApr := range in.GetAddPoliciesReq() {
 			r = append(r, policyReq{
-				Domain:      mgApr.GetDomain(),
-				SubjectType: mgApr.GetSubjectType(),
-				SubjectKind: mgApr.GetSubjectKind(),
-				Subject:     mgApr.GetSubject(),
-				Relation:    mgApr.GetRelation(),
-				Permission:  mgApr.GetPermission(),
-				ObjectType:  mgApr.GetObjectType(),
-				ObjectKind:  mgApr.GetObjectKind(),
-				Object:      mgApr.GetObject(),
+				Domain:       mgApr.GetDomain(),
+				SubjectType:  mgApr.GetSubjectType(),
+				SubjectKind:  mgApr.GetSubjectKind(),
+				Subject:      mgApr.GetSubject(),
+				Relation:     mgApr.GetRelation(),
+				Permission:   mgApr.GetPermission(),
+				ObjectType:   mgApr.GetObjectType(),
+				ObjectKind:   mgApr.GetObjectKind(),
+				Object:       mgApr.GetObject(),
+				ExpiresAt:    mgApr.GetExpiresAt(),
+				AllowedCIDRs: mgApr.GetAllowedCidrs(),
 			})
 		}
 	}
@@ -398,15 +603,17 @@ func encodeAddPoliciesRequest(_ context.Context, grpcReq interface{}) (interface
 
 	for _, req := range reqs {
 		addPolicies = append(addPolicies, &magistrala.AddPolicyReq{
-			Domain:      req.Domain,
-			SubjectType: req.SubjectType,
-			SubjectKind: req.SubjectKind,
-			Subject:     req.Subject,
-			Relation:    req.Relation,
-			Permission:  req.Permission,
-			ObjectType:  req.ObjectType,
-			ObjectKind:  req.ObjectKind,
-			Object:      req.Object,
+			Domain:       req.Domain,
+			SubjectType:  req.SubjectType,
+			SubjectKind:  req.SubjectKind,
+			Subject:      req.Subject,
+			Relation:     req.Relation,
+			Permission:   req.Permission,
+			ObjectType:   req.ObjectType,
+			ObjectKind:   req.ObjectKind,
+			Object:       req.Object,
+			ExpiresAt:    req.ExpiresAt,
+			AllowedCidrs: req.AllowedCIDRs,
 		})
 	}
 	return &magistrala.AddPoliciesReq{AddPoliciesReq: addPolicies}, nil
@@ -517,6 +724,7 @@ func (client policyGrpcClient) ListObjects(ctx context.Context, in *magistrala.L
 	res, err := client.listObjects(ctx, listObjectsReq{
 		Domain:      in.GetDomain(),
 		SubjectType: in.GetSubjectType(),
+		SubjectKind: in.GetSubjectKind(),
 		Subject:     in.GetSubject(),
 		Relation:    in.GetRelation(),
 		Permission:  in.GetPermission(),
@@ -541,6 +749,7 @@ func encodeListObjectsRequest(_ context.Context, grpcReq interface{}) (interface
 	return &magistrala.ListObjectsReq{
 		Domain:      req.Domain,
 		SubjectType: req.SubjectType,
+		SubjectKind: req.SubjectKind,
 		Subject:     req.Subject,
 		Relation:    req.Relation,
 		Permission:  req.Permission,
@@ -556,6 +765,7 @@ func (client policyGrpcClient) ListAllObjects(ctx context.Context, in *magistral
 	res, err := client.listAllObjects(ctx, listObjectsReq{
 		Domain:      in.GetDomain(),
 		SubjectType: in.GetSubjectType(),
+		SubjectKind: in.GetSubjectKind(),
 		Subject:     in.GetSubject(),
 		Relation:    in.GetRelation(),
 		Permission:  in.GetPermission(),
@@ -812,6 +1022,8 @@ func decodeError(err error) error {
 			return errors.Wrap(errors.ErrMalformedEntity, errors.New(st.Message()))
 		case codes.PermissionDenied:
 			return errors.Wrap(svcerr.ErrAuthorization, errors.New(st.Message()))
+		case codes.ResourceExhausted:
+			return errors.Wrap(svcerr.ErrQuotaExceeded, errors.New(st.Message()))
 		default:
 			return errors.Wrap(fmt.Errorf("unexpected gRPC status: %s (status code:%v)", st.Code().String(), st.Code()), errors.New(st.Message()))
 		}
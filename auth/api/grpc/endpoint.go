@@ -21,8 +21,9 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 			Type:   req.keyType,
 			User:   req.userID,
 			Domain: req.domainID,
+			MFA:    req.mfa,
 		}
-		tkn, err := svc.Issue(ctx, "", key)
+		tkn, err := svc.Issue(ctx, req.token, key)
 		if err != nil {
 			return issueRes{}, err
 		}
@@ -68,7 +69,99 @@ func identifyEndpoint(svc auth.Service) endpoint.Endpoint {
 			return identityRes{}, err
 		}
 
-		return identityRes{id: key.Subject, userID: key.User, domainID: key.Domain}, nil
+		return identityRes{id: key.Subject, userID: key.User, domainID: key.Domain, keyType: uint32(key.Type)}, nil
+	}
+}
+
+func revokeTokensEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeTokensReq)
+		if err := req.validate(); err != nil {
+			return revokeTokensRes{}, err
+		}
+
+		if err := svc.RevokeTokens(ctx, req.userID); err != nil {
+			return revokeTokensRes{}, err
+		}
+
+		return revokeTokensRes{revoked: true}, nil
+	}
+}
+
+func applyClaimMappingsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(applyClaimMappingsReq)
+		if err := req.validate(); err != nil {
+			return applyClaimMappingsRes{}, err
+		}
+
+		if err := svc.ApplyClaimMappings(ctx, req.userID, req.provider, req.groups); err != nil {
+			return applyClaimMappingsRes{}, err
+		}
+
+		return applyClaimMappingsRes{applied: true}, nil
+	}
+}
+
+func addDomainMemberEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(addDomainMemberReq)
+		if err := req.validate(); err != nil {
+			return addDomainMemberRes{}, err
+		}
+
+		if err := svc.AddDomainMember(ctx, req.domainID, req.userID, req.relation); err != nil {
+			return addDomainMemberRes{}, err
+		}
+
+		return addDomainMemberRes{added: true}, nil
+	}
+}
+
+func checkQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(checkQuotaReq)
+		if err := req.validate(); err != nil {
+			return checkQuotaRes{}, err
+		}
+
+		limited, err := svc.CheckQuota(ctx, req.domainID, req.resource, req.count)
+		if err != nil {
+			return checkQuotaRes{}, err
+		}
+
+		return checkQuotaRes{allowed: true, limited: limited}, nil
+	}
+}
+
+func reserveQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reserveQuotaReq)
+		if err := req.validate(); err != nil {
+			return reserveQuotaRes{}, err
+		}
+
+		reserved, err := svc.ReserveQuota(ctx, req.domainID, req.resource, req.existing, req.ids...)
+		if err != nil {
+			return reserveQuotaRes{}, err
+		}
+
+		return reserveQuotaRes{reservedIDs: reserved}, nil
+	}
+}
+
+func releaseQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(releaseQuotaReq)
+		if err := req.validate(); err != nil {
+			return releaseQuotaRes{}, err
+		}
+
+		if err := svc.ReleaseQuota(ctx, req.domainID, req.resource, req.ids...); err != nil {
+			return releaseQuotaRes{}, err
+		}
+
+		return releaseQuotaRes{released: true}, nil
 	}
 }
 
@@ -88,6 +181,7 @@ func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
 			Permission:  req.Permission,
 			ObjectType:  req.ObjectType,
 			Object:      req.Object,
+			SourceIP:    req.SourceIP,
 		})
 		if err != nil {
 			return authorizeRes{authorized: false}, err
@@ -113,6 +207,7 @@ func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
 			ObjectType:  req.ObjectType,
 			ObjectKind:  req.ObjectKind,
 			Object:      req.Object,
+			Condition:   req.condition(),
 		})
 		if err != nil {
 			return addPolicyRes{}, err
@@ -141,6 +236,7 @@ func addPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
 				ObjectType:  req.ObjectType,
 				ObjectKind:  req.ObjectKind,
 				Object:      req.Object,
+				Condition:   req.condition(),
 			})
 		}
 
@@ -213,6 +309,7 @@ func listObjectsEndpoint(svc auth.Service) endpoint.Endpoint {
 		page, err := svc.ListObjects(ctx, auth.PolicyReq{
 			Domain:      req.Domain,
 			SubjectType: req.SubjectType,
+			SubjectKind: req.SubjectKind,
 			Subject:     req.Subject,
 			Relation:    req.Relation,
 			Permission:  req.Permission,
@@ -233,6 +330,7 @@ func listAllObjectsEndpoint(svc auth.Service) endpoint.Endpoint {
 		page, err := svc.ListAllObjects(ctx, auth.PolicyReq{
 			Domain:      req.Domain,
 			SubjectType: req.SubjectType,
+			SubjectKind: req.SubjectKind,
 			Subject:     req.Subject,
 			Relation:    req.Relation,
 			Permission:  req.Permission,
@@ -74,6 +74,7 @@ func TestIssue(t *testing.T) {
 		userId        string
 		domainID      string
 		kind          auth.KeyType
+		token         string
 		issueResponse auth.Token
 		err           error
 	}{
@@ -99,11 +100,31 @@ func TestIssue(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:     "issue API key of service account",
+			userId:   validID,
+			domainID: domainID,
+			kind:     auth.APIKey,
+			token:    validToken,
+			issueResponse: auth.Token{
+				AccessToken: validToken,
+			},
+			err: nil,
+		},
+		{
+			desc:          "issue API key without token",
+			userId:        validID,
+			domainID:      domainID,
+			kind:          auth.APIKey,
+			issueResponse: auth.Token{},
+			err:           apiutil.ErrBearerToken,
+		},
 		{
 			desc:          "issue API key unauthenticated",
 			userId:        validID,
 			domainID:      domainID,
 			kind:          auth.APIKey,
+			token:         inValidToken,
 			issueResponse: auth.Token{},
 			err:           svcerr.ErrAuthentication,
 		},
@@ -126,8 +147,8 @@ func TestIssue(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		svcCall := svc.On("Issue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.issueResponse, tc.err)
-		_, err := client.Issue(context.Background(), &magistrala.IssueReq{UserId: tc.userId, DomainId: &tc.domainID, Type: uint32(tc.kind)})
+		svcCall := svc.On("Issue", mock.Anything, tc.token, mock.Anything).Return(tc.issueResponse, tc.err)
+		_, err := client.Issue(context.Background(), &magistrala.IssueReq{UserId: tc.userId, DomainId: &tc.domainID, Type: uint32(tc.kind), Token: tc.token})
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		svcCall.Unset()
 	}
@@ -185,11 +206,12 @@ func TestIdentify(t *testing.T) {
 	client := grpcapi.NewAuthClient(conn, time.Second)
 
 	cases := []struct {
-		desc   string
-		token  string
-		idt    *magistrala.IdentityRes
-		svcErr error
-		err    error
+		desc    string
+		token   string
+		keyType auth.KeyType
+		idt     *magistrala.IdentityRes
+		svcErr  error
+		err     error
 	}{
 		{
 			desc:  "identify user with valid user token",
@@ -197,6 +219,13 @@ func TestIdentify(t *testing.T) {
 			idt:   &magistrala.IdentityRes{Id: id, UserId: email, DomainId: domainID},
 			err:   nil,
 		},
+		{
+			desc:    "identify user with valid invitation token",
+			token:   validToken,
+			keyType: auth.InvitationKey,
+			idt:     &magistrala.IdentityRes{Id: id, UserId: email, DomainId: domainID, Type: uint32(auth.InvitationKey)},
+			err:     nil,
+		},
 		{
 			desc:   "identify user with invalid user token",
 			token:  "invalid",
@@ -213,7 +242,7 @@ func TestIdentify(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		svcCall := svc.On("Identify", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{Subject: id, User: email, Domain: domainID}, tc.svcErr)
+		svcCall := svc.On("Identify", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{Subject: id, User: email, Domain: domainID, Type: tc.keyType}, tc.svcErr)
 		idt, err := client.Identify(context.Background(), &magistrala.IdentityReq{Token: tc.token})
 		if idt != nil {
 			assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, idt))
@@ -223,6 +252,352 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestRevokeTokens(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		userID string
+		res    *magistrala.RevokeTokensRes
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "revoke user tokens",
+			userID: id,
+			res:    &magistrala.RevokeTokensRes{Revoked: true},
+			err:    nil,
+		},
+		{
+			desc:   "revoke user tokens with empty user ID",
+			userID: "",
+			res:    &magistrala.RevokeTokensRes{},
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc:   "revoke user tokens with failed to revoke",
+			userID: id,
+			res:    &magistrala.RevokeTokensRes{},
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RevokeTokens", mock.Anything, tc.userID).Return(tc.svcErr)
+		res, err := client.RevokeTokens(context.Background(), &magistrala.RevokeTokensReq{UserId: tc.userID})
+		if res != nil {
+			assert.Equal(t, tc.res.GetRevoked(), res.GetRevoked(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetRevoked(), res.GetRevoked()))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestApplyClaimMappings(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		userID   string
+		provider string
+		groups   []string
+		res      *magistrala.ApplyClaimMappingsRes
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "apply claim mappings",
+			userID:   id,
+			provider: "oidc",
+			groups:   []string{"iot-ops"},
+			res:      &magistrala.ApplyClaimMappingsRes{Applied: true},
+			err:      nil,
+		},
+		{
+			desc:     "apply claim mappings with empty user ID",
+			userID:   "",
+			provider: "oidc",
+			groups:   []string{"iot-ops"},
+			res:      &magistrala.ApplyClaimMappingsRes{},
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "apply claim mappings with empty provider",
+			userID:   id,
+			provider: "",
+			groups:   []string{"iot-ops"},
+			res:      &magistrala.ApplyClaimMappingsRes{},
+			err:      apiutil.ErrMissingProvider,
+		},
+		{
+			desc:     "apply claim mappings with failed to apply",
+			userID:   id,
+			provider: "oidc",
+			groups:   []string{"iot-ops"},
+			res:      &magistrala.ApplyClaimMappingsRes{},
+			svcErr:   svcerr.ErrNotFound,
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ApplyClaimMappings", mock.Anything, tc.userID, tc.provider, tc.groups).Return(tc.svcErr)
+		res, err := client.ApplyClaimMappings(context.Background(), &magistrala.ApplyClaimMappingsReq{UserId: tc.userID, Provider: tc.provider, Groups: tc.groups})
+		if res != nil {
+			assert.Equal(t, tc.res.GetApplied(), res.GetApplied(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetApplied(), res.GetApplied()))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestAddDomainMember(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		domainID string
+		userID   string
+		relation string
+		res      *magistrala.AddDomainMemberRes
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "add domain member",
+			domainID: domainID,
+			userID:   id,
+			relation: memberRelation,
+			res:      &magistrala.AddDomainMemberRes{Added: true},
+			err:      nil,
+		},
+		{
+			desc:     "add domain member with empty domain ID",
+			domainID: "",
+			userID:   id,
+			relation: memberRelation,
+			res:      &magistrala.AddDomainMemberRes{},
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "add domain member with empty user ID",
+			domainID: domainID,
+			userID:   "",
+			relation: memberRelation,
+			res:      &magistrala.AddDomainMemberRes{},
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "add domain member with empty relation",
+			domainID: domainID,
+			userID:   id,
+			relation: "",
+			res:      &magistrala.AddDomainMemberRes{},
+			err:      apiutil.ErrMissingRelation,
+		},
+		{
+			desc:     "add domain member with failed to add",
+			domainID: domainID,
+			userID:   id,
+			relation: memberRelation,
+			res:      &magistrala.AddDomainMemberRes{},
+			svcErr:   svcerr.ErrNotFound,
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("AddDomainMember", mock.Anything, tc.domainID, tc.userID, tc.relation).Return(tc.svcErr)
+		res, err := client.AddDomainMember(context.Background(), &magistrala.AddDomainMemberReq{DomainId: tc.domainID, UserId: tc.userID, Relation: tc.relation})
+		if res != nil {
+			assert.Equal(t, tc.res.GetAdded(), res.GetAdded(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetAdded(), res.GetAdded()))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		domainID string
+		resource string
+		count    uint64
+		res      *magistrala.CheckQuotaRes
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "check quota",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+			count:    1,
+			res:      &magistrala.CheckQuotaRes{Allowed: true, Limited: true},
+			err:      nil,
+		},
+		{
+			desc:     "check quota of unlimited resource",
+			domainID: domainID,
+			resource: auth.MessagesQuota,
+			count:    1,
+			res:      &magistrala.CheckQuotaRes{Allowed: true},
+			err:      nil,
+		},
+		{
+			desc:     "check quota with empty domain ID",
+			domainID: "",
+			resource: auth.ThingsQuota,
+			count:    1,
+			res:      &magistrala.CheckQuotaRes{},
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "check quota with empty resource",
+			domainID: domainID,
+			resource: "",
+			count:    1,
+			res:      &magistrala.CheckQuotaRes{},
+			err:      apiutil.ErrMissingQuotaResource,
+		},
+		{
+			desc:     "check exceeded quota",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+			count:    1,
+			res:      &magistrala.CheckQuotaRes{},
+			svcErr:   svcerr.ErrQuotaExceeded,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CheckQuota", mock.Anything, tc.domainID, tc.resource, tc.count).Return(tc.res.GetLimited(), tc.svcErr)
+		res, err := client.CheckQuota(context.Background(), &magistrala.CheckQuotaReq{DomainId: tc.domainID, Resource: tc.resource, Count: tc.count})
+		if res != nil {
+			assert.Equal(t, tc.res.GetAllowed(), res.GetAllowed(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetAllowed(), res.GetAllowed()))
+			assert.Equal(t, tc.res.GetLimited(), res.GetLimited(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetLimited(), res.GetLimited()))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestReserveQuota(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		domainID string
+		resource string
+		existing bool
+		reserved []string
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "reserve quota",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+			reserved: []string{id},
+		},
+		{
+			desc:     "reserve quota of existing entities",
+			domainID: domainID,
+			resource: auth.ChannelsQuota,
+			existing: true,
+		},
+		{
+			desc:     "reserve quota with empty domain ID",
+			domainID: "",
+			resource: auth.ThingsQuota,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "reserve quota with empty resource",
+			domainID: domainID,
+			resource: "",
+			err:      apiutil.ErrMissingQuotaResource,
+		},
+		{
+			desc:     "reserve exceeded quota",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+			svcErr:   svcerr.ErrQuotaExceeded,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ReserveQuota", mock.Anything, tc.domainID, tc.resource, tc.existing, id).Return(tc.reserved, tc.svcErr)
+		res, err := client.ReserveQuota(context.Background(), &magistrala.ReserveQuotaReq{DomainId: tc.domainID, Resource: tc.resource, Ids: []string{id}, Existing: tc.existing})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.reserved, res.GetReservedIds(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.reserved, res.GetReservedIds()))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestReleaseQuota(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		domainID string
+		resource string
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "release quota",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+		},
+		{
+			desc:     "release quota with empty domain ID",
+			domainID: "",
+			resource: auth.ThingsQuota,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "release quota with empty resource",
+			domainID: domainID,
+			resource: "",
+			err:      apiutil.ErrMissingQuotaResource,
+		},
+		{
+			desc:     "release quota with invalid resource",
+			domainID: domainID,
+			resource: auth.MessagesQuota,
+			svcErr:   svcerr.ErrMalformedEntity,
+			err:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ReleaseQuota", mock.Anything, tc.domainID, tc.resource, id).Return(tc.svcErr)
+		res, err := client.ReleaseQuota(context.Background(), &magistrala.ReleaseQuotaReq{DomainId: tc.domainID, Resource: tc.resource, Ids: []string{id}})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.err == nil, res.GetReleased(), fmt.Sprintf("%s: expected released %t got %t", tc.desc, tc.err == nil, res.GetReleased()))
+		svcCall.Unset()
+	}
+}
+
 func TestAuthorize(t *testing.T) {
 	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
@@ -345,6 +720,58 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestAuthorizeSourceIP(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	client := grpcapi.NewAuthClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		ctxIP    string
+		reqIP    string
+		sourceIP string
+	}{
+		{
+			desc:     "authorize with source IP from context",
+			ctxIP:    "10.1.2.3",
+			sourceIP: "10.1.2.3",
+		},
+		{
+			desc:     "authorize with source IP from request",
+			ctxIP:    "10.1.2.3",
+			reqIP:    "192.168.1.1",
+			sourceIP: "192.168.1.1",
+		},
+		{
+			desc:     "authorize without source IP",
+			sourceIP: "",
+		},
+	}
+	for _, tc := range cases {
+		req := &magistrala.AuthorizeReq{
+			Subject:     id,
+			SubjectType: usersType,
+			Object:      authoritiesObj,
+			ObjectType:  usersType,
+			Permission:  adminpermission,
+			SourceIp:    tc.reqIP,
+		}
+		svccall := svc.On("Authorize", mock.Anything, auth.PolicyReq{
+			Subject:     id,
+			SubjectType: usersType,
+			Object:      authoritiesObj,
+			ObjectType:  usersType,
+			Permission:  adminpermission,
+			SourceIP:    tc.sourceIP,
+		}).Return(nil)
+		ctx := apiutil.WithSourceIP(context.Background(), tc.ctxIP)
+		ar, err := client.Authorize(ctx, req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.True(t, ar.GetAuthorized(), fmt.Sprintf("%s: expected authorized", tc.desc))
+		svccall.Unset()
+	}
+}
+
 func TestAddPolicy(t *testing.T) {
 	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
@@ -4,6 +4,8 @@
 package grpc
 
 import (
+	"time"
+
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/apiutil"
 )
@@ -24,6 +26,8 @@ type issueReq struct {
 	userID   string
 	domainID string // optional
 	keyType  auth.KeyType
+	mfa      bool
+	token    string // required for API keys
 }
 
 func (req issueReq) validate() error {
@@ -33,6 +37,9 @@ func (req issueReq) validate() error {
 		req.keyType != auth.InvitationKey {
 		return apiutil.ErrInvalidAuthKey
 	}
+	if req.keyType == auth.APIKey && req.token == "" {
+		return apiutil.ErrBearerToken
+	}
 
 	return nil
 }
@@ -50,6 +57,104 @@ func (req refreshReq) validate() error {
 	return nil
 }
 
+type revokeTokensReq struct {
+	userID string
+}
+
+func (req revokeTokensReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type applyClaimMappingsReq struct {
+	userID   string
+	provider string
+	groups   []string
+}
+
+func (req applyClaimMappingsReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.provider == "" {
+		return apiutil.ErrMissingProvider
+	}
+
+	return nil
+}
+
+type addDomainMemberReq struct {
+	domainID string
+	userID   string
+	relation string
+}
+
+func (req addDomainMemberReq) validate() error {
+	if req.domainID == "" || req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.relation == "" {
+		return apiutil.ErrMissingRelation
+	}
+
+	return nil
+}
+
+type checkQuotaReq struct {
+	domainID string
+	resource string
+	count    uint64
+}
+
+func (req checkQuotaReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.resource == "" {
+		return apiutil.ErrMissingQuotaResource
+	}
+
+	return nil
+}
+
+type reserveQuotaReq struct {
+	domainID string
+	resource string
+	ids      []string
+	existing bool
+}
+
+func (req reserveQuotaReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.resource == "" {
+		return apiutil.ErrMissingQuotaResource
+	}
+
+	return nil
+}
+
+type releaseQuotaReq struct {
+	domainID string
+	resource string
+	ids      []string
+}
+
+func (req releaseQuotaReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.resource == "" {
+		return apiutil.ErrMissingQuotaResource
+	}
+
+	return nil
+}
+
 // authReq represents authorization request. It contains:
 // 1. subject - an action invoker
 // 2. object - an entity over which action will be executed
@@ -63,6 +168,7 @@ type authReq struct {
 	Permission  string
 	ObjectType  string
 	Object      string
+	SourceIP    string
 }
 
 func (req authReq) validate() error {
@@ -91,12 +197,29 @@ type policyReq struct {
 	ObjectType  string
 	ObjectKind  string
 	Object      string
+
+	// ExpiresAt is the Unix time in seconds the added policy expires at.
+	ExpiresAt    int64
+	AllowedCIDRs []string
 }
 
 func (req policyReq) validate() error {
 	return nil
 }
 
+// condition returns the condition of the added policy, if any.
+func (req policyReq) condition() *auth.Condition {
+	if req.ExpiresAt == 0 && len(req.AllowedCIDRs) == 0 {
+		return nil
+	}
+	cond := &auth.Condition{AllowedCIDRs: req.AllowedCIDRs}
+	if req.ExpiresAt != 0 {
+		cond.ExpiresAt = time.Unix(req.ExpiresAt, 0)
+	}
+
+	return cond
+}
+
 type policiesReq []policyReq
 
 func (prs policiesReq) validate() error {
@@ -106,6 +229,7 @@ func (prs policiesReq) validate() error {
 type listObjectsReq struct {
 	Domain        string
 	SubjectType   string
+	SubjectKind   string
 	Subject       string
 	Relation      string
 	Permission    string
@@ -7,6 +7,7 @@ type identityRes struct {
 	id       string
 	userID   string
 	domainID string
+	keyType  uint32
 }
 
 type issueRes struct {
@@ -15,6 +16,31 @@ type issueRes struct {
 	accessType   string
 }
 
+type revokeTokensRes struct {
+	revoked bool
+}
+
+type applyClaimMappingsRes struct {
+	applied bool
+}
+
+type addDomainMemberRes struct {
+	added bool
+}
+
+type checkQuotaRes struct {
+	allowed bool
+	limited bool
+}
+
+type reserveQuotaRes struct {
+	reservedIDs []string
+}
+
+type releaseQuotaRes struct {
+	released bool
+}
+
 type authorizeRes struct {
 	id         string
 	authorized bool
@@ -48,9 +48,15 @@ func (s *authzGrpcServer) Authorize(ctx context.Context, req *magistrala.Authori
 
 type authnGrpcServer struct {
 	magistrala.UnimplementedAuthnServiceServer
-	issue    kitgrpc.Handler
-	refresh  kitgrpc.Handler
-	identify kitgrpc.Handler
+	issue         kitgrpc.Handler
+	refresh       kitgrpc.Handler
+	identify      kitgrpc.Handler
+	revokeTokens  kitgrpc.Handler
+	applyMappings kitgrpc.Handler
+	addMember     kitgrpc.Handler
+	checkQuota    kitgrpc.Handler
+	reserveQuota  kitgrpc.Handler
+	releaseQuota  kitgrpc.Handler
 }
 
 // NewAuthnServer returns new AuthnServiceServer instance.
@@ -71,6 +77,36 @@ func NewAuthnServer(svc auth.Service) magistrala.AuthnServiceServer {
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
 		),
+		revokeTokens: kitgrpc.NewServer(
+			(revokeTokensEndpoint(svc)),
+			decodeRevokeTokensRequest,
+			encodeRevokeTokensResponse,
+		),
+		applyMappings: kitgrpc.NewServer(
+			(applyClaimMappingsEndpoint(svc)),
+			decodeApplyClaimMappingsRequest,
+			encodeApplyClaimMappingsResponse,
+		),
+		addMember: kitgrpc.NewServer(
+			(addDomainMemberEndpoint(svc)),
+			decodeAddDomainMemberRequest,
+			encodeAddDomainMemberResponse,
+		),
+		checkQuota: kitgrpc.NewServer(
+			(checkQuotaEndpoint(svc)),
+			decodeCheckQuotaRequest,
+			encodeCheckQuotaResponse,
+		),
+		reserveQuota: kitgrpc.NewServer(
+			(reserveQuotaEndpoint(svc)),
+			decodeReserveQuotaRequest,
+			encodeReserveQuotaResponse,
+		),
+		releaseQuota: kitgrpc.NewServer(
+			(releaseQuotaEndpoint(svc)),
+			decodeReleaseQuotaRequest,
+			encodeReleaseQuotaResponse,
+		),
 	}
 }
 
@@ -98,6 +134,54 @@ func (s *authnGrpcServer) Identify(ctx context.Context, token *magistrala.Identi
 	return res.(*magistrala.IdentityRes), nil
 }
 
+func (s *authnGrpcServer) RevokeTokens(ctx context.Context, req *magistrala.RevokeTokensReq) (*magistrala.RevokeTokensRes, error) {
+	_, res, err := s.revokeTokens.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.RevokeTokensRes), nil
+}
+
+func (s *authnGrpcServer) ApplyClaimMappings(ctx context.Context, req *magistrala.ApplyClaimMappingsReq) (*magistrala.ApplyClaimMappingsRes, error) {
+	_, res, err := s.applyMappings.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ApplyClaimMappingsRes), nil
+}
+
+func (s *authnGrpcServer) AddDomainMember(ctx context.Context, req *magistrala.AddDomainMemberReq) (*magistrala.AddDomainMemberRes, error) {
+	_, res, err := s.addMember.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.AddDomainMemberRes), nil
+}
+
+func (s *authnGrpcServer) CheckQuota(ctx context.Context, req *magistrala.CheckQuotaReq) (*magistrala.CheckQuotaRes, error) {
+	_, res, err := s.checkQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.CheckQuotaRes), nil
+}
+
+func (s *authnGrpcServer) ReserveQuota(ctx context.Context, req *magistrala.ReserveQuotaReq) (*magistrala.ReserveQuotaRes, error) {
+	_, res, err := s.reserveQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ReserveQuotaRes), nil
+}
+
+func (s *authnGrpcServer) ReleaseQuota(ctx context.Context, req *magistrala.ReleaseQuotaReq) (*magistrala.ReleaseQuotaRes, error) {
+	_, res, err := s.releaseQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ReleaseQuotaRes), nil
+}
+
 type policyGrpcServer struct {
 	magistrala.UnimplementedPolicyServiceServer
 	addPolicy            kitgrpc.Handler
@@ -281,6 +365,8 @@ func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, er
 		userID:   req.GetUserId(),
 		domainID: req.GetDomainId(),
 		keyType:  auth.KeyType(req.GetType()),
+		mfa:      req.GetMfa(),
+		token:    req.GetToken(),
 	}, nil
 }
 
@@ -306,7 +392,67 @@ func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
-	return &magistrala.IdentityRes{Id: res.id, UserId: res.userID, DomainId: res.domainID}, nil
+	return &magistrala.IdentityRes{Id: res.id, UserId: res.userID, DomainId: res.domainID, Type: res.keyType}, nil
+}
+
+func decodeRevokeTokensRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.RevokeTokensReq)
+	return revokeTokensReq{userID: req.GetUserId()}, nil
+}
+
+func encodeRevokeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(revokeTokensRes)
+	return &magistrala.RevokeTokensRes{Revoked: res.revoked}, nil
+}
+
+func decodeApplyClaimMappingsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ApplyClaimMappingsReq)
+	return applyClaimMappingsReq{userID: req.GetUserId(), provider: req.GetProvider(), groups: req.GetGroups()}, nil
+}
+
+func encodeApplyClaimMappingsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(applyClaimMappingsRes)
+	return &magistrala.ApplyClaimMappingsRes{Applied: res.applied}, nil
+}
+
+func decodeAddDomainMemberRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.AddDomainMemberReq)
+	return addDomainMemberReq{domainID: req.GetDomainId(), userID: req.GetUserId(), relation: req.GetRelation()}, nil
+}
+
+func encodeAddDomainMemberResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(addDomainMemberRes)
+	return &magistrala.AddDomainMemberRes{Added: res.added}, nil
+}
+
+func decodeCheckQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.CheckQuotaReq)
+	return checkQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), count: req.GetCount()}, nil
+}
+
+func encodeCheckQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(checkQuotaRes)
+	return &magistrala.CheckQuotaRes{Allowed: res.allowed, Limited: res.limited}, nil
+}
+
+func decodeReserveQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ReserveQuotaReq)
+	return reserveQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), ids: req.GetIds(), existing: req.GetExisting()}, nil
+}
+
+func encodeReserveQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(reserveQuotaRes)
+	return &magistrala.ReserveQuotaRes{ReservedIds: res.reservedIDs}, nil
+}
+
+func decodeReleaseQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ReleaseQuotaReq)
+	return releaseQuotaReq{domainID: req.GetDomainId(), resource: req.GetResource(), ids: req.GetIds()}, nil
+}
+
+func encodeReleaseQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(releaseQuotaRes)
+	return &magistrala.ReleaseQuotaRes{Released: res.released}, nil
 }
 
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -320,6 +466,7 @@ func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		Permission:  req.GetPermission(),
 		ObjectType:  req.GetObjectType(),
 		Object:      req.GetObject(),
+		SourceIP:    req.GetSourceIp(),
 	}, nil
 }
 
@@ -331,15 +478,17 @@ func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 func decodeAddPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*magistrala.AddPolicyReq)
 	return policyReq{
-		Domain:      req.GetDomain(),
-		SubjectType: req.GetSubjectType(),
-		SubjectKind: req.GetSubjectKind(),
-		Subject:     req.GetSubject(),
-		Relation:    req.GetRelation(),
-		Permission:  req.GetPermission(),
-		ObjectType:  req.GetObjectType(),
-		ObjectKind:  req.GetObjectKind(),
-		Object:      req.GetObject(),
+		Domain:       req.GetDomain(),
+		SubjectType:  req.GetSubjectType(),
+		SubjectKind:  req.GetSubjectKind(),
+		Subject:      req.GetSubject(),
+		Relation:     req.GetRelation(),
+		Permission:   req.GetPermission(),
+		ObjectType:   req.GetObjectType(),
+		ObjectKind:   req.GetObjectKind(),
+		Object:       req.GetObject(),
+		ExpiresAt:    req.GetExpiresAt(),
+		AllowedCIDRs: req.GetAllowedCidrs(),
 	}, nil
 }
 
@@ -353,15 +502,17 @@ func decodeAddPoliciesRequest(_ context.Context, grpcReq interface{}) (interface
 	r := policiesReq{}
 	for _, req := range reqs.AddPoliciesReq {
 		r = append(r, policyReq{
-			Domain:      req.GetDomain(),
-			SubjectType: req.GetSubjectType(),
-			SubjectKind: req.GetSubjectKind(),
-			Subject:     req.GetSubject(),
-			Relation:    req.GetRelation(),
-			Permission:  req.GetPermission(),
-			ObjectType:  req.GetObjectType(),
-			ObjectKind:  req.GetObjectKind(),
-			Object:      req.GetObject(),
+			Domain:       req.GetDomain(),
+			SubjectType:  req.GetSubjectType(),
+			SubjectKind:  req.GetSubjectKind(),
+			Subject:      req.GetSubject(),
+			Relation:     req.GetRelation(),
+			Permission:   req.GetPermission(),
+			ObjectType:   req.GetObjectType(),
+			ObjectKind:   req.GetObjectKind(),
+			Object:       req.GetObject(),
+			ExpiresAt:    req.GetExpiresAt(),
+			AllowedCIDRs: req.GetAllowedCidrs(),
 		})
 	}
 	return r, nil
@@ -421,6 +572,7 @@ func decodeListObjectsRequest(_ context.Context, grpcReq interface{}) (interface
 	return listObjectsReq{
 		Domain:        req.GetDomain(),
 		SubjectType:   req.GetSubjectType(),
+		SubjectKind:   req.GetSubjectKind(),
 		Subject:       req.GetSubject(),
 		Relation:      req.GetRelation(),
 		Permission:    req.GetPermission(),
@@ -537,6 +689,8 @@ func encodeError(err error) error {
 		errors.Contains(err, svcerr.ErrInvalidPolicy),
 		err == apiutil.ErrInvalidAuthKey,
 		err == apiutil.ErrMissingID,
+		err == apiutil.ErrMissingProvider,
+		err == apiutil.ErrMissingQuotaResource,
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrMissingPolicySub,
 		err == apiutil.ErrMissingPolicyObj,
@@ -554,6 +708,8 @@ func encodeError(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Contains(err, svcerr.ErrConflict):
 		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Contains(err, svcerr.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -0,0 +1,34 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func explainEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(explainReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		exp, err := svc.ExplainPolicy(ctx, req.token, auth.PolicyReq{
+			SubjectType: req.SubjectType,
+			Subject:     req.Subject,
+			Permission:  req.Permission,
+			ObjectType:  req.ObjectType,
+			Object:      req.Object,
+			SourceIP:    req.SourceIP,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return explainRes{PolicyExplanation: exp}, nil
+	}
+}
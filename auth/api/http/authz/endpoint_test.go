@@ -0,0 +1,227 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	httpapi "github.com/absmach/magistrala/auth/api/http/authz"
+	"github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/api"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken   = "token"
+	inValidToken = "invalid"
+	thingID      = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	groupID      = "a2b0e5d8-0d2f-4b6c-9d5e-8f7b7b0e1c4a"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	req.Header.Set("Referer", "http://localhost")
+
+	return tr.client.Do(req)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func newAuthzServer() (*httptest.Server, *mocks.Service) {
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+	svc := new(mocks.Service)
+	httpapi.MakeHandler(svc, mux, logger)
+	return httptest.NewServer(mux), svc
+}
+
+func TestExplain(t *testing.T) {
+	as, svc := newAuthzServer()
+	defer as.Close()
+
+	pr := auth.PolicyReq{
+		Permission: auth.ViewPermission,
+		ObjectType: auth.ThingType,
+		Object:     thingID,
+	}
+	allowed := auth.PolicyExplanation{
+		Allowed: true,
+		Path: []auth.PolicyStep{
+			{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: groupID},
+			{SubjectType: auth.GroupType, Subject: groupID, Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: thingID},
+		},
+	}
+	denied := auth.PolicyExplanation{
+		Required: []auth.PolicyStep{
+			{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: thingID},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		body        string
+		pr          auth.PolicyReq
+		exp         auth.PolicyExplanation
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "explain allowed permission",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        toJSON(pr),
+			pr:          pr,
+			exp:         allowed,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "explain denied permission",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        toJSON(pr),
+			pr:          pr,
+			exp:         denied,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "explain permission of other user",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"subject":"user","permission":"view","object_type":"thing","object":"` + thingID + `"}`,
+			pr:          auth.PolicyReq{Subject: "user", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: thingID},
+			exp:         denied,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "explain permission of other user without admin permission",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"subject":"user","permission":"view","object_type":"thing","object":"` + thingID + `"}`,
+			pr:          auth.PolicyReq{Subject: "user", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: thingID},
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "explain with empty token",
+			contentType: api.ContentType,
+			body:        toJSON(pr),
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "explain with invalid token",
+			token:       inValidToken,
+			contentType: api.ContentType,
+			body:        toJSON(pr),
+			pr:          pr,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "explain with missing object type",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"permission":"view","object":"` + thingID + `"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "explain with missing object",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"permission":"view","object_type":"thing"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "explain with missing permission",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"object_type":"thing","object":"` + thingID + `"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "explain with invalid source ip",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"permission":"view","object_type":"thing","object":"` + thingID + `","source_ip":"invalid"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "explain with malformed body",
+			token:       validToken,
+			contentType: api.ContentType,
+			body:        `{"permission":`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "explain with invalid content type",
+			token:       validToken,
+			contentType: "text/plain",
+			body:        toJSON(pr),
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      as.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/authz/explain", as.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+
+		svcCall := svc.On("ExplainPolicy", mock.Anything, tc.token, tc.pr).Return(tc.exp, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var exp auth.PolicyExplanation
+			err := json.NewDecoder(res.Body).Decode(&exp)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.exp, exp, fmt.Sprintf("%s: expected explanation %v got %v", tc.desc, tc.exp, exp))
+		}
+		svcCall.Unset()
+	}
+}
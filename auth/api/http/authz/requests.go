@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"net"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var errInvalidSourceIP = errors.New("invalid source ip")
+
+type explainReq struct {
+	token       string
+	SubjectType string `json:"subject_type,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	Permission  string `json:"permission"`
+	ObjectType  string `json:"object_type"`
+	Object      string `json:"object"`
+	SourceIP    string `json:"source_ip,omitempty"`
+}
+
+func (req explainReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.ObjectType == "" {
+		return apiutil.ErrMissingPolicyEntityType
+	}
+
+	if req.Object == "" {
+		return apiutil.ErrMissingPolicyObj
+	}
+
+	if req.Permission == "" {
+		return apiutil.ErrMalformedPolicyPer
+	}
+
+	if req.SourceIP != "" && net.ParseIP(req.SourceIP) == nil {
+		return errors.Wrap(apiutil.ErrMalformedPolicy, errInvalidSourceIP)
+	}
+
+	return nil
+}
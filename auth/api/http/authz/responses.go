@@ -0,0 +1,29 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+)
+
+var _ magistrala.Response = (*explainRes)(nil)
+
+type explainRes struct {
+	auth.PolicyExplanation
+}
+
+func (res explainRes) Code() int {
+	return http.StatusOK
+}
+
+func (res explainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res explainRes) Empty() bool {
+	return false
+}
@@ -46,6 +46,30 @@ func decodeRetrieveDomainPermissionsRequest(_ context.Context, r *http.Request)
 	return req, nil
 }
 
+func decodeRetrieveDomainUsageRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := retrieveDomainUsageRequest{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	return req, nil
+}
+
+func decodeDeleteDomainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteDomainReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	return req, nil
+}
+
+func decodeRetrieveDomainDeletionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := retrieveDomainDeletionReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	return req, nil
+}
+
 func decodeUpdateDomainRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -132,6 +156,83 @@ func decodeUnassignUserRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeCreateRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := createRoleReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := roleReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		roleID:   chi.URLParam(r, "roleID"),
+	}
+	return req, nil
+}
+
+func decodeListRolesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listRolesReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		offset:   o,
+		limit:    l,
+	}
+	return req, nil
+}
+
+func decodeUpdateRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateRoleReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		roleID:   chi.URLParam(r, "roleID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeAssignRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := assignRoleReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		roleID:   chi.URLParam(r, "roleID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeListUserDomainsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	page, err := decodePageRequest(ctx, r)
 	if err != nil {
@@ -199,3 +300,46 @@ func decodePageRequest(_ context.Context, r *http.Request) (page, error) {
 		status:     st,
 	}, nil
 }
+
+func decodeCreateClaimMappingRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := createClaimMappingReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeClaimMappingRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := claimMappingReq{
+		token:     apiutil.ExtractBearerToken(r),
+		domainID:  chi.URLParam(r, "domainID"),
+		mappingID: chi.URLParam(r, "mappingID"),
+	}
+	return req, nil
+}
+
+func decodeListClaimMappingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listClaimMappingsReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		offset:   o,
+		limit:    l,
+	}
+	return req, nil
+}
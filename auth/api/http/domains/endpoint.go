@@ -21,10 +21,12 @@ func createDomainEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		d := auth.Domain{
-			Name:     req.Name,
-			Metadata: req.Metadata,
-			Tags:     req.Tags,
-			Alias:    req.Alias,
+			Name:        req.Name,
+			Metadata:    req.Metadata,
+			Tags:        req.Tags,
+			Alias:       req.Alias,
+			MFARequired: req.MFARequired,
+			Quotas:      req.Quotas,
 		}
 		domain, err := svc.CreateDomain(ctx, req.token, d)
 		if err != nil {
@@ -65,6 +67,21 @@ func retrieveDomainPermissionsEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func retrieveDomainUsageEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveDomainUsageRequest)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		usage, err := svc.RetrieveDomainUsage(ctx, req.token, req.domainID)
+		if err != nil {
+			return nil, err
+		}
+		return retrieveDomainUsageRes{usage}, nil
+	}
+}
+
 func updateDomainEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(updateDomainReq)
@@ -77,10 +94,12 @@ func updateDomainEndpoint(svc auth.Service) endpoint.Endpoint {
 			metadata = *req.Metadata
 		}
 		d := auth.DomainReq{
-			Name:     req.Name,
-			Metadata: &metadata,
-			Tags:     req.Tags,
-			Alias:    req.Alias,
+			Name:        req.Name,
+			Metadata:    &metadata,
+			Tags:        req.Tags,
+			Alias:       req.Alias,
+			MFARequired: req.MFARequired,
+			Quotas:      req.Quotas,
 		}
 		domain, err := svc.UpdateDomain(ctx, req.token, req.domainID, d)
 		if err != nil {
@@ -117,6 +136,38 @@ func listDomainsEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func deleteDomainEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteDomainReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		dd, err := svc.DeleteDomain(ctx, req.token, req.domainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return deleteDomainRes{dd}, nil
+	}
+}
+
+func retrieveDomainDeletionEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveDomainDeletionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		dd, err := svc.RetrieveDomainDeletion(ctx, req.token, req.domainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return retrieveDomainDeletionRes{dd}, nil
+	}
+}
+
 func enableDomainEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(enableDomainReq)
@@ -178,7 +229,7 @@ func assignDomainUsersEndpoint(svc auth.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.AssignUsers(ctx, req.token, req.domainID, req.UserIDs, req.Relation); err != nil {
+		if err := svc.AssignUsers(ctx, req.token, req.domainID, req.UserIDs, req.Relation, req.condition()); err != nil {
 			return nil, err
 		}
 		return assignUsersRes{}, nil
@@ -224,3 +275,216 @@ func listUserDomainsEndpoint(svc auth.Service) endpoint.Endpoint {
 		return listUserDomainsRes{dp}, nil
 	}
 }
+
+func createRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		role := auth.Role{
+			Name:        req.Name,
+			Permissions: req.Permissions,
+		}
+		role, err := svc.CreateRole(ctx, req.token, req.domainID, role)
+		if err != nil {
+			return nil, err
+		}
+
+		return createRoleRes{role}, nil
+	}
+}
+
+func viewRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(roleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		role, err := svc.RetrieveRole(ctx, req.token, req.domainID, req.roleID)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRoleRes{role}, nil
+	}
+}
+
+func listRolesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRolesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page := auth.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		rp, err := svc.ListRoles(ctx, req.token, req.domainID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		return listRolesRes{rp}, nil
+	}
+}
+
+func listRoleAssignmentsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(roleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ras, err := svc.ListRoleAssignments(ctx, req.token, req.domainID, req.roleID)
+		if err != nil {
+			return nil, err
+		}
+		if ras == nil {
+			ras = []auth.RoleAssignment{}
+		}
+
+		return listRoleAssignmentsRes{Assignments: ras}, nil
+	}
+}
+
+func updateRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rr := auth.RoleReq{
+			Name:        req.Name,
+			Permissions: req.Permissions,
+		}
+		role, err := svc.UpdateRole(ctx, req.token, req.domainID, req.roleID, rr)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRoleRes{role}, nil
+	}
+}
+
+func deleteRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(roleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DeleteRole(ctx, req.token, req.domainID, req.roleID); err != nil {
+			return nil, err
+		}
+
+		return deleteRoleRes{}, nil
+	}
+}
+
+func assignRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AssignRole(ctx, req.token, req.domainID, req.roleID, req.Entity, req.EntityID, req.UserIDs); err != nil {
+			return nil, err
+		}
+
+		return assignRoleRes{}, nil
+	}
+}
+
+func unassignRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnassignRole(ctx, req.token, req.domainID, req.roleID, req.Entity, req.EntityID, req.UserIDs); err != nil {
+			return nil, err
+		}
+
+		return unassignRoleRes{}, nil
+	}
+}
+
+func createClaimMappingEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClaimMappingReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		mapping := auth.ClaimMapping{
+			Provider: req.Provider,
+			Group:    req.Group,
+			Relation: req.Relation,
+			GroupIDs: req.GroupIDs,
+		}
+		mapping, err := svc.CreateClaimMapping(ctx, req.token, req.domainID, mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		return createClaimMappingRes{mapping}, nil
+	}
+}
+
+func viewClaimMappingEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(claimMappingReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		mapping, err := svc.RetrieveClaimMapping(ctx, req.token, req.domainID, req.mappingID)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewClaimMappingRes{mapping}, nil
+	}
+}
+
+func listClaimMappingsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listClaimMappingsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page := auth.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		mp, err := svc.ListClaimMappings(ctx, req.token, req.domainID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		return listClaimMappingsRes{mp}, nil
+	}
+}
+
+func deleteClaimMappingEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(claimMappingReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DeleteClaimMapping(ctx, req.token, req.domainID, req.mappingID); err != nil {
+			return nil, err
+		}
+
+		return deleteClaimMappingRes{}, nil
+	}
+}
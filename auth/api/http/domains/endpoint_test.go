@@ -626,6 +626,85 @@ func TestViewDomainPermissions(t *testing.T) {
 	}
 }
 
+func TestViewDomainUsage(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	usage := auth.DomainUsage{
+		Quotas: auth.Quotas{Things: 10, MessagesPerDay: 1000},
+		Usage:  auth.Usage{Things: 2, Messages: 10},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		status   int
+		svcRes   auth.DomainUsage
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "view domain usage successfully",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusOK,
+			svcRes:   usage,
+			err:      nil,
+		},
+		{
+			desc:     "view domain usage with empty token",
+			token:    "",
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "view domain usage with invalid token",
+			token:    inValidToken,
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			svcErr:   svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "view domain usage without domain admin permission",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/usage", ds.URL, tc.domainID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RetrieveDomainUsage", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var body struct {
+			auth.DomainUsage
+			respBody
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if body.Err != "" || body.Message != "" {
+			err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+		}
+
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.svcRes, body.DomainUsage, fmt.Sprintf("%s: expected usage %v got %v", tc.desc, tc.svcRes, body.DomainUsage))
+		svcCall.Unset()
+	}
+}
+
 func TestUpdateDomain(t *testing.T) {
 	ds, svc := newDomainsServer()
 	defer ds.Close()
@@ -978,6 +1057,187 @@ func TestFreezeDomain(t *testing.T) {
 	}
 }
 
+func TestDeleteDomain(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	deletion := auth.DomainDeletion{
+		DomainID:    id,
+		Status:      auth.DeletionPending,
+		RequestedBy: id,
+		RequestedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		status   int
+		svcRes   auth.DomainDeletion
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "delete domain successfully",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusAccepted,
+			svcRes:   deletion,
+			err:      nil,
+		},
+		{
+			desc:     "delete domain with empty token",
+			token:    "",
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "delete domain with invalid token",
+			token:    inValidToken,
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			svcErr:   svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "delete domain without domain admin permission",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "delete domain pending deletion",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusConflict,
+			svcErr:   svcerr.ErrConflict,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/domains/%s", ds.URL, tc.domainID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteDomain", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		// The deletion error field collides with the error response field.
+		data, err := io.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while reading response body: %s", tc.desc, err))
+		var dd auth.DomainDeletion
+		if tc.err == nil {
+			err = json.Unmarshal(data, &dd)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		} else {
+			var body respBody
+			err = json.Unmarshal(data, &body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+		}
+
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.svcRes, dd, fmt.Sprintf("%s: expected deletion %v got %v", tc.desc, tc.svcRes, dd))
+		svcCall.Unset()
+	}
+}
+
+func TestViewDomainDeletion(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	deletion := auth.DomainDeletion{
+		DomainID:    id,
+		Status:      auth.DeletionFailed,
+		RequestedBy: id,
+		RequestedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		Error:       "failed to remove the policies",
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		status   int
+		svcRes   auth.DomainDeletion
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "view domain deletion successfully",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusOK,
+			svcRes:   deletion,
+			err:      nil,
+		},
+		{
+			desc:     "view domain deletion with empty token",
+			token:    "",
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "view domain deletion with invalid token",
+			token:    inValidToken,
+			domainID: id,
+			status:   http.StatusUnauthorized,
+			svcErr:   svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "view domain deletion without domain admin permission",
+			token:    validToken,
+			domainID: id,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/deletion", ds.URL, tc.domainID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RetrieveDomainDeletion", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		// The deletion error field collides with the error response field.
+		data, err := io.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while reading response body: %s", tc.desc, err))
+		var dd auth.DomainDeletion
+		if tc.err == nil {
+			err = json.Unmarshal(data, &dd)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		} else {
+			var body respBody
+			err = json.Unmarshal(data, &body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+		}
+
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.svcRes, dd, fmt.Sprintf("%s: expected deletion %v got %v", tc.desc, tc.svcRes, dd))
+		svcCall.Unset()
+	}
+}
+
 func TestAssignDomainUsers(t *testing.T) {
 	ds, svc := newDomainsServer()
 	defer ds.Close()
@@ -1084,7 +1344,7 @@ func TestAssignDomainUsers(t *testing.T) {
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("AssignUsers", mock.Anything, tc.token, tc.domainID, mock.Anything, mock.Anything).Return(tc.err)
+		svcCall := svc.On("AssignUsers", mock.Anything, tc.token, tc.domainID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1299,12 +1559,817 @@ func TestListDomainsByUserID(t *testing.T) {
 	}
 }
 
-type respBody struct {
-	Err         string           `json:"error"`
-	Message     string           `json:"message"`
-	Total       int              `json:"total"`
-	Permissions []string         `json:"permissions"`
-	ID          string           `json:"id"`
-	Tags        []string         `json:"tags"`
-	Status      mgclients.Status `json:"status"`
+func TestCreateRole(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	role := auth.Role{
+		ID:          validID,
+		DomainID:    domain.ID,
+		Name:        "operator",
+		Permissions: []string{"things:view", "channels:publish"},
+	}
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		token       string
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create role successfully",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": role.Permissions}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create role with empty token",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": role.Permissions}),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create role with invalid token",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": role.Permissions}),
+			contentType: contentType,
+			token:       inValidToken,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create role with empty name",
+			data:        toJSON(map[string]interface{}{"permissions": role.Permissions}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create role with empty permissions",
+			data:        toJSON(map[string]interface{}{"name": role.Name}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create role with invalid permissions",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": []string{"users:view"}}),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create role with malformed data",
+			data:        `{"name": "operator"`,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create role with invalid content type",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": role.Permissions}),
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create role with service error",
+			data:        toJSON(map[string]interface{}{"name": role.Name, "permissions": role.Permissions}),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrCreateEntity,
+			status:      http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ds.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/domains/%s/roles", ds.URL, domain.ID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("CreateRole", mock.Anything, tc.token, domain.ID, mock.Anything).Return(role, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusCreated {
+			location := fmt.Sprintf("/domains/%s/roles/%s", domain.ID, role.ID)
+			assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestViewRole(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		roleID string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "view role successfully",
+			token:  validToken,
+			roleID: validID,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view role with empty token",
+			token:  "",
+			roleID: validID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view role with invalid token",
+			token:  inValidToken,
+			roleID: validID,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view non-existing role",
+			token:  validToken,
+			roleID: "invalid",
+			svcErr: svcerr.ErrViewEntity,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/roles/%s", ds.URL, domain.ID, tc.roleID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RetrieveRole", mock.Anything, tc.token, domain.ID, tc.roleID).Return(auth.Role{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListRoleAssignments(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		roleID string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "list role assignments successfully",
+			token:  validToken,
+			roleID: validID,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list role assignments with empty token",
+			token:  "",
+			roleID: validID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list role assignments with invalid token",
+			token:  inValidToken,
+			roleID: validID,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list assignments of non-existing role",
+			token:  validToken,
+			roleID: "invalid",
+			svcErr: svcerr.ErrViewEntity,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/roles/%s/assignments", ds.URL, domain.ID, tc.roleID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListRoleAssignments", mock.Anything, tc.token, domain.ID, tc.roleID).Return([]auth.RoleAssignment{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListRoles(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		page   auth.Page
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "list roles successfully",
+			token:  validToken,
+			page:   auth.Page{Offset: 0, Limit: 10},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list roles with offset and limit",
+			token:  validToken,
+			query:  "?offset=1&limit=5",
+			page:   auth.Page{Offset: 1, Limit: 5},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list roles with empty token",
+			token:  "",
+			page:   auth.Page{Offset: 0, Limit: 10},
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list roles with invalid limit",
+			token:  validToken,
+			query:  "?limit=1000",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list roles with malformed offset",
+			token:  validToken,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list roles with unauthorized user",
+			token:  validToken,
+			page:   auth.Page{Offset: 0, Limit: 10},
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/roles%s", ds.URL, domain.ID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListRoles", mock.Anything, tc.token, domain.ID, tc.page).Return(auth.RolesPage{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		token       string
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update role successfully",
+			data:        toJSON(map[string]interface{}{"name": "viewer", "permissions": []string{"things:view"}}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update role with empty token",
+			data:        toJSON(map[string]interface{}{"name": "viewer"}),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update role with empty name",
+			data:        toJSON(map[string]interface{}{"name": ""}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update role with empty permissions",
+			data:        toJSON(map[string]interface{}{"permissions": []string{}}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update role with malformed data",
+			data:        `{"name": "viewer"`,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update role with invalid content type",
+			data:        toJSON(map[string]interface{}{"name": "viewer"}),
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update role with service error",
+			data:        toJSON(map[string]interface{}{"name": "viewer"}),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrUpdateEntity,
+			status:      http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ds.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/domains/%s/roles/%s", ds.URL, domain.ID, validID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("UpdateRole", mock.Anything, tc.token, domain.ID, validID, mock.Anything).Return(auth.Role{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "delete role successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "delete role with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "delete role with unauthorized user",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "delete role with service error",
+			token:  validToken,
+			svcErr: svcerr.ErrRemoveEntity,
+			status: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/domains/%s/roles/%s", ds.URL, domain.ID, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteRole", mock.Anything, tc.token, domain.ID, validID).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc        string
+		method      string
+		path        string
+		data        string
+		contentType string
+		token       string
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "assign role successfully",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "unassign role successfully",
+			method:      "UnassignRole",
+			path:        "unassign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "assign role with empty token",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "assign role with empty entity",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign role with empty entity id",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity": "channels", "user_ids": ["%s"]}`, validID),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "unassign role with empty user ids",
+			method:      "UnassignRole",
+			path:        "unassign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": []}`, validID),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign role with malformed data",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        `{"entity": "channels"`,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign role with invalid content type",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "assign role with unauthorized user",
+			method:      "AssignRole",
+			path:        "assign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "unassign role with unauthorized user",
+			method:      "UnassignRole",
+			path:        "unassign",
+			data:        fmt.Sprintf(`{"entity": "channels", "entity_id": "%s", "user_ids": ["%s"]}`, validID, validID),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ds.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/domains/%s/roles/%s/%s", ds.URL, domain.ID, validID, tc.path),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On(tc.method, mock.Anything, tc.token, domain.ID, validID, mock.Anything, mock.Anything, mock.Anything).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+type respBody struct {
+	Err         string           `json:"error"`
+	Message     string           `json:"message"`
+	Total       int              `json:"total"`
+	Permissions []string         `json:"permissions"`
+	ID          string           `json:"id"`
+	Tags        []string         `json:"tags"`
+	Status      mgclients.Status `json:"status"`
+}
+
+func TestCreateClaimMapping(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	mapping := auth.ClaimMapping{
+		ID:       validID,
+		DomainID: domain.ID,
+		Provider: "oidc",
+		Group:    "iot-ops",
+		Relation: auth.EditorRelation,
+		GroupIDs: []string{validID},
+	}
+	data := toJSON(map[string]interface{}{"provider": mapping.Provider, "group": mapping.Group, "relation": mapping.Relation, "group_ids": mapping.GroupIDs})
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		token       string
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create claim mapping successfully",
+			data:        data,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create claim mapping with empty token",
+			data:        data,
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create claim mapping with empty group",
+			data:        toJSON(map[string]interface{}{"relation": mapping.Relation}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create claim mapping with empty relation",
+			data:        toJSON(map[string]interface{}{"group": mapping.Group}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create claim mapping with invalid relation",
+			data:        toJSON(map[string]interface{}{"group": mapping.Group, "relation": "owner"}),
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create claim mapping with malformed data",
+			data:        `{"group": "iot-ops"`,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create claim mapping with invalid content type",
+			data:        data,
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create claim mapping with unauthorized user",
+			data:        data,
+			contentType: contentType,
+			token:       validToken,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ds.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/domains/%s/claim-mappings", ds.URL, domain.ID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("CreateClaimMapping", mock.Anything, tc.token, domain.ID, mock.Anything).Return(mapping, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusCreated {
+			location := fmt.Sprintf("/domains/%s/claim-mappings/%s", domain.ID, mapping.ID)
+			assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestViewClaimMapping(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "view claim mapping successfully",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view claim mapping with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view non-existing claim mapping",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/claim-mappings/%s", ds.URL, domain.ID, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RetrieveClaimMapping", mock.Anything, tc.token, domain.ID, validID).Return(auth.ClaimMapping{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListClaimMappings(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		page   auth.Page
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "list claim mappings successfully",
+			token:  validToken,
+			page:   auth.Page{Offset: 0, Limit: 10},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list claim mappings with offset and limit",
+			token:  validToken,
+			query:  "?offset=1&limit=5",
+			page:   auth.Page{Offset: 1, Limit: 5},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list claim mappings with empty token",
+			token:  "",
+			page:   auth.Page{Offset: 0, Limit: 10},
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list claim mappings with invalid limit",
+			token:  validToken,
+			query:  "?limit=1000",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list claim mappings with unauthorized user",
+			token:  validToken,
+			page:   auth.Page{Offset: 0, Limit: 10},
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/claim-mappings%s", ds.URL, domain.ID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListClaimMappings", mock.Anything, tc.token, domain.ID, tc.page).Return(auth.ClaimMappingsPage{}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteClaimMapping(t *testing.T) {
+	ds, svc := newDomainsServer()
+	defer ds.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "delete claim mapping successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "delete claim mapping with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "delete claim mapping with unauthorized user",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "delete claim mapping with service error",
+			token:  validToken,
+			svcErr: svcerr.ErrRemoveEntity,
+			status: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ds.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/domains/%s/claim-mappings/%s", ds.URL, domain.ID, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteClaimMapping", mock.Anything, tc.token, domain.ID, validID).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
 }
@@ -4,7 +4,10 @@
 package domains
 
 import (
+	"time"
+
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 )
 
@@ -21,11 +24,13 @@ type page struct {
 }
 
 type createDomainReq struct {
-	token    string
-	Name     string                 `json:"name"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	Tags     []string               `json:"tags,omitempty"`
-	Alias    string                 `json:"alias"`
+	token       string
+	Name        string                 `json:"name"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Alias       string                 `json:"alias"`
+	MFARequired bool                   `json:"mfa_required,omitempty"`
+	Quotas      auth.Quotas            `json:"quotas,omitempty"`
 }
 
 func (req createDomainReq) validate() error {
@@ -78,13 +83,66 @@ func (req retrieveDomainPermissionsRequest) validate() error {
 	return nil
 }
 
-type updateDomainReq struct {
+type deleteDomainReq struct {
 	token    string
 	domainID string
-	Name     *string                 `json:"name,omitempty"`
-	Metadata *map[string]interface{} `json:"metadata,omitempty"`
-	Tags     *[]string               `json:"tags,omitempty"`
-	Alias    *string                 `json:"alias,omitempty"`
+}
+
+func (req deleteDomainReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type retrieveDomainDeletionReq struct {
+	token    string
+	domainID string
+}
+
+func (req retrieveDomainDeletionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type retrieveDomainUsageRequest struct {
+	token    string
+	domainID string
+}
+
+func (req retrieveDomainUsageRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type updateDomainReq struct {
+	token       string
+	domainID    string
+	Name        *string                 `json:"name,omitempty"`
+	Metadata    *map[string]interface{} `json:"metadata,omitempty"`
+	Tags        *[]string               `json:"tags,omitempty"`
+	Alias       *string                 `json:"alias,omitempty"`
+	MFARequired *bool                   `json:"mfa_required,omitempty"`
+	Quotas      *auth.Quotas            `json:"quotas,omitempty"`
 }
 
 func (req updateDomainReq) validate() error {
@@ -164,10 +222,24 @@ func (req freezeDomainReq) validate() error {
 }
 
 type assignUsersReq struct {
-	token    string
-	domainID string
-	UserIDs  []string `json:"user_ids"`
-	Relation string   `json:"relation"`
+	token        string
+	domainID     string
+	UserIDs      []string  `json:"user_ids"`
+	Relation     string    `json:"relation"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	AllowedCIDRs []string  `json:"allowed_cidrs,omitempty"`
+}
+
+// condition returns the condition of the assignment, if any.
+func (req assignUsersReq) condition() *auth.Condition {
+	if req.ExpiresAt.IsZero() && len(req.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	return &auth.Condition{
+		ExpiresAt:    req.ExpiresAt,
+		AllowedCIDRs: req.AllowedCIDRs,
+	}
 }
 
 func (req assignUsersReq) validate() error {
@@ -229,3 +301,198 @@ func (req listUserDomainsReq) validate() error {
 
 	return nil
 }
+
+type createRoleReq struct {
+	token       string
+	domainID    string
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+func (req createRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	if len(req.Permissions) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type roleReq struct {
+	token    string
+	domainID string
+	roleID   string
+}
+
+func (req roleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" || req.roleID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRolesReq struct {
+	token    string
+	domainID string
+	offset   uint64
+	limit    uint64
+}
+
+func (req listRolesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type updateRoleReq struct {
+	token       string
+	domainID    string
+	roleID      string
+	Name        *string   `json:"name,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+func (req updateRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" || req.roleID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Name != nil && *req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	if req.Permissions != nil && len(*req.Permissions) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type assignRoleReq struct {
+	token    string
+	domainID string
+	roleID   string
+	Entity   string   `json:"entity"`
+	EntityID string   `json:"entity_id"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+func (req assignRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" || req.roleID == "" || req.EntityID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Entity == "" {
+		return apiutil.ErrMissingEntityType
+	}
+
+	if len(req.UserIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type createClaimMappingReq struct {
+	token    string
+	domainID string
+	Provider string   `json:"provider,omitempty"`
+	Group    string   `json:"group"`
+	Relation string   `json:"relation"`
+	GroupIDs []string `json:"group_ids,omitempty"`
+}
+
+func (req createClaimMappingReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Group == "" {
+		return apiutil.ErrMissingClaimGroup
+	}
+
+	if req.Relation == "" {
+		return apiutil.ErrMissingRelation
+	}
+
+	return nil
+}
+
+type claimMappingReq struct {
+	token     string
+	domainID  string
+	mappingID string
+}
+
+func (req claimMappingReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" || req.mappingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listClaimMappingsReq struct {
+	token    string
+	domainID string
+	offset   uint64
+	limit    uint64
+}
+
+func (req listClaimMappingsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
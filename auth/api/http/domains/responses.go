@@ -4,6 +4,7 @@
 package domains
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/absmach/magistrala"
@@ -16,6 +17,17 @@ var (
 	_ magistrala.Response = (*assignUsersRes)(nil)
 	_ magistrala.Response = (*unassignUsersRes)(nil)
 	_ magistrala.Response = (*listDomainsRes)(nil)
+	_ magistrala.Response = (*createRoleRes)(nil)
+	_ magistrala.Response = (*viewRoleRes)(nil)
+	_ magistrala.Response = (*listRolesRes)(nil)
+	_ magistrala.Response = (*listRoleAssignmentsRes)(nil)
+	_ magistrala.Response = (*deleteRoleRes)(nil)
+	_ magistrala.Response = (*assignRoleRes)(nil)
+	_ magistrala.Response = (*unassignRoleRes)(nil)
+	_ magistrala.Response = (*createClaimMappingRes)(nil)
+	_ magistrala.Response = (*viewClaimMappingRes)(nil)
+	_ magistrala.Response = (*listClaimMappingsRes)(nil)
+	_ magistrala.Response = (*deleteClaimMappingRes)(nil)
 )
 
 type createDomainRes struct {
@@ -66,6 +78,54 @@ func (res retrieveDomainPermissionsRes) Empty() bool {
 	return false
 }
 
+type retrieveDomainUsageRes struct {
+	auth.DomainUsage
+}
+
+func (res retrieveDomainUsageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res retrieveDomainUsageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res retrieveDomainUsageRes) Empty() bool {
+	return false
+}
+
+type deleteDomainRes struct {
+	auth.DomainDeletion
+}
+
+func (res deleteDomainRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res deleteDomainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteDomainRes) Empty() bool {
+	return false
+}
+
+type retrieveDomainDeletionRes struct {
+	auth.DomainDeletion
+}
+
+func (res retrieveDomainDeletionRes) Code() int {
+	return http.StatusOK
+}
+
+func (res retrieveDomainDeletionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res retrieveDomainDeletionRes) Empty() bool {
+	return false
+}
+
 type updateDomainRes struct {
 	auth.Domain
 }
@@ -183,3 +243,175 @@ func (res listUserDomainsRes) Headers() map[string]string {
 func (res listUserDomainsRes) Empty() bool {
 	return false
 }
+
+type createRoleRes struct {
+	auth.Role
+}
+
+func (res createRoleRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res createRoleRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/domains/%s/roles/%s", res.DomainID, res.ID),
+	}
+}
+
+func (res createRoleRes) Empty() bool {
+	return false
+}
+
+type viewRoleRes struct {
+	auth.Role
+}
+
+func (res viewRoleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewRoleRes) Empty() bool {
+	return false
+}
+
+type listRolesRes struct {
+	auth.RolesPage
+}
+
+func (res listRolesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRolesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRolesRes) Empty() bool {
+	return false
+}
+
+type listRoleAssignmentsRes struct {
+	Assignments []auth.RoleAssignment `json:"assignments"`
+}
+
+func (res listRoleAssignmentsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRoleAssignmentsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRoleAssignmentsRes) Empty() bool {
+	return false
+}
+
+type deleteRoleRes struct{}
+
+func (res deleteRoleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteRoleRes) Empty() bool {
+	return true
+}
+
+type assignRoleRes struct{}
+
+func (res assignRoleRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res assignRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res assignRoleRes) Empty() bool {
+	return true
+}
+
+type unassignRoleRes struct{}
+
+func (res unassignRoleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unassignRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unassignRoleRes) Empty() bool {
+	return true
+}
+
+type createClaimMappingRes struct {
+	auth.ClaimMapping
+}
+
+func (res createClaimMappingRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res createClaimMappingRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/domains/%s/claim-mappings/%s", res.DomainID, res.ID),
+	}
+}
+
+func (res createClaimMappingRes) Empty() bool {
+	return false
+}
+
+type viewClaimMappingRes struct {
+	auth.ClaimMapping
+}
+
+func (res viewClaimMappingRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewClaimMappingRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewClaimMappingRes) Empty() bool {
+	return false
+}
+
+type listClaimMappingsRes struct {
+	auth.ClaimMappingsPage
+}
+
+func (res listClaimMappingsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listClaimMappingsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listClaimMappingsRes) Empty() bool {
+	return false
+}
+
+type deleteClaimMappingRes struct{}
+
+func (res deleteClaimMappingRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteClaimMappingRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteClaimMappingRes) Empty() bool {
+	return true
+}
@@ -17,6 +17,7 @@ import (
 func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	mux.Route("/domains", func(r chi.Router) {
@@ -49,6 +50,13 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 				opts...,
 			), "view_domain_permissions").ServeHTTP)
 
+			r.Get("/usage", otelhttp.NewHandler(kithttp.NewServer(
+				retrieveDomainUsageEndpoint(svc),
+				decodeRetrieveDomainUsageRequest,
+				api.EncodeResponse,
+				opts...,
+			), "view_domain_usage").ServeHTTP)
+
 			r.Patch("/", otelhttp.NewHandler(kithttp.NewServer(
 				updateDomainEndpoint(svc),
 				decodeUpdateDomainRequest,
@@ -56,6 +64,20 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 				opts...,
 			), "update_domain").ServeHTTP)
 
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteDomainEndpoint(svc),
+				decodeDeleteDomainRequest,
+				api.EncodeResponse,
+				opts...,
+			), "delete_domain").ServeHTTP)
+
+			r.Get("/deletion", otelhttp.NewHandler(kithttp.NewServer(
+				retrieveDomainDeletionEndpoint(svc),
+				decodeRetrieveDomainDeletionRequest,
+				api.EncodeResponse,
+				opts...,
+			), "view_domain_deletion").ServeHTTP)
+
 			r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
 				enableDomainEndpoint(svc),
 				decodeEnableDomainRequest,
@@ -92,6 +114,96 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 					opts...,
 				), "unassign_domain_users").ServeHTTP)
 			})
+
+			r.Route("/roles", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createRoleEndpoint(svc),
+					decodeCreateRoleRequest,
+					api.EncodeResponse,
+					opts...,
+				), "create_role").ServeHTTP)
+
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listRolesEndpoint(svc),
+					decodeListRolesRequest,
+					api.EncodeResponse,
+					opts...,
+				), "list_roles").ServeHTTP)
+
+				r.Route("/{roleID}", func(r chi.Router) {
+					r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+						viewRoleEndpoint(svc),
+						decodeRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "view_role").ServeHTTP)
+
+					r.Patch("/", otelhttp.NewHandler(kithttp.NewServer(
+						updateRoleEndpoint(svc),
+						decodeUpdateRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "update_role").ServeHTTP)
+
+					r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+						deleteRoleEndpoint(svc),
+						decodeRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "delete_role").ServeHTTP)
+
+					r.Post("/assign", otelhttp.NewHandler(kithttp.NewServer(
+						assignRoleEndpoint(svc),
+						decodeAssignRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "assign_role").ServeHTTP)
+
+					r.Post("/unassign", otelhttp.NewHandler(kithttp.NewServer(
+						unassignRoleEndpoint(svc),
+						decodeAssignRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "unassign_role").ServeHTTP)
+
+					r.Get("/assignments", otelhttp.NewHandler(kithttp.NewServer(
+						listRoleAssignmentsEndpoint(svc),
+						decodeRoleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "list_role_assignments").ServeHTTP)
+				})
+			})
+
+			r.Route("/claim-mappings", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createClaimMappingEndpoint(svc),
+					decodeCreateClaimMappingRequest,
+					api.EncodeResponse,
+					opts...,
+				), "create_claim_mapping").ServeHTTP)
+
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listClaimMappingsEndpoint(svc),
+					decodeListClaimMappingsRequest,
+					api.EncodeResponse,
+					opts...,
+				), "list_claim_mappings").ServeHTTP)
+
+				r.Get("/{mappingID}", otelhttp.NewHandler(kithttp.NewServer(
+					viewClaimMappingEndpoint(svc),
+					decodeClaimMappingRequest,
+					api.EncodeResponse,
+					opts...,
+				), "view_claim_mapping").ServeHTTP)
+
+				r.Delete("/{mappingID}", otelhttp.NewHandler(kithttp.NewServer(
+					deleteClaimMappingEndpoint(svc),
+					decodeClaimMappingRequest,
+					api.EncodeResponse,
+					opts...,
+				), "delete_claim_mapping").ServeHTTP)
+			})
 		})
 	})
 	mux.Get("/users/{userID}/domains", otelhttp.NewHandler(kithttp.NewServer(
@@ -22,6 +22,7 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		newKey := auth.Key{
 			IssuedAt: now,
 			Type:     req.Type,
+			Scopes:   req.Scopes,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -36,7 +37,8 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		res := issueKeyRes{
-			Value: tkn.AccessToken,
+			Value:  tkn.AccessToken,
+			Scopes: req.Scopes,
 		}
 
 		return res, nil
@@ -55,18 +57,33 @@ func retrieveEndpoint(svc auth.Service) endpoint.Endpoint {
 		if err != nil {
 			return nil, err
 		}
-		ret := retrieveKeyRes{
-			ID:       key.ID,
-			IssuerID: key.Issuer,
-			Subject:  key.Subject,
-			Type:     key.Type,
-			IssuedAt: key.IssuedAt,
+		return toRetrieveKeyRes(key), nil
+	}
+}
+
+func listKeysEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
 		}
-		if !key.ExpiresAt.IsZero() {
-			ret.ExpiresAt = &key.ExpiresAt
+
+		page, err := svc.ListKeys(ctx, req.token, auth.Page{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+		res := listKeysRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Keys:   []retrieveKeyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, toRetrieveKeyRes(key))
 		}
 
-		return ret, nil
+		return res, nil
 	}
 }
 
@@ -85,3 +102,30 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 		return revokeKeyRes{}, nil
 	}
 }
+
+func jwksEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		keys, err := svc.RetrieveJWKS(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwksRes{Keys: keys}, nil
+	}
+}
+
+func toRetrieveKeyRes(key auth.Key) retrieveKeyRes {
+	ret := retrieveKeyRes{
+		ID:       key.ID,
+		IssuerID: key.Issuer,
+		Subject:  key.Subject,
+		Type:     key.Type,
+		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
+	}
+	if !key.ExpiresAt.IsZero() {
+		ret.ExpiresAt = &key.ExpiresAt
+	}
+
+	return ret
+}
@@ -5,6 +5,8 @@ package keys_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +24,7 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,6 +42,7 @@ const (
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scopes   []auth.Scope  `json:"scopes,omitempty"`
 }
 
 type testRequest struct {
@@ -72,9 +76,17 @@ func newService() (auth.Service, *mocks.KeyRepository) {
 	drepo := new(mocks.DomainsRepository)
 	idProvider := uuid.NewMock()
 
+	srepo := new(mocks.SessionRepository)
+	srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	trepo := new(mocks.TokenRepository)
+	trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+	trepo.On("Generation", mock.Anything, mock.Anything).Return(uint64(0), nil)
+	crepo := new(mocks.ConditionsRepository)
+	crepo.On("Retrieve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
 	t := jwt.New([]byte(secret))
 
-	return auth.New(krepo, drepo, idProvider, t, prepo, loginDuration, refreshDuration, invalidDuration), krepo
+	return auth.New(krepo, drepo, srepo, trepo, new(mocks.RolesRepository), crepo, new(mocks.ClaimMappingsRepository), new(mocks.UsageRepository), idProvider, t, prepo, loginDuration, refreshDuration, invalidDuration), krepo
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -102,6 +114,7 @@ func TestIssue(t *testing.T) {
 	lk := issueRequest{Type: uint32(auth.AccessKey)}
 	ak := issueRequest{Type: uint32(auth.APIKey), Duration: time.Hour}
 	rk := issueRequest{Type: uint32(auth.RecoveryKey)}
+	sk := issueRequest{Type: uint32(auth.APIKey), Scopes: []auth.Scope{{Operation: "things:view", EntityIDs: []string{id}}}}
 
 	cases := []struct {
 		desc   string
@@ -110,6 +123,27 @@ func TestIssue(t *testing.T) {
 		token  string
 		status int
 	}{
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  token.AccessToken,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(issueRequest{Type: uint32(auth.APIKey), Scopes: []auth.Scope{{Operation: "things:read"}}}),
+			ct:     contentType,
+			token:  token.AccessToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue scoped recovery key",
+			req:    toJSON(issueRequest{Type: uint32(auth.RecoveryKey), Scopes: sk.Scopes}),
+			ct:     contentType,
+			token:  token.AccessToken,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue login key with empty token",
 			req:    toJSON(lk),
@@ -273,6 +307,94 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestList(t *testing.T) {
+	svc, krepo := newService()
+	token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	keys := []auth.Key{
+		{ID: id, Type: auth.APIKey, Subject: id, Scopes: []auth.Scope{{Operation: "channels:publish"}}},
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "list keys",
+			url:    fmt.Sprintf("%s/keys", ts.URL),
+			token:  token.AccessToken,
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list keys with limit",
+			url:    fmt.Sprintf("%s/keys?offset=0&limit=5", ts.URL),
+			token:  token.AccessToken,
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list keys with invalid limit",
+			url:    fmt.Sprintf("%s/keys?limit=1000", ts.URL),
+			token:  token.AccessToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid offset",
+			url:    fmt.Sprintf("%s/keys?offset=invalid", ts.URL),
+			token:  token.AccessToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid token",
+			url:    fmt.Sprintf("%s/keys", ts.URL),
+			token:  "wrong",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list keys with empty token",
+			url:    fmt.Sprintf("%s/keys", ts.URL),
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		repocall := krepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(auth.KeyPage{Total: 1, Keys: keys}, nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Total uint64 `json:"total"`
+				Keys  []struct {
+					ID     string       `json:"id"`
+					Scopes []auth.Scope `json:"scopes"`
+				} `json:"keys"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+			assert.Equal(t, keys[0].Scopes, body.Keys[0].Scopes, fmt.Sprintf("%s: expected scopes %v got %v", tc.desc, keys[0].Scopes, body.Keys[0].Scopes))
+		}
+		repocall.Unset()
+	}
+}
+
 func TestRevoke(t *testing.T) {
 	svc, krepo := newService()
 	token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
@@ -334,3 +456,64 @@ func TestRevoke(t *testing.T) {
 		repocall.Unset()
 	}
 }
+
+func TestJWKS(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	signingKey, err := jwk.FromRaw(edKey)
+	assert.Nil(t, err, fmt.Sprintf("creating JWK expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric(signingKey, nil, nil)
+	assert.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	symmetricSvc, _ := newService()
+	asymmetricSvc := auth.New(new(mocks.KeyRepository), new(mocks.DomainsRepository), new(mocks.SessionRepository), new(mocks.TokenRepository), new(mocks.RolesRepository), new(mocks.ConditionsRepository), new(mocks.ClaimMappingsRepository), new(mocks.UsageRepository), uuid.NewMock(), tokenizer, new(mocks.PolicyAgent), loginDuration, refreshDuration, invalidDuration)
+
+	cases := []struct {
+		desc   string
+		svc    auth.Service
+		status int
+		keys   []auth.JWK
+	}{
+		{
+			desc:   "retrieve JWKS of asymmetric keys",
+			svc:    asymmetricSvc,
+			status: http.StatusOK,
+			keys: []auth.JWK{{
+				KeyID:     signingKey.KeyID(),
+				KeyType:   "OKP",
+				Algorithm: "EdDSA",
+				Use:       "sig",
+				Curve:     "Ed25519",
+			}},
+		},
+		{
+			desc:   "retrieve JWKS of symmetric key",
+			svc:    symmetricSvc,
+			status: http.StatusOK,
+			keys:   []auth.JWK{},
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		var body struct {
+			Keys []auth.JWK `json:"keys"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		for i := range body.Keys {
+			assert.NotEmpty(t, body.Keys[i].X, fmt.Sprintf("%s: expected public key", tc.desc))
+			body.Keys[i].X = ""
+		}
+		assert.Equal(t, tc.keys, body.Keys, fmt.Sprintf("%s: expected keys %v got %v", tc.desc, tc.keys, body.Keys))
+		ts.Close()
+	}
+}
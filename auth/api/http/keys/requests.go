@@ -7,13 +7,16 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
 )
 
 type issueKeyReq struct {
 	token    string
 	Type     auth.KeyType  `json:"type,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
+	Scopes   []auth.Scope  `json:"scopes,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
@@ -28,6 +31,34 @@ func (req issueKeyReq) validate() error {
 		return apiutil.ErrInvalidAPIKey
 	}
 
+	// Only API keys can be scoped.
+	if len(req.Scopes) > 0 && req.Type != auth.APIKey {
+		return apiutil.ErrInvalidScope
+	}
+	for _, scope := range req.Scopes {
+		if err := scope.Validate(); err != nil {
+			return errors.Wrap(apiutil.ErrInvalidScope, err)
+		}
+	}
+
+	return nil
+}
+
+type listKeysReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
 	return nil
 }
 
@@ -14,13 +14,16 @@ import (
 var (
 	_ magistrala.Response = (*issueKeyRes)(nil)
 	_ magistrala.Response = (*revokeKeyRes)(nil)
+	_ magistrala.Response = (*listKeysRes)(nil)
+	_ magistrala.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	Value     string     `json:"value,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Value     string       `json:"value,omitempty"`
+	IssuedAt  time.Time    `json:"issued_at,omitempty"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	Scopes    []auth.Scope `json:"scopes,omitempty"`
 }
 
 func (res issueKeyRes) Code() int {
@@ -42,6 +45,7 @@ type retrieveKeyRes struct {
 	Type      auth.KeyType `json:"type,omitempty"`
 	IssuedAt  time.Time    `json:"issued_at,omitempty"`
 	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	Scopes    []auth.Scope `json:"scopes,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -56,6 +60,25 @@ func (res retrieveKeyRes) Empty() bool {
 	return false
 }
 
+type listKeysRes struct {
+	Total  uint64           `json:"total"`
+	Offset uint64           `json:"offset"`
+	Limit  uint64           `json:"limit"`
+	Keys   []retrieveKeyRes `json:"keys"`
+}
+
+func (res listKeysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listKeysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listKeysRes) Empty() bool {
+	return false
+}
+
 type revokeKeyRes struct{}
 
 func (res revokeKeyRes) Code() int {
@@ -69,3 +92,22 @@ func (res revokeKeyRes) Headers() map[string]string {
 func (res revokeKeyRes) Empty() bool {
 	return true
 }
+
+type jwksRes struct {
+	Keys []auth.JWK `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	// Verifiers refresh the keys periodically, and on unknown key IDs.
+	return map[string]string{
+		"Cache-Control": "public, max-age=300",
+	}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
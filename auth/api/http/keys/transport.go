@@ -24,6 +24,7 @@ const contentType = "application/json"
 func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 	mux.Route("/keys", func(r chi.Router) {
 		r.Post("/", kithttp.NewServer(
@@ -33,6 +34,13 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 			opts...,
 		).ServeHTTP)
 
+		r.Get("/", kithttp.NewServer(
+			listKeysEndpoint(svc),
+			decodeListKeysReq,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
 		r.Get("/{id}", kithttp.NewServer(
 			(retrieveEndpoint(svc)),
 			decodeKeyReq,
@@ -47,6 +55,14 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 			opts...,
 		).ServeHTTP)
 	})
+
+	mux.Get("/.well-known/jwks.json", kithttp.NewServer(
+		jwksEndpoint(svc),
+		decodeJWKSReq,
+		api.EncodeResponse,
+		opts...,
+	).ServeHTTP)
+
 	return mux
 }
 
@@ -63,6 +79,28 @@ func decodeIssue(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListKeysReq(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listKeysReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: o,
+		limit:  l,
+	}
+	return req, nil
+}
+
+func decodeJWKSReq(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
 func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := keyReq{
 		token: apiutil.ExtractBearerToken(r),
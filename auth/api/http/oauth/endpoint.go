@@ -0,0 +1,27 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func tokenEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tokenReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tkn, err := svc.ClientCredentials(ctx, req.clientID, req.clientSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{AccessToken: tkn.AccessToken, TokenType: bearerTokenType}, nil
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	httpapi "github.com/absmach/magistrala/auth/api/http/oauth"
+	"github.com/absmach/magistrala/auth/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	formContentType = "application/x-www-form-urlencoded"
+	clientID        = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	clientSecret    = "secret"
+	accessToken     = "token"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	basicAuth   bool
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	if tr.basicAuth {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	return tr.client.Do(req)
+}
+
+func newOAuthServer() (*httptest.Server, *mocks.Service) {
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+	svc := new(mocks.Service)
+	httpapi.MakeHandler(svc, mux, logger)
+	return httptest.NewServer(mux), svc
+}
+
+func TestToken(t *testing.T) {
+	ts, svc := newOAuthServer()
+	defer ts.Close()
+
+	credentials := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	}
+
+	cases := []struct {
+		desc        string
+		data        url.Values
+		contentType string
+		basicAuth   bool
+		svcErr      error
+		status      int
+		code        string
+	}{
+		{
+			desc:        "exchange client credentials from form",
+			data:        credentials,
+			contentType: formContentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "exchange client credentials from basic auth",
+			data:        url.Values{"grant_type": {"client_credentials"}},
+			contentType: formContentType,
+			basicAuth:   true,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "exchange client credentials with invalid content type",
+			data:        credentials,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			code:        "invalid_request",
+		},
+		{
+			desc:        "exchange client credentials with unsupported grant type",
+			data:        url.Values{"grant_type": {"password"}, "client_id": {clientID}, "client_secret": {clientSecret}},
+			contentType: formContentType,
+			status:      http.StatusBadRequest,
+			code:        "unsupported_grant_type",
+		},
+		{
+			desc:        "exchange client credentials without secret",
+			data:        url.Values{"grant_type": {"client_credentials"}, "client_id": {clientID}},
+			contentType: formContentType,
+			status:      http.StatusUnauthorized,
+			code:        "invalid_client",
+		},
+		{
+			desc:        "exchange invalid client credentials",
+			data:        credentials,
+			contentType: formContentType,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			code:        "invalid_client",
+		},
+		{
+			desc:        "exchange scoped client credentials",
+			data:        credentials,
+			contentType: formContentType,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusBadRequest,
+			code:        "unauthorized_client",
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/oauth/token", ts.URL),
+			contentType: tc.contentType,
+			basicAuth:   tc.basicAuth,
+			body:        strings.NewReader(tc.data.Encode()),
+		}
+		svcCall := svc.On("ClientCredentials", mock.Anything, clientID, clientSecret).Return(auth.Token{AccessToken: accessToken, AccessType: "Bearer"}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		var body struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			Error       string `json:"error"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+		switch tc.status {
+		case http.StatusOK:
+			assert.Equal(t, accessToken, body.AccessToken, fmt.Sprintf("%s: expected access token %s got %s", tc.desc, accessToken, body.AccessToken))
+			assert.Equal(t, "Bearer", body.TokenType, fmt.Sprintf("%s: expected token type Bearer got %s", tc.desc, body.TokenType))
+			assert.Equal(t, "no-store", res.Header.Get("Cache-Control"), fmt.Sprintf("%s: expected no-store cache control", tc.desc))
+		default:
+			assert.Equal(t, tc.code, body.Error, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.code, body.Error))
+		}
+		svcCall.Unset()
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import "github.com/absmach/magistrala/pkg/errors"
+
+const clientCredentialsGrant = "client_credentials"
+
+var (
+	errInvalidRequest       = errors.New("invalid token request")
+	errUnsupportedGrantType = errors.New("unsupported grant type")
+	errMissingCredentials   = errors.New("missing client credentials")
+)
+
+type tokenReq struct {
+	grantType    string
+	clientID     string
+	clientSecret string
+}
+
+func (req tokenReq) validate() error {
+	if req.grantType != clientCredentialsGrant {
+		return errUnsupportedGrantType
+	}
+	if req.clientID == "" || req.clientSecret == "" {
+		return errMissingCredentials
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala"
+)
+
+const bearerTokenType = "Bearer"
+
+var _ magistrala.Response = (*tokenRes)(nil)
+
+type tokenRes struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+func (res tokenRes) Code() int {
+	return http.StatusOK
+}
+
+// Headers prevent caching of the token response, as required by RFC 6749.
+func (res tokenRes) Headers() map[string]string {
+	return map[string]string{
+		"Cache-Control": "no-store",
+		"Pragma":        "no-cache",
+	}
+}
+
+func (res tokenRes) Empty() bool {
+	return false
+}
+
+// errorRes is the OAuth2 error response.
+type errorRes struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
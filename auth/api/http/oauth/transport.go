@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+const formContentType = "application/x-www-form-urlencoded"
+
+// MakeHandler returns a HTTP handler for the OAuth2 token endpoint.
+func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+	mux.Post("/oauth/token", kithttp.NewServer(
+		tokenEndpoint(svc),
+		decodeTokenReq,
+		api.EncodeResponse,
+		opts...,
+	).ServeHTTP)
+
+	return mux
+}
+
+// decodeTokenReq decodes the token request form. The client credentials
+// are read from the HTTP Basic authentication header, or from the form
+// if the header is not present.
+func decodeTokenReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), formContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, errors.Wrap(errInvalidRequest, err)
+	}
+
+	req := tokenReq{
+		grantType:    r.PostForm.Get("grant_type"),
+		clientID:     r.PostForm.Get("client_id"),
+		clientSecret: r.PostForm.Get("client_secret"),
+	}
+	if id, secret, ok := r.BasicAuth(); ok {
+		var err error
+		if req.clientID, err = url.QueryUnescape(id); err != nil {
+			return nil, errors.Wrap(errInvalidRequest, err)
+		}
+		if req.clientSecret, err = url.QueryUnescape(secret); err != nil {
+			return nil, errors.Wrap(errInvalidRequest, err)
+		}
+	}
+
+	return req, nil
+}
+
+// encodeError encodes the error response as defined in RFC 6749.
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	code, status := "server_error", http.StatusInternalServerError
+	switch {
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType),
+		errors.Contains(err, errInvalidRequest):
+		code, status = "invalid_request", http.StatusBadRequest
+	case errors.Contains(err, errUnsupportedGrantType):
+		code, status = "unsupported_grant_type", http.StatusBadRequest
+	case errors.Contains(err, errMissingCredentials),
+		errors.Contains(err, svcerr.ErrAuthentication):
+		w.Header().Set("WWW-Authenticate", `Basic realm="magistrala"`)
+		code, status = "invalid_client", http.StatusUnauthorized
+	case errors.Contains(err, svcerr.ErrAuthorization):
+		code, status = "unauthorized_client", http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", api.ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(errorRes{Error: code, Description: err.Error()}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
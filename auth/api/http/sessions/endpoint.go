@@ -0,0 +1,74 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sessions, err := svc.ListSessions(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+		res := listSessionsRes{
+			Total:    uint64(len(sessions)),
+			Sessions: []sessionRes{},
+		}
+		for _, s := range sessions {
+			sr := sessionRes{
+				ID:        s.ID,
+				DomainID:  s.Domain,
+				CreatedAt: s.CreatedAt,
+				ExpiresAt: s.ExpiresAt,
+				Current:   s.Current,
+			}
+			if !s.RefreshedAt.IsZero() {
+				sr.RefreshedAt = &s.RefreshedAt
+			}
+			res.Sessions = append(res.Sessions, sr)
+		}
+
+		return res, nil
+	}
+}
+
+func revokeSessionEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSession(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeSessionRes{}, nil
+	}
+}
+
+func revokeSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSessions(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeSessionRes{}, nil
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	httpapi "github.com/absmach/magistrala/auth/api/http/sessions"
+	"github.com/absmach/magistrala/auth/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken   = "token"
+	inValidToken = "invalid"
+	validID      = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	sessions     = []auth.Session{
+		{
+			ID:        validID,
+			User:      "user",
+			CreatedAt: time.Now().Add(-time.Hour),
+			ExpiresAt: time.Now().Add(time.Hour),
+			Current:   true,
+		},
+		{
+			ID:          "a2b0e5d8-0d2f-4b6c-9d5e-8f7b7b0e1c4a",
+			User:        "user",
+			CreatedAt:   time.Now().Add(-2 * time.Hour),
+			RefreshedAt: time.Now().Add(-time.Hour),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+	}
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+	body   io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	req.Header.Set("Referer", "http://localhost")
+
+	return tr.client.Do(req)
+}
+
+type sessionsPageRes struct {
+	Total    uint64 `json:"total"`
+	Sessions []struct {
+		ID      string `json:"id"`
+		Current bool   `json:"current"`
+	} `json:"sessions"`
+}
+
+func newSessionsServer() (*httptest.Server, *mocks.Service) {
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+	svc := new(mocks.Service)
+	httpapi.MakeHandler(svc, mux, logger)
+	return httptest.NewServer(mux), svc
+}
+
+func TestListSessions(t *testing.T) {
+	ss, svc := newSessionsServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		sessions []auth.Session
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list sessions",
+			token:    validToken,
+			sessions: sessions,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list sessions with no sessions",
+			token:    validToken,
+			sessions: []auth.Session{},
+			status:   http.StatusOK,
+		},
+		{
+			desc:   "list sessions with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list sessions with invalid token",
+			token:  inValidToken,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/sessions", ss.URL),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListSessions", mock.Anything, tc.token).Return(tc.sessions, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var page sessionsPageRes
+			err := json.NewDecoder(res.Body).Decode(&page)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, uint64(len(tc.sessions)), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, len(tc.sessions), page.Total))
+			for i, s := range page.Sessions {
+				assert.Equal(t, tc.sessions[i].ID, s.ID, fmt.Sprintf("%s: expected session %s got %s", tc.desc, tc.sessions[i].ID, s.ID))
+				assert.Equal(t, tc.sessions[i].Current, s.Current, fmt.Sprintf("%s: unexpected current flag for session %s", tc.desc, s.ID))
+			}
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	ss, svc := newSessionsServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "revoke session",
+			token:  validToken,
+			id:     validID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke session with empty token",
+			token:  "",
+			id:     validID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke session with invalid token",
+			token:  inValidToken,
+			id:     validID,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke non-existing session",
+			token:  validToken,
+			id:     "invalid",
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions/%s", ss.URL, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RevokeSession", mock.Anything, tc.token, tc.id).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	ss, svc := newSessionsServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "revoke all sessions",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke all sessions with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke all sessions with invalid token",
+			token:  inValidToken,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions", ss.URL),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RevokeSessions", mock.Anything, tc.token).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import "github.com/absmach/magistrala/pkg/apiutil"
+
+type sessionsReq struct {
+	token string
+}
+
+func (req sessionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type sessionReq struct {
+	token string
+	id    string
+}
+
+func (req sessionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala"
+)
+
+var (
+	_ magistrala.Response = (*listSessionsRes)(nil)
+	_ magistrala.Response = (*revokeSessionRes)(nil)
+)
+
+type sessionRes struct {
+	ID          string     `json:"id"`
+	DomainID    string     `json:"domain_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RefreshedAt *time.Time `json:"refreshed_at,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	Current     bool       `json:"current"`
+}
+
+type listSessionsRes struct {
+	Total    uint64       `json:"total"`
+	Sessions []sessionRes `json:"sessions"`
+}
+
+func (res listSessionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listSessionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listSessionsRes) Empty() bool {
+	return false
+}
+
+type revokeSessionRes struct{}
+
+func (res revokeSessionRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeSessionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeSessionRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
+	}
+	mux.Route("/sessions", func(r chi.Router) {
+		r.Get("/", kithttp.NewServer(
+			listSessionsEndpoint(svc),
+			decodeSessionsReq,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
+		r.Delete("/", kithttp.NewServer(
+			revokeSessionsEndpoint(svc),
+			decodeSessionsReq,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
+		r.Delete("/{id}", kithttp.NewServer(
+			revokeSessionEndpoint(svc),
+			decodeSessionReq,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+	})
+
+	return mux
+}
+
+func decodeSessionsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := sessionsReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+	return req, nil
+}
+
+func decodeSessionReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := sessionReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "id"),
+	}
+	return req, nil
+}
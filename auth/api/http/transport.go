@@ -8,8 +8,11 @@ import (
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/api/http/authz"
 	"github.com/absmach/magistrala/auth/api/http/domains"
 	"github.com/absmach/magistrala/auth/api/http/keys"
+	"github.com/absmach/magistrala/auth/api/http/oauth"
+	"github.com/absmach/magistrala/auth/api/http/sessions"
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -20,6 +23,9 @@ func MakeHandler(svc auth.Service, logger *slog.Logger, instanceID string) http.
 
 	mux = keys.MakeHandler(svc, mux, logger)
 	mux = domains.MakeHandler(svc, mux, logger)
+	mux = sessions.MakeHandler(svc, mux, logger)
+	mux = authz.MakeHandler(svc, mux, logger)
+	mux = oauth.MakeHandler(svc, mux, logger)
 
 	mux.Get("/health", magistrala.Health("auth", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
@@ -230,6 +230,128 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, token string) (id aut
 	return lm.svc.Identify(ctx, token)
 }
 
+func (lm *loggingMiddleware) ListKeys(ctx context.Context, token string, pm auth.Page) (kp auth.KeyPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", kp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List keys failed", args...)
+			return
+		}
+		lm.logger.Info("List keys completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListKeys(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RetrieveJWKS(ctx context.Context) (keys []auth.JWK, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("keys", len(keys)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve JWKS failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve JWKS completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RetrieveJWKS(ctx)
+}
+
+func (lm *loggingMiddleware) ListSessions(ctx context.Context, token string) (sessions []auth.Session, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("sessions", len(sessions)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List sessions failed", args...)
+			return
+		}
+		lm.logger.Info("List sessions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListSessions(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeSession(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("session_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke session failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke session completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeSession(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke sessions failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke sessions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeSessions(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeTokens(ctx context.Context, userID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke tokens failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke tokens completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeTokens(ctx, userID)
+}
+
+func (lm *loggingMiddleware) ClientCredentials(ctx context.Context, clientID, secret string) (tkn auth.Token, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", clientID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Client credentials grant failed", args...)
+			return
+		}
+		lm.logger.Info("Client credentials grant completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ClientCredentials(ctx, clientID, secret)
+}
+
 func (lm *loggingMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -255,6 +377,31 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) (
 	return lm.svc.Authorize(ctx, pr)
 }
 
+func (lm *loggingMiddleware) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (exp auth.PolicyExplanation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("object",
+				slog.String("id", pr.Object),
+				slog.String("type", pr.ObjectType),
+			),
+			slog.Group("subject",
+				slog.String("id", pr.Subject),
+				slog.String("type", pr.SubjectType),
+			),
+			slog.String("permission", pr.Permission),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Explain policy failed", args...)
+			return
+		}
+		args = append(args, slog.Bool("allowed", exp.Allowed))
+		lm.logger.Info("Explain policy completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExplainPolicy(ctx, token, pr)
+}
+
 func (lm *loggingMiddleware) AddPolicy(ctx context.Context, pr auth.PolicyReq) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -330,6 +477,21 @@ func (lm *loggingMiddleware) DeletePolicies(ctx context.Context, prs []auth.Poli
 	return lm.svc.DeletePolicies(ctx, prs)
 }
 
+func (lm *loggingMiddleware) ExpirePolicies(ctx context.Context) (cps []auth.ConditionalPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn(fmt.Sprintf("Expire policies failed after %d expired policies", len(cps)), args...)
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("Expire %d policies completed successfully", len(cps)), args...)
+	}(time.Now())
+	return lm.svc.ExpirePolicies(ctx)
+}
+
 func (lm *loggingMiddleware) CreateDomain(ctx context.Context, token string, d auth.Domain) (do auth.Domain, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -440,7 +602,7 @@ func (lm *loggingMiddleware) ListDomains(ctx context.Context, token string, page
 	return lm.svc.ListDomains(ctx, token, page)
 }
 
-func (lm *loggingMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string) (err error) {
+func (lm *loggingMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string, cond *auth.Condition) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -455,7 +617,7 @@ func (lm *loggingMiddleware) AssignUsers(ctx context.Context, token, id string,
 		}
 		lm.logger.Info("Assign users to domain completed successfully", args...)
 	}(time.Now())
-	return lm.svc.AssignUsers(ctx, token, id, userIds, relation)
+	return lm.svc.AssignUsers(ctx, token, id, userIds, relation, cond)
 }
 
 func (lm *loggingMiddleware) UnassignUser(ctx context.Context, token, id, userID string) (err error) {
@@ -491,6 +653,145 @@ func (lm *loggingMiddleware) ListUserDomains(ctx context.Context, token, userID
 	return lm.svc.ListUserDomains(ctx, token, userID, page)
 }
 
+func (lm *loggingMiddleware) AddDomainMember(ctx context.Context, domainID, userID, relation string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("user_id", userID),
+			slog.String("relation", relation),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add domain member failed", args...)
+			return
+		}
+		lm.logger.Info("Add domain member completed successfully", args...)
+	}(time.Now())
+	return lm.svc.AddDomainMember(ctx, domainID, userID, relation)
+}
+
+func (lm *loggingMiddleware) RetrieveDomainUsage(ctx context.Context, token, id string) (usage auth.DomainUsage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve domain usage failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve domain usage completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveDomainUsage(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) CheckQuota(ctx context.Context, domainID, resource string, count uint64) (limited bool, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("resource", resource),
+			slog.Uint64("count", count),
+			slog.Bool("limited", limited),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Check quota failed", args...)
+			return
+		}
+		lm.logger.Info("Check quota completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CheckQuota(ctx, domainID, resource, count)
+}
+
+func (lm *loggingMiddleware) ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) (reserved []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("resource", resource),
+			slog.Bool("existing", existing),
+			slog.Int("count", len(ids)),
+			slog.Int("reserved", len(reserved)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reserve quota failed", args...)
+			return
+		}
+		lm.logger.Info("Reserve quota completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ReserveQuota(ctx, domainID, resource, existing, ids...)
+}
+
+func (lm *loggingMiddleware) ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("resource", resource),
+			slog.Int("count", len(ids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Release quota failed", args...)
+			return
+		}
+		lm.logger.Info("Release quota completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ReleaseQuota(ctx, domainID, resource, ids...)
+}
+
+func (lm *loggingMiddleware) DeleteDomain(ctx context.Context, token, id string) (dd auth.DomainDeletion, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete domain failed", args...)
+			return
+		}
+		lm.logger.Info("Delete domain completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteDomain(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RetrieveDomainDeletion(ctx context.Context, token, id string) (dd auth.DomainDeletion, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve domain deletion failed", args...)
+			return
+		}
+		args = append(args, slog.String("status", string(dd.Status)))
+		lm.logger.Info("Retrieve domain deletion completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveDomainDeletion(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) PurgeDomains(ctx context.Context, before time.Time) (dds []auth.DomainDeletion, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn(fmt.Sprintf("Purge domains failed after %d processed deletions", len(dds)), args...)
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("Purge %d domains completed successfully", len(dds)), args...)
+	}(time.Now())
+	return lm.svc.PurgeDomains(ctx, before)
+}
+
 func (lm *loggingMiddleware) DeleteEntityPolicies(ctx context.Context, entityType, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -507,3 +808,252 @@ func (lm *loggingMiddleware) DeleteEntityPolicies(ctx context.Context, entityTyp
 	}(time.Now())
 	return lm.svc.DeleteEntityPolicies(ctx, entityType, id)
 }
+
+func (lm *loggingMiddleware) CreateRole(ctx context.Context, token, domainID string, r auth.Role) (ro auth.Role, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("role",
+				slog.String("id", ro.ID),
+				slog.String("name", r.Name),
+				slog.Any("permissions", r.Permissions),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create role failed", args...)
+			return
+		}
+		lm.logger.Info("Create role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CreateRole(ctx, token, domainID, r)
+}
+
+func (lm *loggingMiddleware) RetrieveRole(ctx context.Context, token, domainID, id string) (ro auth.Role, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("role_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve role failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveRole(ctx, token, domainID, id)
+}
+
+func (lm *loggingMiddleware) ListRoleAssignments(ctx context.Context, token, domainID, id string) (ras []auth.RoleAssignment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("role_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List role assignments failed", args...)
+			return
+		}
+		lm.logger.Info("List role assignments completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListRoleAssignments(ctx, token, domainID, id)
+}
+
+func (lm *loggingMiddleware) ListRoles(ctx context.Context, token, domainID string, pm auth.Page) (rp auth.RolesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", rp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List roles failed", args...)
+			return
+		}
+		lm.logger.Info("List roles completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListRoles(ctx, token, domainID, pm)
+}
+
+func (lm *loggingMiddleware) UpdateRole(ctx context.Context, token, domainID, id string, r auth.RoleReq) (ro auth.Role, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("role",
+				slog.String("id", id),
+				slog.String("name", ro.Name),
+				slog.Any("permissions", ro.Permissions),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update role failed", args...)
+			return
+		}
+		lm.logger.Info("Update role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UpdateRole(ctx, token, domainID, id, r)
+}
+
+func (lm *loggingMiddleware) DeleteRole(ctx context.Context, token, domainID, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("role_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete role failed", args...)
+			return
+		}
+		lm.logger.Info("Delete role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteRole(ctx, token, domainID, id)
+}
+
+func (lm *loggingMiddleware) AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("role_id", id),
+			slog.String("entity", entity),
+			slog.String("entity_id", entityID),
+			slog.Any("user_ids", userIDs),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Assign role failed", args...)
+			return
+		}
+		lm.logger.Info("Assign role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.AssignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (lm *loggingMiddleware) UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("role_id", id),
+			slog.String("entity", entity),
+			slog.String("entity_id", entityID),
+			slog.Any("user_ids", userIDs),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unassign role failed", args...)
+			return
+		}
+		lm.logger.Info("Unassign role completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UnassignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (lm *loggingMiddleware) CreateClaimMapping(ctx context.Context, token, domainID string, m auth.ClaimMapping) (mapping auth.ClaimMapping, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("mapping",
+				slog.String("id", mapping.ID),
+				slog.String("provider", m.Provider),
+				slog.String("group", m.Group),
+				slog.String("relation", m.Relation),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create claim mapping failed", args...)
+			return
+		}
+		lm.logger.Info("Create claim mapping completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CreateClaimMapping(ctx, token, domainID, m)
+}
+
+func (lm *loggingMiddleware) RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (m auth.ClaimMapping, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("mapping_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve claim mapping failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve claim mapping completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveClaimMapping(ctx, token, domainID, id)
+}
+
+func (lm *loggingMiddleware) ListClaimMappings(ctx context.Context, token, domainID string, pm auth.Page) (mp auth.ClaimMappingsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", mp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List claim mappings failed", args...)
+			return
+		}
+		lm.logger.Info("List claim mappings completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListClaimMappings(ctx, token, domainID, pm)
+}
+
+func (lm *loggingMiddleware) DeleteClaimMapping(ctx context.Context, token, domainID, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("mapping_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete claim mapping failed", args...)
+			return
+		}
+		lm.logger.Info("Delete claim mapping completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteClaimMapping(ctx, token, domainID, id)
+}
+
+func (lm *loggingMiddleware) ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+			slog.String("provider", provider),
+			slog.Any("groups", groups),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Apply claim mappings failed", args...)
+			return
+		}
+		lm.logger.Info("Apply claim mappings completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ApplyClaimMappings(ctx, userID, provider, groups)
+}
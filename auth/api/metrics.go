@@ -127,6 +127,69 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return ms.svc.Identify(ctx, token)
 }
 
+func (ms *metricsMiddleware) ListKeys(ctx context.Context, token string, pm auth.Page) (auth.KeyPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListKeys(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RetrieveJWKS(ctx context.Context) ([]auth.JWK, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_jwks").Add(1)
+		ms.latency.With("method", "retrieve_jwks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveJWKS(ctx)
+}
+
+func (ms *metricsMiddleware) ListSessions(ctx context.Context, token string) ([]auth.Session, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_sessions").Add(1)
+		ms.latency.With("method", "list_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListSessions(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeSession(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_session").Add(1)
+		ms.latency.With("method", "revoke_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSession(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSessions(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeTokens(ctx context.Context, userID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_tokens").Add(1)
+		ms.latency.With("method", "revoke_tokens").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeTokens(ctx, userID)
+}
+
+func (ms *metricsMiddleware) ClientCredentials(ctx context.Context, clientID, secret string) (auth.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "client_credentials").Add(1)
+		ms.latency.With("method", "client_credentials").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ClientCredentials(ctx, clientID, secret)
+}
+
 func (ms *metricsMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
@@ -135,6 +198,14 @@ func (ms *metricsMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) e
 	return ms.svc.Authorize(ctx, pr)
 }
 
+func (ms *metricsMiddleware) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "explain_policy").Add(1)
+		ms.latency.With("method", "explain_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExplainPolicy(ctx, token, pr)
+}
+
 func (ms *metricsMiddleware) AddPolicy(ctx context.Context, pr auth.PolicyReq) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "add_policy").Add(1)
@@ -168,6 +239,14 @@ func (ms *metricsMiddleware) DeletePolicies(ctx context.Context, prs []auth.Poli
 	return ms.svc.DeletePolicies(ctx, prs)
 }
 
+func (ms *metricsMiddleware) ExpirePolicies(ctx context.Context) ([]auth.ConditionalPolicy, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire_policies").Add(1)
+		ms.latency.With("method", "expire_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExpirePolicies(ctx)
+}
+
 func (ms *metricsMiddleware) CreateDomain(ctx context.Context, token string, d auth.Domain) (auth.Domain, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_domain").Add(1)
@@ -216,12 +295,12 @@ func (ms *metricsMiddleware) ListDomains(ctx context.Context, token string, page
 	return ms.svc.ListDomains(ctx, token, page)
 }
 
-func (ms *metricsMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string) error {
+func (ms *metricsMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string, cond *auth.Condition) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign_users").Add(1)
 		ms.latency.With("method", "assign_users").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.AssignUsers(ctx, token, id, userIds, relation)
+	return ms.svc.AssignUsers(ctx, token, id, userIds, relation, cond)
 }
 
 func (ms *metricsMiddleware) UnassignUser(ctx context.Context, token, id, userID string) error {
@@ -240,6 +319,70 @@ func (ms *metricsMiddleware) ListUserDomains(ctx context.Context, token, userID
 	return ms.svc.ListUserDomains(ctx, token, userID, page)
 }
 
+func (ms *metricsMiddleware) AddDomainMember(ctx context.Context, domainID, userID, relation string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_domain_member").Add(1)
+		ms.latency.With("method", "add_domain_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AddDomainMember(ctx, domainID, userID, relation)
+}
+
+func (ms *metricsMiddleware) RetrieveDomainUsage(ctx context.Context, token, id string) (auth.DomainUsage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_domain_usage").Add(1)
+		ms.latency.With("method", "retrieve_domain_usage").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveDomainUsage(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) CheckQuota(ctx context.Context, domainID, resource string, count uint64) (bool, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "check_quota").Add(1)
+		ms.latency.With("method", "check_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CheckQuota(ctx, domainID, resource, count)
+}
+
+func (ms *metricsMiddleware) ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reserve_quota").Add(1)
+		ms.latency.With("method", "reserve_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ReserveQuota(ctx, domainID, resource, existing, ids...)
+}
+
+func (ms *metricsMiddleware) ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "release_quota").Add(1)
+		ms.latency.With("method", "release_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ReleaseQuota(ctx, domainID, resource, ids...)
+}
+
+func (ms *metricsMiddleware) DeleteDomain(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_domain").Add(1)
+		ms.latency.With("method", "delete_domain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteDomain(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) RetrieveDomainDeletion(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_domain_deletion").Add(1)
+		ms.latency.With("method", "retrieve_domain_deletion").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveDomainDeletion(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) PurgeDomains(ctx context.Context, before time.Time) ([]auth.DomainDeletion, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "purge_domains").Add(1)
+		ms.latency.With("method", "purge_domains").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.PurgeDomains(ctx, before)
+}
+
 func (ms *metricsMiddleware) DeleteEntityPolicies(ctx context.Context, entityType, id string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "delete_entity_policies").Add(1)
@@ -247,3 +390,107 @@ func (ms *metricsMiddleware) DeleteEntityPolicies(ctx context.Context, entityTyp
 	}(time.Now())
 	return ms.svc.DeleteEntityPolicies(ctx, entityType, id)
 }
+
+func (ms *metricsMiddleware) CreateRole(ctx context.Context, token, domainID string, r auth.Role) (auth.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_role").Add(1)
+		ms.latency.With("method", "create_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateRole(ctx, token, domainID, r)
+}
+
+func (ms *metricsMiddleware) RetrieveRole(ctx context.Context, token, domainID, id string) (auth.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_role").Add(1)
+		ms.latency.With("method", "retrieve_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveRole(ctx, token, domainID, id)
+}
+
+func (ms *metricsMiddleware) ListRoleAssignments(ctx context.Context, token, domainID, id string) ([]auth.RoleAssignment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_role_assignments").Add(1)
+		ms.latency.With("method", "list_role_assignments").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListRoleAssignments(ctx, token, domainID, id)
+}
+
+func (ms *metricsMiddleware) ListRoles(ctx context.Context, token, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_roles").Add(1)
+		ms.latency.With("method", "list_roles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListRoles(ctx, token, domainID, pm)
+}
+
+func (ms *metricsMiddleware) UpdateRole(ctx context.Context, token, domainID, id string, r auth.RoleReq) (auth.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_role").Add(1)
+		ms.latency.With("method", "update_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateRole(ctx, token, domainID, id, r)
+}
+
+func (ms *metricsMiddleware) DeleteRole(ctx context.Context, token, domainID, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_role").Add(1)
+		ms.latency.With("method", "delete_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteRole(ctx, token, domainID, id)
+}
+
+func (ms *metricsMiddleware) AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_role").Add(1)
+		ms.latency.With("method", "assign_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AssignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (ms *metricsMiddleware) UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_role").Add(1)
+		ms.latency.With("method", "unassign_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UnassignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (ms *metricsMiddleware) CreateClaimMapping(ctx context.Context, token, domainID string, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_claim_mapping").Add(1)
+		ms.latency.With("method", "create_claim_mapping").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateClaimMapping(ctx, token, domainID, m)
+}
+
+func (ms *metricsMiddleware) RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (auth.ClaimMapping, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_claim_mapping").Add(1)
+		ms.latency.With("method", "retrieve_claim_mapping").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveClaimMapping(ctx, token, domainID, id)
+}
+
+func (ms *metricsMiddleware) ListClaimMappings(ctx context.Context, token, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_claim_mappings").Add(1)
+		ms.latency.With("method", "list_claim_mappings").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListClaimMappings(ctx, token, domainID, pm)
+}
+
+func (ms *metricsMiddleware) DeleteClaimMapping(ctx context.Context, token, domainID, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_claim_mapping").Add(1)
+		ms.latency.With("method", "delete_claim_mapping").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteClaimMapping(ctx, token, domainID, id)
+}
+
+func (ms *metricsMiddleware) ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "apply_claim_mappings").Add(1)
+		ms.latency.With("method", "apply_claim_mappings").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ApplyClaimMappings(ctx, userID, provider, groups)
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache contains Redis implementations of the auth session
+// and revoked token repositories.
+package cache
@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionPrefix  = "session"
+	sessionsPrefix = "sessions"
+)
+
+var _ auth.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	client *redis.Client
+}
+
+// NewSessionRepository returns redis session repository implementation.
+// Each session is stored under its own key expiring with the session,
+// and the sessions of the user are indexed in a sorted set scored by
+// the session expiration time.
+func NewSessionRepository(client *redis.Client) auth.SessionRepository {
+	return &sessionRepository{
+		client: client,
+	}
+}
+
+func (sr *sessionRepository) Save(ctx context.Context, session auth.Session) error {
+	if session.ID == "" || session.User == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("session id or user id is empty"))
+	}
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("session is expired"))
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	index := sessionsKey(session.User)
+	if _, err := sr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(session.User, session.ID), data, ttl)
+		pipe.ZRemRangeByScore(ctx, index, "-inf", now())
+		pipe.ZAdd(ctx, index, redis.Z{Score: float64(session.ExpiresAt.Unix()), Member: session.ID})
+		return nil
+	}); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	// The index expires with the last session of the user.
+	last, err := sr.client.ZRevRangeWithScores(ctx, index, 0, 0).Result()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if len(last) > 0 {
+		if err := sr.client.ExpireAt(ctx, index, time.Unix(int64(last[0].Score), 0)).Err(); err != nil {
+			return errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) Retrieve(ctx context.Context, userID, id string) (auth.Session, error) {
+	if userID == "" || id == "" {
+		return auth.Session{}, repoerr.ErrNotFound
+	}
+
+	data, err := sr.client.Get(ctx, sessionKey(userID, id)).Bytes()
+	// Redis returns Nil Reply when key does not exist.
+	if err == redis.Nil {
+		return auth.Session{}, repoerr.ErrNotFound
+	}
+	if err != nil {
+		return auth.Session{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	var session auth.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return auth.Session{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	return session, nil
+}
+
+func (sr *sessionRepository) RetrieveAll(ctx context.Context, userID string) ([]auth.Session, error) {
+	index := sessionsKey(userID)
+	ids, err := sr.client.ZRangeByScore(ctx, index, &redis.ZRangeBy{Min: now(), Max: "+inf"}).Result()
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	sessions := []auth.Session{}
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKey(userID, id)
+	}
+	values, err := sr.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	for _, value := range values {
+		// Session may expire after it is read from the index.
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var session auth.Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (sr *sessionRepository) Remove(ctx context.Context, userID, id string) error {
+	if _, err := sr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(userID, id))
+		pipe.ZRem(ctx, sessionsKey(userID), id)
+		return nil
+	}); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) RemoveAll(ctx context.Context, userID string) error {
+	index := sessionsKey(userID)
+	ids, err := sr.client.ZRange(ctx, index, 0, -1).Result()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	keys := []string{index}
+	for _, id := range ids {
+		keys = append(keys, sessionKey(userID, id))
+	}
+	if err := sr.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func sessionKey(userID, id string) string {
+	return fmt.Sprintf("%s:%s:%s", sessionPrefix, userID, id)
+}
+
+func sessionsKey(userID string) string {
+	return fmt.Sprintf("%s:%s", sessionsPrefix, userID)
+}
+
+func now() string {
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/cache"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID    = "userID"
+	sessionID = "sessionID"
+)
+
+func TestSessionSave(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewSessionRepository(redisClient)
+
+	cases := []struct {
+		desc    string
+		session auth.Session
+		err     error
+	}{
+		{
+			desc:    "save session",
+			session: auth.Session{ID: sessionID, User: userID, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+			err:     nil,
+		},
+		{
+			desc:    "save existing session",
+			session: auth.Session{ID: sessionID, User: userID, CreatedAt: time.Now(), RefreshedAt: time.Now(), ExpiresAt: time.Now().Add(2 * time.Hour)},
+			err:     nil,
+		},
+		{
+			desc:    "save session with empty ID",
+			session: auth.Session{User: userID, ExpiresAt: time.Now().Add(time.Hour)},
+			err:     repoerr.ErrCreateEntity,
+		},
+		{
+			desc:    "save session with empty user",
+			session: auth.Session{ID: sessionID, ExpiresAt: time.Now().Add(time.Hour)},
+			err:     repoerr.ErrCreateEntity,
+		},
+		{
+			desc:    "save expired session",
+			session: auth.Session{ID: sessionID, User: userID, ExpiresAt: time.Now().Add(-time.Hour)},
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSessionRetrieve(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewSessionRepository(redisClient)
+
+	session := auth.Session{ID: sessionID, User: userID, CreatedAt: time.Now().UTC(), ExpiresAt: time.Now().Add(time.Hour).UTC()}
+	err := repo.Save(context.Background(), session)
+	assert.Nil(t, err, fmt.Sprintf("Saving session expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		userID  string
+		id      string
+		session auth.Session
+		err     error
+	}{
+		{
+			desc:    "retrieve session",
+			userID:  userID,
+			id:      sessionID,
+			session: session,
+			err:     nil,
+		},
+		{
+			desc:   "retrieve session of another user",
+			userID: "otherUserID",
+			id:     sessionID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve non-existing session",
+			userID: userID,
+			id:     "invalid",
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve session with empty ID",
+			userID: userID,
+			id:     "",
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := repo.Retrieve(context.Background(), tc.userID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.session.ID, s.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.session.ID, s.ID))
+		assert.True(t, tc.session.ExpiresAt.Equal(s.ExpiresAt), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.session.ExpiresAt, s.ExpiresAt))
+	}
+}
+
+func TestSessionRetrieveAll(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewSessionRepository(redisClient)
+
+	num := 5
+	for i := 0; i < num; i++ {
+		session := auth.Session{ID: fmt.Sprintf("%s%d", sessionID, i), User: userID, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+		err := repo.Save(context.Background(), session)
+		assert.Nil(t, err, fmt.Sprintf("Saving session expected to succeed: %s", err))
+	}
+	// Short-lived session expires before it is listed.
+	err := repo.Save(context.Background(), auth.Session{ID: "expiring", User: userID, ExpiresAt: time.Now().Add(time.Second)})
+	assert.Nil(t, err, fmt.Sprintf("Saving session expected to succeed: %s", err))
+	time.Sleep(2 * time.Second)
+
+	cases := []struct {
+		desc   string
+		userID string
+		size   int
+	}{
+		{
+			desc:   "retrieve all sessions",
+			userID: userID,
+			size:   num,
+		},
+		{
+			desc:   "retrieve all sessions of user with no sessions",
+			userID: "otherUserID",
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		sessions, err := repo.RetrieveAll(context.Background(), tc.userID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(sessions), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(sessions)))
+	}
+}
+
+func TestSessionRemove(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewSessionRepository(redisClient)
+
+	for i := 0; i < 2; i++ {
+		session := auth.Session{ID: fmt.Sprintf("%s%d", sessionID, i), User: userID, ExpiresAt: time.Now().Add(time.Hour)}
+		err := repo.Save(context.Background(), session)
+		assert.Nil(t, err, fmt.Sprintf("Saving session expected to succeed: %s", err))
+	}
+
+	err := repo.Remove(context.Background(), userID, sessionID+"0")
+	assert.Nil(t, err, fmt.Sprintf("Removing session expected to succeed: %s", err))
+	_, err = repo.Retrieve(context.Background(), userID, sessionID+"0")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+	sessions, err := repo.RetrieveAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving sessions expected to succeed: %s", err))
+	assert.Equal(t, 1, len(sessions), fmt.Sprintf("expected 1 session got %d\n", len(sessions)))
+
+	err = repo.RemoveAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("Removing all sessions expected to succeed: %s", err))
+	_, err = repo.Retrieve(context.Background(), userID, sessionID+"1")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+	sessions, err = repo.RetrieveAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving sessions expected to succeed: %s", err))
+	assert.Equal(t, 0, len(sessions), fmt.Sprintf("expected no sessions got %d\n", len(sessions)))
+}
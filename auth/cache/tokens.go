@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	revokedPrefix    = "revoked_token"
+	generationPrefix = "token_generation"
+)
+
+var _ auth.TokenRepository = (*tokenRepository)(nil)
+
+type tokenRepository struct {
+	client *redis.Client
+}
+
+// NewTokenRepository returns redis revoked token repository implementation.
+// Revoked token IDs are kept only for the remaining token lifetime.
+func NewTokenRepository(client *redis.Client) auth.TokenRepository {
+	return &tokenRepository{
+		client: client,
+	}
+}
+
+func (tr *tokenRepository) Revoke(ctx context.Context, id string, expiresAt time.Time) error {
+	if id == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("token id is empty"))
+	}
+	ttl := time.Until(expiresAt)
+	// There is no need to revoke the expired token.
+	if ttl <= 0 {
+		return nil
+	}
+	if err := tr.client.Set(ctx, revokedKey(id), expiresAt.Unix(), ttl).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr *tokenRepository) Revoked(ctx context.Context, id string) (bool, error) {
+	n, err := tr.client.Exists(ctx, revokedKey(id)).Result()
+	if err != nil {
+		return false, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return n > 0, nil
+}
+
+func (tr *tokenRepository) Generation(ctx context.Context, userID string) (uint64, error) {
+	gen, err := tr.client.Get(ctx, generationKey(userID)).Uint64()
+	// Redis returns Nil Reply when key does not exist.
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return gen, nil
+}
+
+func (tr *tokenRepository) IncrementGeneration(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.Wrap(repoerr.ErrUpdateEntity, errors.New("user id is empty"))
+	}
+	if err := tr.client.Incr(ctx, generationKey(userID)).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func revokedKey(id string) string {
+	return fmt.Sprintf("%s:%s", revokedPrefix, id)
+}
+
+func generationKey(userID string) string {
+	return fmt.Sprintf("%s:%s", generationPrefix, userID)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth/cache"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenID = "tokenID"
+
+func TestRevoke(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewTokenRepository(redisClient)
+
+	cases := []struct {
+		desc      string
+		id        string
+		expiresAt time.Time
+		revoked   bool
+		err       error
+	}{
+		{
+			desc:      "revoke token",
+			id:        tokenID,
+			expiresAt: time.Now().Add(time.Hour),
+			revoked:   true,
+			err:       nil,
+		},
+		{
+			desc:      "revoke expired token",
+			id:        "expiredTokenID",
+			expiresAt: time.Now().Add(-time.Hour),
+			revoked:   false,
+			err:       nil,
+		},
+		{
+			desc:      "revoke token with empty ID",
+			id:        "",
+			expiresAt: time.Now().Add(time.Hour),
+			revoked:   false,
+			err:       repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Revoke(context.Background(), tc.id, tc.expiresAt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		revoked, err := repo.Revoked(context.Background(), tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.revoked, revoked, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.revoked, revoked))
+	}
+}
+
+func TestRevokedExpires(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewTokenRepository(redisClient)
+
+	err := repo.Revoke(context.Background(), tokenID, time.Now().Add(time.Second))
+	assert.Nil(t, err, fmt.Sprintf("Revoking token expected to succeed: %s", err))
+	time.Sleep(2 * time.Second)
+
+	revoked, err := repo.Revoked(context.Background(), tokenID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.False(t, revoked, "expected expired token to be removed from revoked tokens")
+}
+
+func TestGeneration(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	repo := cache.NewTokenRepository(redisClient)
+
+	gen, err := repo.Generation(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving generation expected to succeed: %s", err))
+	assert.Equal(t, uint64(0), gen, fmt.Sprintf("expected generation 0 got %d", gen))
+
+	for i := 1; i <= 3; i++ {
+		err := repo.IncrementGeneration(context.Background(), userID)
+		assert.Nil(t, err, fmt.Sprintf("Incrementing generation expected to succeed: %s", err))
+		gen, err := repo.Generation(context.Background(), userID)
+		assert.Nil(t, err, fmt.Sprintf("Retrieving generation expected to succeed: %s", err))
+		assert.Equal(t, uint64(i), gen, fmt.Sprintf("expected generation %d got %d", i, gen))
+	}
+
+	err = repo.IncrementGeneration(context.Background(), "")
+	assert.True(t, errors.Contains(err, repoerr.ErrUpdateEntity), fmt.Sprintf("expected %s got %s\n", repoerr.ErrUpdateEntity, err))
+
+	gen, err = repo.Generation(context.Background(), "otherUserID")
+	assert.Nil(t, err, fmt.Sprintf("Retrieving generation expected to succeed: %s", err))
+	assert.Equal(t, uint64(0), gen, fmt.Sprintf("expected generation 0 got %d", gen))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrInvalidClaimMapping indicates an invalid identity provider claim mapping.
+var ErrInvalidClaimMapping = errors.New("invalid claim mapping")
+
+// claimRelations lists the domain relations the claim mappings can grant,
+// ordered from the most to the least privileged one.
+var claimRelations = []string{AdministratorRelation, EditorRelation, ContributorRelation, MemberRelation, GuestRelation}
+
+// ClaimMapping maps the identity provider group claim to the domain access.
+// Users logging in using the provider, whose groups claim contains the
+// mapping group, get the relation on the domain and the membership of the
+// domain groups. Mapping with an empty provider applies to all providers.
+type ClaimMapping struct {
+	ID        string    `json:"id"`
+	DomainID  string    `json:"domain_id"`
+	Provider  string    `json:"provider,omitempty"`
+	Group     string    `json:"group"`
+	Relation  string    `json:"relation"`
+	GroupIDs  []string  `json:"group_ids,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks the mapping group and relation.
+func (m ClaimMapping) Validate() error {
+	if m.Group == "" {
+		return errors.Wrap(ErrInvalidClaimMapping, errors.New("empty group"))
+	}
+	if !slices.Contains(claimRelations, m.Relation) {
+		return errors.Wrap(ErrInvalidClaimMapping, fmt.Errorf("unsupported relation %s", m.Relation))
+	}
+	for _, id := range m.GroupIDs {
+		if id == "" {
+			return errors.Wrap(ErrInvalidClaimMapping, errors.New("empty group id"))
+		}
+	}
+
+	return nil
+}
+
+// ClaimMappingsPage contains a page of domain claim mappings.
+type ClaimMappingsPage struct {
+	Total    uint64         `json:"total"`
+	Offset   uint64         `json:"offset"`
+	Limit    uint64         `json:"limit"`
+	Mappings []ClaimMapping `json:"mappings"`
+}
+
+func (page ClaimMappingsPage) MarshalJSON() ([]byte, error) {
+	type Alias ClaimMappingsPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Mappings == nil {
+		a.Mappings = make([]ClaimMapping, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// ClaimGrant records the domain access granted to the user by the claim
+// mappings of the provider. Relation is empty if the user was already
+// a domain member, so the manually assigned membership is never revoked.
+type ClaimGrant struct {
+	DomainID string
+	UserID   string
+	Provider string
+	Relation string
+	GroupIDs []string
+}
+
+// claimAccess returns the most privileged domain relation and the
+// domain groups granted by the mappings.
+func claimAccess(mappings []ClaimMapping) (string, []string) {
+	relation := ""
+	var groupIDs []string
+	for _, m := range mappings {
+		if relation == "" || slices.Index(claimRelations, m.Relation) < slices.Index(claimRelations, relation) {
+			relation = m.Relation
+		}
+		for _, id := range m.GroupIDs {
+			if !slices.Contains(groupIDs, id) {
+				groupIDs = append(groupIDs, id)
+			}
+		}
+	}
+
+	return relation, groupIDs
+}
+
+// ClaimMappings specifies the API for mapping identity provider claims
+// to the domain access.
+type ClaimMappings interface {
+	// CreateClaimMapping creates the claim mapping in the domain.
+	CreateClaimMapping(ctx context.Context, token, domainID string, m ClaimMapping) (ClaimMapping, error)
+
+	// RetrieveClaimMapping retrieves the domain claim mapping.
+	RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (ClaimMapping, error)
+
+	// ListClaimMappings lists the domain claim mappings.
+	ListClaimMappings(ctx context.Context, token, domainID string, pm Page) (ClaimMappingsPage, error)
+
+	// DeleteClaimMapping removes the claim mapping. Access granted by the
+	// mapping is revoked on the next login of the users.
+	DeleteClaimMapping(ctx context.Context, token, domainID, id string) error
+
+	// ApplyClaimMappings grants the user the domain access of the provider
+	// mappings matching the user groups, and revokes the previously granted
+	// access of the mappings which no longer match.
+	ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) error
+}
+
+// ClaimMappingsRepository specifies the claim mappings persistence API.
+//
+//go:generate mockery --name ClaimMappingsRepository --output=./mocks --filename claims.go --quiet --note "Copyright (c) Abstract Machines"
+type ClaimMappingsRepository interface {
+	// Save persists the claim mapping.
+	Save(ctx context.Context, m ClaimMapping) (ClaimMapping, error)
+
+	// RetrieveByID retrieves the domain claim mapping by its ID.
+	RetrieveByID(ctx context.Context, domainID, id string) (ClaimMapping, error)
+
+	// RetrieveAll retrieves the page of the domain claim mappings.
+	RetrieveAll(ctx context.Context, domainID string, pm Page) (ClaimMappingsPage, error)
+
+	// RetrieveByProvider retrieves the mappings of the provider, including
+	// the mappings which apply to all providers.
+	RetrieveByProvider(ctx context.Context, provider string) ([]ClaimMapping, error)
+
+	// Delete removes the claim mapping.
+	Delete(ctx context.Context, domainID, id string) error
+
+	// SaveGrant persists the grant, replacing the existing grant
+	// of the domain to the user.
+	SaveGrant(ctx context.Context, g ClaimGrant) error
+
+	// RetrieveGrants retrieves the grants of the provider to the user.
+	RetrieveGrants(ctx context.Context, userID, provider string) ([]ClaimGrant, error)
+
+	// RemoveGrant removes the grant of the domain to the user.
+	RemoveGrant(ctx context.Context, domainID, userID string) error
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimMappingValidate(t *testing.T) {
+	cases := []struct {
+		desc    string
+		mapping auth.ClaimMapping
+		err     error
+	}{
+		{
+			desc: "valid claim mapping",
+			mapping: auth.ClaimMapping{
+				Provider: "oidc",
+				Group:    "iot-ops",
+				Relation: auth.EditorRelation,
+				GroupIDs: []string{"group"},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid claim mapping for all providers",
+			mapping: auth.ClaimMapping{
+				Group:    "iot-ops",
+				Relation: auth.MemberRelation,
+			},
+			err: nil,
+		},
+		{
+			desc: "claim mapping with empty group",
+			mapping: auth.ClaimMapping{
+				Relation: auth.EditorRelation,
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+		{
+			desc: "claim mapping with empty relation",
+			mapping: auth.ClaimMapping{
+				Group: "iot-ops",
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+		{
+			desc: "claim mapping with unsupported relation",
+			mapping: auth.ClaimMapping{
+				Group:    "iot-ops",
+				Relation: auth.PlatformRelation,
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+		{
+			desc: "claim mapping with empty group id",
+			mapping: auth.ClaimMapping{
+				Group:    "iot-ops",
+				Relation: auth.EditorRelation,
+				GroupIDs: []string{""},
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.mapping.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	// ErrInvalidCondition indicates that the policy condition is malformed.
+	ErrInvalidCondition = errors.New("invalid policy condition")
+
+	errConditionNotMet = errors.New("policy condition is not met")
+)
+
+// Condition restricts the policy granted to the subject. The policy
+// whose condition is not met grants no permission on the object.
+type Condition struct {
+	// ExpiresAt is the time after which the policy lapses. Zero value
+	// means the policy never expires.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// AllowedCIDRs contains the networks the authorized requests must
+	// originate from. Empty list allows requests from any network.
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`
+}
+
+// Validate returns an error if the condition can't be applied to a new policy.
+func (c Condition) Validate() error {
+	if !c.ExpiresAt.IsZero() && !c.ExpiresAt.After(time.Now()) {
+		return errors.Wrap(ErrInvalidCondition, errors.New("expiration time must be in the future"))
+	}
+	for _, cidr := range c.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Wrap(ErrInvalidCondition, err)
+		}
+	}
+
+	return nil
+}
+
+// Met checks if the request from the given source IP satisfies the
+// condition at the given time.
+func (c Condition) Met(sourceIP string, now time.Time) bool {
+	if !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt) {
+		return false
+	}
+	if len(c.AllowedCIDRs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range c.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConditionalPolicy represents the policy granted with the condition.
+type ConditionalPolicy struct {
+	SubjectType string `json:"subject_type"`
+	Subject     string `json:"subject"`
+	Relation    string `json:"relation"`
+	ObjectType  string `json:"object_type"`
+	Object      string `json:"object"`
+	Condition
+}
+
+// ConditionsRepository specifies conditional policies persistence API.
+//
+//go:generate mockery --name ConditionsRepository --output=./mocks --filename conditions.go --quiet --note "Copyright (c) Abstract Machines"
+type ConditionsRepository interface {
+	// Save persists the conditional policies. Existing conditions
+	// of the same policies are replaced.
+	Save(ctx context.Context, cps []ConditionalPolicy) error
+
+	// Exists checks if any conditional policy is persisted.
+	Exists(ctx context.Context) (bool, error)
+
+	// RetrieveBySteps retrieves the conditional policies granted to the
+	// subjects of the steps on the objects of the steps.
+	RetrieveBySteps(ctx context.Context, steps []PolicyStep) ([]ConditionalPolicy, error)
+
+	// RetrieveExpired retrieves at most limit conditional policies that expired before the given time.
+	RetrieveExpired(ctx context.Context, before time.Time, limit uint64) ([]ConditionalPolicy, error)
+
+	// Remove removes the conditional policies.
+	Remove(ctx context.Context, cps []ConditionalPolicy) error
+
+	// RemoveFilter removes the conditional policies matching the non-empty fields of the filter.
+	RemoveFilter(ctx context.Context, filter ConditionalPolicy) error
+}
+
+func conditionalPolicy(pr PolicyReq) ConditionalPolicy {
+	cp := ConditionalPolicy{
+		SubjectType: pr.SubjectType,
+		Subject:     pr.Subject,
+		Relation:    pr.Relation,
+		ObjectType:  pr.ObjectType,
+		Object:      pr.Object,
+	}
+	if pr.Condition != nil {
+		cp.Condition = *pr.Condition
+	}
+
+	return cp
+}
+
+// conditionalPolicies returns the conditional policies of the policy requests carrying a condition.
+func conditionalPolicies(prs []PolicyReq) []ConditionalPolicy {
+	var cps []ConditionalPolicy
+	for _, pr := range prs {
+		if pr.Condition != nil {
+			cps = append(cps, conditionalPolicy(pr))
+		}
+	}
+
+	return cps
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionValidate(t *testing.T) {
+	cases := []struct {
+		desc      string
+		condition auth.Condition
+		err       error
+	}{
+		{
+			desc:      "valid condition",
+			condition: auth.Condition{ExpiresAt: time.Now().Add(time.Hour), AllowedCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"}},
+			err:       nil,
+		},
+		{
+			desc:      "condition without expiration",
+			condition: auth.Condition{AllowedCIDRs: []string{"192.168.0.0/16"}},
+			err:       nil,
+		},
+		{
+			desc:      "condition with past expiration",
+			condition: auth.Condition{ExpiresAt: time.Now().Add(-time.Hour)},
+			err:       auth.ErrInvalidCondition,
+		},
+		{
+			desc:      "condition with invalid network",
+			condition: auth.Condition{AllowedCIDRs: []string{"10.0.0.1"}},
+			err:       auth.ErrInvalidCondition,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.condition.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestConditionMet(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		desc      string
+		condition auth.Condition
+		sourceIP  string
+		met       bool
+	}{
+		{
+			desc:      "empty condition",
+			condition: auth.Condition{},
+			met:       true,
+		},
+		{
+			desc:      "condition before expiration",
+			condition: auth.Condition{ExpiresAt: now.Add(time.Minute)},
+			met:       true,
+		},
+		{
+			desc:      "condition after expiration",
+			condition: auth.Condition{ExpiresAt: now.Add(-time.Minute)},
+			met:       false,
+		},
+		{
+			desc:      "condition at expiration",
+			condition: auth.Condition{ExpiresAt: now},
+			met:       false,
+		},
+		{
+			desc:      "condition with source IP in allowed network",
+			condition: auth.Condition{AllowedCIDRs: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+			sourceIP:  "10.10.10.10",
+			met:       true,
+		},
+		{
+			desc:      "condition with IPv6 source IP in allowed network",
+			condition: auth.Condition{AllowedCIDRs: []string{"2001:db8::/32"}},
+			sourceIP:  "2001:db8::1",
+			met:       true,
+		},
+		{
+			desc:      "condition with source IP out of allowed networks",
+			condition: auth.Condition{AllowedCIDRs: []string{"10.0.0.0/8"}},
+			sourceIP:  "172.16.0.1",
+			met:       false,
+		},
+		{
+			desc:      "condition with missing source IP",
+			condition: auth.Condition{AllowedCIDRs: []string{"10.0.0.0/8"}},
+			met:       false,
+		},
+		{
+			desc:      "condition with invalid source IP",
+			condition: auth.Condition{AllowedCIDRs: []string{"10.0.0.0/8"}},
+			sourceIP:  "invalid",
+			met:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		met := tc.condition.Met(tc.sourceIP, now)
+		assert.Equal(t, tc.met, met, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.met, met))
+	}
+}
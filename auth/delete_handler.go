@@ -0,0 +1,72 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The DeleteHandler is a cron job that runs periodically to delete the domains that have been marked as deleted
+// for a certain period of time together with the domains roles, claim mappings, usage and policies.
+// The handler runs in a separate goroutine and purges the domains through the service,
+// so the service middlewares publish the deleted domains and the other services remove the domains data.
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type deleteHandler struct {
+	svc           Service
+	checkInterval time.Duration
+	deleteAfter   time.Duration
+	logger        *slog.Logger
+}
+
+func NewDeleteHandler(ctx context.Context, svc Service, checkInterval, deleteAfter time.Duration, logger *slog.Logger) {
+	handler := &deleteHandler{
+		svc:           svc,
+		checkInterval: checkInterval,
+		deleteAfter:   deleteAfter,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *deleteHandler) handle(ctx context.Context) {
+	dds, err := h.svc.PurgeDomains(ctx, time.Now().Add(-h.deleteAfter))
+	if err != nil {
+		h.logger.Error("failed to purge domains", slog.Any("error", err))
+	}
+
+	for _, dd := range dds {
+		if dd.Status == DeletionFailed {
+			args := []any{
+				slog.String("id", dd.DomainID),
+				slog.Uint64("attempts", dd.Attempts),
+				slog.String("error", dd.Error),
+			}
+			if dd.Attempts >= maxDeletionAttempts {
+				h.logger.Error("failed to delete domain, giving up", args...)
+				continue
+			}
+			h.logger.Error("failed to delete domain", append(args, slog.Time("retry_at", dd.RetryAt))...)
+			continue
+		}
+		h.logger.Info("domain deleted", slog.Group("domain",
+			slog.String("id", dd.DomainID),
+			slog.String("requested_by", dd.RequestedBy),
+		))
+	}
+}
@@ -24,6 +24,8 @@ const (
 	DisabledStatus
 	// FreezeStatus represents domain is in freezed state.
 	FreezeStatus
+	// DeletedStatus represents domain scheduled for deletion.
+	DeletedStatus
 
 	// AllStatus is used for querying purposes to list Domains irrespective
 	// of their status - enabled, disabled, freezed, deleting. It is never stored in the
@@ -37,6 +39,7 @@ const (
 	Disabled = "disabled"
 	Enabled  = "enabled"
 	Freezed  = "freezed"
+	Deleted  = "deleted"
 	All      = "all"
 	Unknown  = "unknown"
 )
@@ -52,6 +55,8 @@ func (s Status) String() string {
 		return All
 	case FreezeStatus:
 		return Freezed
+	case DeletedStatus:
+		return Deleted
 	default:
 		return Unknown
 	}
@@ -66,6 +71,8 @@ func ToStatus(status string) (Status, error) {
 		return DisabledStatus, nil
 	case Freezed:
 		return FreezeStatus, nil
+	case Deleted:
+		return DeletedStatus, nil
 	case All:
 		return AllStatus, nil
 	}
@@ -86,24 +93,59 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 }
 
 type DomainReq struct {
-	Name     *string           `json:"name,omitempty"`
-	Metadata *clients.Metadata `json:"metadata,omitempty"`
-	Tags     *[]string         `json:"tags,omitempty"`
-	Alias    *string           `json:"alias,omitempty"`
-	Status   *Status           `json:"status,omitempty"`
+	Name        *string           `json:"name,omitempty"`
+	Metadata    *clients.Metadata `json:"metadata,omitempty"`
+	Tags        *[]string         `json:"tags,omitempty"`
+	Alias       *string           `json:"alias,omitempty"`
+	Status      *Status           `json:"status,omitempty"`
+	MFARequired *bool             `json:"mfa_required,omitempty"`
+	Quotas      *Quotas           `json:"quotas,omitempty"`
 }
 type Domain struct {
-	ID         string           `json:"id"`
-	Name       string           `json:"name"`
-	Metadata   clients.Metadata `json:"metadata,omitempty"`
-	Tags       []string         `json:"tags,omitempty"`
-	Alias      string           `json:"alias,omitempty"`
-	Status     Status           `json:"status"`
-	Permission string           `json:"permission,omitempty"`
-	CreatedBy  string           `json:"created_by,omitempty"`
-	CreatedAt  time.Time        `json:"created_at"`
-	UpdatedBy  string           `json:"updated_by,omitempty"`
-	UpdatedAt  time.Time        `json:"updated_at,omitempty"`
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Metadata    clients.Metadata `json:"metadata,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	Alias       string           `json:"alias,omitempty"`
+	Status      Status           `json:"status"`
+	MFARequired bool             `json:"mfa_required,omitempty"`
+	Quotas      Quotas           `json:"quotas"`
+	Permission  string           `json:"permission,omitempty"`
+	CreatedBy   string           `json:"created_by,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedBy   string           `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at,omitempty"`
+}
+
+// DeletionStatus represents the status of the domain deletion.
+type DeletionStatus string
+
+// Possible domain deletion status values.
+const (
+	// DeletionPending represents the deletion waiting for the grace period to pass.
+	DeletionPending DeletionStatus = "pending"
+	// DeletionInProgress represents the deletion of the domain data in progress.
+	DeletionInProgress DeletionStatus = "in_progress"
+	// DeletionAuthCompleted represents the domain removed from the auth service.
+	// The other services remove the domain data when they consume the domain removal.
+	DeletionAuthCompleted DeletionStatus = "auth_cleanup_completed"
+	// DeletionFailed represents the failed deletion, which is retried with the
+	// backoff until the number of attempts reaches the maximum.
+	DeletionFailed DeletionStatus = "failed"
+	// DeletionCanceled represents the deletion canceled by restoring the domain.
+	DeletionCanceled DeletionStatus = "canceled"
+)
+
+// DomainDeletion represents the asynchronous deletion of the domain and its data.
+type DomainDeletion struct {
+	DomainID    string         `json:"domain_id"`
+	Status      DeletionStatus `json:"status"`
+	RequestedBy string         `json:"requested_by"`
+	RequestedAt time.Time      `json:"requested_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	Attempts    uint64         `json:"attempts"`
+	RetryAt     time.Time      `json:"retry_at"`
+	Error       string         `json:"error,omitempty"`
 }
 
 type Page struct {
@@ -161,9 +203,42 @@ type Domains interface {
 	UpdateDomain(ctx context.Context, token string, id string, d DomainReq) (Domain, error)
 	ChangeDomainStatus(ctx context.Context, token string, id string, d DomainReq) (Domain, error)
 	ListDomains(ctx context.Context, token string, page Page) (DomainsPage, error)
-	AssignUsers(ctx context.Context, token string, id string, userIds []string, relation string) error
+	// AssignUsers assigns the users to the domain with the relation. The optional
+	// condition restricts the membership of the users in time or by network.
+	AssignUsers(ctx context.Context, token string, id string, userIds []string, relation string, cond *Condition) error
 	UnassignUser(ctx context.Context, token string, id string, userID string) error
 	ListUserDomains(ctx context.Context, token string, userID string, page Page) (DomainsPage, error)
+	// AddDomainMember adds the user to the domain with the relation without
+	// authorizing the request. The users service uses it to bind the service
+	// accounts to their domain.
+	AddDomainMember(ctx context.Context, domainID, userID, relation string) error
+	// RetrieveDomainUsage retrieves the domain quotas and the current
+	// consumption of the domain resources.
+	RetrieveDomainUsage(ctx context.Context, token, id string) (DomainUsage, error)
+	// CheckQuota checks if the domain can consume the count of the resource
+	// without exceeding its quota, and reports whether the resource of the
+	// domain is limited by the quota at all. It is used by the services
+	// creating the domain resources and doesn't authorize the request.
+	CheckQuota(ctx context.Context, domainID, resource string, count uint64) (bool, error)
+	// ReserveQuota records the things, the channels or the users of the
+	// domain before they are created and returns the IDs of the newly
+	// recorded entities. The entities exceeding the domain quota are
+	// rejected unless they are marked as existing, which seeds the usage of
+	// the entities created before. It doesn't authorize the request.
+	ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) ([]string, error)
+	// ReleaseQuota removes the reserved entities of the domain which
+	// failed to be created. It doesn't authorize the request.
+	ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) error
+	// DeleteDomain marks the domain as deleted and schedules the deletion of
+	// the domain and its data. Changing the domain status before the deletion
+	// starts restores the domain.
+	DeleteDomain(ctx context.Context, token, id string) (DomainDeletion, error)
+	// RetrieveDomainDeletion retrieves the status of the domain deletion.
+	RetrieveDomainDeletion(ctx context.Context, token, id string) (DomainDeletion, error)
+	// PurgeDomains deletes the domains whose deletion was requested before the
+	// given time, together with their roles, claim mappings, usage and
+	// policies, and returns the processed deletions.
+	PurgeDomains(ctx context.Context, before time.Time) ([]DomainDeletion, error)
 }
 
 // DomainsRepository specifies Domain persistence API.
@@ -185,7 +260,7 @@ type DomainsRepository interface {
 	// Update updates the client name and metadata.
 	Update(ctx context.Context, id string, userID string, d DomainReq) (Domain, error)
 
-	// Delete
+	// Delete removes the domain together with its users policies.
 	Delete(ctx context.Context, id string) error
 
 	// SavePolicies save policies in domains database
@@ -202,4 +277,18 @@ type DomainsRepository interface {
 
 	// DeleteUserPolicies deletes user policies from domains database.
 	DeleteUserPolicies(ctx context.Context, id string) (err error)
+
+	// SaveDeletion marks the domain as deleted and saves its deletion.
+	SaveDeletion(ctx context.Context, dd DomainDeletion) error
+
+	// RetrieveDeletion retrieves the deletion of the domain.
+	RetrieveDeletion(ctx context.Context, id string) (DomainDeletion, error)
+
+	// RetrieveDeletions retrieves the pending and the failed deletions
+	// requested before the given time, which are due to be retried at
+	// the now time and have fewer than the max attempts.
+	RetrieveDeletions(ctx context.Context, before, now time.Time, maxAttempts, limit uint64) ([]DomainDeletion, error)
+
+	// UpdateDeletion updates the deletion status, attempts and error.
+	UpdateDeletion(ctx context.Context, dd DomainDeletion) error
 }
@@ -32,6 +32,11 @@ func TestStatusString(t *testing.T) {
 			status:   auth.FreezeStatus,
 			expected: "freezed",
 		},
+		{
+			desc:     "Deleted",
+			status:   auth.DeletedStatus,
+			expected: "deleted",
+		},
 		{
 			desc:     "All",
 			status:   auth.AllStatus,
@@ -77,6 +82,12 @@ func TestToStatus(t *testing.T) {
 			expetcted: auth.FreezeStatus,
 			err:       nil,
 		},
+		{
+			desc:      "Deleted",
+			status:    "deleted",
+			expetcted: auth.DeletedStatus,
+			err:       nil,
+		},
 		{
 			desc:      "All",
 			status:    "all",
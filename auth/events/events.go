@@ -15,24 +15,53 @@ const (
 	domainCreate              = domainPrefix + "create"
 	domainRetrieve            = domainPrefix + "retrieve"
 	domainRetrievePermissions = domainPrefix + "retrieve_permissions"
+	domainRetrieveUsage       = domainPrefix + "retrieve_usage"
 	domainUpdate              = domainPrefix + "update"
 	domainChangeStatus        = domainPrefix + "change_status"
 	domainList                = domainPrefix + "list"
 	domainAssign              = domainPrefix + "assign"
 	domainUnassign            = domainPrefix + "unassign"
 	domainUserList            = domainPrefix + "user_list"
+	domainDelete              = domainPrefix + "delete"
+	domainRemove              = domainPrefix + "remove"
+
+	rolePrefix   = "role."
+	roleCreate   = rolePrefix + "create"
+	roleUpdate   = rolePrefix + "update"
+	roleDelete   = rolePrefix + "delete"
+	roleAssign   = rolePrefix + "assign"
+	roleUnassign = rolePrefix + "unassign"
+
+	claimMappingPrefix = "claim_mapping."
+	claimMappingCreate = claimMappingPrefix + "create"
+	claimMappingDelete = claimMappingPrefix + "delete"
+	claimMappingApply  = claimMappingPrefix + "apply"
+
+	policyPrefix = "policy."
+	policyExpire = policyPrefix + "expire"
 )
 
 var (
 	_ events.Event = (*createDomainEvent)(nil)
 	_ events.Event = (*retrieveDomainEvent)(nil)
 	_ events.Event = (*retrieveDomainPermissionsEvent)(nil)
+	_ events.Event = (*retrieveDomainUsageEvent)(nil)
 	_ events.Event = (*updateDomainEvent)(nil)
 	_ events.Event = (*changeDomainStatusEvent)(nil)
 	_ events.Event = (*listDomainsEvent)(nil)
 	_ events.Event = (*assignUsersEvent)(nil)
 	_ events.Event = (*unassignUsersEvent)(nil)
 	_ events.Event = (*listUserDomainsEvent)(nil)
+	_ events.Event = (*createRoleEvent)(nil)
+	_ events.Event = (*updateRoleEvent)(nil)
+	_ events.Event = (*deleteRoleEvent)(nil)
+	_ events.Event = (*assignRoleEvent)(nil)
+	_ events.Event = (*createClaimMappingEvent)(nil)
+	_ events.Event = (*deleteClaimMappingEvent)(nil)
+	_ events.Event = (*applyClaimMappingsEvent)(nil)
+	_ events.Event = (*expirePolicyEvent)(nil)
+	_ events.Event = (*deleteDomainEvent)(nil)
+	_ events.Event = (*removeDomainEvent)(nil)
 )
 
 type createDomainEvent struct {
@@ -61,6 +90,9 @@ func (cde createDomainEvent) Encode() (map[string]interface{}, error) {
 	if cde.Metadata != nil {
 		val["metadata"] = cde.Metadata
 	}
+	if cde.Quotas != (auth.Quotas{}) {
+		val["quotas"] = cde.Quotas
+	}
 
 	return val, nil
 }
@@ -115,6 +147,46 @@ func (rpe retrieveDomainPermissionsEvent) Encode() (map[string]interface{}, erro
 	return val, nil
 }
 
+type retrieveDomainUsageEvent struct {
+	domainID string
+	auth.DomainUsage
+}
+
+func (rue retrieveDomainUsageEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": domainRetrieveUsage,
+		"domain_id": rue.domainID,
+		"quotas":    rue.Quotas,
+		"usage":     rue.Usage,
+	}, nil
+}
+
+type deleteDomainEvent struct {
+	auth.DomainDeletion
+}
+
+func (dde deleteDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":    domainDelete,
+		"id":           dde.DomainID,
+		"requested_by": dde.RequestedBy,
+		"requested_at": dde.RequestedAt,
+	}, nil
+}
+
+type removeDomainEvent struct {
+	auth.DomainDeletion
+}
+
+func (rde removeDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":    domainRemove,
+		"id":           rde.DomainID,
+		"requested_by": rde.RequestedBy,
+		"removed_at":   rde.UpdatedAt,
+	}, nil
+}
+
 type updateDomainEvent struct {
 	auth.Domain
 }
@@ -140,6 +212,9 @@ func (ude updateDomainEvent) Encode() (map[string]interface{}, error) {
 	if ude.Metadata != nil {
 		val["metadata"] = ude.Metadata
 	}
+	if ude.Quotas != (auth.Quotas{}) {
+		val["quotas"] = ude.Quotas
+	}
 
 	return val, nil
 }
@@ -212,9 +287,10 @@ func (lde listDomainsEvent) Encode() (map[string]interface{}, error) {
 }
 
 type assignUsersEvent struct {
-	userIDs  []string
-	domainID string
-	relation string
+	userIDs   []string
+	domainID  string
+	relation  string
+	condition *auth.Condition
 }
 
 func (ase assignUsersEvent) Encode() (map[string]interface{}, error) {
@@ -224,6 +300,14 @@ func (ase assignUsersEvent) Encode() (map[string]interface{}, error) {
 		"domain_id": ase.domainID,
 		"relation":  ase.relation,
 	}
+	if ase.condition != nil {
+		if !ase.condition.ExpiresAt.IsZero() {
+			val["expires_at"] = ase.condition.ExpiresAt
+		}
+		if len(ase.condition.AllowedCIDRs) > 0 {
+			val["allowed_cidrs"] = ase.condition.AllowedCIDRs
+		}
+	}
 
 	return val, nil
 }
@@ -293,3 +377,155 @@ func (lde listUserDomainsEvent) Encode() (map[string]interface{}, error) {
 
 	return val, nil
 }
+
+type createRoleEvent struct {
+	auth.Role
+}
+
+func (cre createRoleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   roleCreate,
+		"id":          cre.ID,
+		"domain_id":   cre.DomainID,
+		"name":        cre.Name,
+		"permissions": cre.Permissions,
+		"created_at":  cre.CreatedAt,
+		"created_by":  cre.CreatedBy,
+	}, nil
+}
+
+type updateRoleEvent struct {
+	auth.Role
+}
+
+func (ure updateRoleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   roleUpdate,
+		"id":          ure.ID,
+		"domain_id":   ure.DomainID,
+		"name":        ure.Name,
+		"permissions": ure.Permissions,
+		"updated_at":  ure.UpdatedAt,
+		"updated_by":  ure.UpdatedBy,
+	}, nil
+}
+
+type deleteRoleEvent struct {
+	id       string
+	domainID string
+}
+
+func (dre deleteRoleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": roleDelete,
+		"id":        dre.id,
+		"domain_id": dre.domainID,
+	}, nil
+}
+
+type assignRoleEvent struct {
+	operation string
+	id        string
+	domainID  string
+	entity    string
+	entityID  string
+	userIDs   []string
+}
+
+func (are assignRoleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": are.operation,
+		"id":        are.id,
+		"domain_id": are.domainID,
+		"entity":    are.entity,
+		"entity_id": are.entityID,
+		"user_ids":  are.userIDs,
+	}, nil
+}
+
+type createClaimMappingEvent struct {
+	auth.ClaimMapping
+}
+
+func (cce createClaimMappingEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":  claimMappingCreate,
+		"id":         cce.ID,
+		"domain_id":  cce.DomainID,
+		"group":      cce.Group,
+		"relation":   cce.Relation,
+		"created_at": cce.CreatedAt,
+		"created_by": cce.CreatedBy,
+	}
+	if cce.Provider != "" {
+		val["provider"] = cce.Provider
+	}
+	if len(cce.GroupIDs) > 0 {
+		val["group_ids"] = cce.GroupIDs
+	}
+
+	return val, nil
+}
+
+type deleteClaimMappingEvent struct {
+	id       string
+	domainID string
+}
+
+func (dce deleteClaimMappingEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": claimMappingDelete,
+		"id":        dce.id,
+		"domain_id": dce.domainID,
+	}, nil
+}
+
+type applyClaimMappingsEvent struct {
+	userID   string
+	provider string
+	groups   []string
+}
+
+func (ace applyClaimMappingsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": claimMappingApply,
+		"user_id":   ace.userID,
+		"provider":  ace.provider,
+		"groups":    ace.groups,
+	}, nil
+}
+
+type expirePolicyEvent struct {
+	auth.ConditionalPolicy
+}
+
+func (epe expirePolicyEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":    policyExpire,
+		"subject_type": epe.SubjectType,
+		"subject":      epe.Subject,
+		"relation":     epe.Relation,
+		"object_type":  epe.ObjectType,
+		"object":       epe.Object,
+		"expires_at":   epe.ExpiresAt,
+	}
+	// Entity attributes let the journal list the lapsed grant
+	// among the events of the user and of the object.
+	if epe.SubjectType == auth.UserType {
+		domainID, userID := auth.DecodeDomainUserID(epe.Subject)
+		if userID == "" {
+			userID = domainID
+		}
+		val["user_id"] = userID
+	}
+	switch epe.ObjectType {
+	case auth.ThingType:
+		val["thing_id"] = epe.Object
+	case auth.GroupType:
+		val["group_id"] = epe.Object
+	case auth.DomainType:
+		val["domain_id"] = epe.Object
+	}
+
+	return val, nil
+}
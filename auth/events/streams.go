@@ -5,8 +5,10 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 )
@@ -140,16 +142,17 @@ func (es *eventStore) ListDomains(ctx context.Context, token string, p auth.Page
 	return dp, nil
 }
 
-func (es *eventStore) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string) error {
-	err := es.svc.AssignUsers(ctx, token, id, userIds, relation)
+func (es *eventStore) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string, cond *auth.Condition) error {
+	err := es.svc.AssignUsers(ctx, token, id, userIds, relation, cond)
 	if err != nil {
 		return err
 	}
 
 	event := assignUsersEvent{
-		domainID: id,
-		userIDs:  userIds,
-		relation: relation,
+		domainID:  id,
+		userIDs:   userIds,
+		relation:  relation,
+		condition: cond,
 	}
 
 	if err := es.Publish(ctx, event); err != nil {
@@ -159,6 +162,20 @@ func (es *eventStore) AssignUsers(ctx context.Context, token, id string, userIds
 	return nil
 }
 
+func (es *eventStore) AddDomainMember(ctx context.Context, domainID, userID, relation string) error {
+	if err := es.svc.AddDomainMember(ctx, domainID, userID, relation); err != nil {
+		return err
+	}
+
+	event := assignUsersEvent{
+		domainID: domainID,
+		userIDs:  []string{userID},
+		relation: relation,
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) UnassignUser(ctx context.Context, token, id, userID string) error {
 	err := es.svc.UnassignUser(ctx, token, id, userID)
 	if err != nil {
@@ -211,10 +228,42 @@ func (es *eventStore) Identify(ctx context.Context, token string) (auth.Key, err
 	return es.svc.Identify(ctx, token)
 }
 
+func (es *eventStore) ListKeys(ctx context.Context, token string, pm auth.Page) (auth.KeyPage, error) {
+	return es.svc.ListKeys(ctx, token, pm)
+}
+
+func (es *eventStore) RetrieveJWKS(ctx context.Context) ([]auth.JWK, error) {
+	return es.svc.RetrieveJWKS(ctx)
+}
+
+func (es *eventStore) ListSessions(ctx context.Context, token string) ([]auth.Session, error) {
+	return es.svc.ListSessions(ctx, token)
+}
+
+func (es *eventStore) RevokeSession(ctx context.Context, token, id string) error {
+	return es.svc.RevokeSession(ctx, token, id)
+}
+
+func (es *eventStore) RevokeSessions(ctx context.Context, token string) error {
+	return es.svc.RevokeSessions(ctx, token)
+}
+
+func (es *eventStore) RevokeTokens(ctx context.Context, userID string) error {
+	return es.svc.RevokeTokens(ctx, userID)
+}
+
+func (es *eventStore) ClientCredentials(ctx context.Context, clientID, secret string) (auth.Token, error) {
+	return es.svc.ClientCredentials(ctx, clientID, secret)
+}
+
 func (es *eventStore) Authorize(ctx context.Context, pr auth.PolicyReq) error {
 	return es.svc.Authorize(ctx, pr)
 }
 
+func (es *eventStore) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	return es.svc.ExplainPolicy(ctx, token, pr)
+}
+
 func (es *eventStore) AddPolicy(ctx context.Context, pr auth.PolicyReq) error {
 	return es.svc.AddPolicy(ctx, pr)
 }
@@ -227,6 +276,71 @@ func (es *eventStore) DeletePolicyFilter(ctx context.Context, pr auth.PolicyReq)
 	return es.svc.DeletePolicyFilter(ctx, pr)
 }
 
+func (es *eventStore) RetrieveDomainUsage(ctx context.Context, token, id string) (auth.DomainUsage, error) {
+	usage, err := es.svc.RetrieveDomainUsage(ctx, token, id)
+	if err != nil {
+		return usage, err
+	}
+
+	event := retrieveDomainUsageEvent{
+		domainID:    id,
+		DomainUsage: usage,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return usage, err
+	}
+
+	return usage, nil
+}
+
+func (es *eventStore) CheckQuota(ctx context.Context, domainID, resource string, count uint64) (bool, error) {
+	return es.svc.CheckQuota(ctx, domainID, resource, count)
+}
+
+func (es *eventStore) ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) ([]string, error) {
+	return es.svc.ReserveQuota(ctx, domainID, resource, existing, ids...)
+}
+
+func (es *eventStore) ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) error {
+	return es.svc.ReleaseQuota(ctx, domainID, resource, ids...)
+}
+
+func (es *eventStore) DeleteDomain(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	dd, err := es.svc.DeleteDomain(ctx, token, id)
+	if err != nil {
+		return dd, err
+	}
+
+	if err := es.Publish(ctx, deleteDomainEvent{dd}); err != nil {
+		return dd, err
+	}
+
+	return dd, nil
+}
+
+func (es *eventStore) RetrieveDomainDeletion(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	return es.svc.RetrieveDomainDeletion(ctx, token, id)
+}
+
+func (es *eventStore) PurgeDomains(ctx context.Context, before time.Time) ([]auth.DomainDeletion, error) {
+	dds, err := es.svc.PurgeDomains(ctx, before)
+	// The services consuming the removed domains remove the domains data.
+	for _, dd := range dds {
+		if dd.Status != auth.DeletionAuthCompleted {
+			continue
+		}
+		if errPublish := es.Publish(ctx, removeDomainEvent{dd}); errPublish != nil {
+			if err != nil {
+				return dds, errors.Wrap(err, errPublish)
+			}
+			return dds, errPublish
+		}
+	}
+
+	return dds, err
+}
+
 func (es *eventStore) DeleteEntityPolicies(ctx context.Context, entityType, id string) error {
 	return es.svc.DeleteEntityPolicies(ctx, entityType, id)
 }
@@ -235,6 +349,21 @@ func (es *eventStore) DeletePolicies(ctx context.Context, prs []auth.PolicyReq)
 	return es.svc.DeletePolicies(ctx, prs)
 }
 
+func (es *eventStore) ExpirePolicies(ctx context.Context) ([]auth.ConditionalPolicy, error) {
+	cps, err := es.svc.ExpirePolicies(ctx)
+	// Publish the policies expired before the failure as well.
+	for _, cp := range cps {
+		if errPublish := es.Publish(ctx, expirePolicyEvent{cp}); errPublish != nil {
+			if err != nil {
+				return cps, errors.Wrap(err, errPublish)
+			}
+			return cps, errPublish
+		}
+	}
+
+	return cps, err
+}
+
 func (es *eventStore) ListObjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) (auth.PolicyPage, error) {
 	return es.svc.ListObjects(ctx, pr, nextPageToken, limit)
 }
@@ -262,3 +391,136 @@ func (es *eventStore) CountSubjects(ctx context.Context, pr auth.PolicyReq) (uin
 func (es *eventStore) ListPermissions(ctx context.Context, pr auth.PolicyReq, filterPermission []string) (auth.Permissions, error) {
 	return es.svc.ListPermissions(ctx, pr, filterPermission)
 }
+
+func (es *eventStore) CreateRole(ctx context.Context, token, domainID string, r auth.Role) (auth.Role, error) {
+	role, err := es.svc.CreateRole(ctx, token, domainID, r)
+	if err != nil {
+		return role, err
+	}
+
+	if err := es.Publish(ctx, createRoleEvent{role}); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (es *eventStore) RetrieveRole(ctx context.Context, token, domainID, id string) (auth.Role, error) {
+	return es.svc.RetrieveRole(ctx, token, domainID, id)
+}
+
+func (es *eventStore) ListRoleAssignments(ctx context.Context, token, domainID, id string) ([]auth.RoleAssignment, error) {
+	return es.svc.ListRoleAssignments(ctx, token, domainID, id)
+}
+
+func (es *eventStore) ListRoles(ctx context.Context, token, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	return es.svc.ListRoles(ctx, token, domainID, pm)
+}
+
+func (es *eventStore) UpdateRole(ctx context.Context, token, domainID, id string, r auth.RoleReq) (auth.Role, error) {
+	role, err := es.svc.UpdateRole(ctx, token, domainID, id, r)
+	if err != nil {
+		return role, err
+	}
+
+	if err := es.Publish(ctx, updateRoleEvent{role}); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (es *eventStore) DeleteRole(ctx context.Context, token, domainID, id string) error {
+	if err := es.svc.DeleteRole(ctx, token, domainID, id); err != nil {
+		return err
+	}
+
+	event := deleteRoleEvent{
+		id:       id,
+		domainID: domainID,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) CreateClaimMapping(ctx context.Context, token, domainID string, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	mapping, err := es.svc.CreateClaimMapping(ctx, token, domainID, m)
+	if err != nil {
+		return mapping, err
+	}
+
+	if err := es.Publish(ctx, createClaimMappingEvent{mapping}); err != nil {
+		return mapping, err
+	}
+
+	return mapping, nil
+}
+
+func (es *eventStore) RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (auth.ClaimMapping, error) {
+	return es.svc.RetrieveClaimMapping(ctx, token, domainID, id)
+}
+
+func (es *eventStore) ListClaimMappings(ctx context.Context, token, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	return es.svc.ListClaimMappings(ctx, token, domainID, pm)
+}
+
+func (es *eventStore) DeleteClaimMapping(ctx context.Context, token, domainID, id string) error {
+	if err := es.svc.DeleteClaimMapping(ctx, token, domainID, id); err != nil {
+		return err
+	}
+
+	event := deleteClaimMappingEvent{
+		id:       id,
+		domainID: domainID,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) error {
+	if err := es.svc.ApplyClaimMappings(ctx, userID, provider, groups); err != nil {
+		return err
+	}
+
+	event := applyClaimMappingsEvent{
+		userID:   userID,
+		provider: provider,
+		groups:   groups,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	if err := es.svc.AssignRole(ctx, token, domainID, id, entity, entityID, userIDs); err != nil {
+		return err
+	}
+
+	event := assignRoleEvent{
+		operation: roleAssign,
+		id:        id,
+		domainID:  domainID,
+		entity:    entity,
+		entityID:  entityID,
+		userIDs:   userIDs,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	if err := es.svc.UnassignRole(ctx, token, domainID, id, entity, entityID, userIDs); err != nil {
+		return err
+	}
+
+	event := assignRoleEvent{
+		operation: roleUnassign,
+		id:        id,
+		domainID:  domainID,
+		entity:    entity,
+		entityID:  entityID,
+		userIDs:   userIDs,
+	}
+
+	return es.Publish(ctx, event)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+)
+
+const (
+	thingCreate          = "thing.create"
+	thingRemove          = "thing.remove"
+	groupCreate          = "group.create"
+	groupRemove          = "group.remove"
+	userRemove           = "user.remove"
+	serviceAccountRemove = "user.service_account.remove"
+)
+
+type usageHandler struct {
+	repo auth.UsageRepository
+}
+
+// NewUsageHandler returns the event handler which records
+// the domain resources usage from the platform events.
+func NewUsageHandler(repo auth.UsageRepository) events.EventHandler {
+	return &usageHandler{
+		repo: repo,
+	}
+}
+
+// StartUsageAccounting starts recording the domain resources usage from the event store.
+func StartUsageAccounting(ctx context.Context, consumer string, sub events.Subscriber, repo auth.UsageRepository) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   store.StreamAllEvents,
+		Handler:  NewUsageHandler(repo),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+func (h *usageHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case thingCreate:
+		return h.add(ctx, events.Read(msg, "domain", ""), auth.ThingsQuota, events.Read(msg, "id", ""))
+	case thingRemove:
+		return h.repo.RemoveEntities(ctx, "", auth.ThingsQuota, events.Read(msg, "id", ""))
+	case groupCreate:
+		// Only the channels are limited, user groups share the same events.
+		if events.Read(msg, "kind", "") != auth.NewChannelKind {
+			return nil
+		}
+		return h.add(ctx, events.Read(msg, "domain", ""), auth.ChannelsQuota, events.Read(msg, "id", ""))
+	case groupRemove:
+		return h.repo.RemoveEntities(ctx, "", auth.ChannelsQuota, events.Read(msg, "id", ""))
+	case domainCreate:
+		return h.add(ctx, events.Read(msg, "id", ""), auth.UsersQuota, events.Read(msg, "created_by", ""))
+	case domainAssign:
+		return h.add(ctx, events.Read(msg, "domain_id", ""), auth.UsersQuota, events.ReadStringSlice(msg, "user_ids")...)
+	case domainUnassign:
+		return h.repo.RemoveEntities(ctx, events.Read(msg, "domain_id", ""), auth.UsersQuota, events.Read(msg, "user_id", ""))
+	case policyExpire:
+		if events.Read(msg, "object_type", "") != auth.DomainType || events.Read(msg, "subject_type", "") != auth.UserType {
+			return nil
+		}
+		return h.repo.RemoveEntities(ctx, events.Read(msg, "domain_id", ""), auth.UsersQuota, events.Read(msg, "user_id", ""))
+	case userRemove, serviceAccountRemove:
+		return h.repo.RemoveEntities(ctx, "", auth.UsersQuota, events.Read(msg, "id", ""))
+	}
+
+	return nil
+}
+
+func (h *usageHandler) add(ctx context.Context, domainID, resource string, ids ...string) error {
+	if domainID == "" || len(ids) == 0 {
+		return svcerr.ErrMalformedEntity
+	}
+
+	return h.repo.AddEntities(ctx, domainID, resource, ids...)
+}
+
+// messagesKey identifies the messages of the channel published on the day.
+type messagesKey struct {
+	channelID string
+	day       time.Time
+}
+
+type messagesCount struct {
+	count uint64
+	size  uint64
+}
+
+// messagesHandler aggregates the published messages in memory and records
+// them periodically, so the usage is not written for each message.
+type messagesHandler struct {
+	repo   auth.UsageRepository
+	logger *slog.Logger
+	mu     sync.Mutex
+	counts map[messagesKey]messagesCount
+}
+
+// StartMessagesAccounting starts recording the number and the size of the
+// messages published to the channels of the domains. The messages are
+// aggregated per channel and recorded every flush interval, and once more
+// when the context is canceled.
+func StartMessagesAccounting(ctx context.Context, id string, sub messaging.Subscriber, repo auth.UsageRepository, flushInterval time.Duration, logger *slog.Logger) error {
+	h := &messagesHandler{
+		repo:   repo,
+		logger: logger,
+		counts: make(map[messagesKey]messagesCount),
+	}
+	subCfg := messaging.SubscriberConfig{
+		ID:      id,
+		Topic:   brokers.SubjectAllChannels,
+		Handler: h,
+	}
+	if err := sub.Subscribe(ctx, subCfg); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				h.flush(context.WithoutCancel(ctx))
+				return
+			case <-ticker.C:
+				h.flush(ctx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (h *messagesHandler) Handle(msg *messaging.Message) error {
+	at := time.Unix(0, msg.GetCreated())
+	if msg.GetCreated() == 0 {
+		at = time.Now()
+	}
+	key := messagesKey{
+		channelID: msg.GetChannel(),
+		day:       at.UTC().Truncate(24 * time.Hour),
+	}
+	h.add(key, messagesCount{count: 1, size: uint64(len(msg.GetPayload()))})
+
+	return nil
+}
+
+func (h *messagesHandler) Cancel() error {
+	return nil
+}
+
+func (h *messagesHandler) add(key messagesKey, c messagesCount) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total := h.counts[key]
+	total.count += c.count
+	total.size += c.size
+	h.counts[key] = total
+}
+
+// flush records the aggregated messages. The messages which failed to be
+// recorded are kept for the next flush.
+func (h *messagesHandler) flush(ctx context.Context) {
+	h.mu.Lock()
+	counts := h.counts
+	h.counts = make(map[messagesKey]messagesCount)
+	h.mu.Unlock()
+
+	for key, c := range counts {
+		if err := h.repo.AddMessages(ctx, key.channelID, key.day, c.count, c.size); err != nil {
+			h.logger.Warn("failed to record messages usage", slog.String("channel_id", key.channelID), slog.Any("error", err))
+			h.add(key, c)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/events"
+	"github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	msgmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testEvent map[string]interface{}
+
+func (te testEvent) Encode() (map[string]interface{}, error) {
+	return te, nil
+}
+
+func TestUsageHandler(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	entityID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		event    testEvent
+		method   string
+		domainID string
+		resource string
+		ids      []string
+		err      error
+	}{
+		{
+			desc:     "handle thing create event",
+			event:    testEvent{"operation": "thing.create", "domain": domainID, "id": entityID},
+			method:   "AddEntities",
+			domainID: domainID,
+			resource: auth.ThingsQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:     "handle thing remove event",
+			event:    testEvent{"operation": "thing.remove", "id": entityID},
+			method:   "RemoveEntities",
+			resource: auth.ThingsQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:     "handle channel create event",
+			event:    testEvent{"operation": "group.create", "domain": domainID, "id": entityID, "kind": auth.NewChannelKind},
+			method:   "AddEntities",
+			domainID: domainID,
+			resource: auth.ChannelsQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:  "handle user group create event",
+			event: testEvent{"operation": "group.create", "domain": domainID, "id": entityID, "kind": auth.NewGroupKind},
+		},
+		{
+			desc:     "handle domain assign event",
+			event:    testEvent{"operation": "domain.assign", "domain_id": domainID, "user_ids": []interface{}{entityID}},
+			method:   "AddEntities",
+			domainID: domainID,
+			resource: auth.UsersQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:     "handle domain unassign event",
+			event:    testEvent{"operation": "domain.unassign", "domain_id": domainID, "user_id": entityID},
+			method:   "RemoveEntities",
+			domainID: domainID,
+			resource: auth.UsersQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:     "handle user remove event",
+			event:    testEvent{"operation": "user.remove", "id": entityID},
+			method:   "RemoveEntities",
+			resource: auth.UsersQuota,
+			ids:      []string{entityID},
+		},
+		{
+			desc:  "handle thing create event without domain",
+			event: testEvent{"operation": "thing.create", "id": entityID},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "handle unrelated event",
+			event: testEvent{"operation": "thing.view", "id": entityID},
+		},
+	}
+
+	for _, tc := range cases {
+		repo := new(mocks.UsageRepository)
+		repo.On("AddEntities", mock.Anything, tc.domainID, tc.resource, mock.Anything).Return(nil)
+		repo.On("RemoveEntities", mock.Anything, tc.domainID, tc.resource, mock.Anything).Return(nil)
+		err := events.NewUsageHandler(repo).Handle(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.method == "" {
+			repo.AssertNotCalled(t, "AddEntities", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "RemoveEntities", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+		repo.AssertCalled(t, tc.method, mock.Anything, tc.domainID, tc.resource, tc.ids[0])
+	}
+}
+
+func TestMessagesAccounting(t *testing.T) {
+	channelID := testsutil.GenerateUUID(t)
+	otherID := testsutil.GenerateUUID(t)
+	created := time.Date(2024, 5, 10, 13, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		desc     string
+		interval time.Duration
+		msgs     []*messaging.Message
+		setup    func(repo *mocks.UsageRepository)
+		// onCancel is set if the messages are recorded only once the
+		// accounting is stopped.
+		onCancel bool
+	}{
+		{
+			desc:     "aggregate messages per channel and day",
+			interval: time.Hour,
+			msgs: []*messaging.Message{
+				{Channel: channelID, Payload: []byte("abc"), Created: created.UnixNano()},
+				{Channel: channelID, Payload: []byte("abcd"), Created: created.Add(time.Hour).UnixNano()},
+				{Channel: otherID, Payload: []byte("abcd"), Created: created.UnixNano()},
+			},
+			setup: func(repo *mocks.UsageRepository) {
+				repo.On("AddMessages", mock.Anything, channelID, day, uint64(2), uint64(7)).Return(nil).Once()
+				repo.On("AddMessages", mock.Anything, otherID, day, uint64(1), uint64(4)).Return(nil).Once()
+			},
+			onCancel: true,
+		},
+		{
+			desc:     "retry failed messages on next flush",
+			interval: 10 * time.Millisecond,
+			msgs: []*messaging.Message{
+				{Channel: channelID, Payload: []byte("abc"), Created: created.UnixNano()},
+			},
+			setup: func(repo *mocks.UsageRepository) {
+				repo.On("AddMessages", mock.Anything, channelID, day, uint64(1), uint64(3)).Return(repoerr.ErrCreateEntity).Once()
+				repo.On("AddMessages", mock.Anything, channelID, day, uint64(1), uint64(3)).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		repo := new(mocks.UsageRepository)
+		tc.setup(repo)
+
+		var handler messaging.MessageHandler
+		sub := new(msgmocks.PubSub)
+		sub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			handler = args.Get(1).(messaging.SubscriberConfig).Handler
+		}).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		err := events.StartMessagesAccounting(ctx, "auth", sub, repo, tc.interval, slog.Default())
+		assert.Nil(t, err, fmt.Sprintf("%s: starting messages accounting expected to succeed: %s", tc.desc, err))
+		for _, msg := range tc.msgs {
+			err := handler.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: handling message expected to succeed: %s", tc.desc, err))
+		}
+		if tc.onCancel {
+			repo.AssertNotCalled(t, "AddMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			cancel()
+		}
+		assert.Eventually(t, func() bool {
+			return repo.AssertExpectations(new(testing.T))
+		}, time.Second, 10*time.Millisecond, fmt.Sprintf("%s: expected messages to be recorded", tc.desc))
+		cancel()
+	}
+}
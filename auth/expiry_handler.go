@@ -0,0 +1,58 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The ExpiryHandler is a cron job that runs periodically to remove the policies whose conditions expired.
+// The handler runs in a separate goroutine and removes the lapsed policies through the service,
+// so the service middlewares log and publish the expired policies.
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type expiryHandler struct {
+	svc           Service
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+func NewExpiryHandler(ctx context.Context, svc Service, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &expiryHandler{
+		svc:           svc,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *expiryHandler) handle(ctx context.Context) {
+	cps, err := h.svc.ExpirePolicies(ctx)
+	if err != nil {
+		h.logger.Error("failed to expire policies", slog.Any("error", err))
+	}
+
+	for _, cp := range cps {
+		h.logger.Info("policy expired", slog.Group("policy",
+			slog.String("subject", cp.Subject),
+			slog.String("relation", cp.Relation),
+			slog.String("object_type", cp.ObjectType),
+			slog.String("object", cp.Object),
+		))
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"crypto"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+const sigUse = "sig"
+
+// ParseKey parses the PEM encoded private or public key. The key ID is set
+// to the key thumbprint, so the same key always gets the same ID.
+func ParseKey(data []byte) (jwk.Key, error) {
+	key, err := jwk.ParseKey(data, jwk.WithPEM(true))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err)
+	}
+	if err := prepareKey(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// prepareKey sets the key algorithm, usage and ID if they are missing.
+func prepareKey(key jwk.Key) error {
+	if key == nil {
+		return errors.Wrap(ErrInvalidKey, errors.New("missing key"))
+	}
+	alg, err := algorithm(key)
+	if err != nil {
+		return err
+	}
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return errors.Wrap(ErrInvalidKey, err)
+	}
+	if err := key.Set(jwk.KeyUsageKey, sigUse); err != nil {
+		return errors.Wrap(ErrInvalidKey, err)
+	}
+	if key.KeyID() == "" {
+		if err := jwk.AssignKeyID(key, jwk.WithThumbprintHash(crypto.SHA256)); err != nil {
+			return errors.Wrap(ErrInvalidKey, err)
+		}
+	}
+
+	return nil
+}
+
+func algorithm(key jwk.Key) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case jwk.RSAPrivateKey, jwk.RSAPublicKey:
+		return jwa.RS256, nil
+	case jwk.ECDSAPrivateKey:
+		if k.Crv() == jwa.P256 {
+			return jwa.ES256, nil
+		}
+	case jwk.ECDSAPublicKey:
+		if k.Crv() == jwa.P256 {
+			return jwa.ES256, nil
+		}
+	case jwk.OKPPrivateKey:
+		if k.Crv() == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+	case jwk.OKPPublicKey:
+		if k.Crv() == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+	}
+
+	return "", errors.Wrap(ErrInvalidKey, errors.New("supported keys are RSA, ECDSA P-256 and Ed25519"))
+}
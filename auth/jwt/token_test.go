@@ -4,7 +4,15 @@
 package jwt_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +22,8 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -233,6 +243,180 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestAsymmetricIssueParse(t *testing.T) {
+	rsaKey := newKey(t, func() (interface{}, error) { return rsa.GenerateKey(rand.Reader, 2048) })
+	ecKey := newKey(t, func() (interface{}, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) })
+	edKey := newKey(t, func() (interface{}, error) {
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		return k, err
+	})
+
+	cases := []struct {
+		desc string
+		key  jwk.Key
+		alg  jwa.SignatureAlgorithm
+	}{
+		{
+			desc: "issue and parse token signed with RSA key",
+			key:  rsaKey,
+			alg:  jwa.RS256,
+		},
+		{
+			desc: "issue and parse token signed with ECDSA key",
+			key:  ecKey,
+			alg:  jwa.ES256,
+		},
+		{
+			desc: "issue and parse token signed with Ed25519 key",
+			key:  edKey,
+			alg:  jwa.EdDSA,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tokenizer, err := authjwt.NewAsymmetric(tc.key, nil, nil)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			token, err := tokenizer.Issue(key())
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			msg, err := jws.Parse([]byte(token))
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			headers := msg.Signatures()[0].ProtectedHeaders()
+			assert.Equal(t, tc.alg, headers.Algorithm(), fmt.Sprintf("%s: expected algorithm %s got %s", tc.desc, tc.alg, headers.Algorithm()))
+			assert.Equal(t, tc.key.KeyID(), headers.KeyID(), fmt.Sprintf("%s: expected kid %s got %s", tc.desc, tc.key.KeyID(), headers.KeyID()))
+
+			parsed, err := tokenizer.Parse(token)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, key(), parsed, fmt.Sprintf("%s: expected %v got %v", tc.desc, key(), parsed))
+
+			jwks, err := tokenizer.RetrieveJWKS()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Len(t, jwks, 1, fmt.Sprintf("%s: expected a single key", tc.desc))
+			assert.Equal(t, tc.key.KeyID(), jwks[0].KeyID, fmt.Sprintf("%s: expected kid %s got %s", tc.desc, tc.key.KeyID(), jwks[0].KeyID))
+			assert.Equal(t, string(tc.alg), jwks[0].Algorithm, fmt.Sprintf("%s: expected algorithm %s got %s", tc.desc, tc.alg, jwks[0].Algorithm))
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := newKey(t, func() (interface{}, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) })
+	signingKey := newKey(t, func() (interface{}, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) })
+	otherKey := newKey(t, func() (interface{}, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) })
+
+	oldTokenizer, err := authjwt.NewAsymmetric(oldKey, nil, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	oldToken, err := oldTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	otherTokenizer, err := authjwt.NewAsymmetric(otherKey, nil, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	otherToken, err := otherTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	pub, err := jwk.PublicKeyOf(oldKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	tokenizer, err := authjwt.NewAsymmetric(signingKey, []jwk.Key{pub}, []byte(secret))
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	newToken, err := tokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	secretToken, err := authjwt.New([]byte(secret)).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "parse token signed with the signing key",
+			token: newToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse token signed with the retired key",
+			token: oldToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse token signed with the secret",
+			token: secretToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse token signed with unknown key",
+			token: otherToken,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tokenizer.Parse(tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+
+	jwks, err := tokenizer.RetrieveJWKS()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Len(t, jwks, 2, "expected signing and retired key")
+	for _, k := range jwks {
+		assert.Equal(t, "EC", k.KeyType, fmt.Sprintf("expected EC key type got %s", k.KeyType))
+		assert.NotEmpty(t, k.X, "expected public key parameters")
+		assert.NotEmpty(t, k.Y, "expected public key parameters")
+	}
+
+	_, err = authjwt.NewAsymmetric(nil, nil, nil)
+	assert.True(t, errors.Contains(err, authjwt.ErrInvalidKey), fmt.Sprintf("expected %s got %s", authjwt.ErrInvalidKey, err))
+}
+
+func TestParseKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	p384DER, err := x509.MarshalPKCS8PrivateKey(p384Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc string
+		data []byte
+		err  error
+	}{
+		{
+			desc: "parse ECDSA P-256 private key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}),
+			err:  nil,
+		},
+		{
+			desc: "parse ECDSA P-384 private key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: p384DER}),
+			err:  authjwt.ErrInvalidKey,
+		},
+		{
+			desc: "parse invalid key",
+			data: []byte(strings.Repeat("invalid", 3)),
+			err:  authjwt.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := authjwt.ParseKey(tc.data)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, key.KeyID(), fmt.Sprintf("%s expected key ID", tc.desc))
+		}
+	}
+}
+
+func newKey(t *testing.T, generate func() (interface{}, error)) jwk.Key {
+	raw, err := generate()
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("creating JWK expected to succeed: %s", err))
+	return key
+}
+
 func key() auth.Key {
 	exp := time.Now().UTC().Add(10 * time.Minute).Round(time.Second)
 	return auth.Key{
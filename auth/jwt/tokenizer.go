@@ -13,6 +13,8 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
@@ -26,6 +28,8 @@ var (
 	ErrValidateJWTToken = errors.New("failed to validate jwt token")
 	// ErrJSONHandle indicates an error in handling JSON.
 	ErrJSONHandle = errors.New("failed to perform operation JSON")
+	// ErrInvalidKey indicates an invalid or unsupported signing key.
+	ErrInvalidKey = errors.New("invalid or unsupported signing key")
 )
 
 const (
@@ -33,6 +37,8 @@ const (
 	tokenType              = "type"
 	userField              = "user"
 	domainField            = "domain"
+	generationField        = "generation"
+	mfaField               = "mfa"
 	oauthProviderField     = "oauth_provider"
 	oauthAccessTokenField  = "access_token"
 	oauthRefreshTokenField = "refresh_token"
@@ -40,28 +46,80 @@ const (
 
 type tokenizer struct {
 	secret []byte
+	// signingKey is the private key used to sign tokens. If it is nil,
+	// tokens are signed with the HS512 secret.
+	signingKey jwk.Key
+	// verificationKeys contains the public keys of the signing key and
+	// the retired keys whose tokens are still accepted.
+	verificationKeys jwk.Set
 }
 
 var _ auth.Tokenizer = (*tokenizer)(nil)
 
-// NewRepository instantiates an implementation of Token repository.
+// New instantiates the tokenizer signing tokens with the HS512 secret.
 func New(secret []byte) auth.Tokenizer {
 	return &tokenizer{
 		secret: secret,
 	}
 }
 
+// NewAsymmetric instantiates the tokenizer signing tokens with the
+// RSA (RS256), ECDSA P-256 (ES256) or Ed25519 (EdDSA) private key.
+// Tokens signed by any of the verification keys are accepted as well,
+// so the signing key can be rotated without invalidating issued tokens.
+// If the secret is not empty, HS512 tokens issued before switching to
+// the asymmetric keys are accepted too.
+func NewAsymmetric(signingKey jwk.Key, verificationKeys []jwk.Key, secret []byte) (auth.Tokenizer, error) {
+	if err := prepareKey(signingKey); err != nil {
+		return nil, err
+	}
+	if _, ok := signingKey.(jwk.SymmetricKey); ok {
+		return nil, errors.Wrap(ErrInvalidKey, errors.New("signing key must be a private key"))
+	}
+	set := jwk.NewSet()
+	for _, key := range append([]jwk.Key{signingKey}, verificationKeys...) {
+		if err := prepareKey(key); err != nil {
+			return nil, err
+		}
+		pub, err := jwk.PublicKeyOf(key)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidKey, err)
+		}
+		if _, ok := set.LookupKeyID(pub.KeyID()); ok {
+			continue
+		}
+		if err := set.AddKey(pub); err != nil {
+			return nil, errors.Wrap(ErrInvalidKey, err)
+		}
+	}
+
+	return &tokenizer{
+		secret:           secret,
+		signingKey:       signingKey,
+		verificationKeys: set,
+	}, nil
+}
+
 func (tok *tokenizer) Issue(key auth.Key) (string, error) {
 	builder := jwt.NewBuilder()
 	builder.
 		Issuer(issuerName).
 		IssuedAt(key.IssuedAt).
-		Claim(tokenType, key.Type).
-		Expiration(key.ExpiresAt)
+		Claim(tokenType, key.Type)
+	// API keys may not expire.
+	if !key.ExpiresAt.IsZero() {
+		builder.Expiration(key.ExpiresAt)
+	}
 	builder.Claim(userField, key.User)
 	if key.Domain != "" {
 		builder.Claim(domainField, key.Domain)
 	}
+	if key.Generation != 0 {
+		builder.Claim(generationField, key.Generation)
+	}
+	if key.MFA {
+		builder.Claim(mfaField, key.MFA)
+	}
 	if key.Subject != "" {
 		builder.Subject(key.Subject)
 	}
@@ -72,7 +130,11 @@ func (tok *tokenizer) Issue(key auth.Key) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS512, tok.secret))
+	opt := jwt.WithKey(jwa.HS512, tok.secret)
+	if tok.signingKey != nil {
+		opt = jwt.WithKey(tok.signingKey.Algorithm(), tok.signingKey)
+	}
+	signedTkn, err := jwt.Sign(tkn, opt)
 	if err != nil {
 		return "", errors.Wrap(ErrSignJWT, err)
 	}
@@ -93,11 +155,32 @@ func (tok *tokenizer) Parse(token string) (auth.Key, error) {
 	return key, nil
 }
 
+func (tok *tokenizer) RetrieveJWKS() ([]auth.JWK, error) {
+	keys := []auth.JWK{}
+	if tok.verificationKeys == nil {
+		return keys, nil
+	}
+	for i := 0; i < tok.verificationKeys.Len(); i++ {
+		key, _ := tok.verificationKeys.Key(i)
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, errors.Wrap(ErrJSONHandle, err)
+		}
+		var pub auth.JWK
+		if err := json.Unmarshal(data, &pub); err != nil {
+			return nil, errors.Wrap(ErrJSONHandle, err)
+		}
+		keys = append(keys, pub)
+	}
+
+	return keys, nil
+}
+
 func (tok *tokenizer) validateToken(token string) (jwt.Token, error) {
 	tkn, err := jwt.Parse(
 		[]byte(token),
 		jwt.WithValidate(true),
-		jwt.WithKey(jwa.HS512, tok.secret),
+		tok.verificationOption(token),
 	)
 	if err != nil {
 		if errors.Contains(err, errJWTExpiryKey) {
@@ -119,6 +202,22 @@ func (tok *tokenizer) validateToken(token string) (jwt.Token, error) {
 	return tkn, nil
 }
 
+// verificationOption selects the keys the token is verified with. Tokens
+// signed with the secret are verified only if the secret is set.
+func (tok *tokenizer) verificationOption(token string) jwt.ParseOption {
+	if tok.verificationKeys == nil {
+		return jwt.WithKey(jwa.HS512, tok.secret)
+	}
+	if len(tok.secret) > 0 {
+		if msg, err := jws.Parse([]byte(token)); err == nil && len(msg.Signatures()) == 1 &&
+			msg.Signatures()[0].ProtectedHeaders().Algorithm() == jwa.HS512 {
+			return jwt.WithKey(jwa.HS512, tok.secret)
+		}
+	}
+
+	return jwt.WithKeySet(tok.verificationKeys)
+}
+
 func toKey(tkn jwt.Token) (auth.Key, error) {
 	data, err := json.Marshal(tkn.PrivateClaims())
 	if err != nil {
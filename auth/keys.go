@@ -59,6 +59,24 @@ type Key struct {
 	Domain    string    `json:"domain,omitempty"` // domain user ID
 	IssuedAt  time.Time `json:"issued_at,omitempty"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Generation is the token generation of the user at the time the
+	// key is issued. It is not used for API keys.
+	Generation uint64 `json:"generation,omitempty"`
+	// MFA indicates that the user completed the multi-factor
+	// authentication when logging in.
+	MFA bool `json:"mfa,omitempty"`
+	// Scopes limit the API key operations. The key is not limited if
+	// there are no scopes. Scopes are stored with the key rather than
+	// in the token, so they are read from the repository.
+	Scopes []Scope `json:"-"`
+}
+
+// KeyPage contains page related metadata as well as list of API keys.
+type KeyPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []Key  `json:"keys"`
 }
 
 func (key Key) String() string {
@@ -74,6 +92,20 @@ func (key Key) String() string {
 }`, key.ID, key.Type, key.Issuer, key.Subject, key.User, key.Domain, key.IssuedAt, key.ExpiresAt)
 }
 
+// Allows reports whether the key scopes allow the permission on the object.
+func (key Key) Allows(objectType, permission, object string) bool {
+	if len(key.Scopes) == 0 {
+		return true
+	}
+	for _, scope := range key.Scopes {
+		if scope.Allows(objectType, permission, object) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Expired verifies if the key is expired.
 func (key Key) Expired() bool {
 	if key.Type == APIKey && key.ExpiresAt.IsZero() {
@@ -93,6 +125,9 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(ctx context.Context, issuer string, id string) (key Key, err error)
 
+	// RetrieveAll retrieves the API keys issued for the page subject.
+	RetrieveAll(ctx context.Context, pm Page) (KeyPage, error)
+
 	// Remove removes Key with provided ID.
 	Remove(ctx context.Context, issuer string, id string) error
 }
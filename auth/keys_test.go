@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,3 +59,140 @@ func TestExpired(t *testing.T) {
 		assert.Equal(t, tc.expired, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.expired, res))
 	}
 }
+
+func TestKeyAllows(t *testing.T) {
+	thingID := "9bb1f0b1-b7b0-4e5a-9e47-6b7b6a0f6c2a"
+	cases := []struct {
+		desc       string
+		scopes     []auth.Scope
+		objectType string
+		permission string
+		object     string
+		allowed    bool
+	}{
+		{
+			desc:       "key without scopes",
+			objectType: auth.ThingType,
+			permission: auth.DeletePermission,
+			object:     thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "scope with matching operation",
+			scopes:     []auth.Scope{{Operation: "things:view"}},
+			objectType: auth.ThingType,
+			permission: auth.ViewPermission,
+			object:     thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "scope with other permission",
+			scopes:     []auth.Scope{{Operation: "things:view"}},
+			objectType: auth.ThingType,
+			permission: auth.EditPermission,
+			object:     thingID,
+			allowed:    false,
+		},
+		{
+			desc:       "scope with any permission",
+			scopes:     []auth.Scope{{Operation: "things:*"}},
+			objectType: auth.ThingType,
+			permission: auth.EditPermission,
+			object:     thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "scope with other entity",
+			scopes:     []auth.Scope{{Operation: "things:*"}},
+			objectType: auth.GroupType,
+			permission: auth.ViewPermission,
+			object:     thingID,
+			allowed:    false,
+		},
+		{
+			desc:       "channels scope on group",
+			scopes:     []auth.Scope{{Operation: "channels:publish"}},
+			objectType: auth.GroupType,
+			permission: auth.PublishPermission,
+			object:     thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "platform scope on platform",
+			scopes:     []auth.Scope{{Operation: "platform:admin"}},
+			objectType: auth.PlatformType,
+			permission: auth.AdminPermission,
+			object:     auth.MagistralaObject,
+			allowed:    true,
+		},
+		{
+			desc:       "users scope on platform",
+			scopes:     []auth.Scope{{Operation: "users:*"}},
+			objectType: auth.PlatformType,
+			permission: auth.AdminPermission,
+			object:     auth.MagistralaObject,
+			allowed:    false,
+		},
+		{
+			desc:       "scope with listed entity",
+			scopes:     []auth.Scope{{Operation: "things:view", EntityIDs: []string{thingID}}},
+			objectType: auth.ThingType,
+			permission: auth.ViewPermission,
+			object:     thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "scope with other entity ID",
+			scopes:     []auth.Scope{{Operation: "things:view", EntityIDs: []string{thingID}}},
+			objectType: auth.ThingType,
+			permission: auth.ViewPermission,
+			object:     "other",
+			allowed:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		key := auth.Key{Type: auth.APIKey, Scopes: tc.scopes}
+		res := key.Allows(tc.objectType, tc.permission, tc.object)
+		assert.Equal(t, tc.allowed, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, res))
+	}
+}
+
+func TestScopeValidate(t *testing.T) {
+	cases := []struct {
+		desc  string
+		scope auth.Scope
+		err   error
+	}{
+		{
+			desc:  "valid scope",
+			scope: auth.Scope{Operation: "channels:subscribe", EntityIDs: []string{"id"}},
+			err:   nil,
+		},
+		{
+			desc:  "scope without permission",
+			scope: auth.Scope{Operation: "things"},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "scope with unsupported entity",
+			scope: auth.Scope{Operation: "bootstrap:view"},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "scope with unsupported permission",
+			scope: auth.Scope{Operation: "things:read"},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "scope with empty entity ID",
+			scope: auth.Scope{Operation: "things:view", EntityIDs: []string{""}},
+			err:   auth.ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.scope.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -107,6 +107,34 @@ func (_m *PolicyAgent) DeletePolicyFilter(ctx context.Context, pr auth.PolicyReq
 	return r0
 }
 
+// ExplainPolicy provides a mock function with given fields: ctx, pr
+func (_m *PolicyAgent) ExplainPolicy(ctx context.Context, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	ret := _m.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPolicy")
+	}
+
+	var r0 auth.PolicyExplanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.PolicyReq) (auth.PolicyExplanation, error)); ok {
+		return rf(ctx, pr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.PolicyReq) auth.PolicyExplanation); ok {
+		r0 = rf(ctx, pr)
+	} else {
+		r0 = ret.Get(0).(auth.PolicyExplanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.PolicyReq) error); ok {
+		r1 = rf(ctx, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAllObjects provides a mock function with given fields: ctx, pr
 func (_m *PolicyAgent) RetrieveAllObjects(ctx context.Context, pr auth.PolicyReq) ([]auth.PolicyRes, error) {
 	ret := _m.Called(ctx, pr)
@@ -19,6 +19,80 @@ type AuthServiceClient struct {
 	mock.Mock
 }
 
+// AddDomainMember provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) AddDomainMember(ctx context.Context, in *magistrala.AddDomainMemberReq, opts ...grpc.CallOption) (*magistrala.AddDomainMemberRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDomainMember")
+	}
+
+	var r0 *magistrala.AddDomainMemberRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.AddDomainMemberReq, ...grpc.CallOption) (*magistrala.AddDomainMemberRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.AddDomainMemberReq, ...grpc.CallOption) *magistrala.AddDomainMemberRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.AddDomainMemberRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.AddDomainMemberReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyClaimMappings provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) ApplyClaimMappings(ctx context.Context, in *magistrala.ApplyClaimMappingsReq, opts ...grpc.CallOption) (*magistrala.ApplyClaimMappingsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyClaimMappings")
+	}
+
+	var r0 *magistrala.ApplyClaimMappingsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ApplyClaimMappingsReq, ...grpc.CallOption) (*magistrala.ApplyClaimMappingsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ApplyClaimMappingsReq, ...grpc.CallOption) *magistrala.ApplyClaimMappingsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ApplyClaimMappingsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ApplyClaimMappingsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Authorize provides a mock function with given fields: ctx, in, opts
 func (_m *AuthServiceClient) Authorize(ctx context.Context, in *magistrala.AuthorizeReq, opts ...grpc.CallOption) (*magistrala.AuthorizeRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -56,6 +130,43 @@ func (_m *AuthServiceClient) Authorize(ctx context.Context, in *magistrala.Autho
 	return r0, r1
 }
 
+// CheckQuota provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) CheckQuota(ctx context.Context, in *magistrala.CheckQuotaReq, opts ...grpc.CallOption) (*magistrala.CheckQuotaRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckQuota")
+	}
+
+	var r0 *magistrala.CheckQuotaRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.CheckQuotaReq, ...grpc.CallOption) (*magistrala.CheckQuotaRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.CheckQuotaReq, ...grpc.CallOption) *magistrala.CheckQuotaRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.CheckQuotaRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.CheckQuotaReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Identify provides a mock function with given fields: ctx, in, opts
 func (_m *AuthServiceClient) Identify(ctx context.Context, in *magistrala.IdentityReq, opts ...grpc.CallOption) (*magistrala.IdentityRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -167,6 +278,117 @@ func (_m *AuthServiceClient) Refresh(ctx context.Context, in *magistrala.Refresh
 	return r0, r1
 }
 
+// ReleaseQuota provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) ReleaseQuota(ctx context.Context, in *magistrala.ReleaseQuotaReq, opts ...grpc.CallOption) (*magistrala.ReleaseQuotaRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseQuota")
+	}
+
+	var r0 *magistrala.ReleaseQuotaRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ReleaseQuotaReq, ...grpc.CallOption) (*magistrala.ReleaseQuotaRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ReleaseQuotaReq, ...grpc.CallOption) *magistrala.ReleaseQuotaRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ReleaseQuotaRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ReleaseQuotaReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveQuota provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) ReserveQuota(ctx context.Context, in *magistrala.ReserveQuotaReq, opts ...grpc.CallOption) (*magistrala.ReserveQuotaRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveQuota")
+	}
+
+	var r0 *magistrala.ReserveQuotaRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ReserveQuotaReq, ...grpc.CallOption) (*magistrala.ReserveQuotaRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ReserveQuotaReq, ...grpc.CallOption) *magistrala.ReserveQuotaRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ReserveQuotaRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ReserveQuotaReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeTokens provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) RevokeTokens(ctx context.Context, in *magistrala.RevokeTokensReq, opts ...grpc.CallOption) (*magistrala.RevokeTokensRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokens")
+	}
+
+	var r0 *magistrala.RevokeTokensRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.RevokeTokensReq, ...grpc.CallOption) (*magistrala.RevokeTokensRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.RevokeTokensReq, ...grpc.CallOption) *magistrala.RevokeTokensRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.RevokeTokensRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.RevokeTokensReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthServiceClient creates a new instance of AuthServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthServiceClient(t interface {
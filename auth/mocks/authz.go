@@ -181,6 +181,64 @@ func (_m *Authz) DeletePolicyFilter(ctx context.Context, pr auth.PolicyReq) erro
 	return r0
 }
 
+// ExpirePolicies provides a mock function with given fields: ctx
+func (_m *Authz) ExpirePolicies(ctx context.Context) ([]auth.ConditionalPolicy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePolicies")
+	}
+
+	var r0 []auth.ConditionalPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.ConditionalPolicy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.ConditionalPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ConditionalPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplainPolicy provides a mock function with given fields: ctx, token, pr
+func (_m *Authz) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	ret := _m.Called(ctx, token, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPolicy")
+	}
+
+	var r0 auth.PolicyExplanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.PolicyReq) (auth.PolicyExplanation, error)); ok {
+		return rf(ctx, token, pr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.PolicyReq) auth.PolicyExplanation); ok {
+		r0 = rf(ctx, token, pr)
+	} else {
+		r0 = ret.Get(0).(auth.PolicyExplanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.PolicyReq) error); ok {
+		r1 = rf(ctx, token, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAllObjects provides a mock function with given fields: ctx, pr
 func (_m *Authz) ListAllObjects(ctx context.Context, pr auth.PolicyReq) (auth.PolicyPage, error) {
 	ret := _m.Called(ctx, pr)
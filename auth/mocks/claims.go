@@ -0,0 +1,230 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/absmach/magistrala/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ClaimMappingsRepository is an autogenerated mock type for the ClaimMappingsRepository type
+type ClaimMappingsRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, domainID, id
+func (_m *ClaimMappingsRepository) Delete(ctx context.Context, domainID string, id string) error {
+	ret := _m.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveGrant provides a mock function with given fields: ctx, domainID, userID
+func (_m *ClaimMappingsRepository) RemoveGrant(ctx context.Context, domainID string, userID string) error {
+	ret := _m.Called(ctx, domainID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, domainID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveAll provides a mock function with given fields: ctx, domainID, pm
+func (_m *ClaimMappingsRepository) RetrieveAll(ctx context.Context, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	ret := _m.Called(ctx, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAll")
+	}
+
+	var r0 auth.ClaimMappingsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) (auth.ClaimMappingsPage, error)); ok {
+		return rf(ctx, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) auth.ClaimMappingsPage); ok {
+		r0 = rf(ctx, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMappingsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.Page) error); ok {
+		r1 = rf(ctx, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveByID provides a mock function with given fields: ctx, domainID, id
+func (_m *ClaimMappingsRepository) RetrieveByID(ctx context.Context, domainID string, id string) (auth.ClaimMapping, error) {
+	ret := _m.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByID")
+	}
+
+	var r0 auth.ClaimMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.ClaimMapping, error)); ok {
+		return rf(ctx, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.ClaimMapping); ok {
+		r0 = rf(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveByProvider provides a mock function with given fields: ctx, provider
+func (_m *ClaimMappingsRepository) RetrieveByProvider(ctx context.Context, provider string) ([]auth.ClaimMapping, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByProvider")
+	}
+
+	var r0 []auth.ClaimMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]auth.ClaimMapping, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []auth.ClaimMapping); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ClaimMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveGrants provides a mock function with given fields: ctx, userID, provider
+func (_m *ClaimMappingsRepository) RetrieveGrants(ctx context.Context, userID string, provider string) ([]auth.ClaimGrant, error) {
+	ret := _m.Called(ctx, userID, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveGrants")
+	}
+
+	var r0 []auth.ClaimGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]auth.ClaimGrant, error)); ok {
+		return rf(ctx, userID, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []auth.ClaimGrant); ok {
+		r0 = rf(ctx, userID, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ClaimGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, m
+func (_m *ClaimMappingsRepository) Save(ctx context.Context, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	ret := _m.Called(ctx, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 auth.ClaimMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.ClaimMapping) (auth.ClaimMapping, error)); ok {
+		return rf(ctx, m)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.ClaimMapping) auth.ClaimMapping); ok {
+		r0 = rf(ctx, m)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.ClaimMapping) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveGrant provides a mock function with given fields: ctx, g
+func (_m *ClaimMappingsRepository) SaveGrant(ctx context.Context, g auth.ClaimGrant) error {
+	ret := _m.Called(ctx, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.ClaimGrant) error); ok {
+		r0 = rf(ctx, g)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewClaimMappingsRepository creates a new instance of ClaimMappingsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClaimMappingsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClaimMappingsRepository {
+	mock := &ClaimMappingsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
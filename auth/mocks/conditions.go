@@ -0,0 +1,176 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/absmach/magistrala/auth"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ConditionsRepository is an autogenerated mock type for the ConditionsRepository type
+type ConditionsRepository struct {
+	mock.Mock
+}
+
+// Exists provides a mock function with given fields: ctx
+func (_m *ConditionsRepository) Exists(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, cps
+func (_m *ConditionsRepository) Remove(ctx context.Context, cps []auth.ConditionalPolicy) error {
+	ret := _m.Called(ctx, cps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []auth.ConditionalPolicy) error); ok {
+		r0 = rf(ctx, cps)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveFilter provides a mock function with given fields: ctx, filter
+func (_m *ConditionsRepository) RemoveFilter(ctx context.Context, filter auth.ConditionalPolicy) error {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFilter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.ConditionalPolicy) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveBySteps provides a mock function with given fields: ctx, steps
+func (_m *ConditionsRepository) RetrieveBySteps(ctx context.Context, steps []auth.PolicyStep) ([]auth.ConditionalPolicy, error) {
+	ret := _m.Called(ctx, steps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveBySteps")
+	}
+
+	var r0 []auth.ConditionalPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []auth.PolicyStep) ([]auth.ConditionalPolicy, error)); ok {
+		return rf(ctx, steps)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []auth.PolicyStep) []auth.ConditionalPolicy); ok {
+		r0 = rf(ctx, steps)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ConditionalPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []auth.PolicyStep) error); ok {
+		r1 = rf(ctx, steps)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveExpired provides a mock function with given fields: ctx, before, limit
+func (_m *ConditionsRepository) RetrieveExpired(ctx context.Context, before time.Time, limit uint64) ([]auth.ConditionalPolicy, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveExpired")
+	}
+
+	var r0 []auth.ConditionalPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint64) ([]auth.ConditionalPolicy, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint64) []auth.ConditionalPolicy); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ConditionalPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint64) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, cps
+func (_m *ConditionsRepository) Save(ctx context.Context, cps []auth.ConditionalPolicy) error {
+	ret := _m.Called(ctx, cps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []auth.ConditionalPolicy) error); ok {
+		r0 = rf(ctx, cps)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewConditionsRepository creates a new instance of ConditionsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConditionsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ConditionsRepository {
+	mock := &ConditionsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -10,6 +10,8 @@ import (
 	auth "github.com/absmach/magistrala/auth"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // DomainsRepository is an autogenerated mock type for the DomainsRepository type
@@ -180,6 +182,64 @@ func (_m *DomainsRepository) RetrieveByID(ctx context.Context, id string) (auth.
 	return r0, r1
 }
 
+// RetrieveDeletion provides a mock function with given fields: ctx, id
+func (_m *DomainsRepository) RetrieveDeletion(ctx context.Context, id string) (auth.DomainDeletion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDeletion")
+	}
+
+	var r0 auth.DomainDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.DomainDeletion, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) auth.DomainDeletion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(auth.DomainDeletion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveDeletions provides a mock function with given fields: ctx, before, now, maxAttempts, limit
+func (_m *DomainsRepository) RetrieveDeletions(ctx context.Context, before time.Time, now time.Time, maxAttempts uint64, limit uint64) ([]auth.DomainDeletion, error) {
+	ret := _m.Called(ctx, before, now, maxAttempts, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDeletions")
+	}
+
+	var r0 []auth.DomainDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64, uint64) ([]auth.DomainDeletion, error)); ok {
+		return rf(ctx, before, now, maxAttempts, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64, uint64) []auth.DomainDeletion); ok {
+		r0 = rf(ctx, before, now, maxAttempts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.DomainDeletion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint64, uint64) error); ok {
+		r1 = rf(ctx, before, now, maxAttempts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrievePermissions provides a mock function with given fields: ctx, subject, id
 func (_m *DomainsRepository) RetrievePermissions(ctx context.Context, subject string, id string) ([]string, error) {
 	ret := _m.Called(ctx, subject, id)
@@ -238,6 +298,24 @@ func (_m *DomainsRepository) Save(ctx context.Context, d auth.Domain) (auth.Doma
 	return r0, r1
 }
 
+// SaveDeletion provides a mock function with given fields: ctx, dd
+func (_m *DomainsRepository) SaveDeletion(ctx context.Context, dd auth.DomainDeletion) error {
+	ret := _m.Called(ctx, dd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.DomainDeletion) error); ok {
+		r0 = rf(ctx, dd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SavePolicies provides a mock function with given fields: ctx, pcs
 func (_m *DomainsRepository) SavePolicies(ctx context.Context, pcs ...auth.Policy) error {
 	_va := make([]interface{}, len(pcs))
@@ -291,6 +369,24 @@ func (_m *DomainsRepository) Update(ctx context.Context, id string, userID strin
 	return r0, r1
 }
 
+// UpdateDeletion provides a mock function with given fields: ctx, dd
+func (_m *DomainsRepository) UpdateDeletion(ctx context.Context, dd auth.DomainDeletion) error {
+	ret := _m.Called(ctx, dd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.DomainDeletion) error); ok {
+		r0 = rf(ctx, dd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDomainsRepository creates a new instance of DomainsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDomainsRepository(t interface {
@@ -63,6 +63,34 @@ func (_m *KeyRepository) Retrieve(ctx context.Context, issuer string, id string)
 	return r0, r1
 }
 
+// RetrieveAll provides a mock function with given fields: ctx, pm
+func (_m *KeyRepository) RetrieveAll(ctx context.Context, pm auth.Page) (auth.KeyPage, error) {
+	ret := _m.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAll")
+	}
+
+	var r0 auth.KeyPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Page) (auth.KeyPage, error)); ok {
+		return rf(ctx, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Page) auth.KeyPage); ok {
+		r0 = rf(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(auth.KeyPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Page) error); ok {
+		r1 = rf(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, key
 func (_m *KeyRepository) Save(ctx context.Context, key auth.Key) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -0,0 +1,302 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/absmach/magistrala/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RolesRepository is an autogenerated mock type for the RolesRepository type
+type RolesRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, domainID, id
+func (_m *RolesRepository) Delete(ctx context.Context, domainID string, id string) error {
+	ret := _m.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAssignments provides a mock function with given fields: ctx, ras
+func (_m *RolesRepository) RemoveAssignments(ctx context.Context, ras ...auth.RoleAssignment) error {
+	_va := make([]interface{}, len(ras))
+	for _i := range ras {
+		_va[_i] = ras[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAssignments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...auth.RoleAssignment) error); ok {
+		r0 = rf(ctx, ras...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveUserAssignments provides a mock function with given fields: ctx, domainID, userID
+func (_m *RolesRepository) RemoveUserAssignments(ctx context.Context, domainID string, userID string) ([]auth.RoleAssignment, error) {
+	ret := _m.Called(ctx, domainID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserAssignments")
+	}
+
+	var r0 []auth.RoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]auth.RoleAssignment, error)); ok {
+		return rf(ctx, domainID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []auth.RoleAssignment); ok {
+		r0 = rf(ctx, domainID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, domainID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveAll provides a mock function with given fields: ctx, domainID, pm
+func (_m *RolesRepository) RetrieveAll(ctx context.Context, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	ret := _m.Called(ctx, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAll")
+	}
+
+	var r0 auth.RolesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) (auth.RolesPage, error)); ok {
+		return rf(ctx, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) auth.RolesPage); ok {
+		r0 = rf(ctx, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.RolesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.Page) error); ok {
+		r1 = rf(ctx, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveAssignments provides a mock function with given fields: ctx, roleID
+func (_m *RolesRepository) RetrieveAssignments(ctx context.Context, roleID string) ([]auth.RoleAssignment, error) {
+	ret := _m.Called(ctx, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAssignments")
+	}
+
+	var r0 []auth.RoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]auth.RoleAssignment, error)); ok {
+		return rf(ctx, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []auth.RoleAssignment); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveByID provides a mock function with given fields: ctx, domainID, id
+func (_m *RolesRepository) RetrieveByID(ctx context.Context, domainID string, id string) (auth.Role, error) {
+	ret := _m.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByID")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.Role, error)); ok {
+		return rf(ctx, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.Role); ok {
+		r0 = rf(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveEntityAssignments provides a mock function with given fields: ctx, entityType, entityID
+func (_m *RolesRepository) RetrieveEntityAssignments(ctx context.Context, entityType string, entityID string) ([]auth.RoleAssignment, error) {
+	ret := _m.Called(ctx, entityType, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveEntityAssignments")
+	}
+
+	var r0 []auth.RoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]auth.RoleAssignment, error)); ok {
+		return rf(ctx, entityType, entityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []auth.RoleAssignment); ok {
+		r0 = rf(ctx, entityType, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, entityType, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, r
+func (_m *RolesRepository) Save(ctx context.Context, r auth.Role) (auth.Role, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Role) (auth.Role, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Role) auth.Role); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Role) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAssignments provides a mock function with given fields: ctx, ras
+func (_m *RolesRepository) SaveAssignments(ctx context.Context, ras ...auth.RoleAssignment) error {
+	_va := make([]interface{}, len(ras))
+	for _i := range ras {
+		_va[_i] = ras[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAssignments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...auth.RoleAssignment) error); ok {
+		r0 = rf(ctx, ras...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, r
+func (_m *RolesRepository) Update(ctx context.Context, r auth.Role) (auth.Role, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Role) (auth.Role, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Role) auth.Role); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Role) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRolesRepository creates a new instance of RolesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRolesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RolesRepository {
+	mock := &RolesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -10,6 +10,8 @@ import (
 	auth "github.com/absmach/magistrala/auth"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -17,6 +19,24 @@ type Service struct {
 	mock.Mock
 }
 
+// AddDomainMember provides a mock function with given fields: ctx, domainID, userID, relation
+func (_m *Service) AddDomainMember(ctx context.Context, domainID string, userID string, relation string) error {
+	ret := _m.Called(ctx, domainID, userID, relation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDomainMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, domainID, userID, relation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddPolicies provides a mock function with given fields: ctx, prs
 func (_m *Service) AddPolicies(ctx context.Context, prs []auth.PolicyReq) error {
 	ret := _m.Called(ctx, prs)
@@ -53,17 +73,53 @@ func (_m *Service) AddPolicy(ctx context.Context, pr auth.PolicyReq) error {
 	return r0
 }
 
-// AssignUsers provides a mock function with given fields: ctx, token, id, userIds, relation
-func (_m *Service) AssignUsers(ctx context.Context, token string, id string, userIds []string, relation string) error {
-	ret := _m.Called(ctx, token, id, userIds, relation)
+// ApplyClaimMappings provides a mock function with given fields: ctx, userID, provider, groups
+func (_m *Service) ApplyClaimMappings(ctx context.Context, userID string, provider string, groups []string) error {
+	ret := _m.Called(ctx, userID, provider, groups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyClaimMappings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, userID, provider, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssignRole provides a mock function with given fields: ctx, token, domainID, id, entity, entityID, userIDs
+func (_m *Service) AssignRole(ctx context.Context, token string, domainID string, id string, entity string, entityID string, userIDs []string) error {
+	ret := _m.Called(ctx, token, domainID, id, entity, entityID, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, []string) error); ok {
+		r0 = rf(ctx, token, domainID, id, entity, entityID, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssignUsers provides a mock function with given fields: ctx, token, id, userIds, relation, cond
+func (_m *Service) AssignUsers(ctx context.Context, token string, id string, userIds []string, relation string, cond *auth.Condition) error {
+	ret := _m.Called(ctx, token, id, userIds, relation, cond)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AssignUsers")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, string) error); ok {
-		r0 = rf(ctx, token, id, userIds, relation)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, string, *auth.Condition) error); ok {
+		r0 = rf(ctx, token, id, userIds, relation, cond)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -117,6 +173,62 @@ func (_m *Service) ChangeDomainStatus(ctx context.Context, token string, id stri
 	return r0, r1
 }
 
+// CheckQuota provides a mock function with given fields: ctx, domainID, resource, count
+func (_m *Service) CheckQuota(ctx context.Context, domainID string, resource string, count uint64) (bool, error) {
+	ret := _m.Called(ctx, domainID, resource, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckQuota")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) (bool, error)); ok {
+		return rf(ctx, domainID, resource, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) bool); ok {
+		r0 = rf(ctx, domainID, resource, count)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
+		r1 = rf(ctx, domainID, resource, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientCredentials provides a mock function with given fields: ctx, clientID, secret
+func (_m *Service) ClientCredentials(ctx context.Context, clientID string, secret string) (auth.Token, error) {
+	ret := _m.Called(ctx, clientID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientCredentials")
+	}
+
+	var r0 auth.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.Token, error)); ok {
+		return rf(ctx, clientID, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.Token); ok {
+		r0 = rf(ctx, clientID, secret)
+	} else {
+		r0 = ret.Get(0).(auth.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clientID, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountObjects provides a mock function with given fields: ctx, pr
 func (_m *Service) CountObjects(ctx context.Context, pr auth.PolicyReq) (uint64, error) {
 	ret := _m.Called(ctx, pr)
@@ -173,6 +285,34 @@ func (_m *Service) CountSubjects(ctx context.Context, pr auth.PolicyReq) (uint64
 	return r0, r1
 }
 
+// CreateClaimMapping provides a mock function with given fields: ctx, token, domainID, m
+func (_m *Service) CreateClaimMapping(ctx context.Context, token string, domainID string, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	ret := _m.Called(ctx, token, domainID, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClaimMapping")
+	}
+
+	var r0 auth.ClaimMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.ClaimMapping) (auth.ClaimMapping, error)); ok {
+		return rf(ctx, token, domainID, m)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.ClaimMapping) auth.ClaimMapping); ok {
+		r0 = rf(ctx, token, domainID, m)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.ClaimMapping) error); ok {
+		r1 = rf(ctx, token, domainID, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDomain provides a mock function with given fields: ctx, token, d
 func (_m *Service) CreateDomain(ctx context.Context, token string, d auth.Domain) (auth.Domain, error) {
 	ret := _m.Called(ctx, token, d)
@@ -201,6 +341,80 @@ func (_m *Service) CreateDomain(ctx context.Context, token string, d auth.Domain
 	return r0, r1
 }
 
+// CreateRole provides a mock function with given fields: ctx, token, domainID, r
+func (_m *Service) CreateRole(ctx context.Context, token string, domainID string, r auth.Role) (auth.Role, error) {
+	ret := _m.Called(ctx, token, domainID, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Role) (auth.Role, error)); ok {
+		return rf(ctx, token, domainID, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Role) auth.Role); ok {
+		r0 = rf(ctx, token, domainID, r)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.Role) error); ok {
+		r1 = rf(ctx, token, domainID, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClaimMapping provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) DeleteClaimMapping(ctx context.Context, token string, domainID string, id string) error {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClaimMapping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDomain provides a mock function with given fields: ctx, token, id
+func (_m *Service) DeleteDomain(ctx context.Context, token string, id string) (auth.DomainDeletion, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomain")
+	}
+
+	var r0 auth.DomainDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.DomainDeletion, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.DomainDeletion); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(auth.DomainDeletion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteEntityPolicies provides a mock function with given fields: ctx, entityType, id
 func (_m *Service) DeleteEntityPolicies(ctx context.Context, entityType string, id string) error {
 	ret := _m.Called(ctx, entityType, id)
@@ -255,6 +469,82 @@ func (_m *Service) DeletePolicyFilter(ctx context.Context, pr auth.PolicyReq) er
 	return r0
 }
 
+// DeleteRole provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) DeleteRole(ctx context.Context, token string, domainID string, id string) error {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpirePolicies provides a mock function with given fields: ctx
+func (_m *Service) ExpirePolicies(ctx context.Context) ([]auth.ConditionalPolicy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePolicies")
+	}
+
+	var r0 []auth.ConditionalPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.ConditionalPolicy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.ConditionalPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.ConditionalPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplainPolicy provides a mock function with given fields: ctx, token, pr
+func (_m *Service) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	ret := _m.Called(ctx, token, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPolicy")
+	}
+
+	var r0 auth.PolicyExplanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.PolicyReq) (auth.PolicyExplanation, error)); ok {
+		return rf(ctx, token, pr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.PolicyReq) auth.PolicyExplanation); ok {
+		r0 = rf(ctx, token, pr)
+	} else {
+		r0 = ret.Get(0).(auth.PolicyExplanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.PolicyReq) error); ok {
+		r1 = rf(ctx, token, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Identify provides a mock function with given fields: ctx, token
 func (_m *Service) Identify(ctx context.Context, token string) (auth.Key, error) {
 	ret := _m.Called(ctx, token)
@@ -367,6 +657,34 @@ func (_m *Service) ListAllSubjects(ctx context.Context, pr auth.PolicyReq) (auth
 	return r0, r1
 }
 
+// ListClaimMappings provides a mock function with given fields: ctx, token, domainID, pm
+func (_m *Service) ListClaimMappings(ctx context.Context, token string, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	ret := _m.Called(ctx, token, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListClaimMappings")
+	}
+
+	var r0 auth.ClaimMappingsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Page) (auth.ClaimMappingsPage, error)); ok {
+		return rf(ctx, token, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Page) auth.ClaimMappingsPage); ok {
+		r0 = rf(ctx, token, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMappingsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.Page) error); ok {
+		r1 = rf(ctx, token, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDomains provides a mock function with given fields: ctx, token, page
 func (_m *Service) ListDomains(ctx context.Context, token string, page auth.Page) (auth.DomainsPage, error) {
 	ret := _m.Called(ctx, token, page)
@@ -395,6 +713,34 @@ func (_m *Service) ListDomains(ctx context.Context, token string, page auth.Page
 	return r0, r1
 }
 
+// ListKeys provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListKeys(ctx context.Context, token string, pm auth.Page) (auth.KeyPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListKeys")
+	}
+
+	var r0 auth.KeyPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) (auth.KeyPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Page) auth.KeyPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(auth.KeyPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.Page) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListObjects provides a mock function with given fields: ctx, pr, nextPageToken, limit
 func (_m *Service) ListObjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) (auth.PolicyPage, error) {
 	ret := _m.Called(ctx, pr, nextPageToken, limit)
@@ -453,6 +799,94 @@ func (_m *Service) ListPermissions(ctx context.Context, pr auth.PolicyReq, filte
 	return r0, r1
 }
 
+// ListRoleAssignments provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) ListRoleAssignments(ctx context.Context, token string, domainID string, id string) ([]auth.RoleAssignment, error) {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoleAssignments")
+	}
+
+	var r0 []auth.RoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]auth.RoleAssignment, error)); ok {
+		return rf(ctx, token, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []auth.RoleAssignment); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoles provides a mock function with given fields: ctx, token, domainID, pm
+func (_m *Service) ListRoles(ctx context.Context, token string, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	ret := _m.Called(ctx, token, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 auth.RolesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Page) (auth.RolesPage, error)); ok {
+		return rf(ctx, token, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.Page) auth.RolesPage); ok {
+		r0 = rf(ctx, token, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.RolesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.Page) error); ok {
+		r1 = rf(ctx, token, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: ctx, token
+func (_m *Service) ListSessions(ctx context.Context, token string) ([]auth.Session, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []auth.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]auth.Session, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []auth.Session); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSubjects provides a mock function with given fields: ctx, pr, nextPageToken, limit
 func (_m *Service) ListSubjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) (auth.PolicyPage, error) {
 	ret := _m.Called(ctx, pr, nextPageToken, limit)
@@ -509,6 +943,126 @@ func (_m *Service) ListUserDomains(ctx context.Context, token string, userID str
 	return r0, r1
 }
 
+// PurgeDomains provides a mock function with given fields: ctx, before
+func (_m *Service) PurgeDomains(ctx context.Context, before time.Time) ([]auth.DomainDeletion, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDomains")
+	}
+
+	var r0 []auth.DomainDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]auth.DomainDeletion, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []auth.DomainDeletion); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.DomainDeletion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseQuota provides a mock function with given fields: ctx, domainID, resource, ids
+func (_m *Service) ReleaseQuota(ctx context.Context, domainID string, resource string, ids ...string) error {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, domainID, resource)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...string) error); ok {
+		r0 = rf(ctx, domainID, resource, ids...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveQuota provides a mock function with given fields: ctx, domainID, resource, existing, ids
+func (_m *Service) ReserveQuota(ctx context.Context, domainID string, resource string, existing bool, ids ...string) ([]string, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, domainID, resource, existing)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveQuota")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, ...string) ([]string, error)); ok {
+		return rf(ctx, domainID, resource, existing, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, ...string) []string); ok {
+		r0 = rf(ctx, domainID, resource, existing, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, ...string) error); ok {
+		r1 = rf(ctx, domainID, resource, existing, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveClaimMapping provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) RetrieveClaimMapping(ctx context.Context, token string, domainID string, id string) (auth.ClaimMapping, error) {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveClaimMapping")
+	}
+
+	var r0 auth.ClaimMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (auth.ClaimMapping, error)); ok {
+		return rf(ctx, token, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) auth.ClaimMapping); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Get(0).(auth.ClaimMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveDomain provides a mock function with given fields: ctx, token, id
 func (_m *Service) RetrieveDomain(ctx context.Context, token string, id string) (auth.Domain, error) {
 	ret := _m.Called(ctx, token, id)
@@ -537,6 +1091,34 @@ func (_m *Service) RetrieveDomain(ctx context.Context, token string, id string)
 	return r0, r1
 }
 
+// RetrieveDomainDeletion provides a mock function with given fields: ctx, token, id
+func (_m *Service) RetrieveDomainDeletion(ctx context.Context, token string, id string) (auth.DomainDeletion, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDomainDeletion")
+	}
+
+	var r0 auth.DomainDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.DomainDeletion, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.DomainDeletion); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(auth.DomainDeletion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveDomainPermissions provides a mock function with given fields: ctx, token, id
 func (_m *Service) RetrieveDomainPermissions(ctx context.Context, token string, id string) (auth.Permissions, error) {
 	ret := _m.Called(ctx, token, id)
@@ -567,6 +1149,64 @@ func (_m *Service) RetrieveDomainPermissions(ctx context.Context, token string,
 	return r0, r1
 }
 
+// RetrieveDomainUsage provides a mock function with given fields: ctx, token, id
+func (_m *Service) RetrieveDomainUsage(ctx context.Context, token string, id string) (auth.DomainUsage, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDomainUsage")
+	}
+
+	var r0 auth.DomainUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.DomainUsage, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.DomainUsage); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(auth.DomainUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveJWKS provides a mock function with given fields: ctx
+func (_m *Service) RetrieveJWKS(ctx context.Context) ([]auth.JWK, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveJWKS")
+	}
+
+	var r0 []auth.JWK
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.JWK, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.JWK); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.JWK)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveKey provides a mock function with given fields: ctx, token, id
 func (_m *Service) RetrieveKey(ctx context.Context, token string, id string) (auth.Key, error) {
 	ret := _m.Called(ctx, token, id)
@@ -595,6 +1235,34 @@ func (_m *Service) RetrieveKey(ctx context.Context, token string, id string) (au
 	return r0, r1
 }
 
+// RetrieveRole provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) RetrieveRole(ctx context.Context, token string, domainID string, id string) (auth.Role, error) {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRole")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (auth.Role, error)); ok {
+		return rf(ctx, token, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) auth.Role); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Revoke provides a mock function with given fields: ctx, token, id
 func (_m *Service) Revoke(ctx context.Context, token string, id string) error {
 	ret := _m.Called(ctx, token, id)
@@ -613,6 +1281,78 @@ func (_m *Service) Revoke(ctx context.Context, token string, id string) error {
 	return r0
 }
 
+// RevokeSession provides a mock function with given fields: ctx, token, id
+func (_m *Service) RevokeSession(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, token
+func (_m *Service) RevokeSessions(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeTokens provides a mock function with given fields: ctx, userID
+func (_m *Service) RevokeTokens(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnassignRole provides a mock function with given fields: ctx, token, domainID, id, entity, entityID, userIDs
+func (_m *Service) UnassignRole(ctx context.Context, token string, domainID string, id string, entity string, entityID string, userIDs []string) error {
+	ret := _m.Called(ctx, token, domainID, id, entity, entityID, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, []string) error); ok {
+		r0 = rf(ctx, token, domainID, id, entity, entityID, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnassignUser provides a mock function with given fields: ctx, token, id, userID
 func (_m *Service) UnassignUser(ctx context.Context, token string, id string, userID string) error {
 	ret := _m.Called(ctx, token, id, userID)
@@ -659,6 +1399,34 @@ func (_m *Service) UpdateDomain(ctx context.Context, token string, id string, d
 	return r0, r1
 }
 
+// UpdateRole provides a mock function with given fields: ctx, token, domainID, id, r
+func (_m *Service) UpdateRole(ctx context.Context, token string, domainID string, id string, r auth.RoleReq) (auth.Role, error) {
+	ret := _m.Called(ctx, token, domainID, id, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, auth.RoleReq) (auth.Role, error)); ok {
+		return rf(ctx, token, domainID, id, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, auth.RoleReq) auth.Role); ok {
+		r0 = rf(ctx, token, domainID, id, r)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, auth.RoleReq) error); ok {
+		r1 = rf(ctx, token, domainID, id, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -0,0 +1,185 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/absmach/magistrala/auth"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UsageRepository is an autogenerated mock type for the UsageRepository type
+type UsageRepository struct {
+	mock.Mock
+}
+
+// AddEntities provides a mock function with given fields: ctx, domainID, resource, ids
+func (_m *UsageRepository) AddEntities(ctx context.Context, domainID string, resource string, ids ...string) error {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, domainID, resource)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEntities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...string) error); ok {
+		r0 = rf(ctx, domainID, resource, ids...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddMessages provides a mock function with given fields: ctx, channelID, at, count, size
+func (_m *UsageRepository) AddMessages(ctx context.Context, channelID string, at time.Time, count uint64, size uint64) error {
+	ret := _m.Called(ctx, channelID, at, count, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, uint64, uint64) error); ok {
+		r0 = rf(ctx, channelID, at, count, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveDomain provides a mock function with given fields: ctx, domainID
+func (_m *UsageRepository) RemoveDomain(ctx context.Context, domainID string) error {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveEntities provides a mock function with given fields: ctx, domainID, resource, ids
+func (_m *UsageRepository) RemoveEntities(ctx context.Context, domainID string, resource string, ids ...string) error {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, domainID, resource)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveEntities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...string) error); ok {
+		r0 = rf(ctx, domainID, resource, ids...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveEntities provides a mock function with given fields: ctx, domainID, resource, limit, ids
+func (_m *UsageRepository) ReserveEntities(ctx context.Context, domainID string, resource string, limit uint64, ids ...string) ([]string, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, domainID, resource, limit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveEntities")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64, ...string) ([]string, error)); ok {
+		return rf(ctx, domainID, resource, limit, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64, ...string) []string); ok {
+		r0 = rf(ctx, domainID, resource, limit, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64, ...string) error); ok {
+		r1 = rf(ctx, domainID, resource, limit, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Retrieve provides a mock function with given fields: ctx, domainID, day
+func (_m *UsageRepository) Retrieve(ctx context.Context, domainID string, day time.Time) (auth.Usage, error) {
+	ret := _m.Called(ctx, domainID, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 auth.Usage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (auth.Usage, error)); ok {
+		return rf(ctx, domainID, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) auth.Usage); ok {
+		r0 = rf(ctx, domainID, day)
+	} else {
+		r0 = ret.Get(0).(auth.Usage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, domainID, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsageRepository creates a new instance of UsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsageRepository {
+	mock := &UsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -27,6 +27,7 @@ const (
 	UserType     = "user"
 	DomainType   = "domain"
 	PlatformType = "platform"
+	RoleType     = "role"
 )
 
 const (
@@ -94,6 +95,13 @@ type PolicyReq struct {
 	// Permission contains the permission. Supported permissions are admin, delete, edit, share, view,
 	// membership, create, admin_only, edit_only, view_only, membership_only, ext_admin, ext_edit, ext_view.
 	Permission string `json:"permission,omitempty"`
+
+	// Condition contains the optional condition of the added policy.
+	Condition *Condition `json:"condition,omitempty"`
+
+	// SourceIP contains the IP address of the authorized request. It is used
+	// to evaluate the network conditions of the granted policies.
+	SourceIP string `json:"source_ip,omitempty"`
 }
 
 func (pr PolicyReq) String() string {
@@ -122,6 +130,35 @@ type PolicyPage struct {
 
 type Permissions []string
 
+// PolicyStep is the relationship on the relation path between the
+// subject and the object of the explained policy request.
+type PolicyStep struct {
+	SubjectType string `json:"subject_type"`
+	Subject     string `json:"subject"`
+	Relation    string `json:"relation"`
+	ObjectType  string `json:"object_type"`
+	Object      string `json:"object"`
+}
+
+// PolicyExplanation is the authorization decision together
+// with the relations the decision is based on.
+type PolicyExplanation struct {
+	Allowed bool `json:"allowed"`
+
+	// Reason contains the cause of the denial if the relations grant the
+	// permission, but the request is denied by the domain status or by the
+	// policy conditions.
+	Reason string `json:"reason,omitempty"`
+
+	// Path contains the relationships granting the permission, ordered
+	// from the relationship of the subject to the relationship of the object.
+	Path []PolicyStep `json:"path,omitempty"`
+
+	// Required contains the relationships of the subject that would grant
+	// the denied permission.
+	Required []PolicyStep `json:"required,omitempty"`
+}
+
 // Authz represents a authorization service. It exposes
 // functionalities through `auth` to perform authorization.
 //
@@ -134,6 +171,12 @@ type Authz interface {
 	// denied).
 	Authorize(ctx context.Context, pr PolicyReq) error
 
+	// ExplainPolicy explains the authorization decision of the permission of
+	// the subject on the object. The subject defaults to the token user.
+	// Explaining the permissions of other subjects requires the administrator
+	// permission on the token domain.
+	ExplainPolicy(ctx context.Context, token string, pr PolicyReq) (PolicyExplanation, error)
+
 	// AddPolicy creates a policy for the given subject, so that, after
 	// AddPolicy, `subject` has a `relation` on `object`. Returns a non-nil
 	// error in case of failures.
@@ -150,10 +193,16 @@ type Authz interface {
 	// only allowed to use as an admin.
 	DeletePolicies(ctx context.Context, prs []PolicyReq) error
 
+	// ExpirePolicies removes the policies whose conditions expired,
+	// returning the removed policies.
+	ExpirePolicies(ctx context.Context) ([]ConditionalPolicy, error)
+
 	// ListObjects lists policies based on the given PolicyReq structure.
+	// The objects listed with the token subject are limited to the token scopes.
 	ListObjects(ctx context.Context, pr PolicyReq, nextPageToken string, limit uint64) (PolicyPage, error)
 
 	// ListAllObjects lists all policies based on the given PolicyReq structure.
+	// The objects listed with the token subject are limited to the token scopes.
 	ListAllObjects(ctx context.Context, pr PolicyReq) (PolicyPage, error)
 
 	// CountPolicies count policies based on the given PolicyReq structure.
@@ -186,6 +235,10 @@ type PolicyAgent interface {
 	// the object (which simply means the operation is denied).
 	CheckPolicy(ctx context.Context, pr PolicyReq) error
 
+	// ExplainPolicy checks if the subject has a permission on the object,
+	// returning the relationships the decision is based on.
+	ExplainPolicy(ctx context.Context, pr PolicyReq) (PolicyExplanation, error)
+
 	// AddPolicy creates a policy for the given subject, so that, after
 	// AddPolicy, `subject` has a `relation` on `object`. Returns a non-nil
 	// error in case of failures.
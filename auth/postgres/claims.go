@@ -0,0 +1,265 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+var _ auth.ClaimMappingsRepository = (*claimRepo)(nil)
+
+type claimRepo struct {
+	db postgres.Database
+}
+
+// NewClaimMappingsRepository instantiates a PostgreSQL
+// implementation of claim mappings repository.
+func NewClaimMappingsRepository(db postgres.Database) auth.ClaimMappingsRepository {
+	return &claimRepo{
+		db: db,
+	}
+}
+
+func (repo claimRepo) Save(ctx context.Context, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	q := `INSERT INTO claim_mappings (id, domain_id, provider, claim_group, relation, group_ids, created_by, created_at)
+	VALUES (:id, :domain_id, :provider, :claim_group, :relation, :group_ids, :created_by, :created_at)
+	RETURNING id, domain_id, provider, claim_group, relation, group_ids, created_by, created_at`
+
+	dbm, err := toDBClaimMapping(m)
+	if err != nil {
+		return auth.ClaimMapping{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dbm)
+	if err != nil {
+		return auth.ClaimMapping{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	row.Next()
+	dbm = dbClaimMapping{}
+	if err := row.StructScan(&dbm); err != nil {
+		return auth.ClaimMapping{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return toClaimMapping(dbm), nil
+}
+
+func (repo claimRepo) RetrieveByID(ctx context.Context, domainID, id string) (auth.ClaimMapping, error) {
+	q := `SELECT id, domain_id, provider, claim_group, relation, group_ids, created_by, created_at
+	FROM claim_mappings WHERE domain_id = $1 AND id = $2`
+
+	dbm := dbClaimMapping{}
+	if err := repo.db.QueryRowxContext(ctx, q, domainID, id).StructScan(&dbm); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.ClaimMapping{}, repoerr.ErrNotFound
+		}
+		return auth.ClaimMapping{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toClaimMapping(dbm), nil
+}
+
+func (repo claimRepo) RetrieveAll(ctx context.Context, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	q := fmt.Sprintf(`SELECT id, domain_id, provider, claim_group, relation, group_ids, created_by, created_at
+	FROM claim_mappings WHERE domain_id = $1 ORDER BY created_at LIMIT %d OFFSET %d`, pm.Limit, pm.Offset)
+
+	mappings, err := repo.retrieveMappings(ctx, q, domainID)
+	if err != nil {
+		return auth.ClaimMappingsPage{}, err
+	}
+
+	var total uint64
+	if err := repo.db.QueryRowxContext(ctx, `SELECT COUNT(*) FROM claim_mappings WHERE domain_id = $1`, domainID).Scan(&total); err != nil {
+		return auth.ClaimMappingsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.ClaimMappingsPage{
+		Total:    total,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Mappings: mappings,
+	}, nil
+}
+
+func (repo claimRepo) RetrieveByProvider(ctx context.Context, provider string) ([]auth.ClaimMapping, error) {
+	q := `SELECT id, domain_id, provider, claim_group, relation, group_ids, created_by, created_at
+	FROM claim_mappings WHERE provider = $1 OR provider = '' ORDER BY created_at`
+
+	return repo.retrieveMappings(ctx, q, provider)
+}
+
+func (repo claimRepo) Delete(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM claim_mappings WHERE domain_id = $1 AND id = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, domainID, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo claimRepo) SaveGrant(ctx context.Context, g auth.ClaimGrant) error {
+	q := `INSERT INTO claim_grants (domain_id, user_id, provider, relation, group_ids)
+	VALUES (:domain_id, :user_id, :provider, :relation, :group_ids)
+	ON CONFLICT (domain_id, user_id) DO UPDATE
+	SET provider = EXCLUDED.provider, relation = EXCLUDED.relation, group_ids = EXCLUDED.group_ids`
+
+	dbg, err := toDBClaimGrant(g)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbg); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo claimRepo) RetrieveGrants(ctx context.Context, userID, provider string) ([]auth.ClaimGrant, error) {
+	q := `SELECT domain_id, user_id, provider, relation, group_ids FROM claim_grants
+	WHERE user_id = $1 AND provider = $2`
+
+	rows, err := repo.db.QueryxContext(ctx, q, userID, provider)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var grants []auth.ClaimGrant
+	for rows.Next() {
+		dbg := dbClaimGrant{}
+		if err := rows.StructScan(&dbg); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		grants = append(grants, toClaimGrant(dbg))
+	}
+
+	return grants, nil
+}
+
+func (repo claimRepo) RemoveGrant(ctx context.Context, domainID, userID string) error {
+	q := `DELETE FROM claim_grants WHERE domain_id = $1 AND user_id = $2`
+
+	if _, err := repo.db.ExecContext(ctx, q, domainID, userID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo claimRepo) retrieveMappings(ctx context.Context, q string, args ...interface{}) ([]auth.ClaimMapping, error) {
+	rows, err := repo.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var mappings []auth.ClaimMapping
+	for rows.Next() {
+		dbm := dbClaimMapping{}
+		if err := rows.StructScan(&dbm); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		mappings = append(mappings, toClaimMapping(dbm))
+	}
+
+	return mappings, nil
+}
+
+type dbClaimMapping struct {
+	ID        string           `db:"id"`
+	DomainID  string           `db:"domain_id"`
+	Provider  string           `db:"provider"`
+	Group     string           `db:"claim_group"`
+	Relation  string           `db:"relation"`
+	GroupIDs  pgtype.TextArray `db:"group_ids"`
+	CreatedBy string           `db:"created_by"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+func toDBClaimMapping(m auth.ClaimMapping) (dbClaimMapping, error) {
+	var groupIDs pgtype.TextArray
+	if err := groupIDs.Set(m.GroupIDs); err != nil {
+		return dbClaimMapping{}, err
+	}
+
+	return dbClaimMapping{
+		ID:        m.ID,
+		DomainID:  m.DomainID,
+		Provider:  m.Provider,
+		Group:     m.Group,
+		Relation:  m.Relation,
+		GroupIDs:  groupIDs,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+func toClaimMapping(m dbClaimMapping) auth.ClaimMapping {
+	return auth.ClaimMapping{
+		ID:        m.ID,
+		DomainID:  m.DomainID,
+		Provider:  m.Provider,
+		Group:     m.Group,
+		Relation:  m.Relation,
+		GroupIDs:  toStrings(m.GroupIDs),
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+type dbClaimGrant struct {
+	DomainID string           `db:"domain_id"`
+	UserID   string           `db:"user_id"`
+	Provider string           `db:"provider"`
+	Relation string           `db:"relation"`
+	GroupIDs pgtype.TextArray `db:"group_ids"`
+}
+
+func toDBClaimGrant(g auth.ClaimGrant) (dbClaimGrant, error) {
+	var groupIDs pgtype.TextArray
+	if err := groupIDs.Set(g.GroupIDs); err != nil {
+		return dbClaimGrant{}, err
+	}
+
+	return dbClaimGrant{
+		DomainID: g.DomainID,
+		UserID:   g.UserID,
+		Provider: g.Provider,
+		Relation: g.Relation,
+		GroupIDs: groupIDs,
+	}, nil
+}
+
+func toClaimGrant(g dbClaimGrant) auth.ClaimGrant {
+	return auth.ClaimGrant{
+		DomainID: g.DomainID,
+		UserID:   g.UserID,
+		Provider: g.Provider,
+		Relation: g.Relation,
+		GroupIDs: toStrings(g.GroupIDs),
+	}
+}
+
+func toStrings(arr pgtype.TextArray) []string {
+	var strs []string
+	for _, e := range arr.Elements {
+		strs = append(strs, e.String)
+	}
+
+	return strs
+}
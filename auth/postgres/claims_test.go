@@ -0,0 +1,164 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClaimMapping(provider, group string) auth.ClaimMapping {
+	return auth.ClaimMapping{
+		ID:        testsutil.GenerateUUID(&testing.T{}),
+		DomainID:  domainID,
+		Provider:  provider,
+		Group:     group,
+		Relation:  auth.EditorRelation,
+		GroupIDs:  []string{testsutil.GenerateUUID(&testing.T{})},
+		CreatedBy: userID,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSaveClaimMapping(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewClaimMappingsRepository(database)
+
+	mapping := newClaimMapping("oidc", "iot-ops")
+	other := newClaimMapping("", "iot-view")
+	other.DomainID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		mapping auth.ClaimMapping
+		err     error
+	}{
+		{
+			desc:    "save claim mapping successfully",
+			mapping: mapping,
+			err:     nil,
+		},
+		{
+			desc:    "save claim mapping with existing id",
+			mapping: mapping,
+			err:     repoerr.ErrConflict,
+		},
+		{
+			desc:    "save claim mapping in non-existing domain",
+			mapping: other,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		m, err := repo.Save(context.Background(), tc.mapping)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.mapping, m, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.mapping, m))
+		}
+	}
+}
+
+func TestRetrieveClaimMappings(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewClaimMappingsRepository(database)
+
+	oidc, err := repo.Save(context.Background(), newClaimMapping("oidc", "iot-ops"))
+	require.Nil(t, err, fmt.Sprintf("failed to save claim mapping: %s", err))
+	all, err := repo.Save(context.Background(), newClaimMapping("", "iot-view"))
+	require.Nil(t, err, fmt.Sprintf("failed to save claim mapping: %s", err))
+	_, err = repo.Save(context.Background(), newClaimMapping("google", "iot-admins"))
+	require.Nil(t, err, fmt.Sprintf("failed to save claim mapping: %s", err))
+
+	m, err := repo.RetrieveByID(context.Background(), domainID, oidc.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve claim mapping: unexpected error %s", err))
+	assert.Equal(t, oidc, m, fmt.Sprintf("retrieve claim mapping: expected %v got %v", oidc, m))
+
+	_, err = repo.RetrieveByID(context.Background(), testsutil.GenerateUUID(t), oidc.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve claim mapping of other domain: expected %s got %s", repoerr.ErrNotFound, err))
+
+	mp, err := repo.RetrieveAll(context.Background(), domainID, auth.Page{Offset: 0, Limit: 2})
+	assert.Nil(t, err, fmt.Sprintf("retrieve all claim mappings: unexpected error %s", err))
+	assert.Equal(t, uint64(3), mp.Total, fmt.Sprintf("retrieve all claim mappings: expected total 3 got %d", mp.Total))
+	assert.Len(t, mp.Mappings, 2, "retrieve all claim mappings: expected 2 mappings")
+
+	ms, err := repo.RetrieveByProvider(context.Background(), "oidc")
+	assert.Nil(t, err, fmt.Sprintf("retrieve claim mappings by provider: unexpected error %s", err))
+	assert.Equal(t, []auth.ClaimMapping{oidc, all}, ms, fmt.Sprintf("retrieve claim mappings by provider: expected %v got %v", []auth.ClaimMapping{oidc, all}, ms))
+}
+
+func TestDeleteClaimMapping(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewClaimMappingsRepository(database)
+
+	mapping, err := repo.Save(context.Background(), newClaimMapping("oidc", "iot-ops"))
+	require.Nil(t, err, fmt.Sprintf("failed to save claim mapping: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete existing claim mapping",
+			id:   mapping.ID,
+			err:  nil,
+		},
+		{
+			desc: "delete non-existing claim mapping",
+			id:   mapping.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Delete(context.Background(), domainID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestClaimGrants(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewClaimMappingsRepository(database)
+
+	grant := auth.ClaimGrant{
+		DomainID: domainID,
+		UserID:   userID,
+		Provider: "oidc",
+		Relation: auth.EditorRelation,
+		GroupIDs: []string{testsutil.GenerateUUID(t)},
+	}
+	err := repo.SaveGrant(context.Background(), grant)
+	assert.Nil(t, err, fmt.Sprintf("save claim grant: unexpected error %s", err))
+
+	grant.Relation = ""
+	grant.GroupIDs = nil
+	err = repo.SaveGrant(context.Background(), grant)
+	assert.Nil(t, err, fmt.Sprintf("replace claim grant: unexpected error %s", err))
+
+	grants, err := repo.RetrieveGrants(context.Background(), userID, "oidc")
+	assert.Nil(t, err, fmt.Sprintf("retrieve claim grants: unexpected error %s", err))
+	assert.Equal(t, []auth.ClaimGrant{grant}, grants, fmt.Sprintf("retrieve claim grants: expected %v got %v", []auth.ClaimGrant{grant}, grants))
+
+	grants, err = repo.RetrieveGrants(context.Background(), userID, "google")
+	assert.Nil(t, err, fmt.Sprintf("retrieve claim grants of other provider: unexpected error %s", err))
+	assert.Empty(t, grants, "retrieve claim grants of other provider: expected no grants")
+
+	err = repo.RemoveGrant(context.Background(), domainID, userID)
+	assert.Nil(t, err, fmt.Sprintf("remove claim grant: unexpected error %s", err))
+
+	grants, err = repo.RetrieveGrants(context.Background(), userID, "oidc")
+	assert.Nil(t, err, fmt.Sprintf("retrieve removed claim grants: unexpected error %s", err))
+	assert.Empty(t, grants, "retrieve removed claim grants: expected no grants")
+}
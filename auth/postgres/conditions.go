@@ -0,0 +1,217 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+var _ auth.ConditionsRepository = (*conditionsRepo)(nil)
+
+type conditionsRepo struct {
+	db postgres.Database
+}
+
+// NewConditionsRepository instantiates a PostgreSQL
+// implementation of conditional policies repository.
+func NewConditionsRepository(db postgres.Database) auth.ConditionsRepository {
+	return &conditionsRepo{
+		db: db,
+	}
+}
+
+func (repo conditionsRepo) Save(ctx context.Context, cps []auth.ConditionalPolicy) error {
+	q := `INSERT INTO policy_conditions (subject_type, subject, relation, object_type, object, expires_at, allowed_cidrs)
+	VALUES (:subject_type, :subject, :relation, :object_type, :object, :expires_at, :allowed_cidrs)
+	ON CONFLICT (subject_type, subject, relation, object_type, object)
+	DO UPDATE SET expires_at = EXCLUDED.expires_at, allowed_cidrs = EXCLUDED.allowed_cidrs`
+
+	if len(cps) == 0 {
+		return nil
+	}
+	dbcps, err := toDBConditionalPolicies(cps)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbcps); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo conditionsRepo) Exists(ctx context.Context) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM policy_conditions)`
+
+	var exists bool
+	if err := repo.db.QueryRowxContext(ctx, q).Scan(&exists); err != nil {
+		return false, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return exists, nil
+}
+
+func (repo conditionsRepo) RetrieveBySteps(ctx context.Context, steps []auth.PolicyStep) ([]auth.ConditionalPolicy, error) {
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	var tuples []string
+	var args []interface{}
+	for _, step := range steps {
+		n := len(args)
+		tuples = append(tuples, fmt.Sprintf("($%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4))
+		args = append(args, step.SubjectType, step.Subject, step.ObjectType, step.Object)
+	}
+	q := fmt.Sprintf(`SELECT subject_type, subject, relation, object_type, object, expires_at, allowed_cidrs FROM policy_conditions
+	WHERE (subject_type, subject, object_type, object) IN (%s)`, strings.Join(tuples, ", "))
+
+	return repo.retrieve(ctx, q, args...)
+}
+
+func (repo conditionsRepo) RetrieveExpired(ctx context.Context, before time.Time, limit uint64) ([]auth.ConditionalPolicy, error) {
+	q := fmt.Sprintf(`SELECT subject_type, subject, relation, object_type, object, expires_at, allowed_cidrs FROM policy_conditions
+	WHERE expires_at IS NOT NULL AND expires_at <= $1 ORDER BY expires_at LIMIT %d`, limit)
+
+	return repo.retrieve(ctx, q, before)
+}
+
+func (repo conditionsRepo) Remove(ctx context.Context, cps []auth.ConditionalPolicy) (err error) {
+	q := `DELETE FROM policy_conditions
+	WHERE subject_type = :subject_type AND subject = :subject AND relation = :relation AND object_type = :object_type AND object = :object`
+
+	dbcps, err := toDBConditionalPolicies(cps)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	for _, dbcp := range dbcps {
+		if _, err := tx.NamedExecContext(ctx, q, dbcp); err != nil {
+			return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo conditionsRepo) RemoveFilter(ctx context.Context, filter auth.ConditionalPolicy) error {
+	var query []string
+	var args []interface{}
+	for _, f := range []struct {
+		column string
+		value  string
+	}{
+		{"subject_type", filter.SubjectType},
+		{"subject", filter.Subject},
+		{"relation", filter.Relation},
+		{"object_type", filter.ObjectType},
+		{"object", filter.Object},
+	} {
+		if f.value != "" {
+			args = append(args, f.value)
+			query = append(query, fmt.Sprintf("%s = $%d", f.column, len(args)))
+		}
+	}
+	if len(query) == 0 {
+		return errors.Wrap(repoerr.ErrRemoveEntity, errors.New("empty filter"))
+	}
+
+	q := fmt.Sprintf(`DELETE FROM policy_conditions WHERE %s`, strings.Join(query, " AND "))
+	if _, err := repo.db.ExecContext(ctx, q, args...); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo conditionsRepo) retrieve(ctx context.Context, q string, args ...interface{}) ([]auth.ConditionalPolicy, error) {
+	rows, err := repo.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var cps []auth.ConditionalPolicy
+	for rows.Next() {
+		dbcp := dbConditionalPolicy{}
+		if err := rows.StructScan(&dbcp); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		cps = append(cps, toConditionalPolicy(dbcp))
+	}
+
+	return cps, nil
+}
+
+type dbConditionalPolicy struct {
+	SubjectType  string           `db:"subject_type"`
+	Subject      string           `db:"subject"`
+	Relation     string           `db:"relation"`
+	ObjectType   string           `db:"object_type"`
+	Object       string           `db:"object"`
+	ExpiresAt    sql.NullTime     `db:"expires_at"`
+	AllowedCIDRs pgtype.TextArray `db:"allowed_cidrs"`
+}
+
+func toDBConditionalPolicies(cps []auth.ConditionalPolicy) ([]dbConditionalPolicy, error) {
+	var dbcps []dbConditionalPolicy
+	for _, cp := range cps {
+		var cidrs pgtype.TextArray
+		if err := cidrs.Set(cp.AllowedCIDRs); err != nil {
+			return nil, err
+		}
+		dbcps = append(dbcps, dbConditionalPolicy{
+			SubjectType:  cp.SubjectType,
+			Subject:      cp.Subject,
+			Relation:     cp.Relation,
+			ObjectType:   cp.ObjectType,
+			Object:       cp.Object,
+			ExpiresAt:    sql.NullTime{Time: cp.ExpiresAt, Valid: !cp.ExpiresAt.IsZero()},
+			AllowedCIDRs: cidrs,
+		})
+	}
+
+	return dbcps, nil
+}
+
+func toConditionalPolicy(dbcp dbConditionalPolicy) auth.ConditionalPolicy {
+	var cidrs []string
+	for _, e := range dbcp.AllowedCIDRs.Elements {
+		cidrs = append(cidrs, e.String)
+	}
+
+	return auth.ConditionalPolicy{
+		SubjectType: dbcp.SubjectType,
+		Subject:     dbcp.Subject,
+		Relation:    dbcp.Relation,
+		ObjectType:  dbcp.ObjectType,
+		Object:      dbcp.Object,
+		Condition: auth.Condition{
+			ExpiresAt:    dbcp.ExpiresAt.Time,
+			AllowedCIDRs: cidrs,
+		},
+	}
+}
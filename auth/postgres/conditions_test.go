@@ -0,0 +1,191 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConditionalPolicy(object string, expiresAt time.Time, cidrs ...string) auth.ConditionalPolicy {
+	return auth.ConditionalPolicy{
+		SubjectType: auth.UserType,
+		Subject:     auth.EncodeDomainUserID(domainID, userID),
+		Relation:    auth.GuestRelation,
+		ObjectType:  auth.ThingType,
+		Object:      object,
+		Condition: auth.Condition{
+			ExpiresAt:    expiresAt,
+			AllowedCIDRs: cidrs,
+		},
+	}
+}
+
+func policyStep(cp auth.ConditionalPolicy) auth.PolicyStep {
+	return auth.PolicyStep{
+		SubjectType: cp.SubjectType,
+		Subject:     cp.Subject,
+		Relation:    cp.Relation,
+		ObjectType:  cp.ObjectType,
+		Object:      cp.Object,
+	}
+}
+
+func cleanConditions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM policy_conditions")
+		require.Nil(t, err, fmt.Sprintf("clean policy conditions unexpected error: %s", err))
+	})
+}
+
+func TestSaveConditions(t *testing.T) {
+	cleanConditions(t)
+	repo := postgres.NewConditionsRepository(database)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	cp := newConditionalPolicy("thing", expiresAt, "10.0.0.0/8")
+
+	err := repo.Save(context.Background(), []auth.ConditionalPolicy{cp})
+	assert.Nil(t, err, fmt.Sprintf("save conditions unexpected error: %s", err))
+
+	cps, err := repo.RetrieveBySteps(context.Background(), []auth.PolicyStep{policyStep(cp)})
+	assert.Nil(t, err, fmt.Sprintf("retrieve conditions unexpected error: %s", err))
+	assert.Equal(t, []auth.ConditionalPolicy{cp}, cps)
+
+	// Saving the condition of the same policy replaces the existing one.
+	cp.AllowedCIDRs = nil
+	err = repo.Save(context.Background(), []auth.ConditionalPolicy{cp})
+	assert.Nil(t, err, fmt.Sprintf("replace conditions unexpected error: %s", err))
+
+	cps, err = repo.RetrieveBySteps(context.Background(), []auth.PolicyStep{policyStep(cp)})
+	assert.Nil(t, err, fmt.Sprintf("retrieve conditions unexpected error: %s", err))
+	assert.Equal(t, []auth.ConditionalPolicy{cp}, cps)
+}
+
+func TestRetrieveConditionsBySteps(t *testing.T) {
+	cleanConditions(t)
+	repo := postgres.NewConditionsRepository(database)
+
+	exists, err := repo.Exists(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("check conditions unexpected error: %s", err))
+	assert.False(t, exists, "expected no conditional policies")
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	first := newConditionalPolicy("first", expiresAt)
+	second := newConditionalPolicy("second", time.Time{}, "10.0.0.0/8")
+	err = repo.Save(context.Background(), []auth.ConditionalPolicy{first, second})
+	require.Nil(t, err, fmt.Sprintf("save conditions unexpected error: %s", err))
+
+	exists, err = repo.Exists(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("check conditions unexpected error: %s", err))
+	assert.True(t, exists, "expected conditional policies")
+
+	unconditional := policyStep(newConditionalPolicy("unconditional", time.Time{}))
+
+	cases := []struct {
+		desc  string
+		steps []auth.PolicyStep
+		res   []auth.ConditionalPolicy
+	}{
+		{
+			desc:  "retrieve conditions of the path",
+			steps: []auth.PolicyStep{policyStep(first), unconditional, policyStep(second)},
+			res:   []auth.ConditionalPolicy{first, second},
+		},
+		{
+			desc:  "retrieve conditions of the unconditional path",
+			steps: []auth.PolicyStep{unconditional},
+			res:   nil,
+		},
+		{
+			desc:  "retrieve conditions of the empty path",
+			steps: nil,
+			res:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		cps, err := repo.RetrieveBySteps(context.Background(), tc.steps)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.res, cps, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, cps))
+	}
+}
+
+func TestRetrieveExpiredConditions(t *testing.T) {
+	cleanConditions(t)
+	repo := postgres.NewConditionsRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	expired := newConditionalPolicy("expired", now.Add(-time.Hour))
+	active := newConditionalPolicy("active", now.Add(time.Hour))
+	unbounded := newConditionalPolicy("unbounded", time.Time{}, "10.0.0.0/8")
+	err := repo.Save(context.Background(), []auth.ConditionalPolicy{expired, active, unbounded})
+	require.Nil(t, err, fmt.Sprintf("save conditions unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		before time.Time
+		limit  uint64
+		res    []auth.ConditionalPolicy
+	}{
+		{
+			desc:   "retrieve expired conditions",
+			before: now,
+			limit:  10,
+			res:    []auth.ConditionalPolicy{expired},
+		},
+		{
+			desc:   "retrieve conditions expired in the future",
+			before: now.Add(2 * time.Hour),
+			limit:  10,
+			res:    []auth.ConditionalPolicy{expired, active},
+		},
+		{
+			desc:   "retrieve expired conditions with limit",
+			before: now.Add(2 * time.Hour),
+			limit:  1,
+			res:    []auth.ConditionalPolicy{expired},
+		},
+	}
+
+	for _, tc := range cases {
+		cps, err := repo.RetrieveExpired(context.Background(), tc.before, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.res, cps, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, cps))
+	}
+}
+
+func TestRemoveConditions(t *testing.T) {
+	cleanConditions(t)
+	repo := postgres.NewConditionsRepository(database)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	first := newConditionalPolicy("first", expiresAt)
+	second := newConditionalPolicy("second", expiresAt)
+	third := newConditionalPolicy("third", expiresAt)
+	err := repo.Save(context.Background(), []auth.ConditionalPolicy{first, second, third})
+	require.Nil(t, err, fmt.Sprintf("save conditions unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), []auth.ConditionalPolicy{first})
+	assert.Nil(t, err, fmt.Sprintf("remove conditions unexpected error: %s", err))
+	cps, err := repo.RetrieveBySteps(context.Background(), []auth.PolicyStep{policyStep(first)})
+	assert.Nil(t, err, fmt.Sprintf("retrieve conditions unexpected error: %s", err))
+	assert.Empty(t, cps)
+
+	err = repo.RemoveFilter(context.Background(), auth.ConditionalPolicy{Object: second.Object, ObjectType: auth.ThingType})
+	assert.Nil(t, err, fmt.Sprintf("remove conditions by filter unexpected error: %s", err))
+	cps, err = repo.RetrieveExpired(context.Background(), expiresAt, 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve conditions unexpected error: %s", err))
+	assert.Equal(t, []auth.ConditionalPolicy{third}, cps)
+
+	err = repo.RemoveFilter(context.Background(), auth.ConditionalPolicy{})
+	assert.NotNil(t, err, "remove conditions by empty filter expected to fail")
+}
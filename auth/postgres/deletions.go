@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+func (repo domainRepo) SaveDeletion(ctx context.Context, dd auth.DomainDeletion) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := `UPDATE domains SET status = $1, updated_at = $2, updated_by = $3 WHERE id = $4 AND status <> $1`
+	res, err := tx.ExecContext(ctx, q, auth.DeletedStatus, dd.RequestedAt, dd.RequestedBy, dd.DomainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrConflict
+	}
+
+	// Only the canceled deletion is replaced, the domain status
+	// prevents requesting the deletion twice.
+	q = `INSERT INTO domain_deletions (domain_id, status, requested_by, requested_at, updated_at, attempts, retry_at, error)
+	VALUES (:domain_id, :status, :requested_by, :requested_at, :updated_at, :attempts, :retry_at, :error)
+	ON CONFLICT (domain_id) DO UPDATE
+	SET status = EXCLUDED.status, requested_by = EXCLUDED.requested_by, requested_at = EXCLUDED.requested_at,
+	updated_at = EXCLUDED.updated_at, attempts = EXCLUDED.attempts, retry_at = EXCLUDED.retry_at, error = EXCLUDED.error
+	WHERE domain_deletions.status = :canceled`
+
+	dbdd := toDBDeletion(dd)
+	res, err = tx.NamedExecContext(ctx, q, dbdd)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrConflict
+	}
+
+	return tx.Commit()
+}
+
+func (repo domainRepo) RetrieveDeletion(ctx context.Context, id string) (auth.DomainDeletion, error) {
+	q := `SELECT domain_id, status, requested_by, requested_at, updated_at, attempts, retry_at, error
+	FROM domain_deletions WHERE domain_id = $1`
+
+	var dbdd dbDeletion
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbdd); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.DomainDeletion{}, repoerr.ErrNotFound
+		}
+		return auth.DomainDeletion{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toDeletion(dbdd), nil
+}
+
+func (repo domainRepo) RetrieveDeletions(ctx context.Context, before, now time.Time, maxAttempts, limit uint64) ([]auth.DomainDeletion, error) {
+	q := `SELECT domain_id, status, requested_by, requested_at, updated_at, attempts, retry_at, error FROM domain_deletions
+	WHERE status IN ($1, $2) AND requested_at <= $3 AND retry_at <= $4 AND attempts < $5 ORDER BY requested_at LIMIT $6`
+
+	rows, err := repo.db.QueryxContext(ctx, q, auth.DeletionPending, auth.DeletionFailed, before.UTC(), now.UTC(), maxAttempts, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var dds []auth.DomainDeletion
+	for rows.Next() {
+		var dbdd dbDeletion
+		if err := rows.StructScan(&dbdd); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		dds = append(dds, toDeletion(dbdd))
+	}
+
+	return dds, nil
+}
+
+func (repo domainRepo) UpdateDeletion(ctx context.Context, dd auth.DomainDeletion) error {
+	q := `UPDATE domain_deletions SET status = :status, updated_at = :updated_at, attempts = :attempts, retry_at = :retry_at, error = :error
+	WHERE domain_id = :domain_id`
+
+	res, err := repo.db.NamedExecContext(ctx, q, toDBDeletion(dd))
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbDeletion struct {
+	DomainID    string    `db:"domain_id"`
+	Status      string    `db:"status"`
+	RequestedBy string    `db:"requested_by"`
+	RequestedAt time.Time `db:"requested_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	Attempts    uint64    `db:"attempts"`
+	RetryAt     time.Time `db:"retry_at"`
+	Error       string    `db:"error"`
+	Canceled    string    `db:"canceled"`
+}
+
+func toDBDeletion(dd auth.DomainDeletion) dbDeletion {
+	return dbDeletion{
+		DomainID:    dd.DomainID,
+		Status:      string(dd.Status),
+		RequestedBy: dd.RequestedBy,
+		RequestedAt: dd.RequestedAt.UTC(),
+		UpdatedAt:   dd.UpdatedAt.UTC(),
+		Attempts:    dd.Attempts,
+		RetryAt:     dd.RetryAt.UTC(),
+		Error:       dd.Error,
+		Canceled:    string(auth.DeletionCanceled),
+	}
+}
+
+func toDeletion(dbdd dbDeletion) auth.DomainDeletion {
+	return auth.DomainDeletion{
+		DomainID:    dbdd.DomainID,
+		Status:      auth.DeletionStatus(dbdd.Status),
+		RequestedBy: dbdd.RequestedBy,
+		RequestedAt: dbdd.RequestedAt.UTC(),
+		UpdatedAt:   dbdd.UpdatedAt.UTC(),
+		Attempts:    dbdd.Attempts,
+		RetryAt:     dbdd.RetryAt.UTC(),
+		Error:       dbdd.Error,
+	}
+}
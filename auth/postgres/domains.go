@@ -36,9 +36,9 @@ func NewDomainRepository(db postgres.Database) auth.DomainsRepository {
 }
 
 func (repo domainRepo) Save(ctx context.Context, d auth.Domain) (ad auth.Domain, err error) {
-	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status)
-	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status)
-	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`
+	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required, quotas)
+	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status, :mfa_required, :quotas)
+	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required, quotas;`
 
 	dbd, err := toDBDomain(d)
 	if err != nil {
@@ -67,7 +67,7 @@ func (repo domainRepo) Save(ctx context.Context, d auth.Domain) (ad auth.Domain,
 
 // RetrieveByID retrieves Domain by its unique ID.
 func (repo domainRepo) RetrieveByID(ctx context.Context, id string) (auth.Domain, error) {
-	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required, d.quotas as quotas
         FROM domains d WHERE d.id = :id`
 
 	dbdp := dbDomainsPage{
@@ -136,7 +136,7 @@ func (repo domainRepo) RetrieveAllByIDs(ctx context.Context, pm auth.Page) (auth
 		return auth.DomainsPage{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
 	}
 
-	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required, d.quotas as quotas
 	FROM domains d`
 	q = fmt.Sprintf("%s %s  LIMIT %d OFFSET %d;", q, query, pm.Limit, pm.Offset)
 
@@ -182,7 +182,7 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm auth.Page) (auth.Doma
 		return auth.DomainsPage{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
 	}
 
-	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, pc.relation as relation
+	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required, d.quotas as quotas, pc.relation as relation
 	FROM domains as d
 	JOIN policies pc
 	ON pc.object_id = d.id`
@@ -192,7 +192,7 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm auth.Page) (auth.Doma
 	// If the user making the request is a super admin, the service will assign an empty value to the pagemeta subject field.
 	// In the repository, when the pagemeta subject is empty, the query should be constructed without applying the policies filter.
 	if pm.SubjectID == "" {
-		q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+		q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required, d.quotas as quotas
 		FROM domains as d`
 	}
 
@@ -262,6 +262,14 @@ func (repo domainRepo) Update(ctx context.Context, id, userID string, dr auth.Do
 		query = append(query, "alias = :alias, ")
 		d.Alias = *dr.Alias
 	}
+	if dr.MFARequired != nil {
+		query = append(query, "mfa_required = :mfa_required, ")
+		d.MFARequired = *dr.MFARequired
+	}
+	if dr.Quotas != nil {
+		query = append(query, "quotas = :quotas, ")
+		d.Quotas = *dr.Quotas
+	}
 	d.UpdatedAt = time.Now()
 	d.UpdatedBy = userID
 	if len(query) > 0 {
@@ -269,7 +277,7 @@ func (repo domainRepo) Update(ctx context.Context, id, userID string, dr auth.Do
 	}
 	q := fmt.Sprintf(`UPDATE domains SET %s  updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id %s
-        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`,
+        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required, quotas;`,
 		upq, ws)
 
 	dbd, err := toDBDomain(d)
@@ -297,10 +305,26 @@ func (repo domainRepo) Update(ctx context.Context, id, userID string, dr auth.Do
 }
 
 // Delete delete domain from database.
-func (repo domainRepo) Delete(ctx context.Context, id string) error {
-	q := "DELETE FROM domains WHERE id = $1;"
+func (repo domainRepo) Delete(ctx context.Context, id string) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
 
-	res, err := repo.db.ExecContext(ctx, q, id)
+	q := "DELETE FROM policies WHERE object_type = $1 AND object_id = $2;"
+	if _, err := tx.ExecContext(ctx, q, auth.DomainType, id); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	q = "DELETE FROM domains WHERE id = $1;"
+	res, err := tx.ExecContext(ctx, q, id)
 	if err != nil {
 		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
 	}
@@ -308,7 +332,7 @@ func (repo domainRepo) Delete(ctx context.Context, id string) error {
 		return repoerr.ErrNotFound
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // SavePolicies save policies in domains database.
@@ -417,17 +441,19 @@ func (repo domainRepo) processRows(rows *sqlx.Rows) ([]auth.Domain, error) {
 }
 
 type dbDomain struct {
-	ID         string           `db:"id"`
-	Name       string           `db:"name"`
-	Metadata   []byte           `db:"metadata,omitempty"`
-	Tags       pgtype.TextArray `db:"tags,omitempty"`
-	Alias      *string          `db:"alias,omitempty"`
-	Status     auth.Status      `db:"status"`
-	Permission string           `db:"relation"`
-	CreatedBy  string           `db:"created_by"`
-	CreatedAt  time.Time        `db:"created_at"`
-	UpdatedBy  *string          `db:"updated_by,omitempty"`
-	UpdatedAt  sql.NullTime     `db:"updated_at,omitempty"`
+	ID          string           `db:"id"`
+	Name        string           `db:"name"`
+	Metadata    []byte           `db:"metadata,omitempty"`
+	Tags        pgtype.TextArray `db:"tags,omitempty"`
+	Alias       *string          `db:"alias,omitempty"`
+	Status      auth.Status      `db:"status"`
+	MFARequired bool             `db:"mfa_required"`
+	Quotas      []byte           `db:"quotas"`
+	Permission  string           `db:"relation"`
+	CreatedBy   string           `db:"created_by"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedBy   *string          `db:"updated_by,omitempty"`
+	UpdatedAt   sql.NullTime     `db:"updated_at,omitempty"`
 }
 
 func toDBDomain(d auth.Domain) (dbDomain, error) {
@@ -448,6 +474,11 @@ func toDBDomain(d auth.Domain) (dbDomain, error) {
 		alias = &d.Alias
 	}
 
+	quotas, err := json.Marshal(d.Quotas)
+	if err != nil {
+		return dbDomain{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
 	var updatedBy *string
 	if d.UpdatedBy != "" {
 		updatedBy = &d.UpdatedBy
@@ -458,17 +489,19 @@ func toDBDomain(d auth.Domain) (dbDomain, error) {
 	}
 
 	return dbDomain{
-		ID:         d.ID,
-		Name:       d.Name,
-		Metadata:   data,
-		Tags:       tags,
-		Alias:      alias,
-		Status:     d.Status,
-		Permission: d.Permission,
-		CreatedBy:  d.CreatedBy,
-		CreatedAt:  d.CreatedAt,
-		UpdatedBy:  updatedBy,
-		UpdatedAt:  updatedAt,
+		ID:          d.ID,
+		Name:        d.Name,
+		Metadata:    data,
+		Tags:        tags,
+		Alias:       alias,
+		Status:      d.Status,
+		MFARequired: d.MFARequired,
+		Quotas:      quotas,
+		Permission:  d.Permission,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   d.CreatedAt,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   updatedAt,
 	}, nil
 }
 
@@ -487,6 +520,12 @@ func toDomain(d dbDomain) (auth.Domain, error) {
 	if d.Alias != nil {
 		alias = *d.Alias
 	}
+	var quotas auth.Quotas
+	if d.Quotas != nil {
+		if err := json.Unmarshal(d.Quotas, &quotas); err != nil {
+			return auth.Domain{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
 	var updatedBy string
 	if d.UpdatedBy != nil {
 		updatedBy = *d.UpdatedBy
@@ -497,17 +536,19 @@ func toDomain(d dbDomain) (auth.Domain, error) {
 	}
 
 	return auth.Domain{
-		ID:         d.ID,
-		Name:       d.Name,
-		Metadata:   metadata,
-		Tags:       tags,
-		Alias:      alias,
-		Permission: d.Permission,
-		Status:     d.Status,
-		CreatedBy:  d.CreatedBy,
-		CreatedAt:  d.CreatedAt,
-		UpdatedBy:  updatedBy,
-		UpdatedAt:  updatedAt,
+		ID:          d.ID,
+		Name:        d.Name,
+		Metadata:    metadata,
+		Tags:        tags,
+		Alias:       alias,
+		Permission:  d.Permission,
+		Status:      d.Status,
+		MFARequired: d.MFARequired,
+		Quotas:      quotas,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   d.CreatedAt,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   updatedAt,
 	}, nil
 }
 
@@ -0,0 +1,180 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const (
+	// maxExplainDepth limits the number of the schema steps followed
+	// from the object towards the subject.
+	maxExplainDepth = 16
+	// maxExplainSubjects limits the number of the related objects
+	// followed by a single arrow.
+	maxExplainSubjects = 100
+)
+
+func (pa *policyAgent) ExplainPolicy(ctx context.Context, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	err := pa.CheckPolicy(ctx, pr)
+	if err != nil && !errors.Contains(err, svcerr.ErrAuthorization) {
+		return auth.PolicyExplanation{}, err
+	}
+	allowed := err == nil
+	e := explainer{
+		pa:      pa,
+		pr:      pr,
+		granted: map[string]grantedPermissions{},
+		visited: map[string]bool{},
+	}
+	path, _, err := e.explain(ctx, pr.ObjectType, pr.Object, pr.Permission, 0)
+	if err != nil {
+		return auth.PolicyExplanation{}, err
+	}
+	// The path may be cut by the explain limits, so the decision is
+	// always the one of the permission check.
+	if allowed {
+		return auth.PolicyExplanation{Allowed: true, Path: path}, nil
+	}
+
+	return auth.PolicyExplanation{Required: e.required}, nil
+}
+
+// explainer looks for the relationships granting the permission going from
+// the object towards the subject following the schema. The permissions
+// granted on the visited objects are used to skip the excluded subjects.
+type explainer struct {
+	pa       *policyAgent
+	pr       auth.PolicyReq
+	granted  map[string]grantedPermissions
+	visited  map[string]bool
+	required []auth.PolicyStep
+}
+
+// explain returns the relationships granting the relation or the permission
+// on the object, ordered from the relationship of the subject.
+func (e *explainer) explain(ctx context.Context, objectType, objectID, name string, depth int) ([]auth.PolicyStep, bool, error) {
+	key := objectType + ":" + objectID + "#" + name
+	if depth > maxExplainDepth || e.visited[key] {
+		return nil, false, nil
+	}
+	e.visited[key] = true
+
+	granted, err := e.permissions(ctx, objectType, objectID)
+	if err != nil {
+		return nil, false, err
+	}
+	def := e.pa.schema[objectType]
+	if _, ok := def.relations[name]; ok {
+		step := auth.PolicyStep{
+			SubjectType: e.pr.SubjectType,
+			Subject:     e.pr.Subject,
+			Relation:    name,
+			ObjectType:  objectType,
+			Object:      objectID,
+		}
+		if granted[name] {
+			return []auth.PolicyStep{step}, true, nil
+		}
+		e.require(step)
+
+		return nil, false, nil
+	}
+
+	p, ok := def.permissions[name]
+	if !ok || granted[excludedPrefix+name] {
+		return nil, false, nil
+	}
+	for _, t := range p.union {
+		if t.permission == "" {
+			path, ok, err := e.explain(ctx, objectType, objectID, t.relation, depth+1)
+			if err != nil || ok {
+				return path, ok, err
+			}
+			continue
+		}
+		subjects, err := e.pa.relatedSubjects(ctx, objectType, objectID, t.relation)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, s := range subjects {
+			if !e.pa.schema[s.SubjectType].has(t.permission) {
+				continue
+			}
+			path, ok, err := e.explain(ctx, s.SubjectType, s.SubjectID, t.permission, depth+1)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				return append(path, auth.PolicyStep{
+					SubjectType: s.SubjectType,
+					Subject:     s.SubjectID,
+					Relation:    t.relation,
+					ObjectType:  objectType,
+					Object:      objectID,
+				}), true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (e *explainer) permissions(ctx context.Context, objectType, objectID string) (grantedPermissions, error) {
+	key := objectType + ":" + objectID
+	if granted, ok := e.granted[key]; ok {
+		return granted, nil
+	}
+	pr := e.pr
+	pr.ObjectType = objectType
+	pr.Object = objectID
+	granted, err := e.pa.permissions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	e.granted[key] = granted
+
+	return granted, nil
+}
+
+func (e *explainer) require(step auth.PolicyStep) {
+	for _, s := range e.required {
+		if s == step {
+			return
+		}
+	}
+	e.required = append(e.required, step)
+}
+
+// relatedSubjects returns the subjects the object is related to with the relation.
+func (pa *policyAgent) relatedSubjects(ctx context.Context, objectType, objectID, relation string) ([]dbRelationship, error) {
+	q := `SELECT object_type, object_id, relation, subject_type, subject_id, subject_relation FROM relationships
+		WHERE object_type = $1 AND object_id = $2 AND relation = $3 AND subject_relation = ''
+		ORDER BY subject_type, subject_id LIMIT $4`
+	rows, err := pa.db.QueryxContext(ctx, q, objectType, objectID, relation, maxExplainSubjects)
+	if err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+	defer rows.Close()
+
+	var subjects []dbRelationship
+	for rows.Next() {
+		var r dbRelationship
+		if err := rows.StructScan(&r); err != nil {
+			return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+		}
+		subjects = append(subjects, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+
+	return subjects, nil
+}
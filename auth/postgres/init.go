@@ -57,6 +57,193 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE domains ALTER COLUMN alias SET NOT NULL`,
 				},
 			},
+			{
+				Id: "auth_3",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS scopes JSONB`,
+					`CREATE INDEX IF NOT EXISTS keys_subject_idx ON keys (subject)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS keys_subject_idx`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS scopes`,
+				},
+			},
+			{
+				Id: "auth_4",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS relationships (
+                        object_type         VARCHAR(254) NOT NULL,
+                        object_id           VARCHAR(254) NOT NULL,
+                        relation            VARCHAR(254) NOT NULL,
+                        subject_type        VARCHAR(254) NOT NULL,
+                        subject_id          VARCHAR(254) NOT NULL,
+                        subject_relation    VARCHAR(254) NOT NULL DEFAULT '',
+                        PRIMARY KEY (object_type, object_id, relation, subject_type, subject_id, subject_relation)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS relationships_subject_idx ON relationships (subject_type, subject_id, relation)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS relationships_subject_idx`,
+					`DROP TABLE IF EXISTS relationships`,
+				},
+			},
+			{
+				Id: "auth_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS roles (
+                        id          VARCHAR(36) PRIMARY KEY,
+                        domain_id   VARCHAR(36) NOT NULL REFERENCES domains (id) ON DELETE CASCADE,
+                        name        VARCHAR(254) NOT NULL,
+                        permissions TEXT[] NOT NULL,
+                        created_by  VARCHAR(254),
+                        created_at  TIMESTAMP NOT NULL,
+                        updated_by  VARCHAR(254),
+                        updated_at  TIMESTAMP,
+                        UNIQUE (domain_id, name)
+                    )`,
+					`CREATE TABLE IF NOT EXISTS role_assignments (
+                        role_id     VARCHAR(36) NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+                        entity_type VARCHAR(254) NOT NULL,
+                        entity_id   VARCHAR(254) NOT NULL,
+                        user_id     VARCHAR(254) NOT NULL,
+                        PRIMARY KEY (role_id, entity_type, entity_id, user_id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS role_assignments_entity_idx ON role_assignments (entity_type, entity_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS role_assignments`,
+					`DROP TABLE IF EXISTS roles`,
+				},
+			},
+			{
+				Id: "auth_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS policy_conditions (
+                        subject_type  VARCHAR(254) NOT NULL,
+                        subject       VARCHAR(254) NOT NULL,
+                        relation      VARCHAR(254) NOT NULL,
+                        object_type   VARCHAR(254) NOT NULL,
+                        object        VARCHAR(254) NOT NULL,
+                        expires_at    TIMESTAMP,
+                        allowed_cidrs TEXT[],
+                        PRIMARY KEY (subject_type, subject, relation, object_type, object)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS policy_conditions_object_idx ON policy_conditions (subject_type, subject, object_type, object)`,
+					`CREATE INDEX IF NOT EXISTS policy_conditions_expires_at_idx ON policy_conditions (expires_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS policy_conditions`,
+				},
+			},
+			{
+				Id: "auth_7",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS mfa_required BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE domains DROP COLUMN IF EXISTS mfa_required`,
+				},
+			},
+			{
+				Id: "auth_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS claim_mappings (
+                        id          VARCHAR(36) PRIMARY KEY,
+                        domain_id   VARCHAR(36) NOT NULL REFERENCES domains (id) ON DELETE CASCADE,
+                        provider    VARCHAR(254) NOT NULL DEFAULT '',
+                        claim_group VARCHAR(1024) NOT NULL,
+                        relation    VARCHAR(254) NOT NULL,
+                        group_ids   TEXT[],
+                        created_by  VARCHAR(254),
+                        created_at  TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS claim_mappings_provider_idx ON claim_mappings (provider)`,
+					`CREATE TABLE IF NOT EXISTS claim_grants (
+                        domain_id   VARCHAR(36) NOT NULL REFERENCES domains (id) ON DELETE CASCADE,
+                        user_id     VARCHAR(254) NOT NULL,
+                        provider    VARCHAR(254) NOT NULL,
+                        relation    VARCHAR(254) NOT NULL DEFAULT '',
+                        group_ids   TEXT[],
+                        PRIMARY KEY (domain_id, user_id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS claim_grants_user_idx ON claim_grants (user_id, provider)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS claim_grants`,
+					`DROP TABLE IF EXISTS claim_mappings`,
+				},
+			},
+			{
+				Id: "auth_9",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS quotas JSONB NOT NULL DEFAULT '{}'`,
+					`CREATE TABLE IF NOT EXISTS usage_entities (
+                        domain_id   VARCHAR(36) NOT NULL,
+                        resource    VARCHAR(36) NOT NULL,
+                        entity_id   VARCHAR(254) NOT NULL,
+                        PRIMARY KEY (domain_id, resource, entity_id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS usage_entities_entity_idx ON usage_entities (resource, entity_id)`,
+					`CREATE TABLE IF NOT EXISTS usage_messages (
+                        domain_id   VARCHAR(36) NOT NULL,
+                        day         DATE NOT NULL,
+                        messages    BIGINT NOT NULL DEFAULT 0,
+                        bytes       BIGINT NOT NULL DEFAULT 0,
+                        PRIMARY KEY (domain_id, day)
+                    )`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS usage_messages`,
+					`DROP TABLE IF EXISTS usage_entities`,
+					`ALTER TABLE domains DROP COLUMN IF EXISTS quotas`,
+				},
+			},
+			{
+				Id: "auth_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS domain_deletions (
+                        domain_id       VARCHAR(36) PRIMARY KEY,
+                        status          VARCHAR(16) NOT NULL,
+                        requested_by    VARCHAR(254) NOT NULL,
+                        requested_at    TIMESTAMP NOT NULL,
+                        updated_at      TIMESTAMP NOT NULL,
+                        error           TEXT NOT NULL DEFAULT ''
+                    )`,
+					`CREATE INDEX IF NOT EXISTS domain_deletions_status_idx ON domain_deletions (status, requested_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS domain_deletions`,
+				},
+			},
+			{
+				Id: "auth_11",
+				Up: []string{
+					`ALTER TABLE domain_deletions ALTER COLUMN status TYPE VARCHAR(32)`,
+					`ALTER TABLE domain_deletions ADD COLUMN IF NOT EXISTS attempts BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE domain_deletions ADD COLUMN IF NOT EXISTS retry_at TIMESTAMP`,
+					`UPDATE domain_deletions SET retry_at = requested_at WHERE retry_at IS NULL`,
+					`ALTER TABLE domain_deletions ALTER COLUMN retry_at SET NOT NULL`,
+					`UPDATE domain_deletions SET status = 'auth_cleanup_completed' WHERE status = 'completed'`,
+				},
+				Down: []string{
+					`UPDATE domain_deletions SET status = 'completed' WHERE status = 'auth_cleanup_completed'`,
+					`ALTER TABLE domain_deletions DROP COLUMN IF EXISTS retry_at`,
+					`ALTER TABLE domain_deletions DROP COLUMN IF EXISTS attempts`,
+					`ALTER TABLE domain_deletions ALTER COLUMN status TYPE VARCHAR(16)`,
+				},
+			},
+			{
+				// Seeds the users usage of the domains created before the
+				// accounting, the things and the channels are seeded by
+				// Things service.
+				Id: "auth_12",
+				Up: []string{
+					`INSERT INTO usage_entities (domain_id, resource, entity_id)
+                    SELECT object_id, 'users', subject_id FROM policies
+                    WHERE object_type = 'domain' AND subject_type = 'user'
+                    ON CONFLICT DO NOTHING`,
+				},
+			},
 		},
 	}
 }
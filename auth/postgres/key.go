@@ -6,6 +6,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/absmach/magistrala/auth"
@@ -33,10 +35,13 @@ func New(db postgres.Database) auth.KeyRepository {
 }
 
 func (kr *repo) Save(ctx context.Context, key auth.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, scopes)
+	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :scopes)`
 
-	dbKey := toDBKey(key)
+	dbKey, err := toDBKey(key)
+	if err != nil {
+		return "", errors.Wrap(errSave, err)
+	}
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
 		return "", postgres.HandleError(errSave, err)
 	}
@@ -45,7 +50,7 @@ func (kr *repo) Save(ctx context.Context, key auth.Key) (string, error) {
 }
 
 func (kr *repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, scopes FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
 		if err == sql.ErrNoRows {
@@ -55,7 +60,47 @@ func (kr *repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, er
 		return auth.Key{}, postgres.HandleError(errRetrieve, err)
 	}
 
-	return toKey(key), nil
+	return toKey(key)
+}
+
+func (kr *repo) RetrieveAll(ctx context.Context, pm auth.Page) (auth.KeyPage, error) {
+	q := fmt.Sprintf(`SELECT id, type, issuer_id, subject, issued_at, expires_at, scopes FROM keys
+		WHERE subject = :subject AND type = :type ORDER BY issued_at DESC LIMIT %d OFFSET %d`, pm.Limit, pm.Offset)
+	params := dbKey{
+		Subject: pm.SubjectID,
+		Type:    uint32(auth.APIKey),
+	}
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	keys := []auth.Key{}
+	for rows.Next() {
+		dbk := dbKey{}
+		if err := rows.StructScan(&dbk); err != nil {
+			return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+		}
+		key, err := toKey(dbk)
+		if err != nil {
+			return auth.KeyPage{}, errors.Wrap(errRetrieve, err)
+		}
+		keys = append(keys, key)
+	}
+
+	cq := `SELECT COUNT(*) FROM keys WHERE subject = :subject AND type = :type`
+	total, err := postgres.Total(ctx, kr.db, cq, params)
+	if err != nil {
+		return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+	}
+
+	return auth.KeyPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Keys:   keys,
+	}, nil
 }
 
 func (kr *repo) Remove(ctx context.Context, issuerID, id string) error {
@@ -78,9 +123,10 @@ type dbKey struct {
 	Subject   string       `db:"subject"`
 	IssuedAt  time.Time    `db:"issued_at"`
 	ExpiresAt sql.NullTime `db:"expires_at,omitempty"`
+	Scopes    []byte       `db:"scopes,omitempty"`
 }
 
-func toDBKey(key auth.Key) dbKey {
+func toDBKey(key auth.Key) (dbKey, error) {
 	ret := dbKey{
 		ID:       key.ID,
 		Type:     uint32(key.Type),
@@ -91,11 +137,18 @@ func toDBKey(key auth.Key) dbKey {
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
+	if len(key.Scopes) > 0 {
+		scopes, err := json.Marshal(key.Scopes)
+		if err != nil {
+			return dbKey{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		ret.Scopes = scopes
+	}
 
-	return ret
+	return ret, nil
 }
 
-func toKey(key dbKey) auth.Key {
+func toKey(key dbKey) (auth.Key, error) {
 	ret := auth.Key{
 		ID:       key.ID,
 		Type:     auth.KeyType(key.Type),
@@ -106,6 +159,11 @@ func toKey(key dbKey) auth.Key {
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.Scopes != nil {
+		if err := json.Unmarshal(key.Scopes, &ret.Scopes); err != nil {
+			return auth.Key{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
 
-	return ret
+	return ret, nil
 }
@@ -207,6 +207,62 @@ func TestKeyRetrieve(t *testing.T) {
 	}
 }
 
+func TestKeyRetrieveAll(t *testing.T) {
+	repo := postgres.New(database)
+
+	subject := generateID(t)
+	scopes := []auth.Scope{{Operation: "channels:publish", EntityIDs: []string{generateID(t)}}}
+	num := 5
+	for i := 0; i < num; i++ {
+		key := auth.Key{
+			ID:       generateID(t),
+			Type:     auth.APIKey,
+			Subject:  subject,
+			IssuedAt: time.Now().Add(time.Duration(i) * time.Second),
+			Issuer:   generateID(t),
+			Scopes:   scopes,
+		}
+		_, err := repo.Save(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		page  auth.Page
+		size  int
+		total uint64
+	}{
+		{
+			desc:  "retrieve all keys of the subject",
+			page:  auth.Page{SubjectID: subject, Limit: 10},
+			size:  num,
+			total: uint64(num),
+		},
+		{
+			desc:  "retrieve subset of keys of the subject",
+			page:  auth.Page{SubjectID: subject, Offset: 3, Limit: 10},
+			size:  num - 3,
+			total: uint64(num),
+		},
+		{
+			desc:  "retrieve keys of the subject without keys",
+			page:  auth.Page{SubjectID: generateID(t), Limit: 10},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Keys, tc.size, fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.size, len(page.Keys)))
+		for _, key := range page.Keys {
+			assert.Equal(t, scopes, key.Scopes, fmt.Sprintf("%s: expected scopes %v got %v", tc.desc, scopes, key.Scopes))
+		}
+	}
+}
+
 func TestKeyRemove(t *testing.T) {
 	repo := postgres.New(database)
 
@@ -0,0 +1,659 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errInvalidSubject     = errors.New("invalid subject kind")
+	errAddPolicies        = errors.New("failed to add policies")
+	errRetrievePolicies   = errors.New("failed to retrieve policies")
+	errRemovePolicies     = errors.New("failed to remove the policies")
+	errNoPolicies         = errors.New("no policies provided")
+	errPreconditionFailed = errors.New("policy precondition failed")
+	errSuperAdminDomain   = errors.New("user already exists in domain")
+)
+
+var _ auth.PolicyAgent = (*policyAgent)(nil)
+
+type policyAgent struct {
+	db     postgres.Database
+	schema schema
+	// rules is the CTE with the schema rules, shared by all the queries.
+	rules string
+}
+
+// NewPolicyAgent returns the policy agent evaluating the SpiceDB schema
+// with Postgres recursive queries, so the Auth service can run without
+// SpiceDB. The schema has the same format as the SpiceDB schema file.
+func NewPolicyAgent(db postgres.Database, schemaContent string) (auth.PolicyAgent, error) {
+	s, err := parseSchema(schemaContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyAgent{
+		db:     db,
+		schema: s,
+		rules:  rulesCTE(s.rules()),
+	}, nil
+}
+
+func (pa *policyAgent) CheckPolicy(ctx context.Context, pr auth.PolicyReq) error {
+	if err := pa.schema.validatePermission(pr.ObjectType, pr.Permission); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	q, args := pa.checkQuery(pr)
+	var allowed bool
+	if err := pa.db.QueryRowxContext(ctx, q, args...).Scan(&allowed); err != nil {
+		return errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+	if !allowed {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
+func (pa *policyAgent) AddPolicy(ctx context.Context, pr auth.PolicyReq) error {
+	return pa.AddPolicies(ctx, []auth.PolicyReq{pr})
+}
+
+func (pa *policyAgent) AddPolicies(ctx context.Context, prs []auth.PolicyReq) (err error) {
+	if len(prs) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errNoPolicies)
+	}
+	var preconds []precondition
+	for _, pr := range prs {
+		if err := pa.schema.validateRelation(pr.ObjectType, pr.Relation, pr.SubjectType); err != nil {
+			return errors.Wrap(errAddPolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+		precond, err := pa.addPolicyPreCondition(ctx, pr)
+		if err != nil {
+			return err
+		}
+		preconds = append(preconds, precond...)
+	}
+
+	tx, err := pa.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	// Same as in SpiceDB, preconditions are checked before any policy is written.
+	for _, pc := range preconds {
+		if err := pc.check(ctx, tx); err != nil {
+			return errors.Wrap(errAddPolicies, err)
+		}
+	}
+	q := `INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id, subject_relation)
+		VALUES (:object_type, :object_id, :relation, :subject_type, :subject_id, :subject_relation)`
+	for _, pr := range prs {
+		if _, err := tx.NamedExecContext(ctx, q, toDBRelationship(pr)); err != nil {
+			return errors.Wrap(errAddPolicies, postgres.HandleError(repoerr.ErrCreateEntity, err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+
+	return nil
+}
+
+func (pa *policyAgent) DeletePolicyFilter(ctx context.Context, pr auth.PolicyReq) error {
+	f := filter{
+		objectType: pr.ObjectType,
+		objectID:   pr.Object,
+		relation:   pr.Relation,
+	}
+	if pr.SubjectType != "" {
+		f.subjectType = pr.SubjectType
+		f.subjectID = pr.Subject
+		f.subjectRelation = pr.SubjectRelation
+	}
+	where, args := f.where()
+	if _, err := pa.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM relationships WHERE %s", where), args...); err != nil {
+		return errors.Wrap(errRemovePolicies, postgres.HandleError(repoerr.ErrRemoveEntity, err))
+	}
+
+	return nil
+}
+
+func (pa *policyAgent) DeletePolicies(ctx context.Context, prs []auth.PolicyReq) (err error) {
+	if len(prs) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errNoPolicies)
+	}
+	tx, err := pa.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := `DELETE FROM relationships
+		WHERE object_type = :object_type AND object_id = :object_id AND relation = :relation
+		AND subject_type = :subject_type AND subject_id = :subject_id AND subject_relation = :subject_relation`
+	for _, pr := range prs {
+		if _, err := tx.NamedExecContext(ctx, q, toDBRelationship(pr)); err != nil {
+			return errors.Wrap(errRemovePolicies, postgres.HandleError(repoerr.ErrRemoveEntity, err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	return nil
+}
+
+func (pa *policyAgent) RetrieveObjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) ([]auth.PolicyRes, string, error) {
+	ids, err := pa.retrieveObjects(ctx, pr, nextPageToken, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	policies := []auth.PolicyRes{}
+	for _, id := range ids {
+		policies = append(policies, auth.PolicyRes{Object: id})
+	}
+
+	return policies, pageToken(ids, limit), nil
+}
+
+func (pa *policyAgent) RetrieveAllObjects(ctx context.Context, pr auth.PolicyReq) ([]auth.PolicyRes, error) {
+	policies, _, err := pa.RetrieveObjects(ctx, pr, "", 0)
+	return policies, err
+}
+
+func (pa *policyAgent) RetrieveAllObjectsCount(ctx context.Context, pr auth.PolicyReq) (uint64, error) {
+	if err := pa.schema.validatePermission(pr.ObjectType, pr.Permission); err != nil {
+		return 0, errors.Wrap(errRetrievePolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	q, args := pa.objectsQuery(pr)
+
+	return pa.count(ctx, q, args)
+}
+
+func (pa *policyAgent) RetrieveSubjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) ([]auth.PolicyRes, string, error) {
+	ids, err := pa.retrieveSubjects(ctx, pr, nextPageToken, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	policies := []auth.PolicyRes{}
+	for _, id := range ids {
+		policies = append(policies, auth.PolicyRes{Subject: id})
+	}
+
+	return policies, pageToken(ids, limit), nil
+}
+
+func (pa *policyAgent) RetrieveAllSubjects(ctx context.Context, pr auth.PolicyReq) ([]auth.PolicyRes, error) {
+	policies, _, err := pa.RetrieveSubjects(ctx, pr, "", 0)
+	return policies, err
+}
+
+func (pa *policyAgent) RetrieveAllSubjectsCount(ctx context.Context, pr auth.PolicyReq) (uint64, error) {
+	if err := pa.schema.validatePermission(pr.ObjectType, pr.Permission); err != nil {
+		return 0, errors.Wrap(errRetrievePolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	q, args := pa.subjectsQuery(pr)
+
+	return pa.count(ctx, q, args)
+}
+
+func (pa *policyAgent) RetrievePermissions(ctx context.Context, pr auth.PolicyReq, filterPermission []string) (auth.Permissions, error) {
+	for _, fp := range filterPermission {
+		if err := pa.schema.validatePermission(pr.ObjectType, fp); err != nil {
+			return auth.Permissions{}, errors.Wrap(errRetrievePolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+	}
+	granted, err := pa.permissions(ctx, pr)
+	if err != nil {
+		return auth.Permissions{}, err
+	}
+
+	permissions := []string{}
+	for _, fp := range filterPermission {
+		if granted.allows(fp) {
+			permissions = append(permissions, fp)
+		}
+	}
+
+	return permissions, nil
+}
+
+// grantedCTE computes the relations and permissions the subject has on
+// all the objects, starting from the subject relations and following the
+// schema rules. Exclusions are evaluated on the result.
+const grantedCTE = `granted (object_type, object_id, permission) AS (
+		SELECT CAST(object_type AS TEXT), CAST(object_id AS TEXT), CAST(relation AS TEXT)
+		FROM relationships
+		WHERE subject_type = $1 AND subject_id = $2 AND subject_relation = $3
+	UNION
+		SELECT r.object_type, COALESCE(CAST(t.object_id AS TEXT), g.object_id), r.target
+		FROM granted g
+		JOIN rules r ON r.subject_type = g.object_type AND r.source = g.permission
+		LEFT JOIN relationships t ON r.relation <> '' AND t.object_type = r.object_type AND t.relation = r.relation
+			AND t.subject_type = g.object_type AND t.subject_id = g.object_id AND t.subject_relation = ''
+		WHERE r.relation = '' OR t.object_id IS NOT NULL
+	)`
+
+// requiredCTE computes the relations of the objects that grant the
+// permission (root) or its exclusion on the object, going from the
+// object towards the subjects following the schema rules in reverse.
+const requiredCTE = `required (root, object_type, object_id, permission) AS (
+		VALUES (CAST($1 AS TEXT), CAST($2 AS TEXT), CAST($3 AS TEXT), CAST($4 AS TEXT)),
+			(CAST($5 AS TEXT), CAST($6 AS TEXT), CAST($7 AS TEXT), CAST($8 AS TEXT))
+	UNION
+		SELECT q.root, r.subject_type, COALESCE(CAST(t.subject_id AS TEXT), q.object_id), r.source
+		FROM required q
+		JOIN rules r ON r.object_type = q.object_type AND r.target = q.permission
+		LEFT JOIN relationships t ON r.relation <> '' AND t.object_type = q.object_type AND t.object_id = q.object_id
+			AND t.relation = r.relation AND t.subject_type = r.subject_type AND t.subject_relation = ''
+		WHERE r.relation = '' OR t.subject_id IS NOT NULL
+	)`
+
+func (pa *policyAgent) permissions(ctx context.Context, pr auth.PolicyReq) (grantedPermissions, error) {
+	q := fmt.Sprintf(`WITH RECURSIVE %s, %s
+		SELECT DISTINCT permission FROM granted WHERE object_type = $4 AND object_id = $5`, pa.rules, grantedCTE)
+
+	rows, err := pa.db.QueryxContext(ctx, q, pr.SubjectType, pr.Subject, pr.SubjectRelation, pr.ObjectType, pr.Object)
+	if err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+	defer rows.Close()
+
+	granted := grantedPermissions{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+		}
+		granted[permission] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+
+	return granted, nil
+}
+
+// checkQuery checks the permission going from the object towards the
+// subject, so only the relationships which can grant the permission on the
+// object are visited rather than everything the subject has access to.
+func (pa *policyAgent) checkQuery(pr auth.PolicyReq) (string, []interface{}) {
+	q := fmt.Sprintf(`WITH RECURSIVE %s, %s
+		SELECT EXISTS (
+			SELECT 1 FROM required q
+			JOIN relationships t ON t.object_type = q.object_type AND t.object_id = q.object_id AND t.relation = q.permission
+			WHERE q.root = $1 AND t.subject_type = $9 AND t.subject_id = $10 AND t.subject_relation = $11
+		) AND NOT EXISTS (
+			SELECT 1 FROM required q
+			JOIN relationships t ON t.object_type = q.object_type AND t.object_id = q.object_id AND t.relation = q.permission
+			WHERE q.root = $5 AND t.subject_type = $9 AND t.subject_id = $10 AND t.subject_relation = $11
+		)`, pa.rules, requiredCTE)
+	excluded := excludedPrefix + pr.Permission
+	args := []interface{}{
+		pr.Permission, pr.ObjectType, pr.Object, pr.Permission,
+		excluded, pr.ObjectType, pr.Object, excluded,
+		pr.SubjectType, pr.Subject, pr.SubjectRelation,
+	}
+
+	return q, args
+}
+
+func (pa *policyAgent) objectsQuery(pr auth.PolicyReq) (string, []interface{}) {
+	q := fmt.Sprintf(`WITH RECURSIVE %s, %s
+		SELECT object_id AS id FROM granted WHERE object_type = $4 AND permission = $5
+		EXCEPT
+		SELECT object_id AS id FROM granted WHERE object_type = $6 AND permission = $7`, pa.rules, grantedCTE)
+	args := []interface{}{pr.SubjectType, pr.Subject, pr.SubjectRelation, pr.ObjectType, pr.Permission, pr.ObjectType, excludedPrefix + pr.Permission}
+
+	return q, args
+}
+
+func (pa *policyAgent) subjectsQuery(pr auth.PolicyReq) (string, []interface{}) {
+	q := fmt.Sprintf(`WITH RECURSIVE %s, %s
+		SELECT CAST(t.subject_id AS TEXT) AS id FROM required q
+		JOIN relationships t ON t.object_type = q.object_type AND t.object_id = q.object_id AND t.relation = q.permission
+		WHERE q.root = $9 AND t.subject_type = $10 AND t.subject_relation = $11
+		EXCEPT
+		SELECT CAST(t.subject_id AS TEXT) AS id FROM required q
+		JOIN relationships t ON t.object_type = q.object_type AND t.object_id = q.object_id AND t.relation = q.permission
+		WHERE q.root = $12 AND t.subject_type = $13 AND t.subject_relation = $14`, pa.rules, requiredCTE)
+	excluded := excludedPrefix + pr.Permission
+	args := []interface{}{
+		pr.Permission, pr.ObjectType, pr.Object, pr.Permission,
+		excluded, pr.ObjectType, pr.Object, excluded,
+		pr.Permission, pr.SubjectType, pr.SubjectRelation,
+		excluded, pr.SubjectType, pr.SubjectRelation,
+	}
+
+	return q, args
+}
+
+func (pa *policyAgent) retrieveObjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) ([]string, error) {
+	if err := pa.schema.validatePermission(pr.ObjectType, pr.Permission); err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	q, args := pa.objectsQuery(pr)
+
+	return pa.page(ctx, q, args, nextPageToken, limit)
+}
+
+func (pa *policyAgent) retrieveSubjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) ([]string, error) {
+	if err := pa.schema.validatePermission(pr.ObjectType, pr.Permission); err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	q, args := pa.subjectsQuery(pr)
+
+	return pa.page(ctx, q, args, nextPageToken, limit)
+}
+
+// page returns the IDs following the page token, which is the last ID of
+// the previous page.
+func (pa *policyAgent) page(ctx context.Context, query string, args []interface{}, nextPageToken string, limit uint64) ([]string, error) {
+	args = append(args, nextPageToken)
+	q := fmt.Sprintf("SELECT id FROM (%s) AS ids WHERE id > $%d ORDER BY id", query, len(args))
+	if limit > 0 {
+		q = fmt.Sprintf("%s LIMIT %d", q, limit)
+	}
+
+	rows, err := pa.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+
+	return ids, nil
+}
+
+func (pa *policyAgent) count(ctx context.Context, query string, args []interface{}) (uint64, error) {
+	var count uint64
+	q := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS ids", query)
+	if err := pa.db.QueryRowxContext(ctx, q, args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(errRetrievePolicies, postgres.HandleError(repoerr.ErrViewEntity, err))
+	}
+
+	return count, nil
+}
+
+func pageToken(ids []string, limit uint64) string {
+	if limit == 0 || uint64(len(ids)) < limit {
+		return ""
+	}
+
+	return ids[len(ids)-1]
+}
+
+func rulesCTE(rules []rule) string {
+	values := []string{}
+	for _, r := range rules {
+		values = append(values, fmt.Sprintf("('%s', '%s', '%s', '%s', '%s')", r.objectType, r.relation, r.subjectType, r.source, r.target))
+	}
+
+	return fmt.Sprintf("rules (object_type, relation, subject_type, source, target) AS (VALUES %s)", strings.Join(values, ", "))
+}
+
+// grantedPermissions are the relations and permissions granted on the object,
+// together with the excluded ones.
+type grantedPermissions map[string]bool
+
+func (gp grantedPermissions) allows(permission string) bool {
+	return gp[permission] && !gp[excludedPrefix+permission]
+}
+
+type dbRelationship struct {
+	ObjectType      string `db:"object_type"`
+	ObjectID        string `db:"object_id"`
+	Relation        string `db:"relation"`
+	SubjectType     string `db:"subject_type"`
+	SubjectID       string `db:"subject_id"`
+	SubjectRelation string `db:"subject_relation"`
+}
+
+func toDBRelationship(pr auth.PolicyReq) dbRelationship {
+	return dbRelationship{
+		ObjectType:      pr.ObjectType,
+		ObjectID:        pr.Object,
+		Relation:        pr.Relation,
+		SubjectType:     pr.SubjectType,
+		SubjectID:       pr.Subject,
+		SubjectRelation: pr.SubjectRelation,
+	}
+}
+
+// filter matches the relationships by the non-empty fields.
+type filter struct {
+	objectType      string
+	objectID        string
+	relation        string
+	subjectType     string
+	subjectID       string
+	subjectRelation string
+}
+
+func (f filter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, field := range []struct{ column, value string }{
+		{"object_type", f.objectType},
+		{"object_id", f.objectID},
+		{"relation", f.relation},
+		{"subject_type", f.subjectType},
+		{"subject_id", f.subjectID},
+		{"subject_relation", f.subjectRelation},
+	} {
+		if field.value == "" {
+			continue
+		}
+		args = append(args, field.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", field.column, len(args)))
+	}
+	if len(conditions) == 0 {
+		return "TRUE", args
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// precondition requires the relationships matching the filter to exist,
+// or not to exist, for the policies to be added.
+type precondition struct {
+	mustMatch bool
+	filter    filter
+}
+
+func (pc precondition) check(ctx context.Context, tx *sqlx.Tx) error {
+	where, args := pc.filter.where()
+	var exists bool
+	if err := tx.QueryRowxContext(ctx, fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM relationships WHERE %s)", where), args...).Scan(&exists); err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	if exists != pc.mustMatch {
+		return errors.Wrap(errors.ErrMalformedEntity, errPreconditionFailed)
+	}
+
+	return nil
+}
+
+func (pa *policyAgent) addPolicyPreCondition(ctx context.Context, pr auth.PolicyReq) ([]precondition, error) {
+	// Checks are required for following  ( -> means adding)
+	// 1.) user -> group (both user groups and channels)
+	// 2.) user -> thing
+	// 3.) group -> group (both for adding parent_group and channels)
+	// 4.) group (channel) -> thing
+	// 5.) user -> domain
+
+	switch {
+	// 1.) user -> group (both user groups and channels)
+	case pr.SubjectType == auth.UserType && pr.ObjectType == auth.GroupType:
+		return pa.userEntityPreConditions(ctx, pr, auth.NewGroupKind, auth.NewChannelKind)
+
+	// 2.) user -> thing
+	case pr.SubjectType == auth.UserType && pr.ObjectType == auth.ThingType:
+		return pa.userEntityPreConditions(ctx, pr, auth.NewThingKind)
+
+	// 3.) group -> group (both for adding parent_group and channels)
+	case pr.SubjectType == auth.GroupType && pr.ObjectType == auth.GroupType:
+		return groupPreConditions(pr), nil
+
+	// 4.) group (channel) -> thing
+	case pr.SubjectType == auth.GroupType && pr.ObjectType == auth.ThingType:
+		return channelThingPreCondition(pr)
+
+	// 5.) user -> domain
+	case pr.SubjectType == auth.UserType && pr.ObjectType == auth.DomainType:
+		return pa.userDomainPreConditions(ctx, pr)
+
+	// Check thing and group not belongs to other domain before adding to domain
+	case pr.SubjectType == auth.DomainType && pr.Relation == auth.DomainRelation && (pr.ObjectType == auth.ThingType || pr.ObjectType == auth.GroupType):
+		return []precondition{
+			{filter: filter{objectType: pr.ObjectType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType}},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// userEntityPreConditions checks that:
+// - USER has no relation with the entity,
+// - USER has any relation with the DOMAIN, unless USER is super admin,
+// - new entity has no DOMAIN, and the existing one belongs to the DOMAIN.
+func (pa *policyAgent) userEntityPreConditions(ctx context.Context, pr auth.PolicyReq, newKinds ...string) ([]precondition, error) {
+	preconds := []precondition{
+		{filter: filter{objectType: pr.ObjectType, objectID: pr.Object, subjectType: auth.UserType, subjectID: pr.Subject}},
+	}
+
+	if !pa.isSuperAdmin(ctx, pr) {
+		preconds = append(preconds, precondition{
+			mustMatch: true,
+			filter:    filter{objectType: auth.DomainType, objectID: pr.Domain, subjectType: auth.UserType, subjectID: pr.Subject},
+		})
+	}
+
+	for _, kind := range newKinds {
+		if pr.ObjectKind == kind {
+			return append(preconds, precondition{
+				filter: filter{objectType: pr.ObjectType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType},
+			}), nil
+		}
+	}
+
+	return append(preconds, precondition{
+		mustMatch: true,
+		filter:    filter{objectType: pr.ObjectType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType, subjectID: pr.Domain},
+	}), nil
+}
+
+func groupPreConditions(pr auth.PolicyReq) []precondition {
+	// - PARENT_GROUP (subject) with DOMAIN RELATION to DOMAIN
+	preconds := []precondition{
+		{
+			mustMatch: true,
+			filter:    filter{objectType: auth.GroupType, objectID: pr.Subject, relation: auth.DomainRelation, subjectType: auth.DomainType, subjectID: pr.Domain},
+		},
+	}
+	// - CHILD_GROUP (object) without PARENT_GROUP
+	if pr.ObjectKind != auth.ChannelsKind {
+		preconds = append(preconds, precondition{
+			filter: filter{objectType: auth.GroupType, objectID: pr.Object, relation: auth.ParentGroupRelation, subjectType: auth.GroupType},
+		})
+	}
+	switch {
+	// - NEW CHILD_GROUP (object) without DOMAIN RELATION to ANY DOMAIN
+	case pr.ObjectType == auth.GroupType && pr.ObjectKind == auth.NewGroupKind:
+		preconds = append(preconds, precondition{
+			filter: filter{objectType: auth.GroupType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType},
+		})
+	default:
+		// - CHILD_GROUP (object) with DOMAIN RELATION to DOMAIN
+		preconds = append(preconds, precondition{
+			mustMatch: true,
+			filter:    filter{objectType: auth.GroupType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType, subjectID: pr.Domain},
+		})
+	}
+
+	return preconds
+}
+
+func channelThingPreCondition(pr auth.PolicyReq) ([]precondition, error) {
+	if pr.SubjectKind != auth.ChannelsKind {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errInvalidSubject)
+	}
+
+	return []precondition{
+		// - GROUP (channel) with DOMAIN RELATION to DOMAIN
+		{
+			mustMatch: true,
+			filter:    filter{objectType: auth.GroupType, objectID: pr.Subject, relation: auth.DomainRelation, subjectType: auth.DomainType, subjectID: pr.Domain},
+		},
+		// - NO GROUP with PARENT_GROUP RELATION with GROUP (channel)
+		{
+			filter: filter{objectType: auth.GroupType, relation: auth.ParentGroupRelation, subjectType: auth.GroupType, subjectID: pr.Subject},
+		},
+		// - THING with DOMAIN RELATION to DOMAIN
+		{
+			mustMatch: true,
+			filter:    filter{objectType: auth.ThingType, objectID: pr.Object, relation: auth.DomainRelation, subjectType: auth.DomainType, subjectID: pr.Domain},
+		},
+	}, nil
+}
+
+func (pa *policyAgent) userDomainPreConditions(ctx context.Context, pr auth.PolicyReq) ([]precondition, error) {
+	if pa.isSuperAdmin(ctx, pr) {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errSuperAdminDomain)
+	}
+
+	// user should not have any relation with domain.
+	return []precondition{
+		{filter: filter{objectType: auth.DomainType, objectID: pr.Object, subjectType: auth.UserType, subjectID: pr.Subject}},
+	}, nil
+}
+
+func (pa *policyAgent) isSuperAdmin(ctx context.Context, pr auth.PolicyReq) bool {
+	err := pa.CheckPolicy(ctx, auth.PolicyReq{
+		Subject:     pr.Subject,
+		SubjectType: pr.SubjectType,
+		Permission:  auth.AdminPermission,
+		Object:      auth.MagistralaObject,
+		ObjectType:  auth.PlatformType,
+	})
+
+	return err == nil
+}
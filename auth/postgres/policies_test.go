@@ -0,0 +1,561 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schemaFile = "../../docker/spicedb/schema.zed"
+
+var relationships = []auth.PolicyReq{
+	{SubjectType: auth.UserType, Subject: "superadmin", Relation: auth.AdministratorRelation, ObjectType: auth.PlatformType, Object: auth.MagistralaObject},
+	{SubjectType: auth.PlatformType, Subject: auth.MagistralaObject, Relation: auth.PlatformRelation, ObjectType: auth.DomainType, Object: "domain"},
+	{SubjectType: auth.UserType, Subject: "admin", Relation: auth.AdministratorRelation, ObjectType: auth.DomainType, Object: "domain"},
+	{SubjectType: auth.UserType, Subject: "member", Relation: auth.MemberRelation, ObjectType: auth.DomainType, Object: "domain"},
+	{SubjectType: auth.UserType, Subject: "guest", Relation: auth.GuestRelation, ObjectType: auth.DomainType, Object: "domain"},
+	{SubjectType: auth.DomainType, Subject: "domain", Relation: auth.DomainRelation, ObjectType: auth.GroupType, Object: "parent"},
+	{SubjectType: auth.UserType, Subject: "groupadmin", Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: "parent"},
+	{SubjectType: auth.DomainType, Subject: "domain", Relation: auth.DomainRelation, ObjectType: auth.GroupType, Object: "child"},
+	{SubjectType: auth.GroupType, Subject: "parent", Relation: auth.ParentGroupRelation, ObjectType: auth.GroupType, Object: "child"},
+	{SubjectType: auth.UserType, Subject: "editor", Relation: auth.EditorRelation, ObjectType: auth.GroupType, Object: "child"},
+	{SubjectType: auth.DomainType, Subject: "domain", Relation: auth.DomainRelation, ObjectType: auth.ThingType, Object: "thing1"},
+	{SubjectType: auth.GroupType, Subject: "child", Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: "thing1"},
+	{SubjectType: auth.DomainType, Subject: "domain", Relation: auth.DomainRelation, ObjectType: auth.ThingType, Object: "thing2"},
+	{SubjectType: auth.UserType, Subject: "member", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: "thing2"},
+}
+
+func newPolicyAgent(t testing.TB) auth.PolicyAgent {
+	schema, err := os.ReadFile(schemaFile)
+	require.Nil(t, err, fmt.Sprintf("failed to read schema: %s", err))
+	pa, err := postgres.NewPolicyAgent(database, string(schema))
+	require.Nil(t, err, fmt.Sprintf("failed to create policy agent: %s", err))
+
+	return pa
+}
+
+func saveRelationships(t *testing.T) {
+	for _, r := range relationships {
+		_, err := db.Exec("INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id) VALUES ($1, $2, $3, $4, $5)",
+			r.ObjectType, r.Object, r.Relation, r.SubjectType, r.Subject)
+		require.Nil(t, err, fmt.Sprintf("failed to save relationship: %s", err))
+	}
+}
+
+func ids(prs []auth.PolicyRes, objects bool) []string {
+	ids := []string{}
+	for _, pr := range prs {
+		if objects {
+			ids = append(ids, pr.Object)
+			continue
+		}
+		ids = append(ids, pr.Subject)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func TestNewPolicyAgent(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema string
+		err    bool
+	}{
+		{
+			desc:   "create policy agent with valid schema",
+			schema: "definition user {}\ndefinition thing {\n relation administrator: user\n relation editor: user\n permission edit = administrator + editor - administrator\n}",
+		},
+		{
+			desc:   "create policy agent with unknown relation type",
+			schema: "definition thing {\n relation administrator: user\n}",
+			err:    true,
+		},
+		{
+			desc:   "create policy agent with unknown permission term",
+			schema: "definition user {}\ndefinition thing {\n relation administrator: user\n permission edit = administrator + editor\n}",
+			err:    true,
+		},
+		{
+			desc:   "create policy agent with intersection",
+			schema: "definition user {}\ndefinition thing {\n relation administrator: user\n relation editor: user\n permission edit = administrator & editor\n}",
+			err:    true,
+		},
+		{
+			desc:   "create policy agent with wildcard",
+			schema: "definition user {}\ndefinition thing {\n relation viewer: user:*\n}",
+			err:    true,
+		},
+		{
+			desc:   "create policy agent with reference to permission with exclusion",
+			schema: "definition user {}\ndefinition thing {\n relation administrator: user\n relation editor: user\n permission edit_only = editor - administrator\n permission view = edit_only\n}",
+			err:    true,
+		},
+		{
+			desc:   "create policy agent with malformed schema",
+			schema: "definition thing {",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := postgres.NewPolicyAgent(database, tc.schema)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+	}
+}
+
+func TestPolicyAgentCheckPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	cases := []struct {
+		desc string
+		pr   auth.PolicyReq
+		err  error
+	}{
+		{
+			desc: "check domain administrator on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check platform administrator on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "superadmin", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check parent group administrator on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "groupadmin", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check group editor edit on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Permission: auth.EditPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check group editor admin on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "check permission with exclusion for included subject",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Permission: "edit_only", ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check permission with exclusion for excluded subject",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: "edit_only", ObjectType: auth.ThingType, Object: "thing1"},
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "check domain member view on thing",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "member", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "check domain guest membership",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "guest", Permission: auth.MembershipPermission, ObjectType: auth.DomainType, Object: "domain"},
+		},
+		{
+			desc: "check group publish on connected thing",
+			pr:   auth.PolicyReq{SubjectType: auth.GroupType, Subject: "child", Permission: auth.PublishPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "check platform administrator on platform",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "superadmin", Permission: auth.AdminPermission, ObjectType: auth.PlatformType, Object: auth.MagistralaObject},
+		},
+		{
+			desc: "check domain administrator on platform",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.AdminPermission, ObjectType: auth.PlatformType, Object: auth.MagistralaObject},
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "check unknown permission",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: inValid, ObjectType: auth.ThingType, Object: "thing1"},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := pa.CheckPolicy(context.Background(), tc.pr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPolicyAgentExplainPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	cases := []struct {
+		desc     string
+		pr       auth.PolicyReq
+		allowed  bool
+		path     []auth.PolicyStep
+		required auth.PolicyStep
+		err      error
+	}{
+		{
+			desc:    "explain direct administrator on thing",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "member", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing2"},
+			allowed: true,
+			path: []auth.PolicyStep{
+				{SubjectType: auth.UserType, Subject: "member", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: "thing2"},
+			},
+		},
+		{
+			desc:    "explain parent group administrator on thing",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "groupadmin", Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			allowed: true,
+			path: []auth.PolicyStep{
+				{SubjectType: auth.UserType, Subject: "groupadmin", Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: "parent"},
+				{SubjectType: auth.GroupType, Subject: "parent", Relation: auth.ParentGroupRelation, ObjectType: auth.GroupType, Object: "child"},
+				{SubjectType: auth.GroupType, Subject: "child", Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: "thing1"},
+			},
+		},
+		{
+			desc:     "explain denied view on thing",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Subject: "member", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			required: auth.PolicyStep{SubjectType: auth.UserType, Subject: "member", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "explain permission with exclusion for excluded subject",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: "edit_only", ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "explain unknown permission",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: inValid, ObjectType: auth.ThingType, Object: "thing1"},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		exp, err := pa.ExplainPolicy(context.Background(), tc.pr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.allowed, exp.Allowed, fmt.Sprintf("%s: expected allowed %t got %t\n", tc.desc, tc.allowed, exp.Allowed))
+		if tc.allowed {
+			assert.Equal(t, tc.path, exp.Path, fmt.Sprintf("%s: expected path %v got %v\n", tc.desc, tc.path, exp.Path))
+		}
+		if tc.required != (auth.PolicyStep{}) {
+			assert.Contains(t, exp.Required, tc.required, fmt.Sprintf("%s: expected required %v in %v\n", tc.desc, tc.required, exp.Required))
+		}
+	}
+}
+
+func TestPolicyAgentRetrieveObjects(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	cases := []struct {
+		desc    string
+		pr      auth.PolicyReq
+		objects []string
+	}{
+		{
+			desc:    "retrieve things viewed by domain administrator",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.ViewPermission, ObjectType: auth.ThingType},
+			objects: []string{"thing1", "thing2"},
+		},
+		{
+			desc:    "retrieve things viewed by group editor",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Permission: auth.ViewPermission, ObjectType: auth.ThingType},
+			objects: []string{"thing1"},
+		},
+		{
+			desc:    "retrieve things viewed by thing administrator",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "member", Permission: auth.ViewPermission, ObjectType: auth.ThingType},
+			objects: []string{"thing2"},
+		},
+		{
+			desc:    "retrieve groups with permission with exclusion",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: "groupadmin", Permission: "edit_only", ObjectType: auth.GroupType},
+			objects: []string{},
+		},
+		{
+			desc:    "retrieve things of unknown subject",
+			pr:      auth.PolicyReq{SubjectType: auth.UserType, Subject: inValid, Permission: auth.ViewPermission, ObjectType: auth.ThingType},
+			objects: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		objects, err := pa.RetrieveAllObjects(context.Background(), tc.pr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.objects, ids(objects, true), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.objects, objects))
+		count, err := pa.RetrieveAllObjectsCount(context.Background(), tc.pr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.objects)), count, fmt.Sprintf("%s: expected count %d got %d\n", tc.desc, len(tc.objects), count))
+	}
+
+	pr := auth.PolicyReq{SubjectType: auth.UserType, Subject: "superadmin", Permission: auth.ViewPermission, ObjectType: auth.ThingType}
+	page, token, err := pa.RetrieveObjects(context.Background(), pr, "", 1)
+	assert.Nil(t, err, fmt.Sprintf("retrieve first page: unexpected error %s", err))
+	assert.Equal(t, []string{"thing1"}, ids(page, true), "retrieve first page: unexpected objects")
+	page, token, err = pa.RetrieveObjects(context.Background(), pr, token, 1)
+	assert.Nil(t, err, fmt.Sprintf("retrieve second page: unexpected error %s", err))
+	assert.Equal(t, []string{"thing2"}, ids(page, true), "retrieve second page: unexpected objects")
+	page, token, err = pa.RetrieveObjects(context.Background(), pr, token, 1)
+	assert.Nil(t, err, fmt.Sprintf("retrieve last page: unexpected error %s", err))
+	assert.Empty(t, page, "retrieve last page: unexpected objects")
+	assert.Empty(t, token, "retrieve last page: unexpected page token")
+}
+
+func TestPolicyAgentRetrieveSubjects(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	cases := []struct {
+		desc     string
+		pr       auth.PolicyReq
+		subjects []string
+	}{
+		{
+			desc:     "retrieve thing administrators",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Permission: auth.AdminPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			subjects: []string{"admin", "groupadmin", "superadmin"},
+		},
+		{
+			desc:     "retrieve thing editors",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Permission: auth.EditPermission, ObjectType: auth.ThingType, Object: "thing1"},
+			subjects: []string{"admin", "editor", "groupadmin", "superadmin"},
+		},
+		{
+			desc:     "retrieve subjects of permission with exclusion",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Permission: "edit_only", ObjectType: auth.ThingType, Object: "thing1"},
+			subjects: []string{"editor"},
+		},
+		{
+			desc:     "retrieve domain members",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Permission: auth.MembershipPermission, ObjectType: auth.DomainType, Object: "domain"},
+			subjects: []string{"admin", "guest", "member", "superadmin"},
+		},
+		{
+			desc:     "retrieve subjects of unknown object",
+			pr:       auth.PolicyReq{SubjectType: auth.UserType, Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: inValid},
+			subjects: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		subjects, err := pa.RetrieveAllSubjects(context.Background(), tc.pr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.subjects, ids(subjects, false), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.subjects, subjects))
+		count, err := pa.RetrieveAllSubjectsCount(context.Background(), tc.pr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.subjects)), count, fmt.Sprintf("%s: expected count %d got %d\n", tc.desc, len(tc.subjects), count))
+	}
+}
+
+func TestPolicyAgentRetrievePermissions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	filter := []string{auth.AdminPermission, auth.EditPermission, auth.ViewPermission, auth.MembershipPermission, auth.DeletePermission, auth.SharePermission}
+	cases := []struct {
+		desc        string
+		pr          auth.PolicyReq
+		permissions auth.Permissions
+	}{
+		{
+			desc:        "retrieve group editor permissions",
+			pr:          auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", ObjectType: auth.GroupType, Object: "child"},
+			permissions: auth.Permissions{auth.EditPermission, auth.ViewPermission, auth.MembershipPermission, auth.SharePermission},
+		},
+		{
+			desc:        "retrieve domain administrator permissions",
+			pr:          auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", ObjectType: auth.GroupType, Object: "child"},
+			permissions: auth.Permissions{auth.AdminPermission, auth.EditPermission, auth.ViewPermission, auth.MembershipPermission, auth.DeletePermission, auth.SharePermission},
+		},
+		{
+			desc:        "retrieve unknown subject permissions",
+			pr:          auth.PolicyReq{SubjectType: auth.UserType, Subject: inValid, ObjectType: auth.GroupType, Object: "child"},
+			permissions: auth.Permissions{},
+		},
+	}
+
+	for _, tc := range cases {
+		permissions, err := pa.RetrievePermissions(context.Background(), tc.pr, filter)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.permissions, permissions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.permissions, permissions))
+	}
+}
+
+func TestPolicyAgentAddPolicies(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	cases := []struct {
+		desc string
+		prs  []auth.PolicyReq
+		err  error
+	}{
+		{
+			desc: "add new thing of domain administrator",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: "admin", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: "thing3", ObjectKind: auth.NewThingKind, Domain: "domain"},
+				{SubjectType: auth.DomainType, Subject: "domain", Relation: auth.DomainRelation, ObjectType: auth.ThingType, Object: "thing3"},
+			},
+		},
+		{
+			desc: "add new thing of user outside of the domain",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: inValid, Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: "thing4", ObjectKind: auth.NewThingKind, Domain: "domain"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "add thing to the second domain",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.DomainType, Subject: inValid, Relation: auth.DomainRelation, ObjectType: auth.ThingType, Object: "thing3"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "add user to domain",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: "user", Relation: auth.MemberRelation, ObjectType: auth.DomainType, Object: "domain"},
+			},
+		},
+		{
+			desc: "add existing user to domain",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: "user", Relation: auth.MemberRelation, ObjectType: auth.DomainType, Object: "domain"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "add platform administrator to domain",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: "superadmin", Relation: auth.MemberRelation, ObjectType: auth.DomainType, Object: "domain"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "add policy with unknown relation",
+			prs: []auth.PolicyReq{
+				{SubjectType: auth.UserType, Subject: "admin", Relation: inValid, ObjectType: auth.ThingType, Object: "thing1"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "add empty policies",
+			prs:  []auth.PolicyReq{},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := pa.AddPolicies(context.Background(), tc.prs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+	}
+
+	err := pa.CheckPolicy(context.Background(), auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.DeletePermission, ObjectType: auth.ThingType, Object: "thing3"})
+	assert.Nil(t, err, fmt.Sprintf("check added policy: unexpected error %s", err))
+}
+
+func TestPolicyAgentDeletePolicies(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(t, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(t)
+	saveRelationships(t)
+
+	editor := auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Relation: auth.EditorRelation, ObjectType: auth.GroupType, Object: "child"}
+	err := pa.DeletePolicies(context.Background(), []auth.PolicyReq{editor})
+	assert.Nil(t, err, fmt.Sprintf("delete policies: unexpected error %s", err))
+	err = pa.CheckPolicy(context.Background(), auth.PolicyReq{SubjectType: auth.UserType, Subject: "editor", Permission: auth.EditPermission, ObjectType: auth.ThingType, Object: "thing1"})
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("check deleted policy: expected %v got %v\n", svcerr.ErrAuthorization, err))
+
+	err = pa.DeletePolicyFilter(context.Background(), auth.PolicyReq{ObjectType: auth.ThingType, Object: "thing2"})
+	assert.Nil(t, err, fmt.Sprintf("delete policy filter: unexpected error %s", err))
+	things, err := pa.RetrieveAllObjects(context.Background(), auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.ViewPermission, ObjectType: auth.ThingType})
+	assert.Nil(t, err, fmt.Sprintf("retrieve things: unexpected error %s", err))
+	assert.Equal(t, []string{"thing1"}, ids(things, true), fmt.Sprintf("retrieve things: unexpected things %v", things))
+}
+
+// BenchmarkPolicyAgentCheckPolicy checks the permissions of the domain and
+// platform administrators, who have access to all the seeded things.
+func BenchmarkPolicyAgentCheckPolicy(b *testing.B) {
+	b.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM relationships")
+		require.Nil(b, err, fmt.Sprintf("clean relationships unexpected error: %s", err))
+	})
+	pa := newPolicyAgent(b)
+
+	const groups, things = 1000, 10000
+	seed := []string{
+		`INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id) VALUES
+			('platform', 'magistrala', 'administrator', 'user', 'superadmin'),
+			('domain', 'domain', 'platform', 'platform', 'magistrala'),
+			('domain', 'domain', 'administrator', 'user', 'admin')`,
+		fmt.Sprintf(`INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id)
+			SELECT 'group', 'group' || i, 'domain', 'domain', 'domain' FROM generate_series(1, %d) AS i`, groups),
+		fmt.Sprintf(`INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id)
+			SELECT 'thing', 'thing' || i, 'domain', 'domain', 'domain' FROM generate_series(1, %d) AS i`, things),
+		fmt.Sprintf(`INSERT INTO relationships (object_type, object_id, relation, subject_type, subject_id)
+			SELECT 'thing', 'thing' || i, 'group', 'group', 'group' || (i %% %d + 1) FROM generate_series(1, %d) AS i`, groups, things),
+	}
+	for _, q := range seed {
+		_, err := db.Exec(q)
+		require.Nil(b, err, fmt.Sprintf("seed relationships unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc string
+		pr   auth.PolicyReq
+	}{
+		{
+			desc: "domain administrator",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "admin", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+		{
+			desc: "platform administrator",
+			pr:   auth.PolicyReq{SubjectType: auth.UserType, Subject: "superadmin", Permission: auth.ViewPermission, ObjectType: auth.ThingType, Object: "thing1"},
+		},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.desc, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := pa.CheckPolicy(context.Background(), tc.pr); err != nil {
+					b.Fatalf("check policy unexpected error: %s", err)
+				}
+			}
+		})
+	}
+}
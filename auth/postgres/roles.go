@@ -0,0 +1,281 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+var _ auth.RolesRepository = (*roleRepo)(nil)
+
+type roleRepo struct {
+	db postgres.Database
+}
+
+// NewRoleRepository instantiates a PostgreSQL
+// implementation of Role repository.
+func NewRoleRepository(db postgres.Database) auth.RolesRepository {
+	return &roleRepo{
+		db: db,
+	}
+}
+
+func (repo roleRepo) Save(ctx context.Context, r auth.Role) (auth.Role, error) {
+	q := `INSERT INTO roles (id, domain_id, name, permissions, created_by, created_at)
+	VALUES (:id, :domain_id, :name, :permissions, :created_by, :created_at)
+	RETURNING id, domain_id, name, permissions, created_by, created_at, updated_by, updated_at`
+
+	dbr, err := toDBRole(r)
+	if err != nil {
+		return auth.Role{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return auth.Role{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	row.Next()
+	dbr = dbRole{}
+	if err := row.StructScan(&dbr); err != nil {
+		return auth.Role{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (repo roleRepo) RetrieveByID(ctx context.Context, domainID, id string) (auth.Role, error) {
+	q := `SELECT id, domain_id, name, permissions, created_by, created_at, updated_by, updated_at
+	FROM roles WHERE domain_id = $1 AND id = $2`
+
+	dbr := dbRole{}
+	if err := repo.db.QueryRowxContext(ctx, q, domainID, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.Role{}, repoerr.ErrNotFound
+		}
+		return auth.Role{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (repo roleRepo) RetrieveAll(ctx context.Context, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	q := fmt.Sprintf(`SELECT id, domain_id, name, permissions, created_by, created_at, updated_by, updated_at
+	FROM roles WHERE domain_id = $1 ORDER BY name LIMIT %d OFFSET %d`, pm.Limit, pm.Offset)
+
+	rows, err := repo.db.QueryxContext(ctx, q, domainID)
+	if err != nil {
+		return auth.RolesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var roles []auth.Role
+	for rows.Next() {
+		dbr := dbRole{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return auth.RolesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		roles = append(roles, toRole(dbr))
+	}
+
+	var total uint64
+	if err := repo.db.QueryRowxContext(ctx, `SELECT COUNT(*) FROM roles WHERE domain_id = $1`, domainID).Scan(&total); err != nil {
+		return auth.RolesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.RolesPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Roles:  roles,
+	}, nil
+}
+
+func (repo roleRepo) Update(ctx context.Context, r auth.Role) (auth.Role, error) {
+	q := `UPDATE roles SET name = :name, permissions = :permissions, updated_by = :updated_by, updated_at = :updated_at
+	WHERE domain_id = :domain_id AND id = :id
+	RETURNING id, domain_id, name, permissions, created_by, created_at, updated_by, updated_at`
+
+	dbr, err := toDBRole(r)
+	if err != nil {
+		return auth.Role{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return auth.Role{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return auth.Role{}, repoerr.ErrNotFound
+	}
+	dbr = dbRole{}
+	if err := row.StructScan(&dbr); err != nil {
+		return auth.Role{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (repo roleRepo) Delete(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM roles WHERE domain_id = $1 AND id = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, domainID, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo roleRepo) SaveAssignments(ctx context.Context, ras ...auth.RoleAssignment) error {
+	q := `INSERT INTO role_assignments (role_id, entity_type, entity_id, user_id)
+	VALUES (:role_id, :entity_type, :entity_id, :user_id)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBRoleAssignments(ras)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo roleRepo) RetrieveAssignments(ctx context.Context, roleID string) ([]auth.RoleAssignment, error) {
+	q := `SELECT role_id, entity_type, entity_id, user_id FROM role_assignments WHERE role_id = $1`
+
+	return repo.retrieveAssignments(ctx, q, roleID)
+}
+
+func (repo roleRepo) RetrieveEntityAssignments(ctx context.Context, entityType, entityID string) ([]auth.RoleAssignment, error) {
+	q := `SELECT role_id, entity_type, entity_id, user_id FROM role_assignments WHERE entity_type = $1 AND entity_id = $2`
+
+	return repo.retrieveAssignments(ctx, q, entityType, entityID)
+}
+
+func (repo roleRepo) RemoveAssignments(ctx context.Context, ras ...auth.RoleAssignment) (err error) {
+	q := `DELETE FROM role_assignments
+	WHERE role_id = :role_id AND entity_type = :entity_type AND entity_id = :entity_id AND user_id = :user_id`
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	for _, dbra := range toDBRoleAssignments(ras) {
+		if _, err := tx.NamedExecContext(ctx, q, dbra); err != nil {
+			return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo roleRepo) RemoveUserAssignments(ctx context.Context, domainID, userID string) ([]auth.RoleAssignment, error) {
+	q := `DELETE FROM role_assignments ra USING roles r
+	WHERE ra.role_id = r.id AND r.domain_id = $1 AND ra.user_id = $2
+	RETURNING ra.role_id, ra.entity_type, ra.entity_id, ra.user_id`
+
+	return repo.retrieveAssignments(ctx, q, domainID, userID)
+}
+
+func (repo roleRepo) retrieveAssignments(ctx context.Context, q string, args ...interface{}) ([]auth.RoleAssignment, error) {
+	rows, err := repo.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var ras []auth.RoleAssignment
+	for rows.Next() {
+		dbra := dbRoleAssignment{}
+		if err := rows.StructScan(&dbra); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		ras = append(ras, auth.RoleAssignment(dbra))
+	}
+
+	return ras, nil
+}
+
+type dbRole struct {
+	ID          string           `db:"id"`
+	DomainID    string           `db:"domain_id"`
+	Name        string           `db:"name"`
+	Permissions pgtype.TextArray `db:"permissions"`
+	CreatedBy   string           `db:"created_by"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedBy   sql.NullString   `db:"updated_by"`
+	UpdatedAt   sql.NullTime     `db:"updated_at"`
+}
+
+func toDBRole(r auth.Role) (dbRole, error) {
+	var permissions pgtype.TextArray
+	if err := permissions.Set(r.Permissions); err != nil {
+		return dbRole{}, err
+	}
+
+	return dbRole{
+		ID:          r.ID,
+		DomainID:    r.DomainID,
+		Name:        r.Name,
+		Permissions: permissions,
+		CreatedBy:   r.CreatedBy,
+		CreatedAt:   r.CreatedAt,
+		UpdatedBy:   sql.NullString{String: r.UpdatedBy, Valid: r.UpdatedBy != ""},
+		UpdatedAt:   sql.NullTime{Time: r.UpdatedAt, Valid: !r.UpdatedAt.IsZero()},
+	}, nil
+}
+
+func toRole(r dbRole) auth.Role {
+	permissions := []string{}
+	for _, e := range r.Permissions.Elements {
+		permissions = append(permissions, e.String)
+	}
+
+	return auth.Role{
+		ID:          r.ID,
+		DomainID:    r.DomainID,
+		Name:        r.Name,
+		Permissions: permissions,
+		CreatedBy:   r.CreatedBy,
+		CreatedAt:   r.CreatedAt,
+		UpdatedBy:   r.UpdatedBy.String,
+		UpdatedAt:   r.UpdatedAt.Time,
+	}
+}
+
+type dbRoleAssignment struct {
+	RoleID     string `db:"role_id"`
+	EntityType string `db:"entity_type"`
+	EntityID   string `db:"entity_id"`
+	UserID     string `db:"user_id"`
+}
+
+func toDBRoleAssignments(ras []auth.RoleAssignment) []dbRoleAssignment {
+	var dbras []dbRoleAssignment
+	for _, ra := range ras {
+		dbras = append(dbras, dbRoleAssignment(ra))
+	}
+
+	return dbras
+}
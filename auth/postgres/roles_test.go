@@ -0,0 +1,316 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveRoleDomain(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM domains")
+		require.Nil(t, err, fmt.Sprintf("clean domains unexpected error: %s", err))
+	})
+
+	domain := auth.Domain{
+		ID:        domainID,
+		Name:      "test",
+		Alias:     "test",
+		CreatedBy: userID,
+		Status:    auth.EnabledStatus,
+	}
+	_, err := postgres.NewDomainRepository(database).Save(context.Background(), domain)
+	require.Nil(t, err, fmt.Sprintf("failed to save domain %s", domain.ID))
+}
+
+func newRole(name string) auth.Role {
+	return auth.Role{
+		ID:          testsutil.GenerateUUID(&testing.T{}),
+		DomainID:    domainID,
+		Name:        name,
+		Permissions: []string{"things:view", "channels:publish"},
+		CreatedBy:   userID,
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSaveRole(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	role := newRole("operator")
+
+	cases := []struct {
+		desc string
+		role auth.Role
+		err  error
+	}{
+		{
+			desc: "save role successfully",
+			role: role,
+			err:  nil,
+		},
+		{
+			desc: "save role with existing name",
+			role: auth.Role{
+				ID:          testsutil.GenerateUUID(t),
+				DomainID:    domainID,
+				Name:        role.Name,
+				Permissions: role.Permissions,
+				CreatedBy:   userID,
+				CreatedAt:   role.CreatedAt,
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "save role in non-existing domain",
+			role: auth.Role{
+				ID:          testsutil.GenerateUUID(t),
+				DomainID:    testsutil.GenerateUUID(t),
+				Name:        "viewer",
+				Permissions: role.Permissions,
+				CreatedBy:   userID,
+				CreatedAt:   role.CreatedAt,
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.Save(context.Background(), tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.role, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.role, r))
+		}
+	}
+}
+
+func TestRetrieveRole(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	role, err := repo.Save(context.Background(), newRole("operator"))
+	require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		response auth.Role
+		err      error
+	}{
+		{
+			desc:     "retrieve existing role",
+			domainID: domainID,
+			id:       role.ID,
+			response: role,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve role of other domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       role.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve non-existing role",
+			domainID: domainID,
+			id:       testsutil.GenerateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
+		assert.Equal(t, tc.response, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, r))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAllRoles(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	var roles []auth.Role
+	for i := 0; i < 10; i++ {
+		role, err := repo.Save(context.Background(), newRole(fmt.Sprintf("role-%d", i)))
+		require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+		roles = append(roles, role)
+	}
+
+	cases := []struct {
+		desc     string
+		domainID string
+		page     auth.Page
+		response auth.RolesPage
+	}{
+		{
+			desc:     "retrieve all roles",
+			domainID: domainID,
+			page:     auth.Page{Offset: 0, Limit: 10},
+			response: auth.RolesPage{Total: 10, Offset: 0, Limit: 10, Roles: roles},
+		},
+		{
+			desc:     "retrieve roles with offset and limit",
+			domainID: domainID,
+			page:     auth.Page{Offset: 5, Limit: 2},
+			response: auth.RolesPage{Total: 10, Offset: 5, Limit: 2, Roles: roles[5:7]},
+		},
+		{
+			desc:     "retrieve roles of other domain",
+			domainID: testsutil.GenerateUUID(t),
+			page:     auth.Page{Offset: 0, Limit: 10},
+			response: auth.RolesPage{Total: 0, Offset: 0, Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		rp, err := repo.RetrieveAll(context.Background(), tc.domainID, tc.page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.response, rp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, rp))
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	role, err := repo.Save(context.Background(), newRole("operator"))
+	require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+	other, err := repo.Save(context.Background(), newRole("viewer"))
+	require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+
+	updated := role
+	updated.Name = "editor"
+	updated.Permissions = []string{"things:edit"}
+	updated.UpdatedBy = userID
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	duplicate := updated
+	duplicate.ID = other.ID
+
+	missing := updated
+	missing.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		role auth.Role
+		err  error
+	}{
+		{
+			desc: "update role successfully",
+			role: updated,
+			err:  nil,
+		},
+		{
+			desc: "update role with existing name",
+			role: duplicate,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "update non-existing role",
+			role: missing,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.Update(context.Background(), tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.role, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.role, r))
+		}
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	role, err := repo.Save(context.Background(), newRole("operator"))
+	require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+	err = repo.SaveAssignments(context.Background(), auth.RoleAssignment{
+		RoleID:     role.ID,
+		EntityType: auth.DomainType,
+		EntityID:   domainID,
+		UserID:     userID,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to save role assignment: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete existing role",
+			id:   role.ID,
+			err:  nil,
+		},
+		{
+			desc: "delete non-existing role",
+			id:   role.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Delete(context.Background(), domainID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ras, err := repo.RetrieveAssignments(context.Background(), role.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve assignments unexpected error: %s", err))
+	assert.Empty(t, ras, "expected role assignments to be removed with the role")
+}
+
+func TestRoleAssignments(t *testing.T) {
+	saveRoleDomain(t)
+	repo := postgres.NewRoleRepository(database)
+
+	role, err := repo.Save(context.Background(), newRole("operator"))
+	require.Nil(t, err, fmt.Sprintf("failed to save role: %s", err))
+
+	groupID := testsutil.GenerateUUID(t)
+	otherUserID := testsutil.GenerateUUID(t)
+	groupAssignment := auth.RoleAssignment{RoleID: role.ID, EntityType: auth.GroupType, EntityID: groupID, UserID: userID}
+	otherAssignment := auth.RoleAssignment{RoleID: role.ID, EntityType: auth.GroupType, EntityID: groupID, UserID: otherUserID}
+	domainAssignment := auth.RoleAssignment{RoleID: role.ID, EntityType: auth.DomainType, EntityID: domainID, UserID: userID}
+
+	err = repo.SaveAssignments(context.Background(), groupAssignment, otherAssignment, domainAssignment)
+	assert.Nil(t, err, fmt.Sprintf("save assignments unexpected error: %s", err))
+
+	err = repo.SaveAssignments(context.Background(), groupAssignment)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing assignment: expected %s got %s\n", repoerr.ErrConflict, err))
+
+	ras, err := repo.RetrieveAssignments(context.Background(), role.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve assignments unexpected error: %s", err))
+	assert.ElementsMatch(t, []auth.RoleAssignment{groupAssignment, otherAssignment, domainAssignment}, ras)
+
+	ras, err = repo.RetrieveEntityAssignments(context.Background(), auth.GroupType, groupID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve entity assignments unexpected error: %s", err))
+	assert.ElementsMatch(t, []auth.RoleAssignment{groupAssignment, otherAssignment}, ras)
+
+	err = repo.RemoveAssignments(context.Background(), otherAssignment)
+	assert.Nil(t, err, fmt.Sprintf("remove assignments unexpected error: %s", err))
+
+	ras, err = repo.RemoveUserAssignments(context.Background(), domainID, userID)
+	assert.Nil(t, err, fmt.Sprintf("remove user assignments unexpected error: %s", err))
+	assert.ElementsMatch(t, []auth.RoleAssignment{groupAssignment, domainAssignment}, ras)
+
+	ras, err = repo.RetrieveAssignments(context.Background(), role.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve assignments unexpected error: %s", err))
+	assert.Empty(t, ras, "expected all role assignments to be removed")
+}
@@ -0,0 +1,371 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// excludedPrefix marks the permission holding the subjects excluded from the
+// permission with the same name. Prefix can't be a part of the identifier.
+const excludedPrefix = "-"
+
+var (
+	errInvalidSchema = errors.New("invalid policy schema")
+	errUnknownName   = errors.New("unknown relation or permission")
+
+	identifier     = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	tokenPattern   = regexp.MustCompile(`->|[a-z][a-z0-9_]*|[{}:|=+\-#*&]`)
+	commentPattern = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+)
+
+// term is a relation or a permission of the object, or the permission of
+// the subjects of the object relation (arrow) when the permission is set.
+type term struct {
+	relation   string
+	permission string
+}
+
+type permission struct {
+	union   []term
+	exclude []term
+}
+
+type definition struct {
+	relations   map[string][]string
+	permissions map[string]permission
+}
+
+func (d definition) has(name string) bool {
+	if _, ok := d.relations[name]; ok {
+		return true
+	}
+	_, ok := d.permissions[name]
+	return ok
+}
+
+// schema is the subset of the SpiceDB schema language used by the
+// Magistrala schema: relations to plain subject types, and permissions
+// made of unions of relations, permissions and arrows, with optional
+// exclusions.
+type schema map[string]definition
+
+// rule is a single step of the permission evaluation. Subject having
+// the source relation or permission on the object of the subject type
+// has the target permission on the object of the object type: the same
+// object if the relation is empty, or the objects the source object
+// is related to with the relation otherwise.
+type rule struct {
+	objectType  string
+	relation    string
+	subjectType string
+	source      string
+	target      string
+}
+
+func parseSchema(content string) (schema, error) {
+	tokens := tokenPattern.FindAllString(commentPattern.ReplaceAllString(content, ""), -1)
+	p := parser{tokens: tokens}
+	s := schema{}
+	for !p.done() {
+		if err := p.expect("definition"); err != nil {
+			return nil, errors.Wrap(errInvalidSchema, err)
+		}
+		name, err := p.identifier()
+		if err != nil {
+			return nil, errors.Wrap(errInvalidSchema, err)
+		}
+		if _, ok := s[name]; ok {
+			return nil, errors.Wrap(errInvalidSchema, fmt.Errorf("duplicate definition %s", name))
+		}
+		def, err := p.definition()
+		if err != nil {
+			return nil, errors.Wrap(errInvalidSchema, fmt.Errorf("definition %s: %w", name, err))
+		}
+		s[name] = def
+	}
+	if err := s.validate(); err != nil {
+		return nil, errors.Wrap(errInvalidSchema, err)
+	}
+
+	return s, nil
+}
+
+func (s schema) validate() error {
+	for name, def := range s {
+		for rel, types := range def.relations {
+			for _, t := range types {
+				if _, ok := s[t]; !ok {
+					return fmt.Errorf("relation %s#%s: unknown type %s", name, rel, t)
+				}
+			}
+		}
+		for perm, p := range def.permissions {
+			for _, t := range append(append([]term{}, p.union...), p.exclude...) {
+				if err := s.validateTerm(name, t); err != nil {
+					return fmt.Errorf("permission %s#%s: %w", name, perm, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s schema) validateTerm(objectType string, t term) error {
+	def := s[objectType]
+	if t.permission == "" {
+		if !def.has(t.relation) {
+			return fmt.Errorf("unknown relation or permission %s", t.relation)
+		}
+		// Exclusion is evaluated only at the end of the evaluation.
+		if p, ok := def.permissions[t.relation]; ok && len(p.exclude) > 0 {
+			return fmt.Errorf("permission %s with exclusion can't be referenced", t.relation)
+		}
+		return nil
+	}
+	types, ok := def.relations[t.relation]
+	if !ok {
+		return fmt.Errorf("unknown relation %s", t.relation)
+	}
+	found := false
+	for _, st := range types {
+		sdef := s[st]
+		if !sdef.has(t.permission) {
+			continue
+		}
+		if p, ok := sdef.permissions[t.permission]; ok && len(p.exclude) > 0 {
+			return fmt.Errorf("permission %s#%s with exclusion can't be referenced", st, t.permission)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no type of relation %s has %s", t.relation, t.permission)
+	}
+
+	return nil
+}
+
+// validatePermission checks if the relation or permission is defined
+// on the object type.
+func (s schema) validatePermission(objectType, name string) error {
+	def, ok := s[objectType]
+	if !ok || !def.has(name) {
+		return errors.Wrap(errUnknownName, fmt.Errorf("%s#%s", objectType, name))
+	}
+
+	return nil
+}
+
+// validateRelation checks if the subject type is allowed for the
+// relation of the object type.
+func (s schema) validateRelation(objectType, relation, subjectType string) error {
+	def, ok := s[objectType]
+	if !ok {
+		return errors.Wrap(errUnknownName, fmt.Errorf("%s#%s", objectType, relation))
+	}
+	types, ok := def.relations[relation]
+	if !ok {
+		return errors.Wrap(errUnknownName, fmt.Errorf("%s#%s", objectType, relation))
+	}
+	for _, t := range types {
+		if t == subjectType {
+			return nil
+		}
+	}
+
+	return errors.Wrap(errUnknownName, fmt.Errorf("%s#%s@%s", objectType, relation, subjectType))
+}
+
+func (s schema) rules() []rule {
+	var rules []rule
+	for objectType, def := range s {
+		for name, p := range def.permissions {
+			rules = append(rules, s.termRules(objectType, name, p.union)...)
+			rules = append(rules, s.termRules(objectType, excludedPrefix+name, p.exclude)...)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		return strings.Join([]string{a.objectType, a.target, a.relation, a.subjectType, a.source}, " ") <
+			strings.Join([]string{b.objectType, b.target, b.relation, b.subjectType, b.source}, " ")
+	})
+
+	return rules
+}
+
+func (s schema) termRules(objectType, target string, terms []term) []rule {
+	var rules []rule
+	for _, t := range terms {
+		if t.permission == "" {
+			rules = append(rules, rule{objectType: objectType, subjectType: objectType, source: t.relation, target: target})
+			continue
+		}
+		for _, st := range s[objectType].relations[t.relation] {
+			if s[st].has(t.permission) {
+				rules = append(rules, rule{objectType: objectType, relation: t.relation, subjectType: st, source: t.permission, target: target})
+			}
+		}
+	}
+
+	return rules
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(token string) error {
+	if t := p.next(); t != token {
+		return fmt.Errorf("expected %q, got %q", token, t)
+	}
+	return nil
+}
+
+func (p *parser) identifier() (string, error) {
+	t := p.next()
+	if !identifier.MatchString(t) {
+		return "", fmt.Errorf("expected identifier, got %q", t)
+	}
+	return t, nil
+}
+
+func (p *parser) definition() (definition, error) {
+	def := definition{
+		relations:   map[string][]string{},
+		permissions: map[string]permission{},
+	}
+	if err := p.expect("{"); err != nil {
+		return definition{}, err
+	}
+	for {
+		switch t := p.next(); t {
+		case "}":
+			return def, nil
+		case "relation":
+			name, types, err := p.relation()
+			if err != nil {
+				return definition{}, err
+			}
+			if def.has(name) {
+				return definition{}, fmt.Errorf("duplicate name %s", name)
+			}
+			def.relations[name] = types
+		case "permission":
+			name, perm, err := p.permission()
+			if err != nil {
+				return definition{}, err
+			}
+			if def.has(name) {
+				return definition{}, fmt.Errorf("duplicate name %s", name)
+			}
+			def.permissions[name] = perm
+		default:
+			return definition{}, fmt.Errorf("unexpected %q", t)
+		}
+	}
+}
+
+func (p *parser) relation() (string, []string, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return "", nil, err
+	}
+	var types []string
+	for {
+		t, err := p.identifier()
+		if err != nil {
+			return "", nil, err
+		}
+		// Subject relations and wildcards are not used by the schema.
+		if next := p.peek(); next == "#" || next == ":" {
+			return "", nil, fmt.Errorf("relation %s: subject relations and wildcards are not supported", name)
+		}
+		types = append(types, t)
+		if p.peek() != "|" {
+			return name, types, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) permission() (string, permission, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return "", permission{}, err
+	}
+	if err := p.expect("="); err != nil {
+		return "", permission{}, err
+	}
+	var perm permission
+	excluding := false
+	for {
+		t, err := p.term()
+		if err != nil {
+			return "", permission{}, fmt.Errorf("permission %s: %w", name, err)
+		}
+		if excluding {
+			perm.exclude = append(perm.exclude, t)
+		} else {
+			perm.union = append(perm.union, t)
+		}
+		switch p.peek() {
+		case "+":
+			// Union after the exclusion would require nested expressions.
+			if excluding {
+				return "", permission{}, fmt.Errorf("permission %s: union after exclusion is not supported", name)
+			}
+		case "-":
+			excluding = true
+		case "&":
+			return "", permission{}, fmt.Errorf("permission %s: intersection is not supported", name)
+		default:
+			return name, perm, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) term() (term, error) {
+	rel, err := p.identifier()
+	if err != nil {
+		return term{}, err
+	}
+	if p.peek() != "->" {
+		return term{relation: rel}, nil
+	}
+	p.next()
+	perm, err := p.identifier()
+	if err != nil {
+		return term{}, err
+	}
+
+	return term{relation: rel, permission: perm}, nil
+}
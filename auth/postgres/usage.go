@@ -0,0 +1,190 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+var _ auth.UsageRepository = (*usageRepo)(nil)
+
+type usageRepo struct {
+	db postgres.Database
+}
+
+// NewUsageRepository instantiates a PostgreSQL
+// implementation of domain usage repository.
+func NewUsageRepository(db postgres.Database) auth.UsageRepository {
+	return &usageRepo{
+		db: db,
+	}
+}
+
+func (repo usageRepo) AddEntities(ctx context.Context, domainID, resource string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	q := `INSERT INTO usage_entities (domain_id, resource, entity_id)
+	SELECT $1, $2, UNNEST($3::TEXT[])
+	ON CONFLICT DO NOTHING`
+
+	var entities pgtype.TextArray
+	if err := entities.Set(ids); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.ExecContext(ctx, q, domainID, resource, entities); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo usageRepo) ReserveEntities(ctx context.Context, domainID, resource string, limit uint64, ids ...string) (reserved []string, err error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var entities pgtype.TextArray
+	if err := entities.Set(ids); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	// The lock serializes the reservations of the domain resource, so the
+	// count below includes the entities reserved concurrently.
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || '/' || $2))`, domainID, resource); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if limit != 0 {
+		q := `SELECT COUNT(*) FROM usage_entities
+		WHERE domain_id = $1 AND resource = $2 AND entity_id <> ALL($3::TEXT[])`
+		var used uint64
+		if err := tx.QueryRowxContext(ctx, q, domainID, resource, entities).Scan(&used); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+		if used+uint64(len(ids)) > limit {
+			return nil, svcerr.ErrQuotaExceeded
+		}
+	}
+
+	q := `INSERT INTO usage_entities (domain_id, resource, entity_id)
+	SELECT $1, $2, UNNEST($3::TEXT[])
+	ON CONFLICT DO NOTHING
+	RETURNING entity_id`
+	if err := tx.SelectContext(ctx, &reserved, q, domainID, resource, entities); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return reserved, nil
+}
+
+func (repo usageRepo) RemoveEntities(ctx context.Context, domainID, resource string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var entities pgtype.TextArray
+	if err := entities.Set(ids); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	q := `DELETE FROM usage_entities WHERE resource = $1 AND entity_id = ANY($2::TEXT[])`
+	args := []interface{}{resource, entities}
+	if domainID != "" {
+		q += ` AND domain_id = $3`
+		args = append(args, domainID)
+	}
+	if _, err := repo.db.ExecContext(ctx, q, args...); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo usageRepo) AddMessages(ctx context.Context, channelID string, at time.Time, count, size uint64) error {
+	// The messages are accounted to the domain which owns the channel.
+	q := `INSERT INTO usage_messages (domain_id, day, messages, bytes)
+	SELECT domain_id, $2::DATE, $3, $4 FROM usage_entities WHERE resource = $5 AND entity_id = $1
+	ON CONFLICT (domain_id, day) DO UPDATE
+	SET messages = usage_messages.messages + EXCLUDED.messages, bytes = usage_messages.bytes + EXCLUDED.bytes`
+
+	day := at.UTC().Format(time.DateOnly)
+	if _, err := repo.db.ExecContext(ctx, q, channelID, day, int64(count), int64(size), auth.ChannelsQuota); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo usageRepo) Retrieve(ctx context.Context, domainID string, day time.Time) (auth.Usage, error) {
+	q := `SELECT
+		COUNT(*) FILTER (WHERE resource = $2) AS things,
+		COUNT(*) FILTER (WHERE resource = $3) AS channels,
+		COUNT(*) FILTER (WHERE resource = $4) AS users
+	FROM usage_entities WHERE domain_id = $1`
+
+	var u dbUsage
+	if err := repo.db.QueryRowxContext(ctx, q, domainID, auth.ThingsQuota, auth.ChannelsQuota, auth.UsersQuota).StructScan(&u); err != nil {
+		return auth.Usage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	q = `SELECT
+		COALESCE(SUM(messages) FILTER (WHERE day = $2::DATE), 0) AS messages,
+		COALESCE(SUM(bytes), 0) AS storage
+	FROM usage_messages WHERE domain_id = $1`
+
+	if err := repo.db.QueryRowxContext(ctx, q, domainID, day.UTC().Format(time.DateOnly)).StructScan(&u); err != nil {
+		return auth.Usage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.Usage{
+		Things:   uint64(u.Things),
+		Channels: uint64(u.Channels),
+		Users:    uint64(u.Users),
+		Messages: uint64(u.Messages),
+		Storage:  uint64(u.Storage),
+	}, nil
+}
+
+func (repo usageRepo) RemoveDomain(ctx context.Context, domainID string) error {
+	for _, q := range []string{
+		`DELETE FROM usage_entities WHERE domain_id = $1`,
+		`DELETE FROM usage_messages WHERE domain_id = $1`,
+	} {
+		if _, err := repo.db.ExecContext(ctx, q, domainID); err != nil {
+			return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+type dbUsage struct {
+	Things   int64 `db:"things"`
+	Channels int64 `db:"channels"`
+	Users    int64 `db:"users"`
+	Messages int64 `db:"messages"`
+	Storage  int64 `db:"storage"`
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/auth/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM usage_entities")
+		require.Nil(t, err, fmt.Sprintf("clean usage entities unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM usage_messages")
+		require.Nil(t, err, fmt.Sprintf("clean usage messages unexpected error: %s", err))
+	})
+
+	repo := postgres.NewUsageRepository(database)
+	otherDomain := testsutil.GenerateUUID(t)
+	thing := testsutil.GenerateUUID(t)
+	channel := testsutil.GenerateUUID(t)
+	now := time.Now().UTC()
+
+	err := repo.AddEntities(context.Background(), domainID, auth.ThingsQuota, thing, testsutil.GenerateUUID(t))
+	require.Nil(t, err, fmt.Sprintf("add things unexpected error: %s", err))
+	err = repo.AddEntities(context.Background(), domainID, auth.ThingsQuota, thing)
+	require.Nil(t, err, fmt.Sprintf("add existing thing unexpected error: %s", err))
+	err = repo.AddEntities(context.Background(), domainID, auth.ChannelsQuota, channel)
+	require.Nil(t, err, fmt.Sprintf("add channel unexpected error: %s", err))
+	err = repo.AddEntities(context.Background(), domainID, auth.UsersQuota, userID)
+	require.Nil(t, err, fmt.Sprintf("add user unexpected error: %s", err))
+	err = repo.AddEntities(context.Background(), otherDomain, auth.UsersQuota, userID)
+	require.Nil(t, err, fmt.Sprintf("add user to other domain unexpected error: %s", err))
+
+	err = repo.AddMessages(context.Background(), channel, now, 2, 100)
+	require.Nil(t, err, fmt.Sprintf("add messages unexpected error: %s", err))
+	err = repo.AddMessages(context.Background(), channel, now.Add(-48*time.Hour), 1, 50)
+	require.Nil(t, err, fmt.Sprintf("add previous messages unexpected error: %s", err))
+	err = repo.AddMessages(context.Background(), testsutil.GenerateUUID(t), now, 1, 50)
+	require.Nil(t, err, fmt.Sprintf("add messages of unknown channel unexpected error: %s", err))
+
+	u, err := repo.Retrieve(context.Background(), domainID, now)
+	assert.Nil(t, err, fmt.Sprintf("retrieve usage unexpected error: %s", err))
+	expected := auth.Usage{Things: 2, Channels: 1, Users: 1, Messages: 2, Storage: 150}
+	assert.Equal(t, expected, u, fmt.Sprintf("retrieve usage: expected %v got %v", expected, u))
+
+	err = repo.RemoveEntities(context.Background(), "", auth.UsersQuota, userID)
+	require.Nil(t, err, fmt.Sprintf("remove user unexpected error: %s", err))
+	err = repo.RemoveEntities(context.Background(), domainID, auth.ThingsQuota, thing)
+	require.Nil(t, err, fmt.Sprintf("remove thing unexpected error: %s", err))
+
+	u, err = repo.Retrieve(context.Background(), domainID, now)
+	assert.Nil(t, err, fmt.Sprintf("retrieve usage unexpected error: %s", err))
+	expected = auth.Usage{Things: 1, Channels: 1, Messages: 2, Storage: 150}
+	assert.Equal(t, expected, u, fmt.Sprintf("retrieve usage: expected %v got %v", expected, u))
+
+	u, err = repo.Retrieve(context.Background(), otherDomain, now)
+	assert.Nil(t, err, fmt.Sprintf("retrieve other domain usage unexpected error: %s", err))
+	assert.Equal(t, auth.Usage{}, u, fmt.Sprintf("retrieve other domain usage: expected empty usage got %v", u))
+}
+
+func TestReserveEntities(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM usage_entities")
+		require.Nil(t, err, fmt.Sprintf("clean usage entities unexpected error: %s", err))
+	})
+
+	repo := postgres.NewUsageRepository(database)
+	existing := testsutil.GenerateUUID(t)
+	err := repo.AddEntities(context.Background(), domainID, auth.ThingsQuota, existing)
+	require.Nil(t, err, fmt.Sprintf("add thing unexpected error: %s", err))
+
+	thing := testsutil.GenerateUUID(t)
+	reserved, err := repo.ReserveEntities(context.Background(), domainID, auth.ThingsQuota, 3, existing, thing)
+	assert.Nil(t, err, fmt.Sprintf("reserve things unexpected error: %s", err))
+	assert.Equal(t, []string{thing}, reserved, fmt.Sprintf("reserve things: expected %v got %v", []string{thing}, reserved))
+
+	_, err = repo.ReserveEntities(context.Background(), domainID, auth.ThingsQuota, 3, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	assert.True(t, errors.Contains(err, svcerr.ErrQuotaExceeded), fmt.Sprintf("reserve things above the limit: expected %s got %s", svcerr.ErrQuotaExceeded, err))
+
+	reserved, err = repo.ReserveEntities(context.Background(), domainID, auth.ThingsQuota, 0, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	assert.Nil(t, err, fmt.Sprintf("reserve things without limit unexpected error: %s", err))
+	assert.Len(t, reserved, 2, fmt.Sprintf("reserve things without limit: expected 2 reserved got %d", len(reserved)))
+
+	// The concurrent reservations of the domain don't exceed the limit together.
+	channels := make(chan error, 10)
+	for i := 0; i < cap(channels); i++ {
+		channel := testsutil.GenerateUUID(t)
+		go func() {
+			_, err := repo.ReserveEntities(context.Background(), domainID, auth.ChannelsQuota, 5, channel)
+			channels <- err
+		}()
+	}
+	var exceeded int
+	for i := 0; i < cap(channels); i++ {
+		if err := <-channels; err != nil {
+			assert.True(t, errors.Contains(err, svcerr.ErrQuotaExceeded), fmt.Sprintf("reserve channel concurrently: expected %s got %s", svcerr.ErrQuotaExceeded, err))
+			exceeded++
+		}
+	}
+	assert.Equal(t, 5, exceeded, fmt.Sprintf("reserve channels concurrently: expected 5 exceeded got %d", exceeded))
+
+	u, err := repo.Retrieve(context.Background(), domainID, time.Now())
+	assert.Nil(t, err, fmt.Sprintf("retrieve usage unexpected error: %s", err))
+	expected := auth.Usage{Things: 4, Channels: 5}
+	assert.Equal(t, expected, u, fmt.Sprintf("retrieve usage: expected %v got %v", expected, u))
+}
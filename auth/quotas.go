@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Domain resources limited by the quotas.
+const (
+	ThingsQuota   = "things"
+	ChannelsQuota = "channels"
+	UsersQuota    = "users"
+	MessagesQuota = "messages"
+	StorageQuota  = "storage"
+)
+
+// ErrInvalidQuotaResource indicates an unknown quota resource.
+var ErrInvalidQuotaResource = errors.New("invalid quota resource")
+
+// Quotas represents the limits of the domain resources.
+// Zero limit means the resource is not limited.
+type Quotas struct {
+	Things         uint64 `json:"things,omitempty"`
+	Channels       uint64 `json:"channels,omitempty"`
+	Users          uint64 `json:"users,omitempty"`
+	MessagesPerDay uint64 `json:"messages_per_day,omitempty"`
+	// Storage limits the total size of the published messages payloads, in bytes.
+	Storage uint64 `json:"storage,omitempty"`
+}
+
+// Usage represents the consumption of the domain resources.
+type Usage struct {
+	Things   uint64 `json:"things"`
+	Channels uint64 `json:"channels"`
+	Users    uint64 `json:"users"`
+	// Messages is the number of the messages published since the start of the day, in UTC.
+	Messages uint64 `json:"messages"`
+	// Storage is the total size of the published messages payloads, in bytes.
+	Storage uint64 `json:"storage"`
+}
+
+// DomainUsage contains the domain quotas and their consumption.
+type DomainUsage struct {
+	Quotas Quotas `json:"quotas"`
+	Usage  Usage  `json:"usage"`
+}
+
+// Check returns an error if consuming the count of the resource exceeds
+// its quota. Publishing the message also requires the storage quota not
+// to be exhausted, since the size of the message is not known in advance.
+func (q Quotas) Check(u Usage, resource string, count uint64) error {
+	switch resource {
+	case ThingsQuota:
+		return checkLimit(resource, q.Things, u.Things, count)
+	case ChannelsQuota:
+		return checkLimit(resource, q.Channels, u.Channels, count)
+	case UsersQuota:
+		return checkLimit(resource, q.Users, u.Users, count)
+	case MessagesQuota:
+		if err := checkLimit(resource, q.MessagesPerDay, u.Messages, count); err != nil {
+			return err
+		}
+		return checkLimit(StorageQuota, q.Storage, u.Storage, 1)
+	case StorageQuota:
+		return checkLimit(resource, q.Storage, u.Storage, count)
+	default:
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidQuotaResource)
+	}
+}
+
+// Limited reports whether the resource is limited by the quota. Publishing
+// the messages is limited also by the storage quota.
+func (q Quotas) Limited(resource string) (bool, error) {
+	switch resource {
+	case ThingsQuota:
+		return q.Things != 0, nil
+	case ChannelsQuota:
+		return q.Channels != 0, nil
+	case UsersQuota:
+		return q.Users != 0, nil
+	case MessagesQuota:
+		return q.MessagesPerDay != 0 || q.Storage != 0, nil
+	case StorageQuota:
+		return q.Storage != 0, nil
+	default:
+		return false, errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidQuotaResource)
+	}
+}
+
+// entityLimit returns the quota of the entities resource. Only the things,
+// the channels and the users are recorded as the entities of the domain.
+func (q Quotas) entityLimit(resource string) (uint64, error) {
+	switch resource {
+	case ThingsQuota:
+		return q.Things, nil
+	case ChannelsQuota:
+		return q.Channels, nil
+	case UsersQuota:
+		return q.Users, nil
+	default:
+		return 0, errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidQuotaResource)
+	}
+}
+
+func checkLimit(resource string, limit, used, count uint64) error {
+	if limit == 0 || used+count <= limit {
+		return nil
+	}
+
+	return quotaExceeded(resource, limit)
+}
+
+func quotaExceeded(resource string, limit uint64) error {
+	return errors.Wrap(svcerr.ErrQuotaExceeded, fmt.Errorf("%s limit is %d", resource, limit))
+}
+
+// UsageRepository specifies the domain resources usage persistence API.
+// The usage is recorded from the events and the messages of the domain.
+//
+//go:generate mockery --name UsageRepository --output=./mocks --filename usage.go --quiet --note "Copyright (c) Abstract Machines"
+type UsageRepository interface {
+	// AddEntities records the entities of the resource in the domain.
+	AddEntities(ctx context.Context, domainID, resource string, ids ...string) error
+
+	// ReserveEntities records the entities of the resource in the domain if
+	// the entities of the resource in the domain don't exceed the limit,
+	// zero meaning no limit. It returns the IDs of the newly recorded
+	// entities. Concurrent reservations in the domain are serialized.
+	ReserveEntities(ctx context.Context, domainID, resource string, limit uint64, ids ...string) ([]string, error)
+
+	// RemoveEntities removes the entities of the resource. The entities are
+	// removed from all the domains if the domain ID is empty.
+	RemoveEntities(ctx context.Context, domainID, resource string, ids ...string) error
+
+	// AddMessages records the messages published to the channel at the given time.
+	AddMessages(ctx context.Context, channelID string, at time.Time, count, size uint64) error
+
+	// Retrieve retrieves the domain usage, counting the messages published on the given day.
+	Retrieve(ctx context.Context, domainID string, day time.Time) (Usage, error)
+
+	// RemoveDomain removes the usage of the deleted domain.
+	RemoveDomain(ctx context.Context, domainID string) error
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrInvalidRole indicates an invalid custom role.
+var ErrInvalidRole = errors.New("invalid role")
+
+// rolePermissions lists the permissions custom roles can grant on the
+// entities, using the entity names of the API key scopes.
+var rolePermissions = map[string][]string{
+	"domains":  {ViewPermission, EditPermission, SharePermission},
+	"groups":   {ViewPermission, EditPermission, SharePermission, DeletePermission, MembershipPermission, PublishPermission, SubscribePermission},
+	"channels": {ViewPermission, EditPermission, SharePermission, DeletePermission, MembershipPermission, PublishPermission, SubscribePermission},
+	"things":   {ViewPermission, EditPermission, SharePermission, DeletePermission},
+}
+
+// Role is a custom domain role. Permissions have the format of the scope
+// operations, `<entity>:<permission>`, e.g. `things:view` or
+// `channels:publish`. Role assigned on the domain grants the permissions
+// on the domain entities, and role assigned on the group or channel grants
+// the permissions on the group or channel, its subgroups and things.
+type Role struct {
+	ID          string    `json:"id"`
+	DomainID    string    `json:"domain_id"`
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// Validate checks the role name and permissions.
+func (r Role) Validate() error {
+	if r.Name == "" {
+		return errors.Wrap(ErrInvalidRole, errors.New("empty name"))
+	}
+	if len(r.Permissions) == 0 {
+		return errors.Wrap(ErrInvalidRole, errors.New("empty permissions"))
+	}
+	for _, p := range r.Permissions {
+		entity, perm, _ := strings.Cut(p, ":")
+		if !slices.Contains(rolePermissions[entity], perm) {
+			return errors.Wrap(ErrInvalidRole, fmt.Errorf("unsupported permission %s", p))
+		}
+	}
+
+	return nil
+}
+
+// relations returns the relations of the role binding on the object
+// of the object type, granting the role permissions to the role members.
+func (r Role) relations(objectType string) []string {
+	var rels []string
+	for _, p := range r.Permissions {
+		entity, perm, _ := strings.Cut(p, ":")
+		entityType := scopeEntities[entity]
+		// Domain permissions can't be granted on the group.
+		if objectType == GroupType && entityType == DomainType {
+			continue
+		}
+		rel := fmt.Sprintf("role_%s_%s", entityType, perm)
+		if !slices.Contains(rels, rel) {
+			rels = append(rels, rel)
+		}
+	}
+
+	return rels
+}
+
+// RoleReq contains the role fields to update.
+type RoleReq struct {
+	Name        *string   `json:"name,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+// RolesPage contains a page of domain roles.
+type RolesPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Roles  []Role `json:"roles"`
+}
+
+func (page RolesPage) MarshalJSON() ([]byte, error) {
+	type Alias RolesPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Roles == nil {
+		a.Roles = make([]Role, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// RoleAssignment represents the role assigned to the user on the entity.
+type RoleAssignment struct {
+	RoleID     string `json:"role_id"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	UserID     string `json:"user_id"`
+}
+
+// RoleEntityType returns the policy object type of the entity roles are
+// assigned on: domains, groups or channels.
+func RoleEntityType(entity string) (string, error) {
+	switch entity {
+	case "domains":
+		return DomainType, nil
+	case "groups", "channels":
+		return GroupType, nil
+	default:
+		return "", errors.Wrap(ErrInvalidRole, fmt.Errorf("unsupported entity %s", entity))
+	}
+}
+
+// Roles specifies the API for the custom domain roles.
+type Roles interface {
+	// CreateRole creates the custom role in the domain.
+	CreateRole(ctx context.Context, token, domainID string, r Role) (Role, error)
+
+	// RetrieveRole retrieves the domain role.
+	RetrieveRole(ctx context.Context, token, domainID, id string) (Role, error)
+
+	// ListRoles lists the domain roles.
+	ListRoles(ctx context.Context, token, domainID string, pm Page) (RolesPage, error)
+
+	// UpdateRole updates the role name or permissions. Changed
+	// permissions apply to the existing role assignments.
+	UpdateRole(ctx context.Context, token, domainID, id string, r RoleReq) (Role, error)
+
+	// DeleteRole removes the role and its assignments.
+	DeleteRole(ctx context.Context, token, domainID, id string) error
+
+	// AssignRole assigns the role to the domain users on the entity:
+	// the domain, a group or a channel.
+	AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error
+
+	// UnassignRole removes the role assignment of the users on the entity.
+	UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error
+
+	// ListRoleAssignments lists the assignments of the role.
+	ListRoleAssignments(ctx context.Context, token, domainID, id string) ([]RoleAssignment, error)
+}
+
+// RolesRepository specifies the custom roles persistence API.
+//
+//go:generate mockery --name RolesRepository --output=./mocks --filename roles.go --quiet --note "Copyright (c) Abstract Machines"
+type RolesRepository interface {
+	// Save persists the role.
+	Save(ctx context.Context, r Role) (Role, error)
+
+	// RetrieveByID retrieves the domain role by its ID.
+	RetrieveByID(ctx context.Context, domainID, id string) (Role, error)
+
+	// RetrieveAll retrieves the page of the domain roles.
+	RetrieveAll(ctx context.Context, domainID string, pm Page) (RolesPage, error)
+
+	// Update updates the role name and permissions.
+	Update(ctx context.Context, r Role) (Role, error)
+
+	// Delete removes the role together with its assignments.
+	Delete(ctx context.Context, domainID, id string) error
+
+	// SaveAssignments persists the role assignments.
+	SaveAssignments(ctx context.Context, ras ...RoleAssignment) error
+
+	// RetrieveAssignments retrieves the assignments of the role.
+	RetrieveAssignments(ctx context.Context, roleID string) ([]RoleAssignment, error)
+
+	// RetrieveEntityAssignments retrieves the role assignments on the entity.
+	RetrieveEntityAssignments(ctx context.Context, entityType, entityID string) ([]RoleAssignment, error)
+
+	// RemoveAssignments removes the role assignments.
+	RemoveAssignments(ctx context.Context, ras ...RoleAssignment) error
+
+	// RemoveUserAssignments removes the assignments of the domain roles
+	// to the user and returns the removed assignments.
+	RemoveUserAssignments(ctx context.Context, domainID, userID string) ([]RoleAssignment, error)
+}
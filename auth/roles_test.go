@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		role auth.Role
+		err  error
+	}{
+		{
+			desc: "valid role",
+			role: auth.Role{
+				Name:        "operator",
+				Permissions: []string{"domains:view", "groups:membership", "channels:publish", "things:delete"},
+			},
+			err: nil,
+		},
+		{
+			desc: "role with empty name",
+			role: auth.Role{
+				Permissions: []string{"things:view"},
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc: "role with empty permissions",
+			role: auth.Role{
+				Name: "operator",
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc: "role with unsupported entity",
+			role: auth.Role{
+				Name:        "operator",
+				Permissions: []string{"users:view"},
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc: "role with unsupported permission",
+			role: auth.Role{
+				Name:        "operator",
+				Permissions: []string{"domains:delete"},
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc: "role with malformed permission",
+			role: auth.Role{
+				Name:        "operator",
+				Permissions: []string{"things"},
+			},
+			err: auth.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.role.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRoleEntityType(t *testing.T) {
+	cases := []struct {
+		desc       string
+		entity     string
+		entityType string
+		err        error
+	}{
+		{
+			desc:       "domains",
+			entity:     "domains",
+			entityType: auth.DomainType,
+		},
+		{
+			desc:       "groups",
+			entity:     "groups",
+			entityType: auth.GroupType,
+		},
+		{
+			desc:       "channels",
+			entity:     "channels",
+			entityType: auth.GroupType,
+		},
+		{
+			desc:   "things",
+			entity: "things",
+			err:    auth.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range cases {
+		entityType, err := auth.RoleEntityType(tc.entity)
+		assert.Equal(t, tc.entityType, entityType, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.entityType, entityType))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// AnyPermission is the scope operation permission matching all permissions.
+const AnyPermission = "*"
+
+// ErrInvalidScope indicates an invalid API key scope.
+var ErrInvalidScope = errors.New("invalid API key scope")
+
+// scopeEntities maps the entity of the scope operation to the policy object type.
+// Channels are groups in the policy engine, so both entities match groups.
+// Platform scopes allow the platform administration, such as the SCIM
+// provisioning of the users.
+var scopeEntities = map[string]string{
+	"things":   ThingType,
+	"channels": GroupType,
+	"groups":   GroupType,
+	"domains":  DomainType,
+	"users":    UserType,
+	"platform": PlatformType,
+}
+
+var scopePermissions = []string{
+	AdminPermission,
+	DeletePermission,
+	EditPermission,
+	ViewPermission,
+	MembershipPermission,
+	SharePermission,
+	PublishPermission,
+	SubscribePermission,
+	CreatePermission,
+	AnyPermission,
+}
+
+// Scope limits an API key to the operation, optionally on the listed entities.
+// The operation has the format `<entity>:<permission>`, e.g. `things:view` or
+// `channels:publish`, and `*` matches any permission of the entity.
+type Scope struct {
+	Operation string   `json:"operation"`
+	EntityIDs []string `json:"entity_ids,omitempty"`
+}
+
+// Validate checks that the scope operation is supported.
+func (s Scope) Validate() error {
+	if _, _, err := s.parse(); err != nil {
+		return err
+	}
+	for _, id := range s.EntityIDs {
+		if id == "" {
+			return errors.Wrap(ErrInvalidScope, errors.New("empty entity ID"))
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether the scope allows the permission on the object.
+func (s Scope) Allows(objectType, permission, object string) bool {
+	entity, perm, err := s.parse()
+	if err != nil || scopeEntities[entity] != objectType {
+		return false
+	}
+	if perm != AnyPermission && perm != permission {
+		return false
+	}
+
+	return len(s.EntityIDs) == 0 || slices.Contains(s.EntityIDs, object)
+}
+
+func (s Scope) parse() (string, string, error) {
+	entity, perm, ok := strings.Cut(s.Operation, ":")
+	if !ok {
+		return "", "", errors.Wrap(ErrInvalidScope, fmt.Errorf("operation %q must have <entity>:<permission> format", s.Operation))
+	}
+	if _, ok := scopeEntities[entity]; !ok {
+		return "", "", errors.Wrap(ErrInvalidScope, fmt.Errorf("unsupported entity %q", entity))
+	}
+	if !slices.Contains(scopePermissions, perm) {
+		return "", "", errors.Wrap(ErrInvalidScope, fmt.Errorf("unsupported permission %q", perm))
+	}
+
+	return entity, perm, nil
+}
@@ -6,26 +6,45 @@ package auth
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 )
 
 const (
 	recoveryDuration = 5 * time.Minute
 	defLimit         = 100
+
+	// The failed domain deletion is retried after the backoff, doubled
+	// after every failed attempt, until it fails maxDeletionAttempts times.
+	maxDeletionAttempts = 5
+	deletionBackoff     = 10 * time.Minute
 )
 
 var (
 	// ErrExpiry indicates that the token is expired.
 	ErrExpiry = errors.New("token is expired")
 
+	// ErrRevoked indicates that the token is revoked.
+	ErrRevoked = errors.New("token is revoked")
+
 	errIssueUser          = errors.New("failed to issue new login key")
 	errIssueTmp           = errors.New("failed to issue new temporary key")
+	errNotServiceAccount  = errors.New("user is not a service account")
 	errRevoke             = errors.New("failed to remove key")
+	errRevokeSession      = errors.New("failed to revoke session")
+	errRevokeTokens       = errors.New("failed to revoke tokens")
+	errExplainPolicy      = errors.New("failed to explain policy")
+	errApplyClaims        = errors.New("failed to apply claim mappings")
+	errInvalidClient      = errors.New("invalid client credentials")
+	errScopedKey          = errors.New("scoped API key cannot be exchanged for access token")
+	errSaveSession        = errors.New("failed to save session")
 	errRetrieve           = errors.New("failed to retrieve key data")
 	errIdentify           = errors.New("failed to validate token")
 	errPlatform           = errors.New("invalid platform id")
@@ -35,6 +54,8 @@ var (
 	errRollbackPolicy     = errors.New("failed to rollback policy")
 	errRemoveLocalPolicy  = errors.New("failed to remove from local policy copy")
 	errRemovePolicyEngine = errors.New("failed to remove from policy engine")
+	errDeletionRequested  = errors.New("domain deletion already requested")
+	errDeletionStarted    = errors.New("domain deletion already started")
 	// errInvalidEntityType indicates invalid entity type.
 	errInvalidEntityType = errors.New("invalid entity type")
 )
@@ -57,6 +78,8 @@ var (
 		ViewPermission,
 		MembershipPermission,
 		SharePermission,
+		PublishPermission,
+		SubscribePermission,
 	}
 
 	defDomainsFilterPermissions = []string{
@@ -93,6 +116,34 @@ type Authn interface {
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
 	Identify(ctx context.Context, token string) (Key, error)
+
+	// ListKeys lists the API keys issued for the user of the token
+	// in the token domain.
+	ListKeys(ctx context.Context, token string, pm Page) (KeyPage, error)
+
+	// RetrieveJWKS returns the public keys issued tokens are verified with.
+	RetrieveJWKS(ctx context.Context) ([]JWK, error)
+
+	// ListSessions lists the active login sessions of the token user.
+	ListSessions(ctx context.Context, token string) ([]Session, error)
+
+	// RevokeSession terminates the session of the token user, revoking
+	// the access and refresh tokens issued in the session.
+	RevokeSession(ctx context.Context, token, id string) error
+
+	// RevokeSessions terminates all the sessions of the token user,
+	// revoking all the access and refresh tokens issued to the user.
+	RevokeSessions(ctx context.Context, token string) error
+
+	// RevokeTokens revokes all the access and refresh tokens issued to
+	// the user and terminates the user sessions.
+	RevokeTokens(ctx context.Context, userID string) error
+
+	// ClientCredentials exchanges the API key for the access token as in
+	// the OAuth2 client credentials grant. The client ID is the ID of the
+	// user or the service account the API key is issued to, and the
+	// client secret is the API key.
+	ClientCredentials(ctx context.Context, clientID, secret string) (Token, error)
 }
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -105,6 +156,8 @@ type Service interface {
 	Authn
 	Authz
 	Domains
+	Roles
+	ClaimMappings
 }
 
 var _ Service = (*service)(nil)
@@ -112,6 +165,12 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys               KeyRepository
 	domains            DomainsRepository
+	sessions           SessionRepository
+	tokens             TokenRepository
+	roles              RolesRepository
+	conditions         ConditionsRepository
+	claims             ClaimMappingsRepository
+	usage              UsageRepository
 	idProvider         magistrala.IDProvider
 	agent              PolicyAgent
 	tokenizer          Tokenizer
@@ -121,11 +180,17 @@ type service struct {
 }
 
 // New instantiates the auth service implementation.
-func New(keys KeyRepository, domains DomainsRepository, idp magistrala.IDProvider, tokenizer Tokenizer, policyAgent PolicyAgent, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
+func New(keys KeyRepository, domains DomainsRepository, sessions SessionRepository, tokens TokenRepository, roles RolesRepository, conditions ConditionsRepository, claims ClaimMappingsRepository, usage UsageRepository, idp magistrala.IDProvider, tokenizer Tokenizer, policyAgent PolicyAgent, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
 	return &service{
 		tokenizer:          tokenizer,
 		domains:            domains,
 		keys:               keys,
+		sessions:           sessions,
+		tokens:             tokens,
+		roles:              roles,
+		conditions:         conditions,
+		claims:             claims,
+		usage:              usage,
 		idProvider:         idp,
 		agent:              policyAgent,
 		loginDuration:      loginDuration,
@@ -138,11 +203,16 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Token, err
 	key.IssuedAt = time.Now().UTC()
 	switch key.Type {
 	case APIKey:
+		// The users service issues the API keys of the service accounts
+		// with the token of the domain administrator.
+		if key.User != "" {
+			return svc.accountKey(ctx, token, key)
+		}
 		return svc.userKey(ctx, token, key)
 	case RefreshKey:
 		return svc.refreshKey(ctx, token, key)
 	case RecoveryKey:
-		return svc.tmpKey(recoveryDuration, key)
+		return svc.tmpKey(ctx, recoveryDuration, key)
 	case InvitationKey:
 		return svc.invitationKey(ctx, key)
 	default:
@@ -151,7 +221,7 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Token, err
 }
 
 func (svc service) Revoke(ctx context.Context, token, id string) error {
-	issuerID, _, err := svc.authenticate(token)
+	issuerID, _, err := svc.authenticate(ctx, token)
 	if err != nil {
 		return errors.Wrap(errRevoke, err)
 	}
@@ -162,7 +232,7 @@ func (svc service) Revoke(ctx context.Context, token, id string) error {
 }
 
 func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, error) {
-	issuerID, _, err := svc.authenticate(token)
+	issuerID, _, err := svc.authenticate(ctx, token)
 	if err != nil {
 		return Key{}, errors.Wrap(errRetrieve, err)
 	}
@@ -186,39 +256,258 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 
 	switch key.Type {
 	case RecoveryKey, AccessKey, InvitationKey, RefreshKey:
+		if err := svc.checkRevoked(ctx, key); err != nil {
+			return Key{}, err
+		}
 		return key, nil
 	case APIKey:
-		_, err := svc.keys.Retrieve(ctx, key.Issuer, key.ID)
+		k, err := svc.keys.Retrieve(ctx, key.Issuer, key.ID)
 		if err != nil {
 			return Key{}, svcerr.ErrAuthentication
 		}
+		// Revoking the tokens of the user, e.g. on password change or
+		// disabling, revokes the API keys issued to the user as well.
+		if err := svc.checkRevoked(ctx, key); err != nil {
+			return Key{}, err
+		}
+		key.Scopes = k.Scopes
 		return key, nil
 	default:
 		return Key{}, svcerr.ErrAuthentication
 	}
 }
 
+func (svc service) ListKeys(ctx context.Context, token string, pm Page) (KeyPage, error) {
+	_, sub, err := svc.authenticate(ctx, token)
+	if err != nil {
+		return KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+	if pm.Limit == 0 {
+		pm.Limit = defLimit
+	}
+	pm.SubjectID = sub
+
+	page, err := svc.keys.RetrieveAll(ctx, pm)
+	if err != nil {
+		return KeyPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc service) RetrieveJWKS(ctx context.Context) ([]JWK, error) {
+	return svc.tokenizer.RetrieveJWKS()
+}
+
+func (svc service) ListSessions(ctx context.Context, token string) ([]Session, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := svc.sessions.RetrieveAll(ctx, key.User)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range sessions {
+		sessions[i].Current = key.Type != APIKey && sessions[i].ID == key.ID
+	}
+
+	return sessions, nil
+}
+
+func (svc service) RevokeSession(ctx context.Context, token, id string) error {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	session, err := svc.sessions.Retrieve(ctx, key.User, id)
+	if err != nil {
+		return errors.Wrap(errRevokeSession, err)
+	}
+	// The session ID is the ID of the session tokens, so revoking it
+	// until the session expires revokes all of them.
+	if err := svc.tokens.Revoke(ctx, session.ID, session.ExpiresAt); err != nil {
+		return errors.Wrap(errRevokeSession, err)
+	}
+	if err := svc.sessions.Remove(ctx, key.User, session.ID); err != nil {
+		return errors.Wrap(errRevokeSession, err)
+	}
+
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, token string) error {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return svc.RevokeTokens(ctx, key.User)
+}
+
+func (svc service) RevokeTokens(ctx context.Context, userID string) error {
+	if err := svc.tokens.IncrementGeneration(ctx, userID); err != nil {
+		return errors.Wrap(errRevokeTokens, err)
+	}
+	if err := svc.sessions.RemoveAll(ctx, userID); err != nil {
+		return errors.Wrap(errRevokeTokens, err)
+	}
+
+	return nil
+}
+
 func (svc service) Authorize(ctx context.Context, pr PolicyReq) error {
 	if err := svc.PolicyValidation(pr); err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
+	if pr.SourceIP == "" {
+		pr.SourceIP = apiutil.SourceIP(ctx)
+	}
 	if pr.SubjectKind == TokenKind {
 		key, err := svc.Identify(ctx, pr.Subject)
 		if err != nil {
 			return errors.Wrap(svcerr.ErrAuthentication, err)
 		}
-		if key.Subject == "" {
+		// Scoped API keys are allowed only the operations of their scopes,
+		// on top of the permissions of the user they are issued for.
+		if !key.Allows(pr.ObjectType, pr.Permission, pr.Object) {
+			return svcerr.ErrAuthorization
+		}
+		switch {
+		case pr.ObjectType == PlatformType && key.User != "":
+			// Platform permissions are held by the user, not by the domain member.
+			pr.Subject = key.User
+		case key.Subject == "":
 			if pr.ObjectType == GroupType || pr.ObjectType == ThingType || pr.ObjectType == DomainType {
 				return svcerr.ErrDomainAuthorization
 			}
 			return svcerr.ErrAuthentication
+		default:
+			pr.Subject = key.Subject
 		}
-		pr.Subject = key.Subject
 		pr.Domain = key.Domain
 	}
 	if err := svc.checkPolicy(ctx, pr); err != nil {
 		return err
 	}
+	if err := svc.checkConditions(ctx, pr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc service) ExplainPolicy(ctx context.Context, token string, pr PolicyReq) (PolicyExplanation, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return PolicyExplanation{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if pr.SubjectType == "" {
+		pr.SubjectType = UserType
+	}
+	switch {
+	case pr.SubjectType == UserType && (pr.Subject == "" || pr.Subject == key.User):
+		pr.Subject = key.Subject
+		if pr.Subject == "" {
+			pr.Subject = key.User
+		}
+	case key.Domain != "":
+		// Only the domain administrators can explain the permissions of the other domain members.
+		if err := svc.Authorize(ctx, PolicyReq{
+			Subject:     token,
+			SubjectType: UserType,
+			SubjectKind: TokenKind,
+			Permission:  AdminPermission,
+			Object:      key.Domain,
+			ObjectType:  DomainType,
+		}); err != nil {
+			return PolicyExplanation{}, err
+		}
+		if pr.SubjectType == UserType {
+			pr.Subject = EncodeDomainUserID(key.Domain, pr.Subject)
+		}
+	default:
+		if err := svc.Authorize(ctx, PolicyReq{
+			Subject:     token,
+			SubjectType: UserType,
+			SubjectKind: TokenKind,
+			Permission:  AdminPermission,
+			Object:      MagistralaObject,
+			ObjectType:  PlatformType,
+		}); err != nil {
+			return PolicyExplanation{}, err
+		}
+	}
+	pr.Domain = key.Domain
+	if err := svc.PolicyValidation(pr); err != nil {
+		return PolicyExplanation{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	exp, err := svc.agent.ExplainPolicy(ctx, pr)
+	if err != nil {
+		return PolicyExplanation{}, errors.Wrap(errExplainPolicy, err)
+	}
+	if !exp.Allowed {
+		return exp, nil
+	}
+	// The relations grant the permission, but the request is still
+	// denied if the domain status or the policy conditions do not allow it.
+	if err := svc.checkPolicy(ctx, pr); err != nil {
+		exp.Allowed = false
+		exp.Reason = err.Error()
+		return exp, nil
+	}
+	if err := svc.checkPathConditions(ctx, pr, exp.Path); err != nil {
+		exp.Allowed = false
+		exp.Reason = err.Error()
+	}
+
+	return exp, nil
+}
+
+// checkConditions denies the request if the condition of a relationship
+// on the path granting the permission is not met. The path is explained
+// only if there are conditional policies at all.
+func (svc service) checkConditions(ctx context.Context, pr PolicyReq) error {
+	exists, err := svc.conditions.Exists(ctx)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !exists {
+		return nil
+	}
+	exp, err := svc.agent.ExplainPolicy(ctx, pr)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return svc.checkPathConditions(ctx, pr, exp.Path)
+}
+
+// checkPathConditions denies the request if the condition of a relationship
+// on the path, or of the subject membership in the object domain, is not met.
+func (svc service) checkPathConditions(ctx context.Context, pr PolicyReq, path []PolicyStep) error {
+	steps := path
+	if pr.Domain != "" && pr.ObjectType != DomainType {
+		steps = append(steps[:len(steps):len(steps)], PolicyStep{
+			SubjectType: pr.SubjectType,
+			Subject:     pr.Subject,
+			ObjectType:  DomainType,
+			Object:      pr.Domain,
+		})
+	}
+	cps, err := svc.conditions.RetrieveBySteps(ctx, steps)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	now := time.Now()
+	for _, cp := range cps {
+		if !cp.Met(pr.SourceIP, now) {
+			return errors.Wrap(svcerr.ErrAuthorization, errConditionNotMet)
+		}
+	}
+
 	return nil
 }
 
@@ -260,7 +549,8 @@ func (svc service) checkDomain(ctx context.Context, subjectType, subject, domain
 
 	switch d.Status {
 	case EnabledStatus:
-	case DisabledStatus:
+	// Domain administrators can restore the domain pending deletion.
+	case DisabledStatus, DeletedStatus:
 		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
 			Subject:     subject,
 			SubjectType: subjectType,
@@ -291,13 +581,19 @@ func (svc service) AddPolicy(ctx context.Context, pr PolicyReq) error {
 	if err := svc.PolicyValidation(pr); err != nil {
 		return errors.Wrap(svcerr.ErrInvalidPolicy, err)
 	}
-	return svc.agent.AddPolicy(ctx, pr)
+	if err := svc.agent.AddPolicy(ctx, pr); err != nil {
+		return err
+	}
+	return svc.addConditions(ctx, []PolicyReq{pr})
 }
 
 func (svc service) PolicyValidation(pr PolicyReq) error {
 	if pr.ObjectType == PlatformType && pr.Object != MagistralaObject {
 		return errPlatform
 	}
+	if pr.Condition != nil {
+		return pr.Condition.Validate()
+	}
 	return nil
 }
 
@@ -307,50 +603,149 @@ func (svc service) AddPolicies(ctx context.Context, prs []PolicyReq) error {
 			return errors.Wrap(svcerr.ErrInvalidPolicy, err)
 		}
 	}
-	return svc.agent.AddPolicies(ctx, prs)
+	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+		return err
+	}
+	return svc.addConditions(ctx, prs)
+}
+
+// addConditions saves the conditions of the added policies,
+// removing the policies if the conditions can't be saved.
+func (svc service) addConditions(ctx context.Context, prs []PolicyReq) error {
+	cps := conditionalPolicies(prs)
+	if len(cps) == 0 {
+		return nil
+	}
+	if err := svc.conditions.Save(ctx, cps); err != nil {
+		err = errors.Wrap(errAddPolicies, err)
+		if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
+			err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
+		}
+		return err
+	}
+	return nil
 }
 
 func (svc service) DeletePolicyFilter(ctx context.Context, pr PolicyReq) error {
-	return svc.agent.DeletePolicyFilter(ctx, pr)
+	if err := svc.agent.DeletePolicyFilter(ctx, pr); err != nil {
+		return err
+	}
+	return svc.conditions.RemoveFilter(ctx, conditionalPolicy(pr))
 }
 
 func (svc service) DeletePolicies(ctx context.Context, prs []PolicyReq) error {
+	var cps []ConditionalPolicy
 	for _, pr := range prs {
 		if err := svc.PolicyValidation(pr); err != nil {
 			return errors.Wrap(svcerr.ErrInvalidPolicy, err)
 		}
+		cps = append(cps, conditionalPolicy(pr))
+	}
+	if err := svc.agent.DeletePolicies(ctx, prs); err != nil {
+		return err
+	}
+	return svc.conditions.Remove(ctx, cps)
+}
+
+func (svc service) ExpirePolicies(ctx context.Context) ([]ConditionalPolicy, error) {
+	var expired []ConditionalPolicy
+	for {
+		cps, err := svc.conditions.RetrieveExpired(ctx, time.Now(), defLimit)
+		if err != nil {
+			return expired, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, cp := range cps {
+			if err := svc.expirePolicy(ctx, cp); err != nil {
+				return expired, errors.Wrap(errRemovePolicies, err)
+			}
+			expired = append(expired, cp)
+		}
+		if len(cps) < defLimit {
+			return expired, nil
+		}
+	}
+}
+
+func (svc service) expirePolicy(ctx context.Context, cp ConditionalPolicy) error {
+	// Lapsed domain membership revokes all the user permissions in the domain.
+	if cp.SubjectType == UserType && cp.ObjectType == DomainType {
+		_, userID := DecodeDomainUserID(cp.Subject)
+		if err := svc.removeDomainUser(ctx, cp.Object, userID); err != nil {
+			return err
+		}
+		return svc.conditions.Remove(ctx, []ConditionalPolicy{cp})
 	}
-	return svc.agent.DeletePolicies(ctx, prs)
+
+	return svc.DeletePolicies(ctx, []PolicyReq{{
+		SubjectType: cp.SubjectType,
+		Subject:     cp.Subject,
+		Relation:    cp.Relation,
+		ObjectType:  cp.ObjectType,
+		Object:      cp.Object,
+	}})
 }
 
 func (svc service) ListObjects(ctx context.Context, pr PolicyReq, nextPageToken string, limit uint64) (PolicyPage, error) {
 	if limit <= 0 {
 		limit = 100
 	}
+	pr, key, err := svc.identifyObjectsSubject(ctx, pr)
+	if err != nil {
+		return PolicyPage{}, err
+	}
 	res, npt, err := svc.agent.RetrieveObjects(ctx, pr, nextPageToken, limit)
 	if err != nil {
 		return PolicyPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
 	var page PolicyPage
 	for _, tuple := range res {
-		page.Policies = append(page.Policies, tuple.Object)
+		if key.Allows(pr.ObjectType, pr.Permission, tuple.Object) {
+			page.Policies = append(page.Policies, tuple.Object)
+		}
 	}
 	page.NextPageToken = npt
 	return page, nil
 }
 
 func (svc service) ListAllObjects(ctx context.Context, pr PolicyReq) (PolicyPage, error) {
+	pr, key, err := svc.identifyObjectsSubject(ctx, pr)
+	if err != nil {
+		return PolicyPage{}, err
+	}
 	res, err := svc.agent.RetrieveAllObjects(ctx, pr)
 	if err != nil {
 		return PolicyPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
 	var page PolicyPage
 	for _, tuple := range res {
-		page.Policies = append(page.Policies, tuple.Object)
+		if key.Allows(pr.ObjectType, pr.Permission, tuple.Object) {
+			page.Policies = append(page.Policies, tuple.Object)
+		}
 	}
 	return page, nil
 }
 
+// identifyObjectsSubject replaces the token subject of the objects listing
+// with the domain member the key is issued for. The returned key is used to
+// filter the listed objects by the key scopes, so the scoped API keys list
+// only the entities of their scopes. Non-token subjects are listed as they are.
+func (svc service) identifyObjectsSubject(ctx context.Context, pr PolicyReq) (PolicyReq, Key, error) {
+	if pr.SubjectKind != TokenKind {
+		return pr, Key{}, nil
+	}
+	key, err := svc.Identify(ctx, pr.Subject)
+	if err != nil {
+		return PolicyReq{}, Key{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if key.Subject == "" {
+		return PolicyReq{}, Key{}, svcerr.ErrDomainAuthorization
+	}
+	pr.Subject = key.Subject
+	pr.Domain = key.Domain
+
+	return pr, key, nil
+}
+
 func (svc service) CountObjects(ctx context.Context, pr PolicyReq) (uint64, error) {
 	return svc.agent.RetrieveAllObjectsCount(ctx, pr)
 }
@@ -410,8 +805,13 @@ func (svc service) ListPermissions(ctx context.Context, pr PolicyReq, permission
 	return pers, nil
 }
 
-func (svc service) tmpKey(duration time.Duration, key Key) (Token, error) {
+func (svc service) tmpKey(ctx context.Context, duration time.Duration, key Key) (Token, error) {
+	var err error
 	key.ExpiresAt = time.Now().Add(duration)
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
 	value, err := svc.tokenizer.Issue(key)
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
@@ -429,6 +829,19 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 	if err != nil {
 		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if err := svc.checkDomainMFA(ctx, key); err != nil {
+		return Token{}, err
+	}
+
+	// Each login starts a new session.
+	key.ID, err = svc.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
 
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
@@ -442,6 +855,17 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
+	session := Session{
+		ID:        key.ID,
+		User:      key.User,
+		Domain:    key.Domain,
+		CreatedAt: key.IssuedAt,
+		ExpiresAt: key.ExpiresAt,
+	}
+	if err := svc.sessions.Save(ctx, session); err != nil {
+		return Token{}, errors.Wrap(errSaveSession, err)
+	}
+
 	return Token{AccessToken: access, RefreshToken: refresh}, nil
 }
 
@@ -454,6 +878,10 @@ func (svc service) invitationKey(ctx context.Context, key Key) (Token, error) {
 	if err != nil {
 		return Token{}, err
 	}
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
 
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
@@ -471,17 +899,35 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 	if k.Type != RefreshKey {
 		return Token{}, errIssueUser
 	}
+	if err := svc.checkRevoked(ctx, k); err != nil {
+		return Token{}, errors.Wrap(errRetrieve, err)
+	}
 	key.ID = k.ID
 	if key.Domain == "" {
 		key.Domain = k.Domain
 	}
 	key.User = k.User
 	key.Type = AccessKey
+	key.Generation = k.Generation
+	key.MFA = k.MFA
 
 	key.Subject, err = svc.checkUserDomain(ctx, key)
 	if err != nil {
 		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if err := svc.checkDomainMFA(ctx, key); err != nil {
+		return Token{}, err
+	}
+
+	session := Session{CreatedAt: key.IssuedAt}
+	// Refresh tokens issued before the sessions were introduced have no ID.
+	if key.ID == "" {
+		if key.ID, err = svc.idProvider.ID(); err != nil {
+			return Token{}, errors.Wrap(errIssueTmp, err)
+		}
+	} else if s, err := svc.sessions.Retrieve(ctx, key.User, key.ID); err == nil {
+		session = s
+	}
 
 	key.ExpiresAt = time.Now().Add(svc.loginDuration)
 	access, err := svc.tokenizer.Issue(key)
@@ -496,6 +942,15 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
+	session.ID = key.ID
+	session.User = key.User
+	session.Domain = key.Domain
+	session.RefreshedAt = key.IssuedAt
+	session.ExpiresAt = key.ExpiresAt
+	if err := svc.sessions.Save(ctx, session); err != nil {
+		return Token{}, errors.Wrap(errSaveSession, err)
+	}
+
 	return Token{AccessToken: access, RefreshToken: refresh}, nil
 }
 
@@ -527,22 +982,70 @@ func (svc service) checkUserDomain(ctx context.Context, key Key) (subject string
 	return "", nil
 }
 
+// checkDomainMFA checks that the administrators of the domain requiring
+// the multi-factor authentication completed it when logging in.
+func (svc service) checkDomainMFA(ctx context.Context, key Key) error {
+	if key.Domain == "" || key.MFA {
+		return nil
+	}
+	domain, err := svc.domains.RetrieveByID(ctx, key.Domain)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !domain.MFARequired {
+		return nil
+	}
+	// Platform administrators are the administrators of all the domains.
+	if key.Subject != key.User {
+		err := svc.Authorize(ctx, PolicyReq{
+			Subject:     key.Subject,
+			SubjectType: UserType,
+			Permission:  AdminPermission,
+			Object:      key.Domain,
+			ObjectType:  DomainType,
+		})
+		if err != nil {
+			return nil
+		}
+	}
+
+	return errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrMFARequired)
+}
+
 func (svc service) userKey(ctx context.Context, token string, key Key) (Token, error) {
-	id, sub, err := svc.authenticate(token)
+	k, err := svc.tokenizer.Parse(token)
 	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, errors.Wrap(svcerr.ErrAuthentication, err))
+	}
+	if k.Type != AccessKey || k.Issuer == "" {
+		return Token{}, errors.Wrap(errIssueUser, svcerr.ErrAuthentication)
+	}
+	if err := svc.checkRevoked(ctx, k); err != nil {
 		return Token{}, errors.Wrap(errIssueUser, err)
 	}
+	for _, scope := range key.Scopes {
+		if err := scope.Validate(); err != nil {
+			return Token{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
 
-	key.Issuer = id
+	key.Issuer = k.Issuer
 	if key.Subject == "" {
-		key.Subject = sub
+		key.Subject = k.Subject
 	}
+	// The API key acts on behalf of the user in the domain of the access key.
+	key.User = k.User
+	key.Domain = k.Domain
 
 	keyID, err := svc.idProvider.ID()
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueUser, err)
 	}
 	key.ID = keyID
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
 
 	if _, err := svc.keys.Save(ctx, key); err != nil {
 		return Token{}, errors.Wrap(errIssueUser, err)
@@ -556,20 +1059,153 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Token, e
 	return Token{AccessToken: tkn}, nil
 }
 
-func (svc service) authenticate(token string) (string, string, error) {
-	key, err := svc.tokenizer.Parse(token)
-	if err != nil {
-		return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+// accountKey issues the API key of the service account in its domain. The
+// key is issued only by the domain administrator, and only to the domain
+// members who are not the platform users, which are the service accounts.
+func (svc service) accountKey(ctx context.Context, token string, key Key) (Token, error) {
+	if key.Domain == "" {
+		return Token{}, errors.Wrap(errIssueUser, svcerr.ErrMalformedEntity)
 	}
-	// Only login key token is valid for login.
-	if key.Type != AccessKey || key.Issuer == "" {
-		return "", "", svcerr.ErrAuthentication
+	if token == "" {
+		return Token{}, errors.Wrap(errIssueUser, svcerr.ErrAuthentication)
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Permission:  AdminPermission,
+		Object:      key.Domain,
+		ObjectType:  DomainType,
+	}); err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+	// The users are the platform members, unlike the service accounts.
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     key.User,
+		SubjectType: UserType,
+		Permission:  MembershipPermission,
+		Object:      MagistralaObject,
+		ObjectType:  PlatformType,
+	}); err == nil {
+		return Token{}, errors.Wrap(errIssueUser, errors.Wrap(svcerr.ErrAuthorization, errNotServiceAccount))
 	}
 
-	return key.Issuer, key.Subject, nil
-}
-
-// Switch the relative permission for the relation.
+	var err error
+	key.Subject, err = svc.checkUserDomain(ctx, key)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, errors.Wrap(svcerr.ErrAuthorization, err))
+	}
+	key.ID, err = svc.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+
+	tkn, err := svc.tokenizer.Issue(key)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+	// The key is retrieved by the issuer of the token, which is set by
+	// the tokenizer.
+	k, err := svc.tokenizer.Parse(tkn)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+	key.Issuer = k.Issuer
+	if _, err := svc.keys.Save(ctx, key); err != nil {
+		return Token{}, errors.Wrap(errIssueUser, err)
+	}
+
+	return Token{AccessToken: tkn}, nil
+}
+
+func (svc service) ClientCredentials(ctx context.Context, clientID, secret string) (Token, error) {
+	key, err := svc.Identify(ctx, secret)
+	if err != nil {
+		return Token{}, err
+	}
+	if key.Type != APIKey || key.User != clientID {
+		return Token{}, errors.Wrap(svcerr.ErrAuthentication, errInvalidClient)
+	}
+	// Scopes are not carried by the access tokens.
+	if len(key.Scopes) > 0 {
+		return Token{}, errors.Wrap(svcerr.ErrAuthorization, errScopedKey)
+	}
+
+	key.Type = AccessKey
+	key.Scopes = nil
+	key.IssuedAt = time.Now().UTC()
+	key.ExpiresAt = key.IssuedAt.Add(svc.loginDuration)
+	key.Subject, err = svc.checkUserDomain(ctx, key)
+	if err != nil {
+		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if err := svc.checkDomainMFA(ctx, key); err != nil {
+		return Token{}, err
+	}
+	key.ID, err = svc.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+	key.Generation, err = svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+
+	access, err := svc.tokenizer.Issue(key)
+	if err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+
+	return Token{AccessToken: access, AccessType: "Bearer"}, nil
+}
+
+func (svc service) authenticate(ctx context.Context, token string) (string, string, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	// Only login key token is valid for login.
+	if key.Type != AccessKey || key.Issuer == "" {
+		return "", "", svcerr.ErrAuthentication
+	}
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return "", "", err
+	}
+
+	return key.Issuer, key.Subject, nil
+}
+
+// checkRevoked checks whether the token is revoked, either by terminating
+// its session or by revoking all the tokens issued to the user.
+func (svc service) checkRevoked(ctx context.Context, key Key) error {
+	if key.ID != "" {
+		revoked, err := svc.tokens.Revoked(ctx, key.ID)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if revoked {
+			return errors.Wrap(svcerr.ErrAuthentication, ErrRevoked)
+		}
+	}
+	if key.User == "" {
+		return nil
+	}
+	generation, err := svc.tokens.Generation(ctx, key.User)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if key.Generation < generation {
+		return errors.Wrap(svcerr.ErrAuthentication, ErrRevoked)
+	}
+
+	return nil
+}
+
+// Switch the relative permission for the relation.
 func SwitchToPermission(relation string) string {
 	switch relation {
 	case AdministratorRelation:
@@ -593,6 +1229,11 @@ func (svc service) CreateDomain(ctx context.Context, token string, d Domain) (do
 		return Domain{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	d.CreatedBy = key.User
+	if d.Quotas != (Quotas{}) {
+		if err := svc.checkPlatformAdmin(ctx, token); err != nil {
+			return Domain{}, err
+		}
+	}
 
 	domainID, err := svc.idProvider.ID()
 	if err != nil {
@@ -625,8 +1266,7 @@ func (svc service) CreateDomain(ctx context.Context, token string, d Domain) (do
 }
 
 func (svc service) RetrieveDomain(ctx context.Context, token, id string) (Domain, error) {
-	res, err := svc.Identify(ctx, token)
-	if err != nil {
+	if _, err := svc.Identify(ctx, token); err != nil {
 		return Domain{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	domain, err := svc.domains.RetrieveByID(ctx, id)
@@ -634,9 +1274,9 @@ func (svc service) RetrieveDomain(ctx context.Context, token, id string) (Domain
 		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
 	if err = svc.Authorize(ctx, PolicyReq{
-		Subject:     res.Subject,
+		Subject:     token,
 		SubjectType: UserType,
-		SubjectKind: UsersKind,
+		SubjectKind: TokenKind,
 		Object:      id,
 		ObjectType:  DomainType,
 		Permission:  MembershipPermission,
@@ -653,9 +1293,9 @@ func (svc service) RetrieveDomainPermissions(ctx context.Context, token, id stri
 	}
 
 	if err := svc.Authorize(ctx, PolicyReq{
-		Subject:     res.Subject,
+		Subject:     token,
 		SubjectType: UserType,
-		SubjectKind: UsersKind,
+		SubjectKind: TokenKind,
 		Object:      id,
 		ObjectType:  DomainType,
 		Permission:  MembershipPermission,
@@ -681,15 +1321,20 @@ func (svc service) UpdateDomain(ctx context.Context, token, id string, d DomainR
 		return Domain{}, err
 	}
 	if err := svc.Authorize(ctx, PolicyReq{
-		Subject:     key.Subject,
+		Subject:     token,
 		SubjectType: UserType,
-		SubjectKind: UsersKind,
+		SubjectKind: TokenKind,
 		Object:      id,
 		ObjectType:  DomainType,
 		Permission:  EditPermission,
 	}); err != nil {
 		return Domain{}, err
 	}
+	if d.Quotas != nil {
+		if err := svc.checkPlatformAdmin(ctx, token); err != nil {
+			return Domain{}, err
+		}
+	}
 
 	dom, err := svc.domains.Update(ctx, id, key.User, d)
 	if err != nil {
@@ -704,23 +1349,55 @@ func (svc service) ChangeDomainStatus(ctx context.Context, token, id string, d D
 		return Domain{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	if err := svc.Authorize(ctx, PolicyReq{
-		Subject:     key.Subject,
+		Subject:     token,
 		SubjectType: UserType,
-		SubjectKind: UsersKind,
+		SubjectKind: TokenKind,
 		Object:      id,
 		ObjectType:  DomainType,
 		Permission:  AdminPermission,
 	}); err != nil {
 		return Domain{}, err
 	}
+	// Domains are deleted only through the domain deletion.
+	if d.Status != nil && *d.Status == DeletedStatus {
+		return Domain{}, svcerr.ErrInvalidStatus
+	}
 
-	dom, err := svc.domains.Update(ctx, id, key.User, d)
+	dom, err := svc.domains.RetrieveByID(ctx, id)
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dom.Status == DeletedStatus {
+		if err := svc.cancelDeletion(ctx, id); err != nil {
+			return Domain{}, err
+		}
+	}
+
+	dom, err = svc.domains.Update(ctx, id, key.User, d)
 	if err != nil {
 		return Domain{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
 	return dom, nil
 }
 
+// cancelDeletion cancels the domain deletion which hasn't started yet.
+func (svc service) cancelDeletion(ctx context.Context, id string) error {
+	dd, err := svc.domains.RetrieveDeletion(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dd.Status != DeletionPending {
+		return errors.Wrap(svcerr.ErrConflict, errDeletionStarted)
+	}
+	dd.Status = DeletionCanceled
+	dd.UpdatedAt = time.Now().UTC()
+	if err := svc.domains.UpdateDeletion(ctx, dd); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) ListDomains(ctx context.Context, token string, p Page) (DomainsPage, error) {
 	key, err := svc.Identify(ctx, token)
 	if err != nil {
@@ -728,8 +1405,9 @@ func (svc service) ListDomains(ctx context.Context, token string, p Page) (Domai
 	}
 	p.SubjectID = key.User
 	if err := svc.Authorize(ctx, PolicyReq{
-		Subject:     key.User,
+		Subject:     token,
 		SubjectType: UserType,
+		SubjectKind: TokenKind,
 		Permission:  AdminPermission,
 		ObjectType:  PlatformType,
 		Object:      MagistralaObject,
@@ -748,7 +1426,7 @@ func (svc service) ListDomains(ctx context.Context, token string, p Page) (Domai
 	return dp, nil
 }
 
-func (svc service) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string) error {
+func (svc service) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string, cond *Condition) error {
 	if err := svc.Authorize(ctx, PolicyReq{
 		Subject:     token,
 		SubjectType: UserType,
@@ -783,7 +1461,18 @@ func (svc service) AssignUsers(ctx context.Context, token, id string, userIds []
 		}
 	}
 
-	return svc.addDomainPolicies(ctx, id, relation, userIds...)
+	if cond != nil {
+		if err := cond.Validate(); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+
+	d, err := svc.domains.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return svc.addDomainMembers(ctx, d, relation, cond, userIds...)
 }
 
 func (svc service) UnassignUser(ctx context.Context, token, id, userID string) error {
@@ -810,166 +1499,1102 @@ func (svc service) UnassignUser(ctx context.Context, token, id, userID string) e
 		}
 	}
 
+	return svc.removeDomainUser(ctx, id, userID)
+}
+
+func (svc service) removeDomainUser(ctx context.Context, domainID, userID string) error {
 	if err := svc.DeletePolicyFilter(ctx, PolicyReq{
-		Subject:     EncodeDomainUserID(id, userID),
+		Subject:     EncodeDomainUserID(domainID, userID),
 		SubjectType: UserType,
 	}); err != nil {
 		return errors.Wrap(errRemovePolicies, err)
 	}
 
+	ras, err := svc.roles.RemoveUserAssignments(ctx, domainID, userID)
+	if err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	if err := svc.unbindRoles(ctx, ras); err != nil {
+		return err
+	}
+
 	pc := Policy{
 		SubjectType: UserType,
 		SubjectID:   userID,
 		ObjectType:  DomainType,
-		ObjectID:    id,
+		ObjectID:    domainID,
 	}
 
 	if err := svc.domains.DeletePolicies(ctx, pc); err != nil {
 		return errors.Wrap(errRemovePolicies, err)
 	}
 
+	if err := svc.claims.RemoveGrant(ctx, domainID, userID); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
 	return nil
 }
 
-// IMPROVEMENT NOTE: Take decision: Only Patform admin or both Patform and domain admins can see others users domain.
-func (svc service) ListUserDomains(ctx context.Context, token, userID string, p Page) (DomainsPage, error) {
-	res, err := svc.Identify(ctx, token)
+func (svc service) AddDomainMember(ctx context.Context, domainID, userID, relation string) error {
+	d, err := svc.domains.RetrieveByID(ctx, domainID)
 	if err != nil {
-		return DomainsPage{}, errors.Wrap(svcerr.ErrAuthentication, err)
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return svc.addDomainMembers(ctx, d, relation, nil, userID)
+}
+
+// addDomainMembers reserves the users quota of the domain for the users and
+// adds them to the domain, releasing the reservation if adding fails.
+func (svc service) addDomainMembers(ctx context.Context, d Domain, relation string, cond *Condition, userIDs ...string) (err error) {
+	reserved, err := svc.reserveQuota(ctx, d, UsersQuota, false, userIDs...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRelease := svc.usage.RemoveEntities(ctx, d.ID, UsersQuota, reserved...); errRelease != nil {
+				err = errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, errRelease))
+			}
+		}
+	}()
+
+	return svc.addDomainPolicies(ctx, d.ID, relation, cond, userIDs...)
+}
+
+func (svc service) RetrieveDomainUsage(ctx context.Context, token, id string) (DomainUsage, error) {
+	if _, err := svc.Identify(ctx, token); err != nil {
+		return DomainUsage{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	if err := svc.Authorize(ctx, PolicyReq{
-		Subject:     res.User,
+		Subject:     token,
 		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Object:      id,
+		ObjectType:  DomainType,
 		Permission:  AdminPermission,
-		Object:      MagistralaObject,
-		ObjectType:  PlatformType,
 	}); err != nil {
-		return DomainsPage{}, errors.Wrap(svcerr.ErrAuthorization, err)
+		return DomainUsage{}, err
 	}
-	if userID != "" && res.User != userID {
-		p.SubjectID = userID
-	} else {
-		p.SubjectID = res.User
+
+	d, err := svc.domains.RetrieveByID(ctx, id)
+	if err != nil {
+		return DomainUsage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	dp, err := svc.domains.ListDomains(ctx, p)
+	u, err := svc.usage.Retrieve(ctx, id, time.Now().UTC())
 	if err != nil {
-		return DomainsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		return DomainUsage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	return dp, nil
-}
 
-func (svc service) addDomainPolicies(ctx context.Context, domainID, relation string, userIDs ...string) (err error) {
-	var prs []PolicyReq
-	var pcs []Policy
+	return DomainUsage{Quotas: d.Quotas, Usage: u}, nil
+}
 
-	for _, userID := range userIDs {
-		prs = append(prs, PolicyReq{
-			Subject:     EncodeDomainUserID(domainID, userID),
-			SubjectType: UserType,
-			SubjectKind: UsersKind,
-			Relation:    relation,
-			Object:      domainID,
-			ObjectType:  DomainType,
-		})
-		pcs = append(pcs, Policy{
-			SubjectType: UserType,
-			SubjectID:   userID,
-			Relation:    relation,
-			ObjectType:  DomainType,
-			ObjectID:    domainID,
-		})
+func (svc service) CheckQuota(ctx context.Context, domainID, resource string, count uint64) (bool, error) {
+	d, err := svc.domains.RetrieveByID(ctx, domainID)
+	if err != nil {
+		return false, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
-		return errors.Wrap(errAddPolicies, err)
+	limited, err := d.Quotas.Limited(resource)
+	if err != nil {
+		return false, err
 	}
-	defer func() {
-		if err != nil {
-			if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
-				err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
-			}
-		}
-	}()
-
-	if err = svc.domains.SavePolicies(ctx, pcs...); err != nil {
-		return errors.Wrap(errAddPolicies, err)
+	if err := svc.checkQuota(ctx, d, resource, count); err != nil {
+		return limited, err
 	}
-	return nil
+
+	return limited, nil
 }
 
-func (svc service) createDomainPolicy(ctx context.Context, userID, domainID, relation string) (err error) {
-	prs := []PolicyReq{
-		{
-			Subject:     EncodeDomainUserID(domainID, userID),
-			SubjectType: UserType,
-			SubjectKind: UsersKind,
-			Relation:    relation,
-			Object:      domainID,
-			ObjectType:  DomainType,
-		},
-		{
-			Subject:     MagistralaObject,
-			SubjectType: PlatformType,
-			Relation:    PlatformRelation,
-			Object:      domainID,
-			ObjectType:  DomainType,
-		},
-	}
-	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+func (svc service) checkQuota(ctx context.Context, d Domain, resource string, count uint64) error {
+	// Usage is not needed for the resources without quotas.
+	limited, err := d.Quotas.Limited(resource)
+	if err != nil || !limited {
 		return err
 	}
-	defer func() {
-		if err != nil {
-			if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
-				err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
-			}
-		}
-	}()
-	err = svc.domains.SavePolicies(ctx, Policy{
-		SubjectType: UserType,
-		SubjectID:   userID,
-		Relation:    relation,
-		ObjectType:  DomainType,
-		ObjectID:    domainID,
-	})
+	u, err := svc.usage.Retrieve(ctx, d.ID, time.Now().UTC())
 	if err != nil {
-		return errors.Wrap(errCreateDomainPolicy, err)
+		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	return err
+
+	return d.Quotas.Check(u, resource, count)
 }
 
-func (svc service) createDomainPolicyRollback(ctx context.Context, userID, domainID, relation string) error {
-	var err error
-	prs := []PolicyReq{
-		{
+func (svc service) ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) ([]string, error) {
+	d, err := svc.domains.RetrieveByID(ctx, domainID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return svc.reserveQuota(ctx, d, resource, existing, ids...)
+}
+
+func (svc service) reserveQuota(ctx context.Context, d Domain, resource string, existing bool, ids ...string) ([]string, error) {
+	limit, err := d.Quotas.entityLimit(resource)
+	if err != nil {
+		return nil, err
+	}
+	// The existing entities are already created, so they are only recorded.
+	if existing {
+		limit = 0
+	}
+	reserved, err := svc.usage.ReserveEntities(ctx, d.ID, resource, limit, ids...)
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrQuotaExceeded) {
+			return nil, quotaExceeded(resource, limit)
+		}
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return reserved, nil
+}
+
+func (svc service) ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) error {
+	if _, err := (Quotas{}).entityLimit(resource); err != nil {
+		return err
+	}
+	if err := svc.usage.RemoveEntities(ctx, domainID, resource, ids...); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) DeleteDomain(ctx context.Context, token, id string) (DomainDeletion, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return DomainDeletion{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Object:      id,
+		ObjectType:  DomainType,
+		Permission:  AdminPermission,
+	}); err != nil {
+		return DomainDeletion{}, err
+	}
+
+	now := time.Now().UTC()
+	dd := DomainDeletion{
+		DomainID:    id,
+		Status:      DeletionPending,
+		RequestedBy: key.User,
+		RequestedAt: now,
+		UpdatedAt:   now,
+		RetryAt:     now,
+	}
+	if err := svc.domains.SaveDeletion(ctx, dd); err != nil {
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return DomainDeletion{}, errors.Wrap(svcerr.ErrConflict, errDeletionRequested)
+		}
+		return DomainDeletion{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return dd, nil
+}
+
+func (svc service) RetrieveDomainDeletion(ctx context.Context, token, id string) (DomainDeletion, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return DomainDeletion{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	dd, err := svc.domains.RetrieveDeletion(ctx, id)
+	if err != nil {
+		return DomainDeletion{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// The domain policies are removed with the domain, so the user
+	// who requested the deletion can follow it after it completes.
+	if dd.RequestedBy == key.User {
+		return dd, nil
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Object:      id,
+		ObjectType:  DomainType,
+		Permission:  AdminPermission,
+	}); err != nil {
+		return DomainDeletion{}, err
+	}
+
+	return dd, nil
+}
+
+func (svc service) PurgeDomains(ctx context.Context, before time.Time) ([]DomainDeletion, error) {
+	dds, err := svc.domains.RetrieveDeletions(ctx, before, time.Now().UTC(), maxDeletionAttempts, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	var purged []DomainDeletion
+	for _, dd := range dds {
+		dd.Status = DeletionInProgress
+		dd.Attempts++
+		dd.UpdatedAt = time.Now().UTC()
+		if err := svc.domains.UpdateDeletion(ctx, dd); err != nil {
+			return purged, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+
+		dd.Status, dd.Error = DeletionAuthCompleted, ""
+		if err := svc.purgeDomain(ctx, dd.DomainID); err != nil {
+			dd.Status, dd.Error = DeletionFailed, err.Error()
+		}
+		dd.UpdatedAt = time.Now().UTC()
+		if dd.Status == DeletionFailed {
+			dd.RetryAt = dd.UpdatedAt.Add(deletionBackoff << (dd.Attempts - 1))
+		}
+		if err := svc.domains.UpdateDeletion(ctx, dd); err != nil {
+			return purged, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		purged = append(purged, dd)
+	}
+
+	return purged, nil
+}
+
+// purgeDomain removes the domain roles bindings and policies, its usage and
+// the domain itself, which removes the domain roles and claim mappings.
+func (svc service) purgeDomain(ctx context.Context, id string) error {
+	for offset := uint64(0); ; offset += defLimit {
+		rp, err := svc.roles.RetrieveAll(ctx, id, Page{Offset: offset, Limit: defLimit})
+		if err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+		for _, r := range rp.Roles {
+			ras, err := svc.roles.RetrieveAssignments(ctx, r.ID)
+			if err != nil {
+				return errors.Wrap(errRemovePolicies, err)
+			}
+			for _, ra := range roleEntities(ras) {
+				if err := svc.agent.DeletePolicyFilter(ctx, roleBindingFilter(ra.RoleID, ra.EntityType, ra.EntityID)); err != nil {
+					return errors.Wrap(errRemovePolicies, err)
+				}
+			}
+		}
+		if offset+defLimit >= rp.Total {
+			break
+		}
+	}
+
+	if err := svc.DeleteEntityPolicies(ctx, DomainType, id); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	if err := svc.usage.RemoveDomain(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	// The domain is already removed if the previous attempt failed after removing it.
+	if err := svc.domains.Delete(ctx, id); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// checkPlatformAdmin authorizes the platform administrator,
+// who is the only one allowed to set the domain quotas.
+func (svc service) checkPlatformAdmin(ctx context.Context, token string) error {
+	return svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Permission:  AdminPermission,
+		Object:      MagistralaObject,
+		ObjectType:  PlatformType,
+	})
+}
+
+// IMPROVEMENT NOTE: Take decision: Only Patform admin or both Patform and domain admins can see others users domain.
+func (svc service) ListUserDomains(ctx context.Context, token, userID string, p Page) (DomainsPage, error) {
+	res, err := svc.Identify(ctx, token)
+	if err != nil {
+		return DomainsPage{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Permission:  AdminPermission,
+		Object:      MagistralaObject,
+		ObjectType:  PlatformType,
+	}); err != nil {
+		return DomainsPage{}, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if userID != "" && res.User != userID {
+		p.SubjectID = userID
+	} else {
+		p.SubjectID = res.User
+	}
+	dp, err := svc.domains.ListDomains(ctx, p)
+	if err != nil {
+		return DomainsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	return dp, nil
+}
+
+func (svc service) addDomainPolicies(ctx context.Context, domainID, relation string, cond *Condition, userIDs ...string) (err error) {
+	var prs []PolicyReq
+	var pcs []Policy
+
+	for _, userID := range userIDs {
+		prs = append(prs, PolicyReq{
+			Subject:     EncodeDomainUserID(domainID, userID),
+			SubjectType: UserType,
+			SubjectKind: UsersKind,
+			Relation:    relation,
+			Object:      domainID,
+			ObjectType:  DomainType,
+			Condition:   cond,
+		})
+		pcs = append(pcs, Policy{
+			SubjectType: UserType,
+			SubjectID:   userID,
+			Relation:    relation,
+			ObjectType:  DomainType,
+			ObjectID:    domainID,
+		})
+	}
+	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	defer func() {
+		if err != nil {
+			if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
+				err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
+			}
+		}
+	}()
+
+	if cps := conditionalPolicies(prs); len(cps) > 0 {
+		if err = svc.conditions.Save(ctx, cps); err != nil {
+			return errors.Wrap(errAddPolicies, err)
+		}
+		defer func() {
+			if err != nil {
+				if errDel := svc.conditions.Remove(ctx, cps); errDel != nil {
+					err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
+				}
+			}
+		}()
+	}
+
+	if err = svc.domains.SavePolicies(ctx, pcs...); err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	return nil
+}
+
+func (svc service) createDomainPolicy(ctx context.Context, userID, domainID, relation string) (err error) {
+	prs := []PolicyReq{
+		{
+			Subject:     EncodeDomainUserID(domainID, userID),
+			SubjectType: UserType,
+			SubjectKind: UsersKind,
+			Relation:    relation,
+			Object:      domainID,
+			ObjectType:  DomainType,
+		},
+		{
+			Subject:     MagistralaObject,
+			SubjectType: PlatformType,
+			Relation:    PlatformRelation,
+			Object:      domainID,
+			ObjectType:  DomainType,
+		},
+	}
+	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
+				err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
+			}
+		}
+	}()
+	err = svc.domains.SavePolicies(ctx, Policy{
+		SubjectType: UserType,
+		SubjectID:   userID,
+		Relation:    relation,
+		ObjectType:  DomainType,
+		ObjectID:    domainID,
+	})
+	if err != nil {
+		return errors.Wrap(errCreateDomainPolicy, err)
+	}
+	return err
+}
+
+func (svc service) createDomainPolicyRollback(ctx context.Context, userID, domainID, relation string) error {
+	var err error
+	prs := []PolicyReq{
+		{
+			Subject:     EncodeDomainUserID(domainID, userID),
+			SubjectType: UserType,
+			SubjectKind: UsersKind,
+			Relation:    relation,
+			Object:      domainID,
+			ObjectType:  DomainType,
+		},
+		{
+			Subject:     MagistralaObject,
+			SubjectType: PlatformType,
+			Relation:    PlatformRelation,
+			Object:      domainID,
+			ObjectType:  DomainType,
+		},
+	}
+	if errPolicy := svc.agent.DeletePolicies(ctx, prs); errPolicy != nil {
+		err = errors.Wrap(errRemovePolicyEngine, errPolicy)
+	}
+	errPolicyCopy := svc.domains.DeletePolicies(ctx, Policy{
+		SubjectType: UserType,
+		SubjectID:   userID,
+		Relation:    relation,
+		ObjectType:  DomainType,
+		ObjectID:    domainID,
+	})
+	if errPolicyCopy != nil {
+		err = errors.Wrap(err, errors.Wrap(errRemoveLocalPolicy, errPolicyCopy))
+	}
+	return err
+}
+
+func (svc service) CreateRole(ctx context.Context, token, domainID string, r Role) (Role, error) {
+	key, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission)
+	if err != nil {
+		return Role{}, err
+	}
+	if err := r.Validate(); err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	r.ID = id
+	r.DomainID = domainID
+	r.CreatedBy = key.User
+	r.CreatedAt = time.Now()
+	r.UpdatedBy = ""
+	r.UpdatedAt = time.Time{}
+
+	role, err := svc.roles.Save(ctx, r)
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return role, nil
+}
+
+func (svc service) RetrieveRole(ctx context.Context, token, domainID, id string) (Role, error) {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, MembershipPermission); err != nil {
+		return Role{}, err
+	}
+	role, err := svc.roles.RetrieveByID(ctx, domainID, id)
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return role, nil
+}
+
+func (svc service) ListRoles(ctx context.Context, token, domainID string, pm Page) (RolesPage, error) {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, MembershipPermission); err != nil {
+		return RolesPage{}, err
+	}
+	rp, err := svc.roles.RetrieveAll(ctx, domainID, pm)
+	if err != nil {
+		return RolesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return rp, nil
+}
+
+func (svc service) UpdateRole(ctx context.Context, token, domainID, id string, rr RoleReq) (Role, error) {
+	key, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission)
+	if err != nil {
+		return Role{}, err
+	}
+	role, err := svc.roles.RetrieveByID(ctx, domainID, id)
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	old := role
+	if rr.Name != nil {
+		role.Name = *rr.Name
+	}
+	if rr.Permissions != nil {
+		role.Permissions = *rr.Permissions
+	}
+	if err := role.Validate(); err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	role.UpdatedBy = key.User
+	role.UpdatedAt = time.Now()
+
+	role, err = svc.roles.Update(ctx, role)
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if rr.Permissions == nil {
+		return role, nil
+	}
+
+	// Rebind the role to the entities it is assigned on with the new permissions.
+	ras, err := svc.roles.RetrieveAssignments(ctx, id)
+	if err != nil {
+		return Role{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	for _, ra := range roleEntities(ras) {
+		if err := svc.agent.DeletePolicyFilter(ctx, roleBindingFilter(old.ID, ra.EntityType, ra.EntityID)); err != nil {
+			return Role{}, errors.Wrap(errRemovePolicies, err)
+		}
+		if prs := roleBindingPolicies(role, ra.EntityType, ra.EntityID); len(prs) > 0 {
+			if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+				return Role{}, errors.Wrap(errAddPolicies, err)
+			}
+		}
+	}
+
+	return role, nil
+}
+
+func (svc service) DeleteRole(ctx context.Context, token, domainID, id string) error {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission); err != nil {
+		return err
+	}
+	role, err := svc.roles.RetrieveByID(ctx, domainID, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	ras, err := svc.roles.RetrieveAssignments(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	for _, ra := range roleEntities(ras) {
+		if err := svc.removeRoleBinding(ctx, role, ra.EntityType, ra.EntityID); err != nil {
+			return err
+		}
+	}
+	if err := svc.roles.Delete(ctx, domainID, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) (err error) {
+	role, entityType, err := svc.authorizeRoleAssignment(ctx, token, domainID, id, entity, entityID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
 			Subject:     EncodeDomainUserID(domainID, userID),
 			SubjectType: UserType,
-			SubjectKind: UsersKind,
-			Relation:    relation,
-			Object:      domainID,
-			ObjectType:  DomainType,
-		},
-		{
-			Subject:     MagistralaObject,
-			SubjectType: PlatformType,
-			Relation:    PlatformRelation,
-			Object:      domainID,
+			Permission:  MembershipPermission,
+			Object:      domainID,
+			ObjectType:  DomainType,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("invalid user id : %s ", userID))
+		}
+	}
+
+	ras, err := svc.roles.RetrieveAssignments(ctx, id)
+	if err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	prs := roleMemberPolicies(role, entityID, userIDs)
+	// The first assignment on the entity binds the role to the entity.
+	if !hasRoleAssignment(ras, entityType, entityID) {
+		prs = append(prs, roleBindingPolicies(role, entityType, entityID)...)
+	}
+	if err := svc.agent.AddPolicies(ctx, prs); err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	defer func() {
+		if err != nil {
+			if errDel := svc.agent.DeletePolicies(ctx, prs); errDel != nil {
+				err = errors.Wrap(err, errors.Wrap(errRollbackPolicy, errDel))
+			}
+		}
+	}()
+
+	var assignments []RoleAssignment
+	for _, userID := range userIDs {
+		assignments = append(assignments, RoleAssignment{
+			RoleID:     id,
+			EntityType: entityType,
+			EntityID:   entityID,
+			UserID:     userID,
+		})
+	}
+	if err = svc.roles.SaveAssignments(ctx, assignments...); err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+
+	return nil
+}
+
+func (svc service) UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	role, entityType, err := svc.authorizeRoleAssignment(ctx, token, domainID, id, entity, entityID)
+	if err != nil {
+		return err
+	}
+	if err := svc.agent.DeletePolicies(ctx, roleMemberPolicies(role, entityID, userIDs)); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	var assignments []RoleAssignment
+	for _, userID := range userIDs {
+		assignments = append(assignments, RoleAssignment{
+			RoleID:     id,
+			EntityType: entityType,
+			EntityID:   entityID,
+			UserID:     userID,
+		})
+	}
+	if err := svc.roles.RemoveAssignments(ctx, assignments...); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	// The last unassignment on the entity unbinds the role from the entity.
+	ras, err := svc.roles.RetrieveAssignments(ctx, id)
+	if err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	if !hasRoleAssignment(ras, entityType, entityID) {
+		if err := svc.agent.DeletePolicyFilter(ctx, roleBindingFilter(role.ID, entityType, entityID)); err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) ListRoleAssignments(ctx context.Context, token, domainID, id string) ([]RoleAssignment, error) {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, MembershipPermission); err != nil {
+		return nil, err
+	}
+	if _, err := svc.roles.RetrieveByID(ctx, domainID, id); err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	ras, err := svc.roles.RetrieveAssignments(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return ras, nil
+}
+
+func (svc service) authorizeRoles(ctx context.Context, token, domainID, permission string) (Key, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Key{}, err
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Object:      domainID,
+		ObjectType:  DomainType,
+		Permission:  permission,
+	}); err != nil {
+		return Key{}, err
+	}
+
+	return key, nil
+}
+
+// authorizeRoleAssignment checks that the user is allowed to share the entity
+// of the role domain, and returns the role and the entity policy type.
+func (svc service) authorizeRoleAssignment(ctx context.Context, token, domainID, id, entity, entityID string) (Role, string, error) {
+	entityType, err := RoleEntityType(entity)
+	if err != nil {
+		return Role{}, "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := svc.Authorize(ctx, PolicyReq{
+		Subject:     token,
+		SubjectType: UserType,
+		SubjectKind: TokenKind,
+		Object:      entityID,
+		ObjectType:  entityType,
+		Permission:  SharePermission,
+	}); err != nil {
+		return Role{}, "", err
+	}
+	switch entityType {
+	case DomainType:
+		if entityID != domainID {
+			return Role{}, "", errors.Wrap(svcerr.ErrMalformedEntity, errors.New("role can't be assigned on other domain"))
+		}
+	case GroupType:
+		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
+			Subject:     domainID,
+			SubjectType: DomainType,
+			Permission:  DomainRelation,
+			Object:      entityID,
+			ObjectType:  GroupType,
+		}); err != nil {
+			return Role{}, "", errors.Wrap(svcerr.ErrMalformedEntity, errors.New("role can't be assigned on other domain group"))
+		}
+	}
+	role, err := svc.roles.RetrieveByID(ctx, domainID, id)
+	if err != nil {
+		return Role{}, "", errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return role, entityType, nil
+}
+
+// unbindRoles removes the bindings of the roles to the entities
+// of the removed assignments which have no assignments left.
+func (svc service) unbindRoles(ctx context.Context, removed []RoleAssignment) error {
+	for _, ra := range roleEntities(removed) {
+		ras, err := svc.roles.RetrieveAssignments(ctx, ra.RoleID)
+		if err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+		if hasRoleAssignment(ras, ra.EntityType, ra.EntityID) {
+			continue
+		}
+		if err := svc.agent.DeletePolicyFilter(ctx, roleBindingFilter(ra.RoleID, ra.EntityType, ra.EntityID)); err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) removeRoleBinding(ctx context.Context, role Role, entityType, entityID string) error {
+	if err := svc.agent.DeletePolicyFilter(ctx, roleBindingFilter(role.ID, entityType, entityID)); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	if err := svc.agent.DeletePolicyFilter(ctx, PolicyReq{
+		Object:     roleBindingID(role.ID, entityID),
+		ObjectType: RoleType,
+	}); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	return nil
+}
+
+// roleBindingID returns the ID of the binding of the role to the entity,
+// which is the policy object the users the role is assigned to are members of.
+func roleBindingID(roleID, entityID string) string {
+	return roleID + "_" + entityID
+}
+
+func roleBindingPolicies(role Role, entityType, entityID string) []PolicyReq {
+	var prs []PolicyReq
+	for _, rel := range role.relations(entityType) {
+		prs = append(prs, PolicyReq{
+			Subject:     roleBindingID(role.ID, entityID),
+			SubjectType: RoleType,
+			Relation:    rel,
+			Object:      entityID,
+			ObjectType:  entityType,
+		})
+	}
+
+	return prs
+}
+
+func roleBindingFilter(roleID, entityType, entityID string) PolicyReq {
+	return PolicyReq{
+		Subject:     roleBindingID(roleID, entityID),
+		SubjectType: RoleType,
+		Object:      entityID,
+		ObjectType:  entityType,
+	}
+}
+
+func roleMemberPolicies(role Role, entityID string, userIDs []string) []PolicyReq {
+	var prs []PolicyReq
+	for _, userID := range userIDs {
+		prs = append(prs, PolicyReq{
+			Subject:     EncodeDomainUserID(role.DomainID, userID),
+			SubjectType: UserType,
+			Relation:    MemberRelation,
+			Object:      roleBindingID(role.ID, entityID),
+			ObjectType:  RoleType,
+		})
+	}
+
+	return prs
+}
+
+func hasRoleAssignment(ras []RoleAssignment, entityType, entityID string) bool {
+	for _, ra := range ras {
+		if ra.EntityType == entityType && ra.EntityID == entityID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roleEntities returns the assignments of the distinct role entities.
+func roleEntities(ras []RoleAssignment) []RoleAssignment {
+	var entities []RoleAssignment
+	for _, ra := range ras {
+		if !slices.ContainsFunc(entities, func(e RoleAssignment) bool {
+			return e.RoleID == ra.RoleID && e.EntityType == ra.EntityType && e.EntityID == ra.EntityID
+		}) {
+			entities = append(entities, ra)
+		}
+	}
+
+	return entities
+}
+
+func (svc service) CreateClaimMapping(ctx context.Context, token, domainID string, m ClaimMapping) (ClaimMapping, error) {
+	key, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission)
+	if err != nil {
+		return ClaimMapping{}, err
+	}
+	if err := m.Validate(); err != nil {
+		return ClaimMapping{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	for _, groupID := range m.GroupIDs {
+		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
+			Subject:     domainID,
+			SubjectType: DomainType,
+			Permission:  DomainRelation,
+			Object:      groupID,
+			ObjectType:  GroupType,
+		}); err != nil {
+			return ClaimMapping{}, errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("invalid group id : %s ", groupID))
+		}
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return ClaimMapping{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	m.ID = id
+	m.DomainID = domainID
+	m.CreatedBy = key.User
+	m.CreatedAt = time.Now()
+
+	m, err = svc.claims.Save(ctx, m)
+	if err != nil {
+		return ClaimMapping{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return m, nil
+}
+
+func (svc service) RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (ClaimMapping, error) {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission); err != nil {
+		return ClaimMapping{}, err
+	}
+	m, err := svc.claims.RetrieveByID(ctx, domainID, id)
+	if err != nil {
+		return ClaimMapping{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return m, nil
+}
+
+func (svc service) ListClaimMappings(ctx context.Context, token, domainID string, pm Page) (ClaimMappingsPage, error) {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission); err != nil {
+		return ClaimMappingsPage{}, err
+	}
+	mp, err := svc.claims.RetrieveAll(ctx, domainID, pm)
+	if err != nil {
+		return ClaimMappingsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return mp, nil
+}
+
+func (svc service) DeleteClaimMapping(ctx context.Context, token, domainID, id string) error {
+	if _, err := svc.authorizeRoles(ctx, token, domainID, AdminPermission); err != nil {
+		return err
+	}
+	if err := svc.claims.Delete(ctx, domainID, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) error {
+	mappings, err := svc.claims.RetrieveByProvider(ctx, provider)
+	if err != nil {
+		return errors.Wrap(errApplyClaims, err)
+	}
+	grants, err := svc.claims.RetrieveGrants(ctx, userID, provider)
+	if err != nil {
+		return errors.Wrap(errApplyClaims, err)
+	}
+
+	var domainIDs []string
+	matched := make(map[string][]ClaimMapping)
+	for _, m := range mappings {
+		if !slices.Contains(groups, m.Group) {
+			continue
+		}
+		if _, ok := matched[m.DomainID]; !ok {
+			domainIDs = append(domainIDs, m.DomainID)
+		}
+		matched[m.DomainID] = append(matched[m.DomainID], m)
+	}
+
+	current := make(map[string]ClaimGrant)
+	for _, g := range grants {
+		if _, ok := matched[g.DomainID]; !ok {
+			if err := svc.revokeClaimGrant(ctx, g); err != nil {
+				return errors.Wrap(errApplyClaims, err)
+			}
+			continue
+		}
+		current[g.DomainID] = g
+	}
+
+	for _, domainID := range domainIDs {
+		g, ok := current[domainID]
+		if !ok {
+			g = ClaimGrant{DomainID: domainID, UserID: userID, Provider: provider}
+		}
+		if err := svc.grantClaimAccess(ctx, g, !ok, matched[domainID]); err != nil {
+			return errors.Wrap(errApplyClaims, err)
+		}
+	}
+
+	return nil
+}
+
+// grantClaimAccess updates the domain access granted to the user to match
+// the claim mappings. The relation of the user who was already a domain
+// member before the first grant is left unchanged.
+func (svc service) grantClaimAccess(ctx context.Context, g ClaimGrant, first bool, mappings []ClaimMapping) error {
+	relation, groupIDs := claimAccess(mappings)
+	domainUser := EncodeDomainUserID(g.DomainID, g.UserID)
+
+	switch {
+	case first:
+		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
+			Subject:     domainUser,
+			SubjectType: UserType,
+			Permission:  MembershipPermission,
+			Object:      g.DomainID,
 			ObjectType:  DomainType,
-		},
+		}); err == nil {
+			break
+		}
+		if err := svc.addDomainPolicies(ctx, g.DomainID, relation, nil, g.UserID); err != nil {
+			return err
+		}
+		g.Relation = relation
+	case g.Relation != "" && g.Relation != relation:
+		if err := svc.removeDomainRelation(ctx, g.DomainID, g.UserID, g.Relation); err != nil {
+			return err
+		}
+		if err := svc.addDomainPolicies(ctx, g.DomainID, relation, nil, g.UserID); err != nil {
+			return err
+		}
+		g.Relation = relation
 	}
-	if errPolicy := svc.agent.DeletePolicies(ctx, prs); errPolicy != nil {
-		err = errors.Wrap(errRemovePolicyEngine, errPolicy)
+
+	var added, removed []PolicyReq
+	var granted []string
+	for _, groupID := range groupIDs {
+		if slices.Contains(g.GroupIDs, groupID) {
+			granted = append(granted, groupID)
+			continue
+		}
+		// Membership assigned to the user manually is not managed by the mappings.
+		if err := svc.agent.CheckPolicy(ctx, PolicyReq{
+			Subject:     domainUser,
+			SubjectType: UserType,
+			Permission:  MembershipPermission,
+			Object:      groupID,
+			ObjectType:  GroupType,
+		}); err == nil {
+			continue
+		}
+		added = append(added, claimGroupPolicy(domainUser, groupID))
+		granted = append(granted, groupID)
 	}
-	errPolicyCopy := svc.domains.DeletePolicies(ctx, Policy{
+	for _, groupID := range g.GroupIDs {
+		if !slices.Contains(groupIDs, groupID) {
+			removed = append(removed, claimGroupPolicy(domainUser, groupID))
+		}
+	}
+	if len(added) > 0 {
+		if err := svc.agent.AddPolicies(ctx, added); err != nil {
+			return errors.Wrap(errAddPolicies, err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := svc.agent.DeletePolicies(ctx, removed); err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+	}
+	g.GroupIDs = granted
+
+	return svc.claims.SaveGrant(ctx, g)
+}
+
+// revokeClaimGrant revokes the domain access granted to the user by the
+// claim mappings which no longer match the user groups.
+func (svc service) revokeClaimGrant(ctx context.Context, g ClaimGrant) error {
+	if g.Relation != "" {
+		return svc.removeDomainUser(ctx, g.DomainID, g.UserID)
+	}
+
+	var prs []PolicyReq
+	for _, groupID := range g.GroupIDs {
+		prs = append(prs, claimGroupPolicy(EncodeDomainUserID(g.DomainID, g.UserID), groupID))
+	}
+	if len(prs) > 0 {
+		if err := svc.agent.DeletePolicies(ctx, prs); err != nil {
+			return errors.Wrap(errRemovePolicies, err)
+		}
+	}
+
+	return svc.claims.RemoveGrant(ctx, g.DomainID, g.UserID)
+}
+
+// removeDomainRelation removes the relation of the user on the domain,
+// keeping the rest of the user domain policies.
+func (svc service) removeDomainRelation(ctx context.Context, domainID, userID, relation string) error {
+	if err := svc.agent.DeletePolicies(ctx, []PolicyReq{{
+		Subject:     EncodeDomainUserID(domainID, userID),
+		SubjectType: UserType,
+		SubjectKind: UsersKind,
+		Relation:    relation,
+		Object:      domainID,
+		ObjectType:  DomainType,
+	}}); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+	if err := svc.domains.DeletePolicies(ctx, Policy{
 		SubjectType: UserType,
 		SubjectID:   userID,
 		Relation:    relation,
 		ObjectType:  DomainType,
 		ObjectID:    domainID,
-	})
-	if errPolicyCopy != nil {
-		err = errors.Wrap(err, errors.Wrap(errRemoveLocalPolicy, errPolicyCopy))
+	}); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	return nil
+}
+
+func claimGroupPolicy(domainUser, groupID string) PolicyReq {
+	return PolicyReq{
+		Subject:     domainUser,
+		SubjectType: UserType,
+		Relation:    MemberRelation,
+		Object:      groupID,
+		ObjectType:  GroupType,
 	}
-	return err
 }
 
 func EncodeDomainUserID(domainID, userID string) string {
@@ -1055,11 +2680,63 @@ func (svc service) DeleteEntityPolicies(ctx context.Context, entityType, id stri
 			return err
 		}
 
+		ras, err := svc.roles.RetrieveEntityAssignments(ctx, GroupType, id)
+		if err != nil {
+			return err
+		}
+		for _, ra := range roleEntities(ras) {
+			req := PolicyReq{
+				Object:     roleBindingID(ra.RoleID, id),
+				ObjectType: RoleType,
+			}
+			if err := svc.DeletePolicyFilter(ctx, req); err != nil {
+				return err
+			}
+		}
+		if len(ras) > 0 {
+			if err := svc.roles.RemoveAssignments(ctx, ras...); err != nil {
+				return err
+			}
+		}
+
 		req = PolicyReq{
 			Object:     id,
 			ObjectType: GroupType,
 		}
 		return svc.DeletePolicyFilter(ctx, req)
+	case DomainType:
+		members, err := svc.agent.RetrieveAllSubjects(ctx, PolicyReq{
+			SubjectType: UserType,
+			Permission:  MembershipPermission,
+			Object:      id,
+			ObjectType:  DomainType,
+		})
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			req := PolicyReq{
+				Subject:     EncodeDomainUserID(id, m.Subject),
+				SubjectType: UserType,
+			}
+			if err := svc.DeletePolicyFilter(ctx, req); err != nil {
+				return err
+			}
+		}
+
+		req := PolicyReq{
+			Subject:     id,
+			SubjectType: DomainType,
+		}
+		if err := svc.DeletePolicyFilter(ctx, req); err != nil {
+			return err
+		}
+
+		req = PolicyReq{
+			Object:     id,
+			ObjectType: DomainType,
+		}
+		return svc.DeletePolicyFilter(ctx, req)
 	default:
 		return errInvalidEntityType
 	}
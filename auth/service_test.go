@@ -12,6 +12,8 @@ import (
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/auth/jwt"
 	"github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
@@ -58,15 +60,26 @@ var (
 )
 
 var (
-	krepo *mocks.KeyRepository
-	prepo *mocks.PolicyAgent
-	drepo *mocks.DomainsRepository
+	krepo  *mocks.KeyRepository
+	prepo  *mocks.PolicyAgent
+	drepo  *mocks.DomainsRepository
+	srepo  *mocks.SessionRepository
+	trepo  *mocks.TokenRepository
+	rrepo  *mocks.RolesRepository
+	crepo  *mocks.ConditionsRepository
+	cmrepo *mocks.ClaimMappingsRepository
+	urepo  *mocks.UsageRepository
 )
 
 func newService() (auth.Service, string) {
 	krepo = new(mocks.KeyRepository)
 	prepo = new(mocks.PolicyAgent)
 	drepo = new(mocks.DomainsRepository)
+	rrepo = new(mocks.RolesRepository)
+	crepo = newConditionsMock()
+	cmrepo = newClaimsMock()
+	urepo = new(mocks.UsageRepository)
+	srepo, trepo = newSessionMocks()
 	idProvider := uuid.NewMock()
 
 	t := jwt.New([]byte(secret))
@@ -80,7 +93,41 @@ func newService() (auth.Service, string) {
 	}
 	token, _ := t.Issue(key)
 
-	return auth.New(krepo, drepo, idProvider, t, prepo, loginDuration, refreshDuration, invalidDuration), token
+	return auth.New(krepo, drepo, srepo, trepo, rrepo, crepo, cmrepo, urepo, idProvider, t, prepo, loginDuration, refreshDuration, invalidDuration), token
+}
+
+// newSessionMocks returns the session and token repositories
+// with no revoked tokens.
+func newSessionMocks() (*mocks.SessionRepository, *mocks.TokenRepository) {
+	sessions := new(mocks.SessionRepository)
+	sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
+	sessions.On("Retrieve", mock.Anything, mock.Anything, mock.Anything).Return(auth.Session{}, repoerr.ErrNotFound)
+	tokens := new(mocks.TokenRepository)
+	tokens.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+	tokens.On("Generation", mock.Anything, mock.Anything).Return(uint64(0), nil)
+
+	return sessions, tokens
+}
+
+// newConditionsMock returns the conditions repository
+// with no conditional policies.
+func newConditionsMock() *mocks.ConditionsRepository {
+	conditions := new(mocks.ConditionsRepository)
+	conditions.On("Exists", mock.Anything).Return(false, nil)
+	conditions.On("RetrieveBySteps", mock.Anything, mock.Anything).Return(nil, nil)
+	conditions.On("Remove", mock.Anything, mock.Anything).Return(nil)
+	conditions.On("RemoveFilter", mock.Anything, mock.Anything).Return(nil)
+
+	return conditions
+}
+
+// newClaimsMock returns the claim mappings repository
+// with no claim grants.
+func newClaimsMock() *mocks.ClaimMappingsRepository {
+	claims := new(mocks.ClaimMappingsRepository)
+	claims.On("RemoveGrant", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return claims
 }
 
 func TestIssue(t *testing.T) {
@@ -633,10 +680,12 @@ func TestIdentify(t *testing.T) {
 
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+	domainCall := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName}, nil)
 	loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now(), Domain: groupName})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall.Unset()
 	repocall1.Unset()
+	domainCall.Unset()
 
 	repocall2 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	recoverySecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RecoveryKey, IssuedAt: time.Now(), Subject: id})
@@ -736,6 +785,7 @@ func TestIdentify(t *testing.T) {
 func TestAuthorize(t *testing.T) {
 	svc, accessToken := newService()
 
+	domainCall := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName}, nil)
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
 	loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now(), Domain: groupName})
@@ -754,6 +804,7 @@ func TestAuthorize(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall2.Unset()
 	repocall3.Unset()
+	domainCall.Unset()
 
 	te := jwt.New([]byte(secret))
 	key := auth.Key{
@@ -789,7 +840,7 @@ func TestAuthorize(t *testing.T) {
 			},
 			checkPolicyReq3: auth.PolicyReq{
 				Domain:      groupName,
-				Subject:     id,
+				Subject:     email,
 				SubjectType: auth.UserType,
 				SubjectKind: auth.TokenKind,
 				Object:      auth.MagistralaObject,
@@ -1044,7 +1095,7 @@ func TestAuthorize(t *testing.T) {
 				Permission:  auth.AdminPermission,
 			},
 			checkPolicyReq3: auth.PolicyReq{
-				Subject:     id,
+				Subject:     email,
 				SubjectType: auth.UserType,
 				Object:      auth.MagistralaObject,
 				ObjectType:  auth.PlatformType,
@@ -1197,6 +1248,279 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestAuthorizeScopedKey(t *testing.T) {
+	svc, accessToken := newService()
+
+	thingID := testsutil.GenerateUUID(t)
+	scopes := []auth.Scope{
+		{Operation: "things:view", EntityIDs: []string{thingID}},
+		{Operation: "channels:publish"},
+	}
+	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	apiToken, err := svc.Issue(context.Background(), accessToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: scopes})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+	repocall.Unset()
+
+	repocall = krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	_, err = svc.Issue(context.Background(), accessToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: []auth.Scope{{Operation: "things"}}})
+	assert.True(t, errors.Contains(err, auth.ErrInvalidScope), fmt.Sprintf("Issuing API key with invalid scope expected %s got %s", auth.ErrInvalidScope, err))
+	repocall.Unset()
+
+	cases := []struct {
+		desc     string
+		policy   auth.PolicyReq
+		checkErr error
+		err      error
+	}{
+		{
+			desc: "authorize scoped key to view the thing of the scope",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.ThingType,
+				Object:      thingID,
+			},
+			err: nil,
+		},
+		{
+			desc: "authorize scoped key to view the thing outside of the scope",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.ThingType,
+				Object:      testsutil.GenerateUUID(t),
+			},
+			err: svcerr.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to delete the thing of the scope",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.DeletePermission,
+				ObjectType:  auth.ThingType,
+				Object:      thingID,
+			},
+			err: svcerr.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to create things in the domain",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.CreatePermission,
+				ObjectType:  auth.DomainType,
+				Object:      groupName,
+			},
+			err: svcerr.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key as platform administrator",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.AdminPermission,
+				ObjectType:  auth.PlatformType,
+				Object:      auth.MagistralaObject,
+			},
+			err: svcerr.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to publish to any channel",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.PublishPermission,
+				ObjectType:  auth.GroupType,
+				Object:      testsutil.GenerateUUID(t),
+			},
+			err: nil,
+		},
+		{
+			desc: "authorize scoped key without the user permission",
+			policy: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.ThingType,
+				Object:      thingID,
+			},
+			checkErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrDomainAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := krepo.On("Retrieve", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{Scopes: scopes}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkErr)
+		repoCall2 := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{Status: auth.EnabledStatus}, nil)
+		err := svc.Authorize(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestExplainPolicy(t *testing.T) {
+	svc, accessToken := newService()
+
+	pr := auth.PolicyReq{
+		Permission: auth.ViewPermission,
+		ObjectType: auth.ThingType,
+		Object:     validID,
+	}
+	path := []auth.PolicyStep{
+		{SubjectType: auth.UserType, Subject: id, Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: validID},
+		{SubjectType: auth.GroupType, Subject: validID, Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: validID},
+	}
+	required := []auth.PolicyStep{
+		{SubjectType: auth.UserType, Subject: id, Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: validID},
+	}
+
+	cases := []struct {
+		desc       string
+		token      string
+		subject    string
+		agentPr    auth.PolicyReq
+		agentRes   auth.PolicyExplanation
+		agentErr   error
+		checkErr   error
+		domainErr  error
+		res        auth.PolicyExplanation
+		withReason bool
+		err        error
+	}{
+		{
+			desc:     "explain allowed permission",
+			token:    accessToken,
+			agentPr:  auth.PolicyReq{Domain: groupName, Subject: id, SubjectType: auth.UserType, Permission: pr.Permission, ObjectType: pr.ObjectType, Object: pr.Object},
+			agentRes: auth.PolicyExplanation{Allowed: true, Path: path},
+			res:      auth.PolicyExplanation{Allowed: true, Path: path},
+		},
+		{
+			desc:     "explain denied permission",
+			token:    accessToken,
+			agentPr:  auth.PolicyReq{Domain: groupName, Subject: id, SubjectType: auth.UserType, Permission: pr.Permission, ObjectType: pr.ObjectType, Object: pr.Object},
+			agentRes: auth.PolicyExplanation{Required: required},
+			res:      auth.PolicyExplanation{Required: required},
+		},
+		{
+			desc:       "explain permission denied by domain",
+			token:      accessToken,
+			agentPr:    auth.PolicyReq{Domain: groupName, Subject: id, SubjectType: auth.UserType, Permission: pr.Permission, ObjectType: pr.ObjectType, Object: pr.Object},
+			agentRes:   auth.PolicyExplanation{Allowed: true, Path: path},
+			domainErr:  repoerr.ErrNotFound,
+			res:        auth.PolicyExplanation{Path: path},
+			withReason: true,
+		},
+		{
+			desc:     "explain permission of other user",
+			token:    accessToken,
+			subject:  validID,
+			agentPr:  auth.PolicyReq{Domain: groupName, Subject: auth.EncodeDomainUserID(groupName, validID), SubjectType: auth.UserType, Permission: pr.Permission, ObjectType: pr.ObjectType, Object: pr.Object},
+			agentRes: auth.PolicyExplanation{Required: required},
+			res:      auth.PolicyExplanation{Required: required},
+		},
+		{
+			desc:     "explain permission of other user without admin permission",
+			token:    accessToken,
+			subject:  validID,
+			checkErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:  "explain with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "explain with failed to explain",
+			token:    accessToken,
+			agentPr:  auth.PolicyReq{Domain: groupName, Subject: id, SubjectType: auth.UserType, Permission: pr.Permission, ObjectType: pr.ObjectType, Object: pr.Object},
+			agentErr: repoerr.ErrViewEntity,
+			err:      repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := pr
+		req.Subject = tc.subject
+		repoCall := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkErr)
+		repoCall1 := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName, Status: auth.EnabledStatus}, tc.domainErr)
+		repoCall2 := prepo.On("ExplainPolicy", mock.Anything, tc.agentPr).Return(tc.agentRes, tc.agentErr)
+		res, err := svc.ExplainPolicy(context.Background(), tc.token, req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.withReason, res.Reason != "", fmt.Sprintf("%s: unexpected denial reason %q", tc.desc, res.Reason))
+			res.Reason = ""
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	svc, accessToken := newService()
+
+	keys := []auth.Key{
+		{ID: testsutil.GenerateUUID(t), Type: auth.APIKey, Subject: id, Scopes: []auth.Scope{{Operation: "things:*"}}},
+		{ID: testsutil.GenerateUUID(t), Type: auth.APIKey, Subject: id},
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		pm      auth.Page
+		repoRes auth.KeyPage
+		repoErr error
+		res     auth.KeyPage
+		err     error
+	}{
+		{
+			desc:    "list keys successfully",
+			token:   accessToken,
+			pm:      auth.Page{Offset: 0, Limit: 10},
+			repoRes: auth.KeyPage{Total: 2, Limit: 10, Keys: keys},
+			res:     auth.KeyPage{Total: 2, Limit: 10, Keys: keys},
+		},
+		{
+			desc:  "list keys with invalid token",
+			token: inValidToken,
+			pm:    auth.Page{Offset: 0, Limit: 10},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "list keys with failed repository",
+			token:   accessToken,
+			pm:      auth.Page{Offset: 0, Limit: 10},
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := tc.pm
+		pm.SubjectID = id
+		repoCall := krepo.On("RetrieveAll", mock.Anything, pm).Return(tc.repoRes, tc.repoErr)
+		page, err := svc.ListKeys(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, page, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.res, page))
+		repoCall.Unset()
+	}
+}
+
 func TestAddPolicy(t *testing.T) {
 	svc, _ := newService()
 
@@ -1405,97 +1729,401 @@ func TestDeletePolicies(t *testing.T) {
 	}
 }
 
-func TestListObjects(t *testing.T) {
-	svc, accessToken := newService()
+// newConditionalService returns the service with no
+// expectations on the conditions repository.
+func newConditionalService() auth.Service {
+	newService()
+	crepo = new(mocks.ConditionsRepository)
 
-	pageLen := 15
-	expectedPolicies := make([]auth.PolicyRes, pageLen)
+	return auth.New(krepo, drepo, srepo, trepo, rrepo, crepo, cmrepo, urepo, uuid.NewMock(), jwt.New([]byte(secret)), prepo, loginDuration, refreshDuration, invalidDuration)
+}
+
+func TestAddConditionalPolicy(t *testing.T) {
+	svc := newConditionalService()
+
+	pr := auth.PolicyReq{
+		Subject:     auth.EncodeDomainUserID(groupName, validID),
+		SubjectType: auth.UserType,
+		Relation:    auth.GuestRelation,
+		Object:      validID,
+		ObjectType:  auth.ThingType,
+	}
 
 	cases := []struct {
-		desc          string
-		pr            auth.PolicyReq
-		nextPageToken string
-		limit         uint64
-		err           error
+		desc      string
+		condition *auth.Condition
+		saveErr   error
+		deleteErr error
+		err       error
 	}{
 		{
-			desc: "list objects successfully",
-			pr: auth.PolicyReq{
-				Subject:     id,
-				SubjectType: auth.UserType,
-				Relation:    auth.ContributorRelation,
-				ObjectType:  auth.ThingType,
-				ObjectKind:  auth.ThingsKind,
-				Object:      "",
-			},
-			nextPageToken: accessToken,
-			limit:         10,
-			err:           nil,
+			desc:      "add policy with expiration successfully",
+			condition: &auth.Condition{ExpiresAt: time.Now().Add(time.Hour)},
+			err:       nil,
 		},
 		{
-			desc: "list objects with invalid request",
-			pr: auth.PolicyReq{
-				Subject:     inValid,
-				SubjectType: inValid,
-				Relation:    auth.ContributorRelation,
-				ObjectType:  auth.ThingType,
-				ObjectKind:  auth.ThingsKind,
-				Object:      inValid,
-			},
-			nextPageToken: accessToken,
-			limit:         10,
-			err:           svcerr.ErrInvalidPolicy,
+			desc:      "add policy with allowed networks successfully",
+			condition: &auth.Condition{AllowedCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"}},
+			err:       nil,
+		},
+		{
+			desc:      "add policy with past expiration",
+			condition: &auth.Condition{ExpiresAt: time.Now().Add(-time.Hour)},
+			err:       svcerr.ErrInvalidPolicy,
+		},
+		{
+			desc:      "add policy with invalid network",
+			condition: &auth.Condition{AllowedCIDRs: []string{inValid}},
+			err:       svcerr.ErrInvalidPolicy,
+		},
+		{
+			desc:      "add policy with failed to save condition",
+			condition: &auth.Condition{ExpiresAt: time.Now().Add(time.Hour)},
+			saveErr:   repoerr.ErrCreateEntity,
+			err:       repoerr.ErrCreateEntity,
+		},
+		{
+			desc:      "add policy with failed to save condition and rollback",
+			condition: &auth.Condition{ExpiresAt: time.Now().Add(time.Hour)},
+			saveErr:   repoerr.ErrCreateEntity,
+			deleteErr: errors.ErrMalformedEntity,
+			err:       errors.ErrMalformedEntity,
 		},
 	}
+
 	for _, tc := range cases {
-		repocall2 := prepo.On("RetrieveObjects", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(expectedPolicies, mock.Anything, tc.err)
-		page, err := svc.ListObjects(context.Background(), tc.pr, tc.nextPageToken, tc.limit)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("listing policies expected to succeed: %s", err))
-		if err == nil {
-			assert.Equal(t, pageLen, len(page.Policies), fmt.Sprintf("unexpected listing page size, expected %d, got %d: %v", pageLen, len(page.Policies), err))
-		}
-		repocall2.Unset()
+		pr.Condition = tc.condition
+		repoCall := prepo.On("AddPolicy", mock.Anything, pr).Return(nil)
+		repoCall1 := crepo.On("Save", mock.Anything, []auth.ConditionalPolicy{{
+			SubjectType: pr.SubjectType,
+			Subject:     pr.Subject,
+			Relation:    pr.Relation,
+			ObjectType:  pr.ObjectType,
+			Object:      pr.Object,
+			Condition:   *tc.condition,
+		}}).Return(tc.saveErr)
+		repoCall2 := prepo.On("DeletePolicies", mock.Anything, []auth.PolicyReq{pr}).Return(tc.deleteErr)
+		err := svc.AddPolicy(context.Background(), pr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
 	}
 }
 
-func TestListAllObjects(t *testing.T) {
-	svc, accessToken := newService()
+func TestAuthorizeConditionalPolicy(t *testing.T) {
+	svc := newConditionalService()
 
-	pageLen := 15
-	expectedPolicies := make([]auth.PolicyRes, pageLen)
+	pr := auth.PolicyReq{
+		Domain:      groupName,
+		Subject:     id,
+		SubjectType: auth.UserType,
+		SubjectKind: auth.UsersKind,
+		Object:      validID,
+		ObjectType:  auth.ThingType,
+		Permission:  auth.ViewPermission,
+	}
+	// The user is granted the permission on the thing
+	// through the conditional membership in the group.
+	path := []auth.PolicyStep{
+		{SubjectType: auth.UserType, Subject: id, Relation: auth.MemberRelation, ObjectType: auth.GroupType, Object: validID},
+		{SubjectType: auth.GroupType, Subject: validID, Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: validID},
+	}
+	// The conditions of the subject domain membership are checked as well.
+	steps := append(path, auth.PolicyStep{SubjectType: auth.UserType, Subject: id, ObjectType: auth.DomainType, Object: groupName})
+	cp := auth.ConditionalPolicy{
+		SubjectType: auth.UserType,
+		Subject:     id,
+		Relation:    auth.MemberRelation,
+		ObjectType:  auth.GroupType,
+		Object:      validID,
+	}
+	expired := cp
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	active := cp
+	active.ExpiresAt = time.Now().Add(time.Hour)
+	active.AllowedCIDRs = []string{"10.0.0.0/8"}
 
 	cases := []struct {
-		desc          string
-		pr            auth.PolicyReq
-		nextPageToken string
-		limit         int32
-		err           error
+		desc        string
+		sourceIP    string
+		ctxIP       string
+		exists      bool
+		existsErr   error
+		explainErr  error
+		retrieveRes []auth.ConditionalPolicy
+		retrieveErr error
+		err         error
 	}{
 		{
-			desc: "list all objects successfully",
-			pr: auth.PolicyReq{
-				Subject:     id,
-				SubjectType: auth.UserType,
-				Relation:    auth.ContributorRelation,
-				ObjectType:  auth.ThingType,
-				ObjectKind:  auth.ThingsKind,
-				Object:      "",
-			},
-			nextPageToken: accessToken,
-			limit:         10,
-			err:           nil,
+			desc:   "authorize policy without conditional policies",
+			exists: false,
+			err:    nil,
 		},
 		{
-			desc: "list all objects with invalid request",
-			pr: auth.PolicyReq{
-				Subject:     inValid,
-				SubjectType: inValid,
-				Relation:    auth.ContributorRelation,
-				ObjectType:  auth.ThingType,
-				ObjectKind:  auth.ThingsKind,
-				Object:      inValid,
-			},
-			nextPageToken: accessToken,
+			desc:        "authorize policy without conditions on the path",
+			exists:      true,
+			retrieveRes: nil,
+			err:         nil,
+		},
+		{
+			desc:        "authorize policy with met conditions",
+			sourceIP:    "10.1.2.3",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{active},
+			err:         nil,
+		},
+		{
+			desc:        "authorize policy with met conditions and source IP from context",
+			ctxIP:       "10.1.2.3",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{active},
+			err:         nil,
+		},
+		{
+			desc:        "authorize policy granted through expired group membership",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{expired},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "authorize policy from not allowed network",
+			sourceIP:    "192.168.1.1",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{active},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "authorize policy from not allowed network in context",
+			ctxIP:       "192.168.1.1",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{active},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "authorize policy with allowed networks without source IP",
+			exists:      true,
+			retrieveRes: []auth.ConditionalPolicy{active},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "authorize policy with failed to check conditional policies",
+			existsErr: repoerr.ErrViewEntity,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "authorize policy with failed to explain policy",
+			exists:     true,
+			explainErr: svcerr.ErrMalformedEntity,
+			err:        svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "authorize policy with failed to retrieve conditions",
+			exists:      true,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		pr.SourceIP = tc.sourceIP
+		checkPr := pr
+		if checkPr.SourceIP == "" {
+			checkPr.SourceIP = tc.ctxIP
+		}
+		ctx := apiutil.WithSourceIP(context.Background(), tc.ctxIP)
+		repoCall := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+		repoCall1 := crepo.On("Exists", mock.Anything).Return(tc.exists, tc.existsErr)
+		repoCall2 := prepo.On("ExplainPolicy", mock.Anything, checkPr).Return(auth.PolicyExplanation{Allowed: true, Path: path}, tc.explainErr)
+		repoCall3 := crepo.On("RetrieveBySteps", mock.Anything, steps).Return(tc.retrieveRes, tc.retrieveErr)
+		repoCall4 := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{Status: auth.EnabledStatus}, nil)
+		err := svc.Authorize(ctx, pr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestExpirePolicies(t *testing.T) {
+	svc := newConditionalService()
+
+	thingPolicy := auth.ConditionalPolicy{
+		SubjectType: auth.UserType,
+		Subject:     auth.EncodeDomainUserID(groupName, validID),
+		Relation:    auth.GuestRelation,
+		ObjectType:  auth.ThingType,
+		Object:      validID,
+		Condition:   auth.Condition{ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+	domainPolicy := auth.ConditionalPolicy{
+		SubjectType: auth.UserType,
+		Subject:     auth.EncodeDomainUserID(groupName, validID),
+		Relation:    auth.MemberRelation,
+		ObjectType:  auth.DomainType,
+		Object:      groupName,
+		Condition:   auth.Condition{ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+
+	cases := []struct {
+		desc          string
+		retrieveRes   []auth.ConditionalPolicy
+		retrieveErr   error
+		deleteErr     error
+		deleteCopyErr error
+		res           []auth.ConditionalPolicy
+		err           error
+	}{
+		{
+			desc: "expire policies with no expired policies",
+			res:  nil,
+			err:  nil,
+		},
+		{
+			desc:        "expire shared thing policy successfully",
+			retrieveRes: []auth.ConditionalPolicy{thingPolicy},
+			res:         []auth.ConditionalPolicy{thingPolicy},
+			err:         nil,
+		},
+		{
+			desc:        "expire domain membership successfully",
+			retrieveRes: []auth.ConditionalPolicy{domainPolicy},
+			res:         []auth.ConditionalPolicy{domainPolicy},
+			err:         nil,
+		},
+		{
+			desc:        "expire policies with failed to retrieve expired policies",
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "expire policies with failed to delete policy",
+			retrieveRes: []auth.ConditionalPolicy{thingPolicy, domainPolicy},
+			deleteErr:   errors.ErrMalformedEntity,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:          "expire domain membership with failed to delete policy copy",
+			retrieveRes:   []auth.ConditionalPolicy{domainPolicy},
+			deleteCopyErr: repoerr.ErrRemoveEntity,
+			err:           repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := crepo.On("RetrieveExpired", mock.Anything, mock.Anything, uint64(100)).Return(tc.retrieveRes, tc.retrieveErr)
+		repoCall1 := prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deleteErr)
+		repoCall2 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deleteErr)
+		repoCall3 := crepo.On("Remove", mock.Anything, mock.Anything).Return(nil)
+		repoCall4 := crepo.On("RemoveFilter", mock.Anything, mock.Anything).Return(nil)
+		repoCall5 := rrepo.On("RemoveUserAssignments", mock.Anything, groupName, validID).Return(nil, nil)
+		repoCall6 := drepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deleteCopyErr)
+		res, err := svc.ExpirePolicies(context.Background())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.res, res))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+	}
+}
+
+func TestListObjects(t *testing.T) {
+	svc, accessToken := newService()
+
+	pageLen := 15
+	expectedPolicies := make([]auth.PolicyRes, pageLen)
+
+	cases := []struct {
+		desc          string
+		pr            auth.PolicyReq
+		nextPageToken string
+		limit         uint64
+		err           error
+	}{
+		{
+			desc: "list objects successfully",
+			pr: auth.PolicyReq{
+				Subject:     id,
+				SubjectType: auth.UserType,
+				Relation:    auth.ContributorRelation,
+				ObjectType:  auth.ThingType,
+				ObjectKind:  auth.ThingsKind,
+				Object:      "",
+			},
+			nextPageToken: accessToken,
+			limit:         10,
+			err:           nil,
+		},
+		{
+			desc: "list objects with invalid request",
+			pr: auth.PolicyReq{
+				Subject:     inValid,
+				SubjectType: inValid,
+				Relation:    auth.ContributorRelation,
+				ObjectType:  auth.ThingType,
+				ObjectKind:  auth.ThingsKind,
+				Object:      inValid,
+			},
+			nextPageToken: accessToken,
+			limit:         10,
+			err:           svcerr.ErrInvalidPolicy,
+		},
+	}
+	for _, tc := range cases {
+		repocall2 := prepo.On("RetrieveObjects", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(expectedPolicies, mock.Anything, tc.err)
+		page, err := svc.ListObjects(context.Background(), tc.pr, tc.nextPageToken, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("listing policies expected to succeed: %s", err))
+		if err == nil {
+			assert.Equal(t, pageLen, len(page.Policies), fmt.Sprintf("unexpected listing page size, expected %d, got %d: %v", pageLen, len(page.Policies), err))
+		}
+		repocall2.Unset()
+	}
+}
+
+func TestListAllObjects(t *testing.T) {
+	svc, accessToken := newService()
+
+	pageLen := 15
+	expectedPolicies := make([]auth.PolicyRes, pageLen)
+
+	cases := []struct {
+		desc          string
+		pr            auth.PolicyReq
+		nextPageToken string
+		limit         int32
+		err           error
+	}{
+		{
+			desc: "list all objects successfully",
+			pr: auth.PolicyReq{
+				Subject:     id,
+				SubjectType: auth.UserType,
+				Relation:    auth.ContributorRelation,
+				ObjectType:  auth.ThingType,
+				ObjectKind:  auth.ThingsKind,
+				Object:      "",
+			},
+			nextPageToken: accessToken,
+			limit:         10,
+			err:           nil,
+		},
+		{
+			desc: "list all objects with invalid request",
+			pr: auth.PolicyReq{
+				Subject:     inValid,
+				SubjectType: inValid,
+				Relation:    auth.ContributorRelation,
+				ObjectType:  auth.ThingType,
+				ObjectKind:  auth.ThingsKind,
+				Object:      inValid,
+			},
+			nextPageToken: accessToken,
 			limit:         10,
 			err:           svcerr.ErrInvalidPolicy,
 		},
@@ -1511,6 +2139,91 @@ func TestListAllObjects(t *testing.T) {
 	}
 }
 
+func TestListAllObjectsScopedKey(t *testing.T) {
+	svc, accessToken := newService()
+
+	thingID := testsutil.GenerateUUID(t)
+	scopes := []auth.Scope{
+		{Operation: "things:view", EntityIDs: []string{thingID}},
+		{Operation: "channels:view"},
+	}
+	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	apiToken, err := svc.Issue(context.Background(), accessToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: scopes})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+	repocall.Unset()
+
+	groupID := testsutil.GenerateUUID(t)
+	policies := []auth.PolicyRes{{Object: thingID}, {Object: testsutil.GenerateUUID(t)}}
+
+	cases := []struct {
+		desc     string
+		pr       auth.PolicyReq
+		policies []auth.PolicyRes
+		objects  []string
+		err      error
+	}{
+		{
+			desc: "list things of the scope with scoped key",
+			pr: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.ThingType,
+			},
+			policies: policies,
+			objects:  []string{thingID},
+		},
+		{
+			desc: "list things outside of the scope permission with scoped key",
+			pr: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.EditPermission,
+				ObjectType:  auth.ThingType,
+			},
+			policies: policies,
+			objects:  nil,
+		},
+		{
+			desc: "list channels of the unrestricted scope with scoped key",
+			pr: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     apiToken.AccessToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.GroupType,
+			},
+			policies: []auth.PolicyRes{{Object: groupID}},
+			objects:  []string{groupID},
+		},
+		{
+			desc: "list things with invalid token",
+			pr: auth.PolicyReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     inValidToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.ThingType,
+			},
+			err: svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := krepo.On("Retrieve", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{Scopes: scopes}, nil)
+		repoCall1 := prepo.On("RetrieveAllObjects", context.Background(), mock.Anything).Return(tc.policies, nil)
+		page, err := svc.ListAllObjects(context.Background(), tc.pr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.objects, page.Policies, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.objects, page.Policies))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestCountObjects(t *testing.T) {
 	svc, _ := newService()
 
@@ -1715,6 +2428,7 @@ func TestCreateDomain(t *testing.T) {
 		saveDomainErr     error
 		deleteDomainErr   error
 		deletePoliciesErr error
+		checkPolicyErr    error
 		err               error
 	}{
 		{
@@ -1725,6 +2439,25 @@ func TestCreateDomain(t *testing.T) {
 			token: accessToken,
 			err:   nil,
 		},
+		{
+			desc: "create domain with quotas by platform admin",
+			d: auth.Domain{
+				Status: auth.EnabledStatus,
+				Quotas: auth.Quotas{Things: 10},
+			},
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc: "create domain with quotas by non platform admin",
+			d: auth.Domain{
+				Status: auth.EnabledStatus,
+				Quotas: auth.Quotas{Things: 10},
+			},
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrAuthorization,
+		},
 		{
 			desc: "create domain with invalid token",
 			d: auth.Domain{
@@ -1817,6 +2550,15 @@ func TestCreateDomain(t *testing.T) {
 		repoCall2 := prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
 		repoCall3 := drepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deleteDomainErr)
 		repoCall4 := drepo.On("Save", mock.Anything, mock.Anything).Return(auth.Domain{}, tc.saveDomainErr)
+		repoCall5 := prepo.On("CheckPolicy", mock.Anything, auth.PolicyReq{
+			Domain:      groupName,
+			Subject:     email,
+			SubjectType: auth.UserType,
+			SubjectKind: auth.TokenKind,
+			Permission:  auth.AdminPermission,
+			Object:      auth.MagistralaObject,
+			ObjectType:  auth.PlatformType,
+		}).Return(tc.checkPolicyErr)
 		_, err := svc.CreateDomain(context.Background(), tc.token, tc.d)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
@@ -1824,6 +2566,7 @@ func TestCreateDomain(t *testing.T) {
 		repoCall2.Unset()
 		repoCall3.Unset()
 		repoCall4.Unset()
+		repoCall5.Unset()
 	}
 }
 
@@ -2030,16 +2773,22 @@ func TestChangeDomainStatus(t *testing.T) {
 	svc, accessToken := newService()
 
 	disabledStatus := auth.DisabledStatus
+	enabledStatus := auth.EnabledStatus
+	deletedStatus := auth.DeletedStatus
 
 	cases := []struct {
-		desc             string
-		token            string
-		domainID         string
-		domainReq        auth.DomainReq
-		retreieveByIDErr error
-		checkPolicyErr   error
-		updateErr        error
-		err              error
+		desc                string
+		token               string
+		domainID            string
+		domainReq           auth.DomainReq
+		domain              auth.Domain
+		deletion            auth.DomainDeletion
+		retreieveByIDErr    error
+		checkPolicyErr      error
+		retrieveDeletionErr error
+		updateDeletionErr   error
+		updateErr           error
+		err                 error
 	}{
 		{
 			desc:     "change domain status successfully",
@@ -2089,33 +2838,352 @@ func TestChangeDomainStatus(t *testing.T) {
 			updateErr: errors.ErrMalformedEntity,
 			err:       errors.ErrMalformedEntity,
 		},
-	}
-
-	for _, tc := range cases {
-		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, tc.retreieveByIDErr)
-		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
-		repoCall2 := drepo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(auth.Domain{}, tc.updateErr)
-		_, err := svc.ChangeDomainStatus(context.Background(), tc.token, tc.domainID, tc.domainReq)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
-		repoCall.Unset()
-		repoCall1.Unset()
-		repoCall2.Unset()
-	}
-}
-
-func TestListDomains(t *testing.T) {
-	svc, accessToken := newService()
-
-	cases := []struct {
-		desc            string
-		token           string
-		domainID        string
-		authReq         auth.Page
-		listDomainsRes  auth.DomainsPage
-		retreiveByIDErr error
-		checkPolicyErr  error
-		listDomainErr   error
-		err             error
+		{
+			desc:     "change domain status to deleted",
+			token:    accessToken,
+			domainID: validID,
+			domainReq: auth.DomainReq{
+				Status: &deletedStatus,
+			},
+			err: svcerr.ErrInvalidStatus,
+		},
+		{
+			desc:     "restore domain pending deletion",
+			token:    accessToken,
+			domainID: validID,
+			domainReq: auth.DomainReq{
+				Status: &enabledStatus,
+			},
+			domain:   auth.Domain{ID: validID, Status: auth.DeletedStatus},
+			deletion: auth.DomainDeletion{DomainID: validID, Status: auth.DeletionPending},
+			err:      nil,
+		},
+		{
+			desc:     "restore domain with deletion in progress",
+			token:    accessToken,
+			domainID: validID,
+			domainReq: auth.DomainReq{
+				Status: &enabledStatus,
+			},
+			domain:   auth.Domain{ID: validID, Status: auth.DeletedStatus},
+			deletion: auth.DomainDeletion{DomainID: validID, Status: auth.DeletionInProgress},
+			err:      svcerr.ErrConflict,
+		},
+		{
+			desc:     "restore domain with failed to retrieve deletion",
+			token:    accessToken,
+			domainID: validID,
+			domainReq: auth.DomainReq{
+				Status: &enabledStatus,
+			},
+			domain:              auth.Domain{ID: validID, Status: auth.DeletedStatus},
+			retrieveDeletionErr: repoerr.ErrNotFound,
+			err:                 svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "restore domain with failed to update deletion",
+			token:    accessToken,
+			domainID: validID,
+			domainReq: auth.DomainReq{
+				Status: &enabledStatus,
+			},
+			domain:            auth.Domain{ID: validID, Status: auth.DeletedStatus},
+			deletion:          auth.DomainDeletion{DomainID: validID, Status: auth.DeletionPending},
+			updateDeletionErr: repoerr.ErrUpdateEntity,
+			err:               svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(tc.domain, tc.retreieveByIDErr)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := drepo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(auth.Domain{}, tc.updateErr)
+		repoCall3 := drepo.On("RetrieveDeletion", mock.Anything, tc.domainID).Return(tc.deletion, tc.retrieveDeletionErr)
+		repoCall4 := drepo.On("UpdateDeletion", mock.Anything, mock.Anything).Return(tc.updateDeletionErr)
+		_, err := svc.ChangeDomainStatus(context.Background(), tc.token, tc.domainID, tc.domainReq)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestDeleteDomain(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc            string
+		token           string
+		domainID        string
+		checkPolicyErr  error
+		saveDeletionErr error
+		err             error
+	}{
+		{
+			desc:     "delete domain successfully",
+			token:    accessToken,
+			domainID: validID,
+			err:      nil,
+		},
+		{
+			desc:     "delete domain with invalid token",
+			token:    inValidToken,
+			domainID: validID,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "delete domain with unauthorized domain ID",
+			token:          accessToken,
+			domainID:       validID,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:            "delete domain pending deletion",
+			token:           accessToken,
+			domainID:        validID,
+			saveDeletionErr: repoerr.ErrConflict,
+			err:             svcerr.ErrConflict,
+		},
+		{
+			desc:            "delete domain with failed to save deletion",
+			token:           accessToken,
+			domainID:        validID,
+			saveDeletionErr: repoerr.ErrCreateEntity,
+			err:             svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := drepo.On("SaveDeletion", mock.Anything, mock.Anything).Return(tc.saveDeletionErr)
+		dd, err := svc.DeleteDomain(context.Background(), tc.token, tc.domainID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.domainID, dd.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, tc.domainID, dd.DomainID))
+			assert.Equal(t, auth.DeletionPending, dd.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, auth.DeletionPending, dd.Status))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestRetrieveDomainDeletion(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc                string
+		token               string
+		domainID            string
+		deletion            auth.DomainDeletion
+		retrieveDeletionErr error
+		checkPolicyErr      error
+		err                 error
+	}{
+		{
+			desc:     "retrieve domain deletion successfully",
+			token:    accessToken,
+			domainID: validID,
+			deletion: auth.DomainDeletion{DomainID: validID, Status: auth.DeletionPending, RequestedBy: validID},
+			err:      nil,
+		},
+		{
+			desc:           "retrieve completed domain deletion by the requester",
+			token:          accessToken,
+			domainID:       validID,
+			deletion:       auth.DomainDeletion{DomainID: validID, Status: auth.DeletionAuthCompleted, RequestedBy: email},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            nil,
+		},
+		{
+			desc:     "retrieve domain deletion with invalid token",
+			token:    inValidToken,
+			domainID: validID,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:                "retrieve non-existing domain deletion",
+			token:               accessToken,
+			domainID:            validID,
+			retrieveDeletionErr: repoerr.ErrNotFound,
+			err:                 svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "retrieve domain deletion with unauthorized domain ID",
+			token:          accessToken,
+			domainID:       validID,
+			deletion:       auth.DomainDeletion{DomainID: validID, Status: auth.DeletionPending, RequestedBy: validID},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := drepo.On("RetrieveDeletion", mock.Anything, tc.domainID).Return(tc.deletion, tc.retrieveDeletionErr)
+		dd, err := svc.RetrieveDomainDeletion(context.Background(), tc.token, tc.domainID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.deletion, dd, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.deletion, dd))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestPurgeDomains(t *testing.T) {
+	svc, _ := newService()
+
+	deletion := auth.DomainDeletion{DomainID: validID, Status: auth.DeletionPending, RequestedBy: email}
+	failed := auth.DomainDeletion{DomainID: validID, Status: auth.DeletionFailed, RequestedBy: email, Attempts: 2}
+	role := auth.Role{ID: testsutil.GenerateUUID(t), DomainID: validID}
+	assignment := auth.RoleAssignment{RoleID: role.ID, EntityType: auth.DomainType, EntityID: validID, UserID: email}
+
+	cases := []struct {
+		desc                 string
+		deletions            []auth.DomainDeletion
+		retrieveDeletionsErr error
+		updateDeletionErr    error
+		retrieveRolesErr     error
+		deletePoliciesErr    error
+		removeUsageErr       error
+		deleteErr            error
+		status               auth.DeletionStatus
+		attempts             uint64
+		retryAfter           time.Duration
+		err                  error
+	}{
+		{
+			desc:      "purge domains successfully",
+			deletions: []auth.DomainDeletion{deletion},
+			status:    auth.DeletionAuthCompleted,
+			attempts:  1,
+			err:       nil,
+		},
+		{
+			desc:      "purge failed domain deletion successfully",
+			deletions: []auth.DomainDeletion{failed},
+			status:    auth.DeletionAuthCompleted,
+			attempts:  3,
+			err:       nil,
+		},
+		{
+			desc:       "purge failed domain deletion with failed to delete domain",
+			deletions:  []auth.DomainDeletion{failed},
+			deleteErr:  repoerr.ErrRemoveEntity,
+			status:     auth.DeletionFailed,
+			attempts:   3,
+			retryAfter: 40 * time.Minute,
+			err:        nil,
+		},
+		{
+			desc:      "purge domain removed by the previous attempt",
+			deletions: []auth.DomainDeletion{deletion},
+			deleteErr: repoerr.ErrNotFound,
+			status:    auth.DeletionAuthCompleted,
+			attempts:  1,
+			err:       nil,
+		},
+		{
+			desc:                 "purge domains with failed to retrieve deletions",
+			retrieveDeletionsErr: repoerr.ErrViewEntity,
+			err:                  svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "purge domains with failed to update deletion",
+			deletions:         []auth.DomainDeletion{deletion},
+			updateDeletionErr: repoerr.ErrUpdateEntity,
+			err:               svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "purge domains with failed to retrieve roles",
+			deletions:        []auth.DomainDeletion{deletion},
+			retrieveRolesErr: repoerr.ErrViewEntity,
+			status:           auth.DeletionFailed,
+			attempts:         1,
+			retryAfter:       10 * time.Minute,
+			err:              nil,
+		},
+		{
+			desc:              "purge domains with failed to delete policies",
+			deletions:         []auth.DomainDeletion{deletion},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			status:            auth.DeletionFailed,
+			attempts:          1,
+			retryAfter:        10 * time.Minute,
+			err:               nil,
+		},
+		{
+			desc:           "purge domains with failed to remove usage",
+			deletions:      []auth.DomainDeletion{deletion},
+			removeUsageErr: repoerr.ErrRemoveEntity,
+			status:         auth.DeletionFailed,
+			attempts:       1,
+			retryAfter:     10 * time.Minute,
+			err:            nil,
+		},
+		{
+			desc:       "purge domains with failed to delete domain",
+			deletions:  []auth.DomainDeletion{deletion},
+			deleteErr:  repoerr.ErrRemoveEntity,
+			status:     auth.DeletionFailed,
+			attempts:   1,
+			retryAfter: 10 * time.Minute,
+			err:        nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveDeletions", mock.Anything, mock.Anything, mock.Anything, uint64(5), uint64(100)).Return(tc.deletions, tc.retrieveDeletionsErr)
+		repoCall1 := drepo.On("UpdateDeletion", mock.Anything, mock.Anything).Return(tc.updateDeletionErr)
+		repoCall2 := rrepo.On("RetrieveAll", mock.Anything, validID, mock.Anything).Return(auth.RolesPage{Total: 1, Roles: []auth.Role{role}}, tc.retrieveRolesErr)
+		repoCall3 := rrepo.On("RetrieveAssignments", mock.Anything, role.ID).Return([]auth.RoleAssignment{assignment}, nil)
+		repoCall4 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
+		repoCall5 := prepo.On("RetrieveAllSubjects", mock.Anything, mock.Anything).Return([]auth.PolicyRes{{Subject: email}}, nil)
+		repoCall6 := urepo.On("RemoveDomain", mock.Anything, validID).Return(tc.removeUsageErr)
+		repoCall7 := drepo.On("Delete", mock.Anything, validID).Return(tc.deleteErr)
+		dds, err := svc.PurgeDomains(context.Background(), time.Now())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, dds, len(tc.deletions), fmt.Sprintf("%s: expected %d deletions got %d\n", tc.desc, len(tc.deletions), len(dds)))
+			for _, dd := range dds {
+				assert.Equal(t, tc.status, dd.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, dd.Status))
+				assert.Equal(t, tc.attempts, dd.Attempts, fmt.Sprintf("%s: expected %d attempts got %d\n", tc.desc, tc.attempts, dd.Attempts))
+				if tc.status == auth.DeletionFailed {
+					assert.WithinDuration(t, dd.UpdatedAt.Add(tc.retryAfter), dd.RetryAt, 0, fmt.Sprintf("%s: expected retry after %s\n", tc.desc, tc.retryAfter))
+				}
+			}
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+		repoCall7.Unset()
+	}
+}
+
+func TestListDomains(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc            string
+		token           string
+		domainID        string
+		authReq         auth.Page
+		listDomainsRes  auth.DomainsPage
+		retreiveByIDErr error
+		checkPolicyErr  error
+		listDomainErr   error
+		err             error
 	}{
 		{
 			desc:     "list domains successfully",
@@ -2188,6 +3256,7 @@ func TestAssignUsers(t *testing.T) {
 		addPoliciesErr       error
 		savePoliciesErr      error
 		deletePoliciesErr    error
+		reserveErr           error
 		err                  error
 	}{
 		{
@@ -2231,6 +3300,47 @@ func TestAssignUsers(t *testing.T) {
 
 			err: nil,
 		},
+		{
+			desc:     "assign users with exceeded users quota",
+			token:    accessToken,
+			domainID: validID,
+			userIDs:  []string{validID},
+			relation: auth.ContributorRelation,
+			checkPolicyReq3: auth.PolicyReq{
+				Domain:      groupName,
+				Subject:     id,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Object:      validID,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.SharePermission,
+			},
+			checkAdminPolicyReq: auth.PolicyReq{
+				Domain:      groupName,
+				Subject:     id,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Object:      validID,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.ViewPermission,
+			},
+			checkDomainPolicyReq: auth.PolicyReq{
+				Subject:     validID,
+				SubjectType: auth.UserType,
+				Object:      auth.MagistralaObject,
+				ObjectType:  auth.PlatformType,
+				Permission:  auth.MembershipPermission,
+			},
+			checkPolicyReq33: auth.PolicyReq{
+				Subject:     id,
+				SubjectType: auth.UserType,
+				Object:      groupName,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.MembershipPermission,
+			},
+			reserveErr: svcerr.ErrQuotaExceeded,
+			err:        svcerr.ErrQuotaExceeded,
+		},
 		{
 			desc:     "assign users with invalid token",
 			token:    inValidToken,
@@ -2465,7 +3575,7 @@ func TestAssignUsers(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		repoCall := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{}, nil)
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
 		repoCall1 := prepo.On("CheckPolicy", mock.Anything, tc.checkPolicyReq3).Return(tc.checkpolicyErr)
 		repoCall2 := prepo.On("CheckPolicy", mock.Anything, tc.checkAdminPolicyReq).Return(tc.checkPolicyErr1)
 		repoCall3 := prepo.On("CheckPolicy", mock.Anything, tc.checkDomainPolicyReq).Return(tc.checkPolicyErr2)
@@ -2473,8 +3583,14 @@ func TestAssignUsers(t *testing.T) {
 		repoCall5 := prepo.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesErr)
 		repoCall6 := drepo.On("SavePolicies", mock.Anything, mock.Anything, mock.Anything).Return(tc.savePoliciesErr)
 		repoCall7 := prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
-		err := svc.AssignUsers(context.Background(), tc.token, tc.domainID, tc.userIDs, tc.relation)
+		repoCall8 := urepo.On("ReserveEntities", mock.Anything, mock.Anything, auth.UsersQuota, uint64(0), mock.Anything).Return(tc.userIDs, tc.reserveErr)
+		repoCall9 := urepo.On("RemoveEntities", mock.Anything, mock.Anything, auth.UsersQuota, mock.Anything).Return(nil)
+		err := svc.AssignUsers(context.Background(), tc.token, tc.domainID, tc.userIDs, tc.relation, nil)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.reserveErr == nil && (tc.addPoliciesErr != nil || tc.savePoliciesErr != nil) {
+			ok := urepo.AssertCalled(t, "RemoveEntities", mock.Anything, mock.Anything, auth.UsersQuota, tc.userIDs[0])
+			assert.True(t, ok, fmt.Sprintf("%s: expected the reserved users to be released", tc.desc))
+		}
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
@@ -2483,6 +3599,8 @@ func TestAssignUsers(t *testing.T) {
 		repoCall5.Unset()
 		repoCall6.Unset()
 		repoCall7.Unset()
+		repoCall8.Unset()
+		repoCall9.Unset()
 	}
 }
 
@@ -2501,6 +3619,7 @@ func TestUnassignUser(t *testing.T) {
 		checkPolicyErr1       error
 		deletePolicyFilterErr error
 		deletePoliciesErr     error
+		removeAssignmentsErr  error
 		err                   error
 	}{
 		{
@@ -2693,30 +3812,65 @@ func TestUnassignUser(t *testing.T) {
 			deletePoliciesErr: errors.ErrMalformedEntity,
 			err:               errors.ErrMalformedEntity,
 		},
-	}
-
-	for _, tc := range cases {
-		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
-		repoCall1 := prepo.On("CheckPolicy", mock.Anything, tc.checkPolicyReq).Return(tc.checkPolicyErr)
-		repoCall2 := prepo.On("CheckPolicy", mock.Anything, tc.checkAdminPolicyReq).Return(tc.checkPolicyErr1)
-		repoCall3 := prepo.On("CheckPolicy", mock.Anything, tc.checkDomainPolicyReq).Return(tc.checkPolicyErr1)
-		repoCall4 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePolicyFilterErr)
-		repoCall5 := drepo.On("DeletePolicies", mock.Anything, mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
-		err := svc.UnassignUser(context.Background(), tc.token, tc.domainID, tc.userID)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
-		repoCall.Unset()
-		repoCall1.Unset()
-		repoCall2.Unset()
-		repoCall3.Unset()
-		repoCall4.Unset()
-		repoCall5.Unset()
-	}
-}
-
-func TestListUsersDomains(t *testing.T) {
-	svc, accessToken := newService()
-
-	cases := []struct {
+		{
+			desc:     "unassign user with failed to remove role assignments",
+			token:    accessToken,
+			domainID: validID,
+			userID:   validID,
+			checkPolicyReq: auth.PolicyReq{
+				Domain:      groupName,
+				Subject:     id,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Object:      validID,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.SharePermission,
+			},
+			checkAdminPolicyReq: auth.PolicyReq{
+				Domain:      groupName,
+				Subject:     id,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Object:      validID,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.AdminPermission,
+			},
+			checkDomainPolicyReq: auth.PolicyReq{
+				Subject:     id,
+				SubjectType: auth.UserType,
+				Object:      groupName,
+				ObjectType:  auth.DomainType,
+				Permission:  auth.MembershipPermission,
+			},
+			removeAssignmentsErr: repoerr.ErrRemoveEntity,
+			err:                  repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, tc.checkPolicyReq).Return(tc.checkPolicyErr)
+		repoCall2 := prepo.On("CheckPolicy", mock.Anything, tc.checkAdminPolicyReq).Return(tc.checkPolicyErr1)
+		repoCall3 := prepo.On("CheckPolicy", mock.Anything, tc.checkDomainPolicyReq).Return(tc.checkPolicyErr1)
+		repoCall4 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePolicyFilterErr)
+		repoCall5 := drepo.On("DeletePolicies", mock.Anything, mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
+		repoCall6 := rrepo.On("RemoveUserAssignments", mock.Anything, tc.domainID, tc.userID).Return([]auth.RoleAssignment{}, tc.removeAssignmentsErr)
+		err := svc.UnassignUser(context.Background(), tc.token, tc.domainID, tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+	}
+}
+
+func TestListUsersDomains(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
 		desc            string
 		token           string
 		userID          string
@@ -2873,3 +4027,1983 @@ func TestDecodeDomainUserID(t *testing.T) {
 		assert.Equal(t, tc.respDomainID, ar, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.respDomainID, ar))
 	}
 }
+
+func newSessionService(t *testing.T) (auth.Service, auth.Token) {
+	krepo = new(mocks.KeyRepository)
+	prepo = new(mocks.PolicyAgent)
+	drepo = new(mocks.DomainsRepository)
+	srepo = new(mocks.SessionRepository)
+	trepo = new(mocks.TokenRepository)
+	rrepo = new(mocks.RolesRepository)
+	svc := auth.New(krepo, drepo, srepo, trepo, rrepo, crepo, cmrepo, urepo, uuid.NewMock(), jwt.New([]byte(secret)), prepo, loginDuration, refreshDuration, invalidDuration)
+
+	genCall := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+	saveCall := srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	genCall.Unset()
+	saveCall.Unset()
+
+	return svc, token
+}
+
+func TestIdentifyRevoked(t *testing.T) {
+	svc, token := newSessionService(t)
+
+	cases := []struct {
+		desc          string
+		token         string
+		revoked       bool
+		revokedErr    error
+		generation    uint64
+		generationErr error
+		err           error
+	}{
+		{
+			desc:       "identify access token",
+			token:      token.AccessToken,
+			generation: 1,
+			err:        nil,
+		},
+		{
+			desc:       "identify refresh token",
+			token:      token.RefreshToken,
+			generation: 1,
+			err:        nil,
+		},
+		{
+			desc:       "identify revoked access token",
+			token:      token.AccessToken,
+			revoked:    true,
+			generation: 1,
+			err:        auth.ErrRevoked,
+		},
+		{
+			desc:       "identify revoked refresh token",
+			token:      token.RefreshToken,
+			revoked:    true,
+			generation: 1,
+			err:        auth.ErrRevoked,
+		},
+		{
+			desc:       "identify access token of an older generation",
+			token:      token.AccessToken,
+			generation: 2,
+			err:        auth.ErrRevoked,
+		},
+		{
+			desc:       "identify access token with failed to check revocation",
+			token:      token.AccessToken,
+			revokedErr: repoerr.ErrNotFound,
+			err:        svcerr.ErrAuthentication,
+		},
+		{
+			desc:          "identify access token with failed to retrieve generation",
+			token:         token.AccessToken,
+			generationErr: repoerr.ErrNotFound,
+			err:           svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(tc.revoked, tc.revokedErr)
+		repoCall1 := trepo.On("Generation", mock.Anything, id).Return(tc.generation, tc.generationErr)
+		_, err := svc.Identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestIdentifyRevokedAPIKey(t *testing.T) {
+	svc, token := newSessionService(t)
+
+	repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+	repoCall1 := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+	repoCall2 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	apiToken, err := svc.Issue(context.Background(), token.AccessToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	repoCall.Unset()
+	repoCall1.Unset()
+	repoCall2.Unset()
+
+	cases := []struct {
+		desc       string
+		generation uint64
+		err        error
+	}{
+		{
+			desc:       "identify API key",
+			generation: 1,
+			err:        nil,
+		},
+		{
+			desc:       "identify API key of the disabled user",
+			generation: 2,
+			err:        auth.ErrRevoked,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+		repoCall1 := trepo.On("Generation", mock.Anything, id).Return(tc.generation, nil)
+		repoCall2 := krepo.On("Retrieve", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{}, nil)
+		_, err := svc.Identify(context.Background(), apiToken.AccessToken)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			_, err = svc.ClientCredentials(context.Background(), id, apiToken.AccessToken)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected client credentials error %s got %s\n", tc.desc, tc.err, err))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestIssueDomainMFA(t *testing.T) {
+	cases := []struct {
+		desc          string
+		mfaRequired   bool
+		platformAdmin bool
+		domainAdmin   bool
+		mfa           bool
+		err           error
+	}{
+		{
+			desc:        "issue key of domain admin without MFA in domain not requiring MFA",
+			domainAdmin: true,
+		},
+		{
+			desc:        "issue key of domain admin without MFA in domain requiring MFA",
+			mfaRequired: true,
+			domainAdmin: true,
+			err:         svcerr.ErrMFARequired,
+		},
+		{
+			desc:        "issue key of domain admin with MFA in domain requiring MFA",
+			mfaRequired: true,
+			domainAdmin: true,
+			mfa:         true,
+		},
+		{
+			desc:        "issue key of domain member without MFA in domain requiring MFA",
+			mfaRequired: true,
+		},
+		{
+			desc:          "issue key of platform admin without MFA in domain requiring MFA",
+			mfaRequired:   true,
+			platformAdmin: true,
+			err:           svcerr.ErrMFARequired,
+		},
+	}
+
+	for _, tc := range cases {
+		// Policy checks are matched by the request, so every case uses a new service.
+		svc, _ := newService()
+		drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName, Status: auth.EnabledStatus, MFARequired: tc.mfaRequired}, nil)
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.PlatformType
+		})).Return(boolErr(tc.platformAdmin))
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.DomainType && pr.Permission == auth.AdminPermission
+		})).Return(boolErr(tc.domainAdmin))
+		prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+		_, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now(), Domain: groupName, MFA: tc.mfa})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func boolErr(allowed bool) error {
+	if allowed {
+		return nil
+	}
+	return svcerr.ErrAuthorization
+}
+
+func TestRefreshSession(t *testing.T) {
+	svc, token := newSessionService(t)
+	key, err := jwt.New([]byte(secret)).Parse(token.RefreshToken)
+	assert.Nil(t, err, fmt.Sprintf("Parsing refresh token expected to succeed: %s", err))
+
+	session := auth.Session{ID: key.ID, User: id, CreatedAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(refreshDuration)}
+
+	repoCall := trepo.On("Revoked", mock.Anything, key.ID).Return(false, nil)
+	repoCall1 := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+	repoCall2 := srepo.On("Retrieve", mock.Anything, id, key.ID).Return(session, nil)
+	repoCall3 := srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	refreshed, err := svc.Issue(context.Background(), token.RefreshToken, auth.Key{Type: auth.RefreshKey})
+	assert.Nil(t, err, fmt.Sprintf("Refreshing session expected to succeed: %s", err))
+	ok := repoCall3.Parent.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(s auth.Session) bool {
+		return s.ID == session.ID && s.CreatedAt.Equal(session.CreatedAt) && !s.RefreshedAt.IsZero()
+	}))
+	assert.True(t, ok, "Save was not called with the refreshed session")
+
+	refreshedKey, err := svc.Identify(context.Background(), refreshed.AccessToken)
+	assert.Nil(t, err, fmt.Sprintf("Identifying refreshed token expected to succeed: %s", err))
+	assert.Equal(t, key.ID, refreshedKey.ID, fmt.Sprintf("expected session %s got %s\n", key.ID, refreshedKey.ID))
+	repoCall.Unset()
+	repoCall1.Unset()
+	repoCall2.Unset()
+	repoCall3.Unset()
+
+	repoCall = trepo.On("Revoked", mock.Anything, key.ID).Return(true, nil)
+	repoCall1 = trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+	_, err = svc.Issue(context.Background(), token.RefreshToken, auth.Key{Type: auth.RefreshKey})
+	assert.True(t, errors.Contains(err, auth.ErrRevoked), fmt.Sprintf("Refreshing revoked session: expected %s got %s\n", auth.ErrRevoked, err))
+	repoCall.Unset()
+	repoCall1.Unset()
+}
+
+func TestListSessions(t *testing.T) {
+	svc, token := newSessionService(t)
+	key, err := jwt.New([]byte(secret)).Parse(token.AccessToken)
+	assert.Nil(t, err, fmt.Sprintf("Parsing access token expected to succeed: %s", err))
+
+	sessions := []auth.Session{
+		{ID: key.ID, User: id, ExpiresAt: time.Now().Add(refreshDuration)},
+		{ID: validID, User: id, ExpiresAt: time.Now().Add(refreshDuration)},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		sessions []auth.Session
+		current  string
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "list sessions",
+			token:    token.AccessToken,
+			sessions: sessions,
+			current:  key.ID,
+			err:      nil,
+		},
+		{
+			desc:  "list sessions with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "list sessions with failed to retrieve",
+			token:   token.AccessToken,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+		repoCall1 := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+		repoCall2 := srepo.On("RetrieveAll", mock.Anything, id).Return(tc.sessions, tc.repoErr)
+		res, err := svc.ListSessions(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, len(tc.sessions), len(res), fmt.Sprintf("%s expected %d sessions got %d\n", tc.desc, len(tc.sessions), len(res)))
+		for _, s := range res {
+			assert.Equal(t, s.ID == tc.current, s.Current, fmt.Sprintf("%s: unexpected current flag for session %s\n", tc.desc, s.ID))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc, token := newSessionService(t)
+
+	session := auth.Session{ID: validID, User: id, ExpiresAt: time.Now().Add(refreshDuration)}
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		retrieveErr error
+		revokeErr   error
+		removeErr   error
+		err         error
+	}{
+		{
+			desc:  "revoke session",
+			token: token.AccessToken,
+			id:    session.ID,
+			err:   nil,
+		},
+		{
+			desc:  "revoke session with invalid token",
+			token: inValidToken,
+			id:    session.ID,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "revoke non-existing session",
+			token:       token.AccessToken,
+			id:          inValid,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:      "revoke session with failed to revoke tokens",
+			token:     token.AccessToken,
+			id:        session.ID,
+			revokeErr: repoerr.ErrCreateEntity,
+			err:       repoerr.ErrCreateEntity,
+		},
+		{
+			desc:      "revoke session with failed to remove session",
+			token:     token.AccessToken,
+			id:        session.ID,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+		repoCall1 := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+		repoCall2 := srepo.On("Retrieve", mock.Anything, id, tc.id).Return(session, tc.retrieveErr)
+		repoCall3 := trepo.On("Revoke", mock.Anything, session.ID, mock.Anything).Return(tc.revokeErr)
+		repoCall4 := srepo.On("Remove", mock.Anything, id, session.ID).Return(tc.removeErr)
+		err := svc.RevokeSession(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc, token := newSessionService(t)
+
+	cases := []struct {
+		desc         string
+		token        string
+		incrementErr error
+		removeErr    error
+		err          error
+	}{
+		{
+			desc:  "revoke all sessions",
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "revoke all sessions with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "revoke all sessions with failed to increment generation",
+			token:        token.AccessToken,
+			incrementErr: repoerr.ErrUpdateEntity,
+			err:          repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "revoke all sessions with failed to remove sessions",
+			token:     token.AccessToken,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := trepo.On("Revoked", mock.Anything, mock.Anything).Return(false, nil)
+		repoCall1 := trepo.On("Generation", mock.Anything, id).Return(uint64(1), nil)
+		repoCall2 := trepo.On("IncrementGeneration", mock.Anything, id).Return(tc.incrementErr)
+		repoCall3 := srepo.On("RemoveAll", mock.Anything, id).Return(tc.removeErr)
+		err := svc.RevokeSessions(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestCreateRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	role := auth.Role{
+		Name:        "operator",
+		Permissions: []string{"things:view", "channels:publish"},
+	}
+
+	cases := []struct {
+		desc           string
+		token          string
+		domainID       string
+		role           auth.Role
+		checkPolicyErr error
+		saveErr        error
+		err            error
+	}{
+		{
+			desc:     "create role successfully",
+			token:    accessToken,
+			domainID: validID,
+			role:     role,
+			err:      nil,
+		},
+		{
+			desc:     "create role with invalid token",
+			token:    inValidToken,
+			domainID: validID,
+			role:     role,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "create role with unauthorized user",
+			token:          accessToken,
+			domainID:       validID,
+			role:           role,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:     "create role with empty name",
+			token:    accessToken,
+			domainID: validID,
+			role: auth.Role{
+				Permissions: role.Permissions,
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc:     "create role with invalid permission",
+			token:    accessToken,
+			domainID: validID,
+			role: auth.Role{
+				Name:        role.Name,
+				Permissions: []string{"things:publish"},
+			},
+			err: auth.ErrInvalidRole,
+		},
+		{
+			desc:     "create role with failed to save",
+			token:    accessToken,
+			domainID: validID,
+			role:     role,
+			saveErr:  repoerr.ErrCreateEntity,
+			err:      svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("Save", mock.Anything, mock.Anything).Return(tc.role, tc.saveErr)
+		_, err := svc.CreateRole(context.Background(), tc.token, tc.domainID, tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestRetrieveRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc           string
+		token          string
+		domainID       string
+		roleID         string
+		checkPolicyErr error
+		retrieveErr    error
+		err            error
+	}{
+		{
+			desc:     "retrieve role successfully",
+			token:    accessToken,
+			domainID: validID,
+			roleID:   validID,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve role with invalid token",
+			token:    inValidToken,
+			domainID: validID,
+			roleID:   validID,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "retrieve role with unauthorized user",
+			token:          accessToken,
+			domainID:       validID,
+			roleID:         validID,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "retrieve non-existing role",
+			token:       accessToken,
+			domainID:    validID,
+			roleID:      inValid,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, tc.domainID, tc.roleID).Return(auth.Role{}, tc.retrieveErr)
+		_, err := svc.RetrieveRole(context.Background(), tc.token, tc.domainID, tc.roleID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestListRoleAssignments(t *testing.T) {
+	svc, accessToken := newService()
+
+	assignments := []auth.RoleAssignment{
+		{
+			RoleID:     validID,
+			EntityType: auth.DomainType,
+			EntityID:   validID,
+			UserID:     validID,
+		},
+	}
+
+	cases := []struct {
+		desc           string
+		token          string
+		roleID         string
+		checkPolicyErr error
+		retrieveErr    error
+		assignments    []auth.RoleAssignment
+		assignmentsErr error
+		err            error
+	}{
+		{
+			desc:        "list role assignments successfully",
+			token:       accessToken,
+			roleID:      validID,
+			assignments: assignments,
+			err:         nil,
+		},
+		{
+			desc:   "list role assignments with invalid token",
+			token:  inValidToken,
+			roleID: validID,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "list role assignments with unauthorized user",
+			token:          accessToken,
+			roleID:         validID,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "list assignments of non-existing role",
+			token:       accessToken,
+			roleID:      inValid,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "list role assignments with failed repo",
+			token:          accessToken,
+			roleID:         validID,
+			assignmentsErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, validID, tc.roleID).Return(auth.Role{}, tc.retrieveErr)
+		repoCall3 := rrepo.On("RetrieveAssignments", mock.Anything, tc.roleID).Return(tc.assignments, tc.assignmentsErr)
+		ras, err := svc.ListRoleAssignments(context.Background(), tc.token, validID, tc.roleID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.assignments, ras, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.assignments, ras))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestListRoles(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc           string
+		token          string
+		domainID       string
+		page           auth.Page
+		checkPolicyErr error
+		retrieveErr    error
+		err            error
+	}{
+		{
+			desc:     "list roles successfully",
+			token:    accessToken,
+			domainID: validID,
+			page:     auth.Page{Limit: 10},
+			err:      nil,
+		},
+		{
+			desc:     "list roles with invalid token",
+			token:    inValidToken,
+			domainID: validID,
+			page:     auth.Page{Limit: 10},
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "list roles with unauthorized user",
+			token:          accessToken,
+			domainID:       validID,
+			page:           auth.Page{Limit: 10},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "list roles with failed to retrieve",
+			token:       accessToken,
+			domainID:    validID,
+			page:        auth.Page{Limit: 10},
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveAll", mock.Anything, tc.domainID, tc.page).Return(auth.RolesPage{}, tc.retrieveErr)
+		_, err := svc.ListRoles(context.Background(), tc.token, tc.domainID, tc.page)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	role := auth.Role{
+		ID:          validID,
+		DomainID:    validID,
+		Name:        "operator",
+		Permissions: []string{"things:view"},
+	}
+	name := "viewer"
+	permissions := []string{"things:view", "channels:subscribe"}
+	invalidPermissions := []string{"domains:delete"}
+	assignments := []auth.RoleAssignment{
+		{RoleID: validID, EntityType: auth.GroupType, EntityID: validID, UserID: validID},
+	}
+
+	cases := []struct {
+		desc                 string
+		token                string
+		roleReq              auth.RoleReq
+		checkPolicyErr       error
+		retrieveErr          error
+		updateErr            error
+		retrieveAssignErr    error
+		deletePolicyErr      error
+		addPoliciesErr       error
+		expectedPolicyUpdate bool
+		err                  error
+	}{
+		{
+			desc:    "update role name successfully",
+			token:   accessToken,
+			roleReq: auth.RoleReq{Name: &name},
+			err:     nil,
+		},
+		{
+			desc:                 "update role permissions successfully",
+			token:                accessToken,
+			roleReq:              auth.RoleReq{Permissions: &permissions},
+			expectedPolicyUpdate: true,
+			err:                  nil,
+		},
+		{
+			desc:    "update role with invalid token",
+			token:   inValidToken,
+			roleReq: auth.RoleReq{Name: &name},
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "update role with unauthorized user",
+			token:          accessToken,
+			roleReq:        auth.RoleReq{Name: &name},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "update non-existing role",
+			token:       accessToken,
+			roleReq:     auth.RoleReq{Name: &name},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "update role with invalid permissions",
+			token:   accessToken,
+			roleReq: auth.RoleReq{Permissions: &invalidPermissions},
+			err:     auth.ErrInvalidRole,
+		},
+		{
+			desc:      "update role with failed to update",
+			token:     accessToken,
+			roleReq:   auth.RoleReq{Name: &name},
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:              "update role permissions with failed to retrieve assignments",
+			token:             accessToken,
+			roleReq:           auth.RoleReq{Permissions: &permissions},
+			retrieveAssignErr: repoerr.ErrViewEntity,
+			err:               svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:            "update role permissions with failed to delete policies",
+			token:           accessToken,
+			roleReq:         auth.RoleReq{Permissions: &permissions},
+			deletePolicyErr: errors.ErrMalformedEntity,
+			err:             errors.ErrMalformedEntity,
+		},
+		{
+			desc:           "update role permissions with failed to add policies",
+			token:          accessToken,
+			roleReq:        auth.RoleReq{Permissions: &permissions},
+			addPoliciesErr: errors.ErrMalformedEntity,
+			err:            errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, validID, validID).Return(role, tc.retrieveErr)
+		repoCall3 := rrepo.On("Update", mock.Anything, mock.Anything).Return(role, tc.updateErr)
+		repoCall4 := rrepo.On("RetrieveAssignments", mock.Anything, validID).Return(assignments, tc.retrieveAssignErr)
+		repoCall5 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePolicyErr)
+		repoCall6 := prepo.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesErr)
+		_, err := svc.UpdateRole(context.Background(), tc.token, validID, validID, tc.roleReq)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.expectedPolicyUpdate {
+			prepo.AssertCalled(t, "DeletePolicyFilter", mock.Anything, auth.PolicyReq{
+				Subject:     validID + "_" + validID,
+				SubjectType: auth.RoleType,
+				Object:      validID,
+				ObjectType:  auth.GroupType,
+			})
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	assignments := []auth.RoleAssignment{
+		{RoleID: validID, EntityType: auth.DomainType, EntityID: validID, UserID: validID},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		checkPolicyErr    error
+		retrieveErr       error
+		retrieveAssignErr error
+		deletePolicyErr   error
+		deleteErr         error
+		err               error
+	}{
+		{
+			desc:  "delete role successfully",
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc:  "delete role with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "delete role with unauthorized user",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "delete non-existing role",
+			token:       accessToken,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "delete role with failed to retrieve assignments",
+			token:             accessToken,
+			retrieveAssignErr: repoerr.ErrViewEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:            "delete role with failed to delete policies",
+			token:           accessToken,
+			deletePolicyErr: errors.ErrMalformedEntity,
+			err:             errors.ErrMalformedEntity,
+		},
+		{
+			desc:      "delete role with failed to delete",
+			token:     accessToken,
+			deleteErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, validID, validID).Return(auth.Role{ID: validID, DomainID: validID}, tc.retrieveErr)
+		repoCall3 := rrepo.On("RetrieveAssignments", mock.Anything, validID).Return(assignments, tc.retrieveAssignErr)
+		repoCall4 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePolicyErr)
+		repoCall5 := rrepo.On("Delete", mock.Anything, validID, validID).Return(tc.deleteErr)
+		err := svc.DeleteRole(context.Background(), tc.token, validID, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	role := auth.Role{
+		ID:          validID,
+		DomainID:    validID,
+		Name:        "operator",
+		Permissions: []string{"things:view", "channels:publish"},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		entity            string
+		entityID          string
+		assignments       []auth.RoleAssignment
+		checkPolicyErr    error
+		retrieveErr       error
+		retrieveAssignErr error
+		addPoliciesErr    error
+		saveErr           error
+		deletePoliciesErr error
+		expectedPolicies  []auth.PolicyReq
+		err               error
+	}{
+		{
+			desc:     "assign role on channel successfully",
+			token:    accessToken,
+			entity:   "channels",
+			entityID: validID,
+			expectedPolicies: []auth.PolicyReq{
+				{
+					Subject:     auth.EncodeDomainUserID(validID, validID),
+					SubjectType: auth.UserType,
+					Relation:    auth.MemberRelation,
+					Object:      validID + "_" + validID,
+					ObjectType:  auth.RoleType,
+				},
+				{
+					Subject:     validID + "_" + validID,
+					SubjectType: auth.RoleType,
+					Relation:    "role_thing_view",
+					Object:      validID,
+					ObjectType:  auth.GroupType,
+				},
+				{
+					Subject:     validID + "_" + validID,
+					SubjectType: auth.RoleType,
+					Relation:    "role_group_publish",
+					Object:      validID,
+					ObjectType:  auth.GroupType,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "assign role on channel already bound to the role",
+			token:    accessToken,
+			entity:   "channels",
+			entityID: validID,
+			assignments: []auth.RoleAssignment{
+				{RoleID: validID, EntityType: auth.GroupType, EntityID: validID, UserID: id},
+			},
+			expectedPolicies: []auth.PolicyReq{
+				{
+					Subject:     auth.EncodeDomainUserID(validID, validID),
+					SubjectType: auth.UserType,
+					Relation:    auth.MemberRelation,
+					Object:      validID + "_" + validID,
+					ObjectType:  auth.RoleType,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "assign role with invalid token",
+			token:    inValidToken,
+			entity:   "channels",
+			entityID: validID,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "assign role on invalid entity",
+			token:    accessToken,
+			entity:   "things",
+			entityID: validID,
+			err:      auth.ErrInvalidRole,
+		},
+		{
+			desc:     "assign role on other domain",
+			token:    accessToken,
+			entity:   "domains",
+			entityID: inValid,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:           "assign role with unauthorized user",
+			token:          accessToken,
+			entity:         "channels",
+			entityID:       validID,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "assign non-existing role",
+			token:       accessToken,
+			entity:      "channels",
+			entityID:    validID,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "assign role with failed to retrieve assignments",
+			token:             accessToken,
+			entity:            "channels",
+			entityID:          validID,
+			retrieveAssignErr: repoerr.ErrViewEntity,
+			err:               repoerr.ErrViewEntity,
+		},
+		{
+			desc:           "assign role with failed to add policies",
+			token:          accessToken,
+			entity:         "channels",
+			entityID:       validID,
+			addPoliciesErr: errors.ErrMalformedEntity,
+			err:            errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "assign role with failed to save assignments",
+			token:    accessToken,
+			entity:   "domains",
+			entityID: validID,
+			saveErr:  repoerr.ErrCreateEntity,
+			err:      repoerr.ErrCreateEntity,
+		},
+		{
+			desc:              "assign role with failed to save assignments and rollback",
+			token:             accessToken,
+			entity:            "domains",
+			entityID:          validID,
+			saveErr:           repoerr.ErrCreateEntity,
+			deletePoliciesErr: errors.ErrMalformedEntity,
+			err:               errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, validID, validID).Return(role, tc.retrieveErr)
+		repoCall3 := rrepo.On("RetrieveAssignments", mock.Anything, validID).Return(tc.assignments, tc.retrieveAssignErr)
+		repoCall4 := prepo.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesErr)
+		repoCall5 := rrepo.On("SaveAssignments", mock.Anything, mock.Anything).Return(tc.saveErr)
+		repoCall6 := prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
+		err := svc.AssignRole(context.Background(), tc.token, validID, validID, tc.entity, tc.entityID, []string{validID})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.expectedPolicies != nil {
+			prepo.AssertCalled(t, "AddPolicies", mock.Anything, tc.expectedPolicies)
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+	}
+}
+
+func TestUnassignRole(t *testing.T) {
+	svc, accessToken := newService()
+
+	role := auth.Role{
+		ID:          validID,
+		DomainID:    validID,
+		Name:        "operator",
+		Permissions: []string{"things:view"},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		assignments       []auth.RoleAssignment
+		checkPolicyErr    error
+		retrieveErr       error
+		deletePoliciesErr error
+		removeErr         error
+		retrieveAssignErr error
+		deletePolicyErr   error
+		expectedUnbind    bool
+		err               error
+	}{
+		{
+			desc:           "unassign last role user on channel successfully",
+			token:          accessToken,
+			expectedUnbind: true,
+			err:            nil,
+		},
+		{
+			desc:  "unassign role user on channel with remaining users successfully",
+			token: accessToken,
+			assignments: []auth.RoleAssignment{
+				{RoleID: validID, EntityType: auth.GroupType, EntityID: validID, UserID: id},
+			},
+			err: nil,
+		},
+		{
+			desc:  "unassign role with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "unassign role with unauthorized user",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "unassign non-existing role",
+			token:       accessToken,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "unassign role with failed to delete policies",
+			token:             accessToken,
+			deletePoliciesErr: errors.ErrMalformedEntity,
+			err:               errors.ErrMalformedEntity,
+		},
+		{
+			desc:      "unassign role with failed to remove assignments",
+			token:     accessToken,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       repoerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "unassign role with failed to retrieve assignments",
+			token:             accessToken,
+			retrieveAssignErr: repoerr.ErrViewEntity,
+			err:               repoerr.ErrViewEntity,
+		},
+		{
+			desc:            "unassign role with failed to unbind role",
+			token:           accessToken,
+			deletePolicyErr: errors.ErrMalformedEntity,
+			err:             errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := rrepo.On("RetrieveByID", mock.Anything, validID, validID).Return(role, tc.retrieveErr)
+		repoCall3 := prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePoliciesErr)
+		repoCall4 := rrepo.On("RemoveAssignments", mock.Anything, mock.Anything).Return(tc.removeErr)
+		repoCall5 := rrepo.On("RetrieveAssignments", mock.Anything, validID).Return(tc.assignments, tc.retrieveAssignErr)
+		repoCall6 := prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(tc.deletePolicyErr)
+		err := svc.UnassignRole(context.Background(), tc.token, validID, validID, "channels", validID, []string{validID})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.expectedUnbind {
+			prepo.AssertCalled(t, "DeletePolicyFilter", mock.Anything, auth.PolicyReq{
+				Subject:     validID + "_" + validID,
+				SubjectType: auth.RoleType,
+				Object:      validID,
+				ObjectType:  auth.GroupType,
+			})
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+	}
+}
+
+func TestCreateClaimMapping(t *testing.T) {
+	_, accessToken := newService()
+
+	mapping := auth.ClaimMapping{
+		Provider: "oidc",
+		Group:    "iot-ops",
+		Relation: auth.EditorRelation,
+		GroupIDs: []string{validID},
+	}
+
+	cases := []struct {
+		desc           string
+		token          string
+		mapping        auth.ClaimMapping
+		checkPolicyErr error
+		checkGroupErr  error
+		saveErr        error
+		err            error
+	}{
+		{
+			desc:    "create claim mapping successfully",
+			token:   accessToken,
+			mapping: mapping,
+			err:     nil,
+		},
+		{
+			desc:    "create claim mapping with invalid token",
+			token:   inValidToken,
+			mapping: mapping,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "create claim mapping with unauthorized user",
+			token:          accessToken,
+			mapping:        mapping,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:  "create claim mapping with empty group",
+			token: accessToken,
+			mapping: auth.ClaimMapping{
+				Relation: auth.EditorRelation,
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+		{
+			desc:  "create claim mapping with invalid relation",
+			token: accessToken,
+			mapping: auth.ClaimMapping{
+				Group:    mapping.Group,
+				Relation: "owner",
+			},
+			err: auth.ErrInvalidClaimMapping,
+		},
+		{
+			desc:          "create claim mapping with group of other domain",
+			token:         accessToken,
+			mapping:       mapping,
+			checkGroupErr: svcerr.ErrAuthorization,
+			err:           svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create claim mapping with failed to save",
+			token:   accessToken,
+			mapping: mapping,
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		prepo.On("CheckPolicy", mock.Anything, auth.PolicyReq{
+			Subject:     validID,
+			SubjectType: auth.DomainType,
+			Permission:  auth.DomainRelation,
+			Object:      validID,
+			ObjectType:  auth.GroupType,
+		}).Return(tc.checkGroupErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		cmrepo.On("Save", mock.Anything, mock.Anything).Return(tc.mapping, tc.saveErr)
+		_, err := svc.CreateClaimMapping(context.Background(), tc.token, validID, tc.mapping)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveClaimMapping(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc           string
+		token          string
+		checkPolicyErr error
+		retrieveErr    error
+		err            error
+	}{
+		{
+			desc:  "retrieve claim mapping successfully",
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve claim mapping with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "retrieve claim mapping with unauthorized user",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "retrieve non-existing claim mapping",
+			token:       accessToken,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := cmrepo.On("RetrieveByID", mock.Anything, validID, validID).Return(auth.ClaimMapping{}, tc.retrieveErr)
+		_, err := svc.RetrieveClaimMapping(context.Background(), tc.token, validID, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestListClaimMappings(t *testing.T) {
+	svc, accessToken := newService()
+
+	page := auth.Page{Offset: 0, Limit: 10}
+
+	cases := []struct {
+		desc           string
+		token          string
+		checkPolicyErr error
+		retrieveErr    error
+		err            error
+	}{
+		{
+			desc:  "list claim mappings successfully",
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc:  "list claim mappings with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "list claim mappings with unauthorized user",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "list claim mappings with failed to retrieve",
+			token:       accessToken,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := cmrepo.On("RetrieveAll", mock.Anything, validID, page).Return(auth.ClaimMappingsPage{}, tc.retrieveErr)
+		_, err := svc.ListClaimMappings(context.Background(), tc.token, validID, page)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestDeleteClaimMapping(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc           string
+		token          string
+		checkPolicyErr error
+		deleteErr      error
+		err            error
+	}{
+		{
+			desc:  "delete claim mapping successfully",
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc:  "delete claim mapping with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "delete claim mapping with unauthorized user",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:      "delete non-existing claim mapping",
+			token:     accessToken,
+			deleteErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := drepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(auth.Domain{}, nil)
+		repoCall1 := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		repoCall2 := cmrepo.On("Delete", mock.Anything, validID, validID).Return(tc.deleteErr)
+		err := svc.DeleteClaimMapping(context.Background(), tc.token, validID, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestApplyClaimMappings(t *testing.T) {
+	provider := "oidc"
+	groupID := testsutil.GenerateUUID(t)
+	mappings := []auth.ClaimMapping{
+		{ID: validID, DomainID: validID, Provider: provider, Group: "iot-view", Relation: auth.GuestRelation},
+		{ID: validID, DomainID: validID, Provider: provider, Group: "iot-ops", Relation: auth.EditorRelation, GroupIDs: []string{groupID}},
+	}
+
+	cases := []struct {
+		desc           string
+		groups         []string
+		grants         []auth.ClaimGrant
+		grant          auth.ClaimGrant
+		retrieveErr    error
+		checkPolicyErr error
+		addPoliciesErr error
+		saveGrantErr   error
+		err            error
+	}{
+		{
+			desc:   "apply claim mappings to user with no matching groups",
+			groups: []string{"sales"},
+			err:    nil,
+		},
+		{
+			desc:           "apply claim mappings granting domain access",
+			groups:         []string{"iot-ops", "iot-view"},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			grant: auth.ClaimGrant{
+				DomainID: validID,
+				UserID:   id,
+				Provider: provider,
+				Relation: auth.EditorRelation,
+				GroupIDs: []string{groupID},
+			},
+			err: nil,
+		},
+		{
+			desc:   "apply claim mappings to existing domain member",
+			groups: []string{"iot-ops"},
+			grant: auth.ClaimGrant{
+				DomainID: validID,
+				UserID:   id,
+				Provider: provider,
+			},
+			err: nil,
+		},
+		{
+			desc:   "apply claim mappings changing granted relation",
+			groups: []string{"iot-view"},
+			grants: []auth.ClaimGrant{
+				{DomainID: validID, UserID: id, Provider: provider, Relation: auth.EditorRelation, GroupIDs: []string{groupID}},
+			},
+			grant: auth.ClaimGrant{
+				DomainID: validID,
+				UserID:   id,
+				Provider: provider,
+				Relation: auth.GuestRelation,
+			},
+			err: nil,
+		},
+		{
+			desc:   "apply claim mappings revoking granted access",
+			groups: []string{},
+			grants: []auth.ClaimGrant{
+				{DomainID: validID, UserID: id, Provider: provider, Relation: auth.EditorRelation, GroupIDs: []string{groupID}},
+			},
+			err: nil,
+		},
+		{
+			desc:        "apply claim mappings with failed to retrieve mappings",
+			groups:      []string{"iot-ops"},
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+		{
+			desc:           "apply claim mappings with failed to add policies",
+			groups:         []string{"iot-ops"},
+			checkPolicyErr: svcerr.ErrAuthorization,
+			addPoliciesErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "apply claim mappings with failed to save grant",
+			groups: []string{"iot-ops"},
+			grant: auth.ClaimGrant{
+				DomainID: validID,
+				UserID:   id,
+				Provider: provider,
+			},
+			saveGrantErr: repoerr.ErrCreateEntity,
+			err:          repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		cmrepo.On("RetrieveByProvider", mock.Anything, provider).Return(mappings, tc.retrieveErr)
+		cmrepo.On("RetrieveGrants", mock.Anything, id, provider).Return(tc.grants, nil)
+		cmrepo.On("SaveGrant", mock.Anything, tc.grant).Return(tc.saveGrantErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		prepo.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesErr)
+		prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(nil)
+		prepo.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(nil)
+		drepo.On("SavePolicies", mock.Anything, mock.Anything).Return(nil)
+		drepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(nil)
+		rrepo.On("RemoveUserAssignments", mock.Anything, validID, id).Return([]auth.RoleAssignment{}, nil)
+		err := svc.ApplyClaimMappings(context.Background(), id, provider, tc.groups)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestIssueAccountKey(t *testing.T) {
+	_, accessToken := newService()
+
+	cases := []struct {
+		desc      string
+		token     string
+		key       auth.Key
+		adminErr  error
+		userErr   error
+		memberErr error
+		saveErr   error
+		err       error
+	}{
+		{
+			desc:    "issue service account key",
+			token:   accessToken,
+			key:     auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			userErr: svcerr.ErrAuthorization,
+			err:     nil,
+		},
+		{
+			desc:    "issue service account key without domain",
+			token:   accessToken,
+			key:     auth.Key{Type: auth.APIKey, User: validID},
+			userErr: svcerr.ErrAuthorization,
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "issue service account key without token",
+			key:     auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			userErr: svcerr.ErrAuthorization,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "issue service account key with invalid token",
+			token:   inValidToken,
+			key:     auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			userErr: svcerr.ErrAuthorization,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "issue service account key by non domain administrator",
+			token:    accessToken,
+			key:      auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			adminErr: svcerr.ErrAuthorization,
+			userErr:  svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "issue service account key of platform user",
+			token: accessToken,
+			key:   auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "issue service account key of non domain member",
+			token:     accessToken,
+			key:       auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			userErr:   svcerr.ErrAuthorization,
+			memberErr: svcerr.ErrAuthorization,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:    "issue service account key with failed to save key",
+			token:   accessToken,
+			key:     auth.Key{Type: auth.APIKey, User: validID, Domain: groupName},
+			userErr: svcerr.ErrAuthorization,
+			saveErr: repoerr.ErrCreateEntity,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.DomainType && pr.Permission == auth.AdminPermission
+		})).Return(tc.adminErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.PlatformType && pr.Permission == auth.MembershipPermission
+		})).Return(tc.userErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.PlatformType
+		})).Return(svcerr.ErrAuthorization)
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.Subject == auth.EncodeDomainUserID(groupName, validID)
+		})).Return(tc.memberErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+		krepo.On("Save", mock.Anything, mock.MatchedBy(func(key auth.Key) bool {
+			return key.User == validID && key.Subject == auth.EncodeDomainUserID(groupName, validID) && key.Issuer != ""
+		})).Return(validID, tc.saveErr)
+		drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName, Status: auth.EnabledStatus}, nil)
+		tkn, err := svc.Issue(context.Background(), tc.token, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.NotEmpty(t, tkn.AccessToken, fmt.Sprintf("%s expected API key to be issued", tc.desc))
+		}
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	tokenizer := jwt.New([]byte(secret))
+	apiKey, err := tokenizer.Issue(auth.Key{ID: id, Type: auth.APIKey, User: validID, Domain: groupName, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	accessKey, err := tokenizer.Issue(auth.Key{Type: auth.AccessKey, User: validID, Domain: groupName, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(loginDuration)})
+	assert.Nil(t, err, fmt.Sprintf("Issuing access key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc        string
+		clientID    string
+		secret      string
+		scopes      []auth.Scope
+		retrieveErr error
+		memberErr   error
+		err         error
+	}{
+		{
+			desc:     "exchange API key for access token",
+			clientID: validID,
+			secret:   apiKey,
+			err:      nil,
+		},
+		{
+			desc:     "exchange API key with invalid client ID",
+			clientID: inValid,
+			secret:   apiKey,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "exchange invalid API key",
+			clientID: validID,
+			secret:   inValidToken,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "exchange access token",
+			clientID: validID,
+			secret:   accessKey,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "exchange revoked API key",
+			clientID:    validID,
+			secret:      apiKey,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "exchange scoped API key",
+			clientID: validID,
+			secret:   apiKey,
+			scopes:   []auth.Scope{{Operation: "things:view"}},
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "exchange API key of removed domain member",
+			clientID:  validID,
+			secret:    apiKey,
+			memberErr: svcerr.ErrAuthorization,
+			err:       svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		krepo.On("Retrieve", mock.Anything, mock.Anything, id).Return(auth.Key{Scopes: tc.scopes}, tc.retrieveErr)
+		prepo.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(pr auth.PolicyReq) bool {
+			return pr.ObjectType == auth.PlatformType
+		})).Return(svcerr.ErrAuthorization)
+		prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.memberErr)
+		drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName, Status: auth.EnabledStatus}, nil)
+		tkn, err := svc.ClientCredentials(context.Background(), tc.clientID, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			key, err := tokenizer.Parse(tkn.AccessToken)
+			assert.Nil(t, err, fmt.Sprintf("%s parsing access token expected to succeed: %s", tc.desc, err))
+			assert.Equal(t, auth.AccessKey, key.Type, fmt.Sprintf("%s expected access token", tc.desc))
+			assert.Equal(t, auth.EncodeDomainUserID(groupName, validID), key.Subject, fmt.Sprintf("%s expected domain subject", tc.desc))
+			assert.Empty(t, tkn.RefreshToken, fmt.Sprintf("%s expected no refresh token", tc.desc))
+		}
+	}
+}
+
+func TestAddDomainMember(t *testing.T) {
+	cases := []struct {
+		desc           string
+		retrieveErr    error
+		reserveErr     error
+		addPoliciesErr error
+		savePolicyErr  error
+		err            error
+	}{
+		{
+			desc: "add domain member",
+			err:  nil,
+		},
+		{
+			desc:       "add domain member with failed to reserve users quota",
+			reserveErr: repoerr.ErrCreateEntity,
+			err:        svcerr.ErrCreateEntity,
+		},
+		{
+			desc:        "add member to non-existing domain",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "add domain member with failed to add policies",
+			addPoliciesErr: svcerr.ErrAuthorization,
+			err:            errAddPolicies,
+		},
+		{
+			desc:          "add domain member with failed to save policies",
+			savePolicyErr: repoerr.ErrCreateEntity,
+			err:           errAddPolicies,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		drepo.On("RetrieveByID", mock.Anything, validID).Return(domain, tc.retrieveErr)
+		prepo.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesErr)
+		prepo.On("DeletePolicies", mock.Anything, mock.Anything).Return(nil)
+		drepo.On("SavePolicies", mock.Anything, mock.Anything).Return(tc.savePolicyErr)
+		urepo.On("ReserveEntities", mock.Anything, domain.ID, auth.UsersQuota, uint64(0), id).Return([]string{id}, tc.reserveErr)
+		urepo.On("RemoveEntities", mock.Anything, domain.ID, auth.UsersQuota, id).Return(nil)
+		err := svc.AddDomainMember(context.Background(), validID, id, auth.MemberRelation)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.addPoliciesErr != nil || tc.savePolicyErr != nil {
+			ok := urepo.AssertCalled(t, "RemoveEntities", mock.Anything, domain.ID, auth.UsersQuota, id)
+			assert.True(t, ok, fmt.Sprintf("%s: expected the reserved user to be released", tc.desc))
+		}
+	}
+}
+
+func TestRetrieveDomainUsage(t *testing.T) {
+	svc, accessToken := newService()
+
+	usage := auth.Usage{Things: 2, Channels: 1, Users: 1, Messages: 10, Storage: 1024}
+
+	cases := []struct {
+		desc           string
+		token          string
+		checkPolicyErr error
+		retrieveErr    error
+		usageErr       error
+		resp           auth.DomainUsage
+		err            error
+	}{
+		{
+			desc:  "retrieve domain usage successfully",
+			token: accessToken,
+			resp:  auth.DomainUsage{Quotas: auth.Quotas{Things: 10}, Usage: usage},
+		},
+		{
+			desc:  "retrieve domain usage with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:           "retrieve domain usage without domain admin permission",
+			token:          accessToken,
+			checkPolicyErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "retrieve usage of non-existing domain",
+			token:       accessToken,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "retrieve domain usage with failed to retrieve usage",
+			token:    accessToken,
+			usageErr: repoerr.ErrViewEntity,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := prepo.On("CheckPolicy", mock.Anything, mock.Anything).Return(tc.checkPolicyErr)
+		domainCall := drepo.On("RetrieveByID", mock.Anything, groupName).Return(auth.Domain{ID: groupName, Status: auth.EnabledStatus}, nil)
+		repoCall1 := drepo.On("RetrieveByID", mock.Anything, validID).Return(auth.Domain{ID: validID, Quotas: auth.Quotas{Things: 10}}, tc.retrieveErr)
+		repoCall2 := urepo.On("Retrieve", mock.Anything, validID, mock.Anything).Return(usage, tc.usageErr)
+		resp, err := svc.RetrieveDomainUsage(context.Background(), tc.token, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.resp, resp))
+		repoCall.Unset()
+		domainCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	cases := []struct {
+		desc        string
+		quotas      auth.Quotas
+		resource    string
+		count       uint64
+		retrieveErr error
+		usage       auth.Usage
+		usageErr    error
+		limited     bool
+		err         error
+	}{
+		{
+			desc:     "check quota of domain without quotas",
+			resource: auth.ThingsQuota,
+			count:    100,
+		},
+		{
+			desc:     "check quota within the limit",
+			quotas:   auth.Quotas{Things: 10},
+			resource: auth.ThingsQuota,
+			count:    2,
+			usage:    auth.Usage{Things: 8},
+			limited:  true,
+		},
+		{
+			desc:     "check quota of unlimited resource",
+			quotas:   auth.Quotas{Things: 10},
+			resource: auth.MessagesQuota,
+			count:    1,
+			usage:    auth.Usage{Things: 10},
+		},
+		{
+			desc:     "check messages quota with storage quota",
+			quotas:   auth.Quotas{Storage: 1024},
+			resource: auth.MessagesQuota,
+			count:    1,
+			usage:    auth.Usage{Storage: 512},
+			limited:  true,
+		},
+		{
+			desc:     "check quota above the limit",
+			quotas:   auth.Quotas{Things: 10},
+			resource: auth.ThingsQuota,
+			count:    3,
+			usage:    auth.Usage{Things: 8},
+			limited:  true,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:     "check messages quota with exhausted storage",
+			quotas:   auth.Quotas{MessagesPerDay: 100, Storage: 1024},
+			resource: auth.MessagesQuota,
+			count:    1,
+			usage:    auth.Usage{Messages: 10, Storage: 1024},
+			limited:  true,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:     "check quota of invalid resource",
+			quotas:   auth.Quotas{Things: 10},
+			resource: inValid,
+			count:    1,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "check quota of non-existing domain",
+			resource:    auth.ThingsQuota,
+			count:       1,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "check quota with failed to retrieve usage",
+			quotas:   auth.Quotas{Things: 10},
+			resource: auth.ThingsQuota,
+			count:    1,
+			usageErr: repoerr.ErrViewEntity,
+			limited:  true,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		drepo.On("RetrieveByID", mock.Anything, validID).Return(auth.Domain{ID: validID, Quotas: tc.quotas}, tc.retrieveErr)
+		urepo.On("Retrieve", mock.Anything, validID, mock.Anything).Return(tc.usage, tc.usageErr)
+		limited, err := svc.CheckQuota(context.Background(), validID, tc.resource, tc.count)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.limited, limited, fmt.Sprintf("%s expected limited %t got %t\n", tc.desc, tc.limited, limited))
+		if !tc.limited {
+			urepo.AssertNotCalled(t, "Retrieve", mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func TestAddDomainMemberQuota(t *testing.T) {
+	svc, _ := newService()
+	drepo.On("RetrieveByID", mock.Anything, validID).Return(auth.Domain{ID: validID, Quotas: auth.Quotas{Users: 2}}, nil)
+	urepo.On("ReserveEntities", mock.Anything, validID, auth.UsersQuota, uint64(2), id).Return(nil, svcerr.ErrQuotaExceeded)
+
+	err := svc.AddDomainMember(context.Background(), validID, id, auth.MemberRelation)
+	assert.True(t, errors.Contains(err, svcerr.ErrQuotaExceeded), fmt.Sprintf("add domain member above users quota expected %s got %s\n", svcerr.ErrQuotaExceeded, err))
+	prepo.AssertNotCalled(t, "AddPolicies", mock.Anything, mock.Anything)
+}
+
+func TestReserveQuota(t *testing.T) {
+	cases := []struct {
+		desc        string
+		quotas      auth.Quotas
+		resource    string
+		existing    bool
+		limit       uint64
+		retrieveErr error
+		reserved    []string
+		reserveErr  error
+		err         error
+	}{
+		{
+			desc:     "reserve quota within the limit",
+			quotas:   auth.Quotas{Things: 10},
+			resource: auth.ThingsQuota,
+			limit:    10,
+			reserved: []string{id},
+		},
+		{
+			desc:     "reserve quota of domain without quotas",
+			resource: auth.ChannelsQuota,
+			reserved: []string{id},
+		},
+		{
+			desc:     "reserve quota of existing entities above the limit",
+			quotas:   auth.Quotas{Things: 1},
+			resource: auth.ThingsQuota,
+			existing: true,
+			reserved: []string{id},
+		},
+		{
+			desc:       "reserve quota above the limit",
+			quotas:     auth.Quotas{Things: 1},
+			resource:   auth.ThingsQuota,
+			limit:      1,
+			reserveErr: svcerr.ErrQuotaExceeded,
+			err:        svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:     "reserve quota of messages",
+			quotas:   auth.Quotas{MessagesPerDay: 10},
+			resource: auth.MessagesQuota,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "reserve quota of non-existing domain",
+			resource:    auth.ThingsQuota,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "reserve quota with failed to record entities",
+			resource:   auth.ThingsQuota,
+			reserveErr: repoerr.ErrCreateEntity,
+			err:        svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		drepo.On("RetrieveByID", mock.Anything, validID).Return(auth.Domain{ID: validID, Quotas: tc.quotas}, tc.retrieveErr)
+		urepo.On("ReserveEntities", mock.Anything, validID, tc.resource, tc.limit, id, validID).Return(tc.reserved, tc.reserveErr)
+		reserved, err := svc.ReserveQuota(context.Background(), validID, tc.resource, tc.existing, id, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.reserved, reserved, fmt.Sprintf("%s expected reserved %v got %v\n", tc.desc, tc.reserved, reserved))
+	}
+}
+
+func TestReleaseQuota(t *testing.T) {
+	cases := []struct {
+		desc      string
+		resource  string
+		removeErr error
+		err       error
+	}{
+		{
+			desc:     "release quota",
+			resource: auth.ThingsQuota,
+		},
+		{
+			desc:     "release quota of messages",
+			resource: auth.MessagesQuota,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:      "release quota with failed to remove entities",
+			resource:  auth.ChannelsQuota,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _ := newService()
+		urepo.On("RemoveEntities", mock.Anything, validID, tc.resource, id).Return(tc.removeErr)
+		err := svc.ReleaseQuota(context.Background(), validID, tc.resource, id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Session represents the user login session. The access and refresh
+// tokens issued on login, and on the refresh of those tokens, carry
+// the session ID, so terminating the session revokes all of them.
+type Session struct {
+	ID          string    `json:"id"`
+	User        string    `json:"user_id"`
+	Domain      string    `json:"domain_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Current marks the session of the token the sessions are listed with.
+	Current bool `json:"-"`
+}
+
+// SessionRepository specifies session persistence API.
+// Sessions are removed once they expire.
+//
+//go:generate mockery --name SessionRepository --output=./mocks --filename sessions.go --quiet --note "Copyright (c) Abstract Machines"
+type SessionRepository interface {
+	// Save persists the session until it expires.
+	Save(ctx context.Context, session Session) error
+
+	// Retrieve retrieves the session of the user by its ID.
+	Retrieve(ctx context.Context, userID, id string) (Session, error)
+
+	// RetrieveAll retrieves the active sessions of the user.
+	RetrieveAll(ctx context.Context, userID string) ([]Session, error)
+
+	// Remove removes the session of the user.
+	Remove(ctx context.Context, userID, id string) error
+
+	// RemoveAll removes all the sessions of the user.
+	RemoveAll(ctx context.Context, userID string) error
+}
+
+// TokenRepository specifies revoked tokens persistence API.
+//
+//go:generate mockery --name TokenRepository --output=./mocks --filename tokens.go --quiet --note "Copyright (c) Abstract Machines"
+type TokenRepository interface {
+	// Revoke marks the token ID as revoked until the token expires.
+	Revoke(ctx context.Context, id string, expiresAt time.Time) error
+
+	// Revoked checks if the token ID is revoked.
+	Revoked(ctx context.Context, id string) (bool, error)
+
+	// Generation retrieves the token generation of the user. Tokens
+	// issued with a lower generation are revoked.
+	Generation(ctx context.Context, userID string) (uint64, error)
+
+	// IncrementGeneration increments the token generation of the user,
+	// revoking all the tokens issued to the user so far.
+	IncrementGeneration(ctx context.Context, userID string) error
+}
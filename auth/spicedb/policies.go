@@ -80,6 +80,144 @@ func (pa *policyAgent) CheckPolicy(ctx context.Context, pr auth.PolicyReq) error
 	return svcerr.ErrAuthorization
 }
 
+func (pa *policyAgent) ExplainPolicy(ctx context.Context, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	checkReq := v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_FullyConsistent{
+				FullyConsistent: true,
+			},
+		},
+		Resource:    &v1.ObjectReference{ObjectType: pr.ObjectType, ObjectId: pr.Object},
+		Permission:  pr.Permission,
+		Subject:     &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: pr.SubjectType, ObjectId: pr.Subject}, OptionalRelation: pr.SubjectRelation},
+		WithTracing: true,
+	}
+
+	resp, err := pa.permissionClient.CheckPermission(ctx, &checkReq)
+	if err != nil {
+		return auth.PolicyExplanation{}, handleSpicedbError(err)
+	}
+	trace := resp.GetDebugTrace().GetCheck()
+	if resp.Permissionship != v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
+		return auth.PolicyExplanation{Required: requiredSteps(pr, trace, nil)}, nil
+	}
+	path, err := pa.grantingPath(ctx, pr, trace)
+	if err != nil {
+		return auth.PolicyExplanation{}, err
+	}
+
+	return auth.PolicyExplanation{Allowed: true, Path: path}, nil
+}
+
+// grantingPath follows the granting subproblems of the check trace down to the
+// relationship of the subject, returning the relationships on the way ordered
+// from the relationship of the subject.
+func (pa *policyAgent) grantingPath(ctx context.Context, pr auth.PolicyReq, trace *v1.CheckDebugTrace) ([]auth.PolicyStep, error) {
+	if trace == nil {
+		return nil, nil
+	}
+	for _, sub := range trace.GetSubProblems().GetTraces() {
+		if sub.GetResult() != v1.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION {
+			continue
+		}
+		path, err := pa.grantingPath(ctx, pr, sub)
+		if err != nil {
+			return nil, err
+		}
+		res, subRes := trace.GetResource(), sub.GetResource()
+		if res.GetObjectType() == subRes.GetObjectType() && res.GetObjectId() == subRes.GetObjectId() {
+			return path, nil
+		}
+		// The subproblem on the other object is either the subject set of the
+		// relation, or the permission of the object related by the arrow.
+		relation := trace.GetPermission()
+		if trace.GetPermissionType() != v1.CheckDebugTrace_PERMISSION_TYPE_RELATION {
+			if relation, err = pa.relation(ctx, res, subRes); err != nil {
+				return nil, err
+			}
+		}
+		step := auth.PolicyStep{
+			SubjectType: subRes.GetObjectType(),
+			Subject:     subRes.GetObjectId(),
+			Relation:    relation,
+			ObjectType:  res.GetObjectType(),
+			Object:      res.GetObjectId(),
+		}
+
+		return append(path, step), nil
+	}
+
+	return []auth.PolicyStep{{
+		SubjectType: pr.SubjectType,
+		Subject:     pr.Subject,
+		Relation:    trace.GetPermission(),
+		ObjectType:  trace.GetResource().GetObjectType(),
+		Object:      trace.GetResource().GetObjectId(),
+	}}, nil
+}
+
+// relation returns the relation of the resource to the subject object.
+func (pa *policyAgent) relation(ctx context.Context, resource, subject *v1.ObjectReference) (string, error) {
+	req := &v1.ReadRelationshipsRequest{
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_FullyConsistent{
+				FullyConsistent: true,
+			},
+		},
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       resource.GetObjectType(),
+			OptionalResourceId: resource.GetObjectId(),
+			OptionalSubjectFilter: &v1.SubjectFilter{
+				SubjectType:       subject.GetObjectType(),
+				OptionalSubjectId: subject.GetObjectId(),
+			},
+		},
+		OptionalLimit: 1,
+	}
+	stream, err := pa.permissionClient.ReadRelationships(ctx, req)
+	if err != nil {
+		return "", errors.Wrap(errRetrievePolicies, handleSpicedbError(err))
+	}
+	resp, err := stream.Recv()
+	switch {
+	case errors.Contains(err, io.EOF):
+		return "", nil
+	case err != nil:
+		return "", errors.Wrap(errRetrievePolicies, handleSpicedbError(err))
+	}
+
+	return resp.GetRelationship().GetRelation(), nil
+}
+
+// requiredSteps returns the relations of the denied check trace the subject
+// does not have, each of them granting the permission.
+func requiredSteps(pr auth.PolicyReq, trace *v1.CheckDebugTrace, steps []auth.PolicyStep) []auth.PolicyStep {
+	if trace == nil {
+		return steps
+	}
+	subs := trace.GetSubProblems().GetTraces()
+	if len(subs) == 0 && trace.GetPermissionType() == v1.CheckDebugTrace_PERMISSION_TYPE_RELATION {
+		step := auth.PolicyStep{
+			SubjectType: pr.SubjectType,
+			Subject:     pr.Subject,
+			Relation:    trace.GetPermission(),
+			ObjectType:  trace.GetResource().GetObjectType(),
+			Object:      trace.GetResource().GetObjectId(),
+		}
+		for _, s := range steps {
+			if s == step {
+				return steps
+			}
+		}
+		return append(steps, step)
+	}
+	for _, sub := range subs {
+		steps = requiredSteps(pr, sub, steps)
+	}
+
+	return steps
+}
+
 func (pa *policyAgent) AddPolicies(ctx context.Context, prs []auth.PolicyReq) error {
 	updates := []*v1.RelationshipUpdate{}
 	var preconds []*v1.Precondition
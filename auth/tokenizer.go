@@ -3,6 +3,21 @@
 
 package auth
 
+// JWK represents the public JSON Web Key used to verify issued tokens.
+type JWK struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use,omitempty"`
+	// RSA public key parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC and OKP public key parameters.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +25,8 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(token string) (key Key, err error)
+
+	// RetrieveJWKS returns the public keys tokens are verified with.
+	// The set is empty if tokens are signed with a shared secret.
+	RetrieveJWKS() ([]JWK, error)
 }
@@ -6,6 +6,7 @@ package tracing
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
 	"go.opentelemetry.io/otel/attribute"
@@ -59,6 +60,64 @@ func (tm *tracingMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return tm.svc.Identify(ctx, token)
 }
 
+func (tm *tracingMiddleware) ListKeys(ctx context.Context, token string, pm auth.Page) (auth.KeyPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_keys", trace.WithAttributes(
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.Int64("offset", int64(pm.Offset)),
+	))
+	defer span.End()
+
+	return tm.svc.ListKeys(ctx, token, pm)
+}
+
+func (tm *tracingMiddleware) RetrieveJWKS(ctx context.Context) ([]auth.JWK, error) {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_jwks")
+	defer span.End()
+
+	return tm.svc.RetrieveJWKS(ctx)
+}
+
+func (tm *tracingMiddleware) ListSessions(ctx context.Context, token string) ([]auth.Session, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_sessions")
+	defer span.End()
+
+	return tm.svc.ListSessions(ctx, token)
+}
+
+func (tm *tracingMiddleware) RevokeSession(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_session", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RevokeSession(ctx, token, id)
+}
+
+func (tm *tracingMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_sessions")
+	defer span.End()
+
+	return tm.svc.RevokeSessions(ctx, token)
+}
+
+func (tm *tracingMiddleware) RevokeTokens(ctx context.Context, userID string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_tokens", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer span.End()
+
+	return tm.svc.RevokeTokens(ctx, userID)
+}
+
+func (tm *tracingMiddleware) ClientCredentials(ctx context.Context, clientID, secret string) (auth.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "client_credentials", trace.WithAttributes(
+		attribute.String("client_id", clientID),
+	))
+	defer span.End()
+
+	return tm.svc.ClientCredentials(ctx, clientID, secret)
+}
+
 func (tm *tracingMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) error {
 	ctx, span := tm.tracer.Start(ctx, "authorize", trace.WithAttributes(
 		attribute.String("subject", pr.Subject),
@@ -74,6 +133,19 @@ func (tm *tracingMiddleware) Authorize(ctx context.Context, pr auth.PolicyReq) e
 	return tm.svc.Authorize(ctx, pr)
 }
 
+func (tm *tracingMiddleware) ExplainPolicy(ctx context.Context, token string, pr auth.PolicyReq) (auth.PolicyExplanation, error) {
+	ctx, span := tm.tracer.Start(ctx, "explain_policy", trace.WithAttributes(
+		attribute.String("subject", pr.Subject),
+		attribute.String("subject_type", pr.SubjectType),
+		attribute.String("object", pr.Object),
+		attribute.String("object_type", pr.ObjectType),
+		attribute.String("permission", pr.Permission),
+	))
+	defer span.End()
+
+	return tm.svc.ExplainPolicy(ctx, token, pr)
+}
+
 func (tm *tracingMiddleware) AddPolicy(ctx context.Context, pr auth.PolicyReq) error {
 	ctx, span := tm.tracer.Start(ctx, "add_policy", trace.WithAttributes(
 		attribute.String("subject", pr.Subject),
@@ -118,6 +190,13 @@ func (tm *tracingMiddleware) DeletePolicies(ctx context.Context, prs []auth.Poli
 	return tm.svc.DeletePolicies(ctx, prs)
 }
 
+func (tm *tracingMiddleware) ExpirePolicies(ctx context.Context) ([]auth.ConditionalPolicy, error) {
+	ctx, span := tm.tracer.Start(ctx, "expire_policies")
+	defer span.End()
+
+	return tm.svc.ExpirePolicies(ctx)
+}
+
 func (tm *tracingMiddleware) ListObjects(ctx context.Context, pr auth.PolicyReq, nextPageToken string, limit uint64) (auth.PolicyPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "list_objects", trace.WithAttributes(
 		attribute.String("subject", pr.Subject),
@@ -277,14 +356,14 @@ func (tm *tracingMiddleware) ListDomains(ctx context.Context, token string, p au
 	return tm.svc.ListDomains(ctx, token, p)
 }
 
-func (tm *tracingMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string) error {
+func (tm *tracingMiddleware) AssignUsers(ctx context.Context, token, id string, userIds []string, relation string, cond *auth.Condition) error {
 	ctx, span := tm.tracer.Start(ctx, "assign_users", trace.WithAttributes(
 		attribute.String("id", id),
 		attribute.StringSlice("user_ids", userIds),
 		attribute.String("relation", relation),
 	))
 	defer span.End()
-	return tm.svc.AssignUsers(ctx, token, id, userIds, relation)
+	return tm.svc.AssignUsers(ctx, token, id, userIds, relation, cond)
 }
 
 func (tm *tracingMiddleware) UnassignUser(ctx context.Context, token, id, userID string) error {
@@ -304,6 +383,79 @@ func (tm *tracingMiddleware) ListUserDomains(ctx context.Context, token, userID
 	return tm.svc.ListUserDomains(ctx, token, userID, p)
 }
 
+func (tm *tracingMiddleware) AddDomainMember(ctx context.Context, domainID, userID, relation string) error {
+	ctx, span := tm.tracer.Start(ctx, "add_domain_member", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("user_id", userID),
+		attribute.String("relation", relation),
+	))
+	defer span.End()
+	return tm.svc.AddDomainMember(ctx, domainID, userID, relation)
+}
+
+func (tm *tracingMiddleware) RetrieveDomainUsage(ctx context.Context, token, id string) (auth.DomainUsage, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_domain_usage", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RetrieveDomainUsage(ctx, token, id)
+}
+
+func (tm *tracingMiddleware) CheckQuota(ctx context.Context, domainID, resource string, count uint64) (bool, error) {
+	ctx, span := tm.tracer.Start(ctx, "check_quota", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("resource", resource),
+		attribute.Int64("count", int64(count)),
+	))
+	defer span.End()
+	return tm.svc.CheckQuota(ctx, domainID, resource, count)
+}
+
+func (tm *tracingMiddleware) ReserveQuota(ctx context.Context, domainID, resource string, existing bool, ids ...string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "reserve_quota", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("resource", resource),
+		attribute.Bool("existing", existing),
+		attribute.Int("count", len(ids)),
+	))
+	defer span.End()
+	return tm.svc.ReserveQuota(ctx, domainID, resource, existing, ids...)
+}
+
+func (tm *tracingMiddleware) ReleaseQuota(ctx context.Context, domainID, resource string, ids ...string) error {
+	ctx, span := tm.tracer.Start(ctx, "release_quota", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("resource", resource),
+		attribute.Int("count", len(ids)),
+	))
+	defer span.End()
+	return tm.svc.ReleaseQuota(ctx, domainID, resource, ids...)
+}
+
+func (tm *tracingMiddleware) DeleteDomain(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	ctx, span := tm.tracer.Start(ctx, "delete_domain", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.DeleteDomain(ctx, token, id)
+}
+
+func (tm *tracingMiddleware) RetrieveDomainDeletion(ctx context.Context, token, id string) (auth.DomainDeletion, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_domain_deletion", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RetrieveDomainDeletion(ctx, token, id)
+}
+
+func (tm *tracingMiddleware) PurgeDomains(ctx context.Context, before time.Time) ([]auth.DomainDeletion, error) {
+	ctx, span := tm.tracer.Start(ctx, "purge_domains", trace.WithAttributes(
+		attribute.String("before", before.String()),
+	))
+	defer span.End()
+	return tm.svc.PurgeDomains(ctx, before)
+}
+
 func (tm *tracingMiddleware) DeleteEntityPolicies(ctx context.Context, entityType, id string) error {
 	ctx, span := tm.tracer.Start(ctx, "delete_entity_policies", trace.WithAttributes(
 		attribute.String("entity_type", entityType),
@@ -312,3 +464,132 @@ func (tm *tracingMiddleware) DeleteEntityPolicies(ctx context.Context, entityTyp
 	defer span.End()
 	return tm.svc.DeleteEntityPolicies(ctx, entityType, id)
 }
+
+func (tm *tracingMiddleware) CreateRole(ctx context.Context, token, domainID string, r auth.Role) (auth.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("name", r.Name),
+		attribute.StringSlice("permissions", r.Permissions),
+	))
+	defer span.End()
+	return tm.svc.CreateRole(ctx, token, domainID, r)
+}
+
+func (tm *tracingMiddleware) RetrieveRole(ctx context.Context, token, domainID, id string) (auth.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RetrieveRole(ctx, token, domainID, id)
+}
+
+func (tm *tracingMiddleware) ListRoleAssignments(ctx context.Context, token, domainID, id string) ([]auth.RoleAssignment, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_role_assignments", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.ListRoleAssignments(ctx, token, domainID, id)
+}
+
+func (tm *tracingMiddleware) ListRoles(ctx context.Context, token, domainID string, pm auth.Page) (auth.RolesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_roles", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.Int64("offset", int64(pm.Offset)),
+	))
+	defer span.End()
+	return tm.svc.ListRoles(ctx, token, domainID, pm)
+}
+
+func (tm *tracingMiddleware) UpdateRole(ctx context.Context, token, domainID, id string, r auth.RoleReq) (auth.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.UpdateRole(ctx, token, domainID, id, r)
+}
+
+func (tm *tracingMiddleware) DeleteRole(ctx context.Context, token, domainID, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "delete_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.DeleteRole(ctx, token, domainID, id)
+}
+
+func (tm *tracingMiddleware) AssignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	ctx, span := tm.tracer.Start(ctx, "assign_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+		attribute.String("entity", entity),
+		attribute.String("entity_id", entityID),
+		attribute.StringSlice("user_ids", userIDs),
+	))
+	defer span.End()
+	return tm.svc.AssignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (tm *tracingMiddleware) UnassignRole(ctx context.Context, token, domainID, id, entity, entityID string, userIDs []string) error {
+	ctx, span := tm.tracer.Start(ctx, "unassign_role", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+		attribute.String("entity", entity),
+		attribute.String("entity_id", entityID),
+		attribute.StringSlice("user_ids", userIDs),
+	))
+	defer span.End()
+	return tm.svc.UnassignRole(ctx, token, domainID, id, entity, entityID, userIDs)
+}
+
+func (tm *tracingMiddleware) CreateClaimMapping(ctx context.Context, token, domainID string, m auth.ClaimMapping) (auth.ClaimMapping, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_claim_mapping", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("provider", m.Provider),
+		attribute.String("group", m.Group),
+		attribute.String("relation", m.Relation),
+	))
+	defer span.End()
+	return tm.svc.CreateClaimMapping(ctx, token, domainID, m)
+}
+
+func (tm *tracingMiddleware) RetrieveClaimMapping(ctx context.Context, token, domainID, id string) (auth.ClaimMapping, error) {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_claim_mapping", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RetrieveClaimMapping(ctx, token, domainID, id)
+}
+
+func (tm *tracingMiddleware) ListClaimMappings(ctx context.Context, token, domainID string, pm auth.Page) (auth.ClaimMappingsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_claim_mappings", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+	return tm.svc.ListClaimMappings(ctx, token, domainID, pm)
+}
+
+func (tm *tracingMiddleware) DeleteClaimMapping(ctx context.Context, token, domainID, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "delete_claim_mapping", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.DeleteClaimMapping(ctx, token, domainID, id)
+}
+
+func (tm *tracingMiddleware) ApplyClaimMappings(ctx context.Context, userID, provider string, groups []string) error {
+	ctx, span := tm.tracer.Start(ctx, "apply_claim_mappings", trace.WithAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("provider", provider),
+		attribute.StringSlice("groups", groups),
+	))
+	defer span.End()
+	return tm.svc.ApplyClaimMappings(ctx, userID, provider, groups)
+}
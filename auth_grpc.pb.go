@@ -123,9 +123,15 @@ var AuthzService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	AuthnService_Issue_FullMethodName    = "/magistrala.AuthnService/Issue"
-	AuthnService_Refresh_FullMethodName  = "/magistrala.AuthnService/Refresh"
-	AuthnService_Identify_FullMethodName = "/magistrala.AuthnService/Identify"
+	AuthnService_Issue_FullMethodName              = "/magistrala.AuthnService/Issue"
+	AuthnService_Refresh_FullMethodName            = "/magistrala.AuthnService/Refresh"
+	AuthnService_Identify_FullMethodName           = "/magistrala.AuthnService/Identify"
+	AuthnService_RevokeTokens_FullMethodName       = "/magistrala.AuthnService/RevokeTokens"
+	AuthnService_ApplyClaimMappings_FullMethodName = "/magistrala.AuthnService/ApplyClaimMappings"
+	AuthnService_AddDomainMember_FullMethodName    = "/magistrala.AuthnService/AddDomainMember"
+	AuthnService_CheckQuota_FullMethodName         = "/magistrala.AuthnService/CheckQuota"
+	AuthnService_ReserveQuota_FullMethodName       = "/magistrala.AuthnService/ReserveQuota"
+	AuthnService_ReleaseQuota_FullMethodName       = "/magistrala.AuthnService/ReleaseQuota"
 )
 
 // AuthnServiceClient is the client API for AuthnService service.
@@ -138,6 +144,23 @@ type AuthnServiceClient interface {
 	Issue(ctx context.Context, in *IssueReq, opts ...grpc.CallOption) (*Token, error)
 	Refresh(ctx context.Context, in *RefreshReq, opts ...grpc.CallOption) (*Token, error)
 	Identify(ctx context.Context, in *IdentityReq, opts ...grpc.CallOption) (*IdentityRes, error)
+	// RevokeTokens revokes all the access and refresh tokens
+	// issued to the user and terminates the user sessions.
+	RevokeTokens(ctx context.Context, in *RevokeTokensReq, opts ...grpc.CallOption) (*RevokeTokensRes, error)
+	// ApplyClaimMappings updates the domain access of the user
+	// according to the identity provider groups of the user.
+	ApplyClaimMappings(ctx context.Context, in *ApplyClaimMappingsReq, opts ...grpc.CallOption) (*ApplyClaimMappingsRes, error)
+	// AddDomainMember adds the user to the domain with the relation.
+	// It binds the service accounts to their domain.
+	AddDomainMember(ctx context.Context, in *AddDomainMemberReq, opts ...grpc.CallOption) (*AddDomainMemberRes, error)
+	// CheckQuota checks whether the domain may consume
+	// the count of the resource without exceeding its quota.
+	CheckQuota(ctx context.Context, in *CheckQuotaReq, opts ...grpc.CallOption) (*CheckQuotaRes, error)
+	// ReserveQuota records the entities of the resource in the domain
+	// before they are created, if they don't exceed its quota.
+	ReserveQuota(ctx context.Context, in *ReserveQuotaReq, opts ...grpc.CallOption) (*ReserveQuotaRes, error)
+	// ReleaseQuota removes the reserved entities which were not created.
+	ReleaseQuota(ctx context.Context, in *ReleaseQuotaReq, opts ...grpc.CallOption) (*ReleaseQuotaRes, error)
 }
 
 type authnServiceClient struct {
@@ -178,6 +201,66 @@ func (c *authnServiceClient) Identify(ctx context.Context, in *IdentityReq, opts
 	return out, nil
 }
 
+func (c *authnServiceClient) RevokeTokens(ctx context.Context, in *RevokeTokensReq, opts ...grpc.CallOption) (*RevokeTokensRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeTokensRes)
+	err := c.cc.Invoke(ctx, AuthnService_RevokeTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authnServiceClient) ApplyClaimMappings(ctx context.Context, in *ApplyClaimMappingsReq, opts ...grpc.CallOption) (*ApplyClaimMappingsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyClaimMappingsRes)
+	err := c.cc.Invoke(ctx, AuthnService_ApplyClaimMappings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authnServiceClient) AddDomainMember(ctx context.Context, in *AddDomainMemberReq, opts ...grpc.CallOption) (*AddDomainMemberRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDomainMemberRes)
+	err := c.cc.Invoke(ctx, AuthnService_AddDomainMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authnServiceClient) CheckQuota(ctx context.Context, in *CheckQuotaReq, opts ...grpc.CallOption) (*CheckQuotaRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckQuotaRes)
+	err := c.cc.Invoke(ctx, AuthnService_CheckQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authnServiceClient) ReserveQuota(ctx context.Context, in *ReserveQuotaReq, opts ...grpc.CallOption) (*ReserveQuotaRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveQuotaRes)
+	err := c.cc.Invoke(ctx, AuthnService_ReserveQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authnServiceClient) ReleaseQuota(ctx context.Context, in *ReleaseQuotaReq, opts ...grpc.CallOption) (*ReleaseQuotaRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseQuotaRes)
+	err := c.cc.Invoke(ctx, AuthnService_ReleaseQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthnServiceServer is the server API for AuthnService service.
 // All implementations must embed UnimplementedAuthnServiceServer
 // for forward compatibility
@@ -188,6 +271,23 @@ type AuthnServiceServer interface {
 	Issue(context.Context, *IssueReq) (*Token, error)
 	Refresh(context.Context, *RefreshReq) (*Token, error)
 	Identify(context.Context, *IdentityReq) (*IdentityRes, error)
+	// RevokeTokens revokes all the access and refresh tokens
+	// issued to the user and terminates the user sessions.
+	RevokeTokens(context.Context, *RevokeTokensReq) (*RevokeTokensRes, error)
+	// ApplyClaimMappings updates the domain access of the user
+	// according to the identity provider groups of the user.
+	ApplyClaimMappings(context.Context, *ApplyClaimMappingsReq) (*ApplyClaimMappingsRes, error)
+	// AddDomainMember adds the user to the domain with the relation.
+	// It binds the service accounts to their domain.
+	AddDomainMember(context.Context, *AddDomainMemberReq) (*AddDomainMemberRes, error)
+	// CheckQuota checks whether the domain may consume
+	// the count of the resource without exceeding its quota.
+	CheckQuota(context.Context, *CheckQuotaReq) (*CheckQuotaRes, error)
+	// ReserveQuota records the entities of the resource in the domain
+	// before they are created, if they don't exceed its quota.
+	ReserveQuota(context.Context, *ReserveQuotaReq) (*ReserveQuotaRes, error)
+	// ReleaseQuota removes the reserved entities which were not created.
+	ReleaseQuota(context.Context, *ReleaseQuotaReq) (*ReleaseQuotaRes, error)
 	mustEmbedUnimplementedAuthnServiceServer()
 }
 
@@ -204,6 +304,24 @@ func (UnimplementedAuthnServiceServer) Refresh(context.Context, *RefreshReq) (*T
 func (UnimplementedAuthnServiceServer) Identify(context.Context, *IdentityReq) (*IdentityRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Identify not implemented")
 }
+func (UnimplementedAuthnServiceServer) RevokeTokens(context.Context, *RevokeTokensReq) (*RevokeTokensRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeTokens not implemented")
+}
+func (UnimplementedAuthnServiceServer) ApplyClaimMappings(context.Context, *ApplyClaimMappingsReq) (*ApplyClaimMappingsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyClaimMappings not implemented")
+}
+func (UnimplementedAuthnServiceServer) AddDomainMember(context.Context, *AddDomainMemberReq) (*AddDomainMemberRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDomainMember not implemented")
+}
+func (UnimplementedAuthnServiceServer) CheckQuota(context.Context, *CheckQuotaReq) (*CheckQuotaRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckQuota not implemented")
+}
+func (UnimplementedAuthnServiceServer) ReserveQuota(context.Context, *ReserveQuotaReq) (*ReserveQuotaRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveQuota not implemented")
+}
+func (UnimplementedAuthnServiceServer) ReleaseQuota(context.Context, *ReleaseQuotaReq) (*ReleaseQuotaRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseQuota not implemented")
+}
 func (UnimplementedAuthnServiceServer) mustEmbedUnimplementedAuthnServiceServer() {}
 
 // UnsafeAuthnServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -271,6 +389,114 @@ func _AuthnService_Identify_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthnService_RevokeTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokensReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).RevokeTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_RevokeTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).RevokeTokens(ctx, req.(*RevokeTokensReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthnService_ApplyClaimMappings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyClaimMappingsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).ApplyClaimMappings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_ApplyClaimMappings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).ApplyClaimMappings(ctx, req.(*ApplyClaimMappingsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthnService_AddDomainMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).AddDomainMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_AddDomainMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).AddDomainMember(ctx, req.(*AddDomainMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthnService_CheckQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckQuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).CheckQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_CheckQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).CheckQuota(ctx, req.(*CheckQuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthnService_ReserveQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveQuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).ReserveQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_ReserveQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).ReserveQuota(ctx, req.(*ReserveQuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthnService_ReleaseQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseQuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthnServiceServer).ReleaseQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthnService_ReleaseQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthnServiceServer).ReleaseQuota(ctx, req.(*ReleaseQuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthnService_ServiceDesc is the grpc.ServiceDesc for AuthnService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -290,6 +516,30 @@ var AuthnService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Identify",
 			Handler:    _AuthnService_Identify_Handler,
 		},
+		{
+			MethodName: "RevokeTokens",
+			Handler:    _AuthnService_RevokeTokens_Handler,
+		},
+		{
+			MethodName: "ApplyClaimMappings",
+			Handler:    _AuthnService_ApplyClaimMappings_Handler,
+		},
+		{
+			MethodName: "AddDomainMember",
+			Handler:    _AuthnService_AddDomainMember_Handler,
+		},
+		{
+			MethodName: "CheckQuota",
+			Handler:    _AuthnService_CheckQuota_Handler,
+		},
+		{
+			MethodName: "ReserveQuota",
+			Handler:    _AuthnService_ReserveQuota_Handler,
+		},
+		{
+			MethodName: "ReleaseQuota",
+			Handler:    _AuthnService_ReleaseQuota_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
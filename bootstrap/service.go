@@ -145,7 +145,7 @@ func (bs bootstrapService) Add(ctx context.Context, token string, cfg Config) (C
 	if err != nil {
 		return Config{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	if _, err := bs.authorize(ctx, "", auth.UsersKind, user.GetId(), auth.MembershipPermission, auth.DomainType, user.GetDomainId()); err != nil {
+	if _, err := bs.authorize(ctx, "", auth.TokenKind, token, auth.MembershipPermission, auth.DomainType, user.GetDomainId()); err != nil {
 		return Config{}, err
 	}
 
@@ -202,7 +202,7 @@ func (bs bootstrapService) View(ctx context.Context, token, id string) (Config,
 	if err != nil {
 		return Config{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.UsersKind, user.GetId(), auth.ViewPermission, auth.ThingType, id); err != nil {
+	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.TokenKind, token, auth.ViewPermission, auth.ThingType, id); err != nil {
 		return Config{}, err
 	}
 	cfg, err := bs.configs.RetrieveByID(ctx, user.GetDomainId(), id)
@@ -217,7 +217,7 @@ func (bs bootstrapService) Update(ctx context.Context, token string, cfg Config)
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.UsersKind, user.GetId(), auth.EditPermission, auth.ThingType, cfg.ThingID); err != nil {
+	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.TokenKind, token, auth.EditPermission, auth.ThingType, cfg.ThingID); err != nil {
 		return err
 	}
 
@@ -233,7 +233,7 @@ func (bs bootstrapService) UpdateCert(ctx context.Context, token, thingID, clien
 	if err != nil {
 		return Config{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.UsersKind, user.GetId(), auth.EditPermission, auth.ThingType, thingID); err != nil {
+	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.TokenKind, token, auth.EditPermission, auth.ThingType, thingID); err != nil {
 		return Config{}, err
 	}
 
@@ -250,7 +250,7 @@ func (bs bootstrapService) UpdateConnections(ctx context.Context, token, id stri
 		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 
-	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.UsersKind, user.GetId(), auth.EditPermission, auth.ThingType, id); err != nil {
+	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.TokenKind, token, auth.EditPermission, auth.ThingType, id); err != nil {
 		return err
 	}
 
@@ -304,10 +304,13 @@ func (bs bootstrapService) UpdateConnections(ctx context.Context, token, id stri
 	return nil
 }
 
-func (bs bootstrapService) listClientIDs(ctx context.Context, userID string) ([]string, error) {
+// listClientIDs lists the things of the token user, limited to the things
+// of the token scopes.
+func (bs bootstrapService) listClientIDs(ctx context.Context, token string) ([]string, error) {
 	tids, err := bs.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  auth.ViewPermission,
 		ObjectType:  auth.ThingType,
 	})
@@ -317,10 +320,11 @@ func (bs bootstrapService) listClientIDs(ctx context.Context, userID string) ([]
 	return tids.Policies, nil
 }
 
-func (bs bootstrapService) checkSuperAdmin(ctx context.Context, userID string) error {
+func (bs bootstrapService) checkSuperAdmin(ctx context.Context, token string) error {
 	res, err := bs.auth.Authorize(ctx, &magistrala.AuthorizeReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  auth.AdminPermission,
 		ObjectType:  auth.PlatformType,
 		Object:      auth.MagistralaObject,
@@ -340,16 +344,16 @@ func (bs bootstrapService) List(ctx context.Context, token string, filter Filter
 		return ConfigsPage{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 
-	if err := bs.checkSuperAdmin(ctx, user.GetId()); err == nil {
+	if err := bs.checkSuperAdmin(ctx, token); err == nil {
 		return bs.configs.RetrieveAll(ctx, user.GetDomainId(), []string{}, filter, offset, limit), nil
 	}
 
-	if _, err := bs.authorize(ctx, "", auth.UsersKind, user.GetId(), auth.AdminPermission, auth.DomainType, user.GetDomainId()); err == nil {
+	if _, err := bs.authorize(ctx, "", auth.TokenKind, token, auth.AdminPermission, auth.DomainType, user.GetDomainId()); err == nil {
 		return bs.configs.RetrieveAll(ctx, user.GetDomainId(), []string{}, filter, offset, limit), nil
 	}
 
 	// Handle non-admin users
-	thingIDs, err := bs.listClientIDs(ctx, user.GetId())
+	thingIDs, err := bs.listClientIDs(ctx, token)
 	if err != nil {
 		return ConfigsPage{}, errors.Wrap(svcerr.ErrNotFound, err)
 	}
@@ -371,7 +375,7 @@ func (bs bootstrapService) Remove(ctx context.Context, token, id string) error {
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.UsersKind, user.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
+	if _, err := bs.authorize(ctx, user.GetDomainId(), auth.TokenKind, token, auth.DeletePermission, auth.ThingType, id); err != nil {
 		return err
 	}
 	if err := bs.configs.Remove(ctx, user.GetDomainId(), id); err != nil {
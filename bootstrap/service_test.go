@@ -971,22 +971,24 @@ func TestList(t *testing.T) {
 		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: tc.userID, DomainId: tc.domainID}, tc.identifyErr)
 		authCall1 := auth.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 			SubjectType: authsvc.UserType,
-			Subject:     tc.userID,
+			SubjectKind: authsvc.TokenKind,
+			Subject:     tc.token,
 			Permission:  authsvc.AdminPermission,
 			ObjectType:  authsvc.PlatformType,
 			Object:      authsvc.MagistralaObject,
 		}).Return(tc.superAdminAuthRes, tc.superAdmiAuthErr)
 		authCall2 := auth.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 			SubjectType: authsvc.UserType,
-			SubjectKind: authsvc.UsersKind,
-			Subject:     tc.userID,
+			SubjectKind: authsvc.TokenKind,
+			Subject:     tc.token,
 			Permission:  authsvc.AdminPermission,
 			ObjectType:  authsvc.DomainType,
 			Object:      tc.domainID,
 		}).Return(tc.domainAdminAuthRes, tc.domainAdmiAuthErr)
 		authCall3 := policy.On("ListAllObjects", mock.Anything, &magistrala.ListObjectsReq{
 			SubjectType: authsvc.UserType,
-			Subject:     tc.userID,
+			SubjectKind: authsvc.TokenKind,
+			Subject:     tc.token,
 			Permission:  authsvc.ViewPermission,
 			ObjectType:  authsvc.ThingType,
 		}).Return(tc.listObjectsResponse, tc.listObjectsErr)
@@ -129,3 +129,22 @@ func (lm *loggingMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return lm.svc.RevokeCert(ctx, token, thingID)
 }
+
+// RemoveThingCerts logs the remove_thing_certs request. It logs the thing ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveThingCerts(ctx context.Context, thingID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove thing certificates failed", args...)
+			return
+		}
+		lm.logger.Info("Remove thing certificates completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveThingCerts(ctx, thingID)
+}
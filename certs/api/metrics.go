@@ -79,3 +79,13 @@ func (ms *metricsMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return ms.svc.RevokeCert(ctx, token, thingID)
 }
+
+// RemoveThingCerts instruments RemoveThingCerts method with metrics.
+func (ms *metricsMiddleware) RemoveThingCerts(ctx context.Context, thingID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_thing_certs").Add(1)
+		ms.latency.With("method", "remove_thing_certs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveThingCerts(ctx, thingID)
+}
@@ -32,6 +32,7 @@ const (
 func MakeHandler(svc certs.Service, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	r := chi.NewRouter()
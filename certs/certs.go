@@ -39,6 +39,9 @@ type Repository interface {
 	// RetrieveByThing retrieves issued certificates for a given thing ID
 	RetrieveByThing(ctx context.Context, ownerID, thingID string, offset, limit uint64) (Page, error)
 
+	// RetrieveAllByThing retrieves certificates issued by all owners for a given thing ID
+	RetrieveAllByThing(ctx context.Context, thingID string, offset, limit uint64) (Page, error)
+
 	// RetrieveBySerial retrieves a certificate for a given serial ID
 	RetrieveBySerial(ctx context.Context, ownerID, serialID string) (Cert, error)
 }
@@ -0,0 +1,43 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/certs"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const thingRemove = "thing.remove"
+
+type eventHandler struct {
+	svc certs.Service
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(svc certs.Service) events.EventHandler {
+	return &eventHandler{
+		svc: svc,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case thingRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return es.svc.RemoveThingCerts(ctx, id)
+	}
+
+	return nil
+}
@@ -63,6 +63,34 @@ func (_m *Repository) RetrieveAll(ctx context.Context, ownerID string, offset ui
 	return r0, r1
 }
 
+// RetrieveAllByThing provides a mock function with given fields: ctx, thingID, offset, limit
+func (_m *Repository) RetrieveAllByThing(ctx context.Context, thingID string, offset uint64, limit uint64) (certs.Page, error) {
+	ret := _m.Called(ctx, thingID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllByThing")
+	}
+
+	var r0 certs.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) (certs.Page, error)); ok {
+		return rf(ctx, thingID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) certs.Page); ok {
+		r0 = rf(ctx, thingID, offset, limit)
+	} else {
+		r0 = ret.Get(0).(certs.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = rf(ctx, thingID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveBySerial provides a mock function with given fields: ctx, ownerID, serialID
 func (_m *Repository) RetrieveBySerial(ctx context.Context, ownerID string, serialID string) (certs.Cert, error) {
 	ret := _m.Called(ctx, ownerID, serialID)
@@ -101,6 +101,24 @@ func (_m *Service) ListSerials(ctx context.Context, token string, thingID string
 	return r0, r1
 }
 
+// RemoveThingCerts provides a mock function with given fields: ctx, thingID
+func (_m *Service) RemoveThingCerts(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveThingCerts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RevokeCert provides a mock function with given fields: ctx, token, serialID
 func (_m *Service) RevokeCert(ctx context.Context, token string, serialID string) (certs.Revoke, error) {
 	ret := _m.Called(ctx, token, serialID)
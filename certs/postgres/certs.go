@@ -149,6 +149,40 @@ func (cr certsRepository) RetrieveByThing(ctx context.Context, ownerID, thingID
 	}, nil
 }
 
+func (cr certsRepository) RetrieveAllByThing(ctx context.Context, thingID string, offset, limit uint64) (certs.Page, error) {
+	q := `SELECT thing_id, owner_id, serial, expire FROM certs WHERE thing_id = $1 ORDER BY expire LIMIT $2 OFFSET $3;`
+	rows, err := cr.db.QueryContext(ctx, q, thingID, limit, offset)
+	if err != nil {
+		cr.log.Error(fmt.Sprintf("Failed to retrieve certs due to %s", err))
+		return certs.Page{}, err
+	}
+	defer rows.Close()
+
+	certificates := []certs.Cert{}
+	for rows.Next() {
+		c := certs.Cert{}
+		if err := rows.Scan(&c.ThingID, &c.OwnerID, &c.Serial, &c.Expire); err != nil {
+			cr.log.Error(fmt.Sprintf("Failed to read retrieved cert due to %s", err))
+			return certs.Page{}, err
+		}
+		certificates = append(certificates, c)
+	}
+
+	q = `SELECT COUNT(*) FROM certs WHERE thing_id = $1`
+	var total uint64
+	if err := cr.db.QueryRowxContext(ctx, q, thingID).Scan(&total); err != nil {
+		cr.log.Error(fmt.Sprintf("Failed to count certs due to %s", err))
+		return certs.Page{}, err
+	}
+
+	return certs.Page{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+		Certs:  certificates,
+	}, nil
+}
+
 func (cr certsRepository) RetrieveBySerial(ctx context.Context, ownerID, serialID string) (certs.Cert, error) {
 	q := `SELECT thing_id, owner_id, serial, expire FROM certs WHERE owner_id = $1 AND serial = $2`
 	var dbcrt dbCert
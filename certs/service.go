@@ -47,6 +47,9 @@ type Service interface {
 
 	// RevokeCert revokes a certificate for a given serial ID
 	RevokeCert(ctx context.Context, token, serialID string) (Revoke, error)
+
+	// RemoveThingCerts revokes and removes the certificates of the removed thing
+	RemoveThingCerts(ctx context.Context, thingID string) error
 }
 
 type certsService struct {
@@ -147,6 +150,25 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 	return revoke, nil
 }
 
+func (cs *certsService) RemoveThingCerts(ctx context.Context, thingID string) error {
+	offset, limit := uint64(0), uint64(10000)
+	cp, err := cs.certsRepo.RetrieveAllByThing(ctx, thingID, offset, limit)
+	if err != nil {
+		return errors.Wrap(ErrFailedCertRevocation, err)
+	}
+
+	for _, c := range cp.Certs {
+		if _, err := cs.pki.Revoke(c.Serial); err != nil {
+			return errors.Wrap(ErrFailedCertRevocation, err)
+		}
+		if err := cs.certsRepo.Remove(ctx, c.OwnerID, c.Serial); err != nil {
+			return errors.Wrap(ErrFailedToRemoveCertFromDB, err)
+		}
+	}
+
+	return nil
+}
+
 func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, offset, limit uint64) (Page, error) {
 	u, err := cs.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
 	if err != nil {
@@ -198,6 +198,64 @@ func TestRevokeCert(t *testing.T) {
 	}
 }
 
+func TestRemoveThingCerts(t *testing.T) {
+	svc, repo, agent, _, _ := newService(t)
+	c := certs.Cert{OwnerID: validID, ThingID: thingID, Serial: "serial"}
+
+	cases := []struct {
+		desc      string
+		thingID   string
+		page      certs.Page
+		repoErr   error
+		revokeErr error
+		removeErr error
+		err       error
+	}{
+		{
+			desc:    "remove thing certs",
+			thingID: thingID,
+			page:    certs.Page{Limit: 10000, Offset: 0, Total: 1, Certs: []certs.Cert{c}},
+		},
+		{
+			desc:    "remove thing certs for thing without certs",
+			thingID: thingID,
+			page:    certs.Page{Limit: 10000, Offset: 0},
+		},
+		{
+			desc:    "remove thing certs with failed to retrieve certs",
+			thingID: thingID,
+			repoErr: repoerr.ErrViewEntity,
+			err:     certs.ErrFailedCertRevocation,
+		},
+		{
+			desc:      "remove thing certs with failed to revoke cert",
+			thingID:   thingID,
+			page:      certs.Page{Limit: 10000, Offset: 0, Total: 1, Certs: []certs.Cert{c}},
+			revokeErr: errors.New("failed to revoke"),
+			err:       certs.ErrFailedCertRevocation,
+		},
+		{
+			desc:      "remove thing certs with failed to remove cert",
+			thingID:   thingID,
+			page:      certs.Page{Limit: 10000, Offset: 0, Total: 1, Certs: []certs.Cert{c}},
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       certs.ErrFailedToRemoveCertFromDB,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := repo.On("RetrieveAllByThing", context.Background(), tc.thingID, uint64(0), uint64(10000)).Return(tc.page, tc.repoErr)
+		agentCall := agent.On("Revoke", c.Serial).Return(time.Now(), tc.revokeErr)
+		repoCall1 := repo.On("Remove", context.Background(), c.OwnerID, c.Serial).Return(tc.removeErr)
+
+		err := svc.RemoveThingCerts(context.Background(), tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		agentCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestListCerts(t *testing.T) {
 	svc, repo, agent, auth, _ := newService(t)
 	var mycerts []certs.Cert
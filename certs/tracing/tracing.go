@@ -77,3 +77,13 @@ func (tm *tracingMiddleware) RevokeCert(ctx context.Context, token, serialID str
 
 	return tm.svc.RevokeCert(ctx, token, serialID)
 }
+
+// RemoveThingCerts traces the "RemoveThingCerts" operation of the wrapped certs.Service.
+func (tm *tracingMiddleware) RemoveThingCerts(ctx context.Context, thingID string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_thing_certs", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveThingCerts(ctx, thingID)
+}
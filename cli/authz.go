@@ -0,0 +1,58 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdAuthz = []cobra.Command{
+	{
+		Use:   "explain [<user_id>] <permission> <entity_type> <entity_id> <user_auth_token>",
+		Short: "Explain permission",
+		Long: "Explains why the user has or doesn't have the permission on the entity.\n" +
+			"Allowed permissions contain the relations granting them, denied ones the relations that would grant them.\n" +
+			"Explaining the permissions of other users requires the domain administrator permission.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli authz explain view thing <thing_id> $USERTOKEN\n" +
+			"\tmagistrala-cli authz explain <user_id> view thing <thing_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 && len(args) != 5 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var req mgxsdk.ExplainRequest
+			if len(args) == 5 {
+				req.Subject, args = args[0], args[1:]
+			}
+			req.Permission = args[0]
+			req.ObjectType = args[1]
+			req.Object = args[2]
+			exp, err := sdk.ExplainPolicy(req, args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, exp)
+		},
+	},
+}
+
+// NewAuthzCmd returns authorization command.
+func NewAuthzCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "authz [explain]",
+		Short: "Authorization",
+		Long:  `Authorization: explain the permissions of the users`,
+	}
+
+	for i := range cmdAuthz {
+		cmd.AddCommand(&cmdAuthz[i])
+	}
+
+	return &cmd
+}
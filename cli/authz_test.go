@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/cli"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExplainPolicyCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	authzCmd := cli.NewAuthzCmd()
+	rootCmd := setFlags(authzCmd)
+
+	thingID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	allowed := mgsdk.PolicyExplanation{
+		Allowed: true,
+		Path: []mgsdk.PolicyStep{
+			{SubjectType: "user", Subject: userID, Relation: "administrator", ObjectType: "thing", Object: thingID},
+		},
+	}
+	var exp mgsdk.PolicyExplanation
+
+	cases := []struct {
+		desc          string
+		args          []string
+		req           mgsdk.ExplainRequest
+		sdkErr        errors.SDKError
+		exp           mgsdk.PolicyExplanation
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "explain permission successfully",
+			args:    []string{"view", "thing", thingID, token},
+			req:     mgsdk.ExplainRequest{Permission: "view", ObjectType: "thing", Object: thingID},
+			exp:     allowed,
+			logType: entityLog,
+		},
+		{
+			desc:    "explain permission of other user successfully",
+			args:    []string{userID, "view", "thing", thingID, token},
+			req:     mgsdk.ExplainRequest{Subject: userID, Permission: "view", ObjectType: "thing", Object: thingID},
+			exp:     allowed,
+			logType: entityLog,
+		},
+		{
+			desc:    "explain permission with invalid args",
+			args:    []string{"view", "thing", token},
+			logType: usageLog,
+		},
+		{
+			desc:          "explain permission with invalid token",
+			args:          []string{"view", "thing", thingID, invalidToken},
+			req:           mgsdk.ExplainRequest{Permission: "view", ObjectType: "thing", Object: thingID},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ExplainPolicy", tc.req, mock.Anything).Return(tc.exp, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{explainCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &exp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.exp, exp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.exp, exp))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
@@ -19,11 +19,15 @@ const (
 const (
 	tokCmd        = "token"
 	refTokCmd     = "refreshtoken"
+	mfaTokCmd     = "mfatoken"
+	mfaCmd        = "mfa"
 	profCmd       = "profile"
 	resPassReqCmd = "resetpasswordrequest"
 	resPassCmd    = "resetpassword"
 	passCmd       = "password"
 	domsCmd       = "domains"
+	sendVerCmd    = "sendverification"
+	verEmailCmd   = "verifyemail"
 )
 
 // Things commands
@@ -68,4 +72,30 @@ const (
 // Invitations commands
 const (
 	acceptCmd = "accept"
+	signUpCmd = "signup"
+)
+
+// Replay commands
+const (
+	startCmd  = "start"
+	watchCmd  = "watch"
+	cancelCmd = "cancel"
+)
+
+// Authz commands
+const (
+	explainCmd = "explain"
+)
+
+// Service accounts commands
+const (
+	keyCmd = "key"
+)
+
+// Domains commands
+const (
+	usageCmd    = "usage"
+	exportCmd   = "export"
+	importCmd   = "import"
+	deletionCmd = "deletion"
 )
@@ -28,6 +28,7 @@ const (
 	defInvitationsURL  string = defURL + ":9020"
 	defHTTPURL         string = defURL + ":8008"
 	defJournalURL      string = defURL + ":9021"
+	defReplayURL       string = defURL + ":9026"
 	defTLSVerification bool   = false
 	defOffset          string = "0"
 	defLimit           string = "10"
@@ -45,6 +46,7 @@ type remotes struct {
 	CertsURL        string `toml:"certs_url"`
 	InvitationsURL  string `toml:"invitations_url"`
 	JournalURL      string `toml:"journal_url"`
+	ReplayURL       string `toml:"replay_url"`
 	HostURL         string `toml:"host_url"`
 	TLSVerification bool   `toml:"tls_verification"`
 }
@@ -116,6 +118,7 @@ func ParseConfig(sdkConf mgxsdk.Config) (mgxsdk.Config, error) {
 				CertsURL:        defCertsURL,
 				InvitationsURL:  defInvitationsURL,
 				JournalURL:      defJournalURL,
+				ReplayURL:       defReplayURL,
 				HostURL:         defURL,
 				TLSVerification: defTLSVerification,
 			},
@@ -207,6 +210,10 @@ func ParseConfig(sdkConf mgxsdk.Config) (mgxsdk.Config, error) {
 		sdkConf.JournalURL = config.Remotes.JournalURL
 	}
 
+	if sdkConf.ReplayURL == "" && config.Remotes.ReplayURL != "" {
+		sdkConf.ReplayURL = config.Remotes.ReplayURL
+	}
+
 	if sdkConf.HostURL == "" && config.Remotes.HostURL != "" {
 		sdkConf.HostURL = config.Remotes.HostURL
 	}
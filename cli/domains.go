@@ -5,6 +5,7 @@ package cli
 
 import (
 	"encoding/json"
+	"os"
 
 	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/spf13/cobra"
@@ -105,6 +106,27 @@ var cmdDomains = []cobra.Command{
 		},
 	},
 
+	{
+		Use:   "usage <domain_id> <token>",
+		Short: "View domain quotas and usage",
+		Long: "View domain quotas and the current consumption of the domain resources\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli domains usage <domain_id> $TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			u, err := sdk.DomainUsage(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logJSONCmd(*cmd, u)
+		},
+	},
+
 	{
 		Use:   "update <domain_id> <JSON_string> <user_auth_token>",
 		Short: "Update domains",
@@ -171,6 +193,46 @@ var cmdDomains = []cobra.Command{
 			logOKCmd(*cmd)
 		},
 	},
+	{
+		Use:   "delete <domain_id> <token>",
+		Short: "Delete domain",
+		Long: "Schedules the deletion of the domain and its data after the grace period\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli domains delete <domain_id> <token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			dd, err := sdk.DeleteDomain(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logJSONCmd(*cmd, dd)
+		},
+	},
+	{
+		Use:   "deletion <domain_id> <token>",
+		Short: "View domain deletion status",
+		Long: "View the status of the domain deletion\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli domains deletion <domain_id> <token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			dd, err := sdk.DomainDeletion(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logJSONCmd(*cmd, dd)
+		},
+	},
 }
 
 var domainAssignCmds = []cobra.Command{
@@ -221,6 +283,85 @@ var domainUnassignCmds = []cobra.Command{
 	},
 }
 
+// archivePermission restricts access to the domain and profile archives,
+// since they hold secrets and personal data.
+const archivePermission = 0o600
+
+func newDomainExportCmd() *cobra.Command {
+	var withSecrets bool
+
+	cmd := cobra.Command{
+		Use:   "export <domain_id> <file> <token> [--secrets]",
+		Short: "Export domain",
+		Long: "Exports domain things, channels, groups, connections, bootstrap configs, notifier subscriptions and role assignments to the archive file\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli domains export <domain_id> domain.json $TOKEN --secrets\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			archive, sdkErr := sdk.ExportDomain(args[0], withSecrets, args[2])
+			if sdkErr != nil {
+				logErrorCmd(*cmd, sdkErr)
+				return
+			}
+			data, err := json.MarshalIndent(archive, "", "  ")
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			if err := os.WriteFile(args[1], data, archivePermission); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logOKCmd(*cmd)
+		},
+	}
+	cmd.Flags().BoolVar(&withSecrets, "secrets", false, "export things and bootstrap configs secrets")
+
+	return &cmd
+}
+
+func newDomainImportCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := cobra.Command{
+		Use:   "import <domain_id> <file> <token> [--dry-run]",
+		Short: "Import domain",
+		Long: "Imports domain entities from the archive file, and reports the created entities, their IDs and the conflicts\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli domains import <domain_id> domain.json $TOKEN --dry-run\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			data, err := os.ReadFile(args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			var archive mgxsdk.DomainArchive
+			if err := json.Unmarshal(data, &archive); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			report, sdkErr := sdk.ImportDomain(args[0], archive, dryRun, args[2])
+			if sdkErr != nil {
+				logErrorCmd(*cmd, sdkErr)
+				return
+			}
+			logJSONCmd(*cmd, report)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the archive and report conflicts without creating entities")
+
+	return &cmd
+}
+
 func NewDomainAssignCmds() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "assign [users]",
@@ -248,7 +389,7 @@ func NewDomainUnassignCmds() *cobra.Command {
 // NewDomainsCmd returns domains command.
 func NewDomainsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "domains [create | get | update | enable | disable | enable | users | assign | unassign]",
+		Use:   "domains [create | get | update | enable | disable | enable | delete | deletion | users | usage | export | import | assign | unassign]",
 		Short: "Domains management",
 		Long:  `Domains management: create, update, retrieve domains , assign/unassign users to domains and list users of domain"`,
 	}
@@ -257,6 +398,8 @@ func NewDomainsCmd() *cobra.Command {
 		cmd.AddCommand(&cmdDomains[i])
 	}
 
+	cmd.AddCommand(newDomainExportCmd())
+	cmd.AddCommand(newDomainImportCmd())
 	cmd.AddCommand(NewDomainAssignCmds())
 	cmd.AddCommand(NewDomainUnassignCmds())
 	return &cmd
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -279,6 +281,380 @@ func TestListDomainUsers(t *testing.T) {
 	}
 }
 
+func TestDomainUsageCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	domainsCmd := cli.NewDomainsCmd()
+	rootCmd := setFlags(domainsCmd)
+
+	var du mgsdk.DomainUsage
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		usage         mgsdk.DomainUsage
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "view domain usage successfully",
+			args: []string{
+				domain.ID,
+				token,
+			},
+			usage: mgsdk.DomainUsage{
+				Quotas: mgsdk.Quotas{Things: 10},
+				Usage:  mgsdk.Usage{Things: 1},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "view domain usage with invalid args",
+			args: []string{
+				domain.ID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "view domain usage with invalid id",
+			args: []string{
+				invalidID,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DomainUsage", tc.args[0], tc.args[1]).Return(tc.usage, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{usageCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &du)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal JSON: %v", err)
+				}
+				assert.Equal(t, tc.usage, du, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.usage, du))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDeleteDomainCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	domainsCmd := cli.NewDomainsCmd()
+	rootCmd := setFlags(domainsCmd)
+
+	var dd mgsdk.DomainDeletion
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		deletion      mgsdk.DomainDeletion
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "delete domain successfully",
+			args: []string{
+				domain.ID,
+				token,
+			},
+			deletion: mgsdk.DomainDeletion{
+				DomainID: domain.ID,
+				Status:   "pending",
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "delete domain with invalid args",
+			args: []string{
+				domain.ID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "delete domain with invalid id",
+			args: []string{
+				invalidID,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteDomain", tc.args[0], tc.args[1]).Return(tc.deletion, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{delCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &dd)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal JSON: %v", err)
+				}
+				assert.Equal(t, tc.deletion, dd, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.deletion, dd))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDomainDeletionCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	domainsCmd := cli.NewDomainsCmd()
+	rootCmd := setFlags(domainsCmd)
+
+	var dd mgsdk.DomainDeletion
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		deletion      mgsdk.DomainDeletion
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "view domain deletion successfully",
+			args: []string{
+				domain.ID,
+				token,
+			},
+			deletion: mgsdk.DomainDeletion{
+				DomainID: domain.ID,
+				Status:   "pending",
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "view domain deletion with invalid args",
+			args: []string{
+				domain.ID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "view domain deletion with invalid id",
+			args: []string{
+				invalidID,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DomainDeletion", tc.args[0], tc.args[1]).Return(tc.deletion, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{deletionCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &dd)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal JSON: %v", err)
+				}
+				assert.Equal(t, tc.deletion, dd, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.deletion, dd))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestExportDomainCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+
+	archive := mgsdk.DomainArchive{
+		Version:  mgsdk.DomainArchiveVersion,
+		DomainID: domain.ID,
+		Things:   []mgsdk.Thing{{ID: testsutil.GenerateUUID(t), Name: "thing"}},
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		withSecrets   bool
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc:    "export domain successfully",
+			args:    []string{domain.ID, "domain.json", validToken},
+			logType: okLog,
+		},
+		{
+			desc:        "export domain with secrets successfully",
+			args:        []string{domain.ID, "domain.json", validToken, "--secrets"},
+			withSecrets: true,
+			logType:     okLog,
+		},
+		{
+			desc:    "export domain with invalid args",
+			args:    []string{domain.ID, "domain.json", validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "export domain with invalid token",
+			args:          []string{domain.ID, "domain.json", invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rootCmd := setFlags(cli.NewDomainsCmd())
+			file := filepath.Join(t.TempDir(), tc.args[1])
+			args := append([]string{tc.args[0], file}, tc.args[2:]...)
+			sdkCall := sdkMock.On("ExportDomain", tc.args[0], tc.withSecrets, tc.args[2]).Return(archive, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{exportCmd}, args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+				data, err := os.ReadFile(file)
+				assert.Nil(t, err, fmt.Sprintf("%s unexpected error reading archive: %s", tc.desc, err))
+				var a mgsdk.DomainArchive
+				err = json.Unmarshal(data, &a)
+				assert.Nil(t, err, fmt.Sprintf("%s unexpected error decoding archive: %s", tc.desc, err))
+				assert.Equal(t, archive, a, fmt.Sprintf("%s unexpected archive: expected %v got %v", tc.desc, archive, a))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestImportDomainCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+
+	archive := mgsdk.DomainArchive{
+		Version:  mgsdk.DomainArchiveVersion,
+		DomainID: domain.ID,
+		Things:   []mgsdk.Thing{{ID: testsutil.GenerateUUID(t), Name: "thing"}},
+	}
+	data, err := json.Marshal(archive)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error encoding archive: %s", err))
+	file := filepath.Join(t.TempDir(), "domain.json")
+	err = os.WriteFile(file, data, 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing archive: %s", err))
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	err = os.WriteFile(invalidFile, []byte("invalid"), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing archive: %s", err))
+
+	cases := []struct {
+		desc          string
+		args          []string
+		dryRun        bool
+		report        mgsdk.ImportReport
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "import domain successfully",
+			args: []string{domain.ID, file, validToken},
+			report: mgsdk.ImportReport{
+				Created: map[string]int{mgsdk.ThingsKind: 1},
+				IDs:     map[string]string{archive.Things[0].ID: testsutil.GenerateUUID(t)},
+			},
+			logType: entityLog,
+		},
+		{
+			desc:   "import domain with dry run",
+			args:   []string{domain.ID, file, validToken, "--dry-run"},
+			dryRun: true,
+			report: mgsdk.ImportReport{
+				DryRun:  true,
+				Created: map[string]int{mgsdk.ThingsKind: 1},
+				IDs:     map[string]string{},
+			},
+			logType: entityLog,
+		},
+		{
+			desc:    "import domain with invalid args",
+			args:    []string{domain.ID, file, validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "import domain with invalid archive",
+			args:          []string{domain.ID, invalidFile, validToken},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "invalid character 'i' looking for beginning of value"),
+			logType:       errLog,
+		},
+		{
+			desc:          "import domain with invalid token",
+			args:          []string{domain.ID, file, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rootCmd := setFlags(cli.NewDomainsCmd())
+			sdkCall := sdkMock.On("ImportDomain", tc.args[0], archive, tc.dryRun, tc.args[2]).Return(tc.report, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{importCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				var report mgsdk.ImportReport
+				err := json.Unmarshal([]byte(out), &report)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal JSON: %v", err)
+				}
+				assert.Equal(t, tc.report, report, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.report, report))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestUpdateDomainCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
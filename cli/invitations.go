@@ -4,27 +4,34 @@
 package cli
 
 import (
+	"strings"
+
 	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/spf13/cobra"
 )
 
 var cmdInvitations = []cobra.Command{
 	{
-		Use:   "send <user_id> <domain_id> <relation> <user_auth_token>",
+		Use:   "send <user_id | email> <domain_id> <relation> <user_auth_token>",
 		Short: "Send invitation",
-		Long: "Send invitation to user\n" +
+		Long: "Send invitation to user or to email that has no account yet\n" +
 			"For example:\n" +
-			"\tmagistrala-cli invitations send 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a administrator $USER_AUTH_TOKEN\n",
+			"\tmagistrala-cli invitations send 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a administrator $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli invitations send user@example.com 4ef09eff-d500-4d56-b04f-d23a512d6f2a administrator $USER_AUTH_TOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 4 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
 			}
 			inv := mgxsdk.Invitation{
-				UserID:   args[0],
 				DomainID: args[1],
 				Relation: args[2],
 			}
+			if strings.Contains(args[0], "@") {
+				inv.Email = args[0]
+			} else {
+				inv.UserID = args[0]
+			}
 			if err := sdk.SendInvitation(inv, args[3]); err != nil {
 				logErrorCmd(*cmd, err)
 				return
@@ -34,13 +41,14 @@ var cmdInvitations = []cobra.Command{
 		},
 	},
 	{
-		Use:   "get [all | <user_id> <domain_id> ] <user_auth_token>",
+		Use:   "get [all | <user_id | email> <domain_id> ] <user_auth_token>",
 		Short: "Get invitations",
 		Long: "Get invitations\n" +
 			"Usage:\n" +
 			"\tmagistrala-cli invitations get all <user_auth_token> - lists all invitations\n" +
 			"\tmagistrala-cli invitations get all <user_auth_token> --offset <offset> --limit <limit> - lists all invitations with provided offset and limit\n" +
-			"\tmagistrala-cli invitations get <user_id> <domain_id> <user_auth_token> - shows invitation by user id and domain id\n",
+			"\tmagistrala-cli invitations get <user_id> <domain_id> <user_auth_token> - shows invitation by user id and domain id\n" +
+			"\tmagistrala-cli invitations get <email> <domain_id> <user_auth_token> - shows invitation sent to email that has no account yet\n",
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 2 && len(args) != 3 {
 				logUsageCmd(*cmd, cmd.Use)
@@ -61,7 +69,13 @@ var cmdInvitations = []cobra.Command{
 				logJSONCmd(*cmd, l)
 				return
 			}
-			u, err := sdk.Invitation(args[0], args[1], args[2])
+			var u mgxsdk.Invitation
+			var err error
+			if strings.Contains(args[0], "@") {
+				u, err = sdk.EmailInvitation(args[0], args[1], args[2])
+			} else {
+				u, err = sdk.Invitation(args[0], args[1], args[2])
+			}
 			if err != nil {
 				logErrorCmd(*cmd, err)
 				return
@@ -91,18 +105,46 @@ var cmdInvitations = []cobra.Command{
 		},
 	},
 	{
-		Use:   "delete <user_id> <domain_id> <user_auth_token>",
+		Use:   "signup <code> <name> <password>",
+		Short: "Sign up with invitation",
+		Long: "Create account with the code received in the invitation email and accept the invitation\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli invitations signup $INVITATIONCODE user 12345678\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			inv, err := sdk.SignUp(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, inv)
+		},
+	},
+	{
+		Use:   "delete <user_id | email> <domain_id> <user_auth_token>",
 		Short: "Delete invitation",
 		Long: "Delete invitation\n" +
 			"Usage:\n" +
-			"\tmagistrala-cli invitations delete 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USERTOKEN\n",
+			"\tmagistrala-cli invitations delete 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USERTOKEN\n" +
+			"\tmagistrala-cli invitations delete user@example.com 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USERTOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 3 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
 			}
 
-			if err := sdk.DeleteInvitation(args[0], args[1], args[2]); err != nil {
+			var err error
+			if strings.Contains(args[0], "@") {
+				err = sdk.DeleteEmailInvitation(args[0], args[1], args[2])
+			} else {
+				err = sdk.DeleteInvitation(args[0], args[1], args[2])
+			}
+			if err != nil {
 				logErrorCmd(*cmd, err)
 				return
 			}
@@ -115,7 +157,7 @@ var cmdInvitations = []cobra.Command{
 // NewInvitationsCmd returns invitations command.
 func NewInvitationsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "invitations [send | get | accept | delete]",
+		Use:   "invitations [send | get | accept | signup | delete]",
 		Short: "Invitations management",
 		Long:  `Invitations management to send, get, accept and delete invitations`,
 	}
@@ -49,6 +49,16 @@ func TestSendUserInvitationCmd(t *testing.T) {
 			},
 			logType: okLog,
 		},
+		{
+			desc: "send invitation by email successfully",
+			args: []string{
+				"invitee@example.com",
+				domain.ID,
+				relation,
+				validToken,
+			},
+			logType: okLog,
+		},
 		{
 			desc: "send invitation with invalid args",
 			args: []string{
@@ -133,6 +143,16 @@ func TestGetInvitationCmd(t *testing.T) {
 			logType: entityLog,
 			inv:     invitation,
 		},
+		{
+			desc: "get invitation with email",
+			args: []string{
+				"invitee@example.com",
+				domain.ID,
+				token,
+			},
+			logType: entityLog,
+			inv:     invitation,
+		},
 		{
 			desc: "get invitation with invalid args",
 			args: []string{
@@ -170,6 +190,7 @@ func TestGetInvitationCmd(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			sdkCall := sdkMock.On("Invitation", tc.args[0], tc.args[1], mock.Anything).Return(tc.inv, tc.sdkErr)
 			sdkCall1 := sdkMock.On("Invitations", mock.Anything, tc.args[1]).Return(tc.page, tc.sdkErr)
+			sdkCall2 := sdkMock.On("EmailInvitation", tc.args[0], tc.args[1], mock.Anything).Return(tc.inv, tc.sdkErr)
 
 			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
 
@@ -191,6 +212,7 @@ func TestGetInvitationCmd(t *testing.T) {
 			}
 			sdkCall.Unset()
 			sdkCall1.Unset()
+			sdkCall2.Unset()
 		})
 	}
 }
@@ -254,6 +276,75 @@ func TestAcceptInvitationCmd(t *testing.T) {
 	}
 }
 
+func TestSignUpInvitationCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	invCmd := cli.NewInvitationsCmd()
+	rootCmd := setFlags(invCmd)
+
+	var inv mgsdk.Invitation
+	code := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		invitation    mgsdk.Invitation
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "sign up successfully",
+			args: []string{
+				code,
+				user.Name,
+				user.Credentials.Secret,
+			},
+			invitation: invitation,
+			logType:    entityLog,
+		},
+		{
+			desc: "sign up with invalid args",
+			args: []string{
+				code,
+				user.Name,
+				user.Credentials.Secret,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "sign up with invalid code",
+			args: []string{
+				invalidToken,
+				user.Name,
+				user.Credentials.Secret,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("SignUp", tc.args[0], tc.args[1], tc.args[2]).Return(tc.invitation, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{signUpCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &inv)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.invitation, inv, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.invitation, inv))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestDeleteInvitationCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -276,6 +367,15 @@ func TestDeleteInvitationCmd(t *testing.T) {
 			},
 			logType: okLog,
 		},
+		{
+			desc: "delete invitation with email successfully",
+			args: []string{
+				"invitee@example.com",
+				domain.ID,
+				validToken,
+			},
+			logType: okLog,
+		},
 		{
 			desc: "delete invitation with invalid args",
 			args: []string{
@@ -302,6 +402,7 @@ func TestDeleteInvitationCmd(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			sdkCall := sdkMock.On("DeleteInvitation", mock.Anything, mock.Anything, mock.Anything).Return(tc.sdkErr)
+			sdkCall1 := sdkMock.On("DeleteEmailInvitation", mock.Anything, mock.Anything, mock.Anything).Return(tc.sdkErr)
 			out := executeCommand(t, rootCmd, append([]string{delCmd}, tc.args...)...)
 			switch tc.logType {
 			case okLog:
@@ -312,6 +413,7 @@ func TestDeleteInvitationCmd(t *testing.T) {
 				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
 			}
 			sdkCall.Unset()
+			sdkCall1.Unset()
 		})
 	}
 }
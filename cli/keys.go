@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdKeys = []cobra.Command{
+	{
+		Use:   "issue <JSON_key> <user_auth_token>",
+		Short: "Issue API key",
+		Long: "Issues API key. Scopes restrict the key to the operations and entities, duration is in seconds\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli keys issue '{\"duration\":86400, \"scopes\":[{\"operation\":\"channels:publish\", \"entity_ids\":[\"<channel_id>\"]}]}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var key mgxsdk.APIKey
+			if err := json.Unmarshal([]byte(args[0]), &key); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			key, err := sdk.IssueAPIKey(key, args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, key)
+		},
+	},
+	{
+		Use:   "get <user_auth_token>",
+		Short: "Get API keys",
+		Long: "Get API keys issued by the user\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli keys get $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+			page, err := sdk.APIKeys(pageMetadata, args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, page)
+		},
+	},
+	{
+		Use:   "revoke <key_id> <user_auth_token>",
+		Short: "Revoke API key",
+		Long: "Revokes API key\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli keys revoke <key_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			if err := sdk.RevokeAPIKey(args[0], args[1]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+}
+
+// NewKeysCmd returns API keys command.
+func NewKeysCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "keys [issue | get | revoke]",
+		Short: "API keys management",
+		Long:  `API keys management: issue, get or revoke scoped API keys`,
+	}
+
+	for i := range cmdKeys {
+		cmd.AddCommand(&cmdKeys[i])
+	}
+
+	return &cmd
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/cli"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var apiKey = mgsdk.APIKey{
+	ID:     testsutil.GenerateUUID(&testing.T{}),
+	Value:  "key",
+	Type:   3,
+	Scopes: []mgsdk.KeyScope{{Operation: "channels:publish"}},
+}
+
+func TestIssueAPIKeyCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	keysCmd := cli.NewKeysCmd()
+	rootCmd := setFlags(keysCmd)
+
+	keyJSON := "{\"duration\":3600, \"scopes\":[{\"operation\":\"channels:publish\"}]}"
+	var k mgsdk.APIKey
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		key           mgsdk.APIKey
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "issue API key successfully",
+			args:    []string{keyJSON, token},
+			key:     apiKey,
+			logType: entityLog,
+		},
+		{
+			desc:    "issue API key with invalid args",
+			args:    []string{keyJSON, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "issue API key with invalid json",
+			args:          []string{"{\"scopes\":", token},
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.New("unexpected end of JSON input")),
+		},
+		{
+			desc:          "issue API key with invalid token",
+			args:          []string{keyJSON, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("IssueAPIKey", mock.Anything, tc.args[1]).Return(tc.key, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{issueCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &k)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.key, k, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.key, k))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetAPIKeysCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	keysCmd := cli.NewKeysCmd()
+	rootCmd := setFlags(keysCmd)
+
+	var page mgsdk.APIKeysPage
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		page          mgsdk.APIKeysPage
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "get API keys successfully",
+			args: []string{token},
+			page: mgsdk.APIKeysPage{
+				PageRes: mgsdk.PageRes{Total: 1},
+				Keys:    []mgsdk.APIKey{apiKey},
+			},
+			logType: entityLog,
+		},
+		{
+			desc:    "get API keys with invalid args",
+			args:    []string{token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "get API keys with invalid token",
+			args:          []string{invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("APIKeys", mock.Anything, tc.args[0]).Return(tc.page, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &page)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestRevokeAPIKeyCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	keysCmd := cli.NewKeysCmd()
+	rootCmd := setFlags(keysCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "revoke API key successfully",
+			args:    []string{apiKey.ID, token},
+			logType: okLog,
+		},
+		{
+			desc:    "revoke API key with invalid args",
+			args:    []string{apiKey.ID, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "revoke API key with invalid token",
+			args:          []string{apiKey.ID, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("RevokeAPIKey", tc.args[0], tc.args[1]).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{revokeCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
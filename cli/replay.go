@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+const (
+	replayRunning      = "running"
+	replayPollInterval = time.Second
+)
+
+var cmdReplay = []cobra.Command{
+	{
+		Use:   "start <JSON_replay> <user_auth_token>",
+		Short: "Start replay",
+		Long: "Republishes stored channel messages from the time range into the message broker\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli replay start '{\"channel_id\":\"<channel_id>\", \"from\":1700000000, \"to\":1700003600, \"rate\":100}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var replay mgxsdk.Replay
+			if err := json.Unmarshal([]byte(args[0]), &replay); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			replay, err := sdk.StartReplay(replay, args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, replay)
+		},
+	},
+	{
+		Use:   "get [all | <replay_id>] <user_auth_token>",
+		Short: "Get replays",
+		Long: "Get all replays of the domain or get replay with its progress by id\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli replay get all $USERTOKEN - lists all replays\n" +
+			"\tmagistrala-cli replay get <replay_id> $USERTOKEN - shows replay by id\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			if args[0] == all {
+				page, err := sdk.Replays(args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				logJSONCmd(*cmd, page)
+				return
+			}
+			replay, err := sdk.Replay(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, replay)
+		},
+	},
+	{
+		Use:   "watch <replay_id> <user_auth_token>",
+		Short: "Watch replay progress",
+		Long: "Reports replay progress until the replay is finished\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli replay watch <replay_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			for {
+				replay, err := sdk.Replay(args[0], args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				if replay.Status != replayRunning {
+					logJSONCmd(*cmd, replay)
+					return
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %.1f%% (%d/%d scanned, %d published)\n", replay.Status, replay.Percent, replay.Progress.Scanned, replay.Progress.Total, replay.Progress.Published)
+				time.Sleep(replayPollInterval)
+			}
+		},
+	},
+	{
+		Use:   "cancel <replay_id> <user_auth_token>",
+		Short: "Cancel replay",
+		Long: "Stops the running replay\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli replay cancel <replay_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			replay, err := sdk.CancelReplay(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, replay)
+		},
+	},
+}
+
+// NewReplayCmd returns replay command.
+func NewReplayCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "replay [start | get | watch | cancel]",
+		Short: "Message replay",
+		Long:  `Message replay: start, get, watch or cancel republishing of the stored messages`,
+	}
+
+	for i := range cmdReplay {
+		cmd.AddCommand(&cmdReplay[i])
+	}
+
+	return &cmd
+}
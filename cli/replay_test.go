@@ -0,0 +1,242 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/cli"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var replayJob = mgsdk.Replay{
+	ID:        testsutil.GenerateUUID(&testing.T{}),
+	ChannelID: testsutil.GenerateUUID(&testing.T{}),
+	From:      1700000000,
+	To:        1700003600,
+	Status:    "running",
+}
+
+func TestStartReplayCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	replayCmd := cli.NewReplayCmd()
+	rootCmd := setFlags(replayCmd)
+
+	replayJSON := fmt.Sprintf("{\"channel_id\":\"%s\", \"from\":1700000000, \"to\":1700003600}", replayJob.ChannelID)
+	var rp mgsdk.Replay
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		replay        mgsdk.Replay
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "start replay successfully",
+			args:    []string{replayJSON, token},
+			replay:  replayJob,
+			logType: entityLog,
+		},
+		{
+			desc:    "start replay with invalid args",
+			args:    []string{replayJSON, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "start replay with invalid json",
+			args:          []string{"{\"channel_id\":", token},
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.New("unexpected end of JSON input")),
+		},
+		{
+			desc:          "start replay with invalid token",
+			args:          []string{replayJSON, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("StartReplay", mock.Anything, tc.args[1]).Return(tc.replay, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{startCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &rp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.replay, rp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.replay, rp))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetReplayCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	replayCmd := cli.NewReplayCmd()
+	rootCmd := setFlags(replayCmd)
+
+	var rp mgsdk.Replay
+	var page mgsdk.ReplaysPage
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		replay        mgsdk.Replay
+		page          mgsdk.ReplaysPage
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "get all replays successfully",
+			args:    []string{all, token},
+			page:    mgsdk.ReplaysPage{Total: 1, Replays: []mgsdk.Replay{replayJob}},
+			logType: entityLog,
+		},
+		{
+			desc:    "get replay by id successfully",
+			args:    []string{replayJob.ID, token},
+			replay:  replayJob,
+			logType: entityLog,
+		},
+		{
+			desc:    "get replay with invalid args",
+			args:    []string{replayJob.ID, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "get replay with invalid token",
+			args:          []string{replayJob.ID, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("Replay", tc.args[0], tc.args[1]).Return(tc.replay, tc.sdkErr)
+			sdkCall1 := sdkMock.On("Replays", tc.args[1]).Return(tc.page, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				if tc.args[0] == all {
+					err := json.Unmarshal([]byte(out), &page)
+					assert.Nil(t, err)
+					assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+					break
+				}
+				err := json.Unmarshal([]byte(out), &rp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.replay, rp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.replay, rp))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+			sdkCall1.Unset()
+		})
+	}
+}
+
+func TestWatchReplayCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	replayCmd := cli.NewReplayCmd()
+	rootCmd := setFlags(replayCmd)
+
+	completed := replayJob
+	completed.Status = "completed"
+	completed.Percent = 100
+
+	sdkCall := sdkMock.On("Replay", replayJob.ID, token).Return(completed, nil)
+	defer sdkCall.Unset()
+
+	var rp mgsdk.Replay
+	out := executeCommand(t, rootCmd, watchCmd, replayJob.ID, token)
+	err := json.Unmarshal([]byte(out), &rp)
+	assert.Nil(t, err)
+	assert.Equal(t, completed, rp, fmt.Sprintf("watch replay unexpected response: expected: %v, got: %v", completed, rp))
+}
+
+func TestCancelReplayCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	replayCmd := cli.NewReplayCmd()
+	rootCmd := setFlags(replayCmd)
+
+	canceled := replayJob
+	canceled.Status = "canceled"
+	var rp mgsdk.Replay
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		replay        mgsdk.Replay
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "cancel replay successfully",
+			args:    []string{replayJob.ID, token},
+			replay:  canceled,
+			logType: entityLog,
+		},
+		{
+			desc:    "cancel replay with invalid args",
+			args:    []string{replayJob.ID, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "cancel finished replay",
+			args:          []string{replayJob.ID, token},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CancelReplay", tc.args[0], tc.args[1]).Return(tc.replay, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{cancelCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &rp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.replay, rp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.replay, rp))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
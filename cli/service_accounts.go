@@ -0,0 +1,131 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceAccounts = []cobra.Command{
+	{
+		Use:   "create <JSON_service_account> <domain_id> <user_auth_token>",
+		Short: "Create service account",
+		Long: "Creates new service account in the domain\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli service-accounts create '{\"name\":\"backend\", \"tags\":[\"ingest\"]}' <domain_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var sa mgxsdk.ServiceAccount
+			if err := json.Unmarshal([]byte(args[0]), &sa); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			sa, err := sdk.CreateServiceAccount(args[1], sa, args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, sa)
+		},
+	},
+	{
+		Use:   "get [all | <service_account_id>] <domain_id> <user_auth_token>",
+		Short: "Get service accounts",
+		Long: "Get all service accounts of the domain or get service account by id\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli service-accounts get all <domain_id> $USERTOKEN - lists all service accounts\n" +
+			"\tmagistrala-cli service-accounts get <service_account_id> <domain_id> $USERTOKEN - shows service account with provided id\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if args[0] == all {
+				pageMetadata := mgxsdk.PageMetadata{
+					Offset: Offset,
+					Limit:  Limit,
+					Status: Status,
+				}
+				page, err := sdk.ServiceAccounts(args[1], pageMetadata, args[2])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				logJSONCmd(*cmd, page)
+				return
+			}
+
+			sa, err := sdk.ServiceAccount(args[1], args[0], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, sa)
+		},
+	},
+	{
+		Use:   "delete <service_account_id> <domain_id> <user_auth_token>",
+		Short: "Delete service account",
+		Long: "Deletes service account and revokes its keys\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli service-accounts delete <service_account_id> <domain_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			if err := sdk.DeleteServiceAccount(args[1], args[0], args[2]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "key <service_account_id> <domain_id> <user_auth_token>",
+		Short: "Issue service account key",
+		Long: "Issues API key for the service account. The key is used as bearer token or as OAuth2 client secret\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli service-accounts key <service_account_id> <domain_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			token, err := sdk.IssueServiceAccountKey(args[1], args[0], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, token)
+		},
+	},
+}
+
+// NewServiceAccountsCmd returns service accounts command.
+func NewServiceAccountsCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "service-accounts [create | get | delete | key]",
+		Short: "Service accounts management",
+		Long:  `Service accounts management: create, get, delete service accounts of the domain or issue their keys`,
+	}
+
+	for i := range cmdServiceAccounts {
+		cmd.AddCommand(&cmdServiceAccounts[i])
+	}
+
+	return &cmd
+}
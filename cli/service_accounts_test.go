@@ -0,0 +1,279 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/cli"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var serviceAccount = mgsdk.ServiceAccount{
+	ID:     testsutil.GenerateUUID(&testing.T{}),
+	Name:   "backend",
+	Domain: testsutil.GenerateUUID(&testing.T{}),
+	Status: "enabled",
+}
+
+func TestCreateServiceAccountCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	serviceAccountsCmd := cli.NewServiceAccountsCmd()
+	rootCmd := setFlags(serviceAccountsCmd)
+
+	saJSON := "{\"name\":\"backend\"}"
+	var sa mgsdk.ServiceAccount
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		sa            mgsdk.ServiceAccount
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "create service account successfully",
+			args:    []string{saJSON, serviceAccount.Domain, token},
+			sa:      serviceAccount,
+			logType: entityLog,
+		},
+		{
+			desc:    "create service account with invalid args",
+			args:    []string{saJSON, serviceAccount.Domain, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "create service account with invalid json",
+			args:          []string{"{\"name\":", serviceAccount.Domain, token},
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.New("unexpected end of JSON input")),
+		},
+		{
+			desc:          "create service account with invalid token",
+			args:          []string{saJSON, serviceAccount.Domain, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateServiceAccount", tc.args[1], mock.Anything, tc.args[2]).Return(tc.sa, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{createCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &sa)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.sa, sa, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.sa, sa))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetServiceAccountsCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	serviceAccountsCmd := cli.NewServiceAccountsCmd()
+	rootCmd := setFlags(serviceAccountsCmd)
+
+	var page mgsdk.ServiceAccountsPage
+	var sa mgsdk.ServiceAccount
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		page          mgsdk.ServiceAccountsPage
+		sa            mgsdk.ServiceAccount
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "get all service accounts successfully",
+			args: []string{all, serviceAccount.Domain, token},
+			page: mgsdk.ServiceAccountsPage{
+				PageRes:         mgsdk.PageRes{Total: 1},
+				ServiceAccounts: []mgsdk.ServiceAccount{serviceAccount},
+			},
+			logType: entityLog,
+		},
+		{
+			desc:    "get service account by id successfully",
+			args:    []string{serviceAccount.ID, serviceAccount.Domain, token},
+			sa:      serviceAccount,
+			logType: entityLog,
+		},
+		{
+			desc:    "get service accounts with invalid args",
+			args:    []string{all, serviceAccount.Domain, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "get all service accounts with invalid token",
+			args:          []string{all, serviceAccount.Domain, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+		{
+			desc:          "get service account with invalid id",
+			args:          []string{invalidID, serviceAccount.Domain, token},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ServiceAccounts", tc.args[1], mock.Anything, tc.args[2]).Return(tc.page, tc.sdkErr)
+			sdkCall1 := sdkMock.On("ServiceAccount", tc.args[1], tc.args[0], tc.args[2]).Return(tc.sa, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				if tc.args[0] == all {
+					err := json.Unmarshal([]byte(out), &page)
+					assert.Nil(t, err)
+					assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+					break
+				}
+				err := json.Unmarshal([]byte(out), &sa)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.sa, sa, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.sa, sa))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+			sdkCall1.Unset()
+		})
+	}
+}
+
+func TestDeleteServiceAccountCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	serviceAccountsCmd := cli.NewServiceAccountsCmd()
+	rootCmd := setFlags(serviceAccountsCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "delete service account successfully",
+			args:    []string{serviceAccount.ID, serviceAccount.Domain, token},
+			logType: okLog,
+		},
+		{
+			desc:    "delete service account with invalid args",
+			args:    []string{serviceAccount.ID, serviceAccount.Domain, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "delete service account with invalid token",
+			args:          []string{serviceAccount.ID, serviceAccount.Domain, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteServiceAccount", tc.args[1], tc.args[0], tc.args[2]).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{delCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestIssueServiceAccountKeyCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	serviceAccountsCmd := cli.NewServiceAccountsCmd()
+	rootCmd := setFlags(serviceAccountsCmd)
+
+	var tkn mgsdk.Token
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		token         mgsdk.Token
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc:    "issue service account key successfully",
+			args:    []string{serviceAccount.ID, serviceAccount.Domain, token},
+			token:   mgsdk.Token{AccessToken: "key"},
+			logType: entityLog,
+		},
+		{
+			desc:    "issue service account key with invalid args",
+			args:    []string{serviceAccount.ID, serviceAccount.Domain, token, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "issue service account key with invalid token",
+			args:          []string{serviceAccount.ID, serviceAccount.Domain, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("IssueServiceAccountKey", tc.args[1], tc.args[0], tc.args[2]).Return(tc.token, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{keyCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tkn)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.token, tkn, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.token, tkn))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
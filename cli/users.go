@@ -4,9 +4,11 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"strconv"
 
 	mgclients "github.com/absmach/magistrala/pkg/clients"
@@ -144,6 +146,65 @@ var cmdUsers = []cobra.Command{
 			logJSONCmd(*cmd, token)
 		},
 	},
+	{
+		Use:   "mfatoken <mfa_token> <code>",
+		Short: "Get token using MFA code",
+		Long: "Generate new token from MFA token returned by the token command and TOTP or recovery code\n" +
+			"For example:\n" +
+			"\tmagistrala-cli users mfatoken <mfa_token> 123456\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			token, err := sdk.CreateMFAToken(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, token)
+		},
+	},
+	{
+		Use:   "mfa [enroll <user_auth_token> | verify <code> <user_auth_token> | disable <code> <user_auth_token>]",
+		Short: "Manage multi-factor authentication",
+		Long: "Enroll, verify or disable TOTP multi-factor authentication\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli users mfa enroll $USERTOKEN - returns TOTP secret and otpauth URI\n" +
+			"\tmagistrala-cli users mfa verify 123456 $USERTOKEN - enables MFA and returns recovery codes\n" +
+			"\tmagistrala-cli users mfa disable 123456 $USERTOKEN - disables MFA\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			switch {
+			case len(args) == 2 && args[0] == "enroll":
+				enrollment, err := sdk.EnrollMFA(args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, enrollment)
+			case len(args) == 3 && args[0] == "verify":
+				codes, err := sdk.VerifyMFA(args[1], args[2])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, codes)
+			case len(args) == 3 && args[0] == "disable":
+				if err := sdk.DisableMFA(args[1], args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			default:
+				logUsageCmd(*cmd, cmd.Use)
+			}
+		},
+	},
 	{
 		Use:   "update [<user_id> <JSON_string> | tags <user_id> <tags> | identity <user_id> <identity> ] <user_auth_token>",
 		Short: "Update user",
@@ -218,24 +279,50 @@ var cmdUsers = []cobra.Command{
 		},
 	},
 	{
-		Use:   "profile <user_auth_token>",
-		Short: "Get user profile",
-		Long: "Get user profile\n" +
+		Use:   "profile [<user_auth_token> | delete <user_auth_token> | export <file> <user_auth_token>]",
+		Short: "Get, delete or export user profile",
+		Long: "Get user profile, delete own account or export personal data\n" +
 			"Usage:\n" +
-			"\tmagistrala-cli users profile $USERTOKEN\n",
+			"\tmagistrala-cli users profile $USERTOKEN\n" +
+			"\tmagistrala-cli users profile delete $USERTOKEN - deletes the account after the deletion grace period\n" +
+			"\tmagistrala-cli users profile export profile.json $USERTOKEN - exports the personal data to the archive file\n",
 		Run: func(cmd *cobra.Command, args []string) {
-			if len(args) != 1 {
-				logUsageCmd(*cmd, cmd.Use)
-				return
-			}
+			switch {
+			case len(args) == 1:
+				user, err := sdk.UserProfile(args[0])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
 
-			user, err := sdk.UserProfile(args[0])
-			if err != nil {
-				logErrorCmd(*cmd, err)
-				return
-			}
+				logJSONCmd(*cmd, user)
+			case len(args) == 2 && args[0] == "delete":
+				if err := sdk.DeleteProfile(args[1]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
 
-			logJSONCmd(*cmd, user)
+				logOKCmd(*cmd)
+			case len(args) == 3 && args[0] == "export":
+				archive, sdkErr := sdk.ExportProfile(args[2])
+				if sdkErr != nil {
+					logErrorCmd(*cmd, sdkErr)
+					return
+				}
+				var data bytes.Buffer
+				if err := json.Indent(&data, archive, "", "  "); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				if err := os.WriteFile(args[1], data.Bytes(), archivePermission); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			default:
+				logUsageCmd(*cmd, cmd.Use)
+			}
 		},
 	},
 	{
@@ -278,6 +365,51 @@ var cmdUsers = []cobra.Command{
 			logOKCmd(*cmd)
 		},
 	},
+	{
+		Use:   "sendverification <username> <password>",
+		Short: "Send email verification",
+		Long: "Send the email verification link to the user who hasn't verified the email\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli users sendverification user@example.com 12345678\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			lg := mgxsdk.Login{
+				Identity: args[0],
+				Secret:   args[1],
+			}
+			if err := sdk.SendVerification(lg); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "verifyemail <verification_token>",
+		Short: "Verify email",
+		Long: "Verify email with the token received in the verification email\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli users verifyemail $VERIFICATIONTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			user, err := sdk.VerifyEmail(args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, user)
+		},
+	},
 	{
 		Use:   "password <old_password> <password> <user_auth_token>",
 		Short: "Update password",
@@ -341,6 +473,26 @@ var cmdUsers = []cobra.Command{
 			logJSONCmd(*cmd, user)
 		},
 	},
+	{
+		Use:   "unlock <user_id> <user_auth_token>",
+		Short: "Unlock user login",
+		Long: "Remove the login lockout of the user after too many failed login attempts\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli users unlock <user_id> <user_auth_token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if err := sdk.UnlockUser(args[0], args[1]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
 	{
 		Use:   "delete <user_id> <user_auth_token>",
 		Short: "Delete user",
@@ -513,7 +665,7 @@ var cmdUsers = []cobra.Command{
 // NewUsersCmd returns users command.
 func NewUsersCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "users [create | get | update | token | password | enable | disable | delete | channels | things | groups | search]",
+		Use:   "users [create | get | update | token | mfatoken | mfa | profile | sendverification | verifyemail | password | enable | disable | unlock | delete | channels | things | groups | search]",
 		Short: "Users management",
 		Long:  `Users management: create accounts and tokens"`,
 	}
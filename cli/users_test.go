@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -372,6 +374,161 @@ func TestIssueTokenCmd(t *testing.T) {
 	}
 }
 
+func TestMFATokenCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var tkn mgsdk.Token
+	mfaToken := testsutil.GenerateUUID(t)
+	token := mgsdk.Token{
+		AccessToken:  testsutil.GenerateUUID(t),
+		RefreshToken: testsutil.GenerateUUID(t),
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		token         mgsdk.Token
+		logType       outputLog
+	}{
+		{
+			desc:    "issue MFA token successfully",
+			args:    []string{mfaToken, "123456"},
+			logType: entityLog,
+			token:   token,
+		},
+		{
+			desc:          "issue MFA token with invalid code",
+			args:          []string{mfaToken, "000000"},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized).Error()),
+			logType:       errLog,
+		},
+		{
+			desc:    "issue MFA token with invalid args",
+			args:    []string{mfaToken},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateMFAToken", mock.Anything, mock.Anything).Return(tc.token, tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{mfaTokCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tkn)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.token, tkn, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.token, tkn))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestMFACmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	enrollment := mgsdk.MFAEnrollment{
+		Secret: "JBSWY3DPEHPK3PXP",
+		URI:    "otpauth://totp/Magistrala:user?secret=JBSWY3DPEHPK3PXP",
+	}
+	codes := []string{"abcde-fghij"}
+	authErr := errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		response      interface{}
+		logType       outputLog
+	}{
+		{
+			desc:     "enroll MFA successfully",
+			args:     []string{"enroll", validToken},
+			response: enrollment,
+			logType:  entityLog,
+		},
+		{
+			desc:          "enroll MFA with invalid token",
+			args:          []string{"enroll", invalidToken},
+			sdkerr:        authErr,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", authErr.Error()),
+			logType:       errLog,
+		},
+		{
+			desc:     "verify MFA successfully",
+			args:     []string{"verify", "123456", validToken},
+			response: codes,
+			logType:  entityLog,
+		},
+		{
+			desc:          "verify MFA with invalid token",
+			args:          []string{"verify", "123456", invalidToken},
+			sdkerr:        authErr,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", authErr.Error()),
+			logType:       errLog,
+		},
+		{
+			desc:    "disable MFA successfully",
+			args:    []string{"disable", "123456", validToken},
+			logType: okLog,
+		},
+		{
+			desc:          "disable MFA with invalid token",
+			args:          []string{"disable", "123456", invalidToken},
+			sdkerr:        authErr,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", authErr.Error()),
+			logType:       errLog,
+		},
+		{
+			desc:    "MFA with invalid args",
+			args:    []string{"verify", validToken},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("EnrollMFA", mock.Anything).Return(enrollment, tc.sdkerr)
+			sdkCall1 := sdkMock.On("VerifyMFA", mock.Anything, mock.Anything).Return(codes, tc.sdkerr)
+			sdkCall2 := sdkMock.On("DisableMFA", mock.Anything, mock.Anything).Return(tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{mfaCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				expected, err := json.MarshalIndent(tc.response, "", "  ")
+				assert.Nil(t, err)
+				assert.JSONEq(t, string(expected), out, fmt.Sprintf("%s unexpected response: expected: %s, got: %s", tc.desc, expected, out))
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+
+			sdkCall.Unset()
+			sdkCall1.Unset()
+			sdkCall2.Unset()
+		})
+	}
+}
+
 func TestRefreshIssueTokenCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -737,6 +894,276 @@ func TestGetUserProfileCmd(t *testing.T) {
 	}
 }
 
+func TestDeleteProfileCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc: "delete profile successfully",
+			args: []string{
+				delCmd,
+				validToken,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "delete profile with invalid args",
+			args: []string{
+				delCmd,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "delete profile with invalid token",
+			args: []string{
+				delCmd,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteProfile", tc.args[1]).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{profCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestSendVerificationCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc: "send verification successfully",
+			args: []string{
+				user.Credentials.Identity,
+				user.Credentials.Secret,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "send verification with invalid args",
+			args: []string{
+				user.Credentials.Identity,
+				user.Credentials.Secret,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "send verification with verified email",
+			args: []string{
+				user.Credentials.Identity,
+				user.Credentials.Secret,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict)),
+			logType:       errLog,
+		},
+		{
+			desc: "send verification too early after the previous one",
+			args: []string{
+				user.Credentials.Identity,
+				user.Credentials.Secret,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrTooManyRequests, http.StatusTooManyRequests),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrTooManyRequests, http.StatusTooManyRequests)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			lg := mgsdk.Login{
+				Identity: tc.args[0],
+				Secret:   tc.args[1],
+			}
+			sdkCall := sdkMock.On("SendVerification", lg).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{sendVerCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestVerifyEmailCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var usr mgsdk.User
+
+	cases := []struct {
+		desc          string
+		args          []string
+		user          mgsdk.User
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc: "verify email successfully",
+			args: []string{
+				validToken,
+			},
+			user:    user,
+			logType: entityLog,
+		},
+		{
+			desc: "verify email with invalid args",
+			args: []string{
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "verify email with invalid token",
+			args: []string{
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("VerifyEmail", tc.args[0]).Return(tc.user, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{verEmailCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &usr)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.user, usr, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.user, usr))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestExportProfileCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+
+	archive := map[string]any{
+		"profile": map[string]any{"id": user.ID},
+		"domains": []any{
+			map[string]any{
+				"domain_id":   testsutil.GenerateUUID(t),
+				"permissions": []any{"membership"},
+				"things":      []any{},
+				"groups":      []any{},
+			},
+		},
+		"invitations": []any{},
+		"journal":     []any{},
+	}
+	data, err := json.Marshal(archive)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error encoding archive: %s", err))
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc:    "export profile successfully",
+			args:    []string{exportCmd, "profile.json", validToken},
+			logType: okLog,
+		},
+		{
+			desc:    "export profile with invalid args",
+			args:    []string{exportCmd, "profile.json", validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "export profile with invalid token",
+			args:          []string{exportCmd, "profile.json", invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rootCmd := setFlags(cli.NewUsersCmd())
+			file := filepath.Join(t.TempDir(), tc.args[1])
+			args := append([]string{tc.args[0], file}, tc.args[2:]...)
+			sdkCall := sdkMock.On("ExportProfile", tc.args[2]).Return(data, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{profCmd}, args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+				content, err := os.ReadFile(file)
+				assert.Nil(t, err, fmt.Sprintf("%s unexpected error reading archive: %s", tc.desc, err))
+				var a map[string]any
+				err = json.Unmarshal(content, &a)
+				assert.Nil(t, err, fmt.Sprintf("%s unexpected error decoding archive: %s", tc.desc, err))
+				assert.Equal(t, archive, a, fmt.Sprintf("%s unexpected archive: expected %v got %v", tc.desc, archive, a))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestResetPasswordRequestCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -0,0 +1,169 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains amqp-bridge main function to start the amqp-bridge service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/forwarders/bridge"
+	"github.com/absmach/magistrala/consumers/forwarders/bridge/amqp"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	"github.com/absmach/magistrala/consumers/writers/api"
+	mglog "github.com/absmach/magistrala/logger"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "amqp-bridge"
+	envPrefixHTTP  = "MG_AMQP_BRIDGE_HTTP_"
+	envPrefixAMQP  = "MG_AMQP_BRIDGE_AMQP_"
+	defSvcHTTPPort = "9024"
+)
+
+type config struct {
+	LogLevel      string  `env:"MG_AMQP_BRIDGE_LOG_LEVEL"   envDefault:"info"`
+	ConfigPath    string  `env:"MG_AMQP_BRIDGE_CONFIG_PATH" envDefault:"/config.toml"`
+	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL `env:"MG_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"          envDefault:"true"`
+	InstanceID    string  `env:"MG_AMQP_BRIDGE_INSTANCE_ID" envDefault:""`
+	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	amqpConfig := amqp.Config{}
+	if err := env.ParseWithOptions(&amqpConfig, env.Options{Prefix: envPrefixAMQP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s AMQP configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	bridgeConfig, err := bridge.LoadConfig(cfg.ConfigPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to load bridge config, using defaults: %s", err))
+	}
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	pub, err := amqp.NewPublisher(amqpConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to AMQP broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pub.Close()
+
+	svc, err := newService(pub, bridgeConfig, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	svc = consumertracing.NewBlocking(tracer, svc, httpServerConfig)
+
+	if err = consumers.Start(ctx, svcName, pubSub, svc, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create AMQP bridge: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("AMQP bridge service terminated: %s", err))
+	}
+}
+
+func newService(pub bridge.Publisher, cfg bridge.Config, logger *slog.Logger) (consumers.BlockingConsumer, error) {
+	svc, err := bridge.New(pub, cfg)
+	if err != nil {
+		return nil, err
+	}
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("amqp", "message_bridge")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains archive-writer main function to start the archive-writer service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	"github.com/absmach/magistrala/consumers/writers/api"
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/consumers/writers/archive/fs"
+	"github.com/absmach/magistrala/consumers/writers/archive/s3"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName         = "archive-writer"
+	envPrefixHTTP   = "MG_ARCHIVE_WRITER_HTTP_"
+	envPrefixS3     = "MG_ARCHIVE_WRITER_S3_"
+	envPrefixAuth   = "MG_AUTH_GRPC_"
+	envPrefixWriter = "MG_ARCHIVE_WRITER_"
+	defSvcHTTPPort  = "9025"
+	fsStorage       = "fs"
+	s3Storage       = "s3"
+)
+
+type config struct {
+	LogLevel      string  `env:"MG_ARCHIVE_WRITER_LOG_LEVEL"   envDefault:"info"`
+	ConfigPath    string  `env:"MG_ARCHIVE_WRITER_CONFIG_PATH" envDefault:"/config.toml"`
+	Storage       string  `env:"MG_ARCHIVE_WRITER_STORAGE"     envDefault:"s3"`
+	FSPath        string  `env:"MG_ARCHIVE_WRITER_FS_PATH"     envDefault:"/archive"`
+	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"         envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL `env:"MG_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
+	InstanceID    string  `env:"MG_ARCHIVE_WRITER_INSTANCE_ID" envDefault:""`
+	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s service configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	archiveCfg := archive.Config{}
+	if err := env.ParseWithOptions(&archiveCfg, env.Options{Prefix: envPrefixWriter}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s archive configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	storage, err := newStorage(ctx, cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s storage : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	policyClient, policyHandler, err := grpcclient.SetupPolicyClient(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer policyHandler.Close()
+
+	logger.Info("Policy client successfully connected to auth gRPC server " + policyHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	repo, err := newService(ctx, storage, archive.NewDomainResolver(policyClient), archiveCfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s service : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create Archive writer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Archive writer service terminated: %s", err))
+	}
+}
+
+func newStorage(ctx context.Context, cfg config) (archive.Storage, error) {
+	switch cfg.Storage {
+	case fsStorage:
+		return fs.New(cfg.FSPath), nil
+	case s3Storage:
+		s3Cfg := s3.Config{}
+		if err := env.ParseWithOptions(&s3Cfg, env.Options{Prefix: envPrefixS3}); err != nil {
+			return nil, err
+		}
+		return s3.New(ctx, s3Cfg)
+	default:
+		return nil, fmt.Errorf("unsupported storage %q", cfg.Storage)
+	}
+}
+
+func newService(ctx context.Context, storage archive.Storage, resolver archive.DomainResolver, cfg archive.Config, logger *slog.Logger) (consumers.BlockingConsumer, error) {
+	svc, err := archive.New(ctx, storage, resolver, uuid.New(), cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("archive", "message_writer")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc, nil
+}
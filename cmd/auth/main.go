@@ -18,13 +18,17 @@ import (
 	api "github.com/absmach/magistrala/auth/api"
 	grpcapi "github.com/absmach/magistrala/auth/api/grpc"
 	httpapi "github.com/absmach/magistrala/auth/api/http"
+	"github.com/absmach/magistrala/auth/cache"
 	"github.com/absmach/magistrala/auth/events"
 	"github.com/absmach/magistrala/auth/jwt"
 	apostgres "github.com/absmach/magistrala/auth/postgres"
 	"github.com/absmach/magistrala/auth/spicedb"
 	"github.com/absmach/magistrala/auth/tracing"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	"github.com/absmach/magistrala/pkg/postgres"
 	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/pkg/prometheus"
@@ -37,6 +41,8 @@ import (
 	"github.com/authzed/grpcutil"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -52,23 +58,36 @@ const (
 	defDB          = "auth"
 	defSvcHTTPPort = "8189"
 	defSvcGRPCPort = "8181"
+
+	spicedbEngine  = "spicedb"
+	postgresEngine = "postgres"
 )
 
 type config struct {
 	LogLevel            string        `env:"MG_AUTH_LOG_LEVEL"               envDefault:"info"`
 	SecretKey           string        `env:"MG_AUTH_SECRET_KEY"              envDefault:"secret"`
+	SigningKeyPath      string        `env:"MG_AUTH_SIGNING_KEY_PATH"        envDefault:""`
+	VerificationKeys    []string      `env:"MG_AUTH_VERIFICATION_KEY_PATHS"  envDefault:""`
 	JaegerURL           url.URL       `env:"MG_JAEGER_URL"                   envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"               envDefault:"true"`
 	InstanceID          string        `env:"MG_AUTH_ADAPTER_INSTANCE_ID"     envDefault:""`
 	AccessDuration      time.Duration `env:"MG_AUTH_ACCESS_TOKEN_DURATION"   envDefault:"1h"`
 	RefreshDuration     time.Duration `env:"MG_AUTH_REFRESH_TOKEN_DURATION"  envDefault:"24h"`
 	InvitationDuration  time.Duration `env:"MG_AUTH_INVITATION_DURATION"     envDefault:"168h"`
+	PolicyEngine        string        `env:"MG_AUTH_POLICY_ENGINE"           envDefault:"spicedb"`
 	SpicedbHost         string        `env:"MG_SPICEDB_HOST"                 envDefault:"localhost"`
 	SpicedbPort         string        `env:"MG_SPICEDB_PORT"                 envDefault:"50051"`
 	SpicedbSchemaFile   string        `env:"MG_SPICEDB_SCHEMA_FILE"          envDefault:"./docker/spicedb/schema.zed"`
 	SpicedbPreSharedKey string        `env:"MG_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
 	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
 	ESURL               string        `env:"MG_ES_URL"                       envDefault:"nats://localhost:4222"`
+	CacheURL            string        `env:"MG_AUTH_CACHE_URL"               envDefault:"redis://localhost:6379/0"`
+	PolicyExpiryCheck   time.Duration `env:"MG_AUTH_POLICY_EXPIRY_INTERVAL"  envDefault:"1m"`
+	DomainDeleteCheck   time.Duration `env:"MG_AUTH_DOMAIN_DELETE_INTERVAL"  envDefault:"1h"`
+	DomainDeleteAfter   time.Duration `env:"MG_AUTH_DOMAIN_DELETE_AFTER"     envDefault:"720h"`
+	ESConsumerName      string        `env:"MG_AUTH_EVENT_CONSUMER"          envDefault:"auth"`
+	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"           envDefault:"nats://localhost:4222"`
+	UsageFlushInterval  time.Duration `env:"MG_AUTH_USAGE_FLUSH_INTERVAL"    envDefault:"5s"`
 }
 
 func main() {
@@ -122,14 +141,38 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	spicedbclient, err := initSpiceDB(ctx, cfg)
+	pa, err := newPolicyAgent(ctx, db, tracer, cfg, dbConfig, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init %s policy agent : %s\n", cfg.PolicyEngine, err.Error()))
+		exitCode = 1
+		return
+	}
+
+	tokenizer, err := newTokenizer(cfg)
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to init spicedb grpc client : %s\n", err.Error()))
+		logger.Error(fmt.Sprintf("failed to init tokenizer : %s", err))
+		exitCode = 1
+		return
+	}
+
+	// Setup new redis cache client for sessions and revoked tokens.
+	cacheclient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
 		exitCode = 1
 		return
 	}
+	defer cacheclient.Close()
 
-	svc := newService(ctx, db, tracer, cfg, dbConfig, logger, spicedbclient)
+	usageRepo := apostgres.NewUsageRepository(postgres.NewDatabase(db, dbConfig, tracer))
+
+	svc := newService(ctx, db, cacheclient, tracer, cfg, dbConfig, logger, pa, tokenizer, usageRepo)
+
+	if err := startUsageAccounting(ctx, cfg, usageRepo, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to start usage accounting : %s", err))
+		exitCode = 1
+		return
+	}
 
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
@@ -175,6 +218,28 @@ func main() {
 	}
 }
 
+// newPolicyAgent returns the policy agent backed by SpiceDB or, with the
+// postgres engine, evaluated by the auth database using the same schema.
+func newPolicyAgent(ctx context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger) (auth.PolicyAgent, error) {
+	switch cfg.PolicyEngine {
+	case spicedbEngine:
+		client, err := initSpiceDB(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return spicedb.NewPolicyAgent(client, logger), nil
+	case postgresEngine:
+		schemaContent, err := os.ReadFile(cfg.SpicedbSchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema file : %w", err)
+		}
+		database := postgres.NewDatabase(db, dbConfig, tracer)
+		return apostgres.NewPolicyAgent(database, string(schemaContent))
+	default:
+		return nil, fmt.Errorf("unknown policy engine %q", cfg.PolicyEngine)
+	}
+}
+
 func initSpiceDB(ctx context.Context, cfg config) (*authzed.ClientWithExperimental, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
@@ -205,16 +270,18 @@ func initSchema(ctx context.Context, client *authzed.ClientWithExperimental, sch
 	return nil
 }
 
-func newService(ctx context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental) auth.Service {
+func newService(ctx context.Context, db *sqlx.DB, cacheClient *redis.Client, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, pa auth.PolicyAgent, t auth.Tokenizer, usageRepo auth.UsageRepository) auth.Service {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	keysRepo := apostgres.New(database)
 	domainsRepo := apostgres.NewDomainRepository(database)
-	pa := spicedb.NewPolicyAgent(spicedbClient, logger)
+	rolesRepo := apostgres.NewRoleRepository(database)
+	conditionsRepo := apostgres.NewConditionsRepository(database)
+	claimsRepo := apostgres.NewClaimMappingsRepository(database)
+	sessionsRepo := cache.NewSessionRepository(cacheClient)
+	tokensRepo := cache.NewTokenRepository(cacheClient)
 	idProvider := uuid.New()
 
-	t := jwt.New([]byte(cfg.SecretKey))
-
-	svc := auth.New(keysRepo, domainsRepo, idProvider, t, pa, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
+	svc := auth.New(keysRepo, domainsRepo, sessionsRepo, tokensRepo, rolesRepo, conditionsRepo, claimsRepo, usageRepo, idProvider, t, pa, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
 	svc, err := events.NewEventStoreMiddleware(ctx, svc, cfg.ESURL)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init event store middleware : %s", err))
@@ -225,5 +292,64 @@ func newService(ctx context.Context, db *sqlx.DB, tracer trace.Tracer, cfg confi
 	svc = api.MetricsMiddleware(svc, counter, latency)
 	svc = tracing.New(svc, tracer)
 
+	auth.NewExpiryHandler(ctx, svc, cfg.PolicyExpiryCheck, logger)
+	auth.NewDeleteHandler(ctx, svc, cfg.DomainDeleteCheck, cfg.DomainDeleteAfter, logger)
+
 	return svc
 }
+
+// startUsageAccounting records the domain resources usage
+// from the event store and the published messages.
+func startUsageAccounting(ctx context.Context, cfg config, repo auth.UsageRepository, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		return err
+	}
+	if err := events.StartUsageAccounting(ctx, cfg.ESConsumerName, subscriber, repo); err != nil {
+		return err
+	}
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		return err
+	}
+
+	return events.StartMessagesAccounting(ctx, svcName, pubSub, repo, cfg.UsageFlushInterval, logger)
+}
+
+// newTokenizer signs tokens with the HS512 secret unless the signing key is
+// set. With the signing key set, the secret is used only to verify the tokens
+// issued before the switch, and the verification keys are the retired
+// signing keys whose tokens are still accepted.
+func newTokenizer(cfg config) (auth.Tokenizer, error) {
+	if cfg.SigningKeyPath == "" {
+		return jwt.New([]byte(cfg.SecretKey)), nil
+	}
+
+	signingKey, err := readKey(cfg.SigningKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	var verificationKeys []jwk.Key
+	for _, path := range cfg.VerificationKeys {
+		if path == "" {
+			continue
+		}
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	return jwt.NewAsymmetric(signingKey, verificationKeys, []byte(cfg.SecretKey))
+}
+
+func readKey(path string) (jwk.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s : %w", path, err)
+	}
+
+	return jwt.ParseKey(data)
+}
@@ -16,10 +16,13 @@ import (
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/certs"
 	"github.com/absmach/magistrala/certs/api"
+	"github.com/absmach/magistrala/certs/events/consumer"
 	vault "github.com/absmach/magistrala/certs/pki"
 	certspg "github.com/absmach/magistrala/certs/postgres"
 	"github.com/absmach/magistrala/certs/tracing"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/postgres"
@@ -42,6 +45,7 @@ const (
 	envPrefixAuth  = "MG_AUTH_GRPC_"
 	defDB          = "certs"
 	defSvcHTTPPort = "9019"
+	thingsStream   = "events.magistrala.things"
 )
 
 type config struct {
@@ -51,6 +55,8 @@ type config struct {
 	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"         envDefault:"true"`
 	InstanceID    string  `env:"MG_CERTS_INSTANCE_ID"      envDefault:""`
 	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"     envDefault:"1.0"`
+	ESURL         string  `env:"MG_ES_URL"                 envDefault:"nats://localhost:4222"`
+	ESConsumer    string  `env:"MG_CERTS_EVENT_CONSUMER"   envDefault:"certs"`
 
 	// Sign and issue certificates without 3rd party PKI
 	SignCAPath    string `env:"MG_CERTS_SIGN_CA_PATH"        envDefault:"ca.crt"`
@@ -151,6 +157,12 @@ func main() {
 
 	svc := newService(authClient, db, tracer, logger, cfg, dbConfig, pkiclient)
 
+	if err = subscribeToThingsES(ctx, svc, cfg, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
@@ -192,3 +204,17 @@ func newService(authClient magistrala.AuthnServiceClient, db *sqlx.DB, tracer tr
 
 	return svc
 }
+
+func subscribeToThingsES(ctx context.Context, svc certs.Service, cfg config, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:   thingsStream,
+		Consumer: cfg.ESConsumer,
+		Handler:  consumer.NewEventHandler(svc),
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
@@ -48,6 +48,10 @@ func main() {
 	configCmd := cli.NewConfigCmd()
 	invitationsCmd := cli.NewInvitationsCmd()
 	journalCmd := cli.NewJournalCmd()
+	replayCmd := cli.NewReplayCmd()
+	keysCmd := cli.NewKeysCmd()
+	authzCmd := cli.NewAuthzCmd()
+	serviceAccountsCmd := cli.NewServiceAccountsCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -64,6 +68,10 @@ func main() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(invitationsCmd)
 	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(authzCmd)
+	rootCmd.AddCommand(serviceAccountsCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -138,6 +146,14 @@ func main() {
 		"Journal Log URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.ReplayURL,
+		"replay-url",
+		"P",
+		sdkConf.ReplayURL,
+		"Replay service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.HostURL,
 		"host-url",
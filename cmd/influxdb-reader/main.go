@@ -0,0 +1,158 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains influxdb-reader main function to start the influxdb-reader service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	influxdbclient "github.com/absmach/magistrala/pkg/influxdb"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/readers"
+	"github.com/absmach/magistrala/readers/api"
+	"github.com/absmach/magistrala/readers/influxdb"
+	"github.com/caarlos0/env/v11"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName         = "influxdb-reader"
+	envPrefixDB     = "MG_INFLUXDB_"
+	envPrefixHTTP   = "MG_INFLUXDB_READER_HTTP_"
+	envPrefixAuth   = "MG_AUTH_GRPC_"
+	envPrefixThings = "MG_THINGS_AUTH_GRPC_"
+	defSvcHTTPPort  = "9005"
+)
+
+type config struct {
+	LogLevel      string `env:"MG_INFLUXDB_READER_LOG_LEVEL"   envDefault:"info"`
+	SendTelemetry bool   `env:"MG_SEND_TELEMETRY"              envDefault:"true"`
+	InstanceID    string `env:"MG_INFLUXDB_READER_INSTANCE_ID" envDefault:""`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	influxDBConfig := influxdbclient.Config{}
+	if err := env.ParseWithOptions(&influxDBConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s InfluxDB configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	client, err := influxdbclient.Connect(ctx, influxDBConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to InfluxDB : %s", err))
+		exitCode = 1
+		return
+	}
+	defer client.Close()
+
+	repoCfg := influxdb.RepoConfig{
+		Bucket: influxDBConfig.Bucket,
+		Org:    influxDBConfig.Org,
+	}
+	repo := newService(client, repoCfg, logger)
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClient, authHandler, err := grpcclient.SetupAuthClient(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+
+	logger.Info("AuthService gRPC client successfully connected to auth gRPC server " + authHandler.Secure())
+
+	thingsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer thingsHandler.Close()
+
+	logger.Info("ThingsService gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(repo, authClient, thingsClient, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("InfluxDB reader service terminated: %s", err))
+	}
+}
+
+func newService(client influxdb2.Client, repoCfg influxdb.RepoConfig, logger *slog.Logger) readers.MessageRepository {
+	svc := influxdb.New(client, repoCfg)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("influxdb", "message_reader")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains influxdb-writer main function to start the influxdb-writer service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	"github.com/absmach/magistrala/consumers/writers/api"
+	writerevents "github.com/absmach/magistrala/consumers/writers/events"
+	"github.com/absmach/magistrala/consumers/writers/influxdb"
+	mglog "github.com/absmach/magistrala/logger"
+	influxdbclient "github.com/absmach/magistrala/pkg/influxdb"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "influxdb-writer"
+	envPrefixDB    = "MG_INFLUXDB_"
+	envPrefixHTTP  = "MG_INFLUXDB_WRITER_HTTP_"
+	defSvcHTTPPort = "9006"
+)
+
+type config struct {
+	LogLevel      string  `env:"MG_INFLUXDB_WRITER_LOG_LEVEL"      envDefault:"info"`
+	ConfigPath    string  `env:"MG_INFLUXDB_WRITER_CONFIG_PATH"    envDefault:"/config.toml"`
+	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"             envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL `env:"MG_JAEGER_URL"                     envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                 envDefault:"true"`
+	InstanceID    string  `env:"MG_INFLUXDB_WRITER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"             envDefault:"1.0"`
+	ESURL         string  `env:"MG_ES_URL"                         envDefault:"nats://localhost:4222"`
+	ESConsumer    string  `env:"MG_INFLUXDB_WRITER_EVENT_CONSUMER" envDefault:"influxdb-writer"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s service configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	influxDBConfig := influxdbclient.Config{}
+	if err := env.ParseWithOptions(&influxDBConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s InfluxDB configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	client, err := influxdbclient.Connect(ctx, influxDBConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to InfluxDB : %s", err))
+		exitCode = 1
+		return
+	}
+	defer client.Close()
+
+	repoCfg := influxdb.RepoConfig{
+		Bucket: influxDBConfig.Bucket,
+		Org:    influxDBConfig.Org,
+	}
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	repo := newService(client, repoCfg, logger)
+	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create InfluxDB writer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	if err = writerevents.Start(ctx, cfg.ESConsumer, cfg.ESURL, influxdb.NewRemover(client, repoCfg), logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("InfluxDB writer service terminated: %s", err))
+	}
+}
+
+func newService(client influxdb2.Client, repoCfg influxdb.RepoConfig, logger *slog.Logger) consumers.BlockingConsumer {
+	svc := influxdb.New(client, repoCfg)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("influxdb", "message_writer")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+	return svc
+}
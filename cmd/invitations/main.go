@@ -15,8 +15,10 @@ import (
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	authclient "github.com/absmach/magistrala/auth/api/grpc"
+	"github.com/absmach/magistrala/internal/email"
 	"github.com/absmach/magistrala/invitations"
 	"github.com/absmach/magistrala/invitations/api"
+	"github.com/absmach/magistrala/invitations/emailer"
 	"github.com/absmach/magistrala/invitations/middleware"
 	invitationspg "github.com/absmach/magistrala/invitations/postgres"
 	mglog "github.com/absmach/magistrala/logger"
@@ -49,6 +51,7 @@ type config struct {
 	UsersURL      string  `env:"MG_USERS_URL"                  envDefault:"http://localhost:9002"`
 	DomainsURL    string  `env:"MG_DOMAINS_URL"                envDefault:"http://localhost:8189"`
 	InstanceID    string  `env:"MG_INVITATIONS_INSTANCE_ID"    envDefault:""`
+	SignUpURL     string  `env:"MG_INVITATIONS_SIGNUP_URL"     envDefault:"http://localhost:9095/signup"`
 	JaegerURL     url.URL `env:"MG_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
 	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
 	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
@@ -79,6 +82,13 @@ func main() {
 		}
 	}
 
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		logger.Error(fmt.Sprintf("failed to load email configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
 	dbConfig := clientspg.Config{Name: defDB}
 	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s database configuration : %s", svcName, err))
@@ -121,7 +131,7 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	svc, err := newService(db, dbConfig, authClient, tracer, cfg, logger)
+	svc, err := newService(db, dbConfig, authClient, tracer, cfg, ec, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
 		exitCode = 1
@@ -155,7 +165,7 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, dbConfig clientspg.Config, authClient authclient.AuthServiceClient, tracer trace.Tracer, conf config, logger *slog.Logger) (invitations.Service, error) {
+func newService(db *sqlx.DB, dbConfig clientspg.Config, authClient authclient.AuthServiceClient, tracer trace.Tracer, conf config, ec email.Config, logger *slog.Logger) (invitations.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	repo := invitationspg.NewRepository(database)
 
@@ -165,7 +175,12 @@ func newService(db *sqlx.DB, dbConfig clientspg.Config, authClient authclient.Au
 	}
 	sdk := mgsdk.NewSDK(config)
 
-	svc := invitations.NewService(repo, authClient, sdk)
+	emailerClient, err := emailer.New(conf.SignUpURL, &ec)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
+	}
+
+	svc := invitations.NewService(repo, authClient, sdk, uuid.New(), emailerClient)
 	svc = middleware.Tracing(svc, tracer)
 	svc = middleware.Logging(logger, svc)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
@@ -17,6 +17,7 @@ import (
 	"github.com/absmach/magistrala/consumers"
 	consumertracing "github.com/absmach/magistrala/consumers/tracing"
 	"github.com/absmach/magistrala/consumers/writers/api"
+	writerevents "github.com/absmach/magistrala/consumers/writers/events"
 	writerpg "github.com/absmach/magistrala/consumers/writers/postgres"
 	mglog "github.com/absmach/magistrala/logger"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
@@ -41,13 +42,15 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_POSTGRES_WRITER_LOG_LEVEL"     envDefault:"info"`
-	ConfigPath    string  `env:"MG_POSTGRES_WRITER_CONFIG_PATH"   envDefault:"/config.toml"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
-	InstanceID    string  `env:"MG_POSTGRES_WRITER_INSTANCE_ID"   envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+	LogLevel      string  `env:"MG_POSTGRES_WRITER_LOG_LEVEL"      envDefault:"info"`
+	ConfigPath    string  `env:"MG_POSTGRES_WRITER_CONFIG_PATH"    envDefault:"/config.toml"`
+	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"             envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL `env:"MG_JAEGER_URL"                     envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                 envDefault:"true"`
+	InstanceID    string  `env:"MG_POSTGRES_WRITER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"             envDefault:"1.0"`
+	ESURL         string  `env:"MG_ES_URL"                         envDefault:"nats://localhost:4222"`
+	ESConsumer    string  `env:"MG_POSTGRES_WRITER_EVENT_CONSUMER" envDefault:"postgres-writer"`
 }
 
 func main() {
@@ -125,6 +128,12 @@ func main() {
 		return
 	}
 
+	if err = writerevents.Start(ctx, cfg.ESConsumer, cfg.ESURL, writerpg.NewRemover(db), logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
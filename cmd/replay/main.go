@@ -0,0 +1,251 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains replay main function to start the replay service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	authclient "github.com/absmach/magistrala/auth/api/grpc"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	influxdbclient "github.com/absmach/magistrala/pkg/influxdb"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	mongoclient "github.com/absmach/magistrala/pkg/mongo"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/readers"
+	readersapi "github.com/absmach/magistrala/readers/api"
+	"github.com/absmach/magistrala/readers/influxdb"
+	"github.com/absmach/magistrala/readers/mongodb"
+	"github.com/absmach/magistrala/readers/postgres"
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/absmach/magistrala/readers/replay/api"
+	"github.com/absmach/magistrala/readers/timescale"
+	"github.com/caarlos0/env/v11"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName            = "replay"
+	envPrefixHTTP      = "MG_REPLAY_HTTP_"
+	envPrefixAuth      = "MG_AUTH_GRPC_"
+	envPrefixPostgres  = "MG_POSTGRES_"
+	envPrefixTimescale = "MG_TIMESCALE_"
+	envPrefixInfluxDB  = "MG_INFLUXDB_"
+	envPrefixMongo     = "MG_MONGO_"
+	defSvcHTTPPort     = "9026"
+
+	postgresDB  = "postgres"
+	timescaleDB = "timescale"
+	influxDB    = "influxdb"
+	mongoDB     = "mongodb"
+)
+
+type config struct {
+	LogLevel      string        `env:"MG_REPLAY_LOG_LEVEL"   envDefault:"info"`
+	DB            string        `env:"MG_REPLAY_DB"          envDefault:"postgres"`
+	BatchSize     uint64        `env:"MG_REPLAY_BATCH_SIZE"  envDefault:"100"`
+	MaxRate       float64       `env:"MG_REPLAY_MAX_RATE"    envDefault:"0"`
+	Retention     time.Duration `env:"MG_REPLAY_RETENTION"   envDefault:"24h"`
+	BrokerURL     string        `env:"MG_MESSAGE_BROKER_URL" envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL       `env:"MG_JAEGER_URL"         envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool          `env:"MG_SEND_TELEMETRY"     envDefault:"true"`
+	InstanceID    string        `env:"MG_REPLAY_INSTANCE_ID" envDefault:""`
+	TraceRatio    float64       `env:"MG_JAEGER_TRACE_RATIO" envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	replayCfg := replay.Config{
+		BatchSize: cfg.BatchSize,
+		MaxRate:   cfg.MaxRate,
+		Retention: cfg.Retention,
+	}
+	if err := replayCfg.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("invalid %s configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	repo, closeRepo, err := newRepository(ctx, cfg.DB, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to setup %s repository : %s", cfg.DB, err))
+		exitCode = 1
+		return
+	}
+	defer closeRepo()
+	repo = readersapi.LoggingMiddleware(repo, logger)
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClient, authHandler, err := grpcclient.SetupAuthClient(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+
+	logger.Info("AuthService gRPC client successfully connected to auth gRPC server " + authHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pub, err := brokers.NewPublisher(ctx, cfg.BrokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pub.Close()
+	pub = brokerstracing.NewPublisher(httpServerConfig, tracer, pub)
+
+	svc := newService(ctx, authClient, repo, pub, replayCfg, logger)
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, logger, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(ctx context.Context, authClient authclient.AuthServiceClient, repo readers.MessageRepository, pub messaging.Publisher, cfg replay.Config, logger *slog.Logger) replay.Service {
+	idp := uuid.New()
+
+	svc := replay.New(ctx, authClient, repo, pub, idp, cfg, logger)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("replay", "api")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
+
+// newRepository connects to the database the messages are read from. The
+// database connection uses the same environment variables as its reader.
+func newRepository(ctx context.Context, db string, logger *slog.Logger) (readers.MessageRepository, func(), error) {
+	switch db {
+	case postgresDB, timescaleDB:
+		prefix := envPrefixPostgres
+		if db == timescaleDB {
+			prefix = envPrefixTimescale
+		}
+		dbConfig := pgclient.Config{}
+		if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: prefix}); err != nil {
+			return nil, nil, err
+		}
+		conn, err := pgclient.Connect(dbConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		closeFn := func() { conn.Close() }
+		if db == timescaleDB {
+			return timescale.New(conn), closeFn, nil
+		}
+		return postgres.New(conn), closeFn, nil
+	case influxDB:
+		dbConfig := influxdbclient.Config{}
+		if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixInfluxDB}); err != nil {
+			return nil, nil, err
+		}
+		client, err := influxdbclient.Connect(ctx, dbConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		repoCfg := influxdb.RepoConfig{
+			Bucket: dbConfig.Bucket,
+			Org:    dbConfig.Org,
+		}
+		return influxdb.New(client, repoCfg), client.Close, nil
+	case mongoDB:
+		dbConfig := mongoclient.Config{}
+		if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixMongo}); err != nil {
+			return nil, nil, err
+		}
+		database, err := mongoclient.Setup(ctx, dbConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		closeFn := func() {
+			if err := database.Client().Disconnect(context.Background()); err != nil {
+				logger.Error(fmt.Sprintf("failed to disconnect from MongoDB: %s", err))
+			}
+		}
+		return mongodb.New(database), closeFn, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported database %q", db)
+	}
+}
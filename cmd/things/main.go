@@ -23,6 +23,7 @@ import (
 	gpostgres "github.com/absmach/magistrala/internal/groups/postgres"
 	gtracing "github.com/absmach/magistrala/internal/groups/tracing"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
@@ -76,6 +77,13 @@ type config struct {
 	ESURL            string        `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
 	CacheURL         string        `env:"MG_THINGS_CACHE_URL"           envDefault:"redis://localhost:6379/0"`
 	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+	AuthzCacheSize   int           `env:"MG_THINGS_AUTHZ_CACHE_SIZE"    envDefault:"10000"`
+	AuthzCacheTTL    time.Duration `env:"MG_THINGS_AUTHZ_CACHE_TTL"     envDefault:"1m"`
+	AuthzCacheRedis  bool          `env:"MG_THINGS_AUTHZ_CACHE_REDIS"   envDefault:"false"`
+	QuotaCacheSize   int           `env:"MG_THINGS_QUOTA_CACHE_SIZE"    envDefault:"10000"`
+	QuotaCacheTTL    time.Duration `env:"MG_THINGS_QUOTA_CACHE_TTL"     envDefault:"1m"`
+	QuotaDecisionTTL time.Duration `env:"MG_THINGS_QUOTA_DECISION_TTL"  envDefault:"5s"`
+	SeedUsage        bool          `env:"MG_THINGS_SEED_USAGE"          envDefault:"true"`
 }
 
 func main() {
@@ -183,13 +191,54 @@ func main() {
 		logger.Info("PolicyService gRPC client successfully connected to auth gRPC server " + policyHandler.Secure())
 	}
 
-	csvc, gsvc, err := newService(ctx, db, dbConfig, authClient, policyClient, cacheclient, cfg.CacheKeyDuration, cfg.ESURL, tracer, logger)
+	var authzRedis *redis.Client
+	if cfg.AuthzCacheRedis {
+		authzRedis = cacheclient
+	}
+	authzCache := thcache.NewAuthzCache(authzRedis, cfg.AuthzCacheSize, cfg.AuthzCacheTTL)
+	authzCache = api.AuthzCacheMetricsMiddleware(authzCache, prometheus.MakeCacheMetrics(svcName, "authz_cache"))
+
+	quotaCache := thcache.NewQuotaCache(cfg.QuotaCacheSize, cfg.QuotaCacheTTL, cfg.QuotaDecisionTTL)
+
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+
+	// Every instance invalidates its own cached authorization decisions.
+	if err := thevents.StartAuthzCacheInvalidation(ctx, fmt.Sprintf("%s-authz-%s", svcName, cfg.InstanceID), subscriber, authzCache); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to event store: %s", err))
+		exitCode = 1
+		return
+	}
+
+	csvc, gsvc, err := newService(ctx, db, dbConfig, authClient, policyClient, cacheclient, authzCache, quotaCache, cfg.CacheKeyDuration, cfg.ESURL, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
 		return
 	}
 
+	// Seeding skips the recorded entities, so it is repeated on every start.
+	if cfg.SeedUsage {
+		go func() {
+			database := postgres.NewDatabase(db, dbConfig, tracer)
+			if err := things.SeedUsage(ctx, authClient, thingspg.NewRepository(database), gpostgres.New(database)); err != nil {
+				logger.Warn(fmt.Sprintf("failed to seed the domains usage: %s", err))
+			}
+		}()
+	}
+
+	// The instances share the consumer deleting the removed domains data.
+	if err := thevents.StartDomainDeletion(ctx, fmt.Sprintf("%s-domains", svcName), subscriber, csvc, gsvc); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
@@ -234,16 +283,17 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authClient authclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, cacheClient *redis.Client, keyDuration time.Duration, esURL string, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authClient authclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, cacheClient *redis.Client, authzCache things.AuthzCache, quotaCache things.QuotaCache, keyDuration time.Duration, esURL string, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	cRepo := thingspg.NewRepository(database)
 	gRepo := gpostgres.New(database)
+	aclRepo := thingspg.NewACLRepository(database)
 
 	idp := uuid.New()
 
 	thingCache := thcache.NewCache(cacheClient, keyDuration)
 
-	csvc := things.NewService(authClient, policyClient, cRepo, gRepo, thingCache, idp)
+	csvc := things.NewService(authClient, policyClient, cRepo, gRepo, thingCache, authzCache, quotaCache, aclRepo, idp)
 	gsvc := mggroups.NewService(gRepo, idp, authClient, policyClient)
 
 	csvc, err := thevents.NewEventStoreMiddleware(ctx, csvc, esURL)
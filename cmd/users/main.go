@@ -12,12 +12,14 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	authSvc "github.com/absmach/magistrala/auth"
 	authclient "github.com/absmach/magistrala/auth/api/grpc"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
 	"github.com/absmach/magistrala/internal/email"
 	mggroups "github.com/absmach/magistrala/internal/groups"
 	gapi "github.com/absmach/magistrala/internal/groups/api"
@@ -25,29 +27,41 @@ import (
 	gpostgres "github.com/absmach/magistrala/internal/groups/postgres"
 	gtracing "github.com/absmach/magistrala/internal/groups/tracing"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/oauth2"
 	googleoauth "github.com/absmach/magistrala/pkg/oauth2/google"
+	"github.com/absmach/magistrala/pkg/oauth2/oidc"
 	"github.com/absmach/magistrala/pkg/postgres"
 	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/pkg/prometheus"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/users"
 	capi "github.com/absmach/magistrala/users/api"
+	ucache "github.com/absmach/magistrala/users/cache"
 	"github.com/absmach/magistrala/users/emailer"
 	uevents "github.com/absmach/magistrala/users/events"
 	"github.com/absmach/magistrala/users/hasher"
+	uinvitations "github.com/absmach/magistrala/users/invitations"
+	"github.com/absmach/magistrala/users/lockout"
+	"github.com/absmach/magistrala/users/passwords"
+	"github.com/absmach/magistrala/users/personaldata"
 	clientspg "github.com/absmach/magistrala/users/postgres"
+	"github.com/absmach/magistrala/users/scim"
+	scimapi "github.com/absmach/magistrala/users/scim/api"
 	ctracing "github.com/absmach/magistrala/users/tracing"
 	"github.com/caarlos0/env/v11"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
@@ -58,6 +72,8 @@ const (
 	envPrefixHTTP   = "MG_USERS_HTTP_"
 	envPrefixAuth   = "MG_AUTH_GRPC_"
 	envPrefixGoogle = "MG_GOOGLE_"
+	envPrefixOIDC   = "MG_OIDC_"
+	envPrefixLock   = "MG_USERS_LOCKOUT_"
 	defDB           = "users"
 	defSvcHTTPPort  = "9002"
 
@@ -70,16 +86,26 @@ type config struct {
 	AdminPassword      string        `env:"MG_USERS_ADMIN_PASSWORD"      envDefault:"12345678"`
 	PassRegexText      string        `env:"MG_USERS_PASS_REGEX"          envDefault:"^.{8,}$"`
 	ResetURL           string        `env:"MG_TOKEN_RESET_ENDPOINT"      envDefault:"/reset-request"`
+	VerificationURL    string        `env:"MG_USERS_VERIFICATION_URL"    envDefault:"http://localhost:9095/verify-email"`
+	VerifyTemplate     string        `env:"MG_USERS_VERIFY_TEMPLATE"     envDefault:"verification.tmpl"`
 	JaegerURL          url.URL       `env:"MG_JAEGER_URL"                envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry      bool          `env:"MG_SEND_TELEMETRY"            envDefault:"true"`
 	InstanceID         string        `env:"MG_USERS_INSTANCE_ID"         envDefault:""`
 	ESURL              string        `env:"MG_ES_URL"                    envDefault:"nats://localhost:4222"`
 	TraceRatio         float64       `env:"MG_JAEGER_TRACE_RATIO"        envDefault:"1.0"`
 	SelfRegister       bool          `env:"MG_USERS_ALLOW_SELF_REGISTER" envDefault:"false"`
+	VerifyEmail        bool          `env:"MG_USERS_VERIFY_EMAIL"        envDefault:"true"`
 	OAuthUIRedirectURL string        `env:"MG_OAUTH_UI_REDIRECT_URL"     envDefault:"http://localhost:9095/domains"`
 	OAuthUIErrorURL    string        `env:"MG_OAUTH_UI_ERROR_URL"        envDefault:"http://localhost:9095/error"`
+	OIDCProviders      []string      `env:"MG_USERS_OIDC_PROVIDERS"      envDefault:""`
 	DeleteInterval     time.Duration `env:"MG_USERS_DELETE_INTERVAL"     envDefault:"24h"`
 	DeleteAfter        time.Duration `env:"MG_USERS_DELETE_AFTER"        envDefault:"720h"`
+	CacheURL           string        `env:"MG_USERS_CACHE_URL"           envDefault:"redis://localhost:6379/0"`
+	PassMinLength      int           `env:"MG_USERS_PASS_MIN_LENGTH"     envDefault:"8"`
+	PassBreachedFile   string        `env:"MG_USERS_PASS_BREACHED_FILE"  envDefault:""`
+	TrustedProxies     []string      `env:"MG_USERS_TRUSTED_PROXIES"     envDefault:""`
+	InvitationsURL     string        `env:"MG_INVITATIONS_URL"           envDefault:"http://localhost:9020"`
+	JournalURL         string        `env:"MG_USERS_JOURNAL_URL"         envDefault:""`
 	PassRegex          *regexp.Regexp
 }
 
@@ -175,13 +201,44 @@ func main() {
 	defer policyHandler.Close()
 	logger.Info("PolicyService gRPC client successfully connected to auth gRPC server " + policyHandler.Secure())
 
-	csvc, gsvc, err := newService(ctx, authClient, policyClient, db, dbConfig, tracer, cfg, ec, logger)
+	lockoutConfig := lockout.Config{}
+	if err := env.ParseWithOptions(&lockoutConfig, env.Options{Prefix: envPrefixLock}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s lockout configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	// Setup new redis cache client for the login attempts.
+	cacheclient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheclient.Close()
+
+	csvc, gsvc, ssvc, err := newService(ctx, authClient, policyClient, db, dbConfig, cacheclient, lockoutConfig, tracer, cfg, ec, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to setup service: %s", err))
 		exitCode = 1
 		return
 	}
 
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+
+	// The instances share the consumer deleting the removed domains data.
+	if err := uevents.StartDomainDeletion(ctx, fmt.Sprintf("%s-domains", svcName), subscriber, csvc, gsvc); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err.Error()))
@@ -195,10 +252,28 @@ func main() {
 		exitCode = 1
 		return
 	}
-	oauthProvider := googleoauth.NewProvider(oauthConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)
+	states := clientspg.NewStateRepository(postgres.NewDatabase(db, dbConfig, tracer))
+	providers := []oauth2.Provider{googleoauth.NewProvider(oauthConfig, states, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)}
+	for _, name := range cfg.OIDCProviders {
+		oidcConfig := oidc.Config{}
+		if err := env.ParseWithOptions(&oidcConfig, env.Options{Prefix: envPrefixOIDC + strings.ToUpper(name) + "_"}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load %s OpenID Connect %s configuration : %s", svcName, name, err.Error()))
+			exitCode = 1
+			return
+		}
+		providers = append(providers, oidc.NewProvider(name, oidcConfig, states, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL))
+	}
+
+	proxies, err := apiutil.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse %s trusted proxies : %s", svcName, err))
+		exitCode = 1
+		return
+	}
 
 	mux := chi.NewRouter()
-	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, gsvc, mux, logger, cfg.InstanceID, cfg.PassRegex, oauthProvider), logger)
+	scimapi.MakeHandler(ssvc, mux, logger)
+	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, gsvc, mux, logger, cfg.InstanceID, cfg.PassRegex, proxies, providers...), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
@@ -218,29 +293,46 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, authClient authclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, c config, ec email.Config, logger *slog.Logger) (users.Service, groups.Service, error) {
+func newService(ctx context.Context, authClient authclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, db *sqlx.DB, dbConfig pgclient.Config, cacheClient *redis.Client, lockoutConfig lockout.Config, tracer trace.Tracer, c config, ec email.Config, logger *slog.Logger) (users.Service, groups.Service, scim.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	cRepo := clientspg.NewRepository(database)
+	mfaRepo := clientspg.NewMFARepository(database)
+	verificationRepo := clientspg.NewVerificationRepository(database)
+	identities := clientspg.NewIdentityRepository(database)
+	attempts := ucache.NewAttemptsRepository(cacheClient)
 	gRepo := gpostgres.New(database)
 
 	idp := uuid.New()
 	hsr := hasher.New()
+	passPolicy, err := passwords.NewPolicy(c.PassMinLength, c.PassBreachedFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	emailerClient, err := emailer.New(c.ResetURL, &ec)
+	vec := ec
+	vec.Template = c.VerifyTemplate
+	emailerClient, err := emailer.New(c.ResetURL, c.VerificationURL, &ec, &vec)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
 	}
 
-	csvc := users.NewService(cRepo, authClient, policyClient, emailerClient, hsr, idp, c.SelfRegister)
+	sdk := mgsdk.NewSDK(mgsdk.Config{
+		InvitationsURL: c.InvitationsURL,
+		JournalURL:     c.JournalURL,
+	})
+	personalData := personaldata.New(sdk, c.JournalURL != "")
+	invitations := uinvitations.New(sdk)
+
+	csvc := users.NewService(cRepo, mfaRepo, verificationRepo, identities, attempts, lockoutConfig, authClient, policyClient, emailerClient, personalData, invitations, hsr, passPolicy, idp, c.SelfRegister, c.VerifyEmail)
 	gsvc := mggroups.NewService(gRepo, idp, authClient, policyClient)
 
 	csvc, err = uevents.NewEventStoreMiddleware(ctx, csvc, c.ESURL)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	gsvc, err = gevents.NewEventStoreMiddleware(ctx, gsvc, c.ESURL, streamID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	csvc = ctracing.New(csvc, tracer)
@@ -253,17 +345,22 @@ func newService(ctx context.Context, authClient authclient.AuthServiceClient, po
 	counter, latency = prometheus.MakeMetrics("groups", "api")
 	gsvc = gapi.MetricsMiddleware(gsvc, counter, latency)
 
+	ssvc := scim.NewService(csvc, cRepo, gsvc, authClient)
+	ssvc = scimapi.LoggingMiddleware(ssvc, logger)
+	counter, latency = prometheus.MakeMetrics("scim", "api")
+	ssvc = scimapi.MetricsMiddleware(ssvc, counter, latency)
+
 	clientID, err := createAdmin(ctx, c, cRepo, hsr, csvc)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create admin client: %s", err))
 	}
 	if err := createAdminPolicy(ctx, clientID, authClient, policyClient); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	users.NewDeleteHandler(ctx, cRepo, policyClient, c.DeleteInterval, c.DeleteAfter, logger)
 
-	return csvc, gsvc, err
+	return csvc, gsvc, ssvc, err
 }
 
 func createAdmin(ctx context.Context, c config, crepo clientspg.Repository, hsr users.Hasher, svc users.Service) (string, error) {
@@ -286,10 +383,11 @@ func createAdmin(ctx context.Context, c config, crepo clientspg.Repository, hsr
 		Metadata: mgclients.Metadata{
 			"role": "admin",
 		},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Role:      mgclients.AdminRole,
-		Status:    mgclients.EnabledStatus,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		VerifiedAt: time.Now(),
+		Role:       mgclients.AdminRole,
+		Status:     mgclients.EnabledStatus,
 	}
 
 	if c, err := crepo.RetrieveByIdentity(ctx, client.Credentials.Identity); err == nil {
@@ -300,7 +398,7 @@ func createAdmin(ctx context.Context, c config, crepo clientspg.Repository, hsr
 	if _, err = crepo.Save(ctx, client); err != nil {
 		return "", err
 	}
-	if _, err = svc.IssueToken(ctx, c.AdminEmail, c.AdminPassword, ""); err != nil {
+	if _, err = svc.IssueToken(ctx, c.AdminEmail, c.AdminPassword, "", ""); err != nil {
 		return "", err
 	}
 	return client.ID, nil
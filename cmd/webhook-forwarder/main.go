@@ -0,0 +1,215 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains webhook-forwarder main function to start the webhook-forwarder service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	authclient "github.com/absmach/magistrala/auth/api/grpc"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook/api"
+	whhttp "github.com/absmach/magistrala/consumers/forwarders/webhook/http"
+	whpostgres "github.com/absmach/magistrala/consumers/forwarders/webhook/postgres"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook/tracing"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/postgres"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName         = "webhook-forwarder"
+	envPrefixDB     = "MG_WEBHOOK_FORWARDER_DB_"
+	envPrefixHTTP   = "MG_WEBHOOK_FORWARDER_HTTP_"
+	envPrefixSender = "MG_WEBHOOK_FORWARDER_SENDER_"
+	envPrefixAuth   = "MG_AUTH_GRPC_"
+	defDB           = "webhooks"
+	defSvcHTTPPort  = "9022"
+)
+
+type config struct {
+	LogLevel      string        `env:"MG_WEBHOOK_FORWARDER_LOG_LEVEL"      envDefault:"info"`
+	ConfigPath    string        `env:"MG_WEBHOOK_FORWARDER_CONFIG_PATH"    envDefault:"/config.toml"`
+	BatchSize     int           `env:"MG_WEBHOOK_FORWARDER_BATCH_SIZE"     envDefault:"1"`
+	BatchInterval time.Duration `env:"MG_WEBHOOK_FORWARDER_BATCH_INTERVAL" envDefault:"5s"`
+	CacheSize     int           `env:"MG_WEBHOOK_FORWARDER_CACHE_SIZE"     envDefault:"10000"`
+	CacheTTL      time.Duration `env:"MG_WEBHOOK_FORWARDER_CACHE_TTL"      envDefault:"1m"`
+	BrokerURL     string        `env:"MG_MESSAGE_BROKER_URL"               envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL       `env:"MG_JAEGER_URL"                       envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool          `env:"MG_SEND_TELEMETRY"                   envDefault:"true"`
+	InstanceID    string        `env:"MG_WEBHOOK_FORWARDER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64       `env:"MG_JAEGER_TRACE_RATIO"               envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Postgres configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *whpostgres.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	senderConfig := whhttp.Config{}
+	if err := env.ParseWithOptions(&senderConfig, env.Options{Prefix: envPrefixSender}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s sender configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	authClient, authHandler, err := grpcclient.SetupAuthClient(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+
+	logger.Info("AuthService gRPC client successfully connected to auth gRPC server " + authHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	filter, err := webhook.NewAddressFilter(senderConfig.AllowedNetworks)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create webhook address filter: %s", err))
+		exitCode = 1
+		return
+	}
+
+	sender, err := whhttp.New(senderConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create webhook sender: %s", err))
+		exitCode = 1
+		return
+	}
+	sender = webhook.NewBatcher(ctx, sender, cfg.BatchSize, cfg.BatchInterval, logger)
+
+	cache := webhook.NewCache(cfg.CacheSize, cfg.CacheTTL)
+
+	svc := newService(db, dbConfig, authClient, sender, filter, cache, logger, tracer)
+
+	if err = consumers.Start(ctx, svcName, pubSub, svc, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create webhook forwarder: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, logger, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, authClient authclient.AuthServiceClient, sender webhook.Sender, filter webhook.AddressFilter, cache webhook.Cache, logger *slog.Logger, tracer trace.Tracer) webhook.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := whpostgres.New(database)
+	repo = tracing.New(tracer, repo)
+	idp := uuid.New()
+
+	svc := webhook.New(authClient, repo, idp, sender, filter, cache)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("webhooks", "forwarder")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
@@ -58,6 +58,7 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg *messagi
 		Subject:     key,
 		Object:      msg.GetChannel(),
 		ObjectType:  auth.GroupType,
+		Subtopic:    msg.GetSubtopic(),
 	}
 	res, err := svc.things.Authorize(ctx, ar)
 	if err != nil {
@@ -78,6 +79,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		Subject:     key,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := svc.things.Authorize(ctx, ar)
 	if err != nil {
@@ -106,6 +108,7 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 		Subject:     key,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := svc.things.Authorize(ctx, ar)
 	if err != nil {
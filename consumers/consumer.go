@@ -28,3 +28,12 @@ type BlockingConsumer interface {
 	// A non-nil error is returned to indicate operation failure.
 	ConsumeBlocking(ctx context.Context, messages interface{}) error
 }
+
+// MessageRemover specifies the API removing the stored messages,
+// which is used by the writers to remove the messages of the
+// removed channels.
+type MessageRemover interface {
+	// RemoveChannel removes the stored messages of the channel.
+	// A non-nil error is returned to indicate operation failure.
+	RemoveChannel(ctx context.Context, channelID string) error
+}
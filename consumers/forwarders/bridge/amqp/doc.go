@@ -0,0 +1,5 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package amqp contains the AMQP 0-9-1 implementation of the bridge publisher.
+package amqp
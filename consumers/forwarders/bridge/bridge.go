@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/pelletier/go-toml"
+)
+
+const (
+	// FormatRaw forwards the message payload as published.
+	FormatRaw = "raw"
+	// FormatSenML forwards the message payload as SenML JSON array.
+	FormatSenML = "senml"
+	// FormatProto forwards the whole message serialized as protobuf messaging.Message.
+	FormatProto = "proto"
+
+	// KeyNone leaves the record key empty.
+	KeyNone = "none"
+	// KeyChannel uses the message channel as the record key.
+	KeyChannel = "channel"
+	// KeySubtopic uses the message subtopic as the record key.
+	KeySubtopic = "subtopic"
+	// KeyPublisher uses the message publisher as the record key.
+	KeyPublisher = "publisher"
+
+	// ChannelPlaceholder is replaced by the message channel in topic templates.
+	ChannelPlaceholder = "{channel}"
+	// SubtopicPlaceholder is replaced by the message subtopic in topic templates.
+	SubtopicPlaceholder = "{subtopic}"
+
+	// DefaultTopic is used if neither the routes nor the configuration specify the topic.
+	DefaultTopic = "magistrala.{channel}"
+
+	defContentType = "application/senml+json"
+)
+
+var (
+	// ErrInvalidFormat indicates unsupported serialization format.
+	ErrInvalidFormat = errors.New("invalid bridge serialization format")
+
+	// ErrInvalidKey indicates unsupported record key selection.
+	ErrInvalidKey = errors.New("invalid bridge key selection")
+
+	errOpenConfFile  = errors.New("unable to open configuration file")
+	errParseConfFile = errors.New("unable to parse configuration file")
+)
+
+// Message represents a record published to the external broker.
+type Message struct {
+	Topic       string
+	Key         []byte
+	Payload     []byte
+	ContentType string
+	Headers     map[string]string
+}
+
+// Publisher specifies an API for publishing records to the external broker.
+//
+//go:generate mockery --name Publisher --output=./mocks --filename publisher.go --quiet --note "Copyright (c) Abstract Machines"
+type Publisher interface {
+	// Publish publishes the message to the external broker.
+	Publish(ctx context.Context, msg Message) error
+
+	// Close closes the connection to the external broker.
+	Close() error
+}
+
+// Route maps messages of a channel and subtopic to the topic.
+// Empty channel matches all channels. Subtopic uses the same wildcards
+// as message broker subjects: "*" matches one token and ">" matches one
+// or more tokens, while empty subtopic matches all messages.
+type Route struct {
+	Channel  string `toml:"channel"`
+	Subtopic string `toml:"subtopic"`
+	Topic    string `toml:"topic"`
+}
+
+// Config represents bridge configuration.
+type Config struct {
+	// Format is the serialization format of the published records.
+	Format string `toml:"format"`
+	// ContentType is the content type of SenML payloads.
+	ContentType string `toml:"content_type"`
+	// Key selects the message field used as the record key.
+	Key string `toml:"key"`
+	// Topic is the topic template used if no route matches the message.
+	Topic string `toml:"topic"`
+	// Routes are evaluated in order and the first matching route is used.
+	Routes []Route `toml:"routes"`
+}
+
+// Validate checks whether the configuration is valid.
+func (cfg Config) Validate() error {
+	switch cfg.Format {
+	case FormatRaw, FormatSenML, FormatProto:
+	default:
+		return ErrInvalidFormat
+	}
+	switch cfg.Key {
+	case KeyNone, KeyChannel, KeySubtopic, KeyPublisher:
+	default:
+		return ErrInvalidKey
+	}
+
+	return nil
+}
+
+// LoadConfig reads the bridge section of the consumer configuration file.
+// Values which are not present in the file are set to defaults.
+func LoadConfig(configPath string) (Config, error) {
+	file := struct {
+		Bridge Config `toml:"bridge"`
+	}{
+		Bridge: Config{
+			Format:      FormatRaw,
+			ContentType: defContentType,
+			Key:         KeyChannel,
+			Topic:       DefaultTopic,
+		},
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return file.Bridge, errors.Wrap(errOpenConfFile, err)
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return file.Bridge, errors.Wrap(errParseConfFile, err)
+	}
+
+	return file.Bridge, nil
+}
+
+// matches reports whether the subtopic matches the subtopic pattern.
+func matches(pattern, subtopic string) bool {
+	if pattern == "" || pattern == ">" {
+		return true
+	}
+	patterns := strings.Split(pattern, ".")
+	tokens := strings.Split(subtopic, ".")
+	for i, p := range patterns {
+		if p == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) {
+			return false
+		}
+		if p != "*" && p != tokens[i] {
+			return false
+		}
+	}
+
+	return len(patterns) == len(tokens)
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge contains the domain concept definitions needed to support
+// forwarding Magistrala messages to external message brokers such as Kafka
+// or AMQP exchanges.
+package bridge
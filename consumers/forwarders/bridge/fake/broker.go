@@ -0,0 +1,114 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake contains an in-process broker used for testing bridges
+// without running the external message broker.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/absmach/magistrala/consumers/forwarders/bridge"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrClosed indicates that the broker is closed.
+var ErrClosed = errors.New("fake broker is closed")
+
+var _ bridge.Publisher = (*Broker)(nil)
+
+// Broker is an in-memory broker which records published messages and
+// delivers them to the topic subscribers.
+type Broker struct {
+	mu       sync.Mutex
+	closed   bool
+	err      error
+	messages []bridge.Message
+	subs     map[string][]chan bridge.Message
+}
+
+// New returns an empty in-process broker.
+func New() *Broker {
+	return &Broker{
+		subs: make(map[string][]chan bridge.Message),
+	}
+}
+
+// Publish records the message and delivers it to the topic subscribers.
+func (b *Broker) Publish(ctx context.Context, msg bridge.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	if b.err != nil {
+		return b.err
+	}
+	b.messages = append(b.messages, msg)
+	for _, ch := range b.subs[msg.Topic] {
+		select {
+		case ch <- msg:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel receiving messages published to the topic
+// after the subscription. The channel buffer holds up to size messages.
+func (b *Broker) Subscribe(topic string, size int) <-chan bridge.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan bridge.Message, size)
+	b.subs[topic] = append(b.subs[topic], ch)
+
+	return ch
+}
+
+// Messages returns all the messages published to the topic. If the topic
+// is empty, all the published messages are returned.
+func (b *Broker) Messages(topic string) []bridge.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var ret []bridge.Message
+	for _, msg := range b.messages {
+		if topic == "" || msg.Topic == topic {
+			ret = append(ret, msg)
+		}
+	}
+
+	return ret
+}
+
+// Fail makes all the following Publish calls return the error.
+// Passing nil restores normal operation.
+func (b *Broker) Fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
+
+// Close closes the broker and all the subscription channels.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for _, subs := range b.subs {
+		for _, ch := range subs {
+			close(ch)
+		}
+	}
+
+	return nil
+}
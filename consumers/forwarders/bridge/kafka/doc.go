@@ -0,0 +1,5 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka contains the Kafka implementation of the bridge publisher.
+package kafka
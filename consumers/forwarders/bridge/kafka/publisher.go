@@ -0,0 +1,79 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/bridge"
+	"github.com/segmentio/kafka-go"
+)
+
+const contentTypeHeader = "content-type"
+
+// Config represents Kafka publisher configuration. Async publisher doesn't
+// wait for the batch to be written, so the throughput isn't limited by the
+// batch timeout, and the failed writes are logged.
+type Config struct {
+	// Brokers is a comma separated list of Kafka broker addresses.
+	Brokers      string        `env:"BROKERS"        envDefault:"localhost:9092"`
+	BatchSize    int           `env:"BATCH_SIZE"     envDefault:"100"`
+	BatchTimeout time.Duration `env:"BATCH_TIMEOUT"  envDefault:"10ms"`
+	RequiredAcks int           `env:"REQUIRED_ACKS"  envDefault:"-1"`
+	AutoCreate   bool          `env:"AUTO_CREATE"    envDefault:"true"`
+	Async        bool          `env:"ASYNC"          envDefault:"true"`
+}
+
+var _ bridge.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	writer *kafka.Writer
+}
+
+// NewPublisher returns Kafka bridge publisher. Records with the same key are
+// written to the same partition.
+func NewPublisher(cfg Config, logger *slog.Logger) bridge.Publisher {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(strings.Split(cfg.Brokers, ",")...),
+		Balancer:               &kafka.Hash{},
+		BatchSize:              cfg.BatchSize,
+		BatchTimeout:           cfg.BatchTimeout,
+		RequiredAcks:           kafka.RequiredAcks(cfg.RequiredAcks),
+		AllowAutoTopicCreation: cfg.AutoCreate,
+		Async:                  cfg.Async,
+	}
+	if cfg.Async {
+		writer.Completion = func(msgs []kafka.Message, err error) {
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to write %d messages to Kafka: %s", len(msgs), err))
+			}
+		}
+	}
+
+	return &publisher{writer: writer}
+}
+
+func (pub *publisher) Publish(ctx context.Context, msg bridge.Message) error {
+	headers := []kafka.Header{
+		{Key: contentTypeHeader, Value: []byte(msg.ContentType)},
+	}
+	for k, v := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	return pub.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   msg.Topic,
+		Key:     msg.Key,
+		Value:   msg.Payload,
+		Headers: headers,
+	})
+}
+
+func (pub *publisher) Close() error {
+	return pub.writer.Close()
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ChannelHeader contains the channel of the forwarded message.
+	ChannelHeader = "magistrala-channel"
+	// SubtopicHeader contains the subtopic of the forwarded message.
+	SubtopicHeader = "magistrala-subtopic"
+	// PublisherHeader contains the publisher of the forwarded message.
+	PublisherHeader = "magistrala-publisher"
+	// ProtocolHeader contains the protocol of the forwarded message.
+	ProtocolHeader = "magistrala-protocol"
+	// CreatedHeader contains the creation time of the forwarded message in Unix nanoseconds.
+	CreatedHeader = "magistrala-created"
+
+	rawContentType   = "application/octet-stream"
+	senmlContentType = "application/senml+json"
+	protoContentType = "application/x-protobuf"
+)
+
+var (
+	// ErrMessage indicates that the consumed message is not a raw Magistrala message.
+	ErrMessage = errors.New("bridge accepts only raw messages, use raw transformer format")
+
+	// ErrSerialize indicates an error serializing the message.
+	ErrSerialize = errors.New("failed to serialize message")
+
+	// ErrPublish indicates an error publishing the message to the external broker.
+	ErrPublish = errors.New("failed to publish message to external broker")
+)
+
+var _ consumers.BlockingConsumer = (*bridge)(nil)
+
+type bridge struct {
+	pub         Publisher
+	cfg         Config
+	transformer transformers.Transformer
+}
+
+// New returns a blocking consumer that forwards consumed messages to the
+// external broker using the given publisher. The consumer expects raw
+// messages, so it must be started with the raw transformer format.
+func New(pub Publisher, cfg Config) (consumers.BlockingConsumer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = DefaultTopic
+	}
+	if cfg.ContentType == "" {
+		cfg.ContentType = defContentType
+	}
+
+	return &bridge{
+		pub:         pub,
+		cfg:         cfg,
+		transformer: senml.New(cfg.ContentType),
+	}, nil
+}
+
+func (b *bridge) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	msg, ok := message.(*messaging.Message)
+	if !ok {
+		return ErrMessage
+	}
+
+	payload, contentType, err := b.serialize(msg)
+	if err != nil {
+		return errors.Wrap(ErrSerialize, err)
+	}
+
+	m := Message{
+		Topic:       b.topic(msg),
+		Key:         b.key(msg),
+		Payload:     payload,
+		ContentType: contentType,
+		Headers: map[string]string{
+			ChannelHeader:   msg.GetChannel(),
+			SubtopicHeader:  msg.GetSubtopic(),
+			PublisherHeader: msg.GetPublisher(),
+			ProtocolHeader:  msg.GetProtocol(),
+			CreatedHeader:   strconv.FormatInt(msg.GetCreated(), 10),
+		},
+	}
+	if err := b.pub.Publish(ctx, m); err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	return nil
+}
+
+func (b *bridge) topic(msg *messaging.Message) string {
+	tmpl := b.cfg.Topic
+	for _, r := range b.cfg.Routes {
+		if (r.Channel == "" || r.Channel == msg.GetChannel()) && matches(r.Subtopic, msg.GetSubtopic()) {
+			tmpl = r.Topic
+			break
+		}
+	}
+	topic := strings.NewReplacer(
+		ChannelPlaceholder, msg.GetChannel(),
+		SubtopicPlaceholder, msg.GetSubtopic(),
+	).Replace(tmpl)
+
+	// Drop separators left over by an empty subtopic.
+	return strings.Trim(strings.ReplaceAll(topic, "..", "."), ".")
+}
+
+func (b *bridge) key(msg *messaging.Message) []byte {
+	switch b.cfg.Key {
+	case KeyChannel:
+		return []byte(msg.GetChannel())
+	case KeySubtopic:
+		return []byte(msg.GetSubtopic())
+	case KeyPublisher:
+		return []byte(msg.GetPublisher())
+	default:
+		return nil
+	}
+}
+
+func (b *bridge) serialize(msg *messaging.Message) ([]byte, string, error) {
+	switch b.cfg.Format {
+	case FormatSenML:
+		msgs, err := b.transformer.Transform(msg)
+		if err != nil {
+			return nil, "", err
+		}
+		data, err := json.Marshal(msgs)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, senmlContentType, nil
+	case FormatProto:
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, protoContentType, nil
+	default:
+		return msg.GetPayload(), rawContentType, nil
+	}
+}
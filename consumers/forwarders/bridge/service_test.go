@@ -0,0 +1,226 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/forwarders/bridge"
+	"github.com/absmach/magistrala/consumers/forwarders/bridge/fake"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const payload = `[{"bn":"device:","n":"temperature","u":"C","v":21.5,"t":1700000000}]`
+
+var (
+	channelID   = testsutil.GenerateUUID(&testing.T{})
+	publisherID = testsutil.GenerateUUID(&testing.T{})
+)
+
+func newMessage(subtopic string) *messaging.Message {
+	return &messaging.Message{
+		Channel:   channelID,
+		Subtopic:  subtopic,
+		Publisher: publisherID,
+		Protocol:  "mqtt",
+		Payload:   []byte(payload),
+		Created:   1700000000000000000,
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	routes := []bridge.Route{
+		{Channel: channelID, Subtopic: "alerts.>", Topic: "alerts"},
+		{Subtopic: "temperature.*", Topic: "telemetry.{channel}.{subtopic}"},
+	}
+
+	cases := []struct {
+		desc        string
+		cfg         bridge.Config
+		msg         interface{}
+		topic       string
+		key         string
+		contentType string
+		err         error
+	}{
+		{
+			desc:        "forward raw message to default topic",
+			cfg:         bridge.Config{Format: bridge.FormatRaw, Key: bridge.KeyChannel},
+			msg:         newMessage(""),
+			topic:       fmt.Sprintf("magistrala.%s", channelID),
+			key:         channelID,
+			contentType: "application/octet-stream",
+		},
+		{
+			desc:        "forward raw message using channel route",
+			cfg:         bridge.Config{Format: bridge.FormatRaw, Key: bridge.KeyPublisher, Routes: routes},
+			msg:         newMessage("alerts.fire.room1"),
+			topic:       "alerts",
+			key:         publisherID,
+			contentType: "application/octet-stream",
+		},
+		{
+			desc:        "forward SenML message using subtopic route",
+			cfg:         bridge.Config{Format: bridge.FormatSenML, Key: bridge.KeySubtopic, Routes: routes},
+			msg:         newMessage("temperature.room1"),
+			topic:       fmt.Sprintf("telemetry.%s.temperature.room1", channelID),
+			key:         "temperature.room1",
+			contentType: "application/senml+json",
+		},
+		{
+			desc:        "forward protobuf message without key",
+			cfg:         bridge.Config{Format: bridge.FormatProto, Key: bridge.KeyNone, Topic: "events.{subtopic}", Routes: routes},
+			msg:         newMessage("humidity"),
+			topic:       "events.humidity",
+			key:         "",
+			contentType: "application/x-protobuf",
+		},
+		{
+			desc: "forward invalid SenML message",
+			cfg:  bridge.Config{Format: bridge.FormatSenML, Key: bridge.KeyChannel},
+			msg: &messaging.Message{
+				Channel: channelID,
+				Payload: []byte("invalid"),
+			},
+			err: bridge.ErrSerialize,
+		},
+		{
+			desc: "forward transformed message",
+			cfg:  bridge.Config{Format: bridge.FormatRaw, Key: bridge.KeyChannel},
+			msg:  []senml.Message{{Channel: channelID}},
+			err:  bridge.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			broker := fake.New()
+			defer broker.Close()
+
+			b, err := bridge.New(broker, tc.cfg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			err = b.ConsumeBlocking(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				assert.Empty(t, broker.Messages(""), fmt.Sprintf("%s: expected no published messages", tc.desc))
+				return
+			}
+
+			msgs := broker.Messages(tc.topic)
+			require.Len(t, msgs, 1, fmt.Sprintf("%s: expected 1 message on topic %s", tc.desc, tc.topic))
+			got := msgs[0]
+			assert.Equal(t, tc.key, string(got.Key), fmt.Sprintf("%s: expected key %s got %s", tc.desc, tc.key, got.Key))
+			assert.Equal(t, tc.contentType, got.ContentType, fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, got.ContentType))
+			assert.Equal(t, channelID, got.Headers[bridge.ChannelHeader], fmt.Sprintf("%s: expected channel header %s", tc.desc, channelID))
+
+			msg := tc.msg.(*messaging.Message)
+			switch tc.cfg.Format {
+			case bridge.FormatRaw:
+				assert.Equal(t, msg.GetPayload(), got.Payload, fmt.Sprintf("%s: expected raw payload", tc.desc))
+			case bridge.FormatSenML:
+				var recs []senml.Message
+				err := json.Unmarshal(got.Payload, &recs)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				require.Len(t, recs, 1, fmt.Sprintf("%s: expected 1 SenML record", tc.desc))
+				assert.Equal(t, "device:temperature", recs[0].Name, fmt.Sprintf("%s: unexpected SenML name %s", tc.desc, recs[0].Name))
+			case bridge.FormatProto:
+				var decoded messaging.Message
+				err := proto.Unmarshal(got.Payload, &decoded)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.True(t, proto.Equal(msg, &decoded), fmt.Sprintf("%s: expected %v got %v", tc.desc, msg, &decoded))
+			}
+		})
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	broker := fake.New()
+	defer broker.Close()
+
+	b, err := bridge.New(broker, bridge.Config{Format: bridge.FormatRaw, Key: bridge.KeyChannel})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	sub := broker.Subscribe(fmt.Sprintf("magistrala.%s", channelID), 1)
+	err = b.ConsumeBlocking(context.Background(), newMessage(""))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	msg := <-sub
+	assert.Equal(t, []byte(payload), msg.Payload, "expected subscriber to receive the payload")
+
+	broker.Fail(fake.ErrClosed)
+	err = b.ConsumeBlocking(context.Background(), newMessage(""))
+	assert.True(t, errors.Contains(err, bridge.ErrPublish), fmt.Sprintf("expected %s got %s\n", bridge.ErrPublish, err))
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  bridge.Config
+		err  error
+	}{
+		{
+			desc: "create with valid config",
+			cfg:  bridge.Config{Format: bridge.FormatProto, Key: bridge.KeyNone},
+			err:  nil,
+		},
+		{
+			desc: "create with invalid format",
+			cfg:  bridge.Config{Format: "xml", Key: bridge.KeyNone},
+			err:  bridge.ErrInvalidFormat,
+		},
+		{
+			desc: "create with invalid key",
+			cfg:  bridge.Config{Format: bridge.FormatRaw, Key: "protocol"},
+			err:  bridge.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bridge.New(fake.New(), tc.cfg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := `
+[bridge]
+format = "senml"
+key = "publisher"
+
+[[bridge.routes]]
+channel = "` + channelID + `"
+subtopic = "alerts.>"
+topic = "alerts"
+`
+	err := os.WriteFile(path, []byte(data), 0o600)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cfg, err := bridge.LoadConfig(path)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := bridge.Config{
+		Format:      bridge.FormatSenML,
+		ContentType: "application/senml+json",
+		Key:         bridge.KeyPublisher,
+		Topic:       bridge.DefaultTopic,
+		Routes:      []bridge.Route{{Channel: channelID, Subtopic: "alerts.>", Topic: "alerts"}},
+	}
+	assert.Equal(t, expected, cfg, fmt.Sprintf("expected %v got %v", expected, cfg))
+
+	_, err = bridge.LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	assert.NotNil(t, err, "expected error loading missing config")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	// ErrForbiddenAddress indicates that the webhook URL points to an
+	// internal address that is not explicitly allowed.
+	ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+	errInvalidNetwork = errors.New("invalid allowed network")
+)
+
+// AddressFilter decides which addresses webhooks may be delivered to.
+// Loopback, private, link-local, unspecified and multicast addresses are
+// rejected unless they belong to one of the explicitly allowed networks.
+type AddressFilter struct {
+	allowed []*net.IPNet
+}
+
+// NewAddressFilter returns an address filter which additionally allows the
+// given networks in CIDR notation.
+func NewAddressFilter(allowed []string) (AddressFilter, error) {
+	var f AddressFilter
+	for _, cidr := range allowed {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return AddressFilter{}, errors.Wrap(errInvalidNetwork, err)
+		}
+		f.allowed = append(f.allowed, network)
+	}
+
+	return f, nil
+}
+
+// AllowIP reports whether a webhook may be delivered to the IP address.
+func (f AddressFilter) AllowIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range f.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
+
+// CheckURL rejects webhook URLs whose host is a forbidden IP literal or a
+// localhost name. Host names are resolved only when dialing, so the sender
+// has to check the resolved addresses as well.
+func (f AddressFilter) CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(ErrForbiddenAddress, err)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		host = "127.0.0.1"
+	}
+	if ip := net.ParseIP(host); ip != nil && !f.AllowIP(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createWebhookEndpoint(svc webhook.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createWebhookReq)
+		if err := req.validate(); err != nil {
+			return webhookRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		wh := webhook.Webhook{
+			Name:      req.Name,
+			ChannelID: req.ChannelID,
+			Subtopic:  req.Subtopic,
+			URL:       req.URL,
+			Secret:    req.Secret,
+			Headers:   req.Headers,
+			Status:    mgclients.EnabledStatus,
+		}
+		saved, err := svc.CreateWebhook(ctx, req.token, wh)
+		if err != nil {
+			return webhookRes{}, err
+		}
+
+		return webhookRes{Webhook: hideSecret(saved), created: true}, nil
+	}
+}
+
+func viewWebhookEndpoint(svc webhook.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return webhookRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		wh, err := svc.ViewWebhook(ctx, req.token, req.id)
+		if err != nil {
+			return webhookRes{}, err
+		}
+
+		return webhookRes{Webhook: hideSecret(wh)}, nil
+	}
+}
+
+func listWebhooksEndpoint(svc webhook.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listWebhooksReq)
+		if err := req.validate(); err != nil {
+			return listWebhooksRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		pm := webhook.PageMetadata{
+			ChannelID: req.channelID,
+			Status:    req.status,
+			Offset:    req.offset,
+			Limit:     int(req.limit),
+		}
+		page, err := svc.ListWebhooks(ctx, req.token, pm)
+		if err != nil {
+			return listWebhooksRes{}, err
+		}
+		res := listWebhooksRes{
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Total:    page.Total,
+			Webhooks: []webhook.Webhook{},
+		}
+		for _, wh := range page.Webhooks {
+			res.Webhooks = append(res.Webhooks, hideSecret(wh))
+		}
+
+		return res, nil
+	}
+}
+
+func updateWebhookEndpoint(svc webhook.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateWebhookReq)
+		if err := req.validate(); err != nil {
+			return webhookRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		status, err := mgclients.ToStatus(req.Status)
+		if err != nil {
+			return webhookRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		wh := webhook.Webhook{
+			ID:       req.id,
+			Name:     req.Name,
+			Subtopic: req.Subtopic,
+			URL:      req.URL,
+			Secret:   req.Secret,
+			Headers:  req.Headers,
+			Status:   status,
+		}
+		updated, err := svc.UpdateWebhook(ctx, req.token, wh)
+		if err != nil {
+			return webhookRes{}, err
+		}
+
+		return webhookRes{Webhook: hideSecret(updated)}, nil
+	}
+}
+
+func removeWebhookEndpoint(svc webhook.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.RemoveWebhook(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeWebhookRes{}, nil
+	}
+}
+
+// hideSecret removes the signing secret so it is never returned by the API.
+func hideSecret(wh webhook.Webhook) webhook.Webhook {
+	wh.Secret = ""
+	return wh
+}
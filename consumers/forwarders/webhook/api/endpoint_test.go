@@ -0,0 +1,359 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	httpapi "github.com/absmach/magistrala/consumers/forwarders/webhook/api"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	token        = "token"
+	invalidToken = "invalid"
+	validURL     = "https://example.com/hook"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newServer() (*httptest.Server, *mocks.Service) {
+	logger := mglog.NewMock()
+	svc := new(mocks.Service)
+	mux := httpapi.MakeHandler(svc, logger, "webhook-forwarder", instanceID)
+	return httptest.NewServer(mux), svc
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	channelID := testsutil.GenerateUUID(t)
+	wh := webhook.Webhook{
+		ChannelID: channelID,
+		URL:       validURL,
+		Secret:    "secret",
+		Status:    mgclients.EnabledStatus,
+	}
+	saved := wh
+	saved.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+		svcErr      error
+	}{
+		{
+			desc:        "create webhook successfully",
+			req:         toJSON(map[string]string{"channel_id": channelID, "url": validURL, "secret": "secret"}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/webhooks/%s", saved.ID),
+		},
+		{
+			desc:        "create webhook with invalid token",
+			req:         toJSON(map[string]string{"channel_id": channelID, "url": validURL, "secret": "secret"}),
+			contentType: contentType,
+			token:       invalidToken,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "create webhook with empty token",
+			req:         toJSON(map[string]string{"channel_id": channelID, "url": validURL, "secret": "secret"}),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create webhook without channel",
+			req:         toJSON(map[string]string{"url": validURL}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with invalid URL",
+			req:         toJSON(map[string]string{"channel_id": channelID, "url": "ftp://example.com"}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with malformed body",
+			req:         "}",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook without content type",
+			req:         toJSON(map[string]string{"channel_id": channelID, "url": validURL, "secret": "secret"}),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("CreateWebhook", mock.Anything, tc.token, wh).Return(saved, tc.svcErr)
+			req := testRequest{
+				client:      ss.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/webhooks", ss.URL),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			if tc.status == http.StatusCreated {
+				var body webhook.Webhook
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Empty(t, body.Secret, fmt.Sprintf("%s: expected secret to be hidden", tc.desc))
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	channelID := testsutil.GenerateUUID(t)
+	page := webhook.Page{
+		PageMetadata: webhook.PageMetadata{Limit: 10},
+		Total:        1,
+		Webhooks:     []webhook.Webhook{{ID: testsutil.GenerateUUID(t), ChannelID: channelID, URL: validURL}},
+	}
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		pm     webhook.PageMetadata
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list webhooks successfully",
+			query:  "",
+			token:  token,
+			pm:     webhook.PageMetadata{Limit: 10, Status: mgclients.EnabledStatus},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list channel webhooks with all statuses",
+			query:  fmt.Sprintf("?channel_id=%s&status=all&offset=1&limit=5", channelID),
+			token:  token,
+			pm:     webhook.PageMetadata{ChannelID: channelID, Offset: 1, Limit: 5, Status: mgclients.AllStatus},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list webhooks with invalid limit",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list webhooks with invalid status",
+			query:  "?status=invalid",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list webhooks with empty token",
+			query:  "",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list webhooks without permission",
+			query:  "",
+			token:  token,
+			pm:     webhook.PageMetadata{Limit: 10, Status: mgclients.EnabledStatus},
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListWebhooks", mock.Anything, tc.token, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ss.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/webhooks%s", ss.URL, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	id := testsutil.GenerateUUID(t)
+	wh := webhook.Webhook{ID: id, URL: validURL, Status: mgclients.DisabledStatus}
+
+	cases := []struct {
+		desc   string
+		req    string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "update webhook successfully",
+			req:    toJSON(map[string]string{"url": validURL, "status": "disabled"}),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update webhook with invalid status",
+			req:    toJSON(map[string]string{"url": validURL, "status": "deleted"}),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "update webhook with invalid URL",
+			req:    toJSON(map[string]string{"url": "invalid", "status": "disabled"}),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "update non-existing webhook",
+			req:    toJSON(map[string]string{"url": validURL, "status": "disabled"}),
+			token:  token,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("UpdateWebhook", mock.Anything, tc.token, wh).Return(wh, tc.svcErr)
+			req := testRequest{
+				client:      ss.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/webhooks/%s", ss.URL, id),
+				contentType: contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "remove webhook successfully",
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove webhook with invalid token",
+			token:  invalidToken,
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "remove non-existing webhook",
+			token:  token,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RemoveWebhook", mock.Anything, tc.token, id).Return(tc.svcErr)
+			req := testRequest{
+				client: ss.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/webhooks/%s", ss.URL, id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
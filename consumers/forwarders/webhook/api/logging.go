@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+)
+
+var _ webhook.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    webhook.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc webhook.Service, logger *slog.Logger) webhook.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// CreateWebhook logs the create_webhook request. It logs webhook ID and channel ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateWebhook(ctx context.Context, token string, wh webhook.Webhook) (saved webhook.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("webhook",
+				slog.String("id", saved.ID),
+				slog.String("channel_id", wh.ChannelID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Create webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateWebhook(ctx, token, wh)
+}
+
+// ViewWebhook logs the view_webhook request. It logs webhook ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewWebhook(ctx context.Context, token, id string) (wh webhook.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View webhook failed", args...)
+			return
+		}
+		lm.logger.Info("View webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewWebhook(ctx, token, id)
+}
+
+// ListWebhooks logs the list_webhooks request. It logs page metadata and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListWebhooks(ctx context.Context, token string, pm webhook.PageMetadata) (page webhook.Page, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.Int("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhooks failed", args...)
+			return
+		}
+		lm.logger.Info("List webhooks completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListWebhooks(ctx, token, pm)
+}
+
+// UpdateWebhook logs the update_webhook request. It logs webhook ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateWebhook(ctx context.Context, token string, wh webhook.Webhook) (updated webhook.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("webhook",
+				slog.String("id", wh.ID),
+				slog.String("status", wh.Status.String()),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Update webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateWebhook(ctx, token, wh)
+}
+
+// RemoveWebhook logs the remove_webhook request. It logs webhook ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveWebhook(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Remove webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveWebhook(ctx, token, id)
+}
+
+// ConsumeBlocking logs the consume_blocking request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Blocking consumer failed to forward messages successfully", args...)
+			return
+		}
+		lm.logger.Info("Blocking consumer forwarded messages successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msg)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ webhook.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     webhook.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc webhook.Service, counter metrics.Counter, latency metrics.Histogram) webhook.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// CreateWebhook instruments CreateWebhook method with metrics.
+func (ms *metricsMiddleware) CreateWebhook(ctx context.Context, token string, wh webhook.Webhook) (webhook.Webhook, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_webhook").Add(1)
+		ms.latency.With("method", "create_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateWebhook(ctx, token, wh)
+}
+
+// ViewWebhook instruments ViewWebhook method with metrics.
+func (ms *metricsMiddleware) ViewWebhook(ctx context.Context, token, id string) (webhook.Webhook, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_webhook").Add(1)
+		ms.latency.With("method", "view_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewWebhook(ctx, token, id)
+}
+
+// ListWebhooks instruments ListWebhooks method with metrics.
+func (ms *metricsMiddleware) ListWebhooks(ctx context.Context, token string, pm webhook.PageMetadata) (webhook.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_webhooks").Add(1)
+		ms.latency.With("method", "list_webhooks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListWebhooks(ctx, token, pm)
+}
+
+// UpdateWebhook instruments UpdateWebhook method with metrics.
+func (ms *metricsMiddleware) UpdateWebhook(ctx context.Context, token string, wh webhook.Webhook) (webhook.Webhook, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_webhook").Add(1)
+		ms.latency.With("method", "update_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateWebhook(ctx, token, wh)
+}
+
+// RemoveWebhook instruments RemoveWebhook method with metrics.
+func (ms *metricsMiddleware) RemoveWebhook(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_webhook").Add(1)
+		ms.latency.With("method", "remove_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveWebhook(ctx, token, id)
+}
+
+// ConsumeBlocking instruments ConsumeBlocking method with metrics.
+func (ms *metricsMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "consume").Add(1)
+		ms.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConsumeBlocking(ctx, msg)
+}
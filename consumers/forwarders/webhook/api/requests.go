@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/url"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const maxLimitSize = 100
+
+type createWebhookReq struct {
+	token     string
+	Name      string            `json:"name,omitempty"`
+	ChannelID string            `json:"channel_id"`
+	Subtopic  string            `json:"subtopic,omitempty"`
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+func (req createWebhookReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return validateURL(req.URL)
+}
+
+type updateWebhookReq struct {
+	token    string
+	id       string
+	Name     string            `json:"name,omitempty"`
+	Subtopic string            `json:"subtopic,omitempty"`
+	URL      string            `json:"url"`
+	Secret   string            `json:"secret,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Status   string            `json:"status,omitempty"`
+}
+
+func (req updateWebhookReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	status, err := mgclients.ToStatus(req.Status)
+	if err != nil {
+		return err
+	}
+	if status != mgclients.EnabledStatus && status != mgclients.DisabledStatus {
+		return svcerr.ErrInvalidStatus
+	}
+
+	return validateURL(req.URL)
+}
+
+type webhookReq struct {
+	token string
+	id    string
+}
+
+func (req webhookReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listWebhooksReq struct {
+	token     string
+	channelID string
+	status    mgclients.Status
+	offset    uint64
+	limit     uint64
+}
+
+func (req listWebhooksReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit > maxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+func validateURL(u string) error {
+	parsed, err := url.ParseRequestURI(u)
+	if err != nil || parsed.Host == "" {
+		return apiutil.ErrInvalidURL
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return apiutil.ErrInvalidURL
+	}
+
+	return nil
+}
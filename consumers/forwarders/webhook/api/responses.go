@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+)
+
+var (
+	_ magistrala.Response = (*webhookRes)(nil)
+	_ magistrala.Response = (*listWebhooksRes)(nil)
+	_ magistrala.Response = (*removeWebhookRes)(nil)
+)
+
+type webhookRes struct {
+	webhook.Webhook
+	created bool
+}
+
+func (res webhookRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res webhookRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/webhooks/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res webhookRes) Empty() bool {
+	return false
+}
+
+type listWebhooksRes struct {
+	Offset   uint64            `json:"offset"`
+	Limit    int               `json:"limit"`
+	Total    uint64            `json:"total"`
+	Webhooks []webhook.Webhook `json:"webhooks"`
+}
+
+func (res listWebhooksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listWebhooksRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listWebhooksRes) Empty() bool {
+	return false
+}
+
+type removeWebhookRes struct{}
+
+func (res removeWebhookRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeWebhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeWebhookRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const channelKey = "channel_id"
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc webhook.Service, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createWebhookEndpoint(svc),
+			decodeCreate,
+			api.EncodeResponse,
+			opts...,
+		), "create_webhook").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listWebhooksEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_webhooks").ServeHTTP)
+
+		r.Get("/{webhookID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewWebhookEndpoint(svc),
+			decodeWebhook,
+			api.EncodeResponse,
+			opts...,
+		), "view_webhook").ServeHTTP)
+
+		r.Put("/{webhookID}", otelhttp.NewHandler(kithttp.NewServer(
+			updateWebhookEndpoint(svc),
+			decodeUpdate,
+			api.EncodeResponse,
+			opts...,
+		), "update_webhook").ServeHTTP)
+
+		r.Delete("/{webhookID}", otelhttp.NewHandler(kithttp.NewServer(
+			removeWebhookEndpoint(svc),
+			decodeWebhook,
+			api.EncodeResponse,
+			opts...,
+		), "remove_webhook").ServeHTTP)
+	})
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := createWebhookReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateWebhookReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "webhookID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeWebhook(_ context.Context, r *http.Request) (interface{}, error) {
+	req := webhookReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "webhookID"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	channelID, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefStatus)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := mgclients.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listWebhooksReq{
+		token:     apiutil.ExtractBearerToken(r),
+		channelID: channelID,
+		status:    status,
+		offset:    offset,
+		limit:     limit,
+	}
+
+	return req, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var _ Sender = (*batcher)(nil)
+
+type batch struct {
+	wh      Webhook
+	msgs    []interface{}
+	created time.Time
+}
+
+type batcher struct {
+	sender   Sender
+	size     int
+	interval time.Duration
+	logger   *slog.Logger
+	mu       sync.Mutex
+	batches  map[string]*batch
+}
+
+// NewBatcher returns a Sender that groups messages per webhook and delivers
+// them once the batch reaches the given size or when the batch is older than
+// the given interval. Batches flushed by the interval are delivered in the
+// background and delivery errors are logged. Batching is disabled if size is
+// less than or equal to 1. Remaining batches are flushed when ctx is done.
+func NewBatcher(ctx context.Context, sender Sender, size int, interval time.Duration, logger *slog.Logger) Sender {
+	if size <= 1 {
+		return sender
+	}
+	b := &batcher{
+		sender:   sender,
+		size:     size,
+		interval: interval,
+		logger:   logger,
+		batches:  make(map[string]*batch),
+	}
+	if interval > 0 {
+		go b.run(ctx)
+	}
+
+	return b
+}
+
+func (b *batcher) Send(ctx context.Context, wh Webhook, msgs []interface{}) error {
+	b.mu.Lock()
+	bt, ok := b.batches[wh.ID]
+	if !ok {
+		bt = &batch{created: time.Now()}
+		b.batches[wh.ID] = bt
+	}
+	// Always keep the latest webhook version so updates apply to pending messages.
+	bt.wh = wh
+	bt.msgs = append(bt.msgs, msgs...)
+	if len(bt.msgs) < b.size {
+		b.mu.Unlock()
+		return nil
+	}
+	delete(b.batches, wh.ID)
+	b.mu.Unlock()
+
+	return b.sender.Send(ctx, bt.wh, bt.msgs)
+}
+
+func (b *batcher) run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Parent context is canceled, so use a fresh one for the final flush.
+			b.flush(context.Background(), true)
+			return
+		case <-ticker.C:
+			b.flush(ctx, false)
+		}
+	}
+}
+
+func (b *batcher) flush(ctx context.Context, all bool) {
+	b.mu.Lock()
+	var expired []*batch
+	for id, bt := range b.batches {
+		if all || time.Since(bt.created) >= b.interval {
+			expired = append(expired, bt)
+			delete(b.batches, id)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, bt := range expired {
+		if err := b.sender.Send(ctx, bt.wh, bt.msgs); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to deliver batch of %d messages to webhook %s: %s", len(bt.msgs), bt.wh.ID, err))
+		}
+	}
+}
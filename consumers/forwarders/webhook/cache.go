@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+var _ Cache = (*cache)(nil)
+
+// cache keeps the webhooks of the least recently consumed channels
+// in the process memory.
+type cache struct {
+	mu       sync.Mutex
+	size     int
+	duration time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type cacheEntry struct {
+	channelID string
+	webhooks  []Webhook
+	expiresAt time.Time
+}
+
+// NewCache returns the cache holding the webhooks of up to the size channels
+// for the duration. The cache with the size of 0 doesn't store the webhooks.
+func NewCache(size int, duration time.Duration) Cache {
+	return &cache{
+		size:     size,
+		duration: duration,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *cache) Save(_ context.Context, channelID string, whs []Webhook) error {
+	if c.size <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.duration)
+	if el, ok := c.entries[channelID]; ok {
+		e := el.Value.(*cacheEntry)
+		e.webhooks = whs
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return nil
+	}
+	c.entries[channelID] = c.order.PushFront(&cacheEntry{channelID: channelID, webhooks: whs, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).channelID)
+	}
+
+	return nil
+}
+
+func (c *cache) Retrieve(_ context.Context, channelID string) ([]Webhook, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[channelID]
+	if !ok {
+		return nil, repoerr.ErrNotFound
+	}
+	e := el.Value.(*cacheEntry)
+	if time.Now().After(e.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, channelID)
+		return nil, repoerr.ErrNotFound
+	}
+	c.order.MoveToFront(el)
+
+	return e.webhooks, nil
+}
+
+func (c *cache) Remove(_ context.Context, channelID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[channelID]; ok {
+		c.order.Remove(el)
+		delete(c.entries, channelID)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState struct {
+	failures uint32
+	openedAt time.Time
+}
+
+// breaker is a per-webhook circuit breaker. After threshold consecutive
+// failures the circuit opens and deliveries are rejected until timeout
+// elapses. The first delivery after the timeout is let through and either
+// closes the circuit on success or reopens it on failure.
+type breaker struct {
+	threshold uint32
+	timeout   time.Duration
+	mu        sync.Mutex
+	states    map[string]*breakerState
+}
+
+func newBreaker(threshold uint32, timeout time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		timeout:   timeout,
+		states:    make(map[string]*breakerState),
+	}
+}
+
+func (b *breaker) allow(id string) bool {
+	if b.threshold == 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.states[id]
+	if !ok || st.failures < b.threshold {
+		return true
+	}
+	if time.Since(st.openedAt) < b.timeout {
+		return false
+	}
+	// Half-open: let one request through and restart the open period.
+	st.openedAt = time.Now()
+
+	return true
+}
+
+func (b *breaker) success(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.states, id)
+}
+
+func (b *breaker) failure(id string) {
+	if b.threshold == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.states[id]
+	if !ok {
+		st = &breakerState{}
+		b.states[id] = st
+	}
+	st.failures++
+	if st.failures >= b.threshold {
+		st.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package http contains the webhook Sender implementation that delivers
+// messages to the registered endpoints over HTTP(S).
+package http
@@ -0,0 +1,190 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/cenkalti/backoff/v4"
+)
+
+const contentType = "application/json"
+
+var (
+	// ErrCircuitOpen indicates that deliveries to the webhook are suspended
+	// after too many consecutive failures.
+	ErrCircuitOpen = errors.New("webhook circuit breaker is open")
+
+	// ErrUnexpectedStatus indicates that the webhook endpoint responded with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("webhook responded with unexpected status")
+
+	errLoadCerts = errors.New("failed to load certificates")
+)
+
+// Config represents webhook HTTP sender configuration.
+type Config struct {
+	Timeout          time.Duration `env:"TIMEOUT"            envDefault:"10s"`
+	MaxRetries       uint64        `env:"MAX_RETRIES"        envDefault:"3"`
+	RetryInterval    time.Duration `env:"RETRY_INTERVAL"     envDefault:"500ms"`
+	RetryMaxInterval time.Duration `env:"RETRY_MAX_INTERVAL" envDefault:"10s"`
+	BreakerThreshold uint32        `env:"BREAKER_THRESHOLD"  envDefault:"5"`
+	BreakerTimeout   time.Duration `env:"BREAKER_TIMEOUT"    envDefault:"1m"`
+	ClientCert       string        `env:"CLIENT_CERT"        envDefault:""`
+	ClientKey        string        `env:"CLIENT_KEY"         envDefault:""`
+	ServerCAFile     string        `env:"SERVER_CA_CERTS"    envDefault:""`
+	AllowedNetworks  []string      `env:"ALLOWED_NETWORKS"   envDefault:""   envSeparator:","`
+}
+
+var _ webhook.Sender = (*sender)(nil)
+
+type sender struct {
+	client  *http.Client
+	cfg     Config
+	breaker *breaker
+}
+
+// New instantiates the HTTP webhook sender. If the client certificate and
+// key are provided, they are presented to the webhook endpoints (mTLS).
+// Connections to internal addresses outside of the allowed networks are
+// refused after name resolution, so DNS cannot be used to bypass the URL
+// validation.
+func New(cfg Config) (webhook.Sender, error) {
+	filter, err := webhook.NewAddressFilter(cfg.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig, err := loadTLSConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(errLoadCerts, err)
+	}
+	transport.TLSClientConfig = tlsConfig
+	// A proxy would dial the webhook on our behalf and skip the address check.
+	transport.Proxy = nil
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !filter.AllowIP(net.ParseIP(host)) {
+				return backoff.Permanent(webhook.ErrForbiddenAddress)
+			}
+			return nil
+		},
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &sender{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+		},
+		cfg:     cfg,
+		breaker: newBreaker(cfg.BreakerThreshold, cfg.BreakerTimeout),
+	}, nil
+}
+
+func (s *sender) Send(ctx context.Context, wh webhook.Webhook, msgs []interface{}) error {
+	if !s.breaker.allow(wh.ID) {
+		return ErrCircuitOpen
+	}
+
+	payload, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = s.cfg.RetryInterval
+	bo.MaxInterval = s.cfg.RetryMaxInterval
+	// Retries are bounded by the number of attempts, not by the elapsed time.
+	bo.MaxElapsedTime = 0
+
+	op := func() error {
+		return s.post(ctx, wh, payload)
+	}
+	if err := backoff.Retry(op, backoff.WithContext(backoff.WithMaxRetries(bo, s.cfg.MaxRetries), ctx)); err != nil {
+		s.breaker.failure(wh.ID)
+		return err
+	}
+	s.breaker.success(wh.ID)
+
+	return nil
+}
+
+func (s *sender) post(ctx context.Context, wh webhook.Webhook, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(payload))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	for k, v := range wh.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(webhook.WebhookHeader, wh.ID)
+	if wh.Secret != "" {
+		ts := time.Now().Unix()
+		req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(ts, 10))
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(wh.Secret, ts, payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= http.StatusInternalServerError:
+		return errors.Wrap(ErrUnexpectedStatus, fmt.Errorf("status %d", resp.StatusCode))
+	default:
+		// Client errors will not be fixed by retrying the same request.
+		return backoff.Permanent(errors.Wrap(ErrUnexpectedStatus, fmt.Errorf("status %d", resp.StatusCode)))
+	}
+}
+
+func loadTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if cfg.ServerCAFile != "" {
+		rootCA, err := os.ReadFile(cfg.ServerCAFile)
+		if err != nil {
+			return nil, err
+		}
+		capool := x509.NewCertPool()
+		if !capool.AppendCertsFromPEM(rootCA) {
+			return nil, fmt.Errorf("failed to append root ca to tls.Config")
+		}
+		tlsConfig.RootCAs = capool
+	}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
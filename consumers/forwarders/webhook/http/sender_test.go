@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	whhttp "github.com/absmach/magistrala/consumers/forwarders/webhook/http"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+var cfg = whhttp.Config{
+	Timeout:          time.Second,
+	MaxRetries:       2,
+	RetryInterval:    time.Millisecond,
+	RetryMaxInterval: time.Millisecond,
+	BreakerThreshold: 2,
+	BreakerTimeout:   time.Minute,
+	AllowedNetworks:  []string{"127.0.0.0/8", "::1/128"},
+}
+
+func TestSend(t *testing.T) {
+	cases := []struct {
+		desc     string
+		statuses []int
+		attempts int32
+		err      error
+	}{
+		{
+			desc:     "send successfully",
+			statuses: []int{http.StatusOK},
+			attempts: 1,
+			err:      nil,
+		},
+		{
+			desc:     "send after retrying server error",
+			statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusAccepted},
+			attempts: 3,
+			err:      nil,
+		},
+		{
+			desc:     "send with client error",
+			statuses: []int{http.StatusBadRequest},
+			attempts: 1,
+			err:      whhttp.ErrUnexpectedStatus,
+		},
+		{
+			desc:     "send with exhausted retries",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			attempts: 3,
+			err:      whhttp.ErrUnexpectedStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var attempts int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				body, err := io.ReadAll(r.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				ts, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, webhook.Sign(secret, ts, body), r.Header.Get(webhook.SignatureHeader), fmt.Sprintf("%s: invalid signature", tc.desc))
+				assert.Equal(t, "value", r.Header.Get("X-Custom"), fmt.Sprintf("%s: missing custom header", tc.desc))
+				w.WriteHeader(tc.statuses[n-1])
+			}))
+			defer ts.Close()
+
+			sender, err := whhttp.New(cfg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			wh := webhook.Webhook{ID: "id", URL: ts.URL, Secret: secret, Headers: map[string]string{"X-Custom": "value"}}
+			err = sender.Send(context.Background(), wh, []interface{}{map[string]string{"name": "temperature"}})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.attempts, atomic.LoadInt32(&attempts), fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.attempts, attempts))
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	sender, err := whhttp.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	wh := webhook.Webhook{ID: "id", URL: ts.URL}
+	for i := 0; i < int(cfg.BreakerThreshold); i++ {
+		err := sender.Send(context.Background(), wh, []interface{}{})
+		assert.True(t, errors.Contains(err, whhttp.ErrUnexpectedStatus), fmt.Sprintf("expected %s got %s\n", whhttp.ErrUnexpectedStatus, err))
+	}
+	err = sender.Send(context.Background(), wh, []interface{}{})
+	assert.True(t, errors.Contains(err, whhttp.ErrCircuitOpen), fmt.Sprintf("expected %s got %s\n", whhttp.ErrCircuitOpen, err))
+	assert.Equal(t, int32(cfg.BreakerThreshold), atomic.LoadInt32(&attempts), "expected no requests while circuit is open")
+}
+
+func TestSendToForbiddenAddress(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	conf := cfg
+	conf.AllowedNetworks = nil
+	sender, err := whhttp.New(conf)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	// The host name is resolved to the loopback address when dialing.
+	wh := webhook.Webhook{ID: "id", URL: strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)}
+	err = sender.Send(context.Background(), wh, []interface{}{})
+	assert.True(t, errors.Contains(err, webhook.ErrForbiddenAddress), fmt.Sprintf("expected %s got %s\n", webhook.ErrForbiddenAddress, err))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&attempts), "expected no requests to forbidden address")
+}
+
+func TestNewWithInvalidNetwork(t *testing.T) {
+	conf := cfg
+	conf.AllowedNetworks = []string{"invalid"}
+	_, err := whhttp.New(conf)
+	assert.NotNil(t, err, "expected error with invalid allowed network")
+}
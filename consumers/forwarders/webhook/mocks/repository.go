@@ -0,0 +1,161 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	webhook "github.com/absmach/magistrala/consumers/forwarders/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Remove provides a mock function with given fields: ctx, id
+func (_m *Repository) Remove(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Retrieve provides a mock function with given fields: ctx, id
+func (_m *Repository) Retrieve(ctx context.Context, id string) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (webhook.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) webhook.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveAll provides a mock function with given fields: ctx, pm
+func (_m *Repository) RetrieveAll(ctx context.Context, pm webhook.PageMetadata) (webhook.Page, error) {
+	ret := _m.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAll")
+	}
+
+	var r0 webhook.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.PageMetadata) (webhook.Page, error)); ok {
+		return rf(ctx, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.PageMetadata) webhook.Page); ok {
+		r0 = rf(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(webhook.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.PageMetadata) error); ok {
+		r1 = rf(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, wh
+func (_m *Repository) Save(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, wh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Webhook) (webhook.Webhook, error)); ok {
+		return rf(ctx, wh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Webhook) webhook.Webhook); ok {
+		r0 = rf(ctx, wh)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Webhook) error); ok {
+		r1 = rf(ctx, wh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, wh
+func (_m *Repository) Update(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, wh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Webhook) (webhook.Webhook, error)); ok {
+		return rf(ctx, wh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Webhook) webhook.Webhook); ok {
+		r0 = rf(ctx, wh)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Webhook) error); ok {
+		r1 = rf(ctx, wh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,49 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	webhook "github.com/absmach/magistrala/consumers/forwarders/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sender is an autogenerated mock type for the Sender type
+type Sender struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, wh, msgs
+func (_m *Sender) Send(ctx context.Context, wh webhook.Webhook, msgs []interface{}) error {
+	ret := _m.Called(ctx, wh, msgs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Webhook, []interface{}) error); ok {
+		r0 = rf(ctx, wh, msgs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSender creates a new instance of Sender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sender {
+	mock := &Sender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
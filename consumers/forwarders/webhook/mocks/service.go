@@ -0,0 +1,179 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	webhook "github.com/absmach/magistrala/consumers/forwarders/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// ConsumeBlocking provides a mock function with given fields: ctx, messages
+func (_m *Service) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeBlocking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, token, wh
+func (_m *Service) CreateWebhook(ctx context.Context, token string, wh webhook.Webhook) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, token, wh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.Webhook) (webhook.Webhook, error)); ok {
+		return rf(ctx, token, wh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.Webhook) webhook.Webhook); ok {
+		r0 = rf(ctx, token, wh)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, webhook.Webhook) error); ok {
+		r1 = rf(ctx, token, wh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListWebhooks(ctx context.Context, token string, pm webhook.PageMetadata) (webhook.Page, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 webhook.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.PageMetadata) (webhook.Page, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.PageMetadata) webhook.Page); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(webhook.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, webhook.PageMetadata) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveWebhook provides a mock function with given fields: ctx, token, id
+func (_m *Service) RemoveWebhook(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, token, wh
+func (_m *Service) UpdateWebhook(ctx context.Context, token string, wh webhook.Webhook) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, token, wh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.Webhook) (webhook.Webhook, error)); ok {
+		return rf(ctx, token, wh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.Webhook) webhook.Webhook); ok {
+		r0 = rf(ctx, token, wh)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, webhook.Webhook) error); ok {
+		r1 = rf(ctx, token, wh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewWebhook provides a mock function with given fields: ctx, token, id
+func (_m *Service) ViewWebhook(ctx context.Context, token string, id string) (webhook.Webhook, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewWebhook")
+	}
+
+	var r0 webhook.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (webhook.Webhook, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) webhook.Webhook); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(webhook.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of webhook forwarder service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "webhooks_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS webhooks (
+						id          VARCHAR(36) PRIMARY KEY,
+						name        VARCHAR(1024),
+						domain_id   VARCHAR(36) NOT NULL,
+						channel_id  VARCHAR(36) NOT NULL,
+						subtopic    TEXT,
+						url         TEXT NOT NULL,
+						secret      TEXT,
+						headers     JSONB,
+						status      SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						created_by  VARCHAR(254),
+						created_at  TIMESTAMP,
+						updated_at  TIMESTAMP,
+						UNIQUE (channel_id, url, subtopic)
+					)`,
+					`CREATE INDEX idx_webhooks_channel_status ON webhooks(channel_id, status);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS webhooks`,
+				},
+			},
+		},
+	}
+}
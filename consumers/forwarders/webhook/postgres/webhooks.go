@@ -0,0 +1,263 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+var _ webhook.Repository = (*repository)(nil)
+
+type repository struct {
+	db postgres.Database
+}
+
+// New instantiates a PostgreSQL implementation of webhook repository.
+func New(db postgres.Database) webhook.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	q := `INSERT INTO webhooks (id, name, domain_id, channel_id, subtopic, url, secret, headers, status, created_by, created_at)
+		VALUES (:id, :name, :domain_id, :channel_id, :subtopic, :url, :secret, :headers, :status, :created_by, :created_at)
+		RETURNING id, name, domain_id, channel_id, subtopic, url, secret, headers, status, created_by, created_at, updated_at`
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhook.Webhook{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbwh)
+	if err != nil {
+		return webhook.Webhook{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	dbwh = dbWebhook{}
+	if row.Next() {
+		if err := row.StructScan(&dbwh); err != nil {
+			return webhook.Webhook{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	return toWebhook(dbwh)
+}
+
+func (repo *repository) Retrieve(ctx context.Context, id string) (webhook.Webhook, error) {
+	q := `SELECT id, name, domain_id, channel_id, subtopic, url, secret, headers, status, created_by, created_at, updated_at
+		FROM webhooks WHERE id = $1`
+
+	dbwh := dbWebhook{}
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbwh); err != nil {
+		if err == sql.ErrNoRows {
+			return webhook.Webhook{}, errors.Wrap(repoerr.ErrNotFound, err)
+		}
+		return webhook.Webhook{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toWebhook(dbwh)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm webhook.PageMetadata) (webhook.Page, error) {
+	query := pageQuery(pm)
+
+	q := fmt.Sprintf(`SELECT id, name, domain_id, channel_id, subtopic, url, secret, headers, status, created_by, created_at, updated_at
+		FROM webhooks %s ORDER BY created_at OFFSET :offset`, query)
+	if pm.Limit >= 0 {
+		q = fmt.Sprintf("%s LIMIT :limit", q)
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, toDBPageMetadata(pm))
+	if err != nil {
+		return webhook.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []webhook.Webhook
+	for rows.Next() {
+		dbwh := dbWebhook{}
+		if err := rows.StructScan(&dbwh); err != nil {
+			return webhook.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		wh, err := toWebhook(dbwh)
+		if err != nil {
+			return webhook.Page{}, err
+		}
+		items = append(items, wh)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM webhooks %s;`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, toDBPageMetadata(pm))
+	if err != nil {
+		return webhook.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return webhook.Page{
+		PageMetadata: pm,
+		Total:        total,
+		Webhooks:     items,
+	}, nil
+}
+
+func (repo *repository) Update(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	q := `UPDATE webhooks SET name = :name, subtopic = :subtopic, url = :url, secret = :secret,
+		headers = :headers, status = :status, updated_at = :updated_at
+		WHERE id = :id
+		RETURNING id, name, domain_id, channel_id, subtopic, url, secret, headers, status, created_by, created_at, updated_at`
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhook.Webhook{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbwh)
+	if err != nil {
+		return webhook.Webhook{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return webhook.Webhook{}, repoerr.ErrNotFound
+	}
+	dbwh = dbWebhook{}
+	if err := row.StructScan(&dbwh); err != nil {
+		return webhook.Webhook{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return toWebhook(dbwh)
+}
+
+func (repo *repository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM webhooks WHERE id = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func pageQuery(pm webhook.PageMetadata) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.Status != mgclients.AllStatus {
+		query = append(query, "status = :status")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbWebhook struct {
+	ID        string           `db:"id"`
+	Name      string           `db:"name"`
+	DomainID  string           `db:"domain_id"`
+	ChannelID string           `db:"channel_id"`
+	Subtopic  string           `db:"subtopic"`
+	URL       string           `db:"url"`
+	Secret    string           `db:"secret"`
+	Headers   []byte           `db:"headers"`
+	Status    mgclients.Status `db:"status"`
+	CreatedBy string           `db:"created_by"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedAt sql.NullTime     `db:"updated_at"`
+}
+
+func toDBWebhook(wh webhook.Webhook) (dbWebhook, error) {
+	headers := []byte("{}")
+	if len(wh.Headers) > 0 {
+		b, err := json.Marshal(wh.Headers)
+		if err != nil {
+			return dbWebhook{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		headers = b
+	}
+	var updatedAt sql.NullTime
+	if !wh.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: wh.UpdatedAt, Valid: true}
+	}
+
+	return dbWebhook{
+		ID:        wh.ID,
+		Name:      wh.Name,
+		DomainID:  wh.DomainID,
+		ChannelID: wh.ChannelID,
+		Subtopic:  wh.Subtopic,
+		URL:       wh.URL,
+		Secret:    wh.Secret,
+		Headers:   headers,
+		Status:    wh.Status,
+		CreatedBy: wh.CreatedBy,
+		CreatedAt: wh.CreatedAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func toWebhook(dbwh dbWebhook) (webhook.Webhook, error) {
+	var headers map[string]string
+	if len(dbwh.Headers) > 0 {
+		if err := json.Unmarshal(dbwh.Headers, &headers); err != nil {
+			return webhook.Webhook{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+	var updatedAt time.Time
+	if dbwh.UpdatedAt.Valid {
+		updatedAt = dbwh.UpdatedAt.Time
+	}
+
+	return webhook.Webhook{
+		ID:        dbwh.ID,
+		Name:      dbwh.Name,
+		DomainID:  dbwh.DomainID,
+		ChannelID: dbwh.ChannelID,
+		Subtopic:  dbwh.Subtopic,
+		URL:       dbwh.URL,
+		Secret:    dbwh.Secret,
+		Headers:   headers,
+		Status:    dbwh.Status,
+		CreatedBy: dbwh.CreatedBy,
+		CreatedAt: dbwh.CreatedAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+type dbPageMetadata struct {
+	Offset    uint64           `db:"offset"`
+	Limit     int              `db:"limit"`
+	DomainID  string           `db:"domain_id"`
+	ChannelID string           `db:"channel_id"`
+	Status    mgclients.Status `db:"status"`
+}
+
+func toDBPageMetadata(pm webhook.PageMetadata) dbPageMetadata {
+	return dbPageMetadata{
+		Offset:    pm.Offset,
+		Limit:     pm.Limit,
+		DomainID:  pm.DomainID,
+		ChannelID: pm.ChannelID,
+		Status:    pm.Status,
+	}
+}
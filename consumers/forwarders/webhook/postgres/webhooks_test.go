@@ -0,0 +1,273 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	whpostgres "github.com/absmach/magistrala/consumers/forwarders/webhook/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numWebhooks = 10
+	validURL    = "https://example.com/hook"
+)
+
+func newWebhook(t *testing.T, domainID, channelID string) webhook.Webhook {
+	return webhook.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		Name:      "webhook",
+		DomainID:  domainID,
+		ChannelID: channelID,
+		URL:       fmt.Sprintf("%s/%s", validURL, testsutil.GenerateUUID(t)),
+		Secret:    "secret",
+		Headers:   map[string]string{"X-Key": "value"},
+		Status:    mgclients.EnabledStatus,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := whpostgres.New(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	duplicate := wh
+	duplicate.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		wh   webhook.Webhook
+		err  error
+	}{
+		{
+			desc: "save successfully",
+			wh:   wh,
+			err:  nil,
+		},
+		{
+			desc: "save with existing ID",
+			wh:   wh,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save with existing channel, URL and subtopic",
+			wh:   duplicate,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.wh, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.wh, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := whpostgres.New(database)
+
+	wh, err := repo.Save(context.Background(), newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		wh   webhook.Webhook
+		err  error
+	}{
+		{
+			desc: "retrieve existing webhook",
+			id:   wh.ID,
+			wh:   wh,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing webhook",
+			id:   testsutil.GenerateUUID(t),
+			wh:   webhook.Webhook{},
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wh, err := repo.Retrieve(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.wh, wh, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.wh, wh))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := whpostgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	var disabled int
+	for i := 0; i < numWebhooks; i++ {
+		wh := newWebhook(t, domainID, channelID)
+		if i%2 == 0 {
+			wh.Status = mgclients.DisabledStatus
+			disabled++
+		}
+		_, err := repo.Save(context.Background(), wh)
+		require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		pm    webhook.PageMetadata
+		size  int
+		total uint64
+	}{
+		{
+			desc:  "retrieve all webhooks of the domain",
+			pm:    webhook.PageMetadata{DomainID: domainID, Limit: numWebhooks, Status: mgclients.AllStatus},
+			size:  numWebhooks,
+			total: numWebhooks,
+		},
+		{
+			desc:  "retrieve webhooks with limit and offset",
+			pm:    webhook.PageMetadata{DomainID: domainID, Offset: 2, Limit: 5, Status: mgclients.AllStatus},
+			size:  5,
+			total: numWebhooks,
+		},
+		{
+			desc:  "retrieve webhooks without limit",
+			pm:    webhook.PageMetadata{ChannelID: channelID, Limit: -1, Status: mgclients.AllStatus},
+			size:  numWebhooks,
+			total: numWebhooks,
+		},
+		{
+			desc:  "retrieve enabled webhooks of the channel",
+			pm:    webhook.PageMetadata{ChannelID: channelID, Limit: -1, Status: mgclients.EnabledStatus},
+			size:  numWebhooks - disabled,
+			total: uint64(numWebhooks - disabled),
+		},
+		{
+			desc:  "retrieve webhooks of non-existing channel",
+			pm:    webhook.PageMetadata{ChannelID: testsutil.GenerateUUID(t), Limit: -1, Status: mgclients.AllStatus},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.size, len(page.Webhooks), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Webhooks)))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := whpostgres.New(database)
+
+	wh, err := repo.Save(context.Background(), newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	updated := wh
+	updated.Name = "updated"
+	updated.Subtopic = "temperature.*"
+	updated.Status = mgclients.DisabledStatus
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	nonExisting := updated
+	nonExisting.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		wh   webhook.Webhook
+		err  error
+	}{
+		{
+			desc: "update existing webhook",
+			wh:   updated,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing webhook",
+			wh:   nonExisting,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wh, err := repo.Update(context.Background(), tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.wh, wh, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.wh, wh))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := whpostgres.New(database)
+
+	wh, err := repo.Save(context.Background(), newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove existing webhook",
+			id:   wh.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove non-existing webhook",
+			id:   wh.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+var (
+	// ErrMessage indicates an error converting a message to the webhook payload.
+	ErrMessage = errors.New("failed to convert message to webhook payload")
+
+	// ErrForward indicates an error delivering messages to the webhook.
+	ErrForward = errors.New("failed to forward messages to webhook")
+)
+
+// Service represents a webhook forwarding service.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateWebhook registers a new webhook on the channel.
+	CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error)
+
+	// ViewWebhook retrieves the webhook with the given id.
+	ViewWebhook(ctx context.Context, token, id string) (Webhook, error)
+
+	// ListWebhooks lists webhooks of the domain matching the page metadata.
+	ListWebhooks(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
+	// UpdateWebhook updates the webhook identified by the provided ID.
+	UpdateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error)
+
+	// RemoveWebhook removes the webhook with the given id.
+	RemoveWebhook(ctx context.Context, token, id string) error
+
+	consumers.BlockingConsumer
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	auth   grpcclient.AuthServiceClient
+	repo   Repository
+	idp    magistrala.IDProvider
+	sender Sender
+	filter AddressFilter
+	cache  Cache
+}
+
+// New instantiates the webhook service implementation. Webhook URLs are
+// checked against the address filter when they are created or updated.
+// The webhooks of the channel are cached until the channel webhooks change.
+func New(authClient grpcclient.AuthServiceClient, repo Repository, idp magistrala.IDProvider, sender Sender, filter AddressFilter, cache Cache) Service {
+	return &service{
+		auth:   authClient,
+		repo:   repo,
+		idp:    idp,
+		sender: sender,
+		filter: filter,
+		cache:  cache,
+	}
+}
+
+func (svc *service) CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+	if err := svc.authorize(ctx, token, auth.EditPermission, auth.GroupType, wh.ChannelID); err != nil {
+		return Webhook{}, err
+	}
+	if err := svc.filter.CheckURL(wh.URL); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	id, err := svc.idp.ID()
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	wh.ID = id
+	wh.DomainID = user.GetDomainId()
+	wh.CreatedBy = user.GetUserId()
+	wh.CreatedAt = time.Now()
+
+	saved, err := svc.repo.Save(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	if err := svc.cache.Remove(ctx, saved.ChannelID); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewWebhook(ctx context.Context, token, id string) (Webhook, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+	wh, err := svc.retrieve(ctx, token, user, id, auth.ViewPermission)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return wh, nil
+}
+
+func (svc *service) ListWebhooks(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+	switch pm.ChannelID {
+	case "":
+		if err := svc.authorize(ctx, token, auth.AdminPermission, auth.DomainType, user.GetDomainId()); err != nil {
+			return Page{}, err
+		}
+	default:
+		if err := svc.authorize(ctx, token, auth.ViewPermission, auth.GroupType, pm.ChannelID); err != nil {
+			return Page{}, err
+		}
+	}
+	pm.DomainID = user.GetDomainId()
+
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return Page{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) UpdateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+	current, err := svc.retrieve(ctx, token, user, wh.ID, auth.EditPermission)
+	if err != nil {
+		return Webhook{}, err
+	}
+	if err := svc.filter.CheckURL(wh.URL); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	// Secret is never returned by the API, so keep it unless a new one is set.
+	if wh.Secret == "" {
+		wh.Secret = current.Secret
+	}
+	wh.UpdatedAt = time.Now()
+
+	updated, err := svc.repo.Update(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.cache.Remove(ctx, current.ChannelID); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (svc *service) RemoveWebhook(ctx context.Context, token, id string) error {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	wh, err := svc.retrieve(ctx, token, user, id, auth.EditPermission)
+	if err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.cache.Remove(ctx, wh.ChannelID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	msgs, err := group(message)
+	if err != nil {
+		return err
+	}
+
+	// The failing webhook doesn't stop the delivery to the other webhooks,
+	// so the errors are collected and returned once all are tried.
+	var errs error
+	for channel, records := range msgs {
+		whs, err := svc.channelWebhooks(ctx, channel)
+		if err != nil {
+			errs = errors.Wrap(err, errs)
+			continue
+		}
+		for _, wh := range whs {
+			var payload []interface{}
+			for _, r := range records {
+				if wh.Matches(r.subtopic) {
+					payload = append(payload, r.data)
+				}
+			}
+			if len(payload) == 0 {
+				continue
+			}
+			if err := svc.sender.Send(ctx, wh, payload); err != nil {
+				errs = errors.Wrap(err, errs)
+			}
+		}
+	}
+	if errs != nil {
+		return errors.Wrap(ErrForward, errs)
+	}
+
+	return nil
+}
+
+// channelWebhooks returns the webhooks of the channel from the cache,
+// falling back to the repository.
+func (svc *service) channelWebhooks(ctx context.Context, channelID string) ([]Webhook, error) {
+	if whs, err := svc.cache.Retrieve(ctx, channelID); err == nil {
+		return whs, nil
+	}
+	pm := PageMetadata{
+		ChannelID: channelID,
+		Offset:    0,
+		Limit:     -1,
+	}
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.cache.Save(ctx, channelID, page.Webhooks); err != nil {
+		return nil, err
+	}
+
+	return page.Webhooks, nil
+}
+
+// retrieve fetches the webhook and checks that the user holds the permission
+// on the webhook channel within the user's domain.
+func (svc *service) retrieve(ctx context.Context, token string, user *magistrala.IdentityRes, id, permission string) (Webhook, error) {
+	wh, err := svc.repo.Retrieve(ctx, id)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if wh.DomainID != user.GetDomainId() {
+		return Webhook{}, svcerr.ErrAuthorization
+	}
+	if err := svc.authorize(ctx, token, permission, auth.GroupType, wh.ChannelID); err != nil {
+		return Webhook{}, err
+	}
+
+	return wh, nil
+}
+
+func (svc *service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
+	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if res.GetId() == "" || res.GetDomainId() == "" {
+		return nil, svcerr.ErrDomainAuthorization
+	}
+
+	return res, nil
+}
+
+func (svc *service) authorize(ctx context.Context, token, permission, objectType, object string) error {
+	req := &magistrala.AuthorizeReq{
+		SubjectType: auth.UserType,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
+		Permission:  permission,
+		ObjectType:  objectType,
+		Object:      object,
+	}
+	res, err := svc.auth.Authorize(ctx, req)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
+type record struct {
+	subtopic string
+	data     interface{}
+}
+
+// group splits consumed messages by channel.
+func group(message interface{}) (map[string][]record, error) {
+	ret := make(map[string][]record)
+	switch m := message.(type) {
+	case []senml.Message:
+		for _, msg := range m {
+			ret[msg.Channel] = append(ret[msg.Channel], record{subtopic: msg.Subtopic, data: msg})
+		}
+	case mgjson.Messages:
+		for _, msg := range m.Data {
+			ret[msg.Channel] = append(ret[msg.Channel], record{subtopic: msg.Subtopic, data: msg})
+		}
+	case *messaging.Message:
+		ret[m.GetChannel()] = append(ret[m.GetChannel()], record{subtopic: m.GetSubtopic(), data: m})
+	default:
+		return nil, ErrMessage
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,503 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"github.com/absmach/magistrala/consumers/forwarders/webhook/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "token"
+	invalidToken = "invalid"
+	validURL     = "https://example.com/hook"
+	allowedNet   = "10.10.0.0/16"
+)
+
+var (
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+)
+
+func newService() (webhook.Service, *authmocks.AuthServiceClient, *mocks.Repository, *mocks.Sender) {
+	authClient := new(authmocks.AuthServiceClient)
+	repo := new(mocks.Repository)
+	sender := new(mocks.Sender)
+	idp := uuid.NewMock()
+	filter, _ := webhook.NewAddressFilter([]string{allowedNet})
+
+	return webhook.New(authClient, repo, idp, sender, filter, webhook.NewCache(0, 0)), authClient, repo, sender
+}
+
+func TestCreateWebhook(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	cases := []struct {
+		desc        string
+		token       string
+		url         string
+		identifyRes *magistrala.IdentityRes
+		identifyErr error
+		authRes     *magistrala.AuthorizeRes
+		authErr     error
+		saveErr     error
+		err         error
+	}{
+		{
+			desc:        "create webhook successfully",
+			token:       validToken,
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         nil,
+		},
+		{
+			desc:        "create webhook with invalid token",
+			token:       invalidToken,
+			identifyRes: &magistrala.IdentityRes{},
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "create webhook without domain",
+			token:       validToken,
+			identifyRes: &magistrala.IdentityRes{Id: userID, UserId: userID},
+			err:         svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "create webhook with unauthorized user",
+			token:       validToken,
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: false},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "create webhook with failed repository",
+			token:       validToken,
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:     repoerr.ErrConflict,
+			err:         svcerr.ErrCreateEntity,
+		},
+		{
+			desc:        "create webhook with loopback address",
+			token:       validToken,
+			url:         "http://127.0.0.1:8080/hook",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         webhook.ErrForbiddenAddress,
+		},
+		{
+			desc:        "create webhook with localhost name",
+			token:       validToken,
+			url:         "http://localhost/hook",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         webhook.ErrForbiddenAddress,
+		},
+		{
+			desc:        "create webhook with link-local address",
+			token:       validToken,
+			url:         "http://169.254.169.254/latest/meta-data",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         webhook.ErrForbiddenAddress,
+		},
+		{
+			desc:        "create webhook with private address",
+			token:       validToken,
+			url:         "http://192.168.1.10/hook",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         webhook.ErrForbiddenAddress,
+		},
+		{
+			desc:        "create webhook with private IPv6 address",
+			token:       validToken,
+			url:         "http://[fd00::1]/hook",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         webhook.ErrForbiddenAddress,
+		},
+		{
+			desc:        "create webhook with allowed private address",
+			token:       validToken,
+			url:         "http://10.10.1.5/hook",
+			identifyRes: &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyRes, tc.identifyErr)
+			authCall1 := authClient.On("Authorize", context.Background(), mock.Anything).Return(tc.authRes, tc.authErr)
+			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, wh webhook.Webhook) webhook.Webhook { return wh }, tc.saveErr)
+			wh := webhook.Webhook{ChannelID: channelID, URL: tc.url}
+			if wh.URL == "" {
+				wh.URL = validURL
+			}
+			saved, err := svc.CreateWebhook(context.Background(), tc.token, wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected non-empty ID", tc.desc))
+				assert.Equal(t, domainID, saved.DomainID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, domainID, saved.DomainID))
+				assert.Equal(t, userID, saved.CreatedBy, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, userID, saved.CreatedBy))
+			}
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	wh := webhook.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, ChannelID: channelID, URL: validURL}
+	otherDomain := wh
+	otherDomain.DomainID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		id          string
+		retrieveRes webhook.Webhook
+		retrieveErr error
+		authRes     *magistrala.AuthorizeRes
+		err         error
+	}{
+		{
+			desc:        "view webhook successfully",
+			id:          wh.ID,
+			retrieveRes: wh,
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         nil,
+		},
+		{
+			desc:        "view non-existing webhook",
+			id:          wh.ID,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "view webhook from another domain",
+			id:          wh.ID,
+			retrieveRes: otherDomain,
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "view webhook without channel permission",
+			id:          wh.ID,
+			retrieveRes: wh,
+			authRes:     &magistrala.AuthorizeRes{Authorized: false},
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID}, nil)
+			authCall1 := authClient.On("Authorize", context.Background(), mock.Anything).Return(tc.authRes, nil)
+			repoCall := repo.On("Retrieve", context.Background(), tc.id).Return(tc.retrieveRes, tc.retrieveErr)
+			res, err := svc.ViewWebhook(context.Background(), validToken, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.retrieveRes, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.retrieveRes, res))
+			}
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	page := webhook.Page{Total: 1, Webhooks: []webhook.Webhook{{ID: testsutil.GenerateUUID(t), DomainID: domainID, ChannelID: channelID}}}
+
+	cases := []struct {
+		desc    string
+		pm      webhook.PageMetadata
+		authReq *magistrala.AuthorizeReq
+		authRes *magistrala.AuthorizeRes
+		err     error
+	}{
+		{
+			desc: "list domain webhooks as domain admin",
+			pm:   webhook.PageMetadata{Limit: 10},
+			authReq: &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     validToken,
+				Permission:  auth.AdminPermission,
+				ObjectType:  auth.DomainType,
+				Object:      domainID,
+			},
+			authRes: &magistrala.AuthorizeRes{Authorized: true},
+			err:     nil,
+		},
+		{
+			desc: "list channel webhooks",
+			pm:   webhook.PageMetadata{Limit: 10, ChannelID: channelID},
+			authReq: &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     validToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.GroupType,
+				Object:      channelID,
+			},
+			authRes: &magistrala.AuthorizeRes{Authorized: true},
+			err:     nil,
+		},
+		{
+			desc: "list channel webhooks without permission",
+			pm:   webhook.PageMetadata{Limit: 10, ChannelID: channelID},
+			authReq: &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     validToken,
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.GroupType,
+				Object:      channelID,
+			},
+			authRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:     svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID}, nil)
+			authCall1 := authClient.On("Authorize", context.Background(), tc.authReq).Return(tc.authRes, nil)
+			pm := tc.pm
+			pm.DomainID = domainID
+			repoCall := repo.On("RetrieveAll", context.Background(), pm).Return(page, nil)
+			res, err := svc.ListWebhooks(context.Background(), validToken, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, page, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, page, res))
+			}
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	wh := webhook.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, ChannelID: channelID, URL: validURL}
+
+	cases := []struct {
+		desc      string
+		authRes   *magistrala.AuthorizeRes
+		removeErr error
+		err       error
+	}{
+		{
+			desc:    "remove webhook successfully",
+			authRes: &magistrala.AuthorizeRes{Authorized: true},
+			err:     nil,
+		},
+		{
+			desc:    "remove webhook without permission",
+			authRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "remove webhook with failed repository",
+			authRes:   &magistrala.AuthorizeRes{Authorized: true},
+			removeErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID}, nil)
+			authCall1 := authClient.On("Authorize", context.Background(), mock.Anything).Return(tc.authRes, nil)
+			repoCall := repo.On("Retrieve", context.Background(), wh.ID).Return(wh, nil)
+			repoCall1 := repo.On("Remove", context.Background(), wh.ID).Return(tc.removeErr)
+			err := svc.RemoveWebhook(context.Background(), validToken, wh.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestConsume(t *testing.T) {
+	svc, _, repo, sender := newService()
+
+	all := webhook.Webhook{ID: testsutil.GenerateUUID(t), ChannelID: channelID, URL: validURL}
+	temperature := webhook.Webhook{ID: testsutil.GenerateUUID(t), ChannelID: channelID, URL: validURL, Subtopic: "temperature.*"}
+	page := webhook.Page{Total: 2, Webhooks: []webhook.Webhook{all, temperature}}
+
+	tempMsg := senml.Message{Channel: channelID, Subtopic: "temperature.room1", Name: "temp"}
+	humMsg := senml.Message{Channel: channelID, Subtopic: "humidity", Name: "hum"}
+
+	cases := []struct {
+		desc     string
+		msg      interface{}
+		sends    map[string][]interface{}
+		sendErrs map[string]error
+		err      error
+	}{
+		{
+			desc: "consume SenML messages",
+			msg:  []senml.Message{tempMsg, humMsg},
+			sends: map[string][]interface{}{
+				all.ID:         {tempMsg, humMsg},
+				temperature.ID: {tempMsg},
+			},
+			err: nil,
+		},
+		{
+			desc: "consume raw message not matching subtopic filter",
+			msg:  &messaging.Message{Channel: channelID, Subtopic: "humidity"},
+			sends: map[string][]interface{}{
+				all.ID: {&messaging.Message{Channel: channelID, Subtopic: "humidity"}},
+			},
+			err: nil,
+		},
+		{
+			desc: "consume with failed delivery",
+			msg:  []senml.Message{humMsg},
+			sends: map[string][]interface{}{
+				all.ID: {humMsg},
+			},
+			sendErrs: map[string]error{all.ID: svcerr.ErrMalformedEntity},
+			err:      webhook.ErrForward,
+		},
+		{
+			desc: "consume with failed delivery to one of the webhooks",
+			msg:  []senml.Message{tempMsg},
+			sends: map[string][]interface{}{
+				all.ID:         {tempMsg},
+				temperature.ID: {tempMsg},
+			},
+			sendErrs: map[string]error{all.ID: svcerr.ErrMalformedEntity},
+			err:      webhook.ErrForward,
+		},
+		{
+			desc: "consume invalid message",
+			msg:  "invalid",
+			err:  webhook.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveAll", context.Background(), webhook.PageMetadata{ChannelID: channelID, Limit: -1}).Return(page, nil)
+			var calls []*mock.Call
+			for _, wh := range page.Webhooks {
+				if msgs, ok := tc.sends[wh.ID]; ok {
+					calls = append(calls, sender.On("Send", context.Background(), wh, msgs).Return(tc.sendErrs[wh.ID]))
+				}
+			}
+			err := svc.ConsumeBlocking(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			for _, c := range calls {
+				sender.AssertCalled(t, "Send", c.Arguments...)
+			}
+			for _, c := range calls {
+				c.Unset()
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestConsumeCache(t *testing.T) {
+	authClient := new(authmocks.AuthServiceClient)
+	repo := new(mocks.Repository)
+	sender := new(mocks.Sender)
+	filter, _ := webhook.NewAddressFilter([]string{allowedNet})
+	svc := webhook.New(authClient, repo, uuid.NewMock(), sender, filter, webhook.NewCache(10, time.Minute))
+
+	wh := webhook.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, ChannelID: channelID, URL: validURL}
+	msg := &messaging.Message{Channel: channelID, Subtopic: "humidity"}
+	pm := webhook.PageMetadata{ChannelID: channelID, Limit: -1}
+
+	repoCall := repo.On("RetrieveAll", context.Background(), pm).Return(webhook.Page{Total: 1, Webhooks: []webhook.Webhook{wh}}, nil)
+	senderCall := sender.On("Send", context.Background(), wh, []interface{}{msg}).Return(nil)
+	for i := 0; i < 3; i++ {
+		err := svc.ConsumeBlocking(context.Background(), msg)
+		assert.Nil(t, err, fmt.Sprintf("consume with cached webhooks: unexpected error %s", err))
+	}
+	repo.AssertNumberOfCalls(t, "RetrieveAll", 1)
+	sender.AssertNumberOfCalls(t, "Send", 3)
+
+	// Removing the webhook invalidates the webhooks of the channel.
+	identifyCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID}, nil)
+	authorizeCall := authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	retrieveCall := repo.On("Retrieve", context.Background(), wh.ID).Return(wh, nil)
+	removeCall := repo.On("Remove", context.Background(), wh.ID).Return(nil)
+	err := svc.RemoveWebhook(context.Background(), validToken, wh.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove webhook: unexpected error %s", err))
+
+	repoCall.Unset()
+	repoCall = repo.On("RetrieveAll", context.Background(), pm).Return(webhook.Page{}, nil)
+	err = svc.ConsumeBlocking(context.Background(), msg)
+	assert.Nil(t, err, fmt.Sprintf("consume after webhook removal: unexpected error %s", err))
+	repo.AssertNumberOfCalls(t, "RetrieveAll", 2)
+	sender.AssertNumberOfCalls(t, "Send", 3)
+
+	removeCall.Unset()
+	retrieveCall.Unset()
+	authorizeCall.Unset()
+	identifyCall.Unset()
+	senderCall.Unset()
+	repoCall.Unset()
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		desc     string
+		pattern  string
+		subtopic string
+		matches  bool
+	}{
+		{desc: "empty pattern", pattern: "", subtopic: "a.b", matches: true},
+		{desc: "exact match", pattern: "a.b", subtopic: "a.b", matches: true},
+		{desc: "exact mismatch", pattern: "a.b", subtopic: "a.c", matches: false},
+		{desc: "single wildcard", pattern: "a.*", subtopic: "a.b", matches: true},
+		{desc: "single wildcard with extra tokens", pattern: "a.*", subtopic: "a.b.c", matches: false},
+		{desc: "multi wildcard", pattern: "a.>", subtopic: "a.b.c", matches: true},
+		{desc: "multi wildcard without tokens", pattern: "a.>", subtopic: "a", matches: false},
+		{desc: "pattern longer than subtopic", pattern: "a.b.c", subtopic: "a.b", matches: false},
+		{desc: "empty subtopic", pattern: "a", subtopic: "", matches: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wh := webhook.Webhook{Subtopic: tc.pattern}
+			assert.Equal(t, tc.matches, wh.Matches(tc.subtopic), fmt.Sprintf("%s: expected %t for pattern %q and subtopic %q\n", tc.desc, tc.matches, tc.pattern, tc.subtopic))
+		})
+	}
+}
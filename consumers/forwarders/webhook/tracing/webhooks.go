@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers/forwarders/webhook"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	saveOp        = "save_op"
+	retrieveOp    = "retrieve_op"
+	retrieveAllOp = "retrieve_all_op"
+	updateOp      = "update_op"
+	removeOp      = "remove_op"
+)
+
+var _ webhook.Repository = (*repositoryMiddleware)(nil)
+
+type repositoryMiddleware struct {
+	tracer trace.Tracer
+	repo   webhook.Repository
+}
+
+// New instantiates a new webhook repository that
+// tracks request and their latency, and adds spans to context.
+func New(tracer trace.Tracer, repo webhook.Repository) webhook.Repository {
+	return repositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+// Save traces the "Save" operation of the wrapped webhook repository.
+func (rm repositoryMiddleware) Save(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	ctx, span := rm.tracer.Start(ctx, saveOp, trace.WithAttributes(
+		attribute.String("id", wh.ID),
+		attribute.String("domain_id", wh.DomainID),
+		attribute.String("channel_id", wh.ChannelID),
+	))
+	defer span.End()
+
+	return rm.repo.Save(ctx, wh)
+}
+
+// Retrieve traces the "Retrieve" operation of the wrapped webhook repository.
+func (rm repositoryMiddleware) Retrieve(ctx context.Context, id string) (webhook.Webhook, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.Retrieve(ctx, id)
+}
+
+// RetrieveAll traces the "RetrieveAll" operation of the wrapped webhook repository.
+func (rm repositoryMiddleware) RetrieveAll(ctx context.Context, pm webhook.PageMetadata) (webhook.Page, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveAllOp, trace.WithAttributes(
+		attribute.String("domain_id", pm.DomainID),
+		attribute.String("channel_id", pm.ChannelID),
+	))
+	defer span.End()
+
+	return rm.repo.RetrieveAll(ctx, pm)
+}
+
+// Update traces the "Update" operation of the wrapped webhook repository.
+func (rm repositoryMiddleware) Update(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+	ctx, span := rm.tracer.Start(ctx, updateOp, trace.WithAttributes(attribute.String("id", wh.ID)))
+	defer span.End()
+
+	return rm.repo.Update(ctx, wh)
+}
+
+// Remove traces the "Remove" operation of the wrapped webhook repository.
+func (rm repositoryMiddleware) Remove(ctx context.Context, id string) error {
+	ctx, span := rm.tracer.Start(ctx, removeOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.Remove(ctx, id)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the request body.
+	SignatureHeader = "X-Magistrala-Signature"
+	// TimestampHeader contains the Unix time at which the request was signed.
+	TimestampHeader = "X-Magistrala-Timestamp"
+	// WebhookHeader contains the ID of the webhook that produced the request.
+	WebhookHeader = "X-Magistrala-Webhook"
+
+	signaturePrefix = "sha256="
+	subtopicSep     = "."
+	singleWildcard  = "*"
+	multiWildcard   = ">"
+)
+
+// Webhook represents the registration of an external HTTP endpoint
+// that receives messages published to the channel.
+type Webhook struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name,omitempty"`
+	DomainID  string            `json:"domain_id"`
+	ChannelID string            `json:"channel_id"`
+	Subtopic  string            `json:"subtopic,omitempty"`
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Status    mgclients.Status  `json:"status"`
+	CreatedBy string            `json:"created_by,omitempty"`
+	CreatedAt time.Time         `json:"created_at,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+}
+
+// Matches reports whether the message subtopic is accepted by the webhook.
+// An empty webhook subtopic matches all messages. The "*" token matches
+// exactly one subtopic token and the ">" token matches one or more tokens.
+func (wh Webhook) Matches(subtopic string) bool {
+	if wh.Subtopic == "" || wh.Subtopic == multiWildcard {
+		return true
+	}
+	pattern := strings.Split(wh.Subtopic, subtopicSep)
+	tokens := strings.Split(subtopic, subtopicSep)
+	for i, p := range pattern {
+		if p == multiWildcard {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) {
+			return false
+		}
+		if p != singleWildcard && p != tokens[i] {
+			return false
+		}
+	}
+
+	return len(pattern) == len(tokens)
+}
+
+// Sign returns the signature of the payload sent at the given time using
+// the webhook secret. Receivers verify requests by computing the same value
+// from the TimestampHeader and the raw request body.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte(subtopicSep))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total    uint64
+	Webhooks []Webhook
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset uint64
+	// Limit values less than 0 indicate no limit.
+	Limit     int
+	DomainID  string
+	ChannelID string
+	Status    mgclients.Status
+}
+
+// Sender delivers messages to the webhook endpoint.
+//
+//go:generate mockery --name Sender --output=./mocks --filename sender.go --quiet --note "Copyright (c) Abstract Machines"
+type Sender interface {
+	// Send delivers the list of messages to the webhook URL.
+	Send(ctx context.Context, wh Webhook, msgs []interface{}) error
+}
+
+// Cache caches the webhooks of the channels, so that the consumed
+// messages are forwarded without querying the repository.
+//
+//go:generate mockery --name Cache --output=./mocks --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
+type Cache interface {
+	// Save stores the webhooks of the channel.
+	Save(ctx context.Context, channelID string, whs []Webhook) error
+
+	// Retrieve returns the webhooks of the channel.
+	Retrieve(ctx context.Context, channelID string) ([]Webhook, error)
+
+	// Remove removes the webhooks of the channel.
+	Remove(ctx context.Context, channelID string) error
+}
+
+// Repository specifies a Webhook persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists a webhook.
+	Save(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// Retrieve retrieves the webhook for the given id.
+	Retrieve(ctx context.Context, id string) (Webhook, error)
+
+	// RetrieveAll retrieves all the webhooks for the given page metadata.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// Update updates the webhook name, subtopic, URL, secret, headers and status.
+	Update(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// Remove removes the webhook for the given ID.
+	Remove(ctx context.Context, id string) error
+}
@@ -49,12 +49,12 @@ func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer in
 		}
 		switch c := consumer.(type) {
 		case AsyncConsumer:
-			subCfg.Handler = handleAsync(ctx, transformer, c)
+			subCfg.Handler = handleAsync(ctx, transformer, c, cfg.SubscriberCfg.Replay)
 			if err := sub.Subscribe(ctx, subCfg); err != nil {
 				return err
 			}
 		case BlockingConsumer:
-			subCfg.Handler = handleSync(ctx, transformer, c)
+			subCfg.Handler = handleSync(ctx, transformer, c, cfg.SubscriberCfg.Replay)
 			if err := sub.Subscribe(ctx, subCfg); err != nil {
 				return err
 			}
@@ -65,8 +65,13 @@ func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer in
 	return nil
 }
 
-func handleSync(ctx context.Context, t transformers.Transformer, sc BlockingConsumer) handleFunc {
+func handleSync(ctx context.Context, t transformers.Transformer, sc BlockingConsumer, replay bool) handleFunc {
 	return func(msg *messaging.Message) error {
+		// Replayed messages are already stored, so skip them unless
+		// the consumer explicitly opted in to receive them.
+		if !replay && messaging.IsReplay(msg) {
+			return nil
+		}
 		m := interface{}(msg)
 		var err error
 		if t != nil {
@@ -79,8 +84,13 @@ func handleSync(ctx context.Context, t transformers.Transformer, sc BlockingCons
 	}
 }
 
-func handleAsync(ctx context.Context, t transformers.Transformer, ac AsyncConsumer) handleFunc {
+func handleAsync(ctx context.Context, t transformers.Transformer, ac AsyncConsumer, replay bool) handleFunc {
 	return func(msg *messaging.Message) error {
+		// Replayed messages are already stored, so skip them unless
+		// the consumer explicitly opted in to receive them.
+		if !replay && messaging.IsReplay(msg) {
+			return nil
+		}
 		m := interface{}(msg)
 		var err error
 		if t != nil {
@@ -107,6 +117,7 @@ func (h handleFunc) Cancel() error {
 
 type subscriberConfig struct {
 	Subjects []string `toml:"subjects"`
+	Replay   bool     `toml:"replay"`
 }
 
 type transformerConfig struct {
@@ -151,6 +162,9 @@ func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Tr
 	case "JSON":
 		logger.Info("Using JSON transformer")
 		return json.New(cfg.TimeFields)
+	case "RAW":
+		logger.Info("Using raw messages without transformation")
+		return nil
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.Format))
 		os.Exit(1)
@@ -35,6 +35,7 @@ const (
 func MakeHandler(svc notifiers.Service, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	mux := chi.NewRouter()
@@ -0,0 +1,187 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	// NDJSON format stores messages as gzip compressed newline delimited JSON.
+	NDJSON = "ndjson"
+	// Parquet format stores messages as zstd compressed Parquet.
+	Parquet = "parquet"
+
+	// ManifestFile is the name of the manifest object of the channel partition.
+	ManifestFile = "manifest.json"
+
+	dateLayout = "2006-01-02"
+)
+
+var (
+	// ErrNotFound indicates a non-existent object in the storage.
+	ErrNotFound = errors.New("object not found")
+
+	// ErrArchive indicates a failure to archive messages.
+	ErrArchive = errors.New("failed to archive messages")
+
+	// ErrManifest indicates a failure to read or update the manifest.
+	ErrManifest = errors.New("failed to update archive manifest")
+
+	// ErrMessage indicates an unsupported message type.
+	ErrMessage = errors.New("unsupported message type")
+
+	errFormat = errors.New("unsupported archive format")
+	errConfig = errors.New("window and batch size must be positive")
+)
+
+// Config contains the archive writer configuration.
+type Config struct {
+	// Format is the file format, either NDJSON or Parquet.
+	Format string `env:"FORMAT" envDefault:"parquet"`
+	// Prefix is prepended to all the object keys.
+	Prefix string `env:"PREFIX" envDefault:""`
+	// Window is the duration of the time window messages are grouped by.
+	// A file is written once its window is older than the window duration.
+	Window time.Duration `env:"WINDOW" envDefault:"1h"`
+	// BatchSize is the maximal number of messages in a single file.
+	BatchSize int `env:"BATCH_SIZE" envDefault:"10000"`
+}
+
+// Validate checks the archive writer configuration.
+func (cfg Config) Validate() error {
+	switch cfg.Format {
+	case NDJSON, Parquet:
+	default:
+		return errFormat
+	}
+	if cfg.Window <= 0 || cfg.BatchSize <= 0 {
+		return errConfig
+	}
+
+	return nil
+}
+
+// Storage represents an object store the archive files are written to.
+//
+//go:generate mockery --name Storage --output=./mocks --filename storage.go --quiet --note "Copyright (c) Abstract Machines"
+type Storage interface {
+	// Put stores the object under the given key.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Get retrieves the object with the given key.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List returns the keys of the objects with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// DomainResolver resolves the domain a channel belongs to.
+//
+//go:generate mockery --name DomainResolver --output=./mocks --filename resolver.go --quiet --note "Copyright (c) Abstract Machines"
+type DomainResolver interface {
+	// Domain returns the ID of the domain of the channel.
+	Domain(ctx context.Context, channelID string) (string, error)
+}
+
+// Record represents a single archived message. SenML messages populate the
+// SenML fields, while JSON messages populate the format and the payload.
+type Record struct {
+	Channel     string          `json:"channel"                parquet:"channel"`
+	Subtopic    string          `json:"subtopic,omitempty"     parquet:"subtopic"`
+	Publisher   string          `json:"publisher"              parquet:"publisher"`
+	Protocol    string          `json:"protocol"               parquet:"protocol"`
+	Name        string          `json:"name,omitempty"         parquet:"name"`
+	Unit        string          `json:"unit,omitempty"         parquet:"unit"`
+	Time        int64           `json:"time"                   parquet:"time"`
+	UpdateTime  float64         `json:"update_time,omitempty"  parquet:"update_time"`
+	Value       *float64        `json:"value,omitempty"        parquet:"value,optional"`
+	StringValue *string         `json:"string_value,omitempty" parquet:"string_value,optional"`
+	DataValue   *string         `json:"data_value,omitempty"   parquet:"data_value,optional"`
+	BoolValue   *bool           `json:"bool_value,omitempty"   parquet:"bool_value,optional"`
+	Sum         *float64        `json:"sum,omitempty"          parquet:"sum,optional"`
+	Format      string          `json:"format,omitempty"       parquet:"format"`
+	Payload     json.RawMessage `json:"payload,omitempty"      parquet:"payload,optional,json"`
+}
+
+// Entry describes a single archived file.
+type Entry struct {
+	Key     string    `json:"key"`
+	Format  string    `json:"format"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Count   int       `json:"count"`
+	Size    int       `json:"size"`
+	Created time.Time `json:"created"`
+}
+
+// Manifest lists the archived files of a channel.
+type Manifest struct {
+	Domain  string  `json:"domain"`
+	Channel string  `json:"channel"`
+	Entries []Entry `json:"entries"`
+}
+
+// Range returns the manifest entries containing messages in the given
+// time range. Zero from or to leaves the range open.
+func (m Manifest) Range(from, to time.Time) []Entry {
+	var ret []Entry
+	for _, e := range m.Entries {
+		if !from.IsZero() && e.To.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !e.From.Before(to) {
+			continue
+		}
+		ret = append(ret, e)
+	}
+
+	return ret
+}
+
+// ReadManifest retrieves the manifest of the channel. An empty manifest is
+// returned if nothing has been archived for the channel yet.
+func ReadManifest(ctx context.Context, storage Storage, prefix, domainID, channelID string) (Manifest, error) {
+	m := Manifest{
+		Domain:  domainID,
+		Channel: channelID,
+	}
+	data, err := storage.Get(ctx, ManifestKey(prefix, domainID, channelID))
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		return m, nil
+	case err != nil:
+		return Manifest{}, errors.Wrap(ErrManifest, err)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, errors.Wrap(ErrManifest, err)
+	}
+
+	return m, nil
+}
+
+// ManifestKey returns the key of the manifest of the channel.
+func ManifestKey(prefix, domainID, channelID string) string {
+	return path.Join(channelPrefix(prefix, domainID, channelID), ManifestFile)
+}
+
+// fileKey returns the key of the file containing messages of the channel
+// starting from the given window start. Keys are partitioned by domain,
+// channel and date.
+func fileKey(prefix, domainID, channelID string, start time.Time, id, ext string) string {
+	date := "date=" + start.UTC().Format(dateLayout)
+	name := fmt.Sprintf("%d-%s.%s", start.UnixNano(), id, ext)
+
+	return path.Join(channelPrefix(prefix, domainID, channelID), date, name)
+}
+
+func channelPrefix(prefix, domainID, channelID string) string {
+	return path.Join(prefix, "domain="+domainID, "channel="+channelID)
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive contains the archive writer which batches messages per
+// channel and time window and stores them as compressed Parquet or NDJSON
+// files in an object store, partitioned by domain, channel and date.
+package archive
@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+)
+
+type encoder struct {
+	ext         string
+	contentType string
+	encode      func(records []Record) ([]byte, error)
+}
+
+func newEncoder(format string) (encoder, error) {
+	switch format {
+	case NDJSON:
+		return encoder{ext: "ndjson.gz", contentType: "application/x-ndjson", encode: encodeNDJSON}, nil
+	case Parquet:
+		return encoder{ext: "parquet", contentType: "application/vnd.apache.parquet", encode: encodeParquet}, nil
+	default:
+		return encoder{}, errFormat
+	}
+}
+
+func encodeNDJSON(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeParquet(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[Record](&buf, parquet.Compression(&zstd.Codec{}))
+	if _, err := w.Write(records); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
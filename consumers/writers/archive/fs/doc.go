@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fs contains the archive storage implementation using the local
+// filesystem.
+package fs
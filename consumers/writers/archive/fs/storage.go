@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package fs
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+var errInvalidKey = errors.New("invalid object key")
+
+var _ archive.Storage = (*storage)(nil)
+
+type storage struct {
+	root string
+}
+
+// New returns the archive storage which stores objects as files under the
+// root directory. Object keys are used as paths relative to the root.
+func New(root string) archive.Storage {
+	return &storage{root: root}
+}
+
+func (s *storage) Put(_ context.Context, key string, data []byte, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), dirPerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first so readers never see partial objects.
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, p)
+}
+
+func (s *storage) Get(_ context.Context, key string) ([]byte, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, archive.ErrNotFound
+	}
+
+	return data, err
+}
+
+func (s *storage) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(p, ".tmp") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *storage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if filepath.IsAbs(clean) || clean == "." || strings.HasPrefix(clean, "..") {
+		return "", errInvalidKey
+	}
+
+	return filepath.Join(s.root, clean), nil
+}
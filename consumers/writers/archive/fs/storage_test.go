@@ -0,0 +1,64 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package fs_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/consumers/writers/archive/fs"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage(t *testing.T) {
+	storage := fs.New(t.TempDir())
+	ctx := context.Background()
+	data := []byte("data")
+
+	cases := []struct {
+		desc string
+		key  string
+		err  bool
+	}{
+		{
+			desc: "put object with nested key",
+			key:  "domain=1/channel=2/date=2024-01-01/file.parquet",
+		},
+		{
+			desc: "put object outside of the root",
+			key:  "../file.parquet",
+			err:  true,
+		},
+		{
+			desc: "put object with absolute key",
+			key:  "/file.parquet",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := storage.Put(ctx, tc.key, data, "")
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		got, err := storage.Get(ctx, tc.key)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, data, got, fmt.Sprintf("%s: expected %s got %s", tc.desc, data, got))
+	}
+
+	_, err := storage.Get(ctx, "missing")
+	assert.True(t, errors.Contains(err, archive.ErrNotFound), fmt.Sprintf("expected %s got %s", archive.ErrNotFound, err))
+
+	keys, err := storage.List(ctx, "domain=1/")
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, []string{cases[0].key}, keys)
+
+	keys, err = storage.List(ctx, "domain=2/")
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Empty(t, keys)
+}
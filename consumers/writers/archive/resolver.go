@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"sync"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var errNoDomain = errors.New("channel does not belong to a domain")
+
+var _ DomainResolver = (*policyResolver)(nil)
+
+type policyResolver struct {
+	policy  magistrala.PolicyServiceClient
+	mu      sync.RWMutex
+	domains map[string]string
+}
+
+// NewDomainResolver returns a DomainResolver which looks up the channel
+// domain using the policy service. Channels cannot change the domain, so
+// the resolved domains are cached.
+func NewDomainResolver(policy magistrala.PolicyServiceClient) DomainResolver {
+	return &policyResolver{
+		policy:  policy,
+		domains: make(map[string]string),
+	}
+}
+
+func (r *policyResolver) Domain(ctx context.Context, channelID string) (string, error) {
+	r.mu.RLock()
+	domain, ok := r.domains[channelID]
+	r.mu.RUnlock()
+	if ok {
+		return domain, nil
+	}
+
+	res, err := r.policy.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+		SubjectType: auth.DomainType,
+		Permission:  auth.DomainRelation,
+		Object:      channelID,
+		ObjectType:  auth.GroupType,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(res.Policies) == 0 {
+		return "", errNoDomain
+	}
+	domain = res.Policies[0]
+
+	r.mu.Lock()
+	r.domains[channelID] = domain
+	r.mu.Unlock()
+
+	return domain, nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package s3 contains the archive storage implementation using an
+// S3-compatible object store.
+package s3
@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const noSuchKey = "NoSuchKey"
+
+var errBucket = errors.New("failed to set up archive bucket")
+
+// Config contains the S3-compatible object store configuration.
+type Config struct {
+	Endpoint     string `env:"ENDPOINT"      envDefault:"localhost:9000"`
+	Bucket       string `env:"BUCKET"        envDefault:"magistrala-archive"`
+	Region       string `env:"REGION"        envDefault:""`
+	AccessKey    string `env:"ACCESS_KEY"    envDefault:""`
+	SecretKey    string `env:"SECRET_KEY"    envDefault:""`
+	UseSSL       bool   `env:"USE_SSL"       envDefault:"false"`
+	CreateBucket bool   `env:"CREATE_BUCKET" envDefault:"true"`
+}
+
+var _ archive.Storage = (*storage)(nil)
+
+type storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// New returns the archive storage backed by the S3-compatible object store.
+// The bucket is created if it does not exist and bucket creation is enabled.
+func New(ctx context.Context, cfg Config) (archive.Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, errors.Wrap(errBucket, err)
+	}
+	if !exists && cfg.CreateBucket {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, errors.Wrap(errBucket, err)
+		}
+	}
+
+	return &storage{
+		client: client,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+func (s *storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+
+	return err
+}
+
+func (s *storage) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == noSuchKey {
+			return nil, archive.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s *storage) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		keys = append(keys, obj.Key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,278 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+type partition struct {
+	domain  string
+	channel string
+	start   time.Time
+}
+
+type buffer struct {
+	records []Record
+	created time.Time
+}
+
+var _ consumers.BlockingConsumer = (*archiver)(nil)
+
+type archiver struct {
+	storage  Storage
+	resolver DomainResolver
+	idp      magistrala.IDProvider
+	cfg      Config
+	enc      encoder
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	buffers map[partition]*buffer
+	// manifestMu serializes manifest updates.
+	manifestMu sync.Mutex
+}
+
+// New returns the archive writer. Messages are buffered per domain, channel
+// and time window and written to the storage once the buffer reaches the
+// batch size or becomes older than the window. Buffers flushed in the
+// background are logged on failure. Remaining buffers are flushed when ctx
+// is done. The writer assumes it is the only one updating the manifests
+// under the configured prefix.
+func New(ctx context.Context, storage Storage, resolver DomainResolver, idp magistrala.IDProvider, cfg Config, logger *slog.Logger) (consumers.BlockingConsumer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	enc, err := newEncoder(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	a := &archiver{
+		storage:  storage,
+		resolver: resolver,
+		idp:      idp,
+		cfg:      cfg,
+		enc:      enc,
+		logger:   logger,
+		buffers:  make(map[partition]*buffer),
+	}
+	go a.run(ctx)
+
+	return a, nil
+}
+
+func (a *archiver) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	var records []Record
+	var err error
+	switch m := messages.(type) {
+	case []senml.Message:
+		records = senmlRecords(m)
+	case mgjson.Messages:
+		records, err = jsonRecords(m)
+	default:
+		return ErrMessage
+	}
+	if err != nil {
+		return errors.Wrap(ErrArchive, err)
+	}
+
+	domains := make(map[string]string)
+	var full []partition
+	var fullBuffers []*buffer
+
+	for _, r := range records {
+		domain, ok := domains[r.Channel]
+		if !ok {
+			if domain, err = a.resolver.Domain(ctx, r.Channel); err != nil {
+				return errors.Wrap(ErrArchive, err)
+			}
+			domains[r.Channel] = domain
+		}
+		p := partition{
+			domain:  domain,
+			channel: r.Channel,
+			start:   time.Unix(0, r.Time).Truncate(a.cfg.Window),
+		}
+
+		a.mu.Lock()
+		b, ok := a.buffers[p]
+		if !ok {
+			b = &buffer{created: time.Now()}
+			a.buffers[p] = b
+		}
+		b.records = append(b.records, r)
+		if len(b.records) >= a.cfg.BatchSize {
+			delete(a.buffers, p)
+			full = append(full, p)
+			fullBuffers = append(fullBuffers, b)
+		}
+		a.mu.Unlock()
+	}
+
+	for i, p := range full {
+		if err := a.write(ctx, p, fullBuffers[i].records); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *archiver) run(ctx context.Context) {
+	// Check the buffers several times per window so files are not delayed
+	// by much more than the window duration.
+	interval := a.cfg.Window / 10
+	if interval <= 0 {
+		interval = a.cfg.Window
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Parent context is canceled, so use a fresh one for the final flush.
+			a.flush(context.Background(), true)
+			return
+		case <-ticker.C:
+			a.flush(ctx, false)
+		}
+	}
+}
+
+func (a *archiver) flush(ctx context.Context, all bool) {
+	a.mu.Lock()
+	expired := make(map[partition]*buffer)
+	for p, b := range a.buffers {
+		if all || time.Since(b.created) >= a.cfg.Window {
+			expired[p] = b
+			delete(a.buffers, p)
+		}
+	}
+	a.mu.Unlock()
+
+	for p, b := range expired {
+		if err := a.write(ctx, p, b.records); err != nil {
+			a.logger.Warn(fmt.Sprintf("Failed to archive %d messages of channel %s: %s", len(b.records), p.channel, err))
+		}
+	}
+}
+
+// write stores the records as a single file and adds it to the channel manifest.
+func (a *archiver) write(ctx context.Context, p partition, records []Record) error {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Time < records[j].Time
+	})
+
+	data, err := a.enc.encode(records)
+	if err != nil {
+		return errors.Wrap(ErrArchive, err)
+	}
+	id, err := a.idp.ID()
+	if err != nil {
+		return errors.Wrap(ErrArchive, err)
+	}
+	key := fileKey(a.cfg.Prefix, p.domain, p.channel, p.start, id, a.enc.ext)
+	if err := a.storage.Put(ctx, key, data, a.enc.contentType); err != nil {
+		return errors.Wrap(ErrArchive, err)
+	}
+
+	entry := Entry{
+		Key:     key,
+		Format:  a.cfg.Format,
+		From:    time.Unix(0, records[0].Time).UTC(),
+		To:      time.Unix(0, records[len(records)-1].Time).UTC(),
+		Count:   len(records),
+		Size:    len(data),
+		Created: time.Now().UTC(),
+	}
+
+	return a.updateManifest(ctx, p, entry)
+}
+
+func (a *archiver) updateManifest(ctx context.Context, p partition, entry Entry) error {
+	a.manifestMu.Lock()
+	defer a.manifestMu.Unlock()
+
+	m, err := ReadManifest(ctx, a.storage, a.cfg.Prefix, p.domain, p.channel)
+	if err != nil {
+		return err
+	}
+	m.Entries = append(m.Entries, entry)
+	data, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(ErrManifest, err)
+	}
+	if err := a.storage.Put(ctx, ManifestKey(a.cfg.Prefix, p.domain, p.channel), data, "application/json"); err != nil {
+		return errors.Wrap(ErrManifest, err)
+	}
+
+	return nil
+}
+
+func senmlRecords(msgs []senml.Message) []Record {
+	records := make([]Record, 0, len(msgs))
+	for _, msg := range msgs {
+		sec, dec := math.Modf(msg.Time)
+		t := time.Unix(int64(sec), int64(dec*1e9))
+		if msg.Time == 0 {
+			t = time.Now()
+		}
+		records = append(records, Record{
+			Channel:     msg.Channel,
+			Subtopic:    msg.Subtopic,
+			Publisher:   msg.Publisher,
+			Protocol:    msg.Protocol,
+			Name:        msg.Name,
+			Unit:        msg.Unit,
+			Time:        t.UnixNano(),
+			UpdateTime:  msg.UpdateTime,
+			Value:       msg.Value,
+			StringValue: msg.StringValue,
+			DataValue:   msg.DataValue,
+			BoolValue:   msg.BoolValue,
+			Sum:         msg.Sum,
+		})
+	}
+
+	return records
+}
+
+func jsonRecords(msgs mgjson.Messages) ([]Record, error) {
+	records := make([]Record, 0, len(msgs.Data))
+	for _, msg := range msgs.Data {
+		pld, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return nil, err
+		}
+		created := msg.Created
+		if created == 0 {
+			created = time.Now().UnixNano()
+		}
+		records = append(records, Record{
+			Channel:   msg.Channel,
+			Subtopic:  msg.Subtopic,
+			Publisher: msg.Publisher,
+			Protocol:  msg.Protocol,
+			Time:      created,
+			Format:    msgs.Format,
+			Payload:   pld,
+		})
+	}
+
+	return records, nil
+}
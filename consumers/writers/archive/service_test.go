@@ -0,0 +1,247 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/consumers/writers/archive/fs"
+	"github.com/absmach/magistrala/consumers/writers/archive/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	msgsNum = 10
+	prefix  = "archive"
+)
+
+var errResolve = errors.New("failed to resolve domain")
+
+func newArchiver(ctx context.Context, t *testing.T, format string, batchSize int) (archive.Storage, *mocks.DomainResolver, consumers.BlockingConsumer) {
+	storage := fs.New(t.TempDir())
+	resolver := new(mocks.DomainResolver)
+	cfg := archive.Config{
+		Format:    format,
+		Prefix:    prefix,
+		Window:    time.Hour,
+		BatchSize: batchSize,
+	}
+	svc, err := archive.New(ctx, storage, resolver, uuid.New(), cfg, mglog.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive writer: %s", err))
+
+	return storage, resolver, svc
+}
+
+func senmlMessages(chanID string, now time.Time) []senml.Message {
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		v := float64(i)
+		msgs = append(msgs, senml.Message{
+			Channel:   chanID,
+			Publisher: chanID,
+			Protocol:  "mqtt",
+			Name:      "temperature",
+			Time:      float64(now.Add(time.Duration(i)*time.Second).UnixNano()) / 1e9,
+			Value:     &v,
+		})
+	}
+
+	return msgs
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  archive.Config
+		err  bool
+	}{
+		{
+			desc: "create archive writer with parquet format",
+			cfg:  archive.Config{Format: archive.Parquet, Window: time.Hour, BatchSize: 1},
+		},
+		{
+			desc: "create archive writer with ndjson format",
+			cfg:  archive.Config{Format: archive.NDJSON, Window: time.Hour, BatchSize: 1},
+		},
+		{
+			desc: "create archive writer with invalid format",
+			cfg:  archive.Config{Format: "csv", Window: time.Hour, BatchSize: 1},
+			err:  true,
+		},
+		{
+			desc: "create archive writer with invalid window",
+			cfg:  archive.Config{Format: archive.NDJSON, BatchSize: 1},
+			err:  true,
+		},
+		{
+			desc: "create archive writer with invalid batch size",
+			cfg:  archive.Config{Format: archive.NDJSON, Window: time.Hour},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := archive.New(ctx, fs.New(t.TempDir()), new(mocks.DomainResolver), uuid.New(), tc.cfg, mglog.NewMock())
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		cancel()
+	}
+}
+
+func TestConsumeParquet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storage, resolver, svc := newArchiver(ctx, t, archive.Parquet, msgsNum)
+
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().Truncate(time.Hour)
+	msgs := senmlMessages(chanID, now)
+
+	repoCall := resolver.On("Domain", mock.Anything, chanID).Return(domainID, nil)
+	err := svc.ConsumeBlocking(ctx, msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	repoCall.Unset()
+
+	m, err := archive.ReadManifest(ctx, storage, prefix, domainID, chanID)
+	require.Nil(t, err, fmt.Sprintf("expected no error reading manifest got %s", err))
+	require.Len(t, m.Entries, 1)
+
+	entry := m.Entries[0]
+	assert.Equal(t, msgsNum, entry.Count)
+	assert.Equal(t, archive.Parquet, entry.Format)
+	assert.True(t, strings.HasPrefix(entry.Key, fmt.Sprintf("%s/domain=%s/channel=%s/date=%s/", prefix, domainID, chanID, now.UTC().Format("2006-01-02"))), fmt.Sprintf("unexpected key %s", entry.Key))
+	assert.True(t, strings.HasSuffix(entry.Key, ".parquet"), fmt.Sprintf("unexpected key %s", entry.Key))
+	assert.Len(t, m.Range(now.Add(time.Second), now.Add(2*time.Second)), 1)
+	assert.Len(t, m.Range(now.Add(time.Hour), time.Time{}), 0)
+
+	data, err := storage.Get(ctx, entry.Key)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	records, err := parquet.Read[archive.Record](bytes.NewReader(data), int64(len(data)))
+	require.Nil(t, err, fmt.Sprintf("expected no error decoding parquet got %s", err))
+	require.Len(t, records, msgsNum)
+	for i, r := range records {
+		assert.Equal(t, chanID, r.Channel)
+		assert.Equal(t, *msgs[i].Value, *r.Value)
+		assert.Nil(t, r.BoolValue)
+	}
+}
+
+func TestConsumeNDJSON(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storage, resolver, svc := newArchiver(ctx, t, archive.NDJSON, msgsNum)
+
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	msgs := mgjson.Messages{Format: "some_json"}
+	for i := 0; i < msgsNum; i++ {
+		msgs.Data = append(msgs.Data, mgjson.Message{
+			Channel:   chanID,
+			Publisher: chanID,
+			Protocol:  "http",
+			Created:   time.Now().UnixNano(),
+			Payload:   map[string]interface{}{"field": float64(i)},
+		})
+	}
+
+	repoCall := resolver.On("Domain", mock.Anything, chanID).Return(domainID, nil)
+	err := svc.ConsumeBlocking(ctx, msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	repoCall.Unset()
+
+	m, err := archive.ReadManifest(ctx, storage, prefix, domainID, chanID)
+	require.Nil(t, err, fmt.Sprintf("expected no error reading manifest got %s", err))
+	require.Len(t, m.Entries, 1)
+	assert.True(t, strings.HasSuffix(m.Entries[0].Key, ".ndjson.gz"), fmt.Sprintf("unexpected key %s", m.Entries[0].Key))
+
+	data, err := storage.Get(ctx, m.Entries[0].Key)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	var count int
+	sc := bufio.NewScanner(zr)
+	for sc.Scan() {
+		var r archive.Record
+		require.Nil(t, json.Unmarshal(sc.Bytes(), &r))
+		assert.Equal(t, msgs.Format, r.Format)
+		assert.JSONEq(t, fmt.Sprintf(`{"field":%d}`, count), string(r.Payload))
+		count++
+	}
+	assert.Equal(t, msgsNum, count)
+}
+
+func TestConsumeErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, resolver, svc := newArchiver(ctx, t, archive.NDJSON, msgsNum)
+
+	chanID := testsutil.GenerateUUID(t)
+	cases := []struct {
+		desc       string
+		msgs       interface{}
+		resolveErr error
+		err        error
+	}{
+		{
+			desc: "consume unsupported message type",
+			msgs: "invalid",
+			err:  archive.ErrMessage,
+		},
+		{
+			desc:       "consume messages with failed domain resolution",
+			msgs:       senmlMessages(chanID, time.Now()),
+			resolveErr: errResolve,
+			err:        archive.ErrArchive,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := resolver.On("Domain", mock.Anything, chanID).Return("", tc.resolveErr)
+		err := svc.ConsumeBlocking(ctx, tc.msgs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
+
+func TestFlushOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	storage, resolver, svc := newArchiver(ctx, t, archive.Parquet, 2*msgsNum)
+
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	resolver.On("Domain", mock.Anything, chanID).Return(domainID, nil)
+
+	err := svc.ConsumeBlocking(ctx, senmlMessages(chanID, time.Now()))
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	m, err := archive.ReadManifest(context.Background(), storage, prefix, domainID, chanID)
+	require.Nil(t, err, fmt.Sprintf("expected no error reading manifest got %s", err))
+	assert.Empty(t, m.Entries, "expected messages to be buffered")
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		m, err := archive.ReadManifest(context.Background(), storage, prefix, domainID, chanID)
+		return err == nil && len(m.Entries) > 0 && m.Entries[0].Count == msgsNum
+	}, 5*time.Second, 10*time.Millisecond, "expected buffered messages to be flushed on shutdown")
+}
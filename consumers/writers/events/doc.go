@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains events consumer for events
+// published by Things service and consumed by the writers.
+package events
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/magistrala/consumers"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+)
+
+const (
+	thingsStream  = "events.magistrala.things"
+	channelRemove = "group.remove"
+)
+
+// Start starts consuming the things service events, removing the stored
+// messages of the removed channels, including the channels of the deleted
+// domains. The writer instances should share the consumer.
+func Start(ctx context.Context, consumer, esURL string, remover consumers.MessageRemover, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subCfg := events.SubscriberConfig{
+		Stream:   thingsStream,
+		Consumer: consumer,
+		Handler:  NewEventHandler(remover),
+	}
+
+	return subscriber.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	remover consumers.MessageRemover
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(remover consumers.MessageRemover) events.EventHandler {
+	return &eventHandler{
+		remover: remover,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case channelRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return es.remover.RemoveChannel(ctx, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// SenMLMeasurement is the name of the measurement used for SenML messages.
+const SenMLMeasurement = "messages"
+
+var (
+	errSaveMessage    = errors.New("failed to save message to influxdb database")
+	errRemoveMessages = errors.New("failed to remove messages from influxdb database")
+)
+
+// RepoConfig contains the InfluxDB bucket and organization the messages are written to.
+type RepoConfig struct {
+	Bucket string
+	Org    string
+}
+
+var (
+	_ consumers.BlockingConsumer = (*influxRepo)(nil)
+	_ consumers.MessageRemover   = (*influxRepo)(nil)
+)
+
+type influxRepo struct {
+	writeAPI  api.WriteAPIBlocking
+	deleteAPI api.DeleteAPI
+	cfg       RepoConfig
+}
+
+// New returns new InfluxDB writer. Messages are written using the line
+// protocol; SenML messages are stored in the "messages" measurement, while
+// JSON messages are stored in the measurement named by the message format.
+func New(client influxdb2.Client, cfg RepoConfig) consumers.BlockingConsumer {
+	return &influxRepo{
+		writeAPI: client.WriteAPIBlocking(cfg.Org, cfg.Bucket),
+	}
+}
+
+// NewRemover returns new InfluxDB messages remover.
+func NewRemover(client influxdb2.Client, cfg RepoConfig) consumers.MessageRemover {
+	return &influxRepo{
+		deleteAPI: client.DeleteAPI(),
+		cfg:       cfg,
+	}
+}
+
+func (repo *influxRepo) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	var pts []*write.Point
+	var err error
+	switch m := message.(type) {
+	case mgjson.Messages:
+		pts, err = jsonPoints(m)
+	default:
+		pts, err = senmlPoints(m)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := repo.writeAPI.WritePoint(ctx, pts...); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+// RemoveChannel removes the channel messages from all the measurements of the bucket.
+func (repo *influxRepo) RemoveChannel(ctx context.Context, channelID string) error {
+	start, stop := time.Unix(0, math.MinInt64), time.Unix(0, math.MaxInt64)
+	predicate := fmt.Sprintf("channel=%q", channelID)
+	if err := repo.deleteAPI.DeleteWithName(ctx, repo.cfg.Org, repo.cfg.Bucket, start, stop, predicate); err != nil {
+		return errors.Wrap(errRemoveMessages, err)
+	}
+
+	return nil
+}
+
+func senmlPoints(messages interface{}) ([]*write.Point, error) {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return nil, errSaveMessage
+	}
+
+	var pts []*write.Point
+	for _, msg := range msgs {
+		sec, dec := math.Modf(msg.Time)
+		t := time.Unix(int64(sec), int64(dec*1e9))
+		pts = append(pts, influxdb2.NewPoint(SenMLMeasurement, senmlTags(msg), senmlFields(msg), t))
+	}
+
+	return pts, nil
+}
+
+func jsonPoints(msgs mgjson.Messages) ([]*write.Point, error) {
+	var pts []*write.Point
+	for i, m := range msgs.Data {
+		flat, err := mgjson.Flatten(m.Payload)
+		if err != nil {
+			return nil, errors.Wrap(errSaveMessage, err)
+		}
+		// Points with the same tags and time overwrite each other,
+		// so the index is added to keep the messages of the batch unique.
+		t := time.Unix(0, m.Created+int64(i))
+		flat["protocol"] = m.Protocol
+		flat["created"] = m.Created
+		pts = append(pts, influxdb2.NewPoint(msgs.Format, jsonTags(m), flat, t))
+	}
+
+	return pts, nil
+}
+
+func senmlTags(msg senml.Message) map[string]string {
+	return map[string]string{
+		"channel":   msg.Channel,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"name":      msg.Name,
+	}
+}
+
+func senmlFields(msg senml.Message) map[string]interface{} {
+	ret := map[string]interface{}{
+		"protocol":    msg.Protocol,
+		"unit":        msg.Unit,
+		"update_time": msg.UpdateTime,
+	}
+	switch {
+	case msg.Value != nil:
+		ret["value"] = *msg.Value
+	case msg.StringValue != nil:
+		ret["string_value"] = *msg.StringValue
+	case msg.DataValue != nil:
+		ret["data_value"] = *msg.DataValue
+	case msg.BoolValue != nil:
+		ret["bool_value"] = *msg.BoolValue
+	}
+	if msg.Sum != nil {
+		ret["sum"] = *msg.Sum
+	}
+
+	return ret
+}
+
+func jsonTags(msg mgjson.Message) map[string]string {
+	return map[string]string{
+		"channel":   msg.Channel,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/writers/influxdb"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	msgsNum     = 42
+	valueFields = 5
+	subtopic    = "topic"
+)
+
+var (
+	v       float64 = 5
+	stringV         = "value"
+	boolV           = true
+	dataV           = "base64"
+	sum     float64 = 42
+
+	repoCfg = influxdb.RepoConfig{
+		Bucket: dbBucket,
+		Org:    dbOrg,
+	}
+)
+
+func TestSaveSenml(t *testing.T) {
+	repo := influxdb.New(client, repoCfg)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := senml.Message{
+		Channel:   chid.String(),
+		Publisher: pubid.String(),
+		Protocol:  "mqtt",
+	}
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Subtopic = subtopic
+			msg.Value = &v
+		case 1:
+			msg.BoolValue = &boolV
+		case 2:
+			msg.StringValue = &stringV
+		case 3:
+			msg.DataValue = &dataV
+		case 4:
+			msg.Sum = &sum
+		}
+
+		msg.Time = float64(now + int64(i))
+		msgs = append(msgs, msg)
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	query := fmt.Sprintf(`from(bucket: "%s") |> range(start: 0) |> filter(fn: (r) => r._measurement == "%s" and r.channel == "%s" and r._field == "protocol") |> count()`, dbBucket, influxdb.SenMLMeasurement, chid.String())
+	res, err := client.QueryAPI(dbOrg).Query(context.TODO(), query)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	var total int64
+	for res.Next() {
+		total += res.Record().Value().(int64)
+	}
+	assert.Equal(t, int64(msgsNum), total, fmt.Sprintf("expected %d saved messages got %d\n", msgsNum, total))
+}
+
+func TestSaveJSON(t *testing.T) {
+	repo := influxdb.New(client, repoCfg)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := json.Message{
+		Channel:   chid.String(),
+		Publisher: pubid.String(),
+		Created:   time.Now().UnixNano(),
+		Subtopic:  "subtopic/format/some_json",
+		Protocol:  "mqtt",
+		Payload: map[string]interface{}{
+			"field_1": 123,
+			"field_2": "value",
+			"field_3": false,
+			"field_4": 12.344,
+			"field_5": map[string]interface{}{
+				"field_1": "value",
+				"field_2": 42,
+			},
+		},
+	}
+
+	invalid := msg
+	invalid.Payload = map[string]interface{}{
+		"field_1/": "value",
+	}
+
+	now := time.Now().UnixNano()
+	msgs := json.Messages{
+		Format: "some_json",
+	}
+	for i := 0; i < msgsNum; i++ {
+		msg.Created = now + int64(i)
+		msgs.Data = append(msgs.Data, msg)
+	}
+
+	cases := []struct {
+		desc string
+		msgs json.Messages
+		err  bool
+	}{
+		{
+			desc: "consume valid json messages",
+			msgs: msgs,
+		},
+		{
+			desc: "consume json messages with invalid payload keys",
+			msgs: json.Messages{
+				Format: "some_json",
+				Data:   []json.Message{invalid},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.ConsumeBlocking(context.TODO(), tc.msgs)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+	}
+}
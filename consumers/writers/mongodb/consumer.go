@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SenMLCollection is the name of the collection used for SenML messages.
+const SenMLCollection = "messages"
+
+var (
+	errSaveMessage    = errors.New("failed to save message to mongodb database")
+	errRemoveMessages = errors.New("failed to remove messages from mongodb database")
+)
+
+var (
+	_ consumers.BlockingConsumer = (*mongoRepo)(nil)
+	_ consumers.MessageRemover   = (*mongoRepo)(nil)
+)
+
+type mongoRepo struct {
+	db *mongo.Database
+}
+
+// New returns new MongoDB writer. SenML messages are stored in the "messages"
+// collection, while JSON messages are stored in the collection named by the
+// message format.
+func New(db *mongo.Database) consumers.BlockingConsumer {
+	return &mongoRepo{db: db}
+}
+
+// NewRemover returns new MongoDB messages remover.
+func NewRemover(db *mongo.Database) consumers.MessageRemover {
+	return &mongoRepo{db: db}
+}
+
+func (repo *mongoRepo) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	switch m := message.(type) {
+	case mgjson.Messages:
+		return repo.saveJSON(ctx, m)
+	default:
+		return repo.saveSenml(ctx, m)
+	}
+}
+
+func (repo *mongoRepo) saveSenml(ctx context.Context, messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	var docs []interface{}
+	for _, msg := range msgs {
+		docs = append(docs, msg)
+	}
+
+	if _, err := repo.db.Collection(SenMLCollection).InsertMany(ctx, docs); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (repo *mongoRepo) saveJSON(ctx context.Context, msgs mgjson.Messages) error {
+	if len(msgs.Data) == 0 {
+		return nil
+	}
+
+	var docs []interface{}
+	for _, msg := range msgs.Data {
+		docs = append(docs, msg)
+	}
+
+	if _, err := repo.db.Collection(msgs.Format).InsertMany(ctx, docs); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+// RemoveChannel removes the channel messages from the SenML messages
+// collection and from the collections of the JSON messages formats.
+func (repo *mongoRepo) RemoveChannel(ctx context.Context, channelID string) error {
+	colls, err := repo.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return errors.Wrap(errRemoveMessages, err)
+	}
+
+	for _, coll := range colls {
+		if _, err := repo.db.Collection(coll).DeleteMany(ctx, bson.D{{Key: "channel", Value: channelID}}); err != nil {
+			return errors.Wrap(errRemoveMessages, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mongodb contains repository implementations using MongoDB as
+// the underlying database.
+package mongodb
@@ -23,9 +23,13 @@ var (
 	errSaveMessage    = errors.New("failed to save message to postgres database")
 	errTransRollback  = errors.New("failed to rollback transaction")
 	errNoTable        = errors.New("relation does not exist")
+	errRemoveMessages = errors.New("failed to remove messages from postgres database")
 )
 
-var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
+var (
+	_ consumers.BlockingConsumer = (*postgresRepo)(nil)
+	_ consumers.MessageRemover   = (*postgresRepo)(nil)
+)
 
 type postgresRepo struct {
 	db *sqlx.DB
@@ -36,6 +40,11 @@ func New(db *sqlx.DB) consumers.BlockingConsumer {
 	return &postgresRepo{db: db}
 }
 
+// NewRemover returns new PostgreSQL messages remover.
+func NewRemover(db *sqlx.DB) consumers.MessageRemover {
+	return &postgresRepo{db: db}
+}
+
 func (pr postgresRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
 	switch m := message.(type) {
 	case mgjson.Messages:
@@ -152,6 +161,25 @@ func (pr postgresRepo) insertJSON(ctx context.Context, msgs mgjson.Messages) err
 	return nil
 }
 
+// RemoveChannel removes the channel messages from the SenML messages table
+// and from the tables of the JSON messages formats.
+func (pr postgresRepo) RemoveChannel(ctx context.Context, channelID string) error {
+	var tables []string
+	q := `SELECT table_name FROM information_schema.columns WHERE table_schema = current_schema() AND column_name = 'channel'`
+	if err := pr.db.SelectContext(ctx, &tables, q); err != nil {
+		return errors.Wrap(errRemoveMessages, err)
+	}
+
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %q WHERE channel::text = $1`, table)
+		if _, err := pr.db.ExecContext(ctx, q, channelID); err != nil {
+			return errors.Wrap(errRemoveMessages, err)
+		}
+	}
+
+	return nil
+}
+
 func (pr postgresRepo) createTable(name string) error {
 	q := `CREATE TABLE IF NOT EXISTS %s (
             id            UUID,
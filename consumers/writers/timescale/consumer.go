@@ -22,9 +22,13 @@ var (
 	errSaveMessage    = errors.New("failed to save message to timescale database")
 	errTransRollback  = errors.New("failed to rollback transaction")
 	errNoTable        = errors.New("relation does not exist")
+	errRemoveMessages = errors.New("failed to remove messages from timescale database")
 )
 
-var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
+var (
+	_ consumers.BlockingConsumer = (*timescaleRepo)(nil)
+	_ consumers.MessageRemover   = (*timescaleRepo)(nil)
+)
 
 type timescaleRepo struct {
 	db *sqlx.DB
@@ -35,6 +39,11 @@ func New(db *sqlx.DB) consumers.BlockingConsumer {
 	return &timescaleRepo{db: db}
 }
 
+// NewRemover returns new TimescaleSQL messages remover.
+func NewRemover(db *sqlx.DB) consumers.MessageRemover {
+	return &timescaleRepo{db: db}
+}
+
 func (tr *timescaleRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
 	switch m := message.(type) {
 	case mgjson.Messages:
@@ -146,6 +155,25 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs mgjson.Messages) er
 	return nil
 }
 
+// RemoveChannel removes the channel messages from the SenML messages table
+// and from the tables of the JSON messages formats.
+func (tr *timescaleRepo) RemoveChannel(ctx context.Context, channelID string) error {
+	var tables []string
+	q := `SELECT table_name FROM information_schema.columns WHERE table_schema = current_schema() AND column_name = 'channel'`
+	if err := tr.db.SelectContext(ctx, &tables, q); err != nil {
+		return errors.Wrap(errRemoveMessages, err)
+	}
+
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %q WHERE channel::text = $1`, table)
+		if _, err := tr.db.ExecContext(ctx, q, channelID); err != nil {
+			return errors.Wrap(errRemoveMessages, err)
+		}
+	}
+
+	return nil
+}
+
 func (tr timescaleRepo) createTable(name string) error {
 	q := `CREATE TABLE IF NOT EXISTS %s (
             created       BIGINT NOT NULL,
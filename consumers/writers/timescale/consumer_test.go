@@ -110,3 +110,36 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.ConsumeBlocking(context.TODO(), msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestRemoveChannel(t *testing.T) {
+	repo := timescale.New(db)
+	remover := timescale.NewRemover(db)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Value:     &v,
+			Time:      float64(now + int64(i)),
+		}
+		msgs = append(msgs, msg)
+	}
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	err = remover.RemoveChannel(context.TODO(), chid.String())
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM messages WHERE channel = $1", chid.String()).Scan(&count)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, 0, count, fmt.Sprintf("expected no messages of the removed channel got %d\n", count))
+}
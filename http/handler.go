@@ -146,6 +146,7 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		SubjectType: auth.ThingType,
 		Permission:  auth.PublishPermission,
 		ObjectType:  auth.GroupType,
+		Subtopic:    msg.Subtopic,
 	}
 	res, err := h.things.Authorize(ctx, ar)
 	if err != nil {
@@ -108,6 +108,7 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, svcerr.ErrAuthorization),
 		errors.Contains(err, svcerr.ErrDomainAuthorization),
+		errors.Contains(err, svcerr.ErrEmailNotVerified),
 		errors.Contains(err, bootstrap.ErrExternalKey),
 		errors.Contains(err, bootstrap.ErrExternalKeySecure):
 		err = unwrap(err)
@@ -120,6 +121,9 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnauthorized)
 	case errors.Contains(err, svcerr.ErrMalformedEntity),
 		errors.Contains(err, apiutil.ErrMalformedPolicy),
+		errors.Contains(err, apiutil.ErrMissingPolicyObj),
+		errors.Contains(err, apiutil.ErrMissingPolicyEntityType),
+		errors.Contains(err, apiutil.ErrMalformedPolicyPer),
 		errors.Contains(err, apiutil.ErrMissingSecret),
 		errors.Contains(err, errors.ErrMalformedEntity),
 		errors.Contains(err, apiutil.ErrMissingID),
@@ -160,9 +164,23 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrInvalidEntityType),
 		errors.Contains(err, apiutil.ErrMissingEntityType),
 		errors.Contains(err, apiutil.ErrInvalidTimeFormat),
+		errors.Contains(err, apiutil.ErrMissingFrom),
+		errors.Contains(err, apiutil.ErrInvalidTimeRange),
+		errors.Contains(err, apiutil.ErrInvalidRate),
 		errors.Contains(err, svcerr.ErrSearch),
 		errors.Contains(err, apiutil.ErrEmptySearchQuery),
-		errors.Contains(err, apiutil.ErrLenSearchQuery):
+		errors.Contains(err, apiutil.ErrLenSearchQuery),
+		errors.Contains(err, apiutil.ErrInvalidURL),
+		errors.Contains(err, apiutil.ErrInvalidScope),
+		errors.Contains(err, apiutil.ErrMissingMFAToken),
+		errors.Contains(err, apiutil.ErrMissingMFACode),
+		errors.Contains(err, apiutil.ErrMissingVerificationToken),
+		errors.Contains(err, apiutil.ErrMissingInvitationCode),
+		errors.Contains(err, apiutil.ErrInvalidInvitee),
+		errors.Contains(err, apiutil.ErrMissingProvider),
+		errors.Contains(err, apiutil.ErrMissingQuotaResource),
+		errors.Contains(err, apiutil.ErrMissingClaimGroup),
+		errors.Contains(err, svcerr.ErrPasswordPolicy):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusBadRequest)
 
@@ -187,6 +205,12 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err = unwrap(err)
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 
+	case errors.Contains(err, svcerr.ErrLoginLocked),
+		errors.Contains(err, svcerr.ErrQuotaExceeded),
+		errors.Contains(err, svcerr.ErrTooManyRequests):
+		err = unwrap(err)
+		w.WriteHeader(http.StatusTooManyRequests)
+
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -235,6 +235,7 @@ func TestEncodeError(t *testing.T) {
 				apiutil.ErrLimitSize,
 				apiutil.ErrNameSize,
 				svcerr.ErrViewEntity,
+				svcerr.ErrPasswordPolicy,
 			},
 			code: http.StatusBadRequest,
 		},
@@ -294,6 +295,15 @@ func TestEncodeError(t *testing.T) {
 			},
 			code: http.StatusUnsupportedMediaType,
 		},
+		{
+			desc: "TooManyRequests",
+			errs: []error{
+				svcerr.ErrLoginLocked,
+				svcerr.ErrQuotaExceeded,
+				svcerr.ErrTooManyRequests,
+			},
+			code: http.StatusTooManyRequests,
+		},
 		{
 			desc: "StatusUnprocessableEntity",
 			errs: []error{
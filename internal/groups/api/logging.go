@@ -248,3 +248,20 @@ func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string)
 	}(time.Now())
 	return lm.svc.DeleteGroup(ctx, token, id)
 }
+
+func (lm *loggingMiddleware) DeleteDomainGroups(ctx context.Context, domainID string) (ids []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Int("deleted", len(ids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete domain groups failed", args...)
+			return
+		}
+		lm.logger.Info("Delete domain groups completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteDomainGroups(ctx, domainID)
+}
@@ -127,3 +127,12 @@ func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, token, id string)
 	}(time.Now())
 	return ms.svc.DeleteGroup(ctx, token, id)
 }
+
+// DeleteDomainGroups instruments DeleteDomainGroups method with metrics.
+func (ms *metricsMiddleware) DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_domain_groups").Add(1)
+		ms.latency.With("method", "delete_domain_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteDomainGroups(ctx, domainID)
+}
@@ -73,6 +73,7 @@ func (cge unassignEvent) Encode() (map[string]interface{}, error) {
 
 type createGroupEvent struct {
 	groups.Group
+	kind string
 }
 
 func (cge createGroupEvent) Encode() (map[string]interface{}, error) {
@@ -83,6 +84,10 @@ func (cge createGroupEvent) Encode() (map[string]interface{}, error) {
 		"created_at": cge.CreatedAt,
 	}
 
+	if cge.kind != "" {
+		val["kind"] = cge.kind
+	}
+
 	if cge.Domain != "" {
 		val["domain"] = cge.Domain
 	}
@@ -6,6 +6,7 @@ package events
 import (
 	"context"
 
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
@@ -39,7 +40,8 @@ func (es eventStore) CreateGroup(ctx context.Context, token, kind string, group
 	}
 
 	event := createGroupEvent{
-		group,
+		Group: group,
+		kind:  kind,
 	}
 
 	if err := es.Publish(ctx, event); err != nil {
@@ -209,3 +211,15 @@ func (es eventStore) DeleteGroup(ctx context.Context, token, id string) error {
 	}
 	return nil
 }
+
+func (es eventStore) DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error) {
+	ids, err := es.svc.DeleteDomainGroups(ctx, domainID)
+	// The groups deleted before the failure are published as well.
+	for _, id := range ids {
+		if errPublish := es.Publish(ctx, deleteGroupEvent{id}); errPublish != nil {
+			return ids, errors.Wrap(errPublish, err)
+		}
+	}
+
+	return ids, err
+}
@@ -19,6 +19,10 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// deleteBatch is the number of the groups of the deleted domain
+// retrieved at once.
+const deleteBatch = 100
+
 var (
 	errParentUnAuthz = errors.New("failed to authorize parent group")
 	errMemberKind    = errors.New("invalid member kind")
@@ -48,7 +52,7 @@ func (svc service) CreateGroup(ctx context.Context, token, kind string, g groups
 		return groups.Group{}, err
 	}
 	// If domain is disabled , then this authorization will fail for all non-admin domain users
-	if _, err := svc.authorizeKind(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.CreatePermission, auth.DomainType, res.GetDomainId()); err != nil {
+	if _, err := svc.authorizeKind(ctx, "", auth.UserType, auth.TokenKind, token, auth.CreatePermission, auth.DomainType, res.GetDomainId()); err != nil {
 		return groups.Group{}, err
 	}
 	groupID, err := svc.idProvider.ID()
@@ -69,6 +73,20 @@ func (svc service) CreateGroup(ctx context.Context, token, kind string, g groups
 		}
 	}
 
+	// Only the channels are limited by the domain quotas.
+	if kind == auth.NewChannelKind {
+		if _, err = svc.auth.ReserveQuota(ctx, &magistrala.ReserveQuotaReq{DomainId: res.GetDomainId(), Resource: auth.ChannelsQuota, Ids: []string{groupID}}); err != nil {
+			return groups.Group{}, err
+		}
+		defer func() {
+			if err != nil {
+				if _, errRelease := svc.auth.ReleaseQuota(ctx, &magistrala.ReleaseQuotaReq{DomainId: res.GetDomainId(), Resource: auth.ChannelsQuota, Ids: []string{groupID}}); errRelease != nil {
+					err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRelease), err)
+				}
+			}
+		}()
+	}
+
 	if err := svc.addGroupPolicy(ctx, res.GetId(), res.GetDomainId(), g.ID, g.Parent, kind); err != nil {
 		return groups.Group{}, err
 	}
@@ -119,7 +137,7 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 	}
 	switch memberKind {
 	case auth.ThingsKind:
-		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.ViewPermission, auth.ThingType, memberID); err != nil {
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.ViewPermission, auth.ThingType, memberID); err != nil {
 			return groups.Page{}, err
 		}
 		cids, err := svc.policy.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
@@ -131,12 +149,12 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 		if err != nil {
 			return groups.Page{}, err
 		}
-		ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), gm.Permission, cids.Policies)
+		ids, err = svc.filterAllowedGroupIDs(ctx, token, gm.Permission, cids.Policies)
 		if err != nil {
 			return groups.Page{}, err
 		}
 	case auth.GroupsKind:
-		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), gm.Permission, auth.GroupType, memberID); err != nil {
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, gm.Permission, auth.GroupType, memberID); err != nil {
 			return groups.Page{}, err
 		}
 
@@ -149,12 +167,12 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 		if err != nil {
 			return groups.Page{}, err
 		}
-		ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), gm.Permission, gids.Policies)
+		ids, err = svc.filterAllowedGroupIDs(ctx, token, gm.Permission, gids.Policies)
 		if err != nil {
 			return groups.Page{}, err
 		}
 	case auth.ChannelsKind:
-		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.ViewPermission, auth.GroupType, memberID); err != nil {
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.ViewPermission, auth.GroupType, memberID); err != nil {
 			return groups.Page{}, err
 		}
 		gids, err := svc.policy.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
@@ -167,14 +185,14 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 			return groups.Page{}, err
 		}
 
-		ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), gm.Permission, gids.Policies)
+		ids, err = svc.filterAllowedGroupIDs(ctx, token, gm.Permission, gids.Policies)
 		if err != nil {
 			return groups.Page{}, err
 		}
 	case auth.UsersKind:
 		switch {
 		case memberID != "" && res.GetUserId() != memberID:
-			if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
+			if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
 				return groups.Page{}, err
 			}
 			gids, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
@@ -186,20 +204,21 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 			if err != nil {
 				return groups.Page{}, err
 			}
-			ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), gm.Permission, gids.Policies)
+			ids, err = svc.filterAllowedGroupIDs(ctx, token, gm.Permission, gids.Policies)
 			if err != nil {
 				return groups.Page{}, err
 			}
 		default:
-			switch svc.checkSuperAdmin(ctx, res.GetUserId()) {
+			switch svc.checkSuperAdmin(ctx, token) {
 			case nil:
 				gm.PageMeta.DomainID = res.GetDomainId()
 			default:
-				// If domain is disabled , then this authorization will fail for all non-admin domain users
+				// If domain is disabled , then this authorization will fail for all non-admin domain users.
+				// The groups are listed with the token, so the scoped API keys list only the groups of their scopes.
 				if _, err := svc.authorizeKind(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.MembershipPermission, auth.DomainType, res.GetDomainId()); err != nil {
 					return groups.Page{}, err
 				}
-				ids, err = svc.listAllGroupsOfUserID(ctx, res.GetId(), gm.Permission)
+				ids, err = svc.listAllGroupIDs(ctx, token, gm.Permission)
 				if err != nil {
 					return groups.Page{}, err
 				}
@@ -257,10 +276,11 @@ func (svc service) listUserGroupPermission(ctx context.Context, userID, groupID
 	return lp.GetPermissions(), nil
 }
 
-func (svc service) checkSuperAdmin(ctx context.Context, userID string) error {
+func (svc service) checkSuperAdmin(ctx context.Context, token string) error {
 	res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  auth.AdminPermission,
 		ObjectType:  auth.PlatformType,
 		Object:      auth.MagistralaObject,
@@ -379,7 +399,7 @@ func (svc service) Assign(ctx context.Context, token, groupID, relation, memberK
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, groupID); err != nil {
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.GroupType, groupID); err != nil {
 		return err
 	}
 
@@ -530,7 +550,7 @@ func (svc service) Unassign(ctx context.Context, token, groupID, relation, membe
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, groupID); err != nil {
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.GroupType, groupID); err != nil {
 		return err
 	}
 
@@ -588,7 +608,7 @@ func (svc service) DeleteGroup(ctx context.Context, token, id string) error {
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.GroupType, id); err != nil {
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.GroupType, id); err != nil {
 		return err
 	}
 
@@ -610,9 +630,48 @@ func (svc service) DeleteGroup(ctx context.Context, token, id string) error {
 	return nil
 }
 
-func (svc service) filterAllowedGroupIDsOfUserID(ctx context.Context, userID, permission string, groupIDs []string) ([]string, error) {
+func (svc service) DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error) {
+	page := groups.Page{
+		PageMeta: groups.PageMeta{
+			Limit:    deleteBatch,
+			DomainID: domainID,
+			Status:   mgclients.AllStatus,
+		},
+	}
+
+	// The deleted groups are not retrieved again, so the first page is
+	// retrieved until the domain has no groups left.
+	var ids []string
+	for {
+		gp, err := svc.groups.RetrieveByIDs(ctx, page)
+		if err != nil {
+			return ids, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(gp.Groups) == 0 {
+			return ids, nil
+		}
+		for _, g := range gp.Groups {
+			deleteRes, err := svc.policy.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
+				EntityType: auth.GroupType,
+				Id:         g.ID,
+			})
+			if err != nil {
+				return ids, errors.Wrap(svcerr.ErrDeletePolicies, err)
+			}
+			if !deleteRes.Deleted {
+				return ids, svcerr.ErrDeletePolicies
+			}
+			if err := svc.groups.Delete(ctx, g.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			ids = append(ids, g.ID)
+		}
+	}
+}
+
+func (svc service) filterAllowedGroupIDs(ctx context.Context, token, permission string, groupIDs []string) ([]string, error) {
 	var ids []string
-	allowedIDs, err := svc.listAllGroupsOfUserID(ctx, userID, permission)
+	allowedIDs, err := svc.listAllGroupIDs(ctx, token, permission)
 	if err != nil {
 		return []string{}, err
 	}
@@ -627,10 +686,13 @@ func (svc service) filterAllowedGroupIDsOfUserID(ctx context.Context, userID, pe
 	return ids, nil
 }
 
-func (svc service) listAllGroupsOfUserID(ctx context.Context, userID, permission string) ([]string, error) {
+// listAllGroupIDs lists the groups of the token user, limited to the groups
+// of the token scopes.
+func (svc service) listAllGroupIDs(ctx context.Context, token, permission string) ([]string, error) {
 	allowedIDs, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  permission,
 		ObjectType:  auth.GroupType,
 	})
@@ -69,6 +69,7 @@ func TestCreateGroup(t *testing.T) {
 		addPolErr     error
 		deletePolResp *magistrala.DeletePolicyRes
 		deletePolErr  error
+		quotaErr      error
 		err           error
 	}{
 		{
@@ -95,6 +96,42 @@ func TestCreateGroup(t *testing.T) {
 				Added: true,
 			},
 		},
+		{
+			desc:  "channel successfully",
+			token: token,
+			kind:  auth.NewChannelKind,
+			group: validGroup,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			repoResp: mggroups.Group{
+				ID:        testsutil.GenerateUUID(t),
+				CreatedAt: time.Now(),
+				Domain:    testsutil.GenerateUUID(t),
+			},
+			addPolResp: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+		},
+		{
+			desc:  "channel with exceeded channels quota",
+			token: token,
+			kind:  auth.NewChannelKind,
+			group: validGroup,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			quotaErr: svcerr.ErrQuotaExceeded,
+			err:      svcerr.ErrQuotaExceeded,
+		},
 		{
 			desc:   "with invalid token",
 			token:  token,
@@ -291,8 +328,8 @@ func TestCreateGroup(t *testing.T) {
 			authCall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
 			authCall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.idResp.GetId(),
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
 				Permission:  auth.CreatePermission,
 				Object:      tc.idResp.GetDomainId(),
 				ObjectType:  auth.DomainType,
@@ -305,6 +342,8 @@ func TestCreateGroup(t *testing.T) {
 				Object:      tc.group.Parent,
 				ObjectType:  auth.GroupType,
 			}).Return(tc.authzTknResp, tc.authzTknErr)
+			quotaCall := authsvc.On("ReserveQuota", context.Background(), mock.Anything).Return(&magistrala.ReserveQuotaRes{}, tc.quotaErr)
+			quotaCall1 := authsvc.On("ReleaseQuota", context.Background(), mock.Anything).Return(&magistrala.ReleaseQuotaRes{Released: true}, nil)
 			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(tc.repoResp, tc.repoErr)
 			authCall3 := policy.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPolResp, tc.addPolErr)
 			authCall4 := policy.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePolResp, tc.deletePolErr)
@@ -318,12 +357,24 @@ func TestCreateGroup(t *testing.T) {
 				ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.Anything)
 				assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
 			}
+			if tc.kind == auth.NewChannelKind && tc.authzErr == nil && tc.idErr == nil {
+				ok := authsvc.AssertCalled(t, "ReserveQuota", context.Background(), mock.MatchedBy(func(req *magistrala.ReserveQuotaReq) bool {
+					return req.GetDomainId() == tc.idResp.GetDomainId() && req.GetResource() == auth.ChannelsQuota && len(req.GetIds()) == 1
+				}))
+				assert.True(t, ok, fmt.Sprintf("ReserveQuota was not called on %s", tc.desc))
+			}
+			if tc.kind == auth.NewChannelKind && tc.quotaErr == nil && (tc.addPolErr != nil || tc.repoErr != nil) {
+				ok := authsvc.AssertCalled(t, "ReleaseQuota", context.Background(), mock.Anything)
+				assert.True(t, ok, fmt.Sprintf("ReleaseQuota was not called on %s", tc.desc))
+			}
 			authCall.Unset()
 			authCall1.Unset()
 			authCall2.Unset()
 			repoCall.Unset()
 			authCall3.Unset()
 			authCall4.Unset()
+			quotaCall.Unset()
+			quotaCall1.Unset()
 		})
 	}
 }
@@ -1535,8 +1586,8 @@ func TestListGroups(t *testing.T) {
 				authCall1 = authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 					Domain:      tc.idResp.GetDomainId(),
 					SubjectType: auth.UserType,
-					SubjectKind: auth.UsersKind,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  auth.ViewPermission,
 					Object:      tc.memberID,
 					ObjectType:  auth.ThingType,
@@ -1549,7 +1600,8 @@ func TestListGroups(t *testing.T) {
 				}).Return(tc.listSubjectResp, tc.listSubjectErr)
 				authCall3 = policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 					SubjectType: auth.UserType,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  tc.page.Permission,
 					ObjectType:  auth.GroupType,
 				}).Return(tc.listObjectFilterResp, tc.listObjectFilterErr)
@@ -1557,8 +1609,8 @@ func TestListGroups(t *testing.T) {
 				authCall1 = authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 					Domain:      tc.idResp.GetDomainId(),
 					SubjectType: auth.UserType,
-					SubjectKind: auth.UsersKind,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  tc.page.Permission,
 					Object:      tc.memberID,
 					ObjectType:  auth.GroupType,
@@ -1571,7 +1623,8 @@ func TestListGroups(t *testing.T) {
 				}).Return(tc.listObjectResp, tc.listObjectErr)
 				authCall3 = policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 					SubjectType: auth.UserType,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  tc.page.Permission,
 					ObjectType:  auth.GroupType,
 				}).Return(tc.listObjectFilterResp, tc.listObjectFilterErr)
@@ -1579,8 +1632,8 @@ func TestListGroups(t *testing.T) {
 				authCall1 = authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 					Domain:      tc.idResp.GetDomainId(),
 					SubjectType: auth.UserType,
-					SubjectKind: auth.UsersKind,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  auth.ViewPermission,
 					Object:      tc.memberID,
 					ObjectType:  auth.GroupType,
@@ -1593,14 +1646,16 @@ func TestListGroups(t *testing.T) {
 				}).Return(tc.listSubjectResp, tc.listSubjectErr)
 				authCall3 = policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 					SubjectType: auth.UserType,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  tc.page.Permission,
 					ObjectType:  auth.GroupType,
 				}).Return(tc.listObjectFilterResp, tc.listObjectFilterErr)
 			case auth.UsersKind:
 				adminCheckReq := &magistrala.AuthorizeReq{
 					SubjectType: auth.UserType,
-					Subject:     tc.idResp.GetUserId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  auth.AdminPermission,
 					Object:      auth.MagistralaObject,
 					ObjectType:  auth.PlatformType,
@@ -1609,14 +1664,16 @@ func TestListGroups(t *testing.T) {
 				authReq := &magistrala.AuthorizeReq{
 					Domain:      tc.idResp.GetDomainId(),
 					SubjectType: auth.UserType,
-					SubjectKind: auth.UsersKind,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  auth.AdminPermission,
 					Object:      tc.idResp.GetDomainId(),
 					ObjectType:  auth.DomainType,
 				}
 				if tc.memberID == "" {
 					authReq.Domain = ""
+					authReq.SubjectKind = auth.UsersKind
+					authReq.Subject = tc.idResp.GetId()
 					authReq.Permission = auth.MembershipPermission
 				}
 				authCall1 = authsvc.On("Authorize", context.Background(), authReq).Return(tc.authzResp, tc.authzErr)
@@ -1628,7 +1685,8 @@ func TestListGroups(t *testing.T) {
 				}).Return(tc.listObjectResp, tc.listObjectErr)
 				authCall3 = policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 					SubjectType: auth.UserType,
-					Subject:     tc.idResp.GetId(),
+					SubjectKind: auth.TokenKind,
+					Subject:     tc.token,
 					Permission:  tc.page.Permission,
 					ObjectType:  auth.GroupType,
 				}).Return(tc.listObjectFilterResp, tc.listObjectFilterErr)
@@ -1982,8 +2040,8 @@ func TestAssign(t *testing.T) {
 			authCall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 				Domain:      tc.idResp.GetDomainId(),
 				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.idResp.GetId(),
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
 				Permission:  auth.EditPermission,
 				Object:      tc.groupID,
 				ObjectType:  auth.GroupType,
@@ -2392,8 +2450,8 @@ func TestUnassign(t *testing.T) {
 			authCall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 				Domain:      tc.idResp.GetDomainId(),
 				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.idResp.GetId(),
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
 				Permission:  auth.EditPermission,
 				Object:      tc.groupID,
 				ObjectType:  auth.GroupType,
@@ -2576,8 +2634,8 @@ func TestDeleteGroup(t *testing.T) {
 			authCall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 				Domain:      tc.idResp.GetDomainId(),
 				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.idResp.GetId(),
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
 				Permission:  auth.DeletePermission,
 				Object:      tc.groupID,
 				ObjectType:  auth.GroupType,
@@ -2596,3 +2654,95 @@ func TestDeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteDomainGroups(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthServiceClient)
+	policy := new(authmocks.PolicyServiceClient)
+	svc := groups.NewService(repo, idProvider, authsvc, policy)
+
+	domainID := testsutil.GenerateUUID(t)
+	group := mggroups.Group{
+		ID:     testsutil.GenerateUUID(t),
+		Domain: domainID,
+	}
+
+	cases := []struct {
+		desc              string
+		domainID          string
+		retrieveResp      mggroups.Page
+		retrieveErr       error
+		deletePoliciesRes *magistrala.DeletePolicyRes
+		deletePoliciesErr error
+		repoErr           error
+		ids               []string
+		err               error
+	}{
+		{
+			desc:         "successfully",
+			domainID:     domainID,
+			retrieveResp: mggroups.Page{Groups: []mggroups.Group{group}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{
+				Deleted: true,
+			},
+			ids: []string{group.ID},
+		},
+		{
+			desc:         "successfully with the domain without groups",
+			domainID:     domainID,
+			retrieveResp: mggroups.Page{},
+		},
+		{
+			desc:        "unsuccessfully with failed to retrieve groups",
+			domainID:    domainID,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "unsuccessfully with failed to remove policy",
+			domainID:     domainID,
+			retrieveResp: mggroups.Page{Groups: []mggroups.Group{group}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{
+				Deleted: false,
+			},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:         "unsuccessfully with repo err",
+			domainID:     domainID,
+			retrieveResp: mggroups.Page{Groups: []mggroups.Group{group}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{
+				Deleted: true,
+			},
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page := mggroups.Page{
+				PageMeta: mggroups.PageMeta{
+					Limit:    100,
+					DomainID: tc.domainID,
+					Status:   clients.AllStatus,
+				},
+			}
+			// The first page is retrieved until the domain has no groups left.
+			repo.On("RetrieveByIDs", context.Background(), page, mock.Anything).Return(tc.retrieveResp, tc.retrieveErr).Once()
+			repoCall := repo.On("RetrieveByIDs", context.Background(), page, mock.Anything).Return(mggroups.Page{}, nil)
+			authCall := policy.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
+				EntityType: auth.GroupType,
+				Id:         group.ID,
+			}).Return(tc.deletePoliciesRes, tc.deletePoliciesErr)
+			repoCall1 := repo.On("Delete", context.Background(), group.ID).Return(tc.repoErr)
+			ids, err := svc.DeleteDomainGroups(context.Background(), tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.ids, ids)
+			repoCall.Unset()
+			authCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
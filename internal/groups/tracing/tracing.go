@@ -110,3 +110,11 @@ func (tm *tracingMiddleware) DeleteGroup(ctx context.Context, token, id string)
 
 	return tm.gsvc.DeleteGroup(ctx, token, id)
 }
+
+// DeleteDomainGroups traces the "DeleteDomainGroups" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_domain_groups", trace.WithAttributes(attribute.String("domain_id", domainID)))
+	defer span.End()
+
+	return tm.gsvc.DeleteDomainGroups(ctx, domainID)
+}
@@ -24,6 +24,7 @@ func sendInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 
 		invitation := invitations.Invitation{
 			UserID:   req.UserID,
+			Email:    req.Email,
 			DomainID: req.DomainID,
 			Relation: req.Relation,
 			Resend:   req.Resend,
@@ -57,6 +58,24 @@ func viewInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 	}
 }
 
+func viewEmailInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emailInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		invitation, err := svc.ViewEmailInvitation(ctx, req.token, req.email, req.domainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewInvitationRes{
+			Invitation: invitation,
+		}, nil
+	}
+}
+
 func listInvitationsEndpoint(svc invitations.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listInvitationsReq)
@@ -90,6 +109,24 @@ func acceptInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 	}
 }
 
+func signUpEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(signUpReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		invitation, err := svc.SignUp(ctx, req.Code, req.Name, req.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return signUpRes{
+			Invitation: invitation,
+		}, nil
+	}
+}
+
 func rejectInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(acceptInvitationReq)
@@ -119,3 +156,18 @@ func deleteInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 		return deleteInvitationRes{}, nil
 	}
 }
+
+func deleteEmailInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emailInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DeleteEmailInvitation(ctx, req.token, req.email, req.domainID); err != nil {
+			return nil, err
+		}
+
+		return deleteInvitationRes{}, nil
+	}
+}
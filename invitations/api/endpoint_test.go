@@ -26,6 +26,7 @@ var (
 	validToken      = "valid"
 	validContenType = "application/json"
 	validID         = testsutil.GenerateUUID(&testing.T{})
+	validEmail      = "invitee@example.com"
 )
 
 type testRequest struct {
@@ -388,6 +389,73 @@ func TestViewInvitation(t *testing.T) {
 	}
 }
 
+func TestViewEmailInvitation(t *testing.T) {
+	is, svc := newIvitationsServer()
+
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		email       string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "valid request",
+			token:       validToken,
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusOK,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "invalid token",
+			token:       "",
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "with service error",
+			token:       validToken,
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusForbidden,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "with empty email",
+			token:       validToken,
+			email:       "",
+			domainID:    validID,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := svc.On("ViewEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID).Return(invitations.Invitation{}, tc.svcErr)
+		req := testRequest{
+			client:      is.Client(),
+			method:      http.MethodGet,
+			url:         is.URL + "/invitations/email/" + tc.email + "/" + tc.domainID,
+			token:       tc.token,
+			contentType: tc.contentType,
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+		repoCall.Unset()
+	}
+}
+
 func TestDeleteInvitation(t *testing.T) {
 	is, svc := newIvitationsServer()
 
@@ -473,6 +541,73 @@ func TestDeleteInvitation(t *testing.T) {
 	}
 }
 
+func TestDeleteEmailInvitation(t *testing.T) {
+	is, svc := newIvitationsServer()
+
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		email       string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "valid request",
+			token:       validToken,
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusNoContent,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "invalid token",
+			token:       "",
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "with service error",
+			token:       validToken,
+			email:       validEmail,
+			domainID:    validID,
+			status:      http.StatusForbidden,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "with empty email",
+			token:       validToken,
+			email:       "",
+			domainID:    validID,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := svc.On("DeleteEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID).Return(tc.svcErr)
+		req := testRequest{
+			client:      is.Client(),
+			method:      http.MethodDelete,
+			url:         is.URL + "/invitations/email/" + tc.email + "/" + tc.domainID,
+			token:       tc.token,
+			contentType: tc.contentType,
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+		repoCall.Unset()
+	}
+}
+
 func TestAcceptInvitation(t *testing.T) {
 	is, svc := newIvitationsServer()
 
@@ -544,6 +679,72 @@ func TestAcceptInvitation(t *testing.T) {
 	}
 }
 
+func TestSignUp(t *testing.T) {
+	is, svc := newIvitationsServer()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		svcRes      invitations.Invitation
+		svcErr      error
+	}{
+		{
+			desc:        "valid request",
+			data:        fmt.Sprintf(`{"code": "%s", "name": "invitee", "secret": "12345678"}`, validID),
+			status:      http.StatusCreated,
+			contentType: validContenType,
+			svcRes:      invitations.Invitation{UserID: validID, DomainID: validID},
+			svcErr:      nil,
+		},
+		{
+			desc:        "missing code",
+			data:        `{"name": "invitee", "secret": "12345678"}`,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "with service error",
+			data:        fmt.Sprintf(`{"code": "%s", "name": "invitee", "secret": "12345678"}`, validID),
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "invalid content type",
+			data:        fmt.Sprintf(`{"code": "%s", "name": "invitee", "secret": "12345678"}`, validID),
+			status:      http.StatusUnsupportedMediaType,
+			contentType: "text/plain",
+			svcErr:      nil,
+		},
+		{
+			desc:        "invalid data",
+			data:        `data`,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := svc.On("SignUp", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+		req := testRequest{
+			client:      is.Client(),
+			method:      http.MethodPost,
+			url:         is.URL + "/invitations/signup",
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+		repoCall.Unset()
+	}
+}
+
 func TestRejectInvitation(t *testing.T) {
 	is, svc := newIvitationsServer()
 
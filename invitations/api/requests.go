@@ -17,6 +17,7 @@ var errMissingDomain = errors.New("missing domain")
 type sendInvitationReq struct {
 	token    string
 	UserID   string `json:"user_id,omitempty"`
+	Email    string `json:"email,omitempty"`
 	DomainID string `json:"domain_id,omitempty"`
 	Relation string `json:"relation,omitempty"`
 	Resend   bool   `json:"resend,omitempty"`
@@ -26,9 +27,12 @@ func (req *sendInvitationReq) validate() error {
 	if req.token == "" {
 		return apiutil.ErrBearerToken
 	}
-	if req.UserID == "" {
+	if req.UserID == "" && req.Email == "" {
 		return apiutil.ErrMissingID
 	}
+	if req.UserID != "" && req.Email != "" {
+		return apiutil.ErrInvalidInvitee
+	}
 	if req.DomainID == "" {
 		return errMissingDomain
 	}
@@ -71,6 +75,26 @@ func (req *acceptInvitationReq) validate() error {
 	return nil
 }
 
+type signUpReq struct {
+	Code   string `json:"code,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Secret string `json:"secret,omitempty"`
+}
+
+func (req *signUpReq) validate() error {
+	if req.Code == "" {
+		return apiutil.ErrMissingInvitationCode
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+	if req.Secret == "" {
+		return apiutil.ErrMissingSecret
+	}
+
+	return nil
+}
+
 type invitationReq struct {
 	token    string
 	userID   string
@@ -90,3 +114,23 @@ func (req *invitationReq) validate() error {
 
 	return nil
 }
+
+type emailInvitationReq struct {
+	token    string
+	email    string
+	domainID string
+}
+
+func (req *emailInvitationReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.email == "" {
+		return apiutil.ErrMissingEmail
+	}
+	if req.domainID == "" {
+		return errMissingDomain
+	}
+
+	return nil
+}
@@ -54,6 +54,27 @@ func TestSendInvitationReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrMissingID,
 		},
+		{
+			desc: "valid request with email",
+			req: sendInvitationReq{
+				token:    valid,
+				Email:    "invitee@example.com",
+				DomainID: valid,
+				Relation: auth.DomainRelation,
+			},
+			err: nil,
+		},
+		{
+			desc: "both user ID and email",
+			req: sendInvitationReq{
+				token:    valid,
+				UserID:   valid,
+				Email:    "invitee@example.com",
+				DomainID: valid,
+				Relation: auth.DomainRelation,
+			},
+			err: apiutil.ErrInvalidInvitee,
+		},
 		{
 			desc: "empty domain_id",
 			req: sendInvitationReq{
@@ -170,6 +191,53 @@ func TestAcceptInvitationReq(t *testing.T) {
 	}
 }
 
+func TestSignUpReq(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  signUpReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: signUpReq{
+				Code:   valid,
+				Name:   valid,
+				Secret: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty code",
+			req: signUpReq{
+				Name:   valid,
+				Secret: valid,
+			},
+			err: apiutil.ErrMissingInvitationCode,
+		},
+		{
+			desc: "empty name",
+			req: signUpReq{
+				Code:   valid,
+				Secret: valid,
+			},
+			err: apiutil.ErrMissingName,
+		},
+		{
+			desc: "empty secret",
+			req: signUpReq{
+				Code: valid,
+				Name: valid,
+			},
+			err: apiutil.ErrMissingSecret,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestInvitationReqValidation(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -219,3 +287,53 @@ func TestInvitationReqValidation(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestEmailInvitationReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  emailInvitationReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: emailInvitationReq{
+				token:    valid,
+				email:    "invitee@example.com",
+				domainID: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: emailInvitationReq{
+				token:    "",
+				email:    "invitee@example.com",
+				domainID: valid,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty email",
+			req: emailInvitationReq{
+				token:    valid,
+				email:    "",
+				domainID: valid,
+			},
+			err: apiutil.ErrMissingEmail,
+		},
+		{
+			desc: "empty domain",
+			req: emailInvitationReq{
+				token:    valid,
+				email:    "invitee@example.com",
+				domainID: "",
+			},
+			err: errMissingDomain,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
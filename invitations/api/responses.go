@@ -15,6 +15,7 @@ var (
 	_ magistrala.Response = (*viewInvitationRes)(nil)
 	_ magistrala.Response = (*listInvitationsRes)(nil)
 	_ magistrala.Response = (*acceptInvitationRes)(nil)
+	_ magistrala.Response = (*signUpRes)(nil)
 	_ magistrala.Response = (*rejectInvitationRes)(nil)
 	_ magistrala.Response = (*deleteInvitationRes)(nil)
 )
@@ -81,6 +82,22 @@ func (res acceptInvitationRes) Empty() bool {
 	return true
 }
 
+type signUpRes struct {
+	invitations.Invitation `json:",inline"`
+}
+
+func (res signUpRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res signUpRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res signUpRes) Empty() bool {
+	return false
+}
+
 type deleteInvitationRes struct{}
 
 func (res deleteInvitationRes) Code() int {
@@ -32,6 +32,7 @@ const (
 func MakeHandler(svc invitations.Service, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	mux := chi.NewRouter()
@@ -63,6 +64,22 @@ func MakeHandler(svc invitations.Service, logger *slog.Logger, instanceID string
 				opts...,
 			), "delete_invitation").ServeHTTP)
 		})
+		// The invitations sent by email have no user ID until the invited
+		// user signs up, so they are addressed by the email.
+		r.Route("/email/{email}/{domain_id}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewEmailInvitationEndpoint(svc),
+				decodeEmailInvitationReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_email_invitation").ServeHTTP)
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteEmailInvitationEndpoint(svc),
+				decodeEmailInvitationReq,
+				api.EncodeResponse,
+				opts...,
+			), "delete_email_invitation").ServeHTTP)
+		})
 		r.Post("/accept", otelhttp.NewHandler(kithttp.NewServer(
 			acceptInvitationEndpoint(svc),
 			decodeAcceptInvitationReq,
@@ -75,6 +92,12 @@ func MakeHandler(svc invitations.Service, logger *slog.Logger, instanceID string
 			api.EncodeResponse,
 			opts...,
 		), "reject_invitation").ServeHTTP)
+		r.Post("/signup", otelhttp.NewHandler(kithttp.NewServer(
+			signUpEndpoint(svc),
+			decodeSignUpReq,
+			api.EncodeResponse,
+			opts...,
+		), "sign_up").ServeHTTP)
 	})
 
 	mux.Get("/health", magistrala.Health("invitations", instanceID))
@@ -161,6 +184,19 @@ func decodeAcceptInvitationReq(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeSignUpReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req signUpReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeInvitationReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := invitationReq{
 		token:    apiutil.ExtractBearerToken(r),
@@ -170,3 +206,13 @@ func decodeInvitationReq(_ context.Context, r *http.Request) (interface{}, error
 
 	return req, nil
 }
+
+func decodeEmailInvitationReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := emailInvitationReq{
+		token:    apiutil.ExtractBearerToken(r),
+		email:    chi.URLParam(r, "email"),
+		domainID: chi.URLParam(r, "domain_id"),
+	}
+
+	return req, nil
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package invitations
+
+// Emailer wrapper around the email.
+//
+//go:generate mockery --name Emailer --output=./mocks --filename emailer.go --quiet --note "Copyright (c) Abstract Machines"
+type Emailer interface {
+	// SendInvitation sends an email with the sign-up link to the invited email.
+	SendInvitation(To []string, user, code string) error
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package emailer
+
+import (
+	"fmt"
+
+	"github.com/absmach/magistrala/internal/email"
+	"github.com/absmach/magistrala/invitations"
+)
+
+var _ invitations.Emailer = (*emailer)(nil)
+
+type emailer struct {
+	signUpURL string
+	agent     *email.Agent
+}
+
+// New creates new emailer utility.
+func New(signUpURL string, c *email.Config) (invitations.Emailer, error) {
+	e, err := email.New(c)
+	return &emailer{signUpURL: signUpURL, agent: e}, err
+}
+
+func (e *emailer) SendInvitation(to []string, user, code string) error {
+	url := fmt.Sprintf("%s?code=%s", e.signUpURL, code)
+	return e.agent.Send(to, "", "Invitation", "", user, url, "")
+}
@@ -13,11 +13,16 @@ import (
 )
 
 // Invitation is an invitation to join a domain.
+// The invitation is sent either to the existing user with the given user ID,
+// or to the email that has no account yet. The email invitation is
+// identified by the code sent in the sign-up link.
 type Invitation struct {
 	InvitedBy   string    `json:"invited_by"`
 	UserID      string    `json:"user_id"`
+	Email       string    `json:"email,omitempty"`
 	DomainID    string    `json:"domain_id"`
 	Token       string    `json:"token,omitempty"`
+	Code        string    `json:"-"`
 	Relation    string    `json:"relation,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
@@ -66,6 +71,8 @@ func (page InvitationPage) MarshalJSON() ([]byte, error) {
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
 	// SendInvitation sends an invitation to the given user.
+	// If the invitation has an email instead of the user ID, the sign-up
+	// link with the invitation code is sent to the email.
 	// Only domain administrators and platform administrators can send invitations.
 	SendInvitation(ctx context.Context, token string, invitation Invitation) (err error)
 
@@ -77,6 +84,14 @@ type Service interface {
 	// - platform administrators
 	ViewInvitation(ctx context.Context, token, userID, domainID string) (invitation Invitation, err error)
 
+	// ViewEmailInvitation returns the pending invitation sent to the email
+	// that has no account yet.
+	// People who can view email invitations are:
+	// - the user who sent the invitation
+	// - domain administrators
+	// - platform administrators
+	ViewEmailInvitation(ctx context.Context, token, email, domainID string) (invitation Invitation, err error)
+
 	// ListInvitations returns a list of invitations.
 	// People who can list invitations are:
 	// - platform administrators can list all invitations
@@ -87,6 +102,10 @@ type Service interface {
 	// AcceptInvitation accepts an invitation by adding the user to the domain.
 	AcceptInvitation(ctx context.Context, token, domainID string) (err error)
 
+	// SignUp registers the user with the email of the invitation that has
+	// the given code and accepts the invitation on behalf of the user.
+	SignUp(ctx context.Context, code, name, secret string) (invitation Invitation, err error)
+
 	// DeleteInvitation deletes an invitation.
 	// People who can delete invitations are:
 	// - the invited user: they can delete their own invitations
@@ -95,6 +114,14 @@ type Service interface {
 	// - platform administrators
 	DeleteInvitation(ctx context.Context, token, userID, domainID string) (err error)
 
+	// DeleteEmailInvitation deletes the pending invitation sent to the email
+	// that has no account yet.
+	// People who can delete email invitations are:
+	// - the user who sent the invitation
+	// - domain administrators
+	// - platform administrators
+	DeleteEmailInvitation(ctx context.Context, token, email, domainID string) (err error)
+
 	// RejectInvitation rejects an invitation.
 	// People who can reject invitations are:
 	// - the invited user: they can reject their own invitations
@@ -109,20 +136,35 @@ type Repository interface {
 	// Retrieve returns an invitation.
 	Retrieve(ctx context.Context, userID, domainID string) (Invitation, error)
 
+	// RetrieveByEmail returns the pending invitation sent to the email
+	// that has no account yet.
+	RetrieveByEmail(ctx context.Context, email, domainID string) (Invitation, error)
+
+	// RetrieveByCode returns the pending invitation sent by email with the given code.
+	RetrieveByCode(ctx context.Context, code string) (Invitation, error)
+
 	// RetrieveAll returns a list of invitations based on the given page.
 	RetrieveAll(ctx context.Context, page Page) (invitations InvitationPage, err error)
 
-	// UpdateToken updates an invitation by setting the token.
+	// UpdateToken updates an invitation by setting the token and the code.
 	UpdateToken(ctx context.Context, invitation Invitation) (err error)
 
 	// UpdateConfirmation updates an invitation by setting the confirmation time.
 	UpdateConfirmation(ctx context.Context, invitation Invitation) (err error)
 
+	// UpdateSignUp updates the invitation with the given code by setting
+	// the user ID of the signed up user and the confirmation time.
+	UpdateSignUp(ctx context.Context, invitation Invitation) (err error)
+
 	// UpdateRejection updates an invitation by setting the rejection time.
 	UpdateRejection(ctx context.Context, invitation Invitation) (err error)
 
 	// Delete deletes an invitation.
 	Delete(ctx context.Context, userID, domainID string) (err error)
+
+	// DeleteByEmail deletes the pending invitation sent to the email
+	// that has no account yet.
+	DeleteByEmail(ctx context.Context, email, domainID string) (err error)
 }
 
 // CheckRelation checks if the given relation is valid.
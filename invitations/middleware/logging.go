@@ -56,6 +56,22 @@ func (lm *logging) ViewInvitation(ctx context.Context, token, userID, domainID s
 	return lm.svc.ViewInvitation(ctx, token, userID, domainID)
 }
 
+func (lm *logging) ViewEmailInvitation(ctx context.Context, token, email, domainID string) (invitation invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View email invitation failed", args...)
+			return
+		}
+		lm.logger.Info("View email invitation completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewEmailInvitation(ctx, token, email, domainID)
+}
+
 func (lm *logging) ListInvitations(ctx context.Context, token string, page invitations.Page) (invs invitations.InvitationPage, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -92,6 +108,23 @@ func (lm *logging) AcceptInvitation(ctx context.Context, token, domainID string)
 	return lm.svc.AcceptInvitation(ctx, token, domainID)
 }
 
+func (lm *logging) SignUp(ctx context.Context, code, name, secret string) (invitation invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", invitation.UserID),
+			slog.String("domain_id", invitation.DomainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Sign up failed", args...)
+			return
+		}
+		lm.logger.Info("Sign up completed successfully", args...)
+	}(time.Now())
+	return lm.svc.SignUp(ctx, code, name, secret)
+}
+
 func (lm *logging) RejectInvitation(ctx context.Context, token, domainID string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -124,3 +157,19 @@ func (lm *logging) DeleteInvitation(ctx context.Context, token, userID, domainID
 	}(time.Now())
 	return lm.svc.DeleteInvitation(ctx, token, userID, domainID)
 }
+
+func (lm *logging) DeleteEmailInvitation(ctx context.Context, token, email, domainID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete email invitation failed", args...)
+			return
+		}
+		lm.logger.Info("Delete email invitation completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteEmailInvitation(ctx, token, email, domainID)
+}
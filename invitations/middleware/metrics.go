@@ -43,6 +43,14 @@ func (mm *metricsmw) ViewInvitation(ctx context.Context, token, userID, domainID
 	return mm.svc.ViewInvitation(ctx, token, userID, domainID)
 }
 
+func (mm *metricsmw) ViewEmailInvitation(ctx context.Context, token, email, domainID string) (invitation invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_email_invitation").Add(1)
+		mm.latency.With("method", "view_email_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.ViewEmailInvitation(ctx, token, email, domainID)
+}
+
 func (mm *metricsmw) ListInvitations(ctx context.Context, token string, page invitations.Page) (invs invitations.InvitationPage, err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "list_invitations").Add(1)
@@ -59,6 +67,14 @@ func (mm *metricsmw) AcceptInvitation(ctx context.Context, token, domainID strin
 	return mm.svc.AcceptInvitation(ctx, token, domainID)
 }
 
+func (mm *metricsmw) SignUp(ctx context.Context, code, name, secret string) (invitation invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "sign_up").Add(1)
+		mm.latency.With("method", "sign_up").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.SignUp(ctx, code, name, secret)
+}
+
 func (mm *metricsmw) RejectInvitation(ctx context.Context, token, domainID string) (err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "reject_invitation").Add(1)
@@ -74,3 +90,11 @@ func (mm *metricsmw) DeleteInvitation(ctx context.Context, token, userID, domain
 	}(time.Now())
 	return mm.svc.DeleteInvitation(ctx, token, userID, domainID)
 }
+
+func (mm *metricsmw) DeleteEmailInvitation(ctx context.Context, token, email, domainID string) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_email_invitation").Add(1)
+		mm.latency.With("method", "delete_email_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.DeleteEmailInvitation(ctx, token, email, domainID)
+}
@@ -42,6 +42,15 @@ func (tm *tracing) ViewInvitation(ctx context.Context, token, userID, domain str
 	return tm.svc.ViewInvitation(ctx, token, userID, domain)
 }
 
+func (tm *tracing) ViewEmailInvitation(ctx context.Context, token, email, domainID string) (invitation invitations.Invitation, err error) {
+	ctx, span := tm.tracer.Start(ctx, "view_email_invitation", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+
+	return tm.svc.ViewEmailInvitation(ctx, token, email, domainID)
+}
+
 func (tm *tracing) ListInvitations(ctx context.Context, token string, page invitations.Page) (invs invitations.InvitationPage, err error) {
 	ctx, span := tm.tracer.Start(ctx, "list_invitations", trace.WithAttributes(
 		attribute.Int("limit", int(page.Limit)),
@@ -64,6 +73,13 @@ func (tm *tracing) AcceptInvitation(ctx context.Context, token, domainID string)
 	return tm.svc.AcceptInvitation(ctx, token, domainID)
 }
 
+func (tm *tracing) SignUp(ctx context.Context, code, name, secret string) (invitation invitations.Invitation, err error) {
+	ctx, span := tm.tracer.Start(ctx, "sign_up")
+	defer span.End()
+
+	return tm.svc.SignUp(ctx, code, name, secret)
+}
+
 func (tm *tracing) RejectInvitation(ctx context.Context, token, domainID string) (err error) {
 	ctx, span := tm.tracer.Start(ctx, "reject_invitation", trace.WithAttributes(
 		attribute.String("domain_id", domainID),
@@ -82,3 +98,12 @@ func (tm *tracing) DeleteInvitation(ctx context.Context, token, userID, domainID
 
 	return tm.svc.DeleteInvitation(ctx, token, userID, domainID)
 }
+
+func (tm *tracing) DeleteEmailInvitation(ctx context.Context, token, email, domainID string) (err error) {
+	ctx, span := tm.tracer.Start(ctx, "delete_email_invitation", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+
+	return tm.svc.DeleteEmailInvitation(ctx, token, email, domainID)
+}
@@ -0,0 +1,44 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Emailer is an autogenerated mock type for the Emailer type
+type Emailer struct {
+	mock.Mock
+}
+
+// SendInvitation provides a mock function with given fields: To, user, code
+func (_m *Emailer) SendInvitation(To []string, user string, code string) error {
+	ret := _m.Called(To, user, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, string, string) error); ok {
+		r0 = rf(To, user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEmailer creates a new instance of Emailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmailer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Emailer {
+	mock := &Emailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -52,6 +52,24 @@ func (_m *Repository) Delete(ctx context.Context, userID string, domainID string
 	return r0
 }
 
+// DeleteByEmail provides a mock function with given fields: ctx, email, domainID
+func (_m *Repository) DeleteByEmail(ctx context.Context, email string, domainID string) error {
+	ret := _m.Called(ctx, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Retrieve provides a mock function with given fields: ctx, userID, domainID
 func (_m *Repository) Retrieve(ctx context.Context, userID string, domainID string) (invitations.Invitation, error) {
 	ret := _m.Called(ctx, userID, domainID)
@@ -108,6 +126,62 @@ func (_m *Repository) RetrieveAll(ctx context.Context, page invitations.Page) (i
 	return r0, r1
 }
 
+// RetrieveByCode provides a mock function with given fields: ctx, code
+func (_m *Repository) RetrieveByCode(ctx context.Context, code string) (invitations.Invitation, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByCode")
+	}
+
+	var r0 invitations.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (invitations.Invitation, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) invitations.Invitation); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(invitations.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveByEmail provides a mock function with given fields: ctx, email, domainID
+func (_m *Repository) RetrieveByEmail(ctx context.Context, email string, domainID string) (invitations.Invitation, error) {
+	ret := _m.Called(ctx, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByEmail")
+	}
+
+	var r0 invitations.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (invitations.Invitation, error)); ok {
+		return rf(ctx, email, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) invitations.Invitation); ok {
+		r0 = rf(ctx, email, domainID)
+	} else {
+		r0 = ret.Get(0).(invitations.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateConfirmation provides a mock function with given fields: ctx, invitation
 func (_m *Repository) UpdateConfirmation(ctx context.Context, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, invitation)
@@ -144,6 +218,24 @@ func (_m *Repository) UpdateRejection(ctx context.Context, invitation invitation
 	return r0
 }
 
+// UpdateSignUp provides a mock function with given fields: ctx, invitation
+func (_m *Repository) UpdateSignUp(ctx context.Context, invitation invitations.Invitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSignUp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, invitations.Invitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateToken provides a mock function with given fields: ctx, invitation
 func (_m *Repository) UpdateToken(ctx context.Context, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, invitation)
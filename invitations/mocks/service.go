@@ -34,6 +34,24 @@ func (_m *Service) AcceptInvitation(ctx context.Context, token string, domainID
 	return r0
 }
 
+// DeleteEmailInvitation provides a mock function with given fields: ctx, token, email, domainID
+func (_m *Service) DeleteEmailInvitation(ctx context.Context, token string, email string, domainID string) error {
+	ret := _m.Called(ctx, token, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, email, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteInvitation provides a mock function with given fields: ctx, token, userID, domainID
 func (_m *Service) DeleteInvitation(ctx context.Context, token string, userID string, domainID string) error {
 	ret := _m.Called(ctx, token, userID, domainID)
@@ -116,6 +134,62 @@ func (_m *Service) SendInvitation(ctx context.Context, token string, invitation
 	return r0
 }
 
+// SignUp provides a mock function with given fields: ctx, code, name, secret
+func (_m *Service) SignUp(ctx context.Context, code string, name string, secret string) (invitations.Invitation, error) {
+	ret := _m.Called(ctx, code, name, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignUp")
+	}
+
+	var r0 invitations.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (invitations.Invitation, error)); ok {
+		return rf(ctx, code, name, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) invitations.Invitation); ok {
+		r0 = rf(ctx, code, name, secret)
+	} else {
+		r0 = ret.Get(0).(invitations.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, name, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewEmailInvitation provides a mock function with given fields: ctx, token, email, domainID
+func (_m *Service) ViewEmailInvitation(ctx context.Context, token string, email string, domainID string) (invitations.Invitation, error) {
+	ret := _m.Called(ctx, token, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewEmailInvitation")
+	}
+
+	var r0 invitations.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (invitations.Invitation, error)); ok {
+		return rf(ctx, token, email, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) invitations.Invitation); ok {
+		r0 = rf(ctx, token, email, domainID)
+	} else {
+		r0 = ret.Get(0).(invitations.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, email, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ViewInvitation provides a mock function with given fields: ctx, token, userID, domainID
 func (_m *Service) ViewInvitation(ctx context.Context, token string, userID string, domainID string) (invitations.Invitation, error) {
 	ret := _m.Called(ctx, token, userID, domainID)
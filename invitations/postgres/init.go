@@ -43,6 +43,29 @@ func Migration() *migrate.MemoryMigrationSource {
 					 DROP COLUMN rejected_at`,
 				},
 			},
+			{
+				// Invitations sent by email have no user ID until the invited
+				// user signs up, so the user ID is unique only when set.
+				Id: "invitations_03_add_email",
+				Up: []string{
+					`ALTER TABLE invitations
+					 DROP CONSTRAINT IF EXISTS invitations_pkey,
+					 DROP CONSTRAINT IF EXISTS invitations_user_id_domain_id_key,
+					 ADD COLUMN email VARCHAR(254) NOT NULL DEFAULT '',
+					 ADD COLUMN code VARCHAR(36) UNIQUE`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS invitations_user_id_domain_id_key ON invitations (user_id, domain_id) WHERE user_id <> ''`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS invitations_email_domain_id_key ON invitations (email, domain_id) WHERE user_id = ''`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS invitations_email_domain_id_key`,
+					`DROP INDEX IF EXISTS invitations_user_id_domain_id_key`,
+					`DELETE FROM invitations WHERE user_id = ''`,
+					`ALTER TABLE invitations
+					 DROP COLUMN code,
+					 DROP COLUMN email,
+					 ADD PRIMARY KEY (user_id, domain_id)`,
+				},
+			},
 		},
 	}
 }
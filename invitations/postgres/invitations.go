@@ -24,8 +24,8 @@ func NewRepository(db postgres.Database) invitations.Repository {
 }
 
 func (repo *repository) Create(ctx context.Context, invitation invitations.Invitation) (err error) {
-	q := `INSERT INTO invitations (invited_by, user_id, domain_id, token, relation, created_at)
-		VALUES (:invited_by, :user_id, :domain_id, :token, :relation, :created_at)`
+	q := `INSERT INTO invitations (invited_by, user_id, email, domain_id, token, code, relation, created_at)
+		VALUES (:invited_by, :user_id, :email, :domain_id, :token, :code, :relation, :created_at)`
 
 	dbInv := toDBInvitation(invitation)
 	if _, err = repo.db.NamedExecContext(ctx, q, dbInv); err != nil {
@@ -36,7 +36,7 @@ func (repo *repository) Create(ctx context.Context, invitation invitations.Invit
 }
 
 func (repo *repository) Retrieve(ctx context.Context, userID, domainID string) (invitations.Invitation, error) {
-	q := `SELECT invited_by, user_id, domain_id, token, relation, created_at, updated_at, confirmed_at FROM invitations WHERE user_id = :user_id AND domain_id = :domain_id;`
+	q := `SELECT invited_by, user_id, email, domain_id, token, relation, created_at, updated_at, confirmed_at FROM invitations WHERE user_id = :user_id AND domain_id = :domain_id;`
 
 	dbinv := dbInvitation{
 		UserID:   userID,
@@ -60,10 +60,59 @@ func (repo *repository) Retrieve(ctx context.Context, userID, domainID string) (
 	return invitations.Invitation{}, repoerr.ErrNotFound
 }
 
+func (repo *repository) RetrieveByEmail(ctx context.Context, email, domainID string) (invitations.Invitation, error) {
+	q := `SELECT invited_by, user_id, email, domain_id, token, relation, created_at, updated_at, confirmed_at FROM invitations
+		WHERE email = :email AND domain_id = :domain_id AND user_id = '';`
+
+	dbinv := dbInvitation{
+		Email:    email,
+		DomainID: domainID,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbinv)
+	if err != nil {
+		return invitations.Invitation{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbinv = dbInvitation{}
+	if rows.Next() {
+		if err = rows.StructScan(&dbinv); err != nil {
+			return invitations.Invitation{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toInvitation(dbinv), nil
+	}
+
+	return invitations.Invitation{}, repoerr.ErrNotFound
+}
+
+func (repo *repository) RetrieveByCode(ctx context.Context, code string) (invitations.Invitation, error) {
+	q := `SELECT invited_by, user_id, email, domain_id, token, code, relation, created_at, updated_at, confirmed_at FROM invitations
+		WHERE code = :code AND user_id = '' AND confirmed_at IS NULL AND rejected_at IS NULL;`
+
+	dbinv := dbInvitation{Code: sql.NullString{String: code, Valid: true}}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbinv)
+	if err != nil {
+		return invitations.Invitation{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbinv = dbInvitation{}
+	if rows.Next() {
+		if err = rows.StructScan(&dbinv); err != nil {
+			return invitations.Invitation{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toInvitation(dbinv), nil
+	}
+
+	return invitations.Invitation{}, repoerr.ErrNotFound
+}
+
 func (repo *repository) RetrieveAll(ctx context.Context, page invitations.Page) (invitations.InvitationPage, error) {
 	query := pageQuery(page)
 
-	q := fmt.Sprintf("SELECT invited_by, user_id, domain_id, relation, created_at, updated_at, confirmed_at FROM invitations %s LIMIT :limit OFFSET :offset;", query)
+	q := fmt.Sprintf("SELECT invited_by, user_id, email, domain_id, relation, created_at, updated_at, confirmed_at FROM invitations %s LIMIT :limit OFFSET :offset;", query)
 
 	rows, err := repo.db.NamedQueryContext(ctx, q, page)
 	if err != nil {
@@ -98,7 +147,7 @@ func (repo *repository) RetrieveAll(ctx context.Context, page invitations.Page)
 }
 
 func (repo *repository) UpdateToken(ctx context.Context, invitation invitations.Invitation) (err error) {
-	q := `UPDATE invitations SET token = :token, updated_at = :updated_at WHERE user_id = :user_id AND domain_id = :domain_id`
+	q := `UPDATE invitations SET token = :token, code = :code, updated_at = :updated_at WHERE user_id = :user_id AND email = :email AND domain_id = :domain_id`
 
 	dbinv := toDBInvitation(invitation)
 	result, err := repo.db.NamedExecContext(ctx, q, dbinv)
@@ -127,6 +176,21 @@ func (repo *repository) UpdateConfirmation(ctx context.Context, invitation invit
 	return nil
 }
 
+func (repo *repository) UpdateSignUp(ctx context.Context, invitation invitations.Invitation) (err error) {
+	q := `UPDATE invitations SET user_id = :user_id, code = NULL, confirmed_at = :confirmed_at, updated_at = :updated_at WHERE code = :code`
+
+	dbinv := toDBInvitation(invitation)
+	result, err := repo.db.NamedExecContext(ctx, q, dbinv)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
 func (repo *repository) UpdateRejection(ctx context.Context, invitation invitations.Invitation) (err error) {
 	q := `UPDATE invitations SET rejected_at = :rejected_at, updated_at = :updated_at WHERE user_id = :user_id AND domain_id = :domain_id`
 
@@ -156,6 +220,20 @@ func (repo *repository) Delete(ctx context.Context, userID, domain string) (err
 	return nil
 }
 
+func (repo *repository) DeleteByEmail(ctx context.Context, email, domain string) (err error) {
+	q := `DELETE FROM invitations WHERE email = $1 AND domain_id = $2 AND user_id = ''`
+
+	result, err := repo.db.ExecContext(ctx, q, email, domain)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
 func pageQuery(pm invitations.Page) string {
 	var query []string
 	var emq string
@@ -192,18 +270,24 @@ func pageQuery(pm invitations.Page) string {
 }
 
 type dbInvitation struct {
-	InvitedBy   string       `db:"invited_by"`
-	UserID      string       `db:"user_id"`
-	DomainID    string       `db:"domain_id"`
-	Token       string       `db:"token,omitempty"`
-	Relation    string       `db:"relation"`
-	CreatedAt   time.Time    `db:"created_at"`
-	UpdatedAt   sql.NullTime `db:"updated_at,omitempty"`
-	ConfirmedAt sql.NullTime `db:"confirmed_at,omitempty"`
-	RejectedAt  sql.NullTime `db:"rejected_at,omitempty"`
+	InvitedBy   string         `db:"invited_by"`
+	UserID      string         `db:"user_id"`
+	Email       string         `db:"email"`
+	DomainID    string         `db:"domain_id"`
+	Token       string         `db:"token,omitempty"`
+	Code        sql.NullString `db:"code,omitempty"`
+	Relation    string         `db:"relation"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   sql.NullTime   `db:"updated_at,omitempty"`
+	ConfirmedAt sql.NullTime   `db:"confirmed_at,omitempty"`
+	RejectedAt  sql.NullTime   `db:"rejected_at,omitempty"`
 }
 
 func toDBInvitation(inv invitations.Invitation) dbInvitation {
+	var code sql.NullString
+	if inv.Code != "" {
+		code = sql.NullString{String: inv.Code, Valid: true}
+	}
 	var updatedAt, confirmedAt, rejectedAt sql.NullTime
 	if inv.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: inv.UpdatedAt, Valid: true}
@@ -218,8 +302,10 @@ func toDBInvitation(inv invitations.Invitation) dbInvitation {
 	return dbInvitation{
 		InvitedBy:   inv.InvitedBy,
 		UserID:      inv.UserID,
+		Email:       inv.Email,
 		DomainID:    inv.DomainID,
 		Token:       inv.Token,
+		Code:        code,
 		Relation:    inv.Relation,
 		CreatedAt:   inv.CreatedAt,
 		UpdatedAt:   updatedAt,
@@ -243,8 +329,10 @@ func toInvitation(dbinv dbInvitation) invitations.Invitation {
 	return invitations.Invitation{
 		InvitedBy:   dbinv.InvitedBy,
 		UserID:      dbinv.UserID,
+		Email:       dbinv.Email,
 		DomainID:    dbinv.DomainID,
 		Token:       dbinv.Token,
+		Code:        dbinv.Code.String,
 		Relation:    dbinv.Relation,
 		CreatedAt:   dbinv.CreatedAt,
 		UpdatedAt:   updatedAt,
@@ -661,6 +661,18 @@ func TestInvitationUpdateToken(t *testing.T) {
 	}
 	err := repo.Create(context.Background(), invitation)
 	require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+	emails := []string{"first@example.com", "second@example.com"}
+	for _, email := range emails {
+		err := repo.Create(context.Background(), invitations.Invitation{
+			InvitedBy: invitation.InvitedBy,
+			Email:     email,
+			DomainID:  invitation.DomainID,
+			Token:     validToken,
+			Code:      testsutil.GenerateUUID(t),
+			CreatedAt: time.Now(),
+		})
+		require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+	}
 
 	cases := []struct {
 		desc       string
@@ -677,6 +689,28 @@ func TestInvitationUpdateToken(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "update email invitation successfully",
+			invitation: invitations.Invitation{
+				DomainID:  invitation.DomainID,
+				Email:     emails[0],
+				Token:     validToken,
+				Code:      testsutil.GenerateUUID(t),
+				UpdatedAt: time.Now(),
+			},
+			err: nil,
+		},
+		{
+			desc: "update email invitation with invalid email",
+			invitation: invitations.Invitation{
+				DomainID:  invitation.DomainID,
+				Email:     "invalid@example.com",
+				Token:     validToken,
+				Code:      testsutil.GenerateUUID(t),
+				UpdatedAt: time.Now(),
+			},
+			err: repoerr.ErrNotFound,
+		},
 		{
 			desc: "update invitation with invalid user id",
 			invitation: invitations.Invitation{
@@ -760,6 +794,114 @@ func TestInvitationUpdateConfirmation(t *testing.T) {
 	}
 }
 
+func TestInvitationRetrieveByCode(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM invitations")
+		require.Nil(t, err, fmt.Sprintf("clean invitations unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	invitation := invitations.Invitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Token:     validToken,
+		Code:      testsutil.GenerateUUID(t),
+		Relation:  relation,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	err := repo.Create(context.Background(), invitation)
+	require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		code     string
+		response invitations.Invitation
+		err      error
+	}{
+		{
+			desc:     "retrieve invitation successfully",
+			code:     invitation.Code,
+			response: invitation,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve invitation with invalid code",
+			code:     testsutil.GenerateUUID(t),
+			response: invitations.Invitation{},
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve invitation with empty code",
+			code:     "",
+			response: invitations.Invitation{},
+			err:      repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		inv, err := repo.RetrieveByCode(context.Background(), tc.code)
+		assert.Equal(t, tc.response, inv, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, inv))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestInvitationUpdateSignUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM invitations")
+		require.Nil(t, err, fmt.Sprintf("clean invitations unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	invitation := invitations.Invitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Token:     validToken,
+		Code:      testsutil.GenerateUUID(t),
+		CreatedAt: time.Now(),
+	}
+	err := repo.Create(context.Background(), invitation)
+	require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		invitation invitations.Invitation
+		err        error
+	}{
+		{
+			desc: "update invitation with invalid code",
+			invitation: invitations.Invitation{
+				UserID:      testsutil.GenerateUUID(t),
+				Code:        testsutil.GenerateUUID(t),
+				ConfirmedAt: time.Now(),
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "update invitation successfully",
+			invitation: invitations.Invitation{
+				UserID:      testsutil.GenerateUUID(t),
+				Code:        invitation.Code,
+				ConfirmedAt: time.Now(),
+			},
+			err: nil,
+		},
+		{
+			desc: "update already signed up invitation",
+			invitation: invitations.Invitation{
+				UserID:      testsutil.GenerateUUID(t),
+				Code:        invitation.Code,
+				ConfirmedAt: time.Now(),
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.UpdateSignUp(context.Background(), tc.invitation)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestInvitationDelete(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM invitations")
@@ -809,3 +951,143 @@ func TestInvitationDelete(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestInvitationRetrieveByEmail(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM invitations")
+		require.Nil(t, err, fmt.Sprintf("clean invitations unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	var invs []invitations.Invitation
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		invitation := invitations.Invitation{
+			InvitedBy: testsutil.GenerateUUID(t),
+			Email:     email,
+			DomainID:  domainID,
+			Token:     validToken,
+			Code:      testsutil.GenerateUUID(t),
+			Relation:  relation,
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+		err := repo.Create(context.Background(), invitation)
+		require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+		// The code is not retrieved with the invitation.
+		invitation.Code = ""
+		invs = append(invs, invitation)
+	}
+
+	cases := []struct {
+		desc     string
+		email    string
+		domainID string
+		response invitations.Invitation
+		err      error
+	}{
+		{
+			desc:     "retrieve first invitation successfully",
+			email:    invs[0].Email,
+			domainID: domainID,
+			response: invs[0],
+			err:      nil,
+		},
+		{
+			desc:     "retrieve second invitation successfully",
+			email:    invs[1].Email,
+			domainID: domainID,
+			response: invs[1],
+			err:      nil,
+		},
+		{
+			desc:     "retrieve invitation with invalid email",
+			email:    "invalid@example.com",
+			domainID: domainID,
+			response: invitations.Invitation{},
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve invitation with invalid domain",
+			email:    invs[0].Email,
+			domainID: testsutil.GenerateUUID(t),
+			response: invitations.Invitation{},
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve invitation with empty email",
+			email:    "",
+			domainID: domainID,
+			response: invitations.Invitation{},
+			err:      repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		inv, err := repo.RetrieveByEmail(context.Background(), tc.email, tc.domainID)
+		assert.Equal(t, tc.response, inv, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, inv))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestInvitationDeleteByEmail(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM invitations")
+		require.Nil(t, err, fmt.Sprintf("clean invitations unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	emails := []string{"first@example.com", "second@example.com"}
+	for _, email := range emails {
+		invitation := invitations.Invitation{
+			InvitedBy: testsutil.GenerateUUID(t),
+			Email:     email,
+			DomainID:  domainID,
+			Token:     validToken,
+			Code:      testsutil.GenerateUUID(t),
+			CreatedAt: time.Now(),
+		}
+		err := repo.Create(context.Background(), invitation)
+		require.Nil(t, err, fmt.Sprintf("create invitation unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		email    string
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "delete invitation with invalid email",
+			email:    "invalid@example.com",
+			domainID: domainID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "delete invitation with empty email",
+			email:    "",
+			domainID: domainID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "delete first invitation successfully",
+			email:    emails[0],
+			domainID: domainID,
+			err:      nil,
+		},
+		{
+			desc:     "delete already deleted invitation",
+			email:    emails[0],
+			domainID: domainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.DeleteByEmail(context.Background(), tc.email, tc.domainID)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	// Deleting one email invitation keeps the other ones in the domain.
+	inv, err := repo.RetrieveByEmail(context.Background(), emails[1], domainID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve remaining invitation unexpected error: %s", err))
+	assert.Equal(t, emails[1], inv.Email)
+}
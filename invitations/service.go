@@ -5,6 +5,7 @@ package invitations
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/absmach/magistrala"
@@ -16,19 +17,23 @@ import (
 )
 
 type service struct {
-	repo Repository
-	auth grpcclient.AuthServiceClient
-	sdk  mgsdk.SDK
+	repo       Repository
+	auth       grpcclient.AuthServiceClient
+	sdk        mgsdk.SDK
+	idProvider magistrala.IDProvider
+	email      Emailer
 }
 
 // ErrMemberExist indicates that the user is already a member of the domain.
 var ErrMemberExist = errors.New("user is already a member of the domain")
 
-func NewService(repo Repository, authClient grpcclient.AuthServiceClient, sdk mgsdk.SDK) Service {
+func NewService(repo Repository, authClient grpcclient.AuthServiceClient, sdk mgsdk.SDK, idp magistrala.IDProvider, emailer Emailer) Service {
 	return &service{
-		repo: repo,
-		auth: authClient,
-		sdk:  sdk,
+		repo:       repo,
+		auth:       authClient,
+		sdk:        sdk,
+		idProvider: idp,
+		email:      emailer,
 	}
 }
 
@@ -43,13 +48,27 @@ func (svc *service) SendInvitation(ctx context.Context, token string, invitation
 	}
 	invitation.InvitedBy = user.GetUserId()
 
-	domainUserId := auth.EncodeDomainUserID(invitation.DomainID, invitation.UserID)
-	if err := svc.authorize(ctx, domainUserId, auth.MembershipPermission, auth.DomainType, invitation.DomainID); err == nil {
-		// return error if the user is already a member of the domain
-		return errors.Wrap(svcerr.ErrConflict, ErrMemberExist)
+	// The invitation sent by email is for the user that has no account yet,
+	// so the existing user with the email is invited by the ID instead.
+	if invitation.Email != "" {
+		u, sdkerr := svc.sdk.UserByIdentity(invitation.Email, invitation.DomainID, token)
+		switch {
+		case sdkerr == nil:
+			invitation.UserID, invitation.Email = u.ID, ""
+		case sdkerr.StatusCode() != http.StatusNotFound:
+			return sdkerr
+		}
 	}
 
-	if err := svc.checkAdmin(ctx, user.GetId(), invitation.DomainID); err != nil {
+	if invitation.UserID != "" {
+		domainUserId := auth.EncodeDomainUserID(invitation.DomainID, invitation.UserID)
+		if err := svc.authorize(ctx, auth.UsersKind, domainUserId, auth.MembershipPermission, auth.DomainType, invitation.DomainID); err == nil {
+			// return error if the user is already a member of the domain
+			return errors.Wrap(svcerr.ErrConflict, ErrMemberExist)
+		}
+	}
+
+	if err := svc.checkAdmin(ctx, token, invitation.DomainID); err != nil {
 		return err
 	}
 
@@ -59,15 +78,24 @@ func (svc *service) SendInvitation(ctx context.Context, token string, invitation
 	}
 	invitation.Token = joinToken.GetAccessToken()
 
+	if invitation.Email != "" {
+		if invitation.Code, err = svc.idProvider.ID(); err != nil {
+			return err
+		}
+	}
+
 	if invitation.Resend {
 		invitation.UpdatedAt = time.Now()
-
-		return svc.repo.UpdateToken(ctx, invitation)
+		err = svc.repo.UpdateToken(ctx, invitation)
+	} else {
+		invitation.CreatedAt = time.Now()
+		err = svc.repo.Create(ctx, invitation)
+	}
+	if err != nil || invitation.Email == "" {
+		return err
 	}
 
-	invitation.CreatedAt = time.Now()
-
-	return svc.repo.Create(ctx, invitation)
+	return svc.email.SendInvitation([]string{invitation.Email}, invitation.Email, invitation.Code)
 }
 
 func (svc *service) ViewInvitation(ctx context.Context, token, userID, domainID string) (invitation Invitation, err error) {
@@ -89,7 +117,29 @@ func (svc *service) ViewInvitation(ctx context.Context, token, userID, domainID
 		return inv, nil
 	}
 
-	if err := svc.checkAdmin(ctx, user.GetId(), domainID); err != nil {
+	if err := svc.checkAdmin(ctx, token, domainID); err != nil {
+		return Invitation{}, err
+	}
+
+	return inv, nil
+}
+
+func (svc *service) ViewEmailInvitation(ctx context.Context, token, email, domainID string) (invitation Invitation, err error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Invitation{}, err
+	}
+	inv, err := svc.repo.RetrieveByEmail(ctx, email, domainID)
+	if err != nil {
+		return Invitation{}, err
+	}
+	inv.Token = ""
+
+	if inv.InvitedBy == user.GetUserId() {
+		return inv, nil
+	}
+
+	if err := svc.checkAdmin(ctx, token, domainID); err != nil {
 		return Invitation{}, err
 	}
 
@@ -102,12 +152,12 @@ func (svc *service) ListInvitations(ctx context.Context, token string, page Page
 		return InvitationPage{}, err
 	}
 
-	if err := svc.authorize(ctx, user.GetId(), auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err == nil {
+	if err := svc.authorize(ctx, auth.TokenKind, token, auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err == nil {
 		return svc.repo.RetrieveAll(ctx, page)
 	}
 
 	if page.DomainID != "" {
-		if err := svc.checkAdmin(ctx, user.GetId(), page.DomainID); err != nil {
+		if err := svc.checkAdmin(ctx, token, page.DomainID); err != nil {
 			return InvitationPage{}, err
 		}
 
@@ -155,6 +205,45 @@ func (svc *service) AcceptInvitation(ctx context.Context, token, domainID string
 	return svc.repo.UpdateConfirmation(ctx, inv)
 }
 
+func (svc *service) SignUp(ctx context.Context, code, name, secret string) (Invitation, error) {
+	inv, err := svc.repo.RetrieveByCode(ctx, code)
+	if err != nil {
+		return Invitation{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	// The user is created and added to the domain on behalf of the inviter.
+	user := mgsdk.User{
+		Name: name,
+		Credentials: mgsdk.Credentials{
+			Identity: inv.Email,
+			Secret:   secret,
+		},
+	}
+	user, sdkerr := svc.sdk.CreateInvitedUser(user, inv.Token)
+	if sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	req := mgsdk.UsersRelationRequest{
+		Relation: inv.Relation,
+		UserIDs:  []string{user.ID},
+	}
+	if sdkerr := svc.sdk.AddUserToDomain(inv.DomainID, req, inv.Token); sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	inv.UserID = user.ID
+	inv.ConfirmedAt = time.Now()
+	inv.UpdatedAt = inv.ConfirmedAt
+	if err := svc.repo.UpdateSignUp(ctx, inv); err != nil {
+		return Invitation{}, err
+	}
+	inv.Token = ""
+	inv.Code = ""
+
+	return inv, nil
+}
+
 func (svc *service) RejectInvitation(ctx context.Context, token, domainID string) error {
 	user, err := svc.identify(ctx, token)
 	if err != nil {
@@ -201,13 +290,35 @@ func (svc *service) DeleteInvitation(ctx context.Context, token, userID, domainI
 		return svc.repo.Delete(ctx, userID, domainID)
 	}
 
-	if err := svc.checkAdmin(ctx, user.GetId(), domainID); err != nil {
+	if err := svc.checkAdmin(ctx, token, domainID); err != nil {
 		return err
 	}
 
 	return svc.repo.Delete(ctx, userID, domainID)
 }
 
+func (svc *service) DeleteEmailInvitation(ctx context.Context, token, email, domainID string) error {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	inv, err := svc.repo.RetrieveByEmail(ctx, email, domainID)
+	if err != nil {
+		return err
+	}
+
+	if inv.InvitedBy == user.GetUserId() {
+		return svc.repo.DeleteByEmail(ctx, email, domainID)
+	}
+
+	if err := svc.checkAdmin(ctx, token, domainID); err != nil {
+		return err
+	}
+
+	return svc.repo.DeleteByEmail(ctx, email, domainID)
+}
+
 func (svc *service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
 	user, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
 	if err != nil {
@@ -217,10 +328,10 @@ func (svc *service) identify(ctx context.Context, token string) (*magistrala.Ide
 	return user, nil
 }
 
-func (svc *service) authorize(ctx context.Context, subj, perm, objType, obj string) error {
+func (svc *service) authorize(ctx context.Context, subjKind, subj, perm, objType, obj string) error {
 	req := &magistrala.AuthorizeReq{
 		SubjectType: auth.UserType,
-		SubjectKind: auth.UsersKind,
+		SubjectKind: subjKind,
 		Subject:     subj,
 		Permission:  perm,
 		ObjectType:  objType,
@@ -238,13 +349,13 @@ func (svc *service) authorize(ctx context.Context, subj, perm, objType, obj stri
 	return nil
 }
 
-// checkAdmin checks if the given user is a domain or platform administrator.
-func (svc *service) checkAdmin(ctx context.Context, userID, domainID string) error {
-	if err := svc.authorize(ctx, userID, auth.AdminPermission, auth.DomainType, domainID); err == nil {
+// checkAdmin checks if the token user is a domain or platform administrator.
+func (svc *service) checkAdmin(ctx context.Context, token, domainID string) error {
+	if err := svc.authorize(ctx, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, domainID); err == nil {
 		return nil
 	}
 
-	if err := svc.authorize(ctx, userID, auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err == nil {
+	if err := svc.authorize(ctx, auth.TokenKind, token, auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err == nil {
 		return nil
 	}
 
@@ -5,6 +5,8 @@ package invitations_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -17,7 +19,9 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -35,13 +39,19 @@ var (
 func TestSendInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
-	svc := invitations.NewService(repo, authsvc, nil)
+	emailer := new(mocks.Emailer)
+	sdksvc := new(sdkmocks.SDK)
+	svc := invitations.NewService(repo, authsvc, sdksvc, uuid.NewMock(), emailer)
+
+	existingUserID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
 		desc            string
 		token           string
 		tokenUserID     string
 		req             invitations.Invitation
+		existingUserID  string
+		lookupErr       errors.SDKError
 		err             error
 		authNErr        error
 		domainMemberErr error
@@ -50,6 +60,7 @@ func TestSendInvitation(t *testing.T) {
 		authorised      bool
 		issueErr        error
 		repoErr         error
+		emailErr        error
 	}{
 		{
 			desc:            "send invitation successful",
@@ -168,6 +179,84 @@ func TestSendInvitation(t *testing.T) {
 			issueErr:        nil,
 			repoErr:         nil,
 		},
+		{
+			desc:        "send invitation by email successful",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			err:        nil,
+			authorised: true,
+		},
+		{
+			desc:        "send invitation by email with failed to save",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			err:        svcerr.ErrCreateEntity,
+			authorised: true,
+			repoErr:    svcerr.ErrCreateEntity,
+		},
+		{
+			desc:        "send invitation by email with failed to send email",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			err:        svcerr.ErrMalformedEntity,
+			authorised: true,
+			emailErr:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "send invitation by email to existing user",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "existing@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			existingUserID:  existingUserID,
+			err:             nil,
+			domainMemberErr: svcerr.ErrAuthorization,
+			authorised:      true,
+		},
+		{
+			desc:        "send invitation by email to existing domain member",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "existing@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			existingUserID: existingUserID,
+			err:            errors.Wrap(svcerr.ErrConflict, invitations.ErrMemberExist),
+			authorised:     true,
+		},
+		{
+			desc:        "send invitation by email with failed to look up the user",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			req: invitations.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: testsutil.GenerateUUID(t),
+				Relation: auth.ContributorRelation,
+			},
+			lookupErr:  errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			err:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			authorised: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -175,10 +264,21 @@ func TestSendInvitation(t *testing.T) {
 			UserId: tc.tokenUserID,
 			Id:     testsutil.GenerateUUID(t) + "_" + tc.tokenUserID,
 		}
+		// The existing user is invited by the ID instead of the email.
+		invitation := tc.req
+		lookupErr := errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)
+		if tc.existingUserID != "" {
+			invitation.UserID, invitation.Email = tc.existingUserID, ""
+			lookupErr = nil
+		}
+		if tc.lookupErr != nil {
+			lookupErr = tc.lookupErr
+		}
+		sdkcall := sdksvc.On("UserByIdentity", tc.req.Email, tc.req.DomainID, tc.token).Return(mgsdk.User{ID: tc.existingUserID}, lookupErr)
 		domainMemberReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
 			SubjectKind: auth.UsersKind,
-			Subject:     auth.EncodeDomainUserID(tc.req.DomainID, tc.req.UserID),
+			Subject:     auth.EncodeDomainUserID(invitation.DomainID, invitation.UserID),
 			Permission:  auth.MembershipPermission,
 			ObjectType:  auth.DomainType,
 			Object:      tc.req.DomainID,
@@ -187,8 +287,8 @@ func TestSendInvitation(t *testing.T) {
 		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
 		domainAdminReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.DomainType,
 			Object:      tc.req.DomainID,
@@ -196,8 +296,8 @@ func TestSendInvitation(t *testing.T) {
 		domaincall1 := authsvc.On("Authorize", context.Background(), &domainAdminReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainAdminErr)
 		platformReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.PlatformType,
 			Object:      auth.MagistralaObject,
@@ -208,21 +308,30 @@ func TestSendInvitation(t *testing.T) {
 		if tc.req.Resend {
 			repocall2 = repo.On("UpdateToken", context.Background(), mock.Anything).Return(tc.repoErr)
 		}
+		emailcall := emailer.On("SendInvitation", []string{tc.req.Email}, tc.req.Email, mock.Anything).Return(tc.emailErr)
 		err := svc.SendInvitation(context.Background(), tc.token, tc.req)
 		assert.Equal(t, tc.err, err, tc.desc)
+		if tc.existingUserID != "" && err == nil {
+			ok := repocall2.Parent.AssertCalled(t, "Create", context.Background(), mock.MatchedBy(func(inv invitations.Invitation) bool {
+				return inv.UserID == tc.existingUserID && inv.Email == "" && inv.Code == ""
+			}))
+			assert.True(t, ok, fmt.Sprintf("Create was not called with the user invitation on %s", tc.desc))
+		}
+		sdkcall.Unset()
 		repocall.Unset()
 		domaincall.Unset()
 		domaincall1.Unset()
 		platformcall.Unset()
 		repocall1.Unset()
 		repocall2.Unset()
+		emailcall.Unset()
 	}
 }
 
 func TestViewInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
-	svc := invitations.NewService(repo, authsvc, nil)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
 
 	validInvitation := invitations.Invitation{
 		InvitedBy:   testsutil.GenerateUUID(t),
@@ -369,8 +478,8 @@ func TestViewInvitation(t *testing.T) {
 		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
 		domainReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.DomainType,
 			Object:      tc.domainID,
@@ -378,8 +487,8 @@ func TestViewInvitation(t *testing.T) {
 		domaincall := authsvc.On("Authorize", context.Background(), &domainReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainErr)
 		platformReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.PlatformType,
 			Object:      auth.MagistralaObject,
@@ -396,10 +505,118 @@ func TestViewInvitation(t *testing.T) {
 	}
 }
 
+func TestViewEmailInvitation(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthServiceClient)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
+
+	emailInvitation := invitations.Invitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Relation:  auth.ContributorRelation,
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+	cases := []struct {
+		desc        string
+		token       string
+		tokenUserID string
+		resp        invitations.Invitation
+		err         error
+		authNErr    error
+		domainErr   error
+		adminErr    error
+		authorised  bool
+		repoErr     error
+	}{
+		{
+			desc:        "view email invitation by the user who sent it",
+			token:       validToken,
+			tokenUserID: emailInvitation.InvitedBy,
+			resp:        emailInvitation,
+			authorised:  false,
+		},
+		{
+			desc:        "view email invitation by the domain admin",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			resp:        emailInvitation,
+			authorised:  true,
+		},
+		{
+			desc:        "view email invitation by the platform admin",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			resp:        emailInvitation,
+			domainErr:   svcerr.ErrAuthorization,
+			authorised:  true,
+		},
+		{
+			desc:     "view email invitation with invalid token",
+			token:    invalidToken,
+			resp:     invitations.Invitation{},
+			err:      svcerr.ErrAuthentication,
+			authNErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "view non-existing email invitation",
+			token:       validToken,
+			tokenUserID: emailInvitation.InvitedBy,
+			resp:        invitations.Invitation{},
+			err:         svcerr.ErrNotFound,
+			repoErr:     svcerr.ErrNotFound,
+		},
+		{
+			desc:        "view email invitation by the unauthorized user",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			resp:        invitations.Invitation{},
+			err:         svcerr.ErrAuthorization,
+			domainErr:   svcerr.ErrAuthorization,
+			adminErr:    svcerr.ErrAuthorization,
+			authorised:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		idRes := &magistrala.IdentityRes{
+			UserId: tc.tokenUserID,
+			Id:     emailInvitation.DomainID + "_" + tc.tokenUserID,
+		}
+		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
+		domainReq := magistrala.AuthorizeReq{
+			SubjectType: auth.UserType,
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
+			Permission:  auth.AdminPermission,
+			ObjectType:  auth.DomainType,
+			Object:      emailInvitation.DomainID,
+		}
+		domaincall := authsvc.On("Authorize", context.Background(), &domainReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainErr)
+		platformReq := magistrala.AuthorizeReq{
+			SubjectType: auth.UserType,
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
+			Permission:  auth.AdminPermission,
+			ObjectType:  auth.PlatformType,
+			Object:      auth.MagistralaObject,
+		}
+		platformcall := authsvc.On("Authorize", context.Background(), &platformReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.adminErr)
+		repocall1 := repo.On("RetrieveByEmail", context.Background(), emailInvitation.Email, emailInvitation.DomainID).Return(tc.resp, tc.repoErr)
+		inv, err := svc.ViewEmailInvitation(context.Background(), tc.token, emailInvitation.Email, emailInvitation.DomainID)
+		assert.Equal(t, tc.err, err, tc.desc)
+		assert.Equal(t, tc.resp, inv, tc.desc)
+		repocall.Unset()
+		domaincall.Unset()
+		platformcall.Unset()
+		repocall1.Unset()
+	}
+}
+
 func TestListInvitations(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
-	svc := invitations.NewService(repo, authsvc, nil)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
 
 	validPage := invitations.Page{
 		Offset: 0,
@@ -547,8 +764,8 @@ func TestListInvitations(t *testing.T) {
 		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
 		domainReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.DomainType,
 			Object:      tc.page.DomainID,
@@ -556,8 +773,8 @@ func TestListInvitations(t *testing.T) {
 		domaincall := authsvc.On("Authorize", context.Background(), &domainReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainErr)
 		platformReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.PlatformType,
 			Object:      auth.MagistralaObject,
@@ -578,7 +795,7 @@ func TestAcceptInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
 	sdksvc := new(sdkmocks.SDK)
-	svc := invitations.NewService(repo, authsvc, sdksvc)
+	svc := invitations.NewService(repo, authsvc, sdksvc, nil, nil)
 	userID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
@@ -690,10 +907,98 @@ func TestAcceptInvitation(t *testing.T) {
 	}
 }
 
+func TestSignUp(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthServiceClient)
+	sdksvc := new(sdkmocks.SDK)
+	svc := invitations.NewService(repo, authsvc, sdksvc, nil, nil)
+
+	code := testsutil.GenerateUUID(t)
+	inv := invitations.Invitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Token:     validToken,
+		Code:      code,
+		Relation:  auth.ContributorRelation,
+	}
+	user := mgsdk.User{
+		ID:   testsutil.GenerateUUID(t),
+		Name: "invitee",
+		Credentials: mgsdk.Credentials{
+			Identity: inv.Email,
+			Secret:   "12345678",
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		code        string
+		retrieve    invitations.Invitation
+		retrieveErr error
+		createErr   errors.SDKError
+		addErr      errors.SDKError
+		updateErr   error
+		err         error
+	}{
+		{
+			desc:     "sign up successfully",
+			code:     code,
+			retrieve: inv,
+			err:      nil,
+		},
+		{
+			desc:        "sign up with invalid code",
+			code:        invalidToken,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "sign up with failed to create user",
+			code:      code,
+			retrieve:  inv,
+			createErr: errors.NewSDKError(svcerr.ErrConflict),
+			err:       svcerr.ErrConflict,
+		},
+		{
+			desc:     "sign up with failed to add user to domain",
+			code:     code,
+			retrieve: inv,
+			addErr:   errors.NewSDKError(svcerr.ErrAuthorization),
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "sign up with failed to update invitation",
+			code:      code,
+			retrieve:  inv,
+			updateErr: svcerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repocall := repo.On("RetrieveByCode", context.Background(), tc.code).Return(tc.retrieve, tc.retrieveErr)
+		sdkcall := sdksvc.On("CreateInvitedUser", mgsdk.User{Name: user.Name, Credentials: user.Credentials}, inv.Token).Return(user, tc.createErr)
+		sdkcall1 := sdksvc.On("AddUserToDomain", inv.DomainID, mgsdk.UsersRelationRequest{Relation: inv.Relation, UserIDs: []string{user.ID}}, inv.Token).Return(tc.addErr)
+		repocall1 := repo.On("UpdateSignUp", context.Background(), mock.Anything).Return(tc.updateErr)
+		res, err := svc.SignUp(context.Background(), tc.code, user.Name, user.Credentials.Secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, user.ID, res.UserID, fmt.Sprintf("%s: expected user ID %s got %s\n", tc.desc, user.ID, res.UserID))
+			assert.Empty(t, res.Token, fmt.Sprintf("%s: expected empty token\n", tc.desc))
+			assert.False(t, res.ConfirmedAt.IsZero(), fmt.Sprintf("%s: expected invitation to be confirmed\n", tc.desc))
+		}
+		repocall.Unset()
+		sdkcall.Unset()
+		sdkcall1.Unset()
+		repocall1.Unset()
+	}
+}
+
 func TestDeleteInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
-	svc := invitations.NewService(repo, authsvc, nil)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
 
 	cases := []struct {
 		desc        string
@@ -816,8 +1121,8 @@ func TestDeleteInvitation(t *testing.T) {
 		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
 		domainReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.DomainType,
 			Object:      tc.domainID,
@@ -825,8 +1130,8 @@ func TestDeleteInvitation(t *testing.T) {
 		domaincall := authsvc.On("Authorize", context.Background(), &domainReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainErr)
 		platformReq := magistrala.AuthorizeReq{
 			SubjectType: auth.UserType,
-			SubjectKind: auth.UsersKind,
-			Subject:     idRes.GetId(),
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
 			Permission:  auth.AdminPermission,
 			ObjectType:  auth.PlatformType,
 			Object:      auth.MagistralaObject,
@@ -844,10 +1149,118 @@ func TestDeleteInvitation(t *testing.T) {
 	}
 }
 
+func TestDeleteEmailInvitation(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthServiceClient)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
+
+	emailInvitation := invitations.Invitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Relation:  auth.ContributorRelation,
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+	cases := []struct {
+		desc        string
+		token       string
+		tokenUserID string
+		resp        invitations.Invitation
+		err         error
+		authNErr    error
+		domainErr   error
+		adminErr    error
+		authorised  bool
+		repoErr     error
+		deleteErr   error
+	}{
+		{
+			desc:        "delete email invitation by the user who sent it",
+			token:       validToken,
+			tokenUserID: emailInvitation.InvitedBy,
+			resp:        emailInvitation,
+			authorised:  false,
+		},
+		{
+			desc:        "delete email invitation by the domain admin",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			resp:        emailInvitation,
+			authorised:  true,
+		},
+		{
+			desc:     "delete email invitation with invalid token",
+			token:    invalidToken,
+			err:      svcerr.ErrAuthentication,
+			authNErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "delete non-existing email invitation",
+			token:       validToken,
+			tokenUserID: emailInvitation.InvitedBy,
+			err:         svcerr.ErrNotFound,
+			repoErr:     svcerr.ErrNotFound,
+		},
+		{
+			desc:        "delete email invitation by the unauthorized user",
+			token:       validToken,
+			tokenUserID: testsutil.GenerateUUID(t),
+			resp:        emailInvitation,
+			err:         svcerr.ErrAuthorization,
+			domainErr:   svcerr.ErrAuthorization,
+			adminErr:    svcerr.ErrAuthorization,
+			authorised:  false,
+		},
+		{
+			desc:        "delete email invitation with failed to delete",
+			token:       validToken,
+			tokenUserID: emailInvitation.InvitedBy,
+			resp:        emailInvitation,
+			err:         svcerr.ErrRemoveEntity,
+			deleteErr:   svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		idRes := &magistrala.IdentityRes{
+			UserId: tc.tokenUserID,
+			Id:     emailInvitation.DomainID + "_" + tc.tokenUserID,
+		}
+		repocall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(idRes, tc.authNErr)
+		domainReq := magistrala.AuthorizeReq{
+			SubjectType: auth.UserType,
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
+			Permission:  auth.AdminPermission,
+			ObjectType:  auth.DomainType,
+			Object:      emailInvitation.DomainID,
+		}
+		domaincall := authsvc.On("Authorize", context.Background(), &domainReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.domainErr)
+		platformReq := magistrala.AuthorizeReq{
+			SubjectType: auth.UserType,
+			SubjectKind: auth.TokenKind,
+			Subject:     tc.token,
+			Permission:  auth.AdminPermission,
+			ObjectType:  auth.PlatformType,
+			Object:      auth.MagistralaObject,
+		}
+		platformcall := authsvc.On("Authorize", context.Background(), &platformReq).Return(&magistrala.AuthorizeRes{Authorized: tc.authorised}, tc.adminErr)
+		repocall1 := repo.On("RetrieveByEmail", context.Background(), emailInvitation.Email, emailInvitation.DomainID).Return(tc.resp, tc.repoErr)
+		repocall2 := repo.On("DeleteByEmail", context.Background(), emailInvitation.Email, emailInvitation.DomainID).Return(tc.deleteErr)
+		err := svc.DeleteEmailInvitation(context.Background(), tc.token, emailInvitation.Email, emailInvitation.DomainID)
+		assert.Equal(t, tc.err, err, tc.desc)
+		repocall.Unset()
+		repocall1.Unset()
+		domaincall.Unset()
+		platformcall.Unset()
+		repocall2.Unset()
+	}
+}
+
 func TestRejectInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthServiceClient)
-	svc := invitations.NewService(repo, authsvc, nil)
+	svc := invitations.NewService(repo, authsvc, nil, nil, nil)
 
 	cases := []struct {
 		desc        string
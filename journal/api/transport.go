@@ -36,6 +36,7 @@ const (
 func MakeHandler(svc journal.Service, logger *slog.Logger, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	mux := chi.NewRouter()
@@ -54,21 +54,23 @@ func (svc *service) authorize(ctx context.Context, token, entityID, entityType s
 	permission := auth.ViewPermission
 	objectType := entityType
 	object := entityID
-	subject := user.GetId()
+
+	// Users can view their own journal.
+	if entityType == auth.UserType && entityID == user.GetUserId() {
+		return nil
+	}
 
 	// If the entity is a user, we need to check if the user is an admin
 	if entityType == auth.UserType {
 		permission = auth.AdminPermission
 		objectType = auth.PlatformType
 		object = auth.MagistralaObject
-		subject = user.GetUserId()
 	}
 
 	req := &magistrala.AuthorizeReq{
-		Domain:      user.GetDomainId(),
 		SubjectType: auth.UserType,
-		SubjectKind: auth.UsersKind,
-		Subject:     subject,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  permission,
 		ObjectType:  objectType,
 		Object:      object,
@@ -85,6 +85,7 @@ func TestReadAll(t *testing.T) {
 		EntityID:   testsutil.GenerateUUID(t),
 		EntityType: journal.ThingEntity,
 	}
+	ownUserID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
 		desc        string
@@ -135,6 +136,27 @@ func TestReadAll(t *testing.T) {
 			repoErr:     nil,
 			err:         nil,
 		},
+		{
+			desc:  "successful for own user",
+			token: validToken,
+			page: journal.Page{
+				Offset:     0,
+				Limit:      10,
+				EntityID:   ownUserID,
+				EntityType: journal.UserEntity,
+			},
+			resp: journal.JournalsPage{
+				Total:    1,
+				Offset:   0,
+				Limit:    10,
+				Journals: []journal.Journal{validJournal},
+			},
+			identifyRes: &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), UserId: ownUserID},
+			authRes:     &magistrala.AuthorizeRes{Authorized: false},
+			authErr:     nil,
+			repoErr:     nil,
+			err:         nil,
+		},
 		{
 			desc:        "with identify error",
 			token:       validToken,
@@ -182,8 +204,8 @@ func TestReadAll(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			authReq := &magistrala.AuthorizeReq{
 				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.identifyRes.GetId(),
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
 				ObjectType:  tc.page.EntityType.AuthString(),
 				Object:      tc.page.EntityID,
 				Permission:  auth.ViewPermission,
@@ -192,7 +214,6 @@ func TestReadAll(t *testing.T) {
 				authReq.Permission = auth.AdminPermission
 				authReq.ObjectType = auth.PlatformType
 				authReq.Object = auth.MagistralaObject
-				authReq.Subject = tc.identifyRes.GetUserId()
 			}
 			authCall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyRes, tc.identifyErr)
 			authCall1 := authsvc.On("Authorize", context.Background(), authReq).Return(tc.authRes, tc.authErr)
@@ -44,7 +44,7 @@ func (f forwarder) Forward(ctx context.Context, id string, sub messaging.Subscri
 
 func handle(ctx context.Context, pub messaging.Publisher, logger *slog.Logger) handleFunc {
 	return func(msg *messaging.Message) error {
-		if msg.GetProtocol() == protocol {
+		if msg.GetProtocol() == protocol || messaging.IsReplay(msg) {
 			return nil
 		}
 		// Use concatenation instead of fmt.Sprintf for the
@@ -223,6 +223,10 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 	}
 
 	chanID := channelParts[1]
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return errors.Wrap(ErrFailedParseSubtopic, err)
+	}
 
 	ar := &magistrala.AuthorizeReq{
 		SubjectType: auth.ThingType,
@@ -230,6 +234,7 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 		Subject:     password,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := h.things.Authorize(ctx, ar)
 	if err != nil {
@@ -168,6 +168,12 @@ var (
 	// ErrMissingTo indicates missing to value.
 	ErrMissingTo = errors.New("missing to time value")
 
+	// ErrInvalidTimeRange indicates the end of the time range is not after its start.
+	ErrInvalidTimeRange = errors.New("to time value must be greater than from")
+
+	// ErrInvalidRate indicates negative rate limit.
+	ErrInvalidRate = errors.New("rate must not be negative")
+
 	// ErrEmptyMessage indicates empty message.
 	ErrEmptyMessage = errors.New("empty message")
 
@@ -185,4 +191,34 @@ var (
 
 	// ErrLenSearchQuery indicates search query length.
 	ErrLenSearchQuery = errors.New("search query must be at least 3 characters")
+
+	// ErrInvalidURL indicates missing or malformed URL.
+	ErrInvalidURL = errors.New("missing or invalid url")
+
+	// ErrInvalidScope indicates malformed API key scope.
+	ErrInvalidScope = errors.New("invalid API key scope")
+
+	// ErrMissingMFAToken indicates missing MFA token of the login challenge.
+	ErrMissingMFAToken = errors.New("missing MFA token")
+
+	// ErrMissingMFACode indicates missing TOTP or recovery code.
+	ErrMissingMFACode = errors.New("missing MFA code")
+
+	// ErrMissingVerificationToken indicates missing email verification token.
+	ErrMissingVerificationToken = errors.New("missing email verification token")
+
+	// ErrMissingInvitationCode indicates missing code of the invitation sent by email.
+	ErrMissingInvitationCode = errors.New("missing invitation code")
+
+	// ErrInvalidInvitee indicates the invitation has both or none of the user ID and the email.
+	ErrInvalidInvitee = errors.New("invitation must have either user ID or email")
+
+	// ErrMissingProvider indicates missing identity provider.
+	ErrMissingProvider = errors.New("missing identity provider")
+
+	// ErrMissingClaimGroup indicates missing identity provider group of the claim mapping.
+	ErrMissingClaimGroup = errors.New("missing identity provider group")
+
+	// ErrMissingQuotaResource indicates missing resource of the domain quota.
+	ErrMissingQuotaResource = errors.New("missing quota resource")
 )
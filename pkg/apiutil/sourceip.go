@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	forwardedForHeader = "X-Forwarded-For"
+	realIPHeader       = "X-Real-IP"
+)
+
+// ErrInvalidTrustedProxy indicates the trusted proxy which is neither an IP
+// address nor a CIDR.
+var ErrInvalidTrustedProxy = errors.New("invalid trusted proxy")
+
+type sourceIPKey struct{}
+
+// WithSourceIP returns the context carrying the IP address the request
+// originates from. The IP address is used to evaluate the network
+// conditions of the policies when the request is authorized.
+func WithSourceIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, sourceIPKey{}, ip)
+}
+
+// SourceIP returns the IP address the request originates from,
+// or an empty string if the context doesn't carry it.
+func SourceIP(ctx context.Context) string {
+	ip, _ := ctx.Value(sourceIPKey{}).(string)
+	return ip
+}
+
+// SourceIPToContext is a go-kit request function which stores the IP
+// address of the HTTP request peer in the request context. The forwarding
+// headers are ignored.
+func SourceIPToContext(ctx context.Context, r *http.Request) context.Context {
+	return TrustedProxies(nil).SourceIPToContext(ctx, r)
+}
+
+// TrustedProxies are the networks of the reverse proxies which are trusted
+// to set the client IP address in the forwarding headers.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the IP addresses and CIDRs of the trusted
+// reverse proxies.
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	var tp TrustedProxies
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(p); err == nil {
+			tp = append(tp, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(p)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidTrustedProxy, err)
+		}
+		addr = addr.Unmap()
+		tp = append(tp, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return tp, nil
+}
+
+// SourceIP returns the IP address the request originates from. The
+// forwarding headers are honoured only if the request peer is the trusted
+// proxy, in which case the rightmost address of X-Forwarded-For which is
+// not the trusted proxy is returned, or X-Real-IP if X-Forwarded-For is
+// not set. Otherwise, the IP address of the request peer is returned.
+func (tp TrustedProxies) SourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !tp.trusted(host) {
+		return host
+	}
+
+	if hops := r.Header.Values(forwardedForHeader); len(hops) > 0 {
+		addrs := strings.Split(strings.Join(hops, ","), ",")
+		for i := len(addrs) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(addrs[i]))
+			if err != nil {
+				// The header is malformed past this point, so the last
+				// trusted hop is the client.
+				return host
+			}
+			host = addr.Unmap().String()
+			if !tp.trusted(host) {
+				return host
+			}
+		}
+
+		return host
+	}
+	if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get(realIPHeader))); err == nil {
+		return addr.Unmap().String()
+	}
+
+	return host
+}
+
+// SourceIPToContext is a go-kit request function which stores the IP
+// address the request originates from in the request context.
+func (tp TrustedProxies) SourceIPToContext(ctx context.Context, r *http.Request) context.Context {
+	return WithSourceIP(ctx, tp.SourceIP(r))
+}
+
+func (tp TrustedProxies) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range tp {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
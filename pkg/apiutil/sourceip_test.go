@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceIPToContext(t *testing.T) {
+	cases := []struct {
+		desc       string
+		remoteAddr string
+		sourceIP   string
+	}{
+		{
+			desc:       "IPv4 peer address",
+			remoteAddr: "10.1.2.3:43210",
+			sourceIP:   "10.1.2.3",
+		},
+		{
+			desc:       "IPv6 peer address",
+			remoteAddr: "[2001:db8::1]:43210",
+			sourceIP:   "2001:db8::1",
+		},
+		{
+			desc:       "peer address without port",
+			remoteAddr: "10.1.2.3",
+			sourceIP:   "10.1.2.3",
+		},
+	}
+
+	for _, tc := range cases {
+		r := &http.Request{RemoteAddr: tc.remoteAddr}
+		ctx := apiutil.SourceIPToContext(context.Background(), r)
+		assert.Equal(t, tc.sourceIP, apiutil.SourceIP(ctx), tc.desc)
+	}
+	assert.Empty(t, apiutil.SourceIP(context.Background()), "context without source IP")
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	cases := []struct {
+		desc    string
+		proxies []string
+		err     error
+	}{
+		{
+			desc:    "CIDRs and IP addresses",
+			proxies: []string{"10.0.0.0/8", " 192.168.1.1 ", "2001:db8::/32", ""},
+			err:     nil,
+		},
+		{
+			desc:    "no proxies",
+			proxies: nil,
+			err:     nil,
+		},
+		{
+			desc:    "invalid proxy",
+			proxies: []string{"10.0.0.0/8", "proxy.local"},
+			err:     apiutil.ErrInvalidTrustedProxy,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := apiutil.ParseTrustedProxies(tc.proxies)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestTrustedProxiesSourceIP(t *testing.T) {
+	proxies, err := apiutil.ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	assert.Nil(t, err, fmt.Sprintf("parsing trusted proxies expected to succeed: %s", err))
+
+	cases := []struct {
+		desc         string
+		proxies      apiutil.TrustedProxies
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		sourceIP     string
+	}{
+		{
+			desc:         "forwarding headers from untrusted peer",
+			proxies:      proxies,
+			remoteAddr:   "203.0.113.7:43210",
+			forwardedFor: []string{"198.51.100.1"},
+			realIP:       "198.51.100.2",
+			sourceIP:     "203.0.113.7",
+		},
+		{
+			desc:         "forwarding headers without trusted proxies",
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"198.51.100.1"},
+			realIP:       "198.51.100.2",
+			sourceIP:     "10.1.2.3",
+		},
+		{
+			desc:         "forwarded for from trusted peer",
+			proxies:      proxies,
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"198.51.100.1"},
+			sourceIP:     "198.51.100.1",
+		},
+		{
+			desc:         "forwarded for spoofed by client",
+			proxies:      proxies,
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"198.51.100.9, 198.51.100.1"},
+			sourceIP:     "198.51.100.1",
+		},
+		{
+			desc:         "forwarded for over trusted proxy chain",
+			proxies:      proxies,
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"198.51.100.1, 192.168.1.1", "10.4.5.6"},
+			sourceIP:     "198.51.100.1",
+		},
+		{
+			desc:         "forwarded for of trusted proxies only",
+			proxies:      proxies,
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"192.168.1.1"},
+			sourceIP:     "192.168.1.1",
+		},
+		{
+			desc:         "malformed forwarded for",
+			proxies:      proxies,
+			remoteAddr:   "10.1.2.3:43210",
+			forwardedFor: []string{"unknown"},
+			sourceIP:     "10.1.2.3",
+		},
+		{
+			desc:       "real IP from trusted peer",
+			proxies:    proxies,
+			remoteAddr: "10.1.2.3:43210",
+			realIP:     "198.51.100.2",
+			sourceIP:   "198.51.100.2",
+		},
+		{
+			desc:       "trusted peer without forwarding headers",
+			proxies:    proxies,
+			remoteAddr: "192.168.1.1:43210",
+			sourceIP:   "192.168.1.1",
+		},
+	}
+
+	for _, tc := range cases {
+		r := &http.Request{RemoteAddr: tc.remoteAddr, Header: http.Header{}}
+		for _, v := range tc.forwardedFor {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+		if tc.realIP != "" {
+			r.Header.Set("X-Real-IP", tc.realIP)
+		}
+		assert.Equal(t, tc.sourceIP, tc.proxies.SourceIP(r), tc.desc)
+		ctx := tc.proxies.SourceIPToContext(context.Background(), r)
+		assert.Equal(t, tc.sourceIP, apiutil.SourceIP(ctx), tc.desc)
+	}
+}
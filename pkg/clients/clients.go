@@ -51,6 +51,7 @@ type Client struct {
 	Status      Status      `json:"status,omitempty"` // 1 for enabled, 0 for disabled
 	Role        Role        `json:"role,omitempty"`   // 1 for admin, 0 for normal user
 	Permissions []string    `json:"permissions,omitempty"`
+	VerifiedAt  time.Time   `json:"verified_at,omitempty"` // zero until the user verifies the email
 }
 
 // ClientsPage contains page related metadata as well as list
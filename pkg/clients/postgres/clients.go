@@ -343,19 +343,20 @@ func (repo *Repository) Delete(ctx context.Context, id string) error {
 }
 
 type DBClient struct {
-	ID        string           `db:"id"`
-	Name      string           `db:"name,omitempty"`
-	Tags      pgtype.TextArray `db:"tags,omitempty"`
-	Identity  string           `db:"identity"`
-	Domain    string           `db:"domain_id"`
-	Secret    string           `db:"secret"`
-	Metadata  []byte           `db:"metadata,omitempty"`
-	CreatedAt time.Time        `db:"created_at,omitempty"`
-	UpdatedAt sql.NullTime     `db:"updated_at,omitempty"`
-	UpdatedBy *string          `db:"updated_by,omitempty"`
-	Groups    []groups.Group   `db:"groups,omitempty"`
-	Status    clients.Status   `db:"status,omitempty"`
-	Role      *clients.Role    `db:"role,omitempty"`
+	ID         string           `db:"id"`
+	Name       string           `db:"name,omitempty"`
+	Tags       pgtype.TextArray `db:"tags,omitempty"`
+	Identity   string           `db:"identity"`
+	Domain     string           `db:"domain_id"`
+	Secret     string           `db:"secret"`
+	Metadata   []byte           `db:"metadata,omitempty"`
+	CreatedAt  time.Time        `db:"created_at,omitempty"`
+	UpdatedAt  sql.NullTime     `db:"updated_at,omitempty"`
+	UpdatedBy  *string          `db:"updated_by,omitempty"`
+	Groups     []groups.Group   `db:"groups,omitempty"`
+	Status     clients.Status   `db:"status,omitempty"`
+	Role       *clients.Role    `db:"role,omitempty"`
+	VerifiedAt sql.NullTime     `db:"verified_at,omitempty"`
 }
 
 func ToDBClient(c clients.Client) (DBClient, error) {
@@ -379,20 +380,25 @@ func ToDBClient(c clients.Client) (DBClient, error) {
 	if c.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: c.UpdatedAt, Valid: true}
 	}
+	var verifiedAt sql.NullTime
+	if c.VerifiedAt != (time.Time{}) {
+		verifiedAt = sql.NullTime{Time: c.VerifiedAt, Valid: true}
+	}
 
 	return DBClient{
-		ID:        c.ID,
-		Name:      c.Name,
-		Tags:      tags,
-		Domain:    c.Domain,
-		Identity:  c.Credentials.Identity,
-		Secret:    c.Credentials.Secret,
-		Metadata:  data,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: updatedAt,
-		UpdatedBy: updatedBy,
-		Status:    c.Status,
-		Role:      &c.Role,
+		ID:         c.ID,
+		Name:       c.Name,
+		Tags:       tags,
+		Domain:     c.Domain,
+		Identity:   c.Credentials.Identity,
+		Secret:     c.Credentials.Secret,
+		Metadata:   data,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  updatedAt,
+		UpdatedBy:  updatedBy,
+		Status:     c.Status,
+		Role:       &c.Role,
+		VerifiedAt: verifiedAt,
 	}, nil
 }
 
@@ -415,6 +421,10 @@ func ToClient(c DBClient) (clients.Client, error) {
 	if c.UpdatedAt.Valid {
 		updatedAt = c.UpdatedAt.Time
 	}
+	var verifiedAt time.Time
+	if c.VerifiedAt.Valid {
+		verifiedAt = c.VerifiedAt.Time
+	}
 
 	cli := clients.Client{
 		ID:     c.ID,
@@ -425,11 +435,12 @@ func ToClient(c DBClient) (clients.Client, error) {
 			Identity: c.Identity,
 			Secret:   c.Secret,
 		},
-		Metadata:  metadata,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: updatedAt,
-		UpdatedBy: updatedBy,
-		Status:    c.Status,
+		Metadata:   metadata,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  updatedAt,
+		UpdatedBy:  updatedBy,
+		Status:     c.Status,
+		VerifiedAt: verifiedAt,
 	}
 	if c.Role != nil {
 		cli.Role = *c.Role
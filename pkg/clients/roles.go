@@ -17,6 +17,10 @@ type Role uint8
 const (
 	UserRole Role = iota
 	AdminRole
+	// ServiceAccountRole is the role of the non-human client scoped to
+	// a single domain. Service accounts have no password and are not
+	// listed with the users.
+	ServiceAccountRole
 
 	// AllRole is used for querying purposes to list clients irrespective
 	// of their role - both admin and user. It is never stored in the
@@ -27,8 +31,9 @@ const (
 
 // String representation of the possible role values.
 const (
-	Admin = "admin"
-	User  = "user"
+	Admin          = "admin"
+	User           = "user"
+	ServiceAccount = "service_account"
 )
 
 // String converts client role to string literal.
@@ -38,6 +43,8 @@ func (cs Role) String() string {
 		return Admin
 	case UserRole:
 		return User
+	case ServiceAccountRole:
+		return ServiceAccount
 	case AllRole:
 		return All
 	default:
@@ -52,6 +59,8 @@ func ToRole(status string) (Role, error) {
 		return UserRole, nil
 	case Admin:
 		return AdminRole, nil
+	case ServiceAccount:
+		return ServiceAccountRole, nil
 	case All:
 		return AllRole, nil
 	default:
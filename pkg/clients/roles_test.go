@@ -27,6 +27,11 @@ func TestRoleString(t *testing.T) {
 			role:     clients.AdminRole,
 			expected: "admin",
 		},
+		{
+			desc:     "Service account",
+			role:     clients.ServiceAccountRole,
+			expected: "service_account",
+		},
 		{
 			desc:     "All",
 			role:     clients.AllRole,
@@ -66,6 +71,12 @@ func TestToRole(t *testing.T) {
 			expected: clients.AdminRole,
 			err:      nil,
 		},
+		{
+			desc:     "Service account",
+			role:     "service_account",
+			expected: clients.ServiceAccountRole,
+			err:      nil,
+		},
 		{
 			desc:     "All",
 			role:     "all",
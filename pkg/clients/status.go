@@ -6,6 +6,7 @@ package clients
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 )
@@ -76,12 +77,19 @@ func (s Status) MarshalJSON() ([]byte, error) {
 
 func (client Client) MarshalJSON() ([]byte, error) {
 	type Alias Client
+	// The verification time is omitted until the user verifies the email.
+	var verifiedAt *time.Time
+	if !client.VerifiedAt.IsZero() {
+		verifiedAt = &client.VerifiedAt
+	}
 	return json.Marshal(&struct {
 		Alias
-		Status string `json:"status,omitempty"`
+		Status     string     `json:"status,omitempty"`
+		VerifiedAt *time.Time `json:"verified_at,omitempty"`
 	}{
-		Alias:  (Alias)(client),
-		Status: client.Status.String(),
+		Alias:      (Alias)(client),
+		Status:     client.Status.String(),
+		VerifiedAt: verifiedAt,
 	})
 }
 
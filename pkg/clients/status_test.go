@@ -5,6 +5,7 @@ package clients_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/clients"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
@@ -234,6 +235,12 @@ func TestUserMarshalJSON(t *testing.T) {
 			user:     clients.Client{Status: clients.Status(100)},
 			err:      nil,
 		},
+		{
+			desc:     "Verified",
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"enabled","verified_at":"2024-01-01T00:00:00Z"}`),
+			user:     clients.Client{Status: clients.EnabledStatus, VerifiedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			err:      nil,
+		},
 	}
 
 	for _, tc := range cases {
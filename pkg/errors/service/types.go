@@ -69,4 +69,22 @@ var (
 
 	// ErrInvitationAlreadyAccepted indicates that the invitation is already accepted.
 	ErrInvitationAlreadyAccepted = errors.New("invitation already accepted")
+
+	// ErrMFARequired indicates that the multi-factor authentication is required.
+	ErrMFARequired = errors.New("multi-factor authentication is required")
+
+	// ErrEmailNotVerified indicates that the user has not verified the email.
+	ErrEmailNotVerified = errors.New("email is not verified")
+
+	// ErrLoginLocked indicates that the login is locked out after too many failed attempts.
+	ErrLoginLocked = errors.New("login is locked out after too many failed attempts")
+
+	// ErrPasswordPolicy indicates that the password does not satisfy the password policy.
+	ErrPasswordPolicy = errors.New("password does not satisfy the password policy")
+
+	// ErrQuotaExceeded indicates that the domain quota of the resource is exceeded.
+	ErrQuotaExceeded = errors.New("domain quota exceeded")
+
+	// ErrTooManyRequests indicates that the request is repeated too early.
+	ErrTooManyRequests = errors.New("too many requests")
 )
@@ -122,6 +122,10 @@ type Service interface {
 	// DeleteGroup delete the given group id
 	DeleteGroup(ctx context.Context, token, id string) error
 
+	// DeleteDomainGroups deletes the groups of the deleted domain together
+	// with their policies, and returns the IDs of the deleted groups.
+	DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error)
+
 	// Assign member to group
 	Assign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) (err error)
 
@@ -62,6 +62,36 @@ func (_m *Service) CreateGroup(ctx context.Context, token string, kind string, g
 	return r0, r1
 }
 
+// DeleteDomainGroups provides a mock function with given fields: ctx, domainID
+func (_m *Service) DeleteDomainGroups(ctx context.Context, domainID string) ([]string, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomainGroups")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteGroup provides a mock function with given fields: ctx, token, id
 func (_m *Service) DeleteGroup(ctx context.Context, token string, id string) error {
 	ret := _m.Called(ctx, token, id)
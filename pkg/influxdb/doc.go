@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package influxdb contains the domain concept definitions needed to support
+// Magistrala InfluxDB database functionality.
+//
+// It provides the abstraction of the InfluxDB database service, which is used
+// to configure, setup and connect to the InfluxDB database.
+package influxdb
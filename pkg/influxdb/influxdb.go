@@ -0,0 +1,49 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+var errConnect = errors.New("failed to create InfluxDB client")
+
+type Config struct {
+	Protocol string        `env:"PROTOCOL"     envDefault:"http"`
+	Host     string        `env:"HOST"         envDefault:"localhost"`
+	Port     string        `env:"PORT"         envDefault:"8086"`
+	Bucket   string        `env:"BUCKET"       envDefault:"magistrala-bucket"`
+	Org      string        `env:"ORG"          envDefault:"magistrala"`
+	Token    string        `env:"TOKEN"        envDefault:"magistrala-token"`
+	Timeout  time.Duration `env:"HTTP_TIMEOUT" envDefault:"1s"`
+}
+
+// URL returns the InfluxDB server URL.
+func (cfg Config) URL() string {
+	return cfg.Protocol + "://" + cfg.Host + ":" + cfg.Port
+}
+
+// Connect creates a client connected to the InfluxDB instance and checks that
+// the server is reachable. A non-nil error is returned to indicate failure.
+//
+// For example:
+//
+//	client, err := influxdb.Connect(ctx, influxdb.Config{})
+func Connect(ctx context.Context, cfg Config) (influxdb2.Client, error) {
+	opts := influxdb2.DefaultOptions().SetHTTPRequestTimeout(uint(cfg.Timeout.Seconds()))
+	client := influxdb2.NewClientWithOptions(cfg.URL(), cfg.Token, opts)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	if _, err := client.Ping(ctx); err != nil {
+		client.Close()
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	return client, nil
+}
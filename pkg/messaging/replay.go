@@ -0,0 +1,22 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+const (
+	// ReplayTopic is the topic under which replayed messages are published,
+	// i.e. the resulting subject is "channels.replay.<channel>[.<subtopic>]".
+	ReplayTopic = "replay"
+
+	// ReplayHeader marks messages republished from stored history.
+	ReplayHeader = "replay"
+
+	// ReplayIDHeader holds the ID of the replay job that published the message.
+	ReplayIDHeader = "replay_id"
+)
+
+// IsReplay returns true if the message was republished from stored history.
+func IsReplay(msg *Message) bool {
+	_, ok := msg.GetHeaders()[ReplayHeader]
+	return ok
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mongo contains the domain concept definitions needed to support
+// Magistrala MongoDB database functionality.
+//
+// It provides the abstraction of the MongoDB database service, which is used
+// to configure, setup and connect to the MongoDB database.
+package mongo
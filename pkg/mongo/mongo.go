@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var errConnect = errors.New("failed to connect to mongodb server")
+
+type Config struct {
+	Host string `env:"HOST" envDefault:"localhost"`
+	Port string `env:"PORT" envDefault:"27017"`
+	Name string `env:"NAME" envDefault:"messages"`
+}
+
+// Setup creates a connection to the MongoDB instance and returns the database
+// with the configured name. A non-nil error is returned to indicate failure.
+//
+// For example:
+//
+//	db, err := mongo.Setup(ctx, mongo.Config{})
+func Setup(ctx context.Context, cfg Config) (*mongo.Database, error) {
+	addr := fmt.Sprintf("mongodb://%s:%s", cfg.Host, cfg.Port)
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(addr))
+	if err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	return client.Database(cfg.Name), nil
+}
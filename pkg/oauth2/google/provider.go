@@ -12,6 +12,7 @@ import (
 	"time"
 
 	mfclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mgoauth2 "github.com/absmach/magistrala/pkg/oauth2"
 	"golang.org/x/oauth2"
@@ -19,10 +20,11 @@ import (
 )
 
 const (
-	providerName = "google"
-	defTimeout   = 1 * time.Minute
-	userInfoURL  = "https://www.googleapis.com/oauth2/v2/userinfo?access_token="
-	tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo?access_token="
+	providerName  = "google"
+	defTimeout    = 1 * time.Minute
+	stateDuration = 10 * time.Minute
+	userInfoURL   = "https://www.googleapis.com/oauth2/v2/userinfo?access_token="
+	tokenInfoURL  = "https://oauth2.googleapis.com/tokeninfo?access_token="
 )
 
 var scopes = []string{
@@ -34,13 +36,14 @@ var _ mgoauth2.Provider = (*config)(nil)
 
 type config struct {
 	config        *oauth2.Config
-	state         string
+	states        mgoauth2.StateRepository
+	client        *http.Client
 	uiRedirectURL string
 	errorURL      string
 }
 
 // NewProvider returns a new Google OAuth provider.
-func NewProvider(cfg mgoauth2.Config, uiRedirectURL, errorURL string) mgoauth2.Provider {
+func NewProvider(cfg mgoauth2.Config, states mgoauth2.StateRepository, uiRedirectURL, errorURL string) mgoauth2.Provider {
 	return &config{
 		config: &oauth2.Config{
 			ClientID:     cfg.ClientID,
@@ -49,7 +52,8 @@ func NewProvider(cfg mgoauth2.Config, uiRedirectURL, errorURL string) mgoauth2.P
 			RedirectURL:  cfg.RedirectURL,
 			Scopes:       scopes,
 		},
-		state:         cfg.State,
+		states:        states,
+		client:        &http.Client{Timeout: defTimeout},
 		uiRedirectURL: uiRedirectURL,
 		errorURL:      errorURL,
 	}
@@ -59,8 +63,16 @@ func (cfg *config) Name() string {
 	return providerName
 }
 
-func (cfg *config) State() string {
-	return cfg.state
+func (cfg *config) AuthURL(ctx context.Context) (string, string, error) {
+	state, err := mgoauth2.NewState(providerName, stateDuration)
+	if err != nil {
+		return "", "", err
+	}
+	if err := cfg.states.Save(ctx, state); err != nil {
+		return "", "", err
+	}
+
+	return cfg.config.AuthCodeURL(state.State, oauth2.S256ChallengeOption(state.Verifier)), state.State, nil
 }
 
 func (cfg *config) RedirectURL() string {
@@ -75,8 +87,14 @@ func (cfg *config) IsEnabled() bool {
 	return cfg.config.ClientID != "" && cfg.config.ClientSecret != ""
 }
 
-func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, error) {
-	token, err := cfg.config.Exchange(ctx, code)
+func (cfg *config) Exchange(ctx context.Context, state, code string) (oauth2.Token, error) {
+	s, err := cfg.states.Retrieve(ctx, providerName, state)
+	if err != nil {
+		return oauth2.Token{}, errors.Wrap(mgoauth2.ErrInvalidState, err)
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, cfg.client)
+	token, err := cfg.config.Exchange(ctx, code, oauth2.VerifierOption(s.Verifier))
 	if err != nil {
 		return oauth2.Token{}, err
 	}
@@ -84,8 +102,12 @@ func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, err
 	return *token, nil
 }
 
-func (cfg *config) UserInfo(accessToken string) (mfclients.Client, error) {
-	resp, err := http.Get(userInfoURL + url.QueryEscape(accessToken))
+func (cfg *config) UserInfo(ctx context.Context, token oauth2.Token) (mfclients.Client, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userInfoURL+url.QueryEscape(token.AccessToken), nil)
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	resp, err := cfg.client.Do(req)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -101,10 +123,11 @@ func (cfg *config) UserInfo(accessToken string) (mfclients.Client, error) {
 	}
 
 	var user struct {
-		ID      string `json:"id"`
-		Name    string `json:"name"`
-		Email   string `json:"email"`
-		Picture string `json:"picture"`
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		Email         string `json:"email"`
+		VerifiedEmail bool   `json:"verified_email"`
+		Picture       string `json:"picture"`
 	}
 	if err := json.Unmarshal(data, &user); err != nil {
 		return mfclients.Client{}, err
@@ -113,6 +136,9 @@ func (cfg *config) UserInfo(accessToken string) (mfclients.Client, error) {
 	if user.ID == "" || user.Name == "" || user.Email == "" {
 		return mfclients.Client{}, svcerr.ErrAuthentication
 	}
+	if !user.VerifiedEmail {
+		return mfclients.Client{}, mgoauth2.ErrUnverifiedEmail
+	}
 
 	client := mfclients.Client{
 		ID:   user.ID,
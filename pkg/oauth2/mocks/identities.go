@@ -0,0 +1,77 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	oauth2 "github.com/absmach/magistrala/pkg/oauth2"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityRepository is an autogenerated mock type for the IdentityRepository type
+type IdentityRepository struct {
+	mock.Mock
+}
+
+// Retrieve provides a mock function with given fields: ctx, provider, subject
+func (_m *IdentityRepository) Retrieve(ctx context.Context, provider string, subject string) (oauth2.Identity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 oauth2.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (oauth2.Identity, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) oauth2.Identity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(oauth2.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, identity
+func (_m *IdentityRepository) Save(ctx context.Context, identity oauth2.Identity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, oauth2.Identity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityRepository {
+	mock := &IdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
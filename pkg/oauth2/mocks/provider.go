@@ -19,6 +19,41 @@ type Provider struct {
 	mock.Mock
 }
 
+// AuthURL provides a mock function with given fields: ctx
+func (_m *Provider) AuthURL(ctx context.Context) (string, string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthURL")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ErrorURL provides a mock function with given fields:
 func (_m *Provider) ErrorURL() string {
 	ret := _m.Called()
@@ -37,9 +72,9 @@ func (_m *Provider) ErrorURL() string {
 	return r0
 }
 
-// Exchange provides a mock function with given fields: ctx, code
-func (_m *Provider) Exchange(ctx context.Context, code string) (xoauth2.Token, error) {
-	ret := _m.Called(ctx, code)
+// Exchange provides a mock function with given fields: ctx, state, code
+func (_m *Provider) Exchange(ctx context.Context, state string, code string) (xoauth2.Token, error) {
+	ret := _m.Called(ctx, state, code)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Exchange")
@@ -47,17 +82,17 @@ func (_m *Provider) Exchange(ctx context.Context, code string) (xoauth2.Token, e
 
 	var r0 xoauth2.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (xoauth2.Token, error)); ok {
-		return rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (xoauth2.Token, error)); ok {
+		return rf(ctx, state, code)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) xoauth2.Token); ok {
-		r0 = rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) xoauth2.Token); ok {
+		r0 = rf(ctx, state, code)
 	} else {
 		r0 = ret.Get(0).(xoauth2.Token)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, code)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, state, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,27 +154,9 @@ func (_m *Provider) RedirectURL() string {
 	return r0
 }
 
-// State provides a mock function with given fields:
-func (_m *Provider) State() string {
-	ret := _m.Called()
-
-	if len(ret) == 0 {
-		panic("no return value specified for State")
-	}
-
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	return r0
-}
-
-// UserInfo provides a mock function with given fields: accessToken
-func (_m *Provider) UserInfo(accessToken string) (clients.Client, error) {
-	ret := _m.Called(accessToken)
+// UserInfo provides a mock function with given fields: ctx, token
+func (_m *Provider) UserInfo(ctx context.Context, token xoauth2.Token) (clients.Client, error) {
+	ret := _m.Called(ctx, token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserInfo")
@@ -147,17 +164,17 @@ func (_m *Provider) UserInfo(accessToken string) (clients.Client, error) {
 
 	var r0 clients.Client
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (clients.Client, error)); ok {
-		return rf(accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, xoauth2.Token) (clients.Client, error)); ok {
+		return rf(ctx, token)
 	}
-	if rf, ok := ret.Get(0).(func(string) clients.Client); ok {
-		r0 = rf(accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, xoauth2.Token) clients.Client); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Get(0).(clients.Client)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(accessToken)
+	if rf, ok := ret.Get(1).(func(context.Context, xoauth2.Token) error); ok {
+		r1 = rf(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
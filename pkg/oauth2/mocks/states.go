@@ -0,0 +1,77 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	oauth2 "github.com/absmach/magistrala/pkg/oauth2"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StateRepository is an autogenerated mock type for the StateRepository type
+type StateRepository struct {
+	mock.Mock
+}
+
+// Retrieve provides a mock function with given fields: ctx, provider, state
+func (_m *StateRepository) Retrieve(ctx context.Context, provider string, state string) (oauth2.State, error) {
+	ret := _m.Called(ctx, provider, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 oauth2.State
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (oauth2.State, error)); ok {
+		return rf(ctx, provider, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) oauth2.State); ok {
+		r0 = rf(ctx, provider, state)
+	} else {
+		r0 = ret.Get(0).(oauth2.State)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, state
+func (_m *StateRepository) Save(ctx context.Context, state oauth2.State) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, oauth2.State) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStateRepository creates a new instance of StateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StateRepository {
+	mock := &StateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
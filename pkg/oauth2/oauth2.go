@@ -5,19 +5,97 @@ package oauth2
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
 
 	mfclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
 	"golang.org/x/oauth2"
 )
 
+const randomBytes = 32
+
+var (
+	// ErrInvalidState indicates unknown, expired or already used OAuth2 state,
+	// or the state which is not bound to the browser completing the flow.
+	ErrInvalidState = errors.New("invalid OAuth2 state")
+
+	// ErrUnverifiedEmail indicates that the identity provider
+	// has not verified the email of the user.
+	ErrUnverifiedEmail = errors.New("email is not verified by the identity provider")
+)
+
 // Config is the configuration for the OAuth2 provider.
 type Config struct {
 	ClientID     string `env:"CLIENT_ID"       envDefault:""`
 	ClientSecret string `env:"CLIENT_SECRET"   envDefault:""`
-	State        string `env:"STATE"           envDefault:""`
 	RedirectURL  string `env:"REDIRECT_URL"    envDefault:""`
 }
 
+// State holds the per-request values of the authorization code flow.
+// It is stored server-side until the provider redirects the user back.
+type State struct {
+	State     string
+	Provider  string
+	Nonce     string
+	Verifier  string
+	ExpiresAt time.Time
+}
+
+// NewState returns new state with random state, nonce and PKCE verifier.
+func NewState(provider string, duration time.Duration) (State, error) {
+	state, err := random()
+	if err != nil {
+		return State{}, err
+	}
+	nonce, err := random()
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{
+		State:     state,
+		Provider:  provider,
+		Nonce:     nonce,
+		Verifier:  oauth2.GenerateVerifier(),
+		ExpiresAt: time.Now().UTC().Add(duration),
+	}, nil
+}
+
+// StateRepository specifies the persistence API for the state of
+// the pending authorization requests.
+//
+//go:generate mockery --name StateRepository --output=./mocks --filename states.go --quiet --note "Copyright (c) Abstract Machines"
+type StateRepository interface {
+	// Save persists the state.
+	Save(ctx context.Context, state State) error
+
+	// Retrieve retrieves and removes the non-expired state of the provider,
+	// so each state can be used only once.
+	Retrieve(ctx context.Context, provider, state string) (State, error)
+}
+
+// Identity links the user to the account of the identity provider.
+type Identity struct {
+	Provider  string
+	Subject   string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// IdentityRepository specifies the persistence API for the links
+// between the users and the accounts of the identity providers.
+//
+//go:generate mockery --name IdentityRepository --output=./mocks --filename identities.go --quiet --note "Copyright (c) Abstract Machines"
+type IdentityRepository interface {
+	// Save persists the identity.
+	Save(ctx context.Context, identity Identity) error
+
+	// Retrieve retrieves the identity of the provider account with the given subject.
+	Retrieve(ctx context.Context, provider, subject string) (Identity, error)
+}
+
 // Provider is an interface that provides the OAuth2 flow for a specific provider
 // (e.g. Google, GitHub, etc.)
 //
@@ -26,8 +104,11 @@ type Provider interface {
 	// Name returns the name of the OAuth2 provider.
 	Name() string
 
-	// State returns the current state for the OAuth2 flow.
-	State() string
+	// AuthURL starts the OAuth2 flow and returns the URL of the provider's
+	// consent page and the state of the flow. The state has to be bound to
+	// the browser starting the flow, e.g. using a cookie, and checked on
+	// callback, so the flow can't be completed by another browser.
+	AuthURL(ctx context.Context) (string, string, error)
 
 	// RedirectURL returns the URL to redirect the user to after completing the OAuth2 flow.
 	RedirectURL() string
@@ -38,9 +119,18 @@ type Provider interface {
 	// IsEnabled checks if the OAuth2 provider is enabled.
 	IsEnabled() bool
 
-	// Exchange converts an authorization code into a token.
-	Exchange(ctx context.Context, code string) (oauth2.Token, error)
+	// Exchange validates the state and converts an authorization code into a token.
+	Exchange(ctx context.Context, state, code string) (oauth2.Token, error)
+
+	// UserInfo retrieves the user's information using the token.
+	UserInfo(ctx context.Context, token oauth2.Token) (mfclients.Client, error)
+}
+
+func random() (string, error) {
+	b := make([]byte, randomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
 
-	// UserInfo retrieves the user's information using the access token.
-	UserInfo(accessToken string) (mfclients.Client, error)
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
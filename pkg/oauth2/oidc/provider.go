@@ -0,0 +1,422 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	mfclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgoauth2 "github.com/absmach/magistrala/pkg/oauth2"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	defTimeout      = 1 * time.Minute
+	stateDuration   = 10 * time.Minute
+	keysRefresh     = 1 * time.Minute
+	acceptableSkew  = 1 * time.Minute
+	discoveryPath   = "/.well-known/openid-configuration"
+	idTokenField    = "id_token"
+	nonceClaim      = "nonce"
+	providerKey     = "oauth_provider"
+	groupsKey       = "oauth_groups"
+	claimsSeparator = "."
+)
+
+var (
+	// ErrDiscovery indicates failure to retrieve or validate the discovery document.
+	ErrDiscovery = errors.New("failed to discover OpenID Connect provider")
+
+	// ErrInvalidIDToken indicates missing or invalid ID token.
+	ErrInvalidIDToken = errors.New("invalid ID token")
+)
+
+// Config is the configuration of the OpenID Connect provider.
+type Config struct {
+	ClientID     string   `env:"CLIENT_ID"     envDefault:""`
+	ClientSecret string   `env:"CLIENT_SECRET" envDefault:""`
+	RedirectURL  string   `env:"REDIRECT_URL"  envDefault:""`
+	IssuerURL    string   `env:"ISSUER_URL"    envDefault:""`
+	Scopes       []string `env:"SCOPES"        envDefault:"openid,profile,email"`
+	// Claims are mapped to the user fields. Nested claims are
+	// separated with dot, e.g. "realm_access.roles".
+	NameClaim          string `env:"NAME_CLAIM"           envDefault:"name"`
+	EmailClaim         string `env:"EMAIL_CLAIM"          envDefault:"email"`
+	EmailVerifiedClaim string `env:"EMAIL_VERIFIED_CLAIM" envDefault:"email_verified"`
+	GroupsClaim        string `env:"GROUPS_CLAIM"         envDefault:"groups"`
+}
+
+// discovery is the subset of the OpenID Connect discovery document.
+type discovery struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+	JWKSURL     string `json:"jwks_uri"`
+}
+
+var _ mgoauth2.Provider = (*provider)(nil)
+
+type provider struct {
+	name          string
+	cfg           Config
+	states        mgoauth2.StateRepository
+	client        *http.Client
+	uiRedirectURL string
+	errorURL      string
+
+	mu          sync.Mutex
+	discovery   *discovery
+	keys        jwk.Set
+	keysFetched time.Time
+}
+
+// NewProvider returns a new OpenID Connect provider with the given name.
+// The discovery document is retrieved on the first use, so the provider
+// does not have to be available when the service starts.
+func NewProvider(name string, cfg Config, states mgoauth2.StateRepository, uiRedirectURL, errorURL string) mgoauth2.Provider {
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &provider{
+		name:          name,
+		cfg:           cfg,
+		states:        states,
+		client:        &http.Client{Timeout: defTimeout},
+		uiRedirectURL: uiRedirectURL,
+		errorURL:      errorURL,
+	}
+}
+
+func (p *provider) Name() string {
+	return p.name
+}
+
+func (p *provider) AuthURL(ctx context.Context) (string, string, error) {
+	config, err := p.config(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := mgoauth2.NewState(p.name, stateDuration)
+	if err != nil {
+		return "", "", err
+	}
+	if err := p.states.Save(ctx, state); err != nil {
+		return "", "", err
+	}
+
+	return config.AuthCodeURL(state.State, oauth2.S256ChallengeOption(state.Verifier), oauth2.SetAuthURLParam(nonceClaim, state.Nonce)), state.State, nil
+}
+
+func (p *provider) RedirectURL() string {
+	return p.uiRedirectURL
+}
+
+func (p *provider) ErrorURL() string {
+	return p.errorURL
+}
+
+func (p *provider) IsEnabled() bool {
+	return p.cfg.ClientID != "" && p.cfg.IssuerURL != ""
+}
+
+func (p *provider) Exchange(ctx context.Context, state, code string) (oauth2.Token, error) {
+	s, err := p.states.Retrieve(ctx, p.name, state)
+	if err != nil {
+		return oauth2.Token{}, errors.Wrap(mgoauth2.ErrInvalidState, err)
+	}
+
+	config, err := p.config(ctx)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	token, err := config.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(s.Verifier))
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+	if _, err := p.verify(ctx, *token, s.Nonce); err != nil {
+		return oauth2.Token{}, err
+	}
+
+	return *token, nil
+}
+
+func (p *provider) UserInfo(ctx context.Context, token oauth2.Token) (mfclients.Client, error) {
+	idToken, err := p.verify(ctx, token, "")
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	claims, err := idToken.AsMap(ctx)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(ErrInvalidIDToken, err)
+	}
+
+	// Some providers return only the subject in the ID token,
+	// so the remaining claims are read from the UserInfo endpoint.
+	if stringClaim(claims, p.cfg.NameClaim) == "" || stringClaim(claims, p.cfg.EmailClaim) == "" || claim(claims, p.cfg.EmailVerifiedClaim) == nil {
+		if err := p.userInfo(ctx, token, idToken.Subject(), claims); err != nil {
+			return mfclients.Client{}, err
+		}
+	}
+
+	email := stringClaim(claims, p.cfg.EmailClaim)
+	if idToken.Subject() == "" || email == "" {
+		return mfclients.Client{}, svcerr.ErrAuthentication
+	}
+	// Users are registered with the email verified by the provider,
+	// so the unverified email can't be used to log in.
+	if !boolClaim(claims, p.cfg.EmailVerifiedClaim) {
+		return mfclients.Client{}, mgoauth2.ErrUnverifiedEmail
+	}
+	name := stringClaim(claims, p.cfg.NameClaim)
+	if name == "" {
+		name = email
+	}
+
+	metadata := map[string]interface{}{
+		providerKey: p.name,
+	}
+	if groups := stringsClaim(claims, p.cfg.GroupsClaim); len(groups) > 0 {
+		metadata[groupsKey] = groups
+	}
+
+	return mfclients.Client{
+		ID:   idToken.Subject(),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: email,
+		},
+		Metadata: metadata,
+		Status:   mfclients.EnabledStatus,
+	}, nil
+}
+
+// verify verifies signature, issuer, audience and expiration of the ID token
+// and the nonce if it's not empty.
+func (p *provider) verify(ctx context.Context, token oauth2.Token, nonce string) (jwt.Token, error) {
+	raw, ok := token.Extra(idTokenField).(string)
+	if !ok || raw == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []jwt.ParseOption{
+		jwt.WithValidate(true),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithAcceptableSkew(acceptableSkew),
+	}
+	if nonce != "" {
+		opts = append(opts, jwt.WithClaimValue(nonceClaim, nonce))
+	}
+
+	keys, err := p.keySet(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := jwt.ParseString(raw, append(opts, jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)))...)
+	if err == nil {
+		return idToken, nil
+	}
+
+	// Retry with refreshed keys in case the provider rotated them.
+	refreshed, rerr := p.keySet(ctx, true)
+	if rerr != nil || refreshed == keys {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+	idToken, err = jwt.ParseString(raw, append(opts, jwt.WithKeySet(refreshed, jws.WithInferAlgorithmFromKey(true)))...)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+
+	return idToken, nil
+}
+
+func (p *provider) userInfo(ctx context.Context, token oauth2.Token, subject string, claims map[string]interface{}) error {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return err
+	}
+	if d.UserInfoURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.UserInfoURL, nil)
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(req)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return svcerr.ErrAuthentication
+	}
+
+	var info map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return err
+	}
+	// The subject of the UserInfo response must match the ID token.
+	if sub, _ := info["sub"].(string); sub != subject {
+		return svcerr.ErrAuthentication
+	}
+	for k, v := range info {
+		if _, ok := claims[k]; !ok {
+			claims[k] = v
+		}
+	}
+
+	return nil
+}
+
+func (p *provider) config(ctx context.Context) (*oauth2.Config, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  d.AuthURL,
+			TokenURL: d.TokenURL,
+		},
+		RedirectURL: p.cfg.RedirectURL,
+		Scopes:      p.cfg.Scopes,
+	}, nil
+}
+
+// discover retrieves the discovery document once and caches it.
+func (p *provider) discover(ctx context.Context) (discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return *p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.IssuerURL+discoveryPath, nil)
+	if err != nil {
+		return discovery{}, errors.Wrap(ErrDiscovery, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return discovery{}, errors.Wrap(ErrDiscovery, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return discovery{}, errors.Wrap(ErrDiscovery, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	var d discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return discovery{}, errors.Wrap(ErrDiscovery, err)
+	}
+	if d.Issuer != p.cfg.IssuerURL {
+		return discovery{}, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer %s does not match %s", d.Issuer, p.cfg.IssuerURL))
+	}
+	if d.AuthURL == "" || d.TokenURL == "" || d.JWKSURL == "" {
+		return discovery{}, errors.Wrap(ErrDiscovery, errors.New("missing endpoints"))
+	}
+	p.discovery = &d
+
+	return d, nil
+}
+
+// keySet returns cached provider keys. Keys are fetched if they are missing
+// or refresh is requested, but not more often than once per keysRefresh.
+func (p *provider) keySet(ctx context.Context, refresh bool) (jwk.Set, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil && (!refresh || time.Since(p.keysFetched) < keysRefresh) {
+		return p.keys, nil
+	}
+	keys, err := jwk.Fetch(ctx, d.JWKSURL, jwk.WithHTTPClient(p.client))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+	p.keys = keys
+	p.keysFetched = time.Now()
+
+	return keys, nil
+}
+
+func claim(claims map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+	var value interface{} = claims
+	for _, key := range strings.Split(path, claimsSeparator) {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+
+	return value
+}
+
+func stringClaim(claims map[string]interface{}, path string) string {
+	s, _ := claim(claims, path).(string)
+
+	return s
+}
+
+// boolClaim returns the boolean claim. Some providers
+// send boolean claims as strings, so those are accepted too.
+func boolClaim(claims map[string]interface{}, path string) bool {
+	switch v := claim(claims, path).(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+func stringsClaim(claims map[string]interface{}, path string) []string {
+	switch v := claim(claims, path).(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var ret []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mfclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgoauth2 "github.com/absmach/magistrala/pkg/oauth2"
+	"github.com/absmach/magistrala/pkg/oauth2/mocks"
+	"github.com/absmach/magistrala/pkg/oauth2/oidc"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const (
+	providerName = "keycloak"
+	clientID     = "magistrala"
+	clientSecret = "secret"
+	subject      = "f2a3c6c8-7c36-4d4e-b1ab-1a7a3c2e5d10"
+	email        = "john.doe@example.com"
+	validCode    = "code"
+)
+
+// idp is a minimal OpenID Connect provider.
+type idp struct {
+	server    *httptest.Server
+	key       jwk.Key
+	keys      jwk.Set
+	challenge string
+	claims    map[string]interface{}
+	userInfo  map[string]interface{}
+}
+
+func newIDP(t *testing.T) *idp {
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("creating JWK expected to succeed: %s", err))
+	require.Nil(t, key.Set(jwk.KeyIDKey, "kid"))
+	pub, err := key.PublicKey()
+	require.Nil(t, err, fmt.Sprintf("creating public JWK expected to succeed: %s", err))
+	keys := jwk.NewSet()
+	require.Nil(t, keys.AddKey(pub))
+
+	p := &idp{key: key, keys: keys}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/auth",
+			"token_endpoint":         p.server.URL + "/token",
+			"userinfo_endpoint":      p.server.URL + "/userinfo",
+			"jwks_uri":               p.server.URL + "/certs",
+		})
+	})
+	mux.HandleFunc("/certs", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(p.keys)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != validCode || base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     p.idToken(t, p.claims),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(p.userInfo)
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+func (p *idp) idToken(t *testing.T, claims map[string]interface{}) string {
+	token := jwt.New()
+	for k, v := range claims {
+		require.Nil(t, token.Set(k, v))
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, p.key))
+	require.Nil(t, err, fmt.Sprintf("signing ID token expected to succeed: %s", err))
+
+	return string(signed)
+}
+
+func (p *idp) validClaims(nonce string) map[string]interface{} {
+	return map[string]interface{}{
+		jwt.IssuerKey:     p.server.URL,
+		jwt.SubjectKey:    subject,
+		jwt.AudienceKey:   clientID,
+		jwt.ExpirationKey: time.Now().Add(time.Minute).Unix(),
+		jwt.IssuedAtKey:   time.Now().Unix(),
+		"nonce":           nonce,
+		"name":            "John Doe",
+		"email":           email,
+		"email_verified":  true,
+		"realm_access":    map[string]interface{}{"roles": []string{"iot-ops", "viewers"}},
+	}
+}
+
+func newProvider(p *idp) (mgoauth2.Provider, *mocks.StateRepository) {
+	states := new(mocks.StateRepository)
+	cfg := oidc.Config{
+		ClientID:           clientID,
+		ClientSecret:       clientSecret,
+		RedirectURL:        "http://localhost/oauth/callback/" + providerName,
+		IssuerURL:          p.server.URL + "/",
+		Scopes:             []string{"openid", "profile", "email"},
+		NameClaim:          "name",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		GroupsClaim:        "realm_access.roles",
+	}
+
+	return oidc.NewProvider(providerName, cfg, states, "http://localhost/ui", "http://localhost/error"), states
+}
+
+// authorize starts the flow and stores the PKCE challenge at the provider.
+func authorize(t *testing.T, p *idp, provider mgoauth2.Provider, states *mocks.StateRepository) mgoauth2.State {
+	var state mgoauth2.State
+	call := states.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		state = args.Get(1).(mgoauth2.State)
+	}).Return(nil)
+	defer call.Unset()
+
+	authURL, st, err := provider.AuthURL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("auth URL expected to succeed: %s", err))
+	assert.Equal(t, state.State, st)
+	u, err := url.Parse(authURL)
+	require.Nil(t, err, fmt.Sprintf("parsing auth URL expected to succeed: %s", err))
+	q := u.Query()
+	assert.Equal(t, p.server.URL+"/auth", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path))
+	assert.Equal(t, state.State, q.Get("state"))
+	assert.Equal(t, state.Nonce, q.Get("nonce"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, clientID, q.Get("client_id"))
+	p.challenge = q.Get("code_challenge")
+
+	return state
+}
+
+func TestAuthURL(t *testing.T) {
+	p := newIDP(t)
+	defer p.server.Close()
+	provider, states := newProvider(p)
+
+	first := authorize(t, p, provider, states)
+	second := authorize(t, p, provider, states)
+	assert.Equal(t, providerName, first.Provider)
+	assert.NotEqual(t, first.State, second.State, "expected unique state per request")
+	assert.NotEqual(t, first.Nonce, second.Nonce, "expected unique nonce per request")
+	assert.True(t, first.ExpiresAt.After(time.Now()), "expected state not to be expired")
+
+	states.On("Save", mock.Anything, mock.Anything).Return(repoerr.ErrCreateEntity)
+	_, _, err := provider.AuthURL(context.Background())
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("expected %s got %s", repoerr.ErrCreateEntity, err))
+}
+
+func TestExchange(t *testing.T) {
+	p := newIDP(t)
+	defer p.server.Close()
+
+	cases := []struct {
+		desc        string
+		code        string
+		retrieveErr error
+		claims      func(nonce string) map[string]interface{}
+		exchangeErr bool
+		err         error
+	}{
+		{
+			desc:   "exchange code successfully",
+			code:   validCode,
+			claims: p.validClaims,
+			err:    nil,
+		},
+		{
+			desc:        "exchange code with invalid state",
+			code:        validCode,
+			retrieveErr: repoerr.ErrNotFound,
+			claims:      p.validClaims,
+			err:         mgoauth2.ErrInvalidState,
+		},
+		{
+			desc:        "exchange invalid code",
+			code:        "invalid",
+			claims:      p.validClaims,
+			exchangeErr: true,
+		},
+		{
+			desc: "exchange code with invalid nonce",
+			code: validCode,
+			claims: func(string) map[string]interface{} {
+				return p.validClaims("invalid")
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			desc: "exchange code with invalid audience",
+			code: validCode,
+			claims: func(nonce string) map[string]interface{} {
+				claims := p.validClaims(nonce)
+				claims[jwt.AudienceKey] = "another-client"
+				return claims
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			desc: "exchange code with invalid issuer",
+			code: validCode,
+			claims: func(nonce string) map[string]interface{} {
+				claims := p.validClaims(nonce)
+				claims[jwt.IssuerKey] = "https://another.example.com"
+				return claims
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			desc: "exchange code with expired ID token",
+			code: validCode,
+			claims: func(nonce string) map[string]interface{} {
+				claims := p.validClaims(nonce)
+				claims[jwt.ExpirationKey] = time.Now().Add(-time.Hour).Unix()
+				return claims
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			provider, states := newProvider(p)
+			state := authorize(t, p, provider, states)
+			p.claims = tc.claims(state.Nonce)
+			states.On("Retrieve", mock.Anything, providerName, state.State).Return(state, tc.retrieveErr)
+
+			token, err := provider.Exchange(context.Background(), state.State, tc.code)
+			if tc.exchangeErr {
+				assert.NotNil(t, err, fmt.Sprintf("%s: expected error got nil", tc.desc))
+				return
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, "access", token.AccessToken)
+			}
+		})
+	}
+}
+
+func TestExchangeWithUnknownKey(t *testing.T) {
+	p := newIDP(t)
+	defer p.server.Close()
+	provider, states := newProvider(p)
+
+	// The provider signs tokens with a key which is not published.
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("creating JWK expected to succeed: %s", err))
+	require.Nil(t, key.Set(jwk.KeyIDKey, "kid"))
+	p.key = key
+
+	state := authorize(t, p, provider, states)
+	p.claims = p.validClaims(state.Nonce)
+	states.On("Retrieve", mock.Anything, providerName, state.State).Return(state, nil)
+
+	_, err = provider.Exchange(context.Background(), state.State, validCode)
+	assert.True(t, errors.Contains(err, oidc.ErrInvalidIDToken), fmt.Sprintf("expected %s got %s", oidc.ErrInvalidIDToken, err))
+}
+
+func TestUserInfo(t *testing.T) {
+	p := newIDP(t)
+	defer p.server.Close()
+
+	cases := []struct {
+		desc     string
+		claims   map[string]interface{}
+		userInfo map[string]interface{}
+		response mfclients.Client
+		err      error
+	}{
+		{
+			desc:   "retrieve user info from ID token",
+			claims: p.validClaims(""),
+			response: mfclients.Client{
+				ID:          subject,
+				Name:        "John Doe",
+				Credentials: mfclients.Credentials{Identity: email},
+				Metadata: map[string]interface{}{
+					"oauth_provider": providerName,
+					"oauth_groups":   []string{"iot-ops", "viewers"},
+				},
+				Status: mfclients.EnabledStatus,
+			},
+			err: nil,
+		},
+		{
+			desc: "retrieve user info from UserInfo endpoint",
+			claims: func() map[string]interface{} {
+				claims := p.validClaims("")
+				delete(claims, "email")
+				delete(claims, "email_verified")
+				delete(claims, "name")
+				delete(claims, "realm_access")
+				return claims
+			}(),
+			userInfo: map[string]interface{}{
+				"sub":            subject,
+				"email":          email,
+				"email_verified": "true",
+			},
+			response: mfclients.Client{
+				ID:          subject,
+				Name:        email,
+				Credentials: mfclients.Credentials{Identity: email},
+				Metadata: map[string]interface{}{
+					"oauth_provider": providerName,
+				},
+				Status: mfclients.EnabledStatus,
+			},
+			err: nil,
+		},
+		{
+			desc: "retrieve user info with UserInfo subject mismatch",
+			claims: func() map[string]interface{} {
+				claims := p.validClaims("")
+				delete(claims, "email")
+				return claims
+			}(),
+			userInfo: map[string]interface{}{
+				"sub":   "another-subject",
+				"email": email,
+			},
+			err: svcerr.ErrAuthentication,
+		},
+		{
+			desc: "retrieve user info without email",
+			claims: func() map[string]interface{} {
+				claims := p.validClaims("")
+				delete(claims, "email")
+				return claims
+			}(),
+			userInfo: map[string]interface{}{
+				"sub": subject,
+			},
+			err: svcerr.ErrAuthentication,
+		},
+		{
+			desc: "retrieve user info with unverified email",
+			claims: func() map[string]interface{} {
+				claims := p.validClaims("")
+				claims["email_verified"] = false
+				return claims
+			}(),
+			err: mgoauth2.ErrUnverifiedEmail,
+		},
+		{
+			desc: "retrieve user info without email verification",
+			claims: func() map[string]interface{} {
+				claims := p.validClaims("")
+				delete(claims, "email_verified")
+				return claims
+			}(),
+			userInfo: map[string]interface{}{
+				"sub":   subject,
+				"email": email,
+			},
+			err: mgoauth2.ErrUnverifiedEmail,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			provider, _ := newProvider(p)
+			p.userInfo = tc.userInfo
+			token := (&oauth2.Token{AccessToken: "access"}).WithExtra(map[string]interface{}{
+				"id_token": p.idToken(t, tc.claims),
+			})
+
+			client, err := provider.UserInfo(context.Background(), *token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.response, client)
+			}
+		})
+	}
+
+	provider, _ := newProvider(p)
+	_, err := provider.UserInfo(context.Background(), oauth2.Token{AccessToken: "access"})
+	assert.True(t, errors.Contains(err, oidc.ErrInvalidIDToken), fmt.Sprintf("expected %s got %s", oidc.ErrInvalidIDToken, err))
+}
@@ -29,3 +29,16 @@ func MakeMetrics(namespace, subsystem string) (*kitprometheus.Counter, *kitprome
 
 	return counter, latency
 }
+
+// MakeCacheMetrics returns the cache lookups counter,
+// labeled with the lookup result, hit or miss.
+//
+//	counter := metrics.MakeCacheMetrics("demo-service", "cache")
+func MakeCacheMetrics(namespace, subsystem string) *kitprometheus.Counter {
+	return kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "lookup_count",
+		Help:      "Number of cache lookups.",
+	}, []string{"result"})
+}
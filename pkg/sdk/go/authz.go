@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const authzEndpoint = "authz"
+
+// ExplainRequest is the permission check to explain. The subject defaults
+// to the user of the token.
+type ExplainRequest struct {
+	SubjectType string `json:"subject_type,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	Permission  string `json:"permission"`
+	ObjectType  string `json:"object_type"`
+	Object      string `json:"object"`
+	SourceIP    string `json:"source_ip,omitempty"`
+}
+
+// PolicyStep is a relationship between the subject and the object of
+// the explained permission check.
+type PolicyStep struct {
+	SubjectType string `json:"subject_type"`
+	Subject     string `json:"subject"`
+	Relation    string `json:"relation"`
+	ObjectType  string `json:"object_type"`
+	Object      string `json:"object"`
+}
+
+// PolicyExplanation contains the authorization decision, the relationships
+// granting the permission if allowed, and the relationships that would
+// grant it if denied.
+type PolicyExplanation struct {
+	Allowed  bool         `json:"allowed"`
+	Reason   string       `json:"reason,omitempty"`
+	Path     []PolicyStep `json:"path,omitempty"`
+	Required []PolicyStep `json:"required,omitempty"`
+}
+
+func (sdk mgSDK) ExplainPolicy(req ExplainRequest, token string) (PolicyExplanation, errors.SDKError) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return PolicyExplanation{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/explain", sdk.domainsURL, authzEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return PolicyExplanation{}, sdkerr
+	}
+
+	var exp PolicyExplanation
+	if err := json.Unmarshal(body, &exp); err != nil {
+		return PolicyExplanation{}, errors.NewSDKError(err)
+	}
+
+	return exp, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	httpapi "github.com/absmach/magistrala/auth/api/http/authz"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuthz() (*httptest.Server, *authmocks.Service) {
+	svc := new(authmocks.Service)
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+
+	mux = httpapi.MakeHandler(svc, mux, logger)
+	return httptest.NewServer(mux), svc
+}
+
+func TestExplainPolicy(t *testing.T) {
+	as, svc := setupAuthz()
+	defer as.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     as.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	thingID := generateUUID(t)
+	groupID := generateUUID(t)
+	req := sdk.ExplainRequest{
+		Permission: auth.ViewPermission,
+		ObjectType: auth.ThingType,
+		Object:     thingID,
+	}
+	pr := auth.PolicyReq{
+		Permission: auth.ViewPermission,
+		ObjectType: auth.ThingType,
+		Object:     thingID,
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		req      sdk.ExplainRequest
+		svcRes   auth.PolicyExplanation
+		svcErr   error
+		response sdk.PolicyExplanation
+		err      error
+	}{
+		{
+			desc:  "explain allowed permission successfully",
+			token: validToken,
+			req:   req,
+			svcRes: auth.PolicyExplanation{
+				Allowed: true,
+				Path: []auth.PolicyStep{
+					{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: groupID},
+					{SubjectType: auth.GroupType, Subject: groupID, Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: thingID},
+				},
+			},
+			response: sdk.PolicyExplanation{
+				Allowed: true,
+				Path: []sdk.PolicyStep{
+					{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.GroupType, Object: groupID},
+					{SubjectType: auth.GroupType, Subject: groupID, Relation: auth.GroupRelation, ObjectType: auth.ThingType, Object: thingID},
+				},
+			},
+		},
+		{
+			desc:  "explain denied permission successfully",
+			token: validToken,
+			req:   req,
+			svcRes: auth.PolicyExplanation{
+				Required: []auth.PolicyStep{
+					{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: thingID},
+				},
+			},
+			response: sdk.PolicyExplanation{
+				Required: []sdk.PolicyStep{
+					{SubjectType: auth.UserType, Subject: "user", Relation: auth.AdministratorRelation, ObjectType: auth.ThingType, Object: thingID},
+				},
+			},
+		},
+		{
+			desc:   "explain with invalid token",
+			token:  invalidToken,
+			req:    req,
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "explain with empty token",
+			token: "",
+			req:   req,
+			err:   errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:  "explain with missing object",
+			token: validToken,
+			req:   sdk.ExplainRequest{Permission: auth.ViewPermission, ObjectType: auth.ThingType},
+			err:   errors.NewSDKErrorWithStatus(apiutil.ErrMissingPolicyObj, http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ExplainPolicy", mock.Anything, tc.token, pr).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ExplainPolicy(tc.req, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ExplainPolicy", mock.Anything, tc.token, pr)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
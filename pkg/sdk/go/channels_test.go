@@ -45,11 +45,12 @@ func setupChannels() (*httptest.Server, *gmocks.Service) {
 	logger := mglog.NewMock()
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
+	tsvc.On("RemoveConnectionACL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	mux := chi.NewRouter()
 
 	thapi.MakeHandler(tsvc, gsvc, mux, logger, "")
-	usapi.MakeHandler(usvc, gsvc, mux, logger, "", passRegex, provider)
+	usapi.MakeHandler(usvc, gsvc, mux, logger, "", passRegex, nil, provider)
 	return httptest.NewServer(mux), gsvc
 }
 
@@ -13,7 +13,11 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 )
 
-const domainsEndpoint = "domains"
+const (
+	domainsEndpoint  = "domains"
+	usageEndpoint    = "usage"
+	deletionEndpoint = "deletion"
+)
 
 // Domain represents magistrala domain.
 type Domain struct {
@@ -29,6 +33,45 @@ type Domain struct {
 	UpdatedBy   string    `json:"updated_by,omitempty"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 	Permissions []string  `json:"permissions,omitempty"`
+	MFARequired *bool     `json:"mfa_required,omitempty"`
+	Quotas      *Quotas   `json:"quotas,omitempty"`
+}
+
+// Quotas represents the limits of the domain resources.
+// Zero limit means the resource is not limited.
+type Quotas struct {
+	Things         uint64 `json:"things,omitempty"`
+	Channels       uint64 `json:"channels,omitempty"`
+	Users          uint64 `json:"users,omitempty"`
+	MessagesPerDay uint64 `json:"messages_per_day,omitempty"`
+	Storage        uint64 `json:"storage,omitempty"`
+}
+
+// Usage represents the consumption of the domain resources.
+type Usage struct {
+	Things   uint64 `json:"things"`
+	Channels uint64 `json:"channels"`
+	Users    uint64 `json:"users"`
+	Messages uint64 `json:"messages"`
+	Storage  uint64 `json:"storage"`
+}
+
+// DomainUsage contains the domain quotas and their consumption.
+type DomainUsage struct {
+	Quotas Quotas `json:"quotas"`
+	Usage  Usage  `json:"usage"`
+}
+
+// DomainDeletion represents the asynchronous deletion of the domain and its data.
+type DomainDeletion struct {
+	DomainID    string    `json:"domain_id"`
+	Status      string    `json:"status"`
+	RequestedBy string    `json:"requested_by"`
+	RequestedAt time.Time `json:"requested_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Attempts    uint64    `json:"attempts"`
+	RetryAt     time.Time `json:"retry_at"`
+	Error       string    `json:"error,omitempty"`
 }
 
 func (sdk mgSDK) CreateDomain(domain Domain, token string) (Domain, errors.SDKError) {
@@ -109,6 +152,63 @@ func (sdk mgSDK) DomainPermissions(domainID, token string) (Domain, errors.SDKEr
 	return domain, nil
 }
 
+func (sdk mgSDK) DomainUsage(domainID, token string) (DomainUsage, errors.SDKError) {
+	if domainID == "" {
+		return DomainUsage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID, usageEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return DomainUsage{}, sdkerr
+	}
+
+	var usage DomainUsage
+	if err := json.Unmarshal(body, &usage); err != nil {
+		return DomainUsage{}, errors.NewSDKError(err)
+	}
+
+	return usage, nil
+}
+
+func (sdk mgSDK) DeleteDomain(domainID, token string) (DomainDeletion, errors.SDKError) {
+	if domainID == "" {
+		return DomainDeletion{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusAccepted)
+	if sdkerr != nil {
+		return DomainDeletion{}, sdkerr
+	}
+
+	var dd DomainDeletion
+	if err := json.Unmarshal(body, &dd); err != nil {
+		return DomainDeletion{}, errors.NewSDKError(err)
+	}
+
+	return dd, nil
+}
+
+func (sdk mgSDK) DomainDeletion(domainID, token string) (DomainDeletion, errors.SDKError) {
+	if domainID == "" {
+		return DomainDeletion{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID, deletionEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return DomainDeletion{}, sdkerr
+	}
+
+	var dd DomainDeletion
+	if err := json.Unmarshal(body, &dd); err != nil {
+		return DomainDeletion{}, errors.NewSDKError(err)
+	}
+
+	return dd, nil
+}
+
 func (sdk mgSDK) Domains(pm PageMetadata, token string) (DomainsPage, errors.SDKError) {
 	url, err := sdk.withQueryParams(sdk.domainsURL, domainsEndpoint, pm)
 	if err != nil {
@@ -485,6 +485,283 @@ func TestDomainPermissions(t *testing.T) {
 	}
 }
 
+func TestDomainUsage(t *testing.T) {
+	ds, svc := setupDomains()
+	defer ds.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ds.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		svcRes   auth.DomainUsage
+		svcErr   error
+		response sdk.DomainUsage
+		err      error
+	}{
+		{
+			desc:     "retrieve domain usage successfully",
+			token:    validToken,
+			domainID: sdkDomain.ID,
+			svcRes: auth.DomainUsage{
+				Quotas: auth.Quotas{Things: 10, MessagesPerDay: 1000},
+				Usage:  auth.Usage{Things: 2, Messages: 100, Storage: 1024},
+			},
+			svcErr: nil,
+			response: sdk.DomainUsage{
+				Quotas: sdk.Quotas{Things: 10, MessagesPerDay: 1000},
+				Usage:  sdk.Usage{Things: 2, Messages: 100, Storage: 1024},
+			},
+			err: nil,
+		},
+		{
+			desc:     "retrieve domain usage with invalid token",
+			token:    invalidToken,
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainUsage{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.DomainUsage{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "retrieve domain usage with empty token",
+			token:    "",
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainUsage{},
+			svcErr:   nil,
+			response: sdk.DomainUsage{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:     "retrieve domain usage with empty domain id",
+			token:    validToken,
+			domainID: "",
+			svcRes:   auth.DomainUsage{},
+			svcErr:   nil,
+			response: sdk.DomainUsage{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:     "retrieve domain usage with invalid domain id",
+			token:    validToken,
+			domainID: wrongID,
+			svcRes:   auth.DomainUsage{},
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.DomainUsage{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RetrieveDomainUsage", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.DomainUsage(tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RetrieveDomainUsage", mock.Anything, tc.token, tc.domainID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDeleteDomain(t *testing.T) {
+	ds, svc := setupDomains()
+	defer ds.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ds.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	requestedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		svcRes   auth.DomainDeletion
+		svcErr   error
+		response sdk.DomainDeletion
+		err      error
+	}{
+		{
+			desc:     "delete domain successfully",
+			token:    validToken,
+			domainID: sdkDomain.ID,
+			svcRes: auth.DomainDeletion{
+				DomainID:    sdkDomain.ID,
+				Status:      auth.DeletionPending,
+				RequestedBy: sdkDomain.CreatedBy,
+				RequestedAt: requestedAt,
+				UpdatedAt:   requestedAt,
+			},
+			svcErr: nil,
+			response: sdk.DomainDeletion{
+				DomainID:    sdkDomain.ID,
+				Status:      string(auth.DeletionPending),
+				RequestedBy: sdkDomain.CreatedBy,
+				RequestedAt: requestedAt,
+				UpdatedAt:   requestedAt,
+			},
+			err: nil,
+		},
+		{
+			desc:     "delete domain with invalid token",
+			token:    invalidToken,
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "delete domain with empty token",
+			token:    "",
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   nil,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:     "delete domain with empty domain id",
+			token:    validToken,
+			domainID: "",
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   nil,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:     "delete domain with invalid domain id",
+			token:    validToken,
+			domainID: wrongID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DeleteDomain", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.DeleteDomain(tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DeleteDomain", mock.Anything, tc.token, tc.domainID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDomainDeletion(t *testing.T) {
+	ds, svc := setupDomains()
+	defer ds.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ds.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	requestedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		svcRes   auth.DomainDeletion
+		svcErr   error
+		response sdk.DomainDeletion
+		err      error
+	}{
+		{
+			desc:     "retrieve domain deletion successfully",
+			token:    validToken,
+			domainID: sdkDomain.ID,
+			svcRes: auth.DomainDeletion{
+				DomainID:    sdkDomain.ID,
+				Status:      auth.DeletionPending,
+				RequestedBy: sdkDomain.CreatedBy,
+				RequestedAt: requestedAt,
+				UpdatedAt:   requestedAt,
+			},
+			svcErr: nil,
+			response: sdk.DomainDeletion{
+				DomainID:    sdkDomain.ID,
+				Status:      string(auth.DeletionPending),
+				RequestedBy: sdkDomain.CreatedBy,
+				RequestedAt: requestedAt,
+				UpdatedAt:   requestedAt,
+			},
+			err: nil,
+		},
+		{
+			desc:     "retrieve domain deletion with invalid token",
+			token:    invalidToken,
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "retrieve domain deletion with empty token",
+			token:    "",
+			domainID: sdkDomain.ID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   nil,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:     "retrieve domain deletion with empty domain id",
+			token:    validToken,
+			domainID: "",
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   nil,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:     "retrieve domain deletion with invalid domain id",
+			token:    validToken,
+			domainID: wrongID,
+			svcRes:   auth.DomainDeletion{},
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.DomainDeletion{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RetrieveDomainDeletion", mock.Anything, tc.token, tc.domainID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.DomainDeletion(tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RetrieveDomainDeletion", mock.Anything, tc.token, tc.domainID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestListDomians(t *testing.T) {
 	ds, svc := setupDomains()
 	defer ds.Close()
@@ -1016,11 +1293,11 @@ func TestAddUserToDomain(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("AssignUsers", mock.Anything, tc.token, tc.domainID, tc.addUserDomainReq.UserIDs, tc.addUserDomainReq.Relation).Return(tc.svcErr)
+			svcCall := svc.On("AssignUsers", mock.Anything, tc.token, tc.domainID, tc.addUserDomainReq.UserIDs, tc.addUserDomainReq.Relation, (*auth.Condition)(nil)).Return(tc.svcErr)
 			err := mgsdk.AddUserToDomain(tc.domainID, tc.addUserDomainReq, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "AssignUsers", mock.Anything, tc.token, tc.domainID, tc.addUserDomainReq.UserIDs, tc.addUserDomainReq.Relation)
+				ok := svcCall.Parent.AssertCalled(t, "AssignUsers", mock.Anything, tc.token, tc.domainID, tc.addUserDomainReq.UserIDs, tc.addUserDomainReq.Relation, (*auth.Condition)(nil))
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -1131,6 +1408,7 @@ func generateTestDomain(t *testing.T) (auth.Domain, sdk.Domain) {
 		CreatedAt: ad.CreatedAt,
 		UpdatedBy: ad.UpdatedBy,
 		UpdatedAt: ad.UpdatedAt,
+		Quotas:    &sdk.Quotas{},
 	}
 	return ad, sd
 }
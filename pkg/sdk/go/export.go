@@ -0,0 +1,726 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// DomainArchiveVersion is the version of the domain archive format
+// produced by the export and accepted by the import.
+const DomainArchiveVersion = 1
+
+const (
+	exportPageLimit = 100
+	disabledStatus  = "disabled"
+	activeState     = 1
+
+	// Policy object type and entity name of the role assignments on the domain.
+	domainEntityType = "domain"
+	domainsEntity    = "domains"
+)
+
+// Kinds of the entities of the domain archive, used in the import report.
+const (
+	ThingsKind          = "things"
+	ChannelsKind        = "channels"
+	GroupsKind          = "groups"
+	ConnectionsKind     = "connections"
+	BootstrapsKind      = "bootstraps"
+	SubscriptionsKind   = "subscriptions"
+	RolesKind           = "roles"
+	RoleAssignmentsKind = "role_assignments"
+)
+
+// DomainArchive contains the domain entities moved between the deployments.
+// Entity IDs are the IDs of the exporting deployment, and role assignments
+// refer to the users by their identity.
+type DomainArchive struct {
+	Version         int                      `json:"version"`
+	DomainID        string                   `json:"domain_id"`
+	ExportedAt      time.Time                `json:"exported_at"`
+	WithSecrets     bool                     `json:"with_secrets"`
+	Things          []Thing                  `json:"things"`
+	Channels        []Channel                `json:"channels"`
+	Groups          []Group                  `json:"groups"`
+	Connections     []Connection             `json:"connections"`
+	Bootstraps      []BootstrapConfig        `json:"bootstraps"`
+	Subscriptions   []Subscription           `json:"subscriptions"`
+	Roles           []Role                   `json:"roles"`
+	RoleAssignments []ArchivedRoleAssignment `json:"role_assignments"`
+}
+
+// ArchivedRoleAssignment represents the role assigned to the user on the
+// domain, a group or a channel.
+type ArchivedRoleAssignment struct {
+	RoleID       string `json:"role_id"`
+	Entity       string `json:"entity"`
+	EntityID     string `json:"entity_id"`
+	UserIdentity string `json:"user_identity"`
+}
+
+// ImportConflict describes the archived entity which is not imported as is.
+type ImportConflict struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// ImportReport contains the outcome of the domain import. Created holds
+// the number of the created entities per kind, or the number of entities
+// to be created on the dry run. IDs maps the archived entity IDs to the
+// IDs of the entities in the target domain.
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   map[string]int    `json:"created"`
+	IDs       map[string]string `json:"ids"`
+	Conflicts []ImportConflict  `json:"conflicts,omitempty"`
+}
+
+func (sdk mgSDK) ExportDomain(domainID string, withSecrets bool, token string) (DomainArchive, errors.SDKError) {
+	if domainID == "" {
+		return DomainArchive{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	archive := DomainArchive{
+		Version:     DomainArchiveVersion,
+		DomainID:    domainID,
+		ExportedAt:  time.Now().UTC(),
+		WithSecrets: withSecrets,
+	}
+
+	things, err := listAll(func(pm PageMetadata) ([]Thing, uint64, errors.SDKError) {
+		tp, err := sdk.Things(pm, token)
+		return tp.Things, tp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	for _, t := range things {
+		if !withSecrets {
+			t.Credentials.Secret = ""
+		}
+		t.DomainID, t.Permissions = "", nil
+		archive.Things = append(archive.Things, t)
+	}
+
+	channels, err := listAll(func(pm PageMetadata) ([]Channel, uint64, errors.SDKError) {
+		cp, err := sdk.Channels(pm, token)
+		return cp.Channels, cp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	channelIDs := make(map[string]bool)
+	for _, c := range channels {
+		channelIDs[c.ID] = true
+		c.DomainID, c.Level, c.Path, c.Children, c.Permissions = "", 0, "", nil, nil
+		archive.Channels = append(archive.Channels, c)
+
+		connected, err := listAll(func(pm PageMetadata) ([]Thing, uint64, errors.SDKError) {
+			tp, err := sdk.ThingsByChannel(c.ID, pm, token)
+			return tp.Things, tp.Total, err
+		})
+		if err != nil {
+			return DomainArchive{}, err
+		}
+		for _, t := range connected {
+			archive.Connections = append(archive.Connections, Connection{ThingID: t.ID, ChannelID: c.ID})
+		}
+	}
+
+	groups, err := listAll(func(pm PageMetadata) ([]Group, uint64, errors.SDKError) {
+		gp, err := sdk.Groups(pm, token)
+		return gp.Groups, gp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	for _, g := range groups {
+		g.DomainID, g.Level, g.Path, g.Children, g.Permissions = "", 0, "", nil, nil
+		archive.Groups = append(archive.Groups, g)
+	}
+
+	configs, err := listAll(func(pm PageMetadata) ([]BootstrapConfig, uint64, errors.SDKError) {
+		bp, err := sdk.Bootstraps(pm, token)
+		return bp.Configs, bp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	for _, cfg := range configs {
+		cfg.Channels = bootstrapChannels(cfg.Channels)
+		// Thing key is the secret of the thing, exported with the thing.
+		cfg.ThingKey = ""
+		if !withSecrets {
+			cfg.ExternalKey, cfg.ClientKey = "", ""
+		}
+		archive.Bootstraps = append(archive.Bootstraps, cfg)
+	}
+
+	subs, err := listAll(func(pm PageMetadata) ([]Subscription, uint64, errors.SDKError) {
+		sp, err := sdk.ListSubscriptions(pm, token)
+		return sp.Subscriptions, sp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	for _, s := range subs {
+		// Subscription topic is the channel ID, optionally followed by the subtopic.
+		if chID, _, _ := strings.Cut(s.Topic, "."); !channelIDs[chID] {
+			continue
+		}
+		s.ID, s.OwnerID = "", ""
+		archive.Subscriptions = append(archive.Subscriptions, s)
+	}
+
+	users, err := listAll(func(pm PageMetadata) ([]User, uint64, errors.SDKError) {
+		up, err := sdk.ListDomainUsers(domainID, pm, token)
+		return up.Users, up.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	identities := make(map[string]string)
+	for _, u := range users {
+		identities[u.ID] = u.Credentials.Identity
+	}
+
+	roles, err := listAll(func(pm PageMetadata) ([]Role, uint64, errors.SDKError) {
+		rp, err := sdk.Roles(domainID, pm, token)
+		return rp.Roles, rp.Total, err
+	})
+	if err != nil {
+		return DomainArchive{}, err
+	}
+	for _, r := range roles {
+		ras, err := sdk.RoleAssignments(domainID, r.ID, token)
+		if err != nil {
+			return DomainArchive{}, err
+		}
+		for _, ra := range ras {
+			entity := GroupsKind
+			switch {
+			case ra.EntityType == domainEntityType:
+				entity = domainsEntity
+			case channelIDs[ra.EntityID]:
+				entity = ChannelsKind
+			}
+			archive.RoleAssignments = append(archive.RoleAssignments, ArchivedRoleAssignment{
+				RoleID:       r.ID,
+				Entity:       entity,
+				EntityID:     ra.EntityID,
+				UserIdentity: identities[ra.UserID],
+			})
+		}
+		r.DomainID = ""
+		archive.Roles = append(archive.Roles, r)
+	}
+
+	return archive, nil
+}
+
+func (sdk mgSDK) ImportDomain(domainID string, archive DomainArchive, dryRun bool, token string) (ImportReport, errors.SDKError) {
+	if domainID == "" {
+		return ImportReport{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	if archive.Version != DomainArchiveVersion {
+		return ImportReport{}, errors.NewSDKError(ErrArchiveVersion)
+	}
+	imp := importer{
+		sdk:      sdk,
+		domainID: domainID,
+		token:    token,
+		dryRun:   dryRun,
+		ids:      make(map[string]string),
+		planned:  make(map[string]bool),
+		report: ImportReport{
+			DryRun:  dryRun,
+			Created: make(map[string]int),
+			IDs:     make(map[string]string),
+		},
+	}
+
+	steps := []func(DomainArchive) errors.SDKError{
+		imp.importGroups,
+		imp.importChannels,
+		imp.importThings,
+		imp.importConnections,
+		imp.importBootstraps,
+		imp.importSubscriptions,
+		imp.importRoles,
+		imp.importRoleAssignments,
+	}
+	for _, step := range steps {
+		if err := step(archive); err != nil {
+			return imp.report, err
+		}
+	}
+
+	return imp.report, nil
+}
+
+// importer recreates the archived entities in the target domain. Entities
+// with the name of the existing target domain entity are not created, but
+// mapped to the existing entity. On the dry run, the entities to be created
+// are planned instead, and keep their archived IDs.
+type importer struct {
+	sdk      mgSDK
+	domainID string
+	token    string
+	dryRun   bool
+	ids      map[string]string
+	planned  map[string]bool
+	report   ImportReport
+}
+
+func (imp *importer) conflict(kind, id, reason string) {
+	imp.report.Conflicts = append(imp.report.Conflicts, ImportConflict{Kind: kind, ID: id, Reason: reason})
+}
+
+// resolve returns the target domain ID of the archived entity.
+func (imp *importer) resolve(id string) (string, bool) {
+	if newID, ok := imp.ids[id]; ok {
+		return newID, true
+	}
+	return id, imp.planned[id]
+}
+
+func (imp *importer) mapID(id, newID string) {
+	imp.ids[id] = newID
+	imp.report.IDs[id] = newID
+}
+
+// reuse maps the archived entity to the existing entity of the same name.
+func (imp *importer) reuse(kind, id, name string, existing map[string]string) bool {
+	existingID, ok := existing[name]
+	if name == "" || !ok {
+		return false
+	}
+	imp.mapID(id, existingID)
+	imp.conflict(kind, id, "entity with the same name exists, using "+existingID)
+	return true
+}
+
+// create records the created entity, or plans it on the dry run, and
+// reports whether the entity has to be created.
+func (imp *importer) create(kind, id string) bool {
+	imp.report.Created[kind]++
+	if imp.dryRun {
+		imp.planned[id] = true
+		return false
+	}
+	return true
+}
+
+// failed turns the conflict response into the import conflict and
+// returns the other errors.
+func (imp *importer) failed(kind, id string, err errors.SDKError) errors.SDKError {
+	if err.StatusCode() != http.StatusConflict {
+		return err
+	}
+	imp.report.Created[kind]--
+	imp.conflict(kind, id, err.Error())
+	return nil
+}
+
+func (imp *importer) parent(kind, id, parentID string) string {
+	if parentID == "" {
+		return ""
+	}
+	newID, ok := imp.resolve(parentID)
+	if !ok {
+		imp.conflict(kind, id, "unknown parent "+parentID)
+		return ""
+	}
+	return newID
+}
+
+func (imp *importer) importGroups(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]Group, uint64, errors.SDKError) {
+		gp, err := imp.sdk.Groups(pm, imp.token)
+		return gp.Groups, gp.Total, err
+	}, func(g Group) (string, string) { return g.Name, g.ID })
+	if err != nil {
+		return err
+	}
+	groups := parentsFirst(archive.Groups, func(g Group) (string, string) { return g.ID, g.ParentID })
+	for _, g := range groups {
+		if imp.reuse(GroupsKind, g.ID, g.Name, existing) {
+			continue
+		}
+		parentID := imp.parent(GroupsKind, g.ID, g.ParentID)
+		if !imp.create(GroupsKind, g.ID) {
+			continue
+		}
+		created, err := imp.sdk.CreateGroup(Group{
+			ParentID:    parentID,
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+		}, imp.token)
+		if err != nil {
+			if err := imp.failed(GroupsKind, g.ID, err); err != nil {
+				return err
+			}
+			continue
+		}
+		imp.mapID(g.ID, created.ID)
+		if g.Status == disabledStatus {
+			if _, err := imp.sdk.DisableGroup(created.ID, imp.token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importChannels(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]Channel, uint64, errors.SDKError) {
+		cp, err := imp.sdk.Channels(pm, imp.token)
+		return cp.Channels, cp.Total, err
+	}, func(c Channel) (string, string) { return c.Name, c.ID })
+	if err != nil {
+		return err
+	}
+	channels := parentsFirst(archive.Channels, func(c Channel) (string, string) { return c.ID, c.ParentID })
+	for _, c := range channels {
+		if imp.reuse(ChannelsKind, c.ID, c.Name, existing) {
+			continue
+		}
+		parentID := imp.parent(ChannelsKind, c.ID, c.ParentID)
+		if !imp.create(ChannelsKind, c.ID) {
+			continue
+		}
+		created, err := imp.sdk.CreateChannel(Channel{
+			ParentID:    parentID,
+			Name:        c.Name,
+			Description: c.Description,
+			Metadata:    c.Metadata,
+		}, imp.token)
+		if err != nil {
+			if err := imp.failed(ChannelsKind, c.ID, err); err != nil {
+				return err
+			}
+			continue
+		}
+		imp.mapID(c.ID, created.ID)
+		if c.Status == disabledStatus {
+			if _, err := imp.sdk.DisableChannel(created.ID, imp.token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importThings(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]Thing, uint64, errors.SDKError) {
+		tp, err := imp.sdk.Things(pm, imp.token)
+		return tp.Things, tp.Total, err
+	}, func(t Thing) (string, string) { return t.Name, t.ID })
+	if err != nil {
+		return err
+	}
+	for _, t := range archive.Things {
+		if imp.reuse(ThingsKind, t.ID, t.Name, existing) {
+			continue
+		}
+		if !imp.create(ThingsKind, t.ID) {
+			continue
+		}
+		created, err := imp.sdk.CreateThing(Thing{
+			Name:        t.Name,
+			Credentials: t.Credentials,
+			Tags:        t.Tags,
+			Metadata:    t.Metadata,
+		}, imp.token)
+		if err != nil {
+			if err := imp.failed(ThingsKind, t.ID, err); err != nil {
+				return err
+			}
+			continue
+		}
+		imp.mapID(t.ID, created.ID)
+		if t.Status == disabledStatus {
+			if _, err := imp.sdk.DisableThing(created.ID, imp.token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importConnections(archive DomainArchive) errors.SDKError {
+	for _, conn := range archive.Connections {
+		id := conn.ThingID + ":" + conn.ChannelID
+		thingID, ok := imp.resolve(conn.ThingID)
+		if !ok {
+			imp.conflict(ConnectionsKind, id, "unknown thing "+conn.ThingID)
+			continue
+		}
+		channelID, ok := imp.resolve(conn.ChannelID)
+		if !ok {
+			imp.conflict(ConnectionsKind, id, "unknown channel "+conn.ChannelID)
+			continue
+		}
+		if !imp.create(ConnectionsKind, id) {
+			continue
+		}
+		if err := imp.sdk.ConnectThing(thingID, channelID, imp.token); err != nil {
+			if err := imp.failed(ConnectionsKind, id, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importBootstraps(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]BootstrapConfig, uint64, errors.SDKError) {
+		bp, err := imp.sdk.Bootstraps(pm, imp.token)
+		return bp.Configs, bp.Total, err
+	}, func(cfg BootstrapConfig) (string, string) { return cfg.ExternalID, cfg.ThingID })
+	if err != nil {
+		return err
+	}
+	for _, cfg := range archive.Bootstraps {
+		thingID, ok := imp.resolve(cfg.ThingID)
+		if !ok {
+			imp.conflict(BootstrapsKind, cfg.ThingID, "unknown thing "+cfg.ThingID)
+			continue
+		}
+		if _, ok := existing[cfg.ExternalID]; ok {
+			imp.conflict(BootstrapsKind, cfg.ThingID, "external ID "+cfg.ExternalID+" exists")
+			continue
+		}
+		if cfg.ExternalKey == "" {
+			imp.conflict(BootstrapsKind, cfg.ThingID, "missing external key, export with secrets")
+			continue
+		}
+		var channels []string
+		for _, chID := range bootstrapChannels(cfg.Channels) {
+			newID, ok := imp.resolve(chID)
+			if !ok {
+				imp.conflict(BootstrapsKind, cfg.ThingID, "unknown channel "+chID)
+				continue
+			}
+			channels = append(channels, newID)
+		}
+		if !imp.create(BootstrapsKind, cfg.ThingID) {
+			continue
+		}
+		id := cfg.ThingID
+		cfg.ThingID, cfg.Channels = thingID, channels
+		if _, err := imp.sdk.AddBootstrap(cfg, imp.token); err != nil {
+			if err := imp.failed(BootstrapsKind, id, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if cfg.State == activeState {
+			if err := imp.sdk.Whitelist(thingID, activeState, imp.token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importSubscriptions(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]Subscription, uint64, errors.SDKError) {
+		sp, err := imp.sdk.ListSubscriptions(pm, imp.token)
+		return sp.Subscriptions, sp.Total, err
+	}, func(s Subscription) (string, string) { return s.Topic + " " + s.Contact, s.ID })
+	if err != nil {
+		return err
+	}
+	for _, s := range archive.Subscriptions {
+		id := s.Topic + " " + s.Contact
+		chID, subtopic, _ := strings.Cut(s.Topic, ".")
+		newID, ok := imp.resolve(chID)
+		if !ok {
+			imp.conflict(SubscriptionsKind, id, "unknown channel "+chID)
+			continue
+		}
+		topic := newID
+		if subtopic != "" {
+			topic = newID + "." + subtopic
+		}
+		if _, ok := existing[topic+" "+s.Contact]; ok {
+			imp.conflict(SubscriptionsKind, id, "subscription exists")
+			continue
+		}
+		if !imp.create(SubscriptionsKind, id) {
+			continue
+		}
+		if _, err := imp.sdk.CreateSubscription(topic, s.Contact, imp.token); err != nil {
+			if err := imp.failed(SubscriptionsKind, id, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importRoles(archive DomainArchive) errors.SDKError {
+	existing, err := listNames(func(pm PageMetadata) ([]Role, uint64, errors.SDKError) {
+		rp, err := imp.sdk.Roles(imp.domainID, pm, imp.token)
+		return rp.Roles, rp.Total, err
+	}, func(r Role) (string, string) { return r.Name, r.ID })
+	if err != nil {
+		return err
+	}
+	for _, r := range archive.Roles {
+		if imp.reuse(RolesKind, r.ID, r.Name, existing) {
+			continue
+		}
+		if !imp.create(RolesKind, r.ID) {
+			continue
+		}
+		created, err := imp.sdk.CreateRole(imp.domainID, Role{Name: r.Name, Permissions: r.Permissions}, imp.token)
+		if err != nil {
+			if err := imp.failed(RolesKind, r.ID, err); err != nil {
+				return err
+			}
+			continue
+		}
+		imp.mapID(r.ID, created.ID)
+	}
+
+	return nil
+}
+
+func (imp *importer) importRoleAssignments(archive DomainArchive) errors.SDKError {
+	users, err := listNames(func(pm PageMetadata) ([]User, uint64, errors.SDKError) {
+		up, err := imp.sdk.ListDomainUsers(imp.domainID, pm, imp.token)
+		return up.Users, up.Total, err
+	}, func(u User) (string, string) { return u.Credentials.Identity, u.ID })
+	if err != nil {
+		return err
+	}
+	for _, ra := range archive.RoleAssignments {
+		id := ra.RoleID + ":" + ra.EntityID + ":" + ra.UserIdentity
+		roleID, ok := imp.resolve(ra.RoleID)
+		if !ok {
+			imp.conflict(RoleAssignmentsKind, id, "unknown role "+ra.RoleID)
+			continue
+		}
+		entityID := imp.domainID
+		if ra.Entity != domainsEntity {
+			if entityID, ok = imp.resolve(ra.EntityID); !ok {
+				imp.conflict(RoleAssignmentsKind, id, "unknown entity "+ra.EntityID)
+				continue
+			}
+		}
+		userID, ok := users[ra.UserIdentity]
+		if ra.UserIdentity == "" || !ok {
+			imp.conflict(RoleAssignmentsKind, id, "user "+ra.UserIdentity+" is not a member of the domain")
+			continue
+		}
+		if !imp.create(RoleAssignmentsKind, id) {
+			continue
+		}
+		if err := imp.sdk.AssignRole(imp.domainID, roleID, ra.Entity, entityID, []string{userID}, imp.token); err != nil {
+			if err := imp.failed(RoleAssignmentsKind, id, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listAll lists all the pages of the entities.
+func listAll[T any](list func(pm PageMetadata) ([]T, uint64, errors.SDKError)) ([]T, errors.SDKError) {
+	var all []T
+	pm := PageMetadata{Limit: exportPageLimit}
+	for {
+		items, total, err := list(pm)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		pm.Offset += pm.Limit
+		if len(items) == 0 || pm.Offset >= total {
+			return all, nil
+		}
+	}
+}
+
+// listNames lists all the entities and maps their names to their IDs.
+func listNames[T any](list func(pm PageMetadata) ([]T, uint64, errors.SDKError), name func(T) (string, string)) (map[string]string, errors.SDKError) {
+	items, err := listAll(list)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, item := range items {
+		n, id := name(item)
+		if n != "" {
+			names[n] = id
+		}
+	}
+
+	return names, nil
+}
+
+// parentsFirst orders the entities so the parents precede their children.
+func parentsFirst[T any](items []T, ids func(T) (string, string)) []T {
+	archived := make(map[string]bool)
+	for _, item := range items {
+		id, _ := ids(item)
+		archived[id] = true
+	}
+	var ordered []T
+	added := make(map[string]bool)
+	for len(ordered) < len(items) {
+		n := len(ordered)
+		for _, item := range items {
+			id, parentID := ids(item)
+			if added[id] || (archived[parentID] && !added[parentID]) {
+				continue
+			}
+			added[id] = true
+			ordered = append(ordered, item)
+		}
+		// The rest of the entities form a cycle, keep their order.
+		if len(ordered) == n {
+			for _, item := range items {
+				if id, _ := ids(item); !added[id] {
+					added[id] = true
+					ordered = append(ordered, item)
+				}
+			}
+		}
+	}
+
+	return ordered
+}
+
+// bootstrapChannels returns the IDs of the bootstrap config channels.
+func bootstrapChannels(channels interface{}) []string {
+	switch chs := channels.(type) {
+	case []string:
+		return chs
+	case []Channel:
+		ids := make([]string, 0, len(chs))
+		for _, c := range chs {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	default:
+		return []string{}
+	}
+}
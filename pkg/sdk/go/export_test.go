@@ -0,0 +1,332 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportDomainID = "domain"
+
+// deployment is the in-memory stub of the services the domain
+// export and import use.
+type deployment struct {
+	things      []sdk.Thing
+	channels    []sdk.Channel
+	groups      []sdk.Group
+	connections []sdk.Connection
+	configs     []sdk.BootstrapConfig
+	subs        []sdk.Subscription
+	users       []sdk.User
+	roles       []sdk.Role
+	assignments []sdk.RoleAssignment
+	whitelisted []string
+	seq         int
+}
+
+func (d *deployment) id(prefix string) string {
+	d.seq++
+	return fmt.Sprintf("%s-%d", prefix, d.seq)
+}
+
+func (d *deployment) serve(t *testing.T) sdk.SDK {
+	mux := http.NewServeMux()
+	list := func(key string, items interface{}, total int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			res := map[string]interface{}{key: items, "total": total}
+			if r.URL.Query().Get("offset") != "" {
+				res = map[string]interface{}{key: []interface{}{}, "total": total}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			err := json.NewEncoder(w).Encode(res)
+			require.Nil(t, err, fmt.Sprintf("unexpected error encoding response: %s", err))
+		}
+	}
+	created := func(w http.ResponseWriter, location string, entity interface{}) {
+		w.Header().Set("Location", location)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		err := json.NewEncoder(w).Encode(entity)
+		require.Nil(t, err, fmt.Sprintf("unexpected error encoding response: %s", err))
+	}
+	decode := func(r *http.Request, v interface{}) {
+		err := json.NewDecoder(r.Body).Decode(v)
+		require.Nil(t, err, fmt.Sprintf("unexpected error decoding request: %s", err))
+	}
+
+	mux.HandleFunc("GET /things", func(w http.ResponseWriter, r *http.Request) {
+		list("things", d.things, len(d.things))(w, r)
+	})
+	mux.HandleFunc("POST /things", func(w http.ResponseWriter, r *http.Request) {
+		var th sdk.Thing
+		decode(r, &th)
+		th.ID = d.id("thing")
+		d.things = append(d.things, th)
+		created(w, "/things/"+th.ID, th)
+	})
+	mux.HandleFunc("GET /channels", func(w http.ResponseWriter, r *http.Request) {
+		list("channels", d.channels, len(d.channels))(w, r)
+	})
+	mux.HandleFunc("POST /channels", func(w http.ResponseWriter, r *http.Request) {
+		var c sdk.Channel
+		decode(r, &c)
+		c.ID = d.id("channel")
+		d.channels = append(d.channels, c)
+		created(w, "/channels/"+c.ID, c)
+	})
+	mux.HandleFunc("GET /channels/{id}/things", func(w http.ResponseWriter, r *http.Request) {
+		var things []sdk.Thing
+		for _, conn := range d.connections {
+			if conn.ChannelID == r.PathValue("id") {
+				things = append(things, sdk.Thing{ID: conn.ThingID})
+			}
+		}
+		list("things", things, len(things))(w, r)
+	})
+	mux.HandleFunc("POST /channels/{chanID}/things/{thingID}/connect", func(w http.ResponseWriter, r *http.Request) {
+		d.connections = append(d.connections, sdk.Connection{ThingID: r.PathValue("thingID"), ChannelID: r.PathValue("chanID")})
+		created(w, "", struct{}{})
+	})
+	mux.HandleFunc("GET /groups", func(w http.ResponseWriter, r *http.Request) {
+		list("groups", d.groups, len(d.groups))(w, r)
+	})
+	mux.HandleFunc("POST /groups", func(w http.ResponseWriter, r *http.Request) {
+		var g sdk.Group
+		decode(r, &g)
+		g.ID = d.id("group")
+		d.groups = append(d.groups, g)
+		created(w, "/groups/"+g.ID, g)
+	})
+	mux.HandleFunc("GET /things/configs", func(w http.ResponseWriter, r *http.Request) {
+		// Bootstrap service lists the config channels as objects.
+		var configs []sdk.BootstrapConfig
+		for _, cfg := range d.configs {
+			var channels []sdk.Channel
+			for _, id := range cfg.Channels.([]string) {
+				channels = append(channels, sdk.Channel{ID: id})
+			}
+			cfg.Channels = channels
+			configs = append(configs, cfg)
+		}
+		list("configs", configs, len(configs))(w, r)
+	})
+	mux.HandleFunc("POST /things/configs", func(w http.ResponseWriter, r *http.Request) {
+		var cfg sdk.BootstrapConfig
+		decode(r, &cfg)
+		d.configs = append(d.configs, cfg)
+		created(w, "/things/configs/"+cfg.ThingID, struct{}{})
+	})
+	mux.HandleFunc("PUT /things/state/{id}", func(w http.ResponseWriter, r *http.Request) {
+		d.whitelisted = append(d.whitelisted, r.PathValue("id"))
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		list("subscriptions", d.subs, len(d.subs))(w, r)
+	})
+	mux.HandleFunc("POST /subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		var s sdk.Subscription
+		decode(r, &s)
+		s.ID = d.id("subscription")
+		d.subs = append(d.subs, s)
+		created(w, "/subscriptions/"+s.ID, struct{}{})
+	})
+	mux.HandleFunc("GET /domains/{domainID}/users", func(w http.ResponseWriter, r *http.Request) {
+		list("users", d.users, len(d.users))(w, r)
+	})
+	mux.HandleFunc("GET /domains/{domainID}/roles", func(w http.ResponseWriter, r *http.Request) {
+		list("roles", d.roles, len(d.roles))(w, r)
+	})
+	mux.HandleFunc("POST /domains/{domainID}/roles", func(w http.ResponseWriter, r *http.Request) {
+		var role sdk.Role
+		decode(r, &role)
+		role.ID = d.id("role")
+		d.roles = append(d.roles, role)
+		created(w, "/roles/"+role.ID, role)
+	})
+	mux.HandleFunc("GET /domains/{domainID}/roles/{roleID}/assignments", func(w http.ResponseWriter, r *http.Request) {
+		var ras []sdk.RoleAssignment
+		for _, ra := range d.assignments {
+			if ra.RoleID == r.PathValue("roleID") {
+				ras = append(ras, ra)
+			}
+		}
+		err := json.NewEncoder(w).Encode(map[string]interface{}{"assignments": ras})
+		require.Nil(t, err, fmt.Sprintf("unexpected error encoding response: %s", err))
+	})
+	mux.HandleFunc("POST /domains/{domainID}/roles/{roleID}/assign", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Entity   string   `json:"entity"`
+			EntityID string   `json:"entity_id"`
+			UserIDs  []string `json:"user_ids"`
+		}
+		decode(r, &req)
+		for _, userID := range req.UserIDs {
+			d.assignments = append(d.assignments, sdk.RoleAssignment{
+				RoleID:     r.PathValue("roleID"),
+				EntityType: "group",
+				EntityID:   req.EntityID,
+				UserID:     userID,
+			})
+		}
+		created(w, "", struct{}{})
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return sdk.NewSDK(sdk.Config{
+		BootstrapURL: ts.URL,
+		ThingsURL:    ts.URL,
+		UsersURL:     ts.URL,
+		DomainsURL:   ts.URL,
+	})
+}
+
+func newSourceDeployment() *deployment {
+	return &deployment{
+		things:      []sdk.Thing{{ID: "t1", Name: "sensor", Credentials: sdk.Credentials{Secret: "thing-secret"}, Status: "enabled"}},
+		channels:    []sdk.Channel{{ID: "c1", Name: "telemetry", Status: "enabled"}},
+		groups:      []sdk.Group{{ID: "g1", Name: "site"}, {ID: "g2", Name: "floor", ParentID: "g1"}},
+		connections: []sdk.Connection{{ThingID: "t1", ChannelID: "c1"}},
+		configs:     []sdk.BootstrapConfig{{ThingID: "t1", ExternalID: "ext", ExternalKey: "ext-key", Channels: []string{"c1"}, State: 1}},
+		subs: []sdk.Subscription{
+			{ID: "s1", Topic: "c1.alarms", Contact: "ops@example.com"},
+			{ID: "s2", Topic: "other", Contact: "ops@example.com"},
+		},
+		users:       []sdk.User{{ID: "u1", Credentials: sdk.Credentials{Identity: "operator@example.com"}}},
+		roles:       []sdk.Role{{ID: "r1", Name: "operator", Permissions: []string{"channels:publish"}}},
+		assignments: []sdk.RoleAssignment{{RoleID: "r1", EntityType: "group", EntityID: "c1", UserID: "u1"}},
+	}
+}
+
+func TestExportDomain(t *testing.T) {
+	mgsdk := newSourceDeployment().serve(t)
+
+	cases := []struct {
+		desc        string
+		domainID    string
+		withSecrets bool
+		secret      string
+		externalKey string
+		err         errors.SDKError
+	}{
+		{
+			desc:     "export domain without secrets",
+			domainID: exportDomainID,
+		},
+		{
+			desc:        "export domain with secrets",
+			domainID:    exportDomainID,
+			withSecrets: true,
+			secret:      "thing-secret",
+			externalKey: "ext-key",
+		},
+		{
+			desc:     "export domain with empty domain id",
+			domainID: "",
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			archive, err := mgsdk.ExportDomain(tc.domainID, tc.withSecrets, validToken)
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				return
+			}
+			assert.Equal(t, sdk.DomainArchiveVersion, archive.Version)
+			assert.Equal(t, tc.withSecrets, archive.WithSecrets)
+			require.Len(t, archive.Things, 1)
+			assert.Equal(t, tc.secret, archive.Things[0].Credentials.Secret)
+			assert.Len(t, archive.Channels, 1)
+			assert.Len(t, archive.Groups, 2)
+			assert.Equal(t, []sdk.Connection{{ThingID: "t1", ChannelID: "c1"}}, archive.Connections)
+			require.Len(t, archive.Bootstraps, 1)
+			assert.Equal(t, []string{"c1"}, archive.Bootstraps[0].Channels)
+			assert.Equal(t, tc.externalKey, archive.Bootstraps[0].ExternalKey)
+			assert.Equal(t, []sdk.Subscription{{Topic: "c1.alarms", Contact: "ops@example.com"}}, archive.Subscriptions)
+			assert.Len(t, archive.Roles, 1)
+			expected := []sdk.ArchivedRoleAssignment{{RoleID: "r1", Entity: "channels", EntityID: "c1", UserIdentity: "operator@example.com"}}
+			assert.Equal(t, expected, archive.RoleAssignments)
+		})
+	}
+}
+
+func TestImportDomain(t *testing.T) {
+	source := newSourceDeployment().serve(t)
+	withSecrets, err := source.ExportDomain(exportDomainID, true, validToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected export error: %s", err))
+	withoutSecrets, err := source.ExportDomain(exportDomainID, false, validToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected export error: %s", err))
+
+	t.Run("import domain with dry run", func(t *testing.T) {
+		target := &deployment{groups: []sdk.Group{{ID: "existing", Name: "site"}}}
+		mgsdk := target.serve(t)
+
+		report, err := mgsdk.ImportDomain(exportDomainID, withoutSecrets, true, validToken)
+		assert.Nil(t, err, fmt.Sprintf("unexpected import error: %s", err))
+		assert.True(t, report.DryRun)
+		assert.Empty(t, target.things)
+		assert.Len(t, target.groups, 1)
+		expected := map[string]int{
+			sdk.GroupsKind:        1,
+			sdk.ChannelsKind:      1,
+			sdk.ThingsKind:        1,
+			sdk.ConnectionsKind:   1,
+			sdk.SubscriptionsKind: 1,
+			sdk.RolesKind:         1,
+		}
+		assert.Equal(t, expected, report.Created)
+		assert.Equal(t, map[string]string{"g1": "existing"}, report.IDs)
+		kinds := []string{}
+		for _, c := range report.Conflicts {
+			kinds = append(kinds, c.Kind)
+		}
+		assert.Equal(t, []string{sdk.GroupsKind, sdk.BootstrapsKind, sdk.RoleAssignmentsKind}, kinds)
+	})
+
+	t.Run("import domain", func(t *testing.T) {
+		target := &deployment{users: []sdk.User{{ID: "u2", Credentials: sdk.Credentials{Identity: "operator@example.com"}}}}
+		mgsdk := target.serve(t)
+
+		report, err := mgsdk.ImportDomain(exportDomainID, withSecrets, false, validToken)
+		assert.Nil(t, err, fmt.Sprintf("unexpected import error: %s", err))
+		assert.Empty(t, report.Conflicts)
+		require.Len(t, target.things, 1)
+		assert.Equal(t, "thing-secret", target.things[0].Credentials.Secret)
+		thingID, channelID := report.IDs["t1"], report.IDs["c1"]
+		assert.Equal(t, target.things[0].ID, thingID)
+		assert.Equal(t, target.channels[0].ID, channelID)
+		require.Len(t, target.groups, 2)
+		assert.Equal(t, report.IDs["g1"], target.groups[1].ParentID)
+		assert.Equal(t, []sdk.Connection{{ThingID: thingID, ChannelID: channelID}}, target.connections)
+		require.Len(t, target.configs, 1)
+		assert.Equal(t, thingID, target.configs[0].ThingID)
+		assert.Equal(t, []string{channelID}, target.configs[0].Channels)
+		assert.Equal(t, []string{thingID}, target.whitelisted)
+		assert.Equal(t, channelID+".alarms", target.subs[0].Topic)
+		expected := []sdk.RoleAssignment{{RoleID: report.IDs["r1"], EntityType: "group", EntityID: channelID, UserID: "u2"}}
+		assert.Equal(t, expected, target.assignments)
+	})
+
+	t.Run("import domain with unsupported archive version", func(t *testing.T) {
+		mgsdk := (&deployment{}).serve(t)
+
+		archive := withSecrets
+		archive.Version = sdk.DomainArchiveVersion + 1
+		_, err := mgsdk.ImportDomain(exportDomainID, archive, false, validToken)
+		assert.Equal(t, errors.NewSDKError(sdk.ErrArchiveVersion), err)
+	})
+}
@@ -15,11 +15,14 @@ const (
 	invitationsEndpoint = "invitations"
 	acceptEndpoint      = "accept"
 	rejectEndpoint      = "reject"
+	signUpEndpoint      = "signup"
+	emailEndpoint       = "email"
 )
 
 type Invitation struct {
 	InvitedBy   string    `json:"invited_by"`
 	UserID      string    `json:"user_id"`
+	Email       string    `json:"email,omitempty"`
 	DomainID    string    `json:"domain_id"`
 	Token       string    `json:"token,omitempty"`
 	Relation    string    `json:"relation,omitempty"`
@@ -64,6 +67,21 @@ func (sdk mgSDK) Invitation(userID, domainID, token string) (invitation Invitati
 	return invitation, nil
 }
 
+func (sdk mgSDK) EmailInvitation(email, domainID, token string) (invitation Invitation, err error) {
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + emailEndpoint + "/" + email + "/" + domainID
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	if err := json.Unmarshal(body, &invitation); err != nil {
+		return Invitation{}, errors.NewSDKError(err)
+	}
+
+	return invitation, nil
+}
+
 func (sdk mgSDK) Invitations(pm PageMetadata, token string) (invitations InvitationPage, err error) {
 	url, err := sdk.withQueryParams(sdk.invitationsURL, invitationsEndpoint, pm)
 	if err != nil {
@@ -101,6 +119,35 @@ func (sdk mgSDK) AcceptInvitation(domainID, token string) (err error) {
 	return sdkerr
 }
 
+func (sdk mgSDK) SignUp(code, name, secret string) (invitation Invitation, err error) {
+	req := struct {
+		Code   string `json:"code"`
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+	}{
+		Code:   code,
+		Name:   name,
+		Secret: secret,
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return Invitation{}, errors.NewSDKError(err)
+	}
+
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + signUpEndpoint
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	if err := json.Unmarshal(body, &invitation); err != nil {
+		return Invitation{}, errors.NewSDKError(err)
+	}
+
+	return invitation, nil
+}
+
 func (sdk mgSDK) RejectInvitation(domainID, token string) (err error) {
 	req := struct {
 		DomainID string `json:"domain_id"`
@@ -126,3 +173,11 @@ func (sdk mgSDK) DeleteInvitation(userID, domainID, token string) (err error) {
 
 	return sdkerr
 }
+
+func (sdk mgSDK) DeleteEmailInvitation(email, domainID, token string) (err error) {
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + emailEndpoint + "/" + email + "/" + domainID
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
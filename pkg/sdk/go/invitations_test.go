@@ -98,6 +98,35 @@ func TestSendInvitation(t *testing.T) {
 			svcErr: nil,
 			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID), http.StatusBadRequest),
 		},
+		{
+			desc:  "send invitation by email successfully",
+			token: validToken,
+			sendInvitationReq: sdk.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: invitation.DomainID,
+				Relation: invitation.Relation,
+			},
+			svcReq: invitations.Invitation{
+				Email:    "invitee@example.com",
+				DomainID: invitation.DomainID,
+				Relation: invitation.Relation,
+			},
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:  "send invitation with both userID and email",
+			token: validToken,
+			sendInvitationReq: sdk.Invitation{
+				UserID:   invitation.UserID,
+				Email:    "invitee@example.com",
+				DomainID: invitation.DomainID,
+				Relation: invitation.Relation,
+			},
+			svcReq: invitations.Invitation{},
+			svcErr: nil,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidInvitee), http.StatusBadRequest),
+		},
 		{
 			desc:  "send invitation with invalid relation",
 			token: validToken,
@@ -229,6 +258,86 @@ func TestViewInvitation(t *testing.T) {
 	}
 }
 
+func TestViewEmailInvitation(t *testing.T) {
+	is, svc := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	sdkEmailInvitation := sdkInvitation
+	sdkEmailInvitation.UserID = ""
+	sdkEmailInvitation.Email = "invitee@example.com"
+	emailInvitation := convertInvitation(sdkEmailInvitation)
+
+	cases := []struct {
+		desc     string
+		token    string
+		email    string
+		domainID string
+		svcRes   invitations.Invitation
+		svcErr   error
+		response sdk.Invitation
+		err      error
+	}{
+		{
+			desc:     "view email invitation successfully",
+			token:    validToken,
+			email:    emailInvitation.Email,
+			domainID: emailInvitation.DomainID,
+			svcRes:   emailInvitation,
+			svcErr:   nil,
+			response: sdkEmailInvitation,
+			err:      nil,
+		},
+		{
+			desc:     "view email invitation with invalid token",
+			token:    invalidToken,
+			email:    emailInvitation.Email,
+			domainID: emailInvitation.DomainID,
+			svcRes:   invitations.Invitation{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "view email invitation with empty email",
+			token:    validToken,
+			email:    "",
+			domainID: emailInvitation.DomainID,
+			svcRes:   invitations.Invitation{},
+			svcErr:   nil,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingEmail), http.StatusBadRequest),
+		},
+		{
+			desc:     "view email invitation with invalid domainID",
+			token:    validToken,
+			email:    emailInvitation.Email,
+			domainID: wrongID,
+			svcRes:   invitations.Invitation{},
+			svcErr:   svcerr.ErrNotFound,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.EmailInvitation(tc.email, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestListInvitation(t *testing.T) {
 	is, svc := setupInvitations()
 	defer is.Close()
@@ -383,6 +492,93 @@ func TestAcceptInvitation(t *testing.T) {
 	}
 }
 
+func TestSignUp(t *testing.T) {
+	is, svc := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	code := testsutil.GenerateUUID(t)
+	signedUp := sdk.Invitation{
+		InvitedBy:   sdkInvitation.InvitedBy,
+		UserID:      sdkInvitation.UserID,
+		Email:       "invitee@example.com",
+		DomainID:    sdkInvitation.DomainID,
+		Relation:    sdkInvitation.Relation,
+		CreatedAt:   sdkInvitation.CreatedAt,
+		UpdatedAt:   sdkInvitation.UpdatedAt,
+		ConfirmedAt: sdkInvitation.UpdatedAt,
+	}
+
+	cases := []struct {
+		desc     string
+		code     string
+		name     string
+		secret   string
+		svcRes   invitations.Invitation
+		svcErr   error
+		response sdk.Invitation
+		err      error
+	}{
+		{
+			desc:     "sign up successfully",
+			code:     code,
+			name:     "invitee",
+			secret:   "12345678",
+			svcRes:   convertInvitation(signedUp),
+			svcErr:   nil,
+			response: signedUp,
+			err:      nil,
+		},
+		{
+			desc:     "sign up with invalid code",
+			code:     invalidToken,
+			name:     "invitee",
+			secret:   "12345678",
+			svcRes:   invitations.Invitation{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "sign up with empty code",
+			code:     "",
+			name:     "invitee",
+			secret:   "12345678",
+			svcRes:   invitations.Invitation{},
+			svcErr:   nil,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingInvitationCode), http.StatusBadRequest),
+		},
+		{
+			desc:     "sign up with empty secret",
+			code:     code,
+			name:     "invitee",
+			secret:   "",
+			svcRes:   invitations.Invitation{},
+			svcErr:   nil,
+			response: sdk.Invitation{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingSecret), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("SignUp", mock.Anything, tc.code, tc.name, tc.secret).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.SignUp(tc.code, tc.name, tc.secret)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "SignUp", mock.Anything, tc.code, tc.name, tc.secret)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestDeleteInvitation(t *testing.T) {
 	is, svc := setupInvitations()
 	defer is.Close()
@@ -455,6 +651,72 @@ func TestDeleteInvitation(t *testing.T) {
 	}
 }
 
+func TestDeleteEmailInvitation(t *testing.T) {
+	is, svc := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	email := "invitee@example.com"
+
+	cases := []struct {
+		desc     string
+		token    string
+		email    string
+		domainID string
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "delete email invitation successfully",
+			token:    validToken,
+			email:    email,
+			domainID: invitation.DomainID,
+			svcErr:   nil,
+			err:      nil,
+		},
+		{
+			desc:     "delete email invitation with invalid token",
+			token:    invalidToken,
+			email:    email,
+			domainID: invitation.DomainID,
+			svcErr:   svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "delete email invitation with empty email",
+			token:    validToken,
+			email:    "",
+			domainID: invitation.DomainID,
+			svcErr:   nil,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingEmail), http.StatusBadRequest),
+		},
+		{
+			desc:     "delete email invitation with invalid domainID",
+			token:    validToken,
+			email:    email,
+			domainID: wrongID,
+			svcErr:   svcerr.ErrNotFound,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DeleteEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID).Return(tc.svcErr)
+			err := mgsdk.DeleteEmailInvitation(tc.email, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DeleteEmailInvitation", mock.Anything, tc.token, tc.email, tc.domainID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func generateTestInvitation(t *testing.T) sdk.Invitation {
 	createdAt, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error parsing time: %v", err))
@@ -0,0 +1,99 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	keysEndpoint = "keys"
+	// apiKeyType matches the API key type of the auth service.
+	apiKeyType = 3
+)
+
+// KeyScope restricts an API key to the operation and, optionally,
+// to the listed entities. Operation has <entity>:<permission> format,
+// e.g. "channels:publish".
+type KeyScope struct {
+	Operation string   `json:"operation"`
+	EntityIDs []string `json:"entity_ids,omitempty"`
+}
+
+// APIKey represents magistrala API key. Duration is the key lifetime
+// in seconds; a key without duration never expires.
+type APIKey struct {
+	ID        string     `json:"id,omitempty"`
+	Value     string     `json:"value,omitempty"`
+	Type      uint32     `json:"type"`
+	Duration  uint64     `json:"duration,omitempty"`
+	Scopes    []KeyScope `json:"scopes,omitempty"`
+	IssuedAt  time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeysPage contains list of API keys in a page with proper metadata.
+type APIKeysPage struct {
+	Keys []APIKey `json:"keys"`
+	PageRes
+}
+
+func (sdk mgSDK) IssueAPIKey(key APIKey, token string) (APIKey, errors.SDKError) {
+	key.Type = apiKeyType
+	data, err := json.Marshal(key)
+	if err != nil {
+		return APIKey{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.domainsURL, keysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return APIKey{}, sdkerr
+	}
+
+	var k APIKey
+	if err := json.Unmarshal(body, &k); err != nil {
+		return APIKey{}, errors.NewSDKError(err)
+	}
+	k.Type = apiKeyType
+
+	return k, nil
+}
+
+func (sdk mgSDK) APIKeys(pm PageMetadata, token string) (APIKeysPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.domainsURL, keysEndpoint, pm)
+	if err != nil {
+		return APIKeysPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return APIKeysPage{}, sdkerr
+	}
+
+	var kp APIKeysPage
+	if err := json.Unmarshal(body, &kp); err != nil {
+		return APIKeysPage{}, errors.NewSDKError(err)
+	}
+
+	return kp, nil
+}
+
+func (sdk mgSDK) RevokeAPIKey(id, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.domainsURL, keysEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
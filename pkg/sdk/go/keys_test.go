@@ -0,0 +1,236 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	httpapi "github.com/absmach/magistrala/auth/api/http/keys"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupKeys() (*httptest.Server, *authmocks.Service) {
+	svc := new(authmocks.Service)
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+
+	mux = httpapi.MakeHandler(svc, mux, logger)
+	return httptest.NewServer(mux), svc
+}
+
+func TestIssueAPIKey(t *testing.T) {
+	ks, svc := setupKeys()
+	defer ks.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ks.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	channelID := generateUUID(t)
+	scopes := []sdk.KeyScope{{Operation: "channels:publish", EntityIDs: []string{channelID}}}
+
+	cases := []struct {
+		desc     string
+		token    string
+		key      sdk.APIKey
+		svcRes   auth.Token
+		svcErr   error
+		response sdk.APIKey
+		err      error
+	}{
+		{
+			desc:     "issue API key successfully",
+			token:    validToken,
+			key:      sdk.APIKey{Duration: 3600},
+			svcRes:   auth.Token{AccessToken: validToken},
+			response: sdk.APIKey{Value: validToken, Type: uint32(auth.APIKey)},
+		},
+		{
+			desc:     "issue scoped API key successfully",
+			token:    validToken,
+			key:      sdk.APIKey{Scopes: scopes},
+			svcRes:   auth.Token{AccessToken: validToken},
+			response: sdk.APIKey{Value: validToken, Type: uint32(auth.APIKey), Scopes: scopes},
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			token:  validToken,
+			key:    sdk.APIKey{Scopes: []sdk.KeyScope{{Operation: "channels"}}},
+			svcRes: auth.Token{},
+			err:    errors.NewSDKErrorWithStatus(apiutil.ErrInvalidScope, http.StatusBadRequest),
+		},
+		{
+			desc:   "issue API key with invalid token",
+			token:  invalidToken,
+			key:    sdk.APIKey{},
+			svcRes: auth.Token{},
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "issue API key with empty token",
+			token:  "",
+			key:    sdk.APIKey{},
+			svcRes: auth.Token{},
+			err:    errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Issue", mock.Anything, tc.token, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.IssueAPIKey(tc.key, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "Issue", mock.Anything, tc.token, mock.Anything)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	ks, svc := setupKeys()
+	defer ks.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ks.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	issuedAt := time.Now().UTC().Round(time.Second)
+	key := auth.Key{
+		ID:       generateUUID(t),
+		Type:     auth.APIKey,
+		IssuedAt: issuedAt,
+		Scopes:   []auth.Scope{{Operation: "things:view"}},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		pageMeta sdk.PageMetadata
+		svcReq   auth.Page
+		svcRes   auth.KeyPage
+		svcErr   error
+		response sdk.APIKeysPage
+		err      error
+	}{
+		{
+			desc:     "list API keys successfully",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcReq:   auth.Page{Offset: 0, Limit: 10},
+			svcRes:   auth.KeyPage{Total: 1, Limit: 10, Keys: []auth.Key{key}},
+			response: sdk.APIKeysPage{
+				PageRes: sdk.PageRes{Total: 1, Limit: 10},
+				Keys: []sdk.APIKey{{
+					ID:       key.ID,
+					Type:     uint32(auth.APIKey),
+					IssuedAt: issuedAt,
+					Scopes:   []sdk.KeyScope{{Operation: "things:view"}},
+				}},
+			},
+		},
+		{
+			desc:     "list API keys with invalid token",
+			token:    invalidToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcReq:   auth.Page{Offset: 0, Limit: 10},
+			svcErr:   svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list API keys with invalid limit",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 1000},
+			svcReq:   auth.Page{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrLimitSize, http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListKeys", mock.Anything, tc.token, tc.svcReq).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.APIKeys(tc.pageMeta, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ListKeys", mock.Anything, tc.token, tc.svcReq)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	ks, svc := setupKeys()
+	defer ks.Close()
+
+	sdkConf := sdk.Config{
+		DomainsURL:     ks.URL,
+		MsgContentType: contentType,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	keyID := generateUUID(t)
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		svcErr error
+		err    error
+	}{
+		{
+			desc:  "revoke API key successfully",
+			token: validToken,
+			id:    keyID,
+		},
+		{
+			desc:   "revoke API key with invalid token",
+			token:  invalidToken,
+			id:     keyID,
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "revoke API key with empty id",
+			token: validToken,
+			id:    "",
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Revoke", mock.Anything, tc.token, tc.id).Return(tc.svcErr)
+			err := mgsdk.RevokeAPIKey(tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "Revoke", mock.Anything, tc.token, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
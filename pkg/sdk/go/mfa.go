@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const mfaEndpoint = "mfa"
+
+// MFAEnrollment contains the TOTP secret and the otpauth URI
+// of a pending multi-factor authentication enrollment.
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type mfaCodeReq struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+	Code     string `json:"code"`
+}
+
+type recoveryCodesRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (sdk mgSDK) EnrollMFA(token string) (MFAEnrollment, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/enroll", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return MFAEnrollment{}, sdkerr
+	}
+
+	var enrollment MFAEnrollment
+	if err := json.Unmarshal(body, &enrollment); err != nil {
+		return MFAEnrollment{}, errors.NewSDKError(err)
+	}
+
+	return enrollment, nil
+}
+
+func (sdk mgSDK) VerifyMFA(code, token string) ([]string, errors.SDKError) {
+	data, err := json.Marshal(mfaCodeReq{Code: code})
+	if err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/verify", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res recoveryCodesRes
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.RecoveryCodes, nil
+}
+
+func (sdk mgSDK) DisableMFA(code, token string) errors.SDKError {
+	data, err := json.Marshal(mfaCodeReq{Code: code})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/disable", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusNoContent)
+
+	return sdkerr
+}
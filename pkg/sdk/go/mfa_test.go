@@ -0,0 +1,218 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/users/mfa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnrollMFA(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	enrollment := mfa.Enrollment{
+		Secret: "JBSWY3DPEHPK3PXP",
+		URI:    "otpauth://totp/Magistrala:user?secret=JBSWY3DPEHPK3PXP",
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		svcRes   mfa.Enrollment
+		svcErr   error
+		response sdk.MFAEnrollment
+		err      errors.SDKError
+	}{
+		{
+			desc:     "enroll MFA successfully",
+			token:    validToken,
+			svcRes:   enrollment,
+			svcErr:   nil,
+			response: sdk.MFAEnrollment{Secret: enrollment.Secret, URI: enrollment.URI},
+			err:      nil,
+		},
+		{
+			desc:     "enroll MFA with invalid token",
+			token:    invalidToken,
+			svcRes:   mfa.Enrollment{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.MFAEnrollment{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "enroll MFA with empty token",
+			token:    "",
+			svcRes:   mfa.Enrollment{},
+			svcErr:   nil,
+			response: sdk.MFAEnrollment{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusUnauthorized),
+		},
+		{
+			desc:     "enroll MFA with already enabled MFA",
+			token:    validToken,
+			svcRes:   mfa.Enrollment{},
+			svcErr:   svcerr.ErrConflict,
+			response: sdk.MFAEnrollment{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("EnrollMFA", mock.Anything, tc.token).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.EnrollMFA(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "EnrollMFA", mock.Anything, tc.token)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	codes := []string{"abcde-fghij", "klmno-pqrst"}
+
+	cases := []struct {
+		desc     string
+		token    string
+		code     string
+		svcRes   []string
+		svcErr   error
+		response []string
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify MFA successfully",
+			token:    validToken,
+			code:     "123456",
+			svcRes:   codes,
+			svcErr:   nil,
+			response: codes,
+			err:      nil,
+		},
+		{
+			desc:     "verify MFA with invalid code",
+			token:    validToken,
+			code:     "000000",
+			svcRes:   nil,
+			svcErr:   svcerr.ErrMalformedEntity,
+			response: nil,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrMalformedEntity, http.StatusBadRequest),
+		},
+		{
+			desc:     "verify MFA with empty code",
+			token:    validToken,
+			code:     "",
+			svcRes:   nil,
+			svcErr:   nil,
+			response: nil,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+		{
+			desc:     "verify MFA with invalid token",
+			token:    invalidToken,
+			code:     "123456",
+			svcRes:   nil,
+			svcErr:   svcerr.ErrAuthentication,
+			response: nil,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("VerifyMFA", mock.Anything, tc.token, tc.code).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.VerifyMFA(tc.code, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "VerifyMFA", mock.Anything, tc.token, tc.code)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc   string
+		token  string
+		code   string
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:   "disable MFA successfully",
+			token:  validToken,
+			code:   "123456",
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:   "disable MFA with invalid code",
+			token:  validToken,
+			code:   "000000",
+			svcErr: svcerr.ErrMalformedEntity,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrMalformedEntity, http.StatusBadRequest),
+		},
+		{
+			desc:   "disable MFA with empty code",
+			token:  validToken,
+			code:   "",
+			svcErr: nil,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+		{
+			desc:   "disable MFA with invalid token",
+			token:  invalidToken,
+			code:   "123456",
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DisableMFA", mock.Anything, tc.token, tc.code).Return(tc.svcErr)
+			err := mgsdk.DisableMFA(tc.code, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DisableMFA", mock.Anything, tc.token, tc.code)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const profileExportEndpoint = "profile/export"
+
+func (sdk mgSDK) ExportProfile(token string) ([]byte, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, profileExportEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	return body, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportProfile(t *testing.T) {
+	us, usvc := setupUsers()
+	defer us.Close()
+
+	conf := sdk.Config{
+		UsersURL: us.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	domainID := testsutil.GenerateUUID(t)
+	archive := users.ProfileArchive{
+		Profile: convertClient(user),
+		Domains: []users.DomainMembership{
+			{
+				DomainID:    domainID,
+				Permissions: []string{"membership"},
+				Things:      []string{testsutil.GenerateUUID(t)},
+				Groups:      []string{},
+			},
+		},
+		Invitations: []users.Invitation{
+			{
+				InvitedBy: testsutil.GenerateUUID(t),
+				DomainID:  domainID,
+				Relation:  "member",
+			},
+		},
+		Journal: []users.JournalEntry{
+			{
+				ID:        testsutil.GenerateUUID(t),
+				Operation: "user.create",
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		usersRes users.ProfileArchive
+		usersErr error
+		err      errors.SDKError
+	}{
+		{
+			desc:     "export profile successfully",
+			token:    validToken,
+			usersRes: archive,
+			err:      nil,
+		},
+		{
+			desc:     "export profile with invalid token",
+			token:    invalidToken,
+			usersErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			usersCall := usvc.On("ExportProfile", mock.Anything, tc.token).Return(tc.usersRes, tc.usersErr)
+			resp, err := mgsdk.ExportProfile(tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				var a users.ProfileArchive
+				err := json.Unmarshal(resp, &a)
+				assert.Nil(t, err)
+				assert.Equal(t, user.ID, a.Profile.ID)
+				assert.Equal(t, archive.Domains, a.Domains)
+				assert.Equal(t, archive.Invitations, a.Invitations)
+				assert.Equal(t, archive.Journal, a.Journal)
+			}
+			usersCall.Unset()
+		})
+	}
+}
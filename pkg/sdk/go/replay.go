@@ -0,0 +1,127 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	replaysEndpoint = "replays"
+	cancelEndpoint  = "cancel"
+)
+
+// Replay represents a job republishing the stored channel messages
+// from the given time range. From and To are Unix times in seconds.
+type Replay struct {
+	ID         string         `json:"id,omitempty"`
+	DomainID   string         `json:"domain_id,omitempty"`
+	ChannelID  string         `json:"channel_id,omitempty"`
+	Subtopic   string         `json:"subtopic,omitempty"`
+	Publisher  string         `json:"publisher,omitempty"`
+	Format     string         `json:"format,omitempty"`
+	From       float64        `json:"from,omitempty"`
+	To         float64        `json:"to,omitempty"`
+	Rate       float64        `json:"rate,omitempty"`
+	Status     string         `json:"status,omitempty"`
+	Progress   ReplayProgress `json:"progress,omitempty"`
+	Percent    float64        `json:"percent,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	CreatedBy  string         `json:"created_by,omitempty"`
+	StartedAt  time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// ReplayProgress contains the number of scanned and published messages.
+type ReplayProgress struct {
+	Total     uint64 `json:"total"`
+	Scanned   uint64 `json:"scanned"`
+	Published uint64 `json:"published"`
+}
+
+type ReplaysPage struct {
+	Total   uint64   `json:"total"`
+	Replays []Replay `json:"replays"`
+}
+
+func (sdk mgSDK) StartReplay(replay Replay, token string) (Replay, errors.SDKError) {
+	data, err := json.Marshal(replay)
+	if err != nil {
+		return Replay{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.replayURL, replaysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Replay{}, sdkerr
+	}
+
+	replay = Replay{}
+	if err := json.Unmarshal(body, &replay); err != nil {
+		return Replay{}, errors.NewSDKError(err)
+	}
+
+	return replay, nil
+}
+
+func (sdk mgSDK) Replay(id, token string) (Replay, errors.SDKError) {
+	if id == "" {
+		return Replay{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.replayURL, replaysEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Replay{}, sdkerr
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(body, &replay); err != nil {
+		return Replay{}, errors.NewSDKError(err)
+	}
+
+	return replay, nil
+}
+
+func (sdk mgSDK) Replays(token string) (ReplaysPage, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.replayURL, replaysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ReplaysPage{}, sdkerr
+	}
+
+	var page ReplaysPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return ReplaysPage{}, errors.NewSDKError(err)
+	}
+
+	return page, nil
+}
+
+func (sdk mgSDK) CancelReplay(id, token string) (Replay, errors.SDKError) {
+	if id == "" {
+		return Replay{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.replayURL, replaysEndpoint, id, cancelEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Replay{}, sdkerr
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(body, &replay); err != nil {
+		return Replay{}, errors.NewSDKError(err)
+	}
+
+	return replay, nil
+}
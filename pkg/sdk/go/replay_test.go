@@ -0,0 +1,272 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/absmach/magistrala/readers/replay/api"
+	"github.com/absmach/magistrala/readers/replay/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupReplay() (*httptest.Server, *mocks.Service) {
+	svc := new(mocks.Service)
+
+	logger := mglog.NewMock()
+	mux := api.MakeHandler(svc, logger, "replay", "test")
+	return httptest.NewServer(mux), svc
+}
+
+func generateTestReplay(t *testing.T) replay.Job {
+	startedAt, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	assert.Nil(t, err)
+	return replay.Job{
+		ID:        generateUUID(t),
+		DomainID:  generateUUID(t),
+		ChannelID: generateUUID(t),
+		Format:    replay.DefFormat,
+		From:      1700000000,
+		To:        1700003600,
+		Rate:      10,
+		Status:    replay.RunningStatus,
+		Progress:  replay.Progress{Total: 10, Scanned: 5, Published: 5},
+		StartedAt: startedAt,
+	}
+}
+
+func convertReplay(job replay.Job) sdk.Replay {
+	return sdk.Replay{
+		ID:        job.ID,
+		DomainID:  job.DomainID,
+		ChannelID: job.ChannelID,
+		Format:    job.Format,
+		From:      job.From,
+		To:        job.To,
+		Rate:      job.Rate,
+		Status:    string(job.Status),
+		Progress: sdk.ReplayProgress{
+			Total:     job.Progress.Total,
+			Scanned:   job.Progress.Scanned,
+			Published: job.Progress.Published,
+		},
+		Percent:    job.Progress.Percent(),
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+func TestStartReplay(t *testing.T) {
+	rs, svc := setupReplay()
+	defer rs.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{ReplayURL: rs.URL})
+
+	job := generateTestReplay(t)
+	req := sdk.Replay{ChannelID: job.ChannelID, From: job.From, To: job.To, Rate: job.Rate}
+	svcReq := replay.Job{ChannelID: job.ChannelID, From: job.From, To: job.To, Rate: job.Rate}
+
+	cases := []struct {
+		desc     string
+		token    string
+		req      sdk.Replay
+		svcRes   replay.Job
+		svcErr   error
+		response sdk.Replay
+		err      errors.SDKError
+	}{
+		{
+			desc:     "start replay successfully",
+			token:    validToken,
+			req:      req,
+			svcRes:   job,
+			response: convertReplay(job),
+		},
+		{
+			desc:   "start replay with invalid token",
+			token:  invalidToken,
+			req:    req,
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "start replay without channel",
+			token: validToken,
+			req:   sdk.Replay{From: job.From},
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID), http.StatusBadRequest),
+		},
+		{
+			desc:  "start replay with invalid time range",
+			token: validToken,
+			req:   sdk.Replay{ChannelID: job.ChannelID, From: job.To, To: job.From},
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidTimeRange), http.StatusBadRequest),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("StartReplay", mock.Anything, tc.token, svcReq).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.StartReplay(tc.req, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "StartReplay", mock.Anything, tc.token, svcReq)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestViewReplay(t *testing.T) {
+	rs, svc := setupReplay()
+	defer rs.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{ReplayURL: rs.URL})
+
+	job := generateTestReplay(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		svcRes   replay.Job
+		svcErr   error
+		response sdk.Replay
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view replay successfully",
+			token:    validToken,
+			id:       job.ID,
+			svcRes:   job,
+			response: convertReplay(job),
+		},
+		{
+			desc:   "view non-existing replay",
+			token:  validToken,
+			id:     job.ID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:  "view replay with empty id",
+			token: validToken,
+			id:    "",
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewReplay", mock.Anything, tc.token, tc.id).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.Replay(tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestListReplays(t *testing.T) {
+	rs, svc := setupReplay()
+	defer rs.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{ReplayURL: rs.URL})
+
+	job := generateTestReplay(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		svcRes   []replay.Job
+		svcErr   error
+		response sdk.ReplaysPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "list replays successfully",
+			token:    validToken,
+			svcRes:   []replay.Job{job},
+			response: sdk.ReplaysPage{Total: 1, Replays: []sdk.Replay{convertReplay(job)}},
+		},
+		{
+			desc:   "list replays without permission",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListReplays", mock.Anything, tc.token).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.Replays(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestCancelReplay(t *testing.T) {
+	rs, svc := setupReplay()
+	defer rs.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{ReplayURL: rs.URL})
+
+	job := generateTestReplay(t)
+	job.Status = replay.CanceledStatus
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		svcRes   replay.Job
+		svcErr   error
+		response sdk.Replay
+		err      errors.SDKError
+	}{
+		{
+			desc:     "cancel replay successfully",
+			token:    validToken,
+			id:       job.ID,
+			svcRes:   job,
+			response: convertReplay(job),
+		},
+		{
+			desc:   "cancel finished replay",
+			token:  validToken,
+			id:     job.ID,
+			svcErr: errors.Wrap(svcerr.ErrConflict, replay.ErrFinished),
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+		{
+			desc:  "cancel replay with empty id",
+			token: validToken,
+			id:    "",
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("CancelReplay", mock.Anything, tc.token, tc.id).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.CancelReplay(tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
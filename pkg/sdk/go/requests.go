@@ -3,6 +3,8 @@
 
 package sdk
 
+import "time"
+
 // updateClientSecretReq is used to update the client secret.
 type updateClientSecretReq struct {
 	OldSecret string `json:"old_secret,omitempty"`
@@ -46,6 +48,10 @@ type Connection struct {
 type UsersRelationRequest struct {
 	Relation string   `json:"relation"`
 	UserIDs  []string `json:"user_ids"`
+	// ExpiresAt and AllowedCIDRs optionally restrict the granted relation
+	// when sharing a thing or adding users to a domain.
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	AllowedCIDRs []string   `json:"allowed_cidrs,omitempty"`
 }
 
 type UserGroupsRequest struct {
@@ -83,3 +83,8 @@ type DomainsPage struct {
 	Domains []Domain `json:"domains"`
 	PageRes
 }
+
+type RolesPage struct {
+	Roles []Role `json:"roles"`
+	PageRes
+}
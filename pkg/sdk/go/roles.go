@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	rolesEndpoint       = "roles"
+	assignmentsEndpoint = "assignments"
+)
+
+// Role represents magistrala custom domain role.
+type Role struct {
+	ID          string    `json:"id,omitempty"`
+	DomainID    string    `json:"domain_id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// RoleAssignment represents the role assigned to the user on the entity.
+type RoleAssignment struct {
+	RoleID     string `json:"role_id"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	UserID     string `json:"user_id"`
+}
+
+type assignRoleReq struct {
+	Entity   string   `json:"entity"`
+	EntityID string   `json:"entity_id"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type roleAssignmentsRes struct {
+	Assignments []RoleAssignment `json:"assignments"`
+}
+
+func (sdk mgSDK) CreateRole(domainID string, role Role, token string) (Role, errors.SDKError) {
+	if domainID == "" {
+		return Role{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	data, err := json.Marshal(role)
+	if err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID, rolesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Role{}, sdkerr
+	}
+
+	var r Role
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) Roles(domainID string, pm PageMetadata, token string) (RolesPage, errors.SDKError) {
+	if domainID == "" {
+		return RolesPage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url, err := sdk.withQueryParams(sdk.domainsURL, fmt.Sprintf("%s/%s/%s", domainsEndpoint, domainID, rolesEndpoint), pm)
+	if err != nil {
+		return RolesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RolesPage{}, sdkerr
+	}
+
+	var rp RolesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RolesPage{}, errors.NewSDKError(err)
+	}
+
+	return rp, nil
+}
+
+func (sdk mgSDK) RoleAssignments(domainID, roleID, token string) ([]RoleAssignment, errors.SDKError) {
+	if domainID == "" || roleID == "" {
+		return nil, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID, rolesEndpoint, roleID, assignmentsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res roleAssignmentsRes
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Assignments, nil
+}
+
+func (sdk mgSDK) AssignRole(domainID, roleID, entity, entityID string, userIDs []string, token string) errors.SDKError {
+	if domainID == "" || roleID == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	data, err := json.Marshal(assignRoleReq{
+		Entity:   entity,
+		EntityID: entityID,
+		UserIDs:  userIDs,
+	})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.domainsURL, domainsEndpoint, domainID, rolesEndpoint, roleID, assignEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	return sdkerr
+}
@@ -69,6 +69,9 @@ var (
 	ErrFailedDisable = errors.New("failed to disable client")
 
 	ErrInvalidJWT = errors.New("invalid JWT")
+
+	// ErrArchiveVersion indicates that the domain archive version is not supported.
+	ErrArchiveVersion = errors.New("unsupported domain archive version")
 )
 
 type MessagePageMetadata struct {
@@ -150,6 +153,21 @@ type SDK interface {
 	//  fmt.Println(user)
 	CreateUser(user User, token string) (User, errors.SDKError)
 
+	// CreateInvitedUser registers the user invited to the domain
+	// using the invitation token. The user email is verified.
+	//
+	// example:
+	//  user := sdk.User{
+	//    Name:	 "John Doe",
+	//    Credentials: sdk.Credentials{
+	//      Identity: "john.doe@example",
+	//      Secret:   "12345678",
+	//    },
+	//  }
+	//  user, _ := sdk.CreateInvitedUser(user, "invitationToken")
+	//  fmt.Println(user)
+	CreateInvitedUser(user User, token string) (User, errors.SDKError)
+
 	// User returns user object by id.
 	//
 	// example:
@@ -157,6 +175,14 @@ type SDK interface {
 	//  fmt.Println(user)
 	User(id, token string) (User, errors.SDKError)
 
+	// UserByIdentity returns the ID and the name of the user with the identity
+	// to the administrator of the domain or the platform.
+	//
+	// example:
+	//  user, _ := sdk.UserByIdentity("user@example.com", "domainID", "token")
+	//  fmt.Println(user)
+	UserByIdentity(identity, domainID, token string) (User, errors.SDKError)
+
 	// Users returns list of users.
 	//
 	// example:
@@ -271,6 +297,14 @@ type SDK interface {
 	//  fmt.Println(user)
 	DisableUser(id, token string) (User, errors.SDKError)
 
+	// UnlockUser removes the login lockout of the user after too many
+	// failed login attempts.
+	//
+	// example:
+	//  err := sdk.UnlockUser("userID", "token")
+	//  fmt.Println(err)
+	UnlockUser(id, token string) errors.SDKError
+
 	// DeleteUser deletes a user with the given id.
 	//
 	// example:
@@ -278,6 +312,89 @@ type SDK interface {
 	//  fmt.Println(err)
 	DeleteUser(id, token string) errors.SDKError
 
+	// SendVerification sends the email verification link to the user with
+	// the given credentials, who can't log in before the email is verified.
+	// The link is resent to the same email at most once a minute.
+	//
+	// example:
+	//  lt := sdk.Login{
+	//    Identity: "user@example.com",
+	//    Secret:   "password",
+	//  }
+	//  err := sdk.SendVerification(lt)
+	//  fmt.Println(err)
+	SendVerification(lt Login) errors.SDKError
+
+	// VerifyEmail verifies the user email with the token received in the
+	// verification email.
+	//
+	// example:
+	//  user, _ := sdk.VerifyEmail("verificationToken")
+	//  fmt.Println(user)
+	VerifyEmail(token string) (User, errors.SDKError)
+
+	// DeleteProfile schedules the deletion of the logged in user. The user
+	// is deleted once the deletion grace period passes.
+	//
+	// example:
+	//  err := sdk.DeleteProfile("token")
+	//  fmt.Println(err)
+	DeleteProfile(token string) errors.SDKError
+
+	// ExportProfile exports the personal data of the logged in user: the
+	// profile, the domain memberships, the administered things and groups,
+	// the invitations and the journal entries. The archive is returned as
+	// the JSON document exported by the users service.
+	//
+	// example:
+	//  archive, _ := sdk.ExportProfile("token")
+	//  fmt.Println(string(archive))
+	ExportProfile(token string) ([]byte, errors.SDKError)
+
+	// CreateServiceAccount creates the service account in the domain.
+	//
+	// example:
+	//  sa := sdk.ServiceAccount{
+	//    Name: "backend",
+	//  }
+	//  sa, _ := sdk.CreateServiceAccount("domainID", sa, "token")
+	//  fmt.Println(sa)
+	CreateServiceAccount(domainID string, sa ServiceAccount, token string) (ServiceAccount, errors.SDKError)
+
+	// ServiceAccounts returns the page of the service accounts of the domain.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  sas, _ := sdk.ServiceAccounts("domainID", pm, "token")
+	//  fmt.Println(sas)
+	ServiceAccounts(domainID string, pm PageMetadata, token string) (ServiceAccountsPage, errors.SDKError)
+
+	// ServiceAccount returns the service account of the domain.
+	//
+	// example:
+	//  sa, _ := sdk.ServiceAccount("domainID", "serviceAccountID", "token")
+	//  fmt.Println(sa)
+	ServiceAccount(domainID, id, token string) (ServiceAccount, errors.SDKError)
+
+	// DeleteServiceAccount deletes the service account of the domain.
+	//
+	// example:
+	//  err := sdk.DeleteServiceAccount("domainID", "serviceAccountID", "token")
+	//  fmt.Println(err)
+	DeleteServiceAccount(domainID, id, token string) errors.SDKError
+
+	// IssueServiceAccountKey issues the API key of the service account. The
+	// key is used as the bearer token, or exchanged for the access token
+	// with the client credentials grant.
+	//
+	// example:
+	//  key, _ := sdk.IssueServiceAccountKey("domainID", "serviceAccountID", "token")
+	//  fmt.Println(key.AccessToken)
+	IssueServiceAccountKey(domainID, id, token string) (Token, errors.SDKError)
+
 	// CreateToken receives credentials and returns user token.
 	//
 	// example:
@@ -300,6 +417,38 @@ type SDK interface {
 	//  fmt.Println(token)
 	RefreshToken(lt Login, token string) (Token, errors.SDKError)
 
+	// CreateMFAToken exchanges the MFA token returned by CreateToken and
+	// a TOTP or recovery code for the user token.
+	//
+	// example:
+	//  token, _ := sdk.CreateMFAToken("mfa_token", "123456")
+	//  fmt.Println(token)
+	CreateMFAToken(mfaToken, code string) (Token, errors.SDKError)
+
+	// EnrollMFA starts multi-factor authentication enrollment and returns
+	// the TOTP secret and the otpauth URI for authenticator apps.
+	//
+	// example:
+	//  enrollment, _ := sdk.EnrollMFA("token")
+	//  fmt.Println(enrollment.URI)
+	EnrollMFA(token string) (MFAEnrollment, errors.SDKError)
+
+	// VerifyMFA confirms the enrollment with a TOTP code, enables
+	// multi-factor authentication and returns the recovery codes.
+	//
+	// example:
+	//  codes, _ := sdk.VerifyMFA("123456", "token")
+	//  fmt.Println(codes)
+	VerifyMFA(code, token string) ([]string, errors.SDKError)
+
+	// DisableMFA disables multi-factor authentication using a TOTP or
+	// recovery code.
+	//
+	// example:
+	//  err := sdk.DisableMFA("123456", "token")
+	//  fmt.Println(err)
+	DisableMFA(code, token string) errors.SDKError
+
 	// ListUserChannels list all channels belongs a particular user id.
 	//
 	// example:
@@ -1051,6 +1200,86 @@ type SDK interface {
 	//  fmt.Println(permissions)
 	DomainPermissions(domainID, token string) (Domain, errors.SDKError)
 
+	// DomainUsage retrieves the quotas and the resources usage of the given domain ID.
+	//
+	// example:
+	//  usage, _ := sdk.DomainUsage("domainID", "token")
+	//  fmt.Println(usage)
+	DomainUsage(domainID, token string) (DomainUsage, errors.SDKError)
+
+	// DeleteDomain schedules the deletion of the domain and its data.
+	// The domain data is deleted once the grace period passes.
+	//
+	// example:
+	//  deletion, _ := sdk.DeleteDomain("domainID", "token")
+	//  fmt.Println(deletion)
+	DeleteDomain(domainID, token string) (DomainDeletion, errors.SDKError)
+
+	// DomainDeletion retrieves the status of the domain deletion.
+	//
+	// example:
+	//  deletion, _ := sdk.DomainDeletion("domainID", "token")
+	//  fmt.Println(deletion)
+	DomainDeletion(domainID, token string) (DomainDeletion, errors.SDKError)
+
+	// ExportDomain exports the things, channels, groups, connections,
+	// bootstrap configs, notifier subscriptions, roles and role assignments
+	// of the given domain ID. Secrets of the things and the bootstrap
+	// configs are exported only if withSecrets is set.
+	//
+	// example:
+	//  archive, _ := sdk.ExportDomain("domainID", false, "token")
+	//  fmt.Println(archive)
+	ExportDomain(domainID string, withSecrets bool, token string) (DomainArchive, errors.SDKError)
+
+	// ImportDomain recreates the archived entities in the given domain ID,
+	// mapping the archived IDs to the IDs of the created entities. Entities
+	// which can't be imported as is are reported as conflicts. Dry run
+	// reports the entities to be created and the conflicts without creating
+	// them.
+	//
+	// example:
+	//  report, _ := sdk.ImportDomain("domainID", archive, true, "token")
+	//  fmt.Println(report)
+	ImportDomain(domainID string, archive DomainArchive, dryRun bool, token string) (ImportReport, errors.SDKError)
+
+	// CreateRole creates the custom role in the given domain ID.
+	//
+	// example:
+	//  role := sdk.Role{
+	//    Name:        "operator",
+	//    Permissions: []string{"things:view", "channels:publish"},
+	//  }
+	//  role, _ := sdk.CreateRole("domainID", role, "token")
+	//  fmt.Println(role)
+	CreateRole(domainID string, role Role, token string) (Role, errors.SDKError)
+
+	// Roles returns the page of the roles of the given domain ID.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  roles, _ := sdk.Roles("domainID", pm, "token")
+	//  fmt.Println(roles)
+	Roles(domainID string, pm PageMetadata, token string) (RolesPage, errors.SDKError)
+
+	// RoleAssignments returns the assignments of the given role ID.
+	//
+	// example:
+	//  assignments, _ := sdk.RoleAssignments("domainID", "roleID", "token")
+	//  fmt.Println(assignments)
+	RoleAssignments(domainID, roleID, token string) ([]RoleAssignment, errors.SDKError)
+
+	// AssignRole assigns the role to the domain users on the entity:
+	// the domain, a group or a channel.
+	//
+	// example:
+	//  err := sdk.AssignRole("domainID", "roleID", "channels", "channelID", []string{"userID"}, "token")
+	//  fmt.Println(err)
+	AssignRole(domainID, roleID, entity, entityID string, userIDs []string, token string) errors.SDKError
+
 	// UpdateDomain updates details of the given domain ID.
 	//
 	// example:
@@ -1135,11 +1364,12 @@ type SDK interface {
 	RemoveUserFromDomain(domainID, userID, token string) errors.SDKError
 
 	// SendInvitation sends an invitation to the email address associated with the given user.
+	// The invitation can be sent to the email that has no account yet instead of the user ID.
 	//
 	// For example:
 	//  invitation := sdk.Invitation{
 	//    DomainID: "domainID",
-	//    UserID:   "userID",
+	//    UserID:   "userID", // or Email: "user@example.com"
 	//    Relation: "contributor", // available options: "owner", "admin", "editor", "contributor", "guest"
 	//  }
 	//  err := sdk.SendInvitation(invitation, "token")
@@ -1153,6 +1383,14 @@ type SDK interface {
 	//  fmt.Println(invitation)
 	Invitation(userID, domainID, token string) (invitation Invitation, err error)
 
+	// EmailInvitation returns the pending invitation sent to the email
+	// that has no account yet.
+	//
+	// For example:
+	//  invitation, _ := sdk.EmailInvitation("user@example.com", "domainID", "token")
+	//  fmt.Println(invitation)
+	EmailInvitation(email, domainID, token string) (invitation Invitation, err error)
+
 	// Invitations returns a list of invitations.
 	//
 	// For example:
@@ -1167,6 +1405,14 @@ type SDK interface {
 	//  fmt.Println(err)
 	AcceptInvitation(domainID, token string) (err error)
 
+	// SignUp creates the user with the email that the invitation with the
+	// given code was sent to, and accepts the invitation.
+	//
+	// For example:
+	//  invitation, _ := sdk.SignUp("code", "name", "secret")
+	//  fmt.Println(invitation)
+	SignUp(code, name, secret string) (invitation Invitation, err error)
+
 	// RejectInvitation rejects an invitation.
 	//
 	// For example:
@@ -1181,12 +1427,100 @@ type SDK interface {
 	//  fmt.Println(err)
 	DeleteInvitation(userID, domainID, token string) (err error)
 
+	// DeleteEmailInvitation deletes the pending invitation sent to the email
+	// that has no account yet.
+	//
+	// For example:
+	//  err := sdk.DeleteEmailInvitation("user@example.com", "domainID", "token")
+	//  fmt.Println(err)
+	DeleteEmailInvitation(email, domainID, token string) (err error)
+
 	// Journal returns a list of journal logs.
 	//
 	// For example:
 	//  journals, _ := sdk.Journal("thing", "thingID", PageMetadata{Offset: 0, Limit: 10, Operation: "users.create"}, "token")
 	//  fmt.Println(journals)
 	Journal(entityType, entityID string, pm PageMetadata, token string) (journal JournalsPage, err error)
+
+	// StartReplay starts republishing the stored channel messages from the
+	// given time range into the message broker.
+	//
+	// For example:
+	//  replay := sdk.Replay{
+	//    ChannelID: "channelID",
+	//    From:      1700000000,
+	//    To:        1700003600,
+	//    Rate:      100,
+	//  }
+	//  replay, _ := sdk.StartReplay(replay, "token")
+	//  fmt.Println(replay)
+	StartReplay(replay Replay, token string) (Replay, errors.SDKError)
+
+	// Replay returns the replay job with its progress.
+	//
+	// For example:
+	//  replay, _ := sdk.Replay("replayID", "token")
+	//  fmt.Println(replay.Progress)
+	Replay(id, token string) (Replay, errors.SDKError)
+
+	// Replays returns the replay jobs of the domain.
+	//
+	// For example:
+	//  replays, _ := sdk.Replays("token")
+	//  fmt.Println(replays)
+	Replays(token string) (ReplaysPage, errors.SDKError)
+
+	// CancelReplay stops the running replay job.
+	//
+	// For example:
+	//  replay, _ := sdk.CancelReplay("replayID", "token")
+	//  fmt.Println(replay.Status)
+	CancelReplay(id, token string) (Replay, errors.SDKError)
+
+	// IssueAPIKey issues a new API key. Scopes restrict the key to the
+	// given operations and entities; a key without scopes is unrestricted.
+	//
+	// For example:
+	//  key := sdk.APIKey{
+	//    Duration: 86400,
+	//    Scopes: []sdk.KeyScope{
+	//      {Operation: "channels:publish", EntityIDs: []string{"channelID"}},
+	//    },
+	//  }
+	//  key, _ := sdk.IssueAPIKey(key, "token")
+	//  fmt.Println(key.Value)
+	IssueAPIKey(key APIKey, token string) (APIKey, errors.SDKError)
+
+	// APIKeys returns the API keys issued by the user.
+	//
+	// For example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  keys, _ := sdk.APIKeys(pm, "token")
+	//  fmt.Println(keys)
+	APIKeys(pm PageMetadata, token string) (APIKeysPage, errors.SDKError)
+
+	// RevokeAPIKey revokes the API key.
+	//
+	// For example:
+	//  err := sdk.RevokeAPIKey("keyID", "token")
+	//  fmt.Println(err)
+	RevokeAPIKey(id, token string) errors.SDKError
+
+	// ExplainPolicy explains why the subject has or doesn't have the
+	// permission on the object. The subject defaults to the token user.
+	//
+	// For example:
+	//  req := sdk.ExplainRequest{
+	//    Permission: "view",
+	//    ObjectType: "thing",
+	//    Object:     "thingID",
+	//  }
+	//  exp, _ := sdk.ExplainPolicy(req, "token")
+	//  fmt.Println(exp.Allowed, exp.Path)
+	ExplainPolicy(req ExplainRequest, token string) (PolicyExplanation, errors.SDKError)
 }
 
 type mgSDK struct {
@@ -1199,6 +1533,7 @@ type mgSDK struct {
 	domainsURL     string
 	invitationsURL string
 	journalURL     string
+	replayURL      string
 	HostURL        string
 
 	msgContentType ContentType
@@ -1217,6 +1552,7 @@ type Config struct {
 	DomainsURL     string
 	InvitationsURL string
 	JournalURL     string
+	ReplayURL      string
 	HostURL        string
 
 	MsgContentType  ContentType
@@ -1236,6 +1572,7 @@ func NewSDK(conf Config) SDK {
 		domainsURL:     conf.DomainsURL,
 		invitationsURL: conf.InvitationsURL,
 		journalURL:     conf.JournalURL,
+		replayURL:      conf.ReplayURL,
 		HostURL:        conf.HostURL,
 
 		msgContentType: conf.MsgContentType,
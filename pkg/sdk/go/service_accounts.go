@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	serviceAccountsEndpoint = "service-accounts"
+	serviceAccountKeys      = "keys"
+)
+
+// ServiceAccount represents the non-human principal of the domain. Service
+// accounts have no password and authenticate only with their API keys.
+type ServiceAccount struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Domain    string    `json:"domain_id,omitempty"`
+	Metadata  Metadata  `json:"metadata,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	Status    string    `json:"status,omitempty"`
+}
+
+// ServiceAccountsPage contains list of service accounts in a page with proper metadata.
+type ServiceAccountsPage struct {
+	ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	PageRes
+}
+
+func (sdk mgSDK) CreateServiceAccount(domainID string, sa ServiceAccount, token string) (ServiceAccount, errors.SDKError) {
+	if domainID == "" {
+		return ServiceAccount{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	data, err := json.Marshal(sa)
+	if err != nil {
+		return ServiceAccount{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, domainsEndpoint, domainID, serviceAccountsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return ServiceAccount{}, sdkerr
+	}
+
+	sa = ServiceAccount{}
+	if err := json.Unmarshal(body, &sa); err != nil {
+		return ServiceAccount{}, errors.NewSDKError(err)
+	}
+
+	return sa, nil
+}
+
+func (sdk mgSDK) ServiceAccounts(domainID string, pm PageMetadata, token string) (ServiceAccountsPage, errors.SDKError) {
+	if domainID == "" {
+		return ServiceAccountsPage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url, err := sdk.withQueryParams(sdk.usersURL, fmt.Sprintf("%s/%s/%s", domainsEndpoint, domainID, serviceAccountsEndpoint), pm)
+	if err != nil {
+		return ServiceAccountsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ServiceAccountsPage{}, sdkerr
+	}
+
+	var sp ServiceAccountsPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return ServiceAccountsPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
+
+func (sdk mgSDK) ServiceAccount(domainID, id, token string) (ServiceAccount, errors.SDKError) {
+	if domainID == "" || id == "" {
+		return ServiceAccount{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.usersURL, domainsEndpoint, domainID, serviceAccountsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ServiceAccount{}, sdkerr
+	}
+
+	var sa ServiceAccount
+	if err := json.Unmarshal(body, &sa); err != nil {
+		return ServiceAccount{}, errors.NewSDKError(err)
+	}
+
+	return sa, nil
+}
+
+func (sdk mgSDK) DeleteServiceAccount(domainID, id, token string) errors.SDKError {
+	if domainID == "" || id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.usersURL, domainsEndpoint, domainID, serviceAccountsEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) IssueServiceAccountKey(domainID, id, token string) (Token, errors.SDKError) {
+	if domainID == "" || id == "" {
+		return Token{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.usersURL, domainsEndpoint, domainID, serviceAccountsEndpoint, id, serviceAccountKeys)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Token{}, sdkerr
+	}
+
+	var key Token
+	if err := json.Unmarshal(body, &key); err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,324 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	saDomainID = testsutil.GenerateUUID(&testing.T{})
+	saClient   = mgclients.Client{
+		ID:          testsutil.GenerateUUID(&testing.T{}),
+		Name:        "backend",
+		Domain:      saDomainID,
+		Credentials: mgclients.Credentials{Identity: "backend"},
+		Status:      mgclients.EnabledStatus,
+		Role:        mgclients.ServiceAccountRole,
+	}
+	sdkServiceAccount = sdk.ServiceAccount{
+		ID:     saClient.ID,
+		Name:   saClient.Name,
+		Domain: saDomainID,
+		Status: mgclients.EnabledStatus.String(),
+	}
+)
+
+func TestCreateServiceAccount(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		svcErr   error
+		response sdk.ServiceAccount
+		err      errors.SDKError
+	}{
+		{
+			desc:     "create service account successfully",
+			token:    validToken,
+			domainID: saDomainID,
+			response: sdkServiceAccount,
+			err:      nil,
+		},
+		{
+			desc:     "create service account with invalid token",
+			token:    invalidToken,
+			domainID: saDomainID,
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.ServiceAccount{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "create service account with non domain admin token",
+			token:    validToken,
+			domainID: saDomainID,
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.ServiceAccount{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "create service account with empty domain id",
+			token:    validToken,
+			domainID: "",
+			response: sdk.ServiceAccount{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("CreateServiceAccount", mock.Anything, tc.token, tc.domainID, mock.Anything).Return(saClient, tc.svcErr)
+			resp, err := mgsdk.CreateServiceAccount(tc.domainID, sdk.ServiceAccount{Name: saClient.Name}, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "CreateServiceAccount", mock.Anything, tc.token, tc.domainID, mgclients.Client{Name: saClient.Name})
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestServiceAccounts(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		pm       sdk.PageMetadata
+		svcRes   mgclients.ClientsPage
+		svcErr   error
+		response sdk.ServiceAccountsPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "list service accounts successfully",
+			token:    validToken,
+			domainID: saDomainID,
+			pm:       sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcRes: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 10},
+				Clients: []mgclients.Client{saClient},
+			},
+			response: sdk.ServiceAccountsPage{
+				PageRes:         sdk.PageRes{Total: 1, Limit: 10},
+				ServiceAccounts: []sdk.ServiceAccount{sdkServiceAccount},
+			},
+			err: nil,
+		},
+		{
+			desc:     "list service accounts with non domain admin token",
+			token:    validToken,
+			domainID: saDomainID,
+			pm:       sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "list service accounts with invalid limit",
+			token:    validToken,
+			domainID: saDomainID,
+			pm:       sdk.PageMetadata{Offset: 0, Limit: 1000},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), http.StatusBadRequest),
+		},
+		{
+			desc:     "list service accounts with empty domain id",
+			token:    validToken,
+			domainID: "",
+			pm:       sdk.PageMetadata{Offset: 0, Limit: 10},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListServiceAccounts", mock.Anything, tc.token, tc.domainID, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ServiceAccounts(tc.domainID, tc.pm, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		svcErr   error
+		response sdk.ServiceAccount
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view service account successfully",
+			token:    validToken,
+			id:       saClient.ID,
+			response: sdkServiceAccount,
+			err:      nil,
+		},
+		{
+			desc:     "view non-existing service account",
+			token:    validToken,
+			id:       wrongID,
+			svcErr:   svcerr.ErrViewEntity,
+			response: sdk.ServiceAccount{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+		},
+		{
+			desc:     "view service account with empty id",
+			token:    validToken,
+			id:       "",
+			response: sdk.ServiceAccount{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewServiceAccount", mock.Anything, tc.token, saDomainID, tc.id).Return(saClient, tc.svcErr)
+			resp, err := mgsdk.ServiceAccount(saDomainID, tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDeleteServiceAccount(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:  "delete service account successfully",
+			token: validToken,
+			id:    saClient.ID,
+			err:   nil,
+		},
+		{
+			desc:   "delete service account with non domain admin token",
+			token:  validToken,
+			id:     saClient.ID,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:  "delete service account with empty id",
+			token: validToken,
+			id:    "",
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DeleteServiceAccount", mock.Anything, tc.token, saDomainID, tc.id).Return(tc.svcErr)
+			err := mgsdk.DeleteServiceAccount(saDomainID, tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DeleteServiceAccount", mock.Anything, tc.token, saDomainID, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestIssueServiceAccountKey(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		svcRes   *magistrala.Token
+		svcErr   error
+		response sdk.Token
+		err      errors.SDKError
+	}{
+		{
+			desc:     "issue service account key successfully",
+			token:    validToken,
+			id:       saClient.ID,
+			svcRes:   &magistrala.Token{AccessToken: validToken},
+			response: sdk.Token{AccessToken: validToken},
+			err:      nil,
+		},
+		{
+			desc:     "issue service account key with non domain admin token",
+			token:    validToken,
+			id:       saClient.ID,
+			svcRes:   &magistrala.Token{},
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "issue service account key with empty id",
+			token:    validToken,
+			id:       "",
+			svcRes:   &magistrala.Token{},
+			response: sdk.Token{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("IssueServiceAccountKey", mock.Anything, tc.token, saDomainID, tc.id).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.IssueServiceAccountKey(saDomainID, tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+		})
+	}
+}
@@ -151,6 +151,7 @@ func convertClient(c sdk.User) mgclients.Client {
 		UpdatedAt:   c.UpdatedAt,
 		Status:      status,
 		Role:        role,
+		VerifiedAt:  c.VerifiedAt,
 	}
 }
 
@@ -202,6 +203,7 @@ func convertInvitation(i sdk.Invitation) invitations.Invitation {
 	return invitations.Invitation{
 		InvitedBy:   i.InvitedBy,
 		UserID:      i.UserID,
+		Email:       i.Email,
 		DomainID:    i.DomainID,
 		Token:       i.Token,
 		Relation:    i.Relation,
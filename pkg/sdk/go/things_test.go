@@ -1568,11 +1568,11 @@ func TestShareThing(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := tsvc.On("Share", mock.Anything, tc.token, tc.thingID, tc.shareReq.Relation, tc.shareReq.UserIDs[0]).Return(tc.svcErr)
+			svcCall := tsvc.On("Share", mock.Anything, tc.token, tc.thingID, tc.shareReq.Relation, (*auth.Condition)(nil), tc.shareReq.UserIDs[0]).Return(tc.svcErr)
 			err := mgsdk.ShareThing(tc.thingID, tc.shareReq, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "Share", mock.Anything, tc.token, tc.thingID, tc.shareReq.Relation, tc.shareReq.UserIDs[0])
+				ok := svcCall.Parent.AssertCalled(t, "Share", mock.Anything, tc.token, tc.thingID, tc.shareReq.Relation, (*auth.Condition)(nil), tc.shareReq.UserIDs[0])
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -13,10 +13,13 @@ import (
 
 // Token is used for authentication purposes.
 // It contains AccessToken, RefreshToken and AccessExpiry.
+// When the user has multi-factor authentication enabled, only
+// MFAToken is set and has to be exchanged using CreateMFAToken.
 type Token struct {
 	AccessToken  string `json:"access_token,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 	AccessType   string `json:"access_type,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
 type Login struct {
@@ -45,6 +48,26 @@ func (sdk mgSDK) CreateToken(lt Login) (Token, errors.SDKError) {
 	return token, nil
 }
 
+func (sdk mgSDK) CreateMFAToken(mfaToken, code string) (Token, errors.SDKError) {
+	data, err := json.Marshal(mfaCodeReq{MFAToken: mfaToken, Code: code})
+	if err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, mfaTokenEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Token{}, sdkerr
+	}
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	return token, nil
+}
+
 func (sdk mgSDK) RefreshToken(lt Login, token string) (Token, errors.SDKError) {
 	data, err := json.Marshal(lt)
 	if err != nil {
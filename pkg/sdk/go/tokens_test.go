@@ -103,12 +103,89 @@ func TestIssueToken(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("IssueToken", mock.Anything, tc.login.Identity, tc.login.Secret, tc.login.DomainID).Return(tc.svcRes, tc.svcErr)
+			svcCall := svc.On("IssueToken", mock.Anything, tc.login.Identity, tc.login.Secret, tc.login.DomainID, mock.Anything).Return(tc.svcRes, tc.svcErr)
 			resp, err := mgsdk.CreateToken(tc.login)
 			assert.Equal(t, tc.err, err)
 			assert.Equal(t, tc.response, resp)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "IssueToken", mock.Anything, tc.login.Identity, tc.login.Secret, tc.login.DomainID)
+				ok := svcCall.Parent.AssertCalled(t, "IssueToken", mock.Anything, tc.login.Identity, tc.login.Secret, tc.login.DomainID, mock.Anything)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestCreateMFAToken(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	token := generateTestToken()
+	mfaToken := "mfa_token"
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		mfaToken string
+		code     string
+		svcRes   *magistrala.Token
+		svcErr   error
+		response sdk.Token
+		err      errors.SDKError
+	}{
+		{
+			desc:     "create MFA token successfully",
+			mfaToken: mfaToken,
+			code:     "123456",
+			svcRes: &magistrala.Token{
+				AccessToken:  token.AccessToken,
+				RefreshToken: &token.RefreshToken,
+				AccessType:   token.AccessType,
+			},
+			svcErr:   nil,
+			response: token,
+			err:      nil,
+		},
+		{
+			desc:     "create MFA token with invalid code",
+			mfaToken: mfaToken,
+			code:     "000000",
+			svcRes:   nil,
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "create MFA token with empty MFA token",
+			mfaToken: "",
+			code:     "123456",
+			svcRes:   nil,
+			svcErr:   nil,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFAToken), http.StatusBadRequest),
+		},
+		{
+			desc:     "create MFA token with empty code",
+			mfaToken: mfaToken,
+			code:     "",
+			svcRes:   nil,
+			svcErr:   nil,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("IssueMFAToken", mock.Anything, tc.mfaToken, tc.code).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.CreateMFAToken(tc.mfaToken, tc.code)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "IssueMFAToken", mock.Anything, tc.mfaToken, tc.code)
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -19,9 +19,12 @@ const (
 	unassignEndpoint      = "unassign"
 	enableEndpoint        = "enable"
 	disableEndpoint       = "disable"
+	unlockEndpoint        = "unlock"
 	issueTokenEndpoint    = "tokens/issue"
 	refreshTokenEndpoint  = "tokens/refresh"
+	mfaTokenEndpoint      = "tokens/mfa"
 	membersEndpoint       = "members"
+	invitedEndpoint       = "invited"
 	PasswordResetEndpoint = "password"
 )
 
@@ -37,6 +40,7 @@ type User struct {
 	UpdatedAt   time.Time   `json:"updated_at,omitempty"`
 	Status      string      `json:"status,omitempty"`
 	Role        string      `json:"role,omitempty"`
+	VerifiedAt  time.Time   `json:"verified_at,omitempty"`
 }
 
 func (sdk mgSDK) CreateUser(user User, token string) (User, errors.SDKError) {
@@ -60,6 +64,27 @@ func (sdk mgSDK) CreateUser(user User, token string) (User, errors.SDKError) {
 	return user, nil
 }
 
+func (sdk mgSDK) CreateInvitedUser(user User, token string) (User, errors.SDKError) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, invitedEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return User{}, sdkerr
+	}
+
+	user = User{}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	return user, nil
+}
+
 func (sdk mgSDK) Users(pm PageMetadata, token string) (UsersPage, errors.SDKError) {
 	url, err := sdk.withQueryParams(sdk.usersURL, usersEndpoint, pm)
 	if err != nil {
@@ -117,6 +142,25 @@ func (sdk mgSDK) User(id, token string) (User, errors.SDKError) {
 	return user, nil
 }
 
+func (sdk mgSDK) UserByIdentity(identity, domainID, token string) (User, errors.SDKError) {
+	if identity == "" {
+		return User{}, errors.NewSDKError(apiutil.ErrMissingIdentity)
+	}
+	url := fmt.Sprintf("%s/%s/identity/%s/%s", sdk.usersURL, usersEndpoint, identity, domainID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return User{}, sdkerr
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	return user, nil
+}
+
 func (sdk mgSDK) UserProfile(token string) (User, errors.SDKError) {
 	url := fmt.Sprintf("%s/%s/profile", sdk.usersURL, usersEndpoint)
 
@@ -355,6 +399,15 @@ func (sdk mgSDK) DisableUser(id, token string) (User, errors.SDKError) {
 	return sdk.changeClientStatus(token, id, disableEndpoint)
 }
 
+func (sdk mgSDK) UnlockUser(id, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, unlockEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
+
 func (sdk mgSDK) changeClientStatus(token, id, status string) (User, errors.SDKError) {
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, status)
 
@@ -379,3 +432,43 @@ func (sdk mgSDK) DeleteUser(id, token string) errors.SDKError {
 	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
 	return sdkerr
 }
+
+func (sdk mgSDK) SendVerification(lt Login) errors.SDKError {
+	data, err := json.Marshal(lt)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/verification", sdk.usersURL, usersEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusCreated)
+	return sdkerr
+}
+
+func (sdk mgSDK) VerifyEmail(token string) (User, errors.SDKError) {
+	data, err := json.Marshal(struct {
+		Token string `json:"token"`
+	}{Token: token})
+	if err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/verify-email", sdk.usersURL, usersEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return User{}, sdkerr
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	return user, nil
+}
+
+func (sdk mgSDK) DeleteProfile(token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/profile", sdk.usersURL, usersEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
 	internalapi "github.com/absmach/magistrala/internal/api"
@@ -43,7 +44,7 @@ func setupUsers() (*httptest.Server, *umocks.Service) {
 	mux := chi.NewRouter()
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
-	api.MakeHandler(usvc, gsvc, mux, logger, "", passRegex, provider)
+	api.MakeHandler(usvc, gsvc, mux, logger, "", passRegex, nil, provider)
 
 	return httptest.NewServer(mux), usvc
 }
@@ -248,6 +249,89 @@ func TestCreateUser(t *testing.T) {
 	}
 }
 
+func TestCreateInvitedUser(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	createSdkUserReq := sdk.User{
+		Name:        user.Name,
+		Tags:        user.Tags,
+		Credentials: user.Credentials,
+		Metadata:    user.Metadata,
+		Status:      user.Status,
+	}
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc             string
+		token            string
+		createSdkUserReq sdk.User
+		svcReq           mgclients.Client
+		svcRes           mgclients.Client
+		svcErr           error
+		response         sdk.User
+		err              errors.SDKError
+	}{
+		{
+			desc:             "register invited user successfully",
+			token:            validToken,
+			createSdkUserReq: createSdkUserReq,
+			svcReq:           convertClient(createSdkUserReq),
+			svcRes:           convertClient(user),
+			svcErr:           nil,
+			response:         user,
+			err:              nil,
+		},
+		{
+			desc:             "register invited user with token that is not an invitation",
+			token:            validToken,
+			createSdkUserReq: createSdkUserReq,
+			svcReq:           convertClient(createSdkUserReq),
+			svcRes:           mgclients.Client{},
+			svcErr:           svcerr.ErrAuthorization,
+			response:         sdk.User{},
+			err:              errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:             "register invited user with empty token",
+			token:            "",
+			createSdkUserReq: createSdkUserReq,
+			svcReq:           convertClient(createSdkUserReq),
+			svcRes:           mgclients.Client{},
+			svcErr:           nil,
+			response:         sdk.User{},
+			err:              errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusUnauthorized),
+		},
+		{
+			desc:             "register empty invited user",
+			token:            validToken,
+			createSdkUserReq: sdk.User{},
+			svcReq:           mgclients.Client{},
+			svcRes:           mgclients.Client{},
+			svcErr:           nil,
+			response:         sdk.User{},
+			err:              errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingIdentity), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RegisterInvitedClient", mock.Anything, tc.token, tc.svcReq).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.CreateInvitedUser(tc.createSdkUserReq, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RegisterInvitedClient", mock.Anything, tc.token, tc.svcReq)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestListUsers(t *testing.T) {
 	ts, svc := setupUsers()
 	defer ts.Close()
@@ -757,6 +841,87 @@ func TestViewUser(t *testing.T) {
 	}
 }
 
+func TestUserByIdentity(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	domainID := generateUUID(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		identity string
+		svcRes   mgclients.Client
+		svcErr   error
+		response sdk.User
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view user by identity successfully",
+			token:    validToken,
+			identity: user.Credentials.Identity,
+			svcRes:   mgclients.Client{ID: user.ID, Name: user.Name},
+			svcErr:   nil,
+			response: sdk.User{ID: user.ID, Name: user.Name, Status: mgclients.EnabledStatus.String()},
+			err:      nil,
+		},
+		{
+			desc:     "view user by identity with invalid token",
+			token:    invalidToken,
+			identity: user.Credentials.Identity,
+			svcRes:   mgclients.Client{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "view user by identity as non-admin",
+			token:    validToken,
+			identity: user.Credentials.Identity,
+			svcRes:   mgclients.Client{},
+			svcErr:   svcerr.ErrAuthorization,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "view non-existing user by identity",
+			token:    validToken,
+			identity: "unknown@example.com",
+			svcRes:   mgclients.Client{},
+			svcErr:   svcerr.ErrNotFound,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:     "view user by empty identity",
+			token:    validToken,
+			identity: "",
+			svcRes:   mgclients.Client{},
+			svcErr:   nil,
+			response: sdk.User{},
+			err:      errors.NewSDKError(apiutil.ErrMissingIdentity),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewClientByIdentity", mock.Anything, tc.token, domainID, tc.identity).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.UserByIdentity(tc.identity, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewClientByIdentity", mock.Anything, tc.token, domainID, tc.identity)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestUserProfile(t *testing.T) {
 	ts, svc := setupUsers()
 	defer ts.Close()
@@ -2093,6 +2258,240 @@ func TestDeleteUser(t *testing.T) {
 	}
 }
 
+func TestDeleteProfile(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:   "delete profile successfully",
+			token:  validToken,
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:   "delete profile with invalid token",
+			token:  invalidToken,
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "delete profile with empty token",
+			token:  "",
+			svcErr: nil,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusUnauthorized),
+		},
+		{
+			desc:   "delete profile of service account",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DeleteProfile", mock.Anything, tc.token).Return(mgclients.Client{}, tc.svcErr)
+			err := mgsdk.DeleteProfile(tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DeleteProfile", mock.Anything, tc.token)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestSendVerification(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc   string
+		login  sdk.Login
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:   "send verification successfully",
+			login:  sdk.Login{Identity: user.Credentials.Identity, Secret: user.Credentials.Secret},
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:   "send verification with invalid credentials",
+			login:  sdk.Login{Identity: user.Credentials.Identity, Secret: "invalid"},
+			svcErr: svcerr.ErrLogin,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrLogin, http.StatusUnauthorized),
+		},
+		{
+			desc:   "send verification with empty identity",
+			login:  sdk.Login{Secret: user.Credentials.Secret},
+			svcErr: nil,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingIdentity), http.StatusBadRequest),
+		},
+		{
+			desc:   "send verification of verified email",
+			login:  sdk.Login{Identity: user.Credentials.Identity, Secret: user.Credentials.Secret},
+			svcErr: svcerr.ErrConflict,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+		{
+			desc:   "send verification too early after the previous one",
+			login:  sdk.Login{Identity: user.Credentials.Identity, Secret: user.Credentials.Secret},
+			svcErr: svcerr.ErrTooManyRequests,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrTooManyRequests, http.StatusTooManyRequests),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("SendVerification", mock.Anything, tc.login.Identity, tc.login.Secret, mock.Anything).Return(tc.svcErr)
+			err := mgsdk.SendVerification(tc.login)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "SendVerification", mock.Anything, tc.login.Identity, tc.login.Secret, mock.Anything)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	verifiedUser := user
+	verifiedUser.Credentials.Secret = ""
+	verifiedUser.VerifiedAt = time.Now().UTC().Round(time.Millisecond)
+
+	cases := []struct {
+		desc     string
+		token    string
+		svcRes   mgclients.Client
+		svcErr   error
+		response sdk.User
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify email successfully",
+			token:    validToken,
+			svcRes:   convertClient(verifiedUser),
+			svcErr:   nil,
+			response: verifiedUser,
+			err:      nil,
+		},
+		{
+			desc:     "verify email with invalid token",
+			token:    invalidToken,
+			svcRes:   mgclients.Client{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify email with empty token",
+			token:    "",
+			svcRes:   mgclients.Client{},
+			svcErr:   nil,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingVerificationToken), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("VerifyEmail", mock.Anything, tc.token).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.VerifyEmail(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "VerifyEmail", mock.Anything, tc.token)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	ts, svc := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:   "unlock user successfully",
+			token:  validToken,
+			userID: validID,
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:   "unlock user with invalid token",
+			token:  invalidToken,
+			userID: validID,
+			svcErr: svcerr.ErrAuthentication,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "unlock user with normal user token",
+			token:  validToken,
+			userID: validID,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:   "unlock user with empty id",
+			token:  validToken,
+			userID: "",
+			svcErr: nil,
+			err:    errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("UnlockClient", mock.Anything, tc.token, tc.userID).Return(tc.svcErr)
+			err := mgsdk.UnlockUser(tc.userID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "UnlockClient", mock.Anything, tc.token, tc.userID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestListUserGroups(t *testing.T) {
 	ts, svc := setupGroups()
 	defer ts.Close()
@@ -18,6 +18,36 @@ type SDK struct {
 	mock.Mock
 }
 
+// APIKeys provides a mock function with given fields: pm, token
+func (_m *SDK) APIKeys(pm sdk.PageMetadata, token string) (sdk.APIKeysPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for APIKeys")
+	}
+
+	var r0 sdk.APIKeysPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.APIKeysPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.APIKeysPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.APIKeysPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // AcceptInvitation provides a mock function with given fields: domainID, token
 func (_m *SDK) AcceptInvitation(domainID string, token string) error {
 	ret := _m.Called(domainID, token)
@@ -146,6 +176,26 @@ func (_m *SDK) AddUserToGroup(groupID string, req sdk.UsersRelationRequest, toke
 	return r0
 }
 
+// AssignRole provides a mock function with given fields: domainID, roleID, entity, entityID, userIDs, token
+func (_m *SDK) AssignRole(domainID string, roleID string, entity string, entityID string, userIDs []string, token string) errors.SDKError {
+	ret := _m.Called(domainID, roleID, entity, entityID, userIDs, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string, string, []string, string) errors.SDKError); ok {
+		r0 = rf(domainID, roleID, entity, entityID, userIDs, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // Bootstrap provides a mock function with given fields: externalID, externalKey
 func (_m *SDK) Bootstrap(externalID string, externalKey string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _m.Called(externalID, externalKey)
@@ -236,6 +286,36 @@ func (_m *SDK) Bootstraps(pm sdk.PageMetadata, token string) (sdk.BootstrapPage,
 	return r0, r1
 }
 
+// CancelReplay provides a mock function with given fields: id, token
+func (_m *SDK) CancelReplay(id string, token string) (sdk.Replay, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReplay")
+	}
+
+	var r0 sdk.Replay
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Replay, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Replay); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Replay)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Channel provides a mock function with given fields: id, token
 func (_m *SDK) Channel(id string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -516,6 +596,126 @@ func (_m *SDK) CreateGroup(group sdk.Group, token string) (sdk.Group, errors.SDK
 	return r0, r1
 }
 
+// CreateInvitedUser provides a mock function with given fields: user, token
+func (_m *SDK) CreateInvitedUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(user, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitedUser")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.User, string) (sdk.User, errors.SDKError)); ok {
+		return rf(user, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.User, string) sdk.User); ok {
+		r0 = rf(user, token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.User, string) errors.SDKError); ok {
+		r1 = rf(user, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateMFAToken provides a mock function with given fields: mfaToken, code
+func (_m *SDK) CreateMFAToken(mfaToken string, code string) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMFAToken")
+	}
+
+	var r0 sdk.Token
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Token, errors.SDKError)); ok {
+		return rf(mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Token); ok {
+		r0 = rf(mfaToken, code)
+	} else {
+		r0 = ret.Get(0).(sdk.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(mfaToken, code)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateRole provides a mock function with given fields: domainID, role, token
+func (_m *SDK) CreateRole(domainID string, role sdk.Role, token string) (sdk.Role, errors.SDKError) {
+	ret := _m.Called(domainID, role, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 sdk.Role
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.Role, string) (sdk.Role, errors.SDKError)); ok {
+		return rf(domainID, role, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.Role, string) sdk.Role); ok {
+		r0 = rf(domainID, role, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.Role, string) errors.SDKError); ok {
+		r1 = rf(domainID, role, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateServiceAccount provides a mock function with given fields: domainID, sa, token
+func (_m *SDK) CreateServiceAccount(domainID string, sa sdk.ServiceAccount, token string) (sdk.ServiceAccount, errors.SDKError) {
+	ret := _m.Called(domainID, sa, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 sdk.ServiceAccount
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.ServiceAccount, string) (sdk.ServiceAccount, errors.SDKError)); ok {
+		return rf(domainID, sa, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.ServiceAccount, string) sdk.ServiceAccount); ok {
+		r0 = rf(domainID, sa, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ServiceAccount)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.ServiceAccount, string) errors.SDKError); ok {
+		r1 = rf(domainID, sa, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CreateSubscription provides a mock function with given fields: topic, contact, token
 func (_m *SDK) CreateSubscription(topic string, contact string, token string) (string, errors.SDKError) {
 	ret := _m.Called(topic, contact, token)
@@ -688,6 +888,54 @@ func (_m *SDK) DeleteChannel(id string, token string) errors.SDKError {
 	return r0
 }
 
+// DeleteDomain provides a mock function with given fields: domainID, token
+func (_m *SDK) DeleteDomain(domainID string, token string) (sdk.DomainDeletion, errors.SDKError) {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomain")
+	}
+
+	var r0 sdk.DomainDeletion
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.DomainDeletion, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.DomainDeletion); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.DomainDeletion)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteEmailInvitation provides a mock function with given fields: email, domainID, token
+func (_m *SDK) DeleteEmailInvitation(email string, domainID string, token string) error {
+	ret := _m.Called(email, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(email, domainID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteGroup provides a mock function with given fields: id, token
 func (_m *SDK) DeleteGroup(id string, token string) errors.SDKError {
 	ret := _m.Called(id, token)
@@ -726,6 +974,46 @@ func (_m *SDK) DeleteInvitation(userID string, domainID string, token string) er
 	return r0
 }
 
+// DeleteProfile provides a mock function with given fields: token
+func (_m *SDK) DeleteProfile(token string) errors.SDKError {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProfile")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// DeleteServiceAccount provides a mock function with given fields: domainID, id, token
+func (_m *SDK) DeleteServiceAccount(domainID string, id string, token string) errors.SDKError {
+	ret := _m.Called(domainID, id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteServiceAccount")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(domainID, id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // DeleteSubscription provides a mock function with given fields: id, token
 func (_m *SDK) DeleteSubscription(id string, token string) errors.SDKError {
 	ret := _m.Called(id, token)
@@ -866,6 +1154,26 @@ func (_m *SDK) DisableGroup(id string, token string) (sdk.Group, errors.SDKError
 	return r0, r1
 }
 
+// DisableMFA provides a mock function with given fields: code, token
+func (_m *SDK) DisableMFA(code string, token string) errors.SDKError {
+	ret := _m.Called(code, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(code, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // DisableThing provides a mock function with given fields: id, token
 func (_m *SDK) DisableThing(id string, token string) (sdk.Thing, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -996,6 +1304,36 @@ func (_m *SDK) Domain(domainID string, token string) (sdk.Domain, errors.SDKErro
 	return r0, r1
 }
 
+// DomainDeletion provides a mock function with given fields: domainID, token
+func (_m *SDK) DomainDeletion(domainID string, token string) (sdk.DomainDeletion, errors.SDKError) {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DomainDeletion")
+	}
+
+	var r0 sdk.DomainDeletion
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.DomainDeletion, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.DomainDeletion); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.DomainDeletion)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // DomainPermissions provides a mock function with given fields: domainID, token
 func (_m *SDK) DomainPermissions(domainID string, token string) (sdk.Domain, errors.SDKError) {
 	ret := _m.Called(domainID, token)
@@ -1026,15 +1364,45 @@ func (_m *SDK) DomainPermissions(domainID string, token string) (sdk.Domain, err
 	return r0, r1
 }
 
-// Domains provides a mock function with given fields: pm, token
-func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
-	ret := _m.Called(pm, token)
+// DomainUsage provides a mock function with given fields: domainID, token
+func (_m *SDK) DomainUsage(domainID string, token string) (sdk.DomainUsage, errors.SDKError) {
+	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Domains")
+		panic("no return value specified for DomainUsage")
 	}
 
-	var r0 sdk.DomainsPage
+	var r0 sdk.DomainUsage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.DomainUsage, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.DomainUsage); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.DomainUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Domains provides a mock function with given fields: pm, token
+func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Domains")
+	}
+
+	var r0 sdk.DomainsPage
 	var r1 errors.SDKError
 	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.DomainsPage, errors.SDKError)); ok {
 		return rf(pm, token)
@@ -1056,6 +1424,34 @@ func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, erro
 	return r0, r1
 }
 
+// EmailInvitation provides a mock function with given fields: email, domainID, token
+func (_m *SDK) EmailInvitation(email string, domainID string, token string) (sdk.Invitation, error) {
+	ret := _m.Called(email, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailInvitation")
+	}
+
+	var r0 sdk.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Invitation, error)); ok {
+		return rf(email, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Invitation); ok {
+		r0 = rf(email, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(email, domainID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnableChannel provides a mock function with given fields: id, token
 func (_m *SDK) EnableChannel(id string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -1196,6 +1592,128 @@ func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
 	return r0, r1
 }
 
+// EnrollMFA provides a mock function with given fields: token
+func (_m *SDK) EnrollMFA(token string) (sdk.MFAEnrollment, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 sdk.MFAEnrollment
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.MFAEnrollment, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.MFAEnrollment); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.MFAEnrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ExplainPolicy provides a mock function with given fields: req, token
+func (_m *SDK) ExplainPolicy(req sdk.ExplainRequest, token string) (sdk.PolicyExplanation, errors.SDKError) {
+	ret := _m.Called(req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPolicy")
+	}
+
+	var r0 sdk.PolicyExplanation
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.ExplainRequest, string) (sdk.PolicyExplanation, errors.SDKError)); ok {
+		return rf(req, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.ExplainRequest, string) sdk.PolicyExplanation); ok {
+		r0 = rf(req, token)
+	} else {
+		r0 = ret.Get(0).(sdk.PolicyExplanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.ExplainRequest, string) errors.SDKError); ok {
+		r1 = rf(req, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ExportDomain provides a mock function with given fields: domainID, withSecrets, token
+func (_m *SDK) ExportDomain(domainID string, withSecrets bool, token string) (sdk.DomainArchive, errors.SDKError) {
+	ret := _m.Called(domainID, withSecrets, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDomain")
+	}
+
+	var r0 sdk.DomainArchive
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, bool, string) (sdk.DomainArchive, errors.SDKError)); ok {
+		return rf(domainID, withSecrets, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, string) sdk.DomainArchive); ok {
+		r0 = rf(domainID, withSecrets, token)
+	} else {
+		r0 = ret.Get(0).(sdk.DomainArchive)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool, string) errors.SDKError); ok {
+		r1 = rf(domainID, withSecrets, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ExportProfile provides a mock function with given fields: token
+func (_m *SDK) ExportProfile(token string) ([]byte, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportProfile")
+	}
+
+	var r0 []byte
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]byte, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Group provides a mock function with given fields: id, token
 func (_m *SDK) Group(id string, token string) (sdk.Group, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -1316,6 +1834,36 @@ func (_m *SDK) Health(service string) (sdk.HealthInfo, errors.SDKError) {
 	return r0, r1
 }
 
+// ImportDomain provides a mock function with given fields: domainID, archive, dryRun, token
+func (_m *SDK) ImportDomain(domainID string, archive sdk.DomainArchive, dryRun bool, token string) (sdk.ImportReport, errors.SDKError) {
+	ret := _m.Called(domainID, archive, dryRun, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportDomain")
+	}
+
+	var r0 sdk.ImportReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.DomainArchive, bool, string) (sdk.ImportReport, errors.SDKError)); ok {
+		return rf(domainID, archive, dryRun, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.DomainArchive, bool, string) sdk.ImportReport); ok {
+		r0 = rf(domainID, archive, dryRun, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.DomainArchive, bool, string) errors.SDKError); ok {
+		r1 = rf(domainID, archive, dryRun, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Invitation provides a mock function with given fields: userID, domainID, token
 func (_m *SDK) Invitation(userID string, domainID string, token string) (sdk.Invitation, error) {
 	ret := _m.Called(userID, domainID, token)
@@ -1372,6 +1920,36 @@ func (_m *SDK) Invitations(pm sdk.PageMetadata, token string) (sdk.InvitationPag
 	return r0, r1
 }
 
+// IssueAPIKey provides a mock function with given fields: key, token
+func (_m *SDK) IssueAPIKey(key sdk.APIKey, token string) (sdk.APIKey, errors.SDKError) {
+	ret := _m.Called(key, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueAPIKey")
+	}
+
+	var r0 sdk.APIKey
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.APIKey, string) (sdk.APIKey, errors.SDKError)); ok {
+		return rf(key, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.APIKey, string) sdk.APIKey); ok {
+		r0 = rf(key, token)
+	} else {
+		r0 = ret.Get(0).(sdk.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.APIKey, string) errors.SDKError); ok {
+		r1 = rf(key, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // IssueCert provides a mock function with given fields: thingID, validity, token
 func (_m *SDK) IssueCert(thingID string, validity string, token string) (sdk.Cert, errors.SDKError) {
 	ret := _m.Called(thingID, validity, token)
@@ -1402,6 +1980,36 @@ func (_m *SDK) IssueCert(thingID string, validity string, token string) (sdk.Cer
 	return r0, r1
 }
 
+// IssueServiceAccountKey provides a mock function with given fields: domainID, id, token
+func (_m *SDK) IssueServiceAccountKey(domainID string, id string, token string) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(domainID, id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueServiceAccountKey")
+	}
+
+	var r0 sdk.Token
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Token, errors.SDKError)); ok {
+		return rf(domainID, id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Token); ok {
+		r0 = rf(domainID, id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(domainID, id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Journal provides a mock function with given fields: entityType, entityID, pm, token
 func (_m *SDK) Journal(entityType string, entityID string, pm sdk.PageMetadata, token string) (sdk.JournalsPage, error) {
 	ret := _m.Called(entityType, entityID, pm, token)
@@ -1998,6 +2606,66 @@ func (_m *SDK) RemoveUserGroupFromChannel(channelID string, req sdk.UserGroupsRe
 	return r0
 }
 
+// Replay provides a mock function with given fields: id, token
+func (_m *SDK) Replay(id string, token string) (sdk.Replay, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 sdk.Replay
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Replay, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Replay); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Replay)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Replays provides a mock function with given fields: token
+func (_m *SDK) Replays(token string) (sdk.ReplaysPage, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replays")
+	}
+
+	var r0 sdk.ReplaysPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.ReplaysPage, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.ReplaysPage); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.ReplaysPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ResetPassword provides a mock function with given fields: password, confPass, token
 func (_m *SDK) ResetPassword(password string, confPass string, token string) errors.SDKError {
 	ret := _m.Called(password, confPass, token)
@@ -2038,6 +2706,26 @@ func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
 	return r0
 }
 
+// RevokeAPIKey provides a mock function with given fields: id, token
+func (_m *SDK) RevokeAPIKey(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // RevokeCert provides a mock function with given fields: thingID, token
 func (_m *SDK) RevokeCert(thingID string, token string) (time.Time, errors.SDKError) {
 	ret := _m.Called(thingID, token)
@@ -2068,6 +2756,68 @@ func (_m *SDK) RevokeCert(thingID string, token string) (time.Time, errors.SDKEr
 	return r0, r1
 }
 
+// RoleAssignments provides a mock function with given fields: domainID, roleID, token
+func (_m *SDK) RoleAssignments(domainID string, roleID string, token string) ([]sdk.RoleAssignment, errors.SDKError) {
+	ret := _m.Called(domainID, roleID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RoleAssignments")
+	}
+
+	var r0 []sdk.RoleAssignment
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) ([]sdk.RoleAssignment, errors.SDKError)); ok {
+		return rf(domainID, roleID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) []sdk.RoleAssignment); ok {
+		r0 = rf(domainID, roleID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(domainID, roleID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Roles provides a mock function with given fields: domainID, pm, token
+func (_m *SDK) Roles(domainID string, pm sdk.PageMetadata, token string) (sdk.RolesPage, errors.SDKError) {
+	ret := _m.Called(domainID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Roles")
+	}
+
+	var r0 sdk.RolesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.RolesPage, errors.SDKError)); ok {
+		return rf(domainID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.RolesPage); ok {
+		r0 = rf(domainID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RolesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SearchUsers provides a mock function with given fields: pm, token
 func (_m *SDK) SearchUsers(pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
 	ret := _m.Called(pm, token)
@@ -2136,6 +2886,86 @@ func (_m *SDK) SendMessage(chanID string, msg string, key string) errors.SDKErro
 	return r0
 }
 
+// SendVerification provides a mock function with given fields: lt
+func (_m *SDK) SendVerification(lt sdk.Login) errors.SDKError {
+	ret := _m.Called(lt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Login) errors.SDKError); ok {
+		r0 = rf(lt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// ServiceAccount provides a mock function with given fields: domainID, id, token
+func (_m *SDK) ServiceAccount(domainID string, id string, token string) (sdk.ServiceAccount, errors.SDKError) {
+	ret := _m.Called(domainID, id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServiceAccount")
+	}
+
+	var r0 sdk.ServiceAccount
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.ServiceAccount, errors.SDKError)); ok {
+		return rf(domainID, id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.ServiceAccount); ok {
+		r0 = rf(domainID, id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ServiceAccount)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(domainID, id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ServiceAccounts provides a mock function with given fields: domainID, pm, token
+func (_m *SDK) ServiceAccounts(domainID string, pm sdk.PageMetadata, token string) (sdk.ServiceAccountsPage, errors.SDKError) {
+	ret := _m.Called(domainID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServiceAccounts")
+	}
+
+	var r0 sdk.ServiceAccountsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ServiceAccountsPage, errors.SDKError)); ok {
+		return rf(domainID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ServiceAccountsPage); ok {
+		r0 = rf(domainID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ServiceAccountsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SetContentType provides a mock function with given fields: ct
 func (_m *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
 	ret := _m.Called(ct)
@@ -2176,6 +3006,64 @@ func (_m *SDK) ShareThing(thingID string, req sdk.UsersRelationRequest, token st
 	return r0
 }
 
+// SignUp provides a mock function with given fields: code, name, secret
+func (_m *SDK) SignUp(code string, name string, secret string) (sdk.Invitation, error) {
+	ret := _m.Called(code, name, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignUp")
+	}
+
+	var r0 sdk.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Invitation, error)); ok {
+		return rf(code, name, secret)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Invitation); ok {
+		r0 = rf(code, name, secret)
+	} else {
+		r0 = ret.Get(0).(sdk.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(code, name, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartReplay provides a mock function with given fields: replay, token
+func (_m *SDK) StartReplay(replay sdk.Replay, token string) (sdk.Replay, errors.SDKError) {
+	ret := _m.Called(replay, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartReplay")
+	}
+
+	var r0 sdk.Replay
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Replay, string) (sdk.Replay, errors.SDKError)); ok {
+		return rf(replay, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Replay, string) sdk.Replay); ok {
+		r0 = rf(replay, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Replay)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Replay, string) errors.SDKError); ok {
+		r1 = rf(replay, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Thing provides a mock function with given fields: id, token
 func (_m *SDK) Thing(id string, token string) (sdk.Thing, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -2296,6 +3184,26 @@ func (_m *SDK) ThingsByChannel(chanID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
+// UnlockUser provides a mock function with given fields: id, token
+func (_m *SDK) UnlockUser(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // UnshareThing provides a mock function with given fields: thingID, req, token
 func (_m *SDK) UnshareThing(thingID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
 	ret := _m.Called(thingID, req, token)
@@ -2746,6 +3654,36 @@ func (_m *SDK) User(id string, token string) (sdk.User, errors.SDKError) {
 	return r0, r1
 }
 
+// UserByIdentity provides a mock function with given fields: identity, domainID, token
+func (_m *SDK) UserByIdentity(identity string, domainID string, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(identity, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByIdentity")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.User, errors.SDKError)); ok {
+		return rf(identity, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.User); ok {
+		r0 = rf(identity, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(identity, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UserProfile provides a mock function with given fields: token
 func (_m *SDK) UserProfile(token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(token)
@@ -2806,6 +3744,68 @@ func (_m *SDK) Users(pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.S
 	return r0, r1
 }
 
+// VerifyEmail provides a mock function with given fields: token
+func (_m *SDK) VerifyEmail(token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.User, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.User); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: code, token
+func (_m *SDK) VerifyMFA(code string, token string) ([]string, errors.SDKError) {
+	ret := _m.Called(code, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 []string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) ([]string, errors.SDKError)); ok {
+		return rf(code, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(code, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(code, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ViewBootstrap provides a mock function with given fields: id, token
 func (_m *SDK) ViewBootstrap(id string, token string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -57,6 +57,7 @@ var errUserAccess = errors.New("user has no permission")
 func MakeHandler(svc readers.MessageRepository, auth magistrala.AuthzServiceClient, things magistrala.AuthzServiceClient, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 
 	mux := chi.NewRouter()
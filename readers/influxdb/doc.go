@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package influxdb contains repository implementations using InfluxDB as
+// the underlying database.
+package influxdb
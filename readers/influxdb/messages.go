@@ -0,0 +1,350 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/readers"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/query"
+)
+
+const (
+	// Measurement for SenML messages.
+	defMeasurement = "messages"
+	defInterval    = "1s"
+	sep            = "/"
+)
+
+var (
+	errInvalidAggregation = errors.New("invalid aggregation")
+	errInvalidInterval    = errors.New("invalid aggregation interval")
+
+	// Flux functions used for the supported aggregations.
+	aggregations = map[string]string{
+		"MAX":   "max",
+		"MIN":   "min",
+		"AVG":   "mean",
+		"SUM":   "sum",
+		"COUNT": "count",
+	}
+
+	// Columns that are not part of the JSON message payload.
+	jsonColumns = map[string]bool{
+		"_start":       true,
+		"_stop":        true,
+		"_time":        true,
+		"_measurement": true,
+		"result":       true,
+		"table":        true,
+		"channel":      true,
+		"subtopic":     true,
+		"publisher":    true,
+		"protocol":     true,
+		"created":      true,
+	}
+)
+
+var _ readers.MessageRepository = (*influxRepository)(nil)
+
+// RepoConfig contains the InfluxDB bucket and organization the messages are read from.
+type RepoConfig struct {
+	Bucket string
+	Org    string
+}
+
+type influxRepository struct {
+	cfg      RepoConfig
+	queryAPI api.QueryAPI
+}
+
+// New returns new InfluxDB reader.
+func New(client influxdb2.Client, cfg RepoConfig) readers.MessageRepository {
+	return &influxRepository{
+		cfg:      cfg,
+		queryAPI: client.QueryAPI(cfg.Org),
+	}
+}
+
+func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	format := defMeasurement
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	base := repo.fmtQuery(format, chanID, rpm)
+	if rpm.Aggregation != "" {
+		agg, err := fmtAggregation(rpm)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		base = fmt.Sprintf(`%s |> filter(fn: (r) => exists r.value) |> group() |> %s`, base, agg)
+	}
+
+	q := fmt.Sprintf(`%s |> group() |> sort(columns: ["_time"], desc: true) |> limit(n: %d, offset: %d)`, base, rpm.Limit, rpm.Offset)
+	// Pivoted rows always contain the measurement column, so counting it
+	// counts the messages.
+	totalQuery := fmt.Sprintf(`%s |> group() |> count(column: "_measurement")`, base)
+	if rpm.Aggregation != "" {
+		totalQuery = fmt.Sprintf(`%s |> group() |> count(column: "value")`, base)
+	}
+
+	ctx := context.Background()
+	res, err := repo.queryAPI.Query(ctx, q)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer res.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for res.Next() {
+		switch format {
+		case defMeasurement:
+			page.Messages = append(page.Messages, parseSenml(res.Record()))
+		default:
+			page.Messages = append(page.Messages, parseJSON(res.Record()))
+		}
+	}
+	if res.Err() != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, res.Err())
+	}
+
+	total, err := repo.count(ctx, totalQuery)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func (repo *influxRepository) count(ctx context.Context, q string) (uint64, error) {
+	res, err := repo.queryAPI.Query(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+
+	var total uint64
+	for res.Next() {
+		for _, v := range res.Record().Values() {
+			if n, ok := v.(int64); ok {
+				total += uint64(n)
+				break
+			}
+		}
+	}
+
+	return total, res.Err()
+}
+
+// fmtQuery returns the Flux query that selects and pivots the channel
+// messages matching the page metadata.
+func (repo *influxRepository) fmtQuery(measurement, chanID string, rpm readers.PageMetadata) string {
+	start := time.Unix(0, 0).UTC()
+	if rpm.From != 0 {
+		start = toTime(rpm.From)
+	}
+	stop := "now()"
+	if rpm.To != 0 {
+		stop = toTime(rpm.To).Format(time.RFC3339Nano)
+	}
+
+	tags := fmt.Sprintf(`r._measurement == "%s" and r.channel == "%s"`, escape(measurement), escape(chanID))
+	if rpm.Subtopic != "" {
+		tags = fmt.Sprintf(`%s and r.subtopic == "%s"`, tags, escape(rpm.Subtopic))
+	}
+	if rpm.Publisher != "" {
+		tags = fmt.Sprintf(`%s and r.publisher == "%s"`, tags, escape(rpm.Publisher))
+	}
+	if rpm.Name != "" {
+		tags = fmt.Sprintf(`%s and r.name == "%s"`, tags, escape(rpm.Name))
+	}
+
+	// The strings package is required by the string value comparators.
+	q := fmt.Sprintf(`import "strings"
+from(bucket: "%s") |> range(start: %s, stop: %s) |> filter(fn: (r) => %s) |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`,
+		escape(repo.cfg.Bucket), start.Format(time.RFC3339Nano), stop, tags)
+	if cond := fmtCondition(rpm); cond != "" {
+		q = fmt.Sprintf(`%s |> filter(fn: (r) => %s)`, q, cond)
+	}
+
+	return q
+}
+
+// fmtCondition returns the Flux predicate for the filters applied to the
+// message fields.
+func fmtCondition(rpm readers.PageMetadata) string {
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return ""
+	}
+	if err := json.Unmarshal(meta, &query); err != nil {
+		return ""
+	}
+
+	var conds []string
+	for name := range query {
+		switch name {
+		case "protocol":
+			conds = append(conds, fmt.Sprintf(`r.protocol == "%s"`, escape(rpm.Protocol)))
+		case "v":
+			comparator := fluxComparator(readers.ParseValueComparator(query))
+			conds = append(conds, fmt.Sprintf(`exists r.value and r.value %s %v`, comparator, floatLiteral(rpm.Value)))
+		case "vb":
+			conds = append(conds, fmt.Sprintf(`exists r.bool_value and r.bool_value == %t`, rpm.BoolValue))
+		case "vs":
+			vs := escape(rpm.StringValue)
+			switch readers.ParseValueComparator(query) {
+			case "=":
+				conds = append(conds, fmt.Sprintf(`exists r.string_value and r.string_value == "%s"`, vs))
+			case ">":
+				conds = append(conds, fmt.Sprintf(`exists r.string_value and strings.containsStr(v: r.string_value, substr: "%s") and r.string_value != "%s"`, vs, vs))
+			case ">=":
+				conds = append(conds, fmt.Sprintf(`exists r.string_value and strings.containsStr(v: r.string_value, substr: "%s")`, vs))
+			case "<=":
+				conds = append(conds, fmt.Sprintf(`exists r.string_value and strings.containsStr(v: "%s", substr: r.string_value)`, vs))
+			case "<":
+				conds = append(conds, fmt.Sprintf(`exists r.string_value and strings.containsStr(v: "%s", substr: r.string_value) and r.string_value != "%s"`, vs, vs))
+			}
+		case "vd":
+			comparator := fluxComparator(readers.ParseValueComparator(query))
+			conds = append(conds, fmt.Sprintf(`exists r.data_value and r.data_value %s "%s"`, comparator, escape(rpm.DataValue)))
+		}
+	}
+
+	return strings.Join(conds, " and ")
+}
+
+func fmtAggregation(rpm readers.PageMetadata) (string, error) {
+	fn, ok := aggregations[strings.ToUpper(rpm.Aggregation)]
+	if !ok {
+		return "", errInvalidAggregation
+	}
+	interval := rpm.Interval
+	if interval == "" {
+		interval = defInterval
+	}
+	every, err := time.ParseDuration(interval)
+	if err != nil || every <= 0 {
+		return "", errInvalidInterval
+	}
+
+	return fmt.Sprintf(`aggregateWindow(every: %dns, fn: %s, column: "value", createEmpty: false)`, every.Nanoseconds(), fn), nil
+}
+
+func parseSenml(rec *query.FluxRecord) senml.Message {
+	msg := senml.Message{
+		Channel:   stringValue(rec, "channel"),
+		Subtopic:  stringValue(rec, "subtopic"),
+		Publisher: stringValue(rec, "publisher"),
+		Protocol:  stringValue(rec, "protocol"),
+		Name:      stringValue(rec, "name"),
+		Unit:      stringValue(rec, "unit"),
+		Time:      float64(rec.Time().UnixNano()) / 1e9,
+	}
+	if v, ok := rec.ValueByKey("update_time").(float64); ok {
+		msg.UpdateTime = v
+	}
+	switch v := rec.ValueByKey("value").(type) {
+	case float64:
+		msg.Value = &v
+	case int64:
+		// COUNT aggregation returns integer values.
+		f := float64(v)
+		msg.Value = &f
+	}
+	if v, ok := rec.ValueByKey("bool_value").(bool); ok {
+		msg.BoolValue = &v
+	}
+	if v, ok := rec.ValueByKey("string_value").(string); ok {
+		msg.StringValue = &v
+	}
+	if v, ok := rec.ValueByKey("data_value").(string); ok {
+		msg.DataValue = &v
+	}
+	if v, ok := rec.ValueByKey("sum").(float64); ok {
+		msg.Sum = &v
+	}
+
+	return msg
+}
+
+func parseJSON(rec *query.FluxRecord) map[string]interface{} {
+	ret := map[string]interface{}{
+		"channel":   stringValue(rec, "channel"),
+		"created":   rec.ValueByKey("created"),
+		"subtopic":  stringValue(rec, "subtopic"),
+		"publisher": stringValue(rec, "publisher"),
+		"protocol":  stringValue(rec, "protocol"),
+	}
+
+	pld := map[string]interface{}{}
+	for k, v := range rec.Values() {
+		if jsonColumns[k] || v == nil {
+			continue
+		}
+		unflatten(pld, strings.Split(k, sep), v)
+	}
+	ret["payload"] = pld
+
+	return ret
+}
+
+// unflatten restores the nested payload structure flattened by the writer.
+func unflatten(m map[string]interface{}, keys []string, v interface{}) {
+	if len(keys) == 1 {
+		m[keys[0]] = v
+		return
+	}
+	nested, ok := m[keys[0]].(map[string]interface{})
+	if !ok {
+		nested = map[string]interface{}{}
+		m[keys[0]] = nested
+	}
+	unflatten(nested, keys[1:], v)
+}
+
+func stringValue(rec *query.FluxRecord, key string) string {
+	v, _ := rec.ValueByKey(key).(string)
+	return v
+}
+
+func fluxComparator(comparator string) string {
+	if comparator == "=" {
+		return "=="
+	}
+	return comparator
+}
+
+// floatLiteral formats the value so Flux always parses it as a float.
+func floatLiteral(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// escape escapes the value so it can be safely used in a Flux string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `${`, `\${`).Replace(s)
+}
+
+func toTime(secs float64) time.Time {
+	return time.Unix(0, int64(secs*1e9)).UTC()
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package influxdb_test contains tests for InfluxDB repository
+// implementations.
+package influxdb_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/influxdb"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	dbToken  = "test-token"
+	dbOrg    = "test-org"
+	dbBucket = "test-bucket"
+)
+
+var client influxdb2.Client
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	container, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "influxdb",
+		Tag:        "2.7-alpine",
+		Env: []string{
+			"DOCKER_INFLUXDB_INIT_MODE=setup",
+			"DOCKER_INFLUXDB_INIT_USERNAME=test",
+			"DOCKER_INFLUXDB_INIT_PASSWORD=testpassword",
+			"DOCKER_INFLUXDB_INIT_ORG=" + dbOrg,
+			"DOCKER_INFLUXDB_INIT_BUCKET=" + dbBucket,
+			"DOCKER_INFLUXDB_INIT_ADMIN_TOKEN=" + dbToken,
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	cfg := influxdb.Config{
+		Protocol: "http",
+		Host:     "localhost",
+		Port:     container.GetPort("8086/tcp"),
+		Bucket:   dbBucket,
+		Org:      dbOrg,
+		Token:    dbToken,
+		Timeout:  time.Second,
+	}
+
+	pool.MaxWait = 120 * time.Second
+	if err := pool.Retry(func() error {
+		client, err = influxdb.Connect(context.Background(), cfg)
+		return err
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	code := m.Run()
+
+	client.Close()
+	if err := pool.Purge(container); err != nil {
+		log.Fatalf("Could not purge container: %s", err)
+	}
+
+	os.Exit(code)
+}
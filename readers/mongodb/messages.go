@@ -0,0 +1,295 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/readers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// Collection for SenML messages.
+	defCollection = "messages"
+	defInterval   = "1s"
+)
+
+var (
+	errInvalidAggregation = errors.New("invalid aggregation")
+	errInvalidInterval    = errors.New("invalid aggregation interval")
+
+	// MongoDB accumulators used for the supported aggregations.
+	aggregations = map[string]interface{}{
+		"MAX":   bson.M{"$max": "$value"},
+		"MIN":   bson.M{"$min": "$value"},
+		"AVG":   bson.M{"$avg": "$value"},
+		"SUM":   bson.M{"$sum": "$value"},
+		"COUNT": bson.M{"$sum": 1},
+	}
+
+	comparators = map[string]string{
+		"=":  "$eq",
+		"<":  "$lt",
+		"<=": "$lte",
+		">":  "$gt",
+		">=": "$gte",
+	}
+)
+
+var _ readers.MessageRepository = (*mongoRepository)(nil)
+
+type mongoRepository struct {
+	db *mongo.Database
+}
+
+// New returns new MongoDB reader.
+func New(db *mongo.Database) readers.MessageRepository {
+	return mongoRepository{
+		db: db,
+	}
+}
+
+func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	order := "time"
+	format := defCollection
+
+	if rpm.Format != "" && rpm.Format != defCollection {
+		order = "created"
+		format = rpm.Format
+	}
+
+	ctx := context.Background()
+	col := repo.db.Collection(format)
+	filter := fmtCondition(chanID, order, rpm)
+
+	if rpm.Aggregation != "" {
+		return repo.aggregate(ctx, col, filter, rpm)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: order, Value: -1}}).
+		SetSkip(int64(rpm.Offset)).
+		SetLimit(int64(rpm.Limit))
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(ctx)
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for cursor.Next(ctx) {
+		switch format {
+		case defCollection:
+			var msg senml.Message
+			if err := cursor.Decode(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			page.Messages = append(page.Messages, msg)
+		default:
+			var doc bson.M
+			if err := cursor.Decode(&doc); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			delete(doc, "_id")
+			page.Messages = append(page.Messages, normalize(doc))
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	total, err := col.CountDocuments(ctx, filter)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = uint64(total)
+
+	return page, nil
+}
+
+// aggregate groups the SenML values into time buckets of the requested
+// interval and applies the aggregation to each bucket.
+func (repo mongoRepository) aggregate(ctx context.Context, col *mongo.Collection, filter bson.M, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	acc, ok := aggregations[strings.ToUpper(rpm.Aggregation)]
+	if !ok {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidAggregation)
+	}
+	interval := rpm.Interval
+	if interval == "" {
+		interval = defInterval
+	}
+	every, err := time.ParseDuration(interval)
+	if err != nil || every <= 0 {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidInterval)
+	}
+	secs := every.Seconds()
+
+	filter["value"] = bson.M{"$exists": true}
+	group := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "time", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"$floor": bson.M{"$divide": bson.A{"$time", secs}}},
+			"value":     acc,
+			"channel":   bson.M{"$first": "$channel"},
+			"publisher": bson.M{"$first": "$publisher"},
+			"protocol":  bson.M{"$first": "$protocol"},
+			"subtopic":  bson.M{"$first": "$subtopic"},
+			"name":      bson.M{"$first": "$name"},
+			"unit":      bson.M{"$first": "$unit"},
+		}}},
+	}
+
+	pipeline := append(mongo.Pipeline{}, group...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$addFields", Value: bson.M{"time": bson.M{"$multiply": bson.A{"$_id", secs}}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "time", Value: -1}}}},
+		bson.D{{Key: "$skip", Value: int64(rpm.Offset)}},
+		bson.D{{Key: "$limit", Value: int64(rpm.Limit)}},
+	)
+
+	cursor, err := col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(ctx)
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for cursor.Next(ctx) {
+		var msg senml.Message
+		if err := cursor.Decode(&msg); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+	if err := cursor.Err(); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	totalPipeline := append(group, bson.D{{Key: "$count", Value: "total"}})
+	cursor, err = col.Aggregate(ctx, totalPipeline)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(ctx)
+
+	var res struct {
+		Total int64 `bson:"total"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&res); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+	page.Total = uint64(res.Total)
+
+	return page, nil
+}
+
+func fmtCondition(chanID, timeField string, rpm readers.PageMetadata) bson.M {
+	filter := bson.M{
+		"channel": chanID,
+	}
+
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return filter
+	}
+	if err := json.Unmarshal(meta, &query); err != nil {
+		return filter
+	}
+
+	// JSON messages store the creation time in nanoseconds.
+	scale := 1.0
+	if timeField == "created" {
+		scale = 1e9
+	}
+	timeRange := bson.M{}
+
+	for name := range query {
+		switch name {
+		case
+			"subtopic",
+			"publisher",
+			"name",
+			"protocol":
+			filter[name] = query[name]
+		case "v":
+			comparator := comparators[readers.ParseValueComparator(query)]
+			filter["value"] = bson.M{comparator: rpm.Value}
+		case "vb":
+			filter["bool_value"] = rpm.BoolValue
+		case "vs":
+			vs := rpm.StringValue
+			switch readers.ParseValueComparator(query) {
+			case "=":
+				filter["string_value"] = vs
+			case ">":
+				filter["string_value"] = bson.M{"$regex": regexp.QuoteMeta(vs), "$ne": vs}
+			case ">=":
+				filter["string_value"] = bson.M{"$regex": regexp.QuoteMeta(vs)}
+			case "<=":
+				filter["$expr"] = bson.M{"$gte": bson.A{bson.M{"$indexOfCP": bson.A{vs, "$string_value"}}, 0}}
+			case "<":
+				filter["$expr"] = bson.M{"$gte": bson.A{bson.M{"$indexOfCP": bson.A{vs, "$string_value"}}, 0}}
+				filter["string_value"] = bson.M{"$ne": vs}
+			}
+		case "vd":
+			comparator := comparators[readers.ParseValueComparator(query)]
+			filter["data_value"] = bson.M{comparator: rpm.DataValue}
+		case "from":
+			timeRange["$gte"] = rpm.From * scale
+		case "to":
+			timeRange["$lt"] = rpm.To * scale
+		}
+	}
+	if len(timeRange) > 0 {
+		filter[timeField] = timeRange
+	}
+
+	return filter
+}
+
+// normalize converts the decoded BSON documents and arrays into plain
+// maps and slices.
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case bson.M:
+		ret := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			ret[k] = normalize(v)
+		}
+		return ret
+	case bson.D:
+		ret := make(map[string]interface{}, len(val))
+		for _, e := range val {
+			ret[e.Key] = normalize(e.Value)
+		}
+		return ret
+	case bson.A:
+		ret := make([]interface{}, len(val))
+		for i, v := range val {
+			ret[i] = normalize(v)
+		}
+		return ret
+	default:
+		return v
+	}
+}
@@ -0,0 +1,450 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mwriter "github.com/absmach/magistrala/consumers/writers/mongodb"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/readers"
+	mreader "github.com/absmach/magistrala/readers/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic    = "subtopic"
+	msgsNum     = 100
+	limit       = 10
+	valueFields = 5
+	mqttProt    = "mqtt"
+	httpProt    = "http"
+	msgName     = "temperature"
+	format      = "format"
+)
+
+var (
+	v   float64 = 5
+	vs          = "stringValue"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+)
+
+func TestReadSenml(t *testing.T) {
+	writer := mwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	pubID2 := testsutil.GenerateUUID(t)
+	wrongID := testsutil.GenerateUUID(t)
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		{
+			desc:   "read message page for existing channel",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		{
+			desc:   "read message page for non-existent channel",
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		{
+			desc:   "read message last page",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages[msgsNum-20 : msgsNum]),
+			},
+		},
+		{
+			desc:   "read message with subtopic",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Subtopic: subtopic,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		{
+			desc:   "read message with publisher",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		{
+			desc:   "read message with protocol",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		{
+			desc:   "read message with name",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Name:   msgName,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with value and lower-than-or-equal comparator",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with boolean value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with string value and greater-than comparator",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      0,
+				Limit:       limit,
+				StringValue: vs[1:],
+				Comparator:  readers.GreaterThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with data value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with from/to",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				From:   messages[5].Time,
+				To:     messages[0].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    5,
+				Messages: fromSenml(messages[1:6]),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadMessagesWithAggregation(t *testing.T) {
+	writer := mwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	messages := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Time:      now - float64(i),
+			Value:     &v,
+			Protocol:  mqttProt,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	cases := []struct {
+		desc     string
+		pageMeta readers.PageMetadata
+		err      error
+	}{
+		{
+			desc: "read messages with AVG aggregation",
+			pageMeta: readers.PageMetadata{
+				Limit:       msgsNum,
+				Aggregation: "AVG",
+				Interval:    "10s",
+				From:        now - msgsNum,
+				To:          now + 1,
+			},
+		},
+		{
+			desc: "read messages with COUNT aggregation",
+			pageMeta: readers.PageMetadata{
+				Limit:       msgsNum,
+				Aggregation: "COUNT",
+				Interval:    "1h",
+				From:        now - msgsNum,
+				To:          now + 1,
+			},
+		},
+		{
+			desc: "read messages with invalid interval",
+			pageMeta: readers.PageMetadata{
+				Limit:       msgsNum,
+				Aggregation: "MAX",
+				Interval:    "1 hour",
+				From:        now - msgsNum,
+				To:          now + 1,
+			},
+			err: readers.ErrReadMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := reader.ReadAll(chanID, tc.pageMeta)
+		if tc.err != nil {
+			assert.ErrorIs(t, err, tc.err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.NotEmpty(t, page.Messages, fmt.Sprintf("%s: expected non-empty result set", tc.desc))
+		assert.Equal(t, uint64(len(page.Messages)), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, len(page.Messages), page.Total))
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	writer := mwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	messages := json.Messages{
+		Format: format,
+	}
+	msgs := []map[string]interface{}{}
+	httpMsgs := []map[string]interface{}{}
+	now := time.Now().UnixNano()
+	for i := 0; i < msgsNum; i++ {
+		msg := json.Message{
+			Channel:   chanID,
+			Publisher: chanID,
+			Created:   now - int64(i),
+			Subtopic:  "subtopic/format/some_json",
+			Protocol:  mqttProt,
+			Payload: map[string]interface{}{
+				"field_1": "value",
+				"field_2": false,
+				"field_3": 12.344,
+				"field_4": map[string]interface{}{
+					"field_1": 42.0,
+				},
+			},
+		}
+		if i%2 == 0 {
+			msg.Protocol = httpProt
+		}
+		messages.Data = append(messages.Data, msg)
+		msgs = append(msgs, toMap(msg))
+		if i%2 == 0 {
+			httpMsgs = append(httpMsgs, toMap(msg))
+		}
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read message page for existing channel": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Format: format,
+				Offset: 0,
+				Limit:  limit,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs[:limit]),
+			},
+		},
+		"read message with protocol": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Format:   format,
+				Offset:   0,
+				Limit:    msgsNum,
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(httpMsgs)),
+				Messages: fromJSON(httpMsgs),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: got incorrect list of json Messages from ReadAll()", desc))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func fromSenml(msg []senml.Message) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func fromJSON(msg []map[string]interface{}) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func toMap(msg json.Message) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}(msg.Payload),
+	}
+}
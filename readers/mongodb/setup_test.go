@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mongodb_test contains tests for MongoDB repository
+// implementations.
+package mongodb_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	mgmongo "github.com/absmach/magistrala/pkg/mongo"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const testDB = "test"
+
+var db *mongo.Database
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	container, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "mongo",
+		Tag:        "7.0",
+		Env:        []string{"MONGO_INITDB_DATABASE=" + testDB},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	cfg := mgmongo.Config{
+		Host: "localhost",
+		Port: container.GetPort("27017/tcp"),
+		Name: testDB,
+	}
+
+	pool.MaxWait = 120 * time.Second
+	if err := pool.Retry(func() error {
+		db, err = mgmongo.Setup(context.Background(), cfg)
+		if err != nil {
+			return err
+		}
+		return db.Client().Ping(context.Background(), nil)
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	code := m.Run()
+
+	if err := db.Client().Disconnect(context.Background()); err != nil {
+		log.Fatalf("Could not disconnect from mongodb: %s", err)
+	}
+	if err := pool.Purge(container); err != nil {
+		log.Fatalf("Could not purge container: %s", err)
+	}
+
+	os.Exit(code)
+}
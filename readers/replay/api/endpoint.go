@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func startReplayEndpoint(svc replay.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(startReplayReq)
+		if err := req.validate(); err != nil {
+			return replayRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		job := replay.Job{
+			ChannelID: req.ChannelID,
+			Subtopic:  req.Subtopic,
+			Publisher: req.Publisher,
+			Format:    req.Format,
+			From:      req.From,
+			To:        req.To,
+			Rate:      req.Rate,
+		}
+		job, err := svc.StartReplay(ctx, req.token, job)
+		if err != nil {
+			return replayRes{}, err
+		}
+
+		return newReplayRes(job, true), nil
+	}
+}
+
+func viewReplayEndpoint(svc replay.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayReq)
+		if err := req.validate(); err != nil {
+			return replayRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		job, err := svc.ViewReplay(ctx, req.token, req.id)
+		if err != nil {
+			return replayRes{}, err
+		}
+
+		return newReplayRes(job, false), nil
+	}
+}
+
+func listReplaysEndpoint(svc replay.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReplaysReq)
+		if err := req.validate(); err != nil {
+			return listReplaysRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		jobs, err := svc.ListReplays(ctx, req.token)
+		if err != nil {
+			return listReplaysRes{}, err
+		}
+		res := listReplaysRes{
+			Total:   uint64(len(jobs)),
+			Replays: []replayRes{},
+		}
+		for _, job := range jobs {
+			res.Replays = append(res.Replays, newReplayRes(job, false))
+		}
+
+		return res, nil
+	}
+}
+
+func cancelReplayEndpoint(svc replay.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayReq)
+		if err := req.validate(); err != nil {
+			return replayRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		job, err := svc.CancelReplay(ctx, req.token, req.id)
+		if err != nil {
+			return replayRes{}, err
+		}
+
+		return newReplayRes(job, false), nil
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/readers/replay"
+	httpapi "github.com/absmach/magistrala/readers/replay/api"
+	"github.com/absmach/magistrala/readers/replay/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	token        = "token"
+	invalidToken = "invalid"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newServer() (*httptest.Server, *mocks.Service) {
+	logger := mglog.NewMock()
+	svc := new(mocks.Service)
+	mux := httpapi.MakeHandler(svc, logger, "replay", instanceID)
+	return httptest.NewServer(mux), svc
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func TestStartReplay(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	channelID := testsutil.GenerateUUID(t)
+	job := replay.Job{ChannelID: channelID, From: 100, To: 200, Rate: 10}
+	started := job
+	started.ID = testsutil.GenerateUUID(t)
+	started.Status = replay.RunningStatus
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+		svcErr      error
+	}{
+		{
+			desc:        "start replay successfully",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 100, "to": 200, "rate": 10}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/replays/%s", started.ID),
+		},
+		{
+			desc:        "start replay with invalid token",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 100, "to": 200, "rate": 10}),
+			contentType: contentType,
+			token:       invalidToken,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "start replay with empty token",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 100}),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "start replay without channel",
+			req:         toJSON(map[string]interface{}{"from": 100}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "start replay without from",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "start replay with invalid range",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 200, "to": 100}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "start replay with negative rate",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 100, "rate": -1}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "start replay with malformed body",
+			req:         "}",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "start replay without content type",
+			req:         toJSON(map[string]interface{}{"channel_id": channelID, "from": 100, "to": 200, "rate": 10}),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("StartReplay", mock.Anything, tc.token, job).Return(started, tc.svcErr)
+			req := testRequest{
+				client:      ss.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/replays", ss.URL),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestViewReplay(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	job := replay.Job{
+		ID:        testsutil.GenerateUUID(t),
+		ChannelID: testsutil.GenerateUUID(t),
+		Status:    replay.RunningStatus,
+		Progress:  replay.Progress{Total: 4, Scanned: 1, Published: 1},
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		status  int
+		percent float64
+		svcErr  error
+	}{
+		{
+			desc:    "view replay successfully",
+			token:   token,
+			status:  http.StatusOK,
+			percent: 25,
+		},
+		{
+			desc:   "view replay with invalid token",
+			token:  invalidToken,
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view non-existing replay",
+			token:  token,
+			status: http.StatusNotFound,
+			svcErr: errors.Wrap(svcerr.ErrNotFound, replay.ErrNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewReplay", mock.Anything, tc.token, job.ID).Return(job, tc.svcErr)
+			req := testRequest{
+				client: ss.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/replays/%s", ss.URL, job.ID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					replay.Job
+					Percent float64 `json:"percent"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, job.Progress, body.Progress, fmt.Sprintf("%s: expected progress %v got %v", tc.desc, job.Progress, body.Progress))
+				assert.Equal(t, tc.percent, body.Percent, fmt.Sprintf("%s: expected percent %f got %f", tc.desc, tc.percent, body.Percent))
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestListReplays(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	jobs := []replay.Job{{ID: testsutil.GenerateUUID(t), ChannelID: testsutil.GenerateUUID(t), Status: replay.CompletedStatus}}
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list replays successfully",
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list replays with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list replays without permission",
+			token:  token,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListReplays", mock.Anything, tc.token).Return(jobs, tc.svcErr)
+			req := testRequest{
+				client: ss.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/replays", ss.URL),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestCancelReplay(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+
+	job := replay.Job{ID: testsutil.GenerateUUID(t), Status: replay.CanceledStatus}
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "cancel replay successfully",
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "cancel finished replay",
+			token:  token,
+			status: http.StatusConflict,
+			svcErr: errors.Wrap(svcerr.ErrConflict, replay.ErrFinished),
+		},
+		{
+			desc:   "cancel non-existing replay",
+			token:  token,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("CancelReplay", mock.Anything, tc.token, job.ID).Return(job, tc.svcErr)
+			req := testRequest{
+				client: ss.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/replays/%s/cancel", ss.URL, job.ID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/readers/replay"
+)
+
+var _ replay.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    replay.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc replay.Service, logger *slog.Logger) replay.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// StartReplay logs the start_replay request. It logs replay ID, channel ID, time range and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) StartReplay(ctx context.Context, token string, job replay.Job) (started replay.Job, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("replay",
+				slog.String("id", started.ID),
+				slog.String("channel_id", job.ChannelID),
+				slog.Float64("from", job.From),
+				slog.Float64("to", started.To),
+				slog.Float64("rate", started.Rate),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Start replay failed", args...)
+			return
+		}
+		lm.logger.Info("Start replay completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StartReplay(ctx, token, job)
+}
+
+// ViewReplay logs the view_replay request. It logs replay ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewReplay(ctx context.Context, token, id string) (job replay.Job, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("replay_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View replay failed", args...)
+			return
+		}
+		lm.logger.Info("View replay completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewReplay(ctx, token, id)
+}
+
+// ListReplays logs the list_replays request. It logs the number of jobs and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListReplays(ctx context.Context, token string) (jobs []replay.Job, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("total", len(jobs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List replays failed", args...)
+			return
+		}
+		lm.logger.Info("List replays completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListReplays(ctx, token)
+}
+
+// CancelReplay logs the cancel_replay request. It logs replay ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CancelReplay(ctx context.Context, token, id string) (job replay.Job, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("replay_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Cancel replay failed", args...)
+			return
+		}
+		lm.logger.Info("Cancel replay completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CancelReplay(ctx, token, id)
+}
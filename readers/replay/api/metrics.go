@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ replay.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     replay.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc replay.Service, counter metrics.Counter, latency metrics.Histogram) replay.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// StartReplay instruments StartReplay method with metrics.
+func (ms *metricsMiddleware) StartReplay(ctx context.Context, token string, job replay.Job) (replay.Job, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "start_replay").Add(1)
+		ms.latency.With("method", "start_replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.StartReplay(ctx, token, job)
+}
+
+// ViewReplay instruments ViewReplay method with metrics.
+func (ms *metricsMiddleware) ViewReplay(ctx context.Context, token, id string) (replay.Job, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_replay").Add(1)
+		ms.latency.With("method", "view_replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewReplay(ctx, token, id)
+}
+
+// ListReplays instruments ListReplays method with metrics.
+func (ms *metricsMiddleware) ListReplays(ctx context.Context, token string) ([]replay.Job, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_replays").Add(1)
+		ms.latency.With("method", "list_replays").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListReplays(ctx, token)
+}
+
+// CancelReplay instruments CancelReplay method with metrics.
+func (ms *metricsMiddleware) CancelReplay(ctx context.Context, token, id string) (replay.Job, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "cancel_replay").Add(1)
+		ms.latency.With("method", "cancel_replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CancelReplay(ctx, token, id)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/absmach/magistrala/pkg/apiutil"
+
+type startReplayReq struct {
+	token     string
+	ChannelID string  `json:"channel_id"`
+	Subtopic  string  `json:"subtopic,omitempty"`
+	Publisher string  `json:"publisher,omitempty"`
+	Format    string  `json:"format,omitempty"`
+	From      float64 `json:"from"`
+	To        float64 `json:"to,omitempty"`
+	Rate      float64 `json:"rate,omitempty"`
+}
+
+func (req startReplayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.From <= 0 {
+		return apiutil.ErrMissingFrom
+	}
+	if req.To != 0 && req.To <= req.From {
+		return apiutil.ErrInvalidTimeRange
+	}
+	if req.Rate < 0 {
+		return apiutil.ErrInvalidRate
+	}
+
+	return nil
+}
+
+type replayReq struct {
+	token string
+	id    string
+}
+
+func (req replayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReplaysReq struct {
+	token string
+}
+
+func (req listReplaysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
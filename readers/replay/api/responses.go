@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/readers/replay"
+)
+
+var (
+	_ magistrala.Response = (*replayRes)(nil)
+	_ magistrala.Response = (*listReplaysRes)(nil)
+)
+
+type replayRes struct {
+	replay.Job
+	Percent float64 `json:"percent"`
+	created bool
+}
+
+func newReplayRes(job replay.Job, created bool) replayRes {
+	return replayRes{
+		Job:     job,
+		Percent: job.Progress.Percent(),
+		created: created,
+	}
+}
+
+func (res replayRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res replayRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/replays/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res replayRes) Empty() bool {
+	return false
+}
+
+type listReplaysRes struct {
+	Total   uint64      `json:"total"`
+	Replays []replayRes `json:"replays"`
+}
+
+func (res listReplaysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listReplaysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listReplaysRes) Empty() bool {
+	return false
+}
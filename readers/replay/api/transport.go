@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc replay.Service, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/replays", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			startReplayEndpoint(svc),
+			decodeStart,
+			api.EncodeResponse,
+			opts...,
+		), "start_replay").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listReplaysEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_replays").ServeHTTP)
+
+		r.Get("/{replayID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewReplayEndpoint(svc),
+			decodeReplay,
+			api.EncodeResponse,
+			opts...,
+		), "view_replay").ServeHTTP)
+
+		r.Post("/{replayID}/cancel", otelhttp.NewHandler(kithttp.NewServer(
+			cancelReplayEndpoint(svc),
+			decodeReplay,
+			api.EncodeResponse,
+			opts...,
+		), "cancel_replay").ServeHTTP)
+	})
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeStart(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := startReplayReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeReplay(_ context.Context, r *http.Request) (interface{}, error) {
+	req := replayReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "replayID"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	return listReplaysReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
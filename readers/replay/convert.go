@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"encoding/json"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+const (
+	replayValue = "true"
+	secToNano   = 1e9
+)
+
+// senmlRecord is the SenML JSON representation of the stored message.
+type senmlRecord struct {
+	Name        string   `json:"n,omitempty"`
+	Unit        string   `json:"u,omitempty"`
+	Time        float64  `json:"t,omitempty"`
+	UpdateTime  float64  `json:"ut,omitempty"`
+	Value       *float64 `json:"v,omitempty"`
+	StringValue *string  `json:"vs,omitempty"`
+	DataValue   *string  `json:"vd,omitempty"`
+	BoolValue   *bool    `json:"vb,omitempty"`
+	Sum         *float64 `json:"s,omitempty"`
+}
+
+// toMessage converts the stored message back to the broker message flagged
+// as replayed. It returns nil if the message is out of the job time range.
+func toMessage(job Job, stored interface{}) (*messaging.Message, error) {
+	msg := &messaging.Message{
+		Channel: job.ChannelID,
+		Headers: map[string]string{
+			messaging.ReplayHeader:   replayValue,
+			messaging.ReplayIDHeader: job.ID,
+		},
+	}
+
+	switch m := stored.(type) {
+	case senml.Message:
+		payload, err := json.Marshal([]senmlRecord{{
+			Name:        m.Name,
+			Unit:        m.Unit,
+			Time:        m.Time,
+			UpdateTime:  m.UpdateTime,
+			Value:       m.Value,
+			StringValue: m.StringValue,
+			DataValue:   m.DataValue,
+			BoolValue:   m.BoolValue,
+			Sum:         m.Sum,
+		}})
+		if err != nil {
+			return nil, errors.Wrap(ErrMessage, err)
+		}
+		msg.Subtopic = m.Subtopic
+		msg.Publisher = m.Publisher
+		msg.Protocol = m.Protocol
+		msg.Payload = payload
+		msg.Created = int64(m.Time * secToNano)
+	case map[string]interface{}:
+		created, ok := toInt64(m["created"])
+		if !ok {
+			return nil, ErrMessage
+		}
+		if created < int64(job.From*secToNano) || (job.To > 0 && created >= int64(job.To*secToNano)) {
+			return nil, nil
+		}
+		payload, err := json.Marshal(m["payload"])
+		if err != nil {
+			return nil, errors.Wrap(ErrMessage, err)
+		}
+		msg.Subtopic, _ = m["subtopic"].(string)
+		msg.Publisher, _ = m["publisher"].(string)
+		msg.Protocol, _ = m["protocol"].(string)
+		msg.Payload = payload
+		msg.Created = created
+	default:
+		return nil, ErrMessage
+	}
+
+	return msg, nil
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package replay contains the service that republishes messages stored by
+// the writers into the message broker, so that newly added consumers can
+// process the historical data.
+package replay
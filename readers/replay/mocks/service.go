@@ -0,0 +1,145 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	replay "github.com/absmach/magistrala/readers/replay"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// CancelReplay provides a mock function with given fields: ctx, token, id
+func (_m *Service) CancelReplay(ctx context.Context, token string, id string) (replay.Job, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReplay")
+	}
+
+	var r0 replay.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (replay.Job, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) replay.Job); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(replay.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReplays provides a mock function with given fields: ctx, token
+func (_m *Service) ListReplays(ctx context.Context, token string) ([]replay.Job, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplays")
+	}
+
+	var r0 []replay.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]replay.Job, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []replay.Job); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]replay.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartReplay provides a mock function with given fields: ctx, token, job
+func (_m *Service) StartReplay(ctx context.Context, token string, job replay.Job) (replay.Job, error) {
+	ret := _m.Called(ctx, token, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartReplay")
+	}
+
+	var r0 replay.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, replay.Job) (replay.Job, error)); ok {
+		return rf(ctx, token, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, replay.Job) replay.Job); ok {
+		r0 = rf(ctx, token, job)
+	} else {
+		r0 = ret.Get(0).(replay.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, replay.Job) error); ok {
+		r1 = rf(ctx, token, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewReplay provides a mock function with given fields: ctx, token, id
+func (_m *Service) ViewReplay(ctx context.Context, token string, id string) (replay.Job, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewReplay")
+	}
+
+	var r0 replay.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (replay.Job, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) replay.Job); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(replay.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
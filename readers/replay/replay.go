@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Status represents the state of a replay job.
+type Status string
+
+const (
+	// RunningStatus represents a replay job that is publishing messages.
+	RunningStatus Status = "running"
+	// CompletedStatus represents a replay job that published all messages.
+	CompletedStatus Status = "completed"
+	// FailedStatus represents a replay job that stopped due to an error.
+	FailedStatus Status = "failed"
+	// CanceledStatus represents a replay job that was canceled by the user.
+	CanceledStatus Status = "canceled"
+)
+
+// DefFormat is the message format used if the job doesn't specify one.
+const DefFormat = "messages"
+
+var (
+	// ErrNotFound indicates a non-existent replay job.
+	ErrNotFound = errors.New("replay job not found")
+
+	// ErrFinished indicates an attempt to cancel a job that is not running.
+	ErrFinished = errors.New("replay job is not running")
+
+	// ErrMessage indicates a stored message that can't be republished.
+	ErrMessage = errors.New("failed to convert stored message")
+)
+
+// Job represents a replay of the stored channel messages from the given
+// time range. From and To are Unix timestamps in seconds and Rate is the
+// maximum number of messages published per second.
+type Job struct {
+	ID         string     `json:"id"`
+	DomainID   string     `json:"domain_id"`
+	ChannelID  string     `json:"channel_id"`
+	Subtopic   string     `json:"subtopic,omitempty"`
+	Publisher  string     `json:"publisher,omitempty"`
+	Format     string     `json:"format"`
+	From       float64    `json:"from"`
+	To         float64    `json:"to"`
+	Rate       float64    `json:"rate,omitempty"`
+	Status     Status     `json:"status"`
+	Progress   Progress   `json:"progress"`
+	Error      string     `json:"error,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Progress reports how many stored messages have been scanned and
+// published by the replay job.
+type Progress struct {
+	Total     uint64 `json:"total"`
+	Scanned   uint64 `json:"scanned"`
+	Published uint64 `json:"published"`
+}
+
+// Percent returns the share of the scanned messages in the range [0, 100].
+func (p Progress) Percent() float64 {
+	if p.Total == 0 {
+		return 100
+	}
+
+	return float64(p.Scanned) * 100 / float64(p.Total)
+}
+
+// Config contains replay service parameters.
+type Config struct {
+	// BatchSize is the number of messages read from the repository at once.
+	BatchSize uint64
+	// MaxRate limits the rate of all jobs in messages per second. Zero
+	// means jobs are not limited unless they specify the rate themselves.
+	MaxRate float64
+	// Retention is the time finished jobs are kept after they finish.
+	Retention time.Duration
+}
+
+// Validate checks the configuration.
+func (c Config) Validate() error {
+	if c.BatchSize == 0 {
+		return errors.New("replay batch size must be greater than zero")
+	}
+	if c.MaxRate < 0 {
+		return errors.New("replay rate must not be negative")
+	}
+	if c.Retention <= 0 {
+		return errors.New("replay retention must be greater than zero")
+	}
+
+	return nil
+}
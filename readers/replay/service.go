@@ -0,0 +1,347 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/readers"
+	"golang.org/x/time/rate"
+)
+
+// Service specifies an API for replaying stored messages into the broker.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// StartReplay starts the replay job in the background and returns it.
+	StartReplay(ctx context.Context, token string, job Job) (Job, error)
+
+	// ViewReplay retrieves the replay job with its current progress.
+	ViewReplay(ctx context.Context, token, id string) (Job, error)
+
+	// ListReplays lists the replay jobs of the user's domain.
+	ListReplays(ctx context.Context, token string) ([]Job, error)
+
+	// CancelReplay stops the running replay job.
+	CancelReplay(ctx context.Context, token, id string) (Job, error)
+}
+
+var _ Service = (*service)(nil)
+
+type runningJob struct {
+	job    Job
+	cancel context.CancelFunc
+}
+
+type service struct {
+	ctx    context.Context
+	auth   grpcclient.AuthServiceClient
+	repo   readers.MessageRepository
+	pub    messaging.Publisher
+	idp    magistrala.IDProvider
+	cfg    Config
+	logger *slog.Logger
+	mu     sync.RWMutex
+	jobs   map[string]*runningJob
+}
+
+// New instantiates the replay service implementation. Running jobs are
+// canceled once the provided context is done, and finished jobs are
+// removed once the retention passes.
+func New(ctx context.Context, authClient grpcclient.AuthServiceClient, repo readers.MessageRepository, pub messaging.Publisher, idp magistrala.IDProvider, cfg Config, logger *slog.Logger) Service {
+	return &service{
+		ctx:    ctx,
+		auth:   authClient,
+		repo:   repo,
+		pub:    pub,
+		idp:    idp,
+		cfg:    cfg,
+		logger: logger,
+		jobs:   make(map[string]*runningJob),
+	}
+}
+
+func (svc *service) StartReplay(ctx context.Context, token string, job Job) (Job, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Job{}, err
+	}
+	if err := svc.authorize(ctx, token, auth.EditPermission, auth.GroupType, job.ChannelID); err != nil {
+		return Job{}, err
+	}
+
+	id, err := svc.idp.ID()
+	if err != nil {
+		return Job{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	job.ID = id
+	job.DomainID = user.GetDomainId()
+	job.CreatedBy = user.GetUserId()
+	job.Status = RunningStatus
+	job.StartedAt = time.Now()
+	if job.Format == "" {
+		job.Format = DefFormat
+	}
+	// Bound the range so that messages stored during the replay
+	// don't shift the pages being read.
+	if job.To == 0 {
+		job.To = float64(job.StartedAt.UnixNano()) / secToNano
+	}
+	if svc.cfg.MaxRate > 0 && (job.Rate == 0 || job.Rate > svc.cfg.MaxRate) {
+		job.Rate = svc.cfg.MaxRate
+	}
+
+	runCtx, cancel := context.WithCancel(svc.ctx)
+	svc.mu.Lock()
+	svc.evict()
+	svc.jobs[job.ID] = &runningJob{job: job, cancel: cancel}
+	svc.mu.Unlock()
+
+	go svc.run(runCtx, job)
+
+	return job, nil
+}
+
+func (svc *service) ViewReplay(ctx context.Context, token, id string) (Job, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Job{}, err
+	}
+
+	return svc.retrieve(ctx, token, user, id, auth.ViewPermission)
+}
+
+func (svc *service) ListReplays(ctx context.Context, token string) ([]Job, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.authorize(ctx, token, auth.AdminPermission, auth.DomainType, user.GetDomainId()); err != nil {
+		return nil, err
+	}
+
+	svc.mu.Lock()
+	svc.evict()
+	jobs := []Job{}
+	for _, rj := range svc.jobs {
+		if rj.job.DomainID == user.GetDomainId() {
+			jobs = append(jobs, rj.job)
+		}
+	}
+	svc.mu.Unlock()
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.After(jobs[j].StartedAt)
+	})
+
+	return jobs, nil
+}
+
+func (svc *service) CancelReplay(ctx context.Context, token, id string) (Job, error) {
+	user, err := svc.identify(ctx, token)
+	if err != nil {
+		return Job{}, err
+	}
+	if _, err := svc.retrieve(ctx, token, user, id, auth.EditPermission); err != nil {
+		return Job{}, err
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	rj, ok := svc.jobs[id]
+	if !ok {
+		return Job{}, errors.Wrap(svcerr.ErrNotFound, ErrNotFound)
+	}
+	if rj.job.Status != RunningStatus {
+		return Job{}, errors.Wrap(svcerr.ErrConflict, ErrFinished)
+	}
+	svc.finish(rj, CanceledStatus, nil)
+
+	return rj.job, nil
+}
+
+// run reads the stored messages page by page and publishes them in
+// chronological order. Readers return messages sorted from the newest,
+// so pages are read starting from the last one and iterated in reverse.
+func (svc *service) run(ctx context.Context, job Job) {
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if job.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(job.Rate), 1)
+	}
+	pm := svc.pageMetadata(job)
+
+	err := func() error {
+		pm.Limit = 1
+		page, err := svc.repo.ReadAll(job.ChannelID, pm)
+		if err != nil {
+			return err
+		}
+		svc.update(job.ID, func(p *Progress) { p.Total = page.Total })
+
+		for end := page.Total; end > 0; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			pm.Offset = 0
+			if end > svc.cfg.BatchSize {
+				pm.Offset = end - svc.cfg.BatchSize
+			}
+			pm.Limit = end - pm.Offset
+			end = pm.Offset
+
+			page, err := svc.repo.ReadAll(job.ChannelID, pm)
+			if err != nil {
+				return err
+			}
+			for i := len(page.Messages) - 1; i >= 0; i-- {
+				msg, err := toMessage(job, page.Messages[i])
+				if err != nil {
+					return err
+				}
+				published := uint64(0)
+				if msg != nil {
+					if err := limiter.Wait(ctx); err != nil {
+						return err
+					}
+					if err := svc.pub.Publish(ctx, messaging.ReplayTopic+"."+job.ChannelID, msg); err != nil {
+						return err
+					}
+					published = 1
+				}
+				svc.update(job.ID, func(p *Progress) {
+					p.Scanned++
+					p.Published += published
+				})
+			}
+		}
+
+		return nil
+	}()
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	// The canceled job may already be evicted.
+	rj, ok := svc.jobs[job.ID]
+	if !ok || rj.job.Status != RunningStatus {
+		return
+	}
+	switch {
+	case err == nil:
+		svc.finish(rj, CompletedStatus, nil)
+	case ctx.Err() != nil:
+		svc.finish(rj, CanceledStatus, nil)
+	default:
+		svc.logger.Warn("Replay failed", slog.String("id", job.ID), slog.Any("error", err))
+		svc.finish(rj, FailedStatus, err)
+	}
+}
+
+// pageMetadata builds the repository query for the job. The SenML tables
+// are filtered by time, while JSON messages are filtered by their creation
+// time during the conversion since JSON tables have no time column.
+func (svc *service) pageMetadata(job Job) readers.PageMetadata {
+	pm := readers.PageMetadata{
+		Format:    job.Format,
+		Subtopic:  job.Subtopic,
+		Publisher: job.Publisher,
+	}
+	if job.Format == DefFormat {
+		pm.From = job.From
+		pm.To = job.To
+	}
+
+	return pm
+}
+
+func (svc *service) update(id string, fn func(*Progress)) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if rj, ok := svc.jobs[id]; ok {
+		fn(&rj.job.Progress)
+	}
+}
+
+// finish must be called with the lock held.
+func (svc *service) finish(rj *runningJob, status Status, err error) {
+	now := time.Now()
+	rj.job.Status = status
+	rj.job.FinishedAt = &now
+	if err != nil {
+		rj.job.Error = err.Error()
+	}
+	rj.cancel()
+}
+
+// evict removes the jobs finished before the retention.
+// It must be called with the lock held.
+func (svc *service) evict() {
+	expired := time.Now().Add(-svc.cfg.Retention)
+	for id, rj := range svc.jobs {
+		if rj.job.FinishedAt != nil && rj.job.FinishedAt.Before(expired) {
+			delete(svc.jobs, id)
+		}
+	}
+}
+
+// retrieve returns the job of the user's domain if the user holds the
+// permission on the job channel.
+func (svc *service) retrieve(ctx context.Context, token string, user *magistrala.IdentityRes, id, permission string) (Job, error) {
+	svc.mu.Lock()
+	svc.evict()
+	rj, ok := svc.jobs[id]
+	var job Job
+	if ok {
+		job = rj.job
+	}
+	svc.mu.Unlock()
+	if !ok || job.DomainID != user.GetDomainId() {
+		return Job{}, errors.Wrap(svcerr.ErrNotFound, ErrNotFound)
+	}
+	if err := svc.authorize(ctx, token, permission, auth.GroupType, job.ChannelID); err != nil {
+		return Job{}, err
+	}
+
+	return job, nil
+}
+
+func (svc *service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
+	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if res.GetId() == "" || res.GetDomainId() == "" {
+		return nil, svcerr.ErrDomainAuthorization
+	}
+
+	return res, nil
+}
+
+func (svc *service) authorize(ctx context.Context, token, permission, objectType, object string) error {
+	req := &magistrala.AuthorizeReq{
+		SubjectType: auth.UserType,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
+		Permission:  permission,
+		ObjectType:  objectType,
+		Object:      object,
+	}
+	res, err := svc.auth.Authorize(ctx, req)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package replay_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/readers"
+	readersmocks "github.com/absmach/magistrala/readers/mocks"
+	"github.com/absmach/magistrala/readers/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "token"
+	invalidToken = "invalid"
+	batchSize    = 2
+)
+
+var (
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	user      = &magistrala.IdentityRes{Id: domainID + "_" + userID, UserId: userID, DomainId: domainID}
+)
+
+func newService() (replay.Service, *authmocks.AuthServiceClient, *readersmocks.MessageRepository, *pubmocks.PubSub) {
+	authClient := new(authmocks.AuthServiceClient)
+	repo := new(readersmocks.MessageRepository)
+	pub := new(pubmocks.PubSub)
+	cfg := replay.Config{BatchSize: batchSize, Retention: time.Hour}
+
+	return replay.New(context.Background(), authClient, repo, pub, uuid.NewMock(), cfg, mglog.NewMock()), authClient, repo, pub
+}
+
+// pageFn emulates the reader repository which returns messages sorted
+// from the newest one.
+func pageFn(stored []readers.Message) func(string, readers.PageMetadata) (readers.MessagesPage, error) {
+	return func(_ string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+		page := readers.MessagesPage{PageMetadata: pm, Total: uint64(len(stored))}
+		for i := pm.Offset; i < pm.Offset+pm.Limit && i < uint64(len(stored)); i++ {
+			page.Messages = append(page.Messages, stored[len(stored)-1-int(i)])
+		}
+		return page, nil
+	}
+}
+
+func waitStatus(t *testing.T, svc replay.Service, id string, status replay.Status) replay.Job {
+	var job replay.Job
+	assert.Eventually(t, func() bool {
+		var err error
+		job, err = svc.ViewReplay(context.Background(), validToken, id)
+		return err == nil && job.Status == status
+	}, time.Second, 10*time.Millisecond, fmt.Sprintf("expected replay status %s", status))
+
+	return job
+}
+
+func TestStartReplay(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	cases := []struct {
+		desc        string
+		token       string
+		job         replay.Job
+		identifyRes *magistrala.IdentityRes
+		identifyErr error
+		authRes     *magistrala.AuthorizeRes
+		err         error
+	}{
+		{
+			desc:        "start replay successfully",
+			token:       validToken,
+			job:         replay.Job{ChannelID: channelID, From: 1},
+			identifyRes: user,
+			authRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:         nil,
+		},
+		{
+			desc:        "start replay with invalid token",
+			token:       invalidToken,
+			job:         replay.Job{ChannelID: channelID, From: 1},
+			identifyRes: &magistrala.IdentityRes{},
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "start replay without domain",
+			token:       validToken,
+			job:         replay.Job{ChannelID: channelID, From: 1},
+			identifyRes: &magistrala.IdentityRes{Id: userID, UserId: userID},
+			err:         svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:        "start replay with unauthorized user",
+			token:       validToken,
+			job:         replay.Job{ChannelID: channelID, From: 1},
+			identifyRes: user,
+			authRes:     &magistrala.AuthorizeRes{Authorized: false},
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyRes, tc.identifyErr)
+			authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(tc.authRes, nil)
+			repoCall := repo.On("ReadAll", channelID, mock.Anything).Return(readers.MessagesPage{}, nil)
+			job, err := svc.StartReplay(context.Background(), tc.token, tc.job)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, job.ID, fmt.Sprintf("%s: expected non-empty ID", tc.desc))
+				assert.Equal(t, domainID, job.DomainID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, domainID, job.DomainID))
+				assert.Equal(t, replay.DefFormat, job.Format, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, replay.DefFormat, job.Format))
+				assert.Greater(t, job.To, job.From, fmt.Sprintf("%s: expected bounded time range", tc.desc))
+				waitStatus(t, svc, job.ID, replay.CompletedStatus)
+			}
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReplayMessages(t *testing.T) {
+	svc, authClient, repo, pub := newService()
+
+	v := 5.0
+	var stored []readers.Message
+	for i := 1; i <= 3; i++ {
+		stored = append(stored, senml.Message{Channel: channelID, Publisher: "thing", Protocol: "mqtt", Name: "temp", Time: float64(i), Value: &v})
+	}
+	jsonStored := []readers.Message{
+		map[string]interface{}{"channel": channelID, "subtopic": "data", "created": int64(1e9), "payload": map[string]interface{}{"n": 1.0}},
+		map[string]interface{}{"channel": channelID, "subtopic": "data", "created": int64(5e9), "payload": map[string]interface{}{"n": 2.0}},
+		map[string]interface{}{"channel": channelID, "subtopic": "data", "created": int64(9e9), "payload": map[string]interface{}{"n": 3.0}},
+	}
+
+	cases := []struct {
+		desc      string
+		job       replay.Job
+		stored    []readers.Message
+		created   []int64
+		published uint64
+	}{
+		{
+			desc:      "replay SenML messages in chronological order",
+			job:       replay.Job{ChannelID: channelID, From: 1, To: 10},
+			stored:    stored,
+			created:   []int64{1e9, 2e9, 3e9},
+			published: 3,
+		},
+		{
+			desc:      "replay JSON messages within the time range",
+			job:       replay.Job{ChannelID: channelID, Format: "data", From: 2, To: 6},
+			stored:    jsonStored,
+			created:   []int64{5e9},
+			published: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var mu sync.Mutex
+			var msgs []*messaging.Message
+			authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(user, nil)
+			authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			repoCall := repo.On("ReadAll", channelID, mock.Anything).Return(pageFn(tc.stored))
+			pubCall := pub.On("Publish", mock.Anything, messaging.ReplayTopic+"."+channelID, mock.Anything).Run(func(args mock.Arguments) {
+				mu.Lock()
+				defer mu.Unlock()
+				msgs = append(msgs, args.Get(2).(*messaging.Message))
+			}).Return(nil)
+
+			job, err := svc.StartReplay(context.Background(), validToken, tc.job)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+			job = waitStatus(t, svc, job.ID, replay.CompletedStatus)
+			assert.Equal(t, uint64(len(tc.stored)), job.Progress.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, len(tc.stored), job.Progress.Total))
+			assert.Equal(t, job.Progress.Total, job.Progress.Scanned, fmt.Sprintf("%s: expected all messages scanned", tc.desc))
+			assert.Equal(t, tc.published, job.Progress.Published, fmt.Sprintf("%s: expected %d published got %d\n", tc.desc, tc.published, job.Progress.Published))
+
+			mu.Lock()
+			assert.Len(t, msgs, len(tc.created), fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, len(tc.created), len(msgs)))
+			for i, msg := range msgs {
+				assert.Equal(t, tc.created[i], msg.GetCreated(), fmt.Sprintf("%s: expected created %d got %d\n", tc.desc, tc.created[i], msg.GetCreated()))
+				assert.True(t, messaging.IsReplay(msg), fmt.Sprintf("%s: expected message flagged as replayed", tc.desc))
+				assert.Equal(t, job.ID, msg.GetHeaders()[messaging.ReplayIDHeader], fmt.Sprintf("%s: expected replay ID header", tc.desc))
+			}
+			mu.Unlock()
+			authCall.Unset()
+			authCall1.Unset()
+			repoCall.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestCancelReplay(t *testing.T) {
+	svc, authClient, repo, pub := newService()
+
+	v := 1.0
+	stored := []readers.Message{
+		senml.Message{Channel: channelID, Time: 1, Value: &v},
+		senml.Message{Channel: channelID, Time: 2, Value: &v},
+	}
+
+	authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(user, nil)
+	authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	repoCall := repo.On("ReadAll", channelID, mock.Anything).Return(pageFn(stored))
+	pubCall := pub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	defer func() {
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+		pubCall.Unset()
+	}()
+
+	// The second message waits for the rate limiter, so the job keeps running.
+	job, err := svc.StartReplay(context.Background(), validToken, replay.Job{ChannelID: channelID, From: 1, Rate: 0.001})
+	assert.Nil(t, err, fmt.Sprintf("start replay: expected no error got %s\n", err))
+	assert.Eventually(t, func() bool {
+		job, err := svc.ViewReplay(context.Background(), validToken, job.ID)
+		return err == nil && job.Progress.Published == 1
+	}, time.Second, 10*time.Millisecond, "expected first message to be published")
+
+	cases := []struct {
+		desc   string
+		id     string
+		status replay.Status
+		err    error
+	}{
+		{
+			desc:   "cancel running replay",
+			id:     job.ID,
+			status: replay.CanceledStatus,
+			err:    nil,
+		},
+		{
+			desc: "cancel finished replay",
+			id:   job.ID,
+			err:  svcerr.ErrConflict,
+		},
+		{
+			desc: "cancel non-existing replay",
+			id:   testsutil.GenerateUUID(t),
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := svc.CancelReplay(context.Background(), validToken, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.status, res.Status))
+				assert.NotNil(t, res.FinishedAt, fmt.Sprintf("%s: expected finish time", tc.desc))
+			}
+		})
+	}
+}
+
+func TestListReplays(t *testing.T) {
+	svc, authClient, repo, _ := newService()
+
+	authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(user, nil)
+	authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	repoCall := repo.On("ReadAll", channelID, mock.Anything).Return(readers.MessagesPage{}, nil)
+	job, err := svc.StartReplay(context.Background(), validToken, replay.Job{ChannelID: channelID, From: 1})
+	assert.Nil(t, err, fmt.Sprintf("start replay: expected no error got %s\n", err))
+	waitStatus(t, svc, job.ID, replay.CompletedStatus)
+	authCall.Unset()
+	authCall1.Unset()
+	repoCall.Unset()
+
+	cases := []struct {
+		desc     string
+		identify *magistrala.IdentityRes
+		authRes  *magistrala.AuthorizeRes
+		total    int
+		err      error
+	}{
+		{
+			desc:     "list replays as domain admin",
+			identify: user,
+			authRes:  &magistrala.AuthorizeRes{Authorized: true},
+			total:    1,
+			err:      nil,
+		},
+		{
+			desc:     "list replays of another domain",
+			identify: &magistrala.IdentityRes{Id: userID, UserId: userID, DomainId: testsutil.GenerateUUID(t)},
+			authRes:  &magistrala.AuthorizeRes{Authorized: true},
+			total:    0,
+			err:      nil,
+		},
+		{
+			desc:     "list replays without domain admin permission",
+			identify: user,
+			authRes:  &magistrala.AuthorizeRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(tc.identify, nil)
+			authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(tc.authRes, nil)
+			jobs, err := svc.ListReplays(context.Background(), validToken)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Len(t, jobs, tc.total, fmt.Sprintf("%s: expected %d jobs got %d\n", tc.desc, tc.total, len(jobs)))
+				if tc.total > 0 {
+					assert.Equal(t, job.ID, jobs[0].ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, job.ID, jobs[0].ID))
+				}
+			}
+			authCall.Unset()
+			authCall1.Unset()
+		})
+	}
+}
+
+func TestReplayRetention(t *testing.T) {
+	authClient := new(authmocks.AuthServiceClient)
+	repo := new(readersmocks.MessageRepository)
+	pub := new(pubmocks.PubSub)
+	cfg := replay.Config{BatchSize: batchSize, Retention: 50 * time.Millisecond}
+	svc := replay.New(context.Background(), authClient, repo, pub, uuid.NewMock(), cfg, mglog.NewMock())
+
+	authCall := authClient.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(user, nil)
+	authCall1 := authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	repoCall := repo.On("ReadAll", channelID, mock.Anything).Return(readers.MessagesPage{}, nil)
+	defer func() {
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}()
+
+	job, err := svc.StartReplay(context.Background(), validToken, replay.Job{ChannelID: channelID, From: 1})
+	assert.Nil(t, err, fmt.Sprintf("start replay: expected no error got %s\n", err))
+	waitStatus(t, svc, job.ID, replay.CompletedStatus)
+
+	assert.Eventually(t, func() bool {
+		_, err := svc.ViewReplay(context.Background(), validToken, job.ID)
+		return errors.Contains(err, svcerr.ErrNotFound)
+	}, time.Second, 10*time.Millisecond, "expected finished replay to be removed after the retention")
+	jobs, err := svc.ListReplays(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list replays: expected no error got %s\n", err))
+	assert.Empty(t, jobs, "expected no replays after the retention")
+}
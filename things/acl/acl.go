@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	singleLevelWildcard = "+"
+	multiLevelWildcard  = "#"
+)
+
+// ErrMalformedTopicPattern indicates the subtopic pattern of the ACL is malformed.
+var ErrMalformedTopicPattern = errors.New("malformed subtopic pattern")
+
+// ACL restricts the subtopics of the channel the connected thing is allowed
+// to publish and subscribe to. The patterns use the MQTT topic syntax, where
+// `+` matches a single level and `#` matches any number of the trailing levels,
+// e.g. `sensors/#`. Once the ACL is set, the permission without patterns is
+// denied, so the thing is publish-only if it has no subscribe patterns.
+type ACL struct {
+	ThingID   string    `json:"thing_id"`
+	ChannelID string    `json:"channel_id"`
+	Publish   []string  `json:"publish"`
+	Subscribe []string  `json:"subscribe"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+// Repository specifies the ACLs persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save creates or replaces the ACL of the thing connection to the channel.
+	Save(ctx context.Context, acl ACL) (ACL, error)
+
+	// Retrieve returns the ACL of the thing connection to the channel.
+	Retrieve(ctx context.Context, thingID, channelID string) (ACL, error)
+
+	// Remove removes the ACL of the thing connection to the channel.
+	Remove(ctx context.Context, thingID, channelID string) error
+}
+
+// Validate returns an error if any of the ACL patterns is malformed.
+func (acl ACL) Validate() error {
+	for _, p := range append(append([]string{}, acl.Publish...), acl.Subscribe...) {
+		levels := topicLevels(p)
+		if len(levels) == 0 {
+			return ErrMalformedTopicPattern
+		}
+		for i, l := range levels {
+			switch {
+			case l == multiLevelWildcard && i != len(levels)-1:
+				return ErrMalformedTopicPattern
+			case len(l) > 1 && strings.ContainsAny(l, "+#*>"):
+				return ErrMalformedTopicPattern
+			}
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether the ACL allows the permission on the subtopic. The
+// subtopic may contain the wildcards of the subscription, and is allowed only
+// if all the subtopics it matches are allowed.
+func (acl ACL) Allows(permission, subtopic string) bool {
+	var patterns []string
+	switch permission {
+	case auth.PublishPermission:
+		patterns = acl.Publish
+	case auth.SubscribePermission:
+		patterns = acl.Subscribe
+	default:
+		return true
+	}
+	topic := topicLevels(subtopic)
+	for _, p := range patterns {
+		if matchTopic(topicLevels(p), topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchTopic reports whether the topic levels match the pattern levels. As
+// in MQTT, the multi-level wildcard matches the parent level as well, so
+// `sensors/#` matches `sensors` too.
+func matchTopic(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == multiLevelWildcard {
+			return true
+		}
+		if i >= len(topic) {
+			return false
+		}
+		switch p {
+		case singleLevelWildcard:
+			if topic[i] == multiLevelWildcard {
+				return false
+			}
+		default:
+			if p != topic[i] {
+				return false
+			}
+		}
+	}
+
+	return len(pattern) == len(topic)
+}
+
+// topicLevels splits the subtopic into the levels. Both the MQTT subtopic
+// and the subtopic of the message broker are accepted, so the wildcards of
+// the message broker are replaced with the MQTT ones.
+func topicLevels(subtopic string) []string {
+	subtopic = strings.Trim(subtopic, "/.")
+	if subtopic == "" {
+		return nil
+	}
+	levels := strings.FieldsFunc(subtopic, func(r rune) bool {
+		return r == '/' || r == '.'
+	})
+	for i, l := range levels {
+		switch l {
+		case "*":
+			levels[i] = singleLevelWildcard
+		case ">":
+			levels[i] = multiLevelWildcard
+		}
+	}
+
+	return levels
+}
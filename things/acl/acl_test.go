@@ -0,0 +1,209 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package acl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/things/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+const thingID = "6e5e10b3-d4df-4758-b426-4929d55ad740"
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc    string
+		publish []string
+		err     error
+	}{
+		{
+			desc:    "validate subtopic",
+			publish: []string{"sensors/temperature"},
+		},
+		{
+			desc:    "validate subtopic with wildcards",
+			publish: []string{"sensors/+/temperature", "commands/#"},
+		},
+		{
+			desc:    "validate message broker subtopic",
+			publish: []string{"sensors.*.temperature", "commands.>"},
+		},
+		{
+			desc: "validate without patterns",
+		},
+		{
+			desc:    "validate empty subtopic",
+			publish: []string{""},
+			err:     acl.ErrMalformedTopicPattern,
+		},
+		{
+			desc:    "validate multi-level wildcard followed by a level",
+			publish: []string{"sensors/#/temperature"},
+			err:     acl.ErrMalformedTopicPattern,
+		},
+		{
+			desc:    "validate wildcard within a level",
+			publish: []string{"sensors/temp+"},
+			err:     acl.ErrMalformedTopicPattern,
+		},
+	}
+
+	for _, tc := range cases {
+		err := acl.ACL{Publish: tc.publish}.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		err = acl.ACL{Subscribe: tc.publish}.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestAllows(t *testing.T) {
+	connACL := acl.ACL{
+		Publish:   []string{"sensors/#", "status"},
+		Subscribe: []string{"commands/" + thingID, "config/+/value"},
+	}
+
+	cases := []struct {
+		desc       string
+		acl        acl.ACL
+		permission string
+		subtopic   string
+		allowed    bool
+	}{
+		{
+			desc:       "publish to subtopic matching multi-level wildcard",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "sensors.room1.temperature",
+			allowed:    true,
+		},
+		{
+			desc:       "publish to parent of multi-level wildcard",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "sensors",
+			allowed:    true,
+		},
+		{
+			desc:       "publish to exact subtopic",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "status",
+			allowed:    true,
+		},
+		{
+			desc:       "publish to not allowed subtopic",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "commands." + thingID,
+			allowed:    false,
+		},
+		{
+			desc:       "publish to channel without subtopic",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "",
+			allowed:    false,
+		},
+		{
+			desc:       "subscribe to exact subtopic",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "commands/" + thingID,
+			allowed:    true,
+		},
+		{
+			desc:       "subscribe to subtopic of another thing",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "commands.other",
+			allowed:    false,
+		},
+		{
+			desc:       "subscribe to subtopic matching single-level wildcard",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "config.mode.value",
+			allowed:    true,
+		},
+		{
+			desc:       "subscribe with single-level wildcard covered by pattern",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "config/+/value",
+			allowed:    true,
+		},
+		{
+			desc:       "subscribe with multi-level wildcard not covered by pattern",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "config.>",
+			allowed:    false,
+		},
+		{
+			desc:       "subscribe to subtopic with more levels than pattern",
+			acl:        connACL,
+			permission: auth.SubscribePermission,
+			subtopic:   "config.mode.value.raw",
+			allowed:    false,
+		},
+		{
+			desc:       "subscribe without subscribe patterns",
+			acl:        acl.ACL{Publish: []string{"#"}},
+			permission: auth.SubscribePermission,
+			subtopic:   "sensors",
+			allowed:    false,
+		},
+		{
+			desc:       "publish to channel with multi-level wildcard pattern",
+			acl:        acl.ACL{Publish: []string{"#"}},
+			permission: auth.PublishPermission,
+			subtopic:   "",
+			allowed:    true,
+		},
+		{
+			desc:       "subscribe to parent of multi-level wildcard",
+			acl:        acl.ACL{Subscribe: []string{"sensors/#"}},
+			permission: auth.SubscribePermission,
+			subtopic:   "sensors",
+			allowed:    true,
+		},
+		{
+			desc:       "subscribe with multi-level wildcard covered by pattern",
+			acl:        acl.ACL{Subscribe: []string{"sensors/#"}},
+			permission: auth.SubscribePermission,
+			subtopic:   "sensors.>",
+			allowed:    true,
+		},
+		{
+			desc:       "publish to parent of broker multi-level wildcard",
+			acl:        acl.ACL{Publish: []string{"sensors.>"}},
+			permission: auth.PublishPermission,
+			subtopic:   "sensors",
+			allowed:    true,
+		},
+		{
+			desc:       "publish to subtopic prefixed with multi-level wildcard parent",
+			acl:        connACL,
+			permission: auth.PublishPermission,
+			subtopic:   "sensorsroom1",
+			allowed:    false,
+		},
+		{
+			desc:       "other permission",
+			acl:        acl.ACL{},
+			permission: auth.ViewPermission,
+			subtopic:   "sensors",
+			allowed:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := tc.acl.Allows(tc.permission, tc.subtopic)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.allowed, allowed))
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package acl contains the subtopic access control lists of the things
+// connected to the channels.
+package acl
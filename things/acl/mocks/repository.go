@@ -0,0 +1,106 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	acl "github.com/absmach/magistrala/things/acl"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Remove provides a mock function with given fields: ctx, thingID, channelID
+func (_m *Repository) Remove(ctx context.Context, thingID string, channelID string) error {
+	ret := _m.Called(ctx, thingID, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, thingID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Retrieve provides a mock function with given fields: ctx, thingID, channelID
+func (_m *Repository) Retrieve(ctx context.Context, thingID string, channelID string) (acl.ACL, error) {
+	ret := _m.Called(ctx, thingID, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 acl.ACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (acl.ACL, error)); ok {
+		return rf(ctx, thingID, channelID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) acl.ACL); ok {
+		r0 = rf(ctx, thingID, channelID)
+	} else {
+		r0 = ret.Get(0).(acl.ACL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, thingID, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *Repository) Save(ctx context.Context, _a1 acl.ACL) (acl.ACL, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 acl.ACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, acl.ACL) (acl.ACL, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, acl.ACL) acl.ACL); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(acl.ACL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, acl.ACL) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -72,6 +72,7 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		Permission:  req.GetPermission(),
 		ObjectType:  req.GetObjectType(),
 		Object:      req.GetObject(),
+		Subtopic:    req.GetSubtopic(),
 	}, nil
 }
 
@@ -90,6 +91,8 @@ func decodeError(err error) error {
 			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
 		case codes.AlreadyExists:
 			return errors.Wrap(svcerr.ErrConflict, errors.New(st.Message()))
+		case codes.ResourceExhausted:
+			return errors.Wrap(svcerr.ErrQuotaExceeded, errors.New(st.Message()))
 		case codes.OK:
 			if msg := st.Message(); msg != "" {
 				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
@@ -72,6 +72,8 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, svcerr.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, svcerr.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
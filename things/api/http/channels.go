@@ -16,14 +16,16 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
+func groupsHandler(svc groups.Service, tsvc things.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 	r.Route("/channels", func(r chi.Router) {
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
@@ -126,11 +128,32 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		), "connect_channel_thing").ServeHTTP)
 
 		r.Post("/{groupID}/things/{thingID}/disconnect", otelhttp.NewHandler(kithttp.NewServer(
-			disconnectChannelThingEndpoint(svc),
+			disconnectChannelThingEndpoint(svc, tsvc),
 			decodeDisconnectChannelThingRequest,
 			api.EncodeResponse,
 			opts...,
 		), "disconnect_channel_thing").ServeHTTP)
+
+		r.Put("/{groupID}/things/{thingID}/acl", otelhttp.NewHandler(kithttp.NewServer(
+			setConnectionACLEndpoint(tsvc),
+			decodeSetConnectionACLRequest,
+			api.EncodeResponse,
+			opts...,
+		), "set_connection_acl").ServeHTTP)
+
+		r.Get("/{groupID}/things/{thingID}/acl", otelhttp.NewHandler(kithttp.NewServer(
+			viewConnectionACLEndpoint(tsvc),
+			decodeConnectionACLRequest,
+			api.EncodeResponse,
+			opts...,
+		), "view_connection_acl").ServeHTTP)
+
+		r.Delete("/{groupID}/things/{thingID}/acl", otelhttp.NewHandler(kithttp.NewServer(
+			removeConnectionACLEndpoint(tsvc),
+			decodeConnectionACLRequest,
+			api.EncodeResponse,
+			opts...,
+		), "remove_connection_acl").ServeHTTP)
 	})
 
 	// Ideal location: things service,  things endpoint
@@ -178,7 +201,7 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 
 	// Disconnect channel and thing
 	r.Post("/disconnect", otelhttp.NewHandler(kithttp.NewServer(
-		disconnectEndpoint(svc),
+		disconnectEndpoint(svc, tsvc),
 		decodeDisconnectRequest,
 		api.EncodeResponse,
 		opts...,
@@ -300,3 +323,30 @@ func decodeDisconnectRequest(_ context.Context, r *http.Request) (interface{}, e
 
 	return req, nil
 }
+
+func decodeSetConnectionACLRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := setConnectionACLRequest{
+		token:     apiutil.ExtractBearerToken(r),
+		thingID:   chi.URLParam(r, "thingID"),
+		channelID: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeConnectionACLRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := connectionACLRequest{
+		token:     apiutil.ExtractBearerToken(r),
+		thingID:   chi.URLParam(r, "thingID"),
+		channelID: chi.URLParam(r, "groupID"),
+	}
+
+	return req, nil
+}
@@ -23,6 +23,7 @@ import (
 func clientsHandler(svc things.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
 	}
 	r.Route("/things", func(r chi.Router) {
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
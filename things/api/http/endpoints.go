@@ -12,6 +12,7 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
 	"github.com/go-kit/kit/endpoint"
 )
 
@@ -328,7 +329,7 @@ func connectChannelThingEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
-func disconnectChannelThingEndpoint(svc groups.Service) endpoint.Endpoint {
+func disconnectChannelThingEndpoint(svc groups.Service, tsvc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(disconnectChannelThingRequest)
 		if err := req.validate(); err != nil {
@@ -338,6 +339,9 @@ func disconnectChannelThingEndpoint(svc groups.Service) endpoint.Endpoint {
 		if err := svc.Unassign(ctx, req.token, req.ChannelID, auth.GroupRelation, auth.ThingsKind, req.ThingID); err != nil {
 			return nil, err
 		}
+		if err := tsvc.RemoveConnectionACL(ctx, req.token, req.ThingID, req.ChannelID); err != nil {
+			return nil, err
+		}
 
 		return disconnectChannelThingRes{}, nil
 	}
@@ -358,7 +362,7 @@ func connectEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
-func disconnectEndpoint(svc groups.Service) endpoint.Endpoint {
+func disconnectEndpoint(svc groups.Service, tsvc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(disconnectChannelThingRequest)
 		if err := req.validate(); err != nil {
@@ -368,6 +372,9 @@ func disconnectEndpoint(svc groups.Service) endpoint.Endpoint {
 		if err := svc.Unassign(ctx, req.token, req.ChannelID, auth.GroupRelation, auth.ThingsKind, req.ThingID); err != nil {
 			return nil, err
 		}
+		if err := tsvc.RemoveConnectionACL(ctx, req.token, req.ThingID, req.ChannelID); err != nil {
+			return nil, err
+		}
 
 		return disconnectChannelThingRes{}, nil
 	}
@@ -380,7 +387,7 @@ func thingShareEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		if err := svc.Share(ctx, req.token, req.thingID, req.Relation, req.UserIDs...); err != nil {
+		if err := svc.Share(ctx, req.token, req.thingID, req.Relation, req.condition(), req.UserIDs...); err != nil {
 			return nil, err
 		}
 
@@ -417,3 +424,55 @@ func deleteClientEndpoint(svc things.Service) endpoint.Endpoint {
 		return deleteClientRes{}, nil
 	}
 }
+
+func setConnectionACLEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(setConnectionACLRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		connACL, err := svc.SetConnectionACL(ctx, req.token, acl.ACL{
+			ThingID:   req.thingID,
+			ChannelID: req.channelID,
+			Publish:   req.Publish,
+			Subscribe: req.Subscribe,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return connectionACLRes{ACL: connACL}, nil
+	}
+}
+
+func viewConnectionACLEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(connectionACLRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		connACL, err := svc.ViewConnectionACL(ctx, req.token, req.thingID, req.channelID)
+		if err != nil {
+			return nil, err
+		}
+
+		return connectionACLRes{ACL: connACL}, nil
+	}
+}
+
+func removeConnectionACLEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(connectionACLRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveConnectionACL(ctx, req.token, req.thingID, req.channelID); err != nil {
+			return nil, err
+		}
+
+		return removeConnectionACLRes{}, nil
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/absmach/magistrala/things/acl"
 	httpapi "github.com/absmach/magistrala/things/api/http"
 	"github.com/absmach/magistrala/things/mocks"
 	"github.com/go-chi/chi/v5"
@@ -1413,7 +1414,7 @@ func TestShareThing(t *testing.T) {
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("Share", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		svcCall := svc.On("Share", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -2486,7 +2487,7 @@ func TestConnectThingToChannel(t *testing.T) {
 }
 
 func TestDisconnectThingFromChannel(t *testing.T) {
-	ts, _, gsvc := newThingsServer()
+	ts, svc, gsvc := newThingsServer()
 	defer ts.Close()
 
 	cases := []struct {
@@ -2545,10 +2546,12 @@ func TestDisconnectThingFromChannel(t *testing.T) {
 		}
 
 		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.channelID, "group", "things", []string{tc.thingID}).Return(tc.err)
+		svcCall1 := svc.On("RemoveConnectionACL", mock.Anything, tc.token, tc.thingID, tc.channelID).Return(nil)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		svcCall.Unset()
+		svcCall1.Unset()
 	}
 }
 
@@ -2650,7 +2653,7 @@ func TestConnect(t *testing.T) {
 }
 
 func TestDisconnect(t *testing.T) {
-	ts, _, gsvc := newThingsServer()
+	ts, svc, gsvc := newThingsServer()
 	defer ts.Close()
 
 	cases := []struct {
@@ -2739,6 +2742,225 @@ func TestDisconnect(t *testing.T) {
 		}
 
 		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, mock.Anything, "group", "things", mock.Anything).Return(tc.err)
+		svcCall1 := svc.On("RemoveConnectionACL", mock.Anything, tc.token, mock.Anything, mock.Anything).Return(nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+		svcCall1.Unset()
+	}
+}
+
+func TestSetConnectionACL(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	connACL := acl.ACL{
+		ThingID:   validID,
+		ChannelID: validID,
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{"commands/" + validID},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		channelID   string
+		thingID     string
+		reqBody     interface{}
+		contentType string
+		svcRes      acl.ACL
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "set connection ACL successfully",
+			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string][]string{"publish": connACL.Publish, "subscribe": connACL.Subscribe},
+			contentType: contentType,
+			svcRes:      connACL,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set connection ACL with invalid token",
+			token:       inValidToken,
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string][]string{"publish": connACL.Publish, "subscribe": connACL.Subscribe},
+			contentType: contentType,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "set connection ACL with empty token",
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string][]string{"publish": connACL.Publish, "subscribe": connACL.Subscribe},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "set connection ACL with malformed pattern",
+			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string][]string{"publish": {"sensors/#/temperature"}},
+			contentType: contentType,
+			svcErr:      svcerr.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set connection ACL with invalid request body",
+			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string]interface{}{"publish": "sensors/#"},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set connection ACL with invalid content type",
+			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
+			reqBody:     map[string][]string{"publish": connACL.Publish},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/channels/%s/things/%s/acl", ts.URL, tc.channelID, tc.thingID),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(toJSON(tc.reqBody)),
+		}
+
+		svcCall := svc.On("SetConnectionACL", mock.Anything, tc.token, mock.Anything).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var resACL acl.ACL
+			err := json.NewDecoder(res.Body).Decode(&resACL)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, resACL, fmt.Sprintf("%s: expected ACL %v got %v", tc.desc, tc.svcRes, resACL))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestViewConnectionACL(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	connACL := acl.ACL{
+		ThingID:   validID,
+		ChannelID: validID,
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{},
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcRes acl.ACL
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "view connection ACL successfully",
+			token:  validToken,
+			svcRes: connACL,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view connection ACL with invalid token",
+			token:  inValidToken,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view connection ACL with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view connection ACL without permission",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/things/%s/acl", ts.URL, validID, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ViewConnectionACL", mock.Anything, tc.token, validID, validID).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var resACL acl.ACL
+			err := json.NewDecoder(res.Body).Decode(&resACL)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, resACL, fmt.Sprintf("%s: expected ACL %v got %v", tc.desc, tc.svcRes, resACL))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestRemoveConnectionACL(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "remove connection ACL successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove connection ACL with invalid token",
+			token:  inValidToken,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove connection ACL with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove connection ACL without permission",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/things/%s/acl", ts.URL, validID, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RemoveConnectionACL", mock.Anything, tc.token, validID, validID).Return(tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
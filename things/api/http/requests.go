@@ -4,6 +4,9 @@
 package http
 
 import (
+	"time"
+
+	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
@@ -325,10 +328,24 @@ func (req *disconnectChannelThingRequest) validate() error {
 }
 
 type thingShareRequest struct {
-	token    string
-	thingID  string
-	Relation string   `json:"relation,omitempty"`
-	UserIDs  []string `json:"user_ids,omitempty"`
+	token        string
+	thingID      string
+	Relation     string    `json:"relation,omitempty"`
+	UserIDs      []string  `json:"user_ids,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	AllowedCIDRs []string  `json:"allowed_cidrs,omitempty"`
+}
+
+// condition returns the condition of the share, if any.
+func (req *thingShareRequest) condition() *auth.Condition {
+	if req.ExpiresAt.IsZero() && len(req.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	return &auth.Condition{
+		ExpiresAt:    req.ExpiresAt,
+		AllowedCIDRs: req.AllowedCIDRs,
+	}
 }
 
 func (req *thingShareRequest) validate() error {
@@ -372,3 +389,39 @@ func (req deleteClientReq) validate() error {
 	}
 	return nil
 }
+
+type setConnectionACLRequest struct {
+	token     string
+	thingID   string
+	channelID string
+	Publish   []string `json:"publish"`
+	Subscribe []string `json:"subscribe"`
+}
+
+func (req setConnectionACLRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.thingID == "" || req.channelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type connectionACLRequest struct {
+	token     string
+	thingID   string
+	channelID string
+}
+
+func (req connectionACLRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.thingID == "" || req.channelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
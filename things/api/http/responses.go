@@ -9,6 +9,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/things/acl"
 )
 
 var (
@@ -23,6 +24,8 @@ var (
 	_ magistrala.Response = (*connectChannelThingRes)(nil)
 	_ magistrala.Response = (*disconnectChannelThingRes)(nil)
 	_ magistrala.Response = (*changeClientStatusRes)(nil)
+	_ magistrala.Response = (*connectionACLRes)(nil)
+	_ magistrala.Response = (*removeConnectionACLRes)(nil)
 )
 
 type pageRes struct {
@@ -308,3 +311,33 @@ func (res thingUnshareRes) Headers() map[string]string {
 func (res thingUnshareRes) Empty() bool {
 	return true
 }
+
+type connectionACLRes struct {
+	acl.ACL
+}
+
+func (res connectionACLRes) Code() int {
+	return http.StatusOK
+}
+
+func (res connectionACLRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res connectionACLRes) Empty() bool {
+	return false
+}
+
+type removeConnectionACLRes struct{}
+
+func (res removeConnectionACLRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeConnectionACLRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeConnectionACLRes) Empty() bool {
+	return true
+}
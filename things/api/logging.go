@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
 )
 
 var _ things.Service = (*loggingMiddleware)(nil)
@@ -239,6 +241,9 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 			slog.String("subject_type", req.GetSubjectType()),
 			slog.String("permission", req.GetPermission()),
 		}
+		if req.GetSubtopic() != "" {
+			args = append(args, slog.String("subtopic", req.GetSubtopic()))
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Authorize failed", args...)
@@ -249,7 +254,7 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 	return lm.svc.Authorize(ctx, req)
 }
 
-func (lm *loggingMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) (err error) {
+func (lm *loggingMiddleware) Share(ctx context.Context, token, id, relation string, cond *auth.Condition, userids ...string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -264,7 +269,7 @@ func (lm *loggingMiddleware) Share(ctx context.Context, token, id, relation stri
 		}
 		lm.logger.Info("Share thing completed successfully", args...)
 	}(time.Now())
-	return lm.svc.Share(ctx, token, id, relation, userids...)
+	return lm.svc.Share(ctx, token, id, relation, cond, userids...)
 }
 
 func (lm *loggingMiddleware) Unshare(ctx context.Context, token, id, relation string, userids ...string) (err error) {
@@ -300,3 +305,73 @@ func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string)
 	}(time.Now())
 	return lm.svc.DeleteClient(ctx, token, id)
 }
+
+func (lm *loggingMiddleware) DeleteDomainClients(ctx context.Context, domainID string) (ids []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Int("deleted", len(ids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete domain things failed", args...)
+			return
+		}
+		lm.logger.Info("Delete domain things completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteDomainClients(ctx, domainID)
+}
+
+func (lm *loggingMiddleware) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (a acl.ACL, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", connACL.ThingID),
+			slog.String("channel_id", connACL.ChannelID),
+			slog.Any("publish", connACL.Publish),
+			slog.Any("subscribe", connACL.Subscribe),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Set connection ACL failed", args...)
+			return
+		}
+		lm.logger.Info("Set connection ACL completed successfully", args...)
+	}(time.Now())
+	return lm.svc.SetConnectionACL(ctx, token, connACL)
+}
+
+func (lm *loggingMiddleware) ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (a acl.ACL, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+			slog.String("channel_id", channelID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View connection ACL failed", args...)
+			return
+		}
+		lm.logger.Info("View connection ACL completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewConnectionACL(ctx, token, thingID, channelID)
+}
+
+func (lm *loggingMiddleware) RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+			slog.String("channel_id", channelID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove connection ACL failed", args...)
+			return
+		}
+		lm.logger.Info("Remove connection ACL completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RemoveConnectionACL(ctx, token, thingID, channelID)
+}
@@ -8,12 +8,17 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
 	"github.com/go-kit/kit/metrics"
 )
 
-var _ things.Service = (*metricsMiddleware)(nil)
+var (
+	_ things.Service    = (*metricsMiddleware)(nil)
+	_ things.AuthzCache = (*authzCacheMetricsMiddleware)(nil)
+)
 
 type metricsMiddleware struct {
 	counter metrics.Counter
@@ -126,12 +131,12 @@ func (ms *metricsMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 	return ms.svc.Authorize(ctx, req)
 }
 
-func (ms *metricsMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) error {
+func (ms *metricsMiddleware) Share(ctx context.Context, token, id, relation string, cond *auth.Condition, userids ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "share").Add(1)
 		ms.latency.With("method", "share").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.Share(ctx, token, id, relation, userids...)
+	return ms.svc.Share(ctx, token, id, relation, cond, userids...)
 }
 
 func (ms *metricsMiddleware) Unshare(ctx context.Context, token, id, relation string, userids ...string) error {
@@ -149,3 +154,72 @@ func (ms *metricsMiddleware) DeleteClient(ctx context.Context, token, id string)
 	}(time.Now())
 	return ms.svc.DeleteClient(ctx, token, id)
 }
+
+func (ms *metricsMiddleware) DeleteDomainClients(ctx context.Context, domainID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_domain_clients").Add(1)
+		ms.latency.With("method", "delete_domain_clients").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteDomainClients(ctx, domainID)
+}
+
+func (ms *metricsMiddleware) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "set_connection_acl").Add(1)
+		ms.latency.With("method", "set_connection_acl").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.SetConnectionACL(ctx, token, connACL)
+}
+
+func (ms *metricsMiddleware) ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (acl.ACL, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_connection_acl").Add(1)
+		ms.latency.With("method", "view_connection_acl").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewConnectionACL(ctx, token, thingID, channelID)
+}
+
+func (ms *metricsMiddleware) RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_connection_acl").Add(1)
+		ms.latency.With("method", "remove_connection_acl").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveConnectionACL(ctx, token, thingID, channelID)
+}
+
+type authzCacheMetricsMiddleware struct {
+	counter metrics.Counter
+	cache   things.AuthzCache
+}
+
+// AuthzCacheMetricsMiddleware returns the authorization decisions cache
+// wrapper counting the cache hits and misses.
+func AuthzCacheMetricsMiddleware(cache things.AuthzCache, counter metrics.Counter) things.AuthzCache {
+	return &authzCacheMetricsMiddleware{
+		counter: counter,
+		cache:   cache,
+	}
+}
+
+func (am *authzCacheMetricsMiddleware) Save(ctx context.Context, thingID, channelID, permission, subtopic string, allowed bool) error {
+	return am.cache.Save(ctx, thingID, channelID, permission, subtopic, allowed)
+}
+
+func (am *authzCacheMetricsMiddleware) Decision(ctx context.Context, thingID, channelID, permission, subtopic string) (allowed bool, err error) {
+	defer func() {
+		result := "hit"
+		if err != nil {
+			result = "miss"
+		}
+		am.counter.With("result", result).Add(1)
+	}()
+	return am.cache.Decision(ctx, thingID, channelID, permission, subtopic)
+}
+
+func (am *authzCacheMetricsMiddleware) RemoveThing(ctx context.Context, thingID string) error {
+	return am.cache.RemoveThing(ctx, thingID)
+}
+
+func (am *authzCacheMetricsMiddleware) RemoveChannel(ctx context.Context, channelID string) error {
+	return am.cache.RemoveChannel(ctx, channelID)
+}
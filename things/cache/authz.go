@@ -0,0 +1,213 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	authzPrefix = "thing_authz"
+	allowed     = "1"
+	denied      = "0"
+	scanCount   = 1000
+)
+
+var _ things.AuthzCache = (*authzCache)(nil)
+
+// authzCache keeps the decisions in the process memory, and in Redis if
+// the Redis client is set, so the decisions are shared by the instances.
+type authzCache struct {
+	local    *localCache[bool]
+	client   *redis.Client
+	duration time.Duration
+}
+
+// NewAuthzCache returns the thing authorization decisions cache holding up
+// to the size decisions in memory for the duration. The decisions are also
+// kept in Redis if the client is not nil.
+func NewAuthzCache(client *redis.Client, size int, duration time.Duration) things.AuthzCache {
+	return &authzCache{
+		local:    newLocalCache[bool](size, duration),
+		client:   client,
+		duration: duration,
+	}
+}
+
+func (ac *authzCache) Save(ctx context.Context, thingID, channelID, permission, subtopic string, decision bool) error {
+	if thingID == "" || channelID == "" || permission == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id, channel id or permission is empty"))
+	}
+	key := authzKey(thingID, channelID, permission, subtopic)
+	ac.local.set(key, decision)
+	if ac.client == nil {
+		return nil
+	}
+	val := denied
+	if decision {
+		val = allowed
+	}
+	if err := ac.client.Set(ctx, key, val, ac.duration).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ac *authzCache) Decision(ctx context.Context, thingID, channelID, permission, subtopic string) (bool, error) {
+	key := authzKey(thingID, channelID, permission, subtopic)
+	if decision, ok := ac.local.get(key); ok {
+		return decision, nil
+	}
+	if ac.client == nil {
+		return false, repoerr.ErrNotFound
+	}
+	val, err := ac.client.Get(ctx, key).Result()
+	if err != nil {
+		return false, errors.Wrap(repoerr.ErrNotFound, err)
+	}
+	decision := val == allowed
+	ac.local.set(key, decision)
+
+	return decision, nil
+}
+
+func (ac *authzCache) RemoveThing(ctx context.Context, thingID string) error {
+	prefix := fmt.Sprintf("%s:%s:", authzPrefix, thingID)
+	ac.local.remove(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+
+	return ac.removeRemote(ctx, prefix+"*")
+}
+
+func (ac *authzCache) RemoveChannel(ctx context.Context, channelID string) error {
+	infix := fmt.Sprintf(":%s:", channelID)
+	ac.local.remove(func(key string) bool {
+		return strings.Contains(strings.TrimPrefix(key, authzPrefix+":"), infix)
+	})
+
+	return ac.removeRemote(ctx, fmt.Sprintf("%s:*%s*", authzPrefix, infix))
+}
+
+func (ac *authzCache) removeRemote(ctx context.Context, pattern string) error {
+	if ac.client == nil {
+		return nil
+	}
+	iter := ac.client.Scan(ctx, 0, pattern, scanCount).Iterator()
+	for iter.Next(ctx) {
+		if err := ac.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return errors.Wrap(repoerr.ErrRemoveEntity, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func authzKey(thingID, channelID, permission, subtopic string) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", authzPrefix, thingID, channelID, permission, subtopic)
+}
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// localCache is the least recently used cache of the values
+// bounded by the number of the values.
+type localCache[V any] struct {
+	mu       sync.Mutex
+	size     int
+	duration time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func newLocalCache[V any](size int, duration time.Duration) *localCache[V] {
+	return &localCache[V]{
+		size:     size,
+		duration: duration,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (lc *localCache[V]) get(key string) (V, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	var zero V
+	el, ok := lc.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(*entry[V])
+	if time.Now().After(e.expiresAt) {
+		lc.order.Remove(el)
+		delete(lc.entries, key)
+		return zero, false
+	}
+	lc.order.MoveToFront(el)
+
+	return e.value, true
+}
+
+func (lc *localCache[V]) set(key string, value V) {
+	if lc.size <= 0 {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	expiresAt := time.Now().Add(lc.duration)
+	if el, ok := lc.entries[key]; ok {
+		e := el.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = expiresAt
+		lc.order.MoveToFront(el)
+		return
+	}
+	lc.entries[key] = lc.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	if lc.order.Len() > lc.size {
+		oldest := lc.order.Back()
+		lc.order.Remove(oldest)
+		delete(lc.entries, oldest.Value.(*entry[V]).key)
+	}
+}
+
+func (lc *localCache[V]) remove(match func(key string) bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for key, el := range lc.entries {
+		if match(key) {
+			lc.order.Remove(el)
+			delete(lc.entries, key)
+		}
+	}
+}
+
+func (lc *localCache[V]) delete(key string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if el, ok := lc.entries[key]; ok {
+		lc.order.Remove(el)
+		delete(lc.entries, key)
+	}
+}
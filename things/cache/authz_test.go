@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testChannel    = "testChannel"
+	testChannel2   = "testChannel2"
+	testPermission = "publish"
+	testSubtopic   = "sensors.temperature"
+)
+
+func TestAuthzSave(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	acache := cache.NewAuthzCache(redisClient, 10, 1*time.Minute)
+	ctx := context.Background()
+
+	cases := []struct {
+		desc       string
+		thingID    string
+		channelID  string
+		permission string
+		decision   bool
+		err        error
+	}{
+		{
+			desc:       "Save allowed decision",
+			thingID:    testID,
+			channelID:  testChannel,
+			permission: testPermission,
+			decision:   true,
+		},
+		{
+			desc:       "Save denied decision",
+			thingID:    testID2,
+			channelID:  testChannel,
+			permission: testPermission,
+			decision:   false,
+		},
+		{
+			desc:       "Save already saved decision",
+			thingID:    testID,
+			channelID:  testChannel,
+			permission: testPermission,
+			decision:   false,
+		},
+		{
+			desc:       "Save decision with empty thing id",
+			channelID:  testChannel,
+			permission: testPermission,
+			err:        repoerr.ErrCreateEntity,
+		},
+		{
+			desc:       "Save decision with empty channel id",
+			thingID:    testID,
+			permission: testPermission,
+			err:        repoerr.ErrCreateEntity,
+		},
+		{
+			desc:      "Save decision with empty permission",
+			thingID:   testID,
+			channelID: testChannel,
+			err:       repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := acache.Save(ctx, tc.thingID, tc.channelID, tc.permission, testSubtopic, tc.decision)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			decision, err := acache.Decision(ctx, tc.thingID, tc.channelID, tc.permission, testSubtopic)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.decision, decision, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.decision, decision))
+		}
+	}
+}
+
+func TestAuthzDecision(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	err := cache.NewAuthzCache(redisClient, 10, 1*time.Minute).Save(ctx, testID, testChannel, testPermission, testSubtopic, true)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+
+	cases := []struct {
+		desc     string
+		acache   func() things.AuthzCache
+		thingID  string
+		subtopic string
+		decision bool
+		err      error
+	}{
+		{
+			desc:     "Retrieve decision saved by another instance",
+			acache:   func() things.AuthzCache { return cache.NewAuthzCache(redisClient, 10, 1*time.Minute) },
+			thingID:  testID,
+			subtopic: testSubtopic,
+			decision: true,
+		},
+		{
+			desc:     "Retrieve decision of another subtopic",
+			acache:   func() things.AuthzCache { return cache.NewAuthzCache(redisClient, 10, 1*time.Minute) },
+			thingID:  testID,
+			subtopic: "sensors.humidity",
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "Retrieve not saved decision",
+			acache:   func() things.AuthzCache { return cache.NewAuthzCache(redisClient, 10, 1*time.Minute) },
+			thingID:  testID2,
+			subtopic: testSubtopic,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "Retrieve decision saved by another instance without Redis",
+			acache:   func() things.AuthzCache { return cache.NewAuthzCache(nil, 10, 1*time.Minute) },
+			thingID:  testID,
+			subtopic: testSubtopic,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		decision, err := tc.acache().Decision(ctx, tc.thingID, testChannel, testPermission, tc.subtopic)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.decision, decision, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.decision, decision))
+	}
+}
+
+func TestAuthzRemove(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	for _, client := range []*redis.Client{redisClient, nil} {
+		acache := cache.NewAuthzCache(client, 10, 1*time.Minute)
+		for _, id := range []string{testID, testID2} {
+			for _, channel := range []string{testChannel, testChannel2} {
+				err := acache.Save(ctx, id, channel, testPermission, testSubtopic, true)
+				assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+			}
+		}
+
+		err := acache.RemoveThing(ctx, testID)
+		assert.Nil(t, err, fmt.Sprintf("Remove thing decisions: unexpected error %s", err))
+		for _, channel := range []string{testChannel, testChannel2} {
+			_, err := acache.Decision(ctx, testID, channel, testPermission, testSubtopic)
+			assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Retrieve removed thing decision: expected %s got %s", repoerr.ErrNotFound, err))
+		}
+
+		err = acache.RemoveChannel(ctx, testChannel)
+		assert.Nil(t, err, fmt.Sprintf("Remove channel decisions: unexpected error %s", err))
+		_, err = acache.Decision(ctx, testID2, testChannel, testPermission, testSubtopic)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Retrieve removed channel decision: expected %s got %s", repoerr.ErrNotFound, err))
+
+		decision, err := acache.Decision(ctx, testID2, testChannel2, testPermission, testSubtopic)
+		assert.Nil(t, err, fmt.Sprintf("Retrieve kept decision: unexpected error %s", err))
+		assert.True(t, decision, "Retrieve kept decision: expected allowed decision")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things"
+)
+
+var _ things.QuotaCache = (*quotaCache)(nil)
+
+// quotaCache keeps the domains of the things, whether the messages of
+// the domains are limited by the quota and the quota decisions of the
+// limited domains in the process memory.
+type quotaCache struct {
+	domains *localCache[string]
+	limited *localCache[bool]
+	allowed *localCache[bool]
+}
+
+// NewQuotaCache returns the cache holding up to the size thing domains and
+// the size domain quotas in memory for the duration, and the size quota
+// decisions for the decision duration.
+func NewQuotaCache(size int, duration, decisionDuration time.Duration) things.QuotaCache {
+	return &quotaCache{
+		domains: newLocalCache[string](size, duration),
+		limited: newLocalCache[bool](size, duration),
+		allowed: newLocalCache[bool](size, decisionDuration),
+	}
+}
+
+func (qc *quotaCache) SaveDomain(_ context.Context, thingID, domainID string) error {
+	if thingID == "" || domainID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id or domain id is empty"))
+	}
+	qc.domains.set(thingID, domainID)
+
+	return nil
+}
+
+func (qc *quotaCache) Domain(_ context.Context, thingID string) (string, error) {
+	domainID, ok := qc.domains.get(thingID)
+	if !ok {
+		return "", repoerr.ErrNotFound
+	}
+
+	return domainID, nil
+}
+
+func (qc *quotaCache) SaveLimited(_ context.Context, domainID string, limited bool) error {
+	if domainID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("domain id is empty"))
+	}
+	qc.limited.set(domainID, limited)
+
+	return nil
+}
+
+func (qc *quotaCache) Limited(_ context.Context, domainID string) (bool, error) {
+	limited, ok := qc.limited.get(domainID)
+	if !ok {
+		return false, repoerr.ErrNotFound
+	}
+
+	return limited, nil
+}
+
+func (qc *quotaCache) SaveAllowed(_ context.Context, domainID string, allowed bool) error {
+	if domainID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("domain id is empty"))
+	}
+	qc.allowed.set(domainID, allowed)
+
+	return nil
+}
+
+func (qc *quotaCache) Allowed(_ context.Context, domainID string) (bool, error) {
+	allowed, ok := qc.allowed.get(domainID)
+	if !ok {
+		return false, repoerr.ErrNotFound
+	}
+
+	return allowed, nil
+}
+
+func (qc *quotaCache) RemoveThing(_ context.Context, thingID string) error {
+	qc.domains.delete(thingID)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
+)
+
+const (
+	channelPrefix       = "group."
+	channelConnect      = channelPrefix + "assign"
+	channelDisconnect   = channelPrefix + "unassign"
+	channelChangeStatus = channelPrefix + "change_status"
+	channelRemove       = channelPrefix + "remove"
+
+	domainRemove = "domain.remove"
+)
+
+// StartAuthzCacheInvalidation starts consuming the things and channels
+// events, removing the cached authorization decisions the events change.
+// Every instance should use its own consumer, so every instance
+// cache is invalidated.
+func StartAuthzCacheInvalidation(ctx context.Context, consumer string, sub events.Subscriber, cache things.AuthzCache) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   store.StreamAllEvents,
+		Handler:  NewAuthzCacheHandler(cache),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type authzCacheHandler struct {
+	cache things.AuthzCache
+}
+
+// NewAuthzCacheHandler returns the event handler invalidating
+// the authorization decisions cache.
+func NewAuthzCacheHandler(cache things.AuthzCache) events.EventHandler {
+	return &authzCacheHandler{
+		cache: cache,
+	}
+}
+
+func (h *authzCacheHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case clientRemove, clientChangeStatus:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return h.cache.RemoveThing(ctx, id)
+	case aclSet, aclRemove:
+		id := events.Read(msg, "thing_id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return h.cache.RemoveThing(ctx, id)
+	case channelConnect, channelDisconnect:
+		id := events.Read(msg, "group_id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return h.cache.RemoveChannel(ctx, id)
+	case channelChangeStatus, channelRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		return h.cache.RemoveChannel(ctx, id)
+	}
+
+	return nil
+}
+
+// StartDomainDeletion starts consuming the removed domains events, deleting
+// the things and the channels of the removed domains. The instances should
+// share the consumer, so every removed domain is handled once.
+func StartDomainDeletion(ctx context.Context, consumer string, sub events.Subscriber, tsvc things.Service, gsvc groups.Service) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   store.StreamAllEvents,
+		Handler:  NewDomainDeletionHandler(tsvc, gsvc),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type domainDeletionHandler struct {
+	tsvc things.Service
+	gsvc groups.Service
+}
+
+// NewDomainDeletionHandler returns the event handler deleting
+// the things and the channels of the removed domains.
+func NewDomainDeletionHandler(tsvc things.Service, gsvc groups.Service) events.EventHandler {
+	return &domainDeletionHandler{
+		tsvc: tsvc,
+		gsvc: gsvc,
+	}
+}
+
+func (h *domainDeletionHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+	if msg["operation"] != domainRemove {
+		return nil
+	}
+
+	id := events.Read(msg, "id", "")
+	if id == "" {
+		return svcerr.ErrMalformedEntity
+	}
+	if _, err := h.tsvc.DeleteDomainClients(ctx, id); err != nil {
+		return err
+	}
+	if _, err := h.gsvc.DeleteDomainGroups(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ package events
 import (
 	"time"
 
+	"github.com/absmach/magistrala/auth"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/things/acl"
 )
 
 const (
@@ -22,6 +24,9 @@ const (
 	clientListByGroup  = clientPrefix + "list_by_channel"
 	clientIdentify     = clientPrefix + "identify"
 	clientAuthorize    = clientPrefix + "authorize"
+	aclSet             = clientPrefix + "set_acl"
+	aclView            = clientPrefix + "view_acl"
+	aclRemove          = clientPrefix + "remove_acl"
 )
 
 var (
@@ -36,6 +41,9 @@ var (
 	_ events.Event = (*authorizeClientEvent)(nil)
 	_ events.Event = (*shareClientEvent)(nil)
 	_ events.Event = (*removeClientEvent)(nil)
+	_ events.Event = (*setACLEvent)(nil)
+	_ events.Event = (*viewACLEvent)(nil)
+	_ events.Event = (*removeACLEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -297,6 +305,7 @@ type authorizeClientEvent struct {
 	permission      string
 	object          string
 	objectType      string
+	subtopic        string
 }
 
 func (ice authorizeClientEvent) Encode() (map[string]interface{}, error) {
@@ -331,24 +340,38 @@ func (ice authorizeClientEvent) Encode() (map[string]interface{}, error) {
 	if ice.objectType != "" {
 		val["object_type"] = ice.objectType
 	}
+	if ice.subtopic != "" {
+		val["subtopic"] = ice.subtopic
+	}
 
 	return val, nil
 }
 
 type shareClientEvent struct {
-	action   string
-	id       string
-	relation string
-	userIDs  []string
+	action    string
+	id        string
+	relation  string
+	userIDs   []string
+	condition *auth.Condition
 }
 
 func (sce shareClientEvent) Encode() (map[string]interface{}, error) {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"operation": clientPrefix + sce.action,
 		"id":        sce.id,
 		"relation":  sce.relation,
 		"user_ids":  sce.userIDs,
-	}, nil
+	}
+	if sce.condition != nil {
+		if !sce.condition.ExpiresAt.IsZero() {
+			val["expires_at"] = sce.condition.ExpiresAt
+		}
+		if len(sce.condition.AllowedCIDRs) > 0 {
+			val["allowed_cidrs"] = sce.condition.AllowedCIDRs
+		}
+	}
+
+	return val, nil
 }
 
 type removeClientEvent struct {
@@ -361,3 +384,44 @@ func (dce removeClientEvent) Encode() (map[string]interface{}, error) {
 		"id":        dce.id,
 	}, nil
 }
+
+type setACLEvent struct {
+	acl.ACL
+}
+
+func (sae setACLEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  aclSet,
+		"thing_id":   sae.ThingID,
+		"channel_id": sae.ChannelID,
+		"publish":    sae.Publish,
+		"subscribe":  sae.Subscribe,
+		"updated_at": sae.UpdatedAt,
+		"updated_by": sae.UpdatedBy,
+	}, nil
+}
+
+type viewACLEvent struct {
+	acl.ACL
+}
+
+func (vae viewACLEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  aclView,
+		"thing_id":   vae.ThingID,
+		"channel_id": vae.ChannelID,
+	}, nil
+}
+
+type removeACLEvent struct {
+	thingID   string
+	channelID string
+}
+
+func (rae removeACLEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  aclRemove,
+		"thing_id":   rae.thingID,
+		"channel_id": rae.channelID,
+	}, nil
+}
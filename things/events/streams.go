@@ -7,10 +7,13 @@ import (
 	"context"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
 )
 
 const streamID = "magistrala.things"
@@ -213,6 +216,7 @@ func (es *eventStore) Authorize(ctx context.Context, req *magistrala.AuthorizeRe
 		thingID:    thingID,
 		object:     req.GetObject(),
 		permission: req.GetPermission(),
+		subtopic:   req.GetSubtopic(),
 	}
 
 	if err := es.Publish(ctx, event); err != nil {
@@ -222,16 +226,17 @@ func (es *eventStore) Authorize(ctx context.Context, req *magistrala.AuthorizeRe
 	return thingID, nil
 }
 
-func (es *eventStore) Share(ctx context.Context, token, id, relation string, userids ...string) error {
-	if err := es.svc.Share(ctx, token, id, relation, userids...); err != nil {
+func (es *eventStore) Share(ctx context.Context, token, id, relation string, cond *auth.Condition, userids ...string) error {
+	if err := es.svc.Share(ctx, token, id, relation, cond, userids...); err != nil {
 		return err
 	}
 
 	event := shareClientEvent{
-		action:   "share",
-		id:       id,
-		relation: relation,
-		userIDs:  userids,
+		action:    "share",
+		id:        id,
+		relation:  relation,
+		userIDs:   userids,
+		condition: cond,
 	}
 
 	return es.Publish(ctx, event)
@@ -265,3 +270,58 @@ func (es *eventStore) DeleteClient(ctx context.Context, token, id string) error
 
 	return nil
 }
+
+func (es *eventStore) DeleteDomainClients(ctx context.Context, domainID string) ([]string, error) {
+	ids, err := es.svc.DeleteDomainClients(ctx, domainID)
+	// The things deleted before the failure are published as well.
+	for _, id := range ids {
+		if errPublish := es.Publish(ctx, removeClientEvent{id}); errPublish != nil {
+			return ids, errors.Wrap(errPublish, err)
+		}
+	}
+
+	return ids, err
+}
+
+func (es *eventStore) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error) {
+	saved, err := es.svc.SetConnectionACL(ctx, token, connACL)
+	if err != nil {
+		return saved, err
+	}
+
+	event := setACLEvent{saved}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (acl.ACL, error) {
+	connACL, err := es.svc.ViewConnectionACL(ctx, token, thingID, channelID)
+	if err != nil {
+		return connACL, err
+	}
+
+	event := viewACLEvent{connACL}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return connACL, err
+	}
+
+	return connACL, nil
+}
+
+func (es *eventStore) RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) error {
+	if err := es.svc.RemoveConnectionACL(ctx, token, thingID, channelID); err != nil {
+		return err
+	}
+
+	event := removeACLEvent{
+		thingID:   thingID,
+		channelID: channelID,
+	}
+
+	return es.Publish(ctx, event)
+}
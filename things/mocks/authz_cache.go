@@ -0,0 +1,112 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthzCache is an autogenerated mock type for the AuthzCache type
+type AuthzCache struct {
+	mock.Mock
+}
+
+// Decision provides a mock function with given fields: ctx, thingID, channelID, permission, subtopic
+func (_m *AuthzCache) Decision(ctx context.Context, thingID string, channelID string, permission string, subtopic string) (bool, error) {
+	ret := _m.Called(ctx, thingID, channelID, permission, subtopic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decision")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (bool, error)); ok {
+		return rf(ctx, thingID, channelID, permission, subtopic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) bool); ok {
+		r0 = rf(ctx, thingID, channelID, permission, subtopic)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, thingID, channelID, permission, subtopic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveChannel provides a mock function with given fields: ctx, channelID
+func (_m *AuthzCache) RemoveChannel(ctx context.Context, channelID string) error {
+	ret := _m.Called(ctx, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveThing provides a mock function with given fields: ctx, thingID
+func (_m *AuthzCache) RemoveThing(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveThing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, thingID, channelID, permission, subtopic, allowed
+func (_m *AuthzCache) Save(ctx context.Context, thingID string, channelID string, permission string, subtopic string, allowed bool) error {
+	ret := _m.Called(ctx, thingID, channelID, permission, subtopic, allowed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, bool) error); ok {
+		r0 = rf(ctx, thingID, channelID, permission, subtopic, allowed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthzCache creates a new instance of AuthzCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthzCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthzCache {
+	mock := &AuthzCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
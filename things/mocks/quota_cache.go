@@ -0,0 +1,186 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QuotaCache is an autogenerated mock type for the QuotaCache type
+type QuotaCache struct {
+	mock.Mock
+}
+
+// Allowed provides a mock function with given fields: ctx, domainID
+func (_m *QuotaCache) Allowed(ctx context.Context, domainID string) (bool, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allowed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Domain provides a mock function with given fields: ctx, thingID
+func (_m *QuotaCache) Domain(ctx context.Context, thingID string) (string, error) {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Domain")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, thingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, thingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Limited provides a mock function with given fields: ctx, domainID
+func (_m *QuotaCache) Limited(ctx context.Context, domainID string) (bool, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Limited")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveThing provides a mock function with given fields: ctx, thingID
+func (_m *QuotaCache) RemoveThing(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveThing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveAllowed provides a mock function with given fields: ctx, domainID, allowed
+func (_m *QuotaCache) SaveAllowed(ctx context.Context, domainID string, allowed bool) error {
+	ret := _m.Called(ctx, domainID, allowed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAllowed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, domainID, allowed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDomain provides a mock function with given fields: ctx, thingID, domainID
+func (_m *QuotaCache) SaveDomain(ctx context.Context, thingID string, domainID string) error {
+	ret := _m.Called(ctx, thingID, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, thingID, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveLimited provides a mock function with given fields: ctx, domainID, limited
+func (_m *QuotaCache) SaveLimited(ctx context.Context, domainID string, limited bool) error {
+	ret := _m.Called(ctx, domainID, limited)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLimited")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, domainID, limited)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewQuotaCache creates a new instance of QuotaCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQuotaCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuotaCache {
+	mock := &QuotaCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
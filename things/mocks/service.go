@@ -5,10 +5,13 @@
 package mocks
 
 import (
-	context "context"
+	auth "github.com/absmach/magistrala/auth"
+	acl "github.com/absmach/magistrala/things/acl"
 
 	clients "github.com/absmach/magistrala/pkg/clients"
 
+	context "context"
+
 	magistrala "github.com/absmach/magistrala"
 
 	mock "github.com/stretchr/testify/mock"
@@ -102,6 +105,36 @@ func (_m *Service) DeleteClient(ctx context.Context, token string, id string) er
 	return r0
 }
 
+// DeleteDomainClients provides a mock function with given fields: ctx, domainID
+func (_m *Service) DeleteDomainClients(ctx context.Context, domainID string) ([]string, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomainClients")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisableClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) DisableClient(ctx context.Context, token string, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, token, id)
@@ -242,14 +275,60 @@ func (_m *Service) ListClientsByGroup(ctx context.Context, token string, groupID
 	return r0, r1
 }
 
-// Share provides a mock function with given fields: ctx, token, id, relation, userids
-func (_m *Service) Share(ctx context.Context, token string, id string, relation string, userids ...string) error {
+// RemoveConnectionACL provides a mock function with given fields: ctx, token, thingID, channelID
+func (_m *Service) RemoveConnectionACL(ctx context.Context, token string, thingID string, channelID string) error {
+	ret := _m.Called(ctx, token, thingID, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveConnectionACL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, thingID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetConnectionACL provides a mock function with given fields: ctx, token, connACL
+func (_m *Service) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error) {
+	ret := _m.Called(ctx, token, connACL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetConnectionACL")
+	}
+
+	var r0 acl.ACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, acl.ACL) (acl.ACL, error)); ok {
+		return rf(ctx, token, connACL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, acl.ACL) acl.ACL); ok {
+		r0 = rf(ctx, token, connACL)
+	} else {
+		r0 = ret.Get(0).(acl.ACL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, acl.ACL) error); ok {
+		r1 = rf(ctx, token, connACL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Share provides a mock function with given fields: ctx, token, id, relation, cond, userids
+func (_m *Service) Share(ctx context.Context, token string, id string, relation string, cond *auth.Condition, userids ...string) error {
 	_va := make([]interface{}, len(userids))
 	for _i := range userids {
 		_va[_i] = userids[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, token, id, relation)
+	_ca = append(_ca, ctx, token, id, relation, cond)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -258,8 +337,8 @@ func (_m *Service) Share(ctx context.Context, token string, id string, relation
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...string) error); ok {
-		r0 = rf(ctx, token, id, relation, userids...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *auth.Condition, ...string) error); ok {
+		r0 = rf(ctx, token, id, relation, cond, userids...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -434,6 +513,34 @@ func (_m *Service) ViewClientPerms(ctx context.Context, token string, id string)
 	return r0, r1
 }
 
+// ViewConnectionACL provides a mock function with given fields: ctx, token, thingID, channelID
+func (_m *Service) ViewConnectionACL(ctx context.Context, token string, thingID string, channelID string) (acl.ACL, error) {
+	ret := _m.Called(ctx, token, thingID, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewConnectionACL")
+	}
+
+	var r0 acl.ACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (acl.ACL, error)); ok {
+		return rf(ctx, token, thingID, channelID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) acl.ACL); ok {
+		r0 = rf(ctx, token, thingID, channelID)
+	} else {
+		r0 = ret.Get(0).(acl.ACL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, thingID, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/things/acl"
+	"github.com/jackc/pgtype"
+)
+
+var _ acl.Repository = (*aclRepo)(nil)
+
+type aclRepo struct {
+	db postgres.Database
+}
+
+// NewACLRepository instantiates a PostgreSQL
+// implementation of ACLs repository.
+func NewACLRepository(db postgres.Database) acl.Repository {
+	return &aclRepo{
+		db: db,
+	}
+}
+
+func (repo *aclRepo) Save(ctx context.Context, a acl.ACL) (acl.ACL, error) {
+	q := `INSERT INTO connection_acls (thing_id, channel_id, publish, subscribe, updated_at, updated_by)
+		VALUES (:thing_id, :channel_id, :publish, :subscribe, :updated_at, :updated_by)
+		ON CONFLICT (thing_id, channel_id) DO UPDATE SET publish = EXCLUDED.publish, subscribe = EXCLUDED.subscribe,
+		updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+		RETURNING thing_id, channel_id, publish, subscribe, updated_at, updated_by`
+
+	dba, err := toDBACL(a)
+	if err != nil {
+		return acl.ACL{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dba)
+	if err != nil {
+		return acl.ACL{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	dba = dbACL{}
+	if row.Next() {
+		if err := row.StructScan(&dba); err != nil {
+			return acl.ACL{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+		}
+	}
+
+	return toACL(dba), nil
+}
+
+func (repo *aclRepo) Retrieve(ctx context.Context, thingID, channelID string) (acl.ACL, error) {
+	q := `SELECT thing_id, channel_id, publish, subscribe, updated_at, updated_by FROM connection_acls
+		WHERE thing_id = :thing_id AND channel_id = :channel_id`
+
+	dba := dbACL{ThingID: thingID, ChannelID: channelID}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dba)
+	if err != nil {
+		return acl.ACL{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dba = dbACL{}
+	if rows.Next() {
+		if err := rows.StructScan(&dba); err != nil {
+			return acl.ACL{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toACL(dba), nil
+	}
+
+	return acl.ACL{}, repoerr.ErrNotFound
+}
+
+func (repo *aclRepo) Remove(ctx context.Context, thingID, channelID string) error {
+	q := `DELETE FROM connection_acls WHERE thing_id = :thing_id AND channel_id = :channel_id`
+
+	dba := dbACL{ThingID: thingID, ChannelID: channelID}
+	if _, err := repo.db.NamedExecContext(ctx, q, dba); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbACL struct {
+	ThingID   string           `db:"thing_id"`
+	ChannelID string           `db:"channel_id"`
+	Publish   pgtype.TextArray `db:"publish"`
+	Subscribe pgtype.TextArray `db:"subscribe"`
+	UpdatedAt time.Time        `db:"updated_at"`
+	UpdatedBy string           `db:"updated_by"`
+}
+
+func toDBACL(a acl.ACL) (dbACL, error) {
+	var publish, subscribe pgtype.TextArray
+	if err := publish.Set(a.Publish); err != nil {
+		return dbACL{}, err
+	}
+	if err := subscribe.Set(a.Subscribe); err != nil {
+		return dbACL{}, err
+	}
+
+	return dbACL{
+		ThingID:   a.ThingID,
+		ChannelID: a.ChannelID,
+		Publish:   publish,
+		Subscribe: subscribe,
+		UpdatedAt: a.UpdatedAt,
+		UpdatedBy: a.UpdatedBy,
+	}, nil
+}
+
+func toACL(dba dbACL) acl.ACL {
+	a := acl.ACL{
+		ThingID:   dba.ThingID,
+		ChannelID: dba.ChannelID,
+		Publish:   []string{},
+		Subscribe: []string{},
+		UpdatedAt: dba.UpdatedAt.UTC(),
+		UpdatedBy: dba.UpdatedBy,
+	}
+	for _, e := range dba.Publish.Elements {
+		a.Publish = append(a.Publish, e.String)
+	}
+	for _, e := range dba.Subscribe.Elements {
+		a.Subscribe = append(a.Subscribe, e.String)
+	}
+
+	return a
+}
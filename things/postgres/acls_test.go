@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things/acl"
+	"github.com/absmach/magistrala/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveThing(t *testing.T) clients.Client {
+	repo := postgres.NewRepository(database)
+	thing := clients.Client{
+		ID:          testsutil.GenerateUUID(t),
+		Domain:      testsutil.GenerateUUID(t),
+		Name:        namesgen.Generate(),
+		Credentials: clients.Credentials{Secret: testsutil.GenerateUUID(t)},
+		Metadata:    clients.Metadata{},
+		Status:      clients.EnabledStatus,
+	}
+	_, err := repo.Save(context.Background(), thing)
+	require.Nil(t, err, fmt.Sprintf("save thing unexpected error: %s", err))
+
+	return thing
+}
+
+func TestACLSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewACLRepository(database)
+	thing := saveThing(t)
+	channelID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		acl  acl.ACL
+		err  error
+	}{
+		{
+			desc: "save new ACL successfully",
+			acl: acl.ACL{
+				ThingID:   thing.ID,
+				ChannelID: channelID,
+				Publish:   []string{"sensors/#"},
+				Subscribe: []string{"commands/" + thing.ID},
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+			},
+		},
+		{
+			desc: "replace existing ACL successfully",
+			acl: acl.ACL{
+				ThingID:   thing.ID,
+				ChannelID: channelID,
+				Publish:   []string{"status"},
+				Subscribe: []string{},
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+			},
+		},
+		{
+			desc: "save ACL of non-existing thing",
+			acl: acl.ACL{
+				ThingID:   testsutil.GenerateUUID(t),
+				ChannelID: channelID,
+				Publish:   []string{},
+				Subscribe: []string{},
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := repo.Save(context.Background(), tc.acl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.acl, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.acl, saved))
+		}
+	}
+}
+
+func TestACLRetrieve(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewACLRepository(database)
+	thing := saveThing(t)
+
+	saved, err := repo.Save(context.Background(), acl.ACL{
+		ThingID:   thing.ID,
+		ChannelID: testsutil.GenerateUUID(t),
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{},
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	})
+	require.Nil(t, err, fmt.Sprintf("save ACL unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		thingID   string
+		channelID string
+		acl       acl.ACL
+		err       error
+	}{
+		{
+			desc:      "retrieve existing ACL",
+			thingID:   saved.ThingID,
+			channelID: saved.ChannelID,
+			acl:       saved,
+		},
+		{
+			desc:      "retrieve ACL of another channel",
+			thingID:   saved.ThingID,
+			channelID: testsutil.GenerateUUID(t),
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:      "retrieve ACL of another thing",
+			thingID:   testsutil.GenerateUUID(t),
+			channelID: saved.ChannelID,
+			err:       repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.Retrieve(context.Background(), tc.thingID, tc.channelID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.acl, res, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.acl, res))
+	}
+}
+
+func TestACLRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewACLRepository(database)
+	thing := saveThing(t)
+
+	saved, err := repo.Save(context.Background(), acl.ACL{
+		ThingID:   thing.ID,
+		ChannelID: testsutil.GenerateUUID(t),
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{},
+	})
+	require.Nil(t, err, fmt.Sprintf("save ACL unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		thingID   string
+		channelID string
+		err       error
+	}{
+		{
+			desc:      "remove existing ACL",
+			thingID:   saved.ThingID,
+			channelID: saved.ChannelID,
+		},
+		{
+			desc:      "remove non-existing ACL",
+			thingID:   saved.ThingID,
+			channelID: saved.ChannelID,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.thingID, tc.channelID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		_, err = repo.Retrieve(context.Background(), tc.thingID, tc.channelID)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s", tc.desc, repoerr.ErrNotFound, err))
+	}
+}
@@ -36,6 +36,23 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS clients`,
 				},
 			},
+			{
+				Id: "clients_02",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS connection_acls (
+						thing_id	VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						channel_id	VARCHAR(36) NOT NULL,
+						publish		TEXT[] NOT NULL DEFAULT '{}',
+						subscribe	TEXT[] NOT NULL DEFAULT '{}',
+						updated_at	TIMESTAMP,
+						updated_by	VARCHAR(254),
+						PRIMARY KEY	(thing_id, channel_id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS connection_acls`,
+				},
+			},
 		},
 	}
 }
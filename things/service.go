@@ -11,39 +11,123 @@ import (
 	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mggroups "github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things/acl"
 	"github.com/absmach/magistrala/things/postgres"
 	"golang.org/x/sync/errgroup"
 )
 
+// deleteBatch is the number of the things of the deleted domain
+// retrieved at once.
+const deleteBatch = 100
+
 type service struct {
 	auth        grpcclient.AuthServiceClient
 	policy      magistrala.PolicyServiceClient
 	clients     postgres.Repository
 	clientCache Cache
+	authzCache  AuthzCache
+	quotaCache  QuotaCache
+	acls        acl.Repository
 	idProvider  magistrala.IDProvider
 	grepo       mggroups.Repository
 }
 
 // NewService returns a new Clients service implementation.
-func NewService(auth grpcclient.AuthServiceClient, policy magistrala.PolicyServiceClient, c postgres.Repository, grepo mggroups.Repository, tcache Cache, idp magistrala.IDProvider) Service {
+func NewService(auth grpcclient.AuthServiceClient, policy magistrala.PolicyServiceClient, c postgres.Repository, grepo mggroups.Repository, tcache Cache, acache AuthzCache, qcache QuotaCache, acls acl.Repository, idp magistrala.IDProvider) Service {
 	return service{
 		auth:        auth,
 		policy:      policy,
 		clients:     c,
 		grepo:       grepo,
 		clientCache: tcache,
+		authzCache:  acache,
+		quotaCache:  qcache,
+		acls:        acls,
 		idProvider:  idp,
 	}
 }
 
 func (svc service) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
+	thingID, err := svc.authorizeThing(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	// Adapters authorize each published message, so the messages
+	// quota of the thing domain is checked here for all of them.
+	if req.GetPermission() == auth.PublishPermission {
+		if err := svc.checkMessagesQuota(ctx, thingID); err != nil {
+			return "", err
+		}
+	}
+
+	return thingID, nil
+}
+
+// checkMessagesQuota checks the messages quota of the thing domain. The
+// domain of the thing and whether the domain messages are limited are
+// cached, so the quota is checked only for the domains with the quota, and
+// the decisions of those domains are cached briefly.
+func (svc service) checkMessagesQuota(ctx context.Context, thingID string) error {
+	domainID, err := svc.quotaCache.Domain(ctx, thingID)
+	if err != nil {
+		thing, err := svc.clients.RetrieveByID(ctx, thingID)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		domainID = thing.Domain
+		if err := svc.quotaCache.SaveDomain(ctx, thingID, domainID); err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+	}
+	if limited, err := svc.quotaCache.Limited(ctx, domainID); err == nil && !limited {
+		return nil
+	}
+	if allowed, err := svc.quotaCache.Allowed(ctx, domainID); err == nil {
+		if !allowed {
+			return svcerr.ErrQuotaExceeded
+		}
+		return nil
+	}
+
+	res, err := svc.auth.CheckQuota(ctx, &magistrala.CheckQuotaReq{DomainId: domainID, Resource: auth.MessagesQuota, Count: 1})
+	if err != nil {
+		// Only the exceeded quota is a decision, the failed checks are not cached.
+		if errors.Contains(err, svcerr.ErrQuotaExceeded) {
+			if cerr := svc.quotaCache.SaveAllowed(ctx, domainID, false); cerr != nil {
+				return errors.Wrap(svcerr.ErrAuthorization, cerr)
+			}
+		}
+		return err
+	}
+	if err := svc.quotaCache.SaveLimited(ctx, domainID, res.GetLimited()); err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if res.GetLimited() {
+		if err := svc.quotaCache.SaveAllowed(ctx, domainID, true); err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) authorizeThing(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
 	thingID, err := svc.Identify(ctx, req.GetSubject())
 	if err != nil {
 		return "", err
 	}
 
+	allowed, err := svc.authzCache.Decision(ctx, thingID, req.GetObject(), req.GetPermission(), req.GetSubtopic())
+	if err == nil {
+		if !allowed {
+			return "", svcerr.ErrAuthorization
+		}
+		return thingID, nil
+	}
+
 	r := &magistrala.AuthorizeReq{
 		SubjectType: auth.GroupType,
 		Subject:     req.GetObject(),
@@ -52,10 +136,23 @@ func (svc service) Authorize(ctx context.Context, req *magistrala.AuthorizeReq)
 		Permission:  req.GetPermission(),
 	}
 	resp, err := svc.auth.Authorize(ctx, r)
+	// Only the decisions are cached, the failed checks are not.
+	if err != nil && !errors.Contains(err, svcerr.ErrAuthorization) {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	allowed = err == nil && resp.GetAuthorized()
+	if allowed {
+		if allowed, err = svc.subtopicAllowed(ctx, thingID, req); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+	}
+	if cerr := svc.authzCache.Save(ctx, thingID, req.GetObject(), req.GetPermission(), req.GetSubtopic(), allowed); cerr != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, cerr)
+	}
 	if err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
-	if !resp.GetAuthorized() {
+	if !allowed {
 		return "", svcerr.ErrAuthorization
 	}
 
@@ -68,7 +165,7 @@ func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclie
 		return []mgclients.Client{}, err
 	}
 	// If domain is disabled , then this authorization will fail for all non-admin domain users
-	if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, user.GetId(), auth.CreatePermission, auth.DomainType, user.GetDomainId()); err != nil {
+	if _, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.CreatePermission, auth.DomainType, user.GetDomainId()); err != nil {
 		return []mgclients.Client{}, err
 	}
 
@@ -96,7 +193,19 @@ func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclie
 		clients = append(clients, c)
 	}
 
-	if err := svc.addThingPolicies(ctx, user.GetId(), user.GetDomainId(), clients); err != nil {
+	reserved, err := svc.reserveQuota(ctx, user.GetDomainId(), auth.ThingsQuota, clients)
+	if err != nil {
+		return []mgclients.Client{}, err
+	}
+	defer func() {
+		if err != nil {
+			if errRelease := svc.releaseQuota(ctx, user.GetDomainId(), auth.ThingsQuota, reserved); errRelease != nil {
+				err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRelease), err)
+			}
+		}
+	}()
+
+	if err = svc.addThingPolicies(ctx, user.GetId(), user.GetDomainId(), clients); err != nil {
 		return []mgclients.Client{}, err
 	}
 	defer func() {
@@ -154,28 +263,29 @@ func (svc service) ListClients(ctx context.Context, token, reqUserID string, pm
 	switch {
 	case (reqUserID != "" && reqUserID != res.GetUserId()):
 		// Check user is admin of domain, if yes then show listing on domain context
-		if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
+		if _, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
 			return mgclients.ClientsPage{}, err
 		}
-		rtids, err := svc.listClientIDs(ctx, auth.EncodeDomainUserID(res.GetDomainId(), reqUserID), pm.Permission)
+		rtids, err := svc.listClientIDs(ctx, auth.UsersKind, auth.EncodeDomainUserID(res.GetDomainId(), reqUserID), pm.Permission)
 		if err != nil {
 			return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrNotFound, err)
 		}
-		ids, err = svc.filterAllowedThingIDs(ctx, res.GetId(), pm.Permission, rtids)
+		ids, err = svc.filterAllowedThingIDs(ctx, token, pm.Permission, rtids)
 		if err != nil {
 			return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrNotFound, err)
 		}
 	default:
-		err := svc.checkSuperAdmin(ctx, res.GetUserId())
+		err := svc.checkSuperAdmin(ctx, token)
 		switch {
 		case err == nil:
 			pm.Domain = res.GetDomainId()
 		default:
-			// If domain is disabled , then this authorization will fail for all non-admin domain users
+			// If domain is disabled , then this authorization will fail for all non-admin domain users.
+			// The things are listed with the token, so the scoped API keys list only the things of their scopes.
 			if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.MembershipPermission, auth.DomainType, res.GetDomainId()); err != nil {
 				return mgclients.ClientsPage{}, err
 			}
-			ids, err = svc.listClientIDs(ctx, res.GetId(), pm.Permission)
+			ids, err = svc.listClientIDs(ctx, auth.TokenKind, token, pm.Permission)
 			if err != nil {
 				return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrNotFound, err)
 			}
@@ -232,10 +342,11 @@ func (svc service) listUserThingPermission(ctx context.Context, userID, thingID
 	return lp.GetPermissions(), nil
 }
 
-func (svc service) listClientIDs(ctx context.Context, userID, permission string) ([]string, error) {
+func (svc service) listClientIDs(ctx context.Context, subjKind, subject, permission string) ([]string, error) {
 	tids, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: subjKind,
+		Subject:     subject,
 		Permission:  permission,
 		ObjectType:  auth.ThingType,
 	})
@@ -245,11 +356,12 @@ func (svc service) listClientIDs(ctx context.Context, userID, permission string)
 	return tids.Policies, nil
 }
 
-func (svc service) filterAllowedThingIDs(ctx context.Context, userID, permission string, thingIDs []string) ([]string, error) {
+func (svc service) filterAllowedThingIDs(ctx context.Context, token, permission string, thingIDs []string) ([]string, error) {
 	var ids []string
 	tids, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  permission,
 		ObjectType:  auth.ThingType,
 	})
@@ -266,10 +378,11 @@ func (svc service) filterAllowedThingIDs(ctx context.Context, userID, permission
 	return ids, nil
 }
 
-func (svc service) checkSuperAdmin(ctx context.Context, userID string) error {
+func (svc service) checkSuperAdmin(ctx context.Context, token string) error {
 	res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
 		SubjectType: auth.UserType,
-		Subject:     userID,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
 		Permission:  auth.AdminPermission,
 		ObjectType:  auth.PlatformType,
 		Object:      auth.MagistralaObject,
@@ -372,28 +485,43 @@ func (svc service) DisableClient(ctx context.Context, token, id string) (mgclien
 	if err := svc.clientCache.Remove(ctx, client.ID); err != nil {
 		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
+	if err := svc.authzCache.RemoveThing(ctx, client.ID); err != nil {
+		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
 
 	return client, nil
 }
 
-func (svc service) Share(ctx context.Context, token, id, relation string, userids ...string) error {
+func (svc service) Share(ctx context.Context, token, id, relation string, cond *auth.Condition, userids ...string) error {
 	user, err := svc.identify(ctx, token)
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.UsersKind, user.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
+	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.ThingType, id); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if cond != nil {
+		if err := cond.Validate(); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
 
 	policies := magistrala.AddPoliciesReq{}
 	for _, userid := range userids {
-		policies.AddPoliciesReq = append(policies.AddPoliciesReq, &magistrala.AddPolicyReq{
+		pr := &magistrala.AddPolicyReq{
 			SubjectType: auth.UserType,
 			Subject:     auth.EncodeDomainUserID(user.GetDomainId(), userid),
 			Relation:    relation,
 			ObjectType:  auth.ThingType,
 			Object:      id,
-		})
+		}
+		if cond != nil {
+			if !cond.ExpiresAt.IsZero() {
+				pr.ExpiresAt = cond.ExpiresAt.Unix()
+			}
+			pr.AllowedCidrs = cond.AllowedCIDRs
+		}
+		policies.AddPoliciesReq = append(policies.AddPoliciesReq, pr)
 	}
 	res, err := svc.policy.AddPolicies(ctx, &policies)
 	if err != nil {
@@ -410,7 +538,7 @@ func (svc service) Unshare(ctx context.Context, token, id, relation string, user
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.UsersKind, user.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
+	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.ThingType, id); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
@@ -439,13 +567,19 @@ func (svc service) DeleteClient(ctx context.Context, token, id string) error {
 	if err != nil {
 		return err
 	}
-	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.ThingType, id); err != nil {
 		return err
 	}
 
 	if err := svc.clientCache.Remove(ctx, id); err != nil {
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
+	if err := svc.authzCache.RemoveThing(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.quotaCache.RemoveThing(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
 
 	deleteRes, err := svc.policy.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
 		EntityType: auth.ThingType,
@@ -465,6 +599,133 @@ func (svc service) DeleteClient(ctx context.Context, token, id string) error {
 	return nil
 }
 
+func (svc service) DeleteDomainClients(ctx context.Context, domainID string) ([]string, error) {
+	pm := mgclients.Page{
+		Limit:  deleteBatch,
+		Domain: domainID,
+		Status: mgclients.AllStatus,
+	}
+
+	// The deleted things are not retrieved again, so the first page is
+	// retrieved until the domain has no things left.
+	var ids []string
+	for {
+		tp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
+		if err != nil {
+			return ids, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(tp.Clients) == 0 {
+			return ids, nil
+		}
+		for _, c := range tp.Clients {
+			if err := svc.clientCache.Remove(ctx, c.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			if err := svc.authzCache.RemoveThing(ctx, c.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			if err := svc.quotaCache.RemoveThing(ctx, c.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			deleteRes, err := svc.policy.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
+				EntityType: auth.ThingType,
+				Id:         c.ID,
+			})
+			if err != nil {
+				return ids, errors.Wrap(svcerr.ErrDeletePolicies, err)
+			}
+			if !deleteRes.Deleted {
+				return ids, svcerr.ErrDeletePolicies
+			}
+			if err := svc.clients.Delete(ctx, c.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			ids = append(ids, c.ID)
+		}
+	}
+}
+
+func (svc service) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error) {
+	res, err := svc.identify(ctx, token)
+	if err != nil {
+		return acl.ACL{}, err
+	}
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.GroupType, connACL.ChannelID); err != nil {
+		return acl.ACL{}, err
+	}
+	if err := connACL.Validate(); err != nil {
+		return acl.ACL{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if connACL.Publish == nil {
+		connACL.Publish = []string{}
+	}
+	if connACL.Subscribe == nil {
+		connACL.Subscribe = []string{}
+	}
+	connACL.UpdatedAt = time.Now()
+	connACL.UpdatedBy = res.GetId()
+
+	saved, err := svc.acls.Save(ctx, connACL)
+	if err != nil {
+		return acl.ACL{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	if err := svc.authzCache.RemoveThing(ctx, connACL.ThingID); err != nil {
+		return acl.ACL{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc service) ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (acl.ACL, error) {
+	res, err := svc.identify(ctx, token)
+	if err != nil {
+		return acl.ACL{}, err
+	}
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.ViewPermission, auth.GroupType, channelID); err != nil {
+		return acl.ACL{}, err
+	}
+
+	connACL, err := svc.acls.Retrieve(ctx, thingID, channelID)
+	if err != nil {
+		return acl.ACL{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return connACL, nil
+}
+
+func (svc service) RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) error {
+	res, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.GroupType, channelID); err != nil {
+		return err
+	}
+
+	if err := svc.acls.Remove(ctx, thingID, channelID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.authzCache.RemoveThing(ctx, thingID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// subtopicAllowed checks the subtopic against the ACL of the thing connection
+// to the channel. The connection without the ACL allows the whole channel.
+func (svc service) subtopicAllowed(ctx context.Context, thingID string, req *magistrala.AuthorizeReq) (bool, error) {
+	connACL, err := svc.acls.Retrieve(ctx, thingID, req.GetObject())
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+
+	return connACL.Allows(req.GetPermission(), req.GetSubtopic()), nil
+}
+
 func (svc service) changeClientStatus(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
 	userID, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.ThingType, client.ID)
 	if err != nil {
@@ -492,7 +753,7 @@ func (svc service) ListClientsByGroup(ctx context.Context, token, groupID string
 	if err != nil {
 		return mgclients.MembersPage{}, err
 	}
-	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), pm.Permission, auth.GroupType, groupID); err != nil {
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.TokenKind, token, pm.Permission, auth.GroupType, groupID); err != nil {
 		return mgclients.MembersPage{}, err
 	}
 
@@ -563,6 +824,32 @@ func (svc service) identify(ctx context.Context, token string) (*magistrala.Iden
 	return res, nil
 }
 
+// reserveQuota reserves the things quota of the domain for the clients and
+// returns the IDs to release if creating the clients fails.
+func (svc service) reserveQuota(ctx context.Context, domainID, resource string, clients []mgclients.Client) ([]string, error) {
+	ids := make([]string, len(clients))
+	for i, c := range clients {
+		ids[i] = c.ID
+	}
+	res, err := svc.auth.ReserveQuota(ctx, &magistrala.ReserveQuotaReq{DomainId: domainID, Resource: resource, Ids: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetReservedIds(), nil
+}
+
+func (svc service) releaseQuota(ctx context.Context, domainID, resource string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := svc.auth.ReleaseQuota(ctx, &magistrala.ReleaseQuotaReq{DomainId: domainID, Resource: resource, Ids: ids}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (svc *service) authorize(ctx context.Context, domainID, subjType, subjKind, subj, perm, objType, obj string) (string, error) {
 	req := &magistrala.AuthorizeReq{
 		Domain:      domainID,
@@ -19,10 +19,13 @@ import (
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
+	aclmocks "github.com/absmach/magistrala/things/acl/mocks"
 	"github.com/absmach/magistrala/things/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -44,6 +47,9 @@ var (
 	validID           = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 	wrongID           = testsutil.GenerateUUID(&testing.T{})
 	errRemovePolicies = errors.New("failed to delete policies")
+	authzCache        *mocks.AuthzCache
+	quotaCache        *mocks.QuotaCache
+	aclRepo           *aclmocks.Repository
 )
 
 func newService() (things.Service, *mocks.Repository, *authmocks.AuthServiceClient, *authmocks.PolicyServiceClient, *mocks.Cache) {
@@ -53,8 +59,38 @@ func newService() (things.Service, *mocks.Repository, *authmocks.AuthServiceClie
 	idProvider := uuid.NewMock()
 	cRepo := new(mocks.Repository)
 	gRepo := new(gmocks.Repository)
+	authzCache = newAuthzCacheMock()
+	quotaCache = newQuotaCacheMock()
+	aclRepo = new(aclmocks.Repository)
 
-	return things.NewService(auth, policyClient, cRepo, gRepo, thingCache, idProvider), cRepo, auth, policyClient, thingCache
+	return things.NewService(auth, policyClient, cRepo, gRepo, thingCache, authzCache, quotaCache, aclRepo, idProvider), cRepo, auth, policyClient, thingCache
+}
+
+// newQuotaCacheMock returns the quota cache
+// with no cached domains and quotas.
+func newQuotaCacheMock() *mocks.QuotaCache {
+	cache := new(mocks.QuotaCache)
+	cache.On("Domain", mock.Anything, mock.Anything).Return("", repoerr.ErrNotFound)
+	cache.On("SaveDomain", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("Limited", mock.Anything, mock.Anything).Return(false, repoerr.ErrNotFound)
+	cache.On("SaveLimited", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("Allowed", mock.Anything, mock.Anything).Return(false, repoerr.ErrNotFound)
+	cache.On("SaveAllowed", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("RemoveThing", mock.Anything, mock.Anything).Return(nil)
+
+	return cache
+}
+
+// newAuthzCacheMock returns the authorization
+// decisions cache with no cached decisions.
+func newAuthzCacheMock() *mocks.AuthzCache {
+	cache := new(mocks.AuthzCache)
+	cache.On("Decision", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, repoerr.ErrNotFound)
+	cache.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("RemoveThing", mock.Anything, mock.Anything).Return(nil)
+	cache.On("RemoveChannel", mock.Anything, mock.Anything).Return(nil)
+
+	return cache
 }
 
 func TestCreateThings(t *testing.T) {
@@ -69,6 +105,7 @@ func TestCreateThings(t *testing.T) {
 		deletePolicyRes   *magistrala.DeletePolicyRes
 		authorizeErr      error
 		identifyErr       error
+		quotaErr          error
 		addPolicyErr      error
 		deletePolicyErr   error
 		saveErr           error
@@ -308,6 +345,20 @@ func TestCreateThings(t *testing.T) {
 			addPolicyErr:      svcerr.ErrInvalidPolicy,
 			err:               svcerr.ErrInvalidPolicy,
 		},
+		{
+			desc: "create a new thing with exceeded things quota",
+			thing: mgclients.Client{
+				Credentials: mgclients.Credentials{
+					Identity: "newclientwithexceededquota@example.com",
+					Secret:   secret,
+				},
+				Status: mgclients.EnabledStatus,
+			},
+			token:        validToken,
+			authResponse: &magistrala.AuthorizeRes{Authorized: true},
+			quotaErr:     svcerr.ErrQuotaExceeded,
+			err:          svcerr.ErrQuotaExceeded,
+		},
 		{
 			desc: "create a new thing with failed delete policy response",
 			thing: mgclients.Client{
@@ -330,6 +381,8 @@ func TestCreateThings(t *testing.T) {
 	for _, tc := range cases {
 		repoCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)}, tc.identifyErr)
 		authcall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authResponse, tc.authorizeErr)
+		quotaCall := auth.On("ReserveQuota", mock.Anything, mock.Anything).Return(&magistrala.ReserveQuotaRes{ReservedIds: []string{validID}}, tc.quotaErr)
+		quotaCall1 := auth.On("ReleaseQuota", mock.Anything, mock.Anything).Return(&magistrala.ReleaseQuotaRes{Released: true}, nil)
 		repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return([]mgclients.Client{tc.thing}, tc.saveErr)
 		authCall1 := policy.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPolicyResponse, tc.addPolicyErr)
 		authCall2 := policy.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePolicyRes, tc.deletePolicyErr)
@@ -344,8 +397,16 @@ func TestCreateThings(t *testing.T) {
 			tc.thing.UpdatedBy = expected[0].UpdatedBy
 			assert.Equal(t, tc.thing, expected[0], fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.thing, expected[0]))
 		}
+		if tc.quotaErr == nil && (tc.addPolicyErr != nil || tc.saveErr != nil) {
+			ok := auth.AssertCalled(t, "ReleaseQuota", mock.Anything, mock.MatchedBy(func(req *magistrala.ReleaseQuotaReq) bool {
+				return req.GetResource() == authsvc.ThingsQuota && len(req.GetIds()) == 1 && req.GetIds()[0] == validID
+			}))
+			assert.True(t, ok, fmt.Sprintf("%s: expected the reserved things to be released", tc.desc))
+		}
 		repoCall.Unset()
 		authcall.Unset()
+		quotaCall.Unset()
+		quotaCall1.Unset()
 		repoCall1.Unset()
 		authCall1.Unset()
 		authCall2.Unset()
@@ -609,7 +670,8 @@ func TestListClients(t *testing.T) {
 		repoCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
 		authorizeCall := auth.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
 			SubjectType: authsvc.UserType,
-			Subject:     tc.identifyResponse.UserId,
+			SubjectKind: authsvc.TokenKind,
+			Subject:     tc.token,
 			Permission:  authsvc.AdminPermission,
 			ObjectType:  authsvc.PlatformType,
 			Object:      authsvc.MagistralaObject,
@@ -618,7 +680,7 @@ func TestListClients(t *testing.T) {
 			Domain:      "",
 			SubjectType: authsvc.UserType,
 			SubjectKind: authsvc.UsersKind,
-			Subject:     tc.identifyResponse.UserId,
+			Subject:     tc.identifyResponse.Id,
 			Permission:  "membership",
 			ObjectType:  "domain",
 			Object:      tc.identifyResponse.DomainId,
@@ -800,13 +862,15 @@ func TestListClients(t *testing.T) {
 		authorizeCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		listAllObjectsCall := policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 			SubjectType: authsvc.UserType,
+			SubjectKind: authsvc.UsersKind,
 			Subject:     tc.identifyResponse.DomainId + "_" + adminID,
 			Permission:  "",
 			ObjectType:  authsvc.ThingType,
 		}).Return(tc.listObjectsResponse, tc.listObjectsErr)
 		listAllObjectsCall2 := policy.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
 			SubjectType: authsvc.UserType,
-			Subject:     tc.identifyResponse.Id,
+			SubjectKind: authsvc.TokenKind,
+			Subject:     tc.token,
 			Permission:  "",
 			ObjectType:  authsvc.ThingType,
 		}).Return(tc.listObjectsResponse1, tc.listObjectsErr1)
@@ -1701,6 +1765,93 @@ func TestDeleteClient(t *testing.T) {
 	}
 }
 
+func TestDeleteDomainClients(t *testing.T) {
+	svc, cRepo, _, policy, cache := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	client := mgclients.Client{
+		ID:     testsutil.GenerateUUID(t),
+		Domain: domainID,
+	}
+
+	cases := []struct {
+		desc                 string
+		domainID             string
+		retrieveResponse     mgclients.ClientsPage
+		deletePolicyResponse *magistrala.DeletePolicyRes
+		retrieveErr          error
+		removeErr            error
+		deletePolicyErr      error
+		deleteErr            error
+		ids                  []string
+		err                  error
+	}{
+		{
+			desc:                 "delete domain things successfully",
+			domainID:             domainID,
+			retrieveResponse:     mgclients.ClientsPage{Clients: []mgclients.Client{client}},
+			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: true},
+			ids:                  []string{client.ID},
+			err:                  nil,
+		},
+		{
+			desc:             "delete domain things of the domain without things",
+			domainID:         domainID,
+			retrieveResponse: mgclients.ClientsPage{},
+			err:              nil,
+		},
+		{
+			desc:        "delete domain things with failed to retrieve things",
+			domainID:    domainID,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:             "delete domain things with cache error",
+			domainID:         domainID,
+			retrieveResponse: mgclients.ClientsPage{Clients: []mgclients.Client{client}},
+			removeErr:        svcerr.ErrRemoveEntity,
+			err:              svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:                 "delete domain things with failed to delete policies",
+			domainID:             domainID,
+			retrieveResponse:     mgclients.ClientsPage{Clients: []mgclients.Client{client}},
+			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: false},
+			deletePolicyErr:      errRemovePolicies,
+			err:                  svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:                 "delete domain things with repo error",
+			domainID:             domainID,
+			retrieveResponse:     mgclients.ClientsPage{Clients: []mgclients.Client{client}},
+			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: true},
+			deleteErr:            repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := mgclients.Page{Limit: 100, Domain: tc.domainID, Status: mgclients.AllStatus}
+		// The first page is retrieved until the domain has no things left.
+		cRepo.On("RetrieveAllByIDs", context.Background(), pm).Return(tc.retrieveResponse, tc.retrieveErr).Once()
+		repoCall1 := cRepo.On("RetrieveAllByIDs", context.Background(), pm).Return(mgclients.ClientsPage{}, nil)
+		repoCall2 := cache.On("Remove", mock.Anything, client.ID).Return(tc.removeErr)
+		repoCall3 := policy.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
+			EntityType: authsvc.ThingType,
+			Id:         client.ID,
+		}).Return(tc.deletePolicyResponse, tc.deletePolicyErr)
+		repoCall4 := cRepo.On("Delete", context.Background(), client.ID).Return(tc.deleteErr)
+		ids, err := svc.DeleteDomainClients(context.Background(), tc.domainID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
 func TestShare(t *testing.T) {
 	svc, _, auth, policy, _ := newService()
 
@@ -1771,7 +1922,7 @@ func TestShare(t *testing.T) {
 		repoCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
 		repoCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		repoCall2 := policy.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesResponse, tc.addPoliciesErr)
-		err := svc.Share(context.Background(), tc.token, tc.clientID, tc.relation, tc.userID)
+		err := svc.Share(context.Background(), tc.token, tc.clientID, tc.relation, nil, tc.userID)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
 		repoCall1.Unset()
@@ -1857,6 +2008,64 @@ func TestUnShare(t *testing.T) {
 	}
 }
 
+func TestScopedAPIKey(t *testing.T) {
+	svc, _, auth, _, _ := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	key := authsvc.Key{Scopes: []authsvc.Scope{{Operation: "things:view"}}}
+	// The auth service applies the key scopes only to the requests authorized
+	// with the token, so the mock allows every other request.
+	authorize := func(_ context.Context, req *magistrala.AuthorizeReq, _ ...grpc.CallOption) (*magistrala.AuthorizeRes, error) {
+		if req.GetSubjectKind() != authsvc.TokenKind || req.GetSubject() != validToken {
+			return &magistrala.AuthorizeRes{Authorized: true}, nil
+		}
+		if !key.Allows(req.GetObjectType(), req.GetPermission(), req.GetObject()) {
+			return &magistrala.AuthorizeRes{Authorized: false}, svcerr.ErrAuthorization
+		}
+		return &magistrala.AuthorizeRes{Authorized: true}, nil
+	}
+
+	cases := []struct {
+		desc string
+		op   func() error
+	}{
+		{
+			desc: "create thing with view scoped key",
+			op: func() error {
+				_, err := svc.CreateThings(context.Background(), validToken, client)
+				return err
+			},
+		},
+		{
+			desc: "delete thing with view scoped key",
+			op: func() error {
+				return svc.DeleteClient(context.Background(), validToken, client.ID)
+			},
+		},
+		{
+			desc: "share thing with view scoped key",
+			op: func() error {
+				return svc.Share(context.Background(), validToken, client.ID, authsvc.GuestRelation, nil, testsutil.GenerateUUID(t))
+			},
+		},
+		{
+			desc: "unshare thing with view scoped key",
+			op: func() error {
+				return svc.Unshare(context.Background(), validToken, client.ID, authsvc.GuestRelation, testsutil.GenerateUUID(t))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: domainID + "_" + validID, UserId: validID, DomainId: domainID}, nil)
+		repoCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(authorize)
+		err := tc.op()
+		assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, svcerr.ErrAuthorization, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestViewClientPerms(t *testing.T) {
 	svc, _, auth, policy, _ := newService()
 
@@ -2075,6 +2284,7 @@ func TestAuthorize(t *testing.T) {
 		repoCall := cRepo.On("RetrieveBySecret", context.Background(), tc.request.GetSubject()).Return(tc.retrieveBySecretRes, tc.retrieveBySecretErr)
 		cacheCall1 := cache.On("Save", context.Background(), tc.request.GetSubject(), tc.retrieveBySecretRes.ID).Return(tc.cacheSaveErr)
 		authCall := auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeRes, tc.authErr)
+		repoCall1 := aclRepo.On("Retrieve", context.Background(), mock.Anything, tc.request.GetObject()).Return(acl.ACL{}, repoerr.ErrNotFound)
 		id, err := svc.Authorize(context.Background(), tc.request)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		if tc.err == nil {
@@ -2083,6 +2293,280 @@ func TestAuthorize(t *testing.T) {
 		cacheCall.Unset()
 		cacheCall1.Unset()
 		repoCall.Unset()
+		repoCall1.Unset()
+		authCall.Unset()
+	}
+}
+
+func TestAuthorizeSubtopic(t *testing.T) {
+	svc, cRepo, auth, _, cache := newService()
+
+	connACL := acl.ACL{
+		ThingID:   ID,
+		ChannelID: validID,
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{"commands/" + ID},
+	}
+
+	cases := []struct {
+		desc       string
+		permission string
+		subtopic   string
+		acl        acl.ACL
+		aclErr     error
+		saved      bool
+		err        error
+	}{
+		{
+			desc:       "authorize publish without connection ACL",
+			permission: authsvc.PublishPermission,
+			subtopic:   "commands",
+			aclErr:     repoerr.ErrNotFound,
+			saved:      true,
+		},
+		{
+			desc:       "authorize publish to allowed subtopic",
+			permission: authsvc.PublishPermission,
+			subtopic:   "sensors.temperature",
+			acl:        connACL,
+			saved:      true,
+		},
+		{
+			desc:       "authorize publish to not allowed subtopic",
+			permission: authsvc.PublishPermission,
+			subtopic:   "commands." + ID,
+			acl:        connACL,
+			saved:      false,
+			err:        svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "authorize subscribe to allowed subtopic",
+			permission: authsvc.SubscribePermission,
+			subtopic:   "commands." + ID,
+			acl:        connACL,
+			saved:      true,
+		},
+		{
+			desc:       "authorize subscribe to the whole channel",
+			permission: authsvc.SubscribePermission,
+			subtopic:   ">",
+			acl:        connACL,
+			saved:      false,
+			err:        svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "authorize with failed to retrieve connection ACL",
+			permission: authsvc.PublishPermission,
+			subtopic:   "sensors",
+			aclErr:     repoerr.ErrViewEntity,
+			err:        svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := &magistrala.AuthorizeReq{Subject: valid, Object: validID, Permission: tc.permission, Subtopic: tc.subtopic}
+		cacheCall := cache.On("ID", mock.Anything, valid).Return(ID, nil)
+		authCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		repoCall := aclRepo.On("Retrieve", mock.Anything, ID, validID).Return(tc.acl, tc.aclErr)
+		repoCall1 := cRepo.On("RetrieveByID", mock.Anything, ID).Return(mgclients.Client{ID: ID, Domain: validID}, nil)
+		quotaCall := auth.On("CheckQuota", mock.Anything, mock.Anything).Return(&magistrala.CheckQuotaRes{Allowed: true}, nil)
+		_, err := svc.Authorize(context.Background(), req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.aclErr == nil || errors.Contains(tc.aclErr, repoerr.ErrNotFound) {
+			ok := authzCache.AssertCalled(t, "Save", mock.Anything, ID, validID, tc.permission, tc.subtopic, tc.saved)
+			assert.True(t, ok, fmt.Sprintf("%s: expected the decision to be cached", tc.desc))
+		}
+		cacheCall.Unset()
+		authCall.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+		quotaCall.Unset()
+	}
+}
+
+func TestAuthorizeMessagesQuota(t *testing.T) {
+	cases := []struct {
+		desc         string
+		permission   string
+		domain       string
+		domainErr    error
+		limited      bool
+		limitedErr   error
+		allowed      bool
+		allowedErr   error
+		retrieveErr  error
+		quotaLimited bool
+		quotaErr     error
+		checked      bool
+		err          error
+	}{
+		{
+			desc:         "authorize publish within messages quota",
+			permission:   authsvc.PublishPermission,
+			domainErr:    repoerr.ErrNotFound,
+			limitedErr:   repoerr.ErrNotFound,
+			allowedErr:   repoerr.ErrNotFound,
+			quotaLimited: true,
+			checked:      true,
+		},
+		{
+			desc:         "authorize publish with exceeded messages quota",
+			permission:   authsvc.PublishPermission,
+			domainErr:    repoerr.ErrNotFound,
+			limitedErr:   repoerr.ErrNotFound,
+			allowedErr:   repoerr.ErrNotFound,
+			quotaLimited: true,
+			quotaErr:     svcerr.ErrQuotaExceeded,
+			checked:      true,
+			err:          svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:        "authorize publish with failed to retrieve thing",
+			permission:  authsvc.PublishPermission,
+			domainErr:   repoerr.ErrNotFound,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "authorize publish with cached domain without messages quota",
+			permission: authsvc.PublishPermission,
+			domain:     validID,
+			limited:    false,
+		},
+		{
+			desc:         "authorize publish with cached domain with exceeded messages quota",
+			permission:   authsvc.PublishPermission,
+			domain:       validID,
+			limited:      true,
+			allowedErr:   repoerr.ErrNotFound,
+			quotaLimited: true,
+			quotaErr:     svcerr.ErrQuotaExceeded,
+			checked:      true,
+			err:          svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:       "authorize publish with cached messages quota decision",
+			permission: authsvc.PublishPermission,
+			domain:     validID,
+			limited:    true,
+			allowed:    true,
+		},
+		{
+			desc:       "authorize publish with cached exceeded messages quota decision",
+			permission: authsvc.PublishPermission,
+			domain:     validID,
+			limited:    true,
+			allowed:    false,
+			err:        svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:       "authorize subscribe with exceeded messages quota",
+			permission: authsvc.SubscribePermission,
+			quotaErr:   svcerr.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, _, cache := newService()
+		quotaCache = new(mocks.QuotaCache)
+		svc = things.NewService(auth, new(authmocks.PolicyServiceClient), cRepo, new(gmocks.Repository), cache, authzCache, quotaCache, aclRepo, uuid.NewMock())
+		req := &magistrala.AuthorizeReq{Subject: valid, Object: validID, Permission: tc.permission}
+		cache.On("ID", mock.Anything, valid).Return(ID, nil)
+		auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		aclRepo.On("Retrieve", mock.Anything, ID, validID).Return(acl.ACL{}, repoerr.ErrNotFound)
+		quotaCache.On("Domain", mock.Anything, ID).Return(tc.domain, tc.domainErr)
+		cRepo.On("RetrieveByID", mock.Anything, ID).Return(mgclients.Client{ID: ID, Domain: validID}, tc.retrieveErr)
+		quotaCache.On("SaveDomain", mock.Anything, ID, validID).Return(nil)
+		quotaCache.On("Limited", mock.Anything, validID).Return(tc.limited, tc.limitedErr)
+		quotaCache.On("Allowed", mock.Anything, validID).Return(tc.allowed, tc.allowedErr)
+		auth.On("CheckQuota", mock.Anything, &magistrala.CheckQuotaReq{DomainId: validID, Resource: authsvc.MessagesQuota, Count: 1}).Return(&magistrala.CheckQuotaRes{Allowed: tc.quotaErr == nil, Limited: tc.quotaLimited}, tc.quotaErr)
+		quotaCache.On("SaveLimited", mock.Anything, validID, tc.quotaLimited).Return(nil)
+		quotaCache.On("SaveAllowed", mock.Anything, validID, tc.quotaErr == nil).Return(nil)
+		_, err := svc.Authorize(context.Background(), req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.checked {
+			auth.AssertCalled(t, "CheckQuota", mock.Anything, mock.Anything)
+		} else {
+			auth.AssertNotCalled(t, "CheckQuota", mock.Anything, mock.Anything)
+		}
+		if tc.checked && tc.quotaErr == nil {
+			quotaCache.AssertCalled(t, "SaveLimited", mock.Anything, validID, tc.quotaLimited)
+		}
+		if tc.checked {
+			quotaCache.AssertCalled(t, "SaveAllowed", mock.Anything, validID, tc.quotaErr == nil)
+		}
+	}
+}
+
+func TestAuthorizeCachedDecision(t *testing.T) {
+	svc, _, auth, _, cache := newService()
+	authzCache = new(mocks.AuthzCache)
+	aclRepo = new(aclmocks.Repository)
+	cRepo := new(mocks.Repository)
+	svc = things.NewService(auth, new(authmocks.PolicyServiceClient), cRepo, new(gmocks.Repository), cache, authzCache, newQuotaCacheMock(), aclRepo, uuid.NewMock())
+	cRepo.On("RetrieveByID", mock.Anything, ID).Return(mgclients.Client{ID: ID, Domain: validID}, nil)
+	auth.On("CheckQuota", mock.Anything, mock.Anything).Return(&magistrala.CheckQuotaRes{Allowed: true}, nil)
+
+	req := &magistrala.AuthorizeReq{Subject: valid, Object: validID, Permission: authsvc.PublishPermission}
+
+	cases := []struct {
+		desc         string
+		decision     bool
+		decisionErr  error
+		authorizeRes *magistrala.AuthorizeRes
+		authErr      error
+		saved        bool
+		saveErr      error
+		err          error
+	}{
+		{
+			desc:     "authorize with cached allowed decision",
+			decision: true,
+		},
+		{
+			desc:     "authorize with cached denied decision",
+			decision: false,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "authorize with not cached allowed decision",
+			decisionErr:  repoerr.ErrNotFound,
+			authorizeRes: &magistrala.AuthorizeRes{Authorized: true},
+			saved:        true,
+		},
+		{
+			desc:         "authorize with not cached denied decision",
+			decisionErr:  repoerr.ErrNotFound,
+			authorizeRes: &magistrala.AuthorizeRes{},
+			authErr:      svcerr.ErrAuthorization,
+			saved:        false,
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "authorize with not cached decision and failed to save decision",
+			decisionErr:  repoerr.ErrNotFound,
+			authorizeRes: &magistrala.AuthorizeRes{Authorized: true},
+			saved:        true,
+			saveErr:      repoerr.ErrCreateEntity,
+			err:          svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		cacheCall := cache.On("ID", mock.Anything, valid).Return(ID, nil)
+		cacheCall1 := authzCache.On("Decision", mock.Anything, ID, validID, authsvc.PublishPermission, "").Return(tc.decision, tc.decisionErr)
+		cacheCall2 := authzCache.On("Save", mock.Anything, ID, validID, authsvc.PublishPermission, "", tc.saved).Return(tc.saveErr)
+		repoCall := aclRepo.On("Retrieve", mock.Anything, ID, validID).Return(acl.ACL{}, repoerr.ErrNotFound)
+		authCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeRes, tc.authErr)
+		id, err := svc.Authorize(context.Background(), req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, ID, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, ID, id))
+		}
+		cacheCall.Unset()
+		cacheCall1.Unset()
+		cacheCall2.Unset()
+		repoCall.Unset()
 		authCall.Unset()
 	}
 }
@@ -2094,3 +2578,213 @@ func getIDs(clients []mgclients.Client) []string {
 	}
 	return ids
 }
+
+func TestSetConnectionACL(t *testing.T) {
+	svc, _, auth, _, _ := newService()
+
+	connACL := acl.ACL{
+		ThingID:   ID,
+		ChannelID: validID,
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{"commands/" + ID},
+	}
+
+	cases := []struct {
+		desc             string
+		token            string
+		acl              acl.ACL
+		identifyResponse *magistrala.IdentityRes
+		identifyErr      error
+		authorizeRes     *magistrala.AuthorizeRes
+		authorizeErr     error
+		saveErr          error
+		err              error
+	}{
+		{
+			desc:             "set connection ACL successfully",
+			token:            validToken,
+			acl:              connACL,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:             "set connection ACL denying both permissions",
+			token:            validToken,
+			acl:              acl.ACL{ThingID: ID, ChannelID: validID},
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:             "set connection ACL with invalid token",
+			token:            inValidToken,
+			acl:              connACL,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "set connection ACL without channel edit permission",
+			token:            validToken,
+			acl:              connACL,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:     svcerr.ErrAuthorization,
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:             "set connection ACL with malformed pattern",
+			token:            validToken,
+			acl:              acl.ACL{ThingID: ID, ChannelID: validID, Publish: []string{"sensors/#/temperature"}},
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+			err:              svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:             "set connection ACL with failed to save",
+			token:            validToken,
+			acl:              connACL,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:          repoerr.ErrCreateEntity,
+			err:              svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeRes, tc.authorizeErr)
+		repoCall := aclRepo.On("Save", mock.Anything, mock.Anything).Return(tc.acl, tc.saveErr)
+		saved, err := svc.SetConnectionACL(context.Background(), tc.token, tc.acl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.acl, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.acl, saved))
+			ok := authzCache.AssertCalled(t, "RemoveThing", mock.Anything, tc.acl.ThingID)
+			assert.True(t, ok, fmt.Sprintf("%s: expected the cached decisions to be removed", tc.desc))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestViewConnectionACL(t *testing.T) {
+	svc, _, auth, _, _ := newService()
+
+	connACL := acl.ACL{
+		ThingID:   ID,
+		ChannelID: validID,
+		Publish:   []string{"sensors/#"},
+		Subscribe: []string{},
+	}
+
+	cases := []struct {
+		desc             string
+		token            string
+		identifyResponse *magistrala.IdentityRes
+		identifyErr      error
+		authorizeRes     *magistrala.AuthorizeRes
+		authorizeErr     error
+		retrieveRes      acl.ACL
+		retrieveErr      error
+		err              error
+	}{
+		{
+			desc:             "view connection ACL successfully",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+			retrieveRes:      connACL,
+		},
+		{
+			desc:             "view connection ACL with invalid token",
+			token:            inValidToken,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "view connection ACL without channel view permission",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:     svcerr.ErrAuthorization,
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:             "view not existing connection ACL",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr:      repoerr.ErrNotFound,
+			err:              svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeRes, tc.authorizeErr)
+		repoCall := aclRepo.On("Retrieve", mock.Anything, ID, validID).Return(tc.retrieveRes, tc.retrieveErr)
+		res, err := svc.ViewConnectionACL(context.Background(), tc.token, ID, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.retrieveRes, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.retrieveRes, res))
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestRemoveConnectionACL(t *testing.T) {
+	svc, _, auth, _, _ := newService()
+
+	cases := []struct {
+		desc             string
+		token            string
+		identifyResponse *magistrala.IdentityRes
+		identifyErr      error
+		authorizeRes     *magistrala.AuthorizeRes
+		authorizeErr     error
+		removeErr        error
+		err              error
+	}{
+		{
+			desc:             "remove connection ACL successfully",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:             "remove connection ACL with invalid token",
+			token:            inValidToken,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "remove connection ACL without channel edit permission",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:     svcerr.ErrAuthorization,
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:             "remove connection ACL with failed to remove",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeRes:     &magistrala.AuthorizeRes{Authorized: true},
+			removeErr:        repoerr.ErrRemoveEntity,
+			err:              svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeRes, tc.authorizeErr)
+		repoCall := aclRepo.On("Remove", mock.Anything, ID, validID).Return(tc.removeErr)
+		err := svc.RemoveConnectionACL(context.Background(), tc.token, ID, validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
@@ -50,6 +50,30 @@ func (repo singleUserAuth) Identify(ctx context.Context, in *magistrala.Identity
 	return &magistrala.IdentityRes{Id: repo.id}, nil
 }
 
+func (repo singleUserAuth) RevokeTokens(ctx context.Context, in *magistrala.RevokeTokensReq, opts ...grpc.CallOption) (*magistrala.RevokeTokensRes, error) {
+	return nil, nil
+}
+
+func (repo singleUserAuth) ApplyClaimMappings(ctx context.Context, in *magistrala.ApplyClaimMappingsReq, opts ...grpc.CallOption) (*magistrala.ApplyClaimMappingsRes, error) {
+	return nil, nil
+}
+
+func (repo singleUserAuth) AddDomainMember(ctx context.Context, in *magistrala.AddDomainMemberReq, opts ...grpc.CallOption) (*magistrala.AddDomainMemberRes, error) {
+	return nil, nil
+}
+
+func (repo singleUserAuth) CheckQuota(ctx context.Context, in *magistrala.CheckQuotaReq, opts ...grpc.CallOption) (*magistrala.CheckQuotaRes, error) {
+	return &magistrala.CheckQuotaRes{Allowed: true}, nil
+}
+
+func (repo singleUserAuth) ReserveQuota(ctx context.Context, in *magistrala.ReserveQuotaReq, opts ...grpc.CallOption) (*magistrala.ReserveQuotaRes, error) {
+	return &magistrala.ReserveQuotaRes{}, nil
+}
+
+func (repo singleUserAuth) ReleaseQuota(ctx context.Context, in *magistrala.ReleaseQuotaReq, opts ...grpc.CallOption) (*magistrala.ReleaseQuotaRes, error) {
+	return &magistrala.ReleaseQuotaRes{Released: true}, nil
+}
+
 func (repo singleUserAuth) Authorize(ctx context.Context, in *magistrala.AuthorizeReq, opts ...grpc.CallOption) (*magistrala.AuthorizeRes, error) {
 	if repo.id != in.Subject {
 		return &magistrala.AuthorizeRes{Authorized: false}, svcerr.ErrAuthorization
@@ -7,7 +7,9 @@ import (
 	"context"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/things/acl"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -49,7 +51,7 @@ type Service interface {
 	DisableClient(ctx context.Context, token, id string) (clients.Client, error)
 
 	// Share add share policy to thing id with given relation for given user ids
-	Share(ctx context.Context, token, id string, relation string, userids ...string) error
+	Share(ctx context.Context, token, id string, relation string, cond *auth.Condition, userids ...string) error
 
 	// Unshare remove share policy to thing id with given relation for given user ids
 	Unshare(ctx context.Context, token, id string, relation string, userids ...string) error
@@ -62,6 +64,22 @@ type Service interface {
 
 	// DeleteClient deletes client with given ID.
 	DeleteClient(ctx context.Context, token, id string) error
+
+	// DeleteDomainClients deletes the things of the deleted domain together
+	// with their policies, and returns the IDs of the deleted things.
+	DeleteDomainClients(ctx context.Context, domainID string) ([]string, error)
+
+	// SetConnectionACL sets the subtopics the thing is allowed
+	// to publish and subscribe to on the connected channel.
+	SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error)
+
+	// ViewConnectionACL retrieves the subtopics the thing is allowed
+	// to publish and subscribe to on the connected channel.
+	ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (acl.ACL, error)
+
+	// RemoveConnectionACL removes the subtopic restrictions of
+	// the thing on the connected channel.
+	RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) error
 }
 
 // Cache contains thing caching interface.
@@ -77,3 +95,54 @@ type Cache interface {
 	// Removes thing from cache.
 	Remove(ctx context.Context, thingID string) error
 }
+
+// AuthzCache contains the cache of the decisions of the thing permissions
+// on the channels, so the messages published by the thing don't require
+// the policy check each.
+//
+//go:generate mockery --name AuthzCache --filename authz_cache.go --quiet --note "Copyright (c) Abstract Machines"
+type AuthzCache interface {
+	// Save stores the decision of the thing permission on the channel subtopic.
+	Save(ctx context.Context, thingID, channelID, permission, subtopic string, allowed bool) error
+
+	// Decision returns the decision of the thing permission on the channel subtopic.
+	Decision(ctx context.Context, thingID, channelID, permission, subtopic string) (bool, error)
+
+	// RemoveThing removes the decisions of the thing permissions.
+	RemoveThing(ctx context.Context, thingID string) error
+
+	// RemoveChannel removes the decisions of the permissions on the channel.
+	RemoveChannel(ctx context.Context, channelID string) error
+}
+
+// QuotaCache contains the cache of the domains of the things, of whether
+// the messages of the domains are limited by the quota and of the short
+// lived quota decisions of the limited domains, so the messages published
+// by the things don't require retrieving the thing and checking the quota
+// each.
+//
+//go:generate mockery --name QuotaCache --filename quota_cache.go --quiet --note "Copyright (c) Abstract Machines"
+type QuotaCache interface {
+	// SaveDomain stores the domain of the thing.
+	SaveDomain(ctx context.Context, thingID, domainID string) error
+
+	// Domain returns the domain of the thing.
+	Domain(ctx context.Context, thingID string) (string, error)
+
+	// SaveLimited stores whether the messages of the domain are limited by the quota.
+	SaveLimited(ctx context.Context, domainID string, limited bool) error
+
+	// Limited returns whether the messages of the domain are limited by the quota.
+	Limited(ctx context.Context, domainID string) (bool, error)
+
+	// SaveAllowed stores whether the messages quota of the limited domain
+	// allows publishing.
+	SaveAllowed(ctx context.Context, domainID string, allowed bool) error
+
+	// Allowed returns whether the messages quota of the limited domain
+	// allows publishing.
+	Allowed(ctx context.Context, domainID string) (bool, error)
+
+	// RemoveThing removes the domain of the thing.
+	RemoveThing(ctx context.Context, thingID string) error
+}
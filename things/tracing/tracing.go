@@ -7,8 +7,10 @@ import (
 	"context"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/acl"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -121,10 +123,10 @@ func (tm *tracingMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 }
 
 // Share traces the "Share" operation of the wrapped things.Service.
-func (tm *tracingMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) error {
+func (tm *tracingMiddleware) Share(ctx context.Context, token, id, relation string, cond *auth.Condition, userids ...string) error {
 	ctx, span := tm.tracer.Start(ctx, "share", trace.WithAttributes(attribute.String("id", id), attribute.String("relation", relation), attribute.StringSlice("user_ids", userids)))
 	defer span.End()
-	return tm.svc.Share(ctx, token, id, relation, userids...)
+	return tm.svc.Share(ctx, token, id, relation, cond, userids...)
 }
 
 // Unshare traces the "Unshare" operation of the wrapped things.Service.
@@ -140,3 +142,45 @@ func (tm *tracingMiddleware) DeleteClient(ctx context.Context, token, id string)
 	defer span.End()
 	return tm.svc.DeleteClient(ctx, token, id)
 }
+
+// DeleteDomainClients traces the "DeleteDomainClients" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) DeleteDomainClients(ctx context.Context, domainID string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "delete_domain_clients", trace.WithAttributes(attribute.String("domain_id", domainID)))
+	defer span.End()
+	return tm.svc.DeleteDomainClients(ctx, domainID)
+}
+
+// SetConnectionACL traces the "SetConnectionACL" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) SetConnectionACL(ctx context.Context, token string, connACL acl.ACL) (acl.ACL, error) {
+	ctx, span := tm.tracer.Start(ctx, "set_connection_acl", trace.WithAttributes(
+		attribute.String("thing_id", connACL.ThingID),
+		attribute.String("channel_id", connACL.ChannelID),
+		attribute.StringSlice("publish", connACL.Publish),
+		attribute.StringSlice("subscribe", connACL.Subscribe),
+	))
+	defer span.End()
+
+	return tm.svc.SetConnectionACL(ctx, token, connACL)
+}
+
+// ViewConnectionACL traces the "ViewConnectionACL" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ViewConnectionACL(ctx context.Context, token, thingID, channelID string) (acl.ACL, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_connection_acl", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+		attribute.String("channel_id", channelID),
+	))
+	defer span.End()
+
+	return tm.svc.ViewConnectionACL(ctx, token, thingID, channelID)
+}
+
+// RemoveConnectionACL traces the "RemoveConnectionACL" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) RemoveConnectionACL(ctx context.Context, token, thingID, channelID string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_connection_acl", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+		attribute.String("channel_id", channelID),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveConnectionACL(ctx, token, thingID, channelID)
+}
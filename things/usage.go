@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things/postgres"
+)
+
+// seedBatch is the number of the things and the channels
+// seeded at once.
+const seedBatch = 1000
+
+// SeedUsage records the existing things and channels in the usage of their
+// domains, so the domain quotas account the entities created before the
+// usage accounting. The entities are recorded regardless of the quotas and
+// the recorded ones are skipped, so seeding is safely repeated. The first
+// error is returned after all the entities are seeded.
+func SeedUsage(ctx context.Context, authClient grpcclient.AuthServiceClient, clients postgres.Repository, groups mggroups.Repository) (seedErr error) {
+	pm := mgclients.Page{
+		Limit:  seedBatch,
+		Status: mgclients.AllStatus,
+		Role:   mgclients.AllRole,
+	}
+	for {
+		cp, err := clients.RetrieveAll(ctx, pm)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		ids := make(map[string][]string)
+		for _, c := range cp.Clients {
+			ids[c.Domain] = append(ids[c.Domain], c.ID)
+		}
+		if serr := seedDomains(ctx, authClient, auth.ThingsQuota, ids); serr != nil && seedErr == nil {
+			seedErr = serr
+		}
+		pm.Offset += pm.Limit
+		if pm.Offset >= cp.Total {
+			break
+		}
+	}
+
+	gm := mggroups.Page{
+		PageMeta: mggroups.PageMeta{
+			Limit:  seedBatch,
+			Status: mgclients.AllStatus,
+		},
+	}
+	for {
+		gp, err := groups.RetrieveAll(ctx, gm)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		// Things service groups are the channels.
+		ids := make(map[string][]string)
+		for _, g := range gp.Groups {
+			ids[g.Domain] = append(ids[g.Domain], g.ID)
+		}
+		if serr := seedDomains(ctx, authClient, auth.ChannelsQuota, ids); serr != nil && seedErr == nil {
+			seedErr = serr
+		}
+		gm.Offset += gm.Limit
+		if gm.Offset >= gp.Total {
+			return seedErr
+		}
+	}
+}
+
+// seedDomains records the entities of each domain. Failing to record the
+// entities of a domain, e.g. the deleted one, doesn't stop the others.
+func seedDomains(ctx context.Context, authClient grpcclient.AuthServiceClient, resource string, ids map[string][]string) (err error) {
+	for domainID, dids := range ids {
+		// The entities created before the domains are not accounted.
+		if domainID == "" {
+			continue
+		}
+		req := &magistrala.ReserveQuotaReq{
+			DomainId: domainID,
+			Resource: resource,
+			Ids:      dids,
+			Existing: true,
+		}
+		if _, rerr := authClient.ReserveQuota(ctx, req); rerr != nil && err == nil {
+			err = errors.Wrap(svcerr.ErrCreateEntity, rerr)
+		}
+	}
+
+	return err
+}
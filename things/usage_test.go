@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authsvc "github.com/absmach/magistrala/auth"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
+	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSeedUsage(t *testing.T) {
+	thing := testsutil.GenerateUUID(t)
+	channel := testsutil.GenerateUUID(t)
+	deletedDomain := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		retrieveErr error
+		reserveErr  error
+		err         error
+	}{
+		{
+			desc: "seed usage",
+		},
+		{
+			desc:        "seed usage with failed to retrieve things",
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "seed usage with failed to reserve quota of the deleted domain",
+			reserveErr: svcerr.ErrNotFound,
+			err:        svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		auth := new(authmocks.AuthServiceClient)
+		cRepo := new(mocks.Repository)
+		gRepo := new(gmocks.Repository)
+		cRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(mgclients.ClientsPage{
+			Page:    mgclients.Page{Total: 2},
+			Clients: []mgclients.Client{{ID: thing, Domain: validID}, {ID: ID, Domain: deletedDomain}},
+		}, tc.retrieveErr)
+		gRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(mggroups.Page{
+			PageMeta: mggroups.PageMeta{Total: 1},
+			Groups:   []mggroups.Group{{ID: channel, Domain: validID}},
+		}, nil)
+		auth.On("ReserveQuota", mock.Anything, &magistrala.ReserveQuotaReq{DomainId: validID, Resource: authsvc.ThingsQuota, Ids: []string{thing}, Existing: true}).Return(&magistrala.ReserveQuotaRes{}, nil)
+		auth.On("ReserveQuota", mock.Anything, &magistrala.ReserveQuotaReq{DomainId: deletedDomain, Resource: authsvc.ThingsQuota, Ids: []string{ID}, Existing: true}).Return(&magistrala.ReserveQuotaRes{}, tc.reserveErr)
+		auth.On("ReserveQuota", mock.Anything, &magistrala.ReserveQuotaReq{DomainId: validID, Resource: authsvc.ChannelsQuota, Ids: []string{channel}, Existing: true}).Return(&magistrala.ReserveQuotaRes{}, nil)
+
+		err := things.SeedUsage(context.Background(), auth, cRepo, gRepo)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.retrieveErr == nil {
+			// Failing to seed a domain doesn't stop seeding the others.
+			auth.AssertNumberOfCalls(t, "ReserveQuota", 3)
+		}
+	}
+}
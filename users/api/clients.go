@@ -5,11 +5,14 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/internal/api"
@@ -23,14 +26,22 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+const (
+	// oauthStateCookie binds the state of the OAuth2 flow to the browser.
+	oauthStateCookie = "oauth_state"
+	// oauthStateDuration matches the lifetime of the state stored by the providers.
+	oauthStateDuration = 10 * time.Minute
+)
+
 var passRegex = regexp.MustCompile("^.{8,}$")
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
+func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, proxies apiutil.TrustedProxies, providers ...oauth2.Provider) http.Handler {
 	passRegex = pr
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(proxies.SourceIPToContext),
 	}
 
 	r.Route("/users", func(r chi.Router) {
@@ -41,6 +52,13 @@ func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *rege
 			opts...,
 		), "register_client").ServeHTTP)
 
+		r.Post("/invited", otelhttp.NewHandler(kithttp.NewServer(
+			invitedRegistrationEndpoint(svc),
+			decodeCreateInvitedClientReq,
+			api.EncodeResponse,
+			opts...,
+		), "register_invited_client").ServeHTTP)
+
 		r.Get("/profile", otelhttp.NewHandler(kithttp.NewServer(
 			viewProfileEndpoint(svc),
 			decodeViewProfile,
@@ -48,6 +66,41 @@ func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *rege
 			opts...,
 		), "view_profile").ServeHTTP)
 
+		r.Delete("/profile", otelhttp.NewHandler(kithttp.NewServer(
+			deleteProfileEndpoint(svc),
+			decodeViewProfile,
+			api.EncodeResponse,
+			opts...,
+		), "delete_profile").ServeHTTP)
+
+		r.Get("/profile/export", otelhttp.NewHandler(kithttp.NewServer(
+			exportProfileEndpoint(svc),
+			decodeViewProfile,
+			api.EncodeResponse,
+			opts...,
+		), "export_profile").ServeHTTP)
+
+		r.Post("/verification", otelhttp.NewHandler(kithttp.NewServer(
+			sendVerificationEndpoint(svc),
+			decodeCredentials,
+			api.EncodeResponse,
+			opts...,
+		), "send_verification").ServeHTTP)
+
+		r.Post("/verify-email", otelhttp.NewHandler(kithttp.NewServer(
+			verifyEmailEndpoint(svc),
+			decodeVerifyEmail,
+			api.EncodeResponse,
+			opts...,
+		), "verify_email").ServeHTTP)
+
+		r.Get("/identity/{identity}/{domainID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewClientByIdentityEndpoint(svc),
+			decodeViewClientByIdentity,
+			api.EncodeResponse,
+			opts...,
+		), "view_client_by_identity").ServeHTTP)
+
 		r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
 			viewClientEndpoint(svc),
 			decodeViewClient,
@@ -118,6 +171,36 @@ func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *rege
 			opts...,
 		), "refresh_token").ServeHTTP)
 
+		r.Post("/tokens/mfa", otelhttp.NewHandler(kithttp.NewServer(
+			issueMFATokenEndpoint(svc),
+			decodeIssueMFAToken,
+			api.EncodeResponse,
+			opts...,
+		), "issue_mfa_token").ServeHTTP)
+
+		r.Route("/mfa", func(r chi.Router) {
+			r.Post("/enroll", otelhttp.NewHandler(kithttp.NewServer(
+				enrollMFAEndpoint(svc),
+				decodeEnrollMFA,
+				api.EncodeResponse,
+				opts...,
+			), "enroll_mfa").ServeHTTP)
+
+			r.Post("/verify", otelhttp.NewHandler(kithttp.NewServer(
+				verifyMFAEndpoint(svc),
+				decodeMFACode,
+				api.EncodeResponse,
+				opts...,
+			), "verify_mfa").ServeHTTP)
+
+			r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+				disableMFAEndpoint(svc),
+				decodeMFACode,
+				api.EncodeResponse,
+				opts...,
+			), "disable_mfa").ServeHTTP)
+		})
+
 		r.Post("/{id}/enable", otelhttp.NewHandler(kithttp.NewServer(
 			enableClientEndpoint(svc),
 			decodeChangeClientStatus,
@@ -132,6 +215,13 @@ func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *rege
 			opts...,
 		), "disable_client").ServeHTTP)
 
+		r.Post("/{id}/unlock", otelhttp.NewHandler(kithttp.NewServer(
+			unlockClientEndpoint(svc),
+			decodeChangeClientStatus,
+			api.EncodeResponse,
+			opts...,
+		), "unlock_client").ServeHTTP)
+
 		r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
 			deleteClientEndpoint(svc),
 			decodeChangeClientStatus,
@@ -194,7 +284,45 @@ func clientsHandler(svc users.Service, r *chi.Mux, logger *slog.Logger, pr *rege
 		opts...,
 	), "list_users_by_domain_id").ServeHTTP)
 
+	r.Route("/domains/{domainID}/service-accounts", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createServiceAccountEndpoint(svc),
+			decodeCreateServiceAccount,
+			api.EncodeResponse,
+			opts...,
+		), "create_service_account").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listServiceAccountsEndpoint(svc),
+			decodeListServiceAccounts,
+			api.EncodeResponse,
+			opts...,
+		), "list_service_accounts").ServeHTTP)
+
+		r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			viewServiceAccountEndpoint(svc),
+			decodeServiceAccount,
+			api.EncodeResponse,
+			opts...,
+		), "view_service_account").ServeHTTP)
+
+		r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			deleteServiceAccountEndpoint(svc),
+			decodeServiceAccount,
+			api.EncodeResponse,
+			opts...,
+		), "delete_service_account").ServeHTTP)
+
+		r.Post("/{id}/keys", otelhttp.NewHandler(kithttp.NewServer(
+			issueServiceAccountKeyEndpoint(svc),
+			decodeServiceAccount,
+			api.EncodeResponse,
+			opts...,
+		), "issue_service_account_key").ServeHTTP)
+	})
+
 	for _, provider := range providers {
+		r.HandleFunc("/oauth/authorize/"+provider.Name(), oauth2AuthorizeHandler(provider))
 		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc))
 	}
 
@@ -210,6 +338,16 @@ func decodeViewClient(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeViewClientByIdentity(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewClientByIdentityReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		identity: chi.URLParam(r, "identity"),
+	}
+
+	return req, nil
+}
+
 func decodeViewProfile(_ context.Context, r *http.Request) (interface{}, error) {
 	req := viewProfileReq{token: apiutil.ExtractBearerToken(r)}
 
@@ -417,6 +555,19 @@ func decodePasswordReset(_ context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+func decodeVerifyEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req verifyEmailReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeUpdateClientRole(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -434,12 +585,12 @@ func decodeUpdateClientRole(_ context.Context, r *http.Request) (interface{}, er
 	return req, err
 }
 
-func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error) {
+func decodeCredentials(ctx context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
 	}
 
-	req := loginClientReq{}
+	req := loginClientReq{sourceIP: apiutil.SourceIP(ctx)}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
 	}
@@ -459,6 +610,38 @@ func decodeRefreshToken(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeIssueMFAToken(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := issueMFATokenReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeEnrollMFA(_ context.Context, r *http.Request) (interface{}, error) {
+	req := enrollMFAReq{token: apiutil.ExtractBearerToken(r)}
+
+	return req, nil
+}
+
+func decodeMFACode(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := mfaCodeReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeCreateClientReq(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -476,6 +659,71 @@ func decodeCreateClientReq(_ context.Context, r *http.Request) (interface{}, err
 	return req, nil
 }
 
+func decodeCreateInvitedClientReq(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeCreateClientReq(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return createInvitedClientReq{req.(createClientReq)}, nil
+}
+
+func decodeCreateServiceAccount(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var sa mgclients.Client
+	if err := json.NewDecoder(r.Body).Decode(&sa); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req := createServiceAccountReq{
+		token:          apiutil.ExtractBearerToken(r),
+		domainID:       chi.URLParam(r, "domainID"),
+		serviceAccount: sa,
+	}
+
+	return req, nil
+}
+
+func decodeServiceAccount(_ context.Context, r *http.Request) (interface{}, error) {
+	req := serviceAccountReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		id:       chi.URLParam(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListServiceAccounts(_ context.Context, r *http.Request) (interface{}, error) {
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefClientStatus)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	st, err := mgclients.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listServiceAccountsReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		status:   st,
+		offset:   o,
+		limit:    l,
+	}
+
+	return req, nil
+}
+
 func decodeChangeClientStatus(_ context.Context, r *http.Request) (interface{}, error) {
 	req := changeClientStatusReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -596,6 +844,36 @@ func queryPageParams(r *http.Request, defPermission string) (mgclients.Page, err
 	}, nil
 }
 
+// oauth2AuthorizeHandler is a http.HandlerFunc that starts OAuth2 flow
+// by redirecting the user to the provider's consent page. The state of
+// the flow is bound to the browser with the cookie.
+func oauth2AuthorizeHandler(oauth oauth2.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !oauth.IsEnabled() {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
+			return
+		}
+
+		authURL, state, err := oauth.AuthURL(r.Context())
+		if err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie,
+			Value:    state,
+			Path:     "/",
+			MaxAge:   int(oauthStateDuration.Seconds()),
+			HttpOnly: true,
+			Secure:   true,
+			// The provider redirects back with the top-level navigation.
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
 // oauth2CallbackHandler is a http.HandlerFunc that handles OAuth2 callbacks.
 func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -603,20 +881,31 @@ func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service) http.Handle
 			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
 			return
 		}
+
+		// The state is used once, so the cookie is removed in any case.
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
 		state := r.FormValue("state")
-		if state != oauth.State() {
-			http.Redirect(w, r, oauth.ErrorURL()+"?error=invalid%20state", http.StatusSeeOther)
+		cookie, err := r.Cookie(oauthStateCookie)
+		if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+url.QueryEscape(oauth2.ErrInvalidState.Error()), http.StatusSeeOther)
 			return
 		}
 
 		if code := r.FormValue("code"); code != "" {
-			token, err := oauth.Exchange(r.Context(), code)
+			token, err := oauth.Exchange(r.Context(), state, code)
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
 			}
 
-			client, err := oauth.UserInfo(token.AccessToken)
+			client, err := oauth.UserInfo(r.Context(), token)
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
@@ -628,6 +917,13 @@ func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service) http.Handle
 				return
 			}
 
+			// Users with MFA enabled complete the login with the code,
+			// so the UI gets the MFA token instead of the access token.
+			if jwt.MfaToken != nil {
+				http.Redirect(w, r, oauth.RedirectURL()+"?mfa_token="+url.QueryEscape(*jwt.MfaToken), http.StatusFound)
+				return
+			}
+
 			http.SetCookie(w, &http.Cookie{
 				Name:     "access_token",
 				Value:    jwt.AccessToken,
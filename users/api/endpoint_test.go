@@ -23,7 +23,9 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
 	oauth2mocks "github.com/absmach/magistrala/pkg/oauth2/mocks"
+	"github.com/absmach/magistrala/users"
 	httpapi "github.com/absmach/magistrala/users/api"
+	"github.com/absmach/magistrala/users/mfa"
 	"github.com/absmach/magistrala/users/mocks"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -88,7 +90,7 @@ func newUsersServer() (*httptest.Server, *mocks.Service, *gmocks.Service) {
 	mux := chi.NewRouter()
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
-	httpapi.MakeHandler(svc, gsvc, mux, logger, "", passRegex, provider)
+	httpapi.MakeHandler(svc, gsvc, mux, logger, "", passRegex, nil, provider)
 
 	return httptest.NewServer(mux), svc, gsvc
 }
@@ -239,6 +241,92 @@ func TestRegisterClient(t *testing.T) {
 	}
 }
 
+func TestRegisterInvitedClient(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		client      mgclients.Client
+		token       string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "register invited user with a valid token",
+			client:      client,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "register invited user with an empty token",
+			client:      client,
+			token:       "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "register invited user with a token that is not an invitation",
+			client:      client,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc: "register invited user with empty identity",
+			client: mgclients.Client{
+				Credentials: mgclients.Credentials{
+					Secret: "12345678",
+				},
+			},
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "register invited user with invalid content type",
+			client:      client,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/invited", us.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := svc.On("RegisterInvitedClient", mock.Anything, tc.token, tc.client).Return(tc.client, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var errRes respBody
+		err = json.NewDecoder(res.Body).Decode(&errRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if errRes.Err != "" || errRes.Message != "" {
+			err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
 func TestViewClient(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
@@ -296,6 +384,78 @@ func TestViewClient(t *testing.T) {
 	}
 }
 
+func TestViewClientByIdentity(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	identity := "invitee@example.com"
+
+	cases := []struct {
+		desc     string
+		token    string
+		identity string
+		domainID string
+		status   int
+		err      error
+	}{
+		{
+			desc:     "view client by identity as admin",
+			token:    validToken,
+			identity: identity,
+			domainID: validID,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "view client by identity with invalid token",
+			token:    inValidToken,
+			identity: identity,
+			domainID: validID,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "view client by identity with empty token",
+			token:    "",
+			identity: identity,
+			domainID: validID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "view client by identity as non-admin",
+			token:    validToken,
+			identity: identity,
+			domainID: validID,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "view non-existing client by identity",
+			token:    validToken,
+			identity: identity,
+			domainID: validID,
+			status:   http.StatusNotFound,
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/users/identity/%s/%s", us.URL, tc.identity, tc.domainID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ViewClientByIdentity", mock.Anything, tc.token, tc.domainID, tc.identity).Return(mgclients.Client{ID: validID}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
 func TestViewProfile(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
@@ -353,128 +513,406 @@ func TestViewProfile(t *testing.T) {
 	}
 }
 
-func TestListClients(t *testing.T) {
+func TestDeleteProfile(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
 
 	cases := []struct {
-		desc              string
-		query             string
-		token             string
-		listUsersResponse mgclients.ClientsPage
-		status            int
-		err               error
+		desc   string
+		token  string
+		status int
+		err    error
 	}{
 		{
-			desc:   "list users with valid token",
+			desc:   "delete profile with valid token",
 			token:  validToken,
-			status: http.StatusOK,
-			listUsersResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			err: nil,
-		},
-		{
-			desc:   "list users with empty token",
-			token:  "",
-			status: http.StatusUnauthorized,
-			err:    apiutil.ErrBearerToken,
+			status: http.StatusNoContent,
+			err:    nil,
 		},
 		{
-			desc:   "list users with invalid token",
+			desc:   "delete profile with invalid token",
 			token:  inValidToken,
 			status: http.StatusUnauthorized,
 			err:    svcerr.ErrAuthentication,
 		},
 		{
-			desc:  "list users with offset",
-			token: validToken,
-			listUsersResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Offset: 1,
-					Total:  1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "offset=1",
-			status: http.StatusOK,
-			err:    nil,
+			desc:   "delete profile with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
 		},
 		{
-			desc:   "list users with invalid offset",
+			desc:   "delete profile of service account",
 			token:  validToken,
-			query:  "offset=invalid",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/users/profile", us.URL),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteProfile", mock.Anything, tc.token).Return(mgclients.Client{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestSendVerification(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	validIdentity := "valid"
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		err         error
+	}{
 		{
-			desc:  "list users with limit",
-			token: validToken,
-			listUsersResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Limit: 1,
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "limit=1",
-			status: http.StatusOK,
-			err:    nil,
+			desc:        "send verification with valid identity and secret",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, validIdentity, secret),
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
 		},
 		{
-			desc:   "list users with invalid limit",
-			token:  validToken,
-			query:  "limit=invalid",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:        "send verification with empty identity",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, "", secret),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:   "list users with limit greater than max",
-			token:  validToken,
-			query:  fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:        "send verification with empty secret",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, validIdentity, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:  "list users with name",
-			token: validToken,
-			listUsersResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "name=clientname",
-			status: http.StatusOK,
-			err:    nil,
+			desc:        "send verification with invalid credentials",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, "invalid", secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrLogin,
 		},
 		{
-			desc:   "list users with duplicate name",
-			token:  validToken,
-			query:  "name=1&name=2",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrInvalidQueryParams,
+			desc:        "send verification of verified email",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, validIdentity, secret),
+			contentType: contentType,
+			status:      http.StatusConflict,
+			err:         svcerr.ErrConflict,
 		},
 		{
-			desc:  "list users with status",
-			token: validToken,
-			listUsersResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "status=enabled",
-			status: http.StatusOK,
-			err:    nil,
+			desc:        "send verification too early after the previous one",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, validIdentity, secret),
+			contentType: contentType,
+			status:      http.StatusTooManyRequests,
+			err:         svcerr.ErrTooManyRequests,
 		},
 		{
-			desc:   "list users with invalid status",
-			token:  validToken,
-			query:  "status=invalid",
+			desc:        "send verification with malformed data",
+			data:        fmt.Sprintf(`{"identity": %s, "secret": %s}`, validIdentity, secret),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "send verification with invalid content type",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, validIdentity, secret),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/verification", us.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("SendVerification", mock.Anything, mock.Anything, mock.Anything, "127.0.0.1").Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	verificationToken := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		data        string
+		token       string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "verify email with valid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, verificationToken),
+			token:       verificationToken,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "verify email with invalid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, inValidToken),
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify email with empty token",
+			data:        `{"token": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify email with malformed data",
+			data:        fmt.Sprintf(`{"token": %s}`, verificationToken),
+			token:       verificationToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:   "verify email with invalid content type",
+			data:   fmt.Sprintf(`{"token": "%s"}`, verificationToken),
+			token:  verificationToken,
+			status: http.StatusUnsupportedMediaType,
+			err:    apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/verify-email", us.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("VerifyEmail", mock.Anything, tc.token).Return(client, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestExportProfile(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	archive := users.ProfileArchive{
+		Profile: client,
+		Domains: []users.DomainMembership{
+			{
+				DomainID:    testsutil.GenerateUUID(t),
+				Permissions: []string{"membership"},
+				Things:      []string{testsutil.GenerateUUID(t)},
+				Groups:      []string{},
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		response users.ProfileArchive
+		status   int
+		err      error
+	}{
+		{
+			desc:     "export profile with valid token",
+			token:    validToken,
+			response: archive,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:   "export profile with invalid token",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "export profile with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/users/profile/export", us.URL),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ExportProfile", mock.Anything, tc.token).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			var resArchive users.ProfileArchive
+			err = json.NewDecoder(res.Body).Decode(&resArchive)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.response.Domains, resArchive.Domains, fmt.Sprintf("%s: expected domains %v got %v", tc.desc, tc.response.Domains, resArchive.Domains))
+			assert.Contains(t, res.Header.Get("Content-Disposition"), "attachment", fmt.Sprintf("%s: expected attachment response", tc.desc))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListClients(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc              string
+		query             string
+		token             string
+		listUsersResponse mgclients.ClientsPage
+		status            int
+		err               error
+	}{
+		{
+			desc:   "list users with valid token",
+			token:  validToken,
+			status: http.StatusOK,
+			listUsersResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			err: nil,
+		},
+		{
+			desc:   "list users with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "list users with invalid token",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "list users with offset",
+			token: validToken,
+			listUsersResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Offset: 1,
+					Total:  1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "offset=1",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list users with invalid offset",
+			token:  validToken,
+			query:  "offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:  "list users with limit",
+			token: validToken,
+			listUsersResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Limit: 1,
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "limit=1",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list users with invalid limit",
+			token:  validToken,
+			query:  "limit=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list users with limit greater than max",
+			token:  validToken,
+			query:  fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:  "list users with name",
+			token: validToken,
+			listUsersResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "name=clientname",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list users with duplicate name",
+			token:  validToken,
+			query:  "name=1&name=2",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:  "list users with status",
+			token: validToken,
+			listUsersResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "status=enabled",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list users with invalid status",
+			token:  validToken,
+			query:  "status=invalid",
 			status: http.StatusBadRequest,
 			err:    apiutil.ErrValidation,
 		},
@@ -1584,6 +2022,20 @@ func TestIssueToken(t *testing.T) {
 			status:      http.StatusUnauthorized,
 			err:         svcerr.ErrAuthentication,
 		},
+		{
+			desc:        "issue token with locked out login",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s", "domainID": "%s"}`, validIdentity, secret, validID),
+			contentType: contentType,
+			status:      http.StatusTooManyRequests,
+			err:         svcerr.ErrLoginLocked,
+		},
+		{
+			desc:        "issue token with unverified email",
+			data:        fmt.Sprintf(`{"identity": "%s", "secret": "%s", "domainID": "%s"}`, validIdentity, secret, validID),
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrEmailNotVerified,
+		},
 		{
 			desc:        "issues token with malformed data",
 			data:        fmt.Sprintf(`{"identity": %s, "secret": %s, "domainID": %s}`, validIdentity, secret, validID),
@@ -1609,7 +2061,7 @@ func TestIssueToken(t *testing.T) {
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.Token{}, tc.err)
+		svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "127.0.0.1").Return(&magistrala.Token{}, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		if tc.err != nil {
@@ -1622,62 +2074,779 @@ func TestIssueToken(t *testing.T) {
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		}
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		svcCall.Unset()
+		svcCall.Unset()
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "refresh token with valid token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "refresh token with invalid token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, inValidToken, validID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "refresh token with empty token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, "", validID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "refresh token with invalid domain",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, "invalid"),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "refresh token with malformed data",
+			data:        fmt.Sprintf(`{"refresh_token": %s, "domain_id": %s}`, validToken, validID),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "refresh token with invalid contentype",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/tokens/refresh", us.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.Token{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestIssueTokenWithMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	data := fmt.Sprintf(`{"identity": "%s", "secret": "%s"}`, client.Credentials.Identity, secret)
+	mfaToken := testsutil.GenerateUUID(t)
+	svcCall := svc.On("IssueToken", mock.Anything, client.Credentials.Identity, secret, "", mock.Anything).Return(&magistrala.Token{MfaToken: &mfaToken}, nil)
+	defer svcCall.Unset()
+
+	req := testRequest{
+		client:      us.Client(),
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/users/tokens/issue", us.URL),
+		contentType: contentType,
+		body:        strings.NewReader(data),
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusCreated, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusCreated, res.StatusCode))
+
+	var resBody map[string]string
+	err = json.NewDecoder(res.Body).Decode(&resBody)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	assert.Equal(t, mfaToken, resBody["mfa_token"], fmt.Sprintf("expected MFA token %s got %s", mfaToken, resBody["mfa_token"]))
+}
+
+func TestIssueMFAToken(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "issue MFA token with valid code",
+			data:        fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, validID, "123456"),
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "issue MFA token with empty MFA token",
+			data:        fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, "", "123456"),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFAToken,
+		},
+		{
+			desc:        "issue MFA token with empty code",
+			data:        fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, validID, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFACode,
+		},
+		{
+			desc:        "issue MFA token with invalid code",
+			data:        fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, validID, "000000"),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "issue MFA token with malformed data",
+			data:        fmt.Sprintf(`{"mfa_token": %s, "code": %s}`, validID, "123456"),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "issue MFA token with invalid content type",
+			data:        fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, validID, "123456"),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/tokens/mfa", us.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("IssueMFAToken", mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.Token{AccessToken: validToken, RefreshToken: &validToken}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "enroll MFA with valid token",
+			token:  validToken,
+			status: http.StatusCreated,
+			err:    nil,
+		},
+		{
+			desc:   "enroll MFA with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "enroll MFA with invalid token",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "enroll MFA with already enabled MFA",
+			token:  validToken,
+			status: http.StatusConflict,
+			svcErr: svcerr.ErrConflict,
+			err:    svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/mfa/enroll", us.URL),
+			token:  tc.token,
+		}
+
+		enrollment := mfa.Enrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/Magistrala:clientidentity?secret=JBSWY3DPEHPK3PXP"}
+		svcCall := svc.On("EnrollMFA", mock.Anything, tc.token).Return(enrollment, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "verify MFA with valid code",
+			token:       validToken,
+			data:        `{"code": "123456"}`,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "verify MFA with empty token",
+			token:       "",
+			data:        `{"code": "123456"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "verify MFA with empty code",
+			token:       validToken,
+			data:        `{"code": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFACode,
+		},
+		{
+			desc:        "verify MFA with invalid code",
+			token:       validToken,
+			data:        `{"code": "000000"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "verify MFA with malformed data",
+			token:       validToken,
+			data:        `{"code": 123456`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify MFA with invalid content type",
+			token:       validToken,
+			data:        `{"code": "123456"}`,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/mfa/verify", us.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("VerifyMFA", mock.Anything, tc.token, mock.Anything).Return([]string{"abcde-fghij"}, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "disable MFA with valid code",
+			token:       validToken,
+			data:        `{"code": "123456"}`,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:        "disable MFA with empty token",
+			token:       "",
+			data:        `{"code": "123456"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "disable MFA with empty code",
+			token:       validToken,
+			data:        `{"code": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFACode,
+		},
+		{
+			desc:        "disable MFA without enrollment",
+			token:       validToken,
+			data:        `{"code": "123456"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "disable MFA with invalid content type",
+			token:       validToken,
+			data:        `{"code": "123456"}`,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/mfa/disable", us.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("DisableMFA", mock.Anything, tc.token, mock.Anything).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestEnableClient(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+	cases := []struct {
+		desc     string
+		client   mgclients.Client
+		response mgclients.Client
+		token    string
+		status   int
+		err      error
+	}{
+		{
+			desc:   "enable client with valid token",
+			client: client,
+			response: mgclients.Client{
+				ID:     client.ID,
+				Status: mgclients.EnabledStatus,
+			},
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "enable client with invalid token",
+			client: client,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "enable client with empty id",
+			client: mgclients.Client{
+				ID: "",
+			},
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc: "enable client with invalid id",
+			client: mgclients.Client{
+				ID: "invalid",
+			},
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/%s/enable", us.URL, tc.client.ID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := svc.On("EnableClient", mock.Anything, mock.Anything, mock.Anything).Return(mgclients.Client{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDisableClient(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc     string
+		client   mgclients.Client
+		response mgclients.Client
+		token    string
+		status   int
+		err      error
+	}{
+		{
+			desc:   "disable user with valid token",
+			client: client,
+			response: mgclients.Client{
+				ID:     client.ID,
+				Status: mgclients.DisabledStatus,
+			},
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "disable user with invalid token",
+			client: client,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "disable user with empty id",
+			client: mgclients.Client{
+				ID: "",
+			},
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc: "disable user with invalid id",
+			client: mgclients.Client{
+				ID: "invalid",
+			},
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/%s/disable", us.URL, tc.client.ID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := svc.On("DisableClient", mock.Anything, mock.Anything, mock.Anything).Return(mgclients.Client{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUnlockClient(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
+	}{
+		{
+			desc:   "unlock user with valid token",
+			id:     client.ID,
+			token:  validToken,
+			status: http.StatusNoContent,
+			err:    nil,
+		},
+		{
+			desc:   "unlock user with invalid token",
+			id:     client.ID,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "unlock user with empty token",
+			id:     client.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "unlock user with normal user token",
+			id:     client.ID,
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/%s/unlock", us.URL, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("UnlockClient", mock.Anything, tc.token, tc.id).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteClient(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc     string
+		client   mgclients.Client
+		response mgclients.Client
+		token    string
+		status   int
+		err      error
+	}{
+		{
+			desc:   "delete user with valid token",
+			client: client,
+			response: mgclients.Client{
+				ID: client.ID,
+			},
+			token:  validToken,
+			status: http.StatusNoContent,
+			err:    nil,
+		},
+		{
+			desc:   "delete user with invalid token",
+			client: client,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "delete user with empty id",
+			client: mgclients.Client{
+				ID: "",
+			},
+			token:  validToken,
+			status: http.StatusMethodNotAllowed,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc: "delete user with invalid id",
+			client: mgclients.Client{
+				ID: "invalid",
+			},
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("%s/users/%s", us.URL, tc.client.ID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		repoCall := svc.On("DeleteClient", mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		repoCall.Unset()
 	}
 }
 
-func TestRefreshToken(t *testing.T) {
+func TestCreateServiceAccount(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
 
+	sa := mgclients.Client{
+		ID:     testsutil.GenerateUUID(t),
+		Name:   "backend",
+		Domain: validID,
+		Role:   mgclients.ServiceAccountRole,
+	}
+
 	cases := []struct {
 		desc        string
 		data        string
+		domainID    string
+		token       string
 		contentType string
 		status      int
 		err         error
 	}{
 		{
-			desc:        "refresh token with valid token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			desc:        "create service account with valid token",
+			data:        `{"name": "backend"}`,
+			domainID:    validID,
+			token:       validToken,
 			contentType: contentType,
 			status:      http.StatusCreated,
 			err:         nil,
 		},
 		{
-			desc:        "refresh token with invalid token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, inValidToken, validID),
+			desc:        "create service account with empty token",
+			data:        `{"name": "backend"}`,
+			domainID:    validID,
+			token:       "",
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			err:         apiutil.ErrBearerToken,
 		},
 		{
-			desc:        "refresh token with empty token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, "", validID),
+			desc:        "create service account with empty name",
+			data:        `{}`,
+			domainID:    validID,
+			token:       validToken,
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrValidation,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingName,
 		},
 		{
-			desc:        "refresh token with invalid domain",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, "invalid"),
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			desc:        "create service account with invalid content type",
+			data:        `{"name": "backend"}`,
+			domainID:    validID,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
 		},
 		{
-			desc:        "refresh token with malformed data",
-			data:        fmt.Sprintf(`{"refresh_token": %s, "domain_id": %s}`, validToken, validID),
+			desc:        "create service account with malformed body",
+			data:        `{"name": "backend"`,
+			domainID:    validID,
+			token:       validToken,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "refresh token with invalid contentype",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
-			contentType: "application/xml",
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:        "create service account with non domain admin token",
+			data:        `{"name": "backend"}`,
+			domainID:    validID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
 		},
 	}
 
@@ -1685,149 +2854,123 @@ func TestRefreshToken(t *testing.T) {
 		req := testRequest{
 			client:      us.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/users/tokens/refresh", us.URL),
+			url:         fmt.Sprintf("%s/domains/%s/service-accounts", us.URL, tc.domainID),
 			contentType: tc.contentType,
+			token:       tc.token,
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.Token{}, tc.err)
+		svcCall := svc.On("CreateServiceAccount", mock.Anything, tc.token, tc.domainID, mock.Anything).Return(sa, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		if tc.err != nil {
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			location := fmt.Sprintf("/domains/%s/service-accounts/%s", validID, sa.ID)
+			assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
 			var resBody respBody
 			err = json.NewDecoder(res.Body).Decode(&resBody)
-			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-			if resBody.Err != "" || resBody.Message != "" {
-				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
-			}
-			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, mgclients.ServiceAccountRole, resBody.Role, fmt.Sprintf("%s: expected role %s got %s", tc.desc, mgclients.ServiceAccountRole, resBody.Role))
 		}
-		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		svcCall.Unset()
 	}
 }
 
-func TestEnableClient(t *testing.T) {
+func TestViewServiceAccount(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
+
+	sa := mgclients.Client{ID: client.ID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+
 	cases := []struct {
-		desc     string
-		client   mgclients.Client
-		response mgclients.Client
-		token    string
-		status   int
-		err      error
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
 	}{
 		{
-			desc:   "enable client with valid token",
-			client: client,
-			response: mgclients.Client{
-				ID:     client.ID,
-				Status: mgclients.EnabledStatus,
-			},
+			desc:   "view service account with valid token",
+			id:     sa.ID,
 			token:  validToken,
 			status: http.StatusOK,
 			err:    nil,
 		},
 		{
-			desc:   "enable client with invalid token",
-			client: client,
-			token:  inValidToken,
+			desc:   "view service account with empty token",
+			id:     sa.ID,
+			token:  "",
 			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			err:    apiutil.ErrBearerToken,
 		},
 		{
-			desc: "enable client with empty id",
-			client: mgclients.Client{
-				ID: "",
-			},
+			desc:   "view non-existing service account",
+			id:     inValid,
 			token:  validToken,
 			status: http.StatusBadRequest,
-			err:    apiutil.ErrMissingID,
-		},
-		{
-			desc: "enable client with invalid id",
-			client: mgclients.Client{
-				ID: "invalid",
-			},
-			token:  validToken,
-			status: http.StatusForbidden,
-			err:    svcerr.ErrAuthorization,
+			err:    svcerr.ErrViewEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		data := toJSON(tc.client)
 		req := testRequest{
-			client:      us.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/users/%s/enable", us.URL, tc.client.ID),
-			contentType: contentType,
-			token:       tc.token,
-			body:        strings.NewReader(data),
+			client: us.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/service-accounts/%s", us.URL, validID, tc.id),
+			token:  tc.token,
 		}
 
-		svcCall := svc.On("EnableClient", mock.Anything, mock.Anything, mock.Anything).Return(mgclients.Client{}, tc.err)
+		svcCall := svc.On("ViewServiceAccount", mock.Anything, tc.token, validID, tc.id).Return(sa, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		if tc.err != nil {
-			var resBody respBody
-			err = json.NewDecoder(res.Body).Decode(&resBody)
-			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-			if resBody.Err != "" || resBody.Message != "" {
-				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
-			}
-			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		}
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		svcCall.Unset()
 	}
 }
 
-func TestDisableClient(t *testing.T) {
+func TestListServiceAccounts(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
 
+	sa := mgclients.Client{ID: client.ID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+
 	cases := []struct {
-		desc     string
-		client   mgclients.Client
-		response mgclients.Client
-		token    string
-		status   int
-		err      error
+		desc   string
+		query  string
+		token  string
+		status int
+		total  int
+		err    error
 	}{
 		{
-			desc:   "disable user with valid token",
-			client: client,
-			response: mgclients.Client{
-				ID:     client.ID,
-				Status: mgclients.DisabledStatus,
-			},
+			desc:   "list service accounts with valid token",
 			token:  validToken,
 			status: http.StatusOK,
+			total:  1,
 			err:    nil,
 		},
 		{
-			desc:   "disable user with invalid token",
-			client: client,
-			token:  inValidToken,
+			desc:   "list service accounts with empty token",
+			token:  "",
 			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			err:    apiutil.ErrBearerToken,
 		},
 		{
-			desc: "disable user with empty id",
-			client: mgclients.Client{
-				ID: "",
-			},
+			desc:   "list service accounts with invalid limit",
+			query:  "?limit=1000",
 			token:  validToken,
 			status: http.StatusBadRequest,
-			err:    apiutil.ErrMissingID,
+			err:    apiutil.ErrLimitSize,
 		},
 		{
-			desc: "disable user with invalid id",
-			client: mgclients.Client{
-				ID: "invalid",
-			},
+			desc:   "list service accounts with invalid status",
+			query:  "?status=invalid",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list service accounts with non domain admin token",
 			token:  validToken,
 			status: http.StatusForbidden,
 			err:    svcerr.ErrAuthorization,
@@ -1835,67 +2978,109 @@ func TestDisableClient(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		data := toJSON(tc.client)
 		req := testRequest{
-			client:      us.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/users/%s/disable", us.URL, tc.client.ID),
-			contentType: contentType,
-			token:       tc.token,
-			body:        strings.NewReader(data),
+			client: us.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/service-accounts%s", us.URL, validID, tc.query),
+			token:  tc.token,
 		}
 
-		svcCall := svc.On("DisableClient", mock.Anything, mock.Anything, mock.Anything).Return(mgclients.Client{}, tc.err)
+		page := mgclients.ClientsPage{
+			Page:    mgclients.Page{Total: 1, Limit: 10},
+			Clients: []mgclients.Client{sa},
+		}
+		svcCall := svc.On("ListServiceAccounts", mock.Anything, tc.token, validID, mock.Anything).Return(page, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, resBody.Total))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestDeleteClient(t *testing.T) {
+func TestDeleteServiceAccount(t *testing.T) {
 	us, svc, _ := newUsersServer()
 	defer us.Close()
 
 	cases := []struct {
-		desc     string
-		client   mgclients.Client
-		response mgclients.Client
-		token    string
-		status   int
-		err      error
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
 	}{
 		{
-			desc:   "delete user with valid token",
-			client: client,
-			response: mgclients.Client{
-				ID: client.ID,
-			},
+			desc:   "delete service account with valid token",
+			id:     client.ID,
 			token:  validToken,
 			status: http.StatusNoContent,
 			err:    nil,
 		},
 		{
-			desc:   "delete user with invalid token",
-			client: client,
-			token:  inValidToken,
+			desc:   "delete service account with empty token",
+			id:     client.ID,
+			token:  "",
 			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			err:    apiutil.ErrBearerToken,
 		},
 		{
-			desc: "delete user with empty id",
-			client: mgclients.Client{
-				ID: "",
-			},
+			desc:   "delete service account with non domain admin token",
+			id:     client.ID,
 			token:  validToken,
-			status: http.StatusMethodNotAllowed,
-			err:    apiutil.ErrMissingID,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/domains/%s/service-accounts/%s", us.URL, validID, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteServiceAccount", mock.Anything, tc.token, validID, tc.id).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestIssueServiceAccountKey(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
+	}{
+		{
+			desc:   "issue service account key with valid token",
+			id:     client.ID,
+			token:  validToken,
+			status: http.StatusCreated,
+			err:    nil,
 		},
 		{
-			desc: "delete user with invalid id",
-			client: mgclients.Client{
-				ID: "invalid",
-			},
+			desc:   "issue service account key with empty token",
+			id:     client.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "issue service account key with non domain admin token",
+			id:     client.ID,
 			token:  validToken,
 			status: http.StatusForbidden,
 			err:    svcerr.ErrAuthorization,
@@ -1903,21 +3088,26 @@ func TestDeleteClient(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		data := toJSON(tc.client)
 		req := testRequest{
-			client:      us.Client(),
-			method:      http.MethodDelete,
-			url:         fmt.Sprintf("%s/users/%s", us.URL, tc.client.ID),
-			contentType: contentType,
-			token:       tc.token,
-			body:        strings.NewReader(data),
+			client: us.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/domains/%s/service-accounts/%s/keys", us.URL, validID, tc.id),
+			token:  tc.token,
 		}
 
-		repoCall := svc.On("DeleteClient", mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		svcCall := svc.On("IssueServiceAccountKey", mock.Anything, tc.token, validID, tc.id).Return(&magistrala.Token{AccessToken: validToken}, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		repoCall.Unset()
+		if tc.err == nil {
+			var body struct {
+				AccessToken string `json:"access_token"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, validToken, body.AccessToken, fmt.Sprintf("%s: expected access token %s got %s", tc.desc, validToken, body.AccessToken))
+		}
+		svcCall.Unset()
 	}
 }
 
@@ -32,6 +32,25 @@ func registrationEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func invitedRegistrationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createInvitedClientReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		client, err := svc.RegisterInvitedClient(ctx, req.token, req.client)
+		if err != nil {
+			return nil, err
+		}
+
+		return createClientRes{
+			Client:  client,
+			created: true,
+		}, nil
+	}
+}
+
 func viewClientEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewClientReq)
@@ -48,6 +67,22 @@ func viewClientEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func viewClientByIdentityEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewClientByIdentityReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		client, err := svc.ViewClientByIdentity(ctx, req.token, req.domainID, req.identity)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewClientRes{Client: client}, nil
+	}
+}
+
 func viewProfileEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewProfileReq)
@@ -64,6 +99,70 @@ func viewProfileEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func deleteProfileEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if _, err := svc.DeleteProfile(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return deleteClientRes{true}, nil
+	}
+}
+
+func sendVerificationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(loginClientReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.SendVerification(ctx, req.Identity, req.Secret, req.sourceIP); err != nil {
+			return nil, err
+		}
+
+		return sendVerificationRes{Msg: VerificationSent}, nil
+	}
+}
+
+// This endpoint verifies the email when the user clicks on the link
+// sent to the email on the registration.
+func verifyEmailEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyEmailReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		client, err := svc.VerifyEmail(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewClientRes{Client: client}, nil
+	}
+}
+
+func exportProfileEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		archive, err := svc.ExportProfile(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportProfileRes{ProfileArchive: archive}, nil
+	}
+}
+
 func listClientsEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listClientsReq)
@@ -352,7 +451,28 @@ func issueTokenEndpoint(svc users.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		token, err := svc.IssueToken(ctx, req.Identity, req.Secret, req.DomainID)
+		token, err := svc.IssueToken(ctx, req.Identity, req.Secret, req.DomainID, req.sourceIP)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{
+			AccessToken:  token.GetAccessToken(),
+			RefreshToken: token.GetRefreshToken(),
+			AccessType:   token.GetAccessType(),
+			MFAToken:     token.GetMfaToken(),
+		}, nil
+	}
+}
+
+func issueMFATokenEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(issueMFATokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		token, err := svc.IssueMFAToken(ctx, req.MFAToken, req.Code)
 		if err != nil {
 			return nil, err
 		}
@@ -365,6 +485,53 @@ func issueTokenEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func enrollMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollMFAReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		enrollment, err := svc.EnrollMFA(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return enrollMFARes{Secret: enrollment.Secret, URI: enrollment.URI}, nil
+	}
+}
+
+func verifyMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(mfaCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		codes, err := svc.VerifyMFA(ctx, req.token, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return verifyMFARes{RecoveryCodes: codes}, nil
+	}
+}
+
+func disableMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(mfaCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DisableMFA(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return disableMFARes{}, nil
+	}
+}
+
 func refreshTokenEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(tokenReq)
@@ -417,6 +584,21 @@ func disableClientEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func unlockClientEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeClientStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.UnlockClient(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return unlockClientRes{}, nil
+	}
+}
+
 func deleteClientEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeClientStatusReq)
@@ -448,3 +630,102 @@ func buildClientsResponse(cp mgclients.MembersPage) clientsPageRes {
 
 	return res
 }
+
+func createServiceAccountEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createServiceAccountReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		sa, err := svc.CreateServiceAccount(ctx, req.token, req.domainID, req.serviceAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		return serviceAccountRes{
+			Client:  sa,
+			created: true,
+		}, nil
+	}
+}
+
+func viewServiceAccountEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(serviceAccountReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		sa, err := svc.ViewServiceAccount(ctx, req.token, req.domainID, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return serviceAccountRes{Client: sa}, nil
+	}
+}
+
+func listServiceAccountsEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listServiceAccountsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := mgclients.Page{
+			Status: req.status,
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListServiceAccounts(ctx, req.token, req.domainID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := serviceAccountsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			ServiceAccounts: []viewClientRes{},
+		}
+		for _, sa := range page.Clients {
+			res.ServiceAccounts = append(res.ServiceAccounts, viewClientRes{Client: sa})
+		}
+
+		return res, nil
+	}
+}
+
+func deleteServiceAccountEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(serviceAccountReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DeleteServiceAccount(ctx, req.token, req.domainID, req.id); err != nil {
+			return nil, err
+		}
+
+		return deleteClientRes{true}, nil
+	}
+}
+
+func issueServiceAccountKeyEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(serviceAccountReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		tkn, err := svc.IssueServiceAccountKey(ctx, req.token, req.domainID, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return serviceAccountKeyRes{AccessToken: tkn.GetAccessToken()}, nil
+	}
+}
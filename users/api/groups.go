@@ -22,9 +22,10 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for Groups API endpoints.
-func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
+func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger, proxies apiutil.TrustedProxies) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(proxies.SourceIPToContext),
 	}
 
 	r.Route("/groups", func(r chi.Router) {
@@ -11,6 +11,7 @@ import (
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/users"
+	"github.com/absmach/magistrala/users/mfa"
 )
 
 var _ users.Service = (*loggingMiddleware)(nil)
@@ -46,13 +47,71 @@ func (lm *loggingMiddleware) RegisterClient(ctx context.Context, token string, c
 	return lm.svc.RegisterClient(ctx, token, client)
 }
 
+// RegisterInvitedClient logs the register_invited_client request. It logs the client id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RegisterInvitedClient(ctx context.Context, token string, client mgclients.Client) (c mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", c.ID),
+				slog.String("name", c.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Register invited user failed", args...)
+			return
+		}
+		lm.logger.Info("Register invited user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RegisterInvitedClient(ctx, token, client)
+}
+
+// SendVerification logs the send_verification request. It logs the source IP and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) SendVerification(ctx context.Context, identity, secret, sourceIP string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("source_ip", sourceIP),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Send verification failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Send verification completed successfully", args...)
+	}(time.Now())
+	return lm.svc.SendVerification(ctx, identity, secret, sourceIP)
+}
+
+// VerifyEmail logs the verify_email request. It logs the client id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyEmail(ctx context.Context, verificationToken string) (c mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", c.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify email failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Verify email completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyEmail(ctx, verificationToken)
+}
+
 // IssueToken logs the issue_token request. It logs the client identity type and the time it took to complete the request.
 // If the request fails, it logs the error.
-func (lm *loggingMiddleware) IssueToken(ctx context.Context, identity, secret, domainID string) (t *magistrala.Token, err error) {
+func (lm *loggingMiddleware) IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (t *magistrala.Token, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
 			slog.String("domain_id", domainID),
+			slog.String("source_ip", sourceIP),
 		}
 		if t.AccessType != "" {
 			args = append(args, slog.String("access_type", t.AccessType))
@@ -64,7 +123,7 @@ func (lm *loggingMiddleware) IssueToken(ctx context.Context, identity, secret, d
 		}
 		lm.logger.Info("Issue token completed successfully", args...)
 	}(time.Now())
-	return lm.svc.IssueToken(ctx, identity, secret, domainID)
+	return lm.svc.IssueToken(ctx, identity, secret, domainID, sourceIP)
 }
 
 // RefreshToken logs the refresh_token request. It logs the refreshtoken, token type and the time it took to complete the request.
@@ -109,6 +168,28 @@ func (lm *loggingMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return lm.svc.ViewClient(ctx, token, id)
 }
 
+// ViewClientByIdentity logs the view_client_by_identity request. It logs the domain id, the client id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (c mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("user",
+				slog.String("id", c.ID),
+				slog.String("name", c.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View user by identity failed", args...)
+			return
+		}
+		lm.logger.Info("View user by identity completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewClientByIdentity(ctx, token, domainID, identity)
+}
+
 // ViewProfile logs the view_profile request. It logs the client id and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token string) (c mgclients.Client, err error) {
@@ -376,6 +457,145 @@ func (lm *loggingMiddleware) DisableClient(ctx context.Context, token, id string
 	return lm.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient logs the unlock_client request. It logs the client id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UnlockClient(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unlock user failed", args...)
+			return
+		}
+		lm.logger.Info("Unlock user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UnlockClient(ctx, token, id)
+}
+
+// CreateServiceAccount logs the create_service_account request. It logs the domain id, the service account id
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateServiceAccount(ctx context.Context, token, domainID string, sa mgclients.Client) (rsa mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("service_account",
+				slog.String("id", rsa.ID),
+				slog.String("name", rsa.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create service account failed", args...)
+			return
+		}
+		lm.logger.Info("Create service account completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CreateServiceAccount(ctx, token, domainID, sa)
+}
+
+// ViewServiceAccount logs the view_service_account request. It logs the domain id, the service account id
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewServiceAccount(ctx context.Context, token, domainID, id string) (sa mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("service_account_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View service account failed", args...)
+			return
+		}
+		lm.logger.Info("View service account completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewServiceAccount(ctx, token, domainID, id)
+}
+
+// ListServiceAccounts logs the list_service_accounts request. It logs the domain id, the page metadata
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListServiceAccounts(ctx context.Context, token, domainID string, pm mgclients.Page) (sp mgclients.ClientsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", sp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List service accounts failed", args...)
+			return
+		}
+		lm.logger.Info("List service accounts completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListServiceAccounts(ctx, token, domainID, pm)
+}
+
+// DeleteServiceAccount logs the delete_service_account request. It logs the domain id, the service account id
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) DeleteServiceAccount(ctx context.Context, token, domainID, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("service_account_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete service account failed", args...)
+			return
+		}
+		lm.logger.Info("Delete service account completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteServiceAccount(ctx, token, domainID, id)
+}
+
+// DeleteDomainServiceAccounts logs the delete_domain_service_accounts request. It logs the domain id, the number
+// of the deleted service accounts and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) DeleteDomainServiceAccounts(ctx context.Context, domainID string) (ids []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Int("deleted", len(ids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete domain service accounts failed", args...)
+			return
+		}
+		lm.logger.Info("Delete domain service accounts completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteDomainServiceAccounts(ctx, domainID)
+}
+
+// IssueServiceAccountKey logs the issue_service_account_key request. It logs the domain id, the service account id
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (t *magistrala.Token, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("service_account_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue service account key failed", args...)
+			return
+		}
+		lm.logger.Info("Issue service account key completed successfully", args...)
+	}(time.Now())
+	return lm.svc.IssueServiceAccountKey(ctx, token, domainID, id)
+}
+
 // ListMembers logs the list_members request. It logs the group id, and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, objectKind, objectID string, cp mgclients.Page) (mp mgclients.MembersPage, err error) {
@@ -451,3 +671,105 @@ func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string)
 	}(time.Now())
 	return lm.svc.DeleteClient(ctx, token, id)
 }
+
+// DeleteProfile logs the delete_profile request. It logs the client id and the time it took to complete the request.
+func (lm *loggingMiddleware) DeleteProfile(ctx context.Context, token string) (c mgclients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", c.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete profile failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete profile completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteProfile(ctx, token)
+}
+
+// ExportProfile logs the export_profile request. It logs the client id and the time it took to complete the request.
+func (lm *loggingMiddleware) ExportProfile(ctx context.Context, token string) (pa users.ProfileArchive, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", pa.Profile.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export profile failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Export profile completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExportProfile(ctx, token)
+}
+
+// IssueMFAToken logs the issue_mfa_token request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) IssueMFAToken(ctx context.Context, mfaToken, code string) (t *magistrala.Token, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue MFA token failed", args...)
+			return
+		}
+		lm.logger.Info("Issue MFA token completed successfully", args...)
+	}(time.Now())
+	return lm.svc.IssueMFAToken(ctx, mfaToken, code)
+}
+
+// EnrollMFA logs the enroll_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) EnrollMFA(ctx context.Context, token string) (e mfa.Enrollment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Enroll MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.EnrollMFA(ctx, token)
+}
+
+// VerifyMFA logs the verify_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyMFA(ctx context.Context, token, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Verify MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyMFA(ctx, token, code)
+}
+
+// DisableMFA logs the disable_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DisableMFA(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Disable MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisableMFA(ctx, token, code)
+}
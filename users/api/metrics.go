@@ -10,6 +10,7 @@ import (
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/users"
+	"github.com/absmach/magistrala/users/mfa"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -39,13 +40,40 @@ func (ms *metricsMiddleware) RegisterClient(ctx context.Context, token string, c
 	return ms.svc.RegisterClient(ctx, token, client)
 }
 
+// RegisterInvitedClient instruments RegisterInvitedClient method with metrics.
+func (ms *metricsMiddleware) RegisterInvitedClient(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "register_invited_client").Add(1)
+		ms.latency.With("method", "register_invited_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RegisterInvitedClient(ctx, token, client)
+}
+
+// SendVerification instruments SendVerification method with metrics.
+func (ms *metricsMiddleware) SendVerification(ctx context.Context, identity, secret, sourceIP string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "send_verification").Add(1)
+		ms.latency.With("method", "send_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.SendVerification(ctx, identity, secret, sourceIP)
+}
+
+// VerifyEmail instruments VerifyEmail method with metrics.
+func (ms *metricsMiddleware) VerifyEmail(ctx context.Context, verificationToken string) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_email").Add(1)
+		ms.latency.With("method", "verify_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyEmail(ctx, verificationToken)
+}
+
 // IssueToken instruments IssueToken method with metrics.
-func (ms *metricsMiddleware) IssueToken(ctx context.Context, identity, secret, domainID string) (*magistrala.Token, error) {
+func (ms *metricsMiddleware) IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (*magistrala.Token, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "issue_token").Add(1)
 		ms.latency.With("method", "issue_token").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.IssueToken(ctx, identity, secret, domainID)
+	return ms.svc.IssueToken(ctx, identity, secret, domainID, sourceIP)
 }
 
 // RefreshToken instruments RefreshToken method with metrics.
@@ -66,6 +94,15 @@ func (ms *metricsMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return ms.svc.ViewClient(ctx, token, id)
 }
 
+// ViewClientByIdentity instruments ViewClientByIdentity method with metrics.
+func (ms *metricsMiddleware) ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_client_by_identity").Add(1)
+		ms.latency.With("method", "view_client_by_identity").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewClientByIdentity(ctx, token, domainID, identity)
+}
+
 // ViewProfile instruments ViewProfile method with metrics.
 func (ms *metricsMiddleware) ViewProfile(ctx context.Context, token string) (mgclients.Client, error) {
 	defer func(begin time.Time) {
@@ -183,6 +220,69 @@ func (ms *metricsMiddleware) DisableClient(ctx context.Context, token, id string
 	return ms.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient instruments UnlockClient method with metrics.
+func (ms *metricsMiddleware) UnlockClient(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_client").Add(1)
+		ms.latency.With("method", "unlock_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UnlockClient(ctx, token, id)
+}
+
+// CreateServiceAccount instruments CreateServiceAccount method with metrics.
+func (ms *metricsMiddleware) CreateServiceAccount(ctx context.Context, token, domainID string, sa mgclients.Client) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_service_account").Add(1)
+		ms.latency.With("method", "create_service_account").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateServiceAccount(ctx, token, domainID, sa)
+}
+
+// ViewServiceAccount instruments ViewServiceAccount method with metrics.
+func (ms *metricsMiddleware) ViewServiceAccount(ctx context.Context, token, domainID, id string) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_service_account").Add(1)
+		ms.latency.With("method", "view_service_account").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewServiceAccount(ctx, token, domainID, id)
+}
+
+// ListServiceAccounts instruments ListServiceAccounts method with metrics.
+func (ms *metricsMiddleware) ListServiceAccounts(ctx context.Context, token, domainID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_service_accounts").Add(1)
+		ms.latency.With("method", "list_service_accounts").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListServiceAccounts(ctx, token, domainID, pm)
+}
+
+// DeleteServiceAccount instruments DeleteServiceAccount method with metrics.
+func (ms *metricsMiddleware) DeleteServiceAccount(ctx context.Context, token, domainID, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_service_account").Add(1)
+		ms.latency.With("method", "delete_service_account").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteServiceAccount(ctx, token, domainID, id)
+}
+
+// DeleteDomainServiceAccounts instruments DeleteDomainServiceAccounts method with metrics.
+func (ms *metricsMiddleware) DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_domain_service_accounts").Add(1)
+		ms.latency.With("method", "delete_domain_service_accounts").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteDomainServiceAccounts(ctx, domainID)
+}
+
+// IssueServiceAccountKey instruments IssueServiceAccountKey method with metrics.
+func (ms *metricsMiddleware) IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (*magistrala.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_service_account_key").Add(1)
+		ms.latency.With("method", "issue_service_account_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IssueServiceAccountKey(ctx, token, domainID, id)
+}
+
 // ListMembers instruments ListMembers method with metrics.
 func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, objectKind, objectID string, pm mgclients.Page) (mp mgclients.MembersPage, err error) {
 	defer func(begin time.Time) {
@@ -217,3 +317,57 @@ func (ms *metricsMiddleware) DeleteClient(ctx context.Context, token, id string)
 	}(time.Now())
 	return ms.svc.DeleteClient(ctx, token, id)
 }
+
+// DeleteProfile instruments DeleteProfile method with metrics.
+func (ms *metricsMiddleware) DeleteProfile(ctx context.Context, token string) (mgclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_profile").Add(1)
+		ms.latency.With("method", "delete_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteProfile(ctx, token)
+}
+
+// ExportProfile instruments ExportProfile method with metrics.
+func (ms *metricsMiddleware) ExportProfile(ctx context.Context, token string) (users.ProfileArchive, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_profile").Add(1)
+		ms.latency.With("method", "export_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExportProfile(ctx, token)
+}
+
+// IssueMFAToken instruments IssueMFAToken method with metrics.
+func (ms *metricsMiddleware) IssueMFAToken(ctx context.Context, mfaToken, code string) (*magistrala.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_mfa_token").Add(1)
+		ms.latency.With("method", "issue_mfa_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IssueMFAToken(ctx, mfaToken, code)
+}
+
+// EnrollMFA instruments EnrollMFA method with metrics.
+func (ms *metricsMiddleware) EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_mfa").Add(1)
+		ms.latency.With("method", "enroll_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.EnrollMFA(ctx, token)
+}
+
+// VerifyMFA instruments VerifyMFA method with metrics.
+func (ms *metricsMiddleware) VerifyMFA(ctx context.Context, token, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_mfa").Add(1)
+		ms.latency.With("method", "verify_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyMFA(ctx, token, code)
+}
+
+// DisableMFA instruments DisableMFA method with metrics.
+func (ms *metricsMiddleware) DisableMFA(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_mfa").Add(1)
+		ms.latency.With("method", "disable_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisableMFA(ctx, token, code)
+}
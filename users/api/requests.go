@@ -33,6 +33,18 @@ func (req createClientReq) validate() error {
 	return req.client.Validate()
 }
 
+type createInvitedClientReq struct {
+	createClientReq
+}
+
+func (req createInvitedClientReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return req.createClientReq.validate()
+}
+
 type viewClientReq struct {
 	token string
 	id    string
@@ -49,6 +61,26 @@ func (req viewClientReq) validate() error {
 	return nil
 }
 
+type viewClientByIdentityReq struct {
+	token    string
+	domainID string
+	identity string
+}
+
+func (req viewClientByIdentityReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.identity == "" {
+		return apiutil.ErrMissingIdentity
+	}
+
+	return nil
+}
+
 type viewProfileReq struct {
 	token string
 }
@@ -242,6 +274,7 @@ type loginClientReq struct {
 	Identity string `json:"identity,omitempty"`
 	Secret   string `json:"secret,omitempty"`
 	DomainID string `json:"domain_id,omitempty"`
+	sourceIP string
 }
 
 func (req loginClientReq) validate() error {
@@ -268,6 +301,62 @@ func (req tokenReq) validate() error {
 	return nil
 }
 
+type issueMFATokenReq struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+func (req issueMFATokenReq) validate() error {
+	if req.MFAToken == "" {
+		return apiutil.ErrMissingMFAToken
+	}
+	if req.Code == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyEmailReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrMissingVerificationToken
+	}
+
+	return nil
+}
+
+type enrollMFAReq struct {
+	token string
+}
+
+func (req enrollMFAReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type mfaCodeReq struct {
+	token string
+	Code  string `json:"code,omitempty"`
+}
+
+func (req mfaCodeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Code == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
 type passwResetReq struct {
 	Email string `json:"email"`
 	Host  string `json:"host"`
@@ -403,3 +492,65 @@ func (req unassignGroupsReq) validate() error {
 
 	return nil
 }
+
+type createServiceAccountReq struct {
+	token          string
+	domainID       string
+	serviceAccount mgclients.Client
+}
+
+func (req createServiceAccountReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.serviceAccount.Name == "" {
+		return apiutil.ErrMissingName
+	}
+	if len(req.serviceAccount.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type serviceAccountReq struct {
+	token    string
+	domainID string
+	id       string
+}
+
+func (req serviceAccountReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" || req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listServiceAccountsReq struct {
+	token    string
+	domainID string
+	status   mgclients.Status
+	offset   uint64
+	limit    uint64
+}
+
+func (req listServiceAccountsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > maxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
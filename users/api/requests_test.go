@@ -156,6 +156,55 @@ func TestViewClientReqValidate(t *testing.T) {
 	}
 }
 
+func TestViewClientByIdentityReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  viewClientByIdentityReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: viewClientByIdentityReq{
+				token:    valid,
+				domainID: validID,
+				identity: "invitee@example.com",
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: viewClientByIdentityReq{
+				token:    "",
+				domainID: validID,
+				identity: "invitee@example.com",
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty domain id",
+			req: viewClientByIdentityReq{
+				token:    valid,
+				domainID: "",
+				identity: "invitee@example.com",
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty identity",
+			req: viewClientByIdentityReq{
+				token:    valid,
+				domainID: validID,
+				identity: "",
+			},
+			err: apiutil.ErrMissingIdentity,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
 func TestViewProfileReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -736,6 +785,33 @@ func TestResetTokenReqValidate(t *testing.T) {
 	}
 }
 
+func TestVerifyEmailReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  verifyEmailReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: verifyEmailReq{
+				Token: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: verifyEmailReq{
+				Token: "",
+			},
+			err: apiutil.ErrMissingVerificationToken,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err)
+	}
+}
+
 func TestAssignUsersRequestValidate(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -9,25 +9,38 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/users"
 )
 
 // MailSent message response when link is sent.
 const MailSent = "Email with reset link is sent"
 
+// VerificationSent message response when verification link is sent.
+const VerificationSent = "Email with verification link is sent"
+
 var (
 	_ magistrala.Response = (*tokenRes)(nil)
 	_ magistrala.Response = (*viewClientRes)(nil)
+	_ magistrala.Response = (*exportProfileRes)(nil)
 	_ magistrala.Response = (*createClientRes)(nil)
 	_ magistrala.Response = (*changeClientStatusClientRes)(nil)
 	_ magistrala.Response = (*clientsPageRes)(nil)
 	_ magistrala.Response = (*viewMembersRes)(nil)
 	_ magistrala.Response = (*passwResetReqRes)(nil)
 	_ magistrala.Response = (*passwChangeRes)(nil)
+	_ magistrala.Response = (*sendVerificationRes)(nil)
 	_ magistrala.Response = (*assignUsersRes)(nil)
 	_ magistrala.Response = (*unassignUsersRes)(nil)
 	_ magistrala.Response = (*updateClientRes)(nil)
 	_ magistrala.Response = (*tokenRes)(nil)
 	_ magistrala.Response = (*deleteClientRes)(nil)
+	_ magistrala.Response = (*enrollMFARes)(nil)
+	_ magistrala.Response = (*verifyMFARes)(nil)
+	_ magistrala.Response = (*disableMFARes)(nil)
+	_ magistrala.Response = (*unlockClientRes)(nil)
+	_ magistrala.Response = (*serviceAccountRes)(nil)
+	_ magistrala.Response = (*serviceAccountsPageRes)(nil)
+	_ magistrala.Response = (*serviceAccountKeyRes)(nil)
 )
 
 type pageRes struct {
@@ -67,6 +80,7 @@ type tokenRes struct {
 	AccessToken  string `json:"access_token,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 	AccessType   string `json:"access_type,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
 func (res tokenRes) Code() int {
@@ -78,7 +92,68 @@ func (res tokenRes) Headers() map[string]string {
 }
 
 func (res tokenRes) Empty() bool {
-	return res.AccessToken == "" || res.RefreshToken == ""
+	return (res.AccessToken == "" || res.RefreshToken == "") && res.MFAToken == ""
+}
+
+type enrollMFARes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (res enrollMFARes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollMFARes) Empty() bool {
+	return false
+}
+
+type verifyMFARes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res verifyMFARes) Code() int {
+	return http.StatusOK
+}
+
+func (res verifyMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res verifyMFARes) Empty() bool {
+	return false
+}
+
+type disableMFARes struct{}
+
+func (res disableMFARes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res disableMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res disableMFARes) Empty() bool {
+	return true
+}
+
+type unlockClientRes struct{}
+
+func (res unlockClientRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unlockClientRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unlockClientRes) Empty() bool {
+	return true
 }
 
 type updateClientRes struct {
@@ -113,6 +188,24 @@ func (res viewClientRes) Empty() bool {
 	return false
 }
 
+type exportProfileRes struct {
+	users.ProfileArchive `json:",inline"`
+}
+
+func (res exportProfileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportProfileRes) Headers() map[string]string {
+	return map[string]string{
+		"Content-Disposition": `attachment; filename="profile.json"`,
+	}
+}
+
+func (res exportProfileRes) Empty() bool {
+	return false
+}
+
 type clientsPageRes struct {
 	pageRes
 	Clients []viewClientRes `json:"users"`
@@ -178,6 +271,22 @@ func (res passwResetReqRes) Empty() bool {
 	return false
 }
 
+type sendVerificationRes struct {
+	Msg string `json:"msg"`
+}
+
+func (res sendVerificationRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res sendVerificationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sendVerificationRes) Empty() bool {
+	return false
+}
+
 type passwChangeRes struct{}
 
 func (res passwChangeRes) Code() int {
@@ -239,3 +348,63 @@ func (res deleteClientRes) Headers() map[string]string {
 func (res deleteClientRes) Empty() bool {
 	return true
 }
+
+type serviceAccountRes struct {
+	mgclients.Client `json:",inline"`
+	created          bool
+}
+
+func (res serviceAccountRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res serviceAccountRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/domains/%s/service-accounts/%s", res.Domain, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res serviceAccountRes) Empty() bool {
+	return false
+}
+
+type serviceAccountsPageRes struct {
+	pageRes
+	ServiceAccounts []viewClientRes `json:"service_accounts"`
+}
+
+func (res serviceAccountsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res serviceAccountsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res serviceAccountsPageRes) Empty() bool {
+	return false
+}
+
+type serviceAccountKeyRes struct {
+	AccessToken string `json:"access_token"`
+}
+
+func (res serviceAccountKeyRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res serviceAccountKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res serviceAccountKeyRes) Empty() bool {
+	return false
+}
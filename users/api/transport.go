@@ -9,6 +9,7 @@ import (
 	"regexp"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/oauth2"
 	"github.com/absmach/magistrala/users"
@@ -17,9 +18,9 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for Users and Groups API endpoints.
-func MakeHandler(cls users.Service, grps groups.Service, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
-	clientsHandler(cls, mux, logger, pr, providers...)
-	groupsHandler(grps, mux, logger)
+func MakeHandler(cls users.Service, grps groups.Service, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, proxies apiutil.TrustedProxies, providers ...oauth2.Provider) http.Handler {
+	clientsHandler(cls, mux, logger, pr, proxies, providers...)
+	groupsHandler(grps, mux, logger, proxies)
 
 	mux.Get("/health", magistrala.Health("users", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
@@ -0,0 +1,117 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/users/lockout"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	attemptsPrefix   = "login_attempts:"
+	failuresField    = "failures"
+	lastFailureField = "last_failure"
+	lockedUntilField = "locked_until"
+)
+
+// The attempts expire after the window or the lockout, whichever is later,
+// so the expiration is only extended.
+var (
+	failScript = redis.NewScript(`
+redis.call('HINCRBY', KEYS[1], 'failures', 1)
+redis.call('HSET', KEYS[1], 'last_failure', ARGV[1])
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1`)
+	lockScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], 'locked_until', ARGV[1])
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1`)
+)
+
+var _ lockout.Repository = (*attemptsRepository)(nil)
+
+type attemptsRepository struct {
+	client *redis.Client
+}
+
+// NewAttemptsRepository returns redis login attempts repository
+// implementation. The attempts of each key are stored in a hash.
+func NewAttemptsRepository(client *redis.Client) lockout.Repository {
+	return &attemptsRepository{
+		client: client,
+	}
+}
+
+func (ar *attemptsRepository) Retrieve(ctx context.Context, key string) (lockout.Attempts, error) {
+	fields, err := ar.client.HGetAll(ctx, attemptsPrefix+key).Result()
+	if err != nil {
+		return lockout.Attempts{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toAttempts(fields)
+}
+
+func (ar *attemptsRepository) Fail(ctx context.Context, key string, window time.Duration) (lockout.Attempts, error) {
+	k := attemptsPrefix + key
+	now := time.Now()
+	if err := failScript.Run(ctx, ar.client, []string{k}, now.UnixNano(), window.Milliseconds()).Err(); err != nil {
+		return lockout.Attempts{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	fields, err := ar.client.HGetAll(ctx, k).Result()
+	if err != nil {
+		return lockout.Attempts{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toAttempts(fields)
+}
+
+func (ar *attemptsRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return errors.Wrap(repoerr.ErrUpdateEntity, errors.New("lockout is expired"))
+	}
+	if err := lockScript.Run(ctx, ar.client, []string{attemptsPrefix + key}, until.UnixNano(), ttl.Milliseconds()).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (ar *attemptsRepository) Remove(ctx context.Context, key string) error {
+	if err := ar.client.Del(ctx, attemptsPrefix+key).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func toAttempts(fields map[string]string) (lockout.Attempts, error) {
+	var a lockout.Attempts
+	for field, value := range fields {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return lockout.Attempts{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		switch field {
+		case failuresField:
+			a.Failures = uint64(n)
+		case lastFailureField:
+			a.LastFailure = time.Unix(0, n)
+		case lockedUntilField:
+			a.LockedUntil = time.Unix(0, n)
+		}
+	}
+
+	return a, nil
+}
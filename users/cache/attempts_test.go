@@ -0,0 +1,122 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/users/cache"
+	"github.com/absmach/magistrala/users/lockout"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptsFail(t *testing.T) {
+	repo := cache.NewAttemptsRepository(redisClient)
+	key := lockout.IdentityKey(testsutil.GenerateUUID(t))
+
+	for i := 1; i <= 3; i++ {
+		attempts, err := repo.Fail(context.Background(), key, time.Minute)
+		assert.Nil(t, err, "Fail: expected no error, got %s", err)
+		assert.Equal(t, uint64(i), attempts.Failures, "Fail: expected %d failures, got %d", i, attempts.Failures)
+		assert.WithinDuration(t, time.Now(), attempts.LastFailure, time.Second)
+	}
+
+	ttl := redisClient.PTTL(context.Background(), "login_attempts:"+key).Val()
+	assert.True(t, ttl > 0 && ttl <= time.Minute, "Fail: expected attempts to expire within the window, got %s", ttl)
+}
+
+func TestAttemptsRetrieve(t *testing.T) {
+	repo := cache.NewAttemptsRepository(redisClient)
+	key := lockout.IPKey("192.168.1.1")
+
+	_, err := repo.Fail(context.Background(), key, time.Minute)
+	assert.Nil(t, err, "Fail: expected no error, got %s", err)
+
+	cases := []struct {
+		desc     string
+		key      string
+		failures uint64
+		err      error
+	}{
+		{
+			desc:     "retrieve existing attempts",
+			key:      key,
+			failures: 1,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve non-existing attempts",
+			key:      lockout.IPKey("192.168.1.2"),
+			failures: 0,
+			err:      nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			attempts, err := repo.Retrieve(context.Background(), tc.key)
+			assert.True(t, errors.Contains(err, tc.err), "expected %s, got %s", tc.err, err)
+			assert.Equal(t, tc.failures, attempts.Failures)
+		})
+	}
+}
+
+func TestAttemptsLock(t *testing.T) {
+	repo := cache.NewAttemptsRepository(redisClient)
+	key := lockout.IdentityKey(testsutil.GenerateUUID(t))
+
+	_, err := repo.Fail(context.Background(), key, time.Second)
+	assert.Nil(t, err, "Fail: expected no error, got %s", err)
+
+	cases := []struct {
+		desc  string
+		until time.Time
+		err   error
+	}{
+		{
+			desc:  "lock with future time",
+			until: time.Now().Add(time.Hour),
+			err:   nil,
+		},
+		{
+			desc:  "lock with past time",
+			until: time.Now().Add(-time.Hour),
+			err:   repoerr.ErrUpdateEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Lock(context.Background(), key, tc.until)
+			assert.True(t, errors.Contains(err, tc.err), "expected %s, got %s", tc.err, err)
+			if err == nil {
+				attempts, err := repo.Retrieve(context.Background(), key)
+				assert.Nil(t, err, "Retrieve: expected no error, got %s", err)
+				assert.True(t, attempts.Locked(time.Now()), "expected login to be locked")
+				ttl := redisClient.PTTL(context.Background(), "login_attempts:"+key).Val()
+				assert.True(t, ttl > time.Second, "expected lockout to extend expiration, got %s", ttl)
+			}
+		})
+	}
+}
+
+func TestAttemptsRemove(t *testing.T) {
+	repo := cache.NewAttemptsRepository(redisClient)
+	key := lockout.IdentityKey(testsutil.GenerateUUID(t))
+
+	_, err := repo.Fail(context.Background(), key, time.Minute)
+	assert.Nil(t, err, "Fail: expected no error, got %s", err)
+	err = repo.Lock(context.Background(), key, time.Now().Add(time.Hour))
+	assert.Nil(t, err, "Lock: expected no error, got %s", err)
+
+	err = repo.Remove(context.Background(), key)
+	assert.Nil(t, err, "Remove: expected no error, got %s", err)
+
+	attempts, err := repo.Retrieve(context.Background(), key)
+	assert.Nil(t, err, "Retrieve: expected no error, got %s", err)
+	assert.Equal(t, lockout.Attempts{}, attempts)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/users/mfa"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -16,12 +17,32 @@ import (
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
 	// RegisterClient creates new client. In case of the failed registration, a
-	// non-nil error value is returned.
+	// non-nil error value is returned. The email verification is sent to the
+	// identity of the registered client.
 	RegisterClient(ctx context.Context, token string, client clients.Client) (clients.Client, error)
 
+	// RegisterInvitedClient creates the client invited to the domain, on
+	// behalf of the domain administrator identified by the invitation token.
+	// The email of the client is verified by the invitation.
+	RegisterInvitedClient(ctx context.Context, token string, client clients.Client) (clients.Client, error)
+
+	// SendVerification sends a new email verification to the client
+	// identified by the credentials, replacing the pending one. The
+	// verification is resent to the same email at most once a minute.
+	SendVerification(ctx context.Context, identity, secret, sourceIP string) error
+
+	// VerifyEmail marks the email of the client as verified with the
+	// verification token sent to the email.
+	VerifyEmail(ctx context.Context, verificationToken string) (clients.Client, error)
+
 	// ViewClient retrieves client info for a given client ID and an authorized token.
 	ViewClient(ctx context.Context, token, id string) (clients.Client, error)
 
+	// ViewClientByIdentity returns the ID and the name of the user with the
+	// identity to the administrator of the domain or the platform, so that
+	// the existing user is invited to the domain by the ID.
+	ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (clients.Client, error)
+
 	// ViewProfile retrieves client info for a given token.
 	ViewProfile(ctx context.Context, token string) (clients.Client, error)
 
@@ -66,14 +87,70 @@ type Service interface {
 	// DisableClient logically disables the client identified with the provided ID.
 	DisableClient(ctx context.Context, token, id string) (clients.Client, error)
 
+	// UnlockClient removes the failed login attempts and the login lockout
+	// of the client identified with the provided ID.
+	UnlockClient(ctx context.Context, token, id string) error
+
 	// DeleteClient deletes client with given ID.
 	DeleteClient(ctx context.Context, token, id string) error
 
+	// DeleteProfile marks the client identified by the token as deleted and
+	// revokes its tokens. The client is deleted once the deletion grace
+	// period passes.
+	DeleteProfile(ctx context.Context, token string) (clients.Client, error)
+
+	// ExportProfile retrieves the personal data of the client identified by
+	// the token: the profile, the domain memberships and the administered
+	// things and groups.
+	ExportProfile(ctx context.Context, token string) (ProfileArchive, error)
+
+	// CreateServiceAccount creates the service account in the domain. The
+	// service account has no secret and authenticates only with the API keys
+	// or the OAuth2 client credentials grant of the auth service.
+	CreateServiceAccount(ctx context.Context, token, domainID string, sa clients.Client) (clients.Client, error)
+
+	// ViewServiceAccount retrieves the service account of the domain.
+	ViewServiceAccount(ctx context.Context, token, domainID, id string) (clients.Client, error)
+
+	// ListServiceAccounts retrieves the service accounts of the domain.
+	ListServiceAccounts(ctx context.Context, token, domainID string, pm clients.Page) (clients.ClientsPage, error)
+
+	// DeleteServiceAccount deletes the service account of the domain
+	// together with its policies, and revokes its tokens.
+	DeleteServiceAccount(ctx context.Context, token, domainID, id string) error
+
+	// DeleteDomainServiceAccounts deletes the service accounts of the deleted
+	// domain, and returns the IDs of the deleted service accounts.
+	DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error)
+
+	// IssueServiceAccountKey issues the API key of the service account of
+	// the domain. The API key is used as the client secret of the OAuth2
+	// client credentials grant.
+	IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (*magistrala.Token, error)
+
 	// Identify returns the client id from the given token.
 	Identify(ctx context.Context, tkn string) (string, error)
 
-	// IssueToken issues a new access and refresh token.
-	IssueToken(ctx context.Context, identity, secret, domainID string) (*magistrala.Token, error)
+	// IssueToken issues a new access and refresh token. If the user has the
+	// MFA enabled, only the MFA token of the login challenge is issued.
+	// The failed logins of the identity and the source IP are tracked, and
+	// the logins are delayed and locked out after too many failed attempts.
+	IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (*magistrala.Token, error)
+
+	// IssueMFAToken completes the login challenge identified by the MFA token
+	// with the TOTP or recovery code, and issues a new access and refresh token.
+	IssueMFAToken(ctx context.Context, mfaToken, code string) (*magistrala.Token, error)
+
+	// EnrollMFA generates a new TOTP secret of the user. The MFA is enabled
+	// once the secret is verified.
+	EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error)
+
+	// VerifyMFA enables the MFA of the user if the TOTP code of the enrolled
+	// secret is valid, and returns the recovery codes.
+	VerifyMFA(ctx context.Context, token, code string) ([]string, error)
+
+	// DisableMFA disables the MFA of the user with the TOTP or recovery code.
+	DisableMFA(ctx context.Context, token, code string) error
 
 	// RefreshToken refreshes expired access tokens.
 	// After an access token expires, the refresh token is used to get
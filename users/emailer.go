@@ -9,4 +9,7 @@ package users
 type Emailer interface {
 	// SendPasswordReset sends an email to the user with a link to reset the password.
 	SendPasswordReset(To []string, host, user, token string) error
+
+	// SendVerification sends an email to the user with a link to verify the email.
+	SendVerification(To []string, user, token string) error
 }
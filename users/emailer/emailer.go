@@ -13,17 +13,29 @@ import (
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL        string
+	verificationURL string
+	agent           *email.Agent
+	verifyAgent     *email.Agent
 }
 
-// New creates new emailer utility.
-func New(url string, c *email.Config) (users.Emailer, error) {
+// New creates new emailer utility. The password reset and the email
+// verification are sent with the templates of the respective configs.
+func New(resetURL, verificationURL string, c, vc *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	ve, verr := email.New(vc)
+	if err == nil {
+		err = verr
+	}
+	return &emailer{resetURL: resetURL, verificationURL: verificationURL, agent: e, verifyAgent: ve}, err
 }
 
 func (e *emailer) SendPasswordReset(to []string, host, user, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
 	return e.agent.Send(to, "", "Password Reset Request", "", user, url, "")
 }
+
+func (e *emailer) SendVerification(to []string, user, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.verificationURL, token)
+	return e.verifyAgent.Send(to, "", "Email Verification", "", user, url, "")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/users"
+)
+
+const domainRemove = "domain.remove"
+
+// StartDomainDeletion starts consuming the removed domains events, deleting
+// the service accounts and the groups of the removed domains. The instances
+// should share the consumer, so every removed domain is handled once.
+func StartDomainDeletion(ctx context.Context, consumer string, sub events.Subscriber, usvc users.Service, gsvc groups.Service) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   store.StreamAllEvents,
+		Handler:  NewDomainDeletionHandler(usvc, gsvc),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type domainDeletionHandler struct {
+	usvc users.Service
+	gsvc groups.Service
+}
+
+// NewDomainDeletionHandler returns the event handler deleting
+// the service accounts and the groups of the removed domains.
+func NewDomainDeletionHandler(usvc users.Service, gsvc groups.Service) events.EventHandler {
+	return &domainDeletionHandler{
+		usvc: usvc,
+		gsvc: gsvc,
+	}
+}
+
+func (h *domainDeletionHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+	if msg["operation"] != domainRemove {
+		return nil
+	}
+
+	id := events.Read(msg, "id", "")
+	if id == "" {
+		return svcerr.ErrMalformedEntity
+	}
+	if _, err := h.usvc.DeleteDomainServiceAccounts(ctx, id); err != nil {
+		return err
+	}
+	if _, err := h.gsvc.DeleteDomainGroups(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
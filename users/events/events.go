@@ -16,6 +16,7 @@ const (
 	clientUpdate       = clientPrefix + "update"
 	clientRemove       = clientPrefix + "remove"
 	clientView         = clientPrefix + "view"
+	clientViewIdentity = clientPrefix + "view_by_identity"
 	profileView        = clientPrefix + "view_profile"
 	clientList         = clientPrefix + "list"
 	clientSearch       = clientPrefix + "search"
@@ -28,6 +29,23 @@ const (
 	sendPasswordReset  = clientPrefix + "send_password_reset"
 	oauthCallback      = clientPrefix + "oauth_callback"
 	deleteClient       = clientPrefix + "delete"
+	issueMFAToken      = clientPrefix + "issue_mfa_token"
+	enrollMFA          = clientPrefix + "enroll_mfa"
+	verifyMFA          = clientPrefix + "verify_mfa"
+	disableMFA         = clientPrefix + "disable_mfa"
+	loginFailed        = clientPrefix + "login_failed"
+	loginLocked        = clientPrefix + "login_locked"
+	unlockClient       = clientPrefix + "unlock"
+	exportProfile      = clientPrefix + "export_profile"
+	sendVerification   = clientPrefix + "send_verification"
+	verifyEmail        = clientPrefix + "verify_email"
+
+	serviceAccountPrefix   = clientPrefix + "service_account."
+	serviceAccountCreate   = serviceAccountPrefix + "create"
+	serviceAccountView     = serviceAccountPrefix + "view"
+	serviceAccountList     = serviceAccountPrefix + "list"
+	serviceAccountRemove   = serviceAccountPrefix + "remove"
+	serviceAccountIssueKey = serviceAccountPrefix + "issue_key"
 )
 
 var (
@@ -35,6 +53,7 @@ var (
 	_ events.Event = (*updateClientEvent)(nil)
 	_ events.Event = (*removeClientEvent)(nil)
 	_ events.Event = (*viewClientEvent)(nil)
+	_ events.Event = (*viewClientByIdentityEvent)(nil)
 	_ events.Event = (*viewProfileEvent)(nil)
 	_ events.Event = (*listClientEvent)(nil)
 	_ events.Event = (*listClientByGroupEvent)(nil)
@@ -47,6 +66,21 @@ var (
 	_ events.Event = (*sendPasswordResetEvent)(nil)
 	_ events.Event = (*oauthCallbackEvent)(nil)
 	_ events.Event = (*deleteClientEvent)(nil)
+	_ events.Event = (*exportProfileEvent)(nil)
+	_ events.Event = (*sendVerificationEvent)(nil)
+	_ events.Event = (*verifyEmailEvent)(nil)
+	_ events.Event = (*issueMFATokenEvent)(nil)
+	_ events.Event = (*enrollMFAEvent)(nil)
+	_ events.Event = (*verifyMFAEvent)(nil)
+	_ events.Event = (*disableMFAEvent)(nil)
+	_ events.Event = (*loginFailedEvent)(nil)
+	_ events.Event = (*loginLockedEvent)(nil)
+	_ events.Event = (*unlockClientEvent)(nil)
+	_ events.Event = (*createServiceAccountEvent)(nil)
+	_ events.Event = (*viewServiceAccountEvent)(nil)
+	_ events.Event = (*listServiceAccountsEvent)(nil)
+	_ events.Event = (*removeServiceAccountEvent)(nil)
+	_ events.Event = (*issueServiceAccountKeyEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -137,6 +171,19 @@ func (rce removeClientEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type viewClientByIdentityEvent struct {
+	id       string
+	domainID string
+}
+
+func (vce viewClientByIdentityEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": clientViewIdentity,
+		"id":        vce.id,
+		"domain_id": vce.domainID,
+	}, nil
+}
+
 type viewClientEvent struct {
 	mgclients.Client
 }
@@ -425,3 +472,189 @@ func (dce deleteClientEvent) Encode() (map[string]interface{}, error) {
 		"id":        dce.id,
 	}, nil
 }
+
+type exportProfileEvent struct {
+	id string
+}
+
+func (epe exportProfileEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": exportProfile,
+		"id":        epe.id,
+	}, nil
+}
+
+type sendVerificationEvent struct {
+	identity string
+}
+
+func (sve sendVerificationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": sendVerification,
+		"identity":  sve.identity,
+	}, nil
+}
+
+type verifyEmailEvent struct {
+	id         string
+	identity   string
+	verifiedAt time.Time
+}
+
+func (vee verifyEmailEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   verifyEmail,
+		"id":          vee.id,
+		"identity":    vee.identity,
+		"verified_at": vee.verifiedAt,
+	}, nil
+}
+
+type issueMFATokenEvent struct{}
+
+func (imte issueMFATokenEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": issueMFAToken,
+	}, nil
+}
+
+type enrollMFAEvent struct{}
+
+func (eme enrollMFAEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": enrollMFA,
+	}, nil
+}
+
+type verifyMFAEvent struct{}
+
+func (vme verifyMFAEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": verifyMFA,
+	}, nil
+}
+
+type disableMFAEvent struct{}
+
+func (dme disableMFAEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": disableMFA,
+	}, nil
+}
+
+type loginFailedEvent struct {
+	identity string
+	sourceIP string
+}
+
+func (lfe loginFailedEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": loginFailed,
+		"identity":  lfe.identity,
+		"source_ip": lfe.sourceIP,
+	}, nil
+}
+
+type loginLockedEvent struct {
+	identity string
+	sourceIP string
+}
+
+func (lle loginLockedEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": loginLocked,
+		"identity":  lle.identity,
+		"source_ip": lle.sourceIP,
+	}, nil
+}
+
+type unlockClientEvent struct {
+	id string
+}
+
+func (uce unlockClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": unlockClient,
+		"id":        uce.id,
+	}, nil
+}
+
+type createServiceAccountEvent struct {
+	mgclients.Client
+}
+
+func (csae createServiceAccountEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":  serviceAccountCreate,
+		"id":         csae.ID,
+		"domain":     csae.Domain,
+		"status":     csae.Status.String(),
+		"created_at": csae.CreatedAt,
+	}
+	if csae.Name != "" {
+		val["name"] = csae.Name
+	}
+	if len(csae.Tags) > 0 {
+		val["tags"] = csae.Tags
+	}
+	if csae.Metadata != nil {
+		val["metadata"] = csae.Metadata
+	}
+
+	return val, nil
+}
+
+type viewServiceAccountEvent struct {
+	domainID string
+	id       string
+}
+
+func (vsae viewServiceAccountEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": serviceAccountView,
+		"domain":    vsae.domainID,
+		"id":        vsae.id,
+	}, nil
+}
+
+type listServiceAccountsEvent struct {
+	domainID string
+	mgclients.Page
+}
+
+func (lsae listServiceAccountsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": serviceAccountList,
+		"domain":    lsae.domainID,
+		"total":     lsae.Total,
+		"offset":    lsae.Offset,
+		"limit":     lsae.Limit,
+		"status":    lsae.Status.String(),
+	}, nil
+}
+
+type removeServiceAccountEvent struct {
+	domainID string
+	id       string
+}
+
+func (rsae removeServiceAccountEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": serviceAccountRemove,
+		"domain":    rsae.domainID,
+		"id":        rsae.id,
+	}, nil
+}
+
+type issueServiceAccountKeyEvent struct {
+	domainID string
+	id       string
+}
+
+func (isake issueServiceAccountKeyEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": serviceAccountIssueKey,
+		"domain":    isake.domainID,
+		"id":        isake.id,
+	}, nil
+}
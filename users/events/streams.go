@@ -8,9 +8,12 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/users"
+	"github.com/absmach/magistrala/users/mfa"
 )
 
 const streamID = "magistrala.users"
@@ -53,6 +56,50 @@ func (es *eventStore) RegisterClient(ctx context.Context, token string, user mgc
 	return user, nil
 }
 
+func (es *eventStore) RegisterInvitedClient(ctx context.Context, token string, user mgclients.Client) (mgclients.Client, error) {
+	user, err := es.svc.RegisterInvitedClient(ctx, token, user)
+	if err != nil {
+		return user, err
+	}
+
+	event := createClientEvent{
+		user,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (es *eventStore) SendVerification(ctx context.Context, identity, secret, sourceIP string) error {
+	if err := es.svc.SendVerification(ctx, identity, secret, sourceIP); err != nil {
+		return es.loginFailed(ctx, identity, sourceIP, err)
+	}
+
+	return es.Publish(ctx, sendVerificationEvent{identity: identity})
+}
+
+func (es *eventStore) VerifyEmail(ctx context.Context, verificationToken string) (mgclients.Client, error) {
+	user, err := es.svc.VerifyEmail(ctx, verificationToken)
+	if err != nil {
+		return user, err
+	}
+
+	event := verifyEmailEvent{
+		id:         user.ID,
+		identity:   user.Credentials.Identity,
+		verifiedAt: user.VerifiedAt,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
 func (es *eventStore) UpdateClient(ctx context.Context, token string, user mgclients.Client) (mgclients.Client, error) {
 	user, err := es.svc.UpdateClient(ctx, token, user)
 	if err != nil {
@@ -127,6 +174,24 @@ func (es *eventStore) ViewClient(ctx context.Context, token, id string) (mgclien
 	return user, nil
 }
 
+func (es *eventStore) ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (mgclients.Client, error) {
+	user, err := es.svc.ViewClientByIdentity(ctx, token, domainID, identity)
+	if err != nil {
+		return user, err
+	}
+
+	event := viewClientByIdentityEvent{
+		id:       user.ID,
+		domainID: domainID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
 func (es *eventStore) ViewProfile(ctx context.Context, token string) (mgclients.Client, error) {
 	user, err := es.svc.ViewProfile(ctx, token)
 	if err != nil {
@@ -255,10 +320,10 @@ func (es *eventStore) GenerateResetToken(ctx context.Context, email, host string
 	return es.Publish(ctx, event)
 }
 
-func (es *eventStore) IssueToken(ctx context.Context, identity, secret, domainID string) (*magistrala.Token, error) {
-	token, err := es.svc.IssueToken(ctx, identity, secret, domainID)
+func (es *eventStore) IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (*magistrala.Token, error) {
+	token, err := es.svc.IssueToken(ctx, identity, secret, domainID, sourceIP)
 	if err != nil {
-		return token, err
+		return token, es.loginFailed(ctx, identity, sourceIP, err)
 	}
 
 	event := issueTokenEvent{
@@ -273,6 +338,31 @@ func (es *eventStore) IssueToken(ctx context.Context, identity, secret, domainID
 	return token, nil
 }
 
+// loginFailed publishes the event of the failed or locked out login, and
+// returns the login error.
+func (es *eventStore) loginFailed(ctx context.Context, identity, sourceIP string, err error) error {
+	var event events.Event
+	switch {
+	case errors.Contains(err, svcerr.ErrLoginLocked):
+		event = loginLockedEvent{
+			identity: identity,
+			sourceIP: sourceIP,
+		}
+	case errors.Contains(err, svcerr.ErrLogin), errors.Contains(err, svcerr.ErrAuthentication):
+		event = loginFailedEvent{
+			identity: identity,
+			sourceIP: sourceIP,
+		}
+	default:
+		return err
+	}
+	if errPublish := es.Publish(ctx, event); errPublish != nil {
+		return errors.Wrap(err, errPublish)
+	}
+
+	return err
+}
+
 func (es *eventStore) RefreshToken(ctx context.Context, refreshToken, domainID string) (*magistrala.Token, error) {
 	token, err := es.svc.RefreshToken(ctx, refreshToken, domainID)
 	if err != nil {
@@ -340,3 +430,165 @@ func (es *eventStore) DeleteClient(ctx context.Context, token, id string) error
 
 	return es.Publish(ctx, event)
 }
+
+func (es *eventStore) DeleteProfile(ctx context.Context, token string) (mgclients.Client, error) {
+	client, err := es.svc.DeleteProfile(ctx, token)
+	if err != nil {
+		return client, err
+	}
+
+	event := deleteClientEvent{
+		id: client.ID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+func (es *eventStore) ExportProfile(ctx context.Context, token string) (users.ProfileArchive, error) {
+	archive, err := es.svc.ExportProfile(ctx, token)
+	if err != nil {
+		return archive, err
+	}
+
+	event := exportProfileEvent{
+		id: archive.Profile.ID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return archive, err
+	}
+
+	return archive, nil
+}
+
+func (es *eventStore) CreateServiceAccount(ctx context.Context, token, domainID string, sa mgclients.Client) (mgclients.Client, error) {
+	sa, err := es.svc.CreateServiceAccount(ctx, token, domainID, sa)
+	if err != nil {
+		return sa, err
+	}
+
+	if err := es.Publish(ctx, createServiceAccountEvent{sa}); err != nil {
+		return sa, err
+	}
+
+	return sa, nil
+}
+
+func (es *eventStore) ViewServiceAccount(ctx context.Context, token, domainID, id string) (mgclients.Client, error) {
+	sa, err := es.svc.ViewServiceAccount(ctx, token, domainID, id)
+	if err != nil {
+		return sa, err
+	}
+
+	if err := es.Publish(ctx, viewServiceAccountEvent{domainID: domainID, id: id}); err != nil {
+		return sa, err
+	}
+
+	return sa, nil
+}
+
+func (es *eventStore) ListServiceAccounts(ctx context.Context, token, domainID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	sp, err := es.svc.ListServiceAccounts(ctx, token, domainID, pm)
+	if err != nil {
+		return sp, err
+	}
+
+	pm.Total = sp.Total
+	if err := es.Publish(ctx, listServiceAccountsEvent{domainID, pm}); err != nil {
+		return sp, err
+	}
+
+	return sp, nil
+}
+
+func (es *eventStore) DeleteServiceAccount(ctx context.Context, token, domainID, id string) error {
+	if err := es.svc.DeleteServiceAccount(ctx, token, domainID, id); err != nil {
+		return err
+	}
+
+	return es.Publish(ctx, removeServiceAccountEvent{domainID: domainID, id: id})
+}
+
+func (es *eventStore) DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error) {
+	ids, err := es.svc.DeleteDomainServiceAccounts(ctx, domainID)
+	// The service accounts deleted before the failure are published as well.
+	for _, id := range ids {
+		if errPublish := es.Publish(ctx, removeServiceAccountEvent{domainID: domainID, id: id}); errPublish != nil {
+			return ids, errors.Wrap(errPublish, err)
+		}
+	}
+
+	return ids, err
+}
+
+func (es *eventStore) IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (*magistrala.Token, error) {
+	tkn, err := es.svc.IssueServiceAccountKey(ctx, token, domainID, id)
+	if err != nil {
+		return tkn, err
+	}
+
+	if err := es.Publish(ctx, issueServiceAccountKeyEvent{domainID: domainID, id: id}); err != nil {
+		return tkn, err
+	}
+
+	return tkn, nil
+}
+
+func (es *eventStore) IssueMFAToken(ctx context.Context, mfaToken, code string) (*magistrala.Token, error) {
+	token, err := es.svc.IssueMFAToken(ctx, mfaToken, code)
+	if err != nil {
+		return token, err
+	}
+
+	if err := es.Publish(ctx, issueMFATokenEvent{}); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+func (es *eventStore) EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error) {
+	enrollment, err := es.svc.EnrollMFA(ctx, token)
+	if err != nil {
+		return enrollment, err
+	}
+
+	if err := es.Publish(ctx, enrollMFAEvent{}); err != nil {
+		return enrollment, err
+	}
+
+	return enrollment, nil
+}
+
+func (es *eventStore) VerifyMFA(ctx context.Context, token, code string) ([]string, error) {
+	codes, err := es.svc.VerifyMFA(ctx, token, code)
+	if err != nil {
+		return codes, err
+	}
+
+	if err := es.Publish(ctx, verifyMFAEvent{}); err != nil {
+		return codes, err
+	}
+
+	return codes, nil
+}
+
+func (es *eventStore) UnlockClient(ctx context.Context, token, id string) error {
+	if err := es.svc.UnlockClient(ctx, token, id); err != nil {
+		return err
+	}
+
+	return es.Publish(ctx, unlockClientEvent{id: id})
+}
+
+func (es *eventStore) DisableMFA(ctx context.Context, token, code string) error {
+	if err := es.svc.DisableMFA(ctx, token, code); err != nil {
+		return err
+	}
+
+	return es.Publish(ctx, disableMFAEvent{})
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "context"
+
+// Invitations checks the invitations sent by email, kept by the
+// invitations service.
+//
+//go:generate mockery --name Invitations --output=./mocks --filename invitations.go --quiet --note "Copyright (c) Abstract Machines"
+type Invitations interface {
+	// CheckPending checks that the invitation to the domain, sent to the
+	// email that has no account yet, is pending and that it is visible to
+	// the user the invitation token is issued to.
+	CheckPending(ctx context.Context, token, email, domainID string) error
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package invitations checks the invitations sent by email, kept by the
+// invitations service, before the invited users are registered.
+package invitations
@@ -0,0 +1,33 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package invitations
+
+import (
+	"context"
+
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/users"
+)
+
+var _ users.Invitations = (*invitations)(nil)
+
+type invitations struct {
+	sdk mgsdk.SDK
+}
+
+// New returns the invitations checked with the SDK using the invitation
+// token, which is issued to the user who sent the invitation.
+func New(sdk mgsdk.SDK) users.Invitations {
+	return &invitations{sdk: sdk}
+}
+
+func (inv *invitations) CheckPending(_ context.Context, token, email, domainID string) error {
+	// Only the pending email invitations are retrieved by the email,
+	// and only by the user who sent them or the domain administrators.
+	if _, err := inv.sdk.EmailInvitation(email, domainID, token); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lockout contains the tracking of the failed user logins, which
+// delays and temporarily locks out the repeated login attempts.
+package lockout
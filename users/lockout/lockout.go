@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	identityPrefix = "identity:"
+	ipPrefix       = "ip:"
+)
+
+// Config contains the limits of the failed login attempts. Zero maximum
+// attempts disable the lockout.
+type Config struct {
+	// MaxAttempts is the number of failed logins of the identity within
+	// the window after which the identity is locked out.
+	MaxAttempts uint64 `env:"MAX_ATTEMPTS"    envDefault:"10"`
+	// MaxIPAttempts is the number of failed logins from the source IP
+	// within the window after which the source IP is locked out.
+	MaxIPAttempts uint64 `env:"MAX_IP_ATTEMPTS" envDefault:"100"`
+	// Window is the period in which the failed logins are counted.
+	Window time.Duration `env:"WINDOW"          envDefault:"15m"`
+	// Duration is the period for which the login is locked out.
+	Duration time.Duration `env:"DURATION"        envDefault:"15m"`
+	// BaseDelay is the delay of the next login of the identity after the
+	// first failed login, which doubles with each next failed login.
+	BaseDelay time.Duration `env:"BASE_DELAY"      envDefault:"1s"`
+	// MaxDelay is the upper bound of the delay between the logins.
+	MaxDelay time.Duration `env:"MAX_DELAY"       envDefault:"30s"`
+}
+
+// Attempts are the failed login attempts of the identity or source IP.
+type Attempts struct {
+	Failures    uint64
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// Delay returns the delay of the next login after the given number of the
+// failed logins.
+func (cfg Config) Delay(failures uint64) time.Duration {
+	if failures == 0 || cfg.BaseDelay <= 0 {
+		return 0
+	}
+	delay := cfg.BaseDelay
+	for i := uint64(1); i < failures && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, cfg.MaxDelay)
+}
+
+// Locked reports whether the login is locked out at the given time.
+func (a Attempts) Locked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// Delayed reports whether the login at the given time is attempted before
+// the delay after the last failed login expires.
+func (a Attempts) Delayed(cfg Config, now time.Time) bool {
+	return now.Before(a.LastFailure.Add(cfg.Delay(a.Failures)))
+}
+
+// IdentityKey returns the key of the login attempts of the user identity.
+func IdentityKey(identity string) string {
+	return identityPrefix + strings.ToLower(identity)
+}
+
+// IPKey returns the key of the login attempts from the source IP.
+func IPKey(ip string) string {
+	return ipPrefix + ip
+}
+
+// Repository specifies the login attempts persistence API. The attempts
+// expire once the window passes without the failed login.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Retrieve returns the login attempts of the key. The zero attempts are
+	// returned if there are no failed logins.
+	Retrieve(ctx context.Context, key string) (Attempts, error)
+
+	// Fail records the failed login of the key and returns the updated
+	// attempts.
+	Fail(ctx context.Context, key string, window time.Duration) (Attempts, error)
+
+	// Lock locks out the login of the key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+
+	// Remove removes the login attempts and the lockout of the key.
+	Remove(ctx context.Context, key string) error
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/users/lockout"
+	"github.com/stretchr/testify/assert"
+)
+
+var cfg = lockout.Config{
+	MaxAttempts:   3,
+	MaxIPAttempts: 10,
+	Window:        time.Minute,
+	Duration:      time.Minute,
+	BaseDelay:     time.Second,
+	MaxDelay:      10 * time.Second,
+}
+
+func TestDelay(t *testing.T) {
+	cases := []struct {
+		desc     string
+		cfg      lockout.Config
+		failures uint64
+		delay    time.Duration
+	}{
+		{
+			desc:     "no failures",
+			cfg:      cfg,
+			failures: 0,
+			delay:    0,
+		},
+		{
+			desc:     "first failure",
+			cfg:      cfg,
+			failures: 1,
+			delay:    time.Second,
+		},
+		{
+			desc:     "third failure",
+			cfg:      cfg,
+			failures: 3,
+			delay:    4 * time.Second,
+		},
+		{
+			desc:     "delay capped at max delay",
+			cfg:      cfg,
+			failures: 100,
+			delay:    10 * time.Second,
+		},
+		{
+			desc:     "delay disabled",
+			cfg:      lockout.Config{},
+			failures: 5,
+			delay:    0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			delay := tc.cfg.Delay(tc.failures)
+			assert.Equal(t, tc.delay, delay, "expected delay %s, got %s", tc.delay, delay)
+		})
+	}
+}
+
+func TestLocked(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		desc     string
+		attempts lockout.Attempts
+		locked   bool
+	}{
+		{
+			desc:     "no lockout",
+			attempts: lockout.Attempts{Failures: 1, LastFailure: now},
+			locked:   false,
+		},
+		{
+			desc:     "active lockout",
+			attempts: lockout.Attempts{Failures: 3, LastFailure: now, LockedUntil: now.Add(time.Minute)},
+			locked:   true,
+		},
+		{
+			desc:     "expired lockout",
+			attempts: lockout.Attempts{Failures: 3, LastFailure: now, LockedUntil: now.Add(-time.Second)},
+			locked:   false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.locked, tc.attempts.Locked(now))
+		})
+	}
+}
+
+func TestDelayed(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		desc     string
+		attempts lockout.Attempts
+		delayed  bool
+	}{
+		{
+			desc:     "no failures",
+			attempts: lockout.Attempts{},
+			delayed:  false,
+		},
+		{
+			desc:     "login within delay",
+			attempts: lockout.Attempts{Failures: 2, LastFailure: now.Add(-time.Second)},
+			delayed:  true,
+		},
+		{
+			desc:     "login after delay",
+			attempts: lockout.Attempts{Failures: 2, LastFailure: now.Add(-3 * time.Second)},
+			delayed:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.delayed, tc.attempts.Delayed(cfg, now))
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, lockout.IdentityKey("admin@example.com"), lockout.IdentityKey("Admin@Example.com"), "expected identity key to be case-insensitive")
+	assert.NotEqual(t, lockout.IdentityKey("127.0.0.1"), lockout.IPKey("127.0.0.1"), "expected identity and IP keys to differ")
+}
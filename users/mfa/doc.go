@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mfa contains the time-based one-time password (TOTP)
+// multi-factor authentication of the users.
+package mfa
@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+// MFA is the TOTP multi-factor authentication of the user. The secret is
+// pending until the user verifies the first code, so the MFA is not
+// required to log in before it is enabled.
+type MFA struct {
+	UserID  string
+	Secret  string
+	Enabled bool
+	// RecoveryCodes are the hashes of the single use codes which can be
+	// used instead of the TOTP code if the user loses the authenticator.
+	RecoveryCodes []string
+	// LastStep is the time step of the last accepted TOTP code. Codes of
+	// this and the earlier steps are rejected.
+	LastStep  uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Enrollment contains the TOTP secret and the provisioning URI shown to the
+// user as QR code to add the secret to the authenticator app.
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// Challenge is the pending login of the user with the MFA enabled, which is
+// completed by the TOTP or recovery code.
+type Challenge struct {
+	ID        string
+	UserID    string
+	DomainID  string
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+// Repository specifies the MFA persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save creates or replaces the MFA of the user.
+	Save(ctx context.Context, mfa MFA) error
+
+	// Retrieve returns the MFA of the user.
+	Retrieve(ctx context.Context, userID string) (MFA, error)
+
+	// Remove removes the MFA of the user.
+	Remove(ctx context.Context, userID string) error
+
+	// SaveLastStep saves the time step of the accepted TOTP code of the user.
+	// It fails with the conflict error if the step is not after the last
+	// saved one, which means the code was already used.
+	SaveLastStep(ctx context.Context, userID string, step uint64) error
+
+	// SaveChallenge creates or replaces the login challenge.
+	SaveChallenge(ctx context.Context, challenge Challenge) error
+
+	// RetrieveChallenge returns the login challenge which is not expired.
+	RetrieveChallenge(ctx context.Context, id string) (Challenge, error)
+
+	// RemoveChallenge removes the login challenge.
+	RemoveChallenge(ctx context.Context, id string) error
+}
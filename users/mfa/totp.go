@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	// Period is the number of seconds the TOTP code is valid for.
+	Period = 30
+	// Digits is the number of digits of the TOTP code.
+	Digits = 6
+
+	secretSize = 20
+	// skew is the number of periods before and after the current one whose
+	// codes are accepted to tolerate the clock drift.
+	skew = 1
+
+	recoveryCodeSize  = 10
+	recoveryCodeGroup = 5
+)
+
+var (
+	// ErrInvalidSecret indicates the TOTP secret is not a valid base32 string.
+	ErrInvalidSecret = errors.New("invalid TOTP secret")
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret returns a new random TOTP secret encoded in base32.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the key URI of the TOTP secret of the account, as used by
+// the authenticator apps.
+func URI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(Period))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
+
+// Code returns the TOTP code of the secret at the given time (RFC 6238).
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidSecret, err)
+	}
+
+	return code(key, uint64(t.Unix())/Period), nil
+}
+
+// Validate checks if the code is the TOTP code of the secret at the given
+// time, or in the adjacent periods, and returns the time step of the code.
+// Codes of the steps up to the last accepted step are rejected, so the
+// accepted code can't be reused.
+func Validate(secret, c string, t time.Time, lastStep uint64) (uint64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(c) != Digits {
+		return 0, false
+	}
+	counter := uint64(t.Unix()) / Period
+	for i := -skew; i <= skew; i++ {
+		step := uint64(int64(counter) + int64(i))
+		if step <= lastStep {
+			continue
+		}
+		expected := code(key, step)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(c)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns n new random recovery codes.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		c := strings.ToLower(encoding.EncodeToString(b))[:recoveryCodeSize]
+		codes[i] = c[:recoveryCodeGroup] + "-" + c[recoveryCodeGroup:]
+	}
+
+	return codes, nil
+}
+
+// code computes the HOTP value of the counter (RFC 4226).
+func code(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
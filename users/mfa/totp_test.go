@@ -0,0 +1,162 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa_test
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/users/mfa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	cases := []struct {
+		desc   string
+		secret string
+		time   time.Time
+		code   string
+		err    error
+	}{
+		{
+			desc:   "code at the first period",
+			secret: rfcSecret,
+			time:   time.Unix(59, 0),
+			code:   "287082",
+		},
+		{
+			desc:   "code at 2005-03-18",
+			secret: rfcSecret,
+			time:   time.Unix(1111111109, 0),
+			code:   "081804",
+		},
+		{
+			desc:   "code at 2009-02-13",
+			secret: rfcSecret,
+			time:   time.Unix(1234567890, 0),
+			code:   "005924",
+		},
+		{
+			desc:   "code with invalid secret",
+			secret: "invalid secret",
+			time:   time.Unix(59, 0),
+			err:    mfa.ErrInvalidSecret,
+		},
+	}
+
+	for _, tc := range cases {
+		code, err := mfa.Code(tc.secret, tc.time)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, code))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// The RFC 6238 secret gives the distinct codes of the periods around the time.
+	secret := rfcSecret
+	now := time.Unix(1111111109, 0)
+	code, err := mfa.Code(secret, now)
+	require.Nil(t, err, fmt.Sprintf("generate code unexpected error: %s", err))
+	previous, err := mfa.Code(secret, now.Add(-mfa.Period*time.Second))
+	require.Nil(t, err, fmt.Sprintf("generate code unexpected error: %s", err))
+	expired, err := mfa.Code(secret, now.Add(-3*mfa.Period*time.Second))
+	require.Nil(t, err, fmt.Sprintf("generate code unexpected error: %s", err))
+
+	step := uint64(now.Unix()) / mfa.Period
+
+	cases := []struct {
+		desc     string
+		secret   string
+		code     string
+		lastStep uint64
+		step     uint64
+		valid    bool
+	}{
+		{
+			desc:   "validate current code",
+			secret: secret,
+			code:   code,
+			step:   step,
+			valid:  true,
+		},
+		{
+			desc:   "validate code of the previous period",
+			secret: secret,
+			code:   previous,
+			step:   step - 1,
+			valid:  true,
+		},
+		{
+			desc:   "validate expired code",
+			secret: secret,
+			code:   expired,
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid length",
+			secret: secret,
+			code:   code[1:],
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid secret",
+			secret: "invalid secret",
+			code:   code,
+			valid:  false,
+		},
+		{
+			desc:     "validate reused code",
+			secret:   secret,
+			code:     code,
+			lastStep: step,
+			valid:    false,
+		},
+		{
+			desc:     "validate code of the period before the last accepted one",
+			secret:   secret,
+			code:     previous,
+			lastStep: step,
+			valid:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		s, valid := mfa.Validate(tc.secret, tc.code, now, tc.lastStep)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.valid, valid))
+		if valid {
+			assert.Equal(t, tc.step, s, fmt.Sprintf("%s: expected step %d got %d", tc.desc, tc.step, s))
+		}
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := mfa.URI("Magistrala", "user@example.com", rfcSecret)
+	u, err := url.Parse(uri)
+	require.Nil(t, err, fmt.Sprintf("parse URI unexpected error: %s", err))
+	assert.Equal(t, "otpauth", u.Scheme, fmt.Sprintf("expected otpauth scheme got %s", u.Scheme))
+	assert.Equal(t, "totp", u.Host, fmt.Sprintf("expected totp type got %s", u.Host))
+	assert.Equal(t, "/Magistrala:user@example.com", u.Path, fmt.Sprintf("expected account label got %s", u.Path))
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"), fmt.Sprintf("expected secret %s got %s", rfcSecret, u.Query().Get("secret")))
+	assert.Equal(t, "Magistrala", u.Query().Get("issuer"), fmt.Sprintf("expected issuer Magistrala got %s", u.Query().Get("issuer")))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := mfa.GenerateRecoveryCodes(10)
+	require.Nil(t, err, fmt.Sprintf("generate recovery codes unexpected error: %s", err))
+	assert.Len(t, codes, 10, fmt.Sprintf("expected 10 codes got %d", len(codes)))
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		assert.Regexp(t, "^[a-z2-7]{5}-[a-z2-7]{5}$", c, fmt.Sprintf("malformed recovery code %s", c))
+		assert.False(t, seen[c], fmt.Sprintf("duplicated recovery code %s", c))
+		seen[c] = true
+	}
+}
@@ -29,6 +29,24 @@ func (_m *Emailer) SendPasswordReset(To []string, host string, user string, toke
 	return r0
 }
 
+// SendVerification provides a mock function with given fields: To, user, token
+func (_m *Emailer) SendVerification(To []string, user string, token string) error {
+	ret := _m.Called(To, user, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, string, string) error); ok {
+		r0 = rf(To, user, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEmailer creates a new instance of Emailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEmailer(t interface {
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Invitations is an autogenerated mock type for the Invitations type
+type Invitations struct {
+	mock.Mock
+}
+
+// CheckPending provides a mock function with given fields: ctx, token, email, domainID
+func (_m *Invitations) CheckPending(ctx context.Context, token string, email string, domainID string) error {
+	ret := _m.Called(ctx, token, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, email, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewInvitations creates a new instance of Invitations. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitations(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Invitations {
+	mock := &Invitations{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
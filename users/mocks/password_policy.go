@@ -0,0 +1,44 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// PasswordPolicy is an autogenerated mock type for the PasswordPolicy type
+type PasswordPolicy struct {
+	mock.Mock
+}
+
+// Validate provides a mock function with given fields: secret
+func (_m *PasswordPolicy) Validate(secret string) error {
+	ret := _m.Called(secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasswordPolicy creates a new instance of PasswordPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordPolicy {
+	mock := &PasswordPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	users "github.com/absmach/magistrala/users"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalData is an autogenerated mock type for the PersonalData type
+type PersonalData struct {
+	mock.Mock
+}
+
+// Invitations provides a mock function with given fields: ctx, token, userID
+func (_m *PersonalData) Invitations(ctx context.Context, token string, userID string) ([]users.Invitation, error) {
+	ret := _m.Called(ctx, token, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invitations")
+	}
+
+	var r0 []users.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]users.Invitation, error)); ok {
+		return rf(ctx, token, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []users.Invitation); ok {
+		r0 = rf(ctx, token, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]users.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Journal provides a mock function with given fields: ctx, token, userID
+func (_m *PersonalData) Journal(ctx context.Context, token string, userID string) ([]users.JournalEntry, error) {
+	ret := _m.Called(ctx, token, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Journal")
+	}
+
+	var r0 []users.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]users.JournalEntry, error)); ok {
+		return rf(ctx, token, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []users.JournalEntry); ok {
+		r0 = rf(ctx, token, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]users.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPersonalData creates a new instance of PersonalData. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalData(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalData {
+	mock := &PersonalData{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -11,7 +11,11 @@ import (
 
 	magistrala "github.com/absmach/magistrala"
 
+	mfa "github.com/absmach/magistrala/users/mfa"
+
 	mock "github.com/stretchr/testify/mock"
+
+	users "github.com/absmach/magistrala/users"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -19,6 +23,34 @@ type Service struct {
 	mock.Mock
 }
 
+// CreateServiceAccount provides a mock function with given fields: ctx, token, domainID, sa
+func (_m *Service) CreateServiceAccount(ctx context.Context, token string, domainID string, sa clients.Client) (clients.Client, error) {
+	ret := _m.Called(ctx, token, domainID, sa)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Client) (clients.Client, error)); ok {
+		return rf(ctx, token, domainID, sa)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Client) clients.Client); ok {
+		r0 = rf(ctx, token, domainID, sa)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, clients.Client) error); ok {
+		r1 = rf(ctx, token, domainID, sa)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) DeleteClient(ctx context.Context, token string, id string) error {
 	ret := _m.Called(ctx, token, id)
@@ -37,6 +69,82 @@ func (_m *Service) DeleteClient(ctx context.Context, token string, id string) er
 	return r0
 }
 
+// DeleteDomainServiceAccounts provides a mock function with given fields: ctx, domainID
+func (_m *Service) DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomainServiceAccounts")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProfile provides a mock function with given fields: ctx, token
+func (_m *Service) DeleteProfile(ctx context.Context, token string) (clients.Client, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProfile")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.Client, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.Client); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteServiceAccount provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) DeleteServiceAccount(ctx context.Context, token string, domainID string, id string) error {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteServiceAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DisableClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) DisableClient(ctx context.Context, token string, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, token, id)
@@ -65,6 +173,24 @@ func (_m *Service) DisableClient(ctx context.Context, token string, id string) (
 	return r0, r1
 }
 
+// DisableMFA provides a mock function with given fields: ctx, token, code
+func (_m *Service) DisableMFA(ctx context.Context, token string, code string) error {
+	ret := _m.Called(ctx, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnableClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) EnableClient(ctx context.Context, token string, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, token, id)
@@ -93,6 +219,62 @@ func (_m *Service) EnableClient(ctx context.Context, token string, id string) (c
 	return r0, r1
 }
 
+// EnrollMFA provides a mock function with given fields: ctx, token
+func (_m *Service) EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 mfa.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (mfa.Enrollment, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) mfa.Enrollment); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(mfa.Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportProfile provides a mock function with given fields: ctx, token
+func (_m *Service) ExportProfile(ctx context.Context, token string) (users.ProfileArchive, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportProfile")
+	}
+
+	var r0 users.ProfileArchive
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.ProfileArchive, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.ProfileArchive); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(users.ProfileArchive)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateResetToken provides a mock function with given fields: ctx, email, host
 func (_m *Service) GenerateResetToken(ctx context.Context, email string, host string) error {
 	ret := _m.Called(ctx, email, host)
@@ -139,21 +321,51 @@ func (_m *Service) Identify(ctx context.Context, tkn string) (string, error) {
 	return r0, r1
 }
 
-// IssueToken provides a mock function with given fields: ctx, identity, secret, domainID
-func (_m *Service) IssueToken(ctx context.Context, identity string, secret string, domainID string) (*magistrala.Token, error) {
-	ret := _m.Called(ctx, identity, secret, domainID)
+// IssueMFAToken provides a mock function with given fields: ctx, mfaToken, code
+func (_m *Service) IssueMFAToken(ctx context.Context, mfaToken string, code string) (*magistrala.Token, error) {
+	ret := _m.Called(ctx, mfaToken, code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IssueToken")
+		panic("no return value specified for IssueMFAToken")
+	}
+
+	var r0 *magistrala.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*magistrala.Token, error)); ok {
+		return rf(ctx, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *magistrala.Token); ok {
+		r0 = rf(ctx, mfaToken, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueServiceAccountKey provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) IssueServiceAccountKey(ctx context.Context, token string, domainID string, id string) (*magistrala.Token, error) {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueServiceAccountKey")
 	}
 
 	var r0 *magistrala.Token
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*magistrala.Token, error)); ok {
-		return rf(ctx, identity, secret, domainID)
+		return rf(ctx, token, domainID, id)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *magistrala.Token); ok {
-		r0 = rf(ctx, identity, secret, domainID)
+		r0 = rf(ctx, token, domainID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*magistrala.Token)
@@ -161,7 +373,37 @@ func (_m *Service) IssueToken(ctx context.Context, identity string, secret strin
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, identity, secret, domainID)
+		r1 = rf(ctx, token, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueToken provides a mock function with given fields: ctx, identity, secret, domainID, sourceIP
+func (_m *Service) IssueToken(ctx context.Context, identity string, secret string, domainID string, sourceIP string) (*magistrala.Token, error) {
+	ret := _m.Called(ctx, identity, secret, domainID, sourceIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueToken")
+	}
+
+	var r0 *magistrala.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*magistrala.Token, error)); ok {
+		return rf(ctx, identity, secret, domainID, sourceIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *magistrala.Token); ok {
+		r0 = rf(ctx, identity, secret, domainID, sourceIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, identity, secret, domainID, sourceIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -225,6 +467,34 @@ func (_m *Service) ListMembers(ctx context.Context, token string, objectKind str
 	return r0, r1
 }
 
+// ListServiceAccounts provides a mock function with given fields: ctx, token, domainID, pm
+func (_m *Service) ListServiceAccounts(ctx context.Context, token string, domainID string, pm clients.Page) (clients.ClientsPage, error) {
+	ret := _m.Called(ctx, token, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServiceAccounts")
+	}
+
+	var r0 clients.ClientsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Page) (clients.ClientsPage, error)); ok {
+		return rf(ctx, token, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Page) clients.ClientsPage); ok {
+		r0 = rf(ctx, token, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(clients.ClientsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, clients.Page) error); ok {
+		r1 = rf(ctx, token, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OAuthCallback provides a mock function with given fields: ctx, client
 func (_m *Service) OAuthCallback(ctx context.Context, client clients.Client) (*magistrala.Token, error) {
 	ret := _m.Called(ctx, client)
@@ -313,6 +583,34 @@ func (_m *Service) RegisterClient(ctx context.Context, token string, client clie
 	return r0, r1
 }
 
+// RegisterInvitedClient provides a mock function with given fields: ctx, token, client
+func (_m *Service) RegisterInvitedClient(ctx context.Context, token string, client clients.Client) (clients.Client, error) {
+	ret := _m.Called(ctx, token, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterInvitedClient")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client) (clients.Client, error)); ok {
+		return rf(ctx, token, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client) clients.Client); ok {
+		r0 = rf(ctx, token, client)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Client) error); ok {
+		r1 = rf(ctx, token, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetSecret provides a mock function with given fields: ctx, resetToken, secret
 func (_m *Service) ResetSecret(ctx context.Context, resetToken string, secret string) error {
 	ret := _m.Called(ctx, resetToken, secret)
@@ -377,6 +675,42 @@ func (_m *Service) SendPasswordReset(ctx context.Context, host string, email str
 	return r0
 }
 
+// SendVerification provides a mock function with given fields: ctx, identity, secret, sourceIP
+func (_m *Service) SendVerification(ctx context.Context, identity string, secret string, sourceIP string) error {
+	ret := _m.Called(ctx, identity, secret, sourceIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, identity, secret, sourceIP)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockClient provides a mock function with given fields: ctx, token, id
+func (_m *Service) UnlockClient(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateClient provides a mock function with given fields: ctx, token, client
 func (_m *Service) UpdateClient(ctx context.Context, token string, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, token, client)
@@ -517,6 +851,64 @@ func (_m *Service) UpdateClientTags(ctx context.Context, token string, client cl
 	return r0, r1
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, verificationToken
+func (_m *Service) VerifyEmail(ctx context.Context, verificationToken string) (clients.Client, error) {
+	ret := _m.Called(ctx, verificationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.Client, error)); ok {
+		return rf(ctx, verificationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.Client); ok {
+		r0 = rf(ctx, verificationToken)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, verificationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, token, code
+func (_m *Service) VerifyMFA(ctx context.Context, token string, code string) ([]string, error) {
+	ret := _m.Called(ctx, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, token, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, token, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ViewClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) ViewClient(ctx context.Context, token string, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, token, id)
@@ -545,6 +937,34 @@ func (_m *Service) ViewClient(ctx context.Context, token string, id string) (cli
 	return r0, r1
 }
 
+// ViewClientByIdentity provides a mock function with given fields: ctx, token, domainID, identity
+func (_m *Service) ViewClientByIdentity(ctx context.Context, token string, domainID string, identity string) (clients.Client, error) {
+	ret := _m.Called(ctx, token, domainID, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewClientByIdentity")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (clients.Client, error)); ok {
+		return rf(ctx, token, domainID, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) clients.Client); ok {
+		r0 = rf(ctx, token, domainID, identity)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ViewProfile provides a mock function with given fields: ctx, token
 func (_m *Service) ViewProfile(ctx context.Context, token string) (clients.Client, error) {
 	ret := _m.Called(ctx, token)
@@ -573,6 +993,34 @@ func (_m *Service) ViewProfile(ctx context.Context, token string) (clients.Clien
 	return r0, r1
 }
 
+// ViewServiceAccount provides a mock function with given fields: ctx, token, domainID, id
+func (_m *Service) ViewServiceAccount(ctx context.Context, token string, domainID string, id string) (clients.Client, error) {
+	ret := _m.Called(ctx, token, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewServiceAccount")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (clients.Client, error)); ok {
+		return rf(ctx, token, domainID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) clients.Client); ok {
+		r0 = rf(ctx, token, domainID, id)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -0,0 +1,13 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+// PasswordPolicy specifies an API for checking the user secrets before they
+// are hashed and stored.
+//
+//go:generate mockery --name PasswordPolicy --output=./mocks --filename password_policy.go --quiet --note "Copyright (c) Abstract Machines"
+type PasswordPolicy interface {
+	// Validate returns an error if the secret does not satisfy the policy.
+	Validate(secret string) error
+}
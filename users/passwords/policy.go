@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is the hash of the breached passwords lists.
+	"encoding/hex"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/users"
+)
+
+var (
+	errTooShort = errors.New("password is too short")
+	errBreached = errors.New("password is found in the breached passwords list")
+)
+
+var _ users.PasswordPolicy = (*policy)(nil)
+
+type policy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPolicy returns the password policy requiring the minimal password
+// length and rejecting the passwords found in the breached passwords file.
+// Each line of the file contains either the plain-text password or the
+// upper-case hex SHA-1 hash of the password, optionally followed by the
+// colon and the breach count, as in the Have I Been Pwned lists. Empty
+// file path disables the breached passwords check.
+func NewPolicy(minLength int, breachedFile string) (users.PasswordPolicy, error) {
+	p := &policy{
+		minLength: minLength,
+		breached:  make(map[string]struct{}),
+	}
+	if breachedFile == "" {
+		return p, nil
+	}
+
+	f, err := os.Open(breachedFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if hash, _, _ := strings.Cut(line, ":"); isHash(hash) {
+			p.breached[strings.ToUpper(hash)] = struct{}{}
+			continue
+		}
+		p.breached[hashOf(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *policy) Validate(secret string) error {
+	if utf8.RuneCountInString(secret) < p.minLength {
+		return errors.Wrap(svcerr.ErrPasswordPolicy, errTooShort)
+	}
+	if _, ok := p.breached[hashOf(secret)]; ok {
+		return errors.Wrap(svcerr.ErrPasswordPolicy, errBreached)
+	}
+
+	return nil
+}
+
+func hashOf(secret string) string {
+	sum := sha1.Sum([]byte(secret)) //nolint:gosec // SHA-1 is the hash of the breached passwords lists.
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func isHash(s string) bool {
+	if len(s) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package passwords_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/users/passwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 hash of "password123" in the Have I Been Pwned format.
+const breachedList = `qwerty123
+
+CBFDAC6008F9CAB4083784CBD1874F76618D2A97:251682
+`
+
+func TestNewPolicy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	require.Nil(t, os.WriteFile(file, []byte(breachedList), 0o600))
+
+	cases := []struct {
+		desc string
+		file string
+		err  bool
+	}{
+		{
+			desc: "new policy without breached list",
+			file: "",
+			err:  false,
+		},
+		{
+			desc: "new policy with breached list",
+			file: file,
+			err:  false,
+		},
+		{
+			desc: "new policy with missing breached list",
+			file: filepath.Join(t.TempDir(), "missing.txt"),
+			err:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := passwords.NewPolicy(8, tc.file)
+			assert.Equal(t, tc.err, err != nil, "expected error %t, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	require.Nil(t, os.WriteFile(file, []byte(breachedList), 0o600))
+
+	policy, err := passwords.NewPolicy(8, file)
+	require.Nil(t, err, "unexpected error creating policy: %s", err)
+
+	cases := []struct {
+		desc   string
+		secret string
+		err    error
+	}{
+		{
+			desc:   "valid password",
+			secret: "correct horse battery staple",
+			err:    nil,
+		},
+		{
+			desc:   "too short password",
+			secret: "short",
+			err:    svcerr.ErrPasswordPolicy,
+		},
+		{
+			desc:   "breached plain-text password",
+			secret: "qwerty123",
+			err:    svcerr.ErrPasswordPolicy,
+		},
+		{
+			desc:   "breached hashed password",
+			secret: "password123",
+			err:    svcerr.ErrPasswordPolicy,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := policy.Validate(tc.secret)
+			assert.True(t, errors.Contains(err, tc.err), "expected %s, got %s", tc.err, err)
+		})
+	}
+}
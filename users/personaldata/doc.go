@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package personaldata retrieves the personal data of the user kept by
+// the other Magistrala services, exported together with the user profile.
+package personaldata
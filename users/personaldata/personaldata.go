@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package personaldata
+
+import (
+	"context"
+
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/users"
+)
+
+const (
+	userEntityType = "user"
+
+	// Invitations API limits the page size to 100 entries.
+	invitationsPageLimit = 100
+	// Journal API limits the page size to 10 entries.
+	journalPageLimit = 10
+)
+
+var _ users.PersonalData = (*personalData)(nil)
+
+type personalData struct {
+	sdk     mgsdk.SDK
+	journal bool
+}
+
+// New returns the personal data retrieved with the SDK using the token of
+// the user. The journal service is an add-on, so the journal entries are
+// retrieved only if the journal is enabled.
+func New(sdk mgsdk.SDK, journal bool) users.PersonalData {
+	return &personalData{sdk: sdk, journal: journal}
+}
+
+func (pd *personalData) Invitations(_ context.Context, token, userID string) ([]users.Invitation, error) {
+	invitations := []users.Invitation{}
+	received, err := pd.invitations(token, mgsdk.PageMetadata{UserID: userID, Limit: invitationsPageLimit})
+	if err != nil {
+		return nil, err
+	}
+	invitations = append(invitations, received...)
+
+	sent, err := pd.invitations(token, mgsdk.PageMetadata{InvitedBy: userID, Limit: invitationsPageLimit})
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range sent {
+		// The invitations the user sent to themselves are already listed.
+		if inv.UserID != userID {
+			invitations = append(invitations, inv)
+		}
+	}
+
+	return invitations, nil
+}
+
+func (pd *personalData) invitations(token string, pm mgsdk.PageMetadata) ([]users.Invitation, error) {
+	var invitations []users.Invitation
+	for {
+		ip, err := pd.sdk.Invitations(pm, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range ip.Invitations {
+			invitations = append(invitations, users.Invitation{
+				InvitedBy:   inv.InvitedBy,
+				UserID:      inv.UserID,
+				Email:       inv.Email,
+				DomainID:    inv.DomainID,
+				Relation:    inv.Relation,
+				CreatedAt:   inv.CreatedAt,
+				UpdatedAt:   inv.UpdatedAt,
+				ConfirmedAt: inv.ConfirmedAt,
+			})
+		}
+		pm.Offset += pm.Limit
+		if len(ip.Invitations) == 0 || pm.Offset >= ip.Total {
+			return invitations, nil
+		}
+	}
+}
+
+func (pd *personalData) Journal(_ context.Context, token, userID string) ([]users.JournalEntry, error) {
+	entries := []users.JournalEntry{}
+	if !pd.journal {
+		return entries, nil
+	}
+	pm := mgsdk.PageMetadata{Limit: journalPageLimit}
+	for {
+		jp, err := pd.sdk.Journal(userEntityType, userID, pm, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, j := range jp.Journals {
+			entries = append(entries, users.JournalEntry{
+				ID:         j.ID,
+				Operation:  j.Operation,
+				OccurredAt: j.OccurredAt,
+				Attributes: j.Attributes,
+				Metadata:   j.Metadata,
+			})
+		}
+		pm.Offset += pm.Limit
+		if len(jp.Journals) == 0 || pm.Offset >= jp.Total {
+			return entries, nil
+		}
+	}
+}
@@ -46,9 +46,9 @@ func NewRepository(db postgres.Database) Repository {
 }
 
 func (repo clientRepo) Save(ctx context.Context, c mgclients.Client) (mgclients.Client, error) {
-	q := `INSERT INTO clients (id, name, tags, identity, secret, metadata, created_at, status, role)
-        VALUES (:id, :name, :tags, :identity, :secret, :metadata, :created_at, :status, :role)
-        RETURNING id, name, tags, identity, metadata, status, created_at`
+	q := `INSERT INTO clients (id, name, tags, domain_id, identity, secret, metadata, created_at, status, role, verified_at)
+        VALUES (:id, :name, :tags, :domain_id, :identity, :secret, :metadata, :created_at, :status, :role, :verified_at)
+        RETURNING id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, metadata, status, role, created_at, verified_at`
 	dbc, err := pgclients.ToDBClient(c)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
@@ -93,7 +93,7 @@ func (repo clientRepo) CheckSuperAdmin(ctx context.Context, adminID string) erro
 }
 
 func (repo clientRepo) RetrieveByID(ctx context.Context, id string) (mgclients.Client, error) {
-	q := `SELECT id, name, tags, identity, secret, metadata, created_at, updated_at, updated_by, status, role
+	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status, role, verified_at
         FROM clients WHERE id = :id`
 
 	dbc := pgclients.DBClient{
@@ -128,9 +128,13 @@ func (repo clientRepo) RetrieveAll(ctx context.Context, pm mgclients.Page) (mgcl
 	if err != nil {
 		return mgclients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
+	// Service accounts are listed separately from the users.
+	if pm.Role == mgclients.AllRole {
+		query = excludeServiceAccounts(query)
+	}
 
-	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata,  c.status, c.role,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, COALESCE(c.domain_id, '') AS domain_id, c.identity, c.metadata,  c.status, c.role,
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by, c.verified_at FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
 
 	dbPage, err := pgclients.ToDBClientsPage(pm)
 	if err != nil {
@@ -201,3 +205,44 @@ func (repo clientRepo) UpdateRole(ctx context.Context, client mgclients.Client)
 
 	return pgclients.ToClient(dbc)
 }
+
+// UpdateIdentity updates the identity of the client, and resets the email
+// verification since the new email is not verified.
+func (repo clientRepo) UpdateIdentity(ctx context.Context, client mgclients.Client) (mgclients.Client, error) {
+	q := `UPDATE clients SET identity = :identity, verified_at = NULL, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+
+	client.Status = mgclients.EnabledStatus
+	dbc, err := pgclients.ToDBClient(client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	row, err := repo.DB.NamedQueryContext(ctx, q, dbc)
+	if err != nil {
+		return mgclients.Client{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer row.Close()
+
+	dbc = pgclients.DBClient{}
+	if row.Next() {
+		if err := row.StructScan(&dbc); err != nil {
+			return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+
+		return pgclients.ToClient(dbc)
+	}
+
+	return mgclients.Client{}, repoerr.ErrNotFound
+}
+
+func excludeServiceAccounts(query string) string {
+	cond := fmt.Sprintf("c.role != %d", mgclients.ServiceAccountRole)
+	if query == "" {
+		return "WHERE " + cond
+	}
+
+	return fmt.Sprintf("%s AND %s", query, cond)
+}
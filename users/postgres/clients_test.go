@@ -646,7 +646,7 @@ func TestRetrieveAll(t *testing.T) {
 		{
 			desc: "retrieve with invalid role",
 			pageMeta: mgclients.Page{
-				Role:   mgclients.AdminRole + 2,
+				Role:   mgclients.AllRole + 1,
 				Offset: 0,
 				Limit:  200,
 				Status: mgclients.AllStatus,
@@ -693,6 +693,81 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveAllServiceAccounts(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+
+	user := mgclients.Client{
+		ID:   testsutil.GenerateUUID(t),
+		Name: namesgen.Generate(),
+		Credentials: mgclients.Credentials{
+			Identity: fmt.Sprintf("%s@example.com", namesgen.Generate()),
+			Secret:   password,
+		},
+		Metadata: mgclients.Metadata{},
+		Status:   mgclients.EnabledStatus,
+	}
+	saID := testsutil.GenerateUUID(t)
+	sa := mgclients.Client{
+		ID:          saID,
+		Name:        namesgen.Generate(),
+		Domain:      testsutil.GenerateUUID(t),
+		Credentials: mgclients.Credentials{Identity: saID},
+		Metadata:    mgclients.Metadata{},
+		Status:      mgclients.EnabledStatus,
+		Role:        mgclients.ServiceAccountRole,
+	}
+	for _, c := range []mgclients.Client{user, sa} {
+		_, err := repo.Save(context.Background(), c)
+		require.Nil(t, err, fmt.Sprintf("failed to save client %s", c.ID))
+	}
+
+	rsa, err := repo.RetrieveByID(context.Background(), sa.ID)
+	require.Nil(t, err, fmt.Sprintf("failed to retrieve service account %s", sa.ID))
+	assert.Equal(t, sa.Domain, rsa.Domain, fmt.Sprintf("expected domain %s got %s\n", sa.Domain, rsa.Domain))
+
+	cases := []struct {
+		desc     string
+		pageMeta mgclients.Page
+		clients  []mgclients.Client
+	}{
+		{
+			desc:     "retrieve all clients without service accounts",
+			pageMeta: mgclients.Page{Limit: 10, Role: mgclients.AllRole, Status: mgclients.AllStatus},
+			clients:  []mgclients.Client{user},
+		},
+		{
+			desc:     "retrieve service accounts of domain",
+			pageMeta: mgclients.Page{Limit: 10, Role: mgclients.ServiceAccountRole, Domain: sa.Domain, Status: mgclients.AllStatus},
+			clients:  []mgclients.Client{sa},
+		},
+		{
+			desc:     "retrieve service accounts of other domain",
+			pageMeta: mgclients.Page{Limit: 10, Role: mgclients.ServiceAccountRole, Domain: testsutil.GenerateUUID(t), Status: mgclients.AllStatus},
+			clients:  []mgclients.Client{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.clients)), page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, len(tc.clients), page.Total))
+		ids := []string{}
+		for _, c := range page.Clients {
+			ids = append(ids, c.ID)
+		}
+		expected := []string{}
+		for _, c := range tc.clients {
+			expected = append(expected, c.ID)
+		}
+		assert.ElementsMatch(t, expected, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, expected, ids))
+	}
+}
+
 func TestUpdateRole(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -45,6 +45,105 @@ func Migration() *migrate.MemoryMigrationSource {
 				},
 				Down: []string{},
 			},
+			{
+				Id: "clients_03",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_mfa (
+						user_id        VARCHAR(36) PRIMARY KEY REFERENCES clients (id) ON DELETE CASCADE,
+						secret         TEXT NOT NULL,
+						enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+						recovery_codes TEXT[] NOT NULL DEFAULT '{}',
+						created_at     TIMESTAMP,
+						updated_at     TIMESTAMP
+					)`,
+					`CREATE TABLE IF NOT EXISTS mfa_challenges (
+						id         VARCHAR(36) PRIMARY KEY,
+						user_id    VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						domain_id  VARCHAR(36) NOT NULL DEFAULT '',
+						attempts   SMALLINT NOT NULL DEFAULT 0,
+						expires_at TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS mfa_challenges`,
+					`DROP TABLE IF EXISTS users_mfa`,
+				},
+			},
+			{
+				Id: "clients_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS oauth_states (
+						state      VARCHAR(64) PRIMARY KEY,
+						provider   VARCHAR(64) NOT NULL,
+						nonce      VARCHAR(64) NOT NULL,
+						verifier   VARCHAR(128) NOT NULL,
+						expires_at TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS oauth_states`,
+				},
+			},
+			{
+				Id: "clients_05",
+				Up: []string{
+					`ALTER TABLE clients ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP`,
+					`CREATE TABLE IF NOT EXISTS email_verifications (
+						user_id    VARCHAR(36) PRIMARY KEY REFERENCES clients (id) ON DELETE CASCADE,
+						token      VARCHAR(36) NOT NULL UNIQUE,
+						email      VARCHAR(254) NOT NULL,
+						expires_at TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS email_verifications`,
+					`ALTER TABLE clients DROP COLUMN IF EXISTS verified_at`,
+				},
+			},
+			{
+				// Users are linked to the identity provider accounts by the subject.
+				Id: "clients_06",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS oauth_identities (
+						provider   VARCHAR(64) NOT NULL,
+						subject    VARCHAR(254) NOT NULL,
+						user_id    VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						created_at TIMESTAMP,
+						PRIMARY KEY (provider, subject)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS oauth_identities`,
+				},
+			},
+			{
+				// Login requires a verified email, so users created before
+				// verification was enforced are treated as verified.
+				Id: "clients_07",
+				Up: []string{
+					`UPDATE clients SET verified_at = created_at WHERE verified_at IS NULL`,
+				},
+			},
+			{
+				// The time step of the last accepted TOTP code prevents its reuse.
+				Id: "clients_08",
+				Up: []string{
+					`ALTER TABLE users_mfa ADD COLUMN IF NOT EXISTS last_step BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					`ALTER TABLE users_mfa DROP COLUMN IF EXISTS last_step`,
+				},
+			},
+			{
+				// The send time of the verification limits how often it is resent.
+				Id: "clients_09",
+				Up: []string{
+					`ALTER TABLE email_verifications ADD COLUMN IF NOT EXISTS sent_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE email_verifications DROP COLUMN IF EXISTS sent_at`,
+				},
+			},
 		},
 	}
 }
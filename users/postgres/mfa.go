@@ -0,0 +1,215 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/users/mfa"
+	"github.com/jackc/pgtype"
+)
+
+var _ mfa.Repository = (*mfaRepo)(nil)
+
+type mfaRepo struct {
+	db postgres.Database
+}
+
+// NewMFARepository instantiates a PostgreSQL
+// implementation of MFA repository.
+func NewMFARepository(db postgres.Database) mfa.Repository {
+	return &mfaRepo{
+		db: db,
+	}
+}
+
+func (repo *mfaRepo) Save(ctx context.Context, m mfa.MFA) error {
+	q := `INSERT INTO users_mfa (user_id, secret, enabled, recovery_codes, last_step, created_at, updated_at)
+		VALUES (:user_id, :secret, :enabled, :recovery_codes, :last_step, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enabled = EXCLUDED.enabled,
+		recovery_codes = EXCLUDED.recovery_codes, last_step = EXCLUDED.last_step, updated_at = EXCLUDED.updated_at`
+
+	dbm, err := toDBMFA(m)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbm); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *mfaRepo) Retrieve(ctx context.Context, userID string) (mfa.MFA, error) {
+	q := `SELECT user_id, secret, enabled, recovery_codes, last_step, created_at, updated_at FROM users_mfa WHERE user_id = :user_id`
+
+	dbm := dbMFA{UserID: userID}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbm)
+	if err != nil {
+		return mfa.MFA{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbm = dbMFA{}
+	if rows.Next() {
+		if err := rows.StructScan(&dbm); err != nil {
+			return mfa.MFA{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toMFA(dbm), nil
+	}
+
+	return mfa.MFA{}, repoerr.ErrNotFound
+}
+
+func (repo *mfaRepo) Remove(ctx context.Context, userID string) error {
+	q := `DELETE FROM users_mfa WHERE user_id = :user_id`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, dbMFA{UserID: userID}); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *mfaRepo) SaveLastStep(ctx context.Context, userID string, step uint64) error {
+	q := `UPDATE users_mfa SET last_step = :last_step WHERE user_id = :user_id AND last_step < :last_step`
+
+	dbm := dbMFA{UserID: userID, LastStep: int64(step)}
+	result, err := repo.db.NamedExecContext(ctx, q, dbm)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrConflict
+	}
+
+	return nil
+}
+
+func (repo *mfaRepo) SaveChallenge(ctx context.Context, c mfa.Challenge) error {
+	q := `INSERT INTO mfa_challenges (id, user_id, domain_id, attempts, expires_at)
+		VALUES (:id, :user_id, :domain_id, :attempts, :expires_at)
+		ON CONFLICT (id) DO UPDATE SET attempts = EXCLUDED.attempts`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBChallenge(c)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *mfaRepo) RetrieveChallenge(ctx context.Context, id string) (mfa.Challenge, error) {
+	q := `SELECT id, user_id, domain_id, attempts, expires_at FROM mfa_challenges WHERE id = :id AND expires_at > :expires_at`
+
+	dbc := dbChallenge{ID: id, ExpiresAt: time.Now().UTC()}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbc)
+	if err != nil {
+		return mfa.Challenge{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbc = dbChallenge{}
+	if rows.Next() {
+		if err := rows.StructScan(&dbc); err != nil {
+			return mfa.Challenge{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toChallenge(dbc), nil
+	}
+
+	return mfa.Challenge{}, repoerr.ErrNotFound
+}
+
+func (repo *mfaRepo) RemoveChallenge(ctx context.Context, id string) error {
+	// Expired challenges are removed along the way.
+	q := `DELETE FROM mfa_challenges WHERE id = :id OR expires_at <= :expires_at`
+
+	dbc := dbChallenge{ID: id, ExpiresAt: time.Now().UTC()}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbc); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbMFA struct {
+	UserID        string           `db:"user_id"`
+	Secret        string           `db:"secret"`
+	Enabled       bool             `db:"enabled"`
+	RecoveryCodes pgtype.TextArray `db:"recovery_codes"`
+	LastStep      int64            `db:"last_step"`
+	CreatedAt     time.Time        `db:"created_at"`
+	UpdatedAt     time.Time        `db:"updated_at"`
+}
+
+func toDBMFA(m mfa.MFA) (dbMFA, error) {
+	codes := m.RecoveryCodes
+	if codes == nil {
+		codes = []string{}
+	}
+	var recoveryCodes pgtype.TextArray
+	if err := recoveryCodes.Set(codes); err != nil {
+		return dbMFA{}, err
+	}
+
+	return dbMFA{
+		UserID:        m.UserID,
+		Secret:        m.Secret,
+		Enabled:       m.Enabled,
+		RecoveryCodes: recoveryCodes,
+		LastStep:      int64(m.LastStep),
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}, nil
+}
+
+func toMFA(dbm dbMFA) mfa.MFA {
+	m := mfa.MFA{
+		UserID:        dbm.UserID,
+		Secret:        dbm.Secret,
+		Enabled:       dbm.Enabled,
+		RecoveryCodes: []string{},
+		LastStep:      uint64(dbm.LastStep),
+		CreatedAt:     dbm.CreatedAt.UTC(),
+		UpdatedAt:     dbm.UpdatedAt.UTC(),
+	}
+	for _, e := range dbm.RecoveryCodes.Elements {
+		m.RecoveryCodes = append(m.RecoveryCodes, e.String)
+	}
+
+	return m
+}
+
+type dbChallenge struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	DomainID  string    `db:"domain_id"`
+	Attempts  int       `db:"attempts"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func toDBChallenge(c mfa.Challenge) dbChallenge {
+	return dbChallenge{
+		ID:        c.ID,
+		UserID:    c.UserID,
+		DomainID:  c.DomainID,
+		Attempts:  c.Attempts,
+		ExpiresAt: c.ExpiresAt,
+	}
+}
+
+func toChallenge(dbc dbChallenge) mfa.Challenge {
+	return mfa.Challenge{
+		ID:        dbc.ID,
+		UserID:    dbc.UserID,
+		DomainID:  dbc.DomainID,
+		Attempts:  dbc.Attempts,
+		ExpiresAt: dbc.ExpiresAt.UTC(),
+	}
+}
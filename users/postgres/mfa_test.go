@@ -0,0 +1,237 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/users/mfa"
+	cpostgres "github.com/absmach/magistrala/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveUser(t *testing.T) mgclients.Client {
+	name := namesgen.Generate()
+	client, err := cpostgres.NewRepository(database).Save(context.Background(), mgclients.Client{
+		ID:   testsutil.GenerateUUID(t),
+		Name: name,
+		Credentials: mgclients.Credentials{
+			Identity: name + "@example.com",
+			Secret:   password,
+		},
+		Metadata: mgclients.Metadata{},
+		Status:   mgclients.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save user unexpected error: %s", err))
+
+	return client
+}
+
+func TestMFASave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewMFARepository(database)
+	user := saveUser(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc string
+		mfa  mfa.MFA
+		err  error
+	}{
+		{
+			desc: "save pending MFA successfully",
+			mfa: mfa.MFA{
+				UserID:        user.ID,
+				Secret:        "JBSWY3DPEHPK3PXP",
+				RecoveryCodes: []string{},
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+		},
+		{
+			desc: "enable MFA successfully",
+			mfa: mfa.MFA{
+				UserID:        user.ID,
+				Secret:        "JBSWY3DPEHPK3PXP",
+				Enabled:       true,
+				RecoveryCodes: []string{"hash1", "hash2"},
+				CreatedAt:     now,
+				UpdatedAt:     now.Add(time.Minute),
+			},
+		},
+		{
+			desc: "save MFA of non-existing user",
+			mfa: mfa.MFA{
+				UserID: testsutil.GenerateUUID(t),
+				Secret: "JBSWY3DPEHPK3PXP",
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.mfa)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			saved, err := repo.Retrieve(context.Background(), tc.mfa.UserID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.mfa, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.mfa, saved))
+		}
+	}
+}
+
+func TestMFARemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewMFARepository(database)
+	user := saveUser(t)
+
+	err := repo.Save(context.Background(), mfa.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("save MFA unexpected error: %s", err))
+
+	for _, desc := range []string{"remove existing MFA", "remove non-existing MFA"} {
+		err := repo.Remove(context.Background(), user.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		_, err = repo.Retrieve(context.Background(), user.ID)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s", desc, repoerr.ErrNotFound, err))
+	}
+}
+
+func TestMFASaveLastStep(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewMFARepository(database)
+	user := saveUser(t)
+
+	err := repo.Save(context.Background(), mfa.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("save MFA unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		userID   string
+		step     uint64
+		lastStep uint64
+		err      error
+	}{
+		{
+			desc:     "save the step of the accepted code",
+			userID:   user.ID,
+			step:     100,
+			lastStep: 100,
+		},
+		{
+			desc:     "save the step of the reused code",
+			userID:   user.ID,
+			step:     100,
+			lastStep: 100,
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc:     "save the step before the last one",
+			userID:   user.ID,
+			step:     99,
+			lastStep: 100,
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc:     "save the step after the last one",
+			userID:   user.ID,
+			step:     101,
+			lastStep: 101,
+		},
+		{
+			desc:   "save the step of the user without MFA",
+			userID: testsutil.GenerateUUID(t),
+			step:   100,
+			err:    repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveLastStep(context.Background(), tc.userID, tc.step)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.userID == user.ID {
+			m, err := repo.Retrieve(context.Background(), user.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.lastStep, m.LastStep, fmt.Sprintf("%s: expected last step %d got %d", tc.desc, tc.lastStep, m.LastStep))
+		}
+	}
+}
+
+func TestMFAChallenge(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewMFARepository(database)
+	user := saveUser(t)
+
+	challenge := mfa.Challenge{
+		ID:        testsutil.GenerateUUID(t),
+		UserID:    user.ID,
+		DomainID:  testsutil.GenerateUUID(t),
+		ExpiresAt: time.Now().Add(time.Minute).UTC().Truncate(time.Microsecond),
+	}
+	expired := mfa.Challenge{
+		ID:        testsutil.GenerateUUID(t),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(-time.Minute).UTC(),
+	}
+	for _, c := range []mfa.Challenge{challenge, expired} {
+		err := repo.SaveChallenge(context.Background(), c)
+		require.Nil(t, err, fmt.Sprintf("save challenge unexpected error: %s", err))
+	}
+	challenge.Attempts = 1
+	err := repo.SaveChallenge(context.Background(), challenge)
+	require.Nil(t, err, fmt.Sprintf("update challenge unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		id        string
+		challenge mfa.Challenge
+		err       error
+	}{
+		{
+			desc:      "retrieve challenge",
+			id:        challenge.ID,
+			challenge: challenge,
+		},
+		{
+			desc: "retrieve expired challenge",
+			id:   expired.ID,
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve non-existing challenge",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := repo.RetrieveChallenge(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.challenge, c, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.challenge, c))
+	}
+
+	err = repo.RemoveChallenge(context.Background(), challenge.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove challenge unexpected error: %s", err))
+	_, err = repo.RetrieveChallenge(context.Background(), challenge.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed challenge: expected %s got %s", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/oauth2"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+var _ oauth2.StateRepository = (*stateRepo)(nil)
+
+type stateRepo struct {
+	db postgres.Database
+}
+
+// NewStateRepository instantiates a PostgreSQL
+// implementation of OAuth2 state repository.
+func NewStateRepository(db postgres.Database) oauth2.StateRepository {
+	return &stateRepo{
+		db: db,
+	}
+}
+
+func (repo *stateRepo) Save(ctx context.Context, s oauth2.State) error {
+	// Expired states of abandoned flows are removed on each save.
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM oauth_states WHERE expires_at < $1`, time.Now().UTC()); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO oauth_states (state, provider, nonce, verifier, expires_at)
+		VALUES (:state, :provider, :nonce, :verifier, :expires_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBState(s)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *stateRepo) Retrieve(ctx context.Context, provider, state string) (oauth2.State, error) {
+	q := `DELETE FROM oauth_states WHERE state = :state AND provider = :provider
+		RETURNING state, provider, nonce, verifier, expires_at`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbState{State: state, Provider: provider})
+	if err != nil {
+		return oauth2.State{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var dbs dbState
+	if rows.Next() {
+		if err := rows.StructScan(&dbs); err != nil {
+			return oauth2.State{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		if dbs.ExpiresAt.Before(time.Now().UTC()) {
+			return oauth2.State{}, repoerr.ErrNotFound
+		}
+
+		return toState(dbs), nil
+	}
+
+	return oauth2.State{}, repoerr.ErrNotFound
+}
+
+type dbState struct {
+	State     string    `db:"state"`
+	Provider  string    `db:"provider"`
+	Nonce     string    `db:"nonce"`
+	Verifier  string    `db:"verifier"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func toDBState(s oauth2.State) dbState {
+	return dbState{
+		State:     s.State,
+		Provider:  s.Provider,
+		Nonce:     s.Nonce,
+		Verifier:  s.Verifier,
+		ExpiresAt: s.ExpiresAt,
+	}
+}
+
+func toState(s dbState) oauth2.State {
+	return oauth2.State{
+		State:     s.State,
+		Provider:  s.Provider,
+		Nonce:     s.Nonce,
+		Verifier:  s.Verifier,
+		ExpiresAt: s.ExpiresAt,
+	}
+}
+
+var _ oauth2.IdentityRepository = (*identityRepo)(nil)
+
+type identityRepo struct {
+	db postgres.Database
+}
+
+// NewIdentityRepository instantiates a PostgreSQL implementation
+// of the repository of the identity provider accounts of the users.
+func NewIdentityRepository(db postgres.Database) oauth2.IdentityRepository {
+	return &identityRepo{
+		db: db,
+	}
+}
+
+func (repo *identityRepo) Save(ctx context.Context, identity oauth2.Identity) error {
+	q := `INSERT INTO oauth_identities (provider, subject, user_id, created_at)
+		VALUES (:provider, :subject, :user_id, :created_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBIdentity(identity)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *identityRepo) Retrieve(ctx context.Context, provider, subject string) (oauth2.Identity, error) {
+	q := `SELECT provider, subject, user_id, created_at FROM oauth_identities
+		WHERE provider = :provider AND subject = :subject`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbIdentity{Provider: provider, Subject: subject})
+	if err != nil {
+		return oauth2.Identity{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var dbi dbIdentity
+	if rows.Next() {
+		if err := rows.StructScan(&dbi); err != nil {
+			return oauth2.Identity{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toIdentity(dbi), nil
+	}
+
+	return oauth2.Identity{}, repoerr.ErrNotFound
+}
+
+type dbIdentity struct {
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	UserID    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBIdentity(i oauth2.Identity) dbIdentity {
+	return dbIdentity{
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		UserID:    i.UserID,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+func toIdentity(i dbIdentity) oauth2.Identity {
+	return oauth2.Identity{
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		UserID:    i.UserID,
+		CreatedAt: i.CreatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/oauth2"
+	cpostgres "github.com/absmach/magistrala/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRetrieve(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM oauth_states")
+		require.Nil(t, err, fmt.Sprintf("clean oauth states unexpected error: %s", err))
+	})
+	repo := cpostgres.NewStateRepository(database)
+
+	state, err := oauth2.NewState("keycloak", time.Minute)
+	require.Nil(t, err, fmt.Sprintf("new state unexpected error: %s", err))
+	state.ExpiresAt = state.ExpiresAt.Truncate(time.Microsecond)
+	err = repo.Save(context.Background(), state)
+	require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+
+	expired, err := oauth2.NewState("keycloak", -time.Minute)
+	require.Nil(t, err, fmt.Sprintf("new state unexpected error: %s", err))
+	err = repo.Save(context.Background(), expired)
+	require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		provider string
+		state    string
+		response oauth2.State
+		err      error
+	}{
+		{
+			desc:     "retrieve state of another provider",
+			provider: "google",
+			state:    state.State,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve state successfully",
+			provider: state.Provider,
+			state:    state.State,
+			response: state,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve already used state",
+			provider: state.Provider,
+			state:    state.State,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve expired state",
+			provider: expired.Provider,
+			state:    expired.State,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := repo.Retrieve(context.Background(), tc.provider, tc.state)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.response, s, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, s))
+		}
+	}
+}
+
+func TestIdentitySave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewIdentityRepository(database)
+	user := saveUser(t)
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	identity := oauth2.Identity{Provider: "keycloak", Subject: "subject", UserID: user.ID, CreatedAt: createdAt}
+
+	cases := []struct {
+		desc     string
+		identity oauth2.Identity
+		err      error
+	}{
+		{
+			desc:     "save identity successfully",
+			identity: identity,
+			err:      nil,
+		},
+		{
+			desc:     "save identity with the same subject",
+			identity: identity,
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc:     "save identity of the same subject of another provider",
+			identity: oauth2.Identity{Provider: "google", Subject: "subject", UserID: user.ID, CreatedAt: createdAt},
+			err:      nil,
+		},
+		{
+			desc:     "save identity of non-existing user",
+			identity: oauth2.Identity{Provider: "keycloak", Subject: "another", UserID: testsutil.GenerateUUID(t), CreatedAt: createdAt},
+			err:      repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.identity)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), identity.Provider, identity.Subject)
+	assert.Nil(t, err, fmt.Sprintf("retrieve identity unexpected error: %s", err))
+	assert.Equal(t, identity, saved)
+
+	_, err = repo.Retrieve(context.Background(), identity.Provider, "unknown")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/users/verification"
+)
+
+var _ verification.Repository = (*verificationRepo)(nil)
+
+type verificationRepo struct {
+	db postgres.Database
+}
+
+// NewVerificationRepository instantiates a PostgreSQL
+// implementation of email verification repository.
+func NewVerificationRepository(db postgres.Database) verification.Repository {
+	return &verificationRepo{
+		db: db,
+	}
+}
+
+func (repo *verificationRepo) Save(ctx context.Context, v verification.Verification, resendAfter time.Time) error {
+	q := `INSERT INTO email_verifications (user_id, token, email, sent_at, expires_at)
+		VALUES (:user_id, :token, :email, :sent_at, :expires_at)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, email = EXCLUDED.email, sent_at = EXCLUDED.sent_at, expires_at = EXCLUDED.expires_at
+		WHERE email_verifications.email <> EXCLUDED.email OR email_verifications.sent_at IS NULL OR email_verifications.sent_at <= :resend_after`
+
+	dbv := toDBVerification(v)
+	dbv.ResendAfter = resendAfter.UTC()
+	res, err := repo.db.NamedExecContext(ctx, q, dbv)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrConflict
+	}
+
+	return nil
+}
+
+func (repo *verificationRepo) Retrieve(ctx context.Context, token string) (verification.Verification, error) {
+	q := `SELECT user_id, token, email, sent_at, expires_at FROM email_verifications WHERE token = :token AND expires_at > :expires_at`
+
+	dbv := dbVerification{Token: token, ExpiresAt: time.Now().UTC()}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbv)
+	if err != nil {
+		return verification.Verification{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbv = dbVerification{}
+	if rows.Next() {
+		if err := rows.StructScan(&dbv); err != nil {
+			return verification.Verification{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toVerification(dbv), nil
+	}
+
+	return verification.Verification{}, repoerr.ErrNotFound
+}
+
+func (repo *verificationRepo) Verify(ctx context.Context, userID string, verifiedAt time.Time) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := `UPDATE clients SET verified_at = $1 WHERE id = $2`
+	res, err := tx.ExecContext(ctx, q, verifiedAt.UTC(), userID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	// Expired verifications are removed along the way.
+	q = `DELETE FROM email_verifications WHERE user_id = $1 OR expires_at <= $2`
+	if _, err := tx.ExecContext(ctx, q, userID, time.Now().UTC()); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return tx.Commit()
+}
+
+type dbVerification struct {
+	UserID      string       `db:"user_id"`
+	Token       string       `db:"token"`
+	Email       string       `db:"email"`
+	SentAt      sql.NullTime `db:"sent_at"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	ResendAfter time.Time    `db:"resend_after"`
+}
+
+func toDBVerification(v verification.Verification) dbVerification {
+	var sentAt sql.NullTime
+	if !v.SentAt.IsZero() {
+		sentAt = sql.NullTime{Time: v.SentAt, Valid: true}
+	}
+
+	return dbVerification{
+		UserID:    v.UserID,
+		Token:     v.Token,
+		Email:     v.Email,
+		SentAt:    sentAt,
+		ExpiresAt: v.ExpiresAt,
+	}
+}
+
+func toVerification(dbv dbVerification) verification.Verification {
+	var sentAt time.Time
+	if dbv.SentAt.Valid {
+		sentAt = dbv.SentAt.Time.UTC()
+	}
+
+	return verification.Verification{
+		UserID:    dbv.UserID,
+		Token:     dbv.Token,
+		Email:     dbv.Email,
+		SentAt:    sentAt,
+		ExpiresAt: dbv.ExpiresAt.UTC(),
+	}
+}
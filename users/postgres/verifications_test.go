@@ -0,0 +1,202 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	cpostgres "github.com/absmach/magistrala/users/postgres"
+	"github.com/absmach/magistrala/users/verification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewVerificationRepository(database)
+	user := saveUser(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	expiresAt := now.Add(time.Hour)
+	resendAfter := now.Add(-time.Minute)
+
+	cases := []struct {
+		desc         string
+		verification verification.Verification
+		err          error
+	}{
+		{
+			desc: "save verification successfully",
+			verification: verification.Verification{
+				Token:     testsutil.GenerateUUID(t),
+				UserID:    user.ID,
+				Email:     user.Credentials.Identity,
+				SentAt:    now.Add(-2 * time.Minute),
+				ExpiresAt: expiresAt,
+			},
+		},
+		{
+			desc: "replace verification successfully",
+			verification: verification.Verification{
+				Token:     testsutil.GenerateUUID(t),
+				UserID:    user.ID,
+				Email:     user.Credentials.Identity,
+				SentAt:    now,
+				ExpiresAt: expiresAt.Add(time.Hour),
+			},
+		},
+		{
+			desc: "replace verification too early after the previous one",
+			verification: verification.Verification{
+				Token:     testsutil.GenerateUUID(t),
+				UserID:    user.ID,
+				Email:     user.Credentials.Identity,
+				SentAt:    now,
+				ExpiresAt: expiresAt.Add(time.Hour),
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "replace verification of the changed email",
+			verification: verification.Verification{
+				Token:     testsutil.GenerateUUID(t),
+				UserID:    user.ID,
+				Email:     "changed@example.com",
+				SentAt:    now,
+				ExpiresAt: expiresAt.Add(time.Hour),
+			},
+		},
+		{
+			desc: "save verification of non-existing user",
+			verification: verification.Verification{
+				Token:     testsutil.GenerateUUID(t),
+				UserID:    testsutil.GenerateUUID(t),
+				Email:     "non-existing@example.com",
+				SentAt:    now,
+				ExpiresAt: expiresAt,
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.verification, resendAfter)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			saved, err := repo.Retrieve(context.Background(), tc.verification.Token)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.verification, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.verification, saved))
+		}
+	}
+}
+
+func TestVerificationRetrieve(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewVerificationRepository(database)
+
+	v := verification.Verification{
+		Token:     testsutil.GenerateUUID(t),
+		UserID:    saveUser(t).ID,
+		Email:     "verification@example.com",
+		ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond),
+	}
+	expired := verification.Verification{
+		Token:     testsutil.GenerateUUID(t),
+		UserID:    saveUser(t).ID,
+		Email:     "expired@example.com",
+		ExpiresAt: time.Now().Add(-time.Hour).UTC(),
+	}
+	for _, v := range []verification.Verification{v, expired} {
+		err := repo.Save(context.Background(), v, time.Now())
+		require.Nil(t, err, fmt.Sprintf("save verification unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc         string
+		token        string
+		verification verification.Verification
+		err          error
+	}{
+		{
+			desc:         "retrieve verification",
+			token:        v.Token,
+			verification: v,
+		},
+		{
+			desc:  "retrieve expired verification",
+			token: expired.Token,
+			err:   repoerr.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing verification",
+			token: testsutil.GenerateUUID(t),
+			err:   repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		v, err := repo.Retrieve(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.verification, v, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.verification, v))
+	}
+}
+
+func TestVerificationVerify(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := cpostgres.NewVerificationRepository(database)
+	crepo := cpostgres.NewRepository(database)
+	user := saveUser(t)
+
+	v := verification.Verification{
+		Token:     testsutil.GenerateUUID(t),
+		UserID:    user.ID,
+		Email:     user.Credentials.Identity,
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}
+	err := repo.Save(context.Background(), v, time.Now())
+	require.Nil(t, err, fmt.Sprintf("save verification unexpected error: %s", err))
+	verifiedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc   string
+		userID string
+		err    error
+	}{
+		{
+			desc:   "verify email successfully",
+			userID: user.ID,
+		},
+		{
+			desc:   "verify email of non-existing user",
+			userID: testsutil.GenerateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Verify(context.Background(), tc.userID, verifiedAt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			client, err := crepo.RetrieveByID(context.Background(), tc.userID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, verifiedAt, client.VerifiedAt.UTC(), fmt.Sprintf("%s: expected %s got %s", tc.desc, verifiedAt, client.VerifiedAt))
+			_, err = repo.Retrieve(context.Background(), v.Token)
+			assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s", tc.desc, repoerr.ErrNotFound, err))
+		}
+	}
+}
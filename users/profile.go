@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/clients"
+)
+
+// ProfileArchive contains the personal data of the user, exported on the
+// user request.
+type ProfileArchive struct {
+	Profile     clients.Client     `json:"profile"`
+	Domains     []DomainMembership `json:"domains"`
+	Invitations []Invitation       `json:"invitations"`
+	Journal     []JournalEntry     `json:"journal"`
+	ExportedAt  time.Time          `json:"exported_at"`
+}
+
+// DomainMembership represents the permissions of the user in the domain
+// and the domain things and groups administered by the user. Channels are
+// listed together with the groups.
+type DomainMembership struct {
+	DomainID    string   `json:"domain_id"`
+	Permissions []string `json:"permissions"`
+	Things      []string `json:"things"`
+	Groups      []string `json:"groups"`
+}
+
+// Invitation represents the invitation received or sent by the user. The
+// invitation token is not exported.
+type Invitation struct {
+	InvitedBy   string    `json:"invited_by"`
+	UserID      string    `json:"user_id,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	DomainID    string    `json:"domain_id"`
+	Relation    string    `json:"relation"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
+}
+
+// JournalEntry represents the journal entry of the operation on the user.
+type JournalEntry struct {
+	ID         string         `json:"id"`
+	Operation  string         `json:"operation"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+// PersonalData retrieves the personal data of the user kept by the
+// invitations and the journal services.
+//
+//go:generate mockery --name PersonalData --output=./mocks --filename personal_data.go --quiet --note "Copyright (c) Abstract Machines"
+type PersonalData interface {
+	// Invitations retrieves the invitations received and sent by the user.
+	Invitations(ctx context.Context, token, userID string) ([]Invitation, error)
+
+	// Journal retrieves the journal entries of the user.
+	Journal(ctx context.Context, token, userID string) ([]JournalEntry, error)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/users/scim"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createUserEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createUserReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		user, err := svc.CreateUser(ctx, req.token, req.user)
+		if err != nil {
+			return nil, err
+		}
+
+		return userRes{User: user, created: true}, nil
+	}
+}
+
+func viewUserEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		user, err := svc.RetrieveUser(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return userRes{User: user}, nil
+	}
+}
+
+func listUsersEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListUsers(ctx, req.token, req.query)
+		if err != nil {
+			return nil, err
+		}
+		users := page.Users
+		if users == nil {
+			users = []scim.User{}
+		}
+
+		return listRes{
+			Schemas:      []string{scim.ListResponseSchema},
+			TotalResults: page.Total,
+			StartIndex:   page.StartIndex,
+			ItemsPerPage: uint64(len(users)),
+			Resources:    users,
+		}, nil
+	}
+}
+
+func patchUserEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		user, err := svc.PatchUser(ctx, req.token, req.id, req.Operations)
+		if err != nil {
+			return nil, err
+		}
+
+		return userRes{User: user}, nil
+	}
+}
+
+func deleteUserEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DeleteUser(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func createGroupEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		group, err := svc.CreateGroup(ctx, req.token, req.group)
+		if err != nil {
+			return nil, err
+		}
+
+		return groupRes{Group: group, created: true}, nil
+	}
+}
+
+func viewGroupEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		group, err := svc.RetrieveGroup(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return groupRes{Group: group}, nil
+	}
+}
+
+func listGroupsEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListGroups(ctx, req.token, req.query)
+		if err != nil {
+			return nil, err
+		}
+		groups := page.Groups
+		if groups == nil {
+			groups = []scim.Group{}
+		}
+
+		return listRes{
+			Schemas:      []string{scim.ListResponseSchema},
+			TotalResults: page.Total,
+			StartIndex:   page.StartIndex,
+			ItemsPerPage: uint64(len(groups)),
+			Resources:    groups,
+		}, nil
+	}
+}
+
+func patchGroupEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		group, err := svc.PatchGroup(ctx, req.token, req.id, req.Operations)
+		if err != nil {
+			return nil, err
+		}
+
+		return groupRes{Group: group}, nil
+	}
+}
+
+func deleteGroupEndpoint(svc scim.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DeleteGroup(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func serviceProviderConfigEndpoint() endpoint.Endpoint {
+	return func(_ context.Context, _ interface{}) (interface{}, error) {
+		return serviceProviderConfigRes{
+			Schemas: []string{scim.ServiceProviderConfigSchema},
+			Patch:   supported{Supported: true},
+			Filter: filter{
+				Supported:  true,
+				MaxResults: scim.MaxCount,
+			},
+			AuthenticationSchemes: []authenticationScheme{
+				{
+					Type:        "oauthbearertoken",
+					Name:        "Bearer Token",
+					Description: "API key of the platform administrator scoped to the platform administration.",
+					Primary:     true,
+				},
+			},
+		}, nil
+	}
+}
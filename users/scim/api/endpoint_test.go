@@ -0,0 +1,430 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/users/scim"
+	scimapi "github.com/absmach/magistrala/users/scim/api"
+	"github.com/absmach/magistrala/users/scim/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken = "valid"
+	validID    = testsutil.GenerateUUID(&testing.T{})
+	user       = scim.User{
+		Schemas:  []string{scim.UserSchema},
+		ID:       validID,
+		UserName: "john@example.com",
+		Meta: &scim.Meta{
+			ResourceType: scim.UserResourceType,
+			Location:     "/scim/v2/Users/" + validID,
+		},
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newSCIMServer() (*httptest.Server, *mocks.Service) {
+	svc := new(mocks.Service)
+	logger := mglog.NewMock()
+	mux := chi.NewRouter()
+	scimapi.MakeHandler(svc, mux, logger)
+
+	return httptest.NewServer(mux), svc
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+type errorRes struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType"`
+}
+
+func TestCreateUser(t *testing.T) {
+	ss, svc := newSCIMServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc        string
+		user        scim.User
+		token       string
+		contentType string
+		svcErr      error
+		status      int
+		scimType    string
+	}{
+		{
+			desc:        "create user",
+			user:        scim.User{UserName: "john@example.com"},
+			token:       validToken,
+			contentType: scimapi.ContentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create user with JSON content type",
+			user:        scim.User{UserName: "john@example.com"},
+			token:       validToken,
+			contentType: "application/json",
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create user with empty token",
+			user:        scim.User{UserName: "john@example.com"},
+			contentType: scimapi.ContentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create user without platform administration",
+			user:        scim.User{UserName: "john@example.com"},
+			token:       validToken,
+			contentType: scimapi.ContentType,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create user without user name",
+			user:        scim.User{DisplayName: "John"},
+			token:       validToken,
+			contentType: scimapi.ContentType,
+			status:      http.StatusBadRequest,
+			scimType:    "invalidValue",
+		},
+		{
+			desc:        "create existing user",
+			user:        scim.User{UserName: "john@example.com"},
+			token:       validToken,
+			contentType: scimapi.ContentType,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+			scimType:    "uniqueness",
+		},
+		{
+			desc:        "create user with invalid content type",
+			user:        scim.User{UserName: "john@example.com"},
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ss.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/scim/v2/Users/", ss.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(toJSON(tc.user)),
+		}
+
+		svcCall := svc.On("CreateUser", mock.Anything, tc.token, tc.user).Return(user, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, scimapi.ContentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: unexpected content type", tc.desc))
+		switch res.StatusCode {
+		case http.StatusCreated:
+			assert.Equal(t, user.Meta.Location, res.Header.Get("Location"), fmt.Sprintf("%s: unexpected location", tc.desc))
+		default:
+			var errRes errorRes
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, []string{scim.ErrorSchema}, errRes.Schemas, fmt.Sprintf("%s: unexpected error schemas", tc.desc))
+			assert.Equal(t, fmt.Sprint(tc.status), errRes.Status, fmt.Sprintf("%s: expected status %d got %s", tc.desc, tc.status, errRes.Status))
+			assert.Equal(t, tc.scimType, errRes.ScimType, fmt.Sprintf("%s: expected SCIM type %s got %s", tc.desc, tc.scimType, errRes.ScimType))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	ss, svc := newSCIMServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc     string
+		query    string
+		token    string
+		scimQ    scim.Query
+		page     scim.UsersPage
+		svcErr   error
+		status   int
+		scimType string
+		total    uint64
+	}{
+		{
+			desc:   "list users",
+			token:  validToken,
+			scimQ:  scim.Query{StartIndex: 1, Count: scim.DefCount},
+			page:   scim.UsersPage{Total: 1, StartIndex: 1, Users: []scim.User{user}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list users with pagination",
+			query:  "startIndex=0&count=1000",
+			token:  validToken,
+			scimQ:  scim.Query{StartIndex: 1, Count: scim.MaxCount},
+			page:   scim.UsersPage{Total: 1, StartIndex: 1, Users: []scim.User{user}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:  "list users with filter",
+			query: "filter=userName+eq+%22john%40example.com%22",
+			token: validToken,
+			scimQ: scim.Query{
+				Filter:     scim.Filter{Attribute: "username", Operator: scim.EqOperator, Value: "john@example.com"},
+				StartIndex: 1,
+				Count:      scim.DefCount,
+			},
+			page:   scim.UsersPage{StartIndex: 1},
+			status: http.StatusOK,
+			total:  0,
+		},
+		{
+			desc:     "list users with unsupported filter",
+			query:    "filter=userName+sw+%22john%22",
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			scimType: "invalidFilter",
+		},
+		{
+			desc:     "list users with invalid count",
+			query:    "count=invalid",
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			scimType: "invalidSyntax",
+		},
+		{
+			desc:   "list users with invalid token",
+			token:  "invalid",
+			scimQ:  scim.Query{StartIndex: 1, Count: scim.DefCount},
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/scim/v2/Users?%s", ss.URL, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListUsers", mock.Anything, tc.token, tc.scimQ).Return(tc.page, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		switch res.StatusCode {
+		case http.StatusOK:
+			var body struct {
+				Schemas      []string    `json:"schemas"`
+				TotalResults uint64      `json:"totalResults"`
+				Resources    []scim.User `json:"Resources"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, []string{scim.ListResponseSchema}, body.Schemas, fmt.Sprintf("%s: unexpected list schemas", tc.desc))
+			assert.Equal(t, tc.total, body.TotalResults, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.TotalResults))
+			assert.NotNil(t, body.Resources, fmt.Sprintf("%s: expected resources list", tc.desc))
+		default:
+			var errRes errorRes
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.scimType, errRes.ScimType, fmt.Sprintf("%s: expected SCIM type %s got %s", tc.desc, tc.scimType, errRes.ScimType))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	ss, svc := newSCIMServer()
+	defer ss.Close()
+
+	ops := []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: json.RawMessage("false")}}
+
+	cases := []struct {
+		desc     string
+		id       string
+		body     string
+		svcErr   error
+		status   int
+		scimType string
+	}{
+		{
+			desc:   "patch user",
+			id:     validID,
+			body:   toJSON(map[string]interface{}{"schemas": []string{scim.PatchOpSchema}, "Operations": ops}),
+			status: http.StatusOK,
+		},
+		{
+			desc:     "patch user without operations",
+			id:       validID,
+			body:     toJSON(map[string]interface{}{"schemas": []string{scim.PatchOpSchema}}),
+			status:   http.StatusBadRequest,
+			scimType: "invalidSyntax",
+		},
+		{
+			desc:     "patch user with malformed body",
+			id:       validID,
+			body:     "{",
+			status:   http.StatusBadRequest,
+			scimType: "invalidSyntax",
+		},
+		{
+			desc:     "patch user with unsupported path",
+			id:       validID,
+			body:     toJSON(map[string]interface{}{"schemas": []string{scim.PatchOpSchema}, "Operations": ops}),
+			svcErr:   scim.ErrInvalidPath,
+			status:   http.StatusBadRequest,
+			scimType: "invalidPath",
+		},
+		{
+			desc:   "patch non-existing user",
+			id:     validID,
+			body:   toJSON(map[string]interface{}{"schemas": []string{scim.PatchOpSchema}, "Operations": ops}),
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ss.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/scim/v2/Users/%s", ss.URL, tc.id),
+			contentType: scimapi.ContentType,
+			token:       validToken,
+			body:        strings.NewReader(tc.body),
+		}
+
+		svcCall := svc.On("PatchUser", mock.Anything, validToken, tc.id, ops).Return(user, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			var errRes errorRes
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.scimType, errRes.ScimType, fmt.Sprintf("%s: expected SCIM type %s got %s", tc.desc, tc.scimType, errRes.ScimType))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	ss, svc := newSCIMServer()
+	defer ss.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "delete user",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "delete user with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "delete non-existing user",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/scim/v2/Users/%s", ss.URL, validID),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteUser", mock.Anything, tc.token, validID).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestServiceProviderConfig(t *testing.T) {
+	ss, _ := newSCIMServer()
+	defer ss.Close()
+
+	req := testRequest{
+		client: ss.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/scim/v2/ServiceProviderConfig", ss.URL),
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body struct {
+		Schemas []string `json:"schemas"`
+		Patch   struct {
+			Supported bool `json:"supported"`
+		} `json:"patch"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	assert.Equal(t, []string{scim.ServiceProviderConfigSchema}, body.Schemas, "unexpected service provider config schemas")
+	assert.True(t, body.Patch.Supported, "expected patch to be supported")
+}
@@ -0,0 +1,222 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/users/scim"
+)
+
+var _ scim.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    scim.Service
+}
+
+// LoggingMiddleware adds logging facilities to the SCIM service.
+func LoggingMiddleware(svc scim.Service, logger *slog.Logger) scim.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// CreateUser logs the scim_create_user request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateUser(ctx context.Context, token string, user scim.User) (u scim.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", u.ID),
+				slog.String("user_name", user.UserName),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM create user failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM create user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CreateUser(ctx, token, user)
+}
+
+// RetrieveUser logs the scim_retrieve_user request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RetrieveUser(ctx context.Context, token, id string) (u scim.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM retrieve user failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM retrieve user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveUser(ctx, token, id)
+}
+
+// ListUsers logs the scim_list_users request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListUsers(ctx context.Context, token string, q scim.Query) (up scim.UsersPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("query",
+				slog.String("filter", q.Filter.Attribute),
+				slog.Uint64("start_index", q.StartIndex),
+				slog.Uint64("count", q.Count),
+				slog.Uint64("total", up.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM list users failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM list users completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListUsers(ctx, token, q)
+}
+
+// PatchUser logs the scim_patch_user request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) PatchUser(ctx context.Context, token, id string, ops []scim.PatchOp) (u scim.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", id),
+			slog.Int("operations", len(ops)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM patch user failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM patch user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.PatchUser(ctx, token, id, ops)
+}
+
+// DeleteUser logs the scim_delete_user request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DeleteUser(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM delete user failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM delete user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteUser(ctx, token, id)
+}
+
+// CreateGroup logs the scim_create_group request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token string, group scim.Group) (g scim.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("group",
+				slog.String("id", g.ID),
+				slog.String("display_name", group.DisplayName),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM create group failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM create group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CreateGroup(ctx, token, group)
+}
+
+// RetrieveGroup logs the scim_retrieve_group request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RetrieveGroup(ctx context.Context, token, id string) (g scim.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM retrieve group failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM retrieve group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveGroup(ctx, token, id)
+}
+
+// ListGroups logs the scim_list_groups request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListGroups(ctx context.Context, token string, q scim.Query) (gp scim.GroupsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("query",
+				slog.String("filter", q.Filter.Attribute),
+				slog.Uint64("start_index", q.StartIndex),
+				slog.Uint64("count", q.Count),
+				slog.Uint64("total", gp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM list groups failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM list groups completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListGroups(ctx, token, q)
+}
+
+// PatchGroup logs the scim_patch_group request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) PatchGroup(ctx context.Context, token, id string, ops []scim.PatchOp) (g scim.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.Int("operations", len(ops)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM patch group failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM patch group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.PatchGroup(ctx, token, id, ops)
+}
+
+// DeleteGroup logs the scim_delete_group request. It logs the request details and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("SCIM delete group failed", args...)
+			return
+		}
+		lm.logger.Info("SCIM delete group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteGroup(ctx, token, id)
+}
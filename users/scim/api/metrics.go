@@ -0,0 +1,119 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/users/scim"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ scim.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     scim.Service
+}
+
+// MetricsMiddleware instruments the SCIM service by tracking request count and latency.
+func MetricsMiddleware(svc scim.Service, counter metrics.Counter, latency metrics.Histogram) scim.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// CreateUser instruments CreateUser method with metrics.
+func (ms *metricsMiddleware) CreateUser(ctx context.Context, token string, user scim.User) (scim.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_create_user").Add(1)
+		ms.latency.With("method", "scim_create_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateUser(ctx, token, user)
+}
+
+// RetrieveUser instruments RetrieveUser method with metrics.
+func (ms *metricsMiddleware) RetrieveUser(ctx context.Context, token, id string) (scim.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_retrieve_user").Add(1)
+		ms.latency.With("method", "scim_retrieve_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveUser(ctx, token, id)
+}
+
+// ListUsers instruments ListUsers method with metrics.
+func (ms *metricsMiddleware) ListUsers(ctx context.Context, token string, q scim.Query) (scim.UsersPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_list_users").Add(1)
+		ms.latency.With("method", "scim_list_users").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListUsers(ctx, token, q)
+}
+
+// PatchUser instruments PatchUser method with metrics.
+func (ms *metricsMiddleware) PatchUser(ctx context.Context, token, id string, ops []scim.PatchOp) (scim.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_patch_user").Add(1)
+		ms.latency.With("method", "scim_patch_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.PatchUser(ctx, token, id, ops)
+}
+
+// DeleteUser instruments DeleteUser method with metrics.
+func (ms *metricsMiddleware) DeleteUser(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_delete_user").Add(1)
+		ms.latency.With("method", "scim_delete_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteUser(ctx, token, id)
+}
+
+// CreateGroup instruments CreateGroup method with metrics.
+func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token string, group scim.Group) (scim.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_create_group").Add(1)
+		ms.latency.With("method", "scim_create_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateGroup(ctx, token, group)
+}
+
+// RetrieveGroup instruments RetrieveGroup method with metrics.
+func (ms *metricsMiddleware) RetrieveGroup(ctx context.Context, token, id string) (scim.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_retrieve_group").Add(1)
+		ms.latency.With("method", "scim_retrieve_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveGroup(ctx, token, id)
+}
+
+// ListGroups instruments ListGroups method with metrics.
+func (ms *metricsMiddleware) ListGroups(ctx context.Context, token string, q scim.Query) (scim.GroupsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_list_groups").Add(1)
+		ms.latency.With("method", "scim_list_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListGroups(ctx, token, q)
+}
+
+// PatchGroup instruments PatchGroup method with metrics.
+func (ms *metricsMiddleware) PatchGroup(ctx context.Context, token, id string, ops []scim.PatchOp) (scim.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_patch_group").Add(1)
+		ms.latency.With("method", "scim_patch_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.PatchGroup(ctx, token, id, ops)
+}
+
+// DeleteGroup instruments DeleteGroup method with metrics.
+func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "scim_delete_group").Add(1)
+		ms.latency.With("method", "scim_delete_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteGroup(ctx, token, id)
+}
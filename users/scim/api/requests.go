@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/users/scim"
+)
+
+type createUserReq struct {
+	token string
+	user  scim.User
+}
+
+func (req createUserReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.user.UserName == "" {
+		return apiutil.ErrMissingIdentity
+	}
+
+	return nil
+}
+
+type createGroupReq struct {
+	token string
+	group scim.Group
+}
+
+func (req createGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.group.DisplayName == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type viewResourceReq struct {
+	token string
+	id    string
+}
+
+func (req viewResourceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listResourcesReq struct {
+	token string
+	query scim.Query
+}
+
+func (req listResourcesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type patchResourceReq struct {
+	token      string
+	id         string
+	Schemas    []string       `json:"schemas"`
+	Operations []scim.PatchOp `json:"Operations"`
+}
+
+func (req patchResourceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Operations) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/users/scim"
+)
+
+var (
+	_ magistrala.Response = (*userRes)(nil)
+	_ magistrala.Response = (*groupRes)(nil)
+	_ magistrala.Response = (*listRes)(nil)
+	_ magistrala.Response = (*deleteRes)(nil)
+	_ magistrala.Response = (*serviceProviderConfigRes)(nil)
+)
+
+type userRes struct {
+	scim.User
+	created bool
+}
+
+func (res userRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res userRes) Headers() map[string]string {
+	if res.created && res.Meta != nil {
+		return map[string]string{
+			"Location": res.Meta.Location,
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res userRes) Empty() bool {
+	return false
+}
+
+type groupRes struct {
+	scim.Group
+	created bool
+}
+
+func (res groupRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res groupRes) Headers() map[string]string {
+	if res.created && res.Meta != nil {
+		return map[string]string{
+			"Location": res.Meta.Location,
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res groupRes) Empty() bool {
+	return false
+}
+
+type listRes struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults uint64      `json:"totalResults"`
+	StartIndex   uint64      `json:"startIndex"`
+	ItemsPerPage uint64      `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+func (res listRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRes) Empty() bool {
+	return false
+}
+
+type deleteRes struct{}
+
+func (res deleteRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteRes) Empty() bool {
+	return true
+}
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type bulk struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type filter struct {
+	Supported  bool   `json:"supported"`
+	MaxResults uint64 `json:"maxResults"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type serviceProviderConfigRes struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulk                   `json:"bulk"`
+	Filter                filter                 `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+}
+
+func (res serviceProviderConfigRes) Code() int {
+	return http.StatusOK
+}
+
+func (res serviceProviderConfigRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res serviceProviderConfigRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/users/scim"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	// ContentType is the SCIM content type.
+	ContentType = "application/scim+json"
+
+	filterKey             = "filter"
+	startIndexKey         = "startIndex"
+	countKey              = "count"
+	excludedAttributesKey = "excludedAttributes"
+	membersAttribute      = "members"
+)
+
+// MakeHandler returns a HTTP handler for the SCIM API endpoints.
+func MakeHandler(svc scim.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(apiutil.SourceIPToContext),
+	}
+
+	r.Route("/scim/v2", func(r chi.Router) {
+		r.Get("/ServiceProviderConfig", otelhttp.NewHandler(kithttp.NewServer(
+			serviceProviderConfigEndpoint(),
+			kithttp.NopRequestDecoder,
+			encodeResponse,
+			opts...,
+		), "scim_service_provider_config").ServeHTTP)
+
+		r.Route("/Users", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				createUserEndpoint(svc),
+				decodeCreateUser,
+				encodeResponse,
+				opts...,
+			), "scim_create_user").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listUsersEndpoint(svc),
+				decodeListResources,
+				encodeResponse,
+				opts...,
+			), "scim_list_users").ServeHTTP)
+
+			r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				viewUserEndpoint(svc),
+				decodeViewResource,
+				encodeResponse,
+				opts...,
+			), "scim_view_user").ServeHTTP)
+
+			r.Patch("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				patchUserEndpoint(svc),
+				decodePatchResource,
+				encodeResponse,
+				opts...,
+			), "scim_patch_user").ServeHTTP)
+
+			r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				deleteUserEndpoint(svc),
+				decodeViewResource,
+				encodeResponse,
+				opts...,
+			), "scim_delete_user").ServeHTTP)
+		})
+
+		r.Route("/Groups", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				createGroupEndpoint(svc),
+				decodeCreateGroup,
+				encodeResponse,
+				opts...,
+			), "scim_create_group").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listGroupsEndpoint(svc),
+				decodeListResources,
+				encodeResponse,
+				opts...,
+			), "scim_list_groups").ServeHTTP)
+
+			r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				viewGroupEndpoint(svc),
+				decodeViewResource,
+				encodeResponse,
+				opts...,
+			), "scim_view_group").ServeHTTP)
+
+			r.Patch("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				patchGroupEndpoint(svc),
+				decodePatchResource,
+				encodeResponse,
+				opts...,
+			), "scim_patch_group").ServeHTTP)
+
+			r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				deleteGroupEndpoint(svc),
+				decodeViewResource,
+				encodeResponse,
+				opts...,
+			), "scim_delete_group").ServeHTTP)
+		})
+	})
+
+	return r
+}
+
+func decodeCreateUser(_ context.Context, r *http.Request) (interface{}, error) {
+	if !jsonContent(r) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := createUserReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.user); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreateGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !jsonContent(r) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := createGroupReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.group); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeViewResource(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewResourceReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListResources(_ context.Context, r *http.Request) (interface{}, error) {
+	f, err := apiutil.ReadStringQuery(r, filterKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	start, err := apiutil.ReadNumQuery[uint64](r, startIndexKey, 1)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	count, err := apiutil.ReadNumQuery[uint64](r, countKey, scim.DefCount)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	excluded, err := apiutil.ReadStringQuery(r, excludedAttributesKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := scim.ParseFilter(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start index lower than 1 is interpreted as 1, and the count
+	// greater than the maximum is reduced to the maximum.
+	req := listResourcesReq{
+		token: apiutil.ExtractBearerToken(r),
+		query: scim.Query{
+			Filter:     filter,
+			StartIndex: max(start, 1),
+			Count:      min(count, scim.MaxCount),
+		},
+	}
+	for _, attr := range strings.Split(excluded, ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), membersAttribute) {
+			req.query.ExcludeMembers = true
+		}
+	}
+
+	return req, nil
+}
+
+func decodePatchResource(_ context.Context, r *http.Request) (interface{}, error) {
+	if !jsonContent(r) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := patchResourceReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+// jsonContent reports whether the request has the SCIM or JSON content type.
+func jsonContent(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	return strings.Contains(ct, ContentType) || strings.Contains(ct, api.ContentType)
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(magistrala.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// encodeError encodes the error as the SCIM error response, with the
+// SCIM error type of the bad requests.
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	var status int
+	var scimType string
+	switch {
+	case errors.Contains(err, svcerr.ErrAuthentication),
+		errors.Contains(err, apiutil.ErrBearerToken):
+		status = http.StatusUnauthorized
+	case errors.Contains(err, svcerr.ErrAuthorization),
+		errors.Contains(err, svcerr.ErrDomainAuthorization):
+		status = http.StatusForbidden
+	case errors.Contains(err, scim.ErrInvalidFilter):
+		status, scimType = http.StatusBadRequest, "invalidFilter"
+	case errors.Contains(err, scim.ErrInvalidPath):
+		status, scimType = http.StatusBadRequest, "invalidPath"
+	case errors.Contains(err, scim.ErrInvalidValue),
+		errors.Contains(err, apiutil.ErrMissingIdentity),
+		errors.Contains(err, apiutil.ErrMissingName),
+		errors.Contains(err, svcerr.ErrInvalidStatus):
+		status, scimType = http.StatusBadRequest, "invalidValue"
+	case errors.Contains(err, svcerr.ErrConflict):
+		status, scimType = http.StatusConflict, "uniqueness"
+	case errors.Contains(err, svcerr.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		status = http.StatusUnsupportedMediaType
+	case errors.Contains(err, apiutil.ErrValidation),
+		errors.Contains(err, svcerr.ErrMalformedEntity),
+		errors.Contains(err, errors.ErrMalformedEntity):
+		status, scimType = http.StatusBadRequest, "invalidSyntax"
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	res := errorRes{
+		Schemas:  []string{scim.ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   err.Error(),
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
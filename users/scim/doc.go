@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scim contains the SCIM 2.0 provisioning of the users and groups
+// by the identity providers, as specified in RFC 7643 and RFC 7644.
+package scim
@@ -0,0 +1,72 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// EqOperator is the equality filter operator.
+const EqOperator = "eq"
+
+// Filter compares the resource attribute with the value, e.g.
+// `userName eq "john@example.com"`. Only the equality operator is
+// supported, since the identity providers use it to look up the
+// resources before provisioning them. Empty attribute matches all.
+type Filter struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+// ParseFilter parses the filter expression. Attribute names are case
+// insensitive, so the attribute is returned in lower case and without
+// the schema URN prefix.
+func ParseFilter(expr string) (Filter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Filter{}, nil
+	}
+	attr, rest, ok := strings.Cut(expr, " ")
+	if !ok {
+		return Filter{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("missing operator in %q", expr))
+	}
+	op, value, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok {
+		return Filter{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("missing value in %q", expr))
+	}
+	op = strings.ToLower(op)
+	if op != EqOperator {
+		return Filter{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("unsupported operator %q", op))
+	}
+
+	var v string
+	switch value = strings.TrimSpace(value); {
+	case strings.HasPrefix(value, `"`):
+		// Quoted values are JSON strings, so anything after
+		// the closing quote, e.g. the logical operator, is rejected.
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return Filter{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("invalid value %s", value))
+		}
+	case value == "true", value == "false":
+		v = value
+	default:
+		return Filter{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("invalid value %s", value))
+	}
+
+	attr = strings.ToLower(attr)
+	for _, schema := range []string{UserSchema, GroupSchema} {
+		attr = strings.TrimPrefix(attr, strings.ToLower(schema)+":")
+	}
+
+	return Filter{
+		Attribute: attr,
+		Operator:  op,
+		Value:     v,
+	}, nil
+}
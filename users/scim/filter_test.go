@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package scim_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/users/scim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		desc   string
+		expr   string
+		filter scim.Filter
+		err    error
+	}{
+		{
+			desc:   "parse empty filter",
+			expr:   "",
+			filter: scim.Filter{},
+			err:    nil,
+		},
+		{
+			desc:   "parse user name filter",
+			expr:   `userName eq "john@example.com"`,
+			filter: scim.Filter{Attribute: "username", Operator: scim.EqOperator, Value: "john@example.com"},
+			err:    nil,
+		},
+		{
+			desc:   "parse filter with upper case operator",
+			expr:   `externalId EQ "00u1"`,
+			filter: scim.Filter{Attribute: "externalid", Operator: scim.EqOperator, Value: "00u1"},
+			err:    nil,
+		},
+		{
+			desc:   "parse filter with schema prefix",
+			expr:   `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "john@example.com"`,
+			filter: scim.Filter{Attribute: "username", Operator: scim.EqOperator, Value: "john@example.com"},
+			err:    nil,
+		},
+		{
+			desc:   "parse filter with escaped quote",
+			expr:   `displayName eq "Team \"A\""`,
+			filter: scim.Filter{Attribute: "displayname", Operator: scim.EqOperator, Value: `Team "A"`},
+			err:    nil,
+		},
+		{
+			desc:   "parse boolean filter",
+			expr:   "active eq true",
+			filter: scim.Filter{Attribute: "active", Operator: scim.EqOperator, Value: "true"},
+			err:    nil,
+		},
+		{
+			desc: "parse filter without value",
+			expr: "userName eq",
+			err:  scim.ErrInvalidFilter,
+		},
+		{
+			desc: "parse filter with unsupported operator",
+			expr: `userName co "john"`,
+			err:  scim.ErrInvalidFilter,
+		},
+		{
+			desc: "parse filter with unquoted value",
+			expr: "userName eq john",
+			err:  scim.ErrInvalidFilter,
+		},
+		{
+			desc: "parse filter with logical operator",
+			expr: `userName eq "john" and active eq true`,
+			err:  scim.ErrInvalidFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		filter, err := scim.ParseFilter(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.filter, filter, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.filter, filter))
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	scim "github.com/absmach/magistrala/users/scim"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// CreateGroup provides a mock function with given fields: ctx, token, g
+func (_m *Service) CreateGroup(ctx context.Context, token string, g scim.Group) (scim.Group, error) {
+	ret := _m.Called(ctx, token, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 scim.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Group) (scim.Group, error)); ok {
+		return rf(ctx, token, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Group) scim.Group); ok {
+		r0 = rf(ctx, token, g)
+	} else {
+		r0 = ret.Get(0).(scim.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, scim.Group) error); ok {
+		r1 = rf(ctx, token, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, token, u
+func (_m *Service) CreateUser(ctx context.Context, token string, u scim.User) (scim.User, error) {
+	ret := _m.Called(ctx, token, u)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 scim.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.User) (scim.User, error)); ok {
+		return rf(ctx, token, u)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.User) scim.User); ok {
+		r0 = rf(ctx, token, u)
+	} else {
+		r0 = ret.Get(0).(scim.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, scim.User) error); ok {
+		r1 = rf(ctx, token, u)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, token, id
+func (_m *Service) DeleteGroup(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: ctx, token, id
+func (_m *Service) DeleteUser(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListGroups provides a mock function with given fields: ctx, token, q
+func (_m *Service) ListGroups(ctx context.Context, token string, q scim.Query) (scim.GroupsPage, error) {
+	ret := _m.Called(ctx, token, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 scim.GroupsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Query) (scim.GroupsPage, error)); ok {
+		return rf(ctx, token, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Query) scim.GroupsPage); ok {
+		r0 = rf(ctx, token, q)
+	} else {
+		r0 = ret.Get(0).(scim.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, scim.Query) error); ok {
+		r1 = rf(ctx, token, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, token, q
+func (_m *Service) ListUsers(ctx context.Context, token string, q scim.Query) (scim.UsersPage, error) {
+	ret := _m.Called(ctx, token, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 scim.UsersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Query) (scim.UsersPage, error)); ok {
+		return rf(ctx, token, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, scim.Query) scim.UsersPage); ok {
+		r0 = rf(ctx, token, q)
+	} else {
+		r0 = ret.Get(0).(scim.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, scim.Query) error); ok {
+		r1 = rf(ctx, token, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchGroup provides a mock function with given fields: ctx, token, id, ops
+func (_m *Service) PatchGroup(ctx context.Context, token string, id string, ops []scim.PatchOp) (scim.Group, error) {
+	ret := _m.Called(ctx, token, id, ops)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchGroup")
+	}
+
+	var r0 scim.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []scim.PatchOp) (scim.Group, error)); ok {
+		return rf(ctx, token, id, ops)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []scim.PatchOp) scim.Group); ok {
+		r0 = rf(ctx, token, id, ops)
+	} else {
+		r0 = ret.Get(0).(scim.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []scim.PatchOp) error); ok {
+		r1 = rf(ctx, token, id, ops)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchUser provides a mock function with given fields: ctx, token, id, ops
+func (_m *Service) PatchUser(ctx context.Context, token string, id string, ops []scim.PatchOp) (scim.User, error) {
+	ret := _m.Called(ctx, token, id, ops)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 scim.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []scim.PatchOp) (scim.User, error)); ok {
+		return rf(ctx, token, id, ops)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []scim.PatchOp) scim.User); ok {
+		r0 = rf(ctx, token, id, ops)
+	} else {
+		r0 = ret.Get(0).(scim.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []scim.PatchOp) error); ok {
+		r1 = rf(ctx, token, id, ops)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveGroup provides a mock function with given fields: ctx, token, id
+func (_m *Service) RetrieveGroup(ctx context.Context, token string, id string) (scim.Group, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveGroup")
+	}
+
+	var r0 scim.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (scim.Group, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) scim.Group); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(scim.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveUser provides a mock function with given fields: ctx, token, id
+func (_m *Service) RetrieveUser(ctx context.Context, token string, id string) (scim.User, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveUser")
+	}
+
+	var r0 scim.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (scim.User, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) scim.User); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(scim.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
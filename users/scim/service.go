@@ -0,0 +1,841 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/users"
+	"github.com/absmach/magistrala/users/postgres"
+)
+
+const (
+	// The SCIM attributes without the matching client or group
+	// fields are stored in the metadata.
+	externalIDKey = "scim_external_id"
+	nameKey       = "scim_name"
+
+	usersLocation  = "/scim/v2/Users/"
+	groupsLocation = "/scim/v2/Groups/"
+
+	allGroups = uint64(1<<63 - 1)
+)
+
+type service struct {
+	users   users.Service
+	clients postgres.Repository
+	groups  groups.Service
+	auth    grpcclient.AuthServiceClient
+}
+
+// NewService returns a new SCIM service implementation on top of the
+// Users service, the clients repository and the Groups service.
+func NewService(usvc users.Service, crepo postgres.Repository, gsvc groups.Service, authClient grpcclient.AuthServiceClient) Service {
+	return service{
+		users:   usvc,
+		clients: crepo,
+		groups:  gsvc,
+		auth:    authClient,
+	}
+}
+
+func (svc service) CreateUser(ctx context.Context, token string, u User) (User, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return User{}, err
+	}
+	if u.UserName == "" {
+		return User{}, errors.Wrap(ErrInvalidValue, errors.New("missing userName"))
+	}
+
+	status := mgclients.EnabledStatus
+	if u.Active != nil && !*u.Active {
+		status = mgclients.DisabledStatus
+	}
+	client := mgclients.Client{
+		Name: displayName(u),
+		Credentials: mgclients.Credentials{
+			Identity: u.UserName,
+			Secret:   u.Password,
+		},
+		Metadata: userMetadata(mgclients.Metadata{}, u),
+		Role:     mgclients.UserRole,
+		Status:   status,
+	}
+	client, err := svc.users.RegisterClient(ctx, token, client)
+	if err != nil {
+		return User{}, err
+	}
+
+	return toUser(client), nil
+}
+
+func (svc service) RetrieveUser(ctx context.Context, token, id string) (User, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return User{}, err
+	}
+	client, err := svc.retrieveClient(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+
+	return toUser(client), nil
+}
+
+func (svc service) ListUsers(ctx context.Context, token string, q Query) (UsersPage, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return UsersPage{}, err
+	}
+
+	pm := mgclients.Page{
+		Offset: q.StartIndex - 1,
+		Limit:  q.Count,
+		Status: mgclients.AllStatus,
+		Role:   mgclients.AllRole,
+	}
+	switch q.Filter.Attribute {
+	case "":
+	case "id":
+		client, err := svc.retrieveClient(ctx, q.Filter.Value)
+		return usersPage(q, client, err)
+	case "username", "emails", "emails.value":
+		client, err := svc.clients.RetrieveByIdentity(ctx, q.Filter.Value)
+		if err == nil && client.Status == mgclients.DeletedStatus {
+			err = repoerr.ErrNotFound
+		}
+		return usersPage(q, client, err)
+	case "externalid":
+		pm.Metadata = mgclients.Metadata{externalIDKey: q.Filter.Value}
+	case "active":
+		switch q.Filter.Value {
+		case "true":
+			pm.Status = mgclients.EnabledStatus
+		case "false":
+			pm.Status = mgclients.DisabledStatus
+		default:
+			return UsersPage{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("invalid active value %q", q.Filter.Value))
+		}
+	default:
+		return UsersPage{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("unsupported attribute %q", q.Filter.Attribute))
+	}
+
+	page, err := svc.listClients(ctx, pm)
+	if err != nil {
+		return UsersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	up := UsersPage{
+		Total:      page.Total,
+		StartIndex: q.StartIndex,
+	}
+	for _, c := range page.Clients {
+		up.Users = append(up.Users, toUser(c))
+	}
+
+	return up, nil
+}
+
+func (svc service) PatchUser(ctx context.Context, token, id string, ops []PatchOp) (User, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return User{}, err
+	}
+	client, err := svc.retrieveClient(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+
+	u := toUser(client)
+	for _, op := range ops {
+		if err := patchUser(&u, op); err != nil {
+			return User{}, err
+		}
+	}
+
+	if u.UserName == "" {
+		return User{}, errors.Wrap(ErrInvalidValue, errors.New("missing userName"))
+	}
+	if u.UserName != client.Credentials.Identity {
+		if _, err := svc.users.UpdateClientIdentity(ctx, token, id, u.UserName); err != nil {
+			return User{}, err
+		}
+	}
+	name, metadata := displayName(u), userMetadata(client.Metadata, u)
+	if name != client.Name || !reflect.DeepEqual(metadata, client.Metadata) {
+		c := mgclients.Client{
+			ID:       id,
+			Name:     name,
+			Metadata: metadata,
+		}
+		if _, err := svc.users.UpdateClient(ctx, token, c); err != nil {
+			return User{}, err
+		}
+	}
+	active := u.Active == nil || *u.Active
+	switch {
+	case active && client.Status == mgclients.DisabledStatus:
+		if _, err := svc.users.EnableClient(ctx, token, id); err != nil {
+			return User{}, err
+		}
+	case !active && client.Status == mgclients.EnabledStatus:
+		if _, err := svc.users.DisableClient(ctx, token, id); err != nil {
+			return User{}, err
+		}
+	}
+
+	if client, err = svc.retrieveClient(ctx, id); err != nil {
+		return User{}, err
+	}
+
+	return toUser(client), nil
+}
+
+func (svc service) DeleteUser(ctx context.Context, token, id string) error {
+	if err := svc.authorize(ctx, token); err != nil {
+		return err
+	}
+	if _, err := svc.retrieveClient(ctx, id); err != nil {
+		return err
+	}
+
+	return svc.users.DeleteClient(ctx, token, id)
+}
+
+func (svc service) CreateGroup(ctx context.Context, token string, g Group) (Group, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return Group{}, err
+	}
+	if g.DisplayName == "" {
+		return Group{}, errors.Wrap(ErrInvalidValue, errors.New("missing displayName"))
+	}
+	memberIDs, err := svc.memberIDs(ctx, g.Members)
+	if err != nil {
+		return Group{}, err
+	}
+
+	group := groups.Group{
+		Name:     g.DisplayName,
+		Metadata: groupMetadata(mgclients.Metadata{}, g),
+		Status:   mgclients.EnabledStatus,
+	}
+	group, err = svc.groups.CreateGroup(ctx, token, auth.NewGroupKind, group)
+	if err != nil {
+		return Group{}, err
+	}
+	if len(memberIDs) > 0 {
+		if err := svc.groups.Assign(ctx, token, group.ID, auth.MemberRelation, auth.UsersKind, memberIDs...); err != nil {
+			return Group{}, err
+		}
+	}
+
+	return toGroup(group, memberIDs), nil
+}
+
+func (svc service) RetrieveGroup(ctx context.Context, token, id string) (Group, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return Group{}, err
+	}
+	group, err := svc.groups.ViewGroup(ctx, token, id)
+	if err != nil {
+		return Group{}, err
+	}
+	members, err := svc.groupMembers(ctx, token, id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	return toGroup(group, members), nil
+}
+
+func (svc service) ListGroups(ctx context.Context, token string, q Query) (GroupsPage, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return GroupsPage{}, err
+	}
+
+	gm := groups.Page{
+		PageMeta: groups.PageMeta{
+			Offset: q.StartIndex - 1,
+			Limit:  q.Count,
+			Status: mgclients.AllStatus,
+		},
+		Permission: auth.ViewPermission,
+	}
+	switch q.Filter.Attribute {
+	case "":
+	case "id":
+		gm.Offset, gm.Limit = 0, allGroups
+		gm.ID = q.Filter.Value
+	case "displayname":
+		gm.Offset, gm.Limit = 0, allGroups
+		gm.Name = q.Filter.Value
+	case "externalid":
+		gm.Metadata = mgclients.Metadata{externalIDKey: q.Filter.Value}
+	default:
+		return GroupsPage{}, errors.Wrap(ErrInvalidFilter, fmt.Errorf("unsupported attribute %q", q.Filter.Attribute))
+	}
+
+	page, err := svc.groups.ListGroups(ctx, token, auth.UsersKind, "", gm)
+	if err != nil {
+		return GroupsPage{}, err
+	}
+	total, grps := page.Total, page.Groups
+	if gm.Limit == allGroups {
+		// Groups repository matches the names and IDs containing the
+		// filter value, so the exact matches are paginated here.
+		grps = slices.DeleteFunc(grps, func(g groups.Group) bool {
+			if q.Filter.Attribute == "id" {
+				return g.ID != q.Filter.Value
+			}
+			return g.Name != q.Filter.Value
+		})
+		total = uint64(len(grps))
+		start := min(q.StartIndex-1, total)
+		grps = grps[start:min(start+q.Count, total)]
+	}
+
+	gp := GroupsPage{
+		Total:      total,
+		StartIndex: q.StartIndex,
+	}
+	for _, g := range grps {
+		var members []string
+		if !q.ExcludeMembers {
+			if members, err = svc.groupMembers(ctx, token, g.ID); err != nil {
+				return GroupsPage{}, err
+			}
+		}
+		gp.Groups = append(gp.Groups, toGroup(g, members))
+	}
+
+	return gp, nil
+}
+
+func (svc service) PatchGroup(ctx context.Context, token, id string, ops []PatchOp) (Group, error) {
+	if err := svc.authorize(ctx, token); err != nil {
+		return Group{}, err
+	}
+	group, err := svc.groups.ViewGroup(ctx, token, id)
+	if err != nil {
+		return Group{}, err
+	}
+	current, err := svc.groupMembers(ctx, token, id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	g := toGroup(group, current)
+	for _, op := range ops {
+		if err := patchGroup(&g, op); err != nil {
+			return Group{}, err
+		}
+	}
+	if g.DisplayName == "" {
+		return Group{}, errors.Wrap(ErrInvalidValue, errors.New("missing displayName"))
+	}
+	members, err := svc.memberIDs(ctx, g.Members)
+	if err != nil {
+		return Group{}, err
+	}
+
+	metadata := groupMetadata(group.Metadata, g)
+	if g.DisplayName != group.Name || !reflect.DeepEqual(metadata, group.Metadata) {
+		gr := groups.Group{
+			ID:       id,
+			Name:     g.DisplayName,
+			Metadata: metadata,
+		}
+		if _, err := svc.groups.UpdateGroup(ctx, token, gr); err != nil {
+			return Group{}, err
+		}
+	}
+	if added := difference(members, current); len(added) > 0 {
+		if err := svc.groups.Assign(ctx, token, id, auth.MemberRelation, auth.UsersKind, added...); err != nil {
+			return Group{}, err
+		}
+	}
+	if removed := difference(current, members); len(removed) > 0 {
+		if err := svc.groups.Unassign(ctx, token, id, auth.MemberRelation, auth.UsersKind, removed...); err != nil {
+			return Group{}, err
+		}
+	}
+
+	return svc.RetrieveGroup(ctx, token, id)
+}
+
+func (svc service) DeleteGroup(ctx context.Context, token, id string) error {
+	if err := svc.authorize(ctx, token); err != nil {
+		return err
+	}
+
+	return svc.groups.DeleteGroup(ctx, token, id)
+}
+
+// authorize checks that the token is the token of the platform administrator
+// and, if the token is a scoped API key, that it's scoped to the platform
+// administration.
+func (svc service) authorize(ctx context.Context, token string) error {
+	if _, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token}); err != nil {
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	req := &magistrala.AuthorizeReq{
+		SubjectType: auth.UserType,
+		SubjectKind: auth.TokenKind,
+		Subject:     token,
+		Permission:  auth.AdminPermission,
+		ObjectType:  auth.PlatformType,
+		Object:      auth.MagistralaObject,
+	}
+	res, err := svc.auth.Authorize(ctx, req)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
+// retrieveClient retrieves the client, treating the deleted clients
+// which are pending removal as missing.
+func (svc service) retrieveClient(ctx context.Context, id string) (mgclients.Client, error) {
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if client.Status == mgclients.DeletedStatus {
+		return mgclients.Client{}, svcerr.ErrNotFound
+	}
+
+	return client, nil
+}
+
+// listClients lists the enabled and then the disabled clients, leaving
+// out the deleted clients which are pending removal.
+func (svc service) listClients(ctx context.Context, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	if pm.Status != mgclients.AllStatus {
+		return svc.clients.RetrieveAll(ctx, pm)
+	}
+
+	offset, limit := pm.Offset, pm.Limit
+	pm.Status = mgclients.EnabledStatus
+	enabled, err := svc.clients.RetrieveAll(ctx, pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+	pm.Status = mgclients.DisabledStatus
+	pm.Limit = limit - uint64(len(enabled.Clients))
+	pm.Offset = offset - min(offset, enabled.Total)
+	disabled, err := svc.clients.RetrieveAll(ctx, pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+
+	return mgclients.ClientsPage{
+		Page: mgclients.Page{
+			Total:  enabled.Total + disabled.Total,
+			Offset: offset,
+			Limit:  limit,
+		},
+		Clients: append(enabled.Clients, disabled.Clients...),
+	}, nil
+}
+
+// memberIDs returns the IDs of the group members, checking that they
+// are existing users.
+func (svc service) memberIDs(ctx context.Context, members []Member) ([]string, error) {
+	var ids []string
+	for _, m := range members {
+		if slices.Contains(ids, m.Value) {
+			continue
+		}
+		if _, err := svc.retrieveClient(ctx, m.Value); err != nil {
+			return nil, errors.Wrap(ErrInvalidValue, fmt.Errorf("invalid member %q", m.Value))
+		}
+		ids = append(ids, m.Value)
+	}
+
+	return ids, nil
+}
+
+func (svc service) groupMembers(ctx context.Context, token, id string) ([]string, error) {
+	page, err := svc.groups.ListMembers(ctx, token, id, auth.MemberRelation, auth.UsersKind)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, m := range page.Members {
+		// Group members are the domain users.
+		_, userID := auth.DecodeDomainUserID(m.ID)
+		if userID == "" {
+			userID = m.ID
+		}
+		ids = append(ids, userID)
+	}
+
+	return ids, nil
+}
+
+func usersPage(q Query, client mgclients.Client, err error) (UsersPage, error) {
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return UsersPage{StartIndex: q.StartIndex}, nil
+	case err != nil:
+		return UsersPage{}, err
+	}
+	page := UsersPage{
+		Total:      1,
+		StartIndex: q.StartIndex,
+	}
+	if q.StartIndex == 1 && q.Count > 0 {
+		page.Users = []User{toUser(client)}
+	}
+
+	return page, nil
+}
+
+func patchUser(u *User, op PatchOp) error {
+	switch strings.ToLower(op.Op) {
+	case AddOp, ReplaceOp:
+		if op.Path != "" {
+			return setUserAttribute(u, op.Path, op.Value)
+		}
+		// Operation without the path contains the attributes to set,
+		// so the attributes which aren't stored are ignored.
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return errors.Wrap(ErrInvalidValue, err)
+		}
+		for path, value := range attrs {
+			if err := setUserAttribute(u, path, value); err != nil && !errors.Contains(err, ErrInvalidPath) {
+				return err
+			}
+		}
+		return nil
+	case RemoveOp:
+		return removeUserAttribute(u, op.Path)
+	default:
+		return errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("unsupported patch operation %q", op.Op))
+	}
+}
+
+func setUserAttribute(u *User, path string, value json.RawMessage) error {
+	switch attribute(path) {
+	case "username":
+		return unmarshalValue(value, &u.UserName)
+	case "displayname":
+		return unmarshalValue(value, &u.DisplayName)
+	case "externalid":
+		return unmarshalValue(value, &u.ExternalID)
+	case "active":
+		active, err := boolValue(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+		return nil
+	case "name":
+		// Name is merged with the present name components.
+		n := Name{}
+		if u.Name != nil {
+			n = *u.Name
+		}
+		if err := unmarshalValue(value, &n); err != nil {
+			return err
+		}
+		u.Name = &n
+		return nil
+	case "name.givenname":
+		return unmarshalValue(value, &userName(u).GivenName)
+	case "name.familyname":
+		return unmarshalValue(value, &userName(u).FamilyName)
+	case "name.formatted":
+		return unmarshalValue(value, &userName(u).Formatted)
+	default:
+		return errors.Wrap(ErrInvalidPath, fmt.Errorf("unsupported path %q", path))
+	}
+}
+
+func removeUserAttribute(u *User, path string) error {
+	switch attribute(path) {
+	case "displayname":
+		u.DisplayName = ""
+	case "externalid":
+		u.ExternalID = ""
+	case "name":
+		u.Name = nil
+	case "name.givenname":
+		userName(u).GivenName = ""
+	case "name.familyname":
+		userName(u).FamilyName = ""
+	case "name.formatted":
+		userName(u).Formatted = ""
+	default:
+		return errors.Wrap(ErrInvalidPath, fmt.Errorf("unsupported path %q", path))
+	}
+
+	return nil
+}
+
+func patchGroup(g *Group, op PatchOp) error {
+	switch strings.ToLower(op.Op) {
+	case AddOp, ReplaceOp:
+		if op.Path != "" {
+			return setGroupAttribute(g, op.Op, op.Path, op.Value)
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return errors.Wrap(ErrInvalidValue, err)
+		}
+		for path, value := range attrs {
+			if err := setGroupAttribute(g, op.Op, path, value); err != nil && !errors.Contains(err, ErrInvalidPath) {
+				return err
+			}
+		}
+		return nil
+	case RemoveOp:
+		return removeGroupAttribute(g, op.Path, op.Value)
+	default:
+		return errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("unsupported patch operation %q", op.Op))
+	}
+}
+
+func setGroupAttribute(g *Group, op, path string, value json.RawMessage) error {
+	switch attribute(path) {
+	case "displayname":
+		return unmarshalValue(value, &g.DisplayName)
+	case "externalid":
+		return unmarshalValue(value, &g.ExternalID)
+	case "members":
+		var members []Member
+		if err := unmarshalValue(value, &members); err != nil {
+			return err
+		}
+		if strings.ToLower(op) == ReplaceOp {
+			g.Members = nil
+		}
+		g.Members = append(g.Members, members...)
+		return nil
+	default:
+		return errors.Wrap(ErrInvalidPath, fmt.Errorf("unsupported path %q", path))
+	}
+}
+
+func removeGroupAttribute(g *Group, path string, value json.RawMessage) error {
+	attr := attribute(path)
+	switch {
+	case attr == "externalid":
+		g.ExternalID = ""
+	case attr == "members" && len(value) == 0:
+		g.Members = nil
+	case attr == "members":
+		var members []Member
+		if err := unmarshalValue(value, &members); err != nil {
+			return err
+		}
+		for _, m := range members {
+			g.Members = removeMember(g.Members, m.Value)
+		}
+	case strings.HasPrefix(attr, "members[") && strings.HasSuffix(attr, "]"):
+		// Value filter selects the member, e.g. `members[value eq "id"]`.
+		f, err := ParseFilter(path[strings.Index(path, "[")+1 : strings.LastIndex(path, "]")])
+		if err != nil || f.Attribute != "value" {
+			return errors.Wrap(ErrInvalidPath, fmt.Errorf("unsupported path %q", path))
+		}
+		g.Members = removeMember(g.Members, f.Value)
+	default:
+		return errors.Wrap(ErrInvalidPath, fmt.Errorf("unsupported path %q", path))
+	}
+
+	return nil
+}
+
+func removeMember(members []Member, id string) []Member {
+	return slices.DeleteFunc(members, func(m Member) bool {
+		return m.Value == id
+	})
+}
+
+func toUser(c mgclients.Client) User {
+	active := c.Status == mgclients.EnabledStatus
+	u := User{
+		Schemas:     []string{UserSchema},
+		ID:          c.ID,
+		UserName:    c.Credentials.Identity,
+		DisplayName: c.Name,
+		Emails:      []Email{{Value: c.Credentials.Identity, Primary: true}},
+		Active:      &active,
+		Meta:        meta(UserResourceType, usersLocation, c.ID, c.CreatedAt, c.UpdatedAt),
+	}
+	u.ExternalID, _ = c.Metadata[externalIDKey].(string)
+	if n, ok := c.Metadata[nameKey].(map[string]interface{}); ok {
+		u.Name = &Name{}
+		u.Name.Formatted, _ = n["formatted"].(string)
+		u.Name.GivenName, _ = n["givenName"].(string)
+		u.Name.FamilyName, _ = n["familyName"].(string)
+	}
+
+	return u
+}
+
+func toGroup(g groups.Group, memberIDs []string) Group {
+	group := Group{
+		Schemas:     []string{GroupSchema},
+		ID:          g.ID,
+		DisplayName: g.Name,
+		Meta:        meta(GroupResourceType, groupsLocation, g.ID, g.CreatedAt, g.UpdatedAt),
+	}
+	group.ExternalID, _ = g.Metadata[externalIDKey].(string)
+	for _, id := range memberIDs {
+		group.Members = append(group.Members, Member{
+			Value: id,
+			Ref:   usersLocation + id,
+		})
+	}
+
+	return group
+}
+
+func meta(resourceType, location, id string, created, modified time.Time) *Meta {
+	if modified.IsZero() {
+		modified = created
+	}
+
+	return &Meta{
+		ResourceType: resourceType,
+		Created:      created,
+		LastModified: modified,
+		Location:     location + id,
+	}
+}
+
+// userMetadata returns the client metadata with the user attributes
+// which are stored in the metadata.
+func userMetadata(m mgclients.Metadata, u User) mgclients.Metadata {
+	metadata := mgclients.Metadata{}
+	for k, v := range m {
+		metadata[k] = v
+	}
+	delete(metadata, externalIDKey)
+	delete(metadata, nameKey)
+	if u.ExternalID != "" {
+		metadata[externalIDKey] = u.ExternalID
+	}
+	if u.Name != nil {
+		n := map[string]interface{}{}
+		for k, v := range map[string]string{"formatted": u.Name.Formatted, "givenName": u.Name.GivenName, "familyName": u.Name.FamilyName} {
+			if v != "" {
+				n[k] = v
+			}
+		}
+		if len(n) > 0 {
+			metadata[nameKey] = n
+		}
+	}
+
+	return metadata
+}
+
+// groupMetadata returns the group metadata with the external ID.
+func groupMetadata(m mgclients.Metadata, g Group) mgclients.Metadata {
+	metadata := mgclients.Metadata{}
+	for k, v := range m {
+		metadata[k] = v
+	}
+	delete(metadata, externalIDKey)
+	if g.ExternalID != "" {
+		metadata[externalIDKey] = g.ExternalID
+	}
+
+	return metadata
+}
+
+// displayName returns the client name of the user.
+func displayName(u User) string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if n := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); n != "" {
+			return n
+		}
+	}
+
+	return u.UserName
+}
+
+func userName(u *User) *Name {
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+
+	return u.Name
+}
+
+func attribute(path string) string {
+	path = strings.ToLower(path)
+	for _, schema := range []string{UserSchema, GroupSchema} {
+		path = strings.TrimPrefix(path, strings.ToLower(schema)+":")
+	}
+
+	return path
+}
+
+func unmarshalValue(value json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(value, v); err != nil {
+		return errors.Wrap(ErrInvalidValue, err)
+	}
+
+	return nil
+}
+
+// boolValue returns the boolean value, which some identity providers
+// send as a string.
+func boolValue(value json.RawMessage) (bool, error) {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return false, errors.Wrap(ErrInvalidValue, err)
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+
+	return false, errors.Wrap(ErrInvalidValue, fmt.Errorf("invalid boolean %s", value))
+}
+
+func difference(a, b []string) []string {
+	var diff []string
+	for _, id := range a {
+		if !slices.Contains(b, id) {
+			diff = append(diff, id)
+		}
+	}
+
+	return diff
+}
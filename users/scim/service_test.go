@@ -0,0 +1,678 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package scim_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/absmach/magistrala/users/mocks"
+	"github.com/absmach/magistrala/users/scim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken = "token"
+	domainID   = testsutil.GenerateUUID(&testing.T{})
+	userID     = testsutil.GenerateUUID(&testing.T{})
+	groupID    = testsutil.GenerateUUID(&testing.T{})
+	client     = mgclients.Client{
+		ID:          userID,
+		Name:        "John Doe",
+		Credentials: mgclients.Credentials{Identity: "john@example.com"},
+		Metadata:    mgclients.Metadata{"scim_external_id": "00u1"},
+		Status:      mgclients.EnabledStatus,
+		CreatedAt:   time.Now(),
+	}
+	group = groups.Group{
+		ID:        groupID,
+		Domain:    domainID,
+		Name:      "engineering",
+		Metadata:  mgclients.Metadata{"scim_external_id": "00g1"},
+		Status:    mgclients.EnabledStatus,
+		CreatedAt: time.Now(),
+	}
+)
+
+type testService struct {
+	svc     scim.Service
+	users   *mocks.Service
+	clients *mocks.Repository
+	groups  *gmocks.Service
+	auth    *authmocks.AuthServiceClient
+}
+
+// newService returns the SCIM service authorizing the valid token.
+func newService() testService {
+	ts := testService{
+		users:   new(mocks.Service),
+		clients: new(mocks.Repository),
+		groups:  new(gmocks.Service),
+		auth:    new(authmocks.AuthServiceClient),
+	}
+	ts.auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	ts.auth.On("Identify", mock.Anything, mock.Anything).Return(&magistrala.IdentityRes{}, svcerr.ErrAuthentication)
+	ts.auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	ts.svc = scim.NewService(ts.users, ts.clients, ts.groups, ts.auth)
+
+	return ts
+}
+
+func value(v interface{}) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+func TestCreateUser(t *testing.T) {
+	active := false
+	cases := []struct {
+		desc      string
+		token     string
+		user      scim.User
+		authRes   *magistrala.AuthorizeRes
+		client    mgclients.Client
+		createErr error
+		err       error
+	}{
+		{
+			desc:  "create user",
+			token: validToken,
+			user: scim.User{
+				UserName:   "john@example.com",
+				ExternalID: "00u1",
+				Name:       &scim.Name{GivenName: "John", FamilyName: "Doe"},
+			},
+			client: mgclients.Client{
+				Name:        "John Doe",
+				Credentials: mgclients.Credentials{Identity: "john@example.com"},
+				Metadata: mgclients.Metadata{
+					"scim_external_id": "00u1",
+					"scim_name":        map[string]interface{}{"givenName": "John", "familyName": "Doe"},
+				},
+				Role:   mgclients.UserRole,
+				Status: mgclients.EnabledStatus,
+			},
+			err: nil,
+		},
+		{
+			desc:  "create inactive user",
+			token: validToken,
+			user: scim.User{
+				UserName:    "john@example.com",
+				DisplayName: "John",
+				Active:      &active,
+			},
+			client: mgclients.Client{
+				Name:        "John",
+				Credentials: mgclients.Credentials{Identity: "john@example.com"},
+				Metadata:    mgclients.Metadata{},
+				Role:        mgclients.UserRole,
+				Status:      mgclients.DisabledStatus,
+			},
+			err: nil,
+		},
+		{
+			desc:  "create user with invalid token",
+			token: "invalid",
+			user:  scim.User{UserName: "john@example.com"},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "create user without platform administration",
+			token:   validToken,
+			user:    scim.User{UserName: "john@example.com"},
+			authRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "create user without user name",
+			token: validToken,
+			user:  scim.User{DisplayName: "John"},
+			err:   scim.ErrInvalidValue,
+		},
+		{
+			desc:  "create existing user",
+			token: validToken,
+			user:  scim.User{UserName: "john@example.com"},
+			client: mgclients.Client{
+				Name:        "john@example.com",
+				Credentials: mgclients.Credentials{Identity: "john@example.com"},
+				Metadata:    mgclients.Metadata{},
+				Role:        mgclients.UserRole,
+				Status:      mgclients.EnabledStatus,
+			},
+			createErr: errors.Wrap(svcerr.ErrCreateEntity, repoerr.ErrConflict),
+			err:       svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		if tc.authRes != nil {
+			ts.auth = new(authmocks.AuthServiceClient)
+			ts.auth.On("Identify", mock.Anything, mock.Anything).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+			ts.auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authRes, nil)
+			ts.svc = scim.NewService(ts.users, ts.clients, ts.groups, ts.auth)
+		}
+		created := tc.client
+		created.ID = userID
+		ts.users.On("RegisterClient", mock.Anything, tc.token, tc.client).Return(created, tc.createErr)
+		user, err := ts.svc.CreateUser(context.Background(), tc.token, tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, userID, user.ID, fmt.Sprintf("%s: expected user ID %s got %s\n", tc.desc, userID, user.ID))
+			assert.Equal(t, tc.user.UserName, user.UserName, fmt.Sprintf("%s: expected user name %s got %s\n", tc.desc, tc.user.UserName, user.UserName))
+			assert.Equal(t, tc.user.ExternalID, user.ExternalID, fmt.Sprintf("%s: expected external ID %s got %s\n", tc.desc, tc.user.ExternalID, user.ExternalID))
+			assert.Equal(t, tc.client.Status == mgclients.EnabledStatus, *user.Active, fmt.Sprintf("%s: unexpected active attribute\n", tc.desc))
+			ts.users.AssertCalled(t, "RegisterClient", mock.Anything, tc.token, tc.client)
+		}
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	disabled := client
+	disabled.ID = testsutil.GenerateUUID(t)
+	disabled.Status = mgclients.DisabledStatus
+
+	cases := []struct {
+		desc        string
+		query       scim.Query
+		enabled     mgclients.ClientsPage
+		disabled    mgclients.ClientsPage
+		identityRes mgclients.Client
+		identityErr error
+		total       uint64
+		ids         []string
+		err         error
+	}{
+		{
+			desc:  "list users",
+			query: scim.Query{StartIndex: 1, Count: 10},
+			enabled: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1},
+				Clients: []mgclients.Client{client},
+			},
+			disabled: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1},
+				Clients: []mgclients.Client{disabled},
+			},
+			total: 2,
+			ids:   []string{client.ID, disabled.ID},
+			err:   nil,
+		},
+		{
+			desc:  "list users past enabled users",
+			query: scim.Query{StartIndex: 3, Count: 10},
+			enabled: mgclients.ClientsPage{
+				Page: mgclients.Page{Total: 2},
+			},
+			disabled: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 2},
+				Clients: []mgclients.Client{disabled},
+			},
+			total: 4,
+			ids:   []string{disabled.ID},
+			err:   nil,
+		},
+		{
+			desc:        "list users by user name",
+			query:       scim.Query{Filter: scim.Filter{Attribute: "username", Operator: scim.EqOperator, Value: client.Credentials.Identity}, StartIndex: 1, Count: 10},
+			identityRes: client,
+			total:       1,
+			ids:         []string{client.ID},
+			err:         nil,
+		},
+		{
+			desc:        "list users by unknown user name",
+			query:       scim.Query{Filter: scim.Filter{Attribute: "username", Operator: scim.EqOperator, Value: "unknown@example.com"}, StartIndex: 1, Count: 10},
+			identityErr: repoerr.ErrNotFound,
+			total:       0,
+			err:         nil,
+		},
+		{
+			desc:  "list users by external ID",
+			query: scim.Query{Filter: scim.Filter{Attribute: "externalid", Operator: scim.EqOperator, Value: "00u1"}, StartIndex: 1, Count: 10},
+			enabled: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1},
+				Clients: []mgclients.Client{client},
+			},
+			total: 1,
+			ids:   []string{client.ID},
+			err:   nil,
+		},
+		{
+			desc:  "list users by unsupported attribute",
+			query: scim.Query{Filter: scim.Filter{Attribute: "title", Operator: scim.EqOperator, Value: "CEO"}, StartIndex: 1, Count: 10},
+			err:   scim.ErrInvalidFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.clients.On("RetrieveAll", mock.Anything, mock.MatchedBy(func(pm mgclients.Page) bool {
+			return pm.Status == mgclients.EnabledStatus
+		})).Return(tc.enabled, nil)
+		ts.clients.On("RetrieveAll", mock.Anything, mock.MatchedBy(func(pm mgclients.Page) bool {
+			return pm.Status == mgclients.DisabledStatus
+		})).Return(tc.disabled, nil)
+		ts.clients.On("RetrieveByIdentity", mock.Anything, tc.query.Filter.Value).Return(tc.identityRes, tc.identityErr)
+		page, err := ts.svc.ListUsers(context.Background(), validToken, tc.query)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			var ids []string
+			for _, u := range page.Users {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected users %v got %v\n", tc.desc, tc.ids, ids))
+		}
+		if tc.desc == "list users past enabled users" {
+			ts.clients.AssertCalled(t, "RetrieveAll", mock.Anything, mock.MatchedBy(func(pm mgclients.Page) bool {
+				return pm.Status == mgclients.DisabledStatus && pm.Offset == 0 && pm.Limit == 10
+			}))
+		}
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	deleted := client
+	deleted.Status = mgclients.DeletedStatus
+
+	cases := []struct {
+		desc        string
+		client      mgclients.Client
+		retrieveErr error
+		ops         []scim.PatchOp
+		disable     bool
+		update      *mgclients.Client
+		identity    string
+		err         error
+	}{
+		{
+			desc:    "deactivate user",
+			client:  client,
+			ops:     []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: value(false)}},
+			disable: true,
+			err:     nil,
+		},
+		{
+			desc:    "deactivate user with attributes value",
+			client:  client,
+			ops:     []scim.PatchOp{{Op: "Replace", Value: value(map[string]interface{}{"active": false, "phoneNumbers": []string{}})}},
+			disable: true,
+			err:     nil,
+		},
+		{
+			desc:    "deactivate user with string value",
+			client:  client,
+			ops:     []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: value("False")}},
+			disable: true,
+			err:     nil,
+		},
+		{
+			desc:   "replace given name",
+			client: client,
+			ops:    []scim.PatchOp{{Op: scim.AddOp, Path: "name.givenName", Value: value("Johnny")}},
+			update: &mgclients.Client{
+				ID:   userID,
+				Name: "John Doe",
+				Metadata: mgclients.Metadata{
+					"scim_external_id": "00u1",
+					"scim_name":        map[string]interface{}{"givenName": "Johnny"},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "replace user name",
+			client:   client,
+			ops:      []scim.PatchOp{{Op: scim.ReplaceOp, Path: "userName", Value: value("johnny@example.com")}},
+			identity: "johnny@example.com",
+			err:      nil,
+		},
+		{
+			desc:   "remove external ID",
+			client: client,
+			ops:    []scim.PatchOp{{Op: scim.RemoveOp, Path: "externalId"}},
+			update: &mgclients.Client{
+				ID:       userID,
+				Name:     "John Doe",
+				Metadata: mgclients.Metadata{},
+			},
+			err: nil,
+		},
+		{
+			desc:   "patch unsupported path",
+			client: client,
+			ops:    []scim.PatchOp{{Op: scim.ReplaceOp, Path: "title", Value: value("CEO")}},
+			err:    scim.ErrInvalidPath,
+		},
+		{
+			desc:   "patch with invalid value",
+			client: client,
+			ops:    []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: value("maybe")}},
+			err:    scim.ErrInvalidValue,
+		},
+		{
+			desc:   "patch with unsupported operation",
+			client: client,
+			ops:    []scim.PatchOp{{Op: "move", Path: "active", Value: value(false)}},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "patch deleted user",
+			client: deleted,
+			ops:    []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: value(false)}},
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:        "patch non-existing user",
+			retrieveErr: repoerr.ErrNotFound,
+			ops:         []scim.PatchOp{{Op: scim.ReplaceOp, Path: "active", Value: value(false)}},
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.clients.On("RetrieveByID", mock.Anything, userID).Return(tc.client, tc.retrieveErr)
+		ts.users.On("DisableClient", mock.Anything, validToken, userID).Return(mgclients.Client{}, nil)
+		ts.users.On("UpdateClient", mock.Anything, validToken, mock.Anything).Return(mgclients.Client{}, nil)
+		ts.users.On("UpdateClientIdentity", mock.Anything, validToken, userID, tc.identity).Return(mgclients.Client{}, nil)
+		_, err := ts.svc.PatchUser(context.Background(), validToken, userID, tc.ops)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch tc.disable {
+		case true:
+			ts.users.AssertCalled(t, "DisableClient", mock.Anything, validToken, userID)
+		default:
+			ts.users.AssertNotCalled(t, "DisableClient", mock.Anything, validToken, userID)
+		}
+		switch tc.update {
+		case nil:
+			ts.users.AssertNotCalled(t, "UpdateClient", mock.Anything, validToken, mock.Anything)
+		default:
+			ts.users.AssertCalled(t, "UpdateClient", mock.Anything, validToken, *tc.update)
+		}
+		if tc.identity != "" {
+			ts.users.AssertCalled(t, "UpdateClientIdentity", mock.Anything, validToken, userID, tc.identity)
+		}
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	cases := []struct {
+		desc        string
+		token       string
+		retrieveErr error
+		deleteErr   error
+		err         error
+	}{
+		{
+			desc:  "delete user",
+			token: validToken,
+			err:   nil,
+		},
+		{
+			desc:  "delete user with invalid token",
+			token: "invalid",
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "delete non-existing user",
+			token:       validToken,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:      "delete user with failed delete",
+			token:     validToken,
+			deleteErr: svcerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.clients.On("RetrieveByID", mock.Anything, userID).Return(client, tc.retrieveErr)
+		ts.users.On("DeleteClient", mock.Anything, tc.token, userID).Return(tc.deleteErr)
+		err := ts.svc.DeleteUser(context.Background(), tc.token, userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCreateGroup(t *testing.T) {
+	cases := []struct {
+		desc        string
+		group       scim.Group
+		retrieveErr error
+		assign      []string
+		err         error
+	}{
+		{
+			desc:   "create group with members",
+			group:  scim.Group{DisplayName: "engineering", ExternalID: "00g1", Members: []scim.Member{{Value: userID}, {Value: userID}}},
+			assign: []string{userID},
+			err:    nil,
+		},
+		{
+			desc:  "create group without members",
+			group: scim.Group{DisplayName: "engineering", ExternalID: "00g1"},
+			err:   nil,
+		},
+		{
+			desc:  "create group without display name",
+			group: scim.Group{ExternalID: "00g1"},
+			err:   scim.ErrInvalidValue,
+		},
+		{
+			desc:        "create group with unknown member",
+			group:       scim.Group{DisplayName: "engineering", Members: []scim.Member{{Value: userID}}},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         scim.ErrInvalidValue,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.clients.On("RetrieveByID", mock.Anything, userID).Return(client, tc.retrieveErr)
+		ts.groups.On("CreateGroup", mock.Anything, validToken, auth.NewGroupKind, mock.Anything).Return(group, nil)
+		ts.groups.On("Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.assign).Return(nil)
+		g, err := ts.svc.CreateGroup(context.Background(), validToken, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, groupID, g.ID, fmt.Sprintf("%s: expected group ID %s got %s\n", tc.desc, groupID, g.ID))
+		assert.Equal(t, "00g1", g.ExternalID, fmt.Sprintf("%s: expected external ID %s got %s\n", tc.desc, "00g1", g.ExternalID))
+		ts.groups.AssertCalled(t, "CreateGroup", mock.Anything, validToken, auth.NewGroupKind, groups.Group{
+			Name:     "engineering",
+			Metadata: mgclients.Metadata{"scim_external_id": "00g1"},
+			Status:   mgclients.EnabledStatus,
+		})
+		switch len(tc.assign) {
+		case 0:
+			ts.groups.AssertNotCalled(t, "Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, mock.Anything)
+		default:
+			ts.groups.AssertCalled(t, "Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.assign)
+		}
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	other := group
+	other.ID = testsutil.GenerateUUID(t)
+	other.Name = "engineering-ops"
+
+	cases := []struct {
+		desc    string
+		query   scim.Query
+		page    groups.Page
+		members groups.MembersPage
+		total   uint64
+		ids     []string
+		err     error
+	}{
+		{
+			desc:  "list groups",
+			query: scim.Query{StartIndex: 1, Count: 10},
+			page: groups.Page{
+				PageMeta: groups.PageMeta{Total: 2},
+				Groups:   []groups.Group{group, other},
+			},
+			members: groups.MembersPage{Members: []groups.Member{{ID: auth.EncodeDomainUserID(domainID, userID), Type: auth.UserType}}},
+			total:   2,
+			ids:     []string{group.ID, other.ID},
+			err:     nil,
+		},
+		{
+			desc:  "list groups by display name",
+			query: scim.Query{Filter: scim.Filter{Attribute: "displayname", Operator: scim.EqOperator, Value: "engineering"}, StartIndex: 1, Count: 10},
+			page: groups.Page{
+				PageMeta: groups.PageMeta{Total: 2},
+				Groups:   []groups.Group{group, other},
+			},
+			total: 1,
+			ids:   []string{group.ID},
+			err:   nil,
+		},
+		{
+			desc:  "list groups by display name past the matches",
+			query: scim.Query{Filter: scim.Filter{Attribute: "displayname", Operator: scim.EqOperator, Value: "engineering"}, StartIndex: 2, Count: 10},
+			page: groups.Page{
+				PageMeta: groups.PageMeta{Total: 2},
+				Groups:   []groups.Group{group, other},
+			},
+			total: 1,
+			err:   nil,
+		},
+		{
+			desc:  "list groups by unsupported attribute",
+			query: scim.Query{Filter: scim.Filter{Attribute: "members", Operator: scim.EqOperator, Value: userID}, StartIndex: 1, Count: 10},
+			err:   scim.ErrInvalidFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.groups.On("ListGroups", mock.Anything, validToken, auth.UsersKind, "", mock.Anything).Return(tc.page, nil)
+		ts.groups.On("ListMembers", mock.Anything, validToken, mock.Anything, auth.MemberRelation, auth.UsersKind).Return(tc.members, nil)
+		page, err := ts.svc.ListGroups(context.Background(), validToken, tc.query)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		var ids []string
+		for _, g := range page.Groups {
+			ids = append(ids, g.ID)
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected groups %v got %v\n", tc.desc, tc.ids, ids))
+		if len(tc.members.Members) > 0 {
+			assert.Equal(t, userID, page.Groups[0].Members[0].Value, fmt.Sprintf("%s: expected member %s got %s\n", tc.desc, userID, page.Groups[0].Members[0].Value))
+		}
+	}
+}
+
+func TestPatchGroup(t *testing.T) {
+	memberID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		ops      []scim.PatchOp
+		update   bool
+		assign   []string
+		unassign []string
+		err      error
+	}{
+		{
+			desc:   "add member",
+			ops:    []scim.PatchOp{{Op: scim.AddOp, Path: "members", Value: value([]scim.Member{{Value: memberID}})}},
+			assign: []string{memberID},
+			err:    nil,
+		},
+		{
+			desc:     "remove member with value filter",
+			ops:      []scim.PatchOp{{Op: "Remove", Path: fmt.Sprintf(`members[value eq "%s"]`, userID)}},
+			unassign: []string{userID},
+			err:      nil,
+		},
+		{
+			desc:     "remove member with value",
+			ops:      []scim.PatchOp{{Op: scim.RemoveOp, Path: "members", Value: value([]scim.Member{{Value: userID}})}},
+			unassign: []string{userID},
+			err:      nil,
+		},
+		{
+			desc:     "replace members",
+			ops:      []scim.PatchOp{{Op: scim.ReplaceOp, Path: "members", Value: value([]scim.Member{{Value: memberID}})}},
+			assign:   []string{memberID},
+			unassign: []string{userID},
+			err:      nil,
+		},
+		{
+			desc:   "replace display name",
+			ops:    []scim.PatchOp{{Op: scim.ReplaceOp, Value: value(map[string]string{"id": groupID, "displayName": "platform"})}},
+			update: true,
+			err:    nil,
+		},
+		{
+			desc: "remove member with invalid filter",
+			ops:  []scim.PatchOp{{Op: scim.RemoveOp, Path: `members[display eq "John"]`}},
+			err:  scim.ErrInvalidPath,
+		},
+		{
+			desc: "remove display name",
+			ops:  []scim.PatchOp{{Op: scim.RemoveOp, Path: "displayName"}},
+			err:  scim.ErrInvalidPath,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newService()
+		ts.groups.On("ViewGroup", mock.Anything, validToken, groupID).Return(group, nil)
+		ts.groups.On("ListMembers", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind).Return(groups.MembersPage{Members: []groups.Member{{ID: auth.EncodeDomainUserID(domainID, userID), Type: auth.UserType}}}, nil)
+		ts.clients.On("RetrieveByID", mock.Anything, mock.Anything).Return(client, nil)
+		ts.groups.On("UpdateGroup", mock.Anything, validToken, mock.Anything).Return(group, nil)
+		ts.groups.On("Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.assign).Return(nil)
+		ts.groups.On("Unassign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.unassign).Return(nil)
+		_, err := ts.svc.PatchGroup(context.Background(), validToken, groupID, tc.ops)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch tc.update {
+		case true:
+			ts.groups.AssertCalled(t, "UpdateGroup", mock.Anything, validToken, groups.Group{
+				ID:       groupID,
+				Name:     "platform",
+				Metadata: mgclients.Metadata{"scim_external_id": "00g1"},
+			})
+		default:
+			ts.groups.AssertNotCalled(t, "UpdateGroup", mock.Anything, validToken, mock.Anything)
+		}
+		switch len(tc.assign) {
+		case 0:
+			ts.groups.AssertNotCalled(t, "Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, mock.Anything)
+		default:
+			ts.groups.AssertCalled(t, "Assign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.assign)
+		}
+		switch len(tc.unassign) {
+		case 0:
+			ts.groups.AssertNotCalled(t, "Unassign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, mock.Anything)
+		default:
+			ts.groups.AssertCalled(t, "Unassign", mock.Anything, validToken, groupID, auth.MemberRelation, auth.UsersKind, tc.unassign)
+		}
+	}
+}
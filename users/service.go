@@ -10,61 +10,157 @@ import (
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
 	grpcclient "github.com/absmach/magistrala/auth/api/grpc"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/oauth2"
+	"github.com/absmach/magistrala/users/lockout"
+	"github.com/absmach/magistrala/users/mfa"
 	"github.com/absmach/magistrala/users/postgres"
+	"github.com/absmach/magistrala/users/verification"
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	mfaIssuer            = "Magistrala"
+	mfaChallengeDuration = 5 * time.Minute
+	mfaChallengeAttempts = 3
+	recoveryCodesCount   = 10
+	oauthProviderKey     = "oauth_provider"
+	oauthGroupsKey       = "oauth_groups"
+	deleteBatch          = 100
+	verificationDuration = 24 * time.Hour
+	// verificationResendInterval limits how often the verification
+	// is resent to the same email.
+	verificationResendInterval = time.Minute
+)
+
 var (
 	errIssueToken            = errors.New("failed to issue token")
 	errFailedPermissionsList = errors.New("failed to list permissions")
 	errRecoveryToken         = errors.New("failed to generate password recovery token")
 	errLoginDisableUser      = errors.New("failed to login in disabled user")
+	errRevokeTokens          = errors.New("failed to revoke user tokens")
+	errApplyClaims           = errors.New("failed to apply identity provider claims")
+	errMFAEnabled            = errors.New("multi-factor authentication is already enabled")
+	errMFADisabled           = errors.New("multi-factor authentication is not enabled")
+	errInvalidMFACode        = errors.New("invalid multi-factor authentication code")
+	errLoginDelayed          = errors.New("login is attempted too early after the failed attempt")
+	errLoginAttempts         = errors.New("failed to track login attempts")
+	errServiceAccountLogin   = errors.New("service account cannot log in with secret")
+	errIssueKey              = errors.New("failed to issue service account key")
+	errServiceAccountDelete  = errors.New("service account cannot delete itself")
+	errExportProfile         = errors.New("failed to export profile")
+	errEmailVerified         = errors.New("email is already verified")
+	errNotInvitation         = errors.New("token is not an invitation token")
+	errNotInvited            = errors.New("email has no pending invitation")
+	errSendVerification      = errors.New("failed to send email verification")
+	errVerificationResent    = errors.New("email verification is resent too early")
+	errOAuthAccountExists    = errors.New("user with the email is not linked to the identity provider account")
 )
 
 type service struct {
 	clients      postgres.Repository
+	mfa          mfa.Repository
+	verification verification.Repository
+	identities   oauth2.IdentityRepository
+	attempts     lockout.Repository
+	lockout      lockout.Config
 	idProvider   magistrala.IDProvider
 	auth         grpcclient.AuthServiceClient
 	policy       magistrala.PolicyServiceClient
 	hasher       Hasher
+	passwords    PasswordPolicy
 	email        Emailer
+	personalData PersonalData
+	invitations  Invitations
 	selfRegister bool
+	verifyEmail  bool
 }
 
 // NewService returns a new Users service implementation.
-func NewService(crepo postgres.Repository, authClient grpcclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, emailer Emailer, hasher Hasher, idp magistrala.IDProvider, selfRegister bool) Service {
+func NewService(crepo postgres.Repository, mfaRepo mfa.Repository, verificationRepo verification.Repository, identities oauth2.IdentityRepository, attempts lockout.Repository, lockoutCfg lockout.Config, authClient grpcclient.AuthServiceClient, policyClient magistrala.PolicyServiceClient, emailer Emailer, personalData PersonalData, invitations Invitations, hasher Hasher, passwords PasswordPolicy, idp magistrala.IDProvider, selfRegister, verifyEmail bool) Service {
 	return service{
 		clients:      crepo,
+		mfa:          mfaRepo,
+		verification: verificationRepo,
+		identities:   identities,
+		attempts:     attempts,
+		lockout:      lockoutCfg,
 		auth:         authClient,
 		policy:       policyClient,
 		hasher:       hasher,
+		passwords:    passwords,
 		email:        emailer,
+		personalData: personalData,
+		invitations:  invitations,
 		idProvider:   idp,
 		selfRegister: selfRegister,
+		verifyEmail:  verifyEmail,
 	}
 }
 
-func (svc service) RegisterClient(ctx context.Context, token string, cli mgclients.Client) (rc mgclients.Client, err error) {
-	if !svc.selfRegister {
-		userID, err := svc.Identify(ctx, token)
-		if err != nil {
-			return mgclients.Client{}, err
-		}
-		if err := svc.checkSuperAdmin(ctx, userID); err != nil {
-			return mgclients.Client{}, err
-		}
+func (svc service) RegisterClient(ctx context.Context, token string, cli mgclients.Client) (mgclients.Client, error) {
+	if err := svc.checkSelfRegister(ctx, token); err != nil {
+		return mgclients.Client{}, err
 	}
 
+	return svc.registerClient(ctx, cli, false)
+}
+
+func (svc service) RegisterInvitedClient(ctx context.Context, token string, cli mgclients.Client) (mgclients.Client, error) {
+	res, err := svc.identify(ctx, token)
+	if err != nil {
+		return mgclients.Client{}, err
+	}
+	// Only the invitation keys, issued to the domain administrators for
+	// the invitations they send, register the invited users.
+	if res.GetType() != uint32(auth.InvitationKey) || res.GetDomainId() == "" {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, errNotInvitation)
+	}
+	if _, err := svc.authorize(ctx, auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
+		return mgclients.Client{}, err
+	}
+	// The invitation key registers only the email of the pending invitation,
+	// so the domain administrators can't register the emails they don't own.
+	if err := svc.invitations.CheckPending(ctx, token, cli.Credentials.Identity, res.GetDomainId()); err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, errors.Wrap(errNotInvited, err))
+	}
+
+	// The invited user signs up with the code sent to the email,
+	// so the email is verified.
+	cli.Role = mgclients.UserRole
+	return svc.registerClient(ctx, cli, true)
+}
+
+// checkSelfRegister allows only the platform administrator
+// to register the clients if the self registration is disabled.
+func (svc service) checkSelfRegister(ctx context.Context, token string) error {
+	if svc.selfRegister {
+		return nil
+	}
+	userID, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return svc.checkSuperAdmin(ctx, token, userID)
+}
+
+// registerClient creates the client and sends the email verification,
+// unless the email is already verified.
+func (svc service) registerClient(ctx context.Context, cli mgclients.Client, verified bool) (rc mgclients.Client, err error) {
 	clientID, err := svc.idProvider.ID()
 	if err != nil {
 		return mgclients.Client{}, err
 	}
 
 	if cli.Credentials.Secret != "" {
+		if err := svc.passwords.Validate(cli.Credentials.Secret); err != nil {
+			return mgclients.Client{}, err
+		}
 		hash, err := svc.hasher.Hash(cli.Credentials.Secret)
 		if err != nil {
 			return mgclients.Client{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -80,6 +176,10 @@ func (svc service) RegisterClient(ctx context.Context, token string, cli mgclien
 	}
 	cli.ID = clientID
 	cli.CreatedAt = time.Now()
+	cli.VerifiedAt = time.Time{}
+	if verified {
+		cli.VerifiedAt = cli.CreatedAt
+	}
 
 	if err := svc.addClientPolicy(ctx, cli.ID, cli.Role); err != nil {
 		return mgclients.Client{}, err
@@ -95,24 +195,352 @@ func (svc service) RegisterClient(ctx context.Context, token string, cli mgclien
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
+	if !verified {
+		// The user can request the verification again, so the registration
+		// doesn't fail if the verification is not sent.
+		_ = svc.sendVerification(ctx, client)
+	}
 	return client, nil
 }
 
-func (svc service) IssueToken(ctx context.Context, identity, secret, domainID string) (*magistrala.Token, error) {
-	dbUser, err := svc.clients.RetrieveByIdentity(ctx, identity)
+// SendVerification authenticates with the credentials, since the client
+// who hasn't verified the email can't log in to get the token.
+func (svc service) SendVerification(ctx context.Context, identity, secret, sourceIP string) error {
+	if err := svc.checkLoginAttempts(ctx, identity, sourceIP); err != nil {
+		return err
+	}
+	client, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrLogin) || errors.Contains(err, repoerr.ErrNotFound) {
+			if errFail := svc.failLogin(ctx, identity, sourceIP); errFail != nil {
+				err = errors.Wrap(err, errFail)
+			}
+		}
+		return err
+	}
+	if !client.VerifiedAt.IsZero() {
+		return errors.Wrap(svcerr.ErrConflict, errEmailVerified)
+	}
+
+	return svc.sendVerification(ctx, client)
+}
+
+func (svc service) VerifyEmail(ctx context.Context, verificationToken string) (mgclients.Client, error) {
+	v, err := svc.verification.Retrieve(ctx, verificationToken)
 	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	client, err := svc.clients.RetrieveByID(ctx, v.UserID)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// The verification was sent to the previous email of the user.
+	if client.Credentials.Identity != v.Email {
+		return mgclients.Client{}, svcerr.ErrAuthentication
+	}
+
+	client.VerifiedAt = time.Now()
+	if err := svc.verification.Verify(ctx, client.ID, client.VerifiedAt); err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	client.Credentials.Secret = ""
+
+	return client, nil
+}
+
+// sendVerification replaces the pending verification of the client,
+// and sends the new verification token to the client email. The
+// verification is resent to the same email at most once per
+// verificationResendInterval.
+func (svc service) sendVerification(ctx context.Context, client mgclients.Client) error {
+	token, err := svc.idProvider.ID()
+	if err != nil {
+		return errors.Wrap(errSendVerification, err)
+	}
+	now := time.Now()
+	v := verification.Verification{
+		Token:     token,
+		UserID:    client.ID,
+		Email:     client.Credentials.Identity,
+		SentAt:    now,
+		ExpiresAt: now.Add(verificationDuration),
+	}
+	switch err := svc.verification.Save(ctx, v, now.Add(-verificationResendInterval)); {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return errors.Wrap(svcerr.ErrTooManyRequests, errVerificationResent)
+	case err != nil:
+		return errors.Wrap(errSendVerification, err)
+	}
+	if err := svc.email.SendVerification([]string{v.Email}, client.Name, v.Token); err != nil {
+		return errors.Wrap(errSendVerification, err)
+	}
+
+	return nil
+}
+
+func (svc service) IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (*magistrala.Token, error) {
+	if err := svc.checkLoginAttempts(ctx, identity, sourceIP); err != nil {
+		return &magistrala.Token{}, err
+	}
+	dbUser, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrLogin) || errors.Contains(err, repoerr.ErrNotFound) {
+			if errFail := svc.failLogin(ctx, identity, sourceIP); errFail != nil {
+				err = errors.Wrap(err, errFail)
+			}
+		}
+		return &magistrala.Token{}, err
+	}
+
+	// The user who hasn't verified the email requests
+	// the new verification with SendVerification.
+	if svc.verifyEmail && dbUser.VerifiedAt.IsZero() {
+		return &magistrala.Token{}, svcerr.ErrEmailNotVerified
+	}
+
+	// The failed logins are kept until the MFA code is verified, so
+	// that the second factor can't be guessed with the known secret.
+	m, err := svc.mfa.Retrieve(ctx, dbUser.ID)
+	switch {
+	case err == nil && m.Enabled:
+		return svc.issueMFAChallenge(ctx, dbUser.ID, domainID)
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	token, err := svc.issueToken(ctx, dbUser.ID, domainID, false)
+	if err != nil {
+		return &magistrala.Token{}, err
+	}
+	if err := svc.attempts.Remove(ctx, lockout.IdentityKey(identity)); err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, errors.Wrap(errLoginAttempts, err))
+	}
+
+	return token, nil
+}
+
+func (svc service) IssueMFAToken(ctx context.Context, mfaToken, code string) (*magistrala.Token, error) {
+	challenge, err := svc.mfa.RetrieveChallenge(ctx, mfaToken)
+	if err != nil {
+		return &magistrala.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	m, err := svc.mfa.Retrieve(ctx, challenge.UserID)
+	if err != nil {
+		return &magistrala.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	dbUser, err := svc.clients.RetrieveByID(ctx, challenge.UserID)
+	if err != nil {
+		return &magistrala.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	identity, sourceIP := dbUser.Credentials.Identity, apiutil.SourceIP(ctx)
+	if err := svc.checkLoginAttempts(ctx, identity, sourceIP); err != nil {
+		return &magistrala.Token{}, err
+	}
+
+	if err := svc.checkMFACode(ctx, m, code); err != nil {
+		// The challenge is removed after too many attempts, so the
+		// user has to log in with the secret again.
+		challenge.Attempts++
+		if challenge.Attempts >= mfaChallengeAttempts {
+			if errRemove := svc.mfa.RemoveChallenge(ctx, challenge.ID); errRemove != nil {
+				err = errors.Wrap(err, errRemove)
+			}
+		} else if errSave := svc.mfa.SaveChallenge(ctx, challenge); errSave != nil {
+			err = errors.Wrap(err, errSave)
+		}
+		if errFail := svc.failLogin(ctx, identity, sourceIP); errFail != nil {
+			err = errors.Wrap(err, errFail)
+		}
 		return &magistrala.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
+	if err := svc.mfa.RemoveChallenge(ctx, challenge.ID); err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	token, err := svc.issueToken(ctx, challenge.UserID, challenge.DomainID, true)
+	if err != nil {
+		return &magistrala.Token{}, err
+	}
+	if err := svc.attempts.Remove(ctx, lockout.IdentityKey(identity)); err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, errors.Wrap(errLoginAttempts, err))
+	}
+
+	return token, nil
+}
+
+func (svc service) EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error) {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return mfa.Enrollment{}, err
+	}
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return mfa.Enrollment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	m, err := svc.mfa.Retrieve(ctx, id)
+	switch {
+	case err == nil && m.Enabled:
+		return mfa.Enrollment{}, errors.Wrap(svcerr.ErrConflict, errMFAEnabled)
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return mfa.Enrollment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		return mfa.Enrollment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	now := time.Now()
+	m = mfa.MFA{
+		UserID:    id,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := svc.mfa.Save(ctx, m); err != nil {
+		return mfa.Enrollment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return mfa.Enrollment{
+		Secret: secret,
+		URI:    mfa.URI(mfaIssuer, client.Credentials.Identity, secret),
+	}, nil
+}
+
+func (svc service) VerifyMFA(ctx context.Context, token, code string) ([]string, error) {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	m, err := svc.mfa.Retrieve(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if m.Enabled {
+		return nil, errors.Wrap(svcerr.ErrConflict, errMFAEnabled)
+	}
+	step, ok := mfa.Validate(m.Secret, code, time.Now(), m.LastStep)
+	if !ok {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, errInvalidMFACode)
+	}
+
+	codes, err := mfa.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	m.RecoveryCodes = make([]string, len(codes))
+	for i, c := range codes {
+		if m.RecoveryCodes[i], err = svc.hasher.Hash(c); err != nil {
+			return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+	m.Enabled = true
+	m.LastStep = step
+	m.UpdatedAt = time.Now()
+	if err := svc.mfa.Save(ctx, m); err != nil {
+		return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return codes, nil
+}
+
+func (svc service) DisableMFA(ctx context.Context, token, code string) error {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	m, err := svc.mfa.Retrieve(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, errors.Wrap(errMFADisabled, err))
+	}
+	// The pending enrollment is cancelled without the code.
+	if m.Enabled {
+		if err := svc.checkMFACode(ctx, m, code); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+	if err := svc.mfa.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) authenticate(ctx context.Context, identity, secret string) (mgclients.Client, error) {
+	dbUser, err := svc.clients.RetrieveByIdentity(ctx, identity)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if dbUser.Role == mgclients.ServiceAccountRole {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrLogin, errServiceAccountLogin)
+	}
 	if err := svc.hasher.Compare(secret, dbUser.Credentials.Secret); err != nil {
-		return &magistrala.Token{}, errors.Wrap(svcerr.ErrLogin, err)
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrLogin, err)
+	}
+
+	return dbUser, nil
+}
+
+// checkLoginAttempts rejects the login of the locked out identity or source
+// IP, and the login of the identity attempted before the delay after its
+// last failed login expires.
+func (svc service) checkLoginAttempts(ctx context.Context, identity, sourceIP string) error {
+	now := time.Now()
+	a, err := svc.attempts.Retrieve(ctx, lockout.IdentityKey(identity))
+	if err != nil {
+		return errors.Wrap(errIssueToken, errors.Wrap(errLoginAttempts, err))
+	}
+	if a.Locked(now) {
+		return svcerr.ErrLoginLocked
+	}
+	if a.Delayed(svc.lockout, now) {
+		return errors.Wrap(svcerr.ErrLoginLocked, errLoginDelayed)
+	}
+	if sourceIP == "" {
+		return nil
+	}
+
+	a, err = svc.attempts.Retrieve(ctx, lockout.IPKey(sourceIP))
+	if err != nil {
+		return errors.Wrap(errIssueToken, errors.Wrap(errLoginAttempts, err))
+	}
+	if a.Locked(now) {
+		return svcerr.ErrLoginLocked
+	}
+
+	return nil
+}
+
+// failLogin records the failed login of the identity and the source IP, and
+// locks them out once the failed logins reach the limit.
+func (svc service) failLogin(ctx context.Context, identity, sourceIP string) error {
+	limits := map[string]uint64{lockout.IdentityKey(identity): svc.lockout.MaxAttempts}
+	if sourceIP != "" {
+		limits[lockout.IPKey(sourceIP)] = svc.lockout.MaxIPAttempts
+	}
+
+	for key, maxAttempts := range limits {
+		a, err := svc.attempts.Fail(ctx, key, svc.lockout.Window)
+		if err != nil {
+			return errors.Wrap(errLoginAttempts, err)
+		}
+		if maxAttempts == 0 || a.Failures < maxAttempts {
+			continue
+		}
+		if err := svc.attempts.Lock(ctx, key, time.Now().Add(svc.lockout.Duration)); err != nil {
+			return errors.Wrap(errLoginAttempts, err)
+		}
 	}
 
+	return nil
+}
+
+func (svc service) issueToken(ctx context.Context, userID, domainID string, mfa bool) (*magistrala.Token, error) {
 	var d string
 	if domainID != "" {
 		d = domainID
 	}
 
-	token, err := svc.auth.Issue(ctx, &magistrala.IssueReq{UserId: dbUser.ID, DomainId: &d, Type: uint32(auth.AccessKey)})
+	token, err := svc.auth.Issue(ctx, &magistrala.IssueReq{UserId: userID, DomainId: &d, Type: uint32(auth.AccessKey), Mfa: mfa})
 	if err != nil {
 		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
 	}
@@ -120,6 +548,52 @@ func (svc service) IssueToken(ctx context.Context, identity, secret, domainID st
 	return token, err
 }
 
+func (svc service) issueMFAChallenge(ctx context.Context, userID, domainID string) (*magistrala.Token, error) {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
+	}
+	challenge := mfa.Challenge{
+		ID:        id,
+		UserID:    userID,
+		DomainID:  domainID,
+		ExpiresAt: time.Now().Add(mfaChallengeDuration),
+	}
+	if err := svc.mfa.SaveChallenge(ctx, challenge); err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	return &magistrala.Token{MfaToken: &challenge.ID}, nil
+}
+
+// checkMFACode checks the TOTP code, which is accepted only once, or
+// the recovery code which is removed once it is used.
+func (svc service) checkMFACode(ctx context.Context, m mfa.MFA, code string) error {
+	if step, ok := mfa.Validate(m.Secret, code, time.Now(), m.LastStep); ok {
+		// Saving the step fails if the concurrent login has already used the code.
+		if err := svc.mfa.SaveLastStep(ctx, m.UserID, step); err != nil {
+			return errors.Wrap(errInvalidMFACode, err)
+		}
+		return nil
+	}
+	if len(code) == mfa.Digits {
+		return errInvalidMFACode
+	}
+	for i, hash := range m.RecoveryCodes {
+		if svc.hasher.Compare(code, hash) != nil {
+			continue
+		}
+		m.RecoveryCodes = append(m.RecoveryCodes[:i:i], m.RecoveryCodes[i+1:]...)
+		m.UpdatedAt = time.Now()
+		if err := svc.mfa.Save(ctx, m); err != nil {
+			return errors.Wrap(errInvalidMFACode, err)
+		}
+		return nil
+	}
+
+	return errInvalidMFACode
+}
+
 func (svc service) RefreshToken(ctx context.Context, refreshToken, domainID string) (*magistrala.Token, error) {
 	var d string
 	if domainID != "" {
@@ -154,7 +628,7 @@ func (svc service) ViewClient(ctx context.Context, token, id string) (mgclients.
 	}
 
 	if tokenUserID != id {
-		if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+		if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 			return mgclients.Client{Name: client.Name, ID: client.ID}, nil
 		}
 	}
@@ -164,6 +638,33 @@ func (svc service) ViewClient(ctx context.Context, token, id string) (mgclients.
 	return client, nil
 }
 
+func (svc service) ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (mgclients.Client, error) {
+	userID, err := svc.Identify(ctx, token)
+	if err != nil {
+		return mgclients.Client{}, err
+	}
+	// The platform administrators invite the users to any domain.
+	if err := svc.checkSuperAdmin(ctx, token, userID); err != nil {
+		if _, err := svc.authorize(ctx, auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, domainID); err != nil {
+			return mgclients.Client{}, err
+		}
+	}
+
+	client, err := svc.clients.RetrieveByIdentity(ctx, identity)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Service accounts are not invited to the domains.
+	if client.Role == mgclients.ServiceAccountRole {
+		return mgclients.Client{}, svcerr.ErrNotFound
+	}
+
+	return mgclients.Client{ID: client.ID, Name: client.Name}, nil
+}
+
 func (svc service) ViewProfile(ctx context.Context, token string) (mgclients.Client, error) {
 	id, err := svc.Identify(ctx, token)
 	if err != nil {
@@ -183,7 +684,7 @@ func (svc service) ListClients(ctx context.Context, token string, pm mgclients.P
 	if err != nil {
 		return mgclients.ClientsPage{}, err
 	}
-	if err := svc.checkSuperAdmin(ctx, userID); err != nil {
+	if err := svc.checkSuperAdmin(ctx, token, userID); err != nil {
 		return mgclients.ClientsPage{}, err
 	}
 
@@ -224,7 +725,7 @@ func (svc service) UpdateClient(ctx context.Context, token string, cli mgclients
 	}
 
 	if tokenUserID != cli.ID {
-		if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+		if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 			return mgclients.Client{}, err
 		}
 	}
@@ -251,7 +752,7 @@ func (svc service) UpdateClientTags(ctx context.Context, token string, cli mgcli
 	}
 
 	if tokenUserID != cli.ID {
-		if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+		if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 			return mgclients.Client{}, err
 		}
 	}
@@ -277,7 +778,7 @@ func (svc service) UpdateClientIdentity(ctx context.Context, token, clientID, id
 	}
 
 	if tokenUserID != clientID {
-		if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+		if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 			return mgclients.Client{}, err
 		}
 	}
@@ -294,6 +795,9 @@ func (svc service) UpdateClientIdentity(ctx context.Context, token, clientID, id
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	// The new identity is not verified, and the user can request
+	// the verification again if it is not sent.
+	_ = svc.sendVerification(ctx, cli)
 	return cli, nil
 }
 
@@ -323,6 +827,9 @@ func (svc service) ResetSecret(ctx context.Context, resetToken, secret string) e
 	if err != nil {
 		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if err := svc.passwords.Validate(secret); err != nil {
+		return err
+	}
 
 	secret, err = svc.hasher.Hash(secret)
 	if err != nil {
@@ -340,6 +847,14 @@ func (svc service) ResetSecret(ctx context.Context, resetToken, secret string) e
 	if _, err := svc.clients.UpdateSecret(ctx, c); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	// Log out the user everywhere, including the reset token.
+	if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: c.ID}); err != nil {
+		return errors.Wrap(errRevokeTokens, err)
+	}
+	// The user who resets the secret is not locked out anymore.
+	if err := svc.attempts.Remove(ctx, lockout.IdentityKey(c.Credentials.Identity)); err != nil {
+		return errors.Wrap(errLoginAttempts, err)
+	}
 	return nil
 }
 
@@ -352,7 +867,10 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, new
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	if _, err := svc.IssueToken(ctx, dbClient.Credentials.Identity, oldSecret, ""); err != nil {
+	if _, err := svc.authenticate(ctx, dbClient.Credentials.Identity, oldSecret); err != nil {
+		return mgclients.Client{}, err
+	}
+	if err := svc.passwords.Validate(newSecret); err != nil {
 		return mgclients.Client{}, err
 	}
 	newSecret, err = svc.hasher.Hash(newSecret)
@@ -367,6 +885,10 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, new
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	// Sessions started with the old secret must not outlive it.
+	if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: id}); err != nil {
+		return mgclients.Client{}, errors.Wrap(errRevokeTokens, err)
+	}
 
 	return dbClient, nil
 }
@@ -382,9 +904,13 @@ func (svc service) UpdateClientRole(ctx context.Context, token string, cli mgcli
 		return mgclients.Client{}, err
 	}
 
-	if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+	if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 		return mgclients.Client{}, err
 	}
+	// Users cannot become service accounts, and vice versa.
+	if cli.Role != mgclients.UserRole && cli.Role != mgclients.AdminRole {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrMalformedEntity, svcerr.ErrInvalidRole)
+	}
 	client := mgclients.Client{
 		ID:        cli.ID,
 		Role:      cli.Role,
@@ -435,17 +961,39 @@ func (svc service) DisableClient(ctx context.Context, token, id string) (mgclien
 	if err != nil {
 		return mgclients.Client{}, err
 	}
+	if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: client.ID}); err != nil {
+		return mgclients.Client{}, errors.Wrap(errRevokeTokens, err)
+	}
 
 	return client, nil
 }
 
+func (svc service) UnlockClient(ctx context.Context, token, id string) error {
+	tokenUserID, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
+		return err
+	}
+	dbClient, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := svc.attempts.Remove(ctx, lockout.IdentityKey(dbClient.Credentials.Identity)); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) changeClientStatus(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
 	tokenUserID, err := svc.Identify(ctx, token)
 	if err != nil {
 		return mgclients.Client{}, err
 	}
 	if tokenUserID != client.ID {
-		if err := svc.checkSuperAdmin(ctx, tokenUserID); err != nil {
+		if err := svc.checkSuperAdmin(ctx, token, tokenUserID); err != nil {
 			return mgclients.Client{}, err
 		}
 	}
@@ -479,6 +1027,286 @@ func (svc service) DeleteClient(ctx context.Context, token, id string) error {
 	return nil
 }
 
+func (svc service) DeleteProfile(ctx context.Context, token string) (mgclients.Client, error) {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return mgclients.Client{}, err
+	}
+	dbClient, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Service accounts are owned by the domain, so they are deleted by the domain admins.
+	if dbClient.Role == mgclients.ServiceAccountRole {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, errServiceAccountDelete)
+	}
+
+	client := mgclients.Client{
+		ID:        id,
+		UpdatedAt: time.Now(),
+		UpdatedBy: id,
+		Status:    mgclients.DeletedStatus,
+	}
+	client, err = svc.clients.ChangeStatus(ctx, client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: id}); err != nil {
+		return mgclients.Client{}, errors.Wrap(errRevokeTokens, err)
+	}
+
+	return client, nil
+}
+
+func (svc service) ExportProfile(ctx context.Context, token string) (ProfileArchive, error) {
+	client, err := svc.ViewProfile(ctx, token)
+	if err != nil {
+		return ProfileArchive{}, err
+	}
+
+	domains, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.UserType,
+		Subject:     client.ID,
+		Permission:  auth.MembershipPermission,
+		ObjectType:  auth.DomainType,
+	})
+	if err != nil {
+		return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+	}
+
+	invitations, err := svc.personalData.Invitations(ctx, token, client.ID)
+	if err != nil {
+		return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+	}
+	journal, err := svc.personalData.Journal(ctx, token, client.ID)
+	if err != nil {
+		return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+	}
+
+	archive := ProfileArchive{
+		Profile:     client,
+		Domains:     []DomainMembership{},
+		Invitations: invitations,
+		Journal:     journal,
+		ExportedAt:  time.Now(),
+	}
+	for _, domainID := range domains.GetPolicies() {
+		permissions, err := svc.listObjectUserPermission(ctx, client.ID, auth.DomainType, domainID)
+		if err != nil {
+			return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+		}
+		things, err := svc.listAdministeredObjects(ctx, domainID, client.ID, auth.ThingType)
+		if err != nil {
+			return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+		}
+		groups, err := svc.listAdministeredObjects(ctx, domainID, client.ID, auth.GroupType)
+		if err != nil {
+			return ProfileArchive{}, errors.Wrap(errExportProfile, err)
+		}
+		archive.Domains = append(archive.Domains, DomainMembership{
+			DomainID:    domainID,
+			Permissions: permissions,
+			Things:      things,
+			Groups:      groups,
+		})
+	}
+
+	return archive, nil
+}
+
+// listAdministeredObjects lists the domain objects of the given type on which
+// the user has the administrator relation, i.e. the objects created by the user.
+func (svc service) listAdministeredObjects(ctx context.Context, domainID, userID, objectType string) ([]string, error) {
+	res, err := svc.policy.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.UserType,
+		Subject:     auth.EncodeDomainUserID(domainID, userID),
+		Permission:  auth.AdministratorRelation,
+		ObjectType:  objectType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetPolicies() == nil {
+		return []string{}, nil
+	}
+
+	return res.GetPolicies(), nil
+}
+
+func (svc service) CreateServiceAccount(ctx context.Context, token, domainID string, sa mgclients.Client) (rsa mgclients.Client, err error) {
+	if err := svc.checkDomainAdmin(ctx, token, domainID); err != nil {
+		return mgclients.Client{}, err
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return mgclients.Client{}, err
+	}
+	if sa.Status != mgclients.DisabledStatus && sa.Status != mgclients.EnabledStatus {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrMalformedEntity, svcerr.ErrInvalidStatus)
+	}
+	sa.ID = id
+	// The identity is unique, so the ID is used in place of the email.
+	sa.Credentials = mgclients.Credentials{Identity: id}
+	sa.Domain = domainID
+	sa.Role = mgclients.ServiceAccountRole
+	sa.CreatedAt = time.Now()
+
+	// Service accounts are members of their domain only, so they
+	// cannot be assigned to the other domains.
+	if _, err := svc.auth.AddDomainMember(ctx, &magistrala.AddDomainMemberReq{DomainId: domainID, UserId: id, Relation: auth.MemberRelation}); err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := svc.deleteServiceAccountPolicies(ctx, id); errRollback != nil {
+				err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+			}
+		}
+	}()
+	rsa, err = svc.clients.Save(ctx, sa)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return rsa, nil
+}
+
+func (svc service) ViewServiceAccount(ctx context.Context, token, domainID, id string) (mgclients.Client, error) {
+	if err := svc.checkDomainAdmin(ctx, token, domainID); err != nil {
+		return mgclients.Client{}, err
+	}
+
+	return svc.retrieveServiceAccount(ctx, domainID, id)
+}
+
+func (svc service) ListServiceAccounts(ctx context.Context, token, domainID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	if err := svc.checkDomainAdmin(ctx, token, domainID); err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+
+	// The search filters are not combined with the domain, so they are
+	// not applied to the service accounts.
+	page := mgclients.Page{
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Status: pm.Status,
+		Domain: domainID,
+		Role:   mgclients.ServiceAccountRole,
+	}
+	sp, err := svc.clients.RetrieveAll(ctx, page)
+	if err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return sp, nil
+}
+
+func (svc service) DeleteServiceAccount(ctx context.Context, token, domainID, id string) error {
+	if err := svc.checkDomainAdmin(ctx, token, domainID); err != nil {
+		return err
+	}
+	if _, err := svc.retrieveServiceAccount(ctx, domainID, id); err != nil {
+		return err
+	}
+
+	if err := svc.deleteServiceAccountPolicies(ctx, id); err != nil {
+		return err
+	}
+	if err := svc.clients.Delete(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: id}); err != nil {
+		return errors.Wrap(errRevokeTokens, err)
+	}
+
+	return nil
+}
+
+func (svc service) DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error) {
+	pm := mgclients.Page{
+		Limit:  deleteBatch,
+		Domain: domainID,
+		Role:   mgclients.ServiceAccountRole,
+		Status: mgclients.AllStatus,
+	}
+
+	// The deleted service accounts are not retrieved again, so the first
+	// page is retrieved until the domain has no service accounts left.
+	var ids []string
+	for {
+		sp, err := svc.clients.RetrieveAll(ctx, pm)
+		if err != nil {
+			return ids, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(sp.Clients) == 0 {
+			return ids, nil
+		}
+		for _, sa := range sp.Clients {
+			if err := svc.deleteServiceAccountPolicies(ctx, sa.ID); err != nil {
+				return ids, err
+			}
+			if err := svc.clients.Delete(ctx, sa.ID); err != nil {
+				return ids, errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+			if _, err := svc.auth.RevokeTokens(ctx, &magistrala.RevokeTokensReq{UserId: sa.ID}); err != nil {
+				return ids, errors.Wrap(errRevokeTokens, err)
+			}
+			ids = append(ids, sa.ID)
+		}
+	}
+}
+
+func (svc service) IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (*magistrala.Token, error) {
+	if err := svc.checkDomainAdmin(ctx, token, domainID); err != nil {
+		return &magistrala.Token{}, err
+	}
+	sa, err := svc.retrieveServiceAccount(ctx, domainID, id)
+	if err != nil {
+		return &magistrala.Token{}, err
+	}
+	if sa.Status != mgclients.EnabledStatus {
+		return &magistrala.Token{}, errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrInvalidStatus)
+	}
+
+	tkn, err := svc.auth.Issue(ctx, &magistrala.IssueReq{UserId: id, DomainId: &domainID, Type: uint32(auth.APIKey), Token: token})
+	if err != nil {
+		return &magistrala.Token{}, errors.Wrap(errIssueKey, err)
+	}
+
+	return tkn, nil
+}
+
+// retrieveServiceAccount retrieves the service account, and reports the
+// users and the service accounts of the other domains as not found.
+func (svc service) retrieveServiceAccount(ctx context.Context, domainID, id string) (mgclients.Client, error) {
+	sa, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if sa.Role != mgclients.ServiceAccountRole || sa.Domain != domainID {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, repoerr.ErrNotFound)
+	}
+	sa.Credentials.Secret = ""
+
+	return sa, nil
+}
+
+func (svc service) deleteServiceAccountPolicies(ctx context.Context, id string) error {
+	res, err := svc.policy.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
+		Id:         id,
+		EntityType: auth.UserType,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	if !res.Deleted {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
 func (svc service) ListMembers(ctx context.Context, token, objectKind, objectID string, pm mgclients.Page) (mgclients.MembersPage, error) {
 	res, err := svc.identify(ctx, token)
 	if err != nil {
@@ -586,8 +1414,8 @@ func (svc service) listObjectUserPermission(ctx context.Context, userID, objectT
 	return lp.GetPermissions(), nil
 }
 
-func (svc *service) checkSuperAdmin(ctx context.Context, adminID string) error {
-	if _, err := svc.authorize(ctx, auth.UserType, auth.UsersKind, adminID, auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err != nil {
+func (svc *service) checkSuperAdmin(ctx context.Context, token, adminID string) error {
+	if _, err := svc.authorize(ctx, auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.PlatformType, auth.MagistralaObject); err != nil {
 		if err := svc.clients.CheckSuperAdmin(ctx, adminID); err != nil {
 			return errors.Wrap(svcerr.ErrAuthorization, err)
 		}
@@ -597,6 +1425,19 @@ func (svc *service) checkSuperAdmin(ctx context.Context, adminID string) error {
 	return nil
 }
 
+// checkDomainAdmin checks that the token belongs to the administrator of
+// the domain.
+func (svc *service) checkDomainAdmin(ctx context.Context, token, domainID string) error {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return err
+	}
+	if _, err := svc.authorize(ctx, auth.UserType, auth.TokenKind, token, auth.AdminPermission, auth.DomainType, domainID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (svc service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
 	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
 	if err != nil {
@@ -626,17 +1467,13 @@ func (svc *service) authorize(ctx context.Context, subjType, subjKind, subj, per
 }
 
 func (svc service) OAuthCallback(ctx context.Context, client mgclients.Client) (*magistrala.Token, error) {
-	rclient, err := svc.clients.RetrieveByIdentity(ctx, client.Credentials.Identity)
+	provider, groups := oauthClaims(client.Metadata)
+	// Groups are applied on every login, so they are not stored with the user.
+	delete(client.Metadata, oauthGroupsKey)
+
+	rclient, err := svc.oauthUser(ctx, provider, client)
 	if err != nil {
-		switch errors.Contains(err, repoerr.ErrNotFound) {
-		case true:
-			rclient, err = svc.RegisterClient(ctx, "", client)
-			if err != nil {
-				return &magistrala.Token{}, err
-			}
-		default:
-			return &magistrala.Token{}, err
-		}
+		return &magistrala.Token{}, err
 	}
 
 	if _, err = svc.authorize(ctx, auth.UserType, auth.UsersKind, rclient.ID, auth.MembershipPermission, auth.PlatformType, auth.MagistralaObject); err != nil {
@@ -645,6 +1482,26 @@ func (svc service) OAuthCallback(ctx context.Context, client mgclients.Client) (
 		}
 	}
 
+	if provider != "" {
+		req := &magistrala.ApplyClaimMappingsReq{
+			UserId:   rclient.ID,
+			Provider: provider,
+			Groups:   groups,
+		}
+		if _, err := svc.auth.ApplyClaimMappings(ctx, req); err != nil {
+			return &magistrala.Token{}, errors.Wrap(errApplyClaims, err)
+		}
+	}
+
+	// The identity provider replaces the secret, not the second factor.
+	m, err := svc.mfa.Retrieve(ctx, rclient.ID)
+	switch {
+	case err == nil && m.Enabled:
+		return svc.issueMFAChallenge(ctx, rclient.ID, "")
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return &magistrala.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
 	claims := &magistrala.IssueReq{
 		UserId: rclient.ID,
 		Type:   uint32(auth.AccessKey),
@@ -653,6 +1510,72 @@ func (svc service) OAuthCallback(ctx context.Context, client mgclients.Client) (
 	return svc.auth.Issue(ctx, claims)
 }
 
+// oauthUser returns the user linked to the identity provider account with
+// the subject of the client ID, and registers the user on the first login.
+// The existing user with the same email is linked only if it's registered
+// by the same provider before the accounts were linked by the subject.
+func (svc service) oauthUser(ctx context.Context, provider string, client mgclients.Client) (mgclients.Client, error) {
+	identity, err := svc.identities.Retrieve(ctx, provider, client.ID)
+	switch {
+	case err == nil:
+		return svc.clients.RetrieveByID(ctx, identity.UserID)
+	case !errors.Contains(err, repoerr.ErrNotFound):
+		return mgclients.Client{}, err
+	}
+
+	subject := client.ID
+	rclient, err := svc.clients.RetrieveByIdentity(ctx, client.Credentials.Identity)
+	switch {
+	case err == nil:
+		if p, _ := rclient.Metadata[oauthProviderKey].(string); provider == "" || p != provider {
+			return mgclients.Client{}, errors.Wrap(svcerr.ErrConflict, errOAuthAccountExists)
+		}
+	case errors.Contains(err, repoerr.ErrNotFound):
+		if err := svc.checkSelfRegister(ctx, ""); err != nil {
+			return mgclients.Client{}, err
+		}
+		// The identity provider has verified the email.
+		rclient, err = svc.registerClient(ctx, client, true)
+		if err != nil {
+			return mgclients.Client{}, err
+		}
+	default:
+		return mgclients.Client{}, err
+	}
+
+	identity = oauth2.Identity{
+		Provider:  provider,
+		Subject:   subject,
+		UserID:    rclient.ID,
+		CreatedAt: time.Now(),
+	}
+	if err := svc.identities.Save(ctx, identity); err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return rclient, nil
+}
+
+// oauthClaims returns the identity provider name and the groups
+// the provider put in the metadata of the OAuth2 user.
+func oauthClaims(metadata mgclients.Metadata) (string, []string) {
+	provider, _ := metadata[oauthProviderKey].(string)
+
+	var groups []string
+	switch gs := metadata[oauthGroupsKey].(type) {
+	case []string:
+		groups = gs
+	case []interface{}:
+		for _, g := range gs {
+			if g, ok := g.(string); ok {
+				groups = append(groups, g)
+			}
+		}
+	}
+
+	return provider, groups
+}
+
 func (svc service) Identify(ctx context.Context, token string) (string, error) {
 	user, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
 	if err != nil {
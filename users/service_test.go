@@ -8,19 +8,29 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	authsvc "github.com/absmach/magistrala/auth"
 	authmocks "github.com/absmach/magistrala/auth/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/oauth2"
+	oauth2mocks "github.com/absmach/magistrala/pkg/oauth2/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/users"
 	"github.com/absmach/magistrala/users/hasher"
+	"github.com/absmach/magistrala/users/lockout"
+	lockoutmocks "github.com/absmach/magistrala/users/lockout/mocks"
+	"github.com/absmach/magistrala/users/mfa"
+	mfamocks "github.com/absmach/magistrala/users/mfa/mocks"
 	"github.com/absmach/magistrala/users/mocks"
+	"github.com/absmach/magistrala/users/verification"
+	verificationmocks "github.com/absmach/magistrala/users/verification/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -43,11 +53,25 @@ var (
 		Name: "clientname",
 		ID:   clientID,
 	}
-	validToken      = "token"
-	inValidToken    = "invalid"
-	validID         = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
-	wrongID         = testsutil.GenerateUUID(&testing.T{})
-	errHashPassword = errors.New("generate hash from password failed")
+	validToken       = "token"
+	inValidToken     = "invalid"
+	validID          = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	wrongID          = testsutil.GenerateUUID(&testing.T{})
+	errHashPassword  = errors.New("generate hash from password failed")
+	errRevokeTokens  = errors.New("failed to revoke user tokens")
+	mfaRepo          *mfamocks.Repository
+	verificationRepo *verificationmocks.Repository
+	identities       *oauth2mocks.IdentityRepository
+	personalData     *mocks.PersonalData
+	invitations      *mocks.Invitations
+	lockoutConfig    = lockout.Config{
+		MaxAttempts:   3,
+		MaxIPAttempts: 10,
+		Window:        15 * time.Minute,
+		Duration:      15 * time.Minute,
+		BaseDelay:     time.Second,
+		MaxDelay:      30 * time.Second,
+	}
 )
 
 func newService(selfRegister bool) (users.Service, *mocks.Repository, *authmocks.AuthServiceClient, *authmocks.PolicyServiceClient, *mocks.Emailer) {
@@ -55,11 +79,36 @@ func newService(selfRegister bool) (users.Service, *mocks.Repository, *authmocks
 	auth := new(authmocks.AuthServiceClient)
 	policy := new(authmocks.PolicyServiceClient)
 	e := new(mocks.Emailer)
-	return users.NewService(cRepo, auth, policy, e, phasher, idProvider, selfRegister), cRepo, auth, policy, e
+	mfaRepo = new(mfamocks.Repository)
+	verificationRepo = new(verificationmocks.Repository)
+	identities = new(oauth2mocks.IdentityRepository)
+	personalData = new(mocks.PersonalData)
+	invitations = new(mocks.Invitations)
+	attempts := new(lockoutmocks.Repository)
+	attempts.On("Retrieve", mock.Anything, mock.Anything).Return(lockout.Attempts{}, nil)
+	attempts.On("Fail", mock.Anything, mock.Anything, mock.Anything).Return(lockout.Attempts{Failures: 1, LastFailure: time.Now()}, nil)
+	attempts.On("Remove", mock.Anything, mock.Anything).Return(nil)
+	passwords := new(mocks.PasswordPolicy)
+	passwords.On("Validate", mock.Anything).Return(nil)
+	return users.NewService(cRepo, mfaRepo, verificationRepo, identities, attempts, lockoutConfig, auth, policy, e, personalData, invitations, phasher, passwords, idProvider, selfRegister, true), cRepo, auth, policy, e
+}
+
+func newLockoutService() (users.Service, *mocks.Repository, *lockoutmocks.Repository, *mocks.PasswordPolicy, *authmocks.AuthServiceClient, *authmocks.PolicyServiceClient) {
+	cRepo := new(mocks.Repository)
+	auth := new(authmocks.AuthServiceClient)
+	policy := new(authmocks.PolicyServiceClient)
+	attempts := new(lockoutmocks.Repository)
+	passwords := new(mocks.PasswordPolicy)
+	mfaRepo = new(mfamocks.Repository)
+	verificationRepo = new(verificationmocks.Repository)
+	identities = new(oauth2mocks.IdentityRepository)
+	personalData = new(mocks.PersonalData)
+	invitations = new(mocks.Invitations)
+	return users.NewService(cRepo, mfaRepo, verificationRepo, identities, attempts, lockoutConfig, auth, policy, new(mocks.Emailer), personalData, invitations, phasher, passwords, idProvider, true, true), cRepo, attempts, passwords, auth, policy
 }
 
 func TestRegisterClient(t *testing.T) {
-	svc, cRepo, _, policy, _ := newService(true)
+	svc, cRepo, _, policy, e := newService(true)
 
 	cases := []struct {
 		desc                      string
@@ -267,6 +316,8 @@ func TestRegisterClient(t *testing.T) {
 		authCall := policy.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesResponse, tc.addPoliciesResponseErr)
 		authCall1 := policy.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesResponse, tc.deletePoliciesResponseErr)
 		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.client, tc.saveErr)
+		verificationCall := verificationRepo.On("Save", context.Background(), mock.Anything, mock.Anything).Return(nil)
+		emailCall := e.On("SendVerification", []string{tc.client.Credentials.Identity}, tc.client.Name, mock.Anything).Return(nil)
 		expected, err := svc.RegisterClient(context.Background(), tc.token, tc.client)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		if err == nil {
@@ -278,13 +329,17 @@ func TestRegisterClient(t *testing.T) {
 			assert.Equal(t, tc.client, expected, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.client, expected))
 			ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
+			ok = emailCall.Parent.AssertCalled(t, "SendVerification", []string{tc.client.Credentials.Identity}, tc.client.Name, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("SendVerification was not called on %s", tc.desc))
 		}
+		emailCall.Unset()
+		verificationCall.Unset()
 		repoCall.Unset()
 		authCall1.Unset()
 		authCall.Unset()
 	}
 
-	svc, cRepo, auth, policy, _ := newService(false)
+	svc, cRepo, auth, policy, e := newService(false)
 
 	cases2 := []struct {
 		desc                      string
@@ -344,6 +399,8 @@ func TestRegisterClient(t *testing.T) {
 		authCall2 := policy.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesResponse, tc.addPoliciesResponseErr)
 		authCall3 := policy.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesResponse, tc.deletePoliciesResponseErr)
 		repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.client, tc.saveErr)
+		verificationCall := verificationRepo.On("Save", context.Background(), mock.Anything, mock.Anything).Return(nil)
+		emailCall := e.On("SendVerification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		expected, err := svc.RegisterClient(context.Background(), tc.token, tc.client)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		if err == nil {
@@ -357,6 +414,8 @@ func TestRegisterClient(t *testing.T) {
 			assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
 		}
 
+		emailCall.Unset()
+		verificationCall.Unset()
 		repoCall1.Unset()
 		authCall3.Unset()
 		authCall2.Unset()
@@ -366,6 +425,113 @@ func TestRegisterClient(t *testing.T) {
 	}
 }
 
+func TestRegisterInvitedClient(t *testing.T) {
+	svc, cRepo, auth, policy, e := newService(false)
+
+	domainID := testsutil.GenerateUUID(t)
+	cases := []struct {
+		desc                string
+		token               string
+		client              mgclients.Client
+		identifyResponse    *magistrala.IdentityRes
+		authorizeResponse   *magistrala.AuthorizeRes
+		addPoliciesResponse *magistrala.AddPoliciesRes
+		identifyErr         error
+		authorizeErr        error
+		checkPendingErr     error
+		saveErr             error
+		err                 error
+	}{
+		{
+			desc:                "register invited client successfully",
+			token:               validToken,
+			client:              client,
+			identifyResponse:    &magistrala.IdentityRes{UserId: validID, DomainId: domainID, Type: uint32(authsvc.InvitationKey)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true},
+			addPoliciesResponse: &magistrala.AddPoliciesRes{Added: true},
+			err:                 nil,
+		},
+		{
+			desc:             "register invited client with invalid token",
+			token:            inValidToken,
+			client:           client,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "register invited client with access token",
+			token:            validToken,
+			client:           client,
+			identifyResponse: &magistrala.IdentityRes{UserId: validID, DomainId: domainID, Type: uint32(authsvc.AccessKey)},
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:             "register invited client with invitation token without domain",
+			token:            validToken,
+			client:           client,
+			identifyResponse: &magistrala.IdentityRes{UserId: validID, Type: uint32(authsvc.InvitationKey)},
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "register invited client with inviter that is not domain admin",
+			token:             validToken,
+			client:            client,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: domainID, Type: uint32(authsvc.InvitationKey)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:                "register invited client without pending invitation",
+			token:               validToken,
+			client:              client,
+			identifyResponse:    &magistrala.IdentityRes{UserId: validID, DomainId: domainID, Type: uint32(authsvc.InvitationKey)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true},
+			addPoliciesResponse: &magistrala.AddPoliciesRes{Added: true},
+			checkPendingErr:     svcerr.ErrNotFound,
+			err:                 svcerr.ErrAuthorization,
+		},
+		{
+			desc:                "register invited client with failed to save",
+			token:               validToken,
+			client:              client,
+			identifyResponse:    &magistrala.IdentityRes{UserId: validID, DomainId: domainID, Type: uint32(authsvc.InvitationKey)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true},
+			addPoliciesResponse: &magistrala.AddPoliciesRes{Added: true},
+			saveErr:             repoerr.ErrConflict,
+			err:                 svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		policyCall := policy.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesResponse, nil)
+		policyCall1 := policy.On("DeletePolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+		invCall := invitations.On("CheckPending", context.Background(), tc.token, tc.client.Credentials.Identity, domainID).Return(tc.checkPendingErr)
+		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.client, tc.saveErr)
+		emailCall := e.On("SendVerification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		_, err := svc.RegisterInvitedClient(context.Background(), tc.token, tc.client)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+				return !c.VerifiedAt.IsZero() && c.Role == mgclients.UserRole
+			}))
+			assert.True(t, ok, fmt.Sprintf("Save was not called with verified client on %s", tc.desc))
+			ok = emailCall.Parent.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("SendVerification was called on %s", tc.desc))
+		}
+		emailCall.Unset()
+		repoCall.Unset()
+		invCall.Unset()
+		policyCall1.Unset()
+		policyCall.Unset()
+		authCall1.Unset()
+		authCall.Unset()
+	}
+}
+
 func TestViewClient(t *testing.T) {
 	svc, cRepo, auth, _, _ := newService(true)
 
@@ -916,7 +1082,7 @@ func TestUpdateClientTags(t *testing.T) {
 }
 
 func TestUpdateClientIdentity(t *testing.T) {
-	svc, cRepo, auth, _, _ := newService(true)
+	svc, cRepo, auth, _, e := newService(true)
 
 	client2 := client
 	client2.Credentials.Identity = "updated@example.com"
@@ -1020,6 +1186,8 @@ func TestUpdateClientIdentity(t *testing.T) {
 		authCall1 := auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
 		repoCall1 := cRepo.On("UpdateIdentity", context.Background(), mock.Anything).Return(tc.updateClientIdentityResponse, tc.updateClientIdentityErr)
+		verificationCall := verificationRepo.On("Save", context.Background(), mock.Anything, mock.Anything).Return(nil)
+		emailCall := e.On("SendVerification", []string{tc.updateClientIdentityResponse.Credentials.Identity}, tc.updateClientIdentityResponse.Name, mock.Anything).Return(nil)
 
 		updatedClient, err := svc.UpdateClientIdentity(context.Background(), tc.token, tc.id, tc.identity)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
@@ -1032,6 +1200,8 @@ func TestUpdateClientIdentity(t *testing.T) {
 		authCall1.Unset()
 		repoCall.Unset()
 		repoCall1.Unset()
+		verificationCall.Unset()
+		emailCall.Unset()
 	}
 }
 
@@ -1044,8 +1214,8 @@ func TestUpdateClientRole(t *testing.T) {
 
 	superAdminAuthReq := &magistrala.AuthorizeReq{
 		SubjectType: authsvc.UserType,
-		SubjectKind: authsvc.UsersKind,
-		Subject:     client.ID,
+		SubjectKind: authsvc.TokenKind,
+		Subject:     validToken,
 		Permission:  authsvc.AdminPermission,
 		ObjectType:  authsvc.PlatformType,
 		Object:      authsvc.MagistralaObject,
@@ -1094,6 +1264,15 @@ func TestUpdateClientRole(t *testing.T) {
 			token:              validToken,
 			err:                nil,
 		},
+		{
+			desc:              "update client role to service account",
+			client:            mgclients.Client{ID: client.ID, Role: mgclients.ServiceAccountRole},
+			superAdminAuthReq: superAdminAuthReq,
+			identifyResponse:  &magistrala.IdentityRes{UserId: client.ID},
+			superAdminAuthRes: &magistrala.AuthorizeRes{Authorized: true},
+			token:             validToken,
+			err:               svcerr.ErrMalformedEntity,
+		},
 		{
 			desc:              "update client role with invalid token",
 			client:            client,
@@ -1286,6 +1465,7 @@ func TestUpdateClientSecret(t *testing.T) {
 		retrieveByIdentityErr      error
 		updateSecretErr            error
 		issueErr                   error
+		revokeTokensErr            error
 		err                        error
 	}{
 		{
@@ -1363,6 +1543,18 @@ func TestUpdateClientSecret(t *testing.T) {
 			updateSecretErr:            repoerr.ErrMalformedEntity,
 			err:                        svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:                       "update client secret with failed to revoke tokens",
+			oldSecret:                  client.Credentials.Secret,
+			newSecret:                  newSecret,
+			token:                      validToken,
+			identifyResponse:           &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse:       client,
+			retrieveByIdentityResponse: rClient,
+			updateSecretResponse:       responseClient,
+			revokeTokensErr:            svcerr.ErrAuthentication,
+			err:                        errRevokeTokens,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1371,6 +1563,7 @@ func TestUpdateClientSecret(t *testing.T) {
 		repoCall1 := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
 		repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
 		authCall1 := auth.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+		authCall2 := auth.On("RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: client.ID}).Return(&magistrala.RevokeTokensRes{Revoked: tc.revokeTokensErr == nil}, tc.revokeTokensErr)
 
 		updatedClient, err := svc.UpdateClientSecret(context.Background(), tc.token, tc.oldSecret, tc.newSecret)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
@@ -1382,12 +1575,15 @@ func TestUpdateClientSecret(t *testing.T) {
 			assert.True(t, ok, fmt.Sprintf("RetrieveByIdentity was not called on %s", tc.desc))
 			ok = repoCall2.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("UpdateSecret was not called on %s", tc.desc))
+			ok = authCall2.Parent.AssertCalled(t, "RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: client.ID})
+			assert.True(t, ok, fmt.Sprintf("RevokeTokens was not called on %s", tc.desc))
 		}
 		authCall.Unset()
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
 		authCall1.Unset()
+		authCall2.Unset()
 	}
 }
 
@@ -1538,6 +1734,7 @@ func TestDisableClient(t *testing.T) {
 		retrieveByIDErr      error
 		changeStatusErr      error
 		checkSuperAdminErr   error
+		revokeTokensErr      error
 		err                  error
 	}{
 		{
@@ -1614,6 +1811,18 @@ func TestDisableClient(t *testing.T) {
 			changeStatusErr:      repoerr.ErrMalformedEntity,
 			err:                  svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:                 "disable enabled client with failed to revoke tokens",
+			id:                   enabledClient1.ID,
+			token:                validToken,
+			client:               enabledClient1,
+			identifyResponse:     &magistrala.IdentityRes{UserId: enabledClient1.ID},
+			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
+			retrieveByIDResponse: enabledClient1,
+			changeStatusResponse: disenabledClient1,
+			revokeTokensErr:      svcerr.ErrAuthentication,
+			err:                  errRevokeTokens,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1622,6 +1831,7 @@ func TestDisableClient(t *testing.T) {
 		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
 		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
 		repoCall2 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
+		authCall2 := auth.On("RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: tc.changeStatusResponse.ID}).Return(&magistrala.RevokeTokensRes{Revoked: tc.revokeTokensErr == nil}, tc.revokeTokensErr)
 
 		_, err := svc.DisableClient(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
@@ -1633,6 +1843,7 @@ func TestDisableClient(t *testing.T) {
 		}
 		authCall.Unset()
 		authCall1.Unset()
+		authCall2.Unset()
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
@@ -1762,6 +1973,97 @@ func TestDeleteClient(t *testing.T) {
 	}
 }
 
+func TestUnlockClient(t *testing.T) {
+	cases := []struct {
+		desc              string
+		token             string
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		retrieveByIDErr   error
+		removeErr         error
+		err               error
+	}{
+		{
+			desc:              "unlock client",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:             "unlock client with invalid token",
+			token:            inValidToken,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "unlock client with normal user token",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "unlock non-existing client",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveByIDErr:   repoerr.ErrNotFound,
+			err:               svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "unlock client with failed to remove attempts",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			removeErr:         repoerr.ErrRemoveEntity,
+			err:               svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, attempts, _, auth, _ := newLockoutService()
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(svcerr.ErrAuthorization)
+		cRepo.On("RetrieveByID", context.Background(), client.ID).Return(client, tc.retrieveByIDErr)
+		attempts.On("Remove", context.Background(), lockout.IdentityKey(client.Credentials.Identity)).Return(tc.removeErr)
+
+		err := svc.UnlockClient(context.Background(), tc.token, client.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			attempts.AssertCalled(t, "Remove", context.Background(), lockout.IdentityKey(client.Credentials.Identity))
+		}
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	svc, cRepo, attempts, passwords, auth, _ := newLockoutService()
+	auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: client.ID}, nil)
+	cRepo.On("RetrieveByID", context.Background(), client.ID).Return(client, nil)
+	attempts.On("Retrieve", context.Background(), mock.Anything).Return(lockout.Attempts{}, nil)
+	attempts.On("Fail", context.Background(), mock.Anything, mock.Anything).Return(lockout.Attempts{}, nil)
+	passwords.On("Validate", "password").Return(svcerr.ErrPasswordPolicy)
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+
+	_, err := svc.RegisterClient(context.Background(), validToken, mgclients.Client{Credentials: mgclients.Credentials{Identity: "new@example.com", Secret: "password"}})
+	assert.True(t, errors.Contains(err, svcerr.ErrPasswordPolicy), fmt.Sprintf("register client: expected %s got %s\n", svcerr.ErrPasswordPolicy, err))
+
+	_, err = svc.UpdateClientSecret(context.Background(), validToken, client.Credentials.Secret, "password")
+	assert.True(t, errors.Contains(err, svcerr.ErrPasswordPolicy), fmt.Sprintf("update client secret: expected %s got %s\n", svcerr.ErrPasswordPolicy, err))
+
+	err = svc.ResetSecret(context.Background(), validToken, "password")
+	assert.True(t, errors.Contains(err, svcerr.ErrPasswordPolicy), fmt.Sprintf("reset secret: expected %s got %s\n", svcerr.ErrPasswordPolicy, err))
+
+	cRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	cRepo.AssertNotCalled(t, "UpdateSecret", mock.Anything, mock.Anything)
+}
+
 func TestListMembers(t *testing.T) {
 	svc, cRepo, auth, policy, _ := newService(true)
 
@@ -2232,14 +2534,17 @@ func TestListMembers(t *testing.T) {
 }
 
 func TestIssueToken(t *testing.T) {
-	svc, cRepo, auth, _, _ := newService(true)
+	svc, cRepo, auth, _, e := newService(true)
 
 	rClient := client
 	rClient2 := client
 	rClient3 := client
 	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	rClient.VerifiedAt = time.Now()
 	rClient2.Credentials.Secret = "wrongsecret"
 	rClient3.Credentials.Secret, _ = phasher.Hash("wrongsecret")
+	unverified := rClient
+	unverified.VerifiedAt = time.Time{}
 
 	cases := []struct {
 		desc                       string
@@ -2258,6 +2563,12 @@ func TestIssueToken(t *testing.T) {
 			issueResponse:              &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
 			err:                        nil,
 		},
+		{
+			desc:                       "issue token for a client with unverified email",
+			client:                     client,
+			retrieveByIdentityResponse: unverified,
+			err:                        svcerr.ErrEmailNotVerified,
+		},
 		{
 			desc:                       "issue token for non-empty domain id",
 			domainID:                   validID,
@@ -2279,6 +2590,12 @@ func TestIssueToken(t *testing.T) {
 			retrieveByIdentityResponse: rClient3,
 			err:                        svcerr.ErrLogin,
 		},
+		{
+			desc:                       "issue token for a service account",
+			client:                     client,
+			retrieveByIdentityResponse: mgclients.Client{ID: client.ID, Role: mgclients.ServiceAccountRole},
+			err:                        svcerr.ErrLogin,
+		},
 		{
 			desc:                       "issue token with empty domain id",
 			client:                     client,
@@ -2299,8 +2616,9 @@ func TestIssueToken(t *testing.T) {
 
 	for _, tc := range cases {
 		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), tc.client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+		mfaCall := mfaRepo.On("Retrieve", context.Background(), tc.client.ID).Return(mfa.MFA{}, repoerr.ErrNotFound)
 		authCall := auth.On("Issue", context.Background(), &magistrala.IssueReq{UserId: tc.client.ID, DomainId: &tc.domainID, Type: uint32(authsvc.AccessKey)}).Return(tc.issueResponse, tc.issueErr)
-		token, err := svc.IssueToken(context.Background(), tc.client.Credentials.Identity, tc.client.Credentials.Secret, tc.domainID)
+		token, err := svc.IssueToken(context.Background(), tc.client.Credentials.Identity, tc.client.Credentials.Secret, tc.domainID, "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		if err == nil {
 			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetAccessToken()))
@@ -2310,504 +2628,2198 @@ func TestIssueToken(t *testing.T) {
 			ok = authCall.Parent.AssertCalled(t, "Issue", context.Background(), &magistrala.IssueReq{UserId: tc.client.ID, DomainId: &tc.domainID, Type: uint32(authsvc.AccessKey)})
 			assert.True(t, ok, fmt.Sprintf("Issue was not called on %s", tc.desc))
 		}
+		// The verification is sent only on the explicit request.
+		ok := e.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything, mock.Anything)
+		assert.True(t, ok, fmt.Sprintf("SendVerification was called on %s", tc.desc))
 		authCall.Unset()
+		mfaCall.Unset()
 		repoCall.Unset()
 	}
 }
 
-func TestRefreshToken(t *testing.T) {
-	svc, crepo, auth, _, _ := newService(true)
+func TestIssueTokenWithMFA(t *testing.T) {
+	svc, cRepo, _, _, _ := newService(true)
 
 	rClient := client
 	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	rClient.VerifiedAt = time.Now()
 
 	cases := []struct {
-		desc         string
-		token        string
-		domainID     string
-		identifyResp *magistrala.IdentityRes
-		identifyErr  error
-		refreshResp  *magistrala.Token
-		refresErr    error
-		repoResp     mgclients.Client
-		repoErr      error
-		err          error
+		desc        string
+		retrieveRes mfa.MFA
+		retrieveErr error
+		saveErr     error
+		err         error
 	}{
 		{
-			desc:         "refresh token with refresh token for an existing client",
-			token:        validToken,
-			domainID:     validID,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			refreshResp:  &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
-			repoResp:     rClient,
-			err:          nil,
-		},
-		{
-			desc:         "refresh token with refresh token for empty domain id",
-			token:        validToken,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			refreshResp:  &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
-			repoResp:     rClient,
-			err:          nil,
-		},
-		{
-			desc:         "refresh token with access token for an existing client",
-			token:        validToken,
-			domainID:     validID,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			refreshResp:  &magistrala.Token{},
-			refresErr:    svcerr.ErrAuthentication,
-			repoResp:     rClient,
-			err:          svcerr.ErrAuthentication,
-		},
-		{
-			desc:         "refresh token with invalid token",
-			token:        validToken,
-			domainID:     validID,
-			identifyResp: &magistrala.IdentityRes{},
-			identifyErr:  svcerr.ErrAuthentication,
-			err:          svcerr.ErrAuthentication,
-		},
-		{
-			desc:         "refresh token with refresh token for a non-existing client",
-			token:        validToken,
-			domainID:     validID,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			repoErr:      repoerr.ErrNotFound,
-			err:          repoerr.ErrNotFound,
+			desc:        "issue token for a client with enabled MFA",
+			retrieveRes: mfa.MFA{UserID: client.ID, Enabled: true},
+			err:         nil,
 		},
 		{
-			desc:         "refresh token with refresh token for a disable client",
-			token:        validToken,
-			domainID:     validID,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			repoResp:     mgclients.Client{Status: mgclients.DisabledStatus},
-			err:          svcerr.ErrAuthentication,
+			desc:        "issue token with failed to retrieve MFA",
+			retrieveRes: mfa.MFA{},
+			retrieveErr: repoerr.ErrMalformedEntity,
+			err:         repoerr.ErrMalformedEntity,
 		},
 		{
-			desc:         "refresh token with empty domain id",
-			token:        validToken,
-			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
-			refreshResp:  &magistrala.Token{},
-			refresErr:    svcerr.ErrAuthentication,
-			repoResp:     rClient,
-			err:          svcerr.ErrAuthentication,
+			desc:        "issue token with failed to save challenge",
+			retrieveRes: mfa.MFA{UserID: client.ID, Enabled: true},
+			saveErr:     repoerr.ErrCreateEntity,
+			err:         repoerr.ErrCreateEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResp, tc.identifyErr)
-		authCall1 := auth.On("Refresh", context.Background(), &magistrala.RefreshReq{RefreshToken: tc.token, DomainId: &tc.domainID}).Return(tc.refreshResp, tc.refresErr)
-		repoCall := crepo.On("RetrieveByID", context.Background(), tc.identifyResp.GetUserId()).Return(tc.repoResp, tc.repoErr)
-		token, err := svc.RefreshToken(context.Background(), tc.token, tc.domainID)
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+		mfaCall := mfaRepo.On("Retrieve", context.Background(), client.ID).Return(tc.retrieveRes, tc.retrieveErr)
+		mfaCall1 := mfaRepo.On("SaveChallenge", context.Background(), mock.Anything).Return(tc.saveErr)
+		token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret, "", "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		if err == nil {
-			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetAccessToken()))
-			assert.NotEmpty(t, token.GetRefreshToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetRefreshToken()))
-			ok := authCall.Parent.AssertCalled(t, "Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token})
-			assert.True(t, ok, fmt.Sprintf("Identify was not called on %s", tc.desc))
-			ok = authCall.Parent.AssertCalled(t, "Refresh", context.Background(), &magistrala.RefreshReq{RefreshToken: tc.token, DomainId: &tc.domainID})
-			assert.True(t, ok, fmt.Sprintf("Refresh was not called on %s", tc.desc))
-			ok = repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.identifyResp.UserId)
-			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
+			assert.NotEmpty(t, token.GetMfaToken(), fmt.Sprintf("%s: expected MFA token not to be empty\n", tc.desc))
+			assert.Empty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected access token to be empty\n", tc.desc))
 		}
-		authCall.Unset()
-		authCall1.Unset()
 		repoCall.Unset()
+		mfaCall.Unset()
+		mfaCall1.Unset()
 	}
 }
 
-func TestGenerateResetToken(t *testing.T) {
-	svc, cRepo, auth, _, e := newService(true)
+func TestIssueTokenLockout(t *testing.T) {
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	rClient.VerifiedAt = time.Now()
+	sourceIP := "192.168.0.1"
+	identityKey := lockout.IdentityKey(client.Credentials.Identity)
+	ipKey := lockout.IPKey(sourceIP)
 
 	cases := []struct {
-		desc                       string
-		email                      string
-		host                       string
-		retrieveByIdentityResponse mgclients.Client
-		issueResponse              *magistrala.Token
-		retrieveByIdentityErr      error
-		issueErr                   error
-		err                        error
+		desc        string
+		secret      string
+		identity    lockout.Attempts
+		ip          lockout.Attempts
+		identityErr error
+		retrieveErr error
+		mfa         mfa.MFA
+		failed      lockout.Attempts
+		locked      bool
+		err         error
 	}{
 		{
-			desc:                       "generate reset token for existing client",
-			email:                      "existingemail@example.com",
-			host:                       "examplehost",
-			retrieveByIdentityResponse: client,
-			issueResponse:              &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
-			err:                        nil,
+			desc:   "issue token resets failed attempts",
+			secret: client.Credentials.Secret,
+			err:    nil,
 		},
 		{
-			desc:  "generate reset token for client with non-existing client",
-			email: "example@example.com",
-			host:  "examplehost",
-			retrieveByIdentityResponse: mgclients.Client{
-				ID: testsutil.GenerateUUID(t),
-				Credentials: mgclients.Credentials{
-					Identity: "",
-				},
-			},
-			retrieveByIdentityErr: repoerr.ErrNotFound,
-			err:                   repoerr.ErrNotFound,
+			desc:     "issue token with MFA enabled keeps failed attempts",
+			secret:   client.Credentials.Secret,
+			identity: lockout.Attempts{Failures: 2, LastFailure: time.Now().Add(-time.Minute)},
+			mfa:      mfa.MFA{UserID: client.ID, Enabled: true},
+			err:      nil,
 		},
 		{
-			desc:                       "generate reset token with failed to issue token",
-			email:                      "existingemail@example.com",
-			host:                       "examplehost",
-			retrieveByIdentityResponse: client,
-			issueResponse:              &magistrala.Token{},
-			issueErr:                   svcerr.ErrAuthorization,
-			err:                        svcerr.ErrAuthorization,
+			desc:     "issue token for locked out identity",
+			secret:   client.Credentials.Secret,
+			identity: lockout.Attempts{Failures: 3, LockedUntil: time.Now().Add(time.Minute)},
+			err:      svcerr.ErrLoginLocked,
+		},
+		{
+			desc:   "issue token from locked out source IP",
+			secret: client.Credentials.Secret,
+			ip:     lockout.Attempts{Failures: 10, LockedUntil: time.Now().Add(time.Minute)},
+			err:    svcerr.ErrLoginLocked,
+		},
+		{
+			desc:     "issue token before delay expires",
+			secret:   client.Credentials.Secret,
+			identity: lockout.Attempts{Failures: 2, LastFailure: time.Now()},
+			err:      svcerr.ErrLoginLocked,
+		},
+		{
+			desc:     "issue token after delay expires",
+			secret:   client.Credentials.Secret,
+			identity: lockout.Attempts{Failures: 2, LastFailure: time.Now().Add(-time.Minute)},
+			err:      nil,
+		},
+		{
+			desc:     "issue token after lockout expires",
+			secret:   client.Credentials.Secret,
+			identity: lockout.Attempts{Failures: 3, LastFailure: time.Now().Add(-time.Hour), LockedUntil: time.Now().Add(-time.Minute)},
+			err:      nil,
+		},
+		{
+			desc:   "issue token with wrong secret records failed attempt",
+			secret: "wrongsecret",
+			failed: lockout.Attempts{Failures: 1, LastFailure: time.Now()},
+			err:    svcerr.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret locks out identity",
+			secret: "wrongsecret",
+			failed: lockout.Attempts{Failures: 3, LastFailure: time.Now()},
+			locked: true,
+			err:    svcerr.ErrLogin,
+		},
+		{
+			desc:        "issue token with failed to retrieve attempts",
+			secret:      client.Credentials.Secret,
+			identityErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), tc.email).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
-		authCall := auth.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+		svc, cRepo, attempts, _, auth, _ := newLockoutService()
+		attempts.On("Retrieve", context.Background(), identityKey).Return(tc.identity, tc.identityErr)
+		attempts.On("Retrieve", context.Background(), ipKey).Return(tc.ip, nil)
+		attempts.On("Fail", context.Background(), identityKey, lockoutConfig.Window).Return(tc.failed, nil)
+		attempts.On("Fail", context.Background(), ipKey, lockoutConfig.Window).Return(lockout.Attempts{Failures: 1}, nil)
+		attempts.On("Lock", context.Background(), identityKey, mock.Anything).Return(nil)
+		attempts.On("Remove", context.Background(), identityKey).Return(nil)
+		cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, tc.retrieveErr)
+		mfaErr := repoerr.ErrNotFound
+		if tc.mfa.Enabled {
+			mfaErr = nil
+		}
+		mfaRepo.On("Retrieve", context.Background(), client.ID).Return(tc.mfa, mfaErr)
+		mfaRepo.On("SaveChallenge", context.Background(), mock.Anything).Return(nil)
+		auth.On("Issue", context.Background(), mock.Anything).Return(&magistrala.Token{AccessToken: validToken}, nil)
 
-		svcCall := e.On("SendPasswordReset", []string{tc.email}, tc.host, client.Name, validToken).Return(tc.err)
-		err := svc.GenerateResetToken(context.Background(), tc.email, tc.host)
+		_, err := svc.IssueToken(context.Background(), client.Credentials.Identity, tc.secret, "", sourceIP)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		repoCall.Parent.AssertCalled(t, "RetrieveByIdentity", context.Background(), tc.email)
-		repoCall.Unset()
-		authCall.Unset()
-		svcCall.Unset()
+		switch {
+		case err == nil && tc.mfa.Enabled:
+			attempts.AssertNotCalled(t, "Remove", context.Background(), identityKey)
+		case err == nil:
+			attempts.AssertCalled(t, "Remove", context.Background(), identityKey)
+		case errors.Contains(err, svcerr.ErrLogin):
+			attempts.AssertCalled(t, "Fail", context.Background(), identityKey, lockoutConfig.Window)
+			attempts.AssertCalled(t, "Fail", context.Background(), ipKey, lockoutConfig.Window)
+		default:
+			cRepo.AssertNotCalled(t, "RetrieveByIdentity", context.Background(), client.Credentials.Identity)
+		}
+		switch tc.locked {
+		case true:
+			attempts.AssertCalled(t, "Lock", context.Background(), identityKey, mock.Anything)
+		default:
+			attempts.AssertNotCalled(t, "Lock", context.Background(), identityKey, mock.Anything)
+		}
+		attempts.AssertNotCalled(t, "Lock", context.Background(), ipKey, mock.Anything)
 	}
 }
 
-func TestResetSecret(t *testing.T) {
-	svc, cRepo, auth, _, _ := newService(true)
-
-	client := mgclients.Client{
-		ID: "clientID",
-		Credentials: mgclients.Credentials{
-			Identity: "test@example.com",
-			Secret:   "Strongsecret",
-		},
-	}
+func TestIssueMFAToken(t *testing.T) {
+	mfaSecret, err := mfa.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating MFA secret expected to succeed: %s", err))
+	recoveryCode := "abcde-fghij"
+	hashedCode, err := phasher.Hash(recoveryCode)
+	assert.Nil(t, err, fmt.Sprintf("hashing recovery code expected to succeed: %s", err))
+	challenge := mfa.Challenge{ID: validID, UserID: client.ID, ExpiresAt: time.Now().Add(time.Minute)}
+	m := mfa.MFA{UserID: client.ID, Secret: mfaSecret, Enabled: true, RecoveryCodes: []string{hashedCode}}
 
 	cases := []struct {
-		desc                 string
-		token                string
-		newSecret            string
-		identifyResponse     *magistrala.IdentityRes
-		retrieveByIDResponse mgclients.Client
-		updateSecretResponse mgclients.Client
-		identifyErr          error
-		retrieveByIDErr      error
-		updateSecretErr      error
-		err                  error
+		desc            string
+		mfaToken        string
+		code            string
+		challenge       mfa.Challenge
+		retrieveChalErr error
+		lastStep        uint64
+		saveStepErr     error
+		issueResponse   *magistrala.Token
+		issueErr        error
+		attempts        int
+		err             error
 	}{
 		{
-			desc:                 "reset secret with successfully",
-			token:                validToken,
-			newSecret:            "newStrongSecret",
-			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
-			retrieveByIDResponse: client,
-			updateSecretResponse: mgclients.Client{
-				ID: "clientID",
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
-					Secret:   "newStrongSecret",
-				},
-			},
-			err: nil,
+			desc:          "issue MFA token with valid TOTP code",
+			mfaToken:      validID,
+			code:          mfaCode(t, mfaSecret),
+			challenge:     challenge,
+			issueResponse: &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken},
+			err:           nil,
 		},
 		{
-			desc:             "reset secret with invalid token",
-			token:            inValidToken,
-			newSecret:        "newStrongSecret",
-			identifyResponse: &magistrala.IdentityRes{},
-			identifyErr:      svcerr.ErrAuthentication,
-			err:              svcerr.ErrAuthentication,
+			desc:          "issue MFA token with valid recovery code",
+			mfaToken:      validID,
+			code:          recoveryCode,
+			challenge:     challenge,
+			issueResponse: &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken},
+			err:           nil,
 		},
 		{
-			desc:                 "reset secret with invalid ID",
-			token:                validToken,
-			newSecret:            "newStrongSecret",
-			identifyResponse:     &magistrala.IdentityRes{UserId: wrongID},
-			retrieveByIDResponse: mgclients.Client{},
-			retrieveByIDErr:      repoerr.ErrNotFound,
-			err:                  repoerr.ErrNotFound,
+			desc:            "issue MFA token with invalid MFA token",
+			mfaToken:        wrongID,
+			code:            mfaCode(t, mfaSecret),
+			challenge:       mfa.Challenge{},
+			retrieveChalErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrAuthentication,
 		},
 		{
-			desc:             "reset secret with empty identity",
-			token:            validToken,
-			newSecret:        "newStrongSecret",
-			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
-			retrieveByIDResponse: mgclients.Client{
-				ID: "clientID",
-				Credentials: mgclients.Credentials{
-					Identity: "",
-				},
-			},
-			err: nil,
+			desc:      "issue MFA token with invalid code",
+			mfaToken:  validID,
+			code:      "000000",
+			challenge: challenge,
+			err:       svcerr.ErrAuthentication,
 		},
 		{
-			desc:                 "reset secret with failed to update secret",
-			token:                validToken,
-			newSecret:            "newStrongSecret",
-			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
-			retrieveByIDResponse: client,
-			updateSecretResponse: mgclients.Client{},
-			updateSecretErr:      svcerr.ErrUpdateEntity,
-			err:                  svcerr.ErrAuthorization,
+			desc:      "issue MFA token with already used TOTP code",
+			mfaToken:  validID,
+			code:      mfaCode(t, mfaSecret),
+			challenge: challenge,
+			lastStep:  uint64(time.Now().Unix())/mfa.Period + 1,
+			err:       svcerr.ErrAuthentication,
 		},
 		{
-			desc:                 "reset secret with a too long secret",
-			token:                validToken,
-			newSecret:            strings.Repeat("strongSecret", 10),
-			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
-			retrieveByIDResponse: client,
-			err:                  errHashPassword,
+			desc:        "issue MFA token with TOTP code used by concurrent login",
+			mfaToken:    validID,
+			code:        mfaCode(t, mfaSecret),
+			challenge:   challenge,
+			saveStepErr: repoerr.ErrConflict,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "issue MFA token with invalid code after too many attempts",
+			mfaToken:  validID,
+			code:      "000000",
+			challenge: mfa.Challenge{ID: validID, UserID: client.ID, Attempts: 2, ExpiresAt: time.Now().Add(time.Minute)},
+			err:       svcerr.ErrAuthentication,
+		},
+		{
+			desc:          "issue MFA token with failed to issue token",
+			mfaToken:      validID,
+			code:          mfaCode(t, mfaSecret),
+			challenge:     challenge,
+			issueResponse: &magistrala.Token{},
+			issueErr:      svcerr.ErrAuthorization,
+			err:           svcerr.ErrAuthorization,
 		},
 	}
 
 	for _, tc := range cases {
-		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
-		repoCall := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
-		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
-
-		err := svc.ResetSecret(context.Background(), tc.token, tc.newSecret)
+		svc, cRepo, auth, _, _ := newService(true)
+		cRepo.On("RetrieveByID", context.Background(), client.ID).Return(client, nil)
+		mfaRepo.On("RetrieveChallenge", context.Background(), tc.mfaToken).Return(tc.challenge, tc.retrieveChalErr)
+		m.LastStep = tc.lastStep
+		mfaRepo.On("Retrieve", context.Background(), client.ID).Return(m, nil)
+		mfaRepo.On("Save", context.Background(), mock.Anything).Return(nil)
+		mfaRepo.On("SaveLastStep", context.Background(), client.ID, mock.Anything).Return(tc.saveStepErr)
+		mfaRepo.On("SaveChallenge", context.Background(), mock.Anything).Return(nil)
+		mfaRepo.On("RemoveChallenge", context.Background(), tc.mfaToken).Return(nil)
+		auth.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+		token, err := svc.IssueMFAToken(context.Background(), tc.mfaToken, tc.code)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-
-		repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
-		repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), client.ID)
-		authCall.Parent.AssertCalled(t, "Identify", mock.Anything, mock.Anything)
-		authCall.Unset()
-		repoCall1.Unset()
-		repoCall.Unset()
+		if err == nil {
+			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected access token not to be empty\n", tc.desc))
+			ok := auth.AssertCalled(t, "Issue", context.Background(), &magistrala.IssueReq{UserId: client.ID, DomainId: &tc.challenge.DomainID, Type: uint32(authsvc.AccessKey), Mfa: true})
+			assert.True(t, ok, fmt.Sprintf("Issue was not called on %s", tc.desc))
+			mfaRepo.AssertCalled(t, "RemoveChallenge", context.Background(), tc.mfaToken)
+		}
+		if tc.challenge.Attempts == 2 {
+			mfaRepo.AssertCalled(t, "RemoveChallenge", context.Background(), tc.mfaToken)
+			mfaRepo.AssertNotCalled(t, "SaveChallenge", context.Background(), mock.Anything)
+		}
 	}
 }
 
-func TestViewProfile(t *testing.T) {
-	svc, cRepo, auth, _, _ := newService(true)
+func TestIssueMFATokenLockout(t *testing.T) {
+	mfaSecret, err := mfa.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating MFA secret expected to succeed: %s", err))
+	challenge := mfa.Challenge{ID: validID, UserID: client.ID, ExpiresAt: time.Now().Add(time.Minute)}
+	m := mfa.MFA{UserID: client.ID, Secret: mfaSecret, Enabled: true}
+	sourceIP := "192.168.0.1"
+	identityKey := lockout.IdentityKey(client.Credentials.Identity)
+	ipKey := lockout.IPKey(sourceIP)
 
-	client := mgclients.Client{
-		ID: "clientID",
-		Credentials: mgclients.Credentials{
-			Identity: "existingIdentity",
-			Secret:   "Strongsecret",
+	cases := []struct {
+		desc     string
+		code     string
+		identity lockout.Attempts
+		failed   lockout.Attempts
+		locked   bool
+		err      error
+	}{
+		{
+			desc:     "issue MFA token resets failed attempts",
+			code:     mfaCode(t, mfaSecret),
+			identity: lockout.Attempts{Failures: 2, LastFailure: time.Now().Add(-time.Minute)},
+			err:      nil,
+		},
+		{
+			desc:     "issue MFA token for locked out identity",
+			code:     mfaCode(t, mfaSecret),
+			identity: lockout.Attempts{Failures: 3, LockedUntil: time.Now().Add(time.Minute)},
+			err:      svcerr.ErrLoginLocked,
+		},
+		{
+			desc:   "issue MFA token with invalid code records failed attempt",
+			code:   "000000",
+			failed: lockout.Attempts{Failures: 1, LastFailure: time.Now()},
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "issue MFA token with invalid code locks out identity",
+			code:   "000000",
+			failed: lockout.Attempts{Failures: 3, LastFailure: time.Now()},
+			locked: true,
+			err:    svcerr.ErrAuthentication,
 		},
 	}
+
+	for _, tc := range cases {
+		svc, cRepo, attempts, _, auth, _ := newLockoutService()
+		attempts.On("Retrieve", mock.Anything, identityKey).Return(tc.identity, nil)
+		attempts.On("Retrieve", mock.Anything, ipKey).Return(lockout.Attempts{}, nil)
+		attempts.On("Fail", mock.Anything, identityKey, lockoutConfig.Window).Return(tc.failed, nil)
+		attempts.On("Fail", mock.Anything, ipKey, lockoutConfig.Window).Return(lockout.Attempts{Failures: 1}, nil)
+		attempts.On("Lock", mock.Anything, identityKey, mock.Anything).Return(nil)
+		attempts.On("Remove", mock.Anything, identityKey).Return(nil)
+		cRepo.On("RetrieveByID", mock.Anything, client.ID).Return(client, nil)
+		mfaRepo.On("RetrieveChallenge", mock.Anything, validID).Return(challenge, nil)
+		mfaRepo.On("Retrieve", mock.Anything, client.ID).Return(m, nil)
+		mfaRepo.On("SaveLastStep", mock.Anything, client.ID, mock.Anything).Return(nil)
+		mfaRepo.On("SaveChallenge", mock.Anything, mock.Anything).Return(nil)
+		mfaRepo.On("RemoveChallenge", mock.Anything, validID).Return(nil)
+		auth.On("Issue", mock.Anything, mock.Anything).Return(&magistrala.Token{AccessToken: validToken}, nil)
+
+		ctx := apiutil.WithSourceIP(context.Background(), sourceIP)
+		_, err := svc.IssueMFAToken(ctx, validID, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch {
+		case err == nil:
+			attempts.AssertCalled(t, "Remove", mock.Anything, identityKey)
+		case errors.Contains(err, svcerr.ErrLoginLocked):
+			mfaRepo.AssertNotCalled(t, "SaveChallenge", mock.Anything, mock.Anything)
+			auth.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything)
+		default:
+			attempts.AssertCalled(t, "Fail", mock.Anything, identityKey, lockoutConfig.Window)
+			attempts.AssertCalled(t, "Fail", mock.Anything, ipKey, lockoutConfig.Window)
+			attempts.AssertNotCalled(t, "Remove", mock.Anything, identityKey)
+		}
+		switch tc.locked {
+		case true:
+			attempts.AssertCalled(t, "Lock", mock.Anything, identityKey, mock.Anything)
+		default:
+			attempts.AssertNotCalled(t, "Lock", mock.Anything, identityKey, mock.Anything)
+		}
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
 	cases := []struct {
-		desc                 string
-		token                string
-		client               mgclients.Client
-		identifyResponse     *magistrala.IdentityRes
-		retrieveByIDResponse mgclients.Client
-		identifyErr          error
-		retrieveByIDErr      error
-		err                  error
+		desc             string
+		token            string
+		identifyResponse *magistrala.IdentityRes
+		identifyErr      error
+		retrieveRes      mfa.MFA
+		retrieveErr      error
+		saveErr          error
+		err              error
 	}{
 		{
-			desc:                 "view profile successfully",
-			token:                validToken,
-			client:               client,
-			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
-			retrieveByIDResponse: client,
-			err:                  nil,
+			desc:             "enroll MFA with valid token",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
+			retrieveErr:      repoerr.ErrNotFound,
+			err:              nil,
 		},
 		{
-			desc:             "view profile with invalid token",
+			desc:             "enroll MFA with pending enrollment",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
+			retrieveRes:      mfa.MFA{UserID: client.ID, Secret: "secret"},
+			err:              nil,
+		},
+		{
+			desc:             "enroll MFA with invalid token",
 			token:            inValidToken,
-			client:           client,
 			identifyResponse: &magistrala.IdentityRes{},
 			identifyErr:      svcerr.ErrAuthentication,
 			err:              svcerr.ErrAuthentication,
 		},
 		{
-			desc:                 "view profile with invalid ID",
-			token:                validToken,
-			client:               client,
-			identifyResponse:     &magistrala.IdentityRes{UserId: wrongID},
-			retrieveByIDResponse: mgclients.Client{},
-			retrieveByIDErr:      repoerr.ErrNotFound,
-			err:                  repoerr.ErrNotFound,
+			desc:             "enroll MFA with already enabled MFA",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
+			retrieveRes:      mfa.MFA{UserID: client.ID, Enabled: true},
+			err:              svcerr.ErrConflict,
+		},
+		{
+			desc:             "enroll MFA with failed to save",
+			token:            validToken,
+			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
+			retrieveErr:      repoerr.ErrNotFound,
+			saveErr:          repoerr.ErrCreateEntity,
+			err:              svcerr.ErrCreateEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		authCall := auth.On("Identify", context.Background(), mock.Anything).Return(tc.identifyResponse, tc.identifyErr)
-		repoCall := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
-
-		_, err := svc.ViewProfile(context.Background(), tc.token)
+		svc, cRepo, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		cRepo.On("RetrieveByID", context.Background(), client.ID).Return(client, nil)
+		mfaRepo.On("Retrieve", context.Background(), client.ID).Return(tc.retrieveRes, tc.retrieveErr)
+		mfaRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveErr)
+		enrollment, err := svc.EnrollMFA(context.Background(), tc.token)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-
-		authCall.Parent.AssertCalled(t, "Identify", mock.Anything, mock.Anything)
-		repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), mock.Anything)
-		authCall.Unset()
-		repoCall.Unset()
+		if err == nil {
+			assert.NotEmpty(t, enrollment.Secret, fmt.Sprintf("%s: expected secret not to be empty\n", tc.desc))
+			assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"), fmt.Sprintf("%s: expected otpauth URI got %s\n", tc.desc, enrollment.URI))
+		}
 	}
 }
 
-func TestOAuthCallback(t *testing.T) {
-	svc, cRepo, auth, policy, _ := newService(true)
+func TestVerifyMFA(t *testing.T) {
+	mfaSecret, err := mfa.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating MFA secret expected to succeed: %s", err))
+	pending := mfa.MFA{UserID: client.ID, Secret: mfaSecret}
 
 	cases := []struct {
-		desc                       string
-		client                     mgclients.Client
-		retrieveByIdentityResponse mgclients.Client
-		retrieveByIdentityErr      error
-		addPoliciesResponse        *magistrala.AddPoliciesRes
-		addPoliciesErr             error
-		saveResponse               mgclients.Client
-		saveErr                    error
-		deletePoliciesResponse     *magistrala.DeletePolicyRes
-		deletePoliciesErr          error
-		authorizeResponse          *magistrala.AuthorizeRes
-		authorizeErr               error
-		issueResponse              *magistrala.Token
-		issueErr                   error
-		err                        error
+		desc        string
+		code        string
+		retrieveRes mfa.MFA
+		retrieveErr error
+		saveErr     error
+		err         error
 	}{
 		{
-			desc: "oauth signin callback with successfully",
-			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
-				},
-			},
-			retrieveByIdentityResponse: mgclients.Client{
-				ID:   testsutil.GenerateUUID(t),
-				Role: mgclients.UserRole,
-			},
-			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
-			issueResponse: &magistrala.Token{
-				AccessToken:  strings.Repeat("a", 10),
-				RefreshToken: &validToken,
-				AccessType:   "Bearer",
-			},
-			err: nil,
+			desc:        "verify MFA with valid code",
+			code:        mfaCode(t, mfaSecret),
+			retrieveRes: pending,
+			err:         nil,
 		},
 		{
-			desc: "oauth signup callback with successfully",
-			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
-				},
-			},
-			retrieveByIdentityErr: repoerr.ErrNotFound,
-			addPoliciesResponse: &magistrala.AddPoliciesRes{
-				Added: true,
-			},
-			saveResponse: mgclients.Client{
-				ID:   testsutil.GenerateUUID(t),
-				Role: mgclients.UserRole,
-			},
-			issueResponse: &magistrala.Token{
-				AccessToken:  strings.Repeat("a", 10),
-				RefreshToken: &validToken,
-				AccessType:   "Bearer",
-			},
-			err: nil,
+			desc:        "verify MFA with invalid code",
+			code:        "000000",
+			retrieveRes: pending,
+			err:         svcerr.ErrMalformedEntity,
 		},
 		{
-			desc: "oauth signup callback with unknown error",
-			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
-				},
-			},
-			retrieveByIdentityErr: repoerr.ErrMalformedEntity,
-			err:                   repoerr.ErrMalformedEntity,
+			desc:        "verify MFA without enrollment",
+			code:        mfaCode(t, mfaSecret),
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
 		},
 		{
-			desc: "oauth signup callback with failed to register user",
-			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
+			desc:        "verify MFA with already enabled MFA",
+			code:        mfaCode(t, mfaSecret),
+			retrieveRes: mfa.MFA{UserID: client.ID, Secret: mfaSecret, Enabled: true},
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "verify MFA with failed to save",
+			code:        mfaCode(t, mfaSecret),
+			retrieveRes: pending,
+			saveErr:     repoerr.ErrUpdateEntity,
+			err:         svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: client.ID}, nil)
+		mfaRepo.On("Retrieve", context.Background(), client.ID).Return(tc.retrieveRes, tc.retrieveErr)
+		mfaRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveErr)
+		codes, err := svc.VerifyMFA(context.Background(), validToken, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, codes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(codes)))
+			ok := mfaRepo.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(m mfa.MFA) bool {
+				return m.Enabled && len(m.RecoveryCodes) == len(codes)
+			}))
+			assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
+		}
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	mfaSecret, err := mfa.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating MFA secret expected to succeed: %s", err))
+	enabled := mfa.MFA{UserID: client.ID, Secret: mfaSecret, Enabled: true}
+
+	cases := []struct {
+		desc        string
+		code        string
+		retrieveRes mfa.MFA
+		retrieveErr error
+		removeErr   error
+		err         error
+	}{
+		{
+			desc:        "disable MFA with valid code",
+			code:        mfaCode(t, mfaSecret),
+			retrieveRes: enabled,
+			err:         nil,
+		},
+		{
+			desc:        "disable pending MFA enrollment",
+			retrieveRes: mfa.MFA{UserID: client.ID, Secret: mfaSecret},
+			err:         nil,
+		},
+		{
+			desc:        "disable MFA with invalid code",
+			code:        "000000",
+			retrieveRes: enabled,
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "disable MFA without enrollment",
+			code:        mfaCode(t, mfaSecret),
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "disable MFA with failed to remove",
+			code:        mfaCode(t, mfaSecret),
+			retrieveRes: enabled,
+			removeErr:   repoerr.ErrRemoveEntity,
+			err:         svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: client.ID}, nil)
+		mfaRepo.On("Retrieve", context.Background(), client.ID).Return(tc.retrieveRes, tc.retrieveErr)
+		mfaRepo.On("SaveLastStep", context.Background(), client.ID, mock.Anything).Return(nil)
+		mfaRepo.On("Remove", context.Background(), client.ID).Return(tc.removeErr)
+		err := svc.DisableMFA(context.Background(), validToken, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			ok := mfaRepo.AssertCalled(t, "Remove", context.Background(), client.ID)
+			assert.True(t, ok, fmt.Sprintf("Remove was not called on %s", tc.desc))
+		}
+	}
+}
+
+func mfaCode(t *testing.T, secret string) string {
+	code, err := mfa.Code(secret, time.Now())
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+
+	return code
+}
+
+func TestRefreshToken(t *testing.T) {
+	svc, crepo, auth, _, _ := newService(true)
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+
+	cases := []struct {
+		desc         string
+		token        string
+		domainID     string
+		identifyResp *magistrala.IdentityRes
+		identifyErr  error
+		refreshResp  *magistrala.Token
+		refresErr    error
+		repoResp     mgclients.Client
+		repoErr      error
+		err          error
+	}{
+		{
+			desc:         "refresh token with refresh token for an existing client",
+			token:        validToken,
+			domainID:     validID,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			refreshResp:  &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			repoResp:     rClient,
+			err:          nil,
+		},
+		{
+			desc:         "refresh token with refresh token for empty domain id",
+			token:        validToken,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			refreshResp:  &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			repoResp:     rClient,
+			err:          nil,
+		},
+		{
+			desc:         "refresh token with access token for an existing client",
+			token:        validToken,
+			domainID:     validID,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			refreshResp:  &magistrala.Token{},
+			refresErr:    svcerr.ErrAuthentication,
+			repoResp:     rClient,
+			err:          svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "refresh token with invalid token",
+			token:        validToken,
+			domainID:     validID,
+			identifyResp: &magistrala.IdentityRes{},
+			identifyErr:  svcerr.ErrAuthentication,
+			err:          svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "refresh token with refresh token for a non-existing client",
+			token:        validToken,
+			domainID:     validID,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			repoErr:      repoerr.ErrNotFound,
+			err:          repoerr.ErrNotFound,
+		},
+		{
+			desc:         "refresh token with refresh token for a disable client",
+			token:        validToken,
+			domainID:     validID,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			repoResp:     mgclients.Client{Status: mgclients.DisabledStatus},
+			err:          svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "refresh token with empty domain id",
+			token:        validToken,
+			identifyResp: &magistrala.IdentityRes{UserId: client.ID},
+			refreshResp:  &magistrala.Token{},
+			refresErr:    svcerr.ErrAuthentication,
+			repoResp:     rClient,
+			err:          svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResp, tc.identifyErr)
+		authCall1 := auth.On("Refresh", context.Background(), &magistrala.RefreshReq{RefreshToken: tc.token, DomainId: &tc.domainID}).Return(tc.refreshResp, tc.refresErr)
+		repoCall := crepo.On("RetrieveByID", context.Background(), tc.identifyResp.GetUserId()).Return(tc.repoResp, tc.repoErr)
+		token, err := svc.RefreshToken(context.Background(), tc.token, tc.domainID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetAccessToken()))
+			assert.NotEmpty(t, token.GetRefreshToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetRefreshToken()))
+			ok := authCall.Parent.AssertCalled(t, "Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token})
+			assert.True(t, ok, fmt.Sprintf("Identify was not called on %s", tc.desc))
+			ok = authCall.Parent.AssertCalled(t, "Refresh", context.Background(), &magistrala.RefreshReq{RefreshToken: tc.token, DomainId: &tc.domainID})
+			assert.True(t, ok, fmt.Sprintf("Refresh was not called on %s", tc.desc))
+			ok = repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.identifyResp.UserId)
+			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestGenerateResetToken(t *testing.T) {
+	svc, cRepo, auth, _, e := newService(true)
+
+	cases := []struct {
+		desc                       string
+		email                      string
+		host                       string
+		retrieveByIdentityResponse mgclients.Client
+		issueResponse              *magistrala.Token
+		retrieveByIdentityErr      error
+		issueErr                   error
+		err                        error
+	}{
+		{
+			desc:                       "generate reset token for existing client",
+			email:                      "existingemail@example.com",
+			host:                       "examplehost",
+			retrieveByIdentityResponse: client,
+			issueResponse:              &magistrala.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:                        nil,
+		},
+		{
+			desc:  "generate reset token for client with non-existing client",
+			email: "example@example.com",
+			host:  "examplehost",
+			retrieveByIdentityResponse: mgclients.Client{
+				ID: testsutil.GenerateUUID(t),
+				Credentials: mgclients.Credentials{
+					Identity: "",
+				},
+			},
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			err:                   repoerr.ErrNotFound,
+		},
+		{
+			desc:                       "generate reset token with failed to issue token",
+			email:                      "existingemail@example.com",
+			host:                       "examplehost",
+			retrieveByIdentityResponse: client,
+			issueResponse:              &magistrala.Token{},
+			issueErr:                   svcerr.ErrAuthorization,
+			err:                        svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), tc.email).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+		authCall := auth.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+
+		svcCall := e.On("SendPasswordReset", []string{tc.email}, tc.host, client.Name, validToken).Return(tc.err)
+		err := svc.GenerateResetToken(context.Background(), tc.email, tc.host)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Parent.AssertCalled(t, "RetrieveByIdentity", context.Background(), tc.email)
+		repoCall.Unset()
+		authCall.Unset()
+		svcCall.Unset()
+	}
+}
+
+func TestResetSecret(t *testing.T) {
+	svc, cRepo, auth, _, _ := newService(true)
+
+	client := mgclients.Client{
+		ID: "clientID",
+		Credentials: mgclients.Credentials{
+			Identity: "test@example.com",
+			Secret:   "Strongsecret",
+		},
+	}
+
+	cases := []struct {
+		desc                 string
+		token                string
+		newSecret            string
+		identifyResponse     *magistrala.IdentityRes
+		retrieveByIDResponse mgclients.Client
+		updateSecretResponse mgclients.Client
+		identifyErr          error
+		retrieveByIDErr      error
+		updateSecretErr      error
+		revokeTokensErr      error
+		err                  error
+	}{
+		{
+			desc:                 "reset secret with successfully",
+			token:                validToken,
+			newSecret:            "newStrongSecret",
+			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse: client,
+			updateSecretResponse: mgclients.Client{
+				ID: "clientID",
+				Credentials: mgclients.Credentials{
 					Identity: "test@example.com",
+					Secret:   "newStrongSecret",
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:             "reset secret with invalid token",
+			token:            inValidToken,
+			newSecret:        "newStrongSecret",
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "reset secret with invalid ID",
+			token:                validToken,
+			newSecret:            "newStrongSecret",
+			identifyResponse:     &magistrala.IdentityRes{UserId: wrongID},
+			retrieveByIDResponse: mgclients.Client{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  repoerr.ErrNotFound,
+		},
+		{
+			desc:             "reset secret with empty identity",
+			token:            validToken,
+			newSecret:        "newStrongSecret",
+			identifyResponse: &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse: mgclients.Client{
+				ID: "clientID",
+				Credentials: mgclients.Credentials{
+					Identity: "",
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:                 "reset secret with failed to update secret",
+			token:                validToken,
+			newSecret:            "newStrongSecret",
+			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse: client,
+			updateSecretResponse: mgclients.Client{},
+			updateSecretErr:      svcerr.ErrUpdateEntity,
+			err:                  svcerr.ErrAuthorization,
+		},
+		{
+			desc:                 "reset secret with a too long secret",
+			token:                validToken,
+			newSecret:            strings.Repeat("strongSecret", 10),
+			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse: client,
+			err:                  errHashPassword,
+		},
+		{
+			desc:                 "reset secret with failed to revoke tokens",
+			token:                validToken,
+			newSecret:            "newStrongSecret",
+			identifyResponse:     &magistrala.IdentityRes{UserId: client.ID},
+			retrieveByIDResponse: client,
+			updateSecretResponse: client,
+			revokeTokensErr:      svcerr.ErrAuthentication,
+			err:                  errRevokeTokens,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		repoCall := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
+		authCall1 := auth.On("RevokeTokens", context.Background(), mock.Anything).Return(&magistrala.RevokeTokensRes{Revoked: tc.revokeTokensErr == nil}, tc.revokeTokensErr)
+
+		err := svc.ResetSecret(context.Background(), tc.token, tc.newSecret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+		repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), client.ID)
+		authCall.Parent.AssertCalled(t, "Identify", mock.Anything, mock.Anything)
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestViewClientByIdentity(t *testing.T) {
+	svc, cRepo, auth, _, _ := newService(true)
+
+	domainID := testsutil.GenerateUUID(t)
+	superAdminAuthReq := &magistrala.AuthorizeReq{
+		SubjectType: authsvc.UserType,
+		SubjectKind: authsvc.TokenKind,
+		Subject:     validToken,
+		Permission:  authsvc.AdminPermission,
+		ObjectType:  authsvc.PlatformType,
+		Object:      authsvc.MagistralaObject,
+	}
+	domainAdminAuthReq := &magistrala.AuthorizeReq{
+		SubjectType: authsvc.UserType,
+		SubjectKind: authsvc.TokenKind,
+		Subject:     validToken,
+		Permission:  authsvc.AdminPermission,
+		ObjectType:  authsvc.DomainType,
+		Object:      domainID,
+	}
+	serviceAccount := client
+	serviceAccount.Role = mgclients.ServiceAccountRole
+
+	cases := []struct {
+		desc                       string
+		token                      string
+		identifyErr                error
+		superAdminErr              error
+		domainAdminErr             error
+		retrieveByIdentityResponse mgclients.Client
+		retrieveByIdentityErr      error
+		response                   mgclients.Client
+		err                        error
+	}{
+		{
+			desc:                       "view client by identity as platform admin",
+			token:                      validToken,
+			retrieveByIdentityResponse: client,
+			response:                   mgclients.Client{ID: client.ID, Name: client.Name},
+		},
+		{
+			desc:                       "view client by identity as domain admin",
+			token:                      validToken,
+			superAdminErr:              svcerr.ErrAuthorization,
+			retrieveByIdentityResponse: client,
+			response:                   mgclients.Client{ID: client.ID, Name: client.Name},
+		},
+		{
+			desc:           "view client by identity as non-admin",
+			token:          validToken,
+			superAdminErr:  svcerr.ErrAuthorization,
+			domainAdminErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "view client by identity with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:                  "view non-existing client by identity",
+			token:                 validToken,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			err:                   svcerr.ErrNotFound,
+		},
+		{
+			desc:                  "view client by identity with failed to retrieve client",
+			token:                 validToken,
+			retrieveByIdentityErr: repoerr.ErrViewEntity,
+			err:                   svcerr.ErrViewEntity,
+		},
+		{
+			desc:                       "view service account by identity",
+			token:                      validToken,
+			retrieveByIdentityResponse: serviceAccount,
+			err:                        svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, tc.identifyErr)
+		authCall1 := auth.On("Authorize", context.Background(), superAdminAuthReq).Return(&magistrala.AuthorizeRes{Authorized: tc.superAdminErr == nil}, tc.superAdminErr)
+		authCall2 := auth.On("Authorize", context.Background(), domainAdminAuthReq).Return(&magistrala.AuthorizeRes{Authorized: tc.domainAdminErr == nil}, tc.domainAdminErr)
+		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), validID).Return(tc.superAdminErr)
+		repoCall1 := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+
+		res, err := svc.ViewClientByIdentity(context.Background(), tc.token, domainID, client.Credentials.Identity)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, res))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestViewProfile(t *testing.T) {
+	svc, cRepo, auth, _, _ := newService(true)
+
+	client := mgclients.Client{
+		ID: "clientID",
+		Credentials: mgclients.Credentials{
+			Identity: "existingIdentity",
+			Secret:   "Strongsecret",
+		},
+	}
+	cases := []struct {
+		desc                 string
+		token                string
+		client               mgclients.Client
+		identifyResponse     *magistrala.IdentityRes
+		retrieveByIDResponse mgclients.Client
+		identifyErr          error
+		retrieveByIDErr      error
+		err                  error
+	}{
+		{
+			desc:                 "view profile successfully",
+			token:                validToken,
+			client:               client,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			err:                  nil,
+		},
+		{
+			desc:             "view profile with invalid token",
+			token:            inValidToken,
+			client:           client,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "view profile with invalid ID",
+			token:                validToken,
+			client:               client,
+			identifyResponse:     &magistrala.IdentityRes{UserId: wrongID},
+			retrieveByIDResponse: mgclients.Client{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), mock.Anything).Return(tc.identifyResponse, tc.identifyErr)
+		repoCall := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+
+		_, err := svc.ViewProfile(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		authCall.Parent.AssertCalled(t, "Identify", mock.Anything, mock.Anything)
+		repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), mock.Anything)
+		authCall.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestDeleteProfile(t *testing.T) {
+	svc, cRepo, auth, _, _ := newService(true)
+
+	client := mgclients.Client{
+		ID:   validID,
+		Role: mgclients.UserRole,
+		Credentials: mgclients.Credentials{
+			Identity: "existingIdentity",
+		},
+	}
+	serviceAccount := mgclients.Client{
+		ID:   validID,
+		Role: mgclients.ServiceAccountRole,
+	}
+	deletedClient := client
+	deletedClient.Status = mgclients.DeletedStatus
+
+	cases := []struct {
+		desc                 string
+		token                string
+		identifyResponse     *magistrala.IdentityRes
+		identifyErr          error
+		retrieveByIDResponse mgclients.Client
+		retrieveByIDErr      error
+		changeStatusResponse mgclients.Client
+		changeStatusErr      error
+		revokeErr            error
+		err                  error
+	}{
+		{
+			desc:                 "delete profile successfully",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			changeStatusResponse: deletedClient,
+			err:                  nil,
+		},
+		{
+			desc:             "delete profile with invalid token",
+			token:            inValidToken,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "delete profile with failed to retrieve client",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: mgclients.Client{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "delete profile of service account",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: serviceAccount,
+			err:                  svcerr.ErrAuthorization,
+		},
+		{
+			desc:                 "delete profile with failed to change status",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			changeStatusResponse: mgclients.Client{},
+			changeStatusErr:      repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                 "delete profile with failed to revoke tokens",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			changeStatusResponse: deletedClient,
+			revokeErr:            svcerr.ErrRemoveEntity,
+			err:                  svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.identifyResponse.GetUserId()).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall1 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
+		authCall1 := auth.On("RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: tc.identifyResponse.GetUserId()}).Return(&magistrala.RevokeTokensRes{}, tc.revokeErr)
+
+		client, err := svc.DeleteProfile(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, mgclients.DeletedStatus, client.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, mgclients.DeletedStatus, client.Status))
+			ok := repoCall1.Parent.AssertCalled(t, "ChangeStatus", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+		}
+		authCall.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+		authCall1.Unset()
+	}
+}
+
+func TestSendVerification(t *testing.T) {
+	svc, cRepo, _, _, e := newService(true)
+
+	secret := "clientsecret"
+	hash, _ := phasher.Hash(secret)
+	client := mgclients.Client{
+		ID:   validID,
+		Name: "clientname",
+		Credentials: mgclients.Credentials{
+			Identity: "client@example.com",
+			Secret:   hash,
+		},
+	}
+	verifiedClient := client
+	verifiedClient.VerifiedAt = time.Now()
+
+	cases := []struct {
+		desc                       string
+		secret                     string
+		retrieveByIdentityResponse mgclients.Client
+		retrieveByIdentityErr      error
+		saveErr                    error
+		sendErr                    error
+		err                        error
+	}{
+		{
+			desc:                       "send verification successfully",
+			secret:                     secret,
+			retrieveByIdentityResponse: client,
+			err:                        nil,
+		},
+		{
+			desc:                       "send verification with wrong secret",
+			secret:                     "wrongsecret",
+			retrieveByIdentityResponse: client,
+			err:                        svcerr.ErrLogin,
+		},
+		{
+			desc:                       "send verification of non-existing client",
+			secret:                     secret,
+			retrieveByIdentityResponse: mgclients.Client{},
+			retrieveByIdentityErr:      repoerr.ErrNotFound,
+			err:                        repoerr.ErrNotFound,
+		},
+		{
+			desc:                       "send verification of verified email",
+			secret:                     secret,
+			retrieveByIdentityResponse: verifiedClient,
+			err:                        svcerr.ErrConflict,
+		},
+		{
+			desc:                       "send verification too early after the previous one",
+			secret:                     secret,
+			retrieveByIdentityResponse: client,
+			saveErr:                    repoerr.ErrConflict,
+			err:                        svcerr.ErrTooManyRequests,
+		},
+		{
+			desc:                       "send verification with failed to save verification",
+			secret:                     secret,
+			retrieveByIdentityResponse: client,
+			saveErr:                    repoerr.ErrCreateEntity,
+			err:                        repoerr.ErrCreateEntity,
+		},
+		{
+			desc:                       "send verification with failed to send email",
+			secret:                     secret,
+			retrieveByIdentityResponse: client,
+			sendErr:                    errors.New("failed to send email"),
+			err:                        errors.New("failed to send email"),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+		repoCall1 := verificationRepo.On("Save", context.Background(), mock.Anything, mock.Anything).Return(tc.saveErr)
+		emailCall := e.On("SendVerification", []string{client.Credentials.Identity}, client.Name, mock.Anything).Return(tc.sendErr)
+
+		err := svc.SendVerification(context.Background(), client.Credentials.Identity, tc.secret, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "Save", context.Background(), mock.Anything, mock.MatchedBy(func(resendAfter time.Time) bool {
+				return time.Until(resendAfter) < -time.Minute+time.Second
+			}))
+			assert.True(t, ok, fmt.Sprintf("Save was not called with the resend interval on %s", tc.desc))
+			ok = emailCall.Parent.AssertCalled(t, "SendVerification", []string{client.Credentials.Identity}, client.Name, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("SendVerification was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		emailCall.Unset()
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	svc, cRepo, _, _, _ := newService(true)
+
+	verificationToken := testsutil.GenerateUUID(t)
+	client := mgclients.Client{
+		ID:   validID,
+		Name: "clientname",
+		Credentials: mgclients.Credentials{
+			Identity: "client@example.com",
+		},
+	}
+	v := verification.Verification{
+		Token:     verificationToken,
+		UserID:    validID,
+		Email:     client.Credentials.Identity,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	changedClient := client
+	changedClient.Credentials.Identity = "changed@example.com"
+
+	cases := []struct {
+		desc                 string
+		token                string
+		retrieveResponse     verification.Verification
+		retrieveErr          error
+		retrieveByIDResponse mgclients.Client
+		retrieveByIDErr      error
+		verifyErr            error
+		err                  error
+	}{
+		{
+			desc:                 "verify email successfully",
+			token:                verificationToken,
+			retrieveResponse:     v,
+			retrieveByIDResponse: client,
+			err:                  nil,
+		},
+		{
+			desc:             "verify email with invalid token",
+			token:            inValidToken,
+			retrieveResponse: verification.Verification{},
+			retrieveErr:      repoerr.ErrNotFound,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "verify email with failed to retrieve client",
+			token:                verificationToken,
+			retrieveResponse:     v,
+			retrieveByIDResponse: mgclients.Client{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "verify email after the email is changed",
+			token:                verificationToken,
+			retrieveResponse:     v,
+			retrieveByIDResponse: changedClient,
+			err:                  svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "verify email with failed to verify",
+			token:                verificationToken,
+			retrieveResponse:     v,
+			retrieveByIDResponse: client,
+			verifyErr:            repoerr.ErrNotFound,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := verificationRepo.On("Retrieve", context.Background(), tc.token).Return(tc.retrieveResponse, tc.retrieveErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.retrieveResponse.UserID).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall2 := verificationRepo.On("Verify", context.Background(), tc.retrieveResponse.UserID, mock.Anything).Return(tc.verifyErr)
+
+		verified, err := svc.VerifyEmail(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.False(t, verified.VerifiedAt.IsZero(), fmt.Sprintf("%s: expected verified email", tc.desc))
+			ok := repoCall2.Parent.AssertCalled(t, "Verify", context.Background(), validID, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("Verify was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestExportProfile(t *testing.T) {
+	svc, cRepo, auth, policy, _ := newService(true)
+
+	client := mgclients.Client{
+		ID: validID,
+		Credentials: mgclients.Credentials{
+			Identity: "existingIdentity",
+			Secret:   "Strongsecret",
+		},
+	}
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	invitations := []users.Invitation{{InvitedBy: testsutil.GenerateUUID(t), DomainID: domainID, Relation: "member"}}
+	journal := []users.JournalEntry{{ID: testsutil.GenerateUUID(t), Operation: "user.create"}}
+
+	cases := []struct {
+		desc                 string
+		token                string
+		identifyResponse     *magistrala.IdentityRes
+		identifyErr          error
+		retrieveByIDResponse mgclients.Client
+		retrieveByIDErr      error
+		domainsResponse      *magistrala.ListObjectsRes
+		domainsErr           error
+		permissionsResponse  *magistrala.ListPermissionsRes
+		permissionsErr       error
+		thingsResponse       *magistrala.ListObjectsRes
+		thingsErr            error
+		groupsResponse       *magistrala.ListObjectsRes
+		groupsErr            error
+		invitationsResponse  []users.Invitation
+		invitationsErr       error
+		journalResponse      []users.JournalEntry
+		journalErr           error
+		response             []users.DomainMembership
+		err                  error
+	}{
+		{
+			desc:                 "export profile successfully",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{Policies: []string{domainID}},
+			permissionsResponse:  &magistrala.ListPermissionsRes{Permissions: []string{"admin", "membership"}},
+			thingsResponse:       &magistrala.ListObjectsRes{Policies: []string{thingID}},
+			groupsResponse:       &magistrala.ListObjectsRes{Policies: []string{groupID}},
+			invitationsResponse:  invitations,
+			journalResponse:      journal,
+			response: []users.DomainMembership{
+				{
+					DomainID:    domainID,
+					Permissions: []string{"admin", "membership"},
+					Things:      []string{thingID},
+					Groups:      []string{groupID},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:                 "export profile without administered resources",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{Policies: []string{domainID}},
+			permissionsResponse:  &magistrala.ListPermissionsRes{Permissions: []string{"membership"}},
+			thingsResponse:       &magistrala.ListObjectsRes{},
+			groupsResponse:       &magistrala.ListObjectsRes{},
+			response: []users.DomainMembership{
+				{
+					DomainID:    domainID,
+					Permissions: []string{"membership"},
+					Things:      []string{},
+					Groups:      []string{},
 				},
 			},
+			err: nil,
+		},
+		{
+			desc:                 "export profile without domains",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{},
+			response:             []users.DomainMembership{},
+			err:                  nil,
+		},
+		{
+			desc:             "export profile with invalid token",
+			token:            inValidToken,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:                 "export profile with failed to retrieve client",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: mgclients.Client{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "export profile with failed to list domains",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{},
+			domainsErr:           svcerr.ErrNotFound,
+			err:                  svcerr.ErrNotFound,
+		},
+		{
+			desc:                 "export profile with failed to list permissions",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{Policies: []string{domainID}},
+			permissionsResponse:  &magistrala.ListPermissionsRes{},
+			permissionsErr:       svcerr.ErrAuthorization,
+			err:                  svcerr.ErrAuthorization,
+		},
+		{
+			desc:                 "export profile with failed to list things",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{Policies: []string{domainID}},
+			permissionsResponse:  &magistrala.ListPermissionsRes{Permissions: []string{"membership"}},
+			thingsResponse:       &magistrala.ListObjectsRes{},
+			thingsErr:            svcerr.ErrNotFound,
+			err:                  svcerr.ErrNotFound,
+		},
+		{
+			desc:                 "export profile with failed to list groups",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{Policies: []string{domainID}},
+			permissionsResponse:  &magistrala.ListPermissionsRes{Permissions: []string{"membership"}},
+			thingsResponse:       &magistrala.ListObjectsRes{},
+			groupsResponse:       &magistrala.ListObjectsRes{},
+			groupsErr:            svcerr.ErrNotFound,
+			err:                  svcerr.ErrNotFound,
+		},
+		{
+			desc:                 "export profile with failed to list invitations",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{},
+			invitationsErr:       svcerr.ErrAuthorization,
+			err:                  svcerr.ErrAuthorization,
+		},
+		{
+			desc:                 "export profile with failed to list journal",
+			token:                validToken,
+			identifyResponse:     &magistrala.IdentityRes{UserId: validID},
+			retrieveByIDResponse: client,
+			domainsResponse:      &magistrala.ListObjectsRes{},
+			invitationsResponse:  invitations,
+			journalErr:           svcerr.ErrAuthorization,
+			err:                  svcerr.ErrAuthorization,
+		},
+	}
+
+	listObjectsReq := func(objectType string) *magistrala.ListObjectsReq {
+		return &magistrala.ListObjectsReq{
+			SubjectType: authsvc.UserType,
+			Subject:     authsvc.EncodeDomainUserID(domainID, validID),
+			Permission:  authsvc.AdministratorRelation,
+			ObjectType:  objectType,
+		}
+	}
+	domainsReq := &magistrala.ListObjectsReq{
+		SubjectType: authsvc.UserType,
+		Subject:     validID,
+		Permission:  authsvc.MembershipPermission,
+		ObjectType:  authsvc.DomainType,
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.identifyResponse.GetUserId()).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		policyCall := policy.On("ListAllObjects", context.Background(), domainsReq).Return(tc.domainsResponse, tc.domainsErr)
+		policyCall1 := policy.On("ListPermissions", context.Background(), mock.Anything).Return(tc.permissionsResponse, tc.permissionsErr)
+		policyCall2 := policy.On("ListAllObjects", context.Background(), listObjectsReq(authsvc.ThingType)).Return(tc.thingsResponse, tc.thingsErr)
+		policyCall3 := policy.On("ListAllObjects", context.Background(), listObjectsReq(authsvc.GroupType)).Return(tc.groupsResponse, tc.groupsErr)
+		dataCall := personalData.On("Invitations", context.Background(), tc.token, validID).Return(tc.invitationsResponse, tc.invitationsErr)
+		dataCall1 := personalData.On("Journal", context.Background(), tc.token, validID).Return(tc.journalResponse, tc.journalErr)
+
+		archive, err := svc.ExportProfile(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, client.ID, archive.Profile.ID, fmt.Sprintf("%s: expected profile %s got %s\n", tc.desc, client.ID, archive.Profile.ID))
+			assert.Empty(t, archive.Profile.Credentials.Secret, fmt.Sprintf("%s: expected empty secret\n", tc.desc))
+			assert.Equal(t, tc.response, archive.Domains, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, archive.Domains))
+			assert.Equal(t, tc.invitationsResponse, archive.Invitations, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.invitationsResponse, archive.Invitations))
+			assert.Equal(t, tc.journalResponse, archive.Journal, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.journalResponse, archive.Journal))
+		}
+		authCall.Unset()
+		repoCall.Unset()
+		policyCall.Unset()
+		policyCall1.Unset()
+		policyCall2.Unset()
+		policyCall3.Unset()
+		dataCall.Unset()
+		dataCall1.Unset()
+	}
+}
+
+func TestOAuthCallback(t *testing.T) {
+	svc, cRepo, auth, policy, _ := newService(true)
+
+	subject := "provider-subject"
+	userID := testsutil.GenerateUUID(t)
+	oauthClient := mgclients.Client{
+		ID: subject,
+		Credentials: mgclients.Credentials{
+			Identity: "test@example.com",
+		},
+		Metadata: mgclients.Metadata{
+			"oauth_provider": "oidc",
+		},
+	}
+	linked := oauth2.Identity{Provider: "oidc", Subject: subject, UserID: userID}
+	oauthUser := mgclients.Client{
+		ID:       userID,
+		Role:     mgclients.UserRole,
+		Metadata: mgclients.Metadata{"oauth_provider": "oidc"},
+	}
+	token := &magistrala.Token{
+		AccessToken:  strings.Repeat("a", 10),
+		RefreshToken: &validToken,
+		AccessType:   "Bearer",
+	}
+
+	cases := []struct {
+		desc                       string
+		client                     mgclients.Client
+		retrieveIdentityResponse   oauth2.Identity
+		retrieveIdentityErr        error
+		retrieveByIDResponse       mgclients.Client
+		retrieveByIDErr            error
+		retrieveByIdentityResponse mgclients.Client
+		retrieveByIdentityErr      error
+		saveIdentityErr            error
+		addPoliciesResponse        *magistrala.AddPoliciesRes
+		addPoliciesErr             error
+		saveResponse               mgclients.Client
+		saveErr                    error
+		authorizeResponse          *magistrala.AuthorizeRes
+		authorizeErr               error
+		mfaResponse                mfa.MFA
+		mfaErr                     error
+		issueResponse              *magistrala.Token
+		issueErr                   error
+		applyErr                   error
+		mfaToken                   bool
+		err                        error
+	}{
+		{
+			desc:                     "oauth signin callback of linked user successfully",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:                   repoerr.ErrNotFound,
+			issueResponse:            token,
+			err:                      nil,
+		},
+		{
+			desc:                     "oauth signin callback of linked user with failed to retrieve user",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDErr:          repoerr.ErrNotFound,
+			err:                      repoerr.ErrNotFound,
+		},
+		{
+			desc:                "oauth signin callback with failed to retrieve identity",
+			client:              oauthClient,
+			retrieveIdentityErr: repoerr.ErrViewEntity,
+			err:                 repoerr.ErrViewEntity,
+		},
+		{
+			desc:                       "oauth signin callback of user registered by the provider",
+			client:                     oauthClient,
+			retrieveIdentityErr:        repoerr.ErrNotFound,
+			retrieveByIdentityResponse: oauthUser,
+			authorizeResponse:          &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:                     repoerr.ErrNotFound,
+			issueResponse:              token,
+			err:                        nil,
+		},
+		{
+			desc:                "oauth signin callback of user with the same email registered with secret",
+			client:              oauthClient,
+			retrieveIdentityErr: repoerr.ErrNotFound,
+			retrieveByIdentityResponse: mgclients.Client{
+				ID:   userID,
+				Role: mgclients.UserRole,
+			},
+			err: svcerr.ErrConflict,
+		},
+		{
+			desc:                "oauth signin callback of user with the same email registered by another provider",
+			client:              oauthClient,
+			retrieveIdentityErr: repoerr.ErrNotFound,
+			retrieveByIdentityResponse: mgclients.Client{
+				ID:       userID,
+				Role:     mgclients.UserRole,
+				Metadata: mgclients.Metadata{"oauth_provider": "google"},
+			},
+			err: svcerr.ErrConflict,
+		},
+		{
+			desc:                  "oauth signup callback successfully",
+			client:                oauthClient,
+			retrieveIdentityErr:   repoerr.ErrNotFound,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			addPoliciesResponse: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+			saveResponse:      oauthUser,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:            repoerr.ErrNotFound,
+			issueResponse:     token,
+			err:               nil,
+		},
+		{
+			desc:                  "oauth signup callback with failed to link identity",
+			client:                oauthClient,
+			retrieveIdentityErr:   repoerr.ErrNotFound,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			addPoliciesResponse: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+			saveResponse:    oauthUser,
+			saveIdentityErr: repoerr.ErrCreateEntity,
+			err:             svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                  "oauth signup callback with unknown error",
+			client:                oauthClient,
+			retrieveIdentityErr:   repoerr.ErrNotFound,
+			retrieveByIdentityErr: repoerr.ErrMalformedEntity,
+			err:                   repoerr.ErrMalformedEntity,
+		},
+		{
+			desc:                  "oauth signup callback with failed to register user",
+			client:                oauthClient,
+			retrieveIdentityErr:   repoerr.ErrNotFound,
 			retrieveByIdentityErr: repoerr.ErrNotFound,
 			addPoliciesResponse:   &magistrala.AddPoliciesRes{Added: false},
 			addPoliciesErr:        svcerr.ErrAuthorization,
 			err:                   svcerr.ErrAuthorization,
 		},
 		{
-			desc: "oauth signin callback with user not in the platform",
+			desc:                     "oauth signin callback with user not in the platform",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:             svcerr.ErrAuthorization,
+			addPoliciesResponse:      &magistrala.AddPoliciesRes{Added: true},
+			mfaErr:                   repoerr.ErrNotFound,
+			issueResponse:            token,
+			err:                      nil,
+		},
+		{
+			desc:                     "oauth signin callback with user not in the platform and failed to add policy",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:             svcerr.ErrAuthorization,
+			addPoliciesResponse:      &magistrala.AddPoliciesRes{Added: false},
+			addPoliciesErr:           svcerr.ErrAuthorization,
+			err:                      svcerr.ErrAuthorization,
+		},
+		{
+			desc: "oauth signin callback with identity provider groups",
 			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
+				ID:          subject,
+				Credentials: oauthClient.Credentials,
+				Metadata: mgclients.Metadata{
+					"oauth_provider": "oidc",
+					"oauth_groups":   []string{"iot-ops"},
 				},
 			},
-			retrieveByIdentityResponse: mgclients.Client{
-				ID:   testsutil.GenerateUUID(t),
-				Role: mgclients.UserRole,
-			},
-			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: false},
-			authorizeErr:        svcerr.ErrAuthorization,
-			addPoliciesResponse: &magistrala.AddPoliciesRes{Added: true},
-			issueResponse: &magistrala.Token{
-				AccessToken:  strings.Repeat("a", 10),
-				RefreshToken: &validToken,
-				AccessType:   "Bearer",
-			},
-			err: nil,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:                   repoerr.ErrNotFound,
+			issueResponse:            token,
+			err:                      nil,
 		},
 		{
-			desc: "oauth signin callback with user not in the platform and failed to add policy",
+			desc: "oauth signin callback with failed to apply claim mappings",
 			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
+				ID:          subject,
+				Credentials: oauthClient.Credentials,
+				Metadata: mgclients.Metadata{
+					"oauth_provider": "oidc",
+					"oauth_groups":   []interface{}{"iot-ops"},
 				},
 			},
-			retrieveByIdentityResponse: mgclients.Client{
-				ID:   testsutil.GenerateUUID(t),
-				Role: mgclients.UserRole,
-			},
-			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: false},
-			authorizeErr:        svcerr.ErrAuthorization,
-			addPoliciesResponse: &magistrala.AddPoliciesRes{Added: false},
-			addPoliciesErr:      svcerr.ErrAuthorization,
-			err:                 svcerr.ErrAuthorization,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			applyErr:                 svcerr.ErrCreateEntity,
+			err:                      svcerr.ErrCreateEntity,
 		},
 		{
-			desc: "oauth signin callback with failed to issue token",
-			client: mgclients.Client{
-				Credentials: mgclients.Credentials{
-					Identity: "test@example.com",
-				},
-			},
-			retrieveByIdentityResponse: mgclients.Client{
-				ID:   testsutil.GenerateUUID(t),
-				Role: mgclients.UserRole,
-			},
-			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
-			issueErr:          svcerr.ErrAuthorization,
-			err:               svcerr.ErrAuthorization,
+			desc:                     "oauth signin callback of user with MFA enabled",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			mfaResponse:              mfa.MFA{UserID: userID, Enabled: true},
+			mfaToken:                 true,
+			err:                      nil,
+		},
+		{
+			desc:                     "oauth signin callback with failed to retrieve MFA",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:                   repoerr.ErrViewEntity,
+			err:                      repoerr.ErrViewEntity,
+		},
+		{
+			desc:                     "oauth signin callback with failed to issue token",
+			client:                   oauthClient,
+			retrieveIdentityResponse: linked,
+			retrieveByIDResponse:     oauthUser,
+			authorizeResponse:        &magistrala.AuthorizeRes{Authorized: true},
+			mfaErr:                   repoerr.ErrNotFound,
+			issueErr:                 svcerr.ErrAuthorization,
+			err:                      svcerr.ErrAuthorization,
 		},
 	}
 	for _, tc := range cases {
-		id := tc.saveResponse.ID
-		if tc.retrieveByIdentityResponse.ID != "" {
-			id = tc.retrieveByIdentityResponse.ID
-		}
 		authReq := &magistrala.AuthorizeReq{
 			SubjectType: authsvc.UserType,
 			SubjectKind: authsvc.UsersKind,
-			Subject:     id,
+			Subject:     userID,
 			Permission:  authsvc.MembershipPermission,
 			ObjectType:  authsvc.PlatformType,
 			Object:      authsvc.MagistralaObject,
 		}
-		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), tc.client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
-		repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveResponse, tc.saveErr)
+		repoCall := identities.On("Retrieve", context.Background(), "oidc", subject).Return(tc.retrieveIdentityResponse, tc.retrieveIdentityErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), userID).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall2 := cRepo.On("RetrieveByIdentity", context.Background(), tc.client.Credentials.Identity).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+		repoCall3 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveResponse, tc.saveErr)
+		repoCall4 := identities.On("Save", context.Background(), mock.Anything).Return(tc.saveIdentityErr)
+		repoCall5 := mfaRepo.On("Retrieve", context.Background(), userID).Return(tc.mfaResponse, tc.mfaErr)
+		repoCall6 := mfaRepo.On("SaveChallenge", context.Background(), mock.Anything).Return(nil)
 		authCall := auth.On("Issue", mock.Anything, mock.Anything).Return(tc.issueResponse, tc.issueErr)
 		authCall1 := policy.On("AddPolicies", mock.Anything, mock.Anything).Return(tc.addPoliciesResponse, tc.addPoliciesErr)
 		authCall2 := auth.On("Authorize", mock.Anything, authReq).Return(tc.authorizeResponse, tc.authorizeErr)
-		token, err := svc.OAuthCallback(context.Background(), tc.client)
-		if err == nil {
-			assert.Equal(t, tc.issueResponse.AccessToken, token.AccessToken)
-			assert.Equal(t, tc.issueResponse.RefreshToken, token.RefreshToken)
-		}
+		authCall3 := auth.On("ApplyClaimMappings", mock.Anything, &magistrala.ApplyClaimMappingsReq{UserId: userID, Provider: "oidc", Groups: []string{"iot-ops"}}).Return(&magistrala.ApplyClaimMappingsRes{Applied: tc.applyErr == nil}, tc.applyErr)
+		authCall4 := auth.On("ApplyClaimMappings", mock.Anything, &magistrala.ApplyClaimMappingsReq{UserId: userID, Provider: "oidc"}).Return(&magistrala.ApplyClaimMappingsRes{Applied: true}, nil)
+		res, err := svc.OAuthCallback(context.Background(), tc.client)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		repoCall.Parent.AssertCalled(t, "RetrieveByIdentity", context.Background(), tc.client.Credentials.Identity)
+		switch {
+		case err == nil && tc.mfaToken:
+			assert.NotNil(t, res.MfaToken, fmt.Sprintf("%s: expected MFA token", tc.desc))
+			assert.Empty(t, res.AccessToken, fmt.Sprintf("%s: expected no access token", tc.desc))
+		case err == nil:
+			assert.Equal(t, tc.issueResponse.AccessToken, res.AccessToken)
+			assert.Equal(t, tc.issueResponse.RefreshToken, res.RefreshToken)
+		}
 		repoCall.Unset()
 		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
 		authCall.Unset()
 		authCall1.Unset()
 		authCall2.Unset()
+		authCall3.Unset()
+		authCall4.Unset()
+	}
+}
+
+func TestCreateServiceAccount(t *testing.T) {
+	sa := mgclients.Client{Name: "backend", Status: mgclients.EnabledStatus}
+
+	cases := []struct {
+		desc              string
+		token             string
+		serviceAccount    mgclients.Client
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		addMemberErr      error
+		saveErr           error
+		err               error
+	}{
+		{
+			desc:              "create service account",
+			token:             validToken,
+			serviceAccount:    sa,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:             "create service account with invalid token",
+			token:            inValidToken,
+			serviceAccount:   sa,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "create service account with non domain admin token",
+			token:             validToken,
+			serviceAccount:    sa,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "create service account with invalid status",
+			token:             validToken,
+			serviceAccount:    mgclients.Client{Name: "backend", Status: mgclients.AllStatus},
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:              "create service account with failed to add domain member",
+			token:             validToken,
+			serviceAccount:    sa,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			addMemberErr:      svcerr.ErrNotFound,
+			err:               svcerr.ErrAddPolicies,
+		},
+		{
+			desc:              "create service account with failed to save",
+			token:             validToken,
+			serviceAccount:    sa,
+			identifyResponse:  &magistrala.IdentityRes{UserId: validID, DomainId: validID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:           repoerr.ErrCreateEntity,
+			err:               svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, policy, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		auth.On("AddDomainMember", context.Background(), mock.MatchedBy(func(req *magistrala.AddDomainMemberReq) bool {
+			return req.GetDomainId() == validID && req.GetRelation() == authsvc.MemberRelation
+		})).Return(&magistrala.AddDomainMemberRes{Added: tc.addMemberErr == nil}, tc.addMemberErr)
+		policy.On("DeleteEntityPolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+		cRepo.On("Save", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+			return c.Role == mgclients.ServiceAccountRole && c.Domain == validID && c.Credentials.Identity == c.ID && c.Credentials.Secret == ""
+		})).Return(func(_ context.Context, c mgclients.Client) mgclients.Client { return c }, tc.saveErr)
+
+		sa, err := svc.CreateServiceAccount(context.Background(), tc.token, validID, tc.serviceAccount)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch {
+		case tc.err == nil:
+			assert.Equal(t, mgclients.ServiceAccountRole, sa.Role, fmt.Sprintf("%s: expected service account role got %s\n", tc.desc, sa.Role))
+			assert.Equal(t, validID, sa.Domain, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, validID, sa.Domain))
+		case tc.saveErr != nil:
+			policy.AssertCalled(t, "DeleteEntityPolicies", context.Background(), mock.Anything)
+		}
+	}
+}
+
+func TestViewServiceAccount(t *testing.T) {
+	sa := mgclients.Client{ID: clientID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+
+	cases := []struct {
+		desc              string
+		token             string
+		retrieveResponse  mgclients.Client
+		authorizeResponse *magistrala.AuthorizeRes
+		retrieveErr       error
+		err               error
+	}{
+		{
+			desc:              "view service account",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:              "view service account with non domain admin token",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "view non-existing service account",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr:       repoerr.ErrNotFound,
+			err:               svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "view user as service account",
+			token:             validToken,
+			retrieveResponse:  client,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               repoerr.ErrNotFound,
+		},
+		{
+			desc:              "view service account of other domain",
+			token:             validToken,
+			retrieveResponse:  mgclients.Client{ID: clientID, Domain: wrongID, Role: mgclients.ServiceAccountRole},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		cRepo.On("RetrieveByID", context.Background(), clientID).Return(tc.retrieveResponse, tc.retrieveErr)
+
+		rsa, err := svc.ViewServiceAccount(context.Background(), tc.token, validID, clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.retrieveResponse, rsa, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.retrieveResponse, rsa))
+		}
+	}
+}
+
+func TestListServiceAccounts(t *testing.T) {
+	sa := mgclients.Client{ID: clientID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+	page := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 1, Limit: 10},
+		Clients: []mgclients.Client{sa},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		authorizeResponse *magistrala.AuthorizeRes
+		retrieveAllErr    error
+		err               error
+	}{
+		{
+			desc:              "list service accounts",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:              "list service accounts with non domain admin token",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "list service accounts with failed to retrieve",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveAllErr:    repoerr.ErrViewEntity,
+			err:               svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		cRepo.On("RetrieveAll", context.Background(), mock.MatchedBy(func(pm mgclients.Page) bool {
+			return pm.Role == mgclients.ServiceAccountRole && pm.Domain == validID && pm.Name == ""
+		})).Return(page, tc.retrieveAllErr)
+
+		sp, err := svc.ListServiceAccounts(context.Background(), tc.token, validID, mgclients.Page{Limit: 10, Name: "backend"})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, page, sp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, page, sp))
+		}
+	}
+}
+
+func TestDeleteServiceAccount(t *testing.T) {
+	sa := mgclients.Client{ID: clientID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+
+	cases := []struct {
+		desc              string
+		token             string
+		retrieveResponse  mgclients.Client
+		authorizeResponse *magistrala.AuthorizeRes
+		deletePoliciesRes *magistrala.DeletePolicyRes
+		deletePoliciesErr error
+		deleteErr         error
+		revokeErr         error
+		err               error
+	}{
+		{
+			desc:              "delete service account",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			err:               nil,
+		},
+		{
+			desc:              "delete service account with non domain admin token",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "delete user as service account",
+			token:             validToken,
+			retrieveResponse:  client,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               repoerr.ErrNotFound,
+		},
+		{
+			desc:              "delete service account with failed to delete policies",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:              "delete service account with failed to delete",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			deleteErr:         repoerr.ErrRemoveEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "delete service account with failed to revoke tokens",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			revokeErr:         svcerr.ErrAuthentication,
+			err:               errRevokeTokens,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, policy, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		cRepo.On("RetrieveByID", context.Background(), clientID).Return(tc.retrieveResponse, nil)
+		policy.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{Id: clientID, EntityType: authsvc.UserType}).Return(tc.deletePoliciesRes, tc.deletePoliciesErr)
+		cRepo.On("Delete", context.Background(), clientID).Return(tc.deleteErr)
+		auth.On("RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: clientID}).Return(&magistrala.RevokeTokensRes{}, tc.revokeErr)
+
+		err := svc.DeleteServiceAccount(context.Background(), tc.token, validID, clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDeleteDomainServiceAccounts(t *testing.T) {
+	sa := mgclients.Client{ID: clientID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole}
+
+	cases := []struct {
+		desc              string
+		retrieveResponse  mgclients.ClientsPage
+		retrieveErr       error
+		deletePoliciesRes *magistrala.DeletePolicyRes
+		deletePoliciesErr error
+		deleteErr         error
+		revokeErr         error
+		ids               []string
+		err               error
+	}{
+		{
+			desc:              "delete domain service accounts",
+			retrieveResponse:  mgclients.ClientsPage{Clients: []mgclients.Client{sa}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			ids:               []string{clientID},
+			err:               nil,
+		},
+		{
+			desc:             "delete domain service accounts of the domain without service accounts",
+			retrieveResponse: mgclients.ClientsPage{},
+			err:              nil,
+		},
+		{
+			desc:        "delete domain service accounts with failed to retrieve",
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "delete domain service accounts with failed to delete policies",
+			retrieveResponse:  mgclients.ClientsPage{Clients: []mgclients.Client{sa}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:              "delete domain service accounts with failed to delete",
+			retrieveResponse:  mgclients.ClientsPage{Clients: []mgclients.Client{sa}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			deleteErr:         repoerr.ErrRemoveEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "delete domain service accounts with failed to revoke tokens",
+			retrieveResponse:  mgclients.ClientsPage{Clients: []mgclients.Client{sa}},
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: true},
+			revokeErr:         svcerr.ErrAuthentication,
+			err:               errRevokeTokens,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, policy, _ := newService(true)
+		pm := mgclients.Page{Limit: 100, Domain: validID, Role: mgclients.ServiceAccountRole, Status: mgclients.AllStatus}
+		// The first page is retrieved until the domain has no service accounts left.
+		cRepo.On("RetrieveAll", context.Background(), pm).Return(tc.retrieveResponse, tc.retrieveErr).Once()
+		cRepo.On("RetrieveAll", context.Background(), pm).Return(mgclients.ClientsPage{}, nil)
+		policy.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{Id: clientID, EntityType: authsvc.UserType}).Return(tc.deletePoliciesRes, tc.deletePoliciesErr)
+		cRepo.On("Delete", context.Background(), clientID).Return(tc.deleteErr)
+		auth.On("RevokeTokens", context.Background(), &magistrala.RevokeTokensReq{UserId: clientID}).Return(&magistrala.RevokeTokensRes{}, tc.revokeErr)
+
+		ids, err := svc.DeleteDomainServiceAccounts(context.Background(), validID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+	}
+}
+
+func TestIssueServiceAccountKey(t *testing.T) {
+	sa := mgclients.Client{ID: clientID, Name: "backend", Domain: validID, Role: mgclients.ServiceAccountRole, Status: mgclients.EnabledStatus}
+	disabledSA := sa
+	disabledSA.Status = mgclients.DisabledStatus
+
+	cases := []struct {
+		desc              string
+		token             string
+		retrieveResponse  mgclients.Client
+		authorizeResponse *magistrala.AuthorizeRes
+		issueResponse     *magistrala.Token
+		issueErr          error
+		err               error
+	}{
+		{
+			desc:              "issue service account key",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			issueResponse:     &magistrala.Token{AccessToken: validToken},
+			err:               nil,
+		},
+		{
+			desc:              "issue service account key with non domain admin token",
+			token:             validToken,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "issue key of user as service account",
+			token:             validToken,
+			retrieveResponse:  client,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               repoerr.ErrNotFound,
+		},
+		{
+			desc:              "issue key of disabled service account",
+			token:             validToken,
+			retrieveResponse:  disabledSA,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "issue service account key with failed to issue",
+			token:             validToken,
+			retrieveResponse:  sa,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			issueResponse:     &magistrala.Token{},
+			issueErr:          svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, _, _ := newService(true)
+		auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+		auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeResponse, nil)
+		cRepo.On("RetrieveByID", context.Background(), clientID).Return(tc.retrieveResponse, nil)
+		domainID := validID
+		auth.On("Issue", context.Background(), &magistrala.IssueReq{UserId: clientID, DomainId: &domainID, Type: uint32(authsvc.APIKey), Token: tc.token}).Return(tc.issueResponse, tc.issueErr)
+
+		tkn, err := svc.IssueServiceAccountKey(context.Background(), tc.token, validID, clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, validToken, tkn.GetAccessToken(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, validToken, tkn.GetAccessToken()))
+		}
 	}
 }
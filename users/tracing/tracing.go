@@ -9,6 +9,7 @@ import (
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/users"
+	"github.com/absmach/magistrala/users/mfa"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -33,12 +34,36 @@ func (tm *tracingMiddleware) RegisterClient(ctx context.Context, token string, c
 	return tm.svc.RegisterClient(ctx, token, client)
 }
 
+// RegisterInvitedClient traces the "RegisterInvitedClient" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RegisterInvitedClient(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_register_invited_client", trace.WithAttributes(attribute.String("identity", client.Credentials.Identity)))
+	defer span.End()
+
+	return tm.svc.RegisterInvitedClient(ctx, token, client)
+}
+
+// SendVerification traces the "SendVerification" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) SendVerification(ctx context.Context, identity, secret, sourceIP string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_send_verification", trace.WithAttributes(attribute.String("identity", identity)))
+	defer span.End()
+
+	return tm.svc.SendVerification(ctx, identity, secret, sourceIP)
+}
+
+// VerifyEmail traces the "VerifyEmail" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) VerifyEmail(ctx context.Context, verificationToken string) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_email")
+	defer span.End()
+
+	return tm.svc.VerifyEmail(ctx, verificationToken)
+}
+
 // IssueToken traces the "IssueToken" operation of the wrapped clients.Service.
-func (tm *tracingMiddleware) IssueToken(ctx context.Context, identity, secret, domainID string) (*magistrala.Token, error) {
+func (tm *tracingMiddleware) IssueToken(ctx context.Context, identity, secret, domainID, sourceIP string) (*magistrala.Token, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_issue_token", trace.WithAttributes(attribute.String("identity", identity)))
 	defer span.End()
 
-	return tm.svc.IssueToken(ctx, identity, secret, domainID)
+	return tm.svc.IssueToken(ctx, identity, secret, domainID, sourceIP)
 }
 
 // RefreshToken traces the "RefreshToken" operation of the wrapped clients.Service.
@@ -57,6 +82,14 @@ func (tm *tracingMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return tm.svc.ViewClient(ctx, token, id)
 }
 
+// ViewClientByIdentity traces the "ViewClientByIdentity" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ViewClientByIdentity(ctx context.Context, token, domainID, identity string) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_client_by_identity", trace.WithAttributes(attribute.String("domain_id", domainID)))
+	defer span.End()
+
+	return tm.svc.ViewClientByIdentity(ctx, token, domainID, identity)
+}
+
 // ListClients traces the "ListClients" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) ListClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_list_clients", trace.WithAttributes(
@@ -185,6 +218,62 @@ func (tm *tracingMiddleware) DisableClient(ctx context.Context, token, id string
 	return tm.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient traces the "UnlockClient" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) UnlockClient(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unlock_client", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.UnlockClient(ctx, token, id)
+}
+
+// CreateServiceAccount traces the "CreateServiceAccount" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) CreateServiceAccount(ctx context.Context, token, domainID string, sa mgclients.Client) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_service_account", trace.WithAttributes(attribute.String("domain_id", domainID), attribute.String("name", sa.Name)))
+	defer span.End()
+
+	return tm.svc.CreateServiceAccount(ctx, token, domainID, sa)
+}
+
+// ViewServiceAccount traces the "ViewServiceAccount" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ViewServiceAccount(ctx context.Context, token, domainID, id string) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_service_account", trace.WithAttributes(attribute.String("domain_id", domainID), attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewServiceAccount(ctx, token, domainID, id)
+}
+
+// ListServiceAccounts traces the "ListServiceAccounts" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ListServiceAccounts(ctx context.Context, token, domainID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_service_accounts", trace.WithAttributes(attribute.String("domain_id", domainID), attribute.Int64("offset", int64(pm.Offset)), attribute.Int64("limit", int64(pm.Limit))))
+	defer span.End()
+
+	return tm.svc.ListServiceAccounts(ctx, token, domainID, pm)
+}
+
+// DeleteServiceAccount traces the "DeleteServiceAccount" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) DeleteServiceAccount(ctx context.Context, token, domainID, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_service_account", trace.WithAttributes(attribute.String("domain_id", domainID), attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DeleteServiceAccount(ctx, token, domainID, id)
+}
+
+// DeleteDomainServiceAccounts traces the "DeleteDomainServiceAccounts" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) DeleteDomainServiceAccounts(ctx context.Context, domainID string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_domain_service_accounts", trace.WithAttributes(attribute.String("domain_id", domainID)))
+	defer span.End()
+
+	return tm.svc.DeleteDomainServiceAccounts(ctx, domainID)
+}
+
+// IssueServiceAccountKey traces the "IssueServiceAccountKey" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) IssueServiceAccountKey(ctx context.Context, token, domainID, id string) (*magistrala.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_service_account_key", trace.WithAttributes(attribute.String("domain_id", domainID), attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.IssueServiceAccountKey(ctx, token, domainID, id)
+}
+
 // ListMembers traces the "ListMembers" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) ListMembers(ctx context.Context, token, objectKind, objectID string, pm mgclients.Page) (mgclients.MembersPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_list_members", trace.WithAttributes(attribute.String("object_kind", objectKind)), trace.WithAttributes(attribute.String("object_id", objectID)))
@@ -218,3 +307,51 @@ func (tm *tracingMiddleware) DeleteClient(ctx context.Context, token, id string)
 
 	return tm.svc.DeleteClient(ctx, token, id)
 }
+
+// DeleteProfile traces the "DeleteProfile" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) DeleteProfile(ctx context.Context, token string) (mgclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_profile")
+	defer span.End()
+
+	return tm.svc.DeleteProfile(ctx, token)
+}
+
+// ExportProfile traces the "ExportProfile" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ExportProfile(ctx context.Context, token string) (users.ProfileArchive, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_export_profile")
+	defer span.End()
+
+	return tm.svc.ExportProfile(ctx, token)
+}
+
+// IssueMFAToken traces the "IssueMFAToken" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) IssueMFAToken(ctx context.Context, mfaToken, code string) (*magistrala.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_mfa_token")
+	defer span.End()
+
+	return tm.svc.IssueMFAToken(ctx, mfaToken, code)
+}
+
+// EnrollMFA traces the "EnrollMFA" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) EnrollMFA(ctx context.Context, token string) (mfa.Enrollment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll_mfa")
+	defer span.End()
+
+	return tm.svc.EnrollMFA(ctx, token)
+}
+
+// VerifyMFA traces the "VerifyMFA" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) VerifyMFA(ctx context.Context, token, code string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_mfa")
+	defer span.End()
+
+	return tm.svc.VerifyMFA(ctx, token, code)
+}
+
+// DisableMFA traces the "DisableMFA" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) DisableMFA(ctx context.Context, token, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_disable_mfa")
+	defer span.End()
+
+	return tm.svc.DisableMFA(ctx, token, code)
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	verification "github.com/absmach/magistrala/users/verification"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Retrieve provides a mock function with given fields: ctx, token
+func (_m *Repository) Retrieve(ctx context.Context, token string) (verification.Verification, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieve")
+	}
+
+	var r0 verification.Verification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (verification.Verification, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) verification.Verification); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(verification.Verification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, v, resendAfter
+func (_m *Repository) Save(ctx context.Context, v verification.Verification, resendAfter time.Time) error {
+	ret := _m.Called(ctx, v, resendAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, verification.Verification, time.Time) error); ok {
+		r0 = rf(ctx, v, resendAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: ctx, userID, verifiedAt
+func (_m *Repository) Verify(ctx context.Context, userID string, verifiedAt time.Time) error {
+	ret := _m.Called(ctx, userID, verifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package verification
+
+import (
+	"context"
+	"time"
+)
+
+// Verification is the pending verification of the user email. The token is
+// sent to the email, and the verification is valid only for the email it
+// was sent to, so changing the email invalidates the pending verification.
+type Verification struct {
+	Token     string
+	UserID    string
+	Email     string
+	SentAt    time.Time
+	ExpiresAt time.Time
+}
+
+// Repository specifies the email verification persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save creates or replaces the pending verification of the user. The
+	// pending verification of the same email sent after resendAfter is not
+	// replaced, and the conflict error is returned.
+	Save(ctx context.Context, v Verification, resendAfter time.Time) error
+
+	// Retrieve returns the pending verification which is not expired.
+	Retrieve(ctx context.Context, token string) (Verification, error)
+
+	// Verify marks the email of the user as verified and removes
+	// the pending verification.
+	Verify(ctx context.Context, userID string, verifiedAt time.Time) error
+}
@@ -58,7 +58,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		return svcerr.ErrAuthentication
 	}
 
-	thingID, err := svc.authorize(ctx, thingKey, chanID, auth.SubscribePermission)
+	thingID, err := svc.authorize(ctx, thingKey, chanID, subtopic, auth.SubscribePermission)
 	if err != nil {
 		return svcerr.ErrAuthorization
 	}
@@ -83,14 +83,15 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 }
 
 // authorize checks if the thingKey is authorized to access the channel
-// and returns the thingID if it is.
-func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID, action string) (string, error) {
+// subtopic and returns the thingID if it is.
+func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID, subtopic, action string) (string, error) {
 	ar := &magistrala.AuthorizeReq{
 		SubjectType: auth.ThingType,
 		Permission:  action,
 		Subject:     thingKey,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := svc.things.Authorize(ctx, ar)
 	if err != nil {
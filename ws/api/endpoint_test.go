@@ -98,8 +98,12 @@ func TestHandshake(t *testing.T) {
 	ts, err := newProxyHTPPServer(handler, target)
 	require.Nil(t, err)
 	defer ts.Close()
-	things.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{Subject: thingKey, Object: id, Domain: "", SubjectType: "thing", Permission: "publish", ObjectType: "group"}).Return(&magistrala.AuthorizeRes{Authorized: true, Id: "1"}, nil)
-	things.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{Subject: thingKey, Object: id, Domain: "", SubjectType: "thing", Permission: "subscribe", ObjectType: "group"}).Return(&magistrala.AuthorizeRes{Authorized: true, Id: "2"}, nil)
+	things.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+		return req.GetSubject() == thingKey && req.GetObject() == id && req.GetPermission() == "publish"
+	})).Return(&magistrala.AuthorizeRes{Authorized: true, Id: "1"}, nil)
+	things.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+		return req.GetSubject() == thingKey && req.GetObject() == id && req.GetPermission() == "subscribe"
+	})).Return(&magistrala.AuthorizeRes{Authorized: true, Id: "2"}, nil)
 	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: false, Id: "3"}, nil)
 	pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(nil)
 	pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -166,6 +166,7 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		Subject:     token,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := h.things.Authorize(ctx, ar)
 	if err != nil {
@@ -230,6 +231,10 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 	}
 
 	chanID := channelParts[1]
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return errors.Wrap(errFailedParseSubtopic, err)
+	}
 
 	ar := &magistrala.AuthorizeReq{
 		SubjectType: auth.ThingType,
@@ -237,6 +242,7 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 		Subject:     password,
 		Object:      chanID,
 		ObjectType:  auth.GroupType,
+		Subtopic:    subtopic,
 	}
 	res, err := h.things.Authorize(ctx, ar)
 	if err != nil {